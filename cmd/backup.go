@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/backup"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/spf13/cobra"
+)
+
+func NewBackupCommand(app *ArtalkCmd) *cobra.Command {
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Create a backup now",
+		Long:  "\n# Backup\n\n  Back up the comments, the config file and the manifest of uploaded files,\n  the backups are rotated and uploaded to the remote storage by the `backup` config.",
+		Run: func(cmd *cobra.Command, args []string) {
+			backupService, err := core.AppService[*backup.Service](app.App)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			info, err := backupService.Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			log.Info(i18n.T("Backup complete") + ": " + filepath.Join(backupService.GetPath(), info.Name))
+		},
+	}
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore <FILENAME>",
+		Short: "Restore from a backup",
+		Long:  "\n# Backup - Restore\n\n  Import the comments of backup (the existing comments are skipped),\n  extract the config file and verify the uploaded files by the manifest.",
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			backupCmd.PreRun(cmd, args) // bootstrap the app, which is not inherited by the subcommands
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			configOut, _ := cmd.Flags().GetString("config-out")
+			assumeyes, _ := cmd.Flags().GetBool("assumeyes")
+
+			result, err := backup.Restore(app.Dao(), args[0], backup.RestoreOptions{
+				ConfigOut:  configOut,
+				UploadsDir: app.Conf().ImgUpload.Path,
+				Assumeyes:  assumeyes,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if len(result.MissingUploads) > 0 {
+				log.Warn(i18n.T("The uploaded files are missing or changed, which should be restored separately") + ": " +
+					strings.Join(result.MissingUploads, ", "))
+			}
+			log.Info(i18n.T("Restore complete"))
+		},
+	}
+
+	flagPV(restoreCmd, "config-out", "", "", "Extract the config file of backup to the path, which should not exist.")
+	flagPV(restoreCmd, "assumeyes", "y", false, "Automatically answer yes for all questions.")
+
+	backupCmd.AddCommand(restoreCmd)
+
+	return backupCmd
+}
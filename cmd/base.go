@@ -8,6 +8,7 @@ import (
 	"syscall"
 
 	"github.com/adrg/xdg"
+	"github.com/artalkjs/artalk/v2/internal/backup"
 	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/core"
 	"github.com/artalkjs/artalk/v2/internal/log"
@@ -102,6 +103,7 @@ func (atk *ArtalkCmd) addCommand(cmd *cobra.Command) {
 
 			// Create new instance
 			atk.App = core.NewApp(config)
+			core.AppInject(atk.App, backup.NewService(atk.App)) // the service depends on the artransfer, which the core can not import
 
 			// Bootstrap APP
 			if err := atk.App.Bootstrap(); err != nil {
@@ -122,6 +124,9 @@ func (atk *ArtalkCmd) mountCommands() {
 	atk.addCommand(NewAdminCommand(atk))
 	atk.addCommand(NewExportCommand(atk))
 	atk.addCommand(NewImportCommand(atk))
+	atk.addCommand(NewBackupCommand(atk))
+	atk.addCommand(NewDBCommand(atk))
+	atk.addCommand(NewUploadCommand(atk))
 	atk.addCommand(NewConfigCommand())
 	atk.addCommand(NewGenCommand())
 	atk.addCommand(NewUpgradeCommand())
@@ -143,6 +148,11 @@ func (atk *ArtalkCmd) Launch() error {
 		<-sigch
 
 		done <- true
+
+		// force to exit if the signal is received again during the graceful shutdown
+		<-sigch
+		log.Warn("[Shutdown] Forced to exit")
+		os.Exit(1)
 	}()
 
 	// ===================
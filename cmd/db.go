@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/db"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/spf13/cobra"
+)
+
+func NewDBCommand(app *ArtalkCmd) *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Database management",
+		Annotations: map[string]string{
+			BootModeKey: MODE_MINI_BOOT,
+		},
+	}
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the data to another database",
+		Long: "\n# Database - Migrate\n\n" +
+			"  Copy all the tables to another database (e.g. from SQLite to PostgreSQL),\n" +
+			"  the schema is created and the copied data is validated by the row counts and checksums.\n\n" +
+			"  artalk db migrate --from sqlite --to pgsql --to-dsn \"host=localhost user=artalk password=xxx dbname=artalk port=5432\"\n\n" +
+			"  The source is the database of config file by default, and the tables of target should be empty.\n" +
+			"  Update the `db` config to the target after migrated.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			from, _ := cmd.Flags().GetString("from")
+			fromDSN, _ := cmd.Flags().GetString("from-dsn")
+			to, _ := cmd.Flags().GetString("to")
+			toDSN, _ := cmd.Flags().GetString("to-dsn")
+			batchSize, _ := cmd.Flags().GetInt("batch-size")
+
+			conf, err := getConfig(app.cfgFile)
+			if err != nil {
+				log.Fatal("Config fail: ", err)
+			}
+
+			// The source is the database of config file if the DSN is not specified
+			srcConf := conf.DB
+			if from != "" {
+				srcConf.Type = parseDBType(from)
+			}
+			if fromDSN != "" {
+				srcConf.Dsn = fromDSN
+			} else if srcConf.Type != conf.DB.Type {
+				log.Fatal(i18n.T("{{name}} is required", map[string]interface{}{"name": "--from-dsn"}))
+			}
+
+			if to == "" || toDSN == "" {
+				log.Fatal(i18n.T("{{name}} is required", map[string]interface{}{"name": "--to, --to-dsn"}))
+			}
+			dstConf := config.DBConf{
+				Type:        parseDBType(to),
+				Dsn:         toDSN,
+				TablePrefix: conf.DB.TablePrefix,
+				Charset:     conf.DB.Charset,
+			}
+
+			srcDB, err := db.NewDB(srcConf)
+			if err != nil {
+				log.Fatal("Failed to open the source database: ", err)
+			}
+			defer db.CloseDB(srcDB)
+			dstDB, err := db.NewDB(dstConf)
+			if err != nil {
+				log.Fatal("Failed to open the target database: ", err)
+			}
+			defer db.CloseDB(dstDB)
+
+			// The schema of both databases are migrated to the latest
+			srcDao := dao.NewDao(srcDB)
+			dstDao := dao.NewDao(dstDB)
+
+			log.Info(i18n.T("Migrating from {{from}} to {{to}}", map[string]interface{}{"from": srcConf.Type, "to": dstConf.Type}) + "...")
+			results, err := srcDao.CopyDB(dstDao, dao.CopyDBOptions{
+				BatchSize: batchSize,
+				Progress: func(table string, copied int64, total int64) {
+					fmt.Printf("\r  %-32s %d / %d", table, copied, total)
+					if copied >= total {
+						fmt.Println()
+					}
+				},
+			})
+			if err != nil {
+				fmt.Println()
+				log.Fatal(err)
+			}
+
+			// Validation
+			fmt.Println()
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TABLE\tSOURCE ROWS\tTARGET ROWS\tCHECKSUM")
+			invalid := []string{}
+			for _, r := range results {
+				status := "OK"
+				if !r.IsValid() {
+					status = "MISMATCH"
+					invalid = append(invalid, r.Table)
+				}
+				fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", r.Table, r.SourceRows, r.TargetRows, status)
+			}
+			w.Flush()
+			fmt.Println()
+
+			if len(invalid) > 0 {
+				log.Fatal(i18n.T("Validation failed") + ": " + strings.Join(invalid, ", "))
+			}
+			log.Info(i18n.T("Migration complete"))
+		},
+	}
+
+	flagPV(migrateCmd, "from", "", "", "The type of source database (\"sqlite\", \"mysql\", \"pgsql\" or \"mssql\"), the database of config file by default.")
+	flagPV(migrateCmd, "from-dsn", "", "", "The DSN of source database, required if the type differs from the config file.")
+	flagPV(migrateCmd, "to", "", "", "The type of target database (\"sqlite\", \"mysql\", \"pgsql\" or \"mssql\").")
+	flagPV(migrateCmd, "to-dsn", "", "", "The DSN of target database (e.g. the file path of SQLite).")
+	flagPV(migrateCmd, "batch-size", "", 500, "The number of rows to copy at once.")
+
+	dbCmd.AddCommand(migrateCmd)
+
+	return dbCmd
+}
+
+// Parse the type of database, the common aliases are accepted (e.g. "postgres")
+func parseDBType(s string) config.DBType {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "sqlite", "sqlite3":
+		return config.TypeSQLite
+	case "mysql", "mariadb":
+		return config.TypeMySql
+	case "pgsql", "postgres", "postgresql":
+		return config.TypePostgreSQL
+	case "mssql", "sqlserver":
+		return config.TypeMSSQL
+	}
+
+	log.Fatal("Unsupported database type: ", s)
+	return ""
+}
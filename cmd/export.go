@@ -21,7 +21,12 @@ func NewExportCommand(app *ArtalkCmd) *cobra.Command {
 		Short:   "Artransfer export",
 		Long:    "\n# Artransfer - Export\n\n  See the documentation to learn more: https://artalk.js.org/guide/transfer.html",
 		Run: func(cmd *cobra.Command, args []string) {
-			jsonStr, err := artransfer.RunExportArtrans(app.Dao(), &artransfer.ExportParams{})
+			since, _ := cmd.Flags().GetString("since")
+			sinceID, _ := cmd.Flags().GetInt("since-id")
+			jsonStr, err := artransfer.RunExportArtrans(app.Dao(), &artransfer.ExportParams{
+				Since:   since,
+				SinceID: uint(max(sinceID, 0)),
+			})
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -68,5 +73,8 @@ func NewExportCommand(app *ArtalkCmd) *cobra.Command {
 		},
 	}
 
+	flagPV(exportCmd, "since", "", "", "Only export the comments created or updated since the time (e.g. \"2024-01-01 08:00:00 +0800\").")
+	flagPV(exportCmd, "since-id", "", 0, "Only export the comments whose ID is greater than it.")
+
 	return exportCmd
 }
@@ -18,43 +18,83 @@ func NewImportCommand(app *ArtalkCmd) *cobra.Command {
 		Short:   "Artransfer import",
 		Long:    "\n# Artransfer - Import\n\n  See the documentation to learn more: https://artalk.js.org/guide/transfer.html",
 		Run: func(cmd *cobra.Command, args []string) {
-			// Prepare params
-			params := &artransfer.ImportParams{}
-
-			// Parse JSON parameters from flags
-			if jsonParams, _ := cmd.Flags().GetString("parameters"); jsonParams != "" {
-				if err := json.Unmarshal([]byte(jsonParams), params); err != nil {
-					log.Fatal("Failed to parse JSON parameters: ", err)
-				}
-			}
-
-			// If JSON file or JSON data is not provided in flags, try to get it from arguments
-			if params.JsonFile == "" && params.JsonData == "" {
-				if len(args) == 0 {
-					log.Fatal(i18n.T("{{name}} is required", map[string]interface{}{"name": "FILENAME"}))
-				}
-				params.JsonFile = args[0]
-			}
-
-			// Parse flags to params
-			if flagAssumeyes, err := cmd.Flags().GetBool("assumeyes"); err == nil {
-				params.Assumeyes = flagAssumeyes
-			}
-
-			// Check if file exists if JsonFile is provided
-			if params.JsonFile != "" {
-				if _, err := os.Stat(params.JsonFile); errors.Is(err, os.ErrNotExist) {
-					log.Fatal(i18n.T("{{name}} not found", map[string]interface{}{"name": i18n.T("File")}))
-				}
-			}
-
-			// Run import
-			artransfer.RunImportArtrans(app.Dao(), params)
+			runImport(app, cmd, args, "")
 		},
 	}
 
 	flagPV(importCmd, "assumeyes", "y", false, "Automatically answer yes for all questions.")
 	flagPV(importCmd, "parameters", "p", "", "JSON format parameters for the import command.")
+	flagPV(importCmd, "dry-run", "", false, "Report the import without saving the changes.")
+	flagPV(importCmd, "on-conflict", "", "", "Resolve the comments which already exist by \"skip\", \"overwrite\" or \"merge\".")
+
+	// The subcommands to import from other comment systems
+	for _, format := range []string{
+		artransfer.FormatDisqus,
+		artransfer.FormatWordPress,
+		artransfer.FormatWaline,
+		artransfer.FormatTwikoo,
+		artransfer.FormatValine,
+		artransfer.FormatGitalk,
+		artransfer.FormatUtterances,
+	} {
+		converter, _ := artransfer.GetConverter(format)
+		importCmd.AddCommand(&cobra.Command{
+			Use:   format + " [FILENAME]",
+			Short: "Import from " + converter.Summary,
+			Long:  "\n# Artransfer - Import from " + converter.Name + "\n\n  See the documentation to learn more: https://artalk.js.org/guide/transfer.html",
+			PreRun: func(cmd *cobra.Command, args []string) {
+				importCmd.PreRun(cmd, args) // bootstrap the app, which is not inherited by the subcommands
+			},
+			Run: func(cmd *cobra.Command, args []string) {
+				runImport(app, cmd, args, format)
+			},
+		})
+	}
 
 	return importCmd
 }
+
+func runImport(app *ArtalkCmd, cmd *cobra.Command, args []string, format string) {
+	// Prepare params
+	params := &artransfer.ImportParams{}
+
+	// Parse JSON parameters from flags
+	if jsonParams, _ := cmd.Flags().GetString("parameters"); jsonParams != "" {
+		if err := json.Unmarshal([]byte(jsonParams), params); err != nil {
+			log.Fatal("Failed to parse JSON parameters: ", err)
+		}
+	}
+
+	// If JSON file or JSON data is not provided in flags, try to get it from arguments
+	// (the GitHub issues are fetched by the API if the repository is provided)
+	if params.JsonFile == "" && params.JsonData == "" && (params.GitHubRepo == "" || len(args) > 0) {
+		if len(args) == 0 {
+			log.Fatal(i18n.T("{{name}} is required", map[string]interface{}{"name": "FILENAME"}))
+		}
+		params.JsonFile = args[0]
+	}
+
+	// Parse flags to params
+	if format != "" {
+		params.Format = format
+	}
+	if flagAssumeyes, err := cmd.Flags().GetBool("assumeyes"); err == nil {
+		params.Assumeyes = flagAssumeyes
+	}
+	if flagDryRun, err := cmd.Flags().GetBool("dry-run"); err == nil && flagDryRun {
+		params.DryRun = true
+	}
+	if flagOnConflict, err := cmd.Flags().GetString("on-conflict"); err == nil && flagOnConflict != "" {
+		params.OnConflict = flagOnConflict
+	}
+
+	// Check if file exists if JsonFile is provided
+	if params.JsonFile != "" {
+		if _, err := os.Stat(params.JsonFile); errors.Is(err, os.ErrNotExist) {
+			log.Fatal(i18n.T("{{name}} not found", map[string]interface{}{"name": i18n.T("File")}))
+		}
+	}
+
+	// Run import
+	artransfer.RunImportArtrans(app.Dao(), params)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewUploadCommand(app *ArtalkCmd) *cobra.Command {
+	uploadCmd := &cobra.Command{
+		Use:   "upload",
+		Short: "Uploaded files management",
+	}
+
+	gcCmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete the orphaned uploads",
+		Long: "\n# Upload - Garbage Collection\n\n" +
+			"  Delete the uploaded files which are not referenced by any comment after the grace period\n" +
+			"  (`img_upload.gc.grace_period`), in the local disk or the object storage by the `img_upload.storage` config.\n\n" +
+			"  The soft deleted comments and the revisions are also counted as references.",
+		Args: cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			uploadCmd.PreRun(cmd, args) // bootstrap the app, which is not inherited by the subcommands
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			gcService, err := core.AppService[*core.UploadGCService](app.App)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			result, err := gcService.Run(dryRun)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			for _, name := range result.Deleted {
+				fmt.Println("  " + name)
+			}
+
+			data := map[string]interface{}{
+				"count": len(result.Deleted),
+				"total": result.Scanned,
+				"size":  utils.FormatFileSize(result.Reclaimed),
+			}
+			if dryRun {
+				log.Info(i18n.T("{{count}} of {{total}} uploaded files would be deleted, {{size}} would be reclaimed", data))
+			} else {
+				log.Info(i18n.T("{{count}} of {{total}} uploaded files are deleted, {{size}} reclaimed", data))
+			}
+		},
+	}
+
+	flagPV(gcCmd, "dry-run", "", false, "List the orphaned uploads without deleting them.")
+
+	uploadCmd.AddCommand(gcCmd)
+
+	return uploadCmd
+}
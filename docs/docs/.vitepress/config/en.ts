@@ -23,6 +23,8 @@ export const en = defineConfig({
             { text: 'Sidebar', link: '/en/guide/frontend/sidebar.md' },
             { text: 'Email Notification', link: '/en/guide/backend/email.md' },
             { text: 'Multi-channel Notification', link: '/en/guide/backend/admin_notify.md' },
+            { text: 'Webhooks', link: '/en/guide/backend/webhook.md' },
+            { text: 'Web Push', link: '/en/guide/backend/web-push.md' },
             { text: 'Social Login', link: '/en/guide/frontend/auth.md' },
             { text: 'Comment Moderation', link: '/en/guide/backend/moderator.md' },
             { text: 'Captcha', link: '/en/guide/backend/captcha.md' },
@@ -42,6 +44,9 @@ export const en = defineConfig({
             { text: 'Image Lazy Load', link: '/en/guide/frontend/img-lazy-load.md' },
             { text: 'Voting', link: '/zh/guide/frontend/voting.md' },
             { text: 'IP Region', link: '/en/guide/frontend/ip-region.md' },
+            { text: 'No-JavaScript Fallback', link: '/en/guide/frontend/noscript.md' },
+            { text: 'Comment Feeds', link: '/en/guide/frontend/feeds.md' },
+            { text: 'Real-time Comments', link: '/en/guide/frontend/realtime.md' },
             { text: 'Localization', link: '/en/guide/frontend/i18n.md' },
             { text: 'Development Documentation', link: '/en/develop/index.md' },
           ],
@@ -61,6 +66,10 @@ export const en = defineConfig({
           items: [
             { text: 'Daemon Process', link: '/en/guide/backend/daemon.md' },
             { text: 'Reverse Proxy', link: '/en/guide/backend/reverse-proxy.md' },
+            { text: 'Cluster Deployment', link: '/en/guide/backend/cluster.md' },
+            { text: 'Metrics', link: '/en/guide/backend/metrics.md' },
+            { text: 'Tracing', link: '/en/guide/backend/tracing.md' },
+            { text: 'Logging', link: '/en/guide/backend/logging.md' },
             {
               text: 'Compile Source',
               link: 'https://github.com/ArtalkJS/Artalk/blob/master/CONTRIBUTING.md',
@@ -84,6 +93,7 @@ export const en = defineConfig({
             { text: 'Import to Framework', link: '/en/develop/import-framework.md' },
             { text: 'Frontend API', link: '/en/develop/fe-api.md' },
             { text: 'Frontend Events', link: '/en/develop/event.md' },
+            { text: 'GraphQL API', link: '/en/develop/graphql.md' },
             { text: 'Frontend Types', link: 'https://artalk.js.org/typedoc/' },
             { text: 'Plugin Development', link: '/en/develop/plugin.md' },
             { text: 'Compatibility', link: '/en/develop/compatibility.md' },
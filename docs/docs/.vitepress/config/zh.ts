@@ -23,6 +23,8 @@ export const zh = defineConfig({
             { text: '侧边栏', link: '/zh/guide/frontend/sidebar.md' },
             { text: '邮件通知', link: '/zh/guide/backend/email.md' },
             { text: '多元推送', link: '/zh/guide/backend/admin_notify.md' },
+            { text: 'Webhook', link: '/zh/guide/backend/webhook.md' },
+            { text: '浏览器推送', link: '/zh/guide/backend/web-push.md' },
             { text: '社交登录', link: '/zh/guide/frontend/auth.md' },
             { text: '评论审核', link: '/zh/guide/backend/moderator.md' },
             { text: '验证码', link: '/zh/guide/backend/captcha.md' },
@@ -42,6 +44,9 @@ export const zh = defineConfig({
             { text: '图片懒加载', link: '/zh/guide/frontend/img-lazy-load.md' },
             { text: '投票功能', link: '/zh/guide/frontend/voting.md' },
             { text: 'IP 属地', link: '/zh/guide/frontend/ip-region.md' },
+            { text: '无 JavaScript 回退', link: '/zh/guide/frontend/noscript.md' },
+            { text: '评论订阅源', link: '/zh/guide/frontend/feeds.md' },
+            { text: '实时评论', link: '/zh/guide/frontend/realtime.md' },
             { text: '多语言', link: '/zh/guide/frontend/i18n.md' },
             { text: '开发文档', link: '/zh/develop/index.md' },
           ],
@@ -61,6 +66,10 @@ export const zh = defineConfig({
           items: [
             { text: '守护进程', link: '/zh/guide/backend/daemon.md' },
             { text: '反向代理', link: '/zh/guide/backend/reverse-proxy.md' },
+            { text: '集群部署', link: '/zh/guide/backend/cluster.md' },
+            { text: '监控指标', link: '/zh/guide/backend/metrics.md' },
+            { text: '链路追踪', link: '/zh/guide/backend/tracing.md' },
+            { text: '日志', link: '/zh/guide/backend/logging.md' },
             { text: '编译构建', link: '/zh/develop/contributing.md' },
             { text: '程序升级', link: '/zh/guide/backend/update.md' },
             { text: 'Docker', link: '/zh/guide/backend/docker.md' },
@@ -87,6 +96,7 @@ export const zh = defineConfig({
             { text: '置入框架', link: '/zh/develop/import-framework.md' },
             { text: '前端 API', link: '/zh/develop/fe-api.md' },
             { text: '前端 Events', link: '/zh/develop/event.md' },
+            { text: 'GraphQL API', link: '/zh/develop/graphql.md' },
             { text: '前端 Types', link: 'https://artalk.js.org/typedoc/' },
             { text: '插件开发', link: '/zh/develop/plugin.md' },
             { text: '兼容性', link: '/zh/develop/compatibility.md' },
@@ -1,38 +1,181 @@
 package anti_spam
 
 import (
-	"bytes"
+	"cmp"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/samber/lo"
 )
 
-var _ Checker = (*AIChecker)(nil)
+var _ VerdictChecker = (*AIChecker)(nil)
+
+type AICheckerConf struct {
+	Provider AIProvider // default is `openai`
+	ApiKey   string
+	Model    string
+	Host     string // the default host of provider is used if empty
+	Proxy    string // the outbound proxy (e.g. `socks5://127.0.0.1:1080`), the environment proxy is used if empty
+
+	// The endpoint customization for OpenAI compatible gateways (e.g. Azure OpenAI)
+	Path    string            // the API path to replace the default path of provider (placeholders: {{model}})
+	Query   map[string]string // the extra query parameters
+	Headers map[string]string // the extra headers (placeholders: {{api_key}}), the header is removed if the value is empty
+
+	MaxTokens      int              // the max tokens of response (0 for provider default)
+	Temperature    *float64         // the sampling temperature (nil for provider default)
+	ResponseFormat AIResponseFormat // default is `text`
+
+	// Custom moderation prompt, the built-in prompt is used if empty
+	//
+	// Placeholders: {{author}}, {{email}}, {{content}}, {{page_url}}, {{page_title}}, {{site_name}},
+	// {{parent_content}}, {{parent_author}}, {{history}}, {{examples}} (the moderator decisions),
+	// {{language}} (the detected language code) and {{context}} (all the available context above)
+	PromptTemplate string
+
+	// Rate limiter and budget (optional, shared by checkers)
+	Limiter *AILimiter
+
+	// The behavior when the rate limit or budget is exhausted
+	Fallback AIFallback
+
+	// The checker used when the fallback is `keywords`
+	FallbackChecker Checker
+
+	// Retry on transient errors (network errors, HTTP 429 and 5xx)
+	MaxRetries int
+	Backoff    time.Duration // initial backoff interval, doubled after each retry
+
+	// Circuit breaker (optional, shared by checkers)
+	Breaker *CircuitBreaker
+
+	// The decision when the response is unclear (default is `pass`)
+	UnclearDecision AIDecision
+
+	// The decision when the API request is failed,
+	// the error is returned and handled by `ApiFailBlock` if empty
+	ErrorDecision AIDecision
+
+	// Per-language policy (the language is detected from the comment content)
+	LanguagePolicies  map[string]AILanguagePolicy // keyed by the ISO 639-1 code
+	LanguageAllowlist []string                    // the allowed languages
+	HoldUnlisted      bool                        // hold the comments in languages not on the allowlist
+}
+
+type AILanguagePolicy struct {
+	PromptTemplate string  // The prompt for the language, the global prompt is used if empty
+	Threshold      float64 // The minimum confidence to block (range 0~1, 0 for no limit)
+}
+
+type AIDecision string
+
+const (
+	AIDecisionPass    AIDecision = "pass"    // Let the comment pass
+	AIDecisionBlock   AIDecision = "block"   // Block the comment
+	AIDecisionPending AIDecision = "pending" // Hold the comment for manual review
+)
+
+type AIResponseFormat string
+
+const (
+	AIResponseFormatText AIResponseFormat = "text" // Plain text response
+	AIResponseFormatJSON AIResponseFormat = "json" // JSON mode, the response is forced to be a JSON object
+)
+
+type AIFallback string
+
+const (
+	AIFallbackPass     AIFallback = "pass"     // Let the comment pass
+	AIFallbackPending  AIFallback = "pending"  // Set the comment to pending
+	AIFallbackKeywords AIFallback = "keywords" // Fall back to the keywords checker
+)
 
 type AIChecker struct {
-	apiKey string
-	model  string
-	host   string
+	provider aiProvider
+	apiKey   string
+	model    string
+	baseURL  string
+
+	promptTpl string
+	client    *http.Client
+
+	path       string
+	query      map[string]string
+	headers    map[string]string
+	generation aiGenerationOptions
+
+	limiter         *AILimiter
+	fallback        AIFallback
+	fallbackChecker Checker
+
+	maxRetries int
+	backoff    time.Duration
+	breaker    *CircuitBreaker
+	sleep      func(d time.Duration)
+
+	unclearDecision AIDecision
+	errorDecision   AIDecision
+
+	languagePolicies  map[string]AILanguagePolicy
+	languageAllowlist []string
+	holdUnlisted      bool
 }
 
-func NewAIChecker(apiKey, model, host string) Checker {
+func NewAIChecker(conf *AICheckerConf) Checker {
+	provider := getAIProvider(conf.Provider)
+
+	host := strings.TrimSpace(conf.Host)
 	if host == "" {
-		host = "api.openai.com"
+		host = provider.DefaultHost()
 	}
-	// Remove trailing slash and protocol if present
+	// Remove trailing slash and use https if protocol is not specified
 	host = strings.TrimSuffix(host, "/")
-	host = strings.TrimPrefix(host, "https://")
-	host = strings.TrimPrefix(host, "http://")
+	if !strings.HasPrefix(host, "https://") && !strings.HasPrefix(host, "http://") {
+		host = "https://" + host
+	}
+
+	promptTpl := conf.PromptTemplate
+	if strings.TrimSpace(promptTpl) == "" {
+		promptTpl = defaultModerationPrompt
+	}
 
 	return &AIChecker{
-		apiKey: apiKey,
-		model:  model,
-		host:   host,
+		provider:  provider,
+		apiKey:    conf.ApiKey,
+		model:     conf.Model,
+		baseURL:   host,
+		promptTpl: promptTpl,
+		client:    utils.NewTracedHTTPClient(conf.Proxy, 30*time.Second),
+		path:      strings.TrimSpace(conf.Path),
+		query:     conf.Query,
+		headers:   conf.Headers,
+		generation: aiGenerationOptions{
+			MaxTokens:   max(0, conf.MaxTokens),
+			Temperature: conf.Temperature,
+			JSONMode:    conf.ResponseFormat == AIResponseFormatJSON,
+		},
+		limiter:         conf.Limiter,
+		fallback:        conf.Fallback,
+		fallbackChecker: conf.FallbackChecker,
+		maxRetries:      max(0, conf.MaxRetries),
+		backoff:         conf.Backoff,
+		breaker:         conf.Breaker,
+		sleep:           time.Sleep,
+		unclearDecision: conf.UnclearDecision,
+		errorDecision:   conf.ErrorDecision,
+
+		languagePolicies:  conf.LanguagePolicies,
+		languageAllowlist: conf.LanguageAllowlist,
+		holdUnlisted:      conf.HoldUnlisted,
 	}
 }
 
@@ -41,20 +184,84 @@ func (*AIChecker) Name() string {
 }
 
 func (c *AIChecker) Check(p *CheckerParams) (bool, error) {
-	prompt := buildModerationPrompt(p)
-
-	response, err := c.callAPI(prompt)
+	verdict, err := c.CheckVerdict(p)
 	if err != nil {
 		return false, err
 	}
 
-	log.Debug(LOG_TAG, "[AI] Moderation response: ", response)
+	return verdict.Pass, nil
+}
+
+func (c *AIChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	lang := DetectLanguage(p.Content)
+	policy := c.languagePolicies[lang]
+
+	// Hold the comment in the language which is not allowed (unknown language is not held)
+	if c.holdUnlisted && len(c.languageAllowlist) > 0 && lang != "" && !containsFold(c.languageAllowlist, lang) {
+		return &CheckerVerdict{Pass: false, Review: true, Reason: fmt.Sprintf("language %q is not on the allowlist", lang)}, nil
+	}
+
+	// Skip the AI request and let other checkers decide if the circuit breaker is open
+	if c.breaker != nil && !c.breaker.Allow() {
+		log.Ctx(p.ctx()).Warn(LOG_TAG, "[AI] Circuit breaker is open, skip AI checker and fallback to other checkers")
+		return &CheckerVerdict{Pass: true, Reason: "AI checker is temporarily disabled by the circuit breaker", fallback: true}, nil
+	}
+
+	if c.limiter != nil && !c.limiter.Allow() {
+		log.Ctx(p.ctx()).Warn(LOG_TAG, "[AI] Rate limit or budget exhausted, fallback to: ", c.fallback)
+		return c.fallbackVerdict(p, "AI moderation rate limit or budget exhausted")
+	}
+
+	prompt := buildModerationPrompt(cmp.Or(strings.TrimSpace(policy.PromptTemplate), c.promptTpl), p)
+
+	response, err := c.callAPIWithRetry(p.ctx(), prompt)
+	if c.breaker != nil {
+		if err != nil {
+			if c.breaker.Failure() {
+				log.Ctx(p.ctx()).Warn(LOG_TAG, "[AI] Too many consecutive failures, AI checker is temporarily disabled")
+			}
+		} else {
+			c.breaker.Success()
+		}
+	}
+	if err != nil {
+		if c.errorDecision != "" {
+			log.Ctx(p.ctx()).Error(LOG_TAG, "[AI] API error, fallback to: ", c.errorDecision, ", err: ", err)
+			return decisionVerdict(c.errorDecision, "API error: "+err.Error()), nil
+		}
+		return nil, err
+	}
+
+	log.Ctx(p.ctx()).Debug(LOG_TAG, "[AI] Moderation response: ", response)
+
+	verdict, ok := parseAIVerdict(response)
+	if !ok {
+		log.Ctx(p.ctx()).Warn(LOG_TAG, "[AI] Unclear response, fallback to: ", cmp.Or(c.unclearDecision, AIDecisionPass), ", response: ", response)
+		return decisionVerdict(c.unclearDecision, "unclear response"), nil
+	}
+
+	// The blocking with low confidence is not trusted for the language
+	if !verdict.Pass && policy.Threshold > 0 && verdict.Confidence > 0 && verdict.Confidence < policy.Threshold {
+		log.Ctx(p.ctx()).Debug(LOG_TAG, fmt.Sprintf("[AI] Confidence %.2f is below the threshold %.2f of language %q, let it pass", verdict.Confidence, policy.Threshold, lang))
+		verdict.Pass = true
+	}
 
-	return parseAIResponse(response), nil
+	return verdict, nil
 }
 
-func buildModerationPrompt(p *CheckerParams) string {
-	return fmt.Sprintf(`You are a content moderation assistant. Your task is to determine if the following comment should be approved or blocked.
+// Get the verdict by the configured decision (default is pass)
+func decisionVerdict(decision AIDecision, reason string) *CheckerVerdict {
+	switch decision {
+	case AIDecisionBlock:
+		return &CheckerVerdict{Pass: false, Reason: reason, fallback: true}
+	case AIDecisionPending:
+		return &CheckerVerdict{Pass: false, Review: true, Reason: reason, fallback: true}
+	}
+
+	return &CheckerVerdict{Pass: true, Reason: reason, fallback: true}
+}
+
+const defaultModerationPrompt = `You are a content moderation assistant. Your task is to determine if the following comment should be approved or blocked.
 
 A comment should be BLOCKED if it contains:
 - Spam or advertising
@@ -66,106 +273,243 @@ A comment should be BLOCKED if it contains:
 - Meaningless or gibberish text
 - Excessive profanity
 
-Comment Information:
-- Author: %s
-- Email: %s
-- Content: %s
+A comment which is off-topic for the page, or abusive to the replied comment, should also be BLOCKED.
 
-Respond with ONLY one word: "PASS" if the comment should be approved, or "BLOCK" if it should be blocked.`, p.UserName, p.UserEmail, p.Content)
+Comment Information:
+- Author: {{author}}
+- Email: {{email}}
+- Content: {{content}}
+{{context}}
+{{examples}}
+
+Respond with ONLY a JSON object in the following format, without any other text:
+{"verdict": "PASS" or "BLOCK", "confidence": a number between 0 and 1, "reason": "a short explanation"}`
+
+// Render the moderation prompt template with the comment information
+func buildModerationPrompt(tpl string, p *CheckerParams) string {
+	return utils.RenderMustaches(tpl, map[string]interface{}{
+		"author":         p.UserName,
+		"email":          p.UserEmail,
+		"content":        p.Content,
+		"page_url":       p.PageURL,
+		"page_title":     p.PageTitle,
+		"site_name":      p.SiteName,
+		"parent_content": p.ParentContent,
+		"parent_author":  p.ParentAuthor,
+		"history":        buildHistoryList(p.RecentComments),
+		"context":        buildModerationContext(p),
+		"examples":       buildExamplesList(p.Examples),
+		"language":       DetectLanguage(p.Content),
+	})
 }
 
-type openAIRequest struct {
-	Model    string          `json:"model"`
-	Messages []openAIMessage `json:"messages"`
+// Build the context lines of the comment (only the available information is included)
+func buildModerationContext(p *CheckerParams) string {
+	lines := []string{}
+
+	if p.SiteName != "" {
+		lines = append(lines, "- Site: "+p.SiteName)
+	}
+	if p.PageTitle != "" || p.PageURL != "" {
+		lines = append(lines, "- Page: "+strings.TrimSpace(fmt.Sprintf("%s %s", p.PageTitle,
+			lo.If(p.PageURL != "", "("+p.PageURL+")").Else(""))))
+	}
+	if p.ParentContent != "" {
+		lines = append(lines, fmt.Sprintf("- Replying to %s: %s", cmp.Or(p.ParentAuthor, "a comment"), p.ParentContent))
+	}
+	if len(p.RecentComments) > 0 {
+		lines = append(lines, "- Recent comments of the author:\n"+buildHistoryList(p.RecentComments))
+	}
+
+	return strings.Join(lines, "\n")
 }
 
-type openAIMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+// Build the few-shot examples from the previous decisions of moderator
+func buildExamplesList(examples []SpamExample) string {
+	if len(examples) == 0 {
+		return ""
+	}
+
+	items := make([]string, 0, len(examples)+1)
+	items = append(items, "Examples of previous decisions by the moderator:")
+	for _, e := range examples {
+		items = append(items, fmt.Sprintf("- %s: %s", lo.If(e.IsSpam, "BLOCK").Else("PASS"), strings.ReplaceAll(e.Content, "\n", " ")))
+	}
+	return strings.Join(items, "\n")
 }
 
-type openAIResponse struct {
-	Choices []struct {
-		Message struct {
-			Content string `json:"content"`
-		} `json:"message"`
-	} `json:"choices"`
-	Error *struct {
-		Message string `json:"message"`
-	} `json:"error"`
+func buildHistoryList(comments []string) string {
+	items := make([]string, 0, len(comments))
+	for i, c := range comments {
+		items = append(items, fmt.Sprintf("  %d. %s", i+1, strings.ReplaceAll(c, "\n", " ")))
+	}
+	return strings.Join(items, "\n")
 }
 
-func (c *AIChecker) callAPI(prompt string) (string, error) {
-	reqBody := openAIRequest{
-		Model: c.model,
-		Messages: []openAIMessage{
-			{
-				Role:    "user",
-				Content: prompt,
-			},
-		},
+// Get the verdict by the fallback behavior
+func (c *AIChecker) fallbackVerdict(p *CheckerParams, reason string) (*CheckerVerdict, error) {
+	switch c.fallback {
+	case AIFallbackPending:
+		return &CheckerVerdict{Pass: false, Review: true, Reason: reason, fallback: true}, nil
+	case AIFallbackKeywords:
+		if c.fallbackChecker != nil {
+			verdict, err := runChecker(c.fallbackChecker, p)
+			if err != nil {
+				return nil, err
+			}
+			verdict.Reason = fmt.Sprintf("%s, fallback to %s checker", reason, c.fallbackChecker.Name())
+			verdict.fallback = true
+			return verdict, nil
+		}
 	}
 
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+	return &CheckerVerdict{Pass: true, Reason: reason, fallback: true}, nil
+}
+
+// Call the API and retry with exponential backoff if it is a transient error
+func (c *AIChecker) callAPIWithRetry(ctx context.Context, prompt string) (string, error) {
+	backoff := c.backoff
+	for i := 0; ; i++ {
+		response, err := c.callAPI(ctx, prompt)
+
+		var retryable *aiRetryableError
+		if err == nil || !errors.As(err, &retryable) || i >= c.maxRetries {
+			return response, err
+		}
+
+		log.Ctx(ctx).Warn(LOG_TAG, fmt.Sprintf("[AI] Request failed, retry %d/%d after %s: ", i+1, c.maxRetries, backoff), err)
+
+		c.sleep(backoff)
+		backoff *= 2
 	}
+}
 
-	apiURL := fmt.Sprintf("https://%s/v1/chat/completions", c.host)
+// The transient error which can be retried (e.g. network error, HTTP 429 and 5xx)
+type aiRetryableError struct {
+	err error
+}
 
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonData))
+func (e *aiRetryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *aiRetryableError) Unwrap() error {
+	return e.err
+}
+
+func (c *AIChecker) callAPI(ctx context.Context, prompt string) (string, error) {
+	req, err := c.provider.NewRequest(c.baseURL, c.apiKey, c.model, prompt, c.generation)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
-
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+	req = req.WithContext(ctx)
+	if err := c.customizeRequest(req); err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := client.Do(req)
+	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to call AI API: %w", err)
+		return "", &aiRetryableError{fmt.Errorf("failed to call AI API: %w", err)}
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", &aiRetryableError{fmt.Errorf("failed to read response: %w", err)}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return "", &aiRetryableError{fmt.Errorf("AI API error: HTTP %d %s", resp.StatusCode, strings.TrimSpace(string(body)))}
 	}
 
-	var openAIResp openAIResponse
-	if err := json.Unmarshal(body, &openAIResp); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	text, tokens, err := c.provider.ParseResponse(body)
+	if c.limiter != nil && tokens > 0 {
+		c.limiter.AddTokens(tokens)
 	}
 
-	if openAIResp.Error != nil {
-		return "", fmt.Errorf("AI API error: %s", openAIResp.Error.Message)
+	return text, err
+}
+
+// Apply the custom path, query parameters and headers to the request of provider
+func (c *AIChecker) customizeRequest(req *http.Request) error {
+	if c.path != "" {
+		path := strings.ReplaceAll(c.path, "{{model}}", url.PathEscape(c.model))
+		u, err := url.Parse(c.baseURL + "/" + strings.TrimPrefix(path, "/"))
+		if err != nil {
+			return err
+		}
+		req.URL.Path, req.URL.RawPath = u.Path, u.RawPath
+		if u.RawQuery != "" {
+			req.URL.RawQuery = u.RawQuery
+		}
 	}
 
-	if len(openAIResp.Choices) == 0 {
-		return "", fmt.Errorf("no response from AI API")
+	if len(c.query) > 0 {
+		q := req.URL.Query()
+		for k, v := range c.query {
+			q.Set(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
 	}
 
-	return openAIResp.Choices[0].Message.Content, nil
+	for k, v := range c.headers {
+		if v == "" {
+			req.Header.Del(k)
+		} else {
+			req.Header.Set(k, strings.ReplaceAll(v, "{{api_key}}", c.apiKey))
+		}
+	}
+
+	return nil
+}
+
+type aiVerdict struct {
+	Verdict    string  `json:"verdict"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
 }
 
-func parseAIResponse(response string) bool {
-	response = strings.TrimSpace(strings.ToUpper(response))
+// Parse the AI response, defaults to pass if the response is unclear
+func parseAIResponse(response string) *CheckerVerdict {
+	if verdict, ok := parseAIVerdict(response); ok {
+		return verdict
+	}
+
+	return &CheckerVerdict{Pass: true, Reason: "unclear response"}
+}
+
+// Parse the AI response, returns false if the response is unclear
+func parseAIVerdict(response string) (*CheckerVerdict, bool) {
+	// Try to parse the structured JSON verdict
+	// (the JSON object may be wrapped in a markdown code block or other text)
+	if start, end := strings.Index(response, "{"), strings.LastIndex(response, "}"); start != -1 && end > start {
+		var v aiVerdict
+		if err := json.Unmarshal([]byte(response[start:end+1]), &v); err == nil {
+			switch strings.ToUpper(strings.TrimSpace(v.Verdict)) {
+			case "PASS":
+				return &CheckerVerdict{Pass: true, Confidence: clampConfidence(v.Confidence), Reason: v.Reason}, true
+			case "BLOCK":
+				return &CheckerVerdict{Pass: false, Confidence: clampConfidence(v.Confidence), Reason: v.Reason}, true
+			}
+		}
+	}
+
+	// Fallback to the single word response (e.g. custom prompt template)
+	word := strings.TrimSpace(strings.ToUpper(response))
 
 	// If response contains "PASS", consider it passed
-	if strings.Contains(response, "PASS") {
-		return true
+	if strings.Contains(word, "PASS") {
+		return &CheckerVerdict{Pass: true}, true
 	}
 
 	// If response contains "BLOCK", consider it blocked
-	if strings.Contains(response, "BLOCK") {
-		return false
+	if strings.Contains(word, "BLOCK") {
+		return &CheckerVerdict{Pass: false}, true
 	}
 
-	// Default to pass if the response is unclear
-	log.Warn(LOG_TAG, "[AI] Unclear response, defaulting to pass: ", response)
-	return true
+	return nil, false
+}
+
+func clampConfidence(v float64) float64 {
+	return max(0, min(1, v))
 }
@@ -0,0 +1,74 @@
+package anti_spam
+
+import (
+	"sync"
+	"time"
+)
+
+// Circuit breaker for the AI checker
+//
+// The circuit opens after `threshold` consecutive failures and keeps open for `cooldown`,
+// then a trial request is allowed (half-open). If the trial fails, the circuit opens again immediately.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mux       sync.Mutex
+	failures  int
+	openUntil time.Time
+	halfOpen  bool
+
+	now func() time.Time
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Whether the request is allowed (the circuit is closed or half-open)
+func (b *CircuitBreaker) Allow() bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.openUntil.IsZero() {
+		return true
+	}
+
+	if b.now().Before(b.openUntil) {
+		return false
+	}
+
+	// cooldown finished, allow a trial request
+	b.openUntil = time.Time{}
+	b.halfOpen = true
+	return true
+}
+
+// Record a successful request
+func (b *CircuitBreaker) Success() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.failures = 0
+	b.halfOpen = false
+}
+
+// Record a failed request, returns true if the circuit is opened by this failure
+func (b *CircuitBreaker) Failure() bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.failures++
+	if b.halfOpen || b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+		b.failures = 0
+		b.halfOpen = false
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+package anti_spam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	b := NewCircuitBreaker(3, time.Minute)
+	b.now = func() time.Time { return now }
+
+	assert.False(t, b.Failure())
+	assert.False(t, b.Failure())
+	b.Success() // reset consecutive failures
+	assert.False(t, b.Failure())
+	assert.False(t, b.Failure())
+	assert.True(t, b.Allow())
+
+	assert.True(t, b.Failure(), "should open after 3 consecutive failures")
+	assert.False(t, b.Allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.Allow(), "should be half-open after cooldown")
+	assert.True(t, b.Failure(), "should open again if the trial request is failed")
+	assert.False(t, b.Allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.Allow())
+	b.Success()
+	assert.False(t, b.Failure(), "should be closed if the trial request is succeeded")
+	assert.True(t, b.Allow())
+}
@@ -0,0 +1,109 @@
+package anti_spam
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/store"
+)
+
+type AILimiterConf struct {
+	PerMinute       int // Max requests per minute (0 for unlimited)
+	MonthlyRequests int // Max requests per calendar month (0 for unlimited)
+	MonthlyTokens   int // Max tokens per calendar month (0 for unlimited)
+
+	// The store of counters (optional, in memory if nil)
+	Store store.Store
+}
+
+// The counters of month are kept a little longer than the longest month
+const aiLimiterMonthTTL = 32 * 24 * time.Hour
+
+// Rate limiter and budget counter for AI moderation calls
+//
+// The counters are shared by all AI checkers created by the same AntiSpam instance.
+// They are kept in the store, which is in memory for the single instance (the usage will be reset
+// when the program restarts), and in Redis for the cluster mode (shared by the instances).
+type AILimiter struct {
+	conf  AILimiterConf
+	store store.Store
+
+	now func() time.Time
+}
+
+func NewAILimiter(conf AILimiterConf) *AILimiter {
+	s := conf.Store
+	if s == nil {
+		s = store.NewMemoryStore()
+	}
+	return &AILimiter{
+		conf:  conf,
+		store: s,
+		now:   time.Now,
+	}
+}
+
+// Whether the limiter has any limit configured
+func (conf AILimiterConf) IsEnabled() bool {
+	return conf.PerMinute > 0 || conf.MonthlyRequests > 0 || conf.MonthlyTokens > 0
+}
+
+// Acquire a request quota
+//
+// Returns false if the rate limit or the monthly budget is exhausted.
+func (l *AILimiter) Allow() bool {
+	now := l.now()
+
+	if l.conf.MonthlyTokens > 0 && l.count(l.monthKey(now, "tokens")) >= int64(l.conf.MonthlyTokens) {
+		return false
+	}
+
+	// the counters are increased before compared, so the quota is not exceeded by the concurrent requests
+	if l.conf.PerMinute > 0 && l.incr(fmt.Sprintf("ai_limiter:minute:%d", now.Unix()/60), 1, 2*time.Minute) > int64(l.conf.PerMinute) {
+		return false
+	}
+	if l.conf.MonthlyRequests > 0 && l.incr(l.monthKey(now, "requests"), 1, aiLimiterMonthTTL) > int64(l.conf.MonthlyRequests) {
+		return false
+	}
+
+	return true
+}
+
+// Record the tokens used by a request
+func (l *AILimiter) AddTokens(tokens int) {
+	if tokens <= 0 {
+		return
+	}
+	l.incr(l.monthKey(l.now(), "tokens"), int64(tokens), aiLimiterMonthTTL)
+}
+
+// The tokens used in current month
+func (l *AILimiter) monthTokens() int {
+	return int(l.count(l.monthKey(l.now(), "tokens")))
+}
+
+// The key of counter in current month (e.g. "ai_limiter:month:2024-10:tokens")
+func (l *AILimiter) monthKey(now time.Time, name string) string {
+	return "ai_limiter:month:" + now.Format("2006-01") + ":" + name
+}
+
+func (l *AILimiter) count(key string) int64 {
+	v, ok := l.store.Get(key)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.ParseInt(v, 10, 64)
+	return n
+}
+
+// Increase the counter, the errors of store are ignored (not limited), so the moderation is not stopped by the store failure
+func (l *AILimiter) incr(key string, delta int64, ttl time.Duration) int64 {
+	n, err := l.store.Incr(key, delta, ttl)
+	if err != nil {
+		log.Error("[AILimiter] Failed to increase the counter: ", err)
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,109 @@
+package anti_spam
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAILimiter(t *testing.T) {
+	now := time.Date(2024, 10, 31, 23, 58, 0, 0, time.UTC)
+	newLimiter := func(conf AILimiterConf) *AILimiter {
+		l := NewAILimiter(conf)
+		l.now = func() time.Time { return now }
+		return l
+	}
+
+	t.Run("PerMinute", func(t *testing.T) {
+		l := newLimiter(AILimiterConf{PerMinute: 2})
+		assert.True(t, l.Allow())
+		assert.True(t, l.Allow())
+		assert.False(t, l.Allow(), "should be limited in the same minute")
+
+		now = now.Add(time.Minute)
+		assert.True(t, l.Allow(), "should be reset in the next minute")
+	})
+
+	t.Run("MonthlyRequests", func(t *testing.T) {
+		l := newLimiter(AILimiterConf{MonthlyRequests: 1})
+		assert.True(t, l.Allow())
+		now = now.Add(10 * time.Second)
+		assert.False(t, l.Allow())
+
+		now = now.Add(time.Hour) // next month
+		assert.True(t, l.Allow(), "should be reset in the next month")
+	})
+
+	t.Run("MonthlyTokens", func(t *testing.T) {
+		l := newLimiter(AILimiterConf{MonthlyTokens: 100})
+		assert.True(t, l.Allow())
+		l.AddTokens(60)
+		assert.True(t, l.Allow())
+		l.AddTokens(60)
+		assert.False(t, l.Allow(), "should be limited when tokens exhausted")
+	})
+
+	assert.False(t, AILimiterConf{}.IsEnabled())
+	assert.True(t, AILimiterConf{MonthlyTokens: 1}.IsEnabled())
+}
+
+func TestAICheckerLimit(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "PASS"}}], "usage": {"total_tokens": 42}}`))
+	}))
+	defer server.Close()
+
+	kwFile := fmt.Sprintf("%s/keywords.txt", t.TempDir())
+	_ = os.WriteFile(kwFile, []byte("spam"), 0644)
+
+	newChecker := func(fallback AIFallback) *AIChecker {
+		return NewAIChecker(&AICheckerConf{
+			Host:     server.URL,
+			Limiter:  NewAILimiter(AILimiterConf{MonthlyRequests: 1}),
+			Fallback: fallback,
+			FallbackChecker: NewKeywordsChecker(&KeywordsCheckerConf{
+				Files: []string{kwFile}, FileSep: "\n", Mode: KwCheckerModeBlock,
+			}),
+		}).(*AIChecker)
+	}
+
+	t.Run("TokenUsage", func(t *testing.T) {
+		checker := newChecker(AIFallbackPass)
+		_, err := checker.CheckVerdict(&CheckerParams{Content: "hello"})
+		assert.NoError(t, err)
+		assert.Equal(t, 42, checker.limiter.monthTokens())
+	})
+
+	tests := []struct {
+		fallback AIFallback
+		content  string
+		pass     bool
+	}{
+		{AIFallbackPass, "spam", true},
+		{"", "spam", true},
+		{AIFallbackPending, "hello", false},
+		{AIFallbackKeywords, "hello", true},
+		{AIFallbackKeywords, "spam", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Fallback_%s_%s", tt.fallback, tt.content), func(t *testing.T) {
+			checker := newChecker(tt.fallback)
+			_, _ = checker.CheckVerdict(&CheckerParams{Content: tt.content}) // exhaust the budget
+
+			calls = 0
+			verdict, err := checker.CheckVerdict(&CheckerParams{Content: tt.content})
+			assert.NoError(t, err)
+			assert.Equal(t, 0, calls, "should not call API when limited")
+			assert.Equal(t, tt.pass, verdict.Pass)
+			assert.Contains(t, verdict.Reason, "exhausted")
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package anti_spam
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/samber/lo"
+)
+
+type AIProvider string
+
+const (
+	AIProviderOpenAI    AIProvider = "openai"
+	AIProviderAnthropic AIProvider = "anthropic"
+	AIProviderGemini    AIProvider = "gemini"
+	AIProviderOllama    AIProvider = "ollama"
+)
+
+// The request and response marshalling of an AI provider
+type aiProvider interface {
+	DefaultHost() string
+	NewRequest(baseURL, apiKey, model, prompt string, opts aiGenerationOptions) (*http.Request, error)
+	ParseResponse(body []byte) (text string, tokens int, err error)
+}
+
+// The generation options of AI request, the provider default is used if not set
+type aiGenerationOptions struct {
+	MaxTokens   int      // the max tokens of response (0 for provider default)
+	Temperature *float64 // the sampling temperature (nil for provider default)
+	JSONMode    bool     // force the response to be a JSON object (not supported by Anthropic)
+}
+
+func getAIProvider(name AIProvider) aiProvider {
+	switch name {
+	case AIProviderAnthropic:
+		return &anthropicProvider{}
+	case AIProviderGemini:
+		return &geminiProvider{}
+	case AIProviderOllama:
+		return &ollamaProvider{}
+	default:
+		return &openAIProvider{} // OpenAI compatible API by default
+	}
+}
+
+func newJSONRequest(url string, data any) (*http.Request, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+type aiMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// -------------------------------------------------------------------
+//  OpenAI (Chat Completions API)
+//  @link https://platform.openai.com/docs/api-reference/chat
+// -------------------------------------------------------------------
+
+type openAIProvider struct{}
+
+type openAIRequest struct {
+	Model          string                `json:"model"`
+	Messages       []aiMessage           `json:"messages"`
+	Stream         bool                  `json:"stream"`
+	MaxTokens      int                   `json:"max_tokens,omitempty"`
+	Temperature    *float64              `json:"temperature,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (*openAIProvider) DefaultHost() string {
+	return "api.openai.com"
+}
+
+func (*openAIProvider) NewRequest(baseURL, apiKey, model, prompt string, opts aiGenerationOptions) (*http.Request, error) {
+	data := openAIRequest{
+		Model:       model,
+		Messages:    []aiMessage{{Role: "user", Content: prompt}},
+		Stream:      false,
+		MaxTokens:   opts.MaxTokens,
+		Temperature: opts.Temperature,
+	}
+	if opts.JSONMode {
+		data.ResponseFormat = &openAIResponseFormat{Type: "json_object"}
+	}
+
+	req, err := newJSONRequest(baseURL+"/v1/chat/completions", data)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	return req, nil
+}
+
+func (*openAIProvider) ParseResponse(body []byte) (string, int, error) {
+	var resp openAIResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Error != nil {
+		return "", 0, fmt.Errorf("AI API error: %s", resp.Error.Message)
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", resp.Usage.TotalTokens, fmt.Errorf("no response from AI API")
+	}
+
+	return resp.Choices[0].Message.Content, resp.Usage.TotalTokens, nil
+}
+
+// -------------------------------------------------------------------
+//  Anthropic (Messages API)
+//  @link https://docs.anthropic.com/en/api/messages
+// -------------------------------------------------------------------
+
+type anthropicProvider struct{}
+
+type anthropicRequest struct {
+	Model       string      `json:"model"`
+	MaxTokens   int         `json:"max_tokens"`
+	Messages    []aiMessage `json:"messages"`
+	Temperature *float64    `json:"temperature,omitempty"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (*anthropicProvider) DefaultHost() string {
+	return "api.anthropic.com"
+}
+
+func (*anthropicProvider) NewRequest(baseURL, apiKey, model, prompt string, opts aiGenerationOptions) (*http.Request, error) {
+	req, err := newJSONRequest(baseURL+"/v1/messages", anthropicRequest{
+		Model:       model,
+		MaxTokens:   cmp.Or(opts.MaxTokens, 1024), // required by Anthropic API
+		Messages:    []aiMessage{{Role: "user", Content: prompt}},
+		Temperature: opts.Temperature,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	return req, nil
+}
+
+func (*anthropicProvider) ParseResponse(body []byte) (string, int, error) {
+	var resp anthropicResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Error != nil {
+		return "", 0, fmt.Errorf("AI API error: %s", resp.Error.Message)
+	}
+
+	tokens := resp.Usage.InputTokens + resp.Usage.OutputTokens
+	for _, c := range resp.Content {
+		if c.Type == "text" {
+			return c.Text, tokens, nil
+		}
+	}
+
+	return "", tokens, fmt.Errorf("no response from AI API")
+}
+
+// -------------------------------------------------------------------
+//  Google Gemini (generateContent API)
+//  @link https://ai.google.dev/api/generate-content
+// -------------------------------------------------------------------
+
+type geminiProvider struct{}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiRequest struct {
+	Contents         []geminiContent         `json:"contents"`
+	GenerationConfig *geminiGenerationConfig `json:"generationConfig,omitempty"`
+}
+
+type geminiGenerationConfig struct {
+	MaxOutputTokens  int      `json:"maxOutputTokens,omitempty"`
+	Temperature      *float64 `json:"temperature,omitempty"`
+	ResponseMimeType string   `json:"responseMimeType,omitempty"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content geminiContent `json:"content"`
+	} `json:"candidates"`
+	UsageMetadata struct {
+		TotalTokenCount int `json:"totalTokenCount"`
+	} `json:"usageMetadata"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (*geminiProvider) DefaultHost() string {
+	return "generativelanguage.googleapis.com"
+}
+
+func (*geminiProvider) NewRequest(baseURL, apiKey, model, prompt string, opts aiGenerationOptions) (*http.Request, error) {
+	data := geminiRequest{
+		Contents: []geminiContent{{Role: "user", Parts: []geminiPart{{Text: prompt}}}},
+	}
+	if opts != (aiGenerationOptions{}) {
+		data.GenerationConfig = &geminiGenerationConfig{
+			MaxOutputTokens:  opts.MaxTokens,
+			Temperature:      opts.Temperature,
+			ResponseMimeType: lo.If(opts.JSONMode, "application/json").Else(""),
+		}
+	}
+
+	req, err := newJSONRequest(fmt.Sprintf("%s/v1beta/models/%s:generateContent", baseURL, url.PathEscape(model)), data)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("x-goog-api-key", apiKey)
+	return req, nil
+}
+
+func (*geminiProvider) ParseResponse(body []byte) (string, int, error) {
+	var resp geminiResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Error != nil {
+		return "", 0, fmt.Errorf("AI API error: %s", resp.Error.Message)
+	}
+
+	tokens := resp.UsageMetadata.TotalTokenCount
+	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		return "", tokens, fmt.Errorf("no response from AI API")
+	}
+
+	return resp.Candidates[0].Content.Parts[0].Text, tokens, nil
+}
+
+// -------------------------------------------------------------------
+//  Ollama (local, Chat API)
+//  @link https://github.com/ollama/ollama/blob/main/docs/api.md#generate-a-chat-completion
+// -------------------------------------------------------------------
+
+type ollamaProvider struct{}
+
+type ollamaRequest struct {
+	Model    string         `json:"model"`
+	Messages []aiMessage    `json:"messages"`
+	Stream   bool           `json:"stream"`
+	Format   string         `json:"format,omitempty"`
+	Options  *ollamaOptions `json:"options,omitempty"`
+}
+
+type ollamaOptions struct {
+	NumPredict  int      `json:"num_predict,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+}
+
+type ollamaResponse struct {
+	Message         *aiMessage `json:"message"`
+	PromptEvalCount int        `json:"prompt_eval_count"`
+	EvalCount       int        `json:"eval_count"`
+	Error           string     `json:"error"`
+}
+
+func (*ollamaProvider) DefaultHost() string {
+	return "http://localhost:11434"
+}
+
+func (*ollamaProvider) NewRequest(baseURL, apiKey, model, prompt string, opts aiGenerationOptions) (*http.Request, error) {
+	data := ollamaRequest{
+		Model:    model,
+		Messages: []aiMessage{{Role: "user", Content: prompt}},
+		Stream:   false,
+		Format:   lo.If(opts.JSONMode, "json").Else(""),
+	}
+	if opts.MaxTokens > 0 || opts.Temperature != nil {
+		data.Options = &ollamaOptions{NumPredict: opts.MaxTokens, Temperature: opts.Temperature}
+	}
+
+	req, err := newJSONRequest(baseURL+"/api/chat", data)
+	if err != nil {
+		return nil, err
+	}
+
+	// API key is optional (e.g. Ollama behind an auth proxy)
+	if apiKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	}
+	return req, nil
+}
+
+func (*ollamaProvider) ParseResponse(body []byte) (string, int, error) {
+	var resp ollamaResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Error != "" {
+		return "", 0, fmt.Errorf("AI API error: %s", resp.Error)
+	}
+
+	tokens := resp.PromptEvalCount + resp.EvalCount
+	if resp.Message == nil {
+		return "", tokens, fmt.Errorf("no response from AI API")
+	}
+
+	return resp.Message.Content, tokens, nil
+}
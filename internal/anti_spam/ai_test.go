@@ -0,0 +1,396 @@
+package anti_spam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAIChecker(t *testing.T) {
+	assert.Equal(t, "ai", NewAIChecker(&AICheckerConf{}).Name())
+
+	params := &CheckerParams{
+		SiteName:  "ArtalkDocs",
+		PageURL:   "https://artalk.js.org/guide/intro.html",
+		Content:   "Hello World",
+		UserName:  "qwqcode",
+		UserEmail: "qwqcode@example.com",
+	}
+
+	t.Run("DefaultPrompt", func(t *testing.T) {
+		checker := NewAIChecker(&AICheckerConf{}).(*AIChecker)
+		assert.Equal(t, defaultModerationPrompt, checker.promptTpl)
+
+		prompt := buildModerationPrompt(checker.promptTpl, params)
+		assert.Contains(t, prompt, "- Author: qwqcode")
+		assert.Contains(t, prompt, "- Email: qwqcode@example.com")
+		assert.Contains(t, prompt, "- Content: Hello World")
+	})
+
+	t.Run("CustomPrompt", func(t *testing.T) {
+		checker := NewAIChecker(&AICheckerConf{
+			PromptTemplate: "[{{ site_name }}] {{page_url}} {{author}} <{{email}}>: {{content}} {{unknown}}",
+		}).(*AIChecker)
+
+		prompt := buildModerationPrompt(checker.promptTpl, params)
+		assert.Equal(t, "[ArtalkDocs] https://artalk.js.org/guide/intro.html qwqcode <qwqcode@example.com>: Hello World {{unknown}}", prompt)
+	})
+
+	t.Run("PromptContext", func(t *testing.T) {
+		checker := NewAIChecker(&AICheckerConf{}).(*AIChecker)
+		prompt := buildModerationPrompt(checker.promptTpl, &CheckerParams{
+			SiteName:       "ArtalkDocs",
+			PageURL:        "https://artalk.js.org/guide/intro.html",
+			PageTitle:      "Intro",
+			Content:        "Hello World",
+			ParentContent:  "Nice post",
+			ParentAuthor:   "Alice",
+			RecentComments: []string{"Buy now", "Cheap\nwatches"},
+		})
+		assert.Contains(t, prompt, "- Site: ArtalkDocs")
+		assert.Contains(t, prompt, "- Page: Intro (https://artalk.js.org/guide/intro.html)")
+		assert.Contains(t, prompt, "- Replying to Alice: Nice post")
+		assert.Contains(t, prompt, "- Recent comments of the author:\n  1. Buy now\n  2. Cheap watches")
+
+		assert.Equal(t, "", buildModerationContext(&CheckerParams{Content: "Hello"}), "should be empty without context")
+		assert.Equal(t, "- Page: Intro", buildModerationContext(&CheckerParams{PageTitle: "Intro"}))
+
+		prompt = buildModerationPrompt("{{parent_author}}: {{parent_content}} | {{page_title}}\n{{history}}", &CheckerParams{
+			PageTitle: "Intro", ParentContent: "Nice post", ParentAuthor: "Alice", RecentComments: []string{"Hi"},
+		})
+		assert.Equal(t, "Alice: Nice post | Intro\n  1. Hi", prompt)
+	})
+
+	t.Run("PromptExamples", func(t *testing.T) {
+		checker := NewAIChecker(&AICheckerConf{}).(*AIChecker)
+		prompt := buildModerationPrompt(checker.promptTpl, &CheckerParams{
+			Content:  "Hello World",
+			Examples: []SpamExample{{Content: "Buy\nnow", IsSpam: true}, {Content: "Great post", IsSpam: false}},
+		})
+		assert.Contains(t, prompt, "Examples of previous decisions by the moderator:\n- BLOCK: Buy now\n- PASS: Great post")
+
+		assert.Equal(t, "", buildExamplesList(nil), "should be empty without examples")
+	})
+
+	t.Run("ParseResponse", func(t *testing.T) {
+		assert.True(t, parseAIResponse("PASS").Pass)
+		assert.True(t, parseAIResponse(" pass\n").Pass)
+		assert.False(t, parseAIResponse("BLOCK").Pass)
+		assert.True(t, parseAIResponse("unclear").Pass, "should default to pass")
+	})
+
+	t.Run("ParseJSONResponse", func(t *testing.T) {
+		v := parseAIResponse(`{"verdict": "BLOCK", "confidence": 0.92, "reason": "Advertising link"}`)
+		assert.Equal(t, &CheckerVerdict{Pass: false, Confidence: 0.92, Reason: "Advertising link"}, v)
+
+		v = parseAIResponse("```json\n{\"verdict\": \"pass\", \"confidence\": 1.5, \"reason\": \"OK\"}\n```")
+		assert.Equal(t, &CheckerVerdict{Pass: true, Confidence: 1, Reason: "OK"}, v, "should strip code block and clamp confidence")
+
+		v = parseAIResponse(`{"verdict": "MAYBE"} BLOCK`)
+		assert.False(t, v.Pass, "should fallback to word parsing")
+	})
+
+	t.Run("Providers", func(t *testing.T) {
+		const verdictJSON = `{\"verdict\": \"BLOCK\", \"confidence\": 0.8, \"reason\": \"spam\"}`
+
+		tests := []struct {
+			provider   AIProvider
+			path       string
+			authHeader string
+			authValue  string
+			response   string
+		}{
+			{AIProviderOpenAI, "/v1/chat/completions", "Authorization", "Bearer test_key",
+				`{"choices": [{"message": {"content": "` + verdictJSON + `"}}]}`},
+			{AIProviderAnthropic, "/v1/messages", "x-api-key", "test_key",
+				`{"content": [{"type": "text", "text": "` + verdictJSON + `"}]}`},
+			{AIProviderGemini, "/v1beta/models/test_model:generateContent", "x-goog-api-key", "test_key",
+				`{"candidates": [{"content": {"parts": [{"text": "` + verdictJSON + `"}]}}]}`},
+			{AIProviderOllama, "/api/chat", "Authorization", "Bearer test_key",
+				`{"message": {"role": "assistant", "content": "` + verdictJSON + `"}}`},
+		}
+
+		for _, tt := range tests {
+			t.Run(string(tt.provider), func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, tt.path, r.URL.Path)
+					assert.Equal(t, tt.authValue, r.Header.Get(tt.authHeader))
+
+					body, _ := io.ReadAll(r.Body)
+					assert.True(t, json.Valid(body))
+					assert.Contains(t, string(body), "Hello World")
+
+					_, _ = w.Write([]byte(tt.response))
+				}))
+				defer server.Close()
+
+				checker := NewAIChecker(&AICheckerConf{
+					Provider: tt.provider,
+					ApiKey:   "test_key",
+					Model:    "test_model",
+					Host:     server.URL,
+				}).(*AIChecker)
+
+				verdict, err := checker.CheckVerdict(params)
+				assert.NoError(t, err)
+				assert.Equal(t, &CheckerVerdict{Pass: false, Confidence: 0.8, Reason: "spam"}, verdict)
+			})
+		}
+	})
+
+	t.Run("ProviderError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(401)
+			_, _ = w.Write([]byte(`{"error": {"message": "invalid api key"}}`))
+		}))
+		defer server.Close()
+
+		checker := NewAIChecker(&AICheckerConf{Provider: AIProviderAnthropic, Host: server.URL})
+		pass, err := checker.Check(params)
+		assert.ErrorContains(t, err, "invalid api key")
+		assert.False(t, pass)
+	})
+
+	t.Run("Proxy", func(t *testing.T) {
+		var proxied string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r.URL.String()
+			_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "PASS"}}]}`))
+		}))
+		defer proxy.Close()
+
+		checker := NewAIChecker(&AICheckerConf{Host: "http://api.example.invalid", Proxy: proxy.URL})
+		pass, err := checker.Check(params)
+		assert.NoError(t, err)
+		assert.True(t, pass)
+		assert.Equal(t, "http://api.example.invalid/v1/chat/completions", proxied, "should send the request via proxy")
+	})
+
+	t.Run("CustomEndpoint", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/openai/deployments/gpt-4o/chat/completions", r.URL.Path)
+			assert.Equal(t, "2024-10-21", r.URL.Query().Get("api-version"))
+			assert.Equal(t, "test_key", r.Header.Get("api-key"))
+			assert.Empty(t, r.Header.Get("Authorization"), "should remove the default header")
+			assert.Equal(t, "artalk", r.Header.Get("X-Gateway-User"))
+
+			_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "PASS"}}]}`))
+		}))
+		defer server.Close()
+
+		checker := NewAIChecker(&AICheckerConf{
+			ApiKey: "test_key",
+			Model:  "gpt-4o",
+			Host:   server.URL,
+			Path:   "openai/deployments/{{model}}/chat/completions",
+			Query:  map[string]string{"api-version": "2024-10-21"},
+			Headers: map[string]string{
+				"api-key":        "{{api_key}}",
+				"Authorization":  "",
+				"X-Gateway-User": "artalk",
+			},
+		})
+		pass, err := checker.Check(params)
+		assert.NoError(t, err)
+		assert.True(t, pass)
+	})
+
+	t.Run("GenerationOptions", func(t *testing.T) {
+		temperature := 0.0
+		opts := aiGenerationOptions{MaxTokens: 16, Temperature: &temperature, JSONMode: true}
+
+		tests := []struct {
+			provider AIProvider
+			expected string
+		}{
+			{AIProviderOpenAI, `"stream":false,"max_tokens":16,"temperature":0,"response_format":{"type":"json_object"}`},
+			{AIProviderAnthropic, `"max_tokens":16,"messages":[{"role":"user","content":"Hello"}],"temperature":0`},
+			{AIProviderGemini, `"generationConfig":{"maxOutputTokens":16,"temperature":0,"responseMimeType":"application/json"}`},
+			{AIProviderOllama, `"stream":false,"format":"json","options":{"num_predict":16,"temperature":0}`},
+		}
+		for _, tt := range tests {
+			req, err := getAIProvider(tt.provider).NewRequest("https://example.com", "key", "model", "Hello", opts)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(req.Body)
+			assert.Contains(t, string(body), tt.expected, tt.provider)
+
+			// the provider defaults
+			req, err = getAIProvider(tt.provider).NewRequest("https://example.com", "key", "model", "Hello", aiGenerationOptions{})
+			assert.NoError(t, err)
+			body, _ = io.ReadAll(req.Body)
+			assert.NotContains(t, string(body), "temperature", tt.provider)
+		}
+
+		checker := NewAIChecker(&AICheckerConf{MaxTokens: 16, Temperature: &temperature, ResponseFormat: AIResponseFormatJSON}).(*AIChecker)
+		assert.Equal(t, opts, checker.generation)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 3 {
+				w.WriteHeader(lo.If(calls == 1, 429).Else(503))
+				return
+			}
+			_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "PASS"}}]}`))
+		}))
+		defer server.Close()
+
+		var sleeps []time.Duration
+		checker := NewAIChecker(&AICheckerConf{Host: server.URL, MaxRetries: 2, Backoff: 100 * time.Millisecond}).(*AIChecker)
+		checker.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+		pass, err := checker.Check(params)
+		assert.NoError(t, err)
+		assert.True(t, pass)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, sleeps, "should backoff exponentially")
+
+		calls = 0
+		checker.maxRetries = 1
+		_, err = checker.Check(params)
+		assert.ErrorContains(t, err, "HTTP 503", "should give up after max retries")
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("NoRetryOnClientError", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(401)
+			_, _ = w.Write([]byte(`{"error": {"message": "invalid api key"}}`))
+		}))
+		defer server.Close()
+
+		checker := NewAIChecker(&AICheckerConf{Host: server.URL, MaxRetries: 3}).(*AIChecker)
+		checker.sleep = func(d time.Duration) {}
+
+		_, err := checker.Check(params)
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("CircuitBreaker", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(500)
+		}))
+		defer server.Close()
+
+		breaker := NewCircuitBreaker(2, time.Minute)
+		checker := NewAIChecker(&AICheckerConf{Host: server.URL, Breaker: breaker})
+
+		_, _ = checker.Check(params)
+		assert.True(t, breaker.Allow())
+		_, _ = checker.Check(params)
+		assert.False(t, breaker.Allow(), "should open after consecutive failures")
+
+		pass, err := checker.Check(params)
+		assert.NoError(t, err)
+		assert.True(t, pass, "should be skipped when the circuit is open")
+		assert.Equal(t, 2, calls, "should not request the API when the circuit is open")
+	})
+
+	t.Run("Decisions", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "I am not sure"}}]}`))
+		}))
+		defer server.Close()
+
+		errServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(400)
+			_, _ = w.Write([]byte(`{"error": {"message": "bad request"}}`))
+		}))
+		defer errServer.Close()
+
+		tests := []struct {
+			decision AIDecision
+			pass     bool
+			review   bool
+		}{
+			{"", true, false},
+			{AIDecisionPass, true, false},
+			{AIDecisionBlock, false, false},
+			{AIDecisionPending, false, true},
+		}
+
+		for _, tt := range tests {
+			t.Run("Unclear_"+string(tt.decision), func(t *testing.T) {
+				checker := NewAIChecker(&AICheckerConf{Host: server.URL, UnclearDecision: tt.decision}).(*AIChecker)
+				verdict, err := checker.CheckVerdict(params)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.pass, verdict.Pass)
+				assert.Equal(t, tt.review, verdict.Review)
+				assert.Equal(t, "unclear response", verdict.Reason)
+			})
+
+			t.Run("Error_"+string(tt.decision), func(t *testing.T) {
+				checker := NewAIChecker(&AICheckerConf{Host: errServer.URL, ErrorDecision: tt.decision}).(*AIChecker)
+				verdict, err := checker.CheckVerdict(params)
+				if tt.decision == "" {
+					assert.Error(t, err, "should return error if error decision is not set")
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.pass, verdict.Pass)
+				assert.Equal(t, tt.review, verdict.Review)
+				assert.Contains(t, verdict.Reason, "bad request")
+			})
+		}
+	})
+
+	t.Run("Languages", func(t *testing.T) {
+		var prompts []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			prompts = append(prompts, string(body))
+			_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "{\"verdict\": \"BLOCK\", \"confidence\": 0.6, \"reason\": \"spam\"}"}}]}`))
+		}))
+		defer server.Close()
+
+		checker := NewAIChecker(&AICheckerConf{
+			Host: server.URL,
+			LanguagePolicies: map[string]AILanguagePolicy{
+				"zh": {PromptTemplate: "[zh] {{language}}: {{content}}", Threshold: 0.8},
+				"ja": {Threshold: 0.5},
+			},
+			LanguageAllowlist: []string{"zh", "ja", "en"},
+			HoldUnlisted:      true,
+		}).(*AIChecker)
+
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: "感谢分享，这篇文章很有帮助"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass, "should pass if the confidence is below the threshold of language")
+		assert.Contains(t, prompts[0], "[zh] zh: 感谢分享", "should use the prompt of language")
+
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: "記事をありがとうございます"})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass)
+		assert.Contains(t, prompts[1], "You are a content moderation assistant", "should use the global prompt")
+
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: "Спасибо за статью, очень полезно"})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass)
+		assert.True(t, verdict.Review, "should hold the language not on the allowlist")
+		assert.Len(t, prompts, 2, "should not call the API for the held language")
+
+		_, err = checker.CheckVerdict(&CheckerParams{Content: "ok"})
+		assert.NoError(t, err)
+		assert.Len(t, prompts, 3, "should not hold the unknown language")
+	})
+
+	t.Run("DefaultHost", func(t *testing.T) {
+		assert.Equal(t, "https://api.openai.com", NewAIChecker(&AICheckerConf{}).(*AIChecker).baseURL)
+		assert.Equal(t, "https://api.anthropic.com", NewAIChecker(&AICheckerConf{Provider: AIProviderAnthropic}).(*AIChecker).baseURL)
+		assert.Equal(t, "http://localhost:11434", NewAIChecker(&AICheckerConf{Provider: AIProviderOllama}).(*AIChecker).baseURL)
+		assert.Equal(t, "https://example.com", NewAIChecker(&AICheckerConf{Host: "example.com/"}).(*AIChecker).baseURL)
+	})
+}
@@ -9,17 +9,24 @@ import (
 	"strings"
 
 	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/samber/lo"
 )
 
 var _ Checker = (*AkismetChecker)(nil)
+var _ Reporter = (*AkismetChecker)(nil)
 
 type AkismetChecker struct {
-	key string
+	key     string
+	baseURL string
+	client  *http.Client
 }
 
-func NewAkismetChecker(key string) Checker {
+func NewAkismetChecker(key string, proxy string) Checker {
 	return &AkismetChecker{
-		key: key,
+		key:     key,
+		baseURL: fmt.Sprintf("https://%s.rest.akismet.com", key),
+		client:  utils.NewTracedHTTPClient(proxy, 0),
 	}
 }
 
@@ -29,6 +36,45 @@ func (*AkismetChecker) Name() string {
 
 func (c *AkismetChecker) Check(p *CheckerParams) (bool, error) {
 	// @link https://akismet.com/development/api/#comment-check
+	respStr, err := c.request("comment-check", p)
+	if err != nil {
+		return false, err
+	}
+
+	log.Ctx(p.ctx()).Debug("akismet Spam Detection Response ", respStr)
+
+	switch respStr {
+	case "true":
+		// is a spam comment
+		return false, nil
+	case "false":
+		// not a spam comment
+		return true, nil
+	}
+
+	return false, fmt.Errorf(respStr)
+}
+
+// Submit the missed spam or false positive to Akismet
+func (c *AkismetChecker) Report(p *CheckerParams, isSpam bool) error {
+	// @link https://akismet.com/developers/submit-spam-missed-spam/
+	// @link https://akismet.com/developers/submit-ham-false-positives/
+	respStr, err := c.request(lo.If(isSpam, "submit-spam").Else("submit-ham"), p)
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(p.ctx()).Debug("akismet Feedback Response ", respStr)
+
+	if !strings.HasPrefix(respStr, "Thanks") {
+		return fmt.Errorf(respStr)
+	}
+
+	return nil
+}
+
+// Send the comment to the Akismet API method and get the response body
+func (c *AkismetChecker) request(method string, p *CheckerParams) (string, error) {
 	form := url.Values{}
 
 	reqParams := newAkismetReqParams(p)
@@ -40,42 +86,27 @@ func (c *AkismetChecker) Check(p *CheckerParams) (bool, error) {
 		}
 	}
 
-	client := &http.Client{}
-
 	reqBody := strings.NewReader(form.Encode())
-	api := fmt.Sprintf("https://%s.rest.akismet.com/1.1/comment-check", c.key)
-	req, err := http.NewRequest("POST", api, reqBody)
+	api := fmt.Sprintf("%s/1.1/%s", c.baseURL, method)
+	req, err := http.NewRequestWithContext(p.ctx(), "POST", api, reqBody)
 	if err != nil {
-		return false, err
+		return "", err
 	}
 
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := client.Do(req)
+	resp, err := c.client.Do(req)
 	if err != nil {
-		return false, err
+		return "", err
 	}
 
 	defer resp.Body.Close()
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return false, err
-	}
-
-	respStr := string(respBody)
-
-	log.Debug("akismet Spam Detection Response ", respStr)
-
-	switch respStr {
-	case "true":
-		// is a spam comment
-		return false, nil
-	case "false":
-		// not a spam comment
-		return true, nil
+		return "", err
 	}
 
-	return false, fmt.Errorf(respStr)
+	return string(respBody), nil
 }
 
 type AkismetReqParams struct {
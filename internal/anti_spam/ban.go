@@ -0,0 +1,304 @@
+package anti_spam
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+var _ VerdictChecker = (*BanChecker)(nil)
+
+// The ban types
+const (
+	BanTypeIP          = "ip"
+	BanTypeCIDR        = "cidr"
+	BanTypeEmailDomain = "email_domain"
+	BanTypeASN         = "asn"
+	BanTypeCountry     = "country"
+)
+
+var BanTypes = []string{BanTypeIP, BanTypeCIDR, BanTypeEmailDomain, BanTypeASN, BanTypeCountry}
+
+// The rule of ban list, the expired rules should not be loaded
+type BanRule struct {
+	ID      uint
+	Type    string
+	Value   string // The normalized value by `NormalizeBanValue`
+	Pending bool   // Hold the comment for manual review instead of blocking
+	Reason  string
+}
+
+// Get the description of rule as the moderation reason
+func (r BanRule) Describe() string {
+	desc := fmt.Sprintf("banned by %s %s", r.Type, r.Value)
+	if r.Reason != "" {
+		desc += ": " + r.Reason
+	}
+	return desc
+}
+
+// The checker by the ban list of admin, which is consulted before any other checker
+type BanChecker struct {
+	conf *BanCheckerConf
+}
+
+type BanCheckerConf struct {
+	// Load the active ban rules of the site (including the global rules)
+	LoadRules func(siteName string) []BanRule
+
+	// The IP to ASN database (optional, the ASN rules are ignored if nil)
+	ASN *ASNDatabase
+
+	// Find the country code and name of the IP (optional, the country rules are ignored if nil)
+	LookupCountry func(ip string) (code string, name string)
+}
+
+func NewBanChecker(conf *BanCheckerConf) Checker {
+	return &BanChecker{
+		conf: conf,
+	}
+}
+
+func (*BanChecker) Name() string {
+	return "ban"
+}
+
+func (c *BanChecker) Check(p *CheckerParams) (bool, error) {
+	verdict, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return verdict.Pass, nil
+}
+
+func (c *BanChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	rule := c.Match(p.SiteName, p.UserIP, p.UserEmail)
+	if rule == nil {
+		return &CheckerVerdict{Pass: true}, nil
+	}
+
+	return &CheckerVerdict{
+		Pass:       false,
+		Confidence: 1,
+		Reason:     rule.Describe(),
+		Review:     rule.Pending,
+	}, nil
+}
+
+// Find the first matched ban rule by the IP and the email, nil if not banned
+//
+// The block rules take precedence over the pending rules.
+func (c *BanChecker) Match(siteName string, ip string, email string) *BanRule {
+	if c.conf.LoadRules == nil {
+		return nil
+	}
+
+	addr := net.ParseIP(strings.TrimSpace(ip))
+	domain := ""
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		domain = strings.ToLower(strings.TrimSpace(email[at+1:]))
+	}
+
+	var asn uint32
+	asnLoaded := false
+
+	var countryCode, countryName string
+	countryLoaded := false
+
+	var matched *BanRule
+	for _, rule := range c.conf.LoadRules(siteName) {
+		ok := false
+		switch rule.Type {
+		case BanTypeIP:
+			ok = addr != nil && addr.Equal(net.ParseIP(rule.Value))
+		case BanTypeCIDR:
+			_, ipNet, err := net.ParseCIDR(rule.Value)
+			ok = err == nil && addr != nil && ipNet.Contains(addr)
+		case BanTypeEmailDomain:
+			ok = domain != "" && (domain == rule.Value || strings.HasSuffix(domain, "."+rule.Value))
+		case BanTypeASN:
+			if c.conf.ASN == nil || addr == nil {
+				continue
+			}
+			if !asnLoaded {
+				asn, _ = c.conf.ASN.Lookup(addr)
+				asnLoaded = true
+			}
+			ok = asn != 0 && strconv.FormatUint(uint64(asn), 10) == rule.Value
+		case BanTypeCountry:
+			if c.conf.LookupCountry == nil || addr == nil {
+				continue
+			}
+			if !countryLoaded {
+				countryCode, countryName = c.conf.LookupCountry(addr.String())
+				countryLoaded = true
+			}
+			ok = (countryCode != "" && strings.EqualFold(countryCode, rule.Value)) ||
+				(countryName != "" && strings.EqualFold(countryName, rule.Value))
+		}
+
+		if ok {
+			rule := rule
+			if !rule.Pending {
+				return &rule
+			}
+			if matched == nil {
+				matched = &rule
+			}
+		}
+	}
+
+	return matched
+}
+
+// Normalize and validate the value of ban rule
+//
+// The IP and CIDR are in the canonical form, the email domain is in lowercase without "@",
+// the ASN is the number without the "AS" prefix, and the country is the ISO code in uppercase or the name.
+func NormalizeBanValue(banType string, value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch banType {
+	case BanTypeIP:
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return "", fmt.Errorf("invalid IP %q", value)
+		}
+		return ip.String(), nil
+	case BanTypeCIDR:
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid CIDR %q", value)
+		}
+		return ipNet.String(), nil
+	case BanTypeEmailDomain:
+		domain := strings.ToLower(strings.TrimPrefix(value, "@"))
+		if domain == "" || strings.ContainsAny(domain, "@ /") {
+			return "", fmt.Errorf("invalid email domain %q", value)
+		}
+		return domain, nil
+	case BanTypeASN:
+		num := strings.TrimPrefix(strings.ToUpper(value), "AS")
+		n, err := strconv.ParseUint(num, 10, 32)
+		if err != nil || n == 0 {
+			return "", fmt.Errorf("invalid ASN %q", value)
+		}
+		return strconv.FormatUint(n, 10), nil
+	case BanTypeCountry:
+		if value == "" || strings.ContainsAny(value, "|/") {
+			return "", fmt.Errorf("invalid country %q", value)
+		}
+		if len(value) == 2 {
+			return strings.ToUpper(value), nil // ISO 3166-1 alpha-2 code
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid ban type %q", banType)
+	}
+}
+
+// -------------------------------------------------------------------
+//  ASN Database
+// -------------------------------------------------------------------
+
+// The IP to ASN database loaded from the TSV file of iptoasn.com
+//
+// Each line is `range_start	range_end	AS_number	country_code	AS_description`,
+// the ranges are in ascending order and not overlapped.
+//
+// @link https://iptoasn.com
+type ASNDatabase struct {
+	ranges []asnRange
+}
+
+type asnRange struct {
+	start net.IP // 16-byte form
+	end   net.IP
+	asn   uint32
+}
+
+// Load the ASN database from the TSV file (`ip2asn-combined.tsv`, `ip2asn-v4.tsv` or `ip2asn-v6.tsv`)
+func LoadASNDatabase(filename string) (*ASNDatabase, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	db := &ASNDatabase{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 3 {
+			continue
+		}
+		start, end := net.ParseIP(fields[0]), net.ParseIP(fields[1])
+		asn, err := strconv.ParseUint(fields[2], 10, 32)
+		if start == nil || end == nil || err != nil {
+			continue
+		}
+		db.ranges = append(db.ranges, asnRange{start: start.To16(), end: end.To16(), asn: uint32(asn)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return bytes.Compare(db.ranges[i].start, db.ranges[j].start) < 0
+	})
+
+	return db, nil
+}
+
+// Find the ASN of the IP, false is returned if not found or not routed (AS0)
+func (db *ASNDatabase) Lookup(ip net.IP) (uint32, bool) {
+	ip = ip.To16()
+	if ip == nil {
+		return 0, false
+	}
+
+	// the last range which starts before or at the IP
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return bytes.Compare(db.ranges[i].start, ip) > 0
+	}) - 1
+	if i < 0 || bytes.Compare(ip, db.ranges[i].end) > 0 || db.ranges[i].asn == 0 {
+		return 0, false
+	}
+
+	return db.ranges[i].asn, true
+}
+
+var (
+	asnDatabases   = map[string]*ASNDatabase{}
+	asnDatabasesMu sync.Mutex
+)
+
+// Get the ASN database of the file which is loaded once, nil if the file is empty or failed to load
+func getASNDatabase(filename string) *ASNDatabase {
+	filename = strings.TrimSpace(filename)
+	if filename == "" {
+		return nil
+	}
+
+	asnDatabasesMu.Lock()
+	defer asnDatabasesMu.Unlock()
+
+	if db, ok := asnDatabases[filename]; ok {
+		return db
+	}
+
+	db, err := LoadASNDatabase(filename)
+	if err != nil {
+		log.Error(LOG_TAG, "[Ban] Failed to load the ASN database: ", err)
+	}
+	asnDatabases[filename] = db // cache the failure as nil to avoid reloading
+	return db
+}
@@ -0,0 +1,135 @@
+package anti_spam
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeBanValue(t *testing.T) {
+	tests := []struct {
+		banType string
+		value   string
+		want    string
+		ok      bool
+	}{
+		{BanTypeIP, " 192.0.2.1 ", "192.0.2.1", true},
+		{BanTypeIP, "2001:DB8::1", "2001:db8::1", true},
+		{BanTypeIP, "192.0.2", "", false},
+		{BanTypeCIDR, "192.0.2.9/24", "192.0.2.0/24", true},
+		{BanTypeCIDR, "192.0.2.1", "", false},
+		{BanTypeEmailDomain, "@Example.COM", "example.com", true},
+		{BanTypeEmailDomain, "a@example.com", "", false},
+		{BanTypeASN, "as13335", "13335", true},
+		{BanTypeASN, "13335", "13335", true},
+		{BanTypeASN, "AS0", "", false},
+		{BanTypeCountry, " us ", "US", true},
+		{BanTypeCountry, "Russia", "Russia", true},
+		{BanTypeCountry, "", "", false},
+		{"unknown", "x", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeBanValue(tt.banType, tt.value)
+		assert.Equal(t, tt.ok, err == nil, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+}
+
+func TestBanChecker(t *testing.T) {
+	dir := t.TempDir()
+	asnFile := filepath.Join(dir, "ip2asn.tsv")
+	os.WriteFile(asnFile, []byte("1.0.0.0\t1.0.0.255\t13335\tUS\tCLOUDFLARENET\n"+
+		"2.0.0.0\t2.0.0.255\t0\tNone\tNot routed\n"+
+		"2001:db8::\t2001:db8::ffff\t64500\tZZ\tEXAMPLE\n"), 0644)
+	asn, err := LoadASNDatabase(asnFile)
+	assert.NoError(t, err)
+
+	asnNum, ok := asn.Lookup(net.ParseIP("1.0.0.8"))
+	assert.True(t, ok)
+	assert.Equal(t, uint32(13335), asnNum)
+	_, ok = asn.Lookup(net.ParseIP("2.0.0.1"))
+	assert.False(t, ok, "should not match the not routed range")
+	_, ok = asn.Lookup(net.ParseIP("3.0.0.1"))
+	assert.False(t, ok)
+
+	rules := []BanRule{
+		{ID: 1, Type: BanTypeIP, Value: "192.0.2.1"},
+		{ID: 2, Type: BanTypeCIDR, Value: "198.51.100.0/24", Pending: true},
+		{ID: 3, Type: BanTypeEmailDomain, Value: "spam.com", Reason: "spammer"},
+		{ID: 4, Type: BanTypeASN, Value: "64500", Pending: true},
+		{ID: 5, Type: BanTypeIP, Value: "198.51.100.7"},
+		{ID: 6, Type: BanTypeCountry, Value: "ZZ", Pending: true},
+		{ID: 7, Type: BanTypeCountry, Value: "Atlantis"},
+	}
+	checker := NewBanChecker(&BanCheckerConf{
+		LoadRules: func(siteName string) []BanRule { return rules },
+		ASN:       asn,
+		LookupCountry: func(ip string) (string, string) {
+			switch ip {
+			case "203.0.113.1":
+				return "ZZ", "Nowhere"
+			case "203.0.113.2":
+				return "", "atlantis"
+			}
+			return "", ""
+		},
+	}).(*BanChecker)
+	assert.Equal(t, "ban", checker.Name())
+
+	tests := []struct {
+		name  string
+		ip    string
+		email string
+		id    uint // 0 for not banned
+	}{
+		{"IP", "192.0.2.1", "a@example.com", 1},
+		{"CIDR", "198.51.100.200", "", 2},
+		{"BlockFirst", "198.51.100.7", "", 5},
+		{"EmailDomain", "", "a@SPAM.com", 3},
+		{"EmailSubdomain", "", "a@mail.spam.com", 3},
+		{"EmailNotSuffix", "", "a@notspam.com", 0},
+		{"ASN", "2001:db8::1", "", 4},
+		{"CountryCode", "203.0.113.1", "", 6},
+		{"CountryName", "203.0.113.2", "", 7},
+		{"NotBanned", "192.0.2.2", "a@example.com", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := checker.Match("", tt.ip, tt.email)
+			if tt.id == 0 {
+				assert.Nil(t, rule)
+				return
+			}
+			if assert.NotNil(t, rule) {
+				assert.Equal(t, tt.id, rule.ID)
+			}
+		})
+	}
+
+	verdict, err := checker.CheckVerdict(&CheckerParams{UserEmail: "a@spam.com"})
+	assert.NoError(t, err)
+	assert.False(t, verdict.Pass)
+	assert.False(t, verdict.Review)
+	assert.Equal(t, "banned by email_domain spam.com: spammer", verdict.Reason)
+
+	verdict, _ = checker.CheckVerdict(&CheckerParams{UserIP: "198.51.100.1"})
+	assert.True(t, verdict.Review, "should hold for review by the pending rule")
+
+	t.Run("Consulted first", func(t *testing.T) {
+		blocked := uint(0)
+		as := NewAntiSpam(&AntiSpamConf{
+			LoadBans:       func(siteName string) []BanRule { return rules },
+			OnBlockComment: func(commentID uint, verdict *CheckerVerdict) { blocked = commentID },
+		})
+		as.conf.Scoring.Enabled = true
+
+		assert.False(t, as.CheckAndBlock(&CheckerParams{CommentID: 7, UserIP: "192.0.2.1", IsTrusted: true}))
+		assert.Equal(t, uint(7), blocked)
+		assert.True(t, as.CheckAndBlock(&CheckerParams{CommentID: 8, UserIP: "192.0.2.2"}))
+	})
+}
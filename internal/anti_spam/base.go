@@ -1,13 +1,19 @@
 package anti_spam
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/artalkjs/artalk/v2/internal/tracing"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const LOG_TAG = "[AntiSpam] "
@@ -19,59 +25,324 @@ const LOG_TAG = "[AntiSpam] "
 type AntiSpamConf struct {
 	config.ModeratorConf
 
-	OnBlockComment  func(commentID uint)
-	OnUpdateComment func(commentID uint, content string)
+	OnBlockComment   func(commentID uint, verdict *CheckerVerdict)
+	OnPendingComment func(commentID uint, verdict *CheckerVerdict) // grey zone of scoring, send to pending review
+	OnUpdateComment  func(commentID uint, content string)
+	OnShadowVerdict  func(commentID uint, verdict *CheckerVerdict) // the verdict of checker in dry-run mode, which never blocks
+
+	// The hook after each checker is executed (optional, used to collect the latencies and errors of checkers)
+	OnCheckerDone func(checker string, latency time.Duration, err error)
+
+	// The storage of verdicts for identical content (optional, used when `cache.enabled` is on)
+	VerdictCache VerdictCache
+
+	// The store of the AI limiter counters and the reputation lookup results (optional, in memory if nil)
+	Store store.Store
+
+	// The loader of samples decided by the moderator (optional, used to train the bayes checker)
+	LoadSamples func() []SpamExample
+
+	// The loader of images which are not absolute URLs (optional, e.g. the local uploaded images)
+	LoadImage func(src string) ([]byte, bool)
+
+	// The global outbound proxy for remote API checkers (optional, the per-service proxy takes precedence)
+	OutboundProxy string
+
+	// The finder of recent comments of the site (optional, used by the flood checker)
+	FindRecentComments func(p *CheckerParams, since time.Time) []RecentComment
+
+	// The loader of active ban rules of the site (optional, used by the ban checker)
+	LoadBans func(siteName string) []BanRule
+
+	// Find the country of IP (optional, used by the country rules of ban list)
+	LookupCountry func(ip string) (code string, name string)
 }
 
 type AntiSpam struct {
 	conf *AntiSpamConf
+
+	aiLimiter *AILimiter      // shared by all AI checkers
+	aiBreaker *CircuitBreaker // shared by all AI checkers
+
+	remoteKeywords  *RemoteKeywords // shared by all keywords checkers
+	reputationCache store.Store     // shared by all reputation checkers
+	bayesModel      *BayesModel     // shared by all bayes checkers
+
+	cacheCounter *verdictCacheCounter
 }
 
 // Create new AntiSpam instance
 func NewAntiSpam(conf *AntiSpamConf) *AntiSpam {
-	return &AntiSpam{
-		conf: conf,
+	as := &AntiSpam{
+		conf:         conf,
+		cacheCounter: &verdictCacheCounter{},
+
+		remoteKeywords:  NewRemoteKeywords(time.Duration(conf.Keywords.RefreshInterval) * time.Second),
+		reputationCache: conf.Store,
+		bayesModel:      NewBayesModel(conf.LoadSamples, conf.Store),
+	}
+	if as.reputationCache == nil {
+		as.reputationCache = store.NewMemoryStore()
+	}
+
+	aiLimitConf := AILimiterConf{
+		PerMinute:       conf.AI.Limit.PerMinute,
+		MonthlyRequests: conf.AI.Limit.MonthlyRequests,
+		MonthlyTokens:   conf.AI.Limit.MonthlyTokens,
+		Store:           conf.Store,
+	}
+	if aiLimitConf.IsEnabled() {
+		as.aiLimiter = NewAILimiter(aiLimitConf)
 	}
+
+	if breakerConf := conf.AI.CircuitBreaker; breakerConf.Threshold > 0 {
+		as.aiBreaker = NewCircuitBreaker(breakerConf.Threshold, time.Duration(breakerConf.Cooldown)*time.Second)
+	}
+
+	return as
 }
 
 // Check and block comment if it is spam,
 // the function is exposed and can be called by other modules
-func (as AntiSpam) CheckAndBlock(params *CheckerParams) {
+//
+// Returns true if the comment is passed.
+func (as AntiSpam) CheckAndBlock(params *CheckerParams) bool {
+	ctx, span := tracing.Start(params.ctx(), "antispam.check",
+		attribute.Int64("artalk.comment.id", int64(params.CommentID)),
+		attribute.String("artalk.site", params.SiteName))
+	params = params.withContext(ctx)
+
+	pass := as.checkAndBlock(params)
+
+	span.SetAttributes(attribute.Bool("artalk.antispam.pass", pass))
+	span.End()
+
+	return pass
+}
+
+func (as AntiSpam) checkAndBlock(params *CheckerParams) bool {
+	// The ban list is consulted before any other checker (regardless of the trusted user and the scoring mode)
+	if ban := as.getBanChecker(); ban != nil && !as.checkerTrigger(ban, params) {
+		return false
+	}
+
 	checkers := as.getEnabledCheckers()
 
+	// Skip the remote API checkers for trusted users
+	if params.IsTrusted {
+		checkers = lo.Reject(checkers, func(c Checker, _ int) bool {
+			return lo.Contains(trustedBypassCheckers, c.Name())
+		})
+	}
+
+	// Weighted scoring mode, all checkers will be executed
+	if as.conf.Scoring.Enabled {
+		return as.scoringTrigger(checkers, params) == ScoringPass
+	}
+
 	// Execute check one by one
 	// Multiple checkers can be enabled at the same time
 	// If one of the checkers returns false, the comment will be blocked
 	for _, checker := range checkers {
+		if as.isDryRun(checker) {
+			as.dryRunTrigger(checker, params)
+			continue
+		}
+
 		pass := as.checkerTrigger(checker, params)
 
 		if !pass {
-			return // if blocked, stop checking
+			return false // if blocked, stop checking
+		}
+	}
+
+	return true
+}
+
+// Create a new AntiSpam instance with the different moderator config (e.g. per-site overrides),
+// the callbacks, limiter, circuit breaker and cache metrics are shared with the original instance.
+func (as AntiSpam) WithModeratorConf(moderatorConf config.ModeratorConf) *AntiSpam {
+	conf := *as.conf
+	conf.ModeratorConf = moderatorConf
+
+	derived := as
+	derived.conf = &conf
+
+	return &derived
+}
+
+// Get the hit rate metrics of verdict cache
+func (as AntiSpam) CacheStats() VerdictCacheStats {
+	return as.cacheCounter.Stats()
+}
+
+// The result of running a checker by `Test`
+type CheckerTestResult struct {
+	Checker    string  `json:"checker"`
+	Pass       bool    `json:"pass"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
+	Review     bool    `json:"review"`
+	DryRun     bool    `json:"dry_run"`
+	Latency    int64   `json:"latency"` // unit: ms
+	Error      string  `json:"error"`
+}
+
+// Run the sample comment through all the enabled checkers without any side effects,
+// to validate the API keys and prompts.
+//
+// The verdict cache, rate limiter and circuit breaker are bypassed,
+// and the comment is never blocked or updated.
+func (as AntiSpam) Test(params *CheckerParams) []CheckerTestResult {
+	conf := *as.conf
+	conf.Cache.Enabled = false
+	conf.OnUpdateComment = nil
+
+	derived := as
+	derived.conf = &conf
+	derived.aiLimiter = nil
+	derived.aiBreaker = nil
+
+	checkers := derived.getEnabledCheckers()
+	if ban := derived.getBanChecker(); ban != nil {
+		checkers = append([]Checker{ban}, checkers...)
+	}
+
+	results := []CheckerTestResult{}
+	for _, checker := range checkers {
+		start := time.Now()
+		verdict, err := runChecker(checker, params)
+		result := CheckerTestResult{
+			Checker: checker.Name(),
+			DryRun:  derived.isDryRun(checker),
+			Latency: time.Since(start).Milliseconds(),
+		}
+
+		if err != nil {
+			result.Pass = !conf.ApiFailBlock
+			result.Error = err.Error()
+		} else {
+			result.Pass = verdict.Pass
+			result.Confidence = verdict.Confidence
+			result.Reason = verdict.Reason
+			result.Review = verdict.Review
 		}
+
+		results = append(results, result)
 	}
+
+	return results
 }
 
+// The remote API checkers which are skipped for trusted users
+var trustedBypassCheckers = []string{"akismet", "tencent", "aliyun", "reputation", "ai", "openai_moderation", "image"}
+
 // Checker trigger function
 func (as AntiSpam) checkerTrigger(checker Checker, params *CheckerParams) bool {
-	pass, err := checker.Check(params)
+	verdict := as.evaluate(checker, params)
+
+	if !verdict.Pass {
+		if verdict.Review && as.conf.OnPendingComment != nil {
+			as.conf.OnPendingComment(params.CommentID, verdict)
+		} else if as.conf.OnBlockComment != nil {
+			as.conf.OnBlockComment(params.CommentID, verdict)
+		}
+
+		log.Ctx(params.ctx()).Debug(LOG_TAG, fmt.Sprintf("[%s] Successful blocking of comments ID=%d CONT=%s REASON=%s",
+			checker.Name(), params.CommentID, strconv.Quote(params.Content), strconv.Quote(verdict.Reason)))
+	}
+
+	return verdict.Pass
+}
+
+// Whether the checker is in dry-run (shadow) mode, the verdict is recorded but never blocks
+func (as AntiSpam) isDryRun(checker Checker) bool {
+	return checker.Name() == "ai" && as.conf.AI.DryRun
+}
+
+// Dry-run trigger function, the verdict is only recorded in logs and comment metadata
+func (as AntiSpam) dryRunTrigger(checker Checker, params *CheckerParams) {
+	verdict := as.evaluate(checker, params)
+
+	log.Ctx(params.ctx()).Info(LOG_TAG, fmt.Sprintf("[%s] [DryRun] Comment ID=%d PASS=%t CONFIDENCE=%.2f REASON=%s",
+		checker.Name(), params.CommentID, verdict.Pass, verdict.Confidence, strconv.Quote(verdict.Reason)))
+
+	if as.conf.OnShadowVerdict != nil {
+		as.conf.OnShadowVerdict(params.CommentID, verdict)
+	}
+}
+
+// Execute the checker and get the verdict (the error of checker is handled by `ApiFailBlock` config)
+func (as AntiSpam) evaluate(checker Checker, params *CheckerParams) *CheckerVerdict {
+	ctx, span := tracing.Start(params.ctx(), "antispam.checker "+checker.Name(),
+		attribute.String("artalk.antispam.checker", checker.Name()))
+
+	start := time.Now()
+	verdict, err := runChecker(checker, params.withContext(ctx))
+	if as.conf.OnCheckerDone != nil {
+		as.conf.OnCheckerDone(checker.Name(), time.Since(start), err)
+	}
+	if err == nil {
+		span.SetAttributes(attribute.Bool("artalk.antispam.pass", verdict.Pass))
+	}
+	tracing.End(span, err)
 
 	if err != nil {
-		log.Error(LOG_TAG, fmt.Sprintf("%s checker comment=%d error:",
+		log.Ctx(params.ctx()).Error(LOG_TAG, fmt.Sprintf("%s checker comment=%d error:",
 			checker.Name(), params.CommentID), err)
 
-		pass = lo.If(as.conf.ApiFailBlock, false).Else(true) // block if api fail
+		verdict = &CheckerVerdict{
+			Pass:   lo.If(as.conf.ApiFailBlock, false).Else(true), // block if api fail
+			Reason: err.Error(),
+		}
 	}
 
-	if !pass {
-		if as.conf.OnBlockComment != nil {
-			as.conf.OnBlockComment(params.CommentID)
-		}
+	verdict.Checker = checker.Name()
+
+	return verdict
+}
 
-		log.Debug(LOG_TAG, fmt.Sprintf("[%s] Successful blocking of comments ID=%d CONT=%s",
-			checker.Name(), params.CommentID, strconv.Quote(params.Content)))
+// Run the checker and get the verdict
+//
+// If the checker implements `VerdictChecker`, the detailed verdict will be returned,
+// otherwise only the pass result is filled.
+func runChecker(checker Checker, params *CheckerParams) (*CheckerVerdict, error) {
+	if vc, ok := checker.(VerdictChecker); ok {
+		return vc.CheckVerdict(params)
 	}
 
-	return pass
+	pass, err := checker.Check(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckerVerdict{Pass: pass}, nil
+}
+
+// Get the outbound proxy of the service, falls back to the global proxy if empty
+func (as AntiSpam) proxy(serviceProxy string) string {
+	return cmp.Or(strings.TrimSpace(serviceProxy), as.conf.OutboundProxy)
+}
+
+// Find the matched ban rule of the commenter, nil if not banned
+func (as AntiSpam) MatchBan(siteName string, ip string, email string) *BanRule {
+	ban := as.getBanChecker()
+	if ban == nil {
+		return nil
+	}
+	return ban.Match(siteName, ip, email)
+}
+
+// Get the ban checker, nil if the loader of ban rules is not provided
+func (as AntiSpam) getBanChecker() *BanChecker {
+	if as.conf.LoadBans == nil {
+		return nil
+	}
+	return NewBanChecker(&BanCheckerConf{
+		LoadRules:     as.conf.LoadBans,
+		ASN:           getASNDatabase(as.conf.Ban.ASNDatabase),
+		LookupCountry: as.conf.LookupCountry,
+	}).(*BanChecker)
 }
 
 // Get enabled checkers by config
@@ -81,7 +352,7 @@ func (as AntiSpam) getEnabledCheckers() []Checker {
 	// Akismet
 	akismetKey := strings.TrimSpace(as.conf.AkismetKey)
 	if akismetKey != "" {
-		checkers = append(checkers, NewAkismetChecker(akismetKey))
+		checkers = append(checkers, as.withCache(NewAkismetChecker(akismetKey, as.proxy(as.conf.AkismetProxy))))
 	}
 
 	// Tencent Cloud
@@ -119,37 +390,225 @@ func (as AntiSpam) getEnabledCheckers() []Checker {
 					as.conf.OnUpdateComment(commentID, content)
 				}
 			},
+			Remote: as.remoteKeywords,
+		}))
+
+	}
+
+	// Flood Checker
+	floodConf := as.conf.Flood
+	if floodConf.Enabled {
+		checkers = append(checkers, NewFloodChecker(&FloodCheckerConf{
+			Window:             time.Duration(floodConf.Window) * time.Second,
+			MaxComments:        floodConf.MaxComments,
+			DuplicateWindow:    time.Duration(floodConf.DuplicateWindow) * time.Second,
+			Similarity:         floodConf.Similarity,
+			MinLength:          floodConf.MinLength,
+			Pending:            floodConf.Pending,
+			FindRecentComments: as.conf.FindRecentComments,
 		}))
+	}
 
+	// Links Checker
+	linksConf := as.conf.Links
+	if linksConf.Enabled {
+		checkers = append(checkers, NewLinksChecker(&LinksCheckerConf{
+			MaxLinks:        linksConf.MaxLinks,
+			BlockShorteners: linksConf.BlockShorteners,
+			Shorteners:      linksConf.Shorteners,
+			Blacklist:       linksConf.Blacklist,
+			Pending:         linksConf.Pending,
+		}))
 	}
 
-	// AI Checker (OpenAI compatible)
+	// Bayes Checker
+	bayesConf := as.conf.Bayes
+	if bayesConf.Enabled {
+		checkers = append(checkers, NewBayesChecker(&BayesCheckerConf{
+			Model:      as.bayesModel,
+			Threshold:  bayesConf.Threshold,
+			MinSamples: bayesConf.MinSamples,
+			Pending:    bayesConf.Pending,
+		}))
+	}
+
+	// Reputation Checker (StopForumSpam, AbuseIPDB)
+	reputationConf := as.conf.Reputation
+	if reputationConf.Enabled && (reputationConf.StopForumSpam || strings.TrimSpace(reputationConf.AbuseIPDBKey) != "") {
+		checkers = append(checkers, NewReputationChecker(&ReputationCheckerConf{
+			StopForumSpam: reputationConf.StopForumSpam,
+			AbuseIPDBKey:  strings.TrimSpace(reputationConf.AbuseIPDBKey),
+			Threshold:     reputationConf.Threshold,
+			Cache:         as.reputationCache,
+			CacheTTL:      time.Duration(reputationConf.CacheTTL) * time.Second,
+		}))
+	}
+
+	// AI Checker (OpenAI, Anthropic, Gemini, Ollama)
 	aiConf := as.conf.AI
-	if aiConf.Enabled && strings.TrimSpace(aiConf.ApiKey) != "" && strings.TrimSpace(aiConf.Model) != "" {
-		checkers = append(checkers, NewAIChecker(aiConf.ApiKey, aiConf.Model, aiConf.Host))
+	aiProvider := AIProvider(strings.TrimSpace(aiConf.Provider))
+	aiKeyRequired := aiProvider != AIProviderOllama // local Ollama does not require API key
+	if aiConf.Enabled && (!aiKeyRequired || strings.TrimSpace(aiConf.ApiKey) != "") && strings.TrimSpace(aiConf.Model) != "" {
+		checkers = append(checkers, as.withCache(NewAIChecker(&AICheckerConf{
+			Provider:       aiProvider,
+			ApiKey:         aiConf.ApiKey,
+			Model:          aiConf.Model,
+			Host:           aiConf.Host,
+			Proxy:          as.proxy(aiConf.Proxy),
+			Path:           aiConf.Path,
+			Query:          aiConf.Query,
+			Headers:        aiConf.Headers,
+			MaxTokens:      aiConf.MaxTokens,
+			Temperature:    aiConf.Temperature,
+			ResponseFormat: AIResponseFormat(strings.TrimSpace(aiConf.ResponseFormat)),
+			PromptTemplate: aiConf.PromptTemplate,
+			Limiter:        as.aiLimiter,
+			Fallback:       AIFallback(aiConf.Limit.Fallback),
+			FallbackChecker: NewKeywordsChecker(&KeywordsCheckerConf{
+				Files:   as.conf.Keywords.Files,
+				FileSep: as.conf.Keywords.FileSep,
+				Mode:    KwCheckerModeBlock,
+				Remote:  as.remoteKeywords,
+			}),
+			MaxRetries: aiConf.Retry.MaxRetries,
+			Backoff:    time.Duration(aiConf.Retry.Backoff) * time.Millisecond,
+			Breaker:    as.aiBreaker,
+
+			UnclearDecision: AIDecision(aiConf.UnclearDecision),
+			ErrorDecision:   AIDecision(aiConf.ErrorDecision),
+
+			LanguagePolicies: lo.MapValues(aiConf.Languages.Policies, func(p config.AIAntispamLanguagePolicy, _ string) AILanguagePolicy {
+				return AILanguagePolicy{PromptTemplate: p.PromptTemplate, Threshold: p.Threshold}
+			}),
+			LanguageAllowlist: aiConf.Languages.Allowlist,
+			HoldUnlisted:      aiConf.Languages.HoldUnlisted,
+		})))
+	}
+
+	// Image Checker (OpenAI vision, AWS Rekognition, NSFW model endpoint)
+	imageConf := as.conf.Image
+	if imageConf.Enabled {
+		checkers = append(checkers, as.withCache(NewImageChecker(&ImageCheckerConf{
+			Provider:        ImageProvider(strings.TrimSpace(imageConf.Provider)),
+			ApiKey:          imageConf.ApiKey,
+			Model:           imageConf.Model,
+			Host:            imageConf.Host,
+			Proxy:           as.proxy(""),
+			AccessKeyID:     imageConf.AccessKeyID,
+			AccessKeySecret: imageConf.AccessKeySecret,
+			Region:          imageConf.Region,
+			Threshold:       imageConf.Threshold,
+			Categories:      imageConf.Categories,
+			MaxImages:       imageConf.MaxImages,
+			MaxSize:         imageConf.MaxSize * 1024 * 1024,
+			Pending:         imageConf.Pending,
+			Loader:          as.conf.LoadImage,
+		})))
+	}
+
+	// OpenAI Moderation API
+	moderationConf := as.conf.OpenAIModeration
+	if moderationConf.Enabled && strings.TrimSpace(moderationConf.ApiKey) != "" {
+		checkers = append(checkers, as.withCache(NewOpenAIModerationChecker(&OpenAIModerationCheckerConf{
+			ApiKey:     moderationConf.ApiKey,
+			Model:      moderationConf.Model,
+			Host:       moderationConf.Host,
+			Proxy:      as.proxy(""),
+			Thresholds: moderationConf.Thresholds,
+		})))
 	}
 
 	return checkers
 }
 
+// Wrap the checker with verdict cache if it is enabled
+func (as AntiSpam) withCache(checker Checker) Checker {
+	if !as.conf.Cache.Enabled || as.conf.VerdictCache == nil {
+		return checker
+	}
+
+	return newCachedChecker(checker, as.conf.VerdictCache, as.cacheCounter)
+}
+
 // -------------------------------------------------------------------
 //  Checker
 // -------------------------------------------------------------------
 
 type CheckerParams struct {
-	BlogURL string
+	BlogURL   string
+	SiteName  string
+	PageURL   string
+	PageTitle string
 
 	Content   string
 	CommentID uint
 
+	// The parent comment if it is a reply
+	ParentContent string
+	ParentAuthor  string
+
+	// The recent comments content of the user (newest first)
+	RecentComments []string
+
+	// The samples decided by the moderator (used as few-shot examples by AI checker)
+	Examples []SpamExample
+
 	UserName  string
 	UserEmail string
 	UserID    uint
 	UserIP    string
 	UserAgent string
+
+	// The user is trusted (e.g. admin, regular user or in the allowlist),
+	// the remote API checkers will be skipped.
+	IsTrusted bool
+
+	// The context of the API requests (optional, e.g. the span of the comment request for tracing)
+	Context context.Context
+}
+
+func (p *CheckerParams) ctx() context.Context {
+	if p.Context == nil {
+		return context.Background()
+	}
+	return p.Context
+}
+
+// Copy the params with the context, since the params may be shared by the checkers running concurrently
+func (p *CheckerParams) withContext(ctx context.Context) *CheckerParams {
+	derived := *p
+	derived.Context = ctx
+	return &derived
 }
 
 type Checker interface {
 	Name() string
 	Check(p *CheckerParams) (bool, error)
 }
+
+// The checker which can explain its verdict with the confidence and reason
+type VerdictChecker interface {
+	Checker
+	CheckVerdict(p *CheckerParams) (*CheckerVerdict, error)
+}
+
+type CheckerVerdict struct {
+	Checker    string  // The name of checker (filled by AntiSpam)
+	Pass       bool    // Whether the comment is passed
+	Confidence float64 // The confidence of verdict (range 0~1, zero means unknown)
+	Reason     string  // The reason of verdict
+	Review     bool    // Hold the comment for manual review instead of blocking (only if not passed)
+
+	fallback bool // The verdict is not decided by the checker itself (e.g. the limit is exhausted)
+}
+
+// Get the spam score of verdict (range 0~1, higher is more likely to be spam)
+//
+// The confidence is used if it is known, otherwise the score is 0 for pass and 1 for block.
+func (v *CheckerVerdict) SpamScore() float64 {
+	if v.Confidence <= 0 {
+		return lo.If(v.Pass, 0.0).Else(1.0)
+	}
+
+	return lo.If(v.Pass, 1-v.Confidence).Else(v.Confidence)
+}
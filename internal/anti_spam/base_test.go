@@ -69,8 +69,9 @@ func TestAntiSpam(t *testing.T) {
 			conf := getAntiSpamConf()
 			conf.ModeratorConf.Keywords.Pending = true
 
-			conf.OnBlockComment = func(commentID uint) {
+			conf.OnBlockComment = func(commentID uint, verdict *CheckerVerdict) {
 				blockedID = int(commentID)
+				assert.Equal(t, "keywords", verdict.Checker)
 			}
 			conf.OnUpdateComment = func(commentID uint, content string) {
 				updatedID = int(commentID)
@@ -95,7 +96,7 @@ func TestAntiSpam(t *testing.T) {
 			conf := getAntiSpamConf()
 			conf.ModeratorConf.Keywords.Pending = false
 
-			conf.OnBlockComment = func(commentID uint) {
+			conf.OnBlockComment = func(commentID uint, verdict *CheckerVerdict) {
 				blockedID = int(commentID)
 			}
 			conf.OnUpdateComment = func(commentID uint, content string) {
@@ -143,6 +144,125 @@ func TestAntiSpam(t *testing.T) {
 			assert.True(t, pass, "should not be blocked when api fail")
 		})
 	})
+
+	t.Run("Trusted User Bypass", func(t *testing.T) {
+		kwFile := fmt.Sprintf("%s/keywords.txt", t.TempDir())
+		_ = os.WriteFile(kwFile, []byte("关键词A"), 0644)
+
+		var blocked uint
+		antiSpam := NewAntiSpam(&AntiSpamConf{
+			ModeratorConf: config.ModeratorConf{
+				ApiFailBlock: true,
+				AI:           config.AIAntispamConf{Enabled: true, ApiKey: "test", Model: "test", Host: "http://127.0.0.1:1"},
+				Keywords:     config.KeyWordsAntispamConf{Enabled: true, Pending: true, Files: []string{kwFile}, FileSep: "\n"},
+			},
+			OnBlockComment: func(commentID uint, verdict *CheckerVerdict) { blocked = commentID },
+		})
+
+		assert.True(t, antiSpam.CheckAndBlock(&CheckerParams{CommentID: 1000, Content: "Hello", IsTrusted: true}), "should skip the AI checker")
+		assert.False(t, antiSpam.CheckAndBlock(&CheckerParams{CommentID: 1001, Content: "关键词A", IsTrusted: true}), "should still run the keywords checker")
+		assert.Equal(t, uint(1001), blocked)
+		assert.False(t, antiSpam.CheckAndBlock(&CheckerParams{CommentID: 1002, Content: "Hello"}), "should block by API fail if not trusted")
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		var blocked, shadow uint
+		var shadowVerdict *CheckerVerdict
+		newAntiSpam := func(scoring bool) *AntiSpam {
+			return NewAntiSpam(&AntiSpamConf{
+				ModeratorConf: config.ModeratorConf{
+					AI:      config.AIAntispamConf{DryRun: true},
+					Scoring: config.ScoringAntispamConf{Enabled: scoring},
+				},
+				OnBlockComment: func(commentID uint, verdict *CheckerVerdict) { blocked = commentID },
+				OnShadowVerdict: func(commentID uint, verdict *CheckerVerdict) {
+					shadow = commentID
+					shadowVerdict = verdict
+				},
+			})
+		}
+		checker := &mockScoreChecker{name: "ai", pass: false, confidence: 0.9}
+
+		antiSpam := newAntiSpam(false)
+		assert.True(t, antiSpam.isDryRun(checker))
+		assert.False(t, antiSpam.isDryRun(&mockScoreChecker{name: "akismet"}))
+
+		antiSpam.dryRunTrigger(checker, &CheckerParams{CommentID: 1000})
+		assert.Equal(t, uint(1000), shadow)
+		assert.Equal(t, "ai", shadowVerdict.Checker)
+		assert.False(t, shadowVerdict.Pass)
+		assert.Equal(t, uint(0), blocked, "should never block")
+
+		result := newAntiSpam(true).score([]Checker{checker}, &CheckerParams{CommentID: 1001})
+		assert.Equal(t, ScoringPass, result.Decision, "should not be counted in scoring")
+		assert.Equal(t, uint(1001), shadow)
+	})
+
+	t.Run("WithModeratorConf", func(t *testing.T) {
+		var blocked uint
+		antiSpam := NewAntiSpam(&AntiSpamConf{
+			ModeratorConf: config.ModeratorConf{
+				AI: config.AIAntispamConf{Limit: config.AIAntispamLimitConf{PerMinute: 10}},
+			},
+			OnBlockComment: func(commentID uint, verdict *CheckerVerdict) { blocked = commentID },
+		})
+
+		derived := antiSpam.WithModeratorConf(config.ModeratorConf{AkismetKey: "site_key"})
+		assert.Equal(t, "site_key", derived.conf.AkismetKey)
+		assert.Empty(t, antiSpam.conf.AkismetKey, "should not modify the original config")
+		assert.Same(t, antiSpam.aiLimiter, derived.aiLimiter, "should share the limiter")
+
+		derived.checkerTrigger(&mockScoreChecker{name: "test", pass: false}, &CheckerParams{CommentID: 1000})
+		assert.Equal(t, uint(1000), blocked, "should share the callbacks")
+	})
+
+	t.Run("Proxy", func(t *testing.T) {
+		antiSpam := NewAntiSpam(&AntiSpamConf{OutboundProxy: "socks5://127.0.0.1:1080"})
+		assert.Equal(t, "socks5://127.0.0.1:1080", antiSpam.proxy(""), "should fall back to the global proxy")
+		assert.Equal(t, "http://127.0.0.1:7890", antiSpam.proxy(" http://127.0.0.1:7890 "), "should take the service proxy")
+		assert.Equal(t, "direct", antiSpam.proxy("direct"))
+		assert.Equal(t, "socks5://127.0.0.1:1080", antiSpam.WithModeratorConf(config.ModeratorConf{}).proxy(""), "should keep the global proxy")
+	})
+
+	t.Run("Review Verdict", func(t *testing.T) {
+		var blocked, pending uint
+		antiSpam := NewAntiSpam(&AntiSpamConf{
+			OnBlockComment:   func(commentID uint, verdict *CheckerVerdict) { blocked = commentID },
+			OnPendingComment: func(commentID uint, verdict *CheckerVerdict) { pending = commentID },
+		})
+
+		pass := antiSpam.checkerTrigger(&mockScoreChecker{name: "ai", pass: false, review: true}, &CheckerParams{CommentID: 1000})
+		assert.False(t, pass)
+		assert.Equal(t, uint(1000), pending, "should send to pending review")
+		assert.Equal(t, uint(0), blocked)
+	})
+
+	t.Run("Test", func(t *testing.T) {
+		kwFile := fmt.Sprintf("%s/keywords.txt", t.TempDir())
+		_ = os.WriteFile(kwFile, []byte("关键词A"), 0644)
+
+		var updated bool
+		antiSpam := NewAntiSpam(&AntiSpamConf{
+			ModeratorConf: config.ModeratorConf{
+				ApiFailBlock: true,
+				Keywords:     config.KeyWordsAntispamConf{Enabled: true, Files: []string{kwFile}, FileSep: "\n", ReplaceTo: "*"},
+				Links:        config.LinksAntispamConf{Enabled: true, MaxLinks: 1},
+				AI:           config.AIAntispamConf{Enabled: true, DryRun: true, Provider: "ollama", Model: "test", Host: "http://127.0.0.1:1"},
+			},
+			OnUpdateComment: func(commentID uint, content string) { updated = true },
+		})
+
+		results := antiSpam.Test(&CheckerParams{Content: "关键词A https://a.example.com https://b.example.com"})
+		assert.Equal(t, []string{"keywords", "links", "ai"}, lo.Map(results, func(r CheckerTestResult, _ int) string { return r.Checker }))
+		assert.False(t, updated, "should not update the comment")
+
+		assert.True(t, results[0].Pass)
+		assert.False(t, results[1].Pass)
+		assert.Contains(t, results[1].Reason, "too many links")
+		assert.True(t, results[2].DryRun)
+		assert.NotEmpty(t, results[2].Error)
+		assert.False(t, results[2].Pass, "should follow the api_fail_block")
+	})
 }
 
 // -------------------------------------------------------------------
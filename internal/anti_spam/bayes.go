@@ -0,0 +1,276 @@
+package anti_spam
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/store"
+)
+
+var _ VerdictChecker = (*BayesChecker)(nil)
+var _ Reporter = (*BayesChecker)(nil)
+
+// The offline Naive Bayes checker trained by the samples decided by the moderator
+type BayesChecker struct {
+	conf *BayesCheckerConf
+}
+
+type BayesCheckerConf struct {
+	Model      *BayesModel // The trained model (shared by checkers)
+	Threshold  float64     // The spam probability threshold to block (range 0~1, default is 0.9)
+	MinSamples int         // The minimum samples of both spam and ham to take effect (default is 10)
+	Pending    bool        // Hold the comment for manual review instead of blocking
+}
+
+const (
+	defaultBayesThreshold  = 0.9
+	defaultBayesMinSamples = 10
+)
+
+func NewBayesChecker(conf *BayesCheckerConf) Checker {
+	if conf.Threshold <= 0 || conf.Threshold > 1 {
+		conf.Threshold = defaultBayesThreshold
+	}
+	if conf.MinSamples <= 0 {
+		conf.MinSamples = defaultBayesMinSamples
+	}
+
+	return &BayesChecker{
+		conf: conf,
+	}
+}
+
+func (*BayesChecker) Name() string {
+	return "bayes"
+}
+
+func (c *BayesChecker) Check(p *CheckerParams) (bool, error) {
+	verdict, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return verdict.Pass, nil
+}
+
+func (c *BayesChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	spamDocs, hamDocs := c.conf.Model.Samples()
+	if spamDocs < c.conf.MinSamples || hamDocs < c.conf.MinSamples {
+		return &CheckerVerdict{Pass: true, Reason: fmt.Sprintf("not enough samples (spam: %d, ham: %d)", spamDocs, hamDocs), fallback: true}, nil
+	}
+
+	prob := c.conf.Model.SpamProbability(p.Content)
+	if prob >= c.conf.Threshold {
+		return &CheckerVerdict{Pass: false, Review: c.conf.Pending, Confidence: prob,
+			Reason: fmt.Sprintf("spam probability %.2f", prob)}, nil
+	}
+
+	return &CheckerVerdict{Pass: true, Confidence: 1 - prob, Reason: fmt.Sprintf("spam probability %.2f", prob)}, nil
+}
+
+// The model will be reloaded from the samples (by all instances in cluster mode),
+// the decision should be kept in the sample store before reporting.
+func (c *BayesChecker) Report(p *CheckerParams, isSpam bool) error {
+	c.conf.Model.Invalidate()
+	return nil
+}
+
+// -------------------------------------------------------------------
+//  Bayes Model
+// -------------------------------------------------------------------
+
+// The key of model generation in the store, which is increased when the model is invalidated
+const bayesGenerationKey = "anti_spam:bayes:generation"
+
+// The multinomial Naive Bayes model of spam and ham tokens
+//
+// The model is trained lazily by the samples from loader,
+// and reloaded after it is invalidated (e.g. a new decision is made).
+//
+// The generation of model is kept in the store (shared by the instances of cluster mode),
+// so the model invalidated by any instance is reloaded by all instances at next use.
+type BayesModel struct {
+	loader func() []SpamExample
+	store  store.Store // optional, the model is only invalidated locally if nil
+
+	mu         sync.RWMutex
+	loaded     bool
+	generation string // the generation of loaded model
+	spam     map[string]int // token counts of spam samples
+	ham      map[string]int // token counts of ham samples
+	spamToks int            // total token count of spam samples
+	hamToks  int            // total token count of ham samples
+	spamDocs int
+	hamDocs  int
+}
+
+func NewBayesModel(loader func() []SpamExample, s store.Store) *BayesModel {
+	return &BayesModel{
+		loader: loader,
+		store:  s,
+		spam:   map[string]int{},
+		ham:    map[string]int{},
+	}
+}
+
+// Train the model by the sample
+func (m *BayesModel) Train(content string, isSpam bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.train(content, isSpam)
+}
+
+func (m *BayesModel) train(content string, isSpam bool) {
+	tokens := tokenizeBayes(content)
+	if isSpam {
+		m.spamDocs++
+		m.spamToks += len(tokens)
+		for _, t := range tokens {
+			m.spam[t]++
+		}
+	} else {
+		m.hamDocs++
+		m.hamToks += len(tokens)
+		for _, t := range tokens {
+			m.ham[t]++
+		}
+	}
+}
+
+// Discard the trained data of all instances, the samples will be reloaded at next use
+func (m *BayesModel) Invalidate() {
+	if m.store != nil {
+		if _, err := m.store.Incr(bayesGenerationKey, 1, 0); err != nil {
+			log.Error("[Bayes] Failed to increase the model generation: ", err)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.loaded = false
+}
+
+// Get the current generation of model in the store
+func (m *BayesModel) currentGeneration() string {
+	if m.store == nil {
+		return ""
+	}
+	generation, _ := m.store.Get(bayesGenerationKey)
+	return generation
+}
+
+// Load the samples from loader if not loaded or the model is invalidated by other instances
+func (m *BayesModel) ensureLoaded() {
+	if m.loader == nil {
+		return
+	}
+
+	// the generation is got before loading, so the invalidation during loading triggers the reload at next use
+	generation := m.currentGeneration()
+
+	m.mu.RLock()
+	loaded := m.loaded && m.generation == generation
+	m.mu.RUnlock()
+	if loaded {
+		return
+	}
+
+	samples := m.loader()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.spam, m.ham = map[string]int{}, map[string]int{}
+	m.spamToks, m.hamToks, m.spamDocs, m.hamDocs = 0, 0, 0, 0
+	for _, s := range samples {
+		m.train(s.Content, s.IsSpam)
+	}
+	m.loaded = true
+	m.generation = generation
+}
+
+// Get the number of spam and ham samples
+func (m *BayesModel) Samples() (spam int, ham int) {
+	m.ensureLoaded()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.spamDocs, m.hamDocs
+}
+
+// Get the probability of content being spam (range 0~1)
+func (m *BayesModel) SpamProbability(content string) float64 {
+	m.ensureLoaded()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.spamDocs == 0 || m.hamDocs == 0 {
+		return 0.5
+	}
+
+	// the vocabulary size for Laplace smoothing
+	vocab := len(m.spam)
+	for t := range m.ham {
+		if _, ok := m.spam[t]; !ok {
+			vocab++
+		}
+	}
+
+	total := float64(m.spamDocs + m.hamDocs)
+	logSpam := math.Log(float64(m.spamDocs) / total)
+	logHam := math.Log(float64(m.hamDocs) / total)
+
+	for _, t := range tokenizeBayes(content) {
+		logSpam += math.Log(float64(m.spam[t]+1) / float64(m.spamToks+vocab))
+		logHam += math.Log(float64(m.ham[t]+1) / float64(m.hamToks+vocab))
+	}
+
+	return 1 / (1 + math.Exp(logHam-logSpam))
+}
+
+var bayesWordRegexp = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+
+// Split the content into lowercase tokens,
+// the CJK text is split into bigrams since there are no spaces between words.
+func tokenizeBayes(content string) []string {
+	tokens := []string{}
+
+	for _, word := range bayesWordRegexp.FindAllString(strings.ToLower(content), -1) {
+		// split the word by CJK and non-CJK runs
+		var run []rune
+		var isCJK bool
+		flush := func() {
+			if len(run) == 0 {
+				return
+			}
+			if isCJK && len(run) > 1 {
+				for i := 0; i < len(run)-1; i++ {
+					tokens = append(tokens, string(run[i:i+2]))
+				}
+			} else {
+				tokens = append(tokens, string(run))
+			}
+			run = nil
+		}
+
+		for _, r := range word {
+			cjk := unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+			if len(run) > 0 && cjk != isCJK {
+				flush()
+			}
+			isCJK = cjk
+			run = append(run, r)
+		}
+		flush()
+	}
+
+	return tokens
+}
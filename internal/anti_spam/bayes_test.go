@@ -0,0 +1,114 @@
+package anti_spam
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBayesChecker(t *testing.T) {
+	samples := []SpamExample{
+		{Content: "Buy cheap watches now, free shipping", IsSpam: true},
+		{Content: "Cheap pills online, buy now", IsSpam: true},
+		{Content: "Free casino bonus, click to win money", IsSpam: true},
+		{Content: "出售发票，代开发票，联系微信", IsSpam: true},
+		{Content: "Great post, thanks for sharing", IsSpam: false},
+		{Content: "I have a question about the config file", IsSpam: false},
+		{Content: "Thanks, this article helps me a lot", IsSpam: false},
+		{Content: "写得很好，感谢分享", IsSpam: false},
+	}
+
+	var loads int
+	model := NewBayesModel(func() []SpamExample {
+		loads++
+		return samples
+	}, nil)
+
+	checker := NewBayesChecker(&BayesCheckerConf{Model: model, MinSamples: 4}).(*BayesChecker)
+	assert.Equal(t, "bayes", checker.Name())
+	assert.Equal(t, defaultBayesThreshold, checker.conf.Threshold)
+
+	t.Run("Spam", func(t *testing.T) {
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: "buy cheap pills now"})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass)
+		assert.Greater(t, verdict.Confidence, 0.9)
+
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: "代开发票"})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass, "should detect CJK text by bigrams")
+	})
+
+	t.Run("Ham", func(t *testing.T) {
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: "Thanks for sharing the article"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass)
+
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: "感谢分享"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass)
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		checker := NewBayesChecker(&BayesCheckerConf{Model: model, MinSamples: 4, Pending: true})
+		verdict, err := checker.(VerdictChecker).CheckVerdict(&CheckerParams{Content: "buy cheap pills now"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Review)
+	})
+
+	t.Run("NotEnoughSamples", func(t *testing.T) {
+		checker := NewBayesChecker(&BayesCheckerConf{Model: model, MinSamples: 5})
+		pass, err := checker.Check(&CheckerParams{Content: "buy cheap pills now"})
+		assert.NoError(t, err)
+		assert.True(t, pass, "should pass if not enough samples")
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		assert.Equal(t, 1, loads, "should load samples once")
+
+		samples = append(samples, SpamExample{Content: "Nice question about the config", IsSpam: true})
+		assert.NoError(t, checker.Report(&CheckerParams{}, true))
+
+		spam, ham := model.Samples()
+		assert.Equal(t, 2, loads, "should reload samples after reported")
+		assert.Equal(t, 5, spam)
+		assert.Equal(t, 4, ham)
+	})
+}
+
+func TestBayesModelCluster(t *testing.T) {
+	samples := []SpamExample{{Content: "buy cheap pills", IsSpam: true}}
+	loads := map[string]int{}
+	shared := store.NewMemoryStore()
+	newModel := func(instance string) *BayesModel {
+		return NewBayesModel(func() []SpamExample {
+			loads[instance]++
+			return samples
+		}, shared)
+	}
+	a, b := newModel("a"), newModel("b")
+
+	spam, _ := b.Samples()
+	assert.Equal(t, 1, spam)
+	a.Samples()
+
+	samples = append(samples, SpamExample{Content: "free casino bonus", IsSpam: true})
+	a.Invalidate()
+
+	spam, _ = b.Samples()
+	assert.Equal(t, 2, spam, "should reload the model invalidated by other instances")
+	assert.Equal(t, 2, loads["b"])
+
+	a.Samples()
+	b.Samples()
+	assert.Equal(t, 2, loads["a"], "should not reload if the model is not invalidated again")
+	assert.Equal(t, 2, loads["b"])
+}
+
+func TestTokenizeBayes(t *testing.T) {
+	assert.Equal(t, []string{"hello", "world", "42"}, tokenizeBayes("Hello, World! 42"))
+	assert.Equal(t, []string{"感谢", "谢分", "分享", "artalk"}, tokenizeBayes("感谢分享Artalk"))
+	assert.Equal(t, []string{"好"}, tokenizeBayes("好！"))
+	assert.Empty(t, tokenizeBayes(" ... "))
+}
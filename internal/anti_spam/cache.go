@@ -0,0 +1,103 @@
+package anti_spam
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+// The storage of checker verdicts, which is implemented by the caller (e.g. the cache layer)
+type VerdictCache interface {
+	Get(key string) (*CheckerVerdict, bool)
+	Set(key string, verdict *CheckerVerdict)
+}
+
+// The hit rate metrics of verdict cache
+type VerdictCacheStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// Get the hit rate (range 0~1)
+func (s VerdictCacheStats) HitRate() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+type verdictCacheCounter struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func (c *verdictCacheCounter) Stats() VerdictCacheStats {
+	return VerdictCacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+}
+
+var _ VerdictChecker = (*cachedChecker)(nil)
+
+// The checker wrapper which caches the verdicts by the hash of comment content,
+// so the identical content can be decided without another API call.
+type cachedChecker struct {
+	checker Checker
+	cache   VerdictCache
+	counter *verdictCacheCounter
+}
+
+func newCachedChecker(checker Checker, cache VerdictCache, counter *verdictCacheCounter) Checker {
+	return &cachedChecker{
+		checker: checker,
+		cache:   cache,
+		counter: counter,
+	}
+}
+
+func (c *cachedChecker) Name() string {
+	return c.checker.Name()
+}
+
+func (c *cachedChecker) Check(p *CheckerParams) (bool, error) {
+	verdict, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return verdict.Pass, nil
+}
+
+func (c *cachedChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	key := getVerdictCacheKey(c.checker.Name(), p.Content)
+
+	if verdict, ok := c.cache.Get(key); ok && verdict != nil {
+		c.counter.hits.Add(1)
+		log.Ctx(p.ctx()).Debug(LOG_TAG, fmt.Sprintf("[%s] Verdict cache hit for comment ID=%d", c.checker.Name(), p.CommentID))
+
+		cached := *verdict
+		return &cached, nil
+	}
+	c.counter.misses.Add(1)
+
+	verdict, err := runChecker(c.checker, p)
+	if err != nil {
+		return nil, err // the error is not cached
+	}
+
+	// the fallback verdict (e.g. the limit is exhausted) is not the real decision, so it is not cached
+	if !verdict.fallback {
+		c.cache.Set(key, verdict)
+	}
+
+	return verdict, nil
+}
+
+func getVerdictCacheKey(checkerName string, content string) string {
+	hash := sha256.Sum256([]byte(strings.TrimSpace(content)))
+	return fmt.Sprintf("anti_spam#%s#%s", checkerName, hex.EncodeToString(hash[:]))
+}
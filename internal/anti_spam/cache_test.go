@@ -0,0 +1,85 @@
+package anti_spam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedChecker(t *testing.T) {
+	cache := &mockVerdictCache{data: map[string]*CheckerVerdict{}}
+	counter := &verdictCacheCounter{}
+
+	inner := &mockCountChecker{verdict: &CheckerVerdict{Pass: false, Confidence: 0.9, Reason: "spam"}}
+	checker := newCachedChecker(inner, cache, counter).(*cachedChecker)
+	assert.Equal(t, "count", checker.Name())
+
+	for i := 0; i < 3; i++ {
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: "Buy now"})
+		assert.NoError(t, err)
+		assert.Equal(t, &CheckerVerdict{Pass: false, Confidence: 0.9, Reason: "spam"}, verdict)
+	}
+	assert.Equal(t, 1, inner.calls, "should call the checker only once for identical content")
+
+	_, _ = checker.CheckVerdict(&CheckerParams{Content: "Hello"})
+	assert.Equal(t, 2, inner.calls)
+
+	stats := counter.Stats()
+	assert.Equal(t, VerdictCacheStats{Hits: 2, Misses: 2}, stats)
+	assert.Equal(t, 0.5, stats.HitRate())
+	assert.Equal(t, 0.0, VerdictCacheStats{}.HitRate())
+
+	t.Run("FallbackNotCached", func(t *testing.T) {
+		inner := &mockCountChecker{verdict: &CheckerVerdict{Pass: true, fallback: true}}
+		checker := newCachedChecker(inner, cache, counter)
+
+		_, _ = checker.Check(&CheckerParams{Content: "Fallback"})
+		_, _ = checker.Check(&CheckerParams{Content: "Fallback"})
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		as := NewAntiSpam(&AntiSpamConf{VerdictCache: cache})
+		as.conf.AkismetKey = "test"
+		assert.IsType(t, &AkismetChecker{}, as.getEnabledCheckers()[0])
+
+		as.conf.Cache.Enabled = true
+		assert.IsType(t, &cachedChecker{}, as.getEnabledCheckers()[0])
+	})
+}
+
+type mockVerdictCache struct {
+	data map[string]*CheckerVerdict
+}
+
+func (c *mockVerdictCache) Get(key string) (*CheckerVerdict, bool) {
+	v, ok := c.data[key]
+	return v, ok
+}
+
+func (c *mockVerdictCache) Set(key string, verdict *CheckerVerdict) {
+	c.data[key] = verdict
+}
+
+type mockCountChecker struct {
+	verdict *CheckerVerdict
+	calls   int
+}
+
+func (c *mockCountChecker) Name() string {
+	return "count"
+}
+
+func (c *mockCountChecker) Check(p *CheckerParams) (bool, error) {
+	v, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return v.Pass, nil
+}
+
+func (c *mockCountChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	c.calls++
+	v := *c.verdict
+	return &v, nil
+}
@@ -0,0 +1,62 @@
+package anti_spam
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+// The checker which can learn from the spam or ham decision of moderator
+type Reporter interface {
+	Name() string
+	Report(p *CheckerParams, isSpam bool) error
+}
+
+// The spam or ham sample decided by the moderator
+type SpamExample struct {
+	Content string
+	IsSpam  bool
+}
+
+// Feed the moderator decision back to the enabled checkers which support reporting,
+// returns the errors of failed reporters.
+func (as AntiSpam) Feedback(params *CheckerParams, isSpam bool) []error {
+	errs := []error{}
+
+	for _, reporter := range as.getEnabledReporters() {
+		if err := reporter.Report(params, isSpam); err != nil {
+			log.Error(LOG_TAG, fmt.Sprintf("[%s] Feedback comment ID=%d error: %s", reporter.Name(), params.CommentID, err))
+			errs = append(errs, fmt.Errorf("%s: %w", reporter.Name(), err))
+			continue
+		}
+
+		log.Debug(LOG_TAG, fmt.Sprintf("[%s] Feedback comment ID=%d as %s", reporter.Name(), params.CommentID, spamLabel(isSpam)))
+	}
+
+	return errs
+}
+
+// Get the enabled checkers which support reporting
+func (as AntiSpam) getEnabledReporters() []Reporter {
+	reporters := []Reporter{}
+
+	// Akismet
+	if akismetKey := strings.TrimSpace(as.conf.AkismetKey); as.conf.Feedback.Enabled && akismetKey != "" {
+		reporters = append(reporters, NewAkismetChecker(akismetKey, as.proxy(as.conf.AkismetProxy)).(Reporter))
+	}
+
+	// Bayes
+	if as.conf.Bayes.Enabled {
+		reporters = append(reporters, NewBayesChecker(&BayesCheckerConf{Model: as.bayesModel}).(Reporter))
+	}
+
+	return reporters
+}
+
+func spamLabel(isSpam bool) string {
+	if isSpam {
+		return "spam"
+	}
+	return "ham"
+}
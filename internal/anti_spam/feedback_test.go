@@ -0,0 +1,55 @@
+package anti_spam
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAkismetReport(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "Hello World", r.PostForm.Get("comment_content"))
+
+		if r.PostForm.Get("user_ip") == "" {
+			_, _ = w.Write([]byte("Missing required field: user_ip."))
+			return
+		}
+		_, _ = w.Write([]byte("Thanks for making the web a better place."))
+	}))
+	defer server.Close()
+
+	checker := NewAkismetChecker("test_key", "").(*AkismetChecker)
+	checker.baseURL = server.URL
+
+	params := &CheckerParams{Content: "Hello World", UserIP: "127.0.0.1"}
+	assert.NoError(t, checker.Report(params, true))
+	assert.NoError(t, checker.Report(params, false))
+	assert.Equal(t, []string{"/1.1/submit-spam", "/1.1/submit-ham"}, paths)
+
+	err := checker.Report(&CheckerParams{Content: "Hello World"}, true)
+	assert.ErrorContains(t, err, "Missing required field")
+}
+
+func TestFeedback(t *testing.T) {
+	as := NewAntiSpam(&AntiSpamConf{})
+	assert.Empty(t, as.getEnabledReporters())
+	assert.Empty(t, as.Feedback(&CheckerParams{}, true))
+
+	as = NewAntiSpam(&AntiSpamConf{ModeratorConf: config.ModeratorConf{AkismetKey: "test_key"}})
+	assert.Empty(t, as.getEnabledReporters(), "should not report to akismet if feedback is disabled")
+
+	as = NewAntiSpam(&AntiSpamConf{ModeratorConf: config.ModeratorConf{
+		AkismetKey: "test_key",
+		Feedback:   config.ModeratorFeedbackConf{Enabled: true},
+		Bayes:      config.BayesAntispamConf{Enabled: true},
+	}})
+	reporters := as.getEnabledReporters()
+	assert.Equal(t, []string{"akismet", "bayes"}, lo.Map(reporters, func(r Reporter, _ int) string { return r.Name() }))
+}
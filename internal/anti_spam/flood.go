@@ -0,0 +1,162 @@
+package anti_spam
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var _ VerdictChecker = (*FloodChecker)(nil)
+
+// The built-in checker of near-duplicate content and rapid-fire posting
+type FloodChecker struct {
+	conf *FloodCheckerConf
+	now  func() time.Time
+}
+
+type FloodCheckerConf struct {
+	Window          time.Duration // The time window of flood detection (default is 1 minute)
+	MaxComments     int           // Max number of comments from the same IP or user in the window (0 for unlimited)
+	DuplicateWindow time.Duration // The time window of duplicate detection (0 for disabled)
+	Similarity      float64       // The similarity to be considered as duplicate (range 0~1, default is 0.9)
+	MinLength       int           // The min content length for duplicate detection (default is 10)
+	Pending         bool          // Hold the comment for manual review instead of blocking
+
+	// Find the recent comments created after the given time (excluding the checking comment)
+	FindRecentComments func(p *CheckerParams, since time.Time) []RecentComment
+}
+
+// The recent comment for flood detection
+type RecentComment struct {
+	Content   string
+	UserID    uint
+	IP        string
+	CreatedAt time.Time
+}
+
+const (
+	defaultFloodWindow     = time.Minute
+	defaultFloodSimilarity = 0.9
+	defaultFloodMinLength  = 10
+)
+
+func NewFloodChecker(conf *FloodCheckerConf) Checker {
+	if conf.Window <= 0 {
+		conf.Window = defaultFloodWindow
+	}
+	if conf.Similarity <= 0 || conf.Similarity > 1 {
+		conf.Similarity = defaultFloodSimilarity
+	}
+	if conf.MinLength <= 0 {
+		conf.MinLength = defaultFloodMinLength
+	}
+
+	return &FloodChecker{
+		conf: conf,
+		now:  time.Now,
+	}
+}
+
+func (*FloodChecker) Name() string {
+	return "flood"
+}
+
+func (c *FloodChecker) Check(p *CheckerParams) (bool, error) {
+	verdict, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return verdict.Pass, nil
+}
+
+func (c *FloodChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	if c.conf.FindRecentComments == nil {
+		return &CheckerVerdict{Pass: true}, nil
+	}
+
+	now := c.now()
+	comments := c.conf.FindRecentComments(p, now.Add(-max(c.conf.Window, c.conf.DuplicateWindow)))
+
+	// Rapid-fire posting from the same IP or user
+	if c.conf.MaxComments > 0 {
+		count := 0
+		for _, comment := range comments {
+			if now.Sub(comment.CreatedAt) <= c.conf.Window &&
+				((p.UserIP != "" && comment.IP == p.UserIP) || (p.UserID != 0 && comment.UserID == p.UserID)) {
+				count++
+			}
+		}
+		if count >= c.conf.MaxComments {
+			return c.reject(fmt.Sprintf("too many comments (%d in %s)", count+1, c.conf.Window), 0), nil
+		}
+	}
+
+	// Near-duplicate content
+	if c.conf.DuplicateWindow > 0 && len([]rune(normalizeFloodContent(p.Content))) >= c.conf.MinLength {
+		hash := simhash(p.Content)
+		for _, comment := range comments {
+			if now.Sub(comment.CreatedAt) > c.conf.DuplicateWindow {
+				continue
+			}
+			if similarity := simhashSimilarity(hash, simhash(comment.Content)); similarity >= c.conf.Similarity {
+				return c.reject(fmt.Sprintf("duplicate content (similarity %.2f)", similarity), similarity), nil
+			}
+		}
+	}
+
+	return &CheckerVerdict{Pass: true}, nil
+}
+
+func (c *FloodChecker) reject(reason string, confidence float64) *CheckerVerdict {
+	return &CheckerVerdict{Pass: false, Review: c.conf.Pending, Confidence: confidence, Reason: reason}
+}
+
+// Normalize the content by lowercase and removing the non-letter characters
+func normalizeFloodContent(content string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, content)
+}
+
+// Get the 64-bit SimHash of content by the character 3-grams
+func simhash(content string) uint64 {
+	runes := []rune(normalizeFloodContent(content))
+
+	// the short content is treated as a single shingle
+	const n = 3
+	shingles := max(len(runes)-n+1, min(len(runes), 1))
+
+	var weights [64]int
+	for i := 0; i < shingles; i++ {
+		h := fnv.New64a()
+		h.Write([]byte(string(runes[i:min(i+n, len(runes))])))
+		sum := h.Sum64()
+
+		for b := 0; b < 64; b++ {
+			if sum&(1<<b) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+
+	var hash uint64
+	for b := 0; b < 64; b++ {
+		if weights[b] > 0 {
+			hash |= 1 << b
+		}
+	}
+	return hash
+}
+
+// Get the similarity of two SimHashes (range 0~1)
+func simhashSimilarity(a, b uint64) float64 {
+	return 1 - float64(bits.OnesCount64(a^b))/64
+}
@@ -0,0 +1,105 @@
+package anti_spam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloodChecker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newChecker := func(conf *FloodCheckerConf, recent ...RecentComment) *FloodChecker {
+		conf.FindRecentComments = func(p *CheckerParams, since time.Time) []RecentComment {
+			comments := []RecentComment{}
+			for _, c := range recent {
+				if !c.CreatedAt.Before(since) {
+					comments = append(comments, c)
+				}
+			}
+			return comments
+		}
+		checker := NewFloodChecker(conf).(*FloodChecker)
+		checker.now = func() time.Time { return now }
+		return checker
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		checker := NewFloodChecker(&FloodCheckerConf{}).(*FloodChecker)
+		assert.Equal(t, "flood", checker.Name())
+		assert.Equal(t, defaultFloodWindow, checker.conf.Window)
+		assert.Equal(t, defaultFloodSimilarity, checker.conf.Similarity)
+		assert.Equal(t, defaultFloodMinLength, checker.conf.MinLength)
+
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: "hello"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass, "should pass without the recent comments finder")
+	})
+
+	t.Run("Flood", func(t *testing.T) {
+		recent := []RecentComment{
+			{Content: "a", IP: "1.2.3.4", CreatedAt: now.Add(-10 * time.Second)},
+			{Content: "b", UserID: 1, CreatedAt: now.Add(-20 * time.Second)},
+			{Content: "c", IP: "1.2.3.4", CreatedAt: now.Add(-2 * time.Minute)}, // out of window
+			{Content: "d", IP: "5.6.7.8", CreatedAt: now.Add(-5 * time.Second)}, // others
+		}
+		checker := newChecker(&FloodCheckerConf{Window: time.Minute, MaxComments: 2}, recent...)
+
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: "hello", UserIP: "1.2.3.4", UserID: 1})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass)
+		assert.Contains(t, verdict.Reason, "too many comments")
+
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: "hello", UserIP: "1.2.3.4", UserID: 2})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass, "should only count the comments in window")
+
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: "hello", UserIP: "9.9.9.9"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		recent := []RecentComment{
+			{Content: "Check out my awesome website for the best deals on watches!", IP: "5.6.7.8", CreatedAt: now.Add(-30 * time.Minute)},
+			{Content: "Short", CreatedAt: now.Add(-time.Minute)},
+		}
+		checker := newChecker(&FloodCheckerConf{DuplicateWindow: time.Hour}, recent...)
+
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: "Check out my AWESOME website for the best deals on watches!!"})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass, "should detect the near-duplicate content")
+		assert.Contains(t, verdict.Reason, "duplicate content")
+		assert.GreaterOrEqual(t, verdict.Confidence, defaultFloodSimilarity)
+
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: "Thanks for sharing, the config example helps me a lot"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass)
+
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: "Short"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass, "should skip the short content")
+
+		checker = newChecker(&FloodCheckerConf{DuplicateWindow: 10 * time.Minute}, recent...)
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: "Check out my awesome website for the best deals on watches!"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass, "should only compare the comments in window")
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		recent := []RecentComment{{Content: "a", IP: "1.2.3.4", CreatedAt: now}}
+		checker := newChecker(&FloodCheckerConf{MaxComments: 1, Pending: true}, recent...)
+
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: "b", UserIP: "1.2.3.4"})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass)
+		assert.True(t, verdict.Review)
+	})
+}
+
+func TestSimhash(t *testing.T) {
+	assert.Equal(t, 1.0, simhashSimilarity(simhash("Hello, World"), simhash("hello world")), "should ignore case and punctuation")
+	assert.Less(t, simhashSimilarity(simhash("The quick brown fox jumps over the lazy dog"), simhash("我们今天去公园散步吧")), defaultFloodSimilarity)
+	assert.Equal(t, uint64(0), simhash(""))
+}
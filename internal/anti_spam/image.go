@@ -0,0 +1,433 @@
+package anti_spam
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/samber/lo"
+)
+
+var _ VerdictChecker = (*ImageChecker)(nil)
+
+type ImageProvider string
+
+const (
+	ImageProviderOpenAI      ImageProvider = "openai"      // OpenAI vision model (Chat Completions API)
+	ImageProviderRekognition ImageProvider = "rekognition" // AWS Rekognition DetectModerationLabels
+	ImageProviderEndpoint    ImageProvider = "endpoint"    // Self-hosted NSFW model endpoint
+)
+
+// The checker which scans the images in the comment content
+type ImageChecker struct {
+	conf   *ImageCheckerConf
+	client *http.Client
+	now    func() time.Time
+}
+
+type ImageCheckerConf struct {
+	Provider ImageProvider // default is `openai`
+	ApiKey   string        // The API key of OpenAI or the bearer token of endpoint
+	Model    string        // The vision model of OpenAI (default is `gpt-4o-mini`)
+	Host     string        // The API host (the full URL is required for endpoint provider)
+	Proxy    string        // The outbound proxy for API requests and image downloads
+
+	// AWS credentials (for Rekognition)
+	AccessKeyID     string
+	AccessKeySecret string
+	Region          string
+
+	Threshold  float64  // The confidence threshold to block (range 0~1, default is 0.8)
+	Categories []string // The flagged categories (the built-in list of endpoint provider is used if empty)
+	MaxImages  int      // Max number of images to scan in a comment (default is 3)
+	MaxSize    int64    // Max size of image to download (unit: bytes, default is 5MB)
+	Pending    bool     // Hold the comment for manual review instead of blocking
+
+	// Load the image which is not an absolute URL (e.g. the local uploaded image),
+	// returns false if the image can not be resolved.
+	Loader func(src string) ([]byte, bool)
+}
+
+const (
+	defaultImageThreshold = 0.8
+	defaultImageMaxImages = 3
+	defaultImageMaxSize   = 5 * 1024 * 1024
+	defaultImageModel     = "gpt-4o-mini"
+)
+
+// The categories of the common NSFW models (e.g. nsfwjs, GantMan/nsfw_model)
+var defaultImageEndpointCategories = []string{"porn", "hentai", "sexy", "nsfw", "unsafe"}
+
+const defaultImageModerationPrompt = `You are a content moderation assistant. Your task is to determine if the image attached to a comment should be approved or blocked.
+
+An image should be BLOCKED if it contains:
+- Pornographic or sexually explicit content
+- Violence, gore or threats
+- Hate symbols
+- Spam or advertising (e.g. QR codes, contact information)
+- Illegal content
+
+Respond with ONLY a JSON object in the following format, without any other text:
+{"verdict": "PASS" or "BLOCK", "confidence": a number between 0 and 1, "reason": "a short explanation"}`
+
+var imageRegexp = regexp.MustCompile(`(?i)!\[[^\]]*\]\(\s*<?([^\s)>]+)>?[^)]*\)|<img\s[^>]*?src\s*=\s*["']?([^"'\s>]+)`)
+
+func NewImageChecker(conf *ImageCheckerConf) Checker {
+	if conf.Threshold <= 0 || conf.Threshold > 1 {
+		conf.Threshold = defaultImageThreshold
+	}
+	if conf.MaxImages <= 0 {
+		conf.MaxImages = defaultImageMaxImages
+	}
+	if conf.MaxSize <= 0 {
+		conf.MaxSize = defaultImageMaxSize
+	}
+	if conf.Provider == "" {
+		conf.Provider = ImageProviderOpenAI
+	}
+
+	return &ImageChecker{
+		conf:   conf,
+		client: utils.NewTracedHTTPClient(conf.Proxy, 30*time.Second),
+		now:    time.Now,
+	}
+}
+
+func (*ImageChecker) Name() string {
+	return "image"
+}
+
+func (c *ImageChecker) Check(p *CheckerParams) (bool, error) {
+	verdict, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return verdict.Pass, nil
+}
+
+func (c *ImageChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	images := extractImages(p.Content)
+	if len(images) > c.conf.MaxImages {
+		images = images[:c.conf.MaxImages]
+	}
+
+	for _, src := range images {
+		data, ok := c.loadImage(p.ctx(), src)
+		if !ok {
+			continue
+		}
+
+		mime := http.DetectContentType(data)
+		if !strings.HasPrefix(mime, "image/") {
+			log.Ctx(p.ctx()).Warn(LOG_TAG, "[Image] Skip the non-image content: ", src)
+			continue
+		}
+
+		verdict, err := c.moderate(p.ctx(), data, mime)
+		if err != nil {
+			return nil, err
+		}
+
+		if !verdict.Pass {
+			verdict.Review = c.conf.Pending
+			verdict.Reason = fmt.Sprintf("flagged image %s: %s", src, verdict.Reason)
+			return verdict, nil
+		}
+	}
+
+	return &CheckerVerdict{Pass: true}, nil
+}
+
+// Extract the image sources in the content (markdown and HTML images)
+func extractImages(content string) []string {
+	images := []string{}
+	for _, m := range imageRegexp.FindAllStringSubmatch(content, -1) {
+		if src := lo.CoalesceOrEmpty(m[1], m[2]); src != "" && !lo.Contains(images, src) {
+			images = append(images, src)
+		}
+	}
+	return images
+}
+
+// Load the image data, returns false if the image is unavailable
+func (c *ImageChecker) loadImage(ctx context.Context, src string) ([]byte, bool) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		if c.conf.Loader == nil {
+			return nil, false
+		}
+		return c.conf.Loader(src)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		log.Ctx(ctx).Warn(LOG_TAG, "[Image] Failed to download image: ", err)
+		return nil, false
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		log.Ctx(ctx).Warn(LOG_TAG, "[Image] Failed to download image: ", err)
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Ctx(ctx).Warn(LOG_TAG, fmt.Sprintf("[Image] Failed to download image %s: HTTP %d", src, resp.StatusCode))
+		return nil, false
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, c.conf.MaxSize+1))
+	if err != nil || int64(len(data)) > c.conf.MaxSize {
+		log.Ctx(ctx).Warn(LOG_TAG, "[Image] Skip the image which is unreadable or exceeds the size limit: ", src)
+		return nil, false
+	}
+
+	return data, true
+}
+
+func (c *ImageChecker) moderate(ctx context.Context, data []byte, mime string) (*CheckerVerdict, error) {
+	switch c.conf.Provider {
+	case ImageProviderRekognition:
+		return c.moderateByRekognition(ctx, data)
+	case ImageProviderEndpoint:
+		return c.moderateByEndpoint(ctx, data, mime)
+	default:
+		return c.moderateByOpenAI(ctx, data, mime)
+	}
+}
+
+func (c *ImageChecker) doRequest(ctx context.Context, req *http.Request) ([]byte, error) {
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// -------------------------------------------------------------------
+//  OpenAI (Vision)
+//  @link https://platform.openai.com/docs/guides/vision
+// -------------------------------------------------------------------
+
+func (c *ImageChecker) moderateByOpenAI(ctx context.Context, data []byte, mime string) (*CheckerVerdict, error) {
+	host := strings.TrimSuffix(lo.CoalesceOrEmpty(strings.TrimSpace(c.conf.Host), "https://api.openai.com"), "/")
+
+	req, err := newJSONRequest(host+"/v1/chat/completions", map[string]any{
+		"model": lo.CoalesceOrEmpty(c.conf.Model, defaultImageModel),
+		"messages": []map[string]any{{
+			"role": "user",
+			"content": []map[string]any{
+				{"type": "text", "text": defaultImageModerationPrompt},
+				{"type": "image_url", "image_url": map[string]string{
+					"url": fmt.Sprintf("data:%s;base64,%s", mime, base64.StdEncoding.EncodeToString(data)),
+				}},
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.conf.ApiKey)
+
+	body, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp openAIResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("empty response")
+	}
+
+	verdict, ok := parseAIVerdict(resp.Choices[0].Message.Content)
+	if !ok {
+		return &CheckerVerdict{Pass: true, Reason: "unclear response"}, nil
+	}
+
+	// the low confidence blocking is not trusted
+	if !verdict.Pass && verdict.Confidence > 0 && verdict.Confidence < c.conf.Threshold {
+		verdict.Pass = true
+	}
+
+	return verdict, nil
+}
+
+// -------------------------------------------------------------------
+//  AWS Rekognition
+//  @link https://docs.aws.amazon.com/rekognition/latest/APIReference/API_DetectModerationLabels.html
+// -------------------------------------------------------------------
+
+type rekognitionResponse struct {
+	ModerationLabels []struct {
+		Name       string  `json:"Name"`
+		ParentName string  `json:"ParentName"`
+		Confidence float64 `json:"Confidence"` // range 0~100
+	} `json:"ModerationLabels"`
+}
+
+func (c *ImageChecker) moderateByRekognition(ctx context.Context, data []byte) (*CheckerVerdict, error) {
+	region := lo.CoalesceOrEmpty(strings.TrimSpace(c.conf.Region), "us-east-1")
+	host := strings.TrimSuffix(lo.CoalesceOrEmpty(strings.TrimSpace(c.conf.Host), fmt.Sprintf("https://rekognition.%s.amazonaws.com", region)), "/")
+
+	payload, err := json.Marshal(map[string]any{
+		"Image":         map[string]any{"Bytes": data},
+		"MinConfidence": c.conf.Threshold * 100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", host+"/", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "RekognitionService.DetectModerationLabels")
+	signAWSRequest(req, payload, c.conf.AccessKeyID, c.conf.AccessKeySecret, region, "rekognition", c.now())
+
+	body, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp rekognitionResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	for _, label := range resp.ModerationLabels {
+		if len(c.conf.Categories) > 0 && !containsFold(c.conf.Categories, label.Name) && !containsFold(c.conf.Categories, label.ParentName) {
+			continue
+		}
+		if label.Confidence/100 >= c.conf.Threshold {
+			return &CheckerVerdict{Pass: false, Confidence: clampConfidence(label.Confidence / 100), Reason: label.Name}, nil
+		}
+	}
+
+	return &CheckerVerdict{Pass: true}, nil
+}
+
+// Sign the request by AWS Signature Version 4
+//
+// @link https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html
+func signAWSRequest(req *http.Request, payload []byte, accessKeyID, secret, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date;x-amz-target"
+	canonicalHeaders := fmt.Sprintf("content-type:%s\nhost:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\nx-amz-target:%s\n",
+		req.Header.Get("Content-Type"), req.URL.Host, payloadHash, amzDate, req.Header.Get("X-Amz-Target"))
+	canonicalRequest := strings.Join([]string{
+		req.Method, lo.CoalesceOrEmpty(req.URL.EscapedPath(), "/"), req.URL.RawQuery,
+		canonicalHeaders, signedHeaders, payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, service)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// -------------------------------------------------------------------
+//  Self-hosted NSFW model endpoint
+//
+//  The image is posted as the request body,
+//  and the response is a JSON object of category scores (range 0~1),
+//  e.g. {"porn": 0.92, "neutral": 0.05}
+// -------------------------------------------------------------------
+
+func (c *ImageChecker) moderateByEndpoint(ctx context.Context, data []byte, mime string) (*CheckerVerdict, error) {
+	if strings.TrimSpace(c.conf.Host) == "" {
+		return nil, fmt.Errorf("the endpoint URL is required")
+	}
+
+	req, err := http.NewRequest("POST", strings.TrimSpace(c.conf.Host), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mime)
+	if c.conf.ApiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.conf.ApiKey)
+	}
+
+	body, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := map[string]float64{}
+	if err := json.Unmarshal(body, &scores); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	categories := lo.Ternary(len(c.conf.Categories) > 0, c.conf.Categories, defaultImageEndpointCategories)
+
+	flagged, maxScore := "", 0.0
+	for name, score := range scores {
+		if containsFold(categories, name) && score >= c.conf.Threshold && score > maxScore {
+			flagged, maxScore = name, score
+		}
+	}
+	if flagged != "" {
+		return &CheckerVerdict{Pass: false, Confidence: clampConfidence(maxScore), Reason: flagged}, nil
+	}
+
+	return &CheckerVerdict{Pass: true}, nil
+}
+
+func containsFold(list []string, s string) bool {
+	if s == "" {
+		return false
+	}
+	return lo.ContainsBy(list, func(item string) bool {
+		return strings.EqualFold(strings.TrimSpace(item), s)
+	})
+}
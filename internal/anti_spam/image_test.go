@@ -0,0 +1,162 @@
+package anti_spam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestExtractImages(t *testing.T) {
+	assert.Equal(t, []string{"https://example.com/a.png", "/static/images/b.jpg", "https://example.com/c.gif"},
+		extractImages(`![a](https://example.com/a.png) ![b](/static/images/b.jpg "title") <img src="https://example.com/c.gif" alt="c"> ![a](https://example.com/a.png)`))
+	assert.Empty(t, extractImages("[link](https://example.com/a.png) no image"))
+}
+
+func TestImageChecker(t *testing.T) {
+	images := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.png":
+			_, _ = w.Write(testPNG)
+		case "/text.png":
+			_, _ = w.Write([]byte("not an image"))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer images.Close()
+
+	content := "Look ![](" + images.URL + "/image.png)"
+
+	t.Run("Endpoint", func(t *testing.T) {
+		var score float64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer test_token", r.Header.Get("Authorization"))
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, testPNG, body)
+			_ = json.NewEncoder(w).Encode(map[string]float64{"porn": score, "neutral": 1 - score})
+		}))
+		defer server.Close()
+
+		checker := NewImageChecker(&ImageCheckerConf{Provider: ImageProviderEndpoint, Host: server.URL, ApiKey: "test_token", Pending: true}).(*ImageChecker)
+		assert.Equal(t, "image", checker.Name())
+
+		score = 0.95
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: content})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass)
+		assert.True(t, verdict.Review)
+		assert.Equal(t, 0.95, verdict.Confidence)
+		assert.Contains(t, verdict.Reason, "porn")
+
+		score = 0.3
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: content})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass)
+	})
+
+	t.Run("OpenAI", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+			assert.Equal(t, "Bearer test_key", r.Header.Get("Authorization"))
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), "data:image/png;base64,")
+			assert.Contains(t, string(body), `"model":"gpt-4o-mini"`)
+			_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "{\"verdict\": \"BLOCK\", \"confidence\": 0.9, \"reason\": \"nudity\"}"}}]}`))
+		}))
+		defer server.Close()
+
+		checker := NewImageChecker(&ImageCheckerConf{Host: server.URL, ApiKey: "test_key"})
+		verdict, err := checker.(VerdictChecker).CheckVerdict(&CheckerParams{Content: content})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass)
+		assert.Contains(t, verdict.Reason, "nudity")
+
+		checker = NewImageChecker(&ImageCheckerConf{Host: server.URL, ApiKey: "test_key", Threshold: 0.95})
+		pass, err := checker.Check(&CheckerParams{Content: content})
+		assert.NoError(t, err)
+		assert.True(t, pass, "should pass if the confidence is below threshold")
+	})
+
+	t.Run("Rekognition", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "RekognitionService.DetectModerationLabels", r.Header.Get("X-Amz-Target"))
+			assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+				"AWS4-HMAC-SHA256 Credential=test_id/20240101/us-west-2/rekognition/aws4_request"))
+			assert.Equal(t, "20240101T000000Z", r.Header.Get("X-Amz-Date"))
+
+			var req struct {
+				Image struct{ Bytes []byte }
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, testPNG, req.Image.Bytes)
+
+			_, _ = w.Write([]byte(`{"ModerationLabels": [{"Name": "Explicit Nudity", "ParentName": "", "Confidence": 98.5}]}`))
+		}))
+		defer server.Close()
+
+		checker := NewImageChecker(&ImageCheckerConf{
+			Provider: ImageProviderRekognition, Host: server.URL,
+			AccessKeyID: "test_id", AccessKeySecret: "test_secret", Region: "us-west-2",
+		}).(*ImageChecker)
+		checker.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+		verdict, err := checker.CheckVerdict(&CheckerParams{Content: content})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass)
+		assert.InDelta(t, 0.985, verdict.Confidence, 1e-9)
+
+		checker.conf.Categories = []string{"Violence"}
+		verdict, err = checker.CheckVerdict(&CheckerParams{Content: content})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass, "should only block the flagged categories")
+	})
+
+	t.Run("SkipUnavailable", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			_, _ = w.Write([]byte(`{"porn": 1}`))
+		}))
+		defer server.Close()
+
+		checker := NewImageChecker(&ImageCheckerConf{Provider: ImageProviderEndpoint, Host: server.URL, MaxSize: 4})
+		pass, err := checker.Check(&CheckerParams{Content: "![](" + images.URL + "/404.png) ![](" + images.URL + "/text.png) ![](/local.png) ![](" + images.URL + "/image.png)"})
+		assert.NoError(t, err)
+		assert.True(t, pass)
+		assert.Equal(t, 0, calls, "should skip the unavailable, non-image, unresolved and oversize images")
+	})
+
+	t.Run("Loader", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"hentai": 0.9}`))
+		}))
+		defer server.Close()
+
+		checker := NewImageChecker(&ImageCheckerConf{Provider: ImageProviderEndpoint, Host: server.URL, Loader: func(src string) ([]byte, bool) {
+			return testPNG, src == "/static/images/a.png"
+		}})
+		pass, err := checker.Check(&CheckerParams{Content: "![](/static/images/a.png)"})
+		assert.NoError(t, err)
+		assert.False(t, pass)
+	})
+
+	t.Run("ProviderError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(500)
+		}))
+		defer server.Close()
+
+		checker := NewImageChecker(&ImageCheckerConf{Provider: ImageProviderEndpoint, Host: server.URL})
+		_, err := checker.Check(&CheckerParams{Content: content})
+		assert.ErrorContains(t, err, "HTTP 500")
+	})
+}
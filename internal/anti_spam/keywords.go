@@ -3,6 +3,7 @@ package anti_spam
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,16 +22,23 @@ const (
 )
 
 type KeywordsCheckerConf struct {
+	// The keyword files, which can be local paths or remote URLs
+	//
+	// A keyword wrapped with slashes is a regular expression, e.g. `/buy\s+now/i`
 	Files           []string
 	FileSep         string
 	ReplaceTo       string
 	Mode            KwCheckerMode
 	OnUpdateComment func(commentID uint, content string)
+
+	// The store of remote keyword lists (required if the remote URL is in files)
+	Remote *RemoteKeywords
 }
 
 type KeywordsChecker struct {
 	conf     *KeywordsCheckerConf
 	keywords *[]string
+	patterns []*regexp.Regexp
 	mux      sync.Mutex
 }
 
@@ -63,6 +71,18 @@ func (c *KeywordsChecker) Check(p *CheckerParams) (bool, error) {
 		}
 	}
 
+	for _, pattern := range c.patterns {
+		if pattern.MatchString(p.Content) {
+			isContains = true
+
+			if c.conf.Mode == KwCheckerModeReplace {
+				content = pattern.ReplaceAllStringFunc(content, func(s string) string {
+					return strings.Repeat(c.conf.ReplaceTo, len([]rune(s)))
+				})
+			}
+		}
+	}
+
 	switch c.conf.Mode {
 	case KwCheckerModeReplace:
 		if isContains {
@@ -97,15 +117,69 @@ func (c *KeywordsChecker) loadKeywords() error {
 
 	// 加载文件
 	for _, f := range c.conf.Files {
-		buf, err := os.ReadFile(f)
+		fileContent, err := c.readKeywordsFile(f)
 		if err != nil {
-			return fmt.Errorf("failed to load Keywords file: %s, %w", strconv.Quote(f), err)
+			return err
 		}
 
-		fileContent := string(buf)
 		aKeywords := utils.SplitAndTrimSpace(fileContent, c.conf.FileSep)
-		*c.keywords = append(*c.keywords, aKeywords...)
+		for _, kw := range aKeywords {
+			if pattern, ok := parseKeywordPattern(kw); ok {
+				if pattern != nil {
+					c.patterns = append(c.patterns, pattern)
+				}
+				continue
+			}
+			*c.keywords = append(*c.keywords, kw)
+		}
 	}
 
 	return nil
 }
+
+func (c *KeywordsChecker) readKeywordsFile(f string) (string, error) {
+	if isRemoteKeywordsFile(f) {
+		if c.conf.Remote == nil {
+			return "", fmt.Errorf("the remote keywords file is not supported: %s", strconv.Quote(f))
+		}
+		return c.conf.Remote.Get(f)
+	}
+
+	buf, err := os.ReadFile(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to load Keywords file: %s, %w", strconv.Quote(f), err)
+	}
+
+	return string(buf), nil
+}
+
+// Parse the regular expression keyword (e.g. `/pattern/` or `/pattern/i` for case-insensitive)
+//
+// Returns false if the keyword is not a regular expression,
+// the invalid regular expression is ignored and a nil pattern is returned.
+func parseKeywordPattern(kw string) (*regexp.Regexp, bool) {
+	if len(kw) < 3 || kw[0] != '/' {
+		return nil, false
+	}
+
+	expr, flags := "", ""
+	switch {
+	case strings.HasSuffix(kw, "/"):
+		expr = kw[1 : len(kw)-1]
+	case strings.HasSuffix(kw, "/i"):
+		expr, flags = kw[1:len(kw)-2], "(?i)"
+	default:
+		return nil, false
+	}
+	if expr == "" {
+		return nil, false
+	}
+
+	pattern, err := regexp.Compile(flags + expr)
+	if err != nil {
+		log.Warn(LOG_TAG, fmt.Sprintf("[Keywords] Invalid regular expression %s: ", strconv.Quote(kw)), err)
+		return nil, true
+	}
+
+	return pattern, true
+}
@@ -0,0 +1,138 @@
+package anti_spam
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+// The remote keywords lists (e.g. the community-maintained spam pattern lists)
+//
+// The lists are downloaded on first use and re-downloaded in background when the
+// refresh interval is elapsed, the `ETag` and `Last-Modified` headers are used to
+// avoid downloading the unchanged list.
+type RemoteKeywords struct {
+	interval time.Duration
+	client   *http.Client
+
+	mux   sync.Mutex
+	lists map[string]*remoteKeywordsList
+
+	now func() time.Time
+}
+
+type remoteKeywordsList struct {
+	mux          sync.Mutex
+	content      string
+	etag         string
+	lastModified string
+	fetchedAt    time.Time
+	refreshing   bool
+}
+
+const defaultRemoteKeywordsInterval = time.Hour
+
+func NewRemoteKeywords(interval time.Duration) *RemoteKeywords {
+	if interval <= 0 {
+		interval = defaultRemoteKeywordsInterval
+	}
+
+	return &RemoteKeywords{
+		interval: interval,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		lists:    map[string]*remoteKeywordsList{},
+		now:      time.Now,
+	}
+}
+
+// Whether the keywords file is a remote URL
+func isRemoteKeywordsFile(f string) bool {
+	return strings.HasPrefix(f, "http://") || strings.HasPrefix(f, "https://")
+}
+
+// Get the content of remote list
+func (r *RemoteKeywords) Get(url string) (string, error) {
+	r.mux.Lock()
+	list, ok := r.lists[url]
+	if !ok {
+		list = &remoteKeywordsList{}
+		r.lists[url] = list
+	}
+	r.mux.Unlock()
+
+	list.mux.Lock()
+	defer list.mux.Unlock()
+
+	// First download (sync)
+	if list.fetchedAt.IsZero() {
+		if err := r.fetch(url, list); err != nil {
+			return "", err
+		}
+		return list.content, nil
+	}
+
+	// Refresh in background if expired, the old content is used until refreshed
+	if r.now().Sub(list.fetchedAt) >= r.interval && !list.refreshing {
+		list.refreshing = true
+		go func() {
+			list.mux.Lock()
+			defer list.mux.Unlock()
+
+			if err := r.fetch(url, list); err != nil {
+				log.Warn(LOG_TAG, "[Keywords] Failed to refresh remote keywords list: ", err)
+				list.fetchedAt = r.now() // retry after the next interval
+			}
+			list.refreshing = false
+		}()
+	}
+
+	return list.content, nil
+}
+
+// Download the remote list (the lock of list should be held by caller)
+func (r *RemoteKeywords) fetch(url string, list *remoteKeywordsList) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if list.etag != "" {
+		req.Header.Set("If-None-Match", list.etag)
+	}
+	if list.lastModified != "" {
+		req.Header.Set("If-Modified-Since", list.lastModified)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download keywords list %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		list.fetchedAt = r.now()
+		return nil
+	case http.StatusOK:
+	default:
+		return fmt.Errorf("failed to download keywords list %q: HTTP %d", url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read keywords list %q: %w", url, err)
+	}
+
+	list.content = string(body)
+	list.etag = resp.Header.Get("ETag")
+	list.lastModified = resp.Header.Get("Last-Modified")
+	list.fetchedAt = r.now()
+
+	log.Debug(LOG_TAG, "[Keywords] Remote keywords list downloaded: ", url)
+
+	return nil
+}
@@ -2,8 +2,11 @@ package anti_spam
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -95,6 +98,96 @@ func TestNewKeywordsChecker(t *testing.T) {
 		})
 	})
 
+	t.Run("Regex", func(t *testing.T) {
+		kwFile := fmt.Sprintf("%s/keywords_regex.txt", t.TempDir())
+		_ = os.WriteFile(kwFile, []byte("/buy\\s+now/i\n/[0-9]{11}/\n/(invalid/\n关键词A"), 0644)
+
+		checker := NewKeywordsChecker(&KeywordsCheckerConf{
+			Files:   []string{kwFile},
+			FileSep: "\n",
+			Mode:    KwCheckerModeBlock,
+		})
+
+		for content, expected := range map[string]bool{
+			"BUY   NOW!":           false,
+			"call 13800138000":     false,
+			"ABC关键词AEF":            false,
+			"Hello (invalid World": true,
+			"buy later":            true,
+		} {
+			ok, err := checker.Check(&CheckerParams{Content: content})
+			assert.NoError(t, err)
+			assert.Equal(t, expected, ok, content)
+		}
+		assert.Len(t, checker.patterns, 2, "should ignore the invalid regular expression")
+
+		t.Run("Replace", func(t *testing.T) {
+			updatedContent := ""
+			checker := NewKeywordsChecker(&KeywordsCheckerConf{
+				Files:           []string{kwFile},
+				FileSep:         "\n",
+				ReplaceTo:       "x",
+				Mode:            KwCheckerModeReplace,
+				OnUpdateComment: func(commentID uint, content string) { updatedContent = content },
+			})
+			ok, err := checker.Check(&CheckerParams{Content: "Buy now, call 13800138000"})
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, "xxxxxxx, call xxxxxxxxxxx", updatedContent)
+		})
+	})
+
+	t.Run("Remote", func(t *testing.T) {
+		var calls int
+		content := "关键词R"
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if r.Header.Get("If-None-Match") == `"v1"` && content == "关键词R" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = w.Write([]byte(content))
+		}))
+		defer server.Close()
+
+		now := time.Now()
+		remote := NewRemoteKeywords(time.Minute)
+		remote.now = func() time.Time { return now }
+
+		newChecker := func() *KeywordsChecker {
+			return NewKeywordsChecker(&KeywordsCheckerConf{
+				Files:   []string{server.URL + "/list.txt"},
+				FileSep: "\n",
+				Mode:    KwCheckerModeBlock,
+				Remote:  remote,
+			})
+		}
+
+		ok, err := newChecker().Check(&CheckerParams{Content: "ABC关键词R"})
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, 1, calls)
+
+		_, _ = newChecker().Check(&CheckerParams{Content: "ABC"})
+		assert.Equal(t, 1, calls, "should use the downloaded list before the refresh interval")
+
+		// refresh in background after the interval
+		now = now.Add(time.Minute)
+		_, _ = newChecker().Check(&CheckerParams{Content: "ABC"})
+		assert.Eventually(t, func() bool {
+			remote.lists[server.URL+"/list.txt"].mux.Lock()
+			defer remote.lists[server.URL+"/list.txt"].mux.Unlock()
+			return calls == 2
+		}, time.Second, 10*time.Millisecond, "should send the conditional request with ETag")
+
+		ok, _ = newChecker().Check(&CheckerParams{Content: "ABC关键词R"})
+		assert.False(t, ok, "should keep the list if not modified")
+
+		_, err = NewKeywordsChecker(&KeywordsCheckerConf{Files: []string{server.URL}}).Check(&CheckerParams{})
+		assert.ErrorContains(t, err, "not supported", "should return error if the remote store is not provided")
+	})
+
 	t.Run("ErrorLoad", func(t *testing.T) {
 		checker := NewKeywordsChecker(&KeywordsCheckerConf{
 			Files:   []string{"not_exist_file"},
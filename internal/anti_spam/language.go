@@ -0,0 +1,127 @@
+package anti_spam
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+// The common words of the languages in Latin script
+var languageStopWords = map[string][]string{
+	"en": {"the", "and", "is", "are", "you", "this", "that", "it", "of", "to", "for", "with", "not", "have", "was", "be", "on", "in", "my", "your"},
+	"es": {"el", "los", "las", "que", "es", "en", "por", "con", "para", "una", "un", "se", "del", "muy", "pero", "como", "lo", "gracias"},
+	"fr": {"le", "les", "et", "est", "des", "une", "un", "pour", "pas", "dans", "ce", "il", "je", "vous", "du", "sur", "merci", "très"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ich", "sie", "es", "ein", "eine", "zu", "den", "mit", "auf", "für", "danke", "sehr"},
+	"pt": {"os", "que", "é", "em", "um", "uma", "não", "para", "com", "do", "da", "muito", "obrigado", "você", "mas", "isso"},
+	"it": {"il", "lo", "gli", "che", "di", "è", "un", "una", "non", "per", "con", "del", "della", "sono", "grazie", "molto"},
+	"nl": {"de", "het", "een", "en", "is", "niet", "dat", "van", "ik", "je", "op", "te", "met", "voor", "zijn", "bedankt"},
+}
+
+// The priority of languages when the common words are matched equally
+var languageStopWordsOrder = []string{"en", "es", "fr", "de", "pt", "it", "nl"}
+
+// Detect the language of text by the Unicode scripts and the common words
+//
+// Returns the ISO 639-1 code (e.g. "en", "zh", "ja"),
+// or empty if it is unknown (e.g. the text is too short).
+func DetectLanguage(text string) string {
+	counts := map[string]int{}
+	total := 0
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		total++
+
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts["kana"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["cyrillic"]++
+			if strings.ContainsRune("іїєґІЇЄҐ", r) {
+				counts["uk"]++
+			}
+		case unicode.Is(unicode.Arabic, r):
+			counts["arabic"]++
+			if strings.ContainsRune("پچژگ", r) {
+				counts["fa"]++
+			}
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		case unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+			if strings.ContainsRune("ăâđêôơưạảấầẩẫậắằẳẵặẹẻẽếềểễệỉịọỏốồổỗộớờởỡợụủứừửữựỳỷỹỵ", unicode.ToLower(r)) {
+				counts["vi"]++
+			}
+		}
+	}
+
+	if total < 3 {
+		return ""
+	}
+
+	// Find the dominant script
+	script, maxCount := "", 0
+	for _, s := range []string{"han", "kana", "ko", "cyrillic", "arabic", "he", "el", "th", "hi", "latin"} {
+		if counts[s] > maxCount {
+			script, maxCount = s, counts[s]
+		}
+	}
+
+	switch script {
+	case "han", "kana":
+		// Japanese text is mixed with kanji and kana
+		if counts["kana"]*10 >= counts["han"]+counts["kana"] {
+			return "ja"
+		}
+		return "zh"
+	case "cyrillic":
+		if counts["uk"] > 0 {
+			return "uk"
+		}
+		return "ru"
+	case "arabic":
+		if counts["fa"] > 0 {
+			return "fa"
+		}
+		return "ar"
+	case "latin":
+		if counts["vi"]*10 >= counts["latin"] {
+			return "vi"
+		}
+		return detectLatinLanguage(text)
+	}
+
+	return script
+}
+
+// Detect the language in Latin script by the common words
+func detectLatinLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	lang, maxMatches := "", 0
+	for _, l := range languageStopWordsOrder {
+		matches := lo.CountBy(words, func(w string) bool {
+			return lo.Contains(languageStopWords[l], w)
+		})
+		if matches > maxMatches {
+			lang, maxMatches = l, matches
+		}
+	}
+
+	return lang
+}
@@ -0,0 +1,33 @@
+package anti_spam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		lang string
+	}{
+		{"Thanks for sharing this post, it is really helpful", "en"},
+		{"Muchas gracias por el artículo, es muy útil", "es"},
+		{"Merci pour cet article, il est très utile", "fr"},
+		{"Danke für den Artikel, das ist sehr hilfreich", "de"},
+		{"感谢分享，这篇文章很有帮助", "zh"},
+		{"記事をありがとうございます、とても役に立ちました", "ja"},
+		{"좋은 글 감사합니다", "ko"},
+		{"Спасибо за статью, очень полезно", "ru"},
+		{"Дякую, це дуже цікава стаття", "uk"},
+		{"شكرا على المقال", "ar"},
+		{"Cảm ơn bạn đã chia sẻ bài viết này", "vi"},
+		{"ok", ""},
+		{"12345 !!!", ""},
+		{"Lorem ipsum", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.lang, DetectLanguage(tt.text), tt.text)
+	}
+}
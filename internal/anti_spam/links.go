@@ -0,0 +1,126 @@
+package anti_spam
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+var _ VerdictChecker = (*LinksChecker)(nil)
+
+// The built-in heuristic checker by links in the comment content
+//
+// It checks the number of links, the URL shorteners and the domain blacklist.
+type LinksChecker struct {
+	conf *LinksCheckerConf
+}
+
+type LinksCheckerConf struct {
+	MaxLinks        int      // Max number of links (0 for unlimited)
+	BlockShorteners bool     // Whether to block the URL shorteners
+	Shorteners      []string // The domains of URL shorteners (the built-in list is used if empty)
+	Blacklist       []string // The blacklist of domains (the subdomains are also matched)
+	Pending         bool     // Hold the comment for manual review instead of blocking
+}
+
+var defaultURLShorteners = []string{
+	"bit.ly", "bitly.com", "goo.gl", "t.co", "tinyurl.com", "ow.ly", "is.gd", "buff.ly",
+	"adf.ly", "bit.do", "cutt.ly", "shorturl.at", "rb.gy", "t.ly", "tiny.cc", "v.gd",
+	"rebrand.ly", "s.id", "lnkd.in", "dwz.cn", "url.cn", "t.cn",
+}
+
+var linkRegexp = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"'()\[\]]+`)
+
+func NewLinksChecker(conf *LinksCheckerConf) Checker {
+	return &LinksChecker{
+		conf: conf,
+	}
+}
+
+func (*LinksChecker) Name() string {
+	return "links"
+}
+
+func (c *LinksChecker) Check(p *CheckerParams) (bool, error) {
+	verdict, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return verdict.Pass, nil
+}
+
+func (c *LinksChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	links := extractLinks(p.Content)
+
+	if c.conf.MaxLinks > 0 && len(links) > c.conf.MaxLinks {
+		return c.reject(fmt.Sprintf("too many links (%d > %d)", len(links), c.conf.MaxLinks)), nil
+	}
+
+	shorteners := lo.Ternary(len(c.conf.Shorteners) > 0, c.conf.Shorteners, defaultURLShorteners)
+
+	for _, link := range links {
+		host := getLinkHost(link)
+		if host == "" {
+			continue
+		}
+
+		if domain, ok := matchDomain(host, c.conf.Blacklist); ok {
+			return c.reject(fmt.Sprintf("blacklisted domain: %s", domain)), nil
+		}
+
+		if c.conf.BlockShorteners {
+			if domain, ok := matchDomain(host, shorteners); ok {
+				return c.reject(fmt.Sprintf("URL shortener: %s", domain)), nil
+			}
+		}
+	}
+
+	return &CheckerVerdict{Pass: true}, nil
+}
+
+func (c *LinksChecker) reject(reason string) *CheckerVerdict {
+	return &CheckerVerdict{Pass: false, Review: c.conf.Pending, Reason: reason}
+}
+
+// Count the links in the content
+func CountLinks(content string) int {
+	return len(extractLinks(content))
+}
+
+// Extract the links in the content (including markdown and HTML links)
+func extractLinks(content string) []string {
+	return lo.Map(linkRegexp.FindAllString(content, -1), func(link string, _ int) string {
+		return strings.TrimRight(link, ".,;:!?") // trailing punctuation is not part of link
+	})
+}
+
+// Get the lowercase host of link (without port)
+func getLinkHost(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(u.Hostname())
+}
+
+// Match the host by domains (the subdomains are also matched)
+func matchDomain(host string, domains []string) (string, bool) {
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if d == "" {
+			continue
+		}
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return d, true
+		}
+	}
+	return "", false
+}
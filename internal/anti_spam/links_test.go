@@ -0,0 +1,48 @@
+package anti_spam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinksChecker(t *testing.T) {
+	assert.Equal(t, "links", NewLinksChecker(&LinksCheckerConf{}).Name())
+
+	assert.Equal(t, []string{"https://a.com/x", "www.b.com", "http://c.com"},
+		extractLinks("see https://a.com/x and www.b.com, [link](http://c.com) example.com"))
+
+	tests := []struct {
+		name    string
+		conf    LinksCheckerConf
+		content string
+		pass    bool
+		reason  string
+	}{
+		{"NoLinks", LinksCheckerConf{MaxLinks: 1}, "Hello World", true, ""},
+		{"MaxLinks", LinksCheckerConf{MaxLinks: 2}, "https://a.com https://b.com", true, ""},
+		{"TooManyLinks", LinksCheckerConf{MaxLinks: 2}, "https://a.com https://b.com www.c.com", false, "too many links (3 > 2)"},
+		{"Unlimited", LinksCheckerConf{}, "https://a.com https://b.com www.c.com", true, ""},
+		{"Shortener", LinksCheckerConf{BlockShorteners: true}, "click https://BIT.LY/abc", false, "URL shortener: bit.ly"},
+		{"ShortenerNotBlocked", LinksCheckerConf{}, "click https://bit.ly/abc", true, ""},
+		{"CustomShortener", LinksCheckerConf{BlockShorteners: true, Shorteners: []string{"s.example"}}, "https://bit.ly/abc https://s.example/1", false, "URL shortener: s.example"},
+		{"Blacklist", LinksCheckerConf{Blacklist: []string{"spam.com"}}, "visit http://shop.spam.com:8080/buy", false, "blacklisted domain: spam.com"},
+		{"BlacklistNotSuffix", LinksCheckerConf{Blacklist: []string{"spam.com"}}, "visit https://notspam.com", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, err := NewLinksChecker(&tt.conf).(*LinksChecker).CheckVerdict(&CheckerParams{Content: tt.content})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.pass, verdict.Pass)
+			assert.Equal(t, tt.reason, verdict.Reason)
+		})
+	}
+
+	t.Run("Pending", func(t *testing.T) {
+		verdict, _ := NewLinksChecker(&LinksCheckerConf{MaxLinks: 1, Pending: true}).(*LinksChecker).
+			CheckVerdict(&CheckerParams{Content: "https://a.com https://b.com"})
+		assert.False(t, verdict.Pass)
+		assert.True(t, verdict.Review, "should hold for review")
+	})
+}
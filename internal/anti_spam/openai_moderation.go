@@ -0,0 +1,176 @@
+package anti_spam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+var _ VerdictChecker = (*OpenAIModerationChecker)(nil)
+
+// Checker using the OpenAI Moderation API
+//
+// @link https://platform.openai.com/docs/api-reference/moderations
+type OpenAIModerationChecker struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	thresholds map[string]float64
+	client     *http.Client
+}
+
+type OpenAIModerationCheckerConf struct {
+	ApiKey string
+	Model  string // default is `omni-moderation-latest`
+	Host   string // default is `https://api.openai.com`
+	Proxy  string // The outbound proxy (e.g. `socks5://127.0.0.1:1080`)
+
+	// The threshold of category scores (e.g. `hate`, `sexual`, `violence`),
+	// the comment will be blocked if any score reaches its threshold.
+	// If no threshold is set, the `flagged` result of API is used.
+	Thresholds map[string]float64
+}
+
+const defaultOpenAIModerationModel = "omni-moderation-latest"
+
+func NewOpenAIModerationChecker(conf *OpenAIModerationCheckerConf) Checker {
+	model := strings.TrimSpace(conf.Model)
+	if model == "" {
+		model = defaultOpenAIModerationModel
+	}
+
+	baseURL := strings.TrimSuffix(strings.TrimSpace(conf.Host), "/")
+	if baseURL == "" {
+		baseURL = "https://api.openai.com"
+	}
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "https://" + baseURL
+	}
+
+	return &OpenAIModerationChecker{
+		apiKey:     conf.ApiKey,
+		model:      model,
+		baseURL:    baseURL,
+		thresholds: conf.Thresholds,
+		client:     utils.NewTracedHTTPClient(conf.Proxy, 30*time.Second),
+	}
+}
+
+func (*OpenAIModerationChecker) Name() string {
+	return "openai_moderation"
+}
+
+func (c *OpenAIModerationChecker) Check(p *CheckerParams) (bool, error) {
+	verdict, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return verdict.Pass, nil
+}
+
+func (c *OpenAIModerationChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	result, err := c.callAPI(p.ctx(), p.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decide(result), nil
+}
+
+type openAIModerationResult struct {
+	Flagged        bool               `json:"flagged"`
+	Categories     map[string]bool    `json:"categories"`
+	CategoryScores map[string]float64 `json:"category_scores"`
+}
+
+func (c *OpenAIModerationChecker) callAPI(ctx context.Context, content string) (*openAIModerationResult, error) {
+	req, err := newJSONRequest(c.baseURL+"/v1/moderations", map[string]any{
+		"model": c.model,
+		"input": content,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call OpenAI moderation API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result struct {
+		Results []openAIModerationResult `json:"results"`
+		Error   *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("OpenAI moderation API error: %s", result.Error.Message)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenAI moderation API error: HTTP %d", resp.StatusCode)
+	}
+
+	if len(result.Results) == 0 {
+		return nil, fmt.Errorf("OpenAI moderation API returned no results")
+	}
+
+	return &result.Results[0], nil
+}
+
+// Decide the verdict by the category scores and thresholds
+func (c *OpenAIModerationChecker) decide(result *openAIModerationResult) *CheckerVerdict {
+	var (
+		hits     []string
+		maxScore float64
+	)
+
+	if len(c.thresholds) == 0 {
+		// use the `flagged` result of API
+		for category, flagged := range result.Categories {
+			if flagged {
+				hits = append(hits, category)
+				maxScore = max(maxScore, result.CategoryScores[category])
+			}
+		}
+		if result.Flagged && len(hits) == 0 {
+			hits = append(hits, "flagged")
+		}
+	} else {
+		for category, threshold := range c.thresholds {
+			if score, ok := result.CategoryScores[category]; ok && score >= threshold {
+				hits = append(hits, category)
+				maxScore = max(maxScore, score)
+			}
+		}
+	}
+
+	if len(hits) == 0 {
+		return &CheckerVerdict{Pass: true}
+	}
+
+	sort.Strings(hits)
+	return &CheckerVerdict{
+		Pass:       false,
+		Confidence: clampConfidence(maxScore),
+		Reason:     "Flagged categories: " + strings.Join(hits, ", "),
+	}
+}
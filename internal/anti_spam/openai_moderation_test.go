@@ -0,0 +1,71 @@
+package anti_spam
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAIModerationChecker(t *testing.T) {
+	const response = `{"results": [{
+		"flagged": true,
+		"categories": {"hate": false, "sexual": false, "violence": true},
+		"category_scores": {"hate": 0.3, "sexual": 0.01, "violence": 0.85}
+	}]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/moderations", r.URL.Path)
+		assert.Equal(t, "Bearer test_key", r.Header.Get("Authorization"))
+
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"model":"omni-moderation-latest"`)
+		assert.Contains(t, string(body), `"input":"Hello World"`)
+
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	params := &CheckerParams{Content: "Hello World"}
+
+	t.Run("Flagged", func(t *testing.T) {
+		checker := NewOpenAIModerationChecker(&OpenAIModerationCheckerConf{ApiKey: "test_key", Host: server.URL})
+		assert.Equal(t, "openai_moderation", checker.Name())
+
+		verdict, err := checker.(*OpenAIModerationChecker).CheckVerdict(params)
+		assert.NoError(t, err)
+		assert.Equal(t, &CheckerVerdict{Pass: false, Confidence: 0.85, Reason: "Flagged categories: violence"}, verdict)
+	})
+
+	t.Run("Thresholds", func(t *testing.T) {
+		checker := NewOpenAIModerationChecker(&OpenAIModerationCheckerConf{
+			ApiKey: "test_key", Host: server.URL,
+			Thresholds: map[string]float64{"hate": 0.2, "violence": 0.9},
+		})
+		verdict, err := checker.(*OpenAIModerationChecker).CheckVerdict(params)
+		assert.NoError(t, err)
+		assert.Equal(t, &CheckerVerdict{Pass: false, Confidence: 0.3, Reason: "Flagged categories: hate"}, verdict)
+
+		checker = NewOpenAIModerationChecker(&OpenAIModerationCheckerConf{
+			ApiKey: "test_key", Host: server.URL,
+			Thresholds: map[string]float64{"violence": 0.9},
+		})
+		pass, err := checker.Check(params)
+		assert.NoError(t, err)
+		assert.True(t, pass, "should pass if all scores are below the thresholds")
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(401)
+			_, _ = w.Write([]byte(`{"error": {"message": "invalid api key"}}`))
+		}))
+		defer server.Close()
+
+		checker := NewOpenAIModerationChecker(&OpenAIModerationCheckerConf{Host: server.URL})
+		_, err := checker.Check(params)
+		assert.ErrorContains(t, err, "invalid api key")
+	})
+}
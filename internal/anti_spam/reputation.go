@@ -0,0 +1,223 @@
+package anti_spam
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+var _ VerdictChecker = (*ReputationChecker)(nil)
+
+// Checker by the reputation of commenter's IP and email
+//
+// @link https://www.stopforumspam.com/usage
+// @link https://docs.abuseipdb.com/#check-endpoint
+type ReputationChecker struct {
+	conf   *ReputationCheckerConf
+	client *http.Client
+
+	stopForumSpamAPI string
+	abuseIPDBAPI     string
+}
+
+type ReputationCheckerConf struct {
+	StopForumSpam bool   // Query the StopForumSpam by IP and email hash
+	AbuseIPDBKey  string // Query the AbuseIPDB by IP (disabled if empty)
+	Threshold     int    // The confidence threshold to block (range 0~100, default is 50)
+
+	// The store of lookup results (optional, shared by checkers)
+	Cache    store.Store
+	CacheTTL time.Duration // default is 24 hours
+}
+
+const (
+	defaultReputationThreshold = 50
+	defaultReputationCacheTTL  = 24 * time.Hour
+)
+
+func NewReputationChecker(conf *ReputationCheckerConf) Checker {
+	return &ReputationChecker{
+		conf:             conf,
+		client:           utils.NewTracedHTTPClient("", 10*time.Second),
+		stopForumSpamAPI: "https://api.stopforumspam.org/api",
+		abuseIPDBAPI:     "https://api.abuseipdb.com/api/v2/check",
+	}
+}
+
+func (*ReputationChecker) Name() string {
+	return "reputation"
+}
+
+func (c *ReputationChecker) Check(p *CheckerParams) (bool, error) {
+	verdict, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return verdict.Pass, nil
+}
+
+func (c *ReputationChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	threshold := c.conf.Threshold
+	if threshold <= 0 {
+		threshold = defaultReputationThreshold
+	}
+
+	type lookup struct {
+		name  string
+		query func(p *CheckerParams) (int, error)
+	}
+	lookups := []lookup{}
+	if c.conf.StopForumSpam {
+		lookups = append(lookups, lookup{"StopForumSpam", c.queryStopForumSpam})
+	}
+	if c.conf.AbuseIPDBKey != "" && p.UserIP != "" {
+		lookups = append(lookups, lookup{"AbuseIPDB", c.queryAbuseIPDB})
+	}
+
+	for _, l := range lookups {
+		confidence, err := c.cached(l.name, p, l.query)
+		if err != nil {
+			return nil, err
+		}
+
+		if confidence >= threshold {
+			return &CheckerVerdict{
+				Pass:       false,
+				Confidence: clampConfidence(float64(confidence) / 100),
+				Reason:     fmt.Sprintf("%s confidence %d%% (threshold %d%%)", l.name, confidence, threshold),
+			}, nil
+		}
+	}
+
+	return &CheckerVerdict{Pass: true}, nil
+}
+
+// Query with the local cache of lookup results
+func (c *ReputationChecker) cached(name string, p *CheckerParams, query func(p *CheckerParams) (int, error)) (int, error) {
+	key := fmt.Sprintf("%s#%s#%s", name, p.UserIP, strings.ToLower(p.UserEmail))
+
+	if c.conf.Cache != nil {
+		if v, ok := c.conf.Cache.Get(key); ok {
+			if confidence, err := strconv.Atoi(v); err == nil {
+				return confidence, nil
+			}
+		}
+	}
+
+	confidence, err := query(p)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.conf.Cache != nil {
+		if err := c.conf.Cache.Set(key, strconv.Itoa(confidence), cmp.Or(c.conf.CacheTTL, defaultReputationCacheTTL)); err != nil {
+			log.Error("[ReputationChecker] Failed to cache the lookup result: ", err)
+		}
+	}
+
+	return confidence, nil
+}
+
+// Query the StopForumSpam, returns the max confidence of IP and email (range 0~100)
+func (c *ReputationChecker) queryStopForumSpam(p *CheckerParams) (int, error) {
+	q := url.Values{}
+	q.Set("json", "")
+	if p.UserIP != "" {
+		q.Set("ip", p.UserIP)
+	}
+	if p.UserEmail != "" {
+		q.Set("emailhash", utils.GetMD5Hash(strings.ToLower(strings.TrimSpace(p.UserEmail))))
+	}
+
+	var result struct {
+		Success   int                `json:"success"`
+		Error     string             `json:"error"`
+		IP        stopForumSpamField `json:"ip"`
+		EmailHash stopForumSpamField `json:"emailhash"`
+	}
+	if err := c.getJSON(p.ctx(), c.stopForumSpamAPI+"?"+q.Encode(), nil, &result); err != nil {
+		return 0, err
+	}
+	if result.Success != 1 {
+		return 0, fmt.Errorf("StopForumSpam API error: %s", result.Error)
+	}
+
+	log.Ctx(p.ctx()).Debug(LOG_TAG, fmt.Sprintf("[Reputation] StopForumSpam ip=%.2f email=%.2f", result.IP.Confidence, result.EmailHash.Confidence))
+
+	return int(max(result.IP.confidence(), result.EmailHash.confidence())), nil
+}
+
+type stopForumSpamField struct {
+	Appears    int     `json:"appears"`
+	Confidence float64 `json:"confidence"`
+}
+
+func (f stopForumSpamField) confidence() float64 {
+	if f.Appears == 0 {
+		return 0
+	}
+	return f.Confidence
+}
+
+// Query the AbuseIPDB, returns the abuse confidence score of IP (range 0~100)
+func (c *ReputationChecker) queryAbuseIPDB(p *CheckerParams) (int, error) {
+	q := url.Values{}
+	q.Set("ipAddress", p.UserIP)
+	q.Set("maxAgeInDays", "90")
+
+	var result struct {
+		Data struct {
+			AbuseConfidenceScore int `json:"abuseConfidenceScore"`
+		} `json:"data"`
+		Errors []struct {
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+	if err := c.getJSON(p.ctx(), c.abuseIPDBAPI+"?"+q.Encode(), map[string]string{"Key": c.conf.AbuseIPDBKey}, &result); err != nil {
+		return 0, err
+	}
+	if len(result.Errors) > 0 {
+		return 0, fmt.Errorf("AbuseIPDB API error: %s", result.Errors[0].Detail)
+	}
+
+	return result.Data.AbuseConfidenceScore, nil
+}
+
+func (c *ReputationChecker) getJSON(ctx context.Context, api string, headers map[string]string, dest any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, api, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, dest); err != nil {
+		return fmt.Errorf("failed to parse response (HTTP %d): %w", resp.StatusCode, err)
+	}
+
+	return nil
+}
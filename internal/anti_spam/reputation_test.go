@@ -0,0 +1,87 @@
+package anti_spam
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReputationChecker(t *testing.T) {
+	assert.Equal(t, "reputation", NewReputationChecker(&ReputationCheckerConf{}).Name())
+
+	var sfsCalls, abuseCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sfs":
+			sfsCalls++
+			assert.Equal(t, utils.GetMD5Hash("spammer@example.com"), r.URL.Query().Get("emailhash"))
+			if r.URL.Query().Get("ip") == "1.1.1.1" {
+				_, _ = w.Write([]byte(`{"success": 1, "ip": {"appears": 1, "confidence": 92.5}, "emailhash": {"appears": 0, "confidence": 0}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success": 1, "ip": {"appears": 0}, "emailhash": {"appears": 1, "confidence": 30}}`))
+		case "/abuseipdb":
+			abuseCalls++
+			assert.Equal(t, "test_key", r.Header.Get("Key"))
+			_, _ = w.Write([]byte(`{"data": {"ipAddress": "2.2.2.2", "abuseConfidenceScore": 75}}`))
+		}
+	}))
+	defer server.Close()
+
+	newChecker := func(conf *ReputationCheckerConf) *ReputationChecker {
+		c := NewReputationChecker(conf).(*ReputationChecker)
+		c.stopForumSpamAPI = server.URL + "/sfs"
+		c.abuseIPDBAPI = server.URL + "/abuseipdb"
+		return c
+	}
+
+	t.Run("StopForumSpam", func(t *testing.T) {
+		checker := newChecker(&ReputationCheckerConf{StopForumSpam: true, Cache: store.NewMemoryStore()})
+
+		verdict, err := checker.CheckVerdict(&CheckerParams{UserIP: "1.1.1.1", UserEmail: "Spammer@example.com"})
+		assert.NoError(t, err)
+		assert.False(t, verdict.Pass)
+		assert.Equal(t, 0.92, verdict.Confidence)
+		assert.Equal(t, "StopForumSpam confidence 92% (threshold 50%)", verdict.Reason)
+
+		verdict, err = checker.CheckVerdict(&CheckerParams{UserIP: "2.2.2.2", UserEmail: "spammer@example.com"})
+		assert.NoError(t, err)
+		assert.True(t, verdict.Pass, "should pass below the threshold")
+
+		_, _ = checker.CheckVerdict(&CheckerParams{UserIP: "1.1.1.1", UserEmail: "spammer@example.com"})
+		assert.Equal(t, 2, sfsCalls, "should use the cached result")
+	})
+
+	t.Run("AbuseIPDB", func(t *testing.T) {
+		checker := newChecker(&ReputationCheckerConf{AbuseIPDBKey: "test_key", Threshold: 80})
+		pass, err := checker.Check(&CheckerParams{UserIP: "2.2.2.2"})
+		assert.NoError(t, err)
+		assert.True(t, pass)
+
+		checker.conf.Threshold = 70
+		pass, err = checker.Check(&CheckerParams{UserIP: "2.2.2.2"})
+		assert.NoError(t, err)
+		assert.False(t, pass)
+		assert.Equal(t, 2, abuseCalls)
+
+		pass, _ = checker.Check(&CheckerParams{})
+		assert.True(t, pass, "should skip the AbuseIPDB if IP is empty")
+		assert.Equal(t, 2, abuseCalls)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"success": 0, "error": "rate limited"}`))
+		}))
+		defer server.Close()
+
+		checker := newChecker(&ReputationCheckerConf{StopForumSpam: true})
+		checker.stopForumSpamAPI = server.URL
+		_, err := checker.Check(&CheckerParams{UserIP: "1.1.1.1"})
+		assert.ErrorContains(t, err, "rate limited")
+	})
+}
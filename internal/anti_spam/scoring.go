@@ -0,0 +1,113 @@
+package anti_spam
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+const (
+	scoringCheckerName      = "scoring"
+	defaultScoringThreshold = 1.0
+)
+
+type ScoringDecision int
+
+const (
+	ScoringPass   ScoringDecision = iota // Pass
+	ScoringReview                        // Grey zone, send to pending review
+	ScoringBlock                         // Block
+)
+
+type ScoringResult struct {
+	Total    float64
+	Details  []string // Weighted score of each checker (e.g. "akismet=0.60")
+	Decision ScoringDecision
+}
+
+// Weighted scoring trigger function
+//
+// All checkers are executed, and the weighted sum of spam scores is compared with thresholds.
+func (as AntiSpam) scoringTrigger(checkers []Checker, params *CheckerParams) ScoringDecision {
+	result := as.score(checkers, params)
+
+	verdict := &CheckerVerdict{
+		Checker:    scoringCheckerName,
+		Pass:       result.Decision == ScoringPass,
+		Confidence: min(1, result.Total/as.getScoringThreshold()),
+		Reason:     fmt.Sprintf("total=%.2f (%s)", result.Total, strings.Join(result.Details, ", ")),
+	}
+
+	switch result.Decision {
+	case ScoringBlock:
+		if as.conf.OnBlockComment != nil {
+			as.conf.OnBlockComment(params.CommentID, verdict)
+		}
+	case ScoringReview:
+		if as.conf.OnPendingComment != nil {
+			as.conf.OnPendingComment(params.CommentID, verdict)
+		}
+	}
+
+	log.Ctx(params.ctx()).Debug(LOG_TAG, fmt.Sprintf("[%s] Comment ID=%d DECISION=%d REASON=%s",
+		scoringCheckerName, params.CommentID, result.Decision, strconv.Quote(verdict.Reason)))
+
+	return result.Decision
+}
+
+// Execute all checkers and sum up the weighted spam scores
+func (as AntiSpam) score(checkers []Checker, params *CheckerParams) ScoringResult {
+	result := ScoringResult{}
+	needReview := false
+
+	for _, checker := range checkers {
+		if as.isDryRun(checker) {
+			as.dryRunTrigger(checker, params) // not counted in the total score
+			continue
+		}
+
+		verdict := as.evaluate(checker, params)
+		score := verdict.SpamScore() * as.getScoringWeight(checker.Name())
+
+		// the checker asks for manual review, so it is not counted as spam
+		if !verdict.Pass && verdict.Review {
+			needReview = true
+			score = 0
+		}
+
+		result.Total += score
+		result.Details = append(result.Details, fmt.Sprintf("%s=%.2f", checker.Name(), score))
+	}
+
+	threshold := as.getScoringThreshold()
+	reviewThreshold := as.conf.Scoring.ReviewThreshold
+
+	switch {
+	case result.Total >= threshold:
+		result.Decision = ScoringBlock
+	case needReview || (reviewThreshold > 0 && result.Total >= reviewThreshold):
+		result.Decision = ScoringReview
+	default:
+		result.Decision = ScoringPass
+	}
+
+	return result
+}
+
+func (as AntiSpam) getScoringWeight(checkerName string) float64 {
+	if weight, ok := as.conf.Scoring.Weights[checkerName]; ok {
+		return weight
+	}
+
+	return 1 // default weight
+}
+
+func (as AntiSpam) getScoringThreshold() float64 {
+	if as.conf.Scoring.Threshold <= 0 {
+		return defaultScoringThreshold
+	}
+
+	return as.conf.Scoring.Threshold
+}
@@ -0,0 +1,135 @@
+package anti_spam
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpamScore(t *testing.T) {
+	assert.Equal(t, 0.0, (&CheckerVerdict{Pass: true}).SpamScore())
+	assert.Equal(t, 1.0, (&CheckerVerdict{Pass: false}).SpamScore())
+	assert.InDelta(t, 0.2, (&CheckerVerdict{Pass: true, Confidence: 0.8}).SpamScore(), 1e-9)
+	assert.InDelta(t, 0.8, (&CheckerVerdict{Pass: false, Confidence: 0.8}).SpamScore(), 1e-9)
+}
+
+func TestScoring(t *testing.T) {
+	newAntiSpam := func(blocked, pending *uint) *AntiSpam {
+		return NewAntiSpam(&AntiSpamConf{
+			ModeratorConf: config.ModeratorConf{
+				Scoring: config.ScoringAntispamConf{
+					Enabled:         true,
+					Weights:         map[string]float64{"akismet": 0.6, "keywords": 0.3, "ai": 0.8},
+					Threshold:       1.0,
+					ReviewThreshold: 0.5,
+				},
+			},
+			OnBlockComment: func(commentID uint, verdict *CheckerVerdict) {
+				*blocked = commentID
+				assert.Equal(t, "scoring", verdict.Checker)
+			},
+			OnPendingComment: func(commentID uint, verdict *CheckerVerdict) {
+				*pending = commentID
+				assert.Equal(t, "scoring", verdict.Checker)
+			},
+		})
+	}
+
+	tests := []struct {
+		name     string
+		checkers []Checker
+		total    float64
+		decision ScoringDecision
+	}{
+		{"AllPass", []Checker{
+			&mockScoreChecker{name: "akismet", pass: true},
+			&mockScoreChecker{name: "keywords", pass: true},
+		}, 0, ScoringPass},
+		{"Review", []Checker{
+			&mockScoreChecker{name: "akismet", pass: false},
+			&mockScoreChecker{name: "keywords", pass: true},
+		}, 0.6, ScoringReview},
+		{"Block", []Checker{
+			&mockScoreChecker{name: "akismet", pass: false},
+			&mockScoreChecker{name: "keywords", pass: false},
+			&mockScoreChecker{name: "ai", pass: false, confidence: 0.5},
+		}, 1.3, ScoringBlock},
+		{"BelowReview", []Checker{
+			&mockScoreChecker{name: "keywords", pass: false},
+			&mockScoreChecker{name: "ai", pass: true, confidence: 0.9},
+		}, 0.38, ScoringPass},
+		{"DefaultWeight", []Checker{
+			&mockScoreChecker{name: "tencent", pass: false},
+		}, 1, ScoringBlock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var blocked, pending uint
+			as := newAntiSpam(&blocked, &pending)
+
+			result := as.score(tt.checkers, &CheckerParams{CommentID: 1000})
+			assert.InDelta(t, tt.total, result.Total, 1e-9)
+			assert.Equal(t, tt.decision, result.Decision)
+			assert.Len(t, result.Details, len(tt.checkers))
+
+			assert.Equal(t, tt.decision, as.scoringTrigger(tt.checkers, &CheckerParams{CommentID: 1000}))
+			assert.Equal(t, tt.decision == ScoringBlock, blocked == 1000)
+			assert.Equal(t, tt.decision == ScoringReview, pending == 1000)
+		})
+	}
+
+	t.Run("CheckerReview", func(t *testing.T) {
+		var blocked, pending uint
+		as := newAntiSpam(&blocked, &pending)
+
+		checkers := []Checker{&mockScoreChecker{name: "ai", pass: false, review: true}}
+		result := as.score(checkers, &CheckerParams{})
+		assert.Equal(t, 0.0, result.Total)
+		assert.Equal(t, ScoringReview, result.Decision, "should send to review if the checker asks for")
+	})
+
+	t.Run("CheckerError", func(t *testing.T) {
+		var blocked, pending uint
+		as := newAntiSpam(&blocked, &pending)
+
+		as.conf.ApiFailBlock = true
+		result := as.score([]Checker{&mockScoreChecker{name: "ai", err: true}}, &CheckerParams{})
+		assert.InDelta(t, 0.8, result.Total, 1e-9, "should count as spam when api fail")
+
+		as.conf.ApiFailBlock = false
+		result = as.score([]Checker{&mockScoreChecker{name: "ai", err: true}}, &CheckerParams{})
+		assert.Equal(t, 0.0, result.Total, "should count as pass when api fail")
+	})
+}
+
+var _ VerdictChecker = (*mockScoreChecker)(nil)
+
+type mockScoreChecker struct {
+	name       string
+	pass       bool
+	confidence float64
+	review     bool
+	err        bool
+}
+
+func (c *mockScoreChecker) Name() string {
+	return c.name
+}
+
+func (c *mockScoreChecker) Check(p *CheckerParams) (bool, error) {
+	v, err := c.CheckVerdict(p)
+	if err != nil {
+		return false, err
+	}
+	return v.Pass, nil
+}
+
+func (c *mockScoreChecker) CheckVerdict(p *CheckerParams) (*CheckerVerdict, error) {
+	if c.err {
+		return nil, fmt.Errorf("test error")
+	}
+	return &CheckerVerdict{Pass: c.pass, Confidence: c.confidence, Review: c.review}, nil
+}
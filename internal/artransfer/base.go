@@ -1,6 +1,7 @@
 package artransfer
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/artalkjs/artalk/v2/internal/dao"
@@ -13,6 +14,9 @@ func RunExportArtrans(dao *dao.Dao, params *ExportParams) (string, error) {
 	return exportArtrans(dao.DB(), params)
 }
 
+// The dry run is rolled back by this error after the import is done in the transaction
+var errImportDryRun = errors.New("import dry run")
+
 func RunImportArtrans(dao *dao.Dao, params *ImportParams, outputFunc ...func(string)) error {
 	console := NewConsole()
 	if len(outputFunc) > 0 {
@@ -20,8 +24,8 @@ func RunImportArtrans(dao *dao.Dao, params *ImportParams, outputFunc ...func(str
 	}
 	params.SetConsole(console)
 
-	// Read JSON
-	if params.JsonData == "" {
+	// Read JSON (the comments of GitHub issues can be fetched from the API)
+	if params.JsonData == "" && !(isGitHubIssuesFormat(params.Format) && params.GitHubRepo != "") {
 		if params.JsonFile == "" {
 			err := fmt.Errorf(i18n.T("{{name}} is required", map[string]any{"name": "json_file:<JSON file path>"}))
 			console.Error(err)
@@ -36,18 +40,50 @@ func RunImportArtrans(dao *dao.Dao, params *ImportParams, outputFunc ...func(str
 		}
 	}
 
-	// Json to Artrans
 	comments := []*entity.Artran{}
-	if err := jsonDecodeFAS(params.JsonData, &comments); err != nil {
-		console.Error(err)
-		return err
+	switch params.Format {
+	case "", FormatArtrans:
+		// Json to Artrans
+		if err := jsonDecodeFAS(params.JsonData, &comments); err != nil {
+			console.Error(err)
+			return err
+		}
+	default:
+		// The export of other comment systems to Artrans
+		c, ok := converters[params.Format]
+		if !ok {
+			err := fmt.Errorf(i18n.T("Invalid {{name}}", map[string]any{"name": "format"}))
+			console.Error(err)
+			return err
+		}
+
+		var (
+			report ConvertReport
+			err    error
+		)
+		comments, report, err = c.Convert([]byte(params.JsonData), params)
+		if err != nil {
+			console.Error(err)
+			return err
+		}
+		report.Print(console, c.Name)
 	}
 
-	// Execute import
+	// Execute import (the changes are rolled back if dry run)
 	err := dao.DB().Transaction(func(tx *gorm.DB) error {
-		return importArtrans(tx, params, comments)
+		if err := importArtrans(tx, params, comments); err != nil {
+			return err
+		}
+		if params.DryRun {
+			return errImportDryRun
+		}
+		return nil
 	})
 
+	if errors.Is(err, errImportDryRun) {
+		console.Info("[Artransfer] ", i18n.T("Dry run, no changes are saved"))
+		return nil
+	}
 	if err != nil {
 		console.Error("[Artransfer] ", i18n.T("Import failed"), ": ", err)
 	} else {
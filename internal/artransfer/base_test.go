@@ -11,6 +11,7 @@ import (
 	"github.com/artalkjs/artalk/v2/internal/db"
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/test"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -202,3 +203,101 @@ func rebuildAutoIncrementIDs(jsonStr string) string {
 
 	return jsonStr
 }
+
+func TestRunIncrementalExportAndImport(t *testing.T) {
+	newDao := func(t *testing.T) *dao.Dao {
+		ddb, _ := db.NewTestDB()
+		t.Cleanup(func() { db.CloseDB(ddb) })
+		return dao.NewDao(ddb)
+	}
+	importJSON := func(t *testing.T, dao *dao.Dao, data string, onConflict string) {
+		assert.NoError(t, RunImportArtrans(dao, &ImportParams{JsonData: data, OnConflict: onConflict, Assumeyes: true}))
+	}
+	findContents := func(dao *dao.Dao) []string {
+		var contents []string
+		dao.DB().Model(&entity.Comment{}).Order("id ASC").Pluck("content", &contents)
+		return contents
+	}
+
+	const base = `[
+		{"id": "1", "content": "Root", "nick": "Alice", "email": "alice@example.com", "page_key": "/1/", "site_name": "Site",
+		 "created_at": "2024-01-01 08:00:00 +0000", "updated_at": "2024-01-01 08:00:00 +0000"},
+		{"id": "2", "rid": "1", "content": "Reply", "nick": "Bob", "email": "bob@example.com", "page_key": "/1/", "site_name": "Site",
+		 "created_at": "2024-01-02 08:00:00 +0000", "updated_at": "2024-01-02 08:00:00 +0000"}
+	]`
+
+	// The source instance is changed after the target is synced
+	// (the test DBs share the same memory, so the source is closed before the targets are created)
+	var changes []entity.Artran
+	t.Run("Export since", func(t *testing.T) {
+		source := newDao(t)
+		importJSON(t, source, base, "")
+		source.DB().Model(&entity.Comment{}).Where("content = ?", "Reply").UpdateColumns(map[string]any{
+			"content": "Reply (edited)", "updated_at": parseDate("2024-02-01 08:00:00 +0000")})
+		importJSON(t, source, `[
+			{"id": "1", "content": "Root", "nick": "Alice", "email": "alice@example.com", "page_key": "/1/", "site_name": "Site",
+			 "created_at": "2024-01-01 08:00:00 +0000", "updated_at": "2024-01-01 08:00:00 +0000"},
+			{"id": "3", "rid": "1", "content": "New reply", "nick": "Carol", "email": "carol@example.com", "page_key": "/1/", "site_name": "Site",
+			 "created_at": "2024-02-02 08:00:00 +0000", "updated_at": "2024-02-02 08:00:00 +0000"}
+		]`, ConflictSkip)
+		assert.Equal(t, []string{"Root", "Reply (edited)", "New reply"}, findContents(source))
+
+		data, err := RunExportArtrans(source, &ExportParams{Since: "2024-01-15"})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, json.Unmarshal([]byte(data), &changes))
+		assert.Equal(t, []string{"Root", "Reply (edited)", "New reply"}, lo.Map(changes, func(c entity.Artran, _ int) string { return c.Content }),
+			"should include the ancestors of the changed comments")
+
+		data, err = RunExportArtrans(source, &ExportParams{SinceID: 2})
+		if assert.NoError(t, err) {
+			var newComments []entity.Artran
+			json.Unmarshal([]byte(data), &newComments)
+			assert.Len(t, newComments, 2, "should export the new comment and its parent")
+		}
+
+		_, err = RunExportArtrans(source, &ExportParams{Since: "invalid"})
+		assert.Error(t, err)
+	})
+
+	changesJSON, _ := json.Marshal(changes)
+	for _, tc := range []struct {
+		onConflict string
+		expected   []string
+	}{
+		{ConflictSkip, []string{"Root", "Reply", "New reply"}},
+		{ConflictMerge, []string{"Root", "Reply (edited)", "New reply"}},
+	} {
+		t.Run("Import on conflict "+tc.onConflict, func(t *testing.T) {
+			target := newDao(t)
+			importJSON(t, target, base, "")
+			importJSON(t, target, string(changesJSON), tc.onConflict)
+			assert.Equal(t, tc.expected, findContents(target))
+
+			var root, reply entity.Comment
+			target.DB().Where("content = ?", "Root").First(&root)
+			target.DB().Where("content = ?", "New reply").First(&reply)
+			assert.Equal(t, root.ID, reply.Rid, "should reply to the existing comment")
+			assert.Equal(t, root.ID, reply.RootID)
+		})
+	}
+
+	t.Run("Import on conflict overwrite", func(t *testing.T) {
+		target := newDao(t)
+		importJSON(t, target, base, "")
+		target.DB().Model(&entity.Comment{}).Where("content = ?", "Root").UpdateColumns(map[string]any{
+			"content": "Root (edited in target)", "updated_at": parseDate("2024-03-01 08:00:00 +0000")})
+
+		importJSON(t, target, string(changesJSON), ConflictMerge)
+		assert.Equal(t, "Root (edited in target)", findContents(target)[0], "should keep the later change of merge")
+
+		importJSON(t, target, string(changesJSON), ConflictOverwrite)
+		assert.Equal(t, []string{"Root", "Reply (edited)", "New reply"}, findContents(target))
+	})
+
+	t.Run("Invalid on conflict", func(t *testing.T) {
+		err := RunImportArtrans(newDao(t), &ImportParams{JsonData: base, OnConflict: "invalid", Assumeyes: true})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,197 @@
+package artransfer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/samber/lo"
+	"github.com/tidwall/gjson"
+)
+
+// The formats of import data
+const (
+	FormatArtrans    = "artrans"
+	FormatDisqus     = "disqus"
+	FormatWordPress  = "wordpress"
+	FormatWaline     = "waline"
+	FormatTwikoo     = "twikoo"
+	FormatValine     = "valine"
+	FormatGitalk     = "gitalk"
+	FormatUtterances = "utterances"
+)
+
+// The converter of the export of other comment system to Artrans
+type Converter struct {
+	Name    string // The name of comment system
+	Summary string // The description of the export data
+	Convert func(data []byte, params *ImportParams) ([]*entity.Artran, ConvertReport, error)
+}
+
+var converters = map[string]Converter{
+	FormatDisqus: {"Disqus", "the Disqus export XML", func(data []byte, _ *ImportParams) ([]*entity.Artran, ConvertReport, error) {
+		return DisqusToArtrans(data)
+	}},
+	FormatWordPress: {"WordPress", "the WordPress export XML (WXR)", func(data []byte, p *ImportParams) ([]*entity.Artran, ConvertReport, error) {
+		return WordPressToArtrans(data, WordPressOptions{Permalink: p.WPPermalink, ImportSpam: p.WPImportSpam})
+	}},
+	FormatWaline: {"Waline", "the Waline JSON export, SQLite database or MySQL dump", func(data []byte, _ *ImportParams) ([]*entity.Artran, ConvertReport, error) {
+		return WalineToArtrans(data)
+	}},
+	FormatTwikoo: {"Twikoo", "the Twikoo JSON export", func(data []byte, _ *ImportParams) ([]*entity.Artran, ConvertReport, error) {
+		return TwikooToArtrans(data)
+	}},
+	FormatValine: {"Valine", "the LeanCloud JSON export of Valine", func(data []byte, _ *ImportParams) ([]*entity.Artran, ConvertReport, error) {
+		return ValineToArtrans(data)
+	}},
+	FormatGitalk:     {"Gitalk", "the GitHub issues of Gitalk", convertGitHubIssues},
+	FormatUtterances: {"Utterances", "the GitHub issues of Utterances", convertGitHubIssues},
+}
+
+// Get the converter of the format, false if the format is not supported
+func GetConverter(format string) (Converter, bool) {
+	c, ok := converters[format]
+	return c, ok
+}
+
+// The report of converting the export of other comment systems to Artrans
+type ConvertReport struct {
+	Pages    int            // The pages which have comments
+	Comments int            // The comments to import
+	Replies  int            // The comments which reply to another comment
+	Pending  int            // The comments which are imported as pending (e.g. not approved or spam)
+	Spam     int            // The comments marked as spam
+	Skipped  map[string]int // The number of skipped comments by the reason (e.g. "deleted", "orphan")
+}
+
+// Print the report as a table
+func (r ConvertReport) Print(console *Console, source string) {
+	rows := [][]any{
+		{i18n.T("Page"), fmt.Sprint(r.Pages)},
+		{i18n.T("Comment count"), fmt.Sprint(r.Comments)},
+		{"Replies", fmt.Sprint(r.Replies)},
+		{i18n.T("Pending"), fmt.Sprint(r.Pending)},
+		{"Spam", fmt.Sprint(r.Spam)},
+	}
+
+	reasons := make([]string, 0, len(r.Skipped))
+	for reason := range r.Skipped {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		rows = append(rows, []any{"Skipped (" + reason + ")", fmt.Sprint(r.Skipped[reason])})
+	}
+
+	console.Println()
+	console.Print("# " + source + ":\n\n")
+	console.PrintTable(rows)
+}
+
+// Find the nearest ancestor which is imported, empty if not found
+func findImportedParent(id string, parentOf map[string]string, isImported func(id string) bool) string {
+	visited := map[string]bool{id: true}
+	for parent := parentOf[id]; parent != "" && !visited[parent]; parent = parentOf[parent] {
+		visited[parent] = true
+		if isImported(parent) {
+			return parent
+		}
+	}
+	return ""
+}
+
+// The fields of comment record in the JSON export, the first non-empty path is used
+type jsonCommentFields struct {
+	ID        []string
+	Parent    []string // The parent comment ID (e.g. `pid`), or the root comment ID (e.g. `rid`) as the fallback
+	Content   []string
+	Nick      []string
+	Email     []string
+	Link      []string
+	IP        []string
+	UA        []string
+	PageKey   []string
+	PageTitle []string
+	CreatedAt []string
+	UpdatedAt []string
+	Pinned    []string
+	Likes     []string // The number of likes, or the array of users who like
+
+	// Get the status of comment: "approved", "pending" or "spam"
+	Status func(r gjson.Result) string
+}
+
+// Convert the comment records of JSON export to Artrans, the spams are imported as pending
+func convertJSONComments(records []gjson.Result, fields jsonCommentFields) ([]*entity.Artran, ConvertReport) {
+	report := ConvertReport{Skipped: map[string]int{}}
+
+	ids := map[string]bool{} // the comments to import
+	parentOf := map[string]string{}
+	for _, r := range records {
+		id := getJSONString(r, fields.ID...)
+		ids[id] = id != "" && getJSONString(r, fields.PageKey...) != ""
+		if parent := getJSONString(r, fields.Parent...); parent != "0" {
+			parentOf[id] = parent
+		}
+	}
+
+	comments := []*entity.Artran{}
+	pages := map[string]bool{}
+	for _, r := range records {
+		id := getJSONString(r, fields.ID...)
+		pageKey := getJSONString(r, fields.PageKey...)
+		if id == "" || pageKey == "" {
+			report.Skipped["orphan"]++
+			continue
+		}
+
+		status := "approved"
+		if fields.Status != nil {
+			status = fields.Status(r)
+		}
+
+		likes := 0
+		for _, path := range fields.Likes {
+			if v := r.Get(path); v.IsArray() {
+				likes = len(v.Array())
+			} else if v.Exists() {
+				likes = int(v.Int())
+			}
+		}
+
+		rid := findImportedParent(id, parentOf, func(id string) bool { return ids[id] })
+		comments = append(comments, &entity.Artran{
+			ID:        id,
+			Rid:       rid,
+			Content:   getJSONString(r, fields.Content...),
+			IP:        getJSONString(r, fields.IP...),
+			UA:        getJSONString(r, fields.UA...),
+			IsPending: fmt.Sprint(status != "approved"),
+			IsPinned:  fmt.Sprint(lo.SomeBy(fields.Pinned, func(path string) bool { return r.Get(path).Bool() })),
+			VoteUp:    fmt.Sprint(likes),
+			CreatedAt: getJSONDate(r, fields.CreatedAt...),
+			UpdatedAt: getJSONDate(r, fields.UpdatedAt...),
+			Nick:      getJSONString(r, fields.Nick...),
+			Email:     getJSONString(r, fields.Email...),
+			Link:      getJSONString(r, fields.Link...),
+			PageKey:   pageKey,
+			PageTitle: getJSONString(r, fields.PageTitle...),
+		})
+
+		pages[pageKey] = true
+		report.Comments++
+		if rid != "" {
+			report.Replies++
+		}
+		if status != "approved" {
+			report.Pending++
+		}
+		if status == "spam" {
+			report.Spam++
+		}
+	}
+	report.Pages = len(pages)
+
+	return comments, report
+}
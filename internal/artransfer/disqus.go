@@ -0,0 +1,139 @@
+package artransfer
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+)
+
+// The export of Disqus (Admin - Moderation - Export)
+//
+// @link https://help.disqus.com/en/articles/1717164-comments-export
+type disqusExport struct {
+	Threads []disqusThread `xml:"thread"`
+	Posts   []disqusPost   `xml:"post"`
+}
+
+type disqusThread struct {
+	DsqID string `xml:"id,attr"`
+	Forum string `xml:"forum"` // The shortname of forum, which is the fallback site name
+	Link  string `xml:"link"`
+	Title string `xml:"title"`
+}
+
+type disqusPost struct {
+	DsqID      string       `xml:"id,attr"`
+	Message    string       `xml:"message"`
+	CreatedAt  string       `xml:"createdAt"`
+	IsDeleted  bool         `xml:"isDeleted"`
+	IsSpam     bool         `xml:"isSpam"`
+	IsApproved *bool        `xml:"isApproved"` // not in all exports, approved if absent
+	Author     disqusAuthor `xml:"author"`
+	IP         string       `xml:"ipAddress"`
+	Thread     disqusRef    `xml:"thread"`
+	Parent     *disqusRef   `xml:"parent"`
+}
+
+type disqusAuthor struct {
+	Name     string `xml:"name"`
+	Email    string `xml:"email"`
+	Username string `xml:"username"`
+	Link     string `xml:"link"`
+}
+
+type disqusRef struct {
+	DsqID string `xml:"id,attr"`
+}
+
+// Convert the Disqus export XML to Artrans
+//
+// The threads are mapped to pages by the link, the deleted comments are skipped and their replies
+// are attached to the nearest existing ancestor. The spam and unapproved comments are imported as pending.
+func DisqusToArtrans(data []byte) ([]*entity.Artran, ConvertReport, error) {
+	report := ConvertReport{Skipped: map[string]int{}}
+
+	var export disqusExport
+	if err := xml.Unmarshal(data, &export); err != nil {
+		return nil, report, fmt.Errorf("failed to parse Disqus XML: %w", err)
+	}
+
+	threads := map[string]disqusThread{}
+	for _, t := range export.Threads {
+		threads[t.DsqID] = t
+	}
+
+	posts := map[string]disqusPost{}
+	parentOf := map[string]string{}
+	for _, p := range export.Posts {
+		posts[p.DsqID] = p
+		if p.Parent != nil {
+			parentOf[p.DsqID] = p.Parent.DsqID
+		}
+	}
+	isImported := func(id string) bool {
+		p, ok := posts[id]
+		return ok && !p.IsDeleted
+	}
+
+	comments := []*entity.Artran{}
+	threadsWithComments := map[string]bool{}
+	for _, p := range export.Posts {
+		if p.IsDeleted {
+			report.Skipped["deleted"]++
+			continue
+		}
+
+		thread, ok := threads[p.Thread.DsqID]
+		if !ok || strings.TrimSpace(thread.Link) == "" {
+			report.Skipped["orphan"]++
+			continue
+		}
+
+		isPending := p.IsSpam || (p.IsApproved != nil && !*p.IsApproved)
+		rid := findImportedParent(p.DsqID, parentOf, isImported)
+
+		comments = append(comments, &entity.Artran{
+			ID:        p.DsqID,
+			Rid:       rid,
+			Content:   strings.TrimSpace(p.Message),
+			IP:        p.IP,
+			IsPending: fmt.Sprint(isPending),
+			CreatedAt: p.CreatedAt,
+			Nick:      strings.TrimSpace(cmp.Or(p.Author.Name, p.Author.Username)),
+			Email:     strings.TrimSpace(p.Author.Email),
+			Link:      strings.TrimSpace(p.Author.Link),
+			PageKey:   strings.TrimSpace(thread.Link),
+			PageTitle: strings.TrimSpace(thread.Title),
+			SiteName:  thread.Forum,
+			SiteURLs:  getURLOrigin(thread.Link),
+		})
+
+		threadsWithComments[thread.DsqID] = true
+		report.Comments++
+		if rid != "" {
+			report.Replies++
+		}
+		if isPending {
+			report.Pending++
+		}
+		if p.IsSpam {
+			report.Spam++
+		}
+	}
+	report.Pages = len(threadsWithComments)
+
+	return comments, report, nil
+}
+
+// Get the origin of URL (e.g. `https://example.com`), empty if invalid
+func getURLOrigin(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,163 @@
+package artransfer
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/db"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+const testDisqusXML = `<?xml version="1.0" encoding="utf-8"?>
+<disqus xmlns="http://disqus.com" xmlns:dsq="http://disqus.com/disqus-internals">
+  <category dsq:id="1"><forum>example</forum><title>General</title><isDefault>true</isDefault></category>
+  <thread dsq:id="100">
+    <id>post-1</id>
+    <forum>example</forum>
+    <link>https://example.com/post/1/</link>
+    <title>Hello World</title>
+    <createdAt>2013-01-01T00:00:00Z</createdAt>
+  </thread>
+  <thread dsq:id="101">
+    <forum>example</forum>
+    <link>https://example.com/post/2/</link>
+    <title>No Comments</title>
+  </thread>
+  <post dsq:id="200">
+    <message><![CDATA[<p>First</p>]]></message>
+    <createdAt>2013-01-02T08:00:00Z</createdAt>
+    <isDeleted>false</isDeleted>
+    <isSpam>false</isSpam>
+    <author><email>alice@example.com</email><name>Alice</name><isAnonymous>false</isAnonymous><username>alice</username></author>
+    <ipAddress>1.2.3.4</ipAddress>
+    <thread dsq:id="100" />
+  </post>
+  <post dsq:id="201">
+    <message><![CDATA[<p>Deleted</p>]]></message>
+    <createdAt>2013-01-03T08:00:00Z</createdAt>
+    <isDeleted>true</isDeleted>
+    <isSpam>false</isSpam>
+    <author><name>Bob</name></author>
+    <thread dsq:id="100" />
+    <parent dsq:id="200" />
+  </post>
+  <post dsq:id="202">
+    <message><![CDATA[<p>Reply to deleted</p>]]></message>
+    <createdAt>2013-01-04T08:00:00Z</createdAt>
+    <isDeleted>false</isDeleted>
+    <isSpam>false</isSpam>
+    <author><email>carol@example.com</email><name>Carol</name></author>
+    <thread dsq:id="100" />
+    <parent dsq:id="201" />
+  </post>
+  <post dsq:id="203">
+    <message><![CDATA[Buy now]]></message>
+    <createdAt>2013-01-05T08:00:00Z</createdAt>
+    <isDeleted>false</isDeleted>
+    <isSpam>true</isSpam>
+    <author><email>spam@example.com</email><name>Spammer</name></author>
+    <thread dsq:id="100" />
+  </post>
+  <post dsq:id="204">
+    <message>Orphan</message>
+    <isDeleted>false</isDeleted>
+    <author><name>Dave</name></author>
+    <thread dsq:id="999" />
+  </post>
+</disqus>`
+
+func TestDisqusToArtrans(t *testing.T) {
+	comments, report, err := DisqusToArtrans([]byte(testDisqusXML))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, ConvertReport{Pages: 1, Comments: 3, Replies: 1, Pending: 1, Spam: 1, Skipped: map[string]int{"deleted": 1, "orphan": 1}}, report)
+	if !assert.Len(t, comments, 3) {
+		return
+	}
+
+	first := comments[0]
+	assert.Equal(t, "200", first.ID)
+	assert.Equal(t, "", first.Rid)
+	assert.Equal(t, "<p>First</p>", first.Content)
+	assert.Equal(t, "Alice", first.Nick)
+	assert.Equal(t, "alice@example.com", first.Email)
+	assert.Equal(t, "1.2.3.4", first.IP)
+	assert.Equal(t, "2013-01-02T08:00:00Z", first.CreatedAt)
+	assert.Equal(t, "https://example.com/post/1/", first.PageKey)
+	assert.Equal(t, "Hello World", first.PageTitle)
+	assert.Equal(t, "example", first.SiteName)
+	assert.Equal(t, "https://example.com", first.SiteURLs)
+	assert.Equal(t, "false", first.IsPending)
+
+	assert.Equal(t, "200", comments[1].Rid, "should reply to the nearest existing ancestor")
+	assert.Equal(t, "true", comments[2].IsPending, "should import the spam as pending")
+
+	t.Run("Invalid XML", func(t *testing.T) {
+		_, _, err := DisqusToArtrans([]byte("<disqus>"))
+		assert.Error(t, err)
+	})
+}
+
+func TestRunImportDisqus(t *testing.T) {
+	newDao := func(t *testing.T) *dao.Dao {
+		ddb, _ := db.NewTestDB()
+		t.Cleanup(func() { db.CloseDB(ddb) })
+		return dao.NewDao(ddb)
+	}
+
+	t.Run("Import", func(t *testing.T) {
+		dao := newDao(t)
+		err := RunImportArtrans(dao, &ImportParams{
+			JsonData:       testDisqusXML,
+			Format:         FormatDisqus,
+			TargetSiteName: "Site",
+			Assumeyes:      true,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var comments []entity.Comment
+		dao.DB().Order("id ASC").Find(&comments)
+		if !assert.Len(t, comments, 3) {
+			return
+		}
+		assert.Equal(t, "/post/1/", comments[0].PageKey, "should strip the domain of page key")
+		assert.Equal(t, "Site", comments[0].SiteName)
+		assert.Equal(t, comments[0].ID, comments[1].Rid, "should keep the reply relationship")
+		assert.Equal(t, comments[0].ID, comments[1].RootID)
+		assert.True(t, comments[2].IsPending)
+		assert.Equal(t, 2013, comments[0].CreatedAt.Year(), "should keep the timestamp")
+
+		page := dao.FindPage("/post/1/", "Site")
+		assert.Equal(t, "Hello World", page.Title)
+
+		user := dao.FindUser("Carol", "carol@example.com")
+		assert.False(t, user.IsEmpty())
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		dao := newDao(t)
+		err := RunImportArtrans(dao, &ImportParams{
+			JsonData:       testDisqusXML,
+			Format:         FormatDisqus,
+			TargetSiteName: "Site",
+			DryRun:         true,
+		})
+		assert.NoError(t, err)
+
+		var count int64
+		dao.DB().Model(&entity.Comment{}).Count(&count)
+		assert.Zero(t, count, "should not save the comments")
+		dao.DB().Model(&entity.Site{}).Count(&count)
+		assert.Zero(t, count, "should not save the sites")
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		err := RunImportArtrans(newDao(t), &ImportParams{JsonData: "[]", Format: "unknown"})
+		assert.Error(t, err)
+	})
+}
@@ -2,26 +2,51 @@ package artransfer
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
 	"github.com/artalkjs/artalk/v2/internal/utils"
 	"gorm.io/gorm"
 )
 
 type ExportParams struct {
 	SiteNameScope []string `json:"site_name_scope"`
+
+	// Incremental export, only the comments created or updated since the time (e.g. `2024-01-01 08:00:00 +0800`)
+	// and whose ID is greater than `SinceID` are exported, the ancestors of them are included to keep the replies
+	Since   string `json:"since"`
+	SinceID uint   `json:"since_id"`
 }
 
 func exportArtrans(db *gorm.DB, params *ExportParams) (string, error) {
 	comments := []entity.Comment{}
 
+	var since time.Time
+	if params.Since != "" {
+		if since = parseDate(params.Since); since.IsZero() {
+			return "", fmt.Errorf(i18n.T("Invalid {{name}}", map[string]any{"name": "since"}))
+		}
+	}
+
 	db.Scopes(func(db *gorm.DB) *gorm.DB {
 		if len(params.SiteNameScope) > 0 {
 			db = db.Where("site_name IN (?)", params.SiteNameScope)
 		}
+		if !since.IsZero() {
+			db = db.Where("updated_at >= ?", since)
+		}
+		if params.SinceID != 0 {
+			db = db.Where("id > ?", params.SinceID)
+		}
 		return db
 	}).Find(&comments)
 
+	if !since.IsZero() || params.SinceID != 0 {
+		comments = append(findAncestorComments(db, comments), comments...)
+	}
+
 	artrans := []entity.Artran{}
 	cache := newExportCache()
 	for _, c := range comments {
@@ -37,6 +62,34 @@ func exportArtrans(db *gorm.DB, params *ExportParams) (string, error) {
 	return string(jsonByte), nil
 }
 
+// Find the ancestors of the comments which are not in the comments
+func findAncestorComments(db *gorm.DB, comments []entity.Comment) []entity.Comment {
+	found := map[uint]bool{}
+	for _, c := range comments {
+		found[c.ID] = true
+	}
+
+	ancestors := []entity.Comment{}
+	for current := comments; len(current) > 0; {
+		parentIDs := []uint{}
+		for _, c := range current {
+			if c.Rid != 0 && !found[c.Rid] {
+				found[c.Rid] = true
+				parentIDs = append(parentIDs, c.Rid)
+			}
+		}
+		if len(parentIDs) == 0 {
+			break
+		}
+
+		current = []entity.Comment{}
+		db.Where("id IN ?", parentIDs).Find(&current)
+		ancestors = append(current, ancestors...) // the upper ancestors first
+	}
+
+	return ancestors
+}
+
 type exportCache struct {
 	Users map[uint]entity.User
 	Pages map[string]entity.Page
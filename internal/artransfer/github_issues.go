@@ -0,0 +1,196 @@
+package artransfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+// The GitHub issue which is created by Gitalk or Utterances for a page
+type githubIssue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	CommentsURL string          `json:"comments_url"`
+	PullRequest json.RawMessage `json:"pull_request"`
+
+	// The number of comments in the API, or the comments in the exported file
+	Comments json.RawMessage `json:"comments"`
+
+	comments []githubComment
+}
+
+type githubComment struct {
+	ID        int64  `json:"id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	User      struct {
+		Login   string `json:"login"`
+		HTMLURL string `json:"html_url"`
+	} `json:"user"`
+	Reactions struct {
+		PlusOne int `json:"+1"`
+	} `json:"reactions"`
+}
+
+// The pull requests are also listed in the issues of GitHub API
+func (issue githubIssue) isPullRequest() bool {
+	return len(issue.PullRequest) > 0 && string(issue.PullRequest) != "null"
+}
+
+// The base URL of GitHub API
+var githubAPIBaseURL = "https://api.github.com"
+
+func isGitHubIssuesFormat(format string) bool {
+	return format == FormatGitalk || format == FormatUtterances
+}
+
+// Convert the GitHub issues of Gitalk or Utterances to Artrans
+//
+// The issues are fetched from the repository by the API if `github_repo` is set, otherwise the data
+// is the JSON array of issues whose `comments` field is the array of comments.
+func convertGitHubIssues(data []byte, params *ImportParams) ([]*entity.Artran, ConvertReport, error) {
+	var (
+		issues []githubIssue
+		err    error
+	)
+	if params.GitHubRepo != "" {
+		issues, err = fetchGitHubIssues(params.GitHubRepo, params.GitHubToken, params.GitHubLabels)
+	} else {
+		err = json.Unmarshal(data, &issues)
+		for i := range issues {
+			_ = json.Unmarshal(issues[i].Comments, &issues[i].comments)
+		}
+	}
+	if err != nil {
+		return nil, ConvertReport{}, fmt.Errorf("failed to load GitHub issues: %w", err)
+	}
+
+	comments, report := githubIssuesToArtrans(issues)
+	return comments, report, nil
+}
+
+var githubIssueURLRegexp = regexp.MustCompile(`https?://[^\s)\]"'<>]+`)
+
+// Convert the GitHub issues to Artrans, each issue is a page and the issue comments are the page comments
+//
+// The page key is the first URL in the issue body (both Gitalk and Utterances put the page URL in the body),
+// or the issue title if it is the page path (the `pathname` issue term of Utterances).
+func githubIssuesToArtrans(issues []githubIssue) ([]*entity.Artran, ConvertReport) {
+	report := ConvertReport{Skipped: map[string]int{}}
+
+	comments := []*entity.Artran{}
+	for _, issue := range issues {
+		if issue.isPullRequest() {
+			continue
+		}
+
+		pageKey := githubIssueURLRegexp.FindString(issue.Body)
+		if pageKey == "" && strings.HasPrefix(issue.Title, "/") {
+			pageKey = issue.Title
+		}
+		if pageKey == "" {
+			report.Skipped["orphan"] += len(issue.comments)
+			continue
+		}
+
+		for _, c := range issue.comments {
+			login := strings.TrimSpace(c.User.Login)
+			comments = append(comments, &entity.Artran{
+				ID:        fmt.Sprint(c.ID),
+				Content:   strings.TrimSpace(c.Body),
+				IsPending: "false",
+				VoteUp:    fmt.Sprint(c.Reactions.PlusOne),
+				CreatedAt: c.CreatedAt,
+				UpdatedAt: c.UpdatedAt,
+				Nick:      login,
+				Email:     login + "@users.noreply.github.com",
+				Link:      c.User.HTMLURL,
+				PageKey:   pageKey,
+				PageTitle: strings.TrimSpace(issue.Title),
+			})
+			report.Comments++
+		}
+		if len(issue.comments) > 0 {
+			report.Pages++
+		}
+	}
+
+	return comments, report
+}
+
+// Fetch the issues and their comments of the repository (e.g. `owner/repo`) by the GitHub API
+func fetchGitHubIssues(repo string, token string, labels string) ([]githubIssue, error) {
+	repo = strings.Trim(strings.TrimSpace(repo), "/")
+	if strings.Count(repo, "/") != 1 {
+		return nil, fmt.Errorf("invalid GitHub repository %q, which should be `owner/repo`", repo)
+	}
+
+	query := url.Values{"state": {"all"}, "per_page": {"100"}}
+	if labels = strings.Join(utils.SplitAndTrimSpace(labels, ","), ","); labels != "" {
+		query.Set("labels", labels)
+	}
+
+	issues := []githubIssue{}
+	if err := fetchGitHubPages(githubAPIBaseURL+"/repos/"+repo+"/issues?"+query.Encode(), token, &issues); err != nil {
+		return nil, err
+	}
+
+	for i := range issues {
+		var count int
+		if json.Unmarshal(issues[i].Comments, &count); count == 0 || issues[i].CommentsURL == "" || issues[i].isPullRequest() {
+			continue
+		}
+		if err := fetchGitHubPages(issues[i].CommentsURL+"?per_page=100", token, &issues[i].comments); err != nil {
+			return nil, err
+		}
+	}
+
+	return issues, nil
+}
+
+// Fetch all the pages of the GitHub API list
+func fetchGitHubPages[T any](apiURL string, token string, dest *[]T) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	for page := 1; page <= 100; page++ {
+		req, err := http.NewRequest(http.MethodGet, apiURL+"&page="+fmt.Sprint(page), nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		var items []T
+		err = json.NewDecoder(resp.Body).Decode(&items)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("GitHub API responded with status %d", resp.StatusCode)
+		}
+		if err != nil {
+			return err
+		}
+
+		*dest = append(*dest, items...)
+		if len(items) < 100 {
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package artransfer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/db"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGitHubServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var data any
+		switch r.URL.Path {
+		case "/repos/owner/repo/issues":
+			assert.Equal(t, "Gitalk", r.URL.Query().Get("labels"))
+			assert.Equal(t, "all", r.URL.Query().Get("state"))
+			data = []map[string]any{
+				{"number": 1, "title": "Hello World", "body": "https://example.com/post/1/\n\nThe description", "comments": 2,
+					"comments_url": server.URL + "/repos/owner/repo/issues/1/comments"},
+				{"number": 2, "title": "Pull Request", "body": "https://example.com/", "comments": 1,
+					"comments_url": server.URL + "/repos/owner/repo/issues/2/comments", "pull_request": map[string]any{}},
+				{"number": 3, "title": "No Comments", "body": "https://example.com/post/3/", "comments": 0},
+			}
+		case "/repos/owner/repo/issues/1/comments":
+			data = []map[string]any{
+				{"id": 101, "body": "First", "created_at": "2013-01-01T00:00:00Z", "updated_at": "2013-01-02T00:00:00Z",
+					"user": map[string]any{"login": "alice", "html_url": "https://github.com/alice"}, "reactions": map[string]any{"+1": 2}},
+				{"id": 102, "body": "Second", "created_at": "2013-01-03T00:00:00Z",
+					"user": map[string]any{"login": "bob", "html_url": "https://github.com/bob"}},
+			}
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(data)
+	}))
+	t.Cleanup(server.Close)
+
+	original := githubAPIBaseURL
+	githubAPIBaseURL = server.URL
+	t.Cleanup(func() { githubAPIBaseURL = original })
+
+	return server
+}
+
+func TestGitHubIssuesToArtrans(t *testing.T) {
+	t.Run("Fetch by API", func(t *testing.T) {
+		newTestGitHubServer(t)
+
+		comments, report, err := convertGitHubIssues(nil, &ImportParams{GitHubRepo: "owner/repo", GitHubToken: "token", GitHubLabels: "Gitalk"})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, ConvertReport{Pages: 1, Comments: 2, Skipped: map[string]int{}}, report)
+		if !assert.Len(t, comments, 2) {
+			return
+		}
+
+		first := comments[0]
+		assert.Equal(t, "101", first.ID)
+		assert.Equal(t, "First", first.Content)
+		assert.Equal(t, "alice", first.Nick)
+		assert.Equal(t, "alice@users.noreply.github.com", first.Email)
+		assert.Equal(t, "https://github.com/alice", first.Link)
+		assert.Equal(t, "2", first.VoteUp)
+		assert.Equal(t, "2013-01-01T00:00:00Z", first.CreatedAt)
+		assert.Equal(t, "https://example.com/post/1/", first.PageKey, "should use the URL in the issue body")
+		assert.Equal(t, "Hello World", first.PageTitle)
+		assert.Equal(t, "false", first.IsPending)
+	})
+
+	t.Run("Exported file", func(t *testing.T) {
+		comments, report, err := convertGitHubIssues([]byte(`[
+			{"number": 1, "title": "/post/1/", "body": "", "comments": [{"id": 101, "body": "Hi", "user": {"login": "alice"}}]},
+			{"number": 2, "title": "Unknown", "body": "", "comments": [{"id": 201, "body": "Lost"}]}
+		]`), &ImportParams{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, ConvertReport{Pages: 1, Comments: 1, Skipped: map[string]int{"orphan": 1}}, report)
+		if assert.Len(t, comments, 1) {
+			assert.Equal(t, "/post/1/", comments[0].PageKey, "should use the pathname in the issue title")
+		}
+	})
+
+	t.Run("Invalid repository", func(t *testing.T) {
+		_, _, err := convertGitHubIssues(nil, &ImportParams{GitHubRepo: "repo"})
+		assert.Error(t, err)
+	})
+}
+
+func TestRunImportGitHubIssues(t *testing.T) {
+	newTestGitHubServer(t)
+
+	ddb, _ := db.NewTestDB()
+	defer db.CloseDB(ddb)
+	dao := dao.NewDao(ddb)
+
+	err := RunImportArtrans(dao, &ImportParams{
+		Format:         FormatGitalk,
+		GitHubRepo:     "owner/repo",
+		GitHubToken:    "token",
+		GitHubLabels:   "Gitalk",
+		TargetSiteName: "Site",
+		Assumeyes:      true,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var comments []entity.Comment
+	dao.DB().Order("id ASC").Find(&comments)
+	if assert.Len(t, comments, 2) {
+		assert.Equal(t, "/post/1/", comments[0].PageKey)
+		assert.Equal(t, 2, comments[0].VoteUp)
+	}
+	assert.False(t, dao.FindUser("alice", "alice@users.noreply.github.com").IsEmpty())
+}
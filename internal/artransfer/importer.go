@@ -17,17 +17,32 @@ import (
 )
 
 type ImportParams struct {
-	TargetSiteName string `json:"target_site_name" form:"target_site_name" validate:"optional"` // The target site name
-	TargetSiteURL  string `json:"target_site_url" form:"target_site_url" validate:"optional"`   // The target site url
-	URLResolver    bool   `json:"url_resolver" form:"url_resolver" validate:"optional"`         // Enable URL resolver
-	URLKeepDomain  bool   `json:"url_keep_domain" form:"url_keep_domain" validate:"optional"`   // Keep domain
-	JsonFile       string `json:"json_file,omitempty" form:"json_file" validate:"optional"`     // The JSON file path
-	JsonData       string `json:"json_data,omitempty" form:"json_data" validate:"optional"`     // The JSON data
-	Assumeyes      bool   `json:"assumeyes" form:"assumeyes" validate:"optional"`               // Automatically answer yes for all questions
+	TargetSiteName string `json:"target_site_name" form:"target_site_name" validate:"optional"`                                                     // The target site name
+	TargetSiteURL  string `json:"target_site_url" form:"target_site_url" validate:"optional"`                                                       // The target site url
+	URLResolver    bool   `json:"url_resolver" form:"url_resolver" validate:"optional"`                                                             // Enable URL resolver
+	URLKeepDomain  bool   `json:"url_keep_domain" form:"url_keep_domain" validate:"optional"`                                                       // Keep domain
+	JsonFile       string `json:"json_file,omitempty" form:"json_file" validate:"optional"`                                                         // The JSON file path
+	JsonData       string `json:"json_data,omitempty" form:"json_data" validate:"optional"`                                                         // The JSON data
+	Format         string `json:"format" form:"format" enums:"artrans,disqus,wordpress,waline,twikoo,valine,gitalk,utterances" validate:"optional"` // The format of data (default: artrans), the `json_file` and `json_data` are the export of the comment system if the format is not artrans
+	DryRun         bool   `json:"dry_run" form:"dry_run" validate:"optional"`                                                                       // Report the import without saving the changes
+	OnConflict     string `json:"on_conflict" form:"on_conflict" enums:"skip,overwrite,merge" validate:"optional"`                                  // How to resolve the comments which already exist (the same site, page, user and created time), all the comments are created if empty
+	WPPermalink    string `json:"wp_permalink" form:"wp_permalink" validate:"optional"`                                                             // The permalink structure of WordPress posts (e.g. `/%year%/%monthnum%/%postname%/`), the link of post is used if empty
+	WPImportSpam   bool   `json:"wp_import_spam" form:"wp_import_spam" validate:"optional"`                                                         // Import the spam and trashed comments of WordPress as pending
+	GitHubRepo     string `json:"github_repo" form:"github_repo" validate:"optional"`                                                               // The GitHub repository (e.g. `owner/repo`) to fetch the issues of Gitalk or Utterances
+	GitHubToken    string `json:"github_token,omitempty" form:"github_token" validate:"optional"`                                                   // The GitHub token to fetch the issues (optional, for the private repository and the higher rate limit)
+	GitHubLabels   string `json:"github_labels" form:"github_labels" validate:"optional"`                                                           // Only fetch the issues with the labels, separated by commas (e.g. `Gitalk`)
+	Assumeyes      bool   `json:"assumeyes" form:"assumeyes" validate:"optional"`                                                                   // Automatically answer yes for all questions
 
 	console *Console `json:"-"`
 }
 
+// The ways to resolve the imported comment which already exists
+const (
+	ConflictSkip      = "skip"      // Keep the existing comment
+	ConflictOverwrite = "overwrite" // Replace the existing comment with the imported one
+	ConflictMerge     = "merge"     // Replace the existing comment if the imported one is updated later
+)
+
 func (p *ImportParams) SetConsole(c *Console) {
 	p.console = c
 }
@@ -51,6 +66,10 @@ func importArtrans(tx *gorm.DB, params *ImportParams, comments []*entity.Artran)
 		return fmt.Errorf(i18n.T("Invalid {{name}}", map[string]interface{}{"name": i18n.T("Target Site") + " " + "URL"}))
 	}
 
+	if !lo.Contains([]string{"", ConflictSkip, ConflictOverwrite, ConflictMerge}, params.OnConflict) {
+		return fmt.Errorf(i18n.T("Invalid {{name}}", map[string]interface{}{"name": "on_conflict"}))
+	}
+
 	console.Println()
 	console.Print("# " + i18n.T("Please review") + ":\n\n")
 
@@ -68,7 +87,7 @@ func importArtrans(tx *gorm.DB, params *ImportParams, comments []*entity.Artran)
 	console.Println()
 
 	// Confirm to continue
-	if !params.Assumeyes && !console.Confirm(i18n.T("Confirm to continue?")) {
+	if !params.Assumeyes && !params.DryRun && !console.Confirm(i18n.T("Confirm to continue?")) {
 		os.Exit(0)
 	}
 
@@ -78,10 +97,14 @@ func importArtrans(tx *gorm.DB, params *ImportParams, comments []*entity.Artran)
 	//  Start importing
 	// ---------------------
 	importComments := []*entity.Comment{}
+	importGenIds := []uint{}                                         // The GenId of each comment in importComments
 	rawId2GenId := buildGenIdMap(comments)                           // Original ID => GenId (GenId is comment index +1)
 	rawRid2RootGenId := buildRid2RootGenIdMap(comments, rawId2GenId) // Original Rid => RootGenId
-	createdDates := map[int]time.Time{}
-	updatedDates := map[int]time.Time{}
+	createdDates := map[uint]time.Time{}                             // GenId => CreatedAt
+	updatedDates := map[uint]time.Time{}                             // GenId => UpdatedAt
+	genId2DBRealIdMap := map[uint]uint{}                             // GenId => DBRealId
+	genId2DBRootIdMap := map[uint]uint{}                             // GenId => the DBRealId of root comment
+	conflicts := map[string]int{}                                    // The number of existing comments by the resolution
 
 	for i, c := range comments {
 		// ---------------------
@@ -191,15 +214,33 @@ func importArtrans(tx *gorm.DB, params *ImportParams, comments []*entity.Artran)
 		}
 
 		// Prepare slices for restoring CreatedAt and UpdatedAt
-		createdDates[i] = parseDate(c.CreatedAt)
+		genId := uint(i + 1)
+		createdDates[genId] = parseDate(c.CreatedAt)
 		if c.UpdatedAt != "" {
-			updatedDates[i] = parseDate(c.UpdatedAt)
+			updatedDates[genId] = parseDate(c.UpdatedAt)
 		} else {
-			updatedDates[i] = parseDate(c.CreatedAt)
+			updatedDates[genId] = parseDate(c.CreatedAt)
+		}
+
+		// Resolve the conflict if the comment already exists (e.g. sync from another instance)
+		if params.OnConflict != "" {
+			existing := findConflictComment(tx, site.Name, page.Key, user.ID, createdDates[genId])
+			if !existing.IsEmpty() {
+				genId2DBRealIdMap[genId] = existing.ID
+				genId2DBRootIdMap[genId] = cmp.Or(existing.RootID, existing.ID)
+
+				resolution, err := resolveConflictComment(tx, params.OnConflict, existing, &nComment, updatedDates[genId])
+				if err != nil {
+					return fmt.Errorf("failed to update comment, %w", err)
+				}
+				conflicts[resolution]++
+				continue
+			}
 		}
 
 		// Append to importComments for batch insert
 		importComments = append(importComments, &nComment)
+		importGenIds = append(importGenIds, genId)
 	}
 
 	console.Println(i18n.T("Importing") + "...")
@@ -209,14 +250,16 @@ func importArtrans(tx *gorm.DB, params *ImportParams, comments []*entity.Artran)
 	//  Batch insert
 	// ---------------------
 	// @link https://gorm.io/docs/create.html#Batch-Insert
-	if err := tx.CreateInBatches(&importComments, 100).Error; err != nil {
+	if len(importComments) == 0 {
+		// all the comments already exist
+	} else if err := tx.CreateInBatches(&importComments, 100).Error; err != nil {
 		return fmt.Errorf("failed to batch insert comments, %w", err)
 	}
 
 	// GenId => DBRealId Mapping
-	genId2DBRealIdMap := map[uint]uint{}
 	for i, savedComment := range importComments {
-		genId2DBRealIdMap[uint(i+1)] = savedComment.ID // [M_Step.2] Create GenId => DBRealId Map
+		genId2DBRealIdMap[importGenIds[i]] = savedComment.ID // [M_Step.2] Create GenId => DBRealId Map
+		genId2DBRootIdMap[importGenIds[i]] = savedComment.ID
 	}
 
 	// Progress bar
@@ -237,14 +280,14 @@ func importArtrans(tx *gorm.DB, params *ImportParams, comments []*entity.Artran)
 		// savedComment.UpdatedAt = updatedDates[i]
 
 		updateData := map[string]interface{}{
-			"CreatedAt": createdDates[i],
-			"UpdatedAt": updatedDates[i],
+			"CreatedAt": createdDates[importGenIds[i]],
+			"UpdatedAt": updatedDates[importGenIds[i]],
 		}
 
 		// Rebuild Rid
 		if savedComment.Rid != 0 {
 			updateData["Rid"] = genId2DBRealIdMap[savedComment.Rid] // [M_Step.3] GenId => DBRealId
-			updateData["RootID"] = genId2DBRealIdMap[savedComment.RootID]
+			updateData["RootID"] = genId2DBRootIdMap[savedComment.RootID]
 		}
 
 		// Perform update
@@ -296,11 +339,49 @@ func importArtrans(tx *gorm.DB, params *ImportParams, comments []*entity.Artran)
 
 	// Done
 	console.Println()
+	if params.OnConflict != "" {
+		console.PrintTable([][]interface{}{
+			{"Created", fmt.Sprint(len(importComments))},
+			{"Updated (existing)", fmt.Sprint(conflicts["updated"])},
+			{"Skipped (existing)", fmt.Sprint(conflicts["skipped"])},
+		})
+		console.Println()
+	}
 	console.Info(i18n.T("{{count}} items imported", map[string]interface{}{"count": len(comments)}))
 
 	return nil
 }
 
+// Resolve the conflict of the imported comment and the existing comment, "updated" or "skipped" is returned
+//
+// The content, the status and the updated time of the existing comment are replaced,
+// the reply relationship and the votes are kept.
+func resolveConflictComment(tx *gorm.DB, onConflict string, existing entity.Comment, imported *entity.Comment, updatedAt time.Time) (string, error) {
+	switch onConflict {
+	case ConflictMerge:
+		if !updatedAt.After(existing.UpdatedAt) {
+			return "skipped", nil // the existing comment is the latest
+		}
+	case ConflictOverwrite:
+	default:
+		return "skipped", nil
+	}
+
+	err := tx.Model(&existing).Updates(map[string]interface{}{
+		"Content":     imported.Content,
+		"UA":          imported.UA,
+		"IP":          imported.IP,
+		"IsCollapsed": imported.IsCollapsed,
+		"IsPending":   imported.IsPending,
+		"IsPinned":    imported.IsPinned,
+		"UpdatedAt":   lo.Ternary(updatedAt.IsZero(), time.Now(), updatedAt),
+	}).Error
+	if err != nil {
+		return "", err
+	}
+	return "updated", nil
+}
+
 // Prepare site
 func prepareSite(tx *gorm.DB, targetSiteName string, targetSiteURLs string) (*entity.Site, error) {
 	if targetSiteName == "" {
@@ -4,6 +4,8 @@
 package artransfer
 
 import (
+	"time"
+
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"gorm.io/gorm"
 )
@@ -32,6 +34,19 @@ func findCreatePage(db *gorm.DB, pageKey string, pageTitle string, siteName stri
 	return page, nil
 }
 
+// Find the existing comment which is the same as the imported one,
+// which is identified by the site, page, user and the created time (in seconds, the precision of Artrans)
+func findConflictComment(db *gorm.DB, siteName string, pageKey string, userID uint, createdAt time.Time) entity.Comment {
+	var comment entity.Comment
+	if createdAt.IsZero() {
+		return comment
+	}
+	createdAt = createdAt.Truncate(time.Second)
+	db.Where("site_name = ? AND page_key = ? AND user_id = ? AND created_at >= ? AND created_at < ?",
+		siteName, pageKey, userID, createdAt, createdAt.Add(time.Second)).Limit(1).Find(&comment)
+	return comment
+}
+
 func findSite(db *gorm.DB, siteName string) entity.Site {
 	var site entity.Site
 	db.Where(&entity.Site{Name: siteName}).First(&site)
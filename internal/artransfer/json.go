@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/tidwall/gjson"
 )
@@ -47,3 +49,104 @@ func jsonDecodeFAS(str string, fasStructure any) error {
 
 	return nil
 }
+
+// Get the records of the JSON export, which may be an array, JSON lines (e.g. the export of
+// MongoDB or LeanCloud), or an object which has the array in one of the paths (e.g. `results`)
+func getJSONRecords(data string, paths ...string) ([]gjson.Result, error) {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return nil, fmt.Errorf("empty data")
+	}
+
+	if gjson.Valid(data) {
+		root := gjson.Parse(data)
+		if root.IsArray() {
+			return root.Array(), nil
+		}
+		for _, path := range paths {
+			if v := root.Get(path); v.IsArray() {
+				return v.Array(), nil
+			}
+		}
+		return []gjson.Result{root}, nil // the JSON lines of single record
+	}
+
+	records := []gjson.Result{}
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !gjson.Valid(line) {
+			return nil, fmt.Errorf("failed to parse JSON at line %d", i+1)
+		}
+		records = append(records, gjson.Parse(line))
+	}
+	return records, nil
+}
+
+// Get the first non-empty value of the paths as string, the MongoDB ObjectId (`{"$oid": "..."}`) is supported
+func getJSONString(r gjson.Result, paths ...string) string {
+	for _, path := range paths {
+		v := r.Get(path)
+		if v.IsObject() {
+			v = v.Get("$oid")
+		}
+		if s := strings.TrimSpace(v.String()); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// Get the first non-empty date of the paths in RFC3339
+//
+// The timestamp in seconds or milliseconds, the date string, the LeanCloud date (`{"__type": "Date", "iso": "..."}`)
+// and the MongoDB date (`{"$date": ...}`) are supported.
+func getJSONDate(r gjson.Result, paths ...string) string {
+	for _, path := range paths {
+		if date := formatJSONDate(r.Get(path)); date != "" {
+			return date
+		}
+	}
+	return ""
+}
+
+func formatJSONDate(v gjson.Result) string {
+	switch {
+	case v.IsObject():
+		for _, key := range []string{"iso", "$date", "$numberLong"} {
+			if d := v.Get(key); d.Exists() {
+				return formatJSONDate(d)
+			}
+		}
+		return ""
+	case v.Type == gjson.Number || (v.Type == gjson.String && isDigits(v.Str)):
+		n := v.Int()
+		if n <= 0 {
+			return ""
+		}
+		if n < 1e11 {
+			n *= 1000 // in seconds
+		}
+		return time.UnixMilli(n).UTC().Format(time.RFC3339)
+	default:
+		date := strings.TrimSpace(v.String())
+		if t, err := time.Parse(time.RFC3339, date); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+		return date
+	}
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package artransfer
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	sqlCreateTableRegexp = regexp.MustCompile("(?is)CREATE\\s+TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?[`\"]?(\\w+)[`\"]?\\s*\\(")
+	sqlColumnRegexp      = regexp.MustCompile("(?m)^\\s*[`\"](\\w+)[`\"]\\s")
+	sqlInsertRegexp      = regexp.MustCompile("(?is)INSERT\\s+INTO\\s+[`\"]?(\\w+)[`\"]?\\s*(\\([^)]*\\))?\\s*VALUES\\s*")
+)
+
+// Parse the rows of the tables from the INSERT statements of SQL dump (e.g. `mysqldump`)
+//
+// The columns are from the column list of INSERT statement, or the CREATE TABLE statement
+// if the list is omitted (the default of mysqldump). The values are string or nil (NULL).
+func parseSQLDumpRows(dump string, isTable func(table string) bool) []map[string]any {
+	// Columns of the tables
+	tableColumns := map[string][]string{}
+	for _, m := range sqlCreateTableRegexp.FindAllStringSubmatchIndex(dump, -1) {
+		table := dump[m[2]:m[3]]
+		if !isTable(table) {
+			continue
+		}
+		body, _ := scanSQLParentheses(dump, m[1]-1)
+		columns := []string{}
+		for _, c := range sqlColumnRegexp.FindAllStringSubmatch(body, -1) {
+			columns = append(columns, c[1])
+		}
+		tableColumns[table] = columns
+	}
+
+	rows := []map[string]any{}
+	for _, m := range sqlInsertRegexp.FindAllStringSubmatchIndex(dump, -1) {
+		table := dump[m[2]:m[3]]
+		if !isTable(table) {
+			continue
+		}
+
+		columns := tableColumns[table]
+		if m[4] >= 0 {
+			columns = []string{}
+			for _, c := range strings.Split(dump[m[4]+1:m[5]-1], ",") {
+				columns = append(columns, strings.Trim(strings.TrimSpace(c), "`\""))
+			}
+		}
+
+		// Scan the value tuples till the end of statement
+		pos := m[1]
+		for pos < len(dump) && dump[pos] == '(' {
+			values, end := scanSQLTuple(dump, pos)
+			row := map[string]any{}
+			for i, v := range values {
+				if i < len(columns) {
+					row[columns[i]] = v
+				}
+			}
+			rows = append(rows, row)
+
+			pos = end
+			for pos < len(dump) && (dump[pos] == ',' || dump[pos] == ' ' || dump[pos] == '\n' || dump[pos] == '\r' || dump[pos] == '\t') {
+				pos++
+			}
+		}
+	}
+
+	return rows
+}
+
+// Get the content in the parentheses which starts at the position, the quoted parentheses are ignored
+func scanSQLParentheses(s string, start int) (string, int) {
+	depth := 0
+	var quote byte
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return s[start+1 : i], i + 1
+			}
+		}
+	}
+	return s[start+1:], len(s)
+}
+
+// Scan the value tuple `(1, 'a', NULL)` which starts at the position, the values and the end position are returned
+func scanSQLTuple(s string, start int) ([]any, int) {
+	values := []any{}
+	var (
+		buf      strings.Builder
+		isString bool
+	)
+	flush := func() {
+		raw := buf.String()
+		switch {
+		case isString:
+			values = append(values, raw)
+		case strings.EqualFold(strings.TrimSpace(raw), "NULL"):
+			values = append(values, nil)
+		default:
+			values = append(values, strings.TrimSpace(raw))
+		}
+		buf.Reset()
+		isString = false
+	}
+
+	for i := start + 1; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\'':
+			buf.Reset() // the spaces before the quote
+			isString = true
+			for i++; i < len(s); i++ {
+				c = s[i]
+				if c == '\\' && i+1 < len(s) {
+					i++
+					buf.WriteByte(unescapeSQLChar(s[i]))
+				} else if c == '\'' {
+					if i+1 < len(s) && s[i+1] == '\'' { // the escaped quote `''`
+						buf.WriteByte('\'')
+						i++
+					} else {
+						break
+					}
+				} else {
+					buf.WriteByte(c)
+				}
+			}
+		case ',':
+			flush()
+		case ')':
+			flush()
+			return values, i + 1
+		default:
+			if !isString {
+				buf.WriteByte(c)
+			}
+		}
+	}
+	flush()
+	return values, len(s)
+}
+
+func unescapeSQLChar(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case '0':
+		return 0
+	default:
+		return c
+	}
+}
@@ -0,0 +1,39 @@
+package artransfer
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/tidwall/gjson"
+)
+
+// Convert the Twikoo export (Admin - Import / Export, or the JSON lines of CloudBase / MongoDB) to Artrans
+//
+// @link https://twikoo.js.org/
+func TwikooToArtrans(data []byte) ([]*entity.Artran, ConvertReport, error) {
+	records, err := getJSONRecords(string(data), "results", "data")
+	if err != nil {
+		return nil, ConvertReport{}, err
+	}
+
+	comments, report := convertJSONComments(records, jsonCommentFields{
+		ID:        []string{"_id"},
+		Parent:    []string{"pid", "rid"},
+		Content:   []string{"comment"},
+		Nick:      []string{"nick"},
+		Email:     []string{"mail"},
+		Link:      []string{"link"},
+		IP:        []string{"ip"},
+		UA:        []string{"ua"},
+		PageKey:   []string{"url"},
+		CreatedAt: []string{"created"},
+		UpdatedAt: []string{"updated"},
+		Pinned:    []string{"top"},
+		Likes:     []string{"like"},
+		Status: func(r gjson.Result) string {
+			if r.Get("isSpam").Bool() {
+				return "spam"
+			}
+			return "approved"
+		},
+	})
+	return comments, report, nil
+}
@@ -0,0 +1,59 @@
+package artransfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwikooToArtrans(t *testing.T) {
+	const data = `[
+		{"_id": "a1", "nick": "Alice", "mail": "alice@example.com", "link": "https://alice.example.com", "ip": "1.2.3.4",
+		 "ua": "Mozilla/5.0", "url": "/post/1/", "comment": "<p>First</p>", "created": 1357027200000, "updated": 1357027200000,
+		 "top": true, "like": ["u1", "u2"]},
+		{"_id": {"$oid": "a2"}, "pid": "a1", "rid": "a1", "nick": "Bob", "mail": "bob@example.com", "url": "/post/1/",
+		 "comment": "Reply", "created": {"$date": "2013-01-02T00:00:00.000Z"}},
+		{"_id": "a3", "nick": "Spammer", "url": "/post/2/", "comment": "Buy now", "isSpam": true, "created": 1357113600000},
+		{"_id": "a4", "nick": "Nobody", "comment": "Where am I"}
+	]`
+
+	comments, report, err := TwikooToArtrans([]byte(data))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, ConvertReport{Pages: 2, Comments: 3, Replies: 1, Pending: 1, Spam: 1, Skipped: map[string]int{"orphan": 1}}, report)
+	if !assert.Len(t, comments, 3) {
+		return
+	}
+
+	first := comments[0]
+	assert.Equal(t, "a1", first.ID)
+	assert.Equal(t, "Alice", first.Nick)
+	assert.Equal(t, "alice@example.com", first.Email)
+	assert.Equal(t, "https://alice.example.com", first.Link)
+	assert.Equal(t, "1.2.3.4", first.IP)
+	assert.Equal(t, "Mozilla/5.0", first.UA)
+	assert.Equal(t, "/post/1/", first.PageKey)
+	assert.Equal(t, "2013-01-01T08:00:00Z", first.CreatedAt)
+	assert.Equal(t, "true", first.IsPinned)
+	assert.Equal(t, "2", first.VoteUp, "should count the users who like")
+
+	assert.Equal(t, "a2", comments[1].ID, "should read the ObjectId")
+	assert.Equal(t, "a1", comments[1].Rid)
+	assert.Equal(t, "2013-01-02T00:00:00Z", comments[1].CreatedAt)
+	assert.Equal(t, "true", comments[2].IsPending, "should import the spam as pending")
+
+	t.Run("JSON lines", func(t *testing.T) {
+		comments, _, err := TwikooToArtrans([]byte(`{"_id": "a1", "url": "/1/", "comment": "A"}` + "\n" + `{"_id": "a2", "url": "/1/", "comment": "B", "pid": "a1"}`))
+		assert.NoError(t, err)
+		if assert.Len(t, comments, 2) {
+			assert.Equal(t, "a1", comments[1].Rid)
+		}
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, _, err := TwikooToArtrans([]byte(`[{"_id": `))
+		assert.Error(t, err)
+	})
+}
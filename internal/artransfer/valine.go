@@ -0,0 +1,37 @@
+package artransfer
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/tidwall/gjson"
+)
+
+// Convert the LeanCloud export of Valine (the `Comment` class) to Artrans
+//
+// @link https://valine.js.org/
+func ValineToArtrans(data []byte) ([]*entity.Artran, ConvertReport, error) {
+	records, err := getJSONRecords(string(data), "results")
+	if err != nil {
+		return nil, ConvertReport{}, err
+	}
+
+	comments, report := convertJSONComments(records, jsonCommentFields{
+		ID:        []string{"objectId"},
+		Parent:    []string{"pid", "rid"},
+		Content:   []string{"comment"},
+		Nick:      []string{"nick"},
+		Email:     []string{"mail", "email"},
+		Link:      []string{"link"},
+		IP:        []string{"ip"},
+		UA:        []string{"ua"},
+		PageKey:   []string{"url"},
+		CreatedAt: []string{"insertedAt", "createdAt"},
+		UpdatedAt: []string{"updatedAt"},
+		Status: func(r gjson.Result) string {
+			if r.Get("isSpam").Bool() { // marked by Valine-Admin
+				return "spam"
+			}
+			return "approved"
+		},
+	})
+	return comments, report, nil
+}
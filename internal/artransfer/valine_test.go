@@ -0,0 +1,36 @@
+package artransfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValineToArtrans(t *testing.T) {
+	const data = `{"results": [
+		{"objectId": "v1", "nick": "Alice", "mail": "alice@example.com", "link": "https://alice.example.com",
+		 "url": "/post/1/", "comment": "First", "insertedAt": {"__type": "Date", "iso": "2013-01-01T00:00:00.000Z"},
+		 "createdAt": "2013-01-05T00:00:00.000Z", "updatedAt": "2013-01-06T00:00:00.000Z"},
+		{"objectId": "v2", "pid": "v1", "rid": "v1", "nick": "Bob", "url": "/post/1/", "comment": "Reply", "createdAt": "2013-01-02T00:00:00.000Z"},
+		{"objectId": "v3", "pid": "v9", "rid": "v9", "nick": "Carol", "url": "/post/1/", "comment": "Lost parent", "isSpam": true}
+	]}`
+
+	comments, report, err := ValineToArtrans([]byte(data))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, ConvertReport{Pages: 1, Comments: 3, Replies: 1, Pending: 1, Spam: 1, Skipped: map[string]int{}}, report)
+	if !assert.Len(t, comments, 3) {
+		return
+	}
+
+	assert.Equal(t, "v1", comments[0].ID)
+	assert.Equal(t, "Alice", comments[0].Nick)
+	assert.Equal(t, "alice@example.com", comments[0].Email)
+	assert.Equal(t, "2013-01-01T00:00:00Z", comments[0].CreatedAt, "should prefer the insertedAt")
+	assert.Equal(t, "2013-01-06T00:00:00Z", comments[0].UpdatedAt)
+	assert.Equal(t, "v1", comments[1].Rid)
+	assert.Equal(t, "", comments[2].Rid, "should be the root comment if the parent is not found")
+	assert.Equal(t, "true", comments[2].IsPending)
+}
@@ -0,0 +1,126 @@
+package artransfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/tidwall/gjson"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Convert the Waline data to Artrans, which is the JSON export (Admin - Migration - Export),
+// the SQLite database file, or the MySQL dump of the `wl_Comment` table
+//
+// @link https://waline.js.org/
+func WalineToArtrans(data []byte) ([]*entity.Artran, ConvertReport, error) {
+	var (
+		records []gjson.Result
+		err     error
+	)
+
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("SQLite format 3\x00")):
+		records, err = readSQLiteCommentRecords(data)
+	case len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '['):
+		records, err = getJSONRecords(string(data), "data.Comment")
+	default:
+		records = rowsToJSONRecords(parseSQLDumpRows(string(data), isCommentTable))
+		if len(records) == 0 {
+			err = fmt.Errorf("no comment is found in the SQL dump")
+		}
+	}
+	if err != nil {
+		return nil, ConvertReport{}, err
+	}
+
+	comments, report := convertJSONComments(records, jsonCommentFields{
+		ID:        []string{"objectId", "id"},
+		Parent:    []string{"pid", "rid"},
+		Content:   []string{"comment"},
+		Nick:      []string{"nick"},
+		Email:     []string{"mail"},
+		Link:      []string{"link"},
+		IP:        []string{"ip"},
+		UA:        []string{"ua"},
+		PageKey:   []string{"url"},
+		CreatedAt: []string{"insertedAt", "createdAt"},
+		UpdatedAt: []string{"updatedAt"},
+		Pinned:    []string{"sticky"},
+		Likes:     []string{"like"},
+		Status: func(r gjson.Result) string {
+			switch r.Get("status").String() {
+			case "spam":
+				return "spam"
+			case "waiting":
+				return "pending"
+			default:
+				return "approved"
+			}
+		},
+	})
+	return comments, report, nil
+}
+
+// The comment table of Waline is `wl_Comment` by default, the prefix is configurable
+func isCommentTable(table string) bool {
+	return strings.HasSuffix(strings.ToLower(table), "comment")
+}
+
+// Read the comment records from the SQLite database of Waline
+func readSQLiteCommentRecords(data []byte) ([]gjson.Result, error) {
+	tmp, err := os.CreateTemp("", "artalk-import-*.sqlite")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	tmp.Close()
+
+	db, err := gorm.Open(sqlite.Open(tmp.Name()), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the SQLite database: %w", err)
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	var tables []string
+	db.Raw("SELECT name FROM sqlite_master WHERE type = 'table'").Scan(&tables)
+	for _, table := range tables {
+		if !isCommentTable(table) {
+			continue
+		}
+
+		rows := []map[string]any{}
+		if err := db.Table(table).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		return rowsToJSONRecords(rows), nil
+	}
+
+	return nil, fmt.Errorf("the comment table is not found in the SQLite database")
+}
+
+func rowsToJSONRecords(rows []map[string]any) []gjson.Result {
+	records := make([]gjson.Result, 0, len(rows))
+	for _, row := range rows {
+		for k, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[k] = string(b)
+			}
+		}
+		buf, _ := json.Marshal(row)
+		records = append(records, gjson.ParseBytes(buf))
+	}
+	return records
+}
@@ -0,0 +1,125 @@
+package artransfer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testWalineSQLDump = "-- MySQL dump\n" +
+	"DROP TABLE IF EXISTS `wl_Comment`;\n" +
+	"CREATE TABLE `wl_Comment` (\n" +
+	"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+	"  `user_id` int(11) DEFAULT NULL,\n" +
+	"  `comment` text,\n" +
+	"  `insertedAt` timestamp NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+	"  `ip` varchar(100) DEFAULT '',\n" +
+	"  `link` varchar(255) DEFAULT NULL,\n" +
+	"  `mail` varchar(255) DEFAULT NULL,\n" +
+	"  `nick` varchar(255) DEFAULT NULL,\n" +
+	"  `pid` int(11) DEFAULT NULL,\n" +
+	"  `rid` int(11) DEFAULT NULL,\n" +
+	"  `sticky` boolean DEFAULT NULL,\n" +
+	"  `status` varchar(50) NOT NULL DEFAULT '',\n" +
+	"  `like` int(11) DEFAULT NULL,\n" +
+	"  `ua` text,\n" +
+	"  `url` varchar(255) DEFAULT NULL,\n" +
+	"  `createdAt` timestamp NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+	"  `updatedAt` timestamp NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+	"  PRIMARY KEY (`id`)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n" +
+	"INSERT INTO `wl_Users` VALUES (1,'admin');\n" +
+	"INSERT INTO `wl_Comment` VALUES " +
+	"(1,NULL,'It\\'s (first),\\nOK','2013-01-01 00:00:00','1.2.3.4',NULL,'alice@example.com','Alice',NULL,NULL,1,'approved',3,'Mozilla/5.0','/post/1/','2013-01-01 00:00:00','2013-01-01 00:00:00')," +
+	"(2,NULL,'Reply','2013-01-02 00:00:00','',NULL,'bob@example.com','Bob',1,1,NULL,'waiting',NULL,'','/post/1/','2013-01-02 00:00:00','2013-01-02 00:00:00');\n" +
+	"INSERT INTO `wl_Comment` (`id`, `comment`, `nick`, `url`, `status`) VALUES (3, 'Buy now', 'Spammer', '/post/2/', 'spam');\n"
+
+func TestWalineToArtrans(t *testing.T) {
+	assertComments := func(t *testing.T, data []byte) {
+		comments, report, err := WalineToArtrans(data)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, ConvertReport{Pages: 2, Comments: 3, Replies: 1, Pending: 2, Spam: 1, Skipped: map[string]int{}}, report)
+		if !assert.Len(t, comments, 3) {
+			return
+		}
+
+		first := comments[0]
+		assert.Equal(t, "1", first.ID)
+		assert.Equal(t, "It's (first),\nOK", first.Content)
+		assert.Equal(t, "Alice", first.Nick)
+		assert.Equal(t, "alice@example.com", first.Email)
+		assert.Equal(t, "1.2.3.4", first.IP)
+		assert.Equal(t, "/post/1/", first.PageKey)
+		assert.Equal(t, "true", first.IsPinned)
+		assert.Equal(t, "3", first.VoteUp)
+		assert.Equal(t, "false", first.IsPending)
+		assert.Contains(t, first.CreatedAt, "2013-01-01")
+
+		assert.Equal(t, "1", comments[1].Rid)
+		assert.Equal(t, "true", comments[1].IsPending, "should import the waiting comment as pending")
+		assert.Equal(t, "true", comments[2].IsPending, "should import the spam as pending")
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		assertComments(t, []byte(`{"type": "waline", "version": 1, "tables": ["Comment"], "data": {"Comment": [
+			{"objectId": 1, "comment": "It's (first),\nOK", "insertedAt": "2013-01-01T00:00:00.000Z", "ip": "1.2.3.4",
+			 "mail": "alice@example.com", "nick": "Alice", "sticky": 1, "status": "approved", "like": 3, "url": "/post/1/"},
+			{"objectId": 2, "pid": 1, "rid": 1, "comment": "Reply", "nick": "Bob", "status": "waiting", "url": "/post/1/"},
+			{"objectId": 3, "comment": "Buy now", "nick": "Spammer", "status": "spam", "url": "/post/2/"}
+		]}}`))
+	})
+
+	t.Run("SQL dump", func(t *testing.T) {
+		assertComments(t, []byte(testWalineSQLDump))
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "waline.sqlite")
+		db, err := gorm.Open(sqlite.Open(file), &gorm.Config{Logger: logger.Discard})
+		if !assert.NoError(t, err) {
+			return
+		}
+		for _, stmt := range []string{
+			"CREATE TABLE `wl_Comment` (`id` INTEGER PRIMARY KEY, `comment` TEXT, `insertedAt` DATETIME, `ip` TEXT, `mail` TEXT, `nick` TEXT, `pid` INTEGER, `rid` INTEGER, `sticky` NUMERIC, `status` TEXT, `like` INTEGER, `url` TEXT)",
+			"INSERT INTO `wl_Comment` VALUES (1, 'It''s (first),\nOK', '2013-01-01 00:00:00', '1.2.3.4', 'alice@example.com', 'Alice', NULL, NULL, 1, 'approved', 3, '/post/1/')",
+			"INSERT INTO `wl_Comment` VALUES (2, 'Reply', '2013-01-02 00:00:00', '', 'bob@example.com', 'Bob', 1, 1, NULL, 'waiting', NULL, '/post/1/')",
+			"INSERT INTO `wl_Comment` (`id`, `comment`, `nick`, `url`, `status`) VALUES (3, 'Buy now', 'Spammer', '/post/2/', 'spam')",
+		} {
+			assert.NoError(t, db.Exec(stmt).Error)
+		}
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+
+		data, err := os.ReadFile(file)
+		if assert.NoError(t, err) {
+			assertComments(t, data)
+		}
+	})
+
+	t.Run("No comment", func(t *testing.T) {
+		_, _, err := WalineToArtrans([]byte("INSERT INTO `wl_Users` VALUES (1,'admin');"))
+		assert.Error(t, err)
+	})
+}
+
+func Test_parseSQLDumpRows(t *testing.T) {
+	rows := parseSQLDumpRows(testWalineSQLDump, isCommentTable)
+	if !assert.Len(t, rows, 3) {
+		return
+	}
+
+	assert.Equal(t, "1", rows[0]["id"])
+	assert.Nil(t, rows[0]["user_id"], "should parse NULL as nil")
+	assert.Equal(t, "It's (first),\nOK", rows[0]["comment"], "should unescape the string")
+	assert.Equal(t, "waiting", rows[1]["status"])
+	assert.Equal(t, map[string]any{"id": "3", "comment": "Buy now", "nick": "Spammer", "url": "/post/2/", "status": "spam"}, rows[2],
+		"should use the column list of INSERT statement")
+}
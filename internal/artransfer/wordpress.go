@@ -0,0 +1,229 @@
+package artransfer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+)
+
+// The WordPress eXtended RSS (WXR) export (Tools - Export)
+//
+// @link https://wordpress.org/documentation/article/tools-export-screen/
+type wxrExport struct {
+	Channel struct {
+		Title       string    `xml:"title"` // The fallback site name
+		BaseBlogURL string    `xml:"base_blog_url"`
+		Items       []wxrItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type wxrItem struct {
+	Title      string        `xml:"title"`
+	Link       string        `xml:"link"`
+	Creator    string        `xml:"creator"`
+	Categories []wxrCategory `xml:"category"`
+	PostID     string        `xml:"post_id"`
+	PostDate   string        `xml:"post_date"`
+	PostName   string        `xml:"post_name"`
+	PostType   string        `xml:"post_type"`
+	Comments   []wxrComment  `xml:"comment"`
+}
+
+type wxrCategory struct {
+	Domain   string `xml:"domain,attr"`
+	NiceName string `xml:"nicename,attr"`
+}
+
+type wxrComment struct {
+	ID          string           `xml:"comment_id"`
+	Author      string           `xml:"comment_author"`
+	AuthorEmail string           `xml:"comment_author_email"`
+	AuthorURL   string           `xml:"comment_author_url"`
+	AuthorIP    string           `xml:"comment_author_IP"`
+	Date        string           `xml:"comment_date"`
+	DateGMT     string           `xml:"comment_date_gmt"`
+	Content     string           `xml:"comment_content"`
+	Approved    string           `xml:"comment_approved"` // "1", "0", "spam" or "trash"
+	Type        string           `xml:"comment_type"`     // "comment" (or empty), "pingback" or "trackback"
+	Parent      string           `xml:"comment_parent"`
+	Meta        []wxrCommentMeta `xml:"commentmeta"`
+}
+
+type wxrCommentMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// The date format of WXR
+const wxrDateFormat = "2006-01-02 15:04:05"
+
+type WordPressOptions struct {
+	// The permalink structure of posts (e.g. `/%year%/%monthnum%/%postname%/`), the link of post is used if empty
+	//
+	// @link https://wordpress.org/documentation/article/customize-permalinks/
+	Permalink string
+
+	// Import the spam and trashed comments as pending, which are skipped by default
+	ImportSpam bool
+}
+
+// Convert the WordPress WXR export to Artrans
+//
+// The posts are mapped to pages by the permalink rule, the pingbacks and trackbacks are skipped.
+// The replies of skipped comments are attached to the nearest imported ancestor.
+// The comments which are not approved are imported as pending, and so are the comments
+// marked as spam (including by Akismet in the commentmeta) or trashed if `ImportSpam` is enabled.
+func WordPressToArtrans(data []byte, opts WordPressOptions) ([]*entity.Artran, ConvertReport, error) {
+	report := ConvertReport{Skipped: map[string]int{}}
+
+	var export wxrExport
+	if err := xml.Unmarshal(data, &export); err != nil {
+		return nil, report, fmt.Errorf("failed to parse WordPress WXR: %w", err)
+	}
+
+	comments := []*entity.Artran{}
+	for _, item := range export.Channel.Items {
+		pageKey := getWordPressPageKey(item, export.Channel.BaseBlogURL, opts.Permalink)
+		if pageKey == "" {
+			report.Skipped["orphan"] += len(item.Comments)
+			continue
+		}
+
+		// Decide the comments to import
+		parentOf := map[string]string{}
+		status := map[string]string{} // ID => "approved", "pending" or "spam"
+		for _, c := range item.Comments {
+			parentOf[c.ID] = normalizeWordPressParentID(c.Parent)
+
+			switch {
+			case c.Type == "pingback" || c.Type == "trackback":
+				report.Skipped[c.Type]++
+			case c.Approved == "trash" && !opts.ImportSpam:
+				report.Skipped["trash"]++
+			case isWordPressSpam(c) && !opts.ImportSpam:
+				report.Skipped["spam"]++
+			case c.Approved == "trash" || isWordPressSpam(c):
+				status[c.ID] = "spam"
+			case c.Approved == "1":
+				status[c.ID] = "approved"
+			default:
+				status[c.ID] = "pending"
+			}
+		}
+		isImported := func(id string) bool { return status[id] != "" }
+
+		count := 0
+		for _, c := range item.Comments {
+			if !isImported(c.ID) {
+				continue
+			}
+
+			rid := findImportedParent(c.ID, parentOf, isImported)
+			comments = append(comments, &entity.Artran{
+				ID:        c.ID,
+				Rid:       rid,
+				Content:   strings.TrimSpace(c.Content),
+				IP:        c.AuthorIP,
+				IsPending: fmt.Sprint(status[c.ID] != "approved"),
+				CreatedAt: getWordPressCommentDate(c),
+				Nick:      strings.TrimSpace(c.Author),
+				Email:     strings.TrimSpace(c.AuthorEmail),
+				Link:      strings.TrimSpace(c.AuthorURL),
+				PageKey:   pageKey,
+				PageTitle: strings.TrimSpace(item.Title),
+				SiteName:  strings.TrimSpace(export.Channel.Title),
+				SiteURLs:  getURLOrigin(export.Channel.BaseBlogURL),
+			})
+
+			count++
+			report.Comments++
+			if rid != "" {
+				report.Replies++
+			}
+			if status[c.ID] != "approved" {
+				report.Pending++
+			}
+			if status[c.ID] == "spam" {
+				report.Spam++
+			}
+		}
+		if count > 0 {
+			report.Pages++
+		}
+	}
+
+	return comments, report, nil
+}
+
+// Get the page key of post by the permalink rule, the link of post is used if the rule is empty
+//
+// The rule is only applied to the posts, since the pages and the custom post types have their own permalinks.
+func getWordPressPageKey(item wxrItem, baseURL string, permalink string) string {
+	link := strings.TrimSpace(item.Link)
+	if permalink == "" || (item.PostType != "" && item.PostType != "post") {
+		return link
+	}
+
+	date, _ := time.Parse(wxrDateFormat, item.PostDate)
+	category := ""
+	for _, c := range item.Categories {
+		if c.Domain == "category" && c.NiceName != "" {
+			category = c.NiceName
+			break
+		}
+	}
+
+	path := strings.NewReplacer(
+		"%year%", date.Format("2006"),
+		"%monthnum%", date.Format("01"),
+		"%day%", date.Format("02"),
+		"%hour%", date.Format("15"),
+		"%minute%", date.Format("04"),
+		"%second%", date.Format("05"),
+		"%post_id%", item.PostID,
+		"%postname%", strings.TrimSpace(item.PostName),
+		"%category%", category,
+		"%author%", strings.TrimSpace(item.Creator),
+	).Replace(permalink)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return strings.TrimSuffix(strings.TrimSpace(baseURL), "/") + path
+}
+
+// Check if the comment is marked as spam by WordPress or Akismet
+func isWordPressSpam(c wxrComment) bool {
+	if c.Approved == "spam" {
+		return true
+	}
+	if c.Approved == "1" {
+		return false // approved by the moderator
+	}
+	for _, m := range c.Meta {
+		if m.Key == "akismet_result" && m.Value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the date of comment in RFC3339, the GMT date is preferred
+func getWordPressCommentDate(c wxrComment) string {
+	if t, err := time.Parse(wxrDateFormat, c.DateGMT); err == nil && t.Year() > 1 {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return c.Date // in the local time of server
+}
+
+// The parent ID "0" means no parent
+func normalizeWordPressParentID(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "0" {
+		return ""
+	}
+	return id
+}
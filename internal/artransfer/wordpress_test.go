@@ -0,0 +1,187 @@
+package artransfer
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/db"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWordPressXML = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+  <title>My Blog</title>
+  <link>https://example.com</link>
+  <wp:base_site_url>https://example.com</wp:base_site_url>
+  <wp:base_blog_url>https://example.com</wp:base_blog_url>
+  <item>
+    <title>Hello World</title>
+    <link>https://example.com/?p=1</link>
+    <dc:creator><![CDATA[admin]]></dc:creator>
+    <category domain="category" nicename="news"><![CDATA[News]]></category>
+    <wp:post_id>1</wp:post_id>
+    <wp:post_date><![CDATA[2013-01-01 10:00:00]]></wp:post_date>
+    <wp:post_name><![CDATA[hello-world]]></wp:post_name>
+    <wp:post_type><![CDATA[post]]></wp:post_type>
+    <wp:comment>
+      <wp:comment_id>10</wp:comment_id>
+      <wp:comment_author><![CDATA[Alice]]></wp:comment_author>
+      <wp:comment_author_email><![CDATA[alice@example.com]]></wp:comment_author_email>
+      <wp:comment_author_url>https://alice.example.com</wp:comment_author_url>
+      <wp:comment_author_IP><![CDATA[1.2.3.4]]></wp:comment_author_IP>
+      <wp:comment_date><![CDATA[2013-01-02 16:00:00]]></wp:comment_date>
+      <wp:comment_date_gmt><![CDATA[2013-01-02 08:00:00]]></wp:comment_date_gmt>
+      <wp:comment_content><![CDATA[First]]></wp:comment_content>
+      <wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+      <wp:comment_type><![CDATA[comment]]></wp:comment_type>
+      <wp:comment_parent>0</wp:comment_parent>
+    </wp:comment>
+    <wp:comment>
+      <wp:comment_id>11</wp:comment_id>
+      <wp:comment_author><![CDATA[Spammer]]></wp:comment_author>
+      <wp:comment_author_email><![CDATA[spam@example.com]]></wp:comment_author_email>
+      <wp:comment_date_gmt><![CDATA[2013-01-03 08:00:00]]></wp:comment_date_gmt>
+      <wp:comment_content><![CDATA[Buy now]]></wp:comment_content>
+      <wp:comment_approved><![CDATA[0]]></wp:comment_approved>
+      <wp:comment_parent>10</wp:comment_parent>
+      <wp:commentmeta>
+        <wp:meta_key><![CDATA[akismet_result]]></wp:meta_key>
+        <wp:meta_value><![CDATA[true]]></wp:meta_value>
+      </wp:commentmeta>
+    </wp:comment>
+    <wp:comment>
+      <wp:comment_id>12</wp:comment_id>
+      <wp:comment_author><![CDATA[Bob]]></wp:comment_author>
+      <wp:comment_author_email><![CDATA[bob@example.com]]></wp:comment_author_email>
+      <wp:comment_date_gmt><![CDATA[2013-01-04 08:00:00]]></wp:comment_date_gmt>
+      <wp:comment_content><![CDATA[Reply to spam]]></wp:comment_content>
+      <wp:comment_approved><![CDATA[0]]></wp:comment_approved>
+      <wp:comment_parent>11</wp:comment_parent>
+    </wp:comment>
+    <wp:comment>
+      <wp:comment_id>13</wp:comment_id>
+      <wp:comment_author><![CDATA[Other Blog]]></wp:comment_author>
+      <wp:comment_content><![CDATA[Pingback]]></wp:comment_content>
+      <wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+      <wp:comment_type><![CDATA[pingback]]></wp:comment_type>
+      <wp:comment_parent>0</wp:comment_parent>
+    </wp:comment>
+    <wp:comment>
+      <wp:comment_id>14</wp:comment_id>
+      <wp:comment_author><![CDATA[Carol]]></wp:comment_author>
+      <wp:comment_content><![CDATA[Trashed]]></wp:comment_content>
+      <wp:comment_approved><![CDATA[trash]]></wp:comment_approved>
+      <wp:comment_parent>0</wp:comment_parent>
+    </wp:comment>
+  </item>
+  <item>
+    <title>About</title>
+    <link>https://example.com/about/</link>
+    <wp:post_id>2</wp:post_id>
+    <wp:post_name><![CDATA[about]]></wp:post_name>
+    <wp:post_type><![CDATA[page]]></wp:post_type>
+    <wp:comment>
+      <wp:comment_id>20</wp:comment_id>
+      <wp:comment_author><![CDATA[Dave]]></wp:comment_author>
+      <wp:comment_author_email><![CDATA[dave@example.com]]></wp:comment_author_email>
+      <wp:comment_date><![CDATA[2014-05-01 12:00:00]]></wp:comment_date>
+      <wp:comment_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:comment_date_gmt>
+      <wp:comment_content><![CDATA[Nice]]></wp:comment_content>
+      <wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+      <wp:comment_parent>0</wp:comment_parent>
+    </wp:comment>
+  </item>
+</channel>
+</rss>`
+
+func TestWordPressToArtrans(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		comments, report, err := WordPressToArtrans([]byte(testWordPressXML), WordPressOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, ConvertReport{Pages: 2, Comments: 3, Replies: 1, Pending: 1,
+			Skipped: map[string]int{"spam": 1, "pingback": 1, "trash": 1}}, report)
+		if !assert.Len(t, comments, 3) {
+			return
+		}
+
+		first := comments[0]
+		assert.Equal(t, "10", first.ID)
+		assert.Equal(t, "", first.Rid)
+		assert.Equal(t, "First", first.Content)
+		assert.Equal(t, "Alice", first.Nick)
+		assert.Equal(t, "alice@example.com", first.Email)
+		assert.Equal(t, "https://alice.example.com", first.Link)
+		assert.Equal(t, "1.2.3.4", first.IP)
+		assert.Equal(t, "2013-01-02T08:00:00Z", first.CreatedAt, "should use the GMT date")
+		assert.Equal(t, "https://example.com/?p=1", first.PageKey, "should use the link if no permalink rule")
+		assert.Equal(t, "Hello World", first.PageTitle)
+		assert.Equal(t, "My Blog", first.SiteName)
+		assert.Equal(t, "https://example.com", first.SiteURLs)
+		assert.Equal(t, "false", first.IsPending)
+
+		assert.Equal(t, "12", comments[1].ID)
+		assert.Equal(t, "10", comments[1].Rid, "should reply to the nearest imported ancestor")
+		assert.Equal(t, "true", comments[1].IsPending, "should keep the unapproved comment pending")
+
+		assert.Equal(t, "2014-05-01 12:00:00", comments[2].CreatedAt, "should fallback to the local date")
+	})
+
+	t.Run("Permalink", func(t *testing.T) {
+		comments, _, err := WordPressToArtrans([]byte(testWordPressXML), WordPressOptions{
+			Permalink: "/%category%/%year%/%monthnum%/%postname%/",
+		})
+		if assert.NoError(t, err) && assert.Len(t, comments, 3) {
+			assert.Equal(t, "https://example.com/news/2013/01/hello-world/", comments[0].PageKey)
+			assert.Equal(t, "https://example.com/about/", comments[2].PageKey, "should not apply the rule to pages")
+		}
+	})
+
+	t.Run("Import spam", func(t *testing.T) {
+		comments, report, err := WordPressToArtrans([]byte(testWordPressXML), WordPressOptions{ImportSpam: true})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, ConvertReport{Pages: 2, Comments: 5, Replies: 2, Pending: 3, Spam: 2,
+			Skipped: map[string]int{"pingback": 1}}, report)
+		if assert.Len(t, comments, 5) {
+			assert.Equal(t, "11", comments[1].ID)
+			assert.Equal(t, "true", comments[1].IsPending)
+			assert.Equal(t, "11", comments[2].Rid, "should keep the reply to the spam")
+		}
+	})
+}
+
+func TestRunImportWordPress(t *testing.T) {
+	ddb, _ := db.NewTestDB()
+	defer db.CloseDB(ddb)
+	dao := dao.NewDao(ddb)
+
+	err := RunImportArtrans(dao, &ImportParams{
+		JsonData:    testWordPressXML,
+		Format:      FormatWordPress,
+		WPPermalink: "/%year%/%postname%/",
+		Assumeyes:   true,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var comments []entity.Comment
+	dao.DB().Order("id ASC").Find(&comments)
+	if !assert.Len(t, comments, 3) {
+		return
+	}
+	assert.Equal(t, "/2013/hello-world/", comments[0].PageKey)
+	assert.Equal(t, "My Blog", comments[0].SiteName, "should use the blog title as the site name")
+	assert.Equal(t, comments[0].ID, comments[1].Rid)
+	assert.True(t, comments[1].IsPending)
+
+	user := dao.FindUser("Alice", "alice@example.com")
+	assert.Equal(t, "https://alice.example.com", user.Link, "should keep the author URL")
+}
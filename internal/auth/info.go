@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"cmp"
 	"strings"
 
 	"github.com/artalkjs/artalk/v2/internal/config"
@@ -35,6 +36,10 @@ func GetProviderInfo(conf *config.Config, providers []goth.Provider) []AuthProvi
 		if title == "Microsoftonline" {
 			title = "Microsoft"
 		}
+		// Custom name for OIDC
+		if name == "oidc" {
+			title = cmp.Or(conf.Auth.OIDC.Name, "OIDC")
+		}
 		info = append(info, AuthProviderInfo{
 			Name:  name,
 			Label: title,
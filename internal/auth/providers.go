@@ -19,6 +19,7 @@ import (
 	"github.com/markbates/goth/providers/line"
 	"github.com/markbates/goth/providers/mastodon"
 	"github.com/markbates/goth/providers/microsoftonline"
+	"github.com/markbates/goth/providers/openidConnect"
 	"github.com/markbates/goth/providers/patreon"
 	"github.com/markbates/goth/providers/slack"
 	"github.com/markbates/goth/providers/steam"
@@ -117,6 +118,17 @@ func GetProviders(conf *config.Config) []goth.Provider {
 		providers = append(providers, auth0.New(auth0Conf.ClientID, auth0Conf.ClientSecret, callbackURL("auth0"),
 			auth0Conf.Domain, "openid", "profile", "email"))
 	}
+	// @see https://openid.net/specs/openid-connect-discovery-1_0.html
+	if oidcConf := conf.Auth.OIDC; oidcConf.Enabled && oidcConf.DiscoveryURL != "" {
+		scopes := lo.Ternary(len(oidcConf.Scopes) > 0, oidcConf.Scopes, []string{"openid", "profile", "email"})
+		if provider, err := openidConnect.New(oidcConf.ClientID, oidcConf.ClientSecret, callbackURL("oidc"),
+			oidcConf.DiscoveryURL, scopes...); err != nil {
+			log.Error("[SocialLogin] Failed to load OIDC provider: ", err)
+		} else {
+			provider.SetName("oidc")
+			providers = append(providers, provider)
+		}
+	}
 
 	return providers
 }
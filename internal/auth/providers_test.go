@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(`{
+			"issuer": "https://sso.example.com",
+			"authorization_endpoint": "https://sso.example.com/auth",
+			"token_endpoint": "https://sso.example.com/token",
+			"userinfo_endpoint": "https://sso.example.com/userinfo"
+		}`))
+	}))
+	defer server.Close()
+
+	conf := &config.Config{}
+	conf.Auth.Callback = "https://artalk.example.com/api/v2/auth/callback"
+	conf.Auth.OIDC.Enabled = true
+	conf.Auth.OIDC.Name = "Keycloak"
+	conf.Auth.OIDC.DiscoveryURL = server.URL + "/.well-known/openid-configuration"
+
+	providers := GetProviders(conf)
+	if assert.Len(t, providers, 1) {
+		assert.Equal(t, "oidc", providers[0].Name())
+	}
+
+	info := GetProviderInfo(conf, providers)
+	if assert.Len(t, info, 1) {
+		assert.Equal(t, "Keycloak", info[0].Label, "should use the custom name as label")
+		assert.Equal(t, "/api/v2/auth/oidc", info[0].Path)
+		assert.NotEmpty(t, info[0].Icon)
+	}
+
+	conf.Auth.OIDC.DiscoveryURL = server.URL + "/not_found"
+	assert.Empty(t, GetProviders(conf), "should skip the provider if discovery failed")
+}
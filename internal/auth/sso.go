@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/markbates/goth"
+)
+
+// The provider name of the single sign-on users
+const SSOProvider = "sso"
+
+// The user payload signed by the host site
+type SSOUser struct {
+	ID     string `json:"id"`     // The unique user ID in the host site
+	Name   string `json:"name"`   // The username
+	Email  string `json:"email"`  // The user email
+	Avatar string `json:"avatar"` // The avatar URL (optional)
+	Link   string `json:"link"`   // The user website (optional)
+}
+
+// Sign the SSO user payload with the shared secret
+//
+// The signed payload is `<message> <signature> <timestamp>`, where the `message` is
+// the base64 encoded JSON of user, and the `signature` is the hex encoded HMAC-SHA256
+// of `<message> <timestamp>`.
+func SignSSOPayload(u SSOUser, secret string, t time.Time) (string, error) {
+	buf, err := json.Marshal(u)
+	if err != nil {
+		return "", err
+	}
+
+	message := base64.StdEncoding.EncodeToString(buf)
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+
+	return message + " " + signSSO(message, timestamp, secret) + " " + timestamp, nil
+}
+
+// Verify the signed payload and parse the SSO user
+func ParseSSOPayload(payload string, secret string, maxAge time.Duration, now time.Time) (SSOUser, error) {
+	parts := strings.Fields(payload)
+	if len(parts) != 3 {
+		return SSOUser{}, fmt.Errorf("invalid sso payload format")
+	}
+	message, signature, timestamp := parts[0], parts[1], parts[2]
+
+	if !hmac.Equal([]byte(signature), []byte(signSSO(message, timestamp, secret))) {
+		return SSOUser{}, fmt.Errorf("invalid sso payload signature")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return SSOUser{}, fmt.Errorf("invalid sso payload timestamp")
+	}
+	if signedAt := time.Unix(ts, 0); now.Sub(signedAt) > maxAge || signedAt.Sub(now) > maxAge {
+		return SSOUser{}, fmt.Errorf("sso payload is expired")
+	}
+
+	buf, err := base64.StdEncoding.DecodeString(message)
+	if err != nil {
+		return SSOUser{}, fmt.Errorf("invalid sso payload message")
+	}
+
+	var u SSOUser
+	if err := json.Unmarshal(buf, &u); err != nil {
+		return SSOUser{}, fmt.Errorf("invalid sso payload message")
+	}
+	if u.ID == "" {
+		return SSOUser{}, fmt.Errorf("sso user id is required")
+	}
+
+	return u, nil
+}
+
+// Convert to the social user for registering
+func (u SSOUser) SocialUser() SocialUser {
+	return SocialUser{
+		User: goth.User{
+			Provider:  SSOProvider,
+			UserID:    u.ID,
+			Name:      u.Name,
+			Email:     u.Email,
+			AvatarURL: u.Avatar,
+		},
+		RemoteUID: u.ID,
+		Link:      u.Link,
+	}
+}
+
+func signSSO(message string, timestamp string, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message + " " + timestamp))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSOPayload(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	user := SSOUser{ID: "42", Name: "qwqcode", Email: "qwqcode@example.com", Avatar: "https://example.com/avatar.png"}
+
+	payload, err := SignSSOPayload(user, "secret", now)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(payload, " 1700000000"))
+
+	got, err := ParseSSOPayload(payload, "secret", time.Minute, now.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, user, got)
+
+	_, err = ParseSSOPayload(payload, "wrong_secret", time.Minute, now)
+	assert.ErrorContains(t, err, "signature")
+
+	_, err = ParseSSOPayload(payload, "secret", time.Minute, now.Add(2*time.Minute))
+	assert.ErrorContains(t, err, "expired")
+
+	parts := strings.Fields(payload)
+	_, err = ParseSSOPayload(parts[0]+" "+parts[1]+" 1700000001", "secret", time.Minute, now)
+	assert.ErrorContains(t, err, "signature", "should not allow to change the timestamp")
+
+	_, err = ParseSSOPayload("invalid", "secret", time.Minute, now)
+	assert.ErrorContains(t, err, "format")
+
+	payload, _ = SignSSOPayload(SSOUser{Name: "qwqcode"}, "secret", now)
+	_, err = ParseSSOPayload(payload, "secret", time.Minute, now)
+	assert.ErrorContains(t, err, "id is required")
+
+	social := user.SocialUser()
+	assert.Equal(t, SSOProvider, social.Provider)
+	assert.Equal(t, "42", social.RemoteUID)
+}
@@ -0,0 +1,251 @@
+// Package backup dumps the full dataset into an archive on a schedule,
+// which is kept in the local directory and uploaded to the remote storage.
+package backup
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/artransfer"
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+)
+
+// The backup file is named as `artalk-backup-20060102-150405.zip`
+const (
+	filePrefix     = "artalk-backup-"
+	fileExt        = ".zip"
+	fileTimeFormat = "20060102-150405"
+)
+
+// The entries of backup archive
+const (
+	entryMeta    = "meta.json"
+	entryArtrans = "artrans.json"
+	entryConfig  = "artalk.yml"
+	entryUploads = "uploads.json" // the manifest of uploaded files, the files are not included
+)
+
+type Options struct {
+	Dir        string  // The local directory to keep the backups
+	Keep       int     // The number of backups to keep (both local and remote), all are kept if 0
+	ConfigFile string  // The config file to back up, skipped if empty
+	UploadsDir string  // The directory of uploaded files to list in the manifest, skipped if empty
+	Remote     Storage // The remote storage to upload, only kept locally if nil
+}
+
+type Meta struct {
+	Version   string    `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Comments  int64     `json:"comments"`
+	Uploads   int       `json:"uploads"`
+}
+
+type UploadFile struct {
+	Path       string    `json:"path"` // The path relative to the uploads directory
+	Size       int64     `json:"size"`
+	ModifiedAt time.Time `json:"modified_at"`
+	SHA256     string    `json:"sha256"`
+}
+
+type Info struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Create a backup in the local directory, then upload it to the remote storage.
+// The oldest backups which exceed the number to keep are deleted.
+//
+// The local backup is kept if failed to upload, and the info is returned with the error.
+func Create(dao *dao.Dao, opts Options) (Info, error) {
+	now := time.Now()
+	info := Info{Name: filePrefix + now.Format(fileTimeFormat) + fileExt, CreatedAt: now}
+
+	// The backups contain the secrets (e.g. the config file), which are readable by the owner only
+	if err := os.MkdirAll(opts.Dir, 0o700); err != nil {
+		return Info{}, err
+	}
+	file := filepath.Join(opts.Dir, info.Name)
+	tmp := file + ".tmp"
+	if err := writeArchive(dao, opts, tmp, now); err != nil {
+		os.Remove(tmp)
+		return Info{}, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return Info{}, err
+	}
+	if stat, err := os.Stat(file); err == nil {
+		info.Size = stat.Size()
+	}
+
+	if _, err := Rotate(&LocalStorage{Dir: opts.Dir}, opts.Keep); err != nil {
+		return info, fmt.Errorf("failed to rotate local backups: %w", err)
+	}
+
+	if opts.Remote != nil {
+		f, err := os.Open(file)
+		if err != nil {
+			return info, err
+		}
+		defer f.Close()
+		if err := opts.Remote.Upload(info.Name, f, info.Size); err != nil {
+			return info, fmt.Errorf("failed to upload backup to %s: %w", opts.Remote.Name(), err)
+		}
+		if _, err := Rotate(opts.Remote, opts.Keep); err != nil {
+			return info, fmt.Errorf("failed to rotate %s backups: %w", opts.Remote.Name(), err)
+		}
+	}
+
+	return info, nil
+}
+
+func writeArchive(dao *dao.Dao, opts Options, file string, now time.Time) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	writeEntry := func(name string, data []byte) error {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	writeJSON := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		return writeEntry(name, data)
+	}
+
+	// Comments and the pages, users and sites of them
+	artrans, err := artransfer.RunExportArtrans(dao, &artransfer.ExportParams{})
+	if err != nil {
+		return err
+	}
+	if err := writeEntry(entryArtrans, []byte(artrans)); err != nil {
+		return err
+	}
+
+	// Config
+	if opts.ConfigFile != "" {
+		data, err := os.ReadFile(opts.ConfigFile)
+		if err != nil {
+			return err
+		}
+		if err := writeEntry(entryConfig, data); err != nil {
+			return err
+		}
+	}
+
+	// Uploads manifest
+	uploads, err := listUploads(opts.UploadsDir)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(entryUploads, uploads); err != nil {
+		return err
+	}
+
+	meta := Meta{Version: config.Version, CreatedAt: now, Uploads: len(uploads)}
+	dao.DB().Model(&entity.Comment{}).Count(&meta.Comments)
+	if err := writeJSON(entryMeta, meta); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// List the uploaded files with the checksums
+func listUploads(dir string) ([]UploadFile, error) {
+	uploads := []UploadFile{}
+	if dir == "" {
+		return uploads, nil
+	}
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if os.IsNotExist(err) && p == dir {
+			return filepath.SkipDir
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+		sum, err := fileSHA256(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		uploads = append(uploads, UploadFile{
+			Path:       filepath.ToSlash(rel),
+			Size:       stat.Size(),
+			ModifiedAt: stat.ModTime(),
+			SHA256:     sum,
+		})
+		return nil
+	})
+	return uploads, err
+}
+
+func fileSHA256(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// List the local backups, the latest first
+func List(dir string) ([]Info, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []Info{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []Info{}
+	for _, e := range entries {
+		if e.IsDir() || !isBackupFile(e.Name()) {
+			continue
+		}
+		info := Info{Name: e.Name()}
+		if stat, err := e.Info(); err == nil {
+			info.Size = stat.Size()
+		}
+		info.CreatedAt, _ = time.ParseInLocation(fileTimeFormat, e.Name()[len(filePrefix):len(e.Name())-len(fileExt)], time.Local)
+		backups = append(backups, info)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Name > backups[j].Name })
+	return backups, nil
+}
@@ -0,0 +1,165 @@
+package backup
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/db"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	uploadsDir := filepath.Join(dir, "uploads")
+	configFile := filepath.Join(dir, "artalk.yml")
+	os.MkdirAll(filepath.Join(uploadsDir, "2024"), 0o755)
+	os.WriteFile(filepath.Join(uploadsDir, "2024", "a.png"), []byte("image a"), 0o644)
+	os.WriteFile(filepath.Join(uploadsDir, "b.png"), []byte("image b"), 0o644)
+	os.WriteFile(configFile, []byte("site_default: Test\n"), 0o644)
+
+	var backupFile string
+	t.Run("Create", func(t *testing.T) {
+		ddb, _ := db.NewTestDB()
+		defer db.CloseDB(ddb)
+		d := dao.NewDao(ddb)
+
+		d.FindCreateSite("Site", "https://example.com")
+		d.FindCreatePage("/page", "Page", "Site")
+		user, _ := d.FindCreateUser("user", "user@example.com", "")
+		d.CreateComment(&entity.Comment{Content: "Hello", PageKey: "/page", SiteName: "Site", UserID: user.ID})
+
+		remote := &LocalStorage{Dir: filepath.Join(dir, "remote")}
+		opts := Options{
+			Dir:        filepath.Join(dir, "backups"),
+			Keep:       2,
+			ConfigFile: configFile,
+			UploadsDir: uploadsDir,
+			Remote:     remote,
+		}
+
+		// The older backups to rotate
+		os.MkdirAll(opts.Dir, 0o755)
+		for _, ts := range []string{"20200101-000000", "20200102-000000"} {
+			os.WriteFile(filepath.Join(opts.Dir, filePrefix+ts+fileExt), []byte("old"), 0o644)
+			remote.Upload(filePrefix+ts+fileExt, strings.NewReader("old"), 3)
+		}
+
+		info, err := Create(d, opts)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Greater(t, info.Size, int64(0))
+
+		list, err := List(opts.Dir)
+		if assert.NoError(t, err) && assert.Len(t, list, 2, "should keep 2 backups") {
+			assert.Equal(t, info.Name, list[0].Name, "should list the newest first")
+			assert.Equal(t, filePrefix+"20200102-000000"+fileExt, list[1].Name)
+		}
+		remoteList, _ := remote.List()
+		assert.Len(t, remoteList, 2, "should rotate the remote backups")
+		assert.Contains(t, remoteList, info.Name)
+
+		backupFile = filepath.Join(opts.Dir, info.Name)
+		zr, err := zip.OpenReader(backupFile)
+		if assert.NoError(t, err) {
+			names := []string{}
+			for _, f := range zr.File {
+				names = append(names, f.Name)
+			}
+			assert.ElementsMatch(t, []string{entryMeta, entryArtrans, entryConfig, entryUploads}, names)
+			zr.Close()
+		}
+	})
+
+	if backupFile == "" {
+		return
+	}
+
+	// The uploaded file is changed after backed up
+	os.WriteFile(filepath.Join(uploadsDir, "b.png"), []byte("changed"), 0o644)
+
+	t.Run("Restore", func(t *testing.T) {
+		ddb, _ := db.NewTestDB()
+		defer db.CloseDB(ddb)
+		d := dao.NewDao(ddb)
+
+		configOut := filepath.Join(dir, "restored.yml")
+		result, err := Restore(d, backupFile, RestoreOptions{
+			ConfigOut:  configOut,
+			UploadsDir: uploadsDir,
+			Assumeyes:  true,
+			Output:     func(string) {},
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, int64(1), result.Meta.Comments)
+		assert.Equal(t, 2, result.Meta.Uploads)
+		assert.Equal(t, []string{"b.png"}, result.MissingUploads)
+
+		var comments []entity.Comment
+		d.DB().Find(&comments)
+		if assert.Len(t, comments, 1) {
+			assert.Equal(t, "Hello", comments[0].Content)
+		}
+
+		config, _ := os.ReadFile(configOut)
+		assert.Equal(t, "site_default: Test\n", string(config))
+
+		_, err = Restore(d, backupFile, RestoreOptions{ConfigOut: configOut, Assumeyes: true, Output: func(string) {}})
+		assert.Error(t, err, "should not overwrite the existing config file")
+	})
+}
+
+func TestCreateFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the file mode is not supported on windows")
+	}
+
+	ddb, _ := db.NewTestDB()
+	defer db.CloseDB(ddb)
+	d := dao.NewDao(ddb)
+
+	dir := filepath.Join(t.TempDir(), "backups")
+	remote := &LocalStorage{Dir: filepath.Join(t.TempDir(), "remote")}
+	info, err := Create(d, Options{Dir: dir, Keep: 1, Remote: remote})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, dir := range []string{dir, remote.Dir} {
+		stat, err := os.Stat(dir)
+		if assert.NoError(t, err) {
+			assert.Equal(t, os.FileMode(0o700), stat.Mode().Perm(), "the backup dir should be accessible by the owner only")
+		}
+		stat, err = os.Stat(filepath.Join(dir, info.Name))
+		if assert.NoError(t, err) {
+			assert.Equal(t, os.FileMode(0o600), stat.Mode().Perm(), "the backup file should be readable by the owner only")
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, filePrefix+"20240102-030000"+fileExt), []byte("b"), 0o644)
+	os.WriteFile(filepath.Join(dir, filePrefix+"20240101-030000"+fileExt), []byte("a"), 0o644)
+	os.WriteFile(filepath.Join(dir, "other.zip"), []byte("other"), 0o644)
+
+	list, err := List(dir)
+	if assert.NoError(t, err) && assert.Len(t, list, 2) {
+		assert.Equal(t, filePrefix+"20240102-030000"+fileExt, list[0].Name)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 0, 0, 0, time.Local), list[0].CreatedAt)
+	}
+
+	list, err = List(filepath.Join(dir, "not-exists"))
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,49 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule(t *testing.T) {
+	base := time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC) // Wednesday
+
+	for _, tc := range []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"0 3 * * *", time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 31, 11, 0, 0, 0, time.UTC)},
+		{"*/20 * * * *", time.Date(2024, 1, 31, 10, 40, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2024, 2, 1, 10, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 0 30 * *", time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 5", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}, // either the 1st or Friday
+		{"15,45 2 * 3 *", time.Date(2024, 3, 1, 2, 15, 0, 0, time.UTC)},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			s, err := ParseSchedule(tc.expr)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, s.Next(base))
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+			_, err := ParseSchedule(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+
+	t.Run("Never", func(t *testing.T) {
+		s, _ := ParseSchedule("0 0 31 2 *")
+		assert.True(t, s.Next(base).IsZero())
+	})
+}
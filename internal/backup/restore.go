@@ -0,0 +1,114 @@
+package backup
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/artalkjs/artalk/v2/internal/artransfer"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+)
+
+type RestoreOptions struct {
+	ConfigOut  string // The path to extract the config file, skipped if empty
+	UploadsDir string // The directory of uploaded files to verify by the manifest, skipped if empty
+	Assumeyes  bool   // Import without confirmation
+	Output     func(string)
+}
+
+type RestoreResult struct {
+	Meta Meta
+
+	// The uploaded files in the manifest which are missing or changed in the uploads directory,
+	// the uploads are not included in the backup and should be restored separately
+	MissingUploads []string
+}
+
+// Restore the data from the backup file, the comments which already exist are skipped
+func Restore(dao *dao.Dao, file string, opts RestoreOptions) (RestoreResult, error) {
+	result := RestoreResult{MissingUploads: []string{}}
+
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return result, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer zr.Close()
+
+	entries := map[string]*zip.File{}
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+	readEntry := func(name string) ([]byte, error) {
+		f, ok := entries[name]
+		if !ok {
+			return nil, fmt.Errorf("%s is not found in the backup", name)
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+
+	if data, err := readEntry(entryMeta); err != nil {
+		return result, err
+	} else if err := json.Unmarshal(data, &result.Meta); err != nil {
+		return result, fmt.Errorf("invalid backup meta: %w", err)
+	}
+
+	// Import the comments
+	artrans, err := readEntry(entryArtrans)
+	if err != nil {
+		return result, err
+	}
+	if result.Meta.Comments > 0 {
+		var outputFuncs []func(string)
+		if opts.Output != nil {
+			outputFuncs = append(outputFuncs, opts.Output)
+		}
+		if err := artransfer.RunImportArtrans(dao, &artransfer.ImportParams{
+			JsonData:   string(artrans),
+			OnConflict: artransfer.ConflictSkip,
+			Assumeyes:  opts.Assumeyes,
+		}, outputFuncs...); err != nil {
+			return result, err
+		}
+	}
+
+	// Extract the config file
+	if opts.ConfigOut != "" {
+		data, err := readEntry(entryConfig)
+		if err != nil {
+			return result, err
+		}
+		if _, err := os.Stat(opts.ConfigOut); err == nil {
+			return result, fmt.Errorf("the config file %s already exists", opts.ConfigOut)
+		}
+		if err := os.WriteFile(opts.ConfigOut, data, 0o600); err != nil {
+			return result, err
+		}
+	}
+
+	// Verify the uploaded files
+	if opts.UploadsDir != "" {
+		data, err := readEntry(entryUploads)
+		if err != nil {
+			return result, err
+		}
+		var uploads []UploadFile
+		if err := json.Unmarshal(data, &uploads); err != nil {
+			return result, fmt.Errorf("invalid uploads manifest: %w", err)
+		}
+		for _, u := range uploads {
+			if sum, err := fileSHA256(filepath.Join(opts.UploadsDir, filepath.FromSlash(u.Path))); err != nil || sum != u.SHA256 {
+				result.MissingUploads = append(result.MissingUploads, u.Path)
+			}
+		}
+	}
+
+	return result, nil
+}
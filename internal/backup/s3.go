@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/s3"
+)
+
+type S3Options struct {
+	Endpoint  string // The S3 endpoint (e.g. `https://s3.us-east-1.amazonaws.com`), AWS is used if empty
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Prefix    string // The path prefix of objects (e.g. `artalk/`)
+	PathStyle bool   // Use the path style URL (e.g. MinIO), otherwise the virtual-hosted style
+	Client    *http.Client
+}
+
+// The S3 compatible object storage
+type S3Storage struct {
+	client *s3.Client
+	prefix string
+}
+
+func NewS3Storage(opts S3Options) *S3Storage {
+	prefix := strings.TrimPrefix(opts.Prefix, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	return &S3Storage{
+		client: s3.New(s3.Options{
+			Endpoint:  opts.Endpoint,
+			Region:    opts.Region,
+			Bucket:    opts.Bucket,
+			AccessKey: opts.AccessKey,
+			SecretKey: opts.SecretKey,
+			PathStyle: opts.PathStyle,
+			Client:    opts.Client,
+		}),
+		prefix: prefix,
+	}
+}
+
+func (s *S3Storage) Name() string { return "s3" }
+
+func (s *S3Storage) Upload(name string, file io.ReadSeeker, size int64) error {
+	return s.client.PutObject(s.prefix+name, file, size, "application/zip")
+}
+
+func (s *S3Storage) List() ([]string, error) {
+	objects, err := s.client.ListObjects(s.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, obj := range objects {
+		if name := strings.TrimPrefix(obj.Key, s.prefix); !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (s *S3Storage) Delete(name string) error {
+	return s.client.DeleteObject(s.prefix + name)
+}
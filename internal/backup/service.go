@@ -0,0 +1,233 @@
+package backup
+
+import (
+	"cmp"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+var _ core.Service = (*Service)(nil)
+
+const (
+	defaultSchedule = "0 3 * * *"
+	defaultPath     = "./data/backups"
+)
+
+// Back up the full dataset on the schedule, the backups are rotated and uploaded to the remote storage.
+//
+// It is injected by the `cmd` instead of the core, since the core can not depend on the artransfer.
+type Service struct {
+	app  *core.App
+	stop chan struct{}
+
+	mux        sync.Mutex // Only one backup runs at a time
+	statusMux  sync.RWMutex
+	running    bool
+	nextRunAt  time.Time
+	lastRunAt  time.Time
+	lastError  string
+	lastBackup *Info
+}
+
+type Status struct {
+	Enabled    bool       `json:"enabled"`
+	Schedule   string     `json:"schedule"`
+	Remote     string     `json:"remote"` // The remote storage, empty if kept locally only
+	Running    bool       `json:"running"`
+	NextRunAt  *time.Time `json:"next_run_at"`
+	LastRunAt  *time.Time `json:"last_run_at"`
+	LastError  string     `json:"last_error"`
+	LastBackup *Info      `json:"last_backup"`
+	Backups    []Info     `json:"backups"` // The local backups (newest first)
+}
+
+func NewService(app *core.App) *Service {
+	return &Service{app: app}
+}
+
+func (s *Service) Init() error {
+	conf := s.app.Conf().Backup
+	if !conf.Enabled {
+		return nil
+	}
+
+	schedule, err := ParseSchedule(cmp.Or(conf.Schedule, defaultSchedule))
+	if err != nil {
+		log.Error("[BackupService] Invalid config `backup.schedule`: ", err)
+		return nil
+	}
+
+	s.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		for {
+			next := schedule.Next(time.Now())
+			if next.IsZero() {
+				return
+			}
+			s.setStatus(func() { s.nextRunAt = next })
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-timer.C:
+				if info, err := s.Run(); err != nil {
+					log.Error("[BackupService] ", err)
+				} else {
+					log.Info("[BackupService] The backup is created: ", info.Name)
+				}
+			case <-stop:
+				timer.Stop()
+				return
+			}
+		}
+	}(s.stop)
+
+	return nil
+}
+
+func (s *Service) Dispose() error {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+	return nil
+}
+
+// Create a backup now, it waits for the running one to finish
+func (s *Service) Run() (Info, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.setStatus(func() { s.running = true })
+	defer s.setStatus(func() { s.running = false })
+
+	opts, err := s.getOptions()
+	if err != nil {
+		s.setStatus(func() {
+			s.lastRunAt = time.Now()
+			s.lastError = err.Error()
+		})
+		return Info{}, err
+	}
+
+	info, err := Create(s.app.Dao(), opts)
+	s.setStatus(func() {
+		s.lastRunAt = time.Now()
+		s.lastError = ""
+		if err != nil {
+			s.lastError = err.Error()
+		}
+		if info.Name != "" {
+			s.lastBackup = &info
+		}
+	})
+
+	return info, err
+}
+
+// Get the status of the scheduled backup and the local backups
+func (s *Service) Status() Status {
+	conf := s.app.Conf().Backup
+
+	s.statusMux.RLock()
+	status := Status{
+		Enabled:    conf.Enabled,
+		Schedule:   cmp.Or(conf.Schedule, defaultSchedule),
+		Remote:     string(conf.Remote),
+		Running:    s.running,
+		LastError:  s.lastError,
+		LastBackup: s.lastBackup,
+	}
+	if conf.Enabled && !s.nextRunAt.IsZero() {
+		status.NextRunAt = &s.nextRunAt
+	}
+	if !s.lastRunAt.IsZero() {
+		status.LastRunAt = &s.lastRunAt
+	}
+	s.statusMux.RUnlock()
+
+	status.Backups, _ = List(s.GetPath())
+	if status.Backups == nil {
+		status.Backups = []Info{}
+	}
+
+	return status
+}
+
+// Get the local directory of backups
+func (s *Service) GetPath() string {
+	return cmp.Or(s.app.Conf().Backup.Path, defaultPath)
+}
+
+func (s *Service) setStatus(fn func()) {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+	fn()
+}
+
+func (s *Service) getOptions() (Options, error) {
+	conf := s.app.Conf()
+	opts := Options{
+		Dir:        s.GetPath(),
+		Keep:       conf.Backup.Keep,
+		ConfigFile: conf.GetCfgFileLoaded(),
+		UploadsDir: conf.ImgUpload.Path,
+	}
+
+	remote, err := newRemote(conf)
+	if err != nil {
+		return opts, err
+	}
+	opts.Remote = remote
+
+	return opts, nil
+}
+
+// Create the remote storage by the config, nil if kept locally only
+func newRemote(conf *config.Config) (Storage, error) {
+	c := conf.Backup
+	client := utils.NewHTTPClient(conf.HTTP.OutboundProxy, 10*time.Minute)
+
+	switch c.Remote {
+	case config.BackupRemoteNone:
+		return nil, nil
+	case config.BackupRemoteS3:
+		return NewS3Storage(S3Options{
+			Endpoint:  c.S3.Endpoint,
+			Region:    c.S3.Region,
+			Bucket:    c.S3.Bucket,
+			AccessKey: c.S3.AccessKey,
+			SecretKey: c.S3.SecretKey,
+			Prefix:    c.S3.Prefix,
+			PathStyle: c.S3.PathStyle,
+			Client:    client,
+		}), nil
+	case config.BackupRemoteWebDAV:
+		return NewWebDAVStorage(WebDAVOptions{
+			URL:      c.WebDAV.URL,
+			Username: c.WebDAV.Username,
+			Password: c.WebDAV.Password,
+			Client:   client,
+		}), nil
+	case config.BackupRemoteSFTP:
+		return NewSFTPStorage(SFTPOptions{
+			Host:       c.SFTP.Host,
+			Port:       c.SFTP.Port,
+			Username:   c.SFTP.Username,
+			Password:   c.SFTP.Password,
+			PrivateKey: c.SFTP.PrivateKey,
+			HostKey:    c.SFTP.HostKey,
+			KnownHosts: c.SFTP.KnownHosts,
+			Dir:        c.SFTP.Dir,
+
+			InsecureIgnoreHostKey: c.SFTP.InsecureIgnoreHostKey,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown backup remote: %q", c.Remote)
+	}
+}
@@ -0,0 +1,155 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+type SFTPOptions struct {
+	Host       string
+	Port       int // default 22
+	Username   string
+	Password   string
+	PrivateKey string // The path of private key file
+	HostKey    string // The public key of server (e.g. `ssh-ed25519 AAAA...`)
+	KnownHosts string // The path of known_hosts file, used if HostKey is empty
+	Dir        string // The remote directory to store the backups
+
+	// Skip the verification of host key, which is only allowed if set explicitly
+	InsecureIgnoreHostKey bool
+}
+
+var ErrSFTPHostKeyRequired = errors.New("the host key of SFTP server is required, set `host_key` or `known_hosts` (or `insecure_ignore_host_key` to skip the verification)")
+
+// The SFTP server
+type SFTPStorage struct {
+	opts SFTPOptions
+}
+
+func NewSFTPStorage(opts SFTPOptions) *SFTPStorage {
+	if opts.Port == 0 {
+		opts.Port = 22
+	}
+	opts.Dir = strings.TrimSuffix(opts.Dir, "/")
+	if opts.Dir == "" {
+		opts.Dir = "."
+	}
+	return &SFTPStorage{opts: opts}
+}
+
+func (s *SFTPStorage) Name() string { return "sftp" }
+
+func (s *SFTPStorage) Upload(name string, file io.ReadSeeker, _ int64) error {
+	return s.session(func(c *sftp.Client) error {
+		if err := c.MkdirAll(s.opts.Dir); err != nil {
+			return err
+		}
+		f, err := c.Create(path.Join(s.opts.Dir, name))
+		if err != nil {
+			return err
+		}
+		if _, err := f.ReadFrom(file); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	})
+}
+
+func (s *SFTPStorage) List() (names []string, err error) {
+	err = s.session(func(c *sftp.Client) error {
+		files, err := c.ReadDir(s.opts.Dir)
+		if err != nil {
+			return err
+		}
+		names = []string{}
+		for _, f := range files {
+			if f.Mode().IsRegular() {
+				names = append(names, f.Name())
+			}
+		}
+		return nil
+	})
+	return names, err
+}
+
+func (s *SFTPStorage) Delete(name string) error {
+	return s.session(func(c *sftp.Client) error {
+		return c.Remove(path.Join(s.opts.Dir, name))
+	})
+}
+
+// Connect to the server and start the SFTP subsystem
+func (s *SFTPStorage) session(fn func(c *sftp.Client) error) error {
+	hostKeyCallback, err := s.hostKeyCallback()
+	if err != nil {
+		return err
+	}
+
+	auths := []ssh.AuthMethod{}
+	if s.opts.PrivateKey != "" {
+		key, err := os.ReadFile(s.opts.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to read private key: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return fmt.Errorf("failed to parse private key: %w", err)
+		}
+		auths = append(auths, ssh.PublicKeys(signer))
+	}
+	if s.opts.Password != "" {
+		auths = append(auths, ssh.Password(s.opts.Password))
+	}
+
+	conn, err := ssh.Dial("tcp", net.JoinHostPort(s.opts.Host, strconv.Itoa(s.opts.Port)), &ssh.ClientConfig{
+		User:            s.opts.Username,
+		Auth:            auths,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c, err := sftp.NewClient(conn)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	return fn(c)
+}
+
+// Get the verification of host key, refuse to connect if nothing is configured
+func (s *SFTPStorage) hostKeyCallback() (ssh.HostKeyCallback, error) {
+	switch {
+	case s.opts.HostKey != "":
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(s.opts.HostKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse host key: %w", err)
+		}
+		return ssh.FixedHostKey(key), nil
+	case s.opts.KnownHosts != "":
+		callback, err := knownhosts.New(s.opts.KnownHosts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read known_hosts: %w", err)
+		}
+		return callback, nil
+	case s.opts.InsecureIgnoreHostKey:
+		return ssh.InsecureIgnoreHostKey(), nil
+	default:
+		return nil, ErrSFTPHostKeyRequired
+	}
+}
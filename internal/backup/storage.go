@@ -0,0 +1,100 @@
+package backup
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Storage keeps the backup files, which is the local directory or a remote target
+type Storage interface {
+	Name() string
+
+	// Upload the backup file
+	Upload(name string, file io.ReadSeeker, size int64) error
+
+	// List the names of backup files
+	List() ([]string, error)
+
+	// Delete the backup file
+	Delete(name string) error
+}
+
+// The backup files in the local directory
+type LocalStorage struct {
+	Dir string
+}
+
+func (s *LocalStorage) Name() string { return "local" }
+
+func (s *LocalStorage) Upload(name string, file io.ReadSeeker, _ int64) error {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(s.Dir, name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, file)
+	return err
+}
+
+func (s *LocalStorage) List() ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+func (s *LocalStorage) Delete(name string) error {
+	return os.Remove(filepath.Join(s.Dir, name))
+}
+
+// Delete the oldest backups of the storage which exceed the number to keep, the deleted names are returned.
+// The backup files are named with the time, so they are sorted by the name.
+func Rotate(storage Storage, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+
+	names, err := storage.List()
+	if err != nil {
+		return nil, err
+	}
+	backups := []string{}
+	for _, name := range names {
+		if isBackupFile(name) {
+			backups = append(backups, name)
+		}
+	}
+	if len(backups) <= keep {
+		return nil, nil
+	}
+
+	sort.Strings(backups)
+	deleted := backups[:len(backups)-keep]
+	for _, name := range deleted {
+		if err := storage.Delete(name); err != nil {
+			return nil, err
+		}
+	}
+	return deleted, nil
+}
+
+func isBackupFile(name string) bool {
+	return strings.HasPrefix(name, filePrefix) && strings.HasSuffix(name, fileExt)
+}
@@ -0,0 +1,229 @@
+package backup
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/net/webdav"
+)
+
+// Test the storage by uploading, listing, rotating and deleting the backups
+func testStorage(t *testing.T, storage Storage) {
+	names := []string{
+		filePrefix + "20240101-030000" + fileExt,
+		filePrefix + "20240102-030000" + fileExt,
+		filePrefix + "20240103-030000" + fileExt,
+	}
+	for _, name := range names {
+		assert.NoError(t, storage.Upload(name, strings.NewReader("data of "+name), int64(len("data of "+name))))
+	}
+
+	list, err := storage.List()
+	if assert.NoError(t, err) {
+		sort.Strings(list)
+		assert.Equal(t, names, list)
+	}
+
+	deleted, err := Rotate(storage, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, names[:1], deleted, "should delete the oldest backup")
+
+	list, _ = storage.List()
+	sort.Strings(list)
+	assert.Equal(t, names[1:], list)
+}
+
+func TestLocalStorage(t *testing.T) {
+	testStorage(t, &LocalStorage{Dir: t.TempDir()})
+}
+
+func TestS3Storage(t *testing.T) {
+	var mux sync.Mutex
+	objects := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AK/"))
+		key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			assert.NotEmpty(t, r.Header.Get("X-Amz-Content-Sha256"))
+			objects[key] = string(body)
+		case http.MethodDelete:
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			assert.Equal(t, "/bucket/", r.URL.Path)
+			type content struct{ Key string }
+			result := struct {
+				XMLName  xml.Name `xml:"ListBucketResult"`
+				Contents []content
+			}{}
+			for k := range objects {
+				if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+					result.Contents = append(result.Contents, content{k})
+				}
+			}
+			xml.NewEncoder(w).Encode(result)
+		}
+	}))
+	defer server.Close()
+
+	storage := NewS3Storage(S3Options{
+		Endpoint:  server.URL,
+		Bucket:    "bucket",
+		AccessKey: "AK",
+		SecretKey: "SK",
+		Prefix:    "artalk",
+		PathStyle: true,
+	})
+	testStorage(t, storage)
+
+	for k := range objects {
+		assert.True(t, strings.HasPrefix(k, "artalk/"), "should store the objects with the prefix")
+	}
+}
+
+func TestWebDAVStorage(t *testing.T) {
+	handler := &webdav.Handler{FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	testStorage(t, NewWebDAVStorage(WebDAVOptions{URL: server.URL + "/backups", Username: "user", Password: "pass"}))
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		_, err := NewWebDAVStorage(WebDAVOptions{URL: server.URL + "/backups"}).List()
+		assert.Error(t, err)
+	})
+}
+
+func TestSFTPStorage(t *testing.T) {
+	hostKey, addr := serveSFTP(t)
+	host, port, _ := net.SplitHostPort(addr)
+	portNum, _ := strconv.Atoi(port)
+	opts := SFTPOptions{
+		Host:     host,
+		Port:     portNum,
+		Username: "user",
+		Password: "pass",
+		HostKey:  string(ssh.MarshalAuthorizedKey(hostKey)),
+		Dir:      filepath.ToSlash(filepath.Join(t.TempDir(), "backups")),
+	}
+
+	testStorage(t, NewSFTPStorage(opts))
+
+	t.Run("KnownHosts", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "known_hosts")
+		os.WriteFile(file, []byte(knownhosts.Line([]string{addr}, hostKey)+"\n"), 0600)
+
+		o := opts
+		o.HostKey, o.KnownHosts = "", file
+		_, err := NewSFTPStorage(o).List()
+		assert.NoError(t, err)
+	})
+
+	t.Run("HostKeyRequired", func(t *testing.T) {
+		o := opts
+		o.HostKey = ""
+		_, err := NewSFTPStorage(o).List()
+		assert.ErrorIs(t, err, ErrSFTPHostKeyRequired, "should refuse to connect without the host key")
+
+		o.InsecureIgnoreHostKey = true
+		_, err = NewSFTPStorage(o).List()
+		assert.NoError(t, err)
+	})
+
+	t.Run("HostKeyMismatch", func(t *testing.T) {
+		pub, _, _ := ed25519.GenerateKey(rand.Reader)
+		otherKey, _ := ssh.NewPublicKey(pub)
+
+		o := opts
+		o.HostKey = string(ssh.MarshalAuthorizedKey(otherKey))
+		_, err := NewSFTPStorage(o).List()
+		assert.Error(t, err)
+	})
+}
+
+// Start an SSH server with the SFTP subsystem, the host key and address are returned
+func serveSFTP(t *testing.T) (ssh.PublicKey, string) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() != "user" || string(pass) != "pass" {
+				return nil, fmt.Errorf("password rejected")
+			}
+			return nil, nil
+		},
+	}
+	conf.AddHostKey(signer)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			nConn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, chans, reqs, err := ssh.NewServerConn(nConn, conf)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(reqs)
+				for newChan := range chans {
+					channel, requests, err := newChan.Accept()
+					if err != nil {
+						continue
+					}
+					go func() {
+						for req := range requests {
+							ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+							req.Reply(ok, nil)
+							if ok {
+								server, _ := sftp.NewServer(channel)
+								server.Serve()
+								server.Close()
+							}
+						}
+					}()
+				}
+			}()
+		}
+	}()
+
+	return signer.PublicKey(), ln.Addr().String()
+}
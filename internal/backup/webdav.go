@@ -0,0 +1,115 @@
+package backup
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+type WebDAVOptions struct {
+	URL      string // The directory URL to store the backups (e.g. `https://dav.example.com/backups/`)
+	Username string
+	Password string
+	Client   *http.Client
+}
+
+// The WebDAV server (e.g. Nextcloud, Nutstore)
+type WebDAVStorage struct {
+	opts WebDAVOptions
+}
+
+func NewWebDAVStorage(opts WebDAVOptions) *WebDAVStorage {
+	opts.URL = strings.TrimSuffix(opts.URL, "/") + "/"
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Minute}
+	}
+	return &WebDAVStorage{opts: opts}
+}
+
+func (s *WebDAVStorage) Name() string { return "webdav" }
+
+func (s *WebDAVStorage) Upload(name string, file io.ReadSeeker, size int64) error {
+	// Create the directory, which fails if it already exists
+	if req, err := http.NewRequest("MKCOL", s.opts.URL, nil); err == nil {
+		_, _ = s.do(req)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, s.opts.URL+url.PathEscape(name), file)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	_, err = s.do(req)
+	return err
+}
+
+func (s *WebDAVStorage) List() ([]string, error) {
+	req, err := http.NewRequest("PROPFIND", s.opts.URL, strings.NewReader(
+		`<?xml version="1.0" encoding="utf-8"?><propfind xmlns="DAV:"><prop><resourcetype/></prop></propfind>`))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "application/xml")
+	body, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Responses []struct {
+			Href       string    `xml:"href"`
+			Collection *struct{} `xml:"propstat>prop>resourcetype>collection"`
+		} `xml:"response"`
+	}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, r := range result.Responses {
+		if r.Collection != nil {
+			continue // the directory itself or the sub directories
+		}
+		href, err := url.PathUnescape(r.Href)
+		if err != nil {
+			href = r.Href
+		}
+		names = append(names, path.Base(href))
+	}
+	return names, nil
+}
+
+func (s *WebDAVStorage) Delete(name string) error {
+	req, err := http.NewRequest(http.MethodDelete, s.opts.URL+url.PathEscape(name), nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.do(req)
+	return err
+}
+
+func (s *WebDAVStorage) do(req *http.Request) ([]byte, error) {
+	if s.opts.Username != "" {
+		req.SetBasicAuth(s.opts.Username, s.opts.Password)
+	}
+	resp, err := s.opts.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("webdav responded with status %d", resp.StatusCode)
+	}
+	return body, nil
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/log"
 	"github.com/eko/gocache/lib/v4/store"
@@ -54,6 +55,7 @@ func (c *Cache) FindCache(name string, dest any) error {
 	// `Get()` is Thread Safe, so no need to add Mutex
 	// @see https://github.com/go-redis/redis/issues/23
 	_, err := c.marshal.Get(c.ctx, name, dest)
+	c.stats.record(name, err == nil)
 	if err != nil {
 		log.Debug("[CacheMis] " + name)
 		return err
@@ -68,8 +70,9 @@ func (c *Cache) StoreCache(source any, name ...string) (err error) {
 		log.Debug("[StoreCache] " + n)
 
 		// `Set()` is Thread Safe, no need to add Mutex
+		// The expiration time can be configured by the key prefix (e.g. "comment")
 		if setErr := c.marshal.Set(c.ctx, n, source,
-			store.WithExpiration(c.ttl),
+			store.WithExpiration(time.Duration(c.conf.GetKeyExpiresTime(n))),
 		); setErr != nil {
 			err = setErr
 		}
@@ -173,3 +173,21 @@ func TestQueryDBWithCache(t *testing.T) {
 		}
 	})
 }
+
+func TestStats(t *testing.T) {
+	cache := newTestCache(t)
+	defer cache.Close()
+
+	var data string
+	_ = cache.FindCache("comment#id=1", &data)
+	_ = cache.StoreCache("hello", "comment#id=1")
+	_ = cache.FindCache("comment#id=1", &data)
+	_ = cache.FindCache("comment#id=1", &data)
+	_ = cache.FindCache("page#id=1", &data)
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(2), stats["comment"].Hits)
+	assert.Equal(t, uint64(1), stats["comment"].Misses)
+	assert.InDelta(t, 2.0/3, stats["comment"].HitRate(), 0.001)
+	assert.Equal(t, uint64(1), stats["page"].Misses, "should be counted by the key prefix")
+}
@@ -15,15 +15,16 @@ import (
 	bigcache_store "github.com/eko/gocache/store/bigcache/v4"
 	memcache_store "github.com/eko/gocache/store/memcache/v4"
 	redis_store "github.com/eko/gocache/store/redis/v4"
-	"github.com/redis/go-redis/v9"
 )
 
 type Cache struct {
 	ttl      time.Duration
+	conf     config.CacheConf
 	ctx      context.Context
 	cancel   context.CancelFunc
 	instance *lib_cache.Cache[any]
 	marshal  *marshaler.Marshaler
+	stats    statsCounters
 }
 
 func (cache *Cache) Close() {
@@ -38,6 +39,7 @@ func New(conf config.CacheConf) (*Cache, error) {
 
 	cache := &Cache{
 		ttl:    time.Duration(conf.GetExpiresTime()),
+		conf:   conf,
 		ctx:    ctx,
 		cancel: cancel,
 	}
@@ -58,19 +60,12 @@ func New(conf config.CacheConf) (*Cache, error) {
 		cacheStore = bigcache_store.NewBigcache(bigcacheClient) // No options provided (as second argument)
 
 	case config.CacheTypeRedis:
-		// Redis
-		network := "tcp"
-		if conf.Redis.Network != "" {
-			network = conf.Redis.Network
+		// Redis (standalone, cluster or sentinel)
+		client, err := newRedisClient(conf)
+		if err != nil {
+			return nil, err
 		}
-
-		cacheStore = redis_store.NewRedis(redis.NewClient(&redis.Options{
-			Network:  network,
-			Addr:     conf.Server,
-			Username: conf.Redis.Username,
-			Password: conf.Redis.Password,
-			DB:       conf.Redis.DB,
-		}))
+		cacheStore = redis_store.NewRedis(client)
 
 	case config.CacheTypeMemcache:
 		// Memcache
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// Create the Redis client by the `cache` config, which is also used by the store of cluster mode
+func NewRedisClient(conf config.CacheConf) (redis.UniversalClient, error) {
+	return newRedisClient(conf)
+}
+
+// Create the Redis client by the deployment mode of `cache.redis.mode`,
+// the addresses of cluster nodes or sentinels are separated by commas in `cache.server`.
+func newRedisClient(conf config.CacheConf) (redis.UniversalClient, error) {
+	tlsConf, err := newRedisTLSConfig(conf.Redis.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := splitRedisAddrs(conf.Server)
+
+	switch conf.Redis.Mode {
+	case "", config.RedisModeStandalone:
+		network := "tcp"
+		if conf.Redis.Network != "" {
+			network = conf.Redis.Network
+		}
+
+		return redis.NewClient(&redis.Options{
+			Network:   network,
+			Addr:      conf.Server,
+			Username:  conf.Redis.Username,
+			Password:  conf.Redis.Password,
+			DB:        conf.Redis.DB,
+			TLSConfig: tlsConf,
+		}), nil
+
+	case config.RedisModeCluster:
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("the addresses of redis cluster nodes are required")
+		}
+
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     addrs,
+			Username:  conf.Redis.Username,
+			Password:  conf.Redis.Password,
+			TLSConfig: tlsConf,
+		}), nil
+
+	case config.RedisModeSentinel:
+		if len(addrs) == 0 || conf.Redis.MasterName == "" {
+			return nil, fmt.Errorf("the addresses of redis sentinels and the master name are required")
+		}
+
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       conf.Redis.MasterName,
+			SentinelAddrs:    addrs,
+			SentinelUsername: conf.Redis.SentinelUsername,
+			SentinelPassword: conf.Redis.SentinelPassword,
+			Username:         conf.Redis.Username,
+			Password:         conf.Redis.Password,
+			DB:               conf.Redis.DB,
+			TLSConfig:        tlsConf,
+		}), nil
+	}
+
+	return nil, fmt.Errorf(`invalid redis mode "%s", please check config option "cache.redis.mode"`, conf.Redis.Mode)
+}
+
+func splitRedisAddrs(server string) []string {
+	addrs := []string{}
+	for _, addr := range strings.Split(server, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// Create the TLS config of Redis connections, nil if TLS is disabled
+func newRedisTLSConfig(conf config.RedisTLSConf) (*tls.Config, error) {
+	if !conf.Enabled {
+		return nil, nil
+	}
+
+	tlsConf := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         conf.ServerName,
+		InsecureSkipVerify: conf.InsecureSkipVerify,
+	}
+
+	if conf.CACert != "" {
+		pem, err := os.ReadFile(conf.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the redis CA cert: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid cert is found in the redis CA cert %s", conf.CACert)
+		}
+		tlsConf.RootCAs = pool
+	}
+
+	if conf.Cert != "" || conf.Key != "" {
+		cert, err := tls.LoadX509KeyPair(conf.Cert, conf.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the redis client cert: %w", err)
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConf, nil
+}
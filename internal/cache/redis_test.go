@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRedisClient(t *testing.T) {
+	t.Run("Standalone", func(t *testing.T) {
+		client, err := newRedisClient(config.CacheConf{
+			Server: "localhost:6379",
+			Redis:  config.RedisConf{Password: "pass", DB: 2},
+		})
+		if assert.NoError(t, err) {
+			defer client.Close()
+			if assert.IsType(t, &redis.Client{}, client) {
+				opts := client.(*redis.Client).Options()
+				assert.Equal(t, "tcp", opts.Network)
+				assert.Equal(t, "localhost:6379", opts.Addr)
+				assert.Equal(t, 2, opts.DB)
+				assert.Nil(t, opts.TLSConfig)
+			}
+		}
+	})
+
+	t.Run("Cluster", func(t *testing.T) {
+		client, err := newRedisClient(config.CacheConf{
+			Server: "node1:6379, node2:6379,",
+			Redis:  config.RedisConf{Mode: config.RedisModeCluster},
+		})
+		if assert.NoError(t, err) {
+			defer client.Close()
+			if assert.IsType(t, &redis.ClusterClient{}, client) {
+				assert.Equal(t, []string{"node1:6379", "node2:6379"}, client.(*redis.ClusterClient).Options().Addrs)
+			}
+		}
+
+		_, err = newRedisClient(config.CacheConf{Redis: config.RedisConf{Mode: config.RedisModeCluster}})
+		assert.Error(t, err, "the addresses are required")
+	})
+
+	t.Run("Sentinel", func(t *testing.T) {
+		client, err := newRedisClient(config.CacheConf{
+			Server: "sentinel1:26379,sentinel2:26379",
+			Redis:  config.RedisConf{Mode: config.RedisModeSentinel, MasterName: "mymaster"},
+		})
+		if assert.NoError(t, err) {
+			defer client.Close()
+			assert.IsType(t, &redis.Client{}, client, "the failover client should be created")
+		}
+
+		_, err = newRedisClient(config.CacheConf{
+			Server: "sentinel1:26379",
+			Redis:  config.RedisConf{Mode: config.RedisModeSentinel},
+		})
+		assert.Error(t, err, "the master name is required")
+	})
+
+	t.Run("Invalid mode", func(t *testing.T) {
+		_, err := newRedisClient(config.CacheConf{Redis: config.RedisConf{Mode: "unknown"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewRedisTLSConfig(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		tlsConf, err := newRedisTLSConfig(config.RedisTLSConf{ServerName: "redis.example.com"})
+		assert.NoError(t, err)
+		assert.Nil(t, tlsConf)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		tlsConf, err := newRedisTLSConfig(config.RedisTLSConf{
+			Enabled:            true,
+			ServerName:         "redis.example.com",
+			InsecureSkipVerify: true,
+		})
+		if assert.NoError(t, err) && assert.NotNil(t, tlsConf) {
+			assert.Equal(t, "redis.example.com", tlsConf.ServerName)
+			assert.True(t, tlsConf.InsecureSkipVerify)
+			assert.Nil(t, tlsConf.RootCAs, "the system certs should be used")
+		}
+	})
+
+	t.Run("Invalid CA cert", func(t *testing.T) {
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		os.WriteFile(caFile, []byte("not a cert"), 0644)
+
+		_, err := newRedisTLSConfig(config.RedisTLSConf{Enabled: true, CACert: caFile})
+		assert.Error(t, err)
+
+		_, err = newRedisTLSConfig(config.RedisTLSConf{Enabled: true, CACert: filepath.Join(t.TempDir(), "missing.pem")})
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing client key", func(t *testing.T) {
+		_, err := newRedisTLSConfig(config.RedisTLSConf{Enabled: true, Cert: "client.pem"})
+		assert.Error(t, err)
+	})
+}
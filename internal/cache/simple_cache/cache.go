@@ -10,20 +10,20 @@ type Cache struct {
 	data sync.Map
 	// when the number of entries reaches gcThold, GC will be triggered
 	gcThold int
-	len	 atomic.Int32
+	len     atomic.Int32
 }
 
 func New() *Cache {
 	return &Cache{
 		gcThold: 1000,
-		len: atomic.Int32{},
+		len:     atomic.Int32{},
 	}
 }
 
 func NewWithGCThold(gcThold int) *Cache {
 	return &Cache{
 		gcThold: gcThold,
-		len: atomic.Int32{},
+		len:     atomic.Int32{},
 	}
 }
 
@@ -63,7 +63,7 @@ func (c *Cache) Delete(key string) {
 	c.len.Add(-1)
 }
 
-func (c* Cache) GC(force bool) {
+func (c *Cache) GC(force bool) {
 	if int(c.len.Load()) < c.gcThold && !force {
 		return
 	}
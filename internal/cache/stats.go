@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// The hit rate metrics of cache lookups by the key prefix (e.g. "comment" of "comment#id=1")
+type Stats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// Get the hit rate (range 0~1)
+func (s Stats) HitRate() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+type statsCounter struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+type statsCounters struct {
+	prefixes sync.Map // map[string]*statsCounter
+}
+
+func (s *statsCounters) record(key string, hit bool) {
+	prefix, _, _ := strings.Cut(key, "#")
+	v, _ := s.prefixes.LoadOrStore(prefix, &statsCounter{})
+	if c := v.(*statsCounter); hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+// Get the hit rate metrics of cache lookups by the key prefix since the cache is created
+func (cache *Cache) Stats() map[string]Stats {
+	stats := map[string]Stats{}
+	cache.stats.prefixes.Range(func(k, v any) bool {
+		c := v.(*statsCounter)
+		stats[k.(string)] = Stats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+		return true
+	})
+	return stats
+}
@@ -1,7 +1,10 @@
 package captcha
 
 import (
+	"context"
+
 	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/store"
 )
 
 type Map = map[string]interface{}
@@ -9,6 +12,15 @@ type Map = map[string]interface{}
 type CheckerConf struct {
 	config.CaptchaConf
 	User User
+
+	// The global outbound proxy for verification requests
+	OutboundProxy string
+
+	// The store of the captcha answers and challenges (shared by the instances in cluster mode)
+	Store store.Store
+
+	// The context of the verification requests (optional, e.g. the span of the comment request for tracing)
+	Context context.Context
 }
 
 type User struct {
@@ -32,15 +44,25 @@ type Checker interface {
 func NewCaptchaChecker(conf *CheckerConf) Checker {
 	switch conf.CaptchaType {
 	case config.TypeImage:
-		return NewImageChecker(&conf.User)
+		return NewImageChecker(&conf.User, conf.Store)
 	case config.TypeTurnstile:
-		return NewTurnstileChecker(&conf.Turnstile, &conf.User)
+		checker := NewTurnstileChecker(&conf.Turnstile, &conf.User, conf.OutboundProxy)
+		checker.Context = conf.Context
+		return checker
 	case config.TypeReCaptcha:
-		return NewReCaptchaChecker(&conf.ReCaptcha, &conf.User)
+		checker := NewReCaptchaChecker(&conf.ReCaptcha, &conf.User, conf.OutboundProxy)
+		checker.Context = conf.Context
+		return checker
 	case config.TypeHCaptcha:
-		return NewHCaptchaChecker(&conf.HCaptcha, &conf.User)
+		checker := NewHCaptchaChecker(&conf.HCaptcha, &conf.User, conf.OutboundProxy)
+		checker.Context = conf.Context
+		return checker
 	case config.TypeGeetest:
-		return NewGeetestChecker(&conf.Geetest, &conf.User)
+		checker := NewGeetestChecker(&conf.Geetest, &conf.User)
+		checker.Context = conf.Context
+		return checker
+	case config.TypePow:
+		return NewPowChecker(&conf.Pow, &conf.User, conf.Store)
 	default:
 		panic("Unknown captcha type")
 	}
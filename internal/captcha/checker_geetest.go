@@ -1,14 +1,17 @@
 package captcha
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/utils"
 	"github.com/tidwall/gjson"
 )
 
@@ -20,6 +23,9 @@ type GeetestCaptchaChecker struct {
 	User       *User
 	CaptchaID  string
 	CaptchaKey string
+
+	// The context of the verification request (optional, e.g. the span of the comment request for tracing)
+	Context context.Context
 }
 
 func NewGeetestChecker(conf *config.GeetestConf, user *User) *GeetestCaptchaChecker {
@@ -57,8 +63,14 @@ func (c *GeetestCaptchaChecker) Check(value string) (bool, error) {
 
 	// 发起 POST 请求
 	url := GEETEST_API + "/validate?captcha_id=" + c.CaptchaID
-	cli := http.Client{Timeout: time.Second * 10} // 10s 超时
-	resp, err := cli.PostForm(url, values)
+	req, err := http.NewRequestWithContext(verifyContext(c.Context), http.MethodPost, url, strings.NewReader(values.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	cli := utils.NewTracedHTTPClient("", time.Second*10) // 10s 超时
+	resp, err := cli.Do(req)
 	if err != nil || resp.StatusCode != 200 {
 		return false, err
 	}
@@ -2,17 +2,20 @@ package captcha
 
 import (
 	"github.com/artalkjs/artalk/v2/internal/captcha/image_captcha"
+	"github.com/artalkjs/artalk/v2/internal/store"
 )
 
 var _ Checker = (*ImageChecker)(nil)
 
 type ImageChecker struct {
-	User *User
+	User  *User
+	Store store.Store
 }
 
-func NewImageChecker(user *User) *ImageChecker {
+func NewImageChecker(user *User, store store.Store) *ImageChecker {
 	return &ImageChecker{
-		User: user,
+		User:  user,
+		Store: store,
 	}
 }
 
@@ -21,9 +24,9 @@ func (c *ImageChecker) Type() CaptchaType {
 }
 
 func (c *ImageChecker) Check(value string) (bool, error) {
-	return image_captcha.CheckImageCaptchaCode(c.User.IP, value), nil
+	return image_captcha.CheckImageCaptchaCode(c.Store, c.User.IP, value), nil
 }
 
 func (c *ImageChecker) Get() ([]byte, error) {
-	return image_captcha.GetNewImageCaptchaBase64(c.User.IP)
+	return image_captcha.GetNewImageCaptchaBase64(c.Store, c.User.IP)
 }
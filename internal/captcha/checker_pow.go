@@ -0,0 +1,43 @@
+package captcha
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/captcha/pow_captcha"
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/store"
+)
+
+var _ Checker = (*PowChecker)(nil)
+
+type PowChecker struct {
+	User       *User
+	Store      store.Store
+	Difficulty int
+}
+
+func NewPowChecker(conf *config.PowConf, user *User, store store.Store) *PowChecker {
+	return &PowChecker{
+		User:       user,
+		Store:      store,
+		Difficulty: conf.Difficulty,
+	}
+}
+
+func (c *PowChecker) Check(value string) (bool, error) {
+	return pow_captcha.Verify(c.Store, c.User.IP, value), nil
+}
+
+func (c *PowChecker) Type() CaptchaType {
+	return IFrame
+}
+
+func (c *PowChecker) Get() ([]byte, error) {
+	challenge, err := pow_captcha.NewChallenge(c.Store, c.User.IP, c.Difficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	return RenderIFrame("pow.html", Map{
+		"salt":       challenge.Salt,
+		"difficulty": challenge.Difficulty,
+	})
+}
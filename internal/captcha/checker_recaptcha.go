@@ -1,62 +1,36 @@
 package captcha
 
 import (
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"time"
+	"context"
 
 	"github.com/artalkjs/artalk/v2/internal/config"
-	"github.com/tidwall/gjson"
 )
 
 const RECAPTCHA_API = "https://www.google.com/recaptcha/api/siteverify"
 
-var _ Checker = (*ReCaptchaChecker)(nil)
+var _ WidgetChecker = (*ReCaptchaChecker)(nil)
 
 type ReCaptchaChecker struct {
 	User       *User
 	SiteKey    string
 	SecreteKey string
+	Proxy      string
+
+	// The context of the verification request (optional, e.g. the span of the comment request for tracing)
+	Context context.Context
 }
 
-func NewReCaptchaChecker(conf *config.ReCaptchaConf, user *User) *ReCaptchaChecker {
+func NewReCaptchaChecker(conf *config.ReCaptchaConf, user *User, outboundProxy string) *ReCaptchaChecker {
 	return &ReCaptchaChecker{
 		User:       user,
 		SiteKey:    conf.SiteKey,
 		SecreteKey: conf.SecretKey,
+		Proxy:      resolveProxy(conf.Proxy, outboundProxy),
 	}
 }
 
 func (c *ReCaptchaChecker) Check(value string) (bool, error) {
-	// 构建 POST 请求的参数
-	values := make(url.Values)
-	values.Add("secret", c.SecreteKey)
-	values.Add("response", value)
-	if c.User.IP != "" {
-		values.Add("remoteip", c.User.IP)
-	}
-
-	// 发送 POST 请求
-	url := RECAPTCHA_API
-	cli := http.Client{Timeout: time.Second * 10} // 10s 超时
-	resp, err := cli.PostForm(url, values)
-	if err != nil || resp.StatusCode != 200 {
-		return false, err
-	}
-	defer resp.Body.Close()
-
-	// 解析响应内容
-	respBuf, _ := io.ReadAll(resp.Body)
-	success := gjson.GetBytes(respBuf, "success")
-	if success.Exists() && success.Bool() {
-		// 验证成功
-		return true, nil
-	} else {
-		// 验证失败
-		return false, fmt.Errorf("err reason: %s", gjson.GetBytes(respBuf, "error-codes").String())
-	}
+	return siteVerify(verifyContext(c.Context), RECAPTCHA_API, c.SecreteKey, value, c.User.IP, c.Proxy)
 }
 
 func (c *ReCaptchaChecker) Type() CaptchaType {
@@ -68,3 +42,11 @@ func (c *ReCaptchaChecker) Get() ([]byte, error) {
 		"site_key": c.SiteKey,
 	})
 }
+
+func (c *ReCaptchaChecker) Widget() Widget {
+	return Widget{
+		Provider:  "recaptcha",
+		SiteKey:   c.SiteKey,
+		ScriptURL: "https://www.google.com/recaptcha/api.js",
+	}
+}
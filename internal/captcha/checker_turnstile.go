@@ -1,62 +1,36 @@
 package captcha
 
 import (
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"time"
+	"context"
 
 	"github.com/artalkjs/artalk/v2/internal/config"
-	"github.com/tidwall/gjson"
 )
 
 const TURNSTILE_API = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
 
-var _ Checker = (*TurnstileChecker)(nil)
+var _ WidgetChecker = (*TurnstileChecker)(nil)
 
 type TurnstileChecker struct {
 	User       *User
 	SiteKey    string
 	SecreteKey string
+	Proxy      string
+
+	// The context of the verification request (optional, e.g. the span of the comment request for tracing)
+	Context context.Context
 }
 
-func NewTurnstileChecker(conf *config.TurnstileConf, user *User) *TurnstileChecker {
+func NewTurnstileChecker(conf *config.TurnstileConf, user *User, outboundProxy string) *TurnstileChecker {
 	return &TurnstileChecker{
 		User:       user,
 		SiteKey:    conf.SiteKey,
 		SecreteKey: conf.SecretKey,
+		Proxy:      resolveProxy(conf.Proxy, outboundProxy),
 	}
 }
 
 func (c *TurnstileChecker) Check(value string) (bool, error) {
-	// 构建 POST 请求的参数
-	values := make(url.Values)
-	values.Add("secret", c.SecreteKey)
-	values.Add("response", value)
-	if c.User.IP != "" {
-		values.Add("remoteip", c.User.IP)
-	}
-
-	// 发送 POST 请求
-	url := TURNSTILE_API
-	cli := http.Client{Timeout: time.Second * 10} // 10s 超时
-	resp, err := cli.PostForm(url, values)
-	if err != nil || resp.StatusCode != 200 {
-		return false, err
-	}
-	defer resp.Body.Close()
-
-	// 解析响应内容
-	respBuf, _ := io.ReadAll(resp.Body)
-	success := gjson.GetBytes(respBuf, "success")
-	if success.Exists() && success.Bool() {
-		// 验证成功
-		return true, nil
-	} else {
-		// 验证失败
-		return false, fmt.Errorf("err reason: %s", gjson.GetBytes(respBuf, "error-codes").String())
-	}
+	return siteVerify(verifyContext(c.Context), TURNSTILE_API, c.SecreteKey, value, c.User.IP, c.Proxy)
 }
 
 func (c *TurnstileChecker) Type() CaptchaType {
@@ -68,3 +42,11 @@ func (c *TurnstileChecker) Get() ([]byte, error) {
 		"site_key": c.SiteKey,
 	})
 }
+
+func (c *TurnstileChecker) Widget() Widget {
+	return Widget{
+		Provider:  "turnstile",
+		SiteKey:   c.SiteKey,
+		ScriptURL: "https://challenges.cloudflare.com/turnstile/v0/api.js",
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/artalkjs/artalk/v2/internal/cache/simple_cache"
+	"github.com/artalkjs/artalk/v2/internal/store"
 	"github.com/steambap/captcha"
 )
 
@@ -16,17 +16,16 @@ const (
 	CaptchaCachePrefix = "atk_captcha:"
 )
 
-var captchaStore = simple_cache.New()
-
 // 获取对应 IP 图片验证码正确的值
-func CheckImageCaptchaCode(ip string, code string) bool {
-	realVal, isFound := captchaStore.Get(CaptchaCachePrefix + ip)
-	return isFound && strings.EqualFold(realVal.(string), code)
+// (验证码的值保存于存储中，集群模式下由多个实例共享)
+func CheckImageCaptchaCode(s store.Store, ip string, code string) bool {
+	realVal, isFound := s.Get(CaptchaCachePrefix + ip)
+	return isFound && strings.EqualFold(realVal, code)
 }
 
 // 获取新验证码 base64 格式图片
 // (调用该函数将销毁原有验证码)
-func GetNewImageCaptchaBase64(ip string) ([]byte, error) {
+func GetNewImageCaptchaBase64(s store.Store, ip string) ([]byte, error) {
 	// generate a image
 	pngBuffer := bytes.NewBuffer([]byte{})
 	data, err := captcha.New(160, 40, func(o *captcha.Options) {
@@ -47,12 +46,14 @@ func GetNewImageCaptchaBase64(ip string) ([]byte, error) {
 	base64 := "data:image/png;base64," + base64.StdEncoding.EncodeToString(pngBuffer.Bytes())
 
 	// save real code
-	captchaStore.Set(CaptchaCachePrefix+ip, data.Text, CaptchaExpiration)
+	if err := s.Set(CaptchaCachePrefix+ip, data.Text, CaptchaExpiration); err != nil {
+		return nil, err
+	}
 
 	return []byte(base64), nil
 }
 
 // 销毁图片验证码
-func InvalidateImageCaptcha(ip string) {
-	captchaStore.Delete(CaptchaCachePrefix + ip)
+func InvalidateImageCaptcha(s store.Store, ip string) {
+	_ = s.Delete(CaptchaCachePrefix + ip)
 }
@@ -3,13 +3,15 @@ package image_captcha
 import (
 	"testing"
 
+	"github.com/artalkjs/artalk/v2/internal/store"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestImageCaptcha(t *testing.T) {
 	testIP := "127.0.0.1"
+	captchaStore := store.NewMemoryStore()
 
-	buf, err := GetNewImageCaptchaBase64(testIP)
+	buf, err := GetNewImageCaptchaBase64(captchaStore, testIP)
 	if assert.NoError(t, err) {
 		assert.NotEmpty(t, buf)
 	}
@@ -20,24 +22,24 @@ func TestImageCaptcha(t *testing.T) {
 	}
 
 	t.Run("CheckCorrect", func(t *testing.T) {
-		ok := CheckImageCaptchaCode(testIP, realCode.(string))
+		ok := CheckImageCaptchaCode(captchaStore, testIP, realCode)
 		assert.True(t, ok, "code should be correct")
 	})
 
 	t.Run("CheckIncorrect", func(t *testing.T) {
-		ok := CheckImageCaptchaCode(testIP, "123456")
+		ok := CheckImageCaptchaCode(captchaStore, testIP, "123456")
 		assert.False(t, ok, "code should be incorrect")
 	})
 
 	t.Run("Invalidate", func(t *testing.T) {
-		InvalidateImageCaptcha(testIP)
-		ok := CheckImageCaptchaCode(testIP, realCode.(string))
+		InvalidateImageCaptcha(captchaStore, testIP)
+		ok := CheckImageCaptchaCode(captchaStore, testIP, realCode)
 		assert.False(t, ok, "code should be incorrect")
 	})
 
 	t.Run("Regenerate and Check", func(t *testing.T) {
 		// generate x1
-		buf, err := GetNewImageCaptchaBase64(testIP)
+		buf, err := GetNewImageCaptchaBase64(captchaStore, testIP)
 		if assert.NoError(t, err) {
 			assert.NotEmpty(t, buf)
 		}
@@ -48,16 +50,16 @@ func TestImageCaptcha(t *testing.T) {
 			assert.NotEmpty(t, realCode, "captcha cached code should not be empty")
 		}
 
-		ok := CheckImageCaptchaCode(testIP, realCode.(string))
+		ok := CheckImageCaptchaCode(captchaStore, testIP, realCode)
 		assert.True(t, ok, "code should be correct")
 
 		// generate x2
-		buf, err = GetNewImageCaptchaBase64(testIP)
+		buf, err = GetNewImageCaptchaBase64(captchaStore, testIP)
 		if assert.NoError(t, err) {
 			assert.NotEmpty(t, buf)
 		}
 
-		ok = CheckImageCaptchaCode(testIP, realCode.(string))
+		ok = CheckImageCaptchaCode(captchaStore, testIP, realCode)
 		assert.False(t, ok, "code should be incorrect after regenerate")
 	})
 }
@@ -0,0 +1,96 @@
+package pow_captcha
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/bits"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/store"
+)
+
+const (
+	ChallengeExpiration  = 5 * time.Minute // 挑战 5 分钟内有效
+	ChallengeCachePrefix = "atk_pow_captcha:"
+	DefaultDifficulty    = 16 // 默认难度 (前导零比特数)
+	MaxDifficulty        = 32
+)
+
+// 工作量证明挑战
+//
+// 客户端需找到一个 nonce 使 sha256(salt + nonce) 的前导零比特数不小于 difficulty
+type Challenge struct {
+	Salt       string `json:"salt"`
+	Difficulty int    `json:"difficulty"`
+}
+
+// 获取新的挑战
+// (调用该函数将销毁原有挑战，挑战保存于存储中，集群模式下由多个实例共享)
+func NewChallenge(s store.Store, ip string, difficulty int) (Challenge, error) {
+	if difficulty <= 0 {
+		difficulty = DefaultDifficulty
+	}
+	difficulty = min(difficulty, MaxDifficulty)
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return Challenge{}, err
+	}
+
+	challenge := Challenge{
+		Salt:       hex.EncodeToString(buf),
+		Difficulty: difficulty,
+	}
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return Challenge{}, err
+	}
+	if err := s.Set(ChallengeCachePrefix+ip, string(data), ChallengeExpiration); err != nil {
+		return Challenge{}, err
+	}
+
+	return challenge, nil
+}
+
+// 校验对应 IP 挑战的解 (格式为 "salt:nonce")
+// (校验通过后挑战将被销毁，不可重复使用)
+func Verify(s store.Store, ip string, value string) bool {
+	salt, nonce, ok := strings.Cut(value, ":")
+	if !ok || nonce == "" {
+		return false
+	}
+
+	cached, isFound := s.Get(ChallengeCachePrefix + ip)
+	if !isFound {
+		return false
+	}
+	var challenge Challenge
+	if err := json.Unmarshal([]byte(cached), &challenge); err != nil {
+		return false
+	}
+	if challenge.Salt != salt || !IsSolved(challenge, nonce) {
+		return false
+	}
+
+	_ = s.Delete(ChallengeCachePrefix + ip)
+	return true
+}
+
+// 判断 nonce 是否满足挑战难度
+func IsSolved(challenge Challenge, nonce string) bool {
+	return leadingZeroBits(sha256.Sum256([]byte(challenge.Salt+nonce))) >= challenge.Difficulty
+}
+
+func leadingZeroBits(hash [sha256.Size]byte) int {
+	n := 0
+	for _, b := range hash {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
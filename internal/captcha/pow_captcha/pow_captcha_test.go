@@ -0,0 +1,63 @@
+package pow_captcha
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func solve(challenge Challenge) string {
+	for i := 0; ; i++ {
+		if nonce := strconv.Itoa(i); IsSolved(challenge, nonce) {
+			return nonce
+		}
+	}
+}
+
+func TestPowCaptcha(t *testing.T) {
+	testIP := "127.0.0.1"
+	challengeStore := store.NewMemoryStore()
+
+	challenge, err := NewChallenge(challengeStore, testIP, 8)
+	if assert.NoError(t, err) {
+		assert.Len(t, challenge.Salt, 32)
+		assert.Equal(t, 8, challenge.Difficulty)
+	}
+
+	nonce := solve(challenge)
+
+	t.Run("CheckIncorrect", func(t *testing.T) {
+		assert.False(t, Verify(challengeStore, testIP, ""))
+		assert.False(t, Verify(challengeStore, testIP, challenge.Salt), "should reject the value without nonce")
+		assert.False(t, Verify(challengeStore, testIP, "other_salt:"+nonce), "should reject the mismatched salt")
+		assert.False(t, Verify(challengeStore, "127.0.0.2", challenge.Salt+":"+nonce), "should reject the solution of others")
+	})
+
+	t.Run("CheckCorrect", func(t *testing.T) {
+		assert.True(t, Verify(challengeStore, testIP, challenge.Salt+":"+nonce))
+		assert.False(t, Verify(challengeStore, testIP, challenge.Salt+":"+nonce), "should not be reused")
+	})
+
+	t.Run("Regenerate", func(t *testing.T) {
+		first, _ := NewChallenge(challengeStore, testIP, 8)
+		second, _ := NewChallenge(challengeStore, testIP, 8)
+		assert.NotEqual(t, first.Salt, second.Salt)
+		assert.False(t, Verify(challengeStore, testIP, first.Salt+":"+solve(first)), "should be incorrect after regenerate")
+		assert.True(t, Verify(challengeStore, testIP, second.Salt+":"+solve(second)))
+	})
+
+	t.Run("Difficulty", func(t *testing.T) {
+		challenge, _ := NewChallenge(challengeStore, testIP, 0)
+		assert.Equal(t, DefaultDifficulty, challenge.Difficulty)
+		challenge, _ = NewChallenge(challengeStore, testIP, 100)
+		assert.Equal(t, MaxDifficulty, challenge.Difficulty)
+	})
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+	hash := [32]byte{0x00, 0x0f}
+	assert.Equal(t, 12, leadingZeroBits(hash))
+	assert.Equal(t, 256, leadingZeroBits([32]byte{}))
+}
@@ -0,0 +1,81 @@
+package captcha
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/tidwall/gjson"
+)
+
+// 前端组件配置 (用于前端直接渲染服务商的验证组件，而非 iframe 页面)
+type Widget struct {
+	Provider  string `json:"provider"`   // 服务商 (turnstile, recaptcha, hcaptcha)
+	SiteKey   string `json:"site_key"`   // 站点密钥
+	ScriptURL string `json:"script_url"` // 组件脚本地址
+}
+
+// 支持前端组件的验证码
+type WidgetChecker interface {
+	Checker
+	Widget() Widget
+}
+
+// 通过 siteverify API 校验 token (reCAPTCHA, Turnstile 和 hCaptcha 兼容)
+func siteVerify(ctx context.Context, api string, secret string, token string, remoteIP string, proxy string) (bool, error) {
+	// 构建 POST 请求的参数
+	values := make(url.Values)
+	values.Add("secret", secret)
+	values.Add("response", token)
+	if remoteIP != "" {
+		values.Add("remoteip", remoteIP)
+	}
+
+	// 发送 POST 请求
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, api, strings.NewReader(values.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	cli := utils.NewTracedHTTPClient(proxy, time.Second*10) // 10s 超时
+	resp, err := cli.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return false, fmt.Errorf("siteverify API error: HTTP %d", resp.StatusCode)
+	}
+
+	// 解析响应内容
+	respBuf, _ := io.ReadAll(resp.Body)
+	success := gjson.GetBytes(respBuf, "success")
+	if success.Exists() && success.Bool() {
+		// 验证成功
+		return true, nil
+	} else {
+		// 验证失败
+		return false, fmt.Errorf("err reason: %s", gjson.GetBytes(respBuf, "error-codes").String())
+	}
+}
+
+// 未指定请求的 context 时使用 Background
+func verifyContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+// 优先使用服务商独立配置的代理
+func resolveProxy(proxy string, outboundProxy string) string {
+	return cmp.Or(strings.TrimSpace(proxy), outboundProxy)
+}
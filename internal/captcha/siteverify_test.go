@@ -0,0 +1,67 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.PostFormValue("secret"))
+		assert.Equal(t, "127.0.0.1", r.PostFormValue("remoteip"))
+
+		switch r.PostFormValue("response") {
+		case "valid":
+			w.Write([]byte(`{"success": true}`))
+		case "invalid":
+			w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+		default:
+			w.WriteHeader(500)
+		}
+	}))
+	defer server.Close()
+
+	pass, err := siteVerify(context.Background(), server.URL, "secret", "valid", "127.0.0.1", "")
+	assert.NoError(t, err)
+	assert.True(t, pass)
+
+	pass, err = siteVerify(context.Background(), server.URL, "secret", "invalid", "127.0.0.1", "")
+	assert.ErrorContains(t, err, "invalid-input-response")
+	assert.False(t, pass)
+
+	pass, err = siteVerify(context.Background(), server.URL, "secret", "error", "127.0.0.1", "")
+	assert.ErrorContains(t, err, "HTTP 500", "should return error if the API is unavailable")
+	assert.False(t, pass)
+}
+
+func TestWidget(t *testing.T) {
+	user := &User{IP: "127.0.0.1"}
+
+	tests := []struct {
+		checker  WidgetChecker
+		provider string
+	}{
+		{NewTurnstileChecker(&config.TurnstileConf{SiteKey: "site_key"}, user, ""), "turnstile"},
+		{NewReCaptchaChecker(&config.ReCaptchaConf{SiteKey: "site_key"}, user, ""), "recaptcha"},
+		{NewHCaptchaChecker(&config.HCaptchaConf{SiteKey: "site_key"}, user, ""), "hcaptcha"},
+	}
+	for _, tt := range tests {
+		widget := tt.checker.Widget()
+		assert.Equal(t, tt.provider, widget.Provider)
+		assert.Equal(t, "site_key", widget.SiteKey)
+		assert.NotEmpty(t, widget.ScriptURL)
+	}
+
+	_, ok := NewCaptchaChecker(&CheckerConf{CaptchaConf: config.CaptchaConf{CaptchaType: config.TypeImage}}).(WidgetChecker)
+	assert.False(t, ok, "should not support the widget of image captcha")
+
+	checker := NewHCaptchaChecker(&config.HCaptchaConf{Proxy: "direct"}, user, "socks5://127.0.0.1:1080")
+	assert.Equal(t, "direct", checker.Proxy, "should take the provider proxy")
+	checker = NewHCaptchaChecker(&config.HCaptchaConf{}, user, "socks5://127.0.0.1:1080")
+	assert.Equal(t, "socks5://127.0.0.1:1080", checker.Proxy, "should fall back to the global proxy")
+}
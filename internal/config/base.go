@@ -146,6 +146,11 @@ func (conf *Config) normalPatch() {
 		log.Warn("[SocialLogin] config `auth.callback` is not set, now it is: ", strconv.Quote(callbackURL))
 		conf.Auth.Callback = callbackURL
 	}
+
+	// 邮箱验证配置 (验证链接不从请求的 Host 生成，防止被伪造)
+	if conf.Moderator.EmailVerify.Enabled && strings.TrimSpace(conf.Moderator.EmailVerify.ServerURL) == "" {
+		log.Warn("[EmailVerify] config `moderator.email_verify.server_url` is not set, the verification emails will not be sent")
+	}
 }
 
 // 多语言配置修补
@@ -197,7 +202,11 @@ func (conf *Config) ipRegionPatch() {
 
 	// IP 属地默认数据文件
 	if conf.IPRegion.DBPath == "" {
-		conf.IPRegion.DBPath = "./data/ip2region.xdb"
+		if conf.IPRegion.Provider == string(IPRegionProviderMaxMind) {
+			conf.IPRegion.DBPath = "./data/GeoLite2-City.mmdb"
+		} else {
+			conf.IPRegion.DBPath = "./data/ip2region.xdb"
+		}
 	}
 
 	// 检测配置文件是否存在
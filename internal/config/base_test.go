@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/artalkjs/artalk/v2/internal/utils"
@@ -155,3 +156,13 @@ func TestRetrieveDataDir(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheKeyExpiresTime(t *testing.T) {
+	conf := CacheConf{Expires: 10, TTLs: map[string]int{"comment": 60, "user": -1}}
+
+	assert.Equal(t, int64(60*time.Minute), conf.GetKeyExpiresTime("comment#id=1"))
+	assert.Equal(t, int64(-1), conf.GetKeyExpiresTime("user#id=1"), "-1 should keep the TTL")
+	assert.Equal(t, int64(10*time.Minute), conf.GetKeyExpiresTime("comment_child_ids#id=1"), "the prefix should be matched exactly")
+	assert.Equal(t, int64(10*time.Minute), conf.GetKeyExpiresTime("page#id=1"))
+	assert.Equal(t, int64(30*time.Minute), (&CacheConf{}).GetKeyExpiresTime("page#id=1"))
+}
@@ -5,7 +5,7 @@ package config
 import "github.com/artalkjs/artalk/v2/internal/config/meta"
 
 // Cache result of `meta.GetEnvPathMap(config.Config{}, config.Template("en"))`
-var EnvPathMapCache = map[string]string{"ATK_ADMIN_NOTIFY_BARK_ENABLED":"admin_notify.bark.enabled", "ATK_ADMIN_NOTIFY_BARK_SERVER":"admin_notify.bark.server", "ATK_ADMIN_NOTIFY_DING_TALK_ENABLED":"admin_notify.ding_talk.enabled", "ATK_ADMIN_NOTIFY_DING_TALK_SECRET":"admin_notify.ding_talk.secret", "ATK_ADMIN_NOTIFY_DING_TALK_TOKEN":"admin_notify.ding_talk.token", "ATK_ADMIN_NOTIFY_EMAIL":"admin_notify.email", "ATK_ADMIN_NOTIFY_EMAIL_ENABLED":"admin_notify.email.enabled", "ATK_ADMIN_NOTIFY_EMAIL_MAIL_SUBJECT":"admin_notify.email.mail_subject", "ATK_ADMIN_NOTIFY_EMAIL_MAIL_TPL":"admin_notify.email.mail_tpl", "ATK_ADMIN_NOTIFY_LARK_ENABLED":"admin_notify.lark.enabled", "ATK_ADMIN_NOTIFY_LARK_MSG_TYPE":"admin_notify.lark.msg_type", "ATK_ADMIN_NOTIFY_LARK_WEBHOOK_URL":"admin_notify.lark.webhook_url", "ATK_ADMIN_NOTIFY_LINE_CHANNEL_ACCESS_TOKEN":"admin_notify.line.channel_access_token", "ATK_ADMIN_NOTIFY_LINE_CHANNEL_SECRET":"admin_notify.line.channel_secret", "ATK_ADMIN_NOTIFY_LINE_ENABLED":"admin_notify.line.enabled", "ATK_ADMIN_NOTIFY_LINE_RECEIVERS":"admin_notify.line.receivers", "ATK_ADMIN_NOTIFY_LINE_RECEIVERS_$$":"admin_notify.line.receivers.$$", "ATK_ADMIN_NOTIFY_NOISE_MODE":"admin_notify.noise_mode", "ATK_ADMIN_NOTIFY_NOTIFY_PENDING":"admin_notify.notify_pending", "ATK_ADMIN_NOTIFY_NOTIFY_SUBJECT":"admin_notify.notify_subject", "ATK_ADMIN_NOTIFY_NOTIFY_TPL":"admin_notify.notify_tpl", "ATK_ADMIN_NOTIFY_SLACK_ENABLED":"admin_notify.slack.enabled", "ATK_ADMIN_NOTIFY_SLACK_OAUTH_TOKEN":"admin_notify.slack.oauth_token", "ATK_ADMIN_NOTIFY_SLACK_RECEIVERS":"admin_notify.slack.receivers", "ATK_ADMIN_NOTIFY_SLACK_RECEIVERS_$$":"admin_notify.slack.receivers.$$", "ATK_ADMIN_NOTIFY_TELEGRAM_API_TOKEN":"admin_notify.telegram.api_token", "ATK_ADMIN_NOTIFY_TELEGRAM_ENABLED":"admin_notify.telegram.enabled", "ATK_ADMIN_NOTIFY_TELEGRAM_RECEIVERS":"admin_notify.telegram.receivers", "ATK_ADMIN_NOTIFY_TELEGRAM_RECEIVERS_$$":"admin_notify.telegram.receivers.$$", "ATK_ADMIN_NOTIFY_WEBHOOK_ENABLED":"admin_notify.webhook.enabled", "ATK_ADMIN_NOTIFY_WEBHOOK_URL":"admin_notify.webhook.url", "ATK_ADMIN_USERS_$$_BADGE_COLOR":"admin_users.$$.badge_color", "ATK_ADMIN_USERS_$$_BADGE_NAME":"admin_users.$$.badge_name", "ATK_ADMIN_USERS_$$_EMAIL":"admin_users.$$.email", "ATK_ADMIN_USERS_$$_LINK":"admin_users.$$.link", "ATK_ADMIN_USERS_$$_NAME":"admin_users.$$.name", "ATK_ADMIN_USERS_$$_PASSWORD":"admin_users.$$.password", "ATK_ADMIN_USERS_$$_RECEIVE_EMAIL":"admin_users.$$.receive_email", "ATK_ADMIN__NOTIFY_BARK_ENABLED":"admin_notify.bark.enabled", "ATK_ADMIN__NOTIFY_BARK_SERVER":"admin_notify.bark.server", "ATK_ADMIN__NOTIFY_DING__TALK_ENABLED":"admin_notify.ding_talk.enabled", "ATK_ADMIN__NOTIFY_DING__TALK_SECRET":"admin_notify.ding_talk.secret", "ATK_ADMIN__NOTIFY_DING__TALK_TOKEN":"admin_notify.ding_talk.token", "ATK_ADMIN__NOTIFY_EMAIL":"admin_notify.email", "ATK_ADMIN__NOTIFY_LARK_ENABLED":"admin_notify.lark.enabled", "ATK_ADMIN__NOTIFY_LARK_MSG__TYPE":"admin_notify.lark.msg_type", "ATK_ADMIN__NOTIFY_LARK_WEBHOOK__URL":"admin_notify.lark.webhook_url", "ATK_ADMIN__NOTIFY_LINE_CHANNEL__ACCESS__TOKEN":"admin_notify.line.channel_access_token", "ATK_ADMIN__NOTIFY_LINE_CHANNEL__SECRET":"admin_notify.line.channel_secret", "ATK_ADMIN__NOTIFY_LINE_ENABLED":"admin_notify.line.enabled", "ATK_ADMIN__NOTIFY_LINE_RECEIVERS_$$":"admin_notify.line.receivers.$$", "ATK_ADMIN__NOTIFY_NOISE__MODE":"admin_notify.noise_mode", "ATK_ADMIN__NOTIFY_NOTIFY__PENDING":"admin_notify.notify_pending", "ATK_ADMIN__NOTIFY_NOTIFY__SUBJECT":"admin_notify.notify_subject", "ATK_ADMIN__NOTIFY_NOTIFY__TPL":"admin_notify.notify_tpl", "ATK_ADMIN__NOTIFY_SLACK_ENABLED":"admin_notify.slack.enabled", "ATK_ADMIN__NOTIFY_SLACK_OAUTH__TOKEN":"admin_notify.slack.oauth_token", "ATK_ADMIN__NOTIFY_SLACK_RECEIVERS_$$":"admin_notify.slack.receivers.$$", "ATK_ADMIN__NOTIFY_TELEGRAM_API__TOKEN":"admin_notify.telegram.api_token", "ATK_ADMIN__NOTIFY_TELEGRAM_ENABLED":"admin_notify.telegram.enabled", "ATK_ADMIN__NOTIFY_TELEGRAM_RECEIVERS_$$":"admin_notify.telegram.receivers.$$", "ATK_ADMIN__NOTIFY_WEBHOOK_ENABLED":"admin_notify.webhook.enabled", "ATK_ADMIN__NOTIFY_WEBHOOK_URL":"admin_notify.webhook.url", "ATK_ADMIN__USERS_$$_BADGE__COLOR":"admin_users.$$.badge_color", "ATK_ADMIN__USERS_$$_BADGE__NAME":"admin_users.$$.badge_name", "ATK_ADMIN__USERS_$$_EMAIL":"admin_users.$$.email", "ATK_ADMIN__USERS_$$_LINK":"admin_users.$$.link", "ATK_ADMIN__USERS_$$_NAME":"admin_users.$$.name", "ATK_ADMIN__USERS_$$_PASSWORD":"admin_users.$$.password", "ATK_ADMIN__USERS_$$_RECEIVE__EMAIL":"admin_users.$$.receive_email", "ATK_APP_KEY":"app_key", "ATK_APP__KEY":"app_key", "ATK_AUTH_ANONYMOUS":"auth.anonymous", "ATK_AUTH_APPLE_CLIENT_ID":"auth.apple.client_id", "ATK_AUTH_APPLE_CLIENT_SECRET":"auth.apple.client_secret", "ATK_AUTH_APPLE_CLIENT__ID":"auth.apple.client_id", "ATK_AUTH_APPLE_CLIENT__SECRET":"auth.apple.client_secret", "ATK_AUTH_APPLE_ENABLED":"auth.apple.enabled", "ATK_AUTH_AUTH0_CLIENT_ID":"auth.auth0.client_id", "ATK_AUTH_AUTH0_CLIENT_SECRET":"auth.auth0.client_secret", "ATK_AUTH_AUTH0_CLIENT__ID":"auth.auth0.client_id", "ATK_AUTH_AUTH0_CLIENT__SECRET":"auth.auth0.client_secret", "ATK_AUTH_AUTH0_DOMAIN":"auth.auth0.domain", "ATK_AUTH_AUTH0_ENABLED":"auth.auth0.enabled", "ATK_AUTH_CALLBACK":"auth.callback", "ATK_AUTH_DISCORD_CLIENT_ID":"auth.discord.client_id", "ATK_AUTH_DISCORD_CLIENT_SECRET":"auth.discord.client_secret", "ATK_AUTH_DISCORD_CLIENT__ID":"auth.discord.client_id", "ATK_AUTH_DISCORD_CLIENT__SECRET":"auth.discord.client_secret", "ATK_AUTH_DISCORD_ENABLED":"auth.discord.enabled", "ATK_AUTH_EMAIL_ENABLED":"auth.email.enabled", "ATK_AUTH_EMAIL_VERIFY_SUBJECT":"auth.email.verify_subject", "ATK_AUTH_EMAIL_VERIFY_TPL":"auth.email.verify_tpl", "ATK_AUTH_EMAIL_VERIFY__SUBJECT":"auth.email.verify_subject", "ATK_AUTH_EMAIL_VERIFY__TPL":"auth.email.verify_tpl", "ATK_AUTH_ENABLED":"auth.enabled", "ATK_AUTH_FACEBOOK_CLIENT_ID":"auth.facebook.client_id", "ATK_AUTH_FACEBOOK_CLIENT_SECRET":"auth.facebook.client_secret", "ATK_AUTH_FACEBOOK_CLIENT__ID":"auth.facebook.client_id", "ATK_AUTH_FACEBOOK_CLIENT__SECRET":"auth.facebook.client_secret", "ATK_AUTH_FACEBOOK_ENABLED":"auth.facebook.enabled", "ATK_AUTH_GITEA_CLIENT_ID":"auth.gitea.client_id", "ATK_AUTH_GITEA_CLIENT_SECRET":"auth.gitea.client_secret", "ATK_AUTH_GITEA_CLIENT__ID":"auth.gitea.client_id", "ATK_AUTH_GITEA_CLIENT__SECRET":"auth.gitea.client_secret", "ATK_AUTH_GITEA_ENABLED":"auth.gitea.enabled", "ATK_AUTH_GITHUB_CLIENT_ID":"auth.github.client_id", "ATK_AUTH_GITHUB_CLIENT_SECRET":"auth.github.client_secret", "ATK_AUTH_GITHUB_CLIENT__ID":"auth.github.client_id", "ATK_AUTH_GITHUB_CLIENT__SECRET":"auth.github.client_secret", "ATK_AUTH_GITHUB_ENABLED":"auth.github.enabled", "ATK_AUTH_GITLAB_CLIENT_ID":"auth.gitlab.client_id", "ATK_AUTH_GITLAB_CLIENT_SECRET":"auth.gitlab.client_secret", "ATK_AUTH_GITLAB_CLIENT__ID":"auth.gitlab.client_id", "ATK_AUTH_GITLAB_CLIENT__SECRET":"auth.gitlab.client_secret", "ATK_AUTH_GITLAB_ENABLED":"auth.gitlab.enabled", "ATK_AUTH_GOOGLE_CLIENT_ID":"auth.google.client_id", "ATK_AUTH_GOOGLE_CLIENT_SECRET":"auth.google.client_secret", "ATK_AUTH_GOOGLE_CLIENT__ID":"auth.google.client_id", "ATK_AUTH_GOOGLE_CLIENT__SECRET":"auth.google.client_secret", "ATK_AUTH_GOOGLE_ENABLED":"auth.google.enabled", "ATK_AUTH_LINE_CLIENT_ID":"auth.line.client_id", "ATK_AUTH_LINE_CLIENT_SECRET":"auth.line.client_secret", "ATK_AUTH_LINE_CLIENT__ID":"auth.line.client_id", "ATK_AUTH_LINE_CLIENT__SECRET":"auth.line.client_secret", "ATK_AUTH_LINE_ENABLED":"auth.line.enabled", "ATK_AUTH_MASTODON_CLIENT_ID":"auth.mastodon.client_id", "ATK_AUTH_MASTODON_CLIENT_SECRET":"auth.mastodon.client_secret", "ATK_AUTH_MASTODON_CLIENT__ID":"auth.mastodon.client_id", "ATK_AUTH_MASTODON_CLIENT__SECRET":"auth.mastodon.client_secret", "ATK_AUTH_MASTODON_ENABLED":"auth.mastodon.enabled", "ATK_AUTH_MICROSOFT_CLIENT_ID":"auth.microsoft.client_id", "ATK_AUTH_MICROSOFT_CLIENT_SECRET":"auth.microsoft.client_secret", "ATK_AUTH_MICROSOFT_CLIENT__ID":"auth.microsoft.client_id", "ATK_AUTH_MICROSOFT_CLIENT__SECRET":"auth.microsoft.client_secret", "ATK_AUTH_MICROSOFT_ENABLED":"auth.microsoft.enabled", "ATK_AUTH_PATREON_CLIENT_ID":"auth.patreon.client_id", "ATK_AUTH_PATREON_CLIENT_SECRET":"auth.patreon.client_secret", "ATK_AUTH_PATREON_CLIENT__ID":"auth.patreon.client_id", "ATK_AUTH_PATREON_CLIENT__SECRET":"auth.patreon.client_secret", "ATK_AUTH_PATREON_ENABLED":"auth.patreon.enabled", "ATK_AUTH_SLACK_CLIENT_ID":"auth.slack.client_id", "ATK_AUTH_SLACK_CLIENT_SECRET":"auth.slack.client_secret", "ATK_AUTH_SLACK_CLIENT__ID":"auth.slack.client_id", "ATK_AUTH_SLACK_CLIENT__SECRET":"auth.slack.client_secret", "ATK_AUTH_SLACK_ENABLED":"auth.slack.enabled", "ATK_AUTH_STEAM_API_KEY":"auth.steam.api_key", "ATK_AUTH_STEAM_API__KEY":"auth.steam.api_key", "ATK_AUTH_STEAM_ENABLED":"auth.steam.enabled", "ATK_AUTH_TIKTOK_CLIENT_ID":"auth.tiktok.client_id", "ATK_AUTH_TIKTOK_CLIENT_SECRET":"auth.tiktok.client_secret", "ATK_AUTH_TIKTOK_CLIENT__ID":"auth.tiktok.client_id", "ATK_AUTH_TIKTOK_CLIENT__SECRET":"auth.tiktok.client_secret", "ATK_AUTH_TIKTOK_ENABLED":"auth.tiktok.enabled", "ATK_AUTH_TWITTER_CLIENT_ID":"auth.twitter.client_id", "ATK_AUTH_TWITTER_CLIENT_SECRET":"auth.twitter.client_secret", "ATK_AUTH_TWITTER_CLIENT__ID":"auth.twitter.client_id", "ATK_AUTH_TWITTER_CLIENT__SECRET":"auth.twitter.client_secret", "ATK_AUTH_TWITTER_ENABLED":"auth.twitter.enabled", "ATK_AUTH_WECHAT_CLIENT_ID":"auth.wechat.client_id", "ATK_AUTH_WECHAT_CLIENT_SECRET":"auth.wechat.client_secret", "ATK_AUTH_WECHAT_CLIENT__ID":"auth.wechat.client_id", "ATK_AUTH_WECHAT_CLIENT__SECRET":"auth.wechat.client_secret", "ATK_AUTH_WECHAT_ENABLED":"auth.wechat.enabled", "ATK_CACHE_ENABLED":"cache.enabled", "ATK_CACHE_EXPIRES":"cache.expires", "ATK_CACHE_REDIS_DB":"cache.redis.db", "ATK_CACHE_REDIS_NETWORK":"cache.redis.network", "ATK_CACHE_REDIS_PASSWORD":"cache.redis.password", "ATK_CACHE_REDIS_USERNAME":"cache.redis.username", "ATK_CACHE_SERVER":"cache.server", "ATK_CACHE_TYPE":"cache.type", "ATK_CACHE_WARM_UP":"cache.warm_up", "ATK_CACHE_WARM__UP":"cache.warm_up", "ATK_CAPTCHA_ACTION_LIMIT":"captcha.action_limit", "ATK_CAPTCHA_ACTION_RESET":"captcha.action_reset", "ATK_CAPTCHA_ACTION__LIMIT":"captcha.action_limit", "ATK_CAPTCHA_ACTION__RESET":"captcha.action_reset", "ATK_CAPTCHA_ALWAYS":"captcha.always", "ATK_CAPTCHA_CAPTCHA_TYPE":"captcha.captcha_type", "ATK_CAPTCHA_CAPTCHA__TYPE":"captcha.captcha_type", "ATK_CAPTCHA_ENABLED":"captcha.enabled", "ATK_CAPTCHA_GEETEST_CAPTCHA_ID":"captcha.geetest.captcha_id", "ATK_CAPTCHA_GEETEST_CAPTCHA_KEY":"captcha.geetest.captcha_key", "ATK_CAPTCHA_GEETEST_CAPTCHA__ID":"captcha.geetest.captcha_id", "ATK_CAPTCHA_GEETEST_CAPTCHA__KEY":"captcha.geetest.captcha_key", "ATK_CAPTCHA_HCAPTCHA_SECRET_KEY":"captcha.hcaptcha.secret_key", "ATK_CAPTCHA_HCAPTCHA_SECRET__KEY":"captcha.hcaptcha.secret_key", "ATK_CAPTCHA_HCAPTCHA_SITE_KEY":"captcha.hcaptcha.site_key", "ATK_CAPTCHA_HCAPTCHA_SITE__KEY":"captcha.hcaptcha.site_key", "ATK_CAPTCHA_RECAPTCHA_SECRET_KEY":"captcha.recaptcha.secret_key", "ATK_CAPTCHA_RECAPTCHA_SECRET__KEY":"captcha.recaptcha.secret_key", "ATK_CAPTCHA_RECAPTCHA_SITE_KEY":"captcha.recaptcha.site_key", "ATK_CAPTCHA_RECAPTCHA_SITE__KEY":"captcha.recaptcha.site_key", "ATK_CAPTCHA_TURNSTILE_SECRET_KEY":"captcha.turnstile.secret_key", "ATK_CAPTCHA_TURNSTILE_SECRET__KEY":"captcha.turnstile.secret_key", "ATK_CAPTCHA_TURNSTILE_SITE_KEY":"captcha.turnstile.site_key", "ATK_CAPTCHA_TURNSTILE_SITE__KEY":"captcha.turnstile.site_key", "ATK_DB_CHARSET":"db.charset", "ATK_DB_DSN":"db.dsn", "ATK_DB_FILE":"db.file", "ATK_DB_HOST":"db.host", "ATK_DB_NAME":"db.name", "ATK_DB_PASSWORD":"db.password", "ATK_DB_PORT":"db.port", "ATK_DB_PREPARE_STMT":"db.prepare_stmt", "ATK_DB_PREPARE__STMT":"db.prepare_stmt", "ATK_DB_SSL":"db.ssl", "ATK_DB_TABLE_PREFIX":"db.table_prefix", "ATK_DB_TABLE__PREFIX":"db.table_prefix", "ATK_DB_TYPE":"db.type", "ATK_DB_USER":"db.user", "ATK_DEBUG":"debug", "ATK_EMAIL_ALI_DM_ACCESS_KEY_ID":"email.ali_dm.access_key_id", "ATK_EMAIL_ALI_DM_ACCESS_KEY_SECRET":"email.ali_dm.access_key_secret", "ATK_EMAIL_ALI_DM_ACCOUNT_NAME":"email.ali_dm.account_name", "ATK_EMAIL_ALI_DM_REGION":"email.ali_dm.region", "ATK_EMAIL_ALI__DM_ACCESS__KEY__ID":"email.ali_dm.access_key_id", "ATK_EMAIL_ALI__DM_ACCESS__KEY__SECRET":"email.ali_dm.access_key_secret", "ATK_EMAIL_ALI__DM_ACCOUNT__NAME":"email.ali_dm.account_name", "ATK_EMAIL_ALI__DM_REGION":"email.ali_dm.region", "ATK_EMAIL_ENABLED":"email.enabled", "ATK_EMAIL_MAIL_SUBJECT":"email.mail_subject", "ATK_EMAIL_MAIL_TPL":"email.mail_tpl", "ATK_EMAIL_MAIL__SUBJECT":"email.mail_subject", "ATK_EMAIL_MAIL__TPL":"email.mail_tpl", "ATK_EMAIL_QUEUE_BUFFER_SIZE":"email.queue.buffer_size", "ATK_EMAIL_QUEUE_BUFFER__SIZE":"email.queue.buffer_size", "ATK_EMAIL_SEND_ADDR":"email.send_addr", "ATK_EMAIL_SEND_NAME":"email.send_name", "ATK_EMAIL_SEND_TYPE":"email.send_type", "ATK_EMAIL_SEND__ADDR":"email.send_addr", "ATK_EMAIL_SEND__NAME":"email.send_name", "ATK_EMAIL_SEND__TYPE":"email.send_type", "ATK_EMAIL_SMTP_FROM":"email.smtp.from", "ATK_EMAIL_SMTP_HOST":"email.smtp.host", "ATK_EMAIL_SMTP_PASSWORD":"email.smtp.password", "ATK_EMAIL_SMTP_PORT":"email.smtp.port", "ATK_EMAIL_SMTP_USERNAME":"email.smtp.username", "ATK_FRONTEND":"frontend", "ATK_FRONTEND_DARKMODE":"frontend.darkMode", "ATK_FRONTEND_EDITORTRAVEL":"frontend.editorTravel", "ATK_FRONTEND_EMOTICONS":"frontend.emoticons", "ATK_FRONTEND_FLATMODE":"frontend.flatMode", "ATK_FRONTEND_GRAVATAR_MIRROR":"frontend.gravatar.mirror", "ATK_FRONTEND_GRAVATAR_PARAMS":"frontend.gravatar.params", "ATK_FRONTEND_HEIGHTLIMIT_CHILDREN":"frontend.heightLimit.children", "ATK_FRONTEND_HEIGHTLIMIT_CONTENT":"frontend.heightLimit.content", "ATK_FRONTEND_HEIGHTLIMIT_SCROLLABLE":"frontend.heightLimit.scrollable", "ATK_FRONTEND_IMGLAZYLOAD":"frontend.imgLazyLoad", "ATK_FRONTEND_LISTSORT":"frontend.listSort", "ATK_FRONTEND_NESTMAX":"frontend.nestMax", "ATK_FRONTEND_NESTSORT":"frontend.nestSort", "ATK_FRONTEND_NOCOMMENT":"frontend.noComment", "ATK_FRONTEND_PAGINATION_AUTOLOAD":"frontend.pagination.autoLoad", "ATK_FRONTEND_PAGINATION_PAGESIZE":"frontend.pagination.pageSize", "ATK_FRONTEND_PAGINATION_READMORE":"frontend.pagination.readMore", "ATK_FRONTEND_PLACEHOLDER":"frontend.placeholder", "ATK_FRONTEND_PLUGINURLS":"frontend.pluginURLs", "ATK_FRONTEND_PREVIEW":"frontend.preview", "ATK_FRONTEND_REQTIMEOUT":"frontend.reqTimeout", "ATK_FRONTEND_SENDBTN":"frontend.sendBtn", "ATK_FRONTEND_UABADGE":"frontend.uaBadge", "ATK_FRONTEND_VERSIONCHECK":"frontend.versionCheck", "ATK_FRONTEND_VOTE":"frontend.vote", "ATK_FRONTEND_VOTEDOWN":"frontend.voteDown", "ATK_HOST":"host", "ATK_HTTP_BODY_LIMIT":"http.body_limit", "ATK_HTTP_BODY__LIMIT":"http.body_limit", "ATK_HTTP_PROXY_HEADER":"http.proxy_header", "ATK_HTTP_PROXY__HEADER":"http.proxy_header", "ATK_IMG_UPLOAD_ENABLED":"img_upload.enabled", "ATK_IMG_UPLOAD_MAX_SIZE":"img_upload.max_size", "ATK_IMG_UPLOAD_PATH":"img_upload.path", "ATK_IMG_UPLOAD_PUBLIC_PATH":"img_upload.public_path", "ATK_IMG_UPLOAD_QUALITY":"img_upload.quality", "ATK_IMG_UPLOAD_UPGIT_DEL_LOCAL":"img_upload.upgit.del_local", "ATK_IMG_UPLOAD_UPGIT_ENABLED":"img_upload.upgit.enabled", "ATK_IMG_UPLOAD_UPGIT_EXEC":"img_upload.upgit.exec", "ATK_IMG__UPLOAD_ENABLED":"img_upload.enabled", "ATK_IMG__UPLOAD_MAX__SIZE":"img_upload.max_size", "ATK_IMG__UPLOAD_PATH":"img_upload.path", "ATK_IMG__UPLOAD_PUBLIC__PATH":"img_upload.public_path", "ATK_IMG__UPLOAD_QUALITY":"img_upload.quality", "ATK_IMG__UPLOAD_UPGIT_DEL__LOCAL":"img_upload.upgit.del_local", "ATK_IMG__UPLOAD_UPGIT_ENABLED":"img_upload.upgit.enabled", "ATK_IMG__UPLOAD_UPGIT_EXEC":"img_upload.upgit.exec", "ATK_IP_REGION_DB_PATH":"ip_region.db_path", "ATK_IP_REGION_ENABLED":"ip_region.enabled", "ATK_IP_REGION_PRECISION":"ip_region.precision", "ATK_IP__REGION_DB__PATH":"ip_region.db_path", "ATK_IP__REGION_ENABLED":"ip_region.enabled", "ATK_IP__REGION_PRECISION":"ip_region.precision", "ATK_LOCALE":"locale", "ATK_LOGIN_TIMEOUT":"login_timeout", "ATK_LOGIN__TIMEOUT":"login_timeout", "ATK_LOG_ENABLED":"log.enabled", "ATK_LOG_FILENAME":"log.filename", "ATK_MODERATOR_AKISMET_KEY":"moderator.akismet_key", "ATK_MODERATOR_AKISMET__KEY":"moderator.akismet_key", "ATK_MODERATOR_ALIYUN_ACCESS_KEY_ID":"moderator.aliyun.access_key_id", "ATK_MODERATOR_ALIYUN_ACCESS_KEY_SECRET":"moderator.aliyun.access_key_secret", "ATK_MODERATOR_ALIYUN_ACCESS__KEY__ID":"moderator.aliyun.access_key_id", "ATK_MODERATOR_ALIYUN_ACCESS__KEY__SECRET":"moderator.aliyun.access_key_secret", "ATK_MODERATOR_ALIYUN_ENABLED":"moderator.aliyun.enabled", "ATK_MODERATOR_ALIYUN_REGION":"moderator.aliyun.region", "ATK_MODERATOR_API_FAIL_BLOCK":"moderator.api_fail_block", "ATK_MODERATOR_API__FAIL__BLOCK":"moderator.api_fail_block", "ATK_MODERATOR_KEYWORDS_ENABLED":"moderator.keywords.enabled", "ATK_MODERATOR_KEYWORDS_FILES":"moderator.keywords.files", "ATK_MODERATOR_KEYWORDS_FILES_$$":"moderator.keywords.files.$$", "ATK_MODERATOR_KEYWORDS_FILE_SEP":"moderator.keywords.file_sep", "ATK_MODERATOR_KEYWORDS_FILE__SEP":"moderator.keywords.file_sep", "ATK_MODERATOR_KEYWORDS_PENDING":"moderator.keywords.pending", "ATK_MODERATOR_KEYWORDS_REPLACE_TO":"moderator.keywords.replace_to", "ATK_MODERATOR_KEYWORDS_REPLACE__TO":"moderator.keywords.replace_to", "ATK_MODERATOR_PENDING_DEFAULT":"moderator.pending_default", "ATK_MODERATOR_PENDING__DEFAULT":"moderator.pending_default", "ATK_MODERATOR_TENCENT_ENABLED":"moderator.tencent.enabled", "ATK_MODERATOR_TENCENT_REGION":"moderator.tencent.region", "ATK_MODERATOR_TENCENT_SECRET_ID":"moderator.tencent.secret_id", "ATK_MODERATOR_TENCENT_SECRET_KEY":"moderator.tencent.secret_key", "ATK_MODERATOR_TENCENT_SECRET__ID":"moderator.tencent.secret_id", "ATK_MODERATOR_TENCENT_SECRET__KEY":"moderator.tencent.secret_key", "ATK_PORT":"port", "ATK_SITE_DEFAULT":"site_default", "ATK_SITE_URL":"site_url", "ATK_SITE__DEFAULT":"site_default", "ATK_SITE__URL":"site_url", "ATK_SSL_CERT_PATH":"ssl.cert_path", "ATK_SSL_CERT__PATH":"ssl.cert_path", "ATK_SSL_ENABLED":"ssl.enabled", "ATK_SSL_KEY_PATH":"ssl.key_path", "ATK_SSL_KEY__PATH":"ssl.key_path", "ATK_TIMEZONE":"timezone", "ATK_TRUSTED_DOMAINS":"trusted_domains", "ATK_TRUSTED_DOMAINS_$$":"trusted_domains.$$", "ATK_TRUSTED__DOMAINS_$$":"trusted_domains.$$"}
+var EnvPathMapCache = map[string]string{"ATK_ADMIN_LDAP_BASE_DN":"admin_ldap.base_dn", "ATK_ADMIN_LDAP_BIND_DN":"admin_ldap.bind_dn", "ATK_ADMIN_LDAP_BIND_PASSWORD":"admin_ldap.bind_password", "ATK_ADMIN_LDAP_EMAIL_ATTR":"admin_ldap.email_attr", "ATK_ADMIN_LDAP_ENABLED":"admin_ldap.enabled", "ATK_ADMIN_LDAP_GROUPS":"admin_ldap.groups", "ATK_ADMIN_LDAP_GROUPS_$$_DN":"admin_ldap.groups.$$.dn", "ATK_ADMIN_LDAP_GROUPS_$$_ROLE":"admin_ldap.groups.$$.role", "ATK_ADMIN_LDAP_GROUPS_$$_SITES_$$":"admin_ldap.groups.$$.sites.$$", "ATK_ADMIN_LDAP_GROUP_ATTR":"admin_ldap.group_attr", "ATK_ADMIN_LDAP_INSECURE_SKIP_VERIFY":"admin_ldap.insecure_skip_verify", "ATK_ADMIN_LDAP_NAME_ATTR":"admin_ldap.name_attr", "ATK_ADMIN_LDAP_START_TLS":"admin_ldap.start_tls", "ATK_ADMIN_LDAP_URL":"admin_ldap.url", "ATK_ADMIN_LDAP_USER_FILTER":"admin_ldap.user_filter", "ATK_ADMIN_NOTIFY_BARK_ENABLED":"admin_notify.bark.enabled", "ATK_ADMIN_NOTIFY_BARK_SERVER":"admin_notify.bark.server", "ATK_ADMIN_NOTIFY_DING_TALK_ENABLED":"admin_notify.ding_talk.enabled", "ATK_ADMIN_NOTIFY_DING_TALK_MENTIONS":"admin_notify.ding_talk.mentions", "ATK_ADMIN_NOTIFY_DING_TALK_MENTIONS_$$":"admin_notify.ding_talk.mentions.$$", "ATK_ADMIN_NOTIFY_DING_TALK_MSG_TYPE":"admin_notify.ding_talk.msg_type", "ATK_ADMIN_NOTIFY_DING_TALK_SECRET":"admin_notify.ding_talk.secret", "ATK_ADMIN_NOTIFY_DING_TALK_TOKEN":"admin_notify.ding_talk.token", "ATK_ADMIN_NOTIFY_DISCORD_ENABLED":"admin_notify.discord.enabled", "ATK_ADMIN_NOTIFY_DISCORD_SITES":"admin_notify.discord.sites", "ATK_ADMIN_NOTIFY_DISCORD_SITES_$$_SITE":"admin_notify.discord.sites.$$.site", "ATK_ADMIN_NOTIFY_DISCORD_SITES_$$_WEBHOOK_URL":"admin_notify.discord.sites.$$.webhook_url", "ATK_ADMIN_NOTIFY_DISCORD_WEBHOOK_URL":"admin_notify.discord.webhook_url", "ATK_ADMIN_NOTIFY_EMAIL":"admin_notify.email", "ATK_ADMIN_NOTIFY_EMAIL_ENABLED":"admin_notify.email.enabled", "ATK_ADMIN_NOTIFY_EMAIL_MAIL_SUBJECT":"admin_notify.email.mail_subject", "ATK_ADMIN_NOTIFY_EMAIL_MAIL_TPL":"admin_notify.email.mail_tpl", "ATK_ADMIN_NOTIFY_GOTIFY_ENABLED":"admin_notify.gotify.enabled", "ATK_ADMIN_NOTIFY_GOTIFY_SERVER":"admin_notify.gotify.server", "ATK_ADMIN_NOTIFY_GOTIFY_TOKEN":"admin_notify.gotify.token", "ATK_ADMIN_NOTIFY_LARK_ENABLED":"admin_notify.lark.enabled", "ATK_ADMIN_NOTIFY_LARK_MENTIONS":"admin_notify.lark.mentions", "ATK_ADMIN_NOTIFY_LARK_MENTIONS_$$":"admin_notify.lark.mentions.$$", "ATK_ADMIN_NOTIFY_LARK_MSG_TYPE":"admin_notify.lark.msg_type", "ATK_ADMIN_NOTIFY_LARK_WEBHOOK_URL":"admin_notify.lark.webhook_url", "ATK_ADMIN_NOTIFY_LINE_CHANNEL_ACCESS_TOKEN":"admin_notify.line.channel_access_token", "ATK_ADMIN_NOTIFY_LINE_CHANNEL_SECRET":"admin_notify.line.channel_secret", "ATK_ADMIN_NOTIFY_LINE_ENABLED":"admin_notify.line.enabled", "ATK_ADMIN_NOTIFY_LINE_RECEIVERS":"admin_notify.line.receivers", "ATK_ADMIN_NOTIFY_LINE_RECEIVERS_$$":"admin_notify.line.receivers.$$", "ATK_ADMIN_NOTIFY_MATRIX_ACCESS_TOKEN":"admin_notify.matrix.access_token", "ATK_ADMIN_NOTIFY_MATRIX_ENABLED":"admin_notify.matrix.enabled", "ATK_ADMIN_NOTIFY_MATRIX_HOMESERVER":"admin_notify.matrix.homeserver", "ATK_ADMIN_NOTIFY_MATRIX_ROOM_ID":"admin_notify.matrix.room_id", "ATK_ADMIN_NOTIFY_NOISE_MODE":"admin_notify.noise_mode", "ATK_ADMIN_NOTIFY_NOTIFY_PENDING":"admin_notify.notify_pending", "ATK_ADMIN_NOTIFY_NOTIFY_SUBJECT":"admin_notify.notify_subject", "ATK_ADMIN_NOTIFY_NOTIFY_TPL":"admin_notify.notify_tpl", "ATK_ADMIN_NOTIFY_NTFY_ENABLED":"admin_notify.ntfy.enabled", "ATK_ADMIN_NOTIFY_NTFY_SERVER":"admin_notify.ntfy.server", "ATK_ADMIN_NOTIFY_NTFY_TOKEN":"admin_notify.ntfy.token", "ATK_ADMIN_NOTIFY_NTFY_TOPIC":"admin_notify.ntfy.topic", "ATK_ADMIN_NOTIFY_PUSHOVER_DEVICE":"admin_notify.pushover.device", "ATK_ADMIN_NOTIFY_PUSHOVER_ENABLED":"admin_notify.pushover.enabled", "ATK_ADMIN_NOTIFY_PUSHOVER_TOKEN":"admin_notify.pushover.token", "ATK_ADMIN_NOTIFY_PUSHOVER_USER":"admin_notify.pushover.user", "ATK_ADMIN_NOTIFY_SLACK_ENABLED":"admin_notify.slack.enabled", "ATK_ADMIN_NOTIFY_SLACK_OAUTH_TOKEN":"admin_notify.slack.oauth_token", "ATK_ADMIN_NOTIFY_SLACK_RECEIVERS":"admin_notify.slack.receivers", "ATK_ADMIN_NOTIFY_SLACK_RECEIVERS_$$":"admin_notify.slack.receivers.$$", "ATK_ADMIN_NOTIFY_SLACK_SITES":"admin_notify.slack.sites", "ATK_ADMIN_NOTIFY_SLACK_SITES_$$_RECEIVERS_$$":"admin_notify.slack.sites.$$.receivers.$$", "ATK_ADMIN_NOTIFY_SLACK_SITES_$$_SITE":"admin_notify.slack.sites.$$.site", "ATK_ADMIN_NOTIFY_TELEGRAM_API_TOKEN":"admin_notify.telegram.api_token", "ATK_ADMIN_NOTIFY_TELEGRAM_ENABLED":"admin_notify.telegram.enabled", "ATK_ADMIN_NOTIFY_TELEGRAM_MODERATION":"admin_notify.telegram.moderation", "ATK_ADMIN_NOTIFY_TELEGRAM_MODERATORS":"admin_notify.telegram.moderators", "ATK_ADMIN_NOTIFY_TELEGRAM_MODERATORS_$$":"admin_notify.telegram.moderators.$$", "ATK_ADMIN_NOTIFY_TELEGRAM_RECEIVERS":"admin_notify.telegram.receivers", "ATK_ADMIN_NOTIFY_TELEGRAM_RECEIVERS_$$":"admin_notify.telegram.receivers.$$", "ATK_ADMIN_NOTIFY_TELEGRAM_WEBHOOK_SECRET":"admin_notify.telegram.webhook_secret", "ATK_ADMIN_NOTIFY_WEBHOOK_ENABLED":"admin_notify.webhook.enabled", "ATK_ADMIN_NOTIFY_WEBHOOK_URL":"admin_notify.webhook.url", "ATK_ADMIN_NOTIFY_WECOM_ENABLED":"admin_notify.wecom.enabled", "ATK_ADMIN_NOTIFY_WECOM_MENTIONS":"admin_notify.wecom.mentions", "ATK_ADMIN_NOTIFY_WECOM_MENTIONS_$$":"admin_notify.wecom.mentions.$$", "ATK_ADMIN_NOTIFY_WECOM_MSG_TYPE":"admin_notify.wecom.msg_type", "ATK_ADMIN_NOTIFY_WECOM_WEBHOOK_URL":"admin_notify.wecom.webhook_url", "ATK_ADMIN_TOTP_ENFORCE":"admin_totp.enforce", "ATK_ADMIN_TOTP_ISSUER":"admin_totp.issuer", "ATK_ADMIN_USERS_$$_BADGE_COLOR":"admin_users.$$.badge_color", "ATK_ADMIN_USERS_$$_BADGE_NAME":"admin_users.$$.badge_name", "ATK_ADMIN_USERS_$$_EMAIL":"admin_users.$$.email", "ATK_ADMIN_USERS_$$_LINK":"admin_users.$$.link", "ATK_ADMIN_USERS_$$_NAME":"admin_users.$$.name", "ATK_ADMIN_USERS_$$_PASSWORD":"admin_users.$$.password", "ATK_ADMIN_USERS_$$_RECEIVE_EMAIL":"admin_users.$$.receive_email", "ATK_ADMIN_USERS_$$_ROLE":"admin_users.$$.role", "ATK_ADMIN_USERS_$$_SITES_$$":"admin_users.$$.sites.$$", "ATK_ADMIN__LDAP_BASE__DN":"admin_ldap.base_dn", "ATK_ADMIN__LDAP_BIND__DN":"admin_ldap.bind_dn", "ATK_ADMIN__LDAP_BIND__PASSWORD":"admin_ldap.bind_password", "ATK_ADMIN__LDAP_EMAIL__ATTR":"admin_ldap.email_attr", "ATK_ADMIN__LDAP_ENABLED":"admin_ldap.enabled", "ATK_ADMIN__LDAP_GROUPS_$$_DN":"admin_ldap.groups.$$.dn", "ATK_ADMIN__LDAP_GROUPS_$$_ROLE":"admin_ldap.groups.$$.role", "ATK_ADMIN__LDAP_GROUPS_$$_SITES_$$":"admin_ldap.groups.$$.sites.$$", "ATK_ADMIN__LDAP_GROUP__ATTR":"admin_ldap.group_attr", "ATK_ADMIN__LDAP_INSECURE__SKIP__VERIFY":"admin_ldap.insecure_skip_verify", "ATK_ADMIN__LDAP_NAME__ATTR":"admin_ldap.name_attr", "ATK_ADMIN__LDAP_START__TLS":"admin_ldap.start_tls", "ATK_ADMIN__LDAP_URL":"admin_ldap.url", "ATK_ADMIN__LDAP_USER__FILTER":"admin_ldap.user_filter", "ATK_ADMIN__NOTIFY_BARK_ENABLED":"admin_notify.bark.enabled", "ATK_ADMIN__NOTIFY_BARK_SERVER":"admin_notify.bark.server", "ATK_ADMIN__NOTIFY_DING__TALK_ENABLED":"admin_notify.ding_talk.enabled", "ATK_ADMIN__NOTIFY_DING__TALK_MENTIONS_$$":"admin_notify.ding_talk.mentions.$$", "ATK_ADMIN__NOTIFY_DING__TALK_MSG__TYPE":"admin_notify.ding_talk.msg_type", "ATK_ADMIN__NOTIFY_DING__TALK_SECRET":"admin_notify.ding_talk.secret", "ATK_ADMIN__NOTIFY_DING__TALK_TOKEN":"admin_notify.ding_talk.token", "ATK_ADMIN__NOTIFY_DISCORD_ENABLED":"admin_notify.discord.enabled", "ATK_ADMIN__NOTIFY_DISCORD_SITES_$$_SITE":"admin_notify.discord.sites.$$.site", "ATK_ADMIN__NOTIFY_DISCORD_SITES_$$_WEBHOOK__URL":"admin_notify.discord.sites.$$.webhook_url", "ATK_ADMIN__NOTIFY_DISCORD_WEBHOOK__URL":"admin_notify.discord.webhook_url", "ATK_ADMIN__NOTIFY_EMAIL":"admin_notify.email", "ATK_ADMIN__NOTIFY_GOTIFY_ENABLED":"admin_notify.gotify.enabled", "ATK_ADMIN__NOTIFY_GOTIFY_SERVER":"admin_notify.gotify.server", "ATK_ADMIN__NOTIFY_GOTIFY_TOKEN":"admin_notify.gotify.token", "ATK_ADMIN__NOTIFY_LARK_ENABLED":"admin_notify.lark.enabled", "ATK_ADMIN__NOTIFY_LARK_MENTIONS_$$":"admin_notify.lark.mentions.$$", "ATK_ADMIN__NOTIFY_LARK_MSG__TYPE":"admin_notify.lark.msg_type", "ATK_ADMIN__NOTIFY_LARK_WEBHOOK__URL":"admin_notify.lark.webhook_url", "ATK_ADMIN__NOTIFY_LINE_CHANNEL__ACCESS__TOKEN":"admin_notify.line.channel_access_token", "ATK_ADMIN__NOTIFY_LINE_CHANNEL__SECRET":"admin_notify.line.channel_secret", "ATK_ADMIN__NOTIFY_LINE_ENABLED":"admin_notify.line.enabled", "ATK_ADMIN__NOTIFY_LINE_RECEIVERS_$$":"admin_notify.line.receivers.$$", "ATK_ADMIN__NOTIFY_MATRIX_ACCESS__TOKEN":"admin_notify.matrix.access_token", "ATK_ADMIN__NOTIFY_MATRIX_ENABLED":"admin_notify.matrix.enabled", "ATK_ADMIN__NOTIFY_MATRIX_HOMESERVER":"admin_notify.matrix.homeserver", "ATK_ADMIN__NOTIFY_MATRIX_ROOM__ID":"admin_notify.matrix.room_id", "ATK_ADMIN__NOTIFY_NOISE__MODE":"admin_notify.noise_mode", "ATK_ADMIN__NOTIFY_NOTIFY__PENDING":"admin_notify.notify_pending", "ATK_ADMIN__NOTIFY_NOTIFY__SUBJECT":"admin_notify.notify_subject", "ATK_ADMIN__NOTIFY_NOTIFY__TPL":"admin_notify.notify_tpl", "ATK_ADMIN__NOTIFY_NTFY_ENABLED":"admin_notify.ntfy.enabled", "ATK_ADMIN__NOTIFY_NTFY_SERVER":"admin_notify.ntfy.server", "ATK_ADMIN__NOTIFY_NTFY_TOKEN":"admin_notify.ntfy.token", "ATK_ADMIN__NOTIFY_NTFY_TOPIC":"admin_notify.ntfy.topic", "ATK_ADMIN__NOTIFY_PUSHOVER_DEVICE":"admin_notify.pushover.device", "ATK_ADMIN__NOTIFY_PUSHOVER_ENABLED":"admin_notify.pushover.enabled", "ATK_ADMIN__NOTIFY_PUSHOVER_TOKEN":"admin_notify.pushover.token", "ATK_ADMIN__NOTIFY_PUSHOVER_USER":"admin_notify.pushover.user", "ATK_ADMIN__NOTIFY_SLACK_ENABLED":"admin_notify.slack.enabled", "ATK_ADMIN__NOTIFY_SLACK_OAUTH__TOKEN":"admin_notify.slack.oauth_token", "ATK_ADMIN__NOTIFY_SLACK_RECEIVERS_$$":"admin_notify.slack.receivers.$$", "ATK_ADMIN__NOTIFY_SLACK_SITES_$$_RECEIVERS_$$":"admin_notify.slack.sites.$$.receivers.$$", "ATK_ADMIN__NOTIFY_SLACK_SITES_$$_SITE":"admin_notify.slack.sites.$$.site", "ATK_ADMIN__NOTIFY_TELEGRAM_API__TOKEN":"admin_notify.telegram.api_token", "ATK_ADMIN__NOTIFY_TELEGRAM_ENABLED":"admin_notify.telegram.enabled", "ATK_ADMIN__NOTIFY_TELEGRAM_MODERATION":"admin_notify.telegram.moderation", "ATK_ADMIN__NOTIFY_TELEGRAM_MODERATORS_$$":"admin_notify.telegram.moderators.$$", "ATK_ADMIN__NOTIFY_TELEGRAM_RECEIVERS_$$":"admin_notify.telegram.receivers.$$", "ATK_ADMIN__NOTIFY_TELEGRAM_WEBHOOK__SECRET":"admin_notify.telegram.webhook_secret", "ATK_ADMIN__NOTIFY_WEBHOOK_ENABLED":"admin_notify.webhook.enabled", "ATK_ADMIN__NOTIFY_WEBHOOK_URL":"admin_notify.webhook.url", "ATK_ADMIN__NOTIFY_WECOM_ENABLED":"admin_notify.wecom.enabled", "ATK_ADMIN__NOTIFY_WECOM_MENTIONS_$$":"admin_notify.wecom.mentions.$$", "ATK_ADMIN__NOTIFY_WECOM_MSG__TYPE":"admin_notify.wecom.msg_type", "ATK_ADMIN__NOTIFY_WECOM_WEBHOOK__URL":"admin_notify.wecom.webhook_url", "ATK_ADMIN__TOTP_ENFORCE":"admin_totp.enforce", "ATK_ADMIN__TOTP_ISSUER":"admin_totp.issuer", "ATK_ADMIN__USERS_$$_BADGE__COLOR":"admin_users.$$.badge_color", "ATK_ADMIN__USERS_$$_BADGE__NAME":"admin_users.$$.badge_name", "ATK_ADMIN__USERS_$$_EMAIL":"admin_users.$$.email", "ATK_ADMIN__USERS_$$_LINK":"admin_users.$$.link", "ATK_ADMIN__USERS_$$_NAME":"admin_users.$$.name", "ATK_ADMIN__USERS_$$_PASSWORD":"admin_users.$$.password", "ATK_ADMIN__USERS_$$_RECEIVE__EMAIL":"admin_users.$$.receive_email", "ATK_ADMIN__USERS_$$_ROLE":"admin_users.$$.role", "ATK_ADMIN__USERS_$$_SITES_$$":"admin_users.$$.sites.$$", "ATK_APP_KEY":"app_key", "ATK_APP__KEY":"app_key", "ATK_AUTH_ANONYMOUS":"auth.anonymous", "ATK_AUTH_APPLE_CLIENT_ID":"auth.apple.client_id", "ATK_AUTH_APPLE_CLIENT_SECRET":"auth.apple.client_secret", "ATK_AUTH_APPLE_CLIENT__ID":"auth.apple.client_id", "ATK_AUTH_APPLE_CLIENT__SECRET":"auth.apple.client_secret", "ATK_AUTH_APPLE_ENABLED":"auth.apple.enabled", "ATK_AUTH_AUTH0_CLIENT_ID":"auth.auth0.client_id", "ATK_AUTH_AUTH0_CLIENT_SECRET":"auth.auth0.client_secret", "ATK_AUTH_AUTH0_CLIENT__ID":"auth.auth0.client_id", "ATK_AUTH_AUTH0_CLIENT__SECRET":"auth.auth0.client_secret", "ATK_AUTH_AUTH0_DOMAIN":"auth.auth0.domain", "ATK_AUTH_AUTH0_ENABLED":"auth.auth0.enabled", "ATK_AUTH_CALLBACK":"auth.callback", "ATK_AUTH_DISCORD_CLIENT_ID":"auth.discord.client_id", "ATK_AUTH_DISCORD_CLIENT_SECRET":"auth.discord.client_secret", "ATK_AUTH_DISCORD_CLIENT__ID":"auth.discord.client_id", "ATK_AUTH_DISCORD_CLIENT__SECRET":"auth.discord.client_secret", "ATK_AUTH_DISCORD_ENABLED":"auth.discord.enabled", "ATK_AUTH_EMAIL_ENABLED":"auth.email.enabled", "ATK_AUTH_EMAIL_VERIFY_SUBJECT":"auth.email.verify_subject", "ATK_AUTH_EMAIL_VERIFY_TPL":"auth.email.verify_tpl", "ATK_AUTH_EMAIL_VERIFY__SUBJECT":"auth.email.verify_subject", "ATK_AUTH_EMAIL_VERIFY__TPL":"auth.email.verify_tpl", "ATK_AUTH_ENABLED":"auth.enabled", "ATK_AUTH_FACEBOOK_CLIENT_ID":"auth.facebook.client_id", "ATK_AUTH_FACEBOOK_CLIENT_SECRET":"auth.facebook.client_secret", "ATK_AUTH_FACEBOOK_CLIENT__ID":"auth.facebook.client_id", "ATK_AUTH_FACEBOOK_CLIENT__SECRET":"auth.facebook.client_secret", "ATK_AUTH_FACEBOOK_ENABLED":"auth.facebook.enabled", "ATK_AUTH_GITEA_CLIENT_ID":"auth.gitea.client_id", "ATK_AUTH_GITEA_CLIENT_SECRET":"auth.gitea.client_secret", "ATK_AUTH_GITEA_CLIENT__ID":"auth.gitea.client_id", "ATK_AUTH_GITEA_CLIENT__SECRET":"auth.gitea.client_secret", "ATK_AUTH_GITEA_ENABLED":"auth.gitea.enabled", "ATK_AUTH_GITHUB_CLIENT_ID":"auth.github.client_id", "ATK_AUTH_GITHUB_CLIENT_SECRET":"auth.github.client_secret", "ATK_AUTH_GITHUB_CLIENT__ID":"auth.github.client_id", "ATK_AUTH_GITHUB_CLIENT__SECRET":"auth.github.client_secret", "ATK_AUTH_GITHUB_ENABLED":"auth.github.enabled", "ATK_AUTH_GITLAB_CLIENT_ID":"auth.gitlab.client_id", "ATK_AUTH_GITLAB_CLIENT_SECRET":"auth.gitlab.client_secret", "ATK_AUTH_GITLAB_CLIENT__ID":"auth.gitlab.client_id", "ATK_AUTH_GITLAB_CLIENT__SECRET":"auth.gitlab.client_secret", "ATK_AUTH_GITLAB_ENABLED":"auth.gitlab.enabled", "ATK_AUTH_GOOGLE_CLIENT_ID":"auth.google.client_id", "ATK_AUTH_GOOGLE_CLIENT_SECRET":"auth.google.client_secret", "ATK_AUTH_GOOGLE_CLIENT__ID":"auth.google.client_id", "ATK_AUTH_GOOGLE_CLIENT__SECRET":"auth.google.client_secret", "ATK_AUTH_GOOGLE_ENABLED":"auth.google.enabled", "ATK_AUTH_LINE_CLIENT_ID":"auth.line.client_id", "ATK_AUTH_LINE_CLIENT_SECRET":"auth.line.client_secret", "ATK_AUTH_LINE_CLIENT__ID":"auth.line.client_id", "ATK_AUTH_LINE_CLIENT__SECRET":"auth.line.client_secret", "ATK_AUTH_LINE_ENABLED":"auth.line.enabled", "ATK_AUTH_MASTODON_CLIENT_ID":"auth.mastodon.client_id", "ATK_AUTH_MASTODON_CLIENT_SECRET":"auth.mastodon.client_secret", "ATK_AUTH_MASTODON_CLIENT__ID":"auth.mastodon.client_id", "ATK_AUTH_MASTODON_CLIENT__SECRET":"auth.mastodon.client_secret", "ATK_AUTH_MASTODON_ENABLED":"auth.mastodon.enabled", "ATK_AUTH_MICROSOFT_CLIENT_ID":"auth.microsoft.client_id", "ATK_AUTH_MICROSOFT_CLIENT_SECRET":"auth.microsoft.client_secret", "ATK_AUTH_MICROSOFT_CLIENT__ID":"auth.microsoft.client_id", "ATK_AUTH_MICROSOFT_CLIENT__SECRET":"auth.microsoft.client_secret", "ATK_AUTH_MICROSOFT_ENABLED":"auth.microsoft.enabled", "ATK_AUTH_OIDC_CLIENT_ID":"auth.oidc.client_id", "ATK_AUTH_OIDC_CLIENT_SECRET":"auth.oidc.client_secret", "ATK_AUTH_OIDC_CLIENT__ID":"auth.oidc.client_id", "ATK_AUTH_OIDC_CLIENT__SECRET":"auth.oidc.client_secret", "ATK_AUTH_OIDC_DISCOVERY_URL":"auth.oidc.discovery_url", "ATK_AUTH_OIDC_DISCOVERY__URL":"auth.oidc.discovery_url", "ATK_AUTH_OIDC_ENABLED":"auth.oidc.enabled", "ATK_AUTH_OIDC_NAME":"auth.oidc.name", "ATK_AUTH_OIDC_SCOPES":"auth.oidc.scopes", "ATK_AUTH_OIDC_SCOPES_$$":"auth.oidc.scopes.$$", "ATK_AUTH_PATREON_CLIENT_ID":"auth.patreon.client_id", "ATK_AUTH_PATREON_CLIENT_SECRET":"auth.patreon.client_secret", "ATK_AUTH_PATREON_CLIENT__ID":"auth.patreon.client_id", "ATK_AUTH_PATREON_CLIENT__SECRET":"auth.patreon.client_secret", "ATK_AUTH_PATREON_ENABLED":"auth.patreon.enabled", "ATK_AUTH_SLACK_CLIENT_ID":"auth.slack.client_id", "ATK_AUTH_SLACK_CLIENT_SECRET":"auth.slack.client_secret", "ATK_AUTH_SLACK_CLIENT__ID":"auth.slack.client_id", "ATK_AUTH_SLACK_CLIENT__SECRET":"auth.slack.client_secret", "ATK_AUTH_SLACK_ENABLED":"auth.slack.enabled", "ATK_AUTH_SSO_ENABLED":"auth.sso.enabled", "ATK_AUTH_SSO_MAX_AGE":"auth.sso.max_age", "ATK_AUTH_SSO_MAX__AGE":"auth.sso.max_age", "ATK_AUTH_SSO_SECRET":"auth.sso.secret", "ATK_AUTH_STEAM_API_KEY":"auth.steam.api_key", "ATK_AUTH_STEAM_API__KEY":"auth.steam.api_key", "ATK_AUTH_STEAM_ENABLED":"auth.steam.enabled", "ATK_AUTH_TIKTOK_CLIENT_ID":"auth.tiktok.client_id", "ATK_AUTH_TIKTOK_CLIENT_SECRET":"auth.tiktok.client_secret", "ATK_AUTH_TIKTOK_CLIENT__ID":"auth.tiktok.client_id", "ATK_AUTH_TIKTOK_CLIENT__SECRET":"auth.tiktok.client_secret", "ATK_AUTH_TIKTOK_ENABLED":"auth.tiktok.enabled", "ATK_AUTH_TWITTER_CLIENT_ID":"auth.twitter.client_id", "ATK_AUTH_TWITTER_CLIENT_SECRET":"auth.twitter.client_secret", "ATK_AUTH_TWITTER_CLIENT__ID":"auth.twitter.client_id", "ATK_AUTH_TWITTER_CLIENT__SECRET":"auth.twitter.client_secret", "ATK_AUTH_TWITTER_ENABLED":"auth.twitter.enabled", "ATK_AUTH_WECHAT_CLIENT_ID":"auth.wechat.client_id", "ATK_AUTH_WECHAT_CLIENT_SECRET":"auth.wechat.client_secret", "ATK_AUTH_WECHAT_CLIENT__ID":"auth.wechat.client_id", "ATK_AUTH_WECHAT_CLIENT__SECRET":"auth.wechat.client_secret", "ATK_AUTH_WECHAT_ENABLED":"auth.wechat.enabled", "ATK_AVATAR_CACHE_MAX_SIZE":"avatar.cache_max_size", "ATK_AVATAR_CACHE_PATH":"avatar.cache_path", "ATK_AVATAR_CACHE_TTL":"avatar.cache_ttl", "ATK_AVATAR_CACHE__MAX__SIZE":"avatar.cache_max_size", "ATK_AVATAR_CACHE__PATH":"avatar.cache_path", "ATK_AVATAR_CACHE__TTL":"avatar.cache_ttl", "ATK_AVATAR_ENABLED":"avatar.enabled", "ATK_AVATAR_GRAVATAR_MIRROR":"avatar.gravatar_mirror", "ATK_AVATAR_GRAVATAR__MIRROR":"avatar.gravatar_mirror", "ATK_AVATAR_RATE_LIMIT":"avatar.rate_limit", "ATK_AVATAR_RATE__LIMIT":"avatar.rate_limit", "ATK_AVATAR_SOURCES":"avatar.sources", "ATK_AVATAR_SOURCES_$$":"avatar.sources.$$", "ATK_AVATAR_TIMEOUT":"avatar.timeout", "ATK_BACKUP_ENABLED":"backup.enabled", "ATK_BACKUP_KEEP":"backup.keep", "ATK_BACKUP_PATH":"backup.path", "ATK_BACKUP_REMOTE":"backup.remote", "ATK_BACKUP_S3_ACCESS_KEY":"backup.s3.access_key", "ATK_BACKUP_S3_ACCESS__KEY":"backup.s3.access_key", "ATK_BACKUP_S3_BUCKET":"backup.s3.bucket", "ATK_BACKUP_S3_ENDPOINT":"backup.s3.endpoint", "ATK_BACKUP_S3_PATH_STYLE":"backup.s3.path_style", "ATK_BACKUP_S3_PATH__STYLE":"backup.s3.path_style", "ATK_BACKUP_S3_PREFIX":"backup.s3.prefix", "ATK_BACKUP_S3_REGION":"backup.s3.region", "ATK_BACKUP_S3_SECRET_KEY":"backup.s3.secret_key", "ATK_BACKUP_S3_SECRET__KEY":"backup.s3.secret_key", "ATK_BACKUP_SCHEDULE":"backup.schedule", "ATK_BACKUP_SFTP_DIR":"backup.sftp.dir", "ATK_BACKUP_SFTP_HOST":"backup.sftp.host", "ATK_BACKUP_SFTP_HOST_KEY":"backup.sftp.host_key", "ATK_BACKUP_SFTP_HOST__KEY":"backup.sftp.host_key", "ATK_BACKUP_SFTP_INSECURE_IGNORE_HOST_KEY":"backup.sftp.insecure_ignore_host_key", "ATK_BACKUP_SFTP_INSECURE__IGNORE__HOST__KEY":"backup.sftp.insecure_ignore_host_key", "ATK_BACKUP_SFTP_KNOWN_HOSTS":"backup.sftp.known_hosts", "ATK_BACKUP_SFTP_KNOWN__HOSTS":"backup.sftp.known_hosts", "ATK_BACKUP_SFTP_PASSWORD":"backup.sftp.password", "ATK_BACKUP_SFTP_PORT":"backup.sftp.port", "ATK_BACKUP_SFTP_PRIVATE_KEY":"backup.sftp.private_key", "ATK_BACKUP_SFTP_PRIVATE__KEY":"backup.sftp.private_key", "ATK_BACKUP_SFTP_USERNAME":"backup.sftp.username", "ATK_BACKUP_WEBDAV_PASSWORD":"backup.webdav.password", "ATK_BACKUP_WEBDAV_URL":"backup.webdav.url", "ATK_BACKUP_WEBDAV_USERNAME":"backup.webdav.username", "ATK_CACHE_ENABLED":"cache.enabled", "ATK_CACHE_EXPIRES":"cache.expires", "ATK_CACHE_REDIS_DB":"cache.redis.db", "ATK_CACHE_REDIS_MASTER_NAME":"cache.redis.master_name", "ATK_CACHE_REDIS_MASTER__NAME":"cache.redis.master_name", "ATK_CACHE_REDIS_MODE":"cache.redis.mode", "ATK_CACHE_REDIS_NETWORK":"cache.redis.network", "ATK_CACHE_REDIS_PASSWORD":"cache.redis.password", "ATK_CACHE_REDIS_SENTINEL_PASSWORD":"cache.redis.sentinel_password", "ATK_CACHE_REDIS_SENTINEL_USERNAME":"cache.redis.sentinel_username", "ATK_CACHE_REDIS_SENTINEL__PASSWORD":"cache.redis.sentinel_password", "ATK_CACHE_REDIS_SENTINEL__USERNAME":"cache.redis.sentinel_username", "ATK_CACHE_REDIS_TLS_CA_CERT":"cache.redis.tls.ca_cert", "ATK_CACHE_REDIS_TLS_CA__CERT":"cache.redis.tls.ca_cert", "ATK_CACHE_REDIS_TLS_CERT":"cache.redis.tls.cert", "ATK_CACHE_REDIS_TLS_ENABLED":"cache.redis.tls.enabled", "ATK_CACHE_REDIS_TLS_INSECURE_SKIP_VERIFY":"cache.redis.tls.insecure_skip_verify", "ATK_CACHE_REDIS_TLS_INSECURE__SKIP__VERIFY":"cache.redis.tls.insecure_skip_verify", "ATK_CACHE_REDIS_TLS_KEY":"cache.redis.tls.key", "ATK_CACHE_REDIS_TLS_SERVER_NAME":"cache.redis.tls.server_name", "ATK_CACHE_REDIS_TLS_SERVER__NAME":"cache.redis.tls.server_name", "ATK_CACHE_REDIS_USERNAME":"cache.redis.username", "ATK_CACHE_SERVER":"cache.server", "ATK_CACHE_TTLS":"cache.ttls", "ATK_CACHE_TYPE":"cache.type", "ATK_CACHE_WARM_UP":"cache.warm_up", "ATK_CACHE_WARM_UP_PAGES":"cache.warm_up_pages", "ATK_CACHE_WARM__UP":"cache.warm_up", "ATK_CACHE_WARM__UP__PAGES":"cache.warm_up_pages", "ATK_CAPTCHA_ACTION_LIMIT":"captcha.action_limit", "ATK_CAPTCHA_ACTION_RESET":"captcha.action_reset", "ATK_CAPTCHA_ACTION__LIMIT":"captcha.action_limit", "ATK_CAPTCHA_ACTION__RESET":"captcha.action_reset", "ATK_CAPTCHA_ADAPTIVE_ENABLED":"captcha.adaptive.enabled", "ATK_CAPTCHA_ADAPTIVE_GREY_SCORE":"captcha.adaptive.grey_score", "ATK_CAPTCHA_ADAPTIVE_GREY__SCORE":"captcha.adaptive.grey_score", "ATK_CAPTCHA_ADAPTIVE_MAX_COMMENTS":"captcha.adaptive.max_comments", "ATK_CAPTCHA_ADAPTIVE_MAX__COMMENTS":"captcha.adaptive.max_comments", "ATK_CAPTCHA_ADAPTIVE_NEW_IP":"captcha.adaptive.new_ip", "ATK_CAPTCHA_ADAPTIVE_NEW__IP":"captcha.adaptive.new_ip", "ATK_CAPTCHA_ADAPTIVE_WINDOW":"captcha.adaptive.window", "ATK_CAPTCHA_ALWAYS":"captcha.always", "ATK_CAPTCHA_CAPTCHA_TYPE":"captcha.captcha_type", "ATK_CAPTCHA_CAPTCHA__TYPE":"captcha.captcha_type", "ATK_CAPTCHA_ENABLED":"captcha.enabled", "ATK_CAPTCHA_GEETEST_CAPTCHA_ID":"captcha.geetest.captcha_id", "ATK_CAPTCHA_GEETEST_CAPTCHA_KEY":"captcha.geetest.captcha_key", "ATK_CAPTCHA_GEETEST_CAPTCHA__ID":"captcha.geetest.captcha_id", "ATK_CAPTCHA_GEETEST_CAPTCHA__KEY":"captcha.geetest.captcha_key", "ATK_CAPTCHA_HCAPTCHA_PROXY":"captcha.hcaptcha.proxy", "ATK_CAPTCHA_HCAPTCHA_SECRET_KEY":"captcha.hcaptcha.secret_key", "ATK_CAPTCHA_HCAPTCHA_SECRET__KEY":"captcha.hcaptcha.secret_key", "ATK_CAPTCHA_HCAPTCHA_SITE_KEY":"captcha.hcaptcha.site_key", "ATK_CAPTCHA_HCAPTCHA_SITE__KEY":"captcha.hcaptcha.site_key", "ATK_CAPTCHA_POW_DIFFICULTY":"captcha.pow.difficulty", "ATK_CAPTCHA_RECAPTCHA_PROXY":"captcha.recaptcha.proxy", "ATK_CAPTCHA_RECAPTCHA_SECRET_KEY":"captcha.recaptcha.secret_key", "ATK_CAPTCHA_RECAPTCHA_SECRET__KEY":"captcha.recaptcha.secret_key", "ATK_CAPTCHA_RECAPTCHA_SITE_KEY":"captcha.recaptcha.site_key", "ATK_CAPTCHA_RECAPTCHA_SITE__KEY":"captcha.recaptcha.site_key", "ATK_CAPTCHA_TURNSTILE_PROXY":"captcha.turnstile.proxy", "ATK_CAPTCHA_TURNSTILE_SECRET_KEY":"captcha.turnstile.secret_key", "ATK_CAPTCHA_TURNSTILE_SECRET__KEY":"captcha.turnstile.secret_key", "ATK_CAPTCHA_TURNSTILE_SITE_KEY":"captcha.turnstile.site_key", "ATK_CAPTCHA_TURNSTILE_SITE__KEY":"captcha.turnstile.site_key", "ATK_CLUSTER_MODE":"cluster_mode", "ATK_CLUSTER__MODE":"cluster_mode", "ATK_COMMENT_CLOSE_AFTER_DAYS":"comment_close.after_days", "ATK_COMMENT_CLOSE_CLOSE_AT":"comment_close.close_at", "ATK_COMMENT_LIMIT_COOLDOWN":"comment_limit.cooldown", "ATK_COMMENT_LIMIT_MAX_LENGTH":"comment_limit.max_length", "ATK_COMMENT_LIMIT_MAX_LINKS":"comment_limit.max_links", "ATK_COMMENT_LIMIT_MIN_LENGTH":"comment_limit.min_length", "ATK_COMMENT__CLOSE_AFTER__DAYS":"comment_close.after_days", "ATK_COMMENT__CLOSE_CLOSE__AT":"comment_close.close_at", "ATK_COMMENT__LIMIT_COOLDOWN":"comment_limit.cooldown", "ATK_COMMENT__LIMIT_MAX__LENGTH":"comment_limit.max_length", "ATK_COMMENT__LIMIT_MAX__LINKS":"comment_limit.max_links", "ATK_COMMENT__LIMIT_MIN__LENGTH":"comment_limit.min_length", "ATK_DB_CHARSET":"db.charset", "ATK_DB_DSN":"db.dsn", "ATK_DB_FILE":"db.file", "ATK_DB_HOST":"db.host", "ATK_DB_NAME":"db.name", "ATK_DB_PASSWORD":"db.password", "ATK_DB_PORT":"db.port", "ATK_DB_PREPARE_STMT":"db.prepare_stmt", "ATK_DB_PREPARE__STMT":"db.prepare_stmt", "ATK_DB_REPLICAS_CHECK_INTERVAL":"db.replicas.check_interval", "ATK_DB_REPLICAS_CHECK__INTERVAL":"db.replicas.check_interval", "ATK_DB_REPLICAS_DSNS":"db.replicas.dsns", "ATK_DB_REPLICAS_DSNS_$$":"db.replicas.dsns.$$", "ATK_DB_REPLICAS_MAX_LAG":"db.replicas.max_lag", "ATK_DB_REPLICAS_MAX__LAG":"db.replicas.max_lag", "ATK_DB_SSL":"db.ssl", "ATK_DB_TABLE_PREFIX":"db.table_prefix", "ATK_DB_TABLE__PREFIX":"db.table_prefix", "ATK_DB_TYPE":"db.type", "ATK_DB_USER":"db.user", "ATK_DEBUG":"debug", "ATK_EMAIL_ALI_DM_ACCESS_KEY_ID":"email.ali_dm.access_key_id", "ATK_EMAIL_ALI_DM_ACCESS_KEY_SECRET":"email.ali_dm.access_key_secret", "ATK_EMAIL_ALI_DM_ACCOUNT_NAME":"email.ali_dm.account_name", "ATK_EMAIL_ALI_DM_REGION":"email.ali_dm.region", "ATK_EMAIL_ALI__DM_ACCESS__KEY__ID":"email.ali_dm.access_key_id", "ATK_EMAIL_ALI__DM_ACCESS__KEY__SECRET":"email.ali_dm.access_key_secret", "ATK_EMAIL_ALI__DM_ACCOUNT__NAME":"email.ali_dm.account_name", "ATK_EMAIL_ALI__DM_REGION":"email.ali_dm.region", "ATK_EMAIL_DIGEST_DEFAULT":"email.digest.default", "ATK_EMAIL_DIGEST_ENABLED":"email.digest.enabled", "ATK_EMAIL_DIGEST_INTERVAL":"email.digest.interval", "ATK_EMAIL_ENABLED":"email.enabled", "ATK_EMAIL_MAILGUN_API_KEY":"email.mailgun.api_key", "ATK_EMAIL_MAILGUN_API__KEY":"email.mailgun.api_key", "ATK_EMAIL_MAILGUN_DOMAIN":"email.mailgun.domain", "ATK_EMAIL_MAILGUN_REGION":"email.mailgun.region", "ATK_EMAIL_MAILGUN_WEBHOOK_SIGNING_KEY":"email.mailgun.webhook_signing_key", "ATK_EMAIL_MAILGUN_WEBHOOK__SIGNING__KEY":"email.mailgun.webhook_signing_key", "ATK_EMAIL_MAIL_SUBJECT":"email.mail_subject", "ATK_EMAIL_MAIL_TPL":"email.mail_tpl", "ATK_EMAIL_MAIL__SUBJECT":"email.mail_subject", "ATK_EMAIL_MAIL__TPL":"email.mail_tpl", "ATK_EMAIL_POSTMARK_MESSAGE_STREAM":"email.postmark.message_stream", "ATK_EMAIL_POSTMARK_MESSAGE__STREAM":"email.postmark.message_stream", "ATK_EMAIL_POSTMARK_SERVER_TOKEN":"email.postmark.server_token", "ATK_EMAIL_POSTMARK_SERVER__TOKEN":"email.postmark.server_token", "ATK_EMAIL_POSTMARK_WEBHOOK_TOKEN":"email.postmark.webhook_token", "ATK_EMAIL_POSTMARK_WEBHOOK__TOKEN":"email.postmark.webhook_token", "ATK_EMAIL_QUEUE_BUFFER_SIZE":"email.queue.buffer_size", "ATK_EMAIL_QUEUE_BUFFER__SIZE":"email.queue.buffer_size", "ATK_EMAIL_QUEUE_MAX_RETRIES":"email.queue.max_retries", "ATK_EMAIL_QUEUE_MAX__RETRIES":"email.queue.max_retries", "ATK_EMAIL_QUEUE_WORKERS":"email.queue.workers", "ATK_EMAIL_RESEND_API_KEY":"email.resend.api_key", "ATK_EMAIL_RESEND_API__KEY":"email.resend.api_key", "ATK_EMAIL_RESEND_WEBHOOK_SECRET":"email.resend.webhook_secret", "ATK_EMAIL_RESEND_WEBHOOK__SECRET":"email.resend.webhook_secret", "ATK_EMAIL_SEND_ADDR":"email.send_addr", "ATK_EMAIL_SEND_NAME":"email.send_name", "ATK_EMAIL_SEND_TYPE":"email.send_type", "ATK_EMAIL_SEND__ADDR":"email.send_addr", "ATK_EMAIL_SEND__NAME":"email.send_name", "ATK_EMAIL_SEND__TYPE":"email.send_type", "ATK_EMAIL_SES_ACCESS_KEY_ID":"email.ses.access_key_id", "ATK_EMAIL_SES_ACCESS_KEY_SECRET":"email.ses.access_key_secret", "ATK_EMAIL_SES_ACCESS__KEY__ID":"email.ses.access_key_id", "ATK_EMAIL_SES_ACCESS__KEY__SECRET":"email.ses.access_key_secret", "ATK_EMAIL_SES_CONFIGURATION_SET":"email.ses.configuration_set", "ATK_EMAIL_SES_CONFIGURATION__SET":"email.ses.configuration_set", "ATK_EMAIL_SES_REGION":"email.ses.region", "ATK_EMAIL_SES_WEBHOOK_TOKEN":"email.ses.webhook_token", "ATK_EMAIL_SES_WEBHOOK__TOKEN":"email.ses.webhook_token", "ATK_EMAIL_SMTP_FROM":"email.smtp.from", "ATK_EMAIL_SMTP_HOST":"email.smtp.host", "ATK_EMAIL_SMTP_PASSWORD":"email.smtp.password", "ATK_EMAIL_SMTP_PORT":"email.smtp.port", "ATK_EMAIL_SMTP_USERNAME":"email.smtp.username", "ATK_EMAIL_UNSUBSCRIBE_URL":"email.unsubscribe_url", "ATK_EMAIL_UNSUBSCRIBE__URL":"email.unsubscribe_url", "ATK_FRONTEND":"frontend", "ATK_FRONTEND_DARKMODE":"frontend.darkMode", "ATK_FRONTEND_EDITORTRAVEL":"frontend.editorTravel", "ATK_FRONTEND_EMOTICONS":"frontend.emoticons", "ATK_FRONTEND_FLATMODE":"frontend.flatMode", "ATK_FRONTEND_GRAVATAR_MIRROR":"frontend.gravatar.mirror", "ATK_FRONTEND_GRAVATAR_PARAMS":"frontend.gravatar.params", "ATK_FRONTEND_HEIGHTLIMIT_CHILDREN":"frontend.heightLimit.children", "ATK_FRONTEND_HEIGHTLIMIT_CONTENT":"frontend.heightLimit.content", "ATK_FRONTEND_HEIGHTLIMIT_SCROLLABLE":"frontend.heightLimit.scrollable", "ATK_FRONTEND_IMGLAZYLOAD":"frontend.imgLazyLoad", "ATK_FRONTEND_LISTSORT":"frontend.listSort", "ATK_FRONTEND_NESTMAX":"frontend.nestMax", "ATK_FRONTEND_NESTSORT":"frontend.nestSort", "ATK_FRONTEND_NOCOMMENT":"frontend.noComment", "ATK_FRONTEND_PAGINATION_AUTOLOAD":"frontend.pagination.autoLoad", "ATK_FRONTEND_PAGINATION_PAGESIZE":"frontend.pagination.pageSize", "ATK_FRONTEND_PAGINATION_READMORE":"frontend.pagination.readMore", "ATK_FRONTEND_PLACEHOLDER":"frontend.placeholder", "ATK_FRONTEND_PLUGINURLS":"frontend.pluginURLs", "ATK_FRONTEND_PREVIEW":"frontend.preview", "ATK_FRONTEND_REQTIMEOUT":"frontend.reqTimeout", "ATK_FRONTEND_SENDBTN":"frontend.sendBtn", "ATK_FRONTEND_UABADGE":"frontend.uaBadge", "ATK_FRONTEND_VERSIONCHECK":"frontend.versionCheck", "ATK_FRONTEND_VOTE":"frontend.vote", "ATK_FRONTEND_VOTEDOWN":"frontend.voteDown", "ATK_GUEST_EDIT_ENABLED":"guest_edit.enabled", "ATK_GUEST_EDIT_WINDOW":"guest_edit.window", "ATK_GUEST__EDIT_ENABLED":"guest_edit.enabled", "ATK_GUEST__EDIT_WINDOW":"guest_edit.window", "ATK_HOST":"host", "ATK_HTTP_BODY_LIMIT":"http.body_limit", "ATK_HTTP_BODY__LIMIT":"http.body_limit", "ATK_HTTP_OUTBOUND_PROXY":"http.outbound_proxy", "ATK_HTTP_OUTBOUND__PROXY":"http.outbound_proxy", "ATK_HTTP_PROXY_HEADER":"http.proxy_header", "ATK_HTTP_PROXY__HEADER":"http.proxy_header", "ATK_HTTP_REUSE_PORT":"http.reuse_port", "ATK_HTTP_REUSE__PORT":"http.reuse_port", "ATK_HTTP_SHUTDOWN_TIMEOUT":"http.shutdown_timeout", "ATK_HTTP_SHUTDOWN__TIMEOUT":"http.shutdown_timeout", "ATK_IMG_UPLOAD_ENABLED":"img_upload.enabled", "ATK_IMG_UPLOAD_GC_ENABLED":"img_upload.gc.enabled", "ATK_IMG_UPLOAD_GC_GRACE_PERIOD":"img_upload.gc.grace_period", "ATK_IMG_UPLOAD_GC_INTERVAL":"img_upload.gc.interval", "ATK_IMG_UPLOAD_MAX_SIZE":"img_upload.max_size", "ATK_IMG_UPLOAD_PATH":"img_upload.path", "ATK_IMG_UPLOAD_POLICY_ALLOWED_TYPES":"img_upload.policy.allowed_types", "ATK_IMG_UPLOAD_POLICY_ALLOWED_TYPES_$$":"img_upload.policy.allowed_types.$$", "ATK_IMG_UPLOAD_POLICY_DAILY_COUNT":"img_upload.policy.daily_count", "ATK_IMG_UPLOAD_POLICY_DAILY_SIZE":"img_upload.policy.daily_size", "ATK_IMG_UPLOAD_POLICY_MAX_SIZE":"img_upload.policy.max_size", "ATK_IMG_UPLOAD_PROCESS_ENABLED":"img_upload.process.enabled", "ATK_IMG_UPLOAD_PROCESS_FORMAT":"img_upload.process.format", "ATK_IMG_UPLOAD_PROCESS_MAX_HEIGHT":"img_upload.process.max_height", "ATK_IMG_UPLOAD_PROCESS_MAX_PIXELS":"img_upload.process.max_pixels", "ATK_IMG_UPLOAD_PROCESS_MAX_WIDTH":"img_upload.process.max_width", "ATK_IMG_UPLOAD_PROCESS_QUALITY":"img_upload.process.quality", "ATK_IMG_UPLOAD_PROCESS_STRIP_METADATA":"img_upload.process.strip_metadata", "ATK_IMG_UPLOAD_PROCESS_THUMBNAIL_WIDTH":"img_upload.process.thumbnail_width", "ATK_IMG_UPLOAD_PUBLIC_PATH":"img_upload.public_path", "ATK_IMG_UPLOAD_QUALITY":"img_upload.quality", "ATK_IMG_UPLOAD_S3_ACCESS_KEY":"img_upload.s3.access_key", "ATK_IMG_UPLOAD_S3_BUCKET":"img_upload.s3.bucket", "ATK_IMG_UPLOAD_S3_ENDPOINT":"img_upload.s3.endpoint", "ATK_IMG_UPLOAD_S3_PATH_STYLE":"img_upload.s3.path_style", "ATK_IMG_UPLOAD_S3_PREFIX":"img_upload.s3.prefix", "ATK_IMG_UPLOAD_S3_PRESIGN":"img_upload.s3.presign", "ATK_IMG_UPLOAD_S3_PRESIGN_EXPIRES":"img_upload.s3.presign_expires", "ATK_IMG_UPLOAD_S3_PUBLIC_URL":"img_upload.s3.public_url", "ATK_IMG_UPLOAD_S3_REGION":"img_upload.s3.region", "ATK_IMG_UPLOAD_S3_SECRET_KEY":"img_upload.s3.secret_key", "ATK_IMG_UPLOAD_STORAGE":"img_upload.storage", "ATK_IMG_UPLOAD_UPGIT_DEL_LOCAL":"img_upload.upgit.del_local", "ATK_IMG_UPLOAD_UPGIT_ENABLED":"img_upload.upgit.enabled", "ATK_IMG_UPLOAD_UPGIT_EXEC":"img_upload.upgit.exec", "ATK_IMG__UPLOAD_ENABLED":"img_upload.enabled", "ATK_IMG__UPLOAD_GC_ENABLED":"img_upload.gc.enabled", "ATK_IMG__UPLOAD_GC_GRACE__PERIOD":"img_upload.gc.grace_period", "ATK_IMG__UPLOAD_GC_INTERVAL":"img_upload.gc.interval", "ATK_IMG__UPLOAD_MAX__SIZE":"img_upload.max_size", "ATK_IMG__UPLOAD_PATH":"img_upload.path", "ATK_IMG__UPLOAD_POLICY_ALLOWED__TYPES_$$":"img_upload.policy.allowed_types.$$", "ATK_IMG__UPLOAD_POLICY_DAILY__COUNT":"img_upload.policy.daily_count", "ATK_IMG__UPLOAD_POLICY_DAILY__SIZE":"img_upload.policy.daily_size", "ATK_IMG__UPLOAD_POLICY_MAX__SIZE":"img_upload.policy.max_size", "ATK_IMG__UPLOAD_PROCESS_ENABLED":"img_upload.process.enabled", "ATK_IMG__UPLOAD_PROCESS_FORMAT":"img_upload.process.format", "ATK_IMG__UPLOAD_PROCESS_MAX__HEIGHT":"img_upload.process.max_height", "ATK_IMG__UPLOAD_PROCESS_MAX__PIXELS":"img_upload.process.max_pixels", "ATK_IMG__UPLOAD_PROCESS_MAX__WIDTH":"img_upload.process.max_width", "ATK_IMG__UPLOAD_PROCESS_QUALITY":"img_upload.process.quality", "ATK_IMG__UPLOAD_PROCESS_STRIP__METADATA":"img_upload.process.strip_metadata", "ATK_IMG__UPLOAD_PROCESS_THUMBNAIL__WIDTH":"img_upload.process.thumbnail_width", "ATK_IMG__UPLOAD_PUBLIC__PATH":"img_upload.public_path", "ATK_IMG__UPLOAD_QUALITY":"img_upload.quality", "ATK_IMG__UPLOAD_S3_ACCESS__KEY":"img_upload.s3.access_key", "ATK_IMG__UPLOAD_S3_BUCKET":"img_upload.s3.bucket", "ATK_IMG__UPLOAD_S3_ENDPOINT":"img_upload.s3.endpoint", "ATK_IMG__UPLOAD_S3_PATH__STYLE":"img_upload.s3.path_style", "ATK_IMG__UPLOAD_S3_PREFIX":"img_upload.s3.prefix", "ATK_IMG__UPLOAD_S3_PRESIGN":"img_upload.s3.presign", "ATK_IMG__UPLOAD_S3_PRESIGN__EXPIRES":"img_upload.s3.presign_expires", "ATK_IMG__UPLOAD_S3_PUBLIC__URL":"img_upload.s3.public_url", "ATK_IMG__UPLOAD_S3_REGION":"img_upload.s3.region", "ATK_IMG__UPLOAD_S3_SECRET__KEY":"img_upload.s3.secret_key", "ATK_IMG__UPLOAD_STORAGE":"img_upload.storage", "ATK_IMG__UPLOAD_UPGIT_DEL__LOCAL":"img_upload.upgit.del_local", "ATK_IMG__UPLOAD_UPGIT_ENABLED":"img_upload.upgit.enabled", "ATK_IMG__UPLOAD_UPGIT_EXEC":"img_upload.upgit.exec", "ATK_IP_PRIVACY_ANONYMIZE":"ip_privacy.anonymize", "ATK_IP_PRIVACY_HASH_ROTATION":"ip_privacy.hash_rotation", "ATK_IP_PRIVACY_RETENTION":"ip_privacy.retention", "ATK_IP_REGION_ADMIN_ONLY":"ip_region.admin_only", "ATK_IP_REGION_DB_PATH":"ip_region.db_path", "ATK_IP_REGION_ENABLED":"ip_region.enabled", "ATK_IP_REGION_LOCALE":"ip_region.locale", "ATK_IP_REGION_PRECISION":"ip_region.precision", "ATK_IP_REGION_PROVIDER":"ip_region.provider", "ATK_IP__PRIVACY_ANONYMIZE":"ip_privacy.anonymize", "ATK_IP__PRIVACY_HASH__ROTATION":"ip_privacy.hash_rotation", "ATK_IP__PRIVACY_RETENTION":"ip_privacy.retention", "ATK_IP__REGION_ADMIN__ONLY":"ip_region.admin_only", "ATK_IP__REGION_DB__PATH":"ip_region.db_path", "ATK_IP__REGION_ENABLED":"ip_region.enabled", "ATK_IP__REGION_LOCALE":"ip_region.locale", "ATK_IP__REGION_PRECISION":"ip_region.precision", "ATK_IP__REGION_PROVIDER":"ip_region.provider", "ATK_LOCALE":"locale", "ATK_LOGIN_TIMEOUT":"login_timeout", "ATK_LOGIN__TIMEOUT":"login_timeout", "ATK_LOG_COMPRESS":"log.compress", "ATK_LOG_ENABLED":"log.enabled", "ATK_LOG_FILENAME":"log.filename", "ATK_LOG_FORMAT":"log.format", "ATK_LOG_LEVEL":"log.level", "ATK_LOG_MAX_AGE":"log.max_age", "ATK_LOG_MAX_BACKUPS":"log.max_backups", "ATK_LOG_MAX_SIZE":"log.max_size", "ATK_LOG_MAX__AGE":"log.max_age", "ATK_LOG_MAX__BACKUPS":"log.max_backups", "ATK_LOG_MAX__SIZE":"log.max_size", "ATK_LOG_MODULES":"log.modules", "ATK_MARKDOWN_ALLOWED_TAGS":"markdown.allowed_tags", "ATK_MARKDOWN_ALLOWED_TAGS_$$":"markdown.allowed_tags.$$", "ATK_MARKDOWN_ALLOWED__TAGS_$$":"markdown.allowed_tags.$$", "ATK_MARKDOWN_HIGHLIGHT_CODE":"markdown.highlight_code", "ATK_MARKDOWN_HIGHLIGHT__CODE":"markdown.highlight_code", "ATK_MARKDOWN_IMAGE_POLICY":"markdown.image_policy", "ATK_MARKDOWN_IMAGE__POLICY":"markdown.image_policy", "ATK_MARKDOWN_LINK_REL":"markdown.link_rel", "ATK_MARKDOWN_LINK_REL_$$":"markdown.link_rel.$$", "ATK_MARKDOWN_LINK__REL_$$":"markdown.link_rel.$$", "ATK_MARKDOWN_PROFILE":"markdown.profile", "ATK_METRICS_ENABLED":"metrics.enabled", "ATK_METRICS_PATH":"metrics.path", "ATK_METRICS_TOKEN":"metrics.token", "ATK_MODERATOR_AI_API_KEY":"moderator.ai.api_key", "ATK_MODERATOR_AI_API__KEY":"moderator.ai.api_key", "ATK_MODERATOR_AI_CIRCUIT_BREAKER_COOLDOWN":"moderator.ai.circuit_breaker.cooldown", "ATK_MODERATOR_AI_CIRCUIT_BREAKER_THRESHOLD":"moderator.ai.circuit_breaker.threshold", "ATK_MODERATOR_AI_CIRCUIT__BREAKER_COOLDOWN":"moderator.ai.circuit_breaker.cooldown", "ATK_MODERATOR_AI_CIRCUIT__BREAKER_THRESHOLD":"moderator.ai.circuit_breaker.threshold", "ATK_MODERATOR_AI_DRY_RUN":"moderator.ai.dry_run", "ATK_MODERATOR_AI_DRY__RUN":"moderator.ai.dry_run", "ATK_MODERATOR_AI_ENABLED":"moderator.ai.enabled", "ATK_MODERATOR_AI_ERROR_DECISION":"moderator.ai.error_decision", "ATK_MODERATOR_AI_ERROR__DECISION":"moderator.ai.error_decision", "ATK_MODERATOR_AI_HEADERS":"moderator.ai.headers", "ATK_MODERATOR_AI_HISTORY_SIZE":"moderator.ai.history_size", "ATK_MODERATOR_AI_HISTORY__SIZE":"moderator.ai.history_size", "ATK_MODERATOR_AI_HOST":"moderator.ai.host", "ATK_MODERATOR_AI_LANGUAGES_ALLOWLIST":"moderator.ai.languages.allowlist", "ATK_MODERATOR_AI_LANGUAGES_ALLOWLIST_$$":"moderator.ai.languages.allowlist.$$", "ATK_MODERATOR_AI_LANGUAGES_HOLD_UNLISTED":"moderator.ai.languages.hold_unlisted", "ATK_MODERATOR_AI_LANGUAGES_HOLD__UNLISTED":"moderator.ai.languages.hold_unlisted", "ATK_MODERATOR_AI_LANGUAGES_POLICIES":"moderator.ai.languages.policies", "ATK_MODERATOR_AI_LIMIT_FALLBACK":"moderator.ai.limit.fallback", "ATK_MODERATOR_AI_LIMIT_MONTHLY_REQUESTS":"moderator.ai.limit.monthly_requests", "ATK_MODERATOR_AI_LIMIT_MONTHLY_TOKENS":"moderator.ai.limit.monthly_tokens", "ATK_MODERATOR_AI_LIMIT_MONTHLY__REQUESTS":"moderator.ai.limit.monthly_requests", "ATK_MODERATOR_AI_LIMIT_MONTHLY__TOKENS":"moderator.ai.limit.monthly_tokens", "ATK_MODERATOR_AI_LIMIT_PER_MINUTE":"moderator.ai.limit.per_minute", "ATK_MODERATOR_AI_LIMIT_PER__MINUTE":"moderator.ai.limit.per_minute", "ATK_MODERATOR_AI_MAX_TOKENS":"moderator.ai.max_tokens", "ATK_MODERATOR_AI_MAX__TOKENS":"moderator.ai.max_tokens", "ATK_MODERATOR_AI_MODEL":"moderator.ai.model", "ATK_MODERATOR_AI_PATH":"moderator.ai.path", "ATK_MODERATOR_AI_PROMPT_TEMPLATE":"moderator.ai.prompt_template", "ATK_MODERATOR_AI_PROMPT__TEMPLATE":"moderator.ai.prompt_template", "ATK_MODERATOR_AI_PROVIDER":"moderator.ai.provider", "ATK_MODERATOR_AI_PROXY":"moderator.ai.proxy", "ATK_MODERATOR_AI_QUERY":"moderator.ai.query", "ATK_MODERATOR_AI_RESPONSE_FORMAT":"moderator.ai.response_format", "ATK_MODERATOR_AI_RESPONSE__FORMAT":"moderator.ai.response_format", "ATK_MODERATOR_AI_RETRY_BACKOFF":"moderator.ai.retry.backoff", "ATK_MODERATOR_AI_RETRY_MAX_RETRIES":"moderator.ai.retry.max_retries", "ATK_MODERATOR_AI_RETRY_MAX__RETRIES":"moderator.ai.retry.max_retries", "ATK_MODERATOR_AI_TEMPERATURE":"moderator.ai.temperature", "ATK_MODERATOR_AI_UNCLEAR_DECISION":"moderator.ai.unclear_decision", "ATK_MODERATOR_AI_UNCLEAR__DECISION":"moderator.ai.unclear_decision", "ATK_MODERATOR_AKISMET_KEY":"moderator.akismet_key", "ATK_MODERATOR_AKISMET_PROXY":"moderator.akismet_proxy", "ATK_MODERATOR_AKISMET__KEY":"moderator.akismet_key", "ATK_MODERATOR_AKISMET__PROXY":"moderator.akismet_proxy", "ATK_MODERATOR_ALIYUN_ACCESS_KEY_ID":"moderator.aliyun.access_key_id", "ATK_MODERATOR_ALIYUN_ACCESS_KEY_SECRET":"moderator.aliyun.access_key_secret", "ATK_MODERATOR_ALIYUN_ACCESS__KEY__ID":"moderator.aliyun.access_key_id", "ATK_MODERATOR_ALIYUN_ACCESS__KEY__SECRET":"moderator.aliyun.access_key_secret", "ATK_MODERATOR_ALIYUN_ENABLED":"moderator.aliyun.enabled", "ATK_MODERATOR_ALIYUN_REGION":"moderator.aliyun.region", "ATK_MODERATOR_API_FAIL_BLOCK":"moderator.api_fail_block", "ATK_MODERATOR_API__FAIL__BLOCK":"moderator.api_fail_block", "ATK_MODERATOR_ASYNC_BUFFER_SIZE":"moderator.async.buffer_size", "ATK_MODERATOR_ASYNC_BUFFER__SIZE":"moderator.async.buffer_size", "ATK_MODERATOR_ASYNC_ENABLED":"moderator.async.enabled", "ATK_MODERATOR_ASYNC_WORKERS":"moderator.async.workers", "ATK_MODERATOR_BAN_ASN_DB":"moderator.ban.asn_db", "ATK_MODERATOR_BAN_ASN__DB":"moderator.ban.asn_db", "ATK_MODERATOR_BAYES_ENABLED":"moderator.bayes.enabled", "ATK_MODERATOR_BAYES_MIN_SAMPLES":"moderator.bayes.min_samples", "ATK_MODERATOR_BAYES_MIN__SAMPLES":"moderator.bayes.min_samples", "ATK_MODERATOR_BAYES_PENDING":"moderator.bayes.pending", "ATK_MODERATOR_BAYES_THRESHOLD":"moderator.bayes.threshold", "ATK_MODERATOR_CACHE_ENABLED":"moderator.cache.enabled", "ATK_MODERATOR_CACHE_TTL":"moderator.cache.ttl", "ATK_MODERATOR_EDIT_ENABLED":"moderator.edit.enabled", "ATK_MODERATOR_EDIT_PENDING":"moderator.edit.pending", "ATK_MODERATOR_EDIT_SHOW_EDITED":"moderator.edit.show_edited", "ATK_MODERATOR_EDIT_SHOW__EDITED":"moderator.edit.show_edited", "ATK_MODERATOR_EMAIL_VERIFY_ENABLED":"moderator.email_verify.enabled", "ATK_MODERATOR_EMAIL_VERIFY_EXPIRES":"moderator.email_verify.expires", "ATK_MODERATOR_EMAIL_VERIFY_MAIL_SUBJECT":"moderator.email_verify.mail_subject", "ATK_MODERATOR_EMAIL_VERIFY_SERVER_URL":"moderator.email_verify.server_url", "ATK_MODERATOR_EMAIL__VERIFY_ENABLED":"moderator.email_verify.enabled", "ATK_MODERATOR_EMAIL__VERIFY_EXPIRES":"moderator.email_verify.expires", "ATK_MODERATOR_EMAIL__VERIFY_MAIL__SUBJECT":"moderator.email_verify.mail_subject", "ATK_MODERATOR_EMAIL__VERIFY_SERVER__URL":"moderator.email_verify.server_url", "ATK_MODERATOR_FEEDBACK_ENABLED":"moderator.feedback.enabled", "ATK_MODERATOR_FEEDBACK_FEW_SHOT":"moderator.feedback.few_shot", "ATK_MODERATOR_FEEDBACK_FEW__SHOT":"moderator.feedback.few_shot", "ATK_MODERATOR_FLOOD_DUPLICATE_WINDOW":"moderator.flood.duplicate_window", "ATK_MODERATOR_FLOOD_DUPLICATE__WINDOW":"moderator.flood.duplicate_window", "ATK_MODERATOR_FLOOD_ENABLED":"moderator.flood.enabled", "ATK_MODERATOR_FLOOD_MAX_COMMENTS":"moderator.flood.max_comments", "ATK_MODERATOR_FLOOD_MAX__COMMENTS":"moderator.flood.max_comments", "ATK_MODERATOR_FLOOD_MIN_LENGTH":"moderator.flood.min_length", "ATK_MODERATOR_FLOOD_MIN__LENGTH":"moderator.flood.min_length", "ATK_MODERATOR_FLOOD_PENDING":"moderator.flood.pending", "ATK_MODERATOR_FLOOD_SIMILARITY":"moderator.flood.similarity", "ATK_MODERATOR_FLOOD_WINDOW":"moderator.flood.window", "ATK_MODERATOR_IMAGE_ACCESS_KEY_ID":"moderator.image.access_key_id", "ATK_MODERATOR_IMAGE_ACCESS_KEY_SECRET":"moderator.image.access_key_secret", "ATK_MODERATOR_IMAGE_ACCESS__KEY__ID":"moderator.image.access_key_id", "ATK_MODERATOR_IMAGE_ACCESS__KEY__SECRET":"moderator.image.access_key_secret", "ATK_MODERATOR_IMAGE_API_KEY":"moderator.image.api_key", "ATK_MODERATOR_IMAGE_API__KEY":"moderator.image.api_key", "ATK_MODERATOR_IMAGE_CATEGORIES":"moderator.image.categories", "ATK_MODERATOR_IMAGE_CATEGORIES_$$":"moderator.image.categories.$$", "ATK_MODERATOR_IMAGE_ENABLED":"moderator.image.enabled", "ATK_MODERATOR_IMAGE_HOST":"moderator.image.host", "ATK_MODERATOR_IMAGE_MAX_IMAGES":"moderator.image.max_images", "ATK_MODERATOR_IMAGE_MAX_SIZE":"moderator.image.max_size", "ATK_MODERATOR_IMAGE_MAX__IMAGES":"moderator.image.max_images", "ATK_MODERATOR_IMAGE_MAX__SIZE":"moderator.image.max_size", "ATK_MODERATOR_IMAGE_MODEL":"moderator.image.model", "ATK_MODERATOR_IMAGE_PENDING":"moderator.image.pending", "ATK_MODERATOR_IMAGE_PROVIDER":"moderator.image.provider", "ATK_MODERATOR_IMAGE_REGION":"moderator.image.region", "ATK_MODERATOR_IMAGE_THRESHOLD":"moderator.image.threshold", "ATK_MODERATOR_KEYWORDS_ENABLED":"moderator.keywords.enabled", "ATK_MODERATOR_KEYWORDS_FILES":"moderator.keywords.files", "ATK_MODERATOR_KEYWORDS_FILES_$$":"moderator.keywords.files.$$", "ATK_MODERATOR_KEYWORDS_FILE_SEP":"moderator.keywords.file_sep", "ATK_MODERATOR_KEYWORDS_FILE__SEP":"moderator.keywords.file_sep", "ATK_MODERATOR_KEYWORDS_PENDING":"moderator.keywords.pending", "ATK_MODERATOR_KEYWORDS_REFRESH_INTERVAL":"moderator.keywords.refresh_interval", "ATK_MODERATOR_KEYWORDS_REFRESH__INTERVAL":"moderator.keywords.refresh_interval", "ATK_MODERATOR_KEYWORDS_REPLACE_TO":"moderator.keywords.replace_to", "ATK_MODERATOR_KEYWORDS_REPLACE__TO":"moderator.keywords.replace_to", "ATK_MODERATOR_LINKS_BLACKLIST":"moderator.links.blacklist", "ATK_MODERATOR_LINKS_BLACKLIST_$$":"moderator.links.blacklist.$$", "ATK_MODERATOR_LINKS_BLOCK_SHORTENERS":"moderator.links.block_shorteners", "ATK_MODERATOR_LINKS_BLOCK__SHORTENERS":"moderator.links.block_shorteners", "ATK_MODERATOR_LINKS_ENABLED":"moderator.links.enabled", "ATK_MODERATOR_LINKS_MAX_LINKS":"moderator.links.max_links", "ATK_MODERATOR_LINKS_MAX__LINKS":"moderator.links.max_links", "ATK_MODERATOR_LINKS_PENDING":"moderator.links.pending", "ATK_MODERATOR_LINKS_SHORTENERS":"moderator.links.shorteners", "ATK_MODERATOR_LINKS_SHORTENERS_$$":"moderator.links.shorteners.$$", "ATK_MODERATOR_OPENAI_MODERATION_API_KEY":"moderator.openai_moderation.api_key", "ATK_MODERATOR_OPENAI_MODERATION_ENABLED":"moderator.openai_moderation.enabled", "ATK_MODERATOR_OPENAI_MODERATION_HOST":"moderator.openai_moderation.host", "ATK_MODERATOR_OPENAI_MODERATION_MODEL":"moderator.openai_moderation.model", "ATK_MODERATOR_OPENAI_MODERATION_THRESHOLDS":"moderator.openai_moderation.thresholds", "ATK_MODERATOR_OPENAI_MODERATION_THRESHOLDS_HATE":"moderator.openai_moderation.thresholds.hate", "ATK_MODERATOR_OPENAI_MODERATION_THRESHOLDS_SEXUAL":"moderator.openai_moderation.thresholds.sexual", "ATK_MODERATOR_OPENAI_MODERATION_THRESHOLDS_VIOLENCE":"moderator.openai_moderation.thresholds.violence", "ATK_MODERATOR_OPENAI__MODERATION_API__KEY":"moderator.openai_moderation.api_key", "ATK_MODERATOR_OPENAI__MODERATION_ENABLED":"moderator.openai_moderation.enabled", "ATK_MODERATOR_OPENAI__MODERATION_HOST":"moderator.openai_moderation.host", "ATK_MODERATOR_OPENAI__MODERATION_MODEL":"moderator.openai_moderation.model", "ATK_MODERATOR_OPENAI__MODERATION_THRESHOLDS":"moderator.openai_moderation.thresholds", "ATK_MODERATOR_PENDING_DEFAULT":"moderator.pending_default", "ATK_MODERATOR_PENDING__DEFAULT":"moderator.pending_default", "ATK_MODERATOR_REPUTATION_ABUSEIPDB_KEY":"moderator.reputation.abuseipdb_key", "ATK_MODERATOR_REPUTATION_ABUSEIPDB__KEY":"moderator.reputation.abuseipdb_key", "ATK_MODERATOR_REPUTATION_CACHE_TTL":"moderator.reputation.cache_ttl", "ATK_MODERATOR_REPUTATION_CACHE__TTL":"moderator.reputation.cache_ttl", "ATK_MODERATOR_REPUTATION_ENABLED":"moderator.reputation.enabled", "ATK_MODERATOR_REPUTATION_STOPFORUMSPAM":"moderator.reputation.stopforumspam", "ATK_MODERATOR_REPUTATION_THRESHOLD":"moderator.reputation.threshold", "ATK_MODERATOR_SCORING_ENABLED":"moderator.scoring.enabled", "ATK_MODERATOR_SCORING_REVIEW_THRESHOLD":"moderator.scoring.review_threshold", "ATK_MODERATOR_SCORING_REVIEW__THRESHOLD":"moderator.scoring.review_threshold", "ATK_MODERATOR_SCORING_THRESHOLD":"moderator.scoring.threshold", "ATK_MODERATOR_SCORING_WEIGHTS":"moderator.scoring.weights", "ATK_MODERATOR_SCORING_WEIGHTS_AI":"moderator.scoring.weights.ai", "ATK_MODERATOR_SCORING_WEIGHTS_AKISMET":"moderator.scoring.weights.akismet", "ATK_MODERATOR_SCORING_WEIGHTS_KEYWORDS":"moderator.scoring.weights.keywords", "ATK_MODERATOR_TENCENT_ENABLED":"moderator.tencent.enabled", "ATK_MODERATOR_TENCENT_REGION":"moderator.tencent.region", "ATK_MODERATOR_TENCENT_SECRET_ID":"moderator.tencent.secret_id", "ATK_MODERATOR_TENCENT_SECRET_KEY":"moderator.tencent.secret_key", "ATK_MODERATOR_TENCENT_SECRET__ID":"moderator.tencent.secret_id", "ATK_MODERATOR_TENCENT_SECRET__KEY":"moderator.tencent.secret_key", "ATK_MODERATOR_TRUSTED_ALLOWLIST":"moderator.trusted.allowlist", "ATK_MODERATOR_TRUSTED_ALLOWLIST_$$":"moderator.trusted.allowlist.$$", "ATK_MODERATOR_TRUSTED_ENABLED":"moderator.trusted.enabled", "ATK_MODERATOR_TRUSTED_MIN_APPROVED":"moderator.trusted.min_approved", "ATK_MODERATOR_TRUSTED_MIN__APPROVED":"moderator.trusted.min_approved", "ATK_NOTIFY_TEMPLATES_DIR":"notify_templates.dir", "ATK_NOTIFY__TEMPLATES_DIR":"notify_templates.dir", "ATK_PORT":"port", "ATK_PV_DEDUP":"pv.dedup", "ATK_PV_ENABLED":"pv.enabled", "ATK_REACTION_EMOJIS":"reaction.emojis", "ATK_REACTION_EMOJIS_$$":"reaction.emojis.$$", "ATK_REACTION_ENABLED":"reaction.enabled", "ATK_REALTIME_BROKER":"realtime.broker", "ATK_REALTIME_ENABLED":"realtime.enabled", "ATK_REALTIME_MAX_CONNS":"realtime.max_conns", "ATK_REALTIME_MAX_CONNS_PER_IP":"realtime.max_conns_per_ip", "ATK_REALTIME_MAX_MESSAGE_SIZE":"realtime.max_message_size", "ATK_REALTIME_MAX__CONNS":"realtime.max_conns", "ATK_REALTIME_MAX__CONNS__PER__IP":"realtime.max_conns_per_ip", "ATK_REALTIME_MAX__MESSAGE__SIZE":"realtime.max_message_size", "ATK_REALTIME_REDIS_DB":"realtime.redis.db", "ATK_REALTIME_REDIS_MASTER_NAME":"realtime.redis.master_name", "ATK_REALTIME_REDIS_MASTER__NAME":"realtime.redis.master_name", "ATK_REALTIME_REDIS_MODE":"realtime.redis.mode", "ATK_REALTIME_REDIS_NETWORK":"realtime.redis.network", "ATK_REALTIME_REDIS_PASSWORD":"realtime.redis.password", "ATK_REALTIME_REDIS_SENTINEL_PASSWORD":"realtime.redis.sentinel_password", "ATK_REALTIME_REDIS_SENTINEL_USERNAME":"realtime.redis.sentinel_username", "ATK_REALTIME_REDIS_SENTINEL__PASSWORD":"realtime.redis.sentinel_password", "ATK_REALTIME_REDIS_SENTINEL__USERNAME":"realtime.redis.sentinel_username", "ATK_REALTIME_REDIS_TLS_CA_CERT":"realtime.redis.tls.ca_cert", "ATK_REALTIME_REDIS_TLS_CA__CERT":"realtime.redis.tls.ca_cert", "ATK_REALTIME_REDIS_TLS_CERT":"realtime.redis.tls.cert", "ATK_REALTIME_REDIS_TLS_ENABLED":"realtime.redis.tls.enabled", "ATK_REALTIME_REDIS_TLS_INSECURE_SKIP_VERIFY":"realtime.redis.tls.insecure_skip_verify", "ATK_REALTIME_REDIS_TLS_INSECURE__SKIP__VERIFY":"realtime.redis.tls.insecure_skip_verify", "ATK_REALTIME_REDIS_TLS_KEY":"realtime.redis.tls.key", "ATK_REALTIME_REDIS_TLS_SERVER_NAME":"realtime.redis.tls.server_name", "ATK_REALTIME_REDIS_TLS_SERVER__NAME":"realtime.redis.tls.server_name", "ATK_REALTIME_REDIS_USERNAME":"realtime.redis.username", "ATK_REALTIME_SERVER":"realtime.server", "ATK_REPORT_ENABLED":"report.enabled", "ATK_REPORT_THRESHOLD":"report.threshold", "ATK_SEARCH_ENGINE":"search.engine", "ATK_SEARCH_MEILISEARCH_API_KEY":"search.meilisearch.api_key", "ATK_SEARCH_MEILISEARCH_API__KEY":"search.meilisearch.api_key", "ATK_SEARCH_MEILISEARCH_HOST":"search.meilisearch.host", "ATK_SEARCH_MEILISEARCH_INDEX":"search.meilisearch.index", "ATK_SITE_DEFAULT":"site_default", "ATK_SITE_URL":"site_url", "ATK_SITE__DEFAULT":"site_default", "ATK_SITE__URL":"site_url", "ATK_SOFT_DELETE_ENABLED":"soft_delete.enabled", "ATK_SOFT_DELETE_RETENTION":"soft_delete.retention", "ATK_SOFT__DELETE_ENABLED":"soft_delete.enabled", "ATK_SOFT__DELETE_RETENTION":"soft_delete.retention", "ATK_SSL_CERT_PATH":"ssl.cert_path", "ATK_SSL_CERT__PATH":"ssl.cert_path", "ATK_SSL_ENABLED":"ssl.enabled", "ATK_SSL_KEY_PATH":"ssl.key_path", "ATK_SSL_KEY__PATH":"ssl.key_path", "ATK_TIMEZONE":"timezone", "ATK_TOKEN_REFRESH_ACCESS_TTL":"token_refresh.access_ttl", "ATK_TOKEN_REFRESH_ENABLED":"token_refresh.enabled", "ATK_TOKEN__REFRESH_ACCESS__TTL":"token_refresh.access_ttl", "ATK_TOKEN__REFRESH_ENABLED":"token_refresh.enabled", "ATK_TRACING_ENABLED":"tracing.enabled", "ATK_TRACING_ENDPOINT":"tracing.endpoint", "ATK_TRACING_HEADERS":"tracing.headers", "ATK_TRACING_INSECURE":"tracing.insecure", "ATK_TRACING_SAMPLE_RATIO":"tracing.sample_ratio", "ATK_TRACING_SAMPLE__RATIO":"tracing.sample_ratio", "ATK_TRACING_SERVICE_NAME":"tracing.service_name", "ATK_TRACING_SERVICE__NAME":"tracing.service_name", "ATK_TRUSTED_DOMAINS":"trusted_domains", "ATK_TRUSTED_DOMAINS_$$":"trusted_domains.$$", "ATK_TRUSTED__DOMAINS_$$":"trusted_domains.$$", "ATK_WEBHOOK_ENABLED":"webhook.enabled", "ATK_WEBHOOK_ENDPOINTS":"webhook.endpoints", "ATK_WEBHOOK_ENDPOINTS_$$_EVENTS_$$":"webhook.endpoints.$$.events.$$", "ATK_WEBHOOK_ENDPOINTS_$$_SECRET":"webhook.endpoints.$$.secret", "ATK_WEBHOOK_ENDPOINTS_$$_URL":"webhook.endpoints.$$.url", "ATK_WEBHOOK_MAX_RETRIES":"webhook.max_retries", "ATK_WEBHOOK_MAX__RETRIES":"webhook.max_retries", "ATK_WEBHOOK_TIMEOUT":"webhook.timeout", "ATK_WEB_PUSH_ENABLED":"web_push.enabled", "ATK_WEB_PUSH_SUBJECT":"web_push.subject", "ATK_WEB_PUSH_VAPID_PRIVATE_KEY":"web_push.vapid_private_key", "ATK_WEB_PUSH_VAPID_PUBLIC_KEY":"web_push.vapid_public_key", "ATK_WEB__PUSH_ENABLED":"web_push.enabled", "ATK_WEB__PUSH_SUBJECT":"web_push.subject", "ATK_WEB__PUSH_VAPID__PRIVATE__KEY":"web_push.vapid_private_key", "ATK_WEB__PUSH_VAPID__PUBLIC__KEY":"web_push.vapid_public_key"}
 
 // Cache result of `meta.GetOptionsMetaData(config.Template("en"))`
-var OptionsMetaCache = []meta.OptionsMeta{meta.OptionsMeta{Title:"Multi-Push", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY", Path:"admin_notify", PathText:"Multi-Push", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Multi-Push"}, meta.OptionsMeta{Title:"Bark", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_BARK", Path:"admin_notify.bark", PathText:"Multi-Push > Bark", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Bark"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_BARK_ENABLED", Path:"admin_notify.bark.enabled", PathText:"Multi-Push > Bark > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Server", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_BARK_SERVER", Path:"admin_notify.bark.server", PathText:"Multi-Push > Bark > Server", Default:"http://day.app/xxxxxxx/", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Server"}, meta.OptionsMeta{Title:"DingTalk", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DING_TALK", Path:"admin_notify.ding_talk", PathText:"Multi-Push > DingTalk", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"DingTalk"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DING_TALK_ENABLED", Path:"admin_notify.ding_talk.enabled", PathText:"Multi-Push > DingTalk > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Secret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DING_TALK_SECRET", Path:"admin_notify.ding_talk.secret", PathText:"Multi-Push > DingTalk > Secret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Secret"}, meta.OptionsMeta{Title:"Token", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DING_TALK_TOKEN", Path:"admin_notify.ding_talk.token", PathText:"Multi-Push > DingTalk > Token", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Token"}, meta.OptionsMeta{Title:"Notify admin", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_EMAIL", Path:"admin_notify.email", PathText:"Multi-Push > Notify admin", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Notify admin"}, meta.OptionsMeta{Title:"Enable", Desc:"can be disabled when using other push methods", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_EMAIL_ENABLED", Path:"admin_notify.email.enabled", PathText:"Multi-Push > Notify admin > Enable", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable (can be disabled when using other push methods)"}, meta.OptionsMeta{Title:"Email subject", Desc:"email subject sent to admin", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_EMAIL_MAIL_SUBJECT", Path:"admin_notify.email.mail_subject", PathText:"Multi-Push > Notify admin > Email subject", Default:"[{{site_name}}] Post \"{{page_title}}\" has new a comment", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email subject (email subject sent to admin)"}, meta.OptionsMeta{Title:"Admin email template file", Desc:"set to file path to use custom template", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_EMAIL_MAIL_TPL", Path:"admin_notify.email.mail_tpl", PathText:"Multi-Push > Notify admin > Admin email template file", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Admin email template file (set to file path to use custom template)"}, meta.OptionsMeta{Title:"Lark", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LARK", Path:"admin_notify.lark", PathText:"Multi-Push > Lark", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Lark"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LARK_ENABLED", Path:"admin_notify.lark.enabled", PathText:"Multi-Push > Lark > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Message type", Desc:"", Type:"string", Options:[]string{"text", "card"}, Env:"ATK_ADMIN_NOTIFY_LARK_MSG_TYPE", Path:"admin_notify.lark.msg_type", PathText:"Multi-Push > Lark > Message type", Default:"text", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Message type [\"text\", \"card\"]"}, meta.OptionsMeta{Title:"WebhookUrl", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LARK_WEBHOOK_URL", Path:"admin_notify.lark.webhook_url", PathText:"Multi-Push > Lark > WebhookUrl", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"WebhookUrl"}, meta.OptionsMeta{Title:"LINE", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE", Path:"admin_notify.line", PathText:"Multi-Push > LINE", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"LINE"}, meta.OptionsMeta{Title:"ChannelAccessToken", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE_CHANNEL_ACCESS_TOKEN", Path:"admin_notify.line.channel_access_token", PathText:"Multi-Push > LINE > ChannelAccessToken", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ChannelAccessToken"}, meta.OptionsMeta{Title:"ChannelSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE_CHANNEL_SECRET", Path:"admin_notify.line.channel_secret", PathText:"Multi-Push > LINE > ChannelSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ChannelSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE_ENABLED", Path:"admin_notify.line.enabled", PathText:"Multi-Push > LINE > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Receivers", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE_RECEIVERS", Path:"admin_notify.line.receivers", PathText:"Multi-Push > LINE > Receivers", Default:[]interface {}{"USER_ID_1", "GROUP_ID_1"}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Receivers"}, meta.OptionsMeta{Title:"Noise mode", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NOISE_MODE", Path:"admin_notify.noise_mode", PathText:"Multi-Push > Noise mode", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Noise mode -- noise_mode is disabled by default. -- -- When this option is set to `false`, only messages sent to the administrator will be notified, -- -- such as \"user A\" replies to \"user B\", the communication between these two users will not be notified to the administrator. --"}, meta.OptionsMeta{Title:"Pending comment still send notification", Desc:"notifications are still sent when comments are intercepted", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NOTIFY_PENDING", Path:"admin_notify.notify_pending", PathText:"Multi-Push > Pending comment still send notification", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Pending comment still send notification (notifications are still sent when comments are intercepted)"}, meta.OptionsMeta{Title:"Notification template", Desc:"set to file path to use custom template", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NOTIFY_TPL", Path:"admin_notify.notify_tpl", PathText:"Multi-Push > Notification template", Default:"default", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Notification template (set to file path to use custom template)"}, meta.OptionsMeta{Title:"Slack", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_SLACK", Path:"admin_notify.slack", PathText:"Multi-Push > Slack", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Slack"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_SLACK_ENABLED", Path:"admin_notify.slack.enabled", PathText:"Multi-Push > Slack > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"OauthToken", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_SLACK_OAUTH_TOKEN", Path:"admin_notify.slack.oauth_token", PathText:"Multi-Push > Slack > OauthToken", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"OauthToken"}, meta.OptionsMeta{Title:"Receivers", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_SLACK_RECEIVERS", Path:"admin_notify.slack.receivers", PathText:"Multi-Push > Slack > Receivers", Default:[]interface {}{"CHANNEL_ID"}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Receivers"}, meta.OptionsMeta{Title:"Telegram", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM", Path:"admin_notify.telegram", PathText:"Multi-Push > Telegram", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Telegram"}, meta.OptionsMeta{Title:"ApiToken", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM_API_TOKEN", Path:"admin_notify.telegram.api_token", PathText:"Multi-Push > Telegram > ApiToken", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ApiToken"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM_ENABLED", Path:"admin_notify.telegram.enabled", PathText:"Multi-Push > Telegram > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Receivers", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM_RECEIVERS", Path:"admin_notify.telegram.receivers", PathText:"Multi-Push > Telegram > Receivers", Default:[]interface {}{0x76adf1}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Receivers"}, meta.OptionsMeta{Title:"WebHook", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WEBHOOK", Path:"admin_notify.webhook", PathText:"Multi-Push > WebHook", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"WebHook"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WEBHOOK_ENABLED", Path:"admin_notify.webhook.enabled", PathText:"Multi-Push > WebHook > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Url", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WEBHOOK_URL", Path:"admin_notify.webhook.url", PathText:"Multi-Push > WebHook > Url", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Url"}, meta.OptionsMeta{Title:"App Key", Desc:"for generation of JWT", Type:"string", Options:[]string(nil), Env:"ATK_APP_KEY", Path:"app_key", PathText:"App Key", Default:"", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"App Key (for generation of JWT)"}, meta.OptionsMeta{Title:"Social Login", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH", Path:"auth", PathText:"Social Login", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Social Login"}, meta.OptionsMeta{Title:"Allow anonymous commenting", Desc:"Allow skipping verification, only fill in an anonymous nickname and email", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_ANONYMOUS", Path:"auth.anonymous", PathText:"Social Login > Allow anonymous commenting", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Allow anonymous commenting (Allow skipping verification, only fill in an anonymous nickname and email)"}, meta.OptionsMeta{Title:"Apple", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_APPLE", Path:"auth.apple", PathText:"Social Login > Apple", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Apple"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_APPLE_CLIENT_ID", Path:"auth.apple.client_id", PathText:"Social Login > Apple > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_APPLE_CLIENT_SECRET", Path:"auth.apple.client_secret", PathText:"Social Login > Apple > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_APPLE_ENABLED", Path:"auth.apple.enabled", PathText:"Social Login > Apple > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Auth0", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_AUTH0", Path:"auth.auth0", PathText:"Social Login > Auth0", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Auth0"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_AUTH0_CLIENT_ID", Path:"auth.auth0.client_id", PathText:"Social Login > Auth0 > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_AUTH0_CLIENT_SECRET", Path:"auth.auth0.client_secret", PathText:"Social Login > Auth0 > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Domain", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_AUTH0_DOMAIN", Path:"auth.auth0.domain", PathText:"Social Login > Auth0 > Domain", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Domain"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_AUTH0_ENABLED", Path:"auth.auth0.enabled", PathText:"Social Login > Auth0 > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Callback URL", Desc:"https://example.com/api/v2/auth/{provider}/callback", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_CALLBACK", Path:"auth.callback", PathText:"Social Login > Callback URL", Default:"http://localhost:23366/api/v2/auth/{provider}/callback", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Callback URL (https://example.com/api/v2/auth/{provider}/callback)"}, meta.OptionsMeta{Title:"Discord", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_DISCORD", Path:"auth.discord", PathText:"Social Login > Discord", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Discord"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_DISCORD_CLIENT_ID", Path:"auth.discord.client_id", PathText:"Social Login > Discord > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_DISCORD_CLIENT_SECRET", Path:"auth.discord.client_secret", PathText:"Social Login > Discord > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_DISCORD_ENABLED", Path:"auth.discord.enabled", PathText:"Social Login > Discord > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Email", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_EMAIL", Path:"auth.email", PathText:"Social Login > Email", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Email"}, meta.OptionsMeta{Title:"Enable email password login", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_EMAIL_ENABLED", Path:"auth.email.enabled", PathText:"Social Login > Email > Enable email password login", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable email password login"}, meta.OptionsMeta{Title:"Verification email subject", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_EMAIL_VERIFY_SUBJECT", Path:"auth.email.verify_subject", PathText:"Social Login > Email > Verification email subject", Default:"Your Code - {{code}}", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Verification email subject"}, meta.OptionsMeta{Title:"Verification email template", Desc:"set to file path to use custom template", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_EMAIL_VERIFY_TPL", Path:"auth.email.verify_tpl", PathText:"Social Login > Email > Verification email template", Default:"default", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Verification email template (set to file path to use custom template)"}, meta.OptionsMeta{Title:"Enable Social Login", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_ENABLED", Path:"auth.enabled", PathText:"Social Login > Enable Social Login", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable Social Login"}, meta.OptionsMeta{Title:"Facebook", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_FACEBOOK", Path:"auth.facebook", PathText:"Social Login > Facebook", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Facebook"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_FACEBOOK_CLIENT_ID", Path:"auth.facebook.client_id", PathText:"Social Login > Facebook > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_FACEBOOK_CLIENT_SECRET", Path:"auth.facebook.client_secret", PathText:"Social Login > Facebook > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_FACEBOOK_ENABLED", Path:"auth.facebook.enabled", PathText:"Social Login > Facebook > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Gitea", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_GITEA", Path:"auth.gitea", PathText:"Social Login > Gitea", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Gitea"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITEA_CLIENT_ID", Path:"auth.gitea.client_id", PathText:"Social Login > Gitea > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITEA_CLIENT_SECRET", Path:"auth.gitea.client_secret", PathText:"Social Login > Gitea > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_GITEA_ENABLED", Path:"auth.gitea.enabled", PathText:"Social Login > Gitea > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"GitHub", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_GITHUB", Path:"auth.github", PathText:"Social Login > GitHub", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"GitHub"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITHUB_CLIENT_ID", Path:"auth.github.client_id", PathText:"Social Login > GitHub > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITHUB_CLIENT_SECRET", Path:"auth.github.client_secret", PathText:"Social Login > GitHub > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_GITHUB_ENABLED", Path:"auth.github.enabled", PathText:"Social Login > GitHub > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"GitLab", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_GITLAB", Path:"auth.gitlab", PathText:"Social Login > GitLab", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"GitLab"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITLAB_CLIENT_ID", Path:"auth.gitlab.client_id", PathText:"Social Login > GitLab > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITLAB_CLIENT_SECRET", Path:"auth.gitlab.client_secret", PathText:"Social Login > GitLab > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_GITLAB_ENABLED", Path:"auth.gitlab.enabled", PathText:"Social Login > GitLab > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Google", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_GOOGLE", Path:"auth.google", PathText:"Social Login > Google", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Google"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GOOGLE_CLIENT_ID", Path:"auth.google.client_id", PathText:"Social Login > Google > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GOOGLE_CLIENT_SECRET", Path:"auth.google.client_secret", PathText:"Social Login > Google > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_GOOGLE_ENABLED", Path:"auth.google.enabled", PathText:"Social Login > Google > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Line", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_LINE", Path:"auth.line", PathText:"Social Login > Line", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Line"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_LINE_CLIENT_ID", Path:"auth.line.client_id", PathText:"Social Login > Line > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_LINE_CLIENT_SECRET", Path:"auth.line.client_secret", PathText:"Social Login > Line > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_LINE_ENABLED", Path:"auth.line.enabled", PathText:"Social Login > Line > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Mastodon", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_MASTODON", Path:"auth.mastodon", PathText:"Social Login > Mastodon", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Mastodon"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_MASTODON_CLIENT_ID", Path:"auth.mastodon.client_id", PathText:"Social Login > Mastodon > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_MASTODON_CLIENT_SECRET", Path:"auth.mastodon.client_secret", PathText:"Social Login > Mastodon > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_MASTODON_ENABLED", Path:"auth.mastodon.enabled", PathText:"Social Login > Mastodon > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Microsoft", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_MICROSOFT", Path:"auth.microsoft", PathText:"Social Login > Microsoft", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Microsoft"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_MICROSOFT_CLIENT_ID", Path:"auth.microsoft.client_id", PathText:"Social Login > Microsoft > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_MICROSOFT_CLIENT_SECRET", Path:"auth.microsoft.client_secret", PathText:"Social Login > Microsoft > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_MICROSOFT_ENABLED", Path:"auth.microsoft.enabled", PathText:"Social Login > Microsoft > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Patreon", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_PATREON", Path:"auth.patreon", PathText:"Social Login > Patreon", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Patreon"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_PATREON_CLIENT_ID", Path:"auth.patreon.client_id", PathText:"Social Login > Patreon > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_PATREON_CLIENT_SECRET", Path:"auth.patreon.client_secret", PathText:"Social Login > Patreon > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_PATREON_ENABLED", Path:"auth.patreon.enabled", PathText:"Social Login > Patreon > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Slack", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_SLACK", Path:"auth.slack", PathText:"Social Login > Slack", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Slack"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_SLACK_CLIENT_ID", Path:"auth.slack.client_id", PathText:"Social Login > Slack > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_SLACK_CLIENT_SECRET", Path:"auth.slack.client_secret", PathText:"Social Login > Slack > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_SLACK_ENABLED", Path:"auth.slack.enabled", PathText:"Social Login > Slack > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Steam", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_STEAM", Path:"auth.steam", PathText:"Social Login > Steam", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Steam"}, meta.OptionsMeta{Title:"ApiKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_STEAM_API_KEY", Path:"auth.steam.api_key", PathText:"Social Login > Steam > ApiKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ApiKey"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_STEAM_ENABLED", Path:"auth.steam.enabled", PathText:"Social Login > Steam > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Tiktok", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_TIKTOK", Path:"auth.tiktok", PathText:"Social Login > Tiktok", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Tiktok"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_TIKTOK_CLIENT_ID", Path:"auth.tiktok.client_id", PathText:"Social Login > Tiktok > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_TIKTOK_CLIENT_SECRET", Path:"auth.tiktok.client_secret", PathText:"Social Login > Tiktok > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_TIKTOK_ENABLED", Path:"auth.tiktok.enabled", PathText:"Social Login > Tiktok > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Twitter", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_TWITTER", Path:"auth.twitter", PathText:"Social Login > Twitter", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Twitter"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_TWITTER_CLIENT_ID", Path:"auth.twitter.client_id", PathText:"Social Login > Twitter > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_TWITTER_CLIENT_SECRET", Path:"auth.twitter.client_secret", PathText:"Social Login > Twitter > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_TWITTER_ENABLED", Path:"auth.twitter.enabled", PathText:"Social Login > Twitter > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"WeChat", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_WECHAT", Path:"auth.wechat", PathText:"Social Login > WeChat", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"WeChat"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_WECHAT_CLIENT_ID", Path:"auth.wechat.client_id", PathText:"Social Login > WeChat > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_WECHAT_CLIENT_SECRET", Path:"auth.wechat.client_secret", PathText:"Social Login > WeChat > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_WECHAT_ENABLED", Path:"auth.wechat.enabled", PathText:"Social Login > WeChat > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Cache", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_CACHE", Path:"cache", PathText:"Cache", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Cache"}, meta.OptionsMeta{Title:"Enable cache", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_CACHE_ENABLED", Path:"cache.enabled", PathText:"Cache > Enable cache", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable cache"}, meta.OptionsMeta{Title:"Cache expiration time", Desc:"in minutes", Type:"uint64", Options:[]string(nil), Env:"ATK_CACHE_EXPIRES", Path:"cache.expires", PathText:"Cache > Cache expiration time", Default:0x1e, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cache expiration time (in minutes)"}, meta.OptionsMeta{Title:"Redis config", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_CACHE_REDIS", Path:"cache.redis", PathText:"Cache > Redis config", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Redis config"}, meta.OptionsMeta{Title:"Redis database number", Desc:"e.g. 0", Type:"uint64", Options:[]string(nil), Env:"ATK_CACHE_REDIS_DB", Path:"cache.redis.db", PathText:"Cache > Redis config > Redis database number", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis database number (e.g. 0)"}, meta.OptionsMeta{Title:"Connection type", Desc:"", Type:"string", Options:[]string{"tcp", "unix"}, Env:"ATK_CACHE_REDIS_NETWORK", Path:"cache.redis.network", PathText:"Cache > Redis config > Connection type", Default:"tcp", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Connection type [\"tcp\", \"unix\"]"}, meta.OptionsMeta{Title:"Redis password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_PASSWORD", Path:"cache.redis.password", PathText:"Cache > Redis config > Redis password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis password"}, meta.OptionsMeta{Title:"Redis username", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_USERNAME", Path:"cache.redis.username", PathText:"Cache > Redis config > Redis username", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis username"}, meta.OptionsMeta{Title:"Cache server address", Desc:"e.g. \"localhost:6379\"", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_SERVER", Path:"cache.server", PathText:"Cache > Cache server address", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"-- The following is not necessary for `builtin` cache -- Cache server address (e.g. \"localhost:6379\")"}, meta.OptionsMeta{Title:"Cache type", Desc:"", Type:"string", Options:[]string{"redis", "memcache", "builtin"}, Env:"ATK_CACHE_TYPE", Path:"cache.type", PathText:"Cache > Cache type", Default:"builtin", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cache type [\"redis\", \"memcache\", \"builtin\"]"}, meta.OptionsMeta{Title:"Cache warm up", Desc:"warm up cache when program starts", Type:"bool", Options:[]string(nil), Env:"ATK_CACHE_WARM_UP", Path:"cache.warm_up", PathText:"Cache > Cache warm up", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cache warm up (warm up cache when program starts)"}, meta.OptionsMeta{Title:"Captcha", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA", Path:"captcha", PathText:"Captcha", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Captcha"}, meta.OptionsMeta{Title:"Action limit", Desc:"the number of actions required to activate captcha", Type:"uint64", Options:[]string(nil), Env:"ATK_CAPTCHA_ACTION_LIMIT", Path:"captcha.action_limit", PathText:"Captcha > Action limit", Default:0x3, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Action limit (the number of actions required to activate captcha)"}, meta.OptionsMeta{Title:"Reset Timeout", Desc:"timeout to reset action counter. unit: s, set to -1 to disable", Type:"uint64", Options:[]string(nil), Env:"ATK_CAPTCHA_ACTION_RESET", Path:"captcha.action_reset", PathText:"Captcha > Reset Timeout", Default:0x3c, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Reset Timeout (timeout to reset action counter. unit: s, set to -1 to disable)"}, meta.OptionsMeta{Title:"Captcha is required always", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_CAPTCHA_ALWAYS", Path:"captcha.always", PathText:"Captcha > Captcha is required always", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Captcha is required always"}, meta.OptionsMeta{Title:"Captcha type", Desc:"", Type:"string", Options:[]string{"image", "turnstile", "recaptcha", "hcaptcha", "geetest"}, Env:"ATK_CAPTCHA_CAPTCHA_TYPE", Path:"captcha.captcha_type", PathText:"Captcha > Captcha type", Default:"image", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Captcha type [\"image\", \"turnstile\", \"recaptcha\", \"hcaptcha\", \"geetest\"]"}, meta.OptionsMeta{Title:"Enable captcha", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_CAPTCHA_ENABLED", Path:"captcha.enabled", PathText:"Captcha > Enable captcha", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable captcha"}, meta.OptionsMeta{Title:"Geetest", Desc:"https://www.geetest.com", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_GEETEST", Path:"captcha.geetest", PathText:"Captcha > Geetest", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Geetest (https://www.geetest.com)"}, meta.OptionsMeta{Title:"CaptchaId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_GEETEST_CAPTCHA_ID", Path:"captcha.geetest.captcha_id", PathText:"Captcha > Geetest > CaptchaId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"CaptchaId"}, meta.OptionsMeta{Title:"CaptchaKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_GEETEST_CAPTCHA_KEY", Path:"captcha.geetest.captcha_key", PathText:"Captcha > Geetest > CaptchaKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"CaptchaKey"}, meta.OptionsMeta{Title:"hCaptcha", Desc:"https://www.hcaptcha.com/", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_HCAPTCHA", Path:"captcha.hcaptcha", PathText:"Captcha > hCaptcha", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"hCaptcha (https://www.hcaptcha.com/)"}, meta.OptionsMeta{Title:"SecretKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_HCAPTCHA_SECRET_KEY", Path:"captcha.hcaptcha.secret_key", PathText:"Captcha > hCaptcha > SecretKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretKey"}, meta.OptionsMeta{Title:"SiteKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_HCAPTCHA_SITE_KEY", Path:"captcha.hcaptcha.site_key", PathText:"Captcha > hCaptcha > SiteKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SiteKey"}, meta.OptionsMeta{Title:"reCaptcha", Desc:"https://www.google.com/recaptcha/about/", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_RECAPTCHA", Path:"captcha.recaptcha", PathText:"Captcha > reCaptcha", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"reCaptcha (https://www.google.com/recaptcha/about/)"}, meta.OptionsMeta{Title:"SecretKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_RECAPTCHA_SECRET_KEY", Path:"captcha.recaptcha.secret_key", PathText:"Captcha > reCaptcha > SecretKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretKey"}, meta.OptionsMeta{Title:"SiteKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_RECAPTCHA_SITE_KEY", Path:"captcha.recaptcha.site_key", PathText:"Captcha > reCaptcha > SiteKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SiteKey"}, meta.OptionsMeta{Title:"Turnstile", Desc:"https://www.cloudflare.com/products/turnstile/", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_TURNSTILE", Path:"captcha.turnstile", PathText:"Captcha > Turnstile", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Turnstile (https://www.cloudflare.com/products/turnstile/)"}, meta.OptionsMeta{Title:"SecretKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_TURNSTILE_SECRET_KEY", Path:"captcha.turnstile.secret_key", PathText:"Captcha > Turnstile > SecretKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretKey"}, meta.OptionsMeta{Title:"SiteKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_TURNSTILE_SITE_KEY", Path:"captcha.turnstile.site_key", PathText:"Captcha > Turnstile > SiteKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SiteKey"}, meta.OptionsMeta{Title:"Database", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_DB", Path:"db", PathText:"Database", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Database"}, meta.OptionsMeta{Title:"Database charset", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_CHARSET", Path:"db.charset", PathText:"Database > Database charset", Default:"utf8mb4", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database charset"}, meta.OptionsMeta{Title:"Database file", Desc:"only for SQLite", Type:"string", Options:[]string(nil), Env:"ATK_DB_FILE", Path:"db.file", PathText:"Database > Database file", Default:"./data/artalk.db", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database file (only for SQLite)"}, meta.OptionsMeta{Title:"Host address", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_HOST", Path:"db.host", PathText:"Database > Host address", Default:"localhost", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Host address"}, meta.OptionsMeta{Title:"Database name", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_NAME", Path:"db.name", PathText:"Database > Database name", Default:"artalk", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"-- The following is not necessary for SQLite -- Database name"}, meta.OptionsMeta{Title:"Database password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_PASSWORD", Path:"db.password", PathText:"Database > Database password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database password"}, meta.OptionsMeta{Title:"Host port", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_DB_PORT", Path:"db.port", PathText:"Database > Host port", Default:0xcea, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Host port"}, meta.OptionsMeta{Title:"Prepared Statement", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_DB_PREPARE_STMT", Path:"db.prepare_stmt", PathText:"Database > Prepared Statement", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Prepared Statement"}, meta.OptionsMeta{Title:"Enable SSL mode", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_DB_SSL", Path:"db.ssl", PathText:"Database > Enable SSL mode", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable SSL mode"}, meta.OptionsMeta{Title:"Table prefix", Desc:"e.g. \"atk_\"", Type:"string", Options:[]string(nil), Env:"ATK_DB_TABLE_PREFIX", Path:"db.table_prefix", PathText:"Database > Table prefix", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Table prefix (e.g. \"atk_\")"}, meta.OptionsMeta{Title:"Database type", Desc:"", Type:"string", Options:[]string{"sqlite", "mysql", "pgsql", "mssql"}, Env:"ATK_DB_TYPE", Path:"db.type", PathText:"Database > Database type", Default:"sqlite", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database type [\"sqlite\", \"mysql\", \"pgsql\", \"mssql\"]"}, meta.OptionsMeta{Title:"Database user", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_USER", Path:"db.user", PathText:"Database > Database user", Default:"root", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database user"}, meta.OptionsMeta{Title:"Debug mode", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_DEBUG", Path:"debug", PathText:"Debug mode", Default:false, IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Debug mode"}, meta.OptionsMeta{Title:"Email", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL", Path:"email", PathText:"Email", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Email"}, meta.OptionsMeta{Title:"Aliyun mail push", Desc:"set send method to \"ali_dm\" to enable; see: https://help.aliyun.com/document_detail/29444.html", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_ALI_DM", Path:"email.ali_dm", PathText:"Email > Aliyun mail push", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Aliyun mail push (set send method to \"ali_dm\" to enable; see: https://help.aliyun.com/document_detail/29444.html)"}, meta.OptionsMeta{Title:"AccessKeyId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_ALI_DM_ACCESS_KEY_ID", Path:"email.ali_dm.access_key_id", PathText:"Email > Aliyun mail push > AccessKeyId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeyId"}, meta.OptionsMeta{Title:"AccessKeySecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_ALI_DM_ACCESS_KEY_SECRET", Path:"email.ali_dm.access_key_secret", PathText:"Email > Aliyun mail push > AccessKeySecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeySecret"}, meta.OptionsMeta{Title:"AccountName", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_ALI_DM_ACCOUNT_NAME", Path:"email.ali_dm.account_name", PathText:"Email > Aliyun mail push > AccountName", Default:"noreply@example.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccountName"}, meta.OptionsMeta{Title:"Enable email notification", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_EMAIL_ENABLED", Path:"email.enabled", PathText:"Email > Enable email notification", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable email notification"}, meta.OptionsMeta{Title:"Email subject", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_MAIL_SUBJECT", Path:"email.mail_subject", PathText:"Email > Email subject", Default:"[{{site_name}}] You got a reply from @{{reply_nick}}", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email subject"}, meta.OptionsMeta{Title:"Email template file", Desc:"set to file path to use custom template", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_MAIL_TPL", Path:"email.mail_tpl", PathText:"Email > Email template file", Default:"default", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email template file (set to file path to use custom template)"}, meta.OptionsMeta{Title:"Email address of sender", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SEND_ADDR", Path:"email.send_addr", PathText:"Email > Email address of sender", Default:"noreply@example.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email address of sender"}, meta.OptionsMeta{Title:"Nick name of sender", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SEND_NAME", Path:"email.send_name", PathText:"Email > Nick name of sender", Default:"{{reply_nick}}", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Nick name of sender"}, meta.OptionsMeta{Title:"Send method", Desc:"", Type:"string", Options:[]string{"smtp", "ali_dm", "sendmail"}, Env:"ATK_EMAIL_SEND_TYPE", Path:"email.send_type", PathText:"Email > Send method", Default:"smtp", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Send method [\"smtp\", \"ali_dm\", \"sendmail\"]"}, meta.OptionsMeta{Title:"SMTP send", Desc:"set send method to \"smtp\" to enable", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_SMTP", Path:"email.smtp", PathText:"Email > SMTP send", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"SMTP send (set send method to \"smtp\" to enable)"}, meta.OptionsMeta{Title:"Email address of sender", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SMTP_HOST", Path:"email.smtp.host", PathText:"Email > SMTP send > Email address of sender", Default:"smtp.qq.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email address of sender"}, meta.OptionsMeta{Title:"Password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SMTP_PASSWORD", Path:"email.smtp.password", PathText:"Email > SMTP send > Password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Password"}, meta.OptionsMeta{Title:"Email port", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_EMAIL_SMTP_PORT", Path:"email.smtp.port", PathText:"Email > SMTP send > Email port", Default:0x24b, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email port"}, meta.OptionsMeta{Title:"Email address of sender", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SMTP_USERNAME", Path:"email.smtp.username", PathText:"Email > SMTP send > Email address of sender", Default:"example@qq.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email address of sender"}, meta.OptionsMeta{Title:"UI Settings", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_FRONTEND", Path:"frontend", PathText:"UI Settings", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"UI Settings"}, meta.OptionsMeta{Title:"Dark mode", Desc:"", Type:"string", Options:[]string{"inherit", "auto"}, Env:"ATK_FRONTEND_DARKMODE", Path:"frontend.darkMode", PathText:"UI Settings > Dark mode", Default:"inherit", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Dark mode [\"inherit\", \"auto\"]"}, meta.OptionsMeta{Title:"Movable comment box", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_EDITORTRAVEL", Path:"frontend.editorTravel", PathText:"UI Settings > Movable comment box", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Movable comment box"}, meta.OptionsMeta{Title:"Emoticons", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_EMOTICONS", Path:"frontend.emoticons", PathText:"UI Settings > Emoticons", Default:"https://cdn.jsdelivr.net/gh/ArtalkJS/Emoticons/grps/default.json", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Emoticons"}, meta.OptionsMeta{Title:"Flatten mode", Desc:"", Type:"string", Options:[]string{"auto", "true", "false"}, Env:"ATK_FRONTEND_FLATMODE", Path:"frontend.flatMode", PathText:"UI Settings > Flatten mode", Default:"auto", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Flatten mode [\"auto\", true, false]"}, meta.OptionsMeta{Title:"Gravatar", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_FRONTEND_GRAVATAR", Path:"frontend.gravatar", PathText:"UI Settings > Gravatar", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Gravatar"}, meta.OptionsMeta{Title:"API URL", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_GRAVATAR_MIRROR", Path:"frontend.gravatar.mirror", PathText:"UI Settings > Gravatar > API URL", Default:"https://www.gravatar.com/avatar/", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API URL"}, meta.OptionsMeta{Title:"API parameters", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_GRAVATAR_PARAMS", Path:"frontend.gravatar.params", PathText:"UI Settings > Gravatar > API parameters", Default:"sha256=1&d=mp&s=240", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API parameters"}, meta.OptionsMeta{Title:"Content height limit", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_FRONTEND_HEIGHTLIMIT", Path:"frontend.heightLimit", PathText:"UI Settings > Content height limit", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Content height limit"}, meta.OptionsMeta{Title:"Sub-comment area height limit", Desc:"unit: px", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_HEIGHTLIMIT_CHILDREN", Path:"frontend.heightLimit.children", PathText:"UI Settings > Content height limit > Sub-comment area height limit", Default:0x190, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Sub-comment area height limit (unit: px)"}, meta.OptionsMeta{Title:"Comment content height limit", Desc:"unit: px", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_HEIGHTLIMIT_CONTENT", Path:"frontend.heightLimit.content", PathText:"UI Settings > Content height limit > Comment content height limit", Default:0x12c, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Comment content height limit (unit: px)"}, meta.OptionsMeta{Title:"Scrollable", Desc:"scrollable height limit area", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_HEIGHTLIMIT_SCROLLABLE", Path:"frontend.heightLimit.scrollable", PathText:"UI Settings > Content height limit > Scrollable", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Scrollable (scrollable height limit area)"}, meta.OptionsMeta{Title:"Image lazy load", Desc:"", Type:"bool", Options:[]string{"false", "native", "data-src"}, Env:"ATK_FRONTEND_IMGLAZYLOAD", Path:"frontend.imgLazyLoad", PathText:"UI Settings > Image lazy load", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image lazy load [false, \"native\", \"data-src\"]"}, meta.OptionsMeta{Title:"Comment sorting", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_LISTSORT", Path:"frontend.listSort", PathText:"UI Settings > Comment sorting", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Comment sorting"}, meta.OptionsMeta{Title:"Maximum nesting level", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_NESTMAX", Path:"frontend.nestMax", PathText:"UI Settings > Maximum nesting level", Default:0x2, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Maximum nesting level"}, meta.OptionsMeta{Title:"Nesting comment sorting rules", Desc:"", Type:"string", Options:[]string{"DATE_ASC", "DATE_DESC", "VOTE_UP_DESC"}, Env:"ATK_FRONTEND_NESTSORT", Path:"frontend.nestSort", PathText:"UI Settings > Nesting comment sorting rules", Default:"DATE_ASC", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Nesting comment sorting rules [\"DATE_ASC\", \"DATE_DESC\", \"VOTE_UP_DESC\"]"}, meta.OptionsMeta{Title:"Text to display when there is", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_NOCOMMENT", Path:"frontend.noComment", PathText:"UI Settings > Text to display when there is", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Text to display when there is"}, meta.OptionsMeta{Title:"Comment pagination", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_FRONTEND_PAGINATION", Path:"frontend.pagination", PathText:"UI Settings > Comment pagination", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Comment pagination"}, meta.OptionsMeta{Title:"Scroll loading", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_PAGINATION_AUTOLOAD", Path:"frontend.pagination.autoLoad", PathText:"UI Settings > Comment pagination > Scroll loading", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Scroll loading"}, meta.OptionsMeta{Title:"Number of comments per page", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_PAGINATION_PAGESIZE", Path:"frontend.pagination.pageSize", PathText:"UI Settings > Comment pagination > Number of comments per page", Default:0x14, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Number of comments per page"}, meta.OptionsMeta{Title:"Load more mode", Desc:"disabled to use pagination bar", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_PAGINATION_READMORE", Path:"frontend.pagination.readMore", PathText:"UI Settings > Comment pagination > Load more mode", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Load more mode (disabled to use pagination bar)"}, meta.OptionsMeta{Title:"Comment box placeholder", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_PLACEHOLDER", Path:"frontend.placeholder", PathText:"UI Settings > Comment box placeholder", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Comment box placeholder"}, meta.OptionsMeta{Title:"Plugins", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_FRONTEND_PLUGINURLS", Path:"frontend.pluginURLs", PathText:"UI Settings > Plugins", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Plugins"}, meta.OptionsMeta{Title:"Editor real-time preview", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_PREVIEW", Path:"frontend.preview", PathText:"UI Settings > Editor real-time preview", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Editor real-time preview"}, meta.OptionsMeta{Title:"Request timeout", Desc:"unit: ms", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_REQTIMEOUT", Path:"frontend.reqTimeout", PathText:"UI Settings > Request timeout", Default:0x3a98, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Request timeout (unit: ms)"}, meta.OptionsMeta{Title:"Text of the send button", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_SENDBTN", Path:"frontend.sendBtn", PathText:"UI Settings > Text of the send button", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Text of the send button"}, meta.OptionsMeta{Title:"User UA badge", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_UABADGE", Path:"frontend.uaBadge", PathText:"UI Settings > User UA badge", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"User UA badge"}, meta.OptionsMeta{Title:"Version check", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_VERSIONCHECK", Path:"frontend.versionCheck", PathText:"UI Settings > Version check", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Version check"}, meta.OptionsMeta{Title:"Vote button", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_VOTE", Path:"frontend.vote", PathText:"UI Settings > Vote button", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Vote button"}, meta.OptionsMeta{Title:"Dislike button", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_VOTEDOWN", Path:"frontend.voteDown", PathText:"UI Settings > Dislike button", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Dislike button"}, meta.OptionsMeta{Title:"Listen host", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_HOST", Path:"host", PathText:"Listen host", Default:"0.0.0.0", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Listen host"}, meta.OptionsMeta{Title:"Web server", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_HTTP", Path:"http", PathText:"Web server", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Web server"}, meta.OptionsMeta{Title:"Body size limit", Desc:"unit: MB", Type:"uint64", Options:[]string(nil), Env:"ATK_HTTP_BODY_LIMIT", Path:"http.body_limit", PathText:"Web server > Body size limit", Default:0x64, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Body size limit (unit: MB)"}, meta.OptionsMeta{Title:"Proxy Header", Desc:"fill `X-Forwarded-For` to get user real IP if behind a trusted reverse proxy or CDN", Type:"string", Options:[]string(nil), Env:"ATK_HTTP_PROXY_HEADER", Path:"http.proxy_header", PathText:"Web server > Proxy Header", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Proxy Header (fill `X-Forwarded-For` to get user real IP if behind a trusted reverse proxy or CDN)"}, meta.OptionsMeta{Title:"Upload", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD", Path:"img_upload", PathText:"Upload", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Upload"}, meta.OptionsMeta{Title:"Enable image upload", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_ENABLED", Path:"img_upload.enabled", PathText:"Upload > Enable image upload", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable image upload"}, meta.OptionsMeta{Title:"Image size limit", Desc:"unit: MB", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_MAX_SIZE", Path:"img_upload.max_size", PathText:"Upload > Image size limit", Default:0x5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image size limit (unit: MB)"}, meta.OptionsMeta{Title:"Image storage", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PATH", Path:"img_upload.path", PathText:"Upload > Image storage", Default:"./data/artalk-img/", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image storage"}, meta.OptionsMeta{Title:"Image link base path", Desc:"default: \"/static/images/\"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PUBLIC_PATH", Path:"img_upload.public_path", PathText:"Upload > Image link base path", Default:interface {}(nil), IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image link base path (default: \"/static/images/\")"}, meta.OptionsMeta{Title:"Upgit config", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_UPGIT", Path:"img_upload.upgit", PathText:"Upload > Upgit config", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Upgit config"}, meta.OptionsMeta{Title:"Delete local image after upload success", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_UPGIT_DEL_LOCAL", Path:"img_upload.upgit.del_local", PathText:"Upload > Upgit config > Delete local image after upload success", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Delete local image after upload success"}, meta.OptionsMeta{Title:"Enable Upgit", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_UPGIT_ENABLED", Path:"img_upload.upgit.enabled", PathText:"Upload > Upgit config > Enable Upgit", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable Upgit"}, meta.OptionsMeta{Title:"Command line arguments", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_UPGIT_EXEC", Path:"img_upload.upgit.exec", PathText:"Upload > Upgit config > Command line arguments", Default:"upgit -c UPGIT_CONF_FILE_PATH -t /artalk-img", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Command line arguments"}, meta.OptionsMeta{Title:"Language", Desc:"follow Unicode BCP 47", Type:"string", Options:[]string{"en", "zh-CN", "zh-TW", "ja", "fr", "ko", "ru"}, Env:"ATK_LOCALE", Path:"locale", PathText:"Language", Default:"en", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Language (follow Unicode BCP 47) [\"en\", \"zh-CN\", \"zh-TW\", \"ja\", \"fr\", \"ko\", \"ru\"] -- see https://www.techonthenet.com/js/language_tags.php --"}, meta.OptionsMeta{Title:"Logging", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_LOG", Path:"log", PathText:"Logging", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Logging"}, meta.OptionsMeta{Title:"Enable logging", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_LOG_ENABLED", Path:"log.enabled", PathText:"Logging > Enable logging", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable logging"}, meta.OptionsMeta{Title:"Log file path", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_LOG_FILENAME", Path:"log.filename", PathText:"Logging > Log file path", Default:"./data/artalk.log", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Log file path"}, meta.OptionsMeta{Title:"Login timeout", Desc:"in seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_LOGIN_TIMEOUT", Path:"login_timeout", PathText:"Login timeout", Default:0x3f480, IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Login timeout (in seconds)"}, meta.OptionsMeta{Title:"Moderator", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR", Path:"moderator", PathText:"Moderator", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Moderator -- Comment examination before being public --"}, meta.OptionsMeta{Title:"Akismet Key", Desc:"Akismet anti-spam service, https://akismet.com", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_AKISMET_KEY", Path:"moderator.akismet_key", PathText:"Moderator > Akismet Key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Akismet Key (Akismet anti-spam service, https://akismet.com)"}, meta.OptionsMeta{Title:"Aliyun Content Security", Desc:"Auto review comments with Aliyun Content Security", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN", Path:"moderator.aliyun", PathText:"Moderator > Aliyun Content Security", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Aliyun Content Security (Auto review comments with Aliyun Content Security) -- see https://help.aliyun.com/document_detail/28417.html --"}, meta.OptionsMeta{Title:"AccessKeyId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN_ACCESS_KEY_ID", Path:"moderator.aliyun.access_key_id", PathText:"Moderator > Aliyun Content Security > AccessKeyId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeyId"}, meta.OptionsMeta{Title:"AccessKeySecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN_ACCESS_KEY_SECRET", Path:"moderator.aliyun.access_key_secret", PathText:"Moderator > Aliyun Content Security > AccessKeySecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeySecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN_ENABLED", Path:"moderator.aliyun.enabled", PathText:"Moderator > Aliyun Content Security > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Region", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN_REGION", Path:"moderator.aliyun.region", PathText:"Moderator > Aliyun Content Security > Region", Default:"cn-shanghai", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Region"}, meta.OptionsMeta{Title:"Block when API request fails", Desc:"set to false to let comments pass when API request fails", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_API_FAIL_BLOCK", Path:"moderator.api_fail_block", PathText:"Moderator > Block when API request fails", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Block when API request fails (set to false to let comments pass when API request fails)"}, meta.OptionsMeta{Title:"Keyword filter", Desc:"local offline dictionary", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS", Path:"moderator.keywords", PathText:"Moderator > Keyword filter", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Keyword filter (local offline dictionary)"}, meta.OptionsMeta{Title:"Enable keyword filter", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_ENABLED", Path:"moderator.keywords.enabled", PathText:"Moderator > Keyword filter > Enable keyword filter", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable keyword filter"}, meta.OptionsMeta{Title:"FileSep", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_FILE_SEP", Path:"moderator.keywords.file_sep", PathText:"Moderator > Keyword filter > FileSep", Default:"\n", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"FileSep"}, meta.OptionsMeta{Title:"Dictionary file", Desc:"support multiple dictionary files", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_FILES", Path:"moderator.keywords.files", PathText:"Moderator > Keyword filter > Dictionary file", Default:[]interface {}{"./data/keywords_1.txt"}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Dictionary file (support multiple dictionary files)"}, meta.OptionsMeta{Title:"Set to pending when match", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_PENDING", Path:"moderator.keywords.pending", PathText:"Moderator > Keyword filter > Set to pending when match", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Set to pending when match"}, meta.OptionsMeta{Title:"ReplaceTo", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_REPLACE_TO", Path:"moderator.keywords.replace_to", PathText:"Moderator > Keyword filter > ReplaceTo", Default:"x", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ReplaceTo"}, meta.OptionsMeta{Title:"Default pending", Desc:"new comments need to be approved by admin", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_PENDING_DEFAULT", Path:"moderator.pending_default", PathText:"Moderator > Default pending", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Default pending (new comments need to be approved by admin)"}, meta.OptionsMeta{Title:"Tencent Cloud Content Security", Desc:"Auto review comments with Tencent Cloud Content Security", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT", Path:"moderator.tencent", PathText:"Moderator > Tencent Cloud Content Security", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Tencent Cloud Content Security (Auto review comments with Tencent Cloud Content Security) -- see https://cloud.tencent.com/document/product/1124/64508 --"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT_ENABLED", Path:"moderator.tencent.enabled", PathText:"Moderator > Tencent Cloud Content Security > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Region", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT_REGION", Path:"moderator.tencent.region", PathText:"Moderator > Tencent Cloud Content Security > Region", Default:"ap-guangzhou", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Region"}, meta.OptionsMeta{Title:"SecretId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT_SECRET_ID", Path:"moderator.tencent.secret_id", PathText:"Moderator > Tencent Cloud Content Security > SecretId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretId"}, meta.OptionsMeta{Title:"SecretKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT_SECRET_KEY", Path:"moderator.tencent.secret_key", PathText:"Moderator > Tencent Cloud Content Security > SecretKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretKey"}, meta.OptionsMeta{Title:"Listen port", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_PORT", Path:"port", PathText:"Listen port", Default:0x5b46, IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Listen port"}, meta.OptionsMeta{Title:"Default site name", Desc:"create when app is first launched", Type:"string", Options:[]string(nil), Env:"ATK_SITE_DEFAULT", Path:"site_default", PathText:"Default site name", Default:"Default Site", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Default site name (create when app is first launched)"}, meta.OptionsMeta{Title:"Default site url", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_SITE_URL", Path:"site_url", PathText:"Default site url", Default:"", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Default site url"}, meta.OptionsMeta{Title:"SSL", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_SSL", Path:"ssl", PathText:"SSL", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"SSL"}, meta.OptionsMeta{Title:"Certificate file path", Desc:"e.g. \"/etc/letsencrypt/live/example.com/fullchain.pem\"", Type:"string", Options:[]string(nil), Env:"ATK_SSL_CERT_PATH", Path:"ssl.cert_path", PathText:"SSL > Certificate file path", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Certificate file path (e.g. \"/etc/letsencrypt/live/example.com/fullchain.pem\")"}, meta.OptionsMeta{Title:"Enable SSL", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_SSL_ENABLED", Path:"ssl.enabled", PathText:"SSL > Enable SSL", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable SSL"}, meta.OptionsMeta{Title:"Key file path", Desc:"e.g. \"/etc/letsencrypt/live/example.com/privkey.pem\"", Type:"string", Options:[]string(nil), Env:"ATK_SSL_KEY_PATH", Path:"ssl.key_path", PathText:"SSL > Key file path", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Key file path (e.g. \"/etc/letsencrypt/live/example.com/privkey.pem\")"}, meta.OptionsMeta{Title:"Timezone", Desc:"follow IANA Time Zone Database", Type:"string", Options:[]string(nil), Env:"ATK_TIMEZONE", Path:"timezone", PathText:"Timezone", Default:"Asia/Shanghai", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Timezone (follow IANA Time Zone Database) -- see https://en.wikipedia.org/wiki/List_of_tz_database_time_zones --"}, meta.OptionsMeta{Title:"Trusted domains", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_TRUSTED_DOMAINS", Path:"trusted_domains", PathText:"Trusted domains", Default:[]interface {}{}, IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Trusted domains -- e.g. [\"https://artalk.example.com:23366\"] add url of your site her --"}}
+var OptionsMetaCache = []meta.OptionsMeta{meta.OptionsMeta{Title:"Admin login by LDAP / Active Directory", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_LDAP", Path:"admin_ldap", PathText:"Admin login by LDAP / Active Directory", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Admin login by LDAP / Active Directory"}, meta.OptionsMeta{Title:"The base DN to search users", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_BASE_DN", Path:"admin_ldap.base_dn", PathText:"Admin login by LDAP / Active Directory > The base DN to search users", Default:"dc=example,dc=com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The base DN to search users"}, meta.OptionsMeta{Title:"The account to search users, leave empty for anonymous search", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_BIND_DN", Path:"admin_ldap.bind_dn", PathText:"Admin login by LDAP / Active Directory > The account to search users, leave empty for anonymous search", Default:interface {}(nil), IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The account to search users, leave empty for anonymous search"}, meta.OptionsMeta{Title:"The password of the account to search users", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_BIND_PASSWORD", Path:"admin_ldap.bind_password", PathText:"Admin login by LDAP / Active Directory > The password of the account to search users", Default:interface {}(nil), IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The password of the account to search users"}, meta.OptionsMeta{Title:"The attribute of email", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_EMAIL_ATTR", Path:"admin_ldap.email_attr", PathText:"Admin login by LDAP / Active Directory > The attribute of email", Default:"mail", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The attribute of email"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_ENABLED", Path:"admin_ldap.enabled", PathText:"Admin login by LDAP / Active Directory > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"The attribute of the groups which the user belongs to", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_GROUP_ATTR", Path:"admin_ldap.group_attr", PathText:"Admin login by LDAP / Active Directory > The attribute of the groups which the user belongs to", Default:"memberOf", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The attribute of the groups which the user belongs to"}, meta.OptionsMeta{Title:"The groups mapped to the admin roles, users not in any group can not login", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_GROUPS", Path:"admin_ldap.groups", PathText:"Admin login by LDAP / Active Directory > The groups mapped to the admin roles, users not in any group can not login", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The groups mapped to the admin roles, users not in any group can not login"}, meta.OptionsMeta{Title:"Skip the certificate verification", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_INSECURE_SKIP_VERIFY", Path:"admin_ldap.insecure_skip_verify", PathText:"Admin login by LDAP / Active Directory > Skip the certificate verification", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Skip the certificate verification"}, meta.OptionsMeta{Title:"The attribute of username", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_NAME_ATTR", Path:"admin_ldap.name_attr", PathText:"Admin login by LDAP / Active Directory > The attribute of username", Default:"cn", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The attribute of username"}, meta.OptionsMeta{Title:"Upgrade the connection by StartTLS", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_START_TLS", Path:"admin_ldap.start_tls", PathText:"Admin login by LDAP / Active Directory > Upgrade the connection by StartTLS", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Upgrade the connection by StartTLS"}, meta.OptionsMeta{Title:"The server URL", Desc:"ldap:// or ldaps://", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_URL", Path:"admin_ldap.url", PathText:"Admin login by LDAP / Active Directory > The server URL", Default:"ldap://localhost:389", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The server URL (ldap:// or ldaps://)"}, meta.OptionsMeta{Title:"The filter to search users, {{username}} is replaced by the login name", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_LDAP_USER_FILTER", Path:"admin_ldap.user_filter", PathText:"Admin login by LDAP / Active Directory > The filter to search users, {{username}} is replaced by the login name", Default:"(&(objectClass=person)(|(uid={{username}})(mail={{username}})))", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The filter to search users, {{username}} is replaced by the login name"}, meta.OptionsMeta{Title:"Multi-Push", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY", Path:"admin_notify", PathText:"Multi-Push", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Multi-Push"}, meta.OptionsMeta{Title:"Bark", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_BARK", Path:"admin_notify.bark", PathText:"Multi-Push > Bark", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Bark"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_BARK_ENABLED", Path:"admin_notify.bark.enabled", PathText:"Multi-Push > Bark > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Server", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_BARK_SERVER", Path:"admin_notify.bark.server", PathText:"Multi-Push > Bark > Server", Default:"http://day.app/xxxxxxx/", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Server"}, meta.OptionsMeta{Title:"DingTalk", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DING_TALK", Path:"admin_notify.ding_talk", PathText:"Multi-Push > DingTalk", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"DingTalk"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DING_TALK_ENABLED", Path:"admin_notify.ding_talk.enabled", PathText:"Multi-Push > DingTalk > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Users to mention", Desc:"mobile number or userId, \"all\" for everyone", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DING_TALK_MENTIONS", Path:"admin_notify.ding_talk.mentions", PathText:"Multi-Push > DingTalk > Users to mention", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Users to mention (mobile number or userId, \"all\" for everyone)"}, meta.OptionsMeta{Title:"Message type", Desc:"", Type:"string", Options:[]string{"text", "card"}, Env:"ATK_ADMIN_NOTIFY_DING_TALK_MSG_TYPE", Path:"admin_notify.ding_talk.msg_type", PathText:"Multi-Push > DingTalk > Message type", Default:"text", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Message type [\"text\", \"card\"]"}, meta.OptionsMeta{Title:"Secret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DING_TALK_SECRET", Path:"admin_notify.ding_talk.secret", PathText:"Multi-Push > DingTalk > Secret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Secret"}, meta.OptionsMeta{Title:"Token", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DING_TALK_TOKEN", Path:"admin_notify.ding_talk.token", PathText:"Multi-Push > DingTalk > Token", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Token"}, meta.OptionsMeta{Title:"Discord", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DISCORD", Path:"admin_notify.discord", PathText:"Multi-Push > Discord", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Discord"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DISCORD_ENABLED", Path:"admin_notify.discord.enabled", PathText:"Multi-Push > Discord > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Push the comments of the sites to different channels  e.g.", Desc:"the other sites are pushed to webhook_url", Type:"[]interface {}", Options:[]string{"{ site: \"Site A", "webhook_url: \"https://discord.com/api/webhooks/...\" }"}, Env:"ATK_ADMIN_NOTIFY_DISCORD_SITES", Path:"admin_notify.discord.sites", PathText:"Multi-Push > Discord > Push the comments of the sites to different channels  e.g.", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Push the comments of the sites to different channels (the other sites are pushed to webhook_url) e.g. [{ site: \"Site A\", webhook_url: \"https://discord.com/api/webhooks/...\" }]"}, meta.OptionsMeta{Title:"The webhook URL of channel", Desc:"Server Settings > Integrations > Webhooks", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_DISCORD_WEBHOOK_URL", Path:"admin_notify.discord.webhook_url", PathText:"Multi-Push > Discord > The webhook URL of channel", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The webhook URL of channel (Server Settings > Integrations > Webhooks)"}, meta.OptionsMeta{Title:"Notify admin", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_EMAIL", Path:"admin_notify.email", PathText:"Multi-Push > Notify admin", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Notify admin"}, meta.OptionsMeta{Title:"Enable", Desc:"can be disabled when using other push methods", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_EMAIL_ENABLED", Path:"admin_notify.email.enabled", PathText:"Multi-Push > Notify admin > Enable", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable (can be disabled when using other push methods)"}, meta.OptionsMeta{Title:"Email subject", Desc:"email subject sent to admin", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_EMAIL_MAIL_SUBJECT", Path:"admin_notify.email.mail_subject", PathText:"Multi-Push > Notify admin > Email subject", Default:"[{{site_name}}] Post \"{{page_title}}\" has new a comment", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email subject (email subject sent to admin)"}, meta.OptionsMeta{Title:"Admin email template file", Desc:"set to file path to use custom template", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_EMAIL_MAIL_TPL", Path:"admin_notify.email.mail_tpl", PathText:"Multi-Push > Notify admin > Admin email template file", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Admin email template file (set to file path to use custom template)"}, meta.OptionsMeta{Title:"Gotify", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_GOTIFY", Path:"admin_notify.gotify", PathText:"Multi-Push > Gotify", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Gotify"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_GOTIFY_ENABLED", Path:"admin_notify.gotify.enabled", PathText:"Multi-Push > Gotify > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Server", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_GOTIFY_SERVER", Path:"admin_notify.gotify.server", PathText:"Multi-Push > Gotify > Server", Default:"https://gotify.example.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Server"}, meta.OptionsMeta{Title:"Application token", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_GOTIFY_TOKEN", Path:"admin_notify.gotify.token", PathText:"Multi-Push > Gotify > Application token", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Application token"}, meta.OptionsMeta{Title:"Lark", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LARK", Path:"admin_notify.lark", PathText:"Multi-Push > Lark", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Lark"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LARK_ENABLED", Path:"admin_notify.lark.enabled", PathText:"Multi-Push > Lark > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Users to mention", Desc:"open_id, \"all\" for everyone", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LARK_MENTIONS", Path:"admin_notify.lark.mentions", PathText:"Multi-Push > Lark > Users to mention", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Users to mention (open_id, \"all\" for everyone)"}, meta.OptionsMeta{Title:"Message type", Desc:"", Type:"string", Options:[]string{"text", "card"}, Env:"ATK_ADMIN_NOTIFY_LARK_MSG_TYPE", Path:"admin_notify.lark.msg_type", PathText:"Multi-Push > Lark > Message type", Default:"text", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Message type [\"text\", \"card\"]"}, meta.OptionsMeta{Title:"WebhookUrl", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LARK_WEBHOOK_URL", Path:"admin_notify.lark.webhook_url", PathText:"Multi-Push > Lark > WebhookUrl", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"WebhookUrl"}, meta.OptionsMeta{Title:"LINE", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE", Path:"admin_notify.line", PathText:"Multi-Push > LINE", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"LINE"}, meta.OptionsMeta{Title:"ChannelAccessToken", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE_CHANNEL_ACCESS_TOKEN", Path:"admin_notify.line.channel_access_token", PathText:"Multi-Push > LINE > ChannelAccessToken", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ChannelAccessToken"}, meta.OptionsMeta{Title:"ChannelSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE_CHANNEL_SECRET", Path:"admin_notify.line.channel_secret", PathText:"Multi-Push > LINE > ChannelSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ChannelSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE_ENABLED", Path:"admin_notify.line.enabled", PathText:"Multi-Push > LINE > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Receivers", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_LINE_RECEIVERS", Path:"admin_notify.line.receivers", PathText:"Multi-Push > LINE > Receivers", Default:[]interface {}{"USER_ID_1", "GROUP_ID_1"}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Receivers"}, meta.OptionsMeta{Title:"Matrix", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_MATRIX", Path:"admin_notify.matrix", PathText:"Multi-Push > Matrix", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Matrix"}, meta.OptionsMeta{Title:"Access token of the bot account", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_MATRIX_ACCESS_TOKEN", Path:"admin_notify.matrix.access_token", PathText:"Multi-Push > Matrix > Access token of the bot account", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Access token of the bot account"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_MATRIX_ENABLED", Path:"admin_notify.matrix.enabled", PathText:"Multi-Push > Matrix > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Homeserver URL", Desc:"e.g. https://matrix.org", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_MATRIX_HOMESERVER", Path:"admin_notify.matrix.homeserver", PathText:"Multi-Push > Matrix > Homeserver URL", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Homeserver URL (e.g. https://matrix.org)"}, meta.OptionsMeta{Title:"Room ID", Desc:"e.g. !xxxx:matrix.org, the end-to-end encrypted room is not supported", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_MATRIX_ROOM_ID", Path:"admin_notify.matrix.room_id", PathText:"Multi-Push > Matrix > Room ID", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Room ID (e.g. !xxxx:matrix.org, the end-to-end encrypted room is not supported)"}, meta.OptionsMeta{Title:"Noise mode", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NOISE_MODE", Path:"admin_notify.noise_mode", PathText:"Multi-Push > Noise mode", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Noise mode -- noise_mode is disabled by default. -- -- When this option is set to `false`, only messages sent to the administrator will be notified, -- -- such as \"user A\" replies to \"user B\", the communication between these two users will not be notified to the administrator. --"}, meta.OptionsMeta{Title:"Pending comment still send notification", Desc:"notifications are still sent when comments are intercepted", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NOTIFY_PENDING", Path:"admin_notify.notify_pending", PathText:"Multi-Push > Pending comment still send notification", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Pending comment still send notification (notifications are still sent when comments are intercepted)"}, meta.OptionsMeta{Title:"Notification template", Desc:"set to file path to use custom template", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NOTIFY_TPL", Path:"admin_notify.notify_tpl", PathText:"Multi-Push > Notification template", Default:"default", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Notification template (set to file path to use custom template)"}, meta.OptionsMeta{Title:"ntfy", Desc:"https://ntfy.sh or self-hosted", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NTFY", Path:"admin_notify.ntfy", PathText:"Multi-Push > ntfy", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"ntfy (https://ntfy.sh or self-hosted)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NTFY_ENABLED", Path:"admin_notify.ntfy.enabled", PathText:"Multi-Push > ntfy > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Server", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NTFY_SERVER", Path:"admin_notify.ntfy.server", PathText:"Multi-Push > ntfy > Server", Default:"https://ntfy.sh", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Server"}, meta.OptionsMeta{Title:"Access token", Desc:"for the protected topic", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NTFY_TOKEN", Path:"admin_notify.ntfy.token", PathText:"Multi-Push > ntfy > Access token", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Access token (for the protected topic)"}, meta.OptionsMeta{Title:"Topic", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_NTFY_TOPIC", Path:"admin_notify.ntfy.topic", PathText:"Multi-Push > ntfy > Topic", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Topic"}, meta.OptionsMeta{Title:"Pushover", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_PUSHOVER", Path:"admin_notify.pushover", PathText:"Multi-Push > Pushover", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Pushover"}, meta.OptionsMeta{Title:"Device name", Desc:"all devices if empty", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_PUSHOVER_DEVICE", Path:"admin_notify.pushover.device", PathText:"Multi-Push > Pushover > Device name", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Device name (all devices if empty)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_PUSHOVER_ENABLED", Path:"admin_notify.pushover.enabled", PathText:"Multi-Push > Pushover > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"API token of the application", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_PUSHOVER_TOKEN", Path:"admin_notify.pushover.token", PathText:"Multi-Push > Pushover > API token of the application", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API token of the application"}, meta.OptionsMeta{Title:"User or group key", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_PUSHOVER_USER", Path:"admin_notify.pushover.user", PathText:"Multi-Push > Pushover > User or group key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"User or group key"}, meta.OptionsMeta{Title:"Slack", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_SLACK", Path:"admin_notify.slack", PathText:"Multi-Push > Slack", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Slack"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_SLACK_ENABLED", Path:"admin_notify.slack.enabled", PathText:"Multi-Push > Slack > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"OauthToken", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_SLACK_OAUTH_TOKEN", Path:"admin_notify.slack.oauth_token", PathText:"Multi-Push > Slack > OauthToken", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"OauthToken"}, meta.OptionsMeta{Title:"Receivers", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_SLACK_RECEIVERS", Path:"admin_notify.slack.receivers", PathText:"Multi-Push > Slack > Receivers", Default:[]interface {}{"CHANNEL_ID"}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Receivers"}, meta.OptionsMeta{Title:"Push the comments of the sites to different channels  e.g.  }]", Desc:"the other sites are pushed to receivers", Type:"[]interface {}", Options:[]string{"{ site: \"Site A", "receivers: [\"CHANNEL_ID_A"}, Env:"ATK_ADMIN_NOTIFY_SLACK_SITES", Path:"admin_notify.slack.sites", PathText:"Multi-Push > Slack > Push the comments of the sites to different channels  e.g.  }]", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Push the comments of the sites to different channels (the other sites are pushed to receivers) e.g. [{ site: \"Site A\", receivers: [\"CHANNEL_ID_A\"] }]"}, meta.OptionsMeta{Title:"Telegram", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM", Path:"admin_notify.telegram", PathText:"Multi-Push > Telegram", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Telegram"}, meta.OptionsMeta{Title:"ApiToken", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM_API_TOKEN", Path:"admin_notify.telegram.api_token", PathText:"Multi-Push > Telegram > ApiToken", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ApiToken"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM_ENABLED", Path:"admin_notify.telegram.enabled", PathText:"Multi-Push > Telegram > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Attach the moderation buttons  to the notifications", Desc:"approve, spam and delete", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM_MODERATION", Path:"admin_notify.telegram.moderation", PathText:"Multi-Push > Telegram > Attach the moderation buttons  to the notifications", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Attach the moderation buttons (approve, spam and delete) to the notifications -- the bot webhook should be set to \"https://<your_artalk_server>/api/v2/notify/telegram/webhook\" --"}, meta.OptionsMeta{Title:"The Telegram user IDs allowed to moderate", Desc:"the users in receivers if empty", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM_MODERATORS", Path:"admin_notify.telegram.moderators", PathText:"Multi-Push > Telegram > The Telegram user IDs allowed to moderate", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The Telegram user IDs allowed to moderate (the users in receivers if empty)"}, meta.OptionsMeta{Title:"Receivers", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM_RECEIVERS", Path:"admin_notify.telegram.receivers", PathText:"Multi-Push > Telegram > Receivers", Default:[]interface {}{0x76adf1}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Receivers"}, meta.OptionsMeta{Title:"The secret of bot webhook", Desc:"the `secret_token` of setWebhook", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_TELEGRAM_WEBHOOK_SECRET", Path:"admin_notify.telegram.webhook_secret", PathText:"Multi-Push > Telegram > The secret of bot webhook", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The secret of bot webhook (the `secret_token` of setWebhook)"}, meta.OptionsMeta{Title:"WebHook", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WEBHOOK", Path:"admin_notify.webhook", PathText:"Multi-Push > WebHook", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"WebHook"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WEBHOOK_ENABLED", Path:"admin_notify.webhook.enabled", PathText:"Multi-Push > WebHook > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Url", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WEBHOOK_URL", Path:"admin_notify.webhook.url", PathText:"Multi-Push > WebHook > Url", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Url"}, meta.OptionsMeta{Title:"WeCom", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WECOM", Path:"admin_notify.wecom", PathText:"Multi-Push > WeCom", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"WeCom"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WECOM_ENABLED", Path:"admin_notify.wecom.enabled", PathText:"Multi-Push > WeCom > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Users to mention", Desc:"mobile number or userid, \"all\" for everyone, only userid in card", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WECOM_MENTIONS", Path:"admin_notify.wecom.mentions", PathText:"Multi-Push > WeCom > Users to mention", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Users to mention (mobile number or userid, \"all\" for everyone, only userid in card)"}, meta.OptionsMeta{Title:"Message type", Desc:"", Type:"string", Options:[]string{"text", "card"}, Env:"ATK_ADMIN_NOTIFY_WECOM_MSG_TYPE", Path:"admin_notify.wecom.msg_type", PathText:"Multi-Push > WeCom > Message type", Default:"text", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Message type [\"text\", \"card\"]"}, meta.OptionsMeta{Title:"Webhook URL of the group bot", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_ADMIN_NOTIFY_WECOM_WEBHOOK_URL", Path:"admin_notify.wecom.webhook_url", PathText:"Multi-Push > WeCom > Webhook URL of the group bot", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Webhook URL of the group bot"}, meta.OptionsMeta{Title:"Admin two-factor authentication", Desc:"TOTP", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_TOTP", Path:"admin_totp", PathText:"Admin two-factor authentication", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Admin two-factor authentication (TOTP)"}, meta.OptionsMeta{Title:"Require all admins to enable the two-factor authentication", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_ADMIN_TOTP_ENFORCE", Path:"admin_totp.enforce", PathText:"Admin two-factor authentication > Require all admins to enable the two-factor authentication", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Require all admins to enable the two-factor authentication"}, meta.OptionsMeta{Title:"The issuer name shown in the authenticator app", Desc:"the site_default is used if empty", Type:"<nil>", Options:[]string(nil), Env:"ATK_ADMIN_TOTP_ISSUER", Path:"admin_totp.issuer", PathText:"Admin two-factor authentication > The issuer name shown in the authenticator app", Default:interface {}(nil), IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The issuer name shown in the authenticator app (the site_default is used if empty)"}, meta.OptionsMeta{Title:"App Key", Desc:"for generation of JWT", Type:"string", Options:[]string(nil), Env:"ATK_APP_KEY", Path:"app_key", PathText:"App Key", Default:"", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"App Key (for generation of JWT)"}, meta.OptionsMeta{Title:"Social Login", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH", Path:"auth", PathText:"Social Login", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Social Login"}, meta.OptionsMeta{Title:"Allow anonymous commenting", Desc:"Allow skipping verification, only fill in an anonymous nickname and email", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_ANONYMOUS", Path:"auth.anonymous", PathText:"Social Login > Allow anonymous commenting", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Allow anonymous commenting (Allow skipping verification, only fill in an anonymous nickname and email)"}, meta.OptionsMeta{Title:"Apple", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_APPLE", Path:"auth.apple", PathText:"Social Login > Apple", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Apple"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_APPLE_CLIENT_ID", Path:"auth.apple.client_id", PathText:"Social Login > Apple > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_APPLE_CLIENT_SECRET", Path:"auth.apple.client_secret", PathText:"Social Login > Apple > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_APPLE_ENABLED", Path:"auth.apple.enabled", PathText:"Social Login > Apple > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Auth0", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_AUTH0", Path:"auth.auth0", PathText:"Social Login > Auth0", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Auth0"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_AUTH0_CLIENT_ID", Path:"auth.auth0.client_id", PathText:"Social Login > Auth0 > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_AUTH0_CLIENT_SECRET", Path:"auth.auth0.client_secret", PathText:"Social Login > Auth0 > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Domain", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_AUTH0_DOMAIN", Path:"auth.auth0.domain", PathText:"Social Login > Auth0 > Domain", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Domain"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_AUTH0_ENABLED", Path:"auth.auth0.enabled", PathText:"Social Login > Auth0 > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Callback URL", Desc:"https://example.com/api/v2/auth/{provider}/callback", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_CALLBACK", Path:"auth.callback", PathText:"Social Login > Callback URL", Default:"http://localhost:23366/api/v2/auth/{provider}/callback", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Callback URL (https://example.com/api/v2/auth/{provider}/callback)"}, meta.OptionsMeta{Title:"Discord", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_DISCORD", Path:"auth.discord", PathText:"Social Login > Discord", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Discord"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_DISCORD_CLIENT_ID", Path:"auth.discord.client_id", PathText:"Social Login > Discord > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_DISCORD_CLIENT_SECRET", Path:"auth.discord.client_secret", PathText:"Social Login > Discord > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_DISCORD_ENABLED", Path:"auth.discord.enabled", PathText:"Social Login > Discord > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Email", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_EMAIL", Path:"auth.email", PathText:"Social Login > Email", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Email"}, meta.OptionsMeta{Title:"Enable email password login", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_EMAIL_ENABLED", Path:"auth.email.enabled", PathText:"Social Login > Email > Enable email password login", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable email password login"}, meta.OptionsMeta{Title:"Verification email subject", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_EMAIL_VERIFY_SUBJECT", Path:"auth.email.verify_subject", PathText:"Social Login > Email > Verification email subject", Default:"Your Code - {{code}}", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Verification email subject"}, meta.OptionsMeta{Title:"Verification email template", Desc:"set to file path to use custom template", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_EMAIL_VERIFY_TPL", Path:"auth.email.verify_tpl", PathText:"Social Login > Email > Verification email template", Default:"default", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Verification email template (set to file path to use custom template)"}, meta.OptionsMeta{Title:"Enable Social Login", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_ENABLED", Path:"auth.enabled", PathText:"Social Login > Enable Social Login", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable Social Login"}, meta.OptionsMeta{Title:"Facebook", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_FACEBOOK", Path:"auth.facebook", PathText:"Social Login > Facebook", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Facebook"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_FACEBOOK_CLIENT_ID", Path:"auth.facebook.client_id", PathText:"Social Login > Facebook > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_FACEBOOK_CLIENT_SECRET", Path:"auth.facebook.client_secret", PathText:"Social Login > Facebook > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_FACEBOOK_ENABLED", Path:"auth.facebook.enabled", PathText:"Social Login > Facebook > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Gitea", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_GITEA", Path:"auth.gitea", PathText:"Social Login > Gitea", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Gitea"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITEA_CLIENT_ID", Path:"auth.gitea.client_id", PathText:"Social Login > Gitea > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITEA_CLIENT_SECRET", Path:"auth.gitea.client_secret", PathText:"Social Login > Gitea > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_GITEA_ENABLED", Path:"auth.gitea.enabled", PathText:"Social Login > Gitea > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"GitHub", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_GITHUB", Path:"auth.github", PathText:"Social Login > GitHub", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"GitHub"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITHUB_CLIENT_ID", Path:"auth.github.client_id", PathText:"Social Login > GitHub > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITHUB_CLIENT_SECRET", Path:"auth.github.client_secret", PathText:"Social Login > GitHub > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_GITHUB_ENABLED", Path:"auth.github.enabled", PathText:"Social Login > GitHub > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"GitLab", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_GITLAB", Path:"auth.gitlab", PathText:"Social Login > GitLab", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"GitLab"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITLAB_CLIENT_ID", Path:"auth.gitlab.client_id", PathText:"Social Login > GitLab > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GITLAB_CLIENT_SECRET", Path:"auth.gitlab.client_secret", PathText:"Social Login > GitLab > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_GITLAB_ENABLED", Path:"auth.gitlab.enabled", PathText:"Social Login > GitLab > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Google", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_GOOGLE", Path:"auth.google", PathText:"Social Login > Google", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Google"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GOOGLE_CLIENT_ID", Path:"auth.google.client_id", PathText:"Social Login > Google > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_GOOGLE_CLIENT_SECRET", Path:"auth.google.client_secret", PathText:"Social Login > Google > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_GOOGLE_ENABLED", Path:"auth.google.enabled", PathText:"Social Login > Google > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Line", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_LINE", Path:"auth.line", PathText:"Social Login > Line", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Line"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_LINE_CLIENT_ID", Path:"auth.line.client_id", PathText:"Social Login > Line > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_LINE_CLIENT_SECRET", Path:"auth.line.client_secret", PathText:"Social Login > Line > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_LINE_ENABLED", Path:"auth.line.enabled", PathText:"Social Login > Line > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Mastodon", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_MASTODON", Path:"auth.mastodon", PathText:"Social Login > Mastodon", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Mastodon"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_MASTODON_CLIENT_ID", Path:"auth.mastodon.client_id", PathText:"Social Login > Mastodon > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_MASTODON_CLIENT_SECRET", Path:"auth.mastodon.client_secret", PathText:"Social Login > Mastodon > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_MASTODON_ENABLED", Path:"auth.mastodon.enabled", PathText:"Social Login > Mastodon > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Microsoft", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_MICROSOFT", Path:"auth.microsoft", PathText:"Social Login > Microsoft", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Microsoft"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_MICROSOFT_CLIENT_ID", Path:"auth.microsoft.client_id", PathText:"Social Login > Microsoft > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_MICROSOFT_CLIENT_SECRET", Path:"auth.microsoft.client_secret", PathText:"Social Login > Microsoft > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_MICROSOFT_ENABLED", Path:"auth.microsoft.enabled", PathText:"Social Login > Microsoft > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"OpenID Connect", Desc:"generic OIDC provider, e.g. Keycloak, Authentik", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_OIDC", Path:"auth.oidc", PathText:"Social Login > OpenID Connect", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"OpenID Connect (generic OIDC provider, e.g. Keycloak, Authentik)"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_OIDC_CLIENT_ID", Path:"auth.oidc.client_id", PathText:"Social Login > OpenID Connect > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_OIDC_CLIENT_SECRET", Path:"auth.oidc.client_secret", PathText:"Social Login > OpenID Connect > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Discovery URL", Desc:"ends with /.well-known/openid-configuration", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_OIDC_DISCOVERY_URL", Path:"auth.oidc.discovery_url", PathText:"Social Login > OpenID Connect > Discovery URL", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Discovery URL (ends with /.well-known/openid-configuration)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_OIDC_ENABLED", Path:"auth.oidc.enabled", PathText:"Social Login > OpenID Connect > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Display name", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_OIDC_NAME", Path:"auth.oidc.name", PathText:"Social Login > OpenID Connect > Display name", Default:"OIDC", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Display name"}, meta.OptionsMeta{Title:"Scopes", Desc:"leave empty to use openid, profile, email", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_AUTH_OIDC_SCOPES", Path:"auth.oidc.scopes", PathText:"Social Login > OpenID Connect > Scopes", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Scopes (leave empty to use openid, profile, email)"}, meta.OptionsMeta{Title:"Patreon", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_PATREON", Path:"auth.patreon", PathText:"Social Login > Patreon", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Patreon"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_PATREON_CLIENT_ID", Path:"auth.patreon.client_id", PathText:"Social Login > Patreon > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_PATREON_CLIENT_SECRET", Path:"auth.patreon.client_secret", PathText:"Social Login > Patreon > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_PATREON_ENABLED", Path:"auth.patreon.enabled", PathText:"Social Login > Patreon > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Slack", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_SLACK", Path:"auth.slack", PathText:"Social Login > Slack", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Slack"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_SLACK_CLIENT_ID", Path:"auth.slack.client_id", PathText:"Social Login > Slack > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_SLACK_CLIENT_SECRET", Path:"auth.slack.client_secret", PathText:"Social Login > Slack > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_SLACK_ENABLED", Path:"auth.slack.enabled", PathText:"Social Login > Slack > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Single sign-on from the host site", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_SSO", Path:"auth.sso", PathText:"Social Login > Single sign-on from the host site", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Single sign-on from the host site"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_SSO_ENABLED", Path:"auth.sso.enabled", PathText:"Social Login > Single sign-on from the host site > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"The lifetime of the signed payload", Desc:"in seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_AUTH_SSO_MAX_AGE", Path:"auth.sso.max_age", PathText:"Social Login > Single sign-on from the host site > The lifetime of the signed payload", Default:0x12c, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The lifetime of the signed payload (in seconds)"}, meta.OptionsMeta{Title:"The secret shared with the host site to sign the user payload", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_SSO_SECRET", Path:"auth.sso.secret", PathText:"Social Login > Single sign-on from the host site > The secret shared with the host site to sign the user payload", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The secret shared with the host site to sign the user payload"}, meta.OptionsMeta{Title:"Steam", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_STEAM", Path:"auth.steam", PathText:"Social Login > Steam", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Steam"}, meta.OptionsMeta{Title:"ApiKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_STEAM_API_KEY", Path:"auth.steam.api_key", PathText:"Social Login > Steam > ApiKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ApiKey"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_STEAM_ENABLED", Path:"auth.steam.enabled", PathText:"Social Login > Steam > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Tiktok", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_TIKTOK", Path:"auth.tiktok", PathText:"Social Login > Tiktok", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Tiktok"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_TIKTOK_CLIENT_ID", Path:"auth.tiktok.client_id", PathText:"Social Login > Tiktok > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_TIKTOK_CLIENT_SECRET", Path:"auth.tiktok.client_secret", PathText:"Social Login > Tiktok > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_TIKTOK_ENABLED", Path:"auth.tiktok.enabled", PathText:"Social Login > Tiktok > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Twitter", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_TWITTER", Path:"auth.twitter", PathText:"Social Login > Twitter", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Twitter"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_TWITTER_CLIENT_ID", Path:"auth.twitter.client_id", PathText:"Social Login > Twitter > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_TWITTER_CLIENT_SECRET", Path:"auth.twitter.client_secret", PathText:"Social Login > Twitter > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_TWITTER_ENABLED", Path:"auth.twitter.enabled", PathText:"Social Login > Twitter > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"WeChat", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AUTH_WECHAT", Path:"auth.wechat", PathText:"Social Login > WeChat", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"WeChat"}, meta.OptionsMeta{Title:"ClientId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_WECHAT_CLIENT_ID", Path:"auth.wechat.client_id", PathText:"Social Login > WeChat > ClientId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientId"}, meta.OptionsMeta{Title:"ClientSecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AUTH_WECHAT_CLIENT_SECRET", Path:"auth.wechat.client_secret", PathText:"Social Login > WeChat > ClientSecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ClientSecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AUTH_WECHAT_ENABLED", Path:"auth.wechat.enabled", PathText:"Social Login > WeChat > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Avatar proxy", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_AVATAR", Path:"avatar", PathText:"Avatar proxy", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Avatar proxy"}, meta.OptionsMeta{Title:"The max total size of cache , the oldest avatars are removed if exceeded", Desc:"in MB", Type:"uint64", Options:[]string(nil), Env:"ATK_AVATAR_CACHE_MAX_SIZE", Path:"avatar.cache_max_size", PathText:"Avatar proxy > The max total size of cache , the oldest avatars are removed if exceeded", Default:0xc8, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The max total size of cache (in MB), the oldest avatars are removed if exceeded"}, meta.OptionsMeta{Title:"The directory of avatar cache", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AVATAR_CACHE_PATH", Path:"avatar.cache_path", PathText:"Avatar proxy > The directory of avatar cache", Default:"./data/avatar-cache", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The directory of avatar cache"}, meta.OptionsMeta{Title:"The time to live of cache", Desc:"in hours", Type:"uint64", Options:[]string(nil), Env:"ATK_AVATAR_CACHE_TTL", Path:"avatar.cache_ttl", PathText:"Avatar proxy > The time to live of cache", Default:0xa8, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The time to live of cache (in hours)"}, meta.OptionsMeta{Title:"Fetch and cache the avatars by the server, which are loaded from the same origin, so the email hashes of visitors are never exposed to the third parties by the browser", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_AVATAR_ENABLED", Path:"avatar.enabled", PathText:"Avatar proxy > Fetch and cache the avatars by the server, which are loaded from the same origin, so the email hashes of visitors are never exposed to the third parties by the browser", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Fetch and cache the avatars by the server, which are loaded from the same origin, so the email hashes of visitors are never exposed to the third parties by the browser"}, meta.OptionsMeta{Title:"The mirror of Gravatar", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_AVATAR_GRAVATAR_MIRROR", Path:"avatar.gravatar_mirror", PathText:"Avatar proxy > The mirror of Gravatar", Default:"https://www.gravatar.com/avatar/", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The mirror of Gravatar"}, meta.OptionsMeta{Title:"The max number of requests of each IP per minute", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_AVATAR_RATE_LIMIT", Path:"avatar.rate_limit", PathText:"Avatar proxy > The max number of requests of each IP per minute", Default:0xf0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The max number of requests of each IP per minute"}, meta.OptionsMeta{Title:"The sources of avatar, which are tried in order , the identicon is generated if the avatar is not found in all the sources", Desc:"\"gravatar\", \"cravatar\" or \"qq\"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_AVATAR_SOURCES", Path:"avatar.sources", PathText:"Avatar proxy > The sources of avatar, which are tried in order , the identicon is generated if the avatar is not found in all the sources", Default:[]interface {}{"gravatar"}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The sources of avatar, which are tried in order (\"gravatar\", \"cravatar\" or \"qq\"), the identicon is generated if the avatar is not found in all the sources"}, meta.OptionsMeta{Title:"The timeout of fetching avatar", Desc:"in seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_AVATAR_TIMEOUT", Path:"avatar.timeout", PathText:"Avatar proxy > The timeout of fetching avatar", Default:0x5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The timeout of fetching avatar (in seconds)"}, meta.OptionsMeta{Title:"Scheduled backup", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_BACKUP", Path:"backup", PathText:"Scheduled backup", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Scheduled backup"}, meta.OptionsMeta{Title:"Enable the scheduled backup", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_BACKUP_ENABLED", Path:"backup.enabled", PathText:"Scheduled backup > Enable the scheduled backup", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable the scheduled backup -- The backup includes the comments (Artrans), the config file and the manifest of uploaded files --"}, meta.OptionsMeta{Title:"Number of backups to keep", Desc:"both local and remote, 0 to keep all", Type:"uint64", Options:[]string(nil), Env:"ATK_BACKUP_KEEP", Path:"backup.keep", PathText:"Scheduled backup > Number of backups to keep", Default:0x7, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Number of backups to keep (both local and remote, 0 to keep all)"}, meta.OptionsMeta{Title:"Local directory to keep the backups", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_PATH", Path:"backup.path", PathText:"Scheduled backup > Local directory to keep the backups", Default:"./data/backups", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Local directory to keep the backups"}, meta.OptionsMeta{Title:"Remote storage", Desc:"", Type:"string", Options:[]string{"", "s3", "webdav", "sftp"}, Env:"ATK_BACKUP_REMOTE", Path:"backup.remote", PathText:"Scheduled backup > Remote storage", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Remote storage [\"\", \"s3\", \"webdav\", \"sftp\"] -- Kept locally only if empty --"}, meta.OptionsMeta{Title:"S3 compatible storage", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_BACKUP_S3", Path:"backup.s3", PathText:"Scheduled backup > S3 compatible storage", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"S3 compatible storage"}, meta.OptionsMeta{Title:"Access key", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_S3_ACCESS_KEY", Path:"backup.s3.access_key", PathText:"Scheduled backup > S3 compatible storage > Access key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Access key"}, meta.OptionsMeta{Title:"Bucket", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_S3_BUCKET", Path:"backup.s3.bucket", PathText:"Scheduled backup > S3 compatible storage > Bucket", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Bucket"}, meta.OptionsMeta{Title:"Endpoint", Desc:"AWS S3 if empty, e.g. \"https://<account_id>.r2.cloudflarestorage.com\"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_S3_ENDPOINT", Path:"backup.s3.endpoint", PathText:"Scheduled backup > S3 compatible storage > Endpoint", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Endpoint (AWS S3 if empty, e.g. \"https://<account_id>.r2.cloudflarestorage.com\")"}, meta.OptionsMeta{Title:"Use the path-style URL", Desc:"required by MinIO, etc.", Type:"bool", Options:[]string(nil), Env:"ATK_BACKUP_S3_PATH_STYLE", Path:"backup.s3.path_style", PathText:"Scheduled backup > S3 compatible storage > Use the path-style URL", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Use the path-style URL (required by MinIO, etc.)"}, meta.OptionsMeta{Title:"Object key prefix", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_S3_PREFIX", Path:"backup.s3.prefix", PathText:"Scheduled backup > S3 compatible storage > Object key prefix", Default:"artalk", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Object key prefix"}, meta.OptionsMeta{Title:"Region", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_S3_REGION", Path:"backup.s3.region", PathText:"Scheduled backup > S3 compatible storage > Region", Default:"us-east-1", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Region"}, meta.OptionsMeta{Title:"Secret key", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_S3_SECRET_KEY", Path:"backup.s3.secret_key", PathText:"Scheduled backup > S3 compatible storage > Secret key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Secret key"}, meta.OptionsMeta{Title:"Schedule in cron expression", Desc:"e.g. \"0 3 * * *\" for 3 AM every day", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_SCHEDULE", Path:"backup.schedule", PathText:"Scheduled backup > Schedule in cron expression", Default:"0 3 * * *", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Schedule in cron expression (e.g. \"0 3 * * *\" for 3 AM every day)"}, meta.OptionsMeta{Title:"SFTP", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_BACKUP_SFTP", Path:"backup.sftp", PathText:"Scheduled backup > SFTP", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"SFTP"}, meta.OptionsMeta{Title:"Remote directory", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_SFTP_DIR", Path:"backup.sftp.dir", PathText:"Scheduled backup > SFTP > Remote directory", Default:"./artalk-backups", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Remote directory"}, meta.OptionsMeta{Title:"Host", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_SFTP_HOST", Path:"backup.sftp.host", PathText:"Scheduled backup > SFTP > Host", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Host"}, meta.OptionsMeta{Title:"Public key of host", Desc:"authorized_keys format", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_SFTP_HOST_KEY", Path:"backup.sftp.host_key", PathText:"Scheduled backup > SFTP > Public key of host", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Public key of host (authorized_keys format)"}, meta.OptionsMeta{Title:"Skip the verification of host key", Desc:"insecure, must be enabled explicitly if neither the host key nor known_hosts is set", Type:"bool", Options:[]string(nil), Env:"ATK_BACKUP_SFTP_INSECURE_IGNORE_HOST_KEY", Path:"backup.sftp.insecure_ignore_host_key", PathText:"Scheduled backup > SFTP > Skip the verification of host key", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Skip the verification of host key (insecure, must be enabled explicitly if neither the host key nor known_hosts is set)"}, meta.OptionsMeta{Title:"Path of known_hosts file", Desc:"used if the host key is empty", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_SFTP_KNOWN_HOSTS", Path:"backup.sftp.known_hosts", PathText:"Scheduled backup > SFTP > Path of known_hosts file", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Path of known_hosts file (used if the host key is empty)"}, meta.OptionsMeta{Title:"Password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_SFTP_PASSWORD", Path:"backup.sftp.password", PathText:"Scheduled backup > SFTP > Password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Password"}, meta.OptionsMeta{Title:"Port", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_BACKUP_SFTP_PORT", Path:"backup.sftp.port", PathText:"Scheduled backup > SFTP > Port", Default:0x16, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Port"}, meta.OptionsMeta{Title:"Path of private key file", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_SFTP_PRIVATE_KEY", Path:"backup.sftp.private_key", PathText:"Scheduled backup > SFTP > Path of private key file", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Path of private key file"}, meta.OptionsMeta{Title:"Username", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_SFTP_USERNAME", Path:"backup.sftp.username", PathText:"Scheduled backup > SFTP > Username", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Username"}, meta.OptionsMeta{Title:"WebDAV", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_BACKUP_WEBDAV", Path:"backup.webdav", PathText:"Scheduled backup > WebDAV", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"WebDAV"}, meta.OptionsMeta{Title:"Password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_WEBDAV_PASSWORD", Path:"backup.webdav.password", PathText:"Scheduled backup > WebDAV > Password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Password"}, meta.OptionsMeta{Title:"URL of the backup directory", Desc:"e.g. \"https://dav.example.com/artalk\"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_WEBDAV_URL", Path:"backup.webdav.url", PathText:"Scheduled backup > WebDAV > URL of the backup directory", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"URL of the backup directory (e.g. \"https://dav.example.com/artalk\")"}, meta.OptionsMeta{Title:"Username", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_BACKUP_WEBDAV_USERNAME", Path:"backup.webdav.username", PathText:"Scheduled backup > WebDAV > Username", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Username"}, meta.OptionsMeta{Title:"Cache", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_CACHE", Path:"cache", PathText:"Cache", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Cache"}, meta.OptionsMeta{Title:"Enable cache", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_CACHE_ENABLED", Path:"cache.enabled", PathText:"Cache > Enable cache", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable cache"}, meta.OptionsMeta{Title:"Cache expiration time", Desc:"in minutes", Type:"uint64", Options:[]string(nil), Env:"ATK_CACHE_EXPIRES", Path:"cache.expires", PathText:"Cache > Cache expiration time", Default:0x1e, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cache expiration time (in minutes)"}, meta.OptionsMeta{Title:"Redis config", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_CACHE_REDIS", Path:"cache.redis", PathText:"Cache > Redis config", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Redis config"}, meta.OptionsMeta{Title:"Redis database number", Desc:"e.g. 0", Type:"uint64", Options:[]string(nil), Env:"ATK_CACHE_REDIS_DB", Path:"cache.redis.db", PathText:"Cache > Redis config > Redis database number", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis database number (e.g. 0)"}, meta.OptionsMeta{Title:"The master name of sentinel mode", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_MASTER_NAME", Path:"cache.redis.master_name", PathText:"Cache > Redis config > The master name of sentinel mode", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The master name of sentinel mode"}, meta.OptionsMeta{Title:"Deployment mode", Desc:"", Type:"string", Options:[]string{"standalone", "cluster", "sentinel"}, Env:"ATK_CACHE_REDIS_MODE", Path:"cache.redis.mode", PathText:"Cache > Redis config > Deployment mode", Default:"standalone", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Deployment mode [\"standalone\", \"cluster\", \"sentinel\"]"}, meta.OptionsMeta{Title:"Connection type", Desc:"", Type:"string", Options:[]string{"tcp", "unix"}, Env:"ATK_CACHE_REDIS_NETWORK", Path:"cache.redis.network", PathText:"Cache > Redis config > Connection type", Default:"tcp", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Connection type [\"tcp\", \"unix\"]"}, meta.OptionsMeta{Title:"Redis password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_PASSWORD", Path:"cache.redis.password", PathText:"Cache > Redis config > Redis password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis password"}, meta.OptionsMeta{Title:"Sentinel password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_SENTINEL_PASSWORD", Path:"cache.redis.sentinel_password", PathText:"Cache > Redis config > Sentinel password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Sentinel password"}, meta.OptionsMeta{Title:"Sentinel username", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_SENTINEL_USERNAME", Path:"cache.redis.sentinel_username", PathText:"Cache > Redis config > Sentinel username", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Sentinel username"}, meta.OptionsMeta{Title:"TLS connection", Desc:"e.g. the managed Redis services", Type:"<nil>", Options:[]string(nil), Env:"ATK_CACHE_REDIS_TLS", Path:"cache.redis.tls", PathText:"Cache > Redis config > TLS connection", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"TLS connection (e.g. the managed Redis services)"}, meta.OptionsMeta{Title:"The path of CA cert file", Desc:"the system certs are used if empty", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_TLS_CA_CERT", Path:"cache.redis.tls.ca_cert", PathText:"Cache > Redis config > TLS connection > The path of CA cert file", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The path of CA cert file (the system certs are used if empty)"}, meta.OptionsMeta{Title:"The path of client cert file", Desc:"for mutual TLS", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_TLS_CERT", Path:"cache.redis.tls.cert", PathText:"Cache > Redis config > TLS connection > The path of client cert file", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The path of client cert file (for mutual TLS)"}, meta.OptionsMeta{Title:"Enable TLS", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_CACHE_REDIS_TLS_ENABLED", Path:"cache.redis.tls.enabled", PathText:"Cache > Redis config > TLS connection > Enable TLS", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable TLS"}, meta.OptionsMeta{Title:"Skip the cert verification", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_CACHE_REDIS_TLS_INSECURE_SKIP_VERIFY", Path:"cache.redis.tls.insecure_skip_verify", PathText:"Cache > Redis config > TLS connection > Skip the cert verification", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Skip the cert verification"}, meta.OptionsMeta{Title:"The path of client key file", Desc:"for mutual TLS", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_TLS_KEY", Path:"cache.redis.tls.key", PathText:"Cache > Redis config > TLS connection > The path of client key file", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The path of client key file (for mutual TLS)"}, meta.OptionsMeta{Title:"The server name to verify the cert", Desc:"the address is used if empty", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_TLS_SERVER_NAME", Path:"cache.redis.tls.server_name", PathText:"Cache > Redis config > TLS connection > The server name to verify the cert", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The server name to verify the cert (the address is used if empty)"}, meta.OptionsMeta{Title:"Redis username", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_REDIS_USERNAME", Path:"cache.redis.username", PathText:"Cache > Redis config > Redis username", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis username"}, meta.OptionsMeta{Title:"Cache server address", Desc:"e.g. \"localhost:6379\", separated by commas for the Redis cluster or sentinels", Type:"string", Options:[]string(nil), Env:"ATK_CACHE_SERVER", Path:"cache.server", PathText:"Cache > Cache server address", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"-- The following is not necessary for `builtin` cache -- Cache server address (e.g. \"localhost:6379\", separated by commas for the Redis cluster or sentinels)"}, meta.OptionsMeta{Title:"Cache expiration time by the key prefix , e.g. { comment: 60, site: -1 }", Desc:"in minutes", Type:"map[string]interface {}", Options:[]string(nil), Env:"ATK_CACHE_TTLS", Path:"cache.ttls", PathText:"Cache > Cache expiration time by the key prefix , e.g. { comment: 60, site: -1 }", Default:map[string]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cache expiration time by the key prefix (in minutes), e.g. { comment: 60, site: -1 }"}, meta.OptionsMeta{Title:"Cache type", Desc:"", Type:"string", Options:[]string{"redis", "memcache", "builtin"}, Env:"ATK_CACHE_TYPE", Path:"cache.type", PathText:"Cache > Cache type", Default:"builtin", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cache type [\"redis\", \"memcache\", \"builtin\"]"}, meta.OptionsMeta{Title:"Cache warm up", Desc:"warm up cache when program starts", Type:"bool", Options:[]string(nil), Env:"ATK_CACHE_WARM_UP", Path:"cache.warm_up", PathText:"Cache > Cache warm up", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cache warm up (warm up cache when program starts)"}, meta.OptionsMeta{Title:"Number of the busiest pages to warm up the comment lists", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_CACHE_WARM_UP_PAGES", Path:"cache.warm_up_pages", PathText:"Cache > Number of the busiest pages to warm up the comment lists", Default:0x14, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Number of the busiest pages to warm up the comment lists"}, meta.OptionsMeta{Title:"Captcha", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA", Path:"captcha", PathText:"Captcha", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Captcha"}, meta.OptionsMeta{Title:"Action limit", Desc:"the number of actions required to activate captcha", Type:"uint64", Options:[]string(nil), Env:"ATK_CAPTCHA_ACTION_LIMIT", Path:"captcha.action_limit", PathText:"Captcha > Action limit", Default:0x3, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Action limit (the number of actions required to activate captcha)"}, meta.OptionsMeta{Title:"Reset Timeout", Desc:"timeout to reset action counter. unit: s, set to -1 to disable", Type:"uint64", Options:[]string(nil), Env:"ATK_CAPTCHA_ACTION_RESET", Path:"captcha.action_reset", PathText:"Captcha > Reset Timeout", Default:0x3c, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Reset Timeout (timeout to reset action counter. unit: s, set to -1 to disable)"}, meta.OptionsMeta{Title:"Adaptive captcha", Desc:"only challenge the suspicious requests", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_ADAPTIVE", Path:"captcha.adaptive", PathText:"Captcha > Adaptive captcha", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Adaptive captcha (only challenge the suspicious requests)"}, meta.OptionsMeta{Title:"Enable adaptive captcha", Desc:"the option `always` is ignored if enabled", Type:"bool", Options:[]string(nil), Env:"ATK_CAPTCHA_ADAPTIVE_ENABLED", Path:"captcha.adaptive.enabled", PathText:"Captcha > Adaptive captcha > Enable adaptive captcha", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable adaptive captcha (the option `always` is ignored if enabled)"}, meta.OptionsMeta{Title:"Spam score of the IP's comments in the window to require captcha", Desc:"range 0~1, 0 to disable", Type:"float64", Options:[]string(nil), Env:"ATK_CAPTCHA_ADAPTIVE_GREY_SCORE", Path:"captcha.adaptive.grey_score", PathText:"Captcha > Adaptive captcha > Spam score of the IP's comments in the window to require captcha", Default:0.5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Spam score of the IP's comments in the window to require captcha (range 0~1, 0 to disable)"}, meta.OptionsMeta{Title:"Max comments of the IP in the window", Desc:"0 to disable", Type:"uint64", Options:[]string(nil), Env:"ATK_CAPTCHA_ADAPTIVE_MAX_COMMENTS", Path:"captcha.adaptive.max_comments", PathText:"Captcha > Adaptive captcha > Max comments of the IP in the window", Default:0x3, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max comments of the IP in the window (0 to disable)"}, meta.OptionsMeta{Title:"New IP requires captcha", Desc:"the IP without approved comments", Type:"bool", Options:[]string(nil), Env:"ATK_CAPTCHA_ADAPTIVE_NEW_IP", Path:"captcha.adaptive.new_ip", PathText:"Captcha > Adaptive captcha > New IP requires captcha", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"New IP requires captcha (the IP without approved comments)"}, meta.OptionsMeta{Title:"Window", Desc:"unit: s", Type:"uint64", Options:[]string(nil), Env:"ATK_CAPTCHA_ADAPTIVE_WINDOW", Path:"captcha.adaptive.window", PathText:"Captcha > Adaptive captcha > Window", Default:0x258, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Window (unit: s)"}, meta.OptionsMeta{Title:"Captcha is required always", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_CAPTCHA_ALWAYS", Path:"captcha.always", PathText:"Captcha > Captcha is required always", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Captcha is required always"}, meta.OptionsMeta{Title:"Captcha type", Desc:"", Type:"string", Options:[]string{"image", "turnstile", "recaptcha", "hcaptcha", "geetest", "pow"}, Env:"ATK_CAPTCHA_CAPTCHA_TYPE", Path:"captcha.captcha_type", PathText:"Captcha > Captcha type", Default:"image", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Captcha type [\"image\", \"turnstile\", \"recaptcha\", \"hcaptcha\", \"geetest\", \"pow\"]"}, meta.OptionsMeta{Title:"Enable captcha", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_CAPTCHA_ENABLED", Path:"captcha.enabled", PathText:"Captcha > Enable captcha", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable captcha"}, meta.OptionsMeta{Title:"Geetest", Desc:"https://www.geetest.com", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_GEETEST", Path:"captcha.geetest", PathText:"Captcha > Geetest", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Geetest (https://www.geetest.com)"}, meta.OptionsMeta{Title:"CaptchaId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_GEETEST_CAPTCHA_ID", Path:"captcha.geetest.captcha_id", PathText:"Captcha > Geetest > CaptchaId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"CaptchaId"}, meta.OptionsMeta{Title:"CaptchaKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_GEETEST_CAPTCHA_KEY", Path:"captcha.geetest.captcha_key", PathText:"Captcha > Geetest > CaptchaKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"CaptchaKey"}, meta.OptionsMeta{Title:"hCaptcha", Desc:"https://www.hcaptcha.com/", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_HCAPTCHA", Path:"captcha.hcaptcha", PathText:"Captcha > hCaptcha", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"hCaptcha (https://www.hcaptcha.com/)"}, meta.OptionsMeta{Title:"Proxy for hCaptcha verification", Desc:"leave empty to use `http.outbound_proxy`, \"direct\" for no proxy", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_HCAPTCHA_PROXY", Path:"captcha.hcaptcha.proxy", PathText:"Captcha > hCaptcha > Proxy for hCaptcha verification", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Proxy for hCaptcha verification (leave empty to use `http.outbound_proxy`, \"direct\" for no proxy)"}, meta.OptionsMeta{Title:"SecretKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_HCAPTCHA_SECRET_KEY", Path:"captcha.hcaptcha.secret_key", PathText:"Captcha > hCaptcha > SecretKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretKey"}, meta.OptionsMeta{Title:"SiteKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_HCAPTCHA_SITE_KEY", Path:"captcha.hcaptcha.site_key", PathText:"Captcha > hCaptcha > SiteKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SiteKey"}, meta.OptionsMeta{Title:"Proof-of-work", Desc:"solved by the browser, no third-party service", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_POW", Path:"captcha.pow", PathText:"Captcha > Proof-of-work", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Proof-of-work (solved by the browser, no third-party service)"}, meta.OptionsMeta{Title:"Difficulty", Desc:"leading zero bits of the hash, each extra bit doubles the work", Type:"uint64", Options:[]string(nil), Env:"ATK_CAPTCHA_POW_DIFFICULTY", Path:"captcha.pow.difficulty", PathText:"Captcha > Proof-of-work > Difficulty", Default:0x10, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Difficulty (leading zero bits of the hash, each extra bit doubles the work)"}, meta.OptionsMeta{Title:"reCaptcha", Desc:"https://www.google.com/recaptcha/about/", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_RECAPTCHA", Path:"captcha.recaptcha", PathText:"Captcha > reCaptcha", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"reCaptcha (https://www.google.com/recaptcha/about/)"}, meta.OptionsMeta{Title:"Proxy for reCAPTCHA verification", Desc:"leave empty to use `http.outbound_proxy`, \"direct\" for no proxy", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_RECAPTCHA_PROXY", Path:"captcha.recaptcha.proxy", PathText:"Captcha > reCaptcha > Proxy for reCAPTCHA verification", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Proxy for reCAPTCHA verification (leave empty to use `http.outbound_proxy`, \"direct\" for no proxy)"}, meta.OptionsMeta{Title:"SecretKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_RECAPTCHA_SECRET_KEY", Path:"captcha.recaptcha.secret_key", PathText:"Captcha > reCaptcha > SecretKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretKey"}, meta.OptionsMeta{Title:"SiteKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_RECAPTCHA_SITE_KEY", Path:"captcha.recaptcha.site_key", PathText:"Captcha > reCaptcha > SiteKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SiteKey"}, meta.OptionsMeta{Title:"Turnstile", Desc:"https://www.cloudflare.com/products/turnstile/", Type:"<nil>", Options:[]string(nil), Env:"ATK_CAPTCHA_TURNSTILE", Path:"captcha.turnstile", PathText:"Captcha > Turnstile", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Turnstile (https://www.cloudflare.com/products/turnstile/)"}, meta.OptionsMeta{Title:"Proxy for Turnstile verification", Desc:"leave empty to use `http.outbound_proxy`, \"direct\" for no proxy", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_TURNSTILE_PROXY", Path:"captcha.turnstile.proxy", PathText:"Captcha > Turnstile > Proxy for Turnstile verification", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Proxy for Turnstile verification (leave empty to use `http.outbound_proxy`, \"direct\" for no proxy)"}, meta.OptionsMeta{Title:"SecretKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_TURNSTILE_SECRET_KEY", Path:"captcha.turnstile.secret_key", PathText:"Captcha > Turnstile > SecretKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretKey"}, meta.OptionsMeta{Title:"SiteKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_CAPTCHA_TURNSTILE_SITE_KEY", Path:"captcha.turnstile.site_key", PathText:"Captcha > Turnstile > SiteKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SiteKey"}, meta.OptionsMeta{Title:"Cluster mode", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_CLUSTER_MODE", Path:"cluster_mode", PathText:"Cluster mode", Default:false, IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Cluster mode -- Run multiple instances behind a load balancer, the shared states are stored in the Redis cache (see docs) --"}, meta.OptionsMeta{Title:"Scheduled comment closing", Desc:"new comments are rejected on the closed pages, which can be overridden per site or per page", Type:"<nil>", Options:[]string(nil), Env:"ATK_COMMENT_CLOSE", Path:"comment_close", PathText:"Scheduled comment closing", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Scheduled comment closing (new comments are rejected on the closed pages, which can be overridden per site or per page)"}, meta.OptionsMeta{Title:"Close the comments of pages older than N days", Desc:"by the published date of page, or the created date if not set, 0 to disable", Type:"uint64", Options:[]string(nil), Env:"ATK_COMMENT_CLOSE_AFTER_DAYS", Path:"comment_close.after_days", PathText:"Scheduled comment closing > Close the comments of pages older than N days", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Close the comments of pages older than N days (by the published date of page, or the created date if not set, 0 to disable)"}, meta.OptionsMeta{Title:"Close the comments of all pages at the specified time", Desc:"e.g. \"2024-12-31\" or \"2024-12-31T23:59:59+08:00\", empty to disable", Type:"string", Options:[]string(nil), Env:"ATK_COMMENT_CLOSE_CLOSE_AT", Path:"comment_close.close_at", PathText:"Scheduled comment closing > Close the comments of all pages at the specified time", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Close the comments of all pages at the specified time (e.g. \"2024-12-31\" or \"2024-12-31T23:59:59+08:00\", empty to disable)"}, meta.OptionsMeta{Title:"Comment length and posting frequency limits", Desc:"the admins are not limited, and the limits can be overridden per site", Type:"<nil>", Options:[]string(nil), Env:"ATK_COMMENT_LIMIT", Path:"comment_limit", PathText:"Comment length and posting frequency limits", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Comment length and posting frequency limits (the admins are not limited, and the limits can be overridden per site)"}, meta.OptionsMeta{Title:"Minimum interval between two comments of the same user", Desc:"unit: second, 0 for unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_COMMENT_LIMIT_COOLDOWN", Path:"comment_limit.cooldown", PathText:"Comment length and posting frequency limits > Minimum interval between two comments of the same user", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Minimum interval between two comments of the same user (unit: second, 0 for unlimited)"}, meta.OptionsMeta{Title:"Maximum length of comment", Desc:"number of characters, 0 for unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_COMMENT_LIMIT_MAX_LENGTH", Path:"comment_limit.max_length", PathText:"Comment length and posting frequency limits > Maximum length of comment", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Maximum length of comment (number of characters, 0 for unlimited)"}, meta.OptionsMeta{Title:"Maximum number of links in a comment", Desc:"0 for unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_COMMENT_LIMIT_MAX_LINKS", Path:"comment_limit.max_links", PathText:"Comment length and posting frequency limits > Maximum number of links in a comment", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Maximum number of links in a comment (0 for unlimited)"}, meta.OptionsMeta{Title:"Minimum length of comment", Desc:"number of characters, 0 for unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_COMMENT_LIMIT_MIN_LENGTH", Path:"comment_limit.min_length", PathText:"Comment length and posting frequency limits > Minimum length of comment", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Minimum length of comment (number of characters, 0 for unlimited)"}, meta.OptionsMeta{Title:"Database", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_DB", Path:"db", PathText:"Database", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Database"}, meta.OptionsMeta{Title:"Database charset", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_CHARSET", Path:"db.charset", PathText:"Database > Database charset", Default:"utf8mb4", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database charset"}, meta.OptionsMeta{Title:"Database file", Desc:"only for SQLite", Type:"string", Options:[]string(nil), Env:"ATK_DB_FILE", Path:"db.file", PathText:"Database > Database file", Default:"./data/artalk.db", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database file (only for SQLite)"}, meta.OptionsMeta{Title:"Host address", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_HOST", Path:"db.host", PathText:"Database > Host address", Default:"localhost", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Host address"}, meta.OptionsMeta{Title:"Database name", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_NAME", Path:"db.name", PathText:"Database > Database name", Default:"artalk", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"-- The following is not necessary for SQLite -- Database name"}, meta.OptionsMeta{Title:"Database password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_PASSWORD", Path:"db.password", PathText:"Database > Database password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database password"}, meta.OptionsMeta{Title:"Host port", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_DB_PORT", Path:"db.port", PathText:"Database > Host port", Default:0xcea, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Host port"}, meta.OptionsMeta{Title:"Prepared Statement", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_DB_PREPARE_STMT", Path:"db.prepare_stmt", PathText:"Database > Prepared Statement", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Prepared Statement"}, meta.OptionsMeta{Title:"Read replicas", Desc:"the reads are routed to the replicas, and the writes to the primary", Type:"<nil>", Options:[]string(nil), Env:"ATK_DB_REPLICAS", Path:"db.replicas", PathText:"Database > Read replicas", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Read replicas (the reads are routed to the replicas, and the writes to the primary)"}, meta.OptionsMeta{Title:"Health check interval", Desc:"unit: seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_DB_REPLICAS_CHECK_INTERVAL", Path:"db.replicas.check_interval", PathText:"Database > Read replicas > Health check interval", Default:0xa, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Health check interval (unit: seconds)"}, meta.OptionsMeta{Title:"The DSN list of replicas", Desc:"the same database type as the primary", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_DB_REPLICAS_DSNS", Path:"db.replicas.dsns", PathText:"Database > Read replicas > The DSN list of replicas", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The DSN list of replicas (the same database type as the primary)"}, meta.OptionsMeta{Title:"Max replication lag", Desc:"unit: seconds, the reads fall back to the primary if exceeded, 0 to disable", Type:"uint64", Options:[]string(nil), Env:"ATK_DB_REPLICAS_MAX_LAG", Path:"db.replicas.max_lag", PathText:"Database > Read replicas > Max replication lag", Default:0xa, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max replication lag (unit: seconds, the reads fall back to the primary if exceeded, 0 to disable)"}, meta.OptionsMeta{Title:"Enable SSL mode", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_DB_SSL", Path:"db.ssl", PathText:"Database > Enable SSL mode", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable SSL mode"}, meta.OptionsMeta{Title:"Table prefix", Desc:"e.g. \"atk_\"", Type:"string", Options:[]string(nil), Env:"ATK_DB_TABLE_PREFIX", Path:"db.table_prefix", PathText:"Database > Table prefix", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Table prefix (e.g. \"atk_\")"}, meta.OptionsMeta{Title:"Database type", Desc:"", Type:"string", Options:[]string{"sqlite", "mysql", "pgsql", "mssql"}, Env:"ATK_DB_TYPE", Path:"db.type", PathText:"Database > Database type", Default:"sqlite", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database type [\"sqlite\", \"mysql\", \"pgsql\", \"mssql\"]"}, meta.OptionsMeta{Title:"Database user", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_DB_USER", Path:"db.user", PathText:"Database > Database user", Default:"root", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database user"}, meta.OptionsMeta{Title:"Debug mode", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_DEBUG", Path:"debug", PathText:"Debug mode", Default:false, IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Debug mode"}, meta.OptionsMeta{Title:"Email", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL", Path:"email", PathText:"Email", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Email"}, meta.OptionsMeta{Title:"Aliyun mail push", Desc:"set send method to \"ali_dm\" to enable; see: https://help.aliyun.com/document_detail/29444.html", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_ALI_DM", Path:"email.ali_dm", PathText:"Email > Aliyun mail push", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Aliyun mail push (set send method to \"ali_dm\" to enable; see: https://help.aliyun.com/document_detail/29444.html)"}, meta.OptionsMeta{Title:"AccessKeyId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_ALI_DM_ACCESS_KEY_ID", Path:"email.ali_dm.access_key_id", PathText:"Email > Aliyun mail push > AccessKeyId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeyId"}, meta.OptionsMeta{Title:"AccessKeySecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_ALI_DM_ACCESS_KEY_SECRET", Path:"email.ali_dm.access_key_secret", PathText:"Email > Aliyun mail push > AccessKeySecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeySecret"}, meta.OptionsMeta{Title:"AccountName", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_ALI_DM_ACCOUNT_NAME", Path:"email.ali_dm.account_name", PathText:"Email > Aliyun mail push > AccountName", Default:"noreply@example.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccountName"}, meta.OptionsMeta{Title:"Digest mode", Desc:"batch the notifications of a user into a summary email sent periodically", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_DIGEST", Path:"email.digest", PathText:"Email > Digest mode", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Digest mode (batch the notifications of a user into a summary email sent periodically)"}, meta.OptionsMeta{Title:"Use digest for the users who have not set the preference", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_EMAIL_DIGEST_DEFAULT", Path:"email.digest.default", PathText:"Email > Digest mode > Use digest for the users who have not set the preference", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Use digest for the users who have not set the preference"}, meta.OptionsMeta{Title:"Enable", Desc:"users can choose instant or digest in the profile", Type:"bool", Options:[]string(nil), Env:"ATK_EMAIL_DIGEST_ENABLED", Path:"email.digest.enabled", PathText:"Email > Digest mode > Enable", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable (users can choose instant or digest in the profile)"}, meta.OptionsMeta{Title:"The interval of sending the digest", Desc:"hours", Type:"uint64", Options:[]string(nil), Env:"ATK_EMAIL_DIGEST_INTERVAL", Path:"email.digest.interval", PathText:"Email > Digest mode > The interval of sending the digest", Default:0x18, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The interval of sending the digest (hours)"}, meta.OptionsMeta{Title:"Enable email notification", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_EMAIL_ENABLED", Path:"email.enabled", PathText:"Email > Enable email notification", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable email notification"}, meta.OptionsMeta{Title:"Email subject", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_MAIL_SUBJECT", Path:"email.mail_subject", PathText:"Email > Email subject", Default:"[{{site_name}}] You got a reply from @{{reply_nick}}", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email subject"}, meta.OptionsMeta{Title:"Email template file", Desc:"set to file path to use custom template", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_MAIL_TPL", Path:"email.mail_tpl", PathText:"Email > Email template file", Default:"default", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email template file (set to file path to use custom template)"}, meta.OptionsMeta{Title:"Mailgun", Desc:"set send method to \"mailgun\" to enable", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_MAILGUN", Path:"email.mailgun", PathText:"Email > Mailgun", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Mailgun (set send method to \"mailgun\" to enable)"}, meta.OptionsMeta{Title:"ApiKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_MAILGUN_API_KEY", Path:"email.mailgun.api_key", PathText:"Email > Mailgun > ApiKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ApiKey"}, meta.OptionsMeta{Title:"Domain", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_MAILGUN_DOMAIN", Path:"email.mailgun.domain", PathText:"Email > Mailgun > Domain", Default:"mg.example.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Domain"}, meta.OptionsMeta{Title:"Region", Desc:"", Type:"string", Options:[]string{"us", "eu"}, Env:"ATK_EMAIL_MAILGUN_REGION", Path:"email.mailgun.region", PathText:"Email > Mailgun > Region", Default:"us", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Region [\"us\", \"eu\"]"}, meta.OptionsMeta{Title:"Webhook signing key", Desc:"https://example.com/api/v2/email/mailgun/webhook, the webhook is disabled if empty", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_MAILGUN_WEBHOOK_SIGNING_KEY", Path:"email.mailgun.webhook_signing_key", PathText:"Email > Mailgun > Webhook signing key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Webhook signing key (https://example.com/api/v2/email/mailgun/webhook, the webhook is disabled if empty)"}, meta.OptionsMeta{Title:"Postmark", Desc:"set send method to \"postmark\" to enable", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_POSTMARK", Path:"email.postmark", PathText:"Email > Postmark", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Postmark (set send method to \"postmark\" to enable)"}, meta.OptionsMeta{Title:"MessageStream", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_POSTMARK_MESSAGE_STREAM", Path:"email.postmark.message_stream", PathText:"Email > Postmark > MessageStream", Default:"outbound", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"MessageStream"}, meta.OptionsMeta{Title:"ServerToken", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_POSTMARK_SERVER_TOKEN", Path:"email.postmark.server_token", PathText:"Email > Postmark > ServerToken", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ServerToken"}, meta.OptionsMeta{Title:"Token of the webhook URL", Desc:"https://example.com/api/v2/email/postmark/webhook?token=..., the webhook is disabled if empty", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_POSTMARK_WEBHOOK_TOKEN", Path:"email.postmark.webhook_token", PathText:"Email > Postmark > Token of the webhook URL", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Token of the webhook URL (https://example.com/api/v2/email/postmark/webhook?token=..., the webhook is disabled if empty)"}, meta.OptionsMeta{Title:"Sending queue", Desc:"the emails are stored in the database and retried with backoff when failed", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_QUEUE", Path:"email.queue", PathText:"Email > Sending queue", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Sending queue (the emails are stored in the database and retried with backoff when failed)"}, meta.OptionsMeta{Title:"Max retries of a failed email , the emails still failed are listed in the dashboard", Desc:"-1 for no retry", Type:"uint64", Options:[]string(nil), Env:"ATK_EMAIL_QUEUE_MAX_RETRIES", Path:"email.queue.max_retries", PathText:"Email > Sending queue > Max retries of a failed email , the emails still failed are listed in the dashboard", Default:0x5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max retries of a failed email (-1 for no retry), the emails still failed are listed in the dashboard"}, meta.OptionsMeta{Title:"Number of concurrent senders", Desc:"the SMTP connections are reused", Type:"uint64", Options:[]string(nil), Env:"ATK_EMAIL_QUEUE_WORKERS", Path:"email.queue.workers", PathText:"Email > Sending queue > Number of concurrent senders", Default:0x1, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Number of concurrent senders (the SMTP connections are reused)"}, meta.OptionsMeta{Title:"Resend", Desc:"set send method to \"resend\" to enable", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_RESEND", Path:"email.resend", PathText:"Email > Resend", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Resend (set send method to \"resend\" to enable)"}, meta.OptionsMeta{Title:"ApiKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_RESEND_API_KEY", Path:"email.resend.api_key", PathText:"Email > Resend > ApiKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ApiKey"}, meta.OptionsMeta{Title:"Webhook signing secret starting with \"whsec_\"", Desc:"https://example.com/api/v2/email/resend/webhook, the webhook is disabled if empty", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_RESEND_WEBHOOK_SECRET", Path:"email.resend.webhook_secret", PathText:"Email > Resend > Webhook signing secret starting with \"whsec_\"", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Webhook signing secret starting with \"whsec_\" (https://example.com/api/v2/email/resend/webhook, the webhook is disabled if empty)"}, meta.OptionsMeta{Title:"Email address of sender", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SEND_ADDR", Path:"email.send_addr", PathText:"Email > Email address of sender", Default:"noreply@example.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email address of sender"}, meta.OptionsMeta{Title:"Nick name of sender", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SEND_NAME", Path:"email.send_name", PathText:"Email > Nick name of sender", Default:"{{reply_nick}}", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Nick name of sender"}, meta.OptionsMeta{Title:"Send method", Desc:"", Type:"string", Options:[]string{"smtp", "ali_dm", "sendmail", "ses", "mailgun", "postmark", "resend"}, Env:"ATK_EMAIL_SEND_TYPE", Path:"email.send_type", PathText:"Email > Send method", Default:"smtp", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Send method [\"smtp\", \"ali_dm\", \"sendmail\", \"ses\", \"mailgun\", \"postmark\", \"resend\"]"}, meta.OptionsMeta{Title:"AWS SES", Desc:"set send method to \"ses\" to enable", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_SES", Path:"email.ses", PathText:"Email > AWS SES", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"AWS SES (set send method to \"ses\" to enable)"}, meta.OptionsMeta{Title:"AccessKeyId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SES_ACCESS_KEY_ID", Path:"email.ses.access_key_id", PathText:"Email > AWS SES > AccessKeyId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeyId"}, meta.OptionsMeta{Title:"AccessKeySecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SES_ACCESS_KEY_SECRET", Path:"email.ses.access_key_secret", PathText:"Email > AWS SES > AccessKeySecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeySecret"}, meta.OptionsMeta{Title:"Configuration set", Desc:"to publish the bounce and complaint events to SNS", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SES_CONFIGURATION_SET", Path:"email.ses.configuration_set", PathText:"Email > AWS SES > Configuration set", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Configuration set (to publish the bounce and complaint events to SNS)"}, meta.OptionsMeta{Title:"Region", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SES_REGION", Path:"email.ses.region", PathText:"Email > AWS SES > Region", Default:"us-east-1", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Region"}, meta.OptionsMeta{Title:"Token of the SNS webhook URL", Desc:"https://example.com/api/v2/email/ses/webhook?token=..., the webhook is disabled if empty", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SES_WEBHOOK_TOKEN", Path:"email.ses.webhook_token", PathText:"Email > AWS SES > Token of the SNS webhook URL", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Token of the SNS webhook URL (https://example.com/api/v2/email/ses/webhook?token=..., the webhook is disabled if empty)"}, meta.OptionsMeta{Title:"SMTP send", Desc:"set send method to \"smtp\" to enable", Type:"<nil>", Options:[]string(nil), Env:"ATK_EMAIL_SMTP", Path:"email.smtp", PathText:"Email > SMTP send", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"SMTP send (set send method to \"smtp\" to enable)"}, meta.OptionsMeta{Title:"Email address of sender", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SMTP_HOST", Path:"email.smtp.host", PathText:"Email > SMTP send > Email address of sender", Default:"smtp.qq.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email address of sender"}, meta.OptionsMeta{Title:"Password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SMTP_PASSWORD", Path:"email.smtp.password", PathText:"Email > SMTP send > Password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Password"}, meta.OptionsMeta{Title:"Email port", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_EMAIL_SMTP_PORT", Path:"email.smtp.port", PathText:"Email > SMTP send > Email port", Default:0x24b, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email port"}, meta.OptionsMeta{Title:"Email address of sender", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_SMTP_USERNAME", Path:"email.smtp.username", PathText:"Email > SMTP send > Email address of sender", Default:"example@qq.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Email address of sender"}, meta.OptionsMeta{Title:"Unsubscribe URL embedded in every email", Desc:"https://example.com/api/v2/notify_preferences/unsubscribe, not embedded if empty", Type:"string", Options:[]string(nil), Env:"ATK_EMAIL_UNSUBSCRIBE_URL", Path:"email.unsubscribe_url", PathText:"Email > Unsubscribe URL embedded in every email", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Unsubscribe URL embedded in every email (https://example.com/api/v2/notify_preferences/unsubscribe, not embedded if empty)"}, meta.OptionsMeta{Title:"UI Settings", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_FRONTEND", Path:"frontend", PathText:"UI Settings", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"UI Settings"}, meta.OptionsMeta{Title:"Dark mode", Desc:"", Type:"string", Options:[]string{"inherit", "auto"}, Env:"ATK_FRONTEND_DARKMODE", Path:"frontend.darkMode", PathText:"UI Settings > Dark mode", Default:"inherit", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Dark mode [\"inherit\", \"auto\"]"}, meta.OptionsMeta{Title:"Movable comment box", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_EDITORTRAVEL", Path:"frontend.editorTravel", PathText:"UI Settings > Movable comment box", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Movable comment box"}, meta.OptionsMeta{Title:"Emoticons", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_EMOTICONS", Path:"frontend.emoticons", PathText:"UI Settings > Emoticons", Default:"https://cdn.jsdelivr.net/gh/ArtalkJS/Emoticons/grps/default.json", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Emoticons"}, meta.OptionsMeta{Title:"Flatten mode", Desc:"", Type:"string", Options:[]string{"auto", "true", "false"}, Env:"ATK_FRONTEND_FLATMODE", Path:"frontend.flatMode", PathText:"UI Settings > Flatten mode", Default:"auto", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Flatten mode [\"auto\", true, false]"}, meta.OptionsMeta{Title:"Gravatar", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_FRONTEND_GRAVATAR", Path:"frontend.gravatar", PathText:"UI Settings > Gravatar", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Gravatar"}, meta.OptionsMeta{Title:"API URL", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_GRAVATAR_MIRROR", Path:"frontend.gravatar.mirror", PathText:"UI Settings > Gravatar > API URL", Default:"https://www.gravatar.com/avatar/", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API URL"}, meta.OptionsMeta{Title:"API parameters", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_GRAVATAR_PARAMS", Path:"frontend.gravatar.params", PathText:"UI Settings > Gravatar > API parameters", Default:"sha256=1&d=mp&s=240", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API parameters"}, meta.OptionsMeta{Title:"Content height limit", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_FRONTEND_HEIGHTLIMIT", Path:"frontend.heightLimit", PathText:"UI Settings > Content height limit", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Content height limit"}, meta.OptionsMeta{Title:"Sub-comment area height limit", Desc:"unit: px", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_HEIGHTLIMIT_CHILDREN", Path:"frontend.heightLimit.children", PathText:"UI Settings > Content height limit > Sub-comment area height limit", Default:0x190, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Sub-comment area height limit (unit: px)"}, meta.OptionsMeta{Title:"Comment content height limit", Desc:"unit: px", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_HEIGHTLIMIT_CONTENT", Path:"frontend.heightLimit.content", PathText:"UI Settings > Content height limit > Comment content height limit", Default:0x12c, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Comment content height limit (unit: px)"}, meta.OptionsMeta{Title:"Scrollable", Desc:"scrollable height limit area", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_HEIGHTLIMIT_SCROLLABLE", Path:"frontend.heightLimit.scrollable", PathText:"UI Settings > Content height limit > Scrollable", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Scrollable (scrollable height limit area)"}, meta.OptionsMeta{Title:"Image lazy load", Desc:"", Type:"bool", Options:[]string{"false", "native", "data-src"}, Env:"ATK_FRONTEND_IMGLAZYLOAD", Path:"frontend.imgLazyLoad", PathText:"UI Settings > Image lazy load", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image lazy load [false, \"native\", \"data-src\"]"}, meta.OptionsMeta{Title:"Comment sorting", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_LISTSORT", Path:"frontend.listSort", PathText:"UI Settings > Comment sorting", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Comment sorting"}, meta.OptionsMeta{Title:"Maximum nesting level", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_NESTMAX", Path:"frontend.nestMax", PathText:"UI Settings > Maximum nesting level", Default:0x2, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Maximum nesting level"}, meta.OptionsMeta{Title:"Nesting comment sorting rules", Desc:"", Type:"string", Options:[]string{"DATE_ASC", "DATE_DESC", "VOTE_UP_DESC"}, Env:"ATK_FRONTEND_NESTSORT", Path:"frontend.nestSort", PathText:"UI Settings > Nesting comment sorting rules", Default:"DATE_ASC", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Nesting comment sorting rules [\"DATE_ASC\", \"DATE_DESC\", \"VOTE_UP_DESC\"]"}, meta.OptionsMeta{Title:"Text to display when there is", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_NOCOMMENT", Path:"frontend.noComment", PathText:"UI Settings > Text to display when there is", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Text to display when there is"}, meta.OptionsMeta{Title:"Comment pagination", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_FRONTEND_PAGINATION", Path:"frontend.pagination", PathText:"UI Settings > Comment pagination", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Comment pagination"}, meta.OptionsMeta{Title:"Scroll loading", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_PAGINATION_AUTOLOAD", Path:"frontend.pagination.autoLoad", PathText:"UI Settings > Comment pagination > Scroll loading", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Scroll loading"}, meta.OptionsMeta{Title:"Number of comments per page", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_PAGINATION_PAGESIZE", Path:"frontend.pagination.pageSize", PathText:"UI Settings > Comment pagination > Number of comments per page", Default:0x14, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Number of comments per page"}, meta.OptionsMeta{Title:"Load more mode", Desc:"disabled to use pagination bar", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_PAGINATION_READMORE", Path:"frontend.pagination.readMore", PathText:"UI Settings > Comment pagination > Load more mode", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Load more mode (disabled to use pagination bar)"}, meta.OptionsMeta{Title:"Comment box placeholder", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_PLACEHOLDER", Path:"frontend.placeholder", PathText:"UI Settings > Comment box placeholder", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Comment box placeholder"}, meta.OptionsMeta{Title:"Plugins", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_FRONTEND_PLUGINURLS", Path:"frontend.pluginURLs", PathText:"UI Settings > Plugins", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Plugins"}, meta.OptionsMeta{Title:"Editor real-time preview", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_PREVIEW", Path:"frontend.preview", PathText:"UI Settings > Editor real-time preview", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Editor real-time preview"}, meta.OptionsMeta{Title:"Request timeout", Desc:"unit: ms", Type:"uint64", Options:[]string(nil), Env:"ATK_FRONTEND_REQTIMEOUT", Path:"frontend.reqTimeout", PathText:"UI Settings > Request timeout", Default:0x3a98, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Request timeout (unit: ms)"}, meta.OptionsMeta{Title:"Text of the send button", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_FRONTEND_SENDBTN", Path:"frontend.sendBtn", PathText:"UI Settings > Text of the send button", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Text of the send button"}, meta.OptionsMeta{Title:"User UA badge", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_UABADGE", Path:"frontend.uaBadge", PathText:"UI Settings > User UA badge", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"User UA badge"}, meta.OptionsMeta{Title:"Version check", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_VERSIONCHECK", Path:"frontend.versionCheck", PathText:"UI Settings > Version check", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Version check"}, meta.OptionsMeta{Title:"Vote button", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_VOTE", Path:"frontend.vote", PathText:"UI Settings > Vote button", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Vote button"}, meta.OptionsMeta{Title:"Vote down button", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_FRONTEND_VOTEDOWN", Path:"frontend.voteDown", PathText:"UI Settings > Vote down button", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Vote down button"}, meta.OptionsMeta{Title:"Guest comment editing", Desc:"the guests can edit or delete their own comments by the edit token returned at creation", Type:"<nil>", Options:[]string(nil), Env:"ATK_GUEST_EDIT", Path:"guest_edit", PathText:"Guest comment editing", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Guest comment editing (the guests can edit or delete their own comments by the edit token returned at creation)"}, meta.OptionsMeta{Title:"Enable guest comment editing", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_GUEST_EDIT_ENABLED", Path:"guest_edit.enabled", PathText:"Guest comment editing > Enable guest comment editing", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable guest comment editing"}, meta.OptionsMeta{Title:"The time window to edit after the comment is created", Desc:"unit: second", Type:"uint64", Options:[]string(nil), Env:"ATK_GUEST_EDIT_WINDOW", Path:"guest_edit.window", PathText:"Guest comment editing > The time window to edit after the comment is created", Default:0x12c, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The time window to edit after the comment is created (unit: second)"}, meta.OptionsMeta{Title:"Listen host", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_HOST", Path:"host", PathText:"Listen host", Default:"0.0.0.0", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Listen host"}, meta.OptionsMeta{Title:"Web server", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_HTTP", Path:"http", PathText:"Web server", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Web server"}, meta.OptionsMeta{Title:"Body size limit", Desc:"unit: MB", Type:"uint64", Options:[]string(nil), Env:"ATK_HTTP_BODY_LIMIT", Path:"http.body_limit", PathText:"Web server > Body size limit", Default:0x64, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Body size limit (unit: MB)"}, meta.OptionsMeta{Title:"Outbound proxy for AI, Akismet and captcha verification requests", Desc:"e.g. \"http://127.0.0.1:7890\", \"socks5://127.0.0.1:1080\"", Type:"string", Options:[]string(nil), Env:"ATK_HTTP_OUTBOUND_PROXY", Path:"http.outbound_proxy", PathText:"Web server > Outbound proxy for AI, Akismet and captcha verification requests", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Outbound proxy for AI, Akismet and captcha verification requests (e.g. \"http://127.0.0.1:7890\", \"socks5://127.0.0.1:1080\")"}, meta.OptionsMeta{Title:"Proxy Header", Desc:"fill `X-Forwarded-For` to get user real IP if behind a trusted reverse proxy or CDN", Type:"string", Options:[]string(nil), Env:"ATK_HTTP_PROXY_HEADER", Path:"http.proxy_header", PathText:"Web server > Proxy Header", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Proxy Header (fill `X-Forwarded-For` to get user real IP if behind a trusted reverse proxy or CDN)"}, meta.OptionsMeta{Title:"Enable SO_REUSEPORT, so the new process can bind the same port before the old one exits", Desc:"for zero-downtime upgrades", Type:"bool", Options:[]string(nil), Env:"ATK_HTTP_REUSE_PORT", Path:"http.reuse_port", PathText:"Web server > Enable SO_REUSEPORT, so the new process can bind the same port before the old one exits", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable SO_REUSEPORT, so the new process can bind the same port before the old one exits (for zero-downtime upgrades)"}, meta.OptionsMeta{Title:"Graceful shutdown timeout for draining the in-flight requests and background tasks", Desc:"unit: s", Type:"uint64", Options:[]string(nil), Env:"ATK_HTTP_SHUTDOWN_TIMEOUT", Path:"http.shutdown_timeout", PathText:"Web server > Graceful shutdown timeout for draining the in-flight requests and background tasks", Default:0x1e, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Graceful shutdown timeout for draining the in-flight requests and background tasks (unit: s)"}, meta.OptionsMeta{Title:"Upload", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD", Path:"img_upload", PathText:"Upload", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Upload"}, meta.OptionsMeta{Title:"Enable image upload", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_ENABLED", Path:"img_upload.enabled", PathText:"Upload > Enable image upload", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable image upload"}, meta.OptionsMeta{Title:"Delete the uploaded files not referenced by any comment", Desc:"e.g. the images of deleted comments", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_GC", Path:"img_upload.gc", PathText:"Upload > Delete the uploaded files not referenced by any comment", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Delete the uploaded files not referenced by any comment (e.g. the images of deleted comments)"}, meta.OptionsMeta{Title:"Enable the scheduled garbage collection", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_GC_ENABLED", Path:"img_upload.gc.enabled", PathText:"Upload > Delete the uploaded files not referenced by any comment > Enable the scheduled garbage collection", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable the scheduled garbage collection"}, meta.OptionsMeta{Title:"Grace period", Desc:"unit: hours, the files uploaded within it are kept", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_GC_GRACE_PERIOD", Path:"img_upload.gc.grace_period", PathText:"Upload > Delete the uploaded files not referenced by any comment > Grace period", Default:0x48, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Grace period (unit: hours, the files uploaded within it are kept)"}, meta.OptionsMeta{Title:"Interval", Desc:"unit: hours", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_GC_INTERVAL", Path:"img_upload.gc.interval", PathText:"Upload > Delete the uploaded files not referenced by any comment > Interval", Default:0x18, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Interval (unit: hours)"}, meta.OptionsMeta{Title:"Image size limit", Desc:"unit: MB", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_MAX_SIZE", Path:"img_upload.max_size", PathText:"Upload > Image size limit", Default:0x5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image size limit (unit: MB)"}, meta.OptionsMeta{Title:"Image storage", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PATH", Path:"img_upload.path", PathText:"Upload > Image storage", Default:"./data/artalk-img/", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image storage"}, meta.OptionsMeta{Title:"Upload quota and file type policy", Desc:"can be overridden per site", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_POLICY", Path:"img_upload.policy", PathText:"Upload > Upload quota and file type policy", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Upload quota and file type policy (can be overridden per site)"}, meta.OptionsMeta{Title:"Allowed file types", Desc:"the MIME types sniffed from the content, all supported images are allowed if empty", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_POLICY_ALLOWED_TYPES", Path:"img_upload.policy.allowed_types", PathText:"Upload > Upload quota and file type policy > Allowed file types", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Allowed file types (the MIME types sniffed from the content, all supported images are allowed if empty)"}, meta.OptionsMeta{Title:"Daily upload count per user", Desc:"guests are counted by the IP, admins are not limited, 0 is unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_POLICY_DAILY_COUNT", Path:"img_upload.policy.daily_count", PathText:"Upload > Upload quota and file type policy > Daily upload count per user", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Daily upload count per user (guests are counted by the IP, admins are not limited, 0 is unlimited)"}, meta.OptionsMeta{Title:"Daily upload size per user", Desc:"unit: MB, 0 is unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_POLICY_DAILY_SIZE", Path:"img_upload.policy.daily_size", PathText:"Upload > Upload quota and file type policy > Daily upload size per user", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Daily upload size per user (unit: MB, 0 is unlimited)"}, meta.OptionsMeta{Title:"Size limit of a file", Desc:"unit: MB, 0 is to use `img_upload.max_size`", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_POLICY_MAX_SIZE", Path:"img_upload.policy.max_size", PathText:"Upload > Upload quota and file type policy > Size limit of a file", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Size limit of a file (unit: MB, 0 is to use `img_upload.max_size`)"}, meta.OptionsMeta{Title:"Image processing", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PROCESS", Path:"img_upload.process", PathText:"Upload > Image processing", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Image processing"}, meta.OptionsMeta{Title:"Enable processing the uploaded images", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PROCESS_ENABLED", Path:"img_upload.process.enabled", PathText:"Upload > Image processing > Enable processing the uploaded images", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable processing the uploaded images"}, meta.OptionsMeta{Title:"Convert format", Desc:"", Type:"string", Options:[]string{"", "jpeg", "png", "webp", "avif"}, Env:"ATK_IMG_UPLOAD_PROCESS_FORMAT", Path:"img_upload.process.format", PathText:"Upload > Image processing > Convert format", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Convert format [\"\", \"jpeg\", \"png\", \"webp\", \"avif\"] -- The original format is kept if empty, \"webp\" and \"avif\" require the `cwebp` and `avifenc` commands --"}, meta.OptionsMeta{Title:"Max height", Desc:"0 is unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PROCESS_MAX_HEIGHT", Path:"img_upload.process.max_height", PathText:"Upload > Image processing > Max height", Default:0x780, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max height (0 is unlimited)"}, meta.OptionsMeta{Title:"Max pixels", Desc:"unit: megapixels, 0 is the default 40, the larger images are rejected", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PROCESS_MAX_PIXELS", Path:"img_upload.process.max_pixels", PathText:"Upload > Image processing > Max pixels", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max pixels (unit: megapixels, 0 is the default 40, the larger images are rejected)"}, meta.OptionsMeta{Title:"Max width", Desc:"0 is unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PROCESS_MAX_WIDTH", Path:"img_upload.process.max_width", PathText:"Upload > Image processing > Max width", Default:0x780, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max width (0 is unlimited)"}, meta.OptionsMeta{Title:"Image quality", Desc:"1-100", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PROCESS_QUALITY", Path:"img_upload.process.quality", PathText:"Upload > Image processing > Image quality", Default:0x55, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image quality (1-100)"}, meta.OptionsMeta{Title:"Strip the EXIF metadata", Desc:"GPS location, device, etc.", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PROCESS_STRIP_METADATA", Path:"img_upload.process.strip_metadata", PathText:"Upload > Image processing > Strip the EXIF metadata", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Strip the EXIF metadata (GPS location, device, etc.)"}, meta.OptionsMeta{Title:"Thumbnail width", Desc:"0 is disabled", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PROCESS_THUMBNAIL_WIDTH", Path:"img_upload.process.thumbnail_width", PathText:"Upload > Image processing > Thumbnail width", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Thumbnail width (0 is disabled)"}, meta.OptionsMeta{Title:"Image link base path", Desc:"default: \"/static/images/\"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_PUBLIC_PATH", Path:"img_upload.public_path", PathText:"Upload > Image link base path", Default:interface {}(nil), IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image link base path (default: \"/static/images/\")"}, meta.OptionsMeta{Title:"S3 compatible storage", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3", Path:"img_upload.s3", PathText:"Upload > S3 compatible storage", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"S3 compatible storage"}, meta.OptionsMeta{Title:"Access key", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_ACCESS_KEY", Path:"img_upload.s3.access_key", PathText:"Upload > S3 compatible storage > Access key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Access key"}, meta.OptionsMeta{Title:"Bucket", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_BUCKET", Path:"img_upload.s3.bucket", PathText:"Upload > S3 compatible storage > Bucket", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Bucket"}, meta.OptionsMeta{Title:"Endpoint", Desc:"AWS S3 if empty, e.g. \"https://<account_id>.r2.cloudflarestorage.com\"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_ENDPOINT", Path:"img_upload.s3.endpoint", PathText:"Upload > S3 compatible storage > Endpoint", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Endpoint (AWS S3 if empty, e.g. \"https://<account_id>.r2.cloudflarestorage.com\")"}, meta.OptionsMeta{Title:"Use the path-style URL", Desc:"required by MinIO, etc.", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_PATH_STYLE", Path:"img_upload.s3.path_style", PathText:"Upload > S3 compatible storage > Use the path-style URL", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Use the path-style URL (required by MinIO, etc.)"}, meta.OptionsMeta{Title:"Object key prefix", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_PREFIX", Path:"img_upload.s3.prefix", PathText:"Upload > S3 compatible storage > Object key prefix", Default:"artalk-img", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Object key prefix"}, meta.OptionsMeta{Title:"Allow uploading to the bucket directly by the presigned URL", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_PRESIGN", Path:"img_upload.s3.presign", PathText:"Upload > S3 compatible storage > Allow uploading to the bucket directly by the presigned URL", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Allow uploading to the bucket directly by the presigned URL"}, meta.OptionsMeta{Title:"Expiration of presigned URL", Desc:"unit: seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_PRESIGN_EXPIRES", Path:"img_upload.s3.presign_expires", PathText:"Upload > S3 compatible storage > Expiration of presigned URL", Default:0x12c, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Expiration of presigned URL (unit: seconds)"}, meta.OptionsMeta{Title:"Image link prefix", Desc:"e.g. \"https://cdn.example.com\", the bucket URL is used if empty", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_PUBLIC_URL", Path:"img_upload.s3.public_url", PathText:"Upload > S3 compatible storage > Image link prefix", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image link prefix (e.g. \"https://cdn.example.com\", the bucket URL is used if empty)"}, meta.OptionsMeta{Title:"Region", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_REGION", Path:"img_upload.s3.region", PathText:"Upload > S3 compatible storage > Region", Default:"us-east-1", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Region"}, meta.OptionsMeta{Title:"Secret key", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_S3_SECRET_KEY", Path:"img_upload.s3.secret_key", PathText:"Upload > S3 compatible storage > Secret key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Secret key"}, meta.OptionsMeta{Title:"Storage of images", Desc:"", Type:"string", Options:[]string{"local", "s3"}, Env:"ATK_IMG_UPLOAD_STORAGE", Path:"img_upload.storage", PathText:"Upload > Storage of images", Default:"local", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Storage of images [\"local\", \"s3\"] -- \"s3\" stores the images in the S3 compatible object storage (AWS S3, MinIO, R2, OSS, COS, etc.), which can be shared by multiple nodes --"}, meta.OptionsMeta{Title:"Upgit config", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_UPGIT", Path:"img_upload.upgit", PathText:"Upload > Upgit config", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Upgit config"}, meta.OptionsMeta{Title:"Delete local image after upload success", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_UPGIT_DEL_LOCAL", Path:"img_upload.upgit.del_local", PathText:"Upload > Upgit config > Delete local image after upload success", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Delete local image after upload success"}, meta.OptionsMeta{Title:"Enable Upgit", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_UPGIT_ENABLED", Path:"img_upload.upgit.enabled", PathText:"Upload > Upgit config > Enable Upgit", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable Upgit"}, meta.OptionsMeta{Title:"Command line arguments", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_IMG_UPLOAD_UPGIT_EXEC", Path:"img_upload.upgit.exec", PathText:"Upload > Upgit config > Command line arguments", Default:"upgit -c UPGIT_CONF_FILE_PATH -t /artalk-img", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Command line arguments"}, meta.OptionsMeta{Title:"IP privacy", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IP_PRIVACY", Path:"ip_privacy", PathText:"IP privacy", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"IP privacy"}, meta.OptionsMeta{Title:"Anonymize the stored IPs  - truncate: drop the last octet of IPv4 and keep the /64 prefix of IPv6 - hash: replace the IP with the hash by a rotating salt", Desc:"comparable within the rotation period", Type:"string", Options:[]string{"", "truncate", "hash"}, Env:"ATK_IP_PRIVACY_ANONYMIZE", Path:"ip_privacy.anonymize", PathText:"IP privacy > Anonymize the stored IPs  - truncate: drop the last octet of IPv4 and keep the /64 prefix of IPv6 - hash: replace the IP with the hash by a rotating salt", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Anonymize the stored IPs [\"\", \"truncate\", \"hash\"] - truncate: drop the last octet of IPv4 and keep the /64 prefix of IPv6 - hash: replace the IP with the hash by a rotating salt (comparable within the rotation period)"}, meta.OptionsMeta{Title:"Salt rotation period of the hash mode", Desc:"hours", Type:"uint64", Options:[]string(nil), Env:"ATK_IP_PRIVACY_HASH_ROTATION", Path:"ip_privacy.hash_rotation", PathText:"IP privacy > Salt rotation period of the hash mode", Default:0x18, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Salt rotation period of the hash mode (hours)"}, meta.OptionsMeta{Title:"Purge the IPs of comments older than the days", Desc:"0 to keep forever", Type:"uint64", Options:[]string(nil), Env:"ATK_IP_PRIVACY_RETENTION", Path:"ip_privacy.retention", PathText:"IP privacy > Purge the IPs of comments older than the days", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Purge the IPs of comments older than the days (0 to keep forever)"}, meta.OptionsMeta{Title:"IP Region", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_IP_REGION", Path:"ip_region", PathText:"IP Region", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"IP Region"}, meta.OptionsMeta{Title:"Only visible to admins", Desc:"the region is still stored and used for moderation", Type:"bool", Options:[]string(nil), Env:"ATK_IP_REGION_ADMIN_ONLY", Path:"ip_region.admin_only", PathText:"IP Region > Only visible to admins", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Only visible to admins (the region is still stored and used for moderation)"}, meta.OptionsMeta{Title:"Database file path", Desc:".xdb for ip2region, .mmdb for MaxMind GeoLite2 / GeoIP2", Type:"string", Options:[]string(nil), Env:"ATK_IP_REGION_DB_PATH", Path:"ip_region.db_path", PathText:"IP Region > Database file path", Default:"./data/ip2region.xdb", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database file path (.xdb for ip2region, .mmdb for MaxMind GeoLite2 / GeoIP2)"}, meta.OptionsMeta{Title:"Enable the IP region display", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_IP_REGION_ENABLED", Path:"ip_region.enabled", PathText:"IP Region > Enable the IP region display", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable the IP region display"}, meta.OptionsMeta{Title:"Language of the place names", Desc:"MaxMind only, e.g. \"en\", \"zh-CN\", \"ja\"", Type:"string", Options:[]string(nil), Env:"ATK_IP_REGION_LOCALE", Path:"ip_region.locale", PathText:"IP Region > Language of the place names", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Language of the place names (MaxMind only, e.g. \"en\", \"zh-CN\", \"ja\")"}, meta.OptionsMeta{Title:"Display precision", Desc:"", Type:"string", Options:[]string{"province", "city", "country"}, Env:"ATK_IP_REGION_PRECISION", Path:"ip_region.precision", PathText:"IP Region > Display precision", Default:"province", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Display precision [\"province\", \"city\", \"country\"]"}, meta.OptionsMeta{Title:"Database provider", Desc:"detected by the file extension if empty", Type:"string", Options:[]string{"ip2region", "maxmind"}, Env:"ATK_IP_REGION_PROVIDER", Path:"ip_region.provider", PathText:"IP Region > Database provider", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Database provider [\"ip2region\", \"maxmind\"] (detected by the file extension if empty)"}, meta.OptionsMeta{Title:"Language", Desc:"follow Unicode BCP 47", Type:"string", Options:[]string{"en", "zh-CN", "zh-TW", "ja", "fr", "ko", "ru"}, Env:"ATK_LOCALE", Path:"locale", PathText:"Language", Default:"en", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Language (follow Unicode BCP 47) [\"en\", \"zh-CN\", \"zh-TW\", \"ja\", \"fr\", \"ko\", \"ru\"] -- see https://www.techonthenet.com/js/language_tags.php --"}, meta.OptionsMeta{Title:"Logging", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_LOG", Path:"log", PathText:"Logging", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Logging"}, meta.OptionsMeta{Title:"Compress old log files", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_LOG_COMPRESS", Path:"log.compress", PathText:"Logging > Compress old log files", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Compress old log files"}, meta.OptionsMeta{Title:"Enable logging", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_LOG_ENABLED", Path:"log.enabled", PathText:"Logging > Enable logging", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable logging"}, meta.OptionsMeta{Title:"Log file path", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_LOG_FILENAME", Path:"log.filename", PathText:"Logging > Log file path", Default:"./data/artalk.log", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Log file path"}, meta.OptionsMeta{Title:"Console log format", Desc:"the log file is always in JSON", Type:"string", Options:[]string{"text", "json"}, Env:"ATK_LOG_FORMAT", Path:"log.format", PathText:"Logging > Console log format", Default:"text", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Console log format [\"text\", \"json\"] (the log file is always in JSON)"}, meta.OptionsMeta{Title:"Log level", Desc:"default is \"info\", or \"debug\" in debug mode", Type:"string", Options:[]string{"debug", "info", "warn", "error"}, Env:"ATK_LOG_LEVEL", Path:"log.level", PathText:"Logging > Log level", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Log level [\"debug\", \"info\", \"warn\", \"error\"] (default is \"info\", or \"debug\" in debug mode)"}, meta.OptionsMeta{Title:"Max days to retain old log files", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_LOG_MAX_AGE", Path:"log.max_age", PathText:"Logging > Max days to retain old log files", Default:0xf, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max days to retain old log files"}, meta.OptionsMeta{Title:"Max number of old log files to retain", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_LOG_MAX_BACKUPS", Path:"log.max_backups", PathText:"Logging > Max number of old log files to retain", Default:0x1e, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max number of old log files to retain"}, meta.OptionsMeta{Title:"Max size of log file before rotation", Desc:"in MB", Type:"uint64", Options:[]string(nil), Env:"ATK_LOG_MAX_SIZE", Path:"log.max_size", PathText:"Logging > Max size of log file before rotation", Default:0x1f4, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max size of log file before rotation (in MB)"}, meta.OptionsMeta{Title:"Log levels by module, the module is the tag at the beginning of messages, e.g. { AntiSpam: debug, HTTP: warn }", Desc:"", Type:"map[string]interface {}", Options:[]string(nil), Env:"ATK_LOG_MODULES", Path:"log.modules", PathText:"Logging > Log levels by module, the module is the tag at the beginning of messages, e.g. { AntiSpam: debug, HTTP: warn }", Default:map[string]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Log levels by module, the module is the tag at the beginning of messages, e.g. { AntiSpam: debug, HTTP: warn }"}, meta.OptionsMeta{Title:"Login timeout", Desc:"in seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_LOGIN_TIMEOUT", Path:"login_timeout", PathText:"Login timeout", Default:0x3f480, IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Login timeout (in seconds)"}, meta.OptionsMeta{Title:"Comment content rendering", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_MARKDOWN", Path:"markdown", PathText:"Comment content rendering", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Comment content rendering"}, meta.OptionsMeta{Title:"Extra allowed HTML tags", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_MARKDOWN_ALLOWED_TAGS", Path:"markdown.allowed_tags", PathText:"Comment content rendering > Extra allowed HTML tags", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Extra allowed HTML tags"}, meta.OptionsMeta{Title:"Keep the language class of code blocks for code highlighting", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MARKDOWN_HIGHLIGHT_CODE", Path:"markdown.highlight_code", PathText:"Comment content rendering > Keep the language class of code blocks for code highlighting", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Keep the language class of code blocks for code highlighting"}, meta.OptionsMeta{Title:"Image policy", Desc:"", Type:"string", Options:[]string{"allow", "https", "none"}, Env:"ATK_MARKDOWN_IMAGE_POLICY", Path:"markdown.image_policy", PathText:"Comment content rendering > Image policy", Default:"allow", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Image policy [\"allow\", \"https\", \"none\"]"}, meta.OptionsMeta{Title:"The rel attribute of links", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_MARKDOWN_LINK_REL", Path:"markdown.link_rel", PathText:"Comment content rendering > The rel attribute of links", Default:[]interface {}{"nofollow", "noreferrer"}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The rel attribute of links"}, meta.OptionsMeta{Title:"Sanitization profile", Desc:"", Type:"string", Options:[]string{"default", "strict"}, Env:"ATK_MARKDOWN_PROFILE", Path:"markdown.profile", PathText:"Comment content rendering > Sanitization profile", Default:"default", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Sanitization profile [\"default\", \"strict\"]"}, meta.OptionsMeta{Title:"Metrics", Desc:"Prometheus", Type:"<nil>", Options:[]string(nil), Env:"ATK_METRICS", Path:"metrics", PathText:"Metrics", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Metrics (Prometheus)"}, meta.OptionsMeta{Title:"Enable metrics endpoint", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_METRICS_ENABLED", Path:"metrics.enabled", PathText:"Metrics > Enable metrics endpoint", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable metrics endpoint"}, meta.OptionsMeta{Title:"Metrics endpoint path", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_METRICS_PATH", Path:"metrics.path", PathText:"Metrics > Metrics endpoint path", Default:"/metrics", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Metrics endpoint path"}, meta.OptionsMeta{Title:"Access token", Desc:"required in the header \"Authorization: Bearer TOKEN\", not checked if empty", Type:"string", Options:[]string(nil), Env:"ATK_METRICS_TOKEN", Path:"metrics.token", PathText:"Metrics > Access token", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Access token (required in the header \"Authorization: Bearer TOKEN\", not checked if empty)"}, meta.OptionsMeta{Title:"Moderator", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR", Path:"moderator", PathText:"Moderator", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Moderator -- Comment examination before being public --"}, meta.OptionsMeta{Title:"AI Comment Moderation", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_AI", Path:"moderator.ai", PathText:"Moderator > AI Comment Moderation", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"AI Comment Moderation"}, meta.OptionsMeta{Title:"API Key", Desc:"optional for Ollama", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_AI_API_KEY", Path:"moderator.ai.api_key", PathText:"Moderator > AI Comment Moderation > API Key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API Key (optional for Ollama)"}, meta.OptionsMeta{Title:"Circuit breaker, temporarily disable the AI checker after consecutive failures and fall back to other checkers", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_AI_CIRCUIT_BREAKER", Path:"moderator.ai.circuit_breaker", PathText:"Moderator > AI Comment Moderation > Circuit breaker, temporarily disable the AI checker after consecutive failures and fall back to other checkers", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Circuit breaker, temporarily disable the AI checker after consecutive failures and fall back to other checkers"}, meta.OptionsMeta{Title:"Cooldown duration", Desc:"unit: seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_AI_CIRCUIT_BREAKER_COOLDOWN", Path:"moderator.ai.circuit_breaker.cooldown", PathText:"Moderator > AI Comment Moderation > Circuit breaker, temporarily disable the AI checker after consecutive failures and fall back to other checkers > Cooldown duration", Default:0x3c, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cooldown duration (unit: seconds)"}, meta.OptionsMeta{Title:"Consecutive failures to open the circuit", Desc:"0 for disabled", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_AI_CIRCUIT_BREAKER_THRESHOLD", Path:"moderator.ai.circuit_breaker.threshold", PathText:"Moderator > AI Comment Moderation > Circuit breaker, temporarily disable the AI checker after consecutive failures and fall back to other checkers > Consecutive failures to open the circuit", Default:0x5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Consecutive failures to open the circuit (0 for disabled)"}, meta.OptionsMeta{Title:"Dry-run mode, the verdict is only recorded in logs and comment metadata, never blocks", Desc:"to evaluate the false-positive rate before enforcement", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_AI_DRY_RUN", Path:"moderator.ai.dry_run", PathText:"Moderator > AI Comment Moderation > Dry-run mode, the verdict is only recorded in logs and comment metadata, never blocks", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Dry-run mode, the verdict is only recorded in logs and comment metadata, never blocks (to evaluate the false-positive rate before enforcement)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_AI_ENABLED", Path:"moderator.ai.enabled", PathText:"Moderator > AI Comment Moderation > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Decision when the AI API request is failed", Desc:"if empty, follow the `api_fail_block` option", Type:"string", Options:[]string{"pass", "block", "pending"}, Env:"ATK_MODERATOR_AI_ERROR_DECISION", Path:"moderator.ai.error_decision", PathText:"Moderator > AI Comment Moderation > Decision when the AI API request is failed", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Decision when the AI API request is failed [\"pass\", \"block\", \"pending\"] (if empty, follow the `api_fail_block` option)"}, meta.OptionsMeta{Title:"Extra headers of API request", Desc:"placeholders: {{api_key}}, e.g. `api-key: \"{{api_key}}\"` for Azure OpenAI, set to empty to remove the default header", Type:"map[string]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_AI_HEADERS", Path:"moderator.ai.headers", PathText:"Moderator > AI Comment Moderation > Extra headers of API request", Default:map[string]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Extra headers of API request (placeholders: {{api_key}}, e.g. `api-key: \"{{api_key}}\"` for Azure OpenAI, set to empty to remove the default header)"}, meta.OptionsMeta{Title:"Number of the author's recent comments included in the prompt", Desc:"0 for none", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_AI_HISTORY_SIZE", Path:"moderator.ai.history_size", PathText:"Moderator > AI Comment Moderation > Number of the author's recent comments included in the prompt", Default:0x5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Number of the author's recent comments included in the prompt (0 for none)"}, meta.OptionsMeta{Title:"API Host", Desc:"leave empty to use the default host of provider, e.g. \"api.openai.com\", \"http://localhost:11434\" for Ollama", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_AI_HOST", Path:"moderator.ai.host", PathText:"Moderator > AI Comment Moderation > API Host", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API Host (leave empty to use the default host of provider, e.g. \"api.openai.com\", \"http://localhost:11434\" for Ollama)"}, meta.OptionsMeta{Title:"Per-language policy", Desc:"the language is detected from the comment content", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_AI_LANGUAGES", Path:"moderator.ai.languages", PathText:"Moderator > AI Comment Moderation > Per-language policy", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Per-language policy (the language is detected from the comment content)"}, meta.OptionsMeta{Title:"Allowed languages", Desc:"ISO 639-1 codes, e.g. \"zh\", \"en\"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_AI_LANGUAGES_ALLOWLIST", Path:"moderator.ai.languages.allowlist", PathText:"Moderator > AI Comment Moderation > Per-language policy > Allowed languages", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Allowed languages (ISO 639-1 codes, e.g. \"zh\", \"en\")"}, meta.OptionsMeta{Title:"Hold the comments in languages not on the allowlist for manual review", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_AI_LANGUAGES_HOLD_UNLISTED", Path:"moderator.ai.languages.hold_unlisted", PathText:"Moderator > AI Comment Moderation > Per-language policy > Hold the comments in languages not on the allowlist for manual review", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Hold the comments in languages not on the allowlist for manual review"}, meta.OptionsMeta{Title:"Policies of each language", Desc:"keyed by the language code", Type:"map[string]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_AI_LANGUAGES_POLICIES", Path:"moderator.ai.languages.policies", PathText:"Moderator > AI Comment Moderation > Per-language policy > Policies of each language", Default:map[string]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Policies of each language (keyed by the language code)"}, meta.OptionsMeta{Title:"Rate limit and budget", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_AI_LIMIT", Path:"moderator.ai.limit", PathText:"Moderator > AI Comment Moderation > Rate limit and budget", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Rate limit and budget"}, meta.OptionsMeta{Title:"Fallback when the limit is exhausted", Desc:"keywords: fall back to the keyword filter dictionary", Type:"string", Options:[]string{"pass", "pending", "keywords"}, Env:"ATK_MODERATOR_AI_LIMIT_FALLBACK", Path:"moderator.ai.limit.fallback", PathText:"Moderator > AI Comment Moderation > Rate limit and budget > Fallback when the limit is exhausted", Default:"pass", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Fallback when the limit is exhausted [\"pass\", \"pending\", \"keywords\"] (keywords: fall back to the keyword filter dictionary)"}, meta.OptionsMeta{Title:"Max requests per month", Desc:"0 for unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_AI_LIMIT_MONTHLY_REQUESTS", Path:"moderator.ai.limit.monthly_requests", PathText:"Moderator > AI Comment Moderation > Rate limit and budget > Max requests per month", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max requests per month (0 for unlimited)"}, meta.OptionsMeta{Title:"Max tokens per month", Desc:"0 for unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_AI_LIMIT_MONTHLY_TOKENS", Path:"moderator.ai.limit.monthly_tokens", PathText:"Moderator > AI Comment Moderation > Rate limit and budget > Max tokens per month", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max tokens per month (0 for unlimited)"}, meta.OptionsMeta{Title:"Max requests per minute", Desc:"0 for unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_AI_LIMIT_PER_MINUTE", Path:"moderator.ai.limit.per_minute", PathText:"Moderator > AI Comment Moderation > Rate limit and budget > Max requests per minute", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max requests per minute (0 for unlimited)"}, meta.OptionsMeta{Title:"Max tokens of the AI response", Desc:"0 to use the default of provider", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_AI_MAX_TOKENS", Path:"moderator.ai.max_tokens", PathText:"Moderator > AI Comment Moderation > Max tokens of the AI response", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max tokens of the AI response (0 to use the default of provider)"}, meta.OptionsMeta{Title:"Model name", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_AI_MODEL", Path:"moderator.ai.model", PathText:"Moderator > AI Comment Moderation > Model name", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Model name"}, meta.OptionsMeta{Title:"API path", Desc:"leave empty to use the default path of provider, placeholders: {{model}}, e.g. \"/openai/deployments/{{model}}/chat/completions\" for Azure OpenAI", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_AI_PATH", Path:"moderator.ai.path", PathText:"Moderator > AI Comment Moderation > API path", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API path (leave empty to use the default path of provider, placeholders: {{model}}, e.g. \"/openai/deployments/{{model}}/chat/completions\" for Azure OpenAI)"}, meta.OptionsMeta{Title:"Prompt template", Desc:"leave empty to use the built-in prompt, placeholders: {{author}}, {{email}}, {{content}}, {{page_url}}, {{page_title}}, {{site_name}}, {{parent_content}}, {{parent_author}}, {{history}}, {{examples}}, {{language}}, {{context}}", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_AI_PROMPT_TEMPLATE", Path:"moderator.ai.prompt_template", PathText:"Moderator > AI Comment Moderation > Prompt template", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Prompt template (leave empty to use the built-in prompt, placeholders: {{author}}, {{email}}, {{content}}, {{page_url}}, {{page_title}}, {{site_name}}, {{parent_content}}, {{parent_author}}, {{history}}, {{examples}}, {{language}}, {{context}})"}, meta.OptionsMeta{Title:"Provider", Desc:"use \"openai\" for OpenAI compatible API", Type:"string", Options:[]string{"openai", "anthropic", "gemini", "ollama"}, Env:"ATK_MODERATOR_AI_PROVIDER", Path:"moderator.ai.provider", PathText:"Moderator > AI Comment Moderation > Provider", Default:"openai", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Provider [\"openai\", \"anthropic\", \"gemini\", \"ollama\"] (use \"openai\" for OpenAI compatible API)"}, meta.OptionsMeta{Title:"Proxy for AI provider", Desc:"leave empty to use `http.outbound_proxy`, \"direct\" for no proxy", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_AI_PROXY", Path:"moderator.ai.proxy", PathText:"Moderator > AI Comment Moderation > Proxy for AI provider", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Proxy for AI provider (leave empty to use `http.outbound_proxy`, \"direct\" for no proxy)"}, meta.OptionsMeta{Title:"Extra query parameters of API request", Desc:"e.g. `api-version: \"2024-10-21\"` for Azure OpenAI", Type:"map[string]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_AI_QUERY", Path:"moderator.ai.query", PathText:"Moderator > AI Comment Moderation > Extra query parameters of API request", Default:map[string]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Extra query parameters of API request (e.g. `api-version: \"2024-10-21\"` for Azure OpenAI)"}, meta.OptionsMeta{Title:"Response format", Desc:"JSON mode forces the response to be a JSON object, not supported by Anthropic", Type:"string", Options:[]string{"text", "json"}, Env:"ATK_MODERATOR_AI_RESPONSE_FORMAT", Path:"moderator.ai.response_format", PathText:"Moderator > AI Comment Moderation > Response format", Default:"text", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Response format [\"text\", \"json\"] (JSON mode forces the response to be a JSON object, not supported by Anthropic)"}, meta.OptionsMeta{Title:"Retry on transient errors", Desc:"network error, HTTP 429 and 5xx", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_AI_RETRY", Path:"moderator.ai.retry", PathText:"Moderator > AI Comment Moderation > Retry on transient errors", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Retry on transient errors (network error, HTTP 429 and 5xx)"}, meta.OptionsMeta{Title:"Initial backoff interval", Desc:"unit: milliseconds, doubled after each retry", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_AI_RETRY_BACKOFF", Path:"moderator.ai.retry.backoff", PathText:"Moderator > AI Comment Moderation > Retry on transient errors > Initial backoff interval", Default:0x1f4, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Initial backoff interval (unit: milliseconds, doubled after each retry)"}, meta.OptionsMeta{Title:"Max retries", Desc:"0 for no retry", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_AI_RETRY_MAX_RETRIES", Path:"moderator.ai.retry.max_retries", PathText:"Moderator > AI Comment Moderation > Retry on transient errors > Max retries", Default:0x2, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max retries (0 for no retry)"}, meta.OptionsMeta{Title:"Sampling temperature", Desc:"leave empty to use the default of provider, 0 for the most deterministic verdict", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_AI_TEMPERATURE", Path:"moderator.ai.temperature", PathText:"Moderator > AI Comment Moderation > Sampling temperature", Default:interface {}(nil), IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Sampling temperature (leave empty to use the default of provider, 0 for the most deterministic verdict)"}, meta.OptionsMeta{Title:"Decision when the AI response is unclear", Desc:"", Type:"string", Options:[]string{"pass", "block", "pending"}, Env:"ATK_MODERATOR_AI_UNCLEAR_DECISION", Path:"moderator.ai.unclear_decision", PathText:"Moderator > AI Comment Moderation > Decision when the AI response is unclear", Default:"pass", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Decision when the AI response is unclear [\"pass\", \"block\", \"pending\"]"}, meta.OptionsMeta{Title:"Akismet Key", Desc:"Akismet anti-spam service, https://akismet.com", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_AKISMET_KEY", Path:"moderator.akismet_key", PathText:"Moderator > Akismet Key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Akismet Key (Akismet anti-spam service, https://akismet.com)"}, meta.OptionsMeta{Title:"Proxy for Akismet", Desc:"leave empty to use `http.outbound_proxy`, \"direct\" for no proxy", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_AKISMET_PROXY", Path:"moderator.akismet_proxy", PathText:"Moderator > Proxy for Akismet", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Proxy for Akismet (leave empty to use `http.outbound_proxy`, \"direct\" for no proxy)"}, meta.OptionsMeta{Title:"Aliyun Content Security", Desc:"Auto review comments with Aliyun Content Security", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN", Path:"moderator.aliyun", PathText:"Moderator > Aliyun Content Security", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Aliyun Content Security (Auto review comments with Aliyun Content Security) -- see https://help.aliyun.com/document_detail/28417.html --"}, meta.OptionsMeta{Title:"AccessKeyId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN_ACCESS_KEY_ID", Path:"moderator.aliyun.access_key_id", PathText:"Moderator > Aliyun Content Security > AccessKeyId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeyId"}, meta.OptionsMeta{Title:"AccessKeySecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN_ACCESS_KEY_SECRET", Path:"moderator.aliyun.access_key_secret", PathText:"Moderator > Aliyun Content Security > AccessKeySecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeySecret"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN_ENABLED", Path:"moderator.aliyun.enabled", PathText:"Moderator > Aliyun Content Security > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Region", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_ALIYUN_REGION", Path:"moderator.aliyun.region", PathText:"Moderator > Aliyun Content Security > Region", Default:"cn-shanghai", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Region"}, meta.OptionsMeta{Title:"Block when API request fails", Desc:"set to false to let comments pass when API request fails", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_API_FAIL_BLOCK", Path:"moderator.api_fail_block", PathText:"Moderator > Block when API request fails", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Block when API request fails (set to false to let comments pass when API request fails)"}, meta.OptionsMeta{Title:"Async moderation", Desc:"comments are pending until the checkers in the background queue finished, then published automatically", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_ASYNC", Path:"moderator.async", PathText:"Moderator > Async moderation", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Async moderation (comments are pending until the checkers in the background queue finished, then published automatically)"}, meta.OptionsMeta{Title:"Queue buffer size", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_ASYNC_BUFFER_SIZE", Path:"moderator.async.buffer_size", PathText:"Moderator > Async moderation > Queue buffer size", Default:0x64, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Queue buffer size"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_ASYNC_ENABLED", Path:"moderator.async.enabled", PathText:"Moderator > Async moderation > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Number of concurrent workers", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_ASYNC_WORKERS", Path:"moderator.async.workers", PathText:"Moderator > Async moderation > Number of concurrent workers", Default:0x1, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Number of concurrent workers"}, meta.OptionsMeta{Title:"Ban list of IP, CIDR, email domain and ASN", Desc:"the ban rules are managed by the admin API, which are consulted before any other checker", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_BAN", Path:"moderator.ban", PathText:"Moderator > Ban list of IP, CIDR, email domain and ASN", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Ban list of IP, CIDR, email domain and ASN (the ban rules are managed by the admin API, which are consulted before any other checker)"}, meta.OptionsMeta{Title:"The IP to ASN database file in the TSV format of iptoasn.com", Desc:"leave empty to ignore the ASN rules", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_BAN_ASN_DB", Path:"moderator.ban.asn_db", PathText:"Moderator > Ban list of IP, CIDR, email domain and ASN > The IP to ASN database file in the TSV format of iptoasn.com", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The IP to ASN database file in the TSV format of iptoasn.com (leave empty to ignore the ASN rules)"}, meta.OptionsMeta{Title:"Local Bayesian filter", Desc:"the samples are collected from the moderator actions, see `feedback`", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_BAYES", Path:"moderator.bayes", PathText:"Moderator > Local Bayesian filter", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Local Bayesian filter (trained by the decisions of moderator, no external API is required) (the samples are collected from the moderator actions, see `feedback`)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_BAYES_ENABLED", Path:"moderator.bayes.enabled", PathText:"Moderator > Local Bayesian filter > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Minimum samples of both spam and ham to take effect", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_BAYES_MIN_SAMPLES", Path:"moderator.bayes.min_samples", PathText:"Moderator > Local Bayesian filter > Minimum samples of both spam and ham to take effect", Default:0xa, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Minimum samples of both spam and ham to take effect"}, meta.OptionsMeta{Title:"Set to pending instead of blocking", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_BAYES_PENDING", Path:"moderator.bayes.pending", PathText:"Moderator > Local Bayesian filter > Set to pending instead of blocking", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Set to pending instead of blocking"}, meta.OptionsMeta{Title:"Spam probability threshold to block", Desc:"range 0~1", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_BAYES_THRESHOLD", Path:"moderator.bayes.threshold", PathText:"Moderator > Local Bayesian filter > Spam probability threshold to block", Default:0.9, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Spam probability threshold to block (range 0~1)"}, meta.OptionsMeta{Title:"Verdict cache of identical content", Desc:"the verdicts of AI and Akismet are reused for the same comment content within the TTL", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_CACHE", Path:"moderator.cache", PathText:"Moderator > Verdict cache of identical content", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Verdict cache of identical content (the verdicts of AI and Akismet are reused for the same comment content within the TTL)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_CACHE_ENABLED", Path:"moderator.cache.enabled", PathText:"Moderator > Verdict cache of identical content > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Cache TTL", Desc:"unit: seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_CACHE_TTL", Path:"moderator.cache.ttl", PathText:"Moderator > Verdict cache of identical content > Cache TTL", Default:0xe10, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cache TTL (unit: seconds)"}, meta.OptionsMeta{Title:"Re-moderation on edit", Desc:"re-run the checkers when the content of a comment is modified", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_EDIT", Path:"moderator.edit", PathText:"Moderator > Re-moderation on edit", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Re-moderation on edit (re-run the checkers when the content of a comment is modified)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_EDIT_ENABLED", Path:"moderator.edit.enabled", PathText:"Moderator > Re-moderation on edit > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Set the edited comment to pending for manual review", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_EDIT_PENDING", Path:"moderator.edit.pending", PathText:"Moderator > Re-moderation on edit > Set the edited comment to pending for manual review", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Set the edited comment to pending for manual review"}, meta.OptionsMeta{Title:"Show the edited marker with time in the public comment data", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_EDIT_SHOW_EDITED", Path:"moderator.edit.show_edited", PathText:"Moderator > Re-moderation on edit > Show the edited marker with time in the public comment data", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Show the edited marker with time in the public comment data"}, meta.OptionsMeta{Title:"Email verification", Desc:"send a verification link for the first comment of an email, the comments are pending until verified", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_EMAIL_VERIFY", Path:"moderator.email_verify", PathText:"Moderator > Email verification", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Email verification (send a verification link for the first comment of an email, the comments are pending until verified)"}, meta.OptionsMeta{Title:"Enable email verification", Desc:"the email sending should be enabled", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_EMAIL_VERIFY_ENABLED", Path:"moderator.email_verify.enabled", PathText:"Moderator > Email verification > Enable email verification", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable email verification (the email sending should be enabled)"}, meta.OptionsMeta{Title:"Expiration of the verification link", Desc:"unit: s", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_EMAIL_VERIFY_EXPIRES", Path:"moderator.email_verify.expires", PathText:"Moderator > Email verification > Expiration of the verification link", Default:0x15180, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Expiration of the verification link (unit: s)"}, meta.OptionsMeta{Title:"Subject of the verification email", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_EMAIL_VERIFY_MAIL_SUBJECT", Path:"moderator.email_verify.mail_subject", PathText:"Moderator > Email verification > Subject of the verification email", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Subject of the verification email"}, meta.OptionsMeta{Title:"Server URL of the verification link", Desc:"e.g. https://artalk.example.com, the email is not sent if empty", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_EMAIL_VERIFY_SERVER_URL", Path:"moderator.email_verify.server_url", PathText:"Moderator > Email verification > Server URL of the verification link", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Server URL of the verification link (e.g. https://artalk.example.com, the email is not sent if empty)"}, meta.OptionsMeta{Title:"Feedback from moderator actions", Desc:"approving a pending comment reports ham, setting a comment to pending reports spam, the decision is submitted to Akismet and kept as the samples for the AI prompt", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_FEEDBACK", Path:"moderator.feedback", PathText:"Moderator > Feedback from moderator actions", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Feedback from moderator actions (approving a pending comment reports ham, setting a comment to pending reports spam, the decision is submitted to Akismet and kept as the samples for the AI prompt)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_FEEDBACK_ENABLED", Path:"moderator.feedback.enabled", PathText:"Moderator > Feedback from moderator actions > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Number of samples injected into the AI prompt as few-shot examples", Desc:"0 for disabled", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_FEEDBACK_FEW_SHOT", Path:"moderator.feedback.few_shot", PathText:"Moderator > Feedback from moderator actions > Number of samples injected into the AI prompt as few-shot examples", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Number of samples injected into the AI prompt as few-shot examples (0 for disabled)"}, meta.OptionsMeta{Title:"Duplicate content and flood detection", Desc:"near-duplicate comments and rapid-fire posting from the same IP or user", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_FLOOD", Path:"moderator.flood", PathText:"Moderator > Duplicate content and flood detection", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Duplicate content and flood detection (near-duplicate comments and rapid-fire posting from the same IP or user)"}, meta.OptionsMeta{Title:"Time window of duplicate content detection", Desc:"unit: seconds, 0 for disabled", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_FLOOD_DUPLICATE_WINDOW", Path:"moderator.flood.duplicate_window", PathText:"Moderator > Duplicate content and flood detection > Time window of duplicate content detection", Default:0xe10, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Time window of duplicate content detection (unit: seconds, 0 for disabled)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_FLOOD_ENABLED", Path:"moderator.flood.enabled", PathText:"Moderator > Duplicate content and flood detection > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Max number of comments from the same IP or user in the window", Desc:"0 for unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_FLOOD_MAX_COMMENTS", Path:"moderator.flood.max_comments", PathText:"Moderator > Duplicate content and flood detection > Max number of comments from the same IP or user in the window", Default:0x5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max number of comments from the same IP or user in the window (0 for unlimited)"}, meta.OptionsMeta{Title:"Min content length for duplicate detection", Desc:"short comments like \"Thanks!\" are skipped", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_FLOOD_MIN_LENGTH", Path:"moderator.flood.min_length", PathText:"Moderator > Duplicate content and flood detection > Min content length for duplicate detection", Default:0xa, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Min content length for duplicate detection (short comments like \"Thanks!\" are skipped)"}, meta.OptionsMeta{Title:"Set to pending instead of blocking", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_FLOOD_PENDING", Path:"moderator.flood.pending", PathText:"Moderator > Duplicate content and flood detection > Set to pending instead of blocking", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Set to pending instead of blocking"}, meta.OptionsMeta{Title:"Similarity to be considered as duplicate", Desc:"range 0~1", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_FLOOD_SIMILARITY", Path:"moderator.flood.similarity", PathText:"Moderator > Duplicate content and flood detection > Similarity to be considered as duplicate", Default:0.9, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Similarity to be considered as duplicate (range 0~1)"}, meta.OptionsMeta{Title:"Time window of flood detection", Desc:"unit: seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_FLOOD_WINDOW", Path:"moderator.flood.window", PathText:"Moderator > Duplicate content and flood detection > Time window of flood detection", Default:0x3c, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Time window of flood detection (unit: seconds)"}, meta.OptionsMeta{Title:"Image content moderation", Desc:"scan the images in the comment content", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE", Path:"moderator.image", PathText:"Moderator > Image content moderation", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Image content moderation (scan the images in the comment content)"}, meta.OptionsMeta{Title:"AWS credentials", Desc:"for rekognition", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_ACCESS_KEY_ID", Path:"moderator.image.access_key_id", PathText:"Moderator > Image content moderation > AWS credentials", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AWS credentials (for rekognition)"}, meta.OptionsMeta{Title:"AccessKeySecret", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_ACCESS_KEY_SECRET", Path:"moderator.image.access_key_secret", PathText:"Moderator > Image content moderation > AccessKeySecret", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AccessKeySecret"}, meta.OptionsMeta{Title:"OpenAI API key or the bearer token of endpoint", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_API_KEY", Path:"moderator.image.api_key", PathText:"Moderator > Image content moderation > OpenAI API key or the bearer token of endpoint", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"OpenAI API key or the bearer token of endpoint"}, meta.OptionsMeta{Title:"Flagged categories", Desc:"leave empty to use the default categories", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_CATEGORIES", Path:"moderator.image.categories", PathText:"Moderator > Image content moderation > Flagged categories", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Flagged categories (leave empty to use the default categories)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_ENABLED", Path:"moderator.image.enabled", PathText:"Moderator > Image content moderation > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"API host", Desc:"the full URL of model is required for endpoint provider", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_HOST", Path:"moderator.image.host", PathText:"Moderator > Image content moderation > API host", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API host (the full URL of model is required for endpoint provider)"}, meta.OptionsMeta{Title:"Max number of images to scan in a comment", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_MAX_IMAGES", Path:"moderator.image.max_images", PathText:"Moderator > Image content moderation > Max number of images to scan in a comment", Default:0x3, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max number of images to scan in a comment"}, meta.OptionsMeta{Title:"Max size of image to download", Desc:"unit: MB", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_MAX_SIZE", Path:"moderator.image.max_size", PathText:"Moderator > Image content moderation > Max size of image to download", Default:0x5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max size of image to download (unit: MB)"}, meta.OptionsMeta{Title:"OpenAI vision model", Desc:"default: gpt-4o-mini", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_MODEL", Path:"moderator.image.model", PathText:"Moderator > Image content moderation > OpenAI vision model", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"OpenAI vision model (default: gpt-4o-mini)"}, meta.OptionsMeta{Title:"Set to pending instead of blocking", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_PENDING", Path:"moderator.image.pending", PathText:"Moderator > Image content moderation > Set to pending instead of blocking", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Set to pending instead of blocking"}, meta.OptionsMeta{Title:"Provider", Desc:"openai: vision model, rekognition: AWS Rekognition, endpoint: self-hosted NSFW model", Type:"string", Options:[]string{"openai", "rekognition", "endpoint"}, Env:"ATK_MODERATOR_IMAGE_PROVIDER", Path:"moderator.image.provider", PathText:"Moderator > Image content moderation > Provider", Default:"openai", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Provider [\"openai\", \"rekognition\", \"endpoint\"] (openai: vision model, rekognition: AWS Rekognition, endpoint: self-hosted NSFW model)"}, meta.OptionsMeta{Title:"Region", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_REGION", Path:"moderator.image.region", PathText:"Moderator > Image content moderation > Region", Default:"us-east-1", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Region"}, meta.OptionsMeta{Title:"Confidence threshold to block", Desc:"range 0~1", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_IMAGE_THRESHOLD", Path:"moderator.image.threshold", PathText:"Moderator > Image content moderation > Confidence threshold to block", Default:0.8, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Confidence threshold to block (range 0~1)"}, meta.OptionsMeta{Title:"Keyword filter", Desc:"local offline dictionary", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS", Path:"moderator.keywords", PathText:"Moderator > Keyword filter", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Keyword filter (local offline dictionary)"}, meta.OptionsMeta{Title:"Enable keyword filter", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_ENABLED", Path:"moderator.keywords.enabled", PathText:"Moderator > Keyword filter > Enable keyword filter", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable keyword filter"}, meta.OptionsMeta{Title:"FileSep", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_FILE_SEP", Path:"moderator.keywords.file_sep", PathText:"Moderator > Keyword filter > FileSep", Default:"\n", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"FileSep"}, meta.OptionsMeta{Title:"Dictionary file", Desc:"a keyword wrapped with slashes is a regular expression, e.g. \"/buy\\s+now/i\"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_FILES", Path:"moderator.keywords.files", PathText:"Moderator > Keyword filter > Dictionary file", Default:[]interface {}{"./data/keywords_1.txt"}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Dictionary file (support multiple dictionary files and remote URLs) (a keyword wrapped with slashes is a regular expression, e.g. \"/buy\\s+now/i\")"}, meta.OptionsMeta{Title:"Set to pending when match", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_PENDING", Path:"moderator.keywords.pending", PathText:"Moderator > Keyword filter > Set to pending when match", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Set to pending when match"}, meta.OptionsMeta{Title:"Refresh interval of remote dictionary files", Desc:"unit: seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_REFRESH_INTERVAL", Path:"moderator.keywords.refresh_interval", PathText:"Moderator > Keyword filter > Refresh interval of remote dictionary files", Default:0xe10, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Refresh interval of remote dictionary files (unit: seconds)"}, meta.OptionsMeta{Title:"ReplaceTo", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_KEYWORDS_REPLACE_TO", Path:"moderator.keywords.replace_to", PathText:"Moderator > Keyword filter > ReplaceTo", Default:"x", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ReplaceTo"}, meta.OptionsMeta{Title:"Link heuristics", Desc:"the number of links, URL shorteners and domain blacklist", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_LINKS", Path:"moderator.links", PathText:"Moderator > Link heuristics", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Link heuristics (the number of links, URL shorteners and domain blacklist)"}, meta.OptionsMeta{Title:"Domain blacklist", Desc:"subdomains are also matched", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_LINKS_BLACKLIST", Path:"moderator.links.blacklist", PathText:"Moderator > Link heuristics > Domain blacklist", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Domain blacklist (subdomains are also matched)"}, meta.OptionsMeta{Title:"Block the URL shorteners", Desc:"e.g. bit.ly, t.co", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_LINKS_BLOCK_SHORTENERS", Path:"moderator.links.block_shorteners", PathText:"Moderator > Link heuristics > Block the URL shorteners", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Block the URL shorteners (e.g. bit.ly, t.co)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_LINKS_ENABLED", Path:"moderator.links.enabled", PathText:"Moderator > Link heuristics > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Max number of links", Desc:"0 for unlimited", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_LINKS_MAX_LINKS", Path:"moderator.links.max_links", PathText:"Moderator > Link heuristics > Max number of links", Default:0x3, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max number of links (0 for unlimited)"}, meta.OptionsMeta{Title:"Set to pending instead of blocking", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_LINKS_PENDING", Path:"moderator.links.pending", PathText:"Moderator > Link heuristics > Set to pending instead of blocking", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Set to pending instead of blocking"}, meta.OptionsMeta{Title:"Domains of URL shorteners", Desc:"leave empty to use the built-in list", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_LINKS_SHORTENERS", Path:"moderator.links.shorteners", PathText:"Moderator > Link heuristics > Domains of URL shorteners", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Domains of URL shorteners (leave empty to use the built-in list)"}, meta.OptionsMeta{Title:"OpenAI Moderation API", Desc:"cheaper and faster than the chat model, returns the scores of categories", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_OPENAI_MODERATION", Path:"moderator.openai_moderation", PathText:"Moderator > OpenAI Moderation API", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"OpenAI Moderation API (cheaper and faster than the chat model, returns the scores of categories)"}, meta.OptionsMeta{Title:"ApiKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_OPENAI_MODERATION_API_KEY", Path:"moderator.openai_moderation.api_key", PathText:"Moderator > OpenAI Moderation API > ApiKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"ApiKey"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_OPENAI_MODERATION_ENABLED", Path:"moderator.openai_moderation.enabled", PathText:"Moderator > OpenAI Moderation API > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"API host", Desc:"default: https://api.openai.com", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_OPENAI_MODERATION_HOST", Path:"moderator.openai_moderation.host", PathText:"Moderator > OpenAI Moderation API > API host", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API host (default: https://api.openai.com)"}, meta.OptionsMeta{Title:"Model", Desc:"default: omni-moderation-latest", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_OPENAI_MODERATION_MODEL", Path:"moderator.openai_moderation.model", PathText:"Moderator > OpenAI Moderation API > Model", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Model (default: omni-moderation-latest)"}, meta.OptionsMeta{Title:"Block threshold of each category score", Desc:"if empty, the `flagged` result of API is used", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_OPENAI_MODERATION_THRESHOLDS", Path:"moderator.openai_moderation.thresholds", PathText:"Moderator > OpenAI Moderation API > Block threshold of each category score", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Block threshold of each category score (range 0~1) (if empty, the `flagged` result of API is used)"}, meta.OptionsMeta{Title:"Hate", Desc:"", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_OPENAI_MODERATION_THRESHOLDS_HATE", Path:"moderator.openai_moderation.thresholds.hate", PathText:"Moderator > OpenAI Moderation API > Block threshold of each category score > Hate", Default:0.5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Hate"}, meta.OptionsMeta{Title:"Sexual", Desc:"", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_OPENAI_MODERATION_THRESHOLDS_SEXUAL", Path:"moderator.openai_moderation.thresholds.sexual", PathText:"Moderator > OpenAI Moderation API > Block threshold of each category score > Sexual", Default:0.5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Sexual"}, meta.OptionsMeta{Title:"Violence", Desc:"", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_OPENAI_MODERATION_THRESHOLDS_VIOLENCE", Path:"moderator.openai_moderation.thresholds.violence", PathText:"Moderator > OpenAI Moderation API > Block threshold of each category score > Violence", Default:0.5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Violence"}, meta.OptionsMeta{Title:"Default pending", Desc:"new comments need to be approved by admin", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_PENDING_DEFAULT", Path:"moderator.pending_default", PathText:"Moderator > Default pending", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Default pending (new comments need to be approved by admin)"}, meta.OptionsMeta{Title:"IP and email reputation", Desc:"StopForumSpam, AbuseIPDB", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_REPUTATION", Path:"moderator.reputation", PathText:"Moderator > IP and email reputation", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"IP and email reputation (StopForumSpam, AbuseIPDB)"}, meta.OptionsMeta{Title:"AbuseIPDB API key", Desc:"leave empty to disable", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_REPUTATION_ABUSEIPDB_KEY", Path:"moderator.reputation.abuseipdb_key", PathText:"Moderator > IP and email reputation > AbuseIPDB API key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"AbuseIPDB API key (leave empty to disable)"}, meta.OptionsMeta{Title:"Cache duration of lookup results", Desc:"unit: seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_REPUTATION_CACHE_TTL", Path:"moderator.reputation.cache_ttl", PathText:"Moderator > IP and email reputation > Cache duration of lookup results", Default:0x15180, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Cache duration of lookup results (unit: seconds)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_REPUTATION_ENABLED", Path:"moderator.reputation.enabled", PathText:"Moderator > IP and email reputation > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Query the StopForumSpam by IP and email hash", Desc:"free, no key required", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_REPUTATION_STOPFORUMSPAM", Path:"moderator.reputation.stopforumspam", PathText:"Moderator > IP and email reputation > Query the StopForumSpam by IP and email hash", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Query the StopForumSpam by IP and email hash (free, no key required)"}, meta.OptionsMeta{Title:"Confidence threshold to block", Desc:"range 0~100", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_REPUTATION_THRESHOLD", Path:"moderator.reputation.threshold", PathText:"Moderator > IP and email reputation > Confidence threshold to block", Default:0x32, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Confidence threshold to block (range 0~100)"}, meta.OptionsMeta{Title:"Weighted scoring", Desc:"combine the results of all checkers by weighted sum of spam scores, instead of blocking by any checker", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_SCORING", Path:"moderator.scoring", PathText:"Moderator > Weighted scoring", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Weighted scoring (combine the results of all checkers by weighted sum of spam scores, instead of blocking by any checker)"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_SCORING_ENABLED", Path:"moderator.scoring.enabled", PathText:"Moderator > Weighted scoring > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Review threshold", Desc:"send the comment to pending review when the total score is between it and the block threshold, set to 0 to disable", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_SCORING_REVIEW_THRESHOLD", Path:"moderator.scoring.review_threshold", PathText:"Moderator > Weighted scoring > Review threshold", Default:0.5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Review threshold (send the comment to pending review when the total score is between it and the block threshold, set to 0 to disable)"}, meta.OptionsMeta{Title:"Block threshold", Desc:"block the comment when the total score reaches it", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_SCORING_THRESHOLD", Path:"moderator.scoring.threshold", PathText:"Moderator > Weighted scoring > Block threshold", Default:1, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Block threshold (block the comment when the total score reaches it)"}, meta.OptionsMeta{Title:"Weight of checkers", Desc:"default weight is 1", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_SCORING_WEIGHTS", Path:"moderator.scoring.weights", PathText:"Moderator > Weighted scoring > Weight of checkers", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Weight of checkers (default weight is 1)"}, meta.OptionsMeta{Title:"Ai", Desc:"", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_SCORING_WEIGHTS_AI", Path:"moderator.scoring.weights.ai", PathText:"Moderator > Weighted scoring > Weight of checkers > Ai", Default:0.8, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Ai"}, meta.OptionsMeta{Title:"Akismet", Desc:"", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_SCORING_WEIGHTS_AKISMET", Path:"moderator.scoring.weights.akismet", PathText:"Moderator > Weighted scoring > Weight of checkers > Akismet", Default:0.6, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Akismet"}, meta.OptionsMeta{Title:"Keywords", Desc:"", Type:"float64", Options:[]string(nil), Env:"ATK_MODERATOR_SCORING_WEIGHTS_KEYWORDS", Path:"moderator.scoring.weights.keywords", PathText:"Moderator > Weighted scoring > Weight of checkers > Keywords", Default:0.3, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Keywords"}, meta.OptionsMeta{Title:"Tencent Cloud Content Security", Desc:"Auto review comments with Tencent Cloud Content Security", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT", Path:"moderator.tencent", PathText:"Moderator > Tencent Cloud Content Security", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Tencent Cloud Content Security (Auto review comments with Tencent Cloud Content Security) -- see https://cloud.tencent.com/document/product/1124/64508 --"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT_ENABLED", Path:"moderator.tencent.enabled", PathText:"Moderator > Tencent Cloud Content Security > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Region", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT_REGION", Path:"moderator.tencent.region", PathText:"Moderator > Tencent Cloud Content Security > Region", Default:"ap-guangzhou", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Region"}, meta.OptionsMeta{Title:"SecretId", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT_SECRET_ID", Path:"moderator.tencent.secret_id", PathText:"Moderator > Tencent Cloud Content Security > SecretId", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretId"}, meta.OptionsMeta{Title:"SecretKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_MODERATOR_TENCENT_SECRET_KEY", Path:"moderator.tencent.secret_key", PathText:"Moderator > Tencent Cloud Content Security > SecretKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"SecretKey"}, meta.OptionsMeta{Title:"Trusted users skip the remote API checkers", Desc:"admins, users in the allowlist, or users with enough approved comments", Type:"<nil>", Options:[]string(nil), Env:"ATK_MODERATOR_TRUSTED", Path:"moderator.trusted", PathText:"Moderator > Trusted users skip the remote API checkers", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Trusted users skip the remote API checkers (AI, Akismet, etc.) (admins, users in the allowlist, or users with enough approved comments)"}, meta.OptionsMeta{Title:"Trusted emails or domains", Desc:"e.g. \"user@example.com\", \"example.com\"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_MODERATOR_TRUSTED_ALLOWLIST", Path:"moderator.trusted.allowlist", PathText:"Moderator > Trusted users skip the remote API checkers > Trusted emails or domains", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Trusted emails or domains (e.g. \"user@example.com\", \"example.com\")"}, meta.OptionsMeta{Title:"Enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_MODERATOR_TRUSTED_ENABLED", Path:"moderator.trusted.enabled", PathText:"Moderator > Trusted users skip the remote API checkers > Enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enabled"}, meta.OptionsMeta{Title:"Number of approved comments to be trusted", Desc:"0 for disabled", Type:"uint64", Options:[]string(nil), Env:"ATK_MODERATOR_TRUSTED_MIN_APPROVED", Path:"moderator.trusted.min_approved", PathText:"Moderator > Trusted users skip the remote API checkers > Number of approved comments to be trusted", Default:0x5, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Number of approved comments to be trusted (0 for disabled)"}, meta.OptionsMeta{Title:"Notification templates", Desc:"Go templates by the event type and channel", Type:"<nil>", Options:[]string(nil), Env:"ATK_NOTIFY_TEMPLATES", Path:"notify_templates", PathText:"Notification templates", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Notification templates (Go templates by the event type and channel)"}, meta.OptionsMeta{Title:"The directory of templates, e.g. \"./data/templates\" Files are named as \"{channel}/{event}.{locale}.tmpl\" or \"{channel}/{event}.tmpl\", which take effect immediately when modified", Desc:"the templates edited in the dashboard take precedence", Type:"string", Options:[]string(nil), Env:"ATK_NOTIFY_TEMPLATES_DIR", Path:"notify_templates.dir", PathText:"Notification templates > The directory of templates, e.g. \"./data/templates\" Files are named as \"{channel}/{event}.{locale}.tmpl\" or \"{channel}/{event}.tmpl\", which take effect immediately when modified", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The directory of templates, e.g. \"./data/templates\" Files are named as \"{channel}/{event}.{locale}.tmpl\" or \"{channel}/{event}.tmpl\", which take effect immediately when modified (the templates edited in the dashboard take precedence)"}, meta.OptionsMeta{Title:"Listen port", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_PORT", Path:"port", PathText:"Listen port", Default:0x5b46, IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Listen port"}, meta.OptionsMeta{Title:"Page view counter", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_PV", Path:"pv", PathText:"Page view counter", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Page view counter"}, meta.OptionsMeta{Title:"Count the views of a visitor on a page only once per day", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_PV_DEDUP", Path:"pv.dedup", PathText:"Page view counter > Count the views of a visitor on a page only once per day", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Count the views of a visitor on a page only once per day"}, meta.OptionsMeta{Title:"Enable counting the page views", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_PV_ENABLED", Path:"pv.enabled", PathText:"Page view counter > Enable counting the page views", Default:true, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable counting the page views"}, meta.OptionsMeta{Title:"Comment reactions", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_REACTION", Path:"reaction", PathText:"Comment reactions", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Comment reactions"}, meta.OptionsMeta{Title:"Available emojis", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_REACTION_EMOJIS", Path:"reaction.emojis", PathText:"Comment reactions > Available emojis", Default:[]interface {}{"👍", "👎", "😄", "🎉", "😕", "❤️"}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Available emojis"}, meta.OptionsMeta{Title:"Enable reactions", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_REACTION_ENABLED", Path:"reaction.enabled", PathText:"Comment reactions > Enable reactions", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable reactions"}, meta.OptionsMeta{Title:"Real-time comment stream", Desc:"WebSocket and Server-Sent Events", Type:"<nil>", Options:[]string(nil), Env:"ATK_REALTIME", Path:"realtime", PathText:"Real-time comment stream", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Real-time comment stream (WebSocket and Server-Sent Events)"}, meta.OptionsMeta{Title:"Message broker", Desc:"", Type:"string", Options:[]string{"memory", "redis"}, Env:"ATK_REALTIME_BROKER", Path:"realtime.broker", PathText:"Real-time comment stream > Message broker", Default:"memory", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Message broker [\"memory\", \"redis\"] -- use \"redis\" to share the events between multiple nodes --"}, meta.OptionsMeta{Title:"Enable real-time stream", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_REALTIME_ENABLED", Path:"realtime.enabled", PathText:"Real-time comment stream > Enable real-time stream", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable real-time stream"}, meta.OptionsMeta{Title:"Max connections of this node", Desc:"WebSocket and SSE", Type:"uint64", Options:[]string(nil), Env:"ATK_REALTIME_MAX_CONNS", Path:"realtime.max_conns", PathText:"Real-time comment stream > Max connections of this node", Default:0x2710, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max connections of this node (WebSocket and SSE)"}, meta.OptionsMeta{Title:"Max connections of each IP", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_REALTIME_MAX_CONNS_PER_IP", Path:"realtime.max_conns_per_ip", PathText:"Real-time comment stream > Max connections of each IP", Default:0xa, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max connections of each IP"}, meta.OptionsMeta{Title:"Max message size of WebSocket client", Desc:"unit: KB", Type:"uint64", Options:[]string(nil), Env:"ATK_REALTIME_MAX_MESSAGE_SIZE", Path:"realtime.max_message_size", PathText:"Real-time comment stream > Max message size of WebSocket client", Default:0x40, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max message size of WebSocket client (unit: KB)"}, meta.OptionsMeta{Title:"Redis config", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_REALTIME_REDIS", Path:"realtime.redis", PathText:"Real-time comment stream > Redis config", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Redis config"}, meta.OptionsMeta{Title:"Redis database number", Desc:"e.g. 0", Type:"uint64", Options:[]string(nil), Env:"ATK_REALTIME_REDIS_DB", Path:"realtime.redis.db", PathText:"Real-time comment stream > Redis config > Redis database number", Default:0x0, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis database number (e.g. 0)"}, meta.OptionsMeta{Title:"Connection type", Desc:"", Type:"string", Options:[]string{"tcp", "unix"}, Env:"ATK_REALTIME_REDIS_NETWORK", Path:"realtime.redis.network", PathText:"Real-time comment stream > Redis config > Connection type", Default:"tcp", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Connection type [\"tcp\", \"unix\"]"}, meta.OptionsMeta{Title:"Redis password", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_REALTIME_REDIS_PASSWORD", Path:"realtime.redis.password", PathText:"Real-time comment stream > Redis config > Redis password", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis password"}, meta.OptionsMeta{Title:"Redis username", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_REALTIME_REDIS_USERNAME", Path:"realtime.redis.username", PathText:"Real-time comment stream > Redis config > Redis username", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis username"}, meta.OptionsMeta{Title:"Redis server address", Desc:"e.g. \"localhost:6379\"", Type:"string", Options:[]string(nil), Env:"ATK_REALTIME_SERVER", Path:"realtime.server", PathText:"Real-time comment stream > Redis server address", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Redis server address (e.g. \"localhost:6379\")"}, meta.OptionsMeta{Title:"Comment reports by readers", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_REPORT", Path:"report", PathText:"Comment reports by readers", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Comment reports by readers"}, meta.OptionsMeta{Title:"Enable comment reports", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_REPORT_ENABLED", Path:"report.enabled", PathText:"Comment reports by readers > Enable comment reports", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable comment reports"}, meta.OptionsMeta{Title:"Hide the comment  after reported by the number of readers, 0 to disable", Desc:"set to pending", Type:"uint64", Options:[]string(nil), Env:"ATK_REPORT_THRESHOLD", Path:"report.threshold", PathText:"Comment reports by readers > Hide the comment  after reported by the number of readers, 0 to disable", Default:0x3, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Hide the comment (set to pending) after reported by the number of readers, 0 to disable"}, meta.OptionsMeta{Title:"Comment search", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_SEARCH", Path:"search", PathText:"Comment search", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Comment search"}, meta.OptionsMeta{Title:"Search engine", Desc:"", Type:"string", Options:[]string{"database", "meilisearch"}, Env:"ATK_SEARCH_ENGINE", Path:"search.engine", PathText:"Comment search > Search engine", Default:"database", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Search engine [\"database\", \"meilisearch\"] -- \"database\" uses the full-text index of database (SQLite FTS5, PostgreSQL tsvector or MySQL FULLTEXT) --"}, meta.OptionsMeta{Title:"Meilisearch config", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_SEARCH_MEILISEARCH", Path:"search.meilisearch", PathText:"Comment search > Meilisearch config", Default:interface {}(nil), IsRoot:false, HasChild:true, AllowsSet:false, CommentRaw:"Meilisearch config"}, meta.OptionsMeta{Title:"API key", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_SEARCH_MEILISEARCH_API_KEY", Path:"search.meilisearch.api_key", PathText:"Comment search > Meilisearch config > API key", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"API key"}, meta.OptionsMeta{Title:"Meilisearch server address", Desc:"e.g. \"http://localhost:7700\"", Type:"string", Options:[]string(nil), Env:"ATK_SEARCH_MEILISEARCH_HOST", Path:"search.meilisearch.host", PathText:"Comment search > Meilisearch config > Meilisearch server address", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Meilisearch server address (e.g. \"http://localhost:7700\")"}, meta.OptionsMeta{Title:"Index name", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_SEARCH_MEILISEARCH_INDEX", Path:"search.meilisearch.index", PathText:"Comment search > Meilisearch config > Index name", Default:"artalk_comments", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Index name"}, meta.OptionsMeta{Title:"Default site name", Desc:"create when app is first launched", Type:"string", Options:[]string(nil), Env:"ATK_SITE_DEFAULT", Path:"site_default", PathText:"Default site name", Default:"Default Site", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Default site name (create when app is first launched)"}, meta.OptionsMeta{Title:"Default site url", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_SITE_URL", Path:"site_url", PathText:"Default site url", Default:"", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Default site url"}, meta.OptionsMeta{Title:"Soft delete", Desc:"the deleted comments are kept as placeholders to preserve the replies, and can be restored", Type:"<nil>", Options:[]string(nil), Env:"ATK_SOFT_DELETE", Path:"soft_delete", PathText:"Soft delete", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Soft delete (the deleted comments are kept as placeholders to preserve the replies, and can be restored)"}, meta.OptionsMeta{Title:"Enable soft delete", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_SOFT_DELETE_ENABLED", Path:"soft_delete.enabled", PathText:"Soft delete > Enable soft delete", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable soft delete"}, meta.OptionsMeta{Title:"Retention days of the deleted comments", Desc:"0 for keeping forever", Type:"uint64", Options:[]string(nil), Env:"ATK_SOFT_DELETE_RETENTION", Path:"soft_delete.retention", PathText:"Soft delete > Retention days of the deleted comments", Default:0x1e, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Retention days of the deleted comments (0 for keeping forever)"}, meta.OptionsMeta{Title:"SSL", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_SSL", Path:"ssl", PathText:"SSL", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"SSL"}, meta.OptionsMeta{Title:"Certificate file path", Desc:"e.g. \"/etc/letsencrypt/live/example.com/fullchain.pem\"", Type:"string", Options:[]string(nil), Env:"ATK_SSL_CERT_PATH", Path:"ssl.cert_path", PathText:"SSL > Certificate file path", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Certificate file path (e.g. \"/etc/letsencrypt/live/example.com/fullchain.pem\")"}, meta.OptionsMeta{Title:"Enable SSL", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_SSL_ENABLED", Path:"ssl.enabled", PathText:"SSL > Enable SSL", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable SSL"}, meta.OptionsMeta{Title:"Key file path", Desc:"e.g. \"/etc/letsencrypt/live/example.com/privkey.pem\"", Type:"string", Options:[]string(nil), Env:"ATK_SSL_KEY_PATH", Path:"ssl.key_path", PathText:"SSL > Key file path", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Key file path (e.g. \"/etc/letsencrypt/live/example.com/privkey.pem\")"}, meta.OptionsMeta{Title:"Timezone", Desc:"follow IANA Time Zone Database", Type:"string", Options:[]string(nil), Env:"ATK_TIMEZONE", Path:"timezone", PathText:"Timezone", Default:"Asia/Shanghai", IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Timezone (follow IANA Time Zone Database) -- see https://en.wikipedia.org/wiki/List_of_tz_database_time_zones --"}, meta.OptionsMeta{Title:"Refresh token for the login session", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_TOKEN_REFRESH", Path:"token_refresh", PathText:"Refresh token for the login session", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Refresh token for the login session"}, meta.OptionsMeta{Title:"The lifetime of access token", Desc:"in seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_TOKEN_REFRESH_ACCESS_TTL", Path:"token_refresh.access_ttl", PathText:"Refresh token for the login session > The lifetime of access token", Default:0x384, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The lifetime of access token (in seconds)"}, meta.OptionsMeta{Title:"Issue the short-lived access token with a refresh token, the login_timeout is used as the lifetime of refresh token if enabled", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_TOKEN_REFRESH_ENABLED", Path:"token_refresh.enabled", PathText:"Refresh token for the login session > Issue the short-lived access token with a refresh token, the login_timeout is used as the lifetime of refresh token if enabled", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Issue the short-lived access token with a refresh token, the login_timeout is used as the lifetime of refresh token if enabled"}, meta.OptionsMeta{Title:"Tracing", Desc:"OpenTelemetry", Type:"<nil>", Options:[]string(nil), Env:"ATK_TRACING", Path:"tracing", PathText:"Tracing", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Tracing (OpenTelemetry)"}, meta.OptionsMeta{Title:"Enable tracing", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_TRACING_ENABLED", Path:"tracing.enabled", PathText:"Tracing > Enable tracing", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable tracing"}, meta.OptionsMeta{Title:"OTLP/HTTP receiver address", Desc:"e.g. \"localhost:4318\", the env OTEL_EXPORTER_OTLP_ENDPOINT is used if empty", Type:"string", Options:[]string(nil), Env:"ATK_TRACING_ENDPOINT", Path:"tracing.endpoint", PathText:"Tracing > OTLP/HTTP receiver address", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"OTLP/HTTP receiver address (e.g. \"localhost:4318\", the env OTEL_EXPORTER_OTLP_ENDPOINT is used if empty)"}, meta.OptionsMeta{Title:"Request headers", Desc:"e.g. the credentials of the receiver", Type:"map[string]interface {}", Options:[]string(nil), Env:"ATK_TRACING_HEADERS", Path:"tracing.headers", PathText:"Tracing > Request headers", Default:map[string]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Request headers (e.g. the credentials of the receiver)"}, meta.OptionsMeta{Title:"Disable TLS", Desc:"plain HTTP", Type:"bool", Options:[]string(nil), Env:"ATK_TRACING_INSECURE", Path:"tracing.insecure", PathText:"Tracing > Disable TLS", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Disable TLS (plain HTTP)"}, meta.OptionsMeta{Title:"Sampling ratio", Desc:"0~1, 1 to sample all the traces", Type:"uint64", Options:[]string(nil), Env:"ATK_TRACING_SAMPLE_RATIO", Path:"tracing.sample_ratio", PathText:"Tracing > Sampling ratio", Default:0x1, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Sampling ratio (0~1, 1 to sample all the traces)"}, meta.OptionsMeta{Title:"Service name", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_TRACING_SERVICE_NAME", Path:"tracing.service_name", PathText:"Tracing > Service name", Default:"artalk", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Service name"}, meta.OptionsMeta{Title:"Trusted domains", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_TRUSTED_DOMAINS", Path:"trusted_domains", PathText:"Trusted domains", Default:[]interface {}{}, IsRoot:true, HasChild:false, AllowsSet:true, CommentRaw:"Trusted domains -- e.g. [\"https://artalk.example.com:23366\"] add url of your site her --"}, meta.OptionsMeta{Title:"Web Push", Desc:"notify the readers of replies and mentions in the browser, without the email address", Type:"<nil>", Options:[]string(nil), Env:"ATK_WEB_PUSH", Path:"web_push", PathText:"Web Push", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Web Push (notify the readers of replies and mentions in the browser, without the email address)"}, meta.OptionsMeta{Title:"Enable", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_WEB_PUSH_ENABLED", Path:"web_push.enabled", PathText:"Web Push > Enable", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable"}, meta.OptionsMeta{Title:"Contact of the sender for the push services", Desc:"mailto: or https: URL", Type:"string", Options:[]string(nil), Env:"ATK_WEB_PUSH_SUBJECT", Path:"web_push.subject", PathText:"Web Push > Contact of the sender for the push services", Default:"mailto:admin@example.com", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Contact of the sender for the push services (mailto: or https: URL)"}, meta.OptionsMeta{Title:"VapidPrivateKey", Desc:"", Type:"string", Options:[]string(nil), Env:"ATK_WEB_PUSH_VAPID_PRIVATE_KEY", Path:"web_push.vapid_private_key", PathText:"Web Push > VapidPrivateKey", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"VapidPrivateKey"}, meta.OptionsMeta{Title:"VAPID keys", Desc:"Base64URL encoded, generated by `artalk gen config` or `npx web-push generate-vapid-keys`", Type:"string", Options:[]string(nil), Env:"ATK_WEB_PUSH_VAPID_PUBLIC_KEY", Path:"web_push.vapid_public_key", PathText:"Web Push > VAPID keys", Default:"", IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"VAPID keys (Base64URL encoded, generated by `artalk gen config` or `npx web-push generate-vapid-keys`)"}, meta.OptionsMeta{Title:"Webhooks of the comment lifecycle events", Desc:"", Type:"<nil>", Options:[]string(nil), Env:"ATK_WEBHOOK", Path:"webhook", PathText:"Webhooks of the comment lifecycle events", Default:interface {}(nil), IsRoot:true, HasChild:true, AllowsSet:false, CommentRaw:"Webhooks of the comment lifecycle events"}, meta.OptionsMeta{Title:"Enable webhooks", Desc:"", Type:"bool", Options:[]string(nil), Env:"ATK_WEBHOOK_ENABLED", Path:"webhook.enabled", PathText:"Webhooks of the comment lifecycle events > Enable webhooks", Default:false, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Enable webhooks"}, meta.OptionsMeta{Title:"The endpoints to receive the events", Desc:"", Type:"[]interface {}", Options:[]string(nil), Env:"ATK_WEBHOOK_ENDPOINTS", Path:"webhook.endpoints", PathText:"Webhooks of the comment lifecycle events > The endpoints to receive the events", Default:[]interface {}{}, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"The endpoints to receive the events -- each endpoint has the `url`, `secret` (signature key) and `events` (leave empty for all events) --"}, meta.OptionsMeta{Title:"Max retries of the failed delivery", Desc:"", Type:"uint64", Options:[]string(nil), Env:"ATK_WEBHOOK_MAX_RETRIES", Path:"webhook.max_retries", PathText:"Webhooks of the comment lifecycle events > Max retries of the failed delivery", Default:0x3, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Max retries of the failed delivery"}, meta.OptionsMeta{Title:"Request timeout", Desc:"seconds", Type:"uint64", Options:[]string(nil), Env:"ATK_WEBHOOK_TIMEOUT", Path:"webhook.timeout", PathText:"Webhooks of the comment lifecycle events > Request timeout", Default:0xa, IsRoot:false, HasChild:false, AllowsSet:true, CommentRaw:"Request timeout (seconds)"}}
@@ -1,36 +1,59 @@
 package config
 
 import (
+	"strings"
 	"time"
 )
 
 // Config 配置
 // @link https://github.com/knadh/koanf
 type Config struct {
-	AppKey         string                 `koanf:"app_key" json:"app_key"`                 // 加密密钥
-	Debug          bool                   `koanf:"debug" json:"debug"`                     // 调试模式
-	Locale         string                 `koanf:"locale" json:"locale"`                   // 语言
-	TimeZone       string                 `koanf:"timezone" json:"timezone"`               // 时区
-	Host           string                 `koanf:"host" json:"host"`                       // HTTP Server 监听 IP
-	Port           int                    `koanf:"port" json:"port"`                       // HTTP Server 监听 Port
-	DB             DBConf                 `koanf:"db" json:"db"`                           // 数据库配置
-	HTTP           HTTPConf               `koanf:"http" json:"http"`                       // HTTP 配置
-	Cache          CacheConf              `koanf:"cache" json:"cache"`                     // 缓存
-	Log            LogConf                `koanf:"log" json:"log"`                         // 日志文件
-	TrustedDomains []string               `koanf:"trusted_domains" json:"trusted_domains"` // 可信任的域名 (新)
-	SSL            SSLConf                `koanf:"ssl" json:"ssl"`                         // SSL
-	SiteDefault    string                 `koanf:"site_default" json:"site_default"`       // 默认站点名（当请求无指定 site_name 时使用）
-	SiteURL        string                 `koanf:"site_url" json:"site_url"`               // 默认站点 URL
-	AdminUsers     []AdminUserConf        `koanf:"admin_users" json:"admin_users"`         // 管理员账户
-	LoginTimeout   int                    `koanf:"login_timeout" json:"login_timeout"`     // 登录超时
-	Moderator      ModeratorConf          `koanf:"moderator" json:"moderator"`             // 评论审查
-	Captcha        CaptchaConf            `koanf:"captcha" json:"captcha"`                 // 验证码
-	Email          EmailConf              `koanf:"email" json:"email"`                     // 邮箱提醒
-	IPRegion       IPRegionConf           `koanf:"ip_region" json:"ip_region"`             // IP 属地展示
-	ImgUpload      ImgUploadConf          `koanf:"img_upload" json:"img_upload"`           // 图片上传
-	AdminNotify    AdminNotifyConf        `koanf:"admin_notify" json:"admin_notify"`       // 其他通知方式
-	Auth           AuthConf               `koanf:"auth" json:"auth"`                       // Social Login
-	Frontend       map[string]interface{} `koanf:"frontend" json:"frontend"`
+	AppKey          string                 `koanf:"app_key" json:"app_key"`                   // 加密密钥
+	Debug           bool                   `koanf:"debug" json:"debug"`                       // 调试模式
+	Locale          string                 `koanf:"locale" json:"locale"`                     // 语言
+	TimeZone        string                 `koanf:"timezone" json:"timezone"`                 // 时区
+	Host            string                 `koanf:"host" json:"host"`                         // HTTP Server 监听 IP
+	Port            int                    `koanf:"port" json:"port"`                         // HTTP Server 监听 Port
+	DB              DBConf                 `koanf:"db" json:"db"`                             // 数据库配置
+	HTTP            HTTPConf               `koanf:"http" json:"http"`                         // HTTP 配置
+	Cache           CacheConf              `koanf:"cache" json:"cache"`                       // 缓存
+	ClusterMode     bool                   `koanf:"cluster_mode" json:"cluster_mode"`         // 集群模式 (多实例部署，共享的状态存储于 Redis 缓存)
+	Log             LogConf                `koanf:"log" json:"log"`                           // 日志文件
+	Metrics         MetricsConf            `koanf:"metrics" json:"metrics"`                   // 监控指标 (Prometheus)
+	Tracing         TracingConf            `koanf:"tracing" json:"tracing"`                   // 链路追踪 (OpenTelemetry)
+	TrustedDomains  []string               `koanf:"trusted_domains" json:"trusted_domains"`   // 可信任的域名 (新)
+	SSL             SSLConf                `koanf:"ssl" json:"ssl"`                           // SSL
+	SiteDefault     string                 `koanf:"site_default" json:"site_default"`         // 默认站点名（当请求无指定 site_name 时使用）
+	SiteURL         string                 `koanf:"site_url" json:"site_url"`                 // 默认站点 URL
+	AdminUsers      []AdminUserConf        `koanf:"admin_users" json:"admin_users"`           // 管理员账户
+	LoginTimeout    int                    `koanf:"login_timeout" json:"login_timeout"`       // 登录超时
+	AdminTOTP       AdminTOTPConf          `koanf:"admin_totp" json:"admin_totp"`             // 管理员两步验证
+	TokenRefresh    TokenRefreshConf       `koanf:"token_refresh" json:"token_refresh"`       // 登录令牌续期
+	AdminLDAP       AdminLDAPConf          `koanf:"admin_ldap" json:"admin_ldap"`             // 管理员 LDAP 登录
+	Moderator       ModeratorConf          `koanf:"moderator" json:"moderator"`               // 评论审查
+	Captcha         CaptchaConf            `koanf:"captcha" json:"captcha"`                   // 验证码
+	Email           EmailConf              `koanf:"email" json:"email"`                       // 邮箱提醒
+	IPRegion        IPRegionConf           `koanf:"ip_region" json:"ip_region"`               // IP 属地展示
+	IPPrivacy       IPPrivacyConf          `koanf:"ip_privacy" json:"ip_privacy"`             // IP 隐私保护
+	ImgUpload       ImgUploadConf          `koanf:"img_upload" json:"img_upload"`             // 图片上传
+	Reaction        ReactionConf           `koanf:"reaction" json:"reaction"`                 // 评论表情回应
+	Report          ReportConf             `koanf:"report" json:"report"`                     // 评论举报
+	CommentLimit    CommentLimitConf       `koanf:"comment_limit" json:"comment_limit"`       // 评论字数与发布频率限制
+	GuestEdit       GuestEditConf          `koanf:"guest_edit" json:"guest_edit"`             // 访客编辑评论
+	CommentClose    CommentCloseConf       `koanf:"comment_close" json:"comment_close"`       // 定时关闭评论
+	SoftDelete      SoftDeleteConf         `koanf:"soft_delete" json:"soft_delete"`           // 评论软删除
+	Markdown        MarkdownConf           `koanf:"markdown" json:"markdown"`                 // 评论内容渲染
+	Realtime        RealtimeConf           `koanf:"realtime" json:"realtime"`                 // 实时评论推送
+	Webhook         WebhookConf            `koanf:"webhook" json:"webhook"`                   // 事件 Webhook
+	Search          SearchConf             `koanf:"search" json:"search"`                     // 评论搜索
+	Backup          BackupConf             `koanf:"backup" json:"backup"`                     // 自动备份
+	PV              PVConf                 `koanf:"pv" json:"pv"`                             // 页面浏览量统计
+	Avatar          AvatarConf             `koanf:"avatar" json:"avatar"`                     // 头像代理
+	NotifyTemplates NotifyTemplatesConf    `koanf:"notify_templates" json:"notify_templates"` // 通知模板 (按事件类型和推送渠道)
+	AdminNotify     AdminNotifyConf        `koanf:"admin_notify" json:"admin_notify"`         // 其他通知方式
+	WebPush         WebPushConf            `koanf:"web_push" json:"web_push"`                 // 浏览器推送通知
+	Auth            AuthConf               `koanf:"auth" json:"auth"`                         // Social Login
+	Frontend        map[string]interface{} `koanf:"frontend" json:"frontend"`
 
 	// deprecated options
 	// (only for unmarshal, please not reference)
@@ -46,8 +69,12 @@ type Config struct {
 }
 
 type HTTPConf struct {
-	BodyLimit   int     `koanf:"body_limit" json:"body_limit"`     // 请求体大小限制 MB
-	ProxyHeader *string `koanf:"proxy_header" json:"proxy_header"` // 代理头
+	BodyLimit     int     `koanf:"body_limit" json:"body_limit"`         // 请求体大小限制 MB
+	ProxyHeader   *string `koanf:"proxy_header" json:"proxy_header"`     // 代理头
+	OutboundProxy string  `koanf:"outbound_proxy" json:"outbound_proxy"` // 出站请求代理 (支持 http, https, socks5)
+
+	ShutdownTimeout int  `koanf:"shutdown_timeout" json:"shutdown_timeout"` // 优雅关闭超时 (单位：秒)
+	ReusePort       bool `koanf:"reuse_port" json:"reuse_port"`             // 端口复用 (SO_REUSEPORT，用于零停机升级)
 }
 
 func (c *HTTPConf) GetProxyHeader() string {
@@ -73,15 +100,25 @@ type DBConf struct {
 	Charset     string `koanf:"charset" json:"charset"`
 	SSL         bool   `koanf:"ssl" json:"ssl"`
 	PrepareStmt *bool  `koanf:"prepare_stmt" json:"prepare_stmt"`
+
+	Replicas DBReplicasConf `koanf:"replicas" json:"replicas"` // 只读副本
+}
+
+type DBReplicasConf struct {
+	Dsns          []string `koanf:"dsns" json:"dsns"`                     // 只读副本的 DSN 列表 (数据库类型与主库相同)
+	MaxLag        int      `koanf:"max_lag" json:"max_lag"`               // 最大复制延迟 (单位：秒，超过则读取主库，0 为不检查)
+	CheckInterval int      `koanf:"check_interval" json:"check_interval"` // 健康检查间隔 (单位：秒)
 }
 
 type CacheConf struct {
-	Enabled bool      `koanf:"enabled" json:"enabled"`
-	Type    CacheType `koanf:"type" json:"type"`
-	Expires int       `koanf:"expires" json:"expires"` // 过期时间
-	WarmUp  bool      `koanf:"warm_up" json:"warm_up"` // 启动时缓存预热
-	Server  string    `koanf:"server" json:"server"`   // 缓存服务器
-	Redis   RedisConf `koanf:"redis" json:"redis"`
+	Enabled     bool           `koanf:"enabled" json:"enabled"`
+	Type        CacheType      `koanf:"type" json:"type"`
+	Expires     int            `koanf:"expires" json:"expires"`             // 过期时间
+	WarmUp      bool           `koanf:"warm_up" json:"warm_up"`             // 启动时缓存预热
+	WarmUpPages int            `koanf:"warm_up_pages" json:"warm_up_pages"` // 启动时预热评论列表的页面数 (按浏览量排序)
+	Server      string         `koanf:"server" json:"server"`               // 缓存服务器 (Redis 集群和哨兵模式使用逗号分隔多个地址)
+	TTLs        map[string]int `koanf:"ttls" json:"ttls"`                   // 按缓存键前缀设置过期时间 (单位：分钟，例如 "comment", "user", "page"，内建缓存不支持)
+	Redis       RedisConf      `koanf:"redis" json:"redis"`
 }
 
 func (c *CacheConf) GetExpiresTime() int64 {
@@ -96,9 +133,68 @@ func (c *CacheConf) GetExpiresTime() int64 {
 	return int64(time.Duration(c.Expires) * time.Minute)
 }
 
+// Get the expiration time of cache key by the prefix (e.g. "comment" of "comment#id=1"),
+// the default expiration time is used if not configured
+func (c *CacheConf) GetKeyExpiresTime(key string) int64 {
+	prefix, _, _ := strings.Cut(key, "#")
+	if expires, ok := c.TTLs[prefix]; ok {
+		return (&CacheConf{Expires: expires}).GetExpiresTime()
+	}
+	return c.GetExpiresTime()
+}
+
 type LogConf struct {
-	Enabled  bool   `koanf:"enabled" json:"enabled"`
-	Filename string `koanf:"filename" json:"filename"`
+	Enabled    bool              `koanf:"enabled" json:"enabled"`
+	Filename   string            `koanf:"filename" json:"filename"`
+	Format     string            `koanf:"format" json:"format"`           // 控制台日志格式 ["text", "json"] (文件日志总是 JSON 格式)
+	Level      string            `koanf:"level" json:"level"`             // 日志级别 ["debug", "info", "warn", "error"] (为空则默认为 info，调试模式下为 debug)
+	Modules    map[string]string `koanf:"modules" json:"modules"`         // 各模块的日志级别 (模块为日志消息开头的标签)，例如 { AntiSpam: debug, HTTP: warn }
+	MaxSize    int               `koanf:"max_size" json:"max_size"`       // 日志文件轮转大小 (单位：MB，默认为 500)
+	MaxBackups int               `koanf:"max_backups" json:"max_backups"` // 保留的旧日志文件数量 (默认为 30)
+	MaxAge     int               `koanf:"max_age" json:"max_age"`         // 旧日志文件保留天数 (默认为 15)
+	Compress   *bool             `koanf:"compress" json:"compress"`       // 压缩旧日志文件 (默认启用)
+}
+
+type MetricsConf struct {
+	Enabled bool   `koanf:"enabled" json:"enabled"`
+	Path    string `koanf:"path" json:"path"`   // 指标路径 (默认为 "/metrics")
+	Token   string `koanf:"token" json:"token"` // 访问令牌 (请求头 "Authorization: Bearer TOKEN"，为空则不校验)
+}
+
+// The default path of metrics endpoint
+const DefaultMetricsPath = "/metrics"
+
+func (c *MetricsConf) GetPath() string {
+	if c.Path == "" {
+		return DefaultMetricsPath
+	}
+	return "/" + strings.TrimLeft(c.Path, "/")
+}
+
+type TracingConf struct {
+	Enabled     bool              `koanf:"enabled" json:"enabled"`
+	Endpoint    string            `koanf:"endpoint" json:"endpoint"`         // OTLP/HTTP 接收端地址 (例如 "localhost:4318"，为空则使用环境变量 OTEL_EXPORTER_OTLP_ENDPOINT)
+	Insecure    bool              `koanf:"insecure" json:"insecure"`         // 不使用 TLS (HTTP)
+	Headers     map[string]string `koanf:"headers" json:"headers"`           // 请求头 (例如认证信息)
+	ServiceName string            `koanf:"service_name" json:"service_name"` // 服务名 (默认为 "artalk")
+	SampleRatio float64           `koanf:"sample_ratio" json:"sample_ratio"` // 采样率 (0~1，默认为 1 全部采样)
+}
+
+// The default service name of tracing
+const DefaultTracingServiceName = "artalk"
+
+func (c *TracingConf) GetServiceName() string {
+	if c.ServiceName == "" {
+		return DefaultTracingServiceName
+	}
+	return c.ServiceName
+}
+
+func (c *TracingConf) GetSampleRatio() float64 {
+	if c.SampleRatio <= 0 || c.SampleRatio > 1 {
+		return 1
+	}
+	return c.SampleRatio
 }
 
 type SSLConf struct {
@@ -115,24 +211,199 @@ type AdminUserConf struct {
 	BadgeName    string `koanf:"badge_name" json:"badge_name"`
 	BadgeColor   string `koanf:"badge_color" json:"badge_color"`
 	ReceiveEmail *bool  `koanf:"receive_email" json:"receive_email"`
+
+	Role  string   `koanf:"role" json:"role"`   // 管理员角色 (super_admin, site_admin, moderator, read_only)
+	Sites []string `koanf:"sites" json:"sites"` // 可管理的站点 (留空为全部站点)
+}
+
+type AdminTOTPConf struct {
+	Enforce bool   `koanf:"enforce" json:"enforce"` // 强制管理员启用两步验证
+	Issuer  string `koanf:"issuer" json:"issuer"`   // 验证器应用中显示的名称
+}
+
+type AdminLDAPConf struct {
+	Enabled            bool                 `koanf:"enabled" json:"enabled"`
+	URL                string               `koanf:"url" json:"url"`                                   // 服务器地址 (ldap:// 或 ldaps://)
+	StartTLS           bool                 `koanf:"start_tls" json:"start_tls"`                       // 使用 StartTLS
+	InsecureSkipVerify bool                 `koanf:"insecure_skip_verify" json:"insecure_skip_verify"` // 跳过证书校验
+	BindDN             string               `koanf:"bind_dn" json:"bind_dn"`                           // 用于搜索用户的账号 DN
+	BindPassword       string               `koanf:"bind_password" json:"bind_password"`               // 用于搜索用户的账号密码
+	BaseDN             string               `koanf:"base_dn" json:"base_dn"`                           // 搜索用户的 Base DN
+	UserFilter         string               `koanf:"user_filter" json:"user_filter"`                   // 搜索用户的过滤器 ({{username}} 替换为登录名)
+	NameAttr           string               `koanf:"name_attr" json:"name_attr"`                       // 用户名属性
+	EmailAttr          string               `koanf:"email_attr" json:"email_attr"`                     // 邮箱属性
+	GroupAttr          string               `koanf:"group_attr" json:"group_attr"`                     // 用户所属组的属性
+	Groups             []AdminLDAPGroupConf `koanf:"groups" json:"groups"`                             // 组与管理员角色的映射
+}
+
+type AdminLDAPGroupConf struct {
+	DN    string   `koanf:"dn" json:"dn"`       // 组 DN
+	Role  string   `koanf:"role" json:"role"`   // 管理员角色 (super_admin, site_admin, moderator, read_only)
+	Sites []string `koanf:"sites" json:"sites"` // 可管理的站点 (留空为全部站点)
+}
+
+type TokenRefreshConf struct {
+	Enabled   bool `koanf:"enabled" json:"enabled"`       // 启用刷新令牌
+	AccessTTL int  `koanf:"access_ttl" json:"access_ttl"` // 访问令牌有效时长 (单位：秒)
 }
 
 type ModeratorConf struct {
-	PendingDefault bool                 `koanf:"pending_default" json:"pending_default"`
-	ApiFailBlock   bool                 `koanf:"api_fail_block" json:"api_fail_block"` // API 请求错误仍然拦截
-	AkismetKey     string               `koanf:"akismet_key" json:"akismet_key"`
-	Tencent        TencentAntispamConf  `koanf:"tencent" json:"tencent"`
-	Aliyun         AliyunAntispamConf   `koanf:"aliyun" json:"aliyun"`
-	Keywords       KeyWordsAntispamConf `koanf:"keywords" json:"keywords"`
-	AI             AIAntispamConf       `koanf:"ai" json:"ai"`
+	PendingDefault   bool                         `koanf:"pending_default" json:"pending_default"`
+	ApiFailBlock     bool                         `koanf:"api_fail_block" json:"api_fail_block"` // API 请求错误仍然拦截
+	AkismetKey       string                       `koanf:"akismet_key" json:"akismet_key"`
+	AkismetProxy     string                       `koanf:"akismet_proxy" json:"akismet_proxy"` // Akismet 请求代理 (留空使用 http.outbound_proxy, "direct" 为不使用代理)
+	Tencent          TencentAntispamConf          `koanf:"tencent" json:"tencent"`
+	Aliyun           AliyunAntispamConf           `koanf:"aliyun" json:"aliyun"`
+	Keywords         KeyWordsAntispamConf         `koanf:"keywords" json:"keywords"`
+	Links            LinksAntispamConf            `koanf:"links" json:"links"`
+	Ban              BanAntispamConf              `koanf:"ban" json:"ban"`
+	Flood            FloodAntispamConf            `koanf:"flood" json:"flood"`
+	Reputation       ReputationAntispamConf       `koanf:"reputation" json:"reputation"`
+	Bayes            BayesAntispamConf            `koanf:"bayes" json:"bayes"`
+	AI               AIAntispamConf               `koanf:"ai" json:"ai"`
+	OpenAIModeration OpenAIModerationAntispamConf `koanf:"openai_moderation" json:"openai_moderation"`
+	Image            ImageAntispamConf            `koanf:"image" json:"image"`
+	Scoring          ScoringAntispamConf          `koanf:"scoring" json:"scoring"`
+	Async            ModeratorAsyncConf           `koanf:"async" json:"async"`
+	Cache            ModeratorCacheConf           `koanf:"cache" json:"cache"`
+	Trusted          ModeratorTrustedConf         `koanf:"trusted" json:"trusted"`
+	Feedback         ModeratorFeedbackConf        `koanf:"feedback" json:"feedback"`
+	Edit             ModeratorEditConf            `koanf:"edit" json:"edit"`
+	EmailVerify      ModeratorEmailVerifyConf     `koanf:"email_verify" json:"email_verify"`
+}
+
+// 编辑后重新审核 (评论内容被修改时重新运行检测器)
+type ModeratorEditConf struct {
+	Enabled bool `koanf:"enabled" json:"enabled"`
+	Pending bool `koanf:"pending" json:"pending"` // 将编辑后的评论设为待审状态 (需人工审核)
+
+	ShowEdited bool `koanf:"show_edited" json:"show_edited"` // 在公开的评论数据中显示已编辑标记与时间 (管理员始终可见，编辑历史仅管理员可查看)
+}
+
+// 邮箱验证 (首次使用某邮箱评论时发送验证链接，验证前评论为待审状态)
+type ModeratorEmailVerifyConf struct {
+	Enabled     bool   `koanf:"enabled" json:"enabled"`           // 启用邮箱验证 (需启用邮件发送)
+	MailSubject string `koanf:"mail_subject" json:"mail_subject"` // 验证邮件标题
+	Expires     int    `koanf:"expires" json:"expires"`           // 验证链接有效期 (单位：s)
+	ServerURL   string `koanf:"server_url" json:"server_url"`     // 验证链接的服务器地址 (例如 https://artalk.example.com，为空时不发送验证邮件)
 }
 
-// AI 评论审核 (OpenAI 兼容接口)
+// 审核反馈 (管理员将评论标记为垃圾评论或正常评论时，反馈给检测器)
+type ModeratorFeedbackConf struct {
+	Enabled bool `koanf:"enabled" json:"enabled"`
+	FewShot int  `koanf:"few_shot" json:"few_shot"` // 注入 AI 提示词的样本数量 (0 为禁用)
+}
+
+// 可信用户 (跳过 AI、Akismet 等远程 API 检测)
+type ModeratorTrustedConf struct {
+	Enabled     bool     `koanf:"enabled" json:"enabled"`
+	MinApproved int      `koanf:"min_approved" json:"min_approved"` // 已通过审核的评论数达到该值时视为可信用户 (0 为禁用)
+	Allowlist   []string `koanf:"allowlist" json:"allowlist"`       // 可信的邮箱或域名 (例如 "user@example.com", "example.com")
+}
+
+// 审核结果缓存 (相同内容的评论在有效期内直接使用 AI 和 Akismet 的检测结果)
+type ModeratorCacheConf struct {
+	Enabled bool `koanf:"enabled" json:"enabled"`
+	TTL     int  `koanf:"ttl" json:"ttl"` // 缓存有效期 (单位: 秒)
+}
+
+// 异步审核 (评论先进入待审核状态，由后台队列检测后自动发布)
+type ModeratorAsyncConf struct {
+	Enabled    bool `koanf:"enabled" json:"enabled"`
+	Workers    int  `koanf:"workers" json:"workers"`         // 并发检测数量 (默认 1)
+	BufferSize int  `koanf:"buffer_size" json:"buffer_size"` // 队列缓冲区大小
+}
+
+// 加权评分 (综合多个检测器的结果)
+type ScoringAntispamConf struct {
+	Enabled         bool               `koanf:"enabled" json:"enabled"`
+	Weights         map[string]float64 `koanf:"weights" json:"weights"`                   // 检测器权重 (未设置时权重为 1)
+	Threshold       float64            `koanf:"threshold" json:"threshold"`               // 拦截阈值
+	ReviewThreshold float64            `koanf:"review_threshold" json:"review_threshold"` // 待审核阈值 (灰色区间)
+}
+
+// AI 评论审核
 type AIAntispamConf struct {
-	Enabled bool   `koanf:"enabled" json:"enabled"`
-	ApiKey  string `koanf:"api_key" json:"api_key"`
-	Model   string `koanf:"model" json:"model"`
-	Host    string `koanf:"host" json:"host"` // 默认 api.openai.com
+	Enabled         bool                    `koanf:"enabled" json:"enabled"`
+	DryRun          bool                    `koanf:"dry_run" json:"dry_run"`   // 试运行模式 (仅记录 AI 的检测结果，不拦截评论)
+	Provider        string                  `koanf:"provider" json:"provider"` // openai (默认), anthropic, gemini, ollama
+	ApiKey          string                  `koanf:"api_key" json:"api_key"`
+	Model           string                  `koanf:"model" json:"model"`
+	Host            string                  `koanf:"host" json:"host"`                         // 为空时使用服务商默认地址
+	Path            string                  `koanf:"path" json:"path"`                         // API 路径 (留空使用服务商默认路径，占位符: {{model}})
+	Query           map[string]string       `koanf:"query" json:"query"`                       // API 请求的额外查询参数
+	Headers         map[string]string       `koanf:"headers" json:"headers"`                   // API 请求的额外请求头 (占位符: {{api_key}}，设为空值则移除默认请求头)
+	Proxy           string                  `koanf:"proxy" json:"proxy"`                       // 请求代理 (留空使用 http.outbound_proxy, "direct" 为不使用代理)
+	PromptTemplate  string                  `koanf:"prompt_template" json:"prompt_template"`   // 审核提示词模板 (留空使用内置模板)
+	HistorySize     int                     `koanf:"history_size" json:"history_size"`         // 提示词中附带的用户近期评论数量 (0 为不附带)
+	MaxTokens       int                     `koanf:"max_tokens" json:"max_tokens"`             // AI 响应的最大 Token 数 (0 为使用服务商默认值)
+	Temperature     *float64                `koanf:"temperature" json:"temperature"`           // 采样温度 (留空使用服务商默认值)
+	ResponseFormat  string                  `koanf:"response_format" json:"response_format"`   // 响应格式: text (默认), json
+	Limit           AIAntispamLimitConf     `koanf:"limit" json:"limit"`                       // 调用频率与预算限制
+	Retry           AIAntispamRetryConf     `koanf:"retry" json:"retry"`                       // 失败重试
+	CircuitBreaker  AIAntispamBreakerConf   `koanf:"circuit_breaker" json:"circuit_breaker"`   // 熔断
+	UnclearDecision string                  `koanf:"unclear_decision" json:"unclear_decision"` // 响应不明确时的处理方式: pass (默认), block, pending
+	ErrorDecision   string                  `koanf:"error_decision" json:"error_decision"`     // API 请求错误时的处理方式: pass, block, pending (留空则遵循 api_fail_block)
+	Languages       AIAntispamLanguagesConf `koanf:"languages" json:"languages"`               // 语言策略
+}
+
+// OpenAI Moderation API 审核
+type OpenAIModerationAntispamConf struct {
+	Enabled    bool               `koanf:"enabled" json:"enabled"`
+	ApiKey     string             `koanf:"api_key" json:"api_key"`
+	Model      string             `koanf:"model" json:"model"`           // 默认 omni-moderation-latest
+	Host       string             `koanf:"host" json:"host"`             // 为空时使用 OpenAI 默认地址
+	Thresholds map[string]float64 `koanf:"thresholds" json:"thresholds"` // 各分类的拦截阈值 (未设置时使用 API 的 flagged 结果)
+}
+
+// 图片内容审核 (检测评论中的图片)
+type ImageAntispamConf struct {
+	Enabled         bool     `koanf:"enabled" json:"enabled"`
+	Provider        string   `koanf:"provider" json:"provider"`                   // openai (默认), rekognition, endpoint
+	ApiKey          string   `koanf:"api_key" json:"api_key"`                     // OpenAI API Key 或自建接口的 Bearer Token
+	Model           string   `koanf:"model" json:"model"`                         // OpenAI 视觉模型 (默认 gpt-4o-mini)
+	Host            string   `koanf:"host" json:"host"`                           // API 地址 (endpoint 服务商需填写完整的接口 URL)
+	AccessKeyID     string   `koanf:"access_key_id" json:"access_key_id"`         // AWS Access Key ID (rekognition)
+	AccessKeySecret string   `koanf:"access_key_secret" json:"access_key_secret"` // AWS Secret Access Key (rekognition)
+	Region          string   `koanf:"region" json:"region"`                       // AWS 区域 (rekognition)
+	Threshold       float64  `koanf:"threshold" json:"threshold"`                 // 拦截的置信度阈值 (范围 0~1)
+	Categories      []string `koanf:"categories" json:"categories"`               // 拦截的分类 (留空使用默认分类)
+	MaxImages       int      `koanf:"max_images" json:"max_images"`               // 每条评论最多检测的图片数量
+	MaxSize         int64    `koanf:"max_size" json:"max_size"`                   // 下载图片的大小限制 (单位: MB)
+	Pending         bool     `koanf:"pending" json:"pending"`                     // 设为待审状态而非拦截
+}
+
+// AI 评论审核的语言策略 (根据评论内容检测语言)
+type AIAntispamLanguagesConf struct {
+	Allowlist    []string                            `koanf:"allowlist" json:"allowlist"`         // 允许的语言 (ISO 639-1 代码，例如 "zh", "en")
+	HoldUnlisted bool                                `koanf:"hold_unlisted" json:"hold_unlisted"` // 不在允许列表中的语言直接设为待审
+	Policies     map[string]AIAntispamLanguagePolicy `koanf:"policies" json:"policies"`           // 各语言的审核策略 (键为语言代码)
+}
+
+// AI 评论审核的单个语言策略
+type AIAntispamLanguagePolicy struct {
+	PromptTemplate string  `koanf:"prompt_template" json:"prompt_template"` // 审核提示词模板 (留空使用全局模板)
+	Threshold      float64 `koanf:"threshold" json:"threshold"`             // 拦截所需的最低置信度 (范围 0~1，0 为不限制)
+}
+
+// AI 评论审核失败重试 (网络错误、HTTP 429 和 5xx)
+type AIAntispamRetryConf struct {
+	MaxRetries int `koanf:"max_retries" json:"max_retries"` // 最大重试次数 (0 为不重试)
+	Backoff    int `koanf:"backoff" json:"backoff"`         // 初始重试间隔 (单位: 毫秒，每次重试后加倍)
+}
+
+// AI 评论审核熔断 (连续失败后暂时停用 AI 检测)
+type AIAntispamBreakerConf struct {
+	Threshold int `koanf:"threshold" json:"threshold"` // 连续失败次数阈值 (0 为禁用)
+	Cooldown  int `koanf:"cooldown" json:"cooldown"`   // 停用时长 (单位: 秒)
+}
+
+// AI 评论审核调用限制
+type AIAntispamLimitConf struct {
+	PerMinute       int    `koanf:"per_minute" json:"per_minute"`             // 每分钟最大请求数 (0 为不限制)
+	MonthlyRequests int    `koanf:"monthly_requests" json:"monthly_requests"` // 每月最大请求数 (0 为不限制)
+	MonthlyTokens   int    `koanf:"monthly_tokens" json:"monthly_tokens"`     // 每月最大 Token 用量 (0 为不限制)
+	Fallback        string `koanf:"fallback" json:"fallback"`                 // 超出限制时的处理方式: pass, pending, keywords
 }
 
 // 腾讯云反垃圾
@@ -153,24 +424,79 @@ type AliyunAntispamConf struct {
 
 // 关键词词库过滤
 type KeyWordsAntispamConf struct {
-	Enabled   bool     `koanf:"enabled" json:"enabled"`
-	Pending   bool     `koanf:"pending" json:"pending"`
-	Files     []string `koanf:"files" json:"files"`
-	FileSep   string   `koanf:"file_sep" json:"file_sep"`
-	ReplaceTo string   `koanf:"replace_to" json:"replace_to"`
+	Enabled         bool     `koanf:"enabled" json:"enabled"`
+	Pending         bool     `koanf:"pending" json:"pending"`
+	Files           []string `koanf:"files" json:"files"`
+	FileSep         string   `koanf:"file_sep" json:"file_sep"`
+	ReplaceTo       string   `koanf:"replace_to" json:"replace_to"`
+	RefreshInterval int      `koanf:"refresh_interval" json:"refresh_interval"` // 远程词库刷新间隔 (单位: 秒)
+}
+
+// 重复内容和刷屏检测
+type FloodAntispamConf struct {
+	Enabled         bool    `koanf:"enabled" json:"enabled"`
+	Window          int     `koanf:"window" json:"window"`                     // 刷屏检测的时间窗口 (单位: 秒)
+	MaxComments     int     `koanf:"max_comments" json:"max_comments"`         // 时间窗口内同一 IP 或用户的最大评论数 (0 为不限制)
+	DuplicateWindow int     `koanf:"duplicate_window" json:"duplicate_window"` // 重复内容检测的时间窗口 (单位: 秒, 0 为禁用)
+	Similarity      float64 `koanf:"similarity" json:"similarity"`             // 视为重复内容的相似度 (范围 0~1)
+	MinLength       int     `koanf:"min_length" json:"min_length"`             // 参与重复内容检测的最短内容长度
+	Pending         bool    `koanf:"pending" json:"pending"`                   // 设为待审状态而非拦截
+}
+
+// 链接启发式检测 (链接数量、短链接和域名黑名单)
+type LinksAntispamConf struct {
+	Enabled         bool     `koanf:"enabled" json:"enabled"`
+	MaxLinks        int      `koanf:"max_links" json:"max_links"`               // 最大链接数量 (0 为不限制)
+	BlockShorteners bool     `koanf:"block_shorteners" json:"block_shorteners"` // 拦截短链接
+	Shorteners      []string `koanf:"shorteners" json:"shorteners"`             // 短链接域名 (留空使用内置列表)
+	Blacklist       []string `koanf:"blacklist" json:"blacklist"`               // 域名黑名单 (包括子域名)
+	Pending         bool     `koanf:"pending" json:"pending"`                   // 设为待审状态而非拦截
+}
+
+// IP、邮箱域名和 ASN 封禁列表 (封禁规则通过管理 API 管理)
+type BanAntispamConf struct {
+	ASNDatabase string `koanf:"asn_db" json:"asn_db"` // IP 到 ASN 的数据库文件路径 (iptoasn.com 的 TSV 格式，留空则忽略 ASN 封禁规则)
+}
+
+// 本地贝叶斯过滤 (使用管理员的审核结果训练)
+type BayesAntispamConf struct {
+	Enabled    bool    `koanf:"enabled" json:"enabled"`
+	Threshold  float64 `koanf:"threshold" json:"threshold"`     // 垃圾评论概率阈值 (范围 0~1)
+	MinSamples int     `koanf:"min_samples" json:"min_samples"` // 生效所需的垃圾评论和正常评论的最少样本数
+	Pending    bool    `koanf:"pending" json:"pending"`         // 设为待审状态而非拦截
+}
+
+// IP 和邮箱信誉检测 (StopForumSpam, AbuseIPDB)
+type ReputationAntispamConf struct {
+	Enabled       bool   `koanf:"enabled" json:"enabled"`
+	StopForumSpam bool   `koanf:"stopforumspam" json:"stopforumspam"` // 查询 StopForumSpam (IP 和邮箱哈希)
+	AbuseIPDBKey  string `koanf:"abuseipdb_key" json:"abuseipdb_key"` // AbuseIPDB API Key (留空则不查询)
+	Threshold     int    `koanf:"threshold" json:"threshold"`         // 拦截的置信度阈值 (范围 0~100，默认 50)
+	CacheTTL      int    `koanf:"cache_ttl" json:"cache_ttl"`         // 查询结果缓存时长 (单位: 秒)
 }
 
 type CaptchaConf struct {
-	Enabled       bool          `koanf:"enabled" json:"enabled"`
-	Always        bool          `koanf:"always" json:"always"`
-	CaptchaType   CaptchaType   `koanf:"captcha_type" json:"captcha_type"`
-	ActionTimeout int           `koanf:"action_timeout" json:"-"` // @deprecated 已废弃 (请使用 ActionReset)
-	ActionReset   int           `koanf:"action_reset" json:"action_reset"`
-	ActionLimit   int           `koanf:"action_limit" json:"action_limit"`
-	Turnstile     TurnstileConf `koanf:"turnstile" json:"turnstile"`
-	ReCaptcha     ReCaptchaConf `koanf:"recaptcha" json:"recaptcha"`
-	HCaptcha      HCaptchaConf  `koanf:"hcaptcha" json:"hcaptcha"`
-	Geetest       GeetestConf   `koanf:"geetest" json:"geetest"`
+	Enabled       bool                `koanf:"enabled" json:"enabled"`
+	Always        bool                `koanf:"always" json:"always"`
+	CaptchaType   CaptchaType         `koanf:"captcha_type" json:"captcha_type"`
+	ActionTimeout int                 `koanf:"action_timeout" json:"-"` // @deprecated 已废弃 (请使用 ActionReset)
+	ActionReset   int                 `koanf:"action_reset" json:"action_reset"`
+	ActionLimit   int                 `koanf:"action_limit" json:"action_limit"`
+	Adaptive      CaptchaAdaptiveConf `koanf:"adaptive" json:"adaptive"`
+	Turnstile     TurnstileConf       `koanf:"turnstile" json:"turnstile"`
+	ReCaptcha     ReCaptchaConf       `koanf:"recaptcha" json:"recaptcha"`
+	HCaptcha      HCaptchaConf        `koanf:"hcaptcha" json:"hcaptcha"`
+	Geetest       GeetestConf         `koanf:"geetest" json:"geetest"`
+	Pow           PowConf             `koanf:"pow" json:"pow"`
+}
+
+// 自适应验证码 (仅对可疑的请求要求验证码)
+type CaptchaAdaptiveConf struct {
+	Enabled     bool    `koanf:"enabled" json:"enabled"`           // 启用自适应验证码 (启用后 always 失效)
+	NewIP       bool    `koanf:"new_ip" json:"new_ip"`             // 新 IP 需要验证 (没有通过审核的评论的 IP)
+	MaxComments int     `koanf:"max_comments" json:"max_comments"` // 时间窗口内 IP 的评论数达到该值需要验证 (0 为不检测)
+	Window      int     `koanf:"window" json:"window"`             // 时间窗口 (单位：s)
+	GreyScore   float64 `koanf:"grey_score" json:"grey_score"`     // 时间窗口内 IP 评论的反垃圾评分达到该值需要验证 (范围 0~1, 0 为不检测)
 }
 
 type CaptchaType string
@@ -181,21 +507,25 @@ const (
 	TypeReCaptcha CaptchaType = "recaptcha"
 	TypeHCaptcha  CaptchaType = "hcaptcha"
 	TypeGeetest   CaptchaType = "geetest"
+	TypePow       CaptchaType = "pow"
 )
 
 type TurnstileConf struct {
 	SiteKey   string `koanf:"site_key" json:"site_key"`
 	SecretKey string `koanf:"secret_key" json:"secret_key"`
+	Proxy     string `koanf:"proxy" json:"proxy"` // 验证请求代理 (留空使用 http.outbound_proxy, "direct" 为不使用代理)
 }
 
 type ReCaptchaConf struct {
 	SiteKey   string `koanf:"site_key" json:"site_key"`
 	SecretKey string `koanf:"secret_key" json:"secret_key"`
+	Proxy     string `koanf:"proxy" json:"proxy"` // 验证请求代理 (留空使用 http.outbound_proxy, "direct" 为不使用代理)
 }
 
 type HCaptchaConf struct {
 	SiteKey   string `koanf:"site_key" json:"site_key"`
 	SecretKey string `koanf:"secret_key" json:"secret_key"`
+	Proxy     string `koanf:"proxy" json:"proxy"` // 验证请求代理 (留空使用 http.outbound_proxy, "direct" 为不使用代理)
 }
 
 type GeetestConf struct {
@@ -204,17 +534,41 @@ type GeetestConf struct {
 	CaptchaKey string `koanf:"captcha_key" json:"captcha_key"`
 }
 
+type PowConf struct {
+	Difficulty int `koanf:"difficulty" json:"difficulty"` // 难度 (哈希前导零比特数, 每加 1 计算量翻倍)
+}
+
 type EmailConf struct {
-	Enabled            bool            `koanf:"enabled" json:"enabled"`           // 总开关
-	SendType           EmailSenderType `koanf:"send_type" json:"send_type"`       // 发送方式
-	SendName           string          `koanf:"send_name" json:"send_name"`       // 发件人名
-	SendAddr           string          `koanf:"send_addr" json:"send_addr"`       // 发件人地址
-	MailSubject        string          `koanf:"mail_subject" json:"mail_subject"` // 邮件标题
-	MailSubjectToAdmin string          `koanf:"mail_subject_to_admin" json:"-"`   // @deprecated 已废弃 (请使用 AdminNotify.Email.MailSubject) - 邮件标题 (发送给管理员用)
-	MailTpl            string          `koanf:"mail_tpl" json:"mail_tpl"`         // 邮件模板
-	SMTP               SMTPConf        `koanf:"smtp" json:"smtp"`                 // SMTP 配置
-	AliDM              AliDMConf       `koanf:"ali_dm" json:"ali_dm"`             // 阿里云邮件配置
-	Queue              EmailQueueConf  `koanf:"queue" json:"queue"`               // 邮件发送队列配置
+	Enabled            bool            `koanf:"enabled" json:"enabled"`                 // 总开关
+	SendType           EmailSenderType `koanf:"send_type" json:"send_type"`             // 发送方式
+	SendName           string          `koanf:"send_name" json:"send_name"`             // 发件人名
+	SendAddr           string          `koanf:"send_addr" json:"send_addr"`             // 发件人地址
+	MailSubject        string          `koanf:"mail_subject" json:"mail_subject"`       // 邮件标题
+	MailSubjectToAdmin string          `koanf:"mail_subject_to_admin" json:"-"`         // @deprecated 已废弃 (请使用 AdminNotify.Email.MailSubject) - 邮件标题 (发送给管理员用)
+	MailTpl            string          `koanf:"mail_tpl" json:"mail_tpl"`               // 邮件模板
+	SMTP               SMTPConf        `koanf:"smtp" json:"smtp"`                       // SMTP 配置
+	AliDM              AliDMConf       `koanf:"ali_dm" json:"ali_dm"`                   // 阿里云邮件配置
+	SES                SESConf         `koanf:"ses" json:"ses"`                         // AWS SES 配置
+	Mailgun            MailgunConf     `koanf:"mailgun" json:"mailgun"`                 // Mailgun 配置
+	Postmark           PostmarkConf    `koanf:"postmark" json:"postmark"`               // Postmark 配置
+	Resend             ResendConf      `koanf:"resend" json:"resend"`                   // Resend 配置
+	Queue              EmailQueueConf  `koanf:"queue" json:"queue"`                     // 邮件发送队列配置
+	Digest             EmailDigestConf `koanf:"digest" json:"digest"`                   // 邮件摘要 (合并通知定期发送)
+	UnsubscribeURL     string          `koanf:"unsubscribe_url" json:"unsubscribe_url"` // 退订链接地址 (为空时邮件中不附带退订链接)
+}
+
+type EmailDigestConf struct {
+	Enabled  bool `koanf:"enabled" json:"enabled"`   // 启用摘要模式 (用户可在个人资料中选择即时或摘要)
+	Default  bool `koanf:"default" json:"default"`   // 未设置偏好的用户默认使用摘要
+	Interval int  `koanf:"interval" json:"interval"` // 摘要发送间隔 (小时，默认 24)
+}
+
+// Get the interval of sending the digest, 24 hours by default
+func (c *EmailDigestConf) GetInterval() time.Duration {
+	if c.Interval <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.Interval) * time.Hour
 }
 
 type SMTPConf struct {
@@ -232,8 +586,44 @@ type AliDMConf struct {
 	Region          string `koanf:"region" json:"region"`
 }
 
+type SESConf struct {
+	Region           string `koanf:"region" json:"region"` // 区域 (如 us-east-1)
+	AccessKeyId      string `koanf:"access_key_id" json:"access_key_id"`
+	AccessKeySecret  string `koanf:"access_key_secret" json:"access_key_secret"`
+	ConfigurationSet string `koanf:"configuration_set" json:"configuration_set"` // 配置集 (用于发布退信和投诉事件到 SNS)
+	WebhookToken     string `koanf:"webhook_token" json:"webhook_token"`         // SNS 回调地址中的 token 参数 (为空时不接收回调)
+}
+
+type MailgunConf struct {
+	Domain            string `koanf:"domain" json:"domain"` // 发信域名
+	ApiKey            string `koanf:"api_key" json:"api_key"`
+	Region            string `koanf:"region" json:"region"`                           // 区域 ["us", "eu"]
+	WebhookSigningKey string `koanf:"webhook_signing_key" json:"webhook_signing_key"` // Webhook 签名密钥 (为空时不接收回调)
+}
+
+type PostmarkConf struct {
+	ServerToken   string `koanf:"server_token" json:"server_token"`
+	MessageStream string `koanf:"message_stream" json:"message_stream"` // 消息流 (默认 outbound)
+	WebhookToken  string `koanf:"webhook_token" json:"webhook_token"`   // 回调地址中的 token 参数 (为空时不接收回调)
+}
+
+type ResendConf struct {
+	ApiKey        string `koanf:"api_key" json:"api_key"`
+	WebhookSecret string `koanf:"webhook_secret" json:"webhook_secret"` // Webhook 签名密钥 (whsec_ 开头，为空时不接收回调)
+}
+
 type EmailQueueConf struct {
-	BufferSize int `koanf:"buffer_size" json:"buffer_size"` // Channel buffer size (default is zero that not create buffer)
+	Workers    int `koanf:"workers" json:"workers"`         // 并发发送数量 (默认 1, SMTP 连接数随之增加)
+	MaxRetries int `koanf:"max_retries" json:"max_retries"` // 发送失败的最大重试次数 (默认 5, 设为 -1 不重试)
+	BufferSize int `koanf:"buffer_size" json:"buffer_size"` // Channel buffer size (default 100, the emails which exceed it are sent later from the database)
+}
+
+// Get the max retries of sending failed emails, 5 by default and no retry if negative
+func (c *EmailQueueConf) GetMaxRetries() int {
+	if c.MaxRetries == 0 {
+		return 5
+	}
+	return max(0, c.MaxRetries)
 }
 
 type DBType string
@@ -259,6 +649,10 @@ const (
 	TypeSMTP     EmailSenderType = "smtp"
 	TypeAliDM    EmailSenderType = "ali_dm"
 	TypeSendmail EmailSenderType = "sendmail"
+	TypeSES      EmailSenderType = "ses"
+	TypeMailgun  EmailSenderType = "mailgun"
+	TypePostmark EmailSenderType = "postmark"
+	TypeResend   EmailSenderType = "resend"
 )
 
 // # Redis 配置
@@ -269,18 +663,75 @@ const (
 //	password: ""
 //	db: 0
 type RedisConf struct {
-	Network  string `koanf:"network" json:"network"` // tcp or unix
-	Username string `koanf:"username" json:"username"`
-	Password string `koanf:"password" json:"password"`
-	DB       int    `koanf:"db" json:"db"` // Redis 默认数据库 0
+	Mode             RedisMode    `koanf:"mode" json:"mode"`       // 部署模式 ["standalone", "cluster", "sentinel"]
+	Network          string       `koanf:"network" json:"network"` // tcp or unix
+	Username         string       `koanf:"username" json:"username"`
+	Password         string       `koanf:"password" json:"password"`
+	DB               int          `koanf:"db" json:"db"`                               // Redis 默认数据库 0 (集群模式不支持)
+	MasterName       string       `koanf:"master_name" json:"master_name"`             // 哨兵模式的主节点名称
+	SentinelUsername string       `koanf:"sentinel_username" json:"sentinel_username"` // 哨兵用户名
+	SentinelPassword string       `koanf:"sentinel_password" json:"sentinel_password"` // 哨兵密码
+	TLS              RedisTLSConf `koanf:"tls" json:"tls"`                             // TLS 加密连接
+}
+
+type RedisMode string
+
+const (
+	RedisModeStandalone RedisMode = "standalone" // 单机
+	RedisModeCluster    RedisMode = "cluster"    // 集群
+	RedisModeSentinel   RedisMode = "sentinel"   // 哨兵 (主从自动切换)
+)
+
+type RedisTLSConf struct {
+	Enabled            bool   `koanf:"enabled" json:"enabled"`                           // 启用 TLS
+	ServerName         string `koanf:"server_name" json:"server_name"`                   // 校验证书的服务器名称 (留空使用连接地址)
+	CACert             string `koanf:"ca_cert" json:"ca_cert"`                           // CA 证书文件路径 (留空使用系统证书)
+	Cert               string `koanf:"cert" json:"cert"`                                 // 客户端证书文件路径 (双向认证)
+	Key                string `koanf:"key" json:"key"`                                   // 客户端私钥文件路径 (双向认证)
+	InsecureSkipVerify bool   `koanf:"insecure_skip_verify" json:"insecure_skip_verify"` // 跳过证书校验
 }
 
 type IPRegionConf struct {
-	Enabled   bool   `koanf:"enabled" json:"enabled"`     // 启用 IP 属地展示
-	DBPath    string `koanf:"db_path" json:"db_path"`     // 数据文件路径
-	Precision string `koanf:"precision" json:"precision"` // 显示精度
+	Enabled   bool   `koanf:"enabled" json:"enabled"`       // 启用 IP 属地展示
+	Provider  string `koanf:"provider" json:"provider"`     // 数据库类型 ["ip2region", "maxmind"]
+	DBPath    string `koanf:"db_path" json:"db_path"`       // 数据文件路径
+	Precision string `koanf:"precision" json:"precision"`   // 显示精度
+	Locale    string `koanf:"locale" json:"locale"`         // 地名语言 (MaxMind 数据库)
+	AdminOnly bool   `koanf:"admin_only" json:"admin_only"` // 仅管理员可见
+}
+
+type IPRegionProvider string
+
+const (
+	IPRegionProviderIP2Region IPRegionProvider = "ip2region"
+	IPRegionProviderMaxMind   IPRegionProvider = "maxmind"
+)
+
+// Get the provider of IP database, which is detected by the file extension if not set
+func (c *IPRegionConf) GetProvider() IPRegionProvider {
+	if c.Provider == "" && strings.HasSuffix(strings.ToLower(c.DBPath), ".mmdb") {
+		return IPRegionProviderMaxMind
+	}
+	if c.Provider == string(IPRegionProviderMaxMind) {
+		return IPRegionProviderMaxMind
+	}
+	return IPRegionProviderIP2Region
 }
 
+type IPPrivacyConf struct {
+	Anonymize    string `koanf:"anonymize" json:"anonymize"`         // IP 匿名化方式 ["", "truncate", "hash"]
+	HashRotation int    `koanf:"hash_rotation" json:"hash_rotation"` // 哈希盐轮换周期 (小时)
+	Retention    int    `koanf:"retention" json:"retention"`         // IP 保留天数 (0 为永久保留)
+}
+
+type IPAnonymizeMode string
+
+const (
+	IPAnonymizeNone     IPAnonymizeMode = ""
+	IPAnonymizeTruncate IPAnonymizeMode = "truncate"
+	IPAnonymizeHash     IPAnonymizeMode = "hash"
+)
+
 type IPRegionPrecision string
 
 const (
@@ -290,12 +741,61 @@ const (
 )
 
 type ImgUploadConf struct {
-	Enabled    bool      `koanf:"enabled" json:"enabled"`         // 总开关
-	Path       string    `koanf:"path" json:"path"`               // 图片存放路径
-	MaxSize    int64     `koanf:"max_size" json:"max_size"`       // 图片大小限制
-	Quality    string    `koanf:"quality" json:"quality"`         // 图片质量
-	PublicPath string    `koanf:"public_path" json:"public_path"` // 图片 URL 基础路径
-	Upgit      UpgitConf `koanf:"upgit" json:"upgit"`             // upgit
+	Enabled    bool             `koanf:"enabled" json:"enabled"`         // 总开关
+	Path       string           `koanf:"path" json:"path"`               // 图片存放路径
+	MaxSize    int64            `koanf:"max_size" json:"max_size"`       // 图片大小限制
+	Quality    string           `koanf:"quality" json:"quality"`         // 图片质量
+	PublicPath string           `koanf:"public_path" json:"public_path"` // 图片 URL 基础路径
+	Upgit      UpgitConf        `koanf:"upgit" json:"upgit"`             // upgit
+	Storage    UploadStorage    `koanf:"storage" json:"storage"`         // 存储方式 ("local" 或 "s3")
+	S3         UploadS3Conf     `koanf:"s3" json:"s3"`                   // S3 兼容的对象存储
+	Process    ImgProcessConf   `koanf:"process" json:"process"`         // 图片处理
+	Policy     UploadPolicyConf `koanf:"policy" json:"policy"`           // 上传配额和文件类型策略
+	GC         UploadGCConf     `koanf:"gc" json:"gc"`                   // 清理未被引用的上传文件
+}
+
+type UploadStorage string
+
+const (
+	UploadStorageLocal UploadStorage = "local" // 本地磁盘
+	UploadStorageS3    UploadStorage = "s3"    // S3 兼容的对象存储 (AWS S3、MinIO、R2、OSS、COS 等)
+)
+
+type UploadS3Conf struct {
+	Endpoint       string `koanf:"endpoint" json:"endpoint"`               // 服务地址 (留空为 AWS S3)
+	Region         string `koanf:"region" json:"region"`                   // 区域
+	Bucket         string `koanf:"bucket" json:"bucket"`                   // 存储桶
+	AccessKey      string `koanf:"access_key" json:"access_key"`           // Access Key
+	SecretKey      string `koanf:"secret_key" json:"secret_key"`           // Secret Key
+	Prefix         string `koanf:"prefix" json:"prefix"`                   // 对象路径前缀
+	PathStyle      bool   `koanf:"path_style" json:"path_style"`           // 使用路径风格的地址 (MinIO 等需要启用)
+	PublicURL      string `koanf:"public_url" json:"public_url"`           // 图片 URL 前缀 (例如 CDN 域名，留空使用存储桶地址)
+	Presign        bool   `koanf:"presign" json:"presign"`                 // 允许通过预签名 URL 直接上传到存储桶
+	PresignExpires int    `koanf:"presign_expires" json:"presign_expires"` // 预签名 URL 有效期 (单位：秒)
+}
+
+type ImgProcessConf struct {
+	Enabled        bool   `koanf:"enabled" json:"enabled"`                 // 启用图片处理
+	StripMetadata  bool   `koanf:"strip_metadata" json:"strip_metadata"`   // 移除 EXIF 等元数据 (包括 GPS 位置)
+	MaxWidth       int    `koanf:"max_width" json:"max_width"`             // 最大宽度 (0 为不限制)
+	MaxHeight      int    `koanf:"max_height" json:"max_height"`           // 最大高度 (0 为不限制)
+	Format         string `koanf:"format" json:"format"`                   // 转换格式 ("jpeg", "png", "webp" 或 "avif"，留空保持原格式)
+	Quality        int    `koanf:"quality" json:"quality"`                 // 图片质量 (1-100)
+	ThumbnailWidth int    `koanf:"thumbnail_width" json:"thumbnail_width"` // 缩略图宽度 (0 为不生成)
+	MaxPixels      int    `koanf:"max_pixels" json:"max_pixels"`           // 最大像素数 (单位：百万像素，0 为默认 40，超出的图片将被拒绝)
+}
+
+type UploadPolicyConf struct {
+	MaxSize      int64    `koanf:"max_size" json:"max_size"`           // 单个文件大小限制 (单位：MB，0 为使用 img_upload.max_size)
+	AllowedTypes []string `koanf:"allowed_types" json:"allowed_types"` // 允许的文件类型 (按文件内容识别的 MIME 类型，留空为允许所有支持的图片格式)
+	DailyCount   int      `koanf:"daily_count" json:"daily_count"`     // 每个用户每日上传的文件数 (未登录用户按 IP 计算，管理员不受限制，0 为不限制)
+	DailySize    int64    `koanf:"daily_size" json:"daily_size"`       // 每个用户每日上传的总大小 (单位：MB，0 为不限制)
+}
+
+type UploadGCConf struct {
+	Enabled     bool `koanf:"enabled" json:"enabled"`           // 定时清理未被任何评论引用的上传文件
+	GracePeriod int  `koanf:"grace_period" json:"grace_period"` // 宽限期 (单位：小时，上传未超过宽限期的文件不会被清理)
+	Interval    int  `koanf:"interval" json:"interval"`         // 清理间隔 (单位：小时)
 }
 
 type UpgitConf struct {
@@ -305,6 +805,153 @@ type UpgitConf struct {
 }
 
 // 其他通知方式
+type ReactionConf struct {
+	Enabled bool     `koanf:"enabled" json:"enabled"` // 启用表情回应
+	Emojis  []string `koanf:"emojis" json:"emojis"`   // 可用的表情 (留空使用默认表情)
+}
+
+type ReportConf struct {
+	Enabled   bool `koanf:"enabled" json:"enabled"`     // 启用评论举报
+	Threshold int  `koanf:"threshold" json:"threshold"` // 自动隐藏的举报人数 (达到后评论转为待审，0 为不自动隐藏)
+}
+
+type CommentLimitConf struct {
+	MinLength int `koanf:"min_length" json:"min_length"` // 评论最少字数 (0 为不限制)
+	MaxLength int `koanf:"max_length" json:"max_length"` // 评论最多字数 (0 为不限制)
+	MaxLinks  int `koanf:"max_links" json:"max_links"`   // 评论最多链接数 (0 为不限制)
+	Cooldown  int `koanf:"cooldown" json:"cooldown"`     // 同一用户两次发布评论的最短间隔 (单位：秒，0 为不限制)
+}
+
+type GuestEditConf struct {
+	Enabled bool `koanf:"enabled" json:"enabled"` // 允许未登录的访客在发布后的一段时间内编辑或删除自己的评论
+	Window  int  `koanf:"window" json:"window"`   // 可编辑的时长 (单位：秒)
+}
+
+type CommentCloseConf struct {
+	AfterDays int    `koanf:"after_days" json:"after_days"` // 页面发布 N 天后自动关闭评论 (以页面的发布时间为准，未设置则为页面的创建时间，0 为不自动关闭)
+	CloseAt   string `koanf:"close_at" json:"close_at"`     // 在指定的时间关闭所有页面的评论 (例如 "2024-12-31" 或 "2024-12-31T23:59:59+08:00"，留空为不关闭)
+}
+
+// 默认的表情回应
+var DefaultReactionEmojis = []string{"👍", "👎", "😄", "🎉", "😕", "❤️"}
+
+func (c *ReactionConf) GetEmojis() []string {
+	if len(c.Emojis) == 0 {
+		return DefaultReactionEmojis
+	}
+	return c.Emojis
+}
+
+type MarkdownConf struct {
+	Profile       string   `koanf:"profile" json:"profile"`               // 净化规则 ("default" 或 "strict")
+	AllowedTags   []string `koanf:"allowed_tags" json:"allowed_tags"`     // 额外允许的 HTML 标签
+	HighlightCode bool     `koanf:"highlight_code" json:"highlight_code"` // 代码高亮 (保留代码块的语言标记)
+	ImagePolicy   string   `koanf:"image_policy" json:"image_policy"`     // 图片策略 ("allow", "https" 或 "none")
+	LinkRel       []string `koanf:"link_rel" json:"link_rel"`             // 链接的 rel 属性 (留空使用 "nofollow" 和 "noreferrer")
+}
+
+type RealtimeBroker string
+
+const (
+	RealtimeBrokerMemory RealtimeBroker = "memory" // 单节点
+	RealtimeBrokerRedis  RealtimeBroker = "redis"  // 多节点
+)
+
+type RealtimeConf struct {
+	Enabled bool           `koanf:"enabled" json:"enabled"` // 启用实时推送 (WebSocket 和 SSE)
+	Broker  RealtimeBroker `koanf:"broker" json:"broker"`   // 消息代理 ("memory" 或 "redis")
+	Server  string         `koanf:"server" json:"server"`   // Redis 服务器地址
+	Redis   RedisConf      `koanf:"redis" json:"redis"`
+
+	MaxConns       int `koanf:"max_conns" json:"max_conns"`               // 本节点的最大连接数 (默认 10000)
+	MaxConnsPerIP  int `koanf:"max_conns_per_ip" json:"max_conns_per_ip"` // 每个 IP 的最大连接数 (默认 10)
+	MaxMessageSize int `koanf:"max_message_size" json:"max_message_size"` // WebSocket 客户端消息的最大长度 (单位：KB，默认 64)
+}
+
+type WebhookConf struct {
+	Enabled    bool                  `koanf:"enabled" json:"enabled"`         // 启用事件 Webhook
+	Endpoints  []WebhookEndpointConf `koanf:"endpoints" json:"endpoints"`     // 接收事件的地址
+	MaxRetries int                   `koanf:"max_retries" json:"max_retries"` // 发送失败的重试次数
+	Timeout    int                   `koanf:"timeout" json:"timeout"`         // 请求超时 (秒)
+}
+
+type WebhookEndpointConf struct {
+	URL    string   `koanf:"url" json:"url"`
+	Secret string   `koanf:"secret" json:"secret"` // 签名密钥 (HMAC-SHA256，留空不签名)
+	Events []string `koanf:"events" json:"events"` // 订阅的事件 (留空为全部事件)
+}
+
+type SearchEngine string
+
+const (
+	SearchEngineDatabase    SearchEngine = "database"    // 数据库全文索引
+	SearchEngineMeilisearch SearchEngine = "meilisearch" // 外部搜索引擎 Meilisearch
+)
+
+type SearchConf struct {
+	Engine      SearchEngine    `koanf:"engine" json:"engine"` // 搜索引擎 ("database" 或 "meilisearch")
+	Meilisearch MeilisearchConf `koanf:"meilisearch" json:"meilisearch"`
+}
+
+type MeilisearchConf struct {
+	Host   string `koanf:"host" json:"host"`       // Meilisearch 服务地址
+	APIKey string `koanf:"api_key" json:"api_key"` // API 密钥
+	Index  string `koanf:"index" json:"index"`     // 索引名称
+}
+
+type BackupRemote string
+
+const (
+	BackupRemoteNone   BackupRemote = ""       // 仅保存在本地
+	BackupRemoteS3     BackupRemote = "s3"     // S3 兼容的对象存储
+	BackupRemoteWebDAV BackupRemote = "webdav" // WebDAV
+	BackupRemoteSFTP   BackupRemote = "sftp"   // SFTP
+)
+
+type BackupConf struct {
+	Enabled  bool             `koanf:"enabled" json:"enabled"`   // 启用自动备份
+	Schedule string           `koanf:"schedule" json:"schedule"` // 备份计划 (Cron 表达式，例如 "0 3 * * *" 为每天 3 点)
+	Path     string           `koanf:"path" json:"path"`         // 本地备份目录
+	Keep     int              `koanf:"keep" json:"keep"`         // 保留的备份数量 (本地和远程，0 为全部保留)
+	Remote   BackupRemote     `koanf:"remote" json:"remote"`     // 远程存储 ("s3", "webdav" 或 "sftp"，留空仅保存在本地)
+	S3       BackupS3Conf     `koanf:"s3" json:"s3"`
+	WebDAV   BackupWebDAVConf `koanf:"webdav" json:"webdav"`
+	SFTP     BackupSFTPConf   `koanf:"sftp" json:"sftp"`
+}
+
+type BackupS3Conf struct {
+	Endpoint  string `koanf:"endpoint" json:"endpoint"`     // 服务地址 (留空为 AWS S3)
+	Region    string `koanf:"region" json:"region"`         // 区域
+	Bucket    string `koanf:"bucket" json:"bucket"`         // 存储桶
+	AccessKey string `koanf:"access_key" json:"access_key"` // Access Key
+	SecretKey string `koanf:"secret_key" json:"secret_key"` // Secret Key
+	Prefix    string `koanf:"prefix" json:"prefix"`         // 对象路径前缀
+	PathStyle bool   `koanf:"path_style" json:"path_style"` // 使用路径风格的地址 (MinIO 等需要启用)
+}
+
+type BackupWebDAVConf struct {
+	URL      string `koanf:"url" json:"url"`           // 备份目录地址
+	Username string `koanf:"username" json:"username"` // 用户名
+	Password string `koanf:"password" json:"password"` // 密码
+}
+
+type BackupSFTPConf struct {
+	Host                  string `koanf:"host" json:"host"`                                         // 主机
+	Port                  int    `koanf:"port" json:"port"`                                         // 端口
+	Username              string `koanf:"username" json:"username"`                                 // 用户名
+	Password              string `koanf:"password" json:"password"`                                 // 密码
+	PrivateKey            string `koanf:"private_key" json:"private_key"`                           // 私钥文件路径
+	HostKey               string `koanf:"host_key" json:"host_key"`                                 // 主机公钥 (authorized_keys 格式)
+	KnownHosts            string `koanf:"known_hosts" json:"known_hosts"`                           // known_hosts 文件路径 (未配置主机公钥时使用)
+	InsecureIgnoreHostKey bool   `koanf:"insecure_ignore_host_key" json:"insecure_ignore_host_key"` // 不校验主机公钥 (不安全，未配置主机公钥和 known_hosts 时须显式开启)
+	Dir                   string `koanf:"dir" json:"dir"`                                           // 远程备份目录
+}
+
+type SoftDeleteConf struct {
+	Enabled   bool `koanf:"enabled" json:"enabled"`     // 启用软删除 (删除的评论保留为占位并可恢复)
+	Retention int  `koanf:"retention" json:"retention"` // 保留天数 (超过后彻底删除，0 为永久保留)
+}
+
 type AdminNotifyConf struct {
 	NotifyTpl     string             `koanf:"notify_tpl" json:"notify_tpl"`         // 通知模板
 	NotifySubject string             `koanf:"notify_subject" json:"notify_subject"` // 通知标题
@@ -312,8 +959,14 @@ type AdminNotifyConf struct {
 	Telegram      NotifyTelegramConf `koanf:"telegram" json:"telegram"`             // TG
 	Lark          NotifyLarkConf     `koanf:"lark" json:"lark"`                     // 飞书
 	DingTalk      NotifyDingTalkConf `koanf:"ding_talk" json:"ding_talk"`           // 钉钉
+	WeCom         NotifyWeComConf    `koanf:"wecom" json:"wecom"`                   // 企业微信
 	Bark          NotifyBarkConf     `koanf:"bark" json:"bark"`                     // bark
+	Ntfy          NotifyNtfyConf     `koanf:"ntfy" json:"ntfy"`                     // ntfy
+	Gotify        NotifyGotifyConf   `koanf:"gotify" json:"gotify"`                 // Gotify
+	Pushover      NotifyPushoverConf `koanf:"pushover" json:"pushover"`             // Pushover
+	Matrix        NotifyMatrixConf   `koanf:"matrix" json:"matrix"`                 // Matrix
 	Slack         NotifySlackConf    `koanf:"slack" json:"slack"`                   // slack
+	Discord       NotifyDiscordConf  `koanf:"discord" json:"discord"`               // Discord
 	LINE          NotifyLINEConf     `koanf:"line" json:"line"`                     // LINE
 	WebHook       NotifyWebHookConf  `koanf:"webhook" json:"webhook"`               // WebHook
 	NotifyPending bool               `koanf:"notify_pending" json:"notify_pending"` // 待审核评论通知
@@ -327,21 +980,34 @@ type AdminEmailConf struct {
 }
 
 type NotifyTelegramConf struct {
-	Enabled   bool    `koanf:"enabled" json:"enabled"`
-	ApiToken  string  `koanf:"api_token" json:"api_token"`
-	Receivers []int64 `koanf:"receivers" json:"receivers"`
+	Enabled       bool    `koanf:"enabled" json:"enabled"`
+	ApiToken      string  `koanf:"api_token" json:"api_token"`
+	Receivers     []int64 `koanf:"receivers" json:"receivers"`
+	Moderation    bool    `koanf:"moderation" json:"moderation"`         // 在通知中附加审核按钮 (通过、垃圾评论、删除)
+	WebhookSecret string  `koanf:"webhook_secret" json:"webhook_secret"` // 按钮回调 Webhook 的密钥 (即 setWebhook 的 secret_token)
+	Moderators    []int64 `koanf:"moderators" json:"moderators"`         // 允许使用审核按钮的 Telegram 用户 ID (留空为 receivers 中的用户)
 }
 
 type NotifyDingTalkConf struct {
-	Enabled bool   `koanf:"enabled" json:"enabled"`
-	Token   string `koanf:"token" json:"token"`
-	Secret  string `koanf:"secret" json:"secret"`
+	Enabled  bool     `koanf:"enabled" json:"enabled"`
+	Token    string   `koanf:"token" json:"token"`
+	Secret   string   `koanf:"secret" json:"secret"`
+	MsgType  string   `koanf:"msg_type" json:"msg_type"` // 消息类型 ["text", "card"]
+	Mentions []string `koanf:"mentions" json:"mentions"` // @ 的成员 (手机号或 userId，all 为所有人)
+}
+
+type NotifyWeComConf struct {
+	Enabled    bool     `koanf:"enabled" json:"enabled"`
+	WebhookURL string   `koanf:"webhook_url" json:"webhook_url"` // 群机器人的 Webhook 地址
+	MsgType    string   `koanf:"msg_type" json:"msg_type"`       // 消息类型 ["text", "card"]
+	Mentions   []string `koanf:"mentions" json:"mentions"`       // @ 的成员 (手机号或 userid，all 为所有人，卡片消息仅支持 userid)
 }
 
 type NotifyLarkConf struct {
-	Enabled    bool   `koanf:"enabled" json:"enabled"`
-	WebhookURL string `koanf:"webhook_url" json:"webhook_url"`
-	MsgType    string `koanf:"msg_type" json:"msg_type"`
+	Enabled    bool     `koanf:"enabled" json:"enabled"`
+	WebhookURL string   `koanf:"webhook_url" json:"webhook_url"`
+	MsgType    string   `koanf:"msg_type" json:"msg_type"`
+	Mentions   []string `koanf:"mentions" json:"mentions"` // @ 的成员 (open_id，all 为所有人)
 }
 
 type NotifyBarkConf struct {
@@ -349,10 +1015,54 @@ type NotifyBarkConf struct {
 	Server  string `koanf:"server" json:"server"`
 }
 
+type NotifyNtfyConf struct {
+	Enabled bool   `koanf:"enabled" json:"enabled"`
+	Server  string `koanf:"server" json:"server"` // 服务地址 (默认 https://ntfy.sh)
+	Topic   string `koanf:"topic" json:"topic"`   // 主题
+	Token   string `koanf:"token" json:"token"`   // 访问令牌 (受保护的主题需要)
+}
+
+type NotifyGotifyConf struct {
+	Enabled bool   `koanf:"enabled" json:"enabled"`
+	Server  string `koanf:"server" json:"server"` // 服务地址
+	Token   string `koanf:"token" json:"token"`   // 应用令牌
+}
+
+type NotifyPushoverConf struct {
+	Enabled bool   `koanf:"enabled" json:"enabled"`
+	Token   string `koanf:"token" json:"token"`   // 应用的 API Token
+	User    string `koanf:"user" json:"user"`     // 用户或群组的 Key
+	Device  string `koanf:"device" json:"device"` // 设备名称 (留空为所有设备)
+}
+
+type NotifyMatrixConf struct {
+	Enabled     bool   `koanf:"enabled" json:"enabled"`
+	Homeserver  string `koanf:"homeserver" json:"homeserver"`     // 服务器地址 (如 https://matrix.org)
+	AccessToken string `koanf:"access_token" json:"access_token"` // 机器人账号的访问令牌
+	RoomID      string `koanf:"room_id" json:"room_id"`           // 房间 ID (如 !xxxx:matrix.org，不支持端到端加密的房间)
+}
+
 type NotifySlackConf struct {
-	Enabled    bool     `koanf:"enabled" json:"enabled"`
-	OauthToken string   `koanf:"oauth_token" json:"oauth_token"`
-	Receivers  []string `koanf:"receivers" json:"receivers"`
+	Enabled    bool                  `koanf:"enabled" json:"enabled"`
+	OauthToken string                `koanf:"oauth_token" json:"oauth_token"`
+	Receivers  []string              `koanf:"receivers" json:"receivers"`
+	Sites      []NotifySlackSiteConf `koanf:"sites" json:"sites"` // 按站点推送到不同的频道 (未匹配的站点推送到 receivers)
+}
+
+type NotifySlackSiteConf struct {
+	Site      string   `koanf:"site" json:"site"`           // 站点名称
+	Receivers []string `koanf:"receivers" json:"receivers"` // 频道 ID
+}
+
+type NotifyDiscordConf struct {
+	Enabled    bool                    `koanf:"enabled" json:"enabled"`
+	WebhookURL string                  `koanf:"webhook_url" json:"webhook_url"`
+	Sites      []NotifyDiscordSiteConf `koanf:"sites" json:"sites"` // 按站点推送到不同的频道 (未匹配的站点推送到 webhook_url)
+}
+
+type NotifyDiscordSiteConf struct {
+	Site       string `koanf:"site" json:"site"`               // 站点名称
+	WebhookURL string `koanf:"webhook_url" json:"webhook_url"` // 频道的 Webhook 地址
 }
 
 type NotifyLINEConf struct {
@@ -373,6 +1083,12 @@ type AuthEmailConf struct {
 	VerifyTpl     string `koanf:"verify_tpl" json:"verify_tpl"`
 }
 
+type AuthSSOConf struct {
+	Enabled bool   `koanf:"enabled" json:"enabled"`
+	Secret  string `koanf:"secret" json:"secret"`   // 签名密钥 (与宿主网站共享)
+	MaxAge  int    `koanf:"max_age" json:"max_age"` // 签名有效时长 (单位：秒)
+}
+
 type AuthConf struct {
 	Enabled   bool          `koanf:"enabled" json:"enabled"`
 	Anonymous bool          `koanf:"anonymous" json:"anonymous"`
@@ -463,4 +1179,44 @@ type AuthConf struct {
 		ClientSecret string `koanf:"client_secret" json:"client_secret"`
 		Domain       string `koanf:"domain" json:"domain"`
 	} `koanf:"auth0" json:"auth0"`
+	OIDC struct {
+		Enabled      bool     `koanf:"enabled" json:"enabled"`
+		Name         string   `koanf:"name" json:"name"` // 显示名称
+		ClientID     string   `koanf:"client_id" json:"client_id"`
+		ClientSecret string   `koanf:"client_secret" json:"client_secret"`
+		DiscoveryURL string   `koanf:"discovery_url" json:"discovery_url"` // 自动发现地址 (以 /.well-known/openid-configuration 结尾)
+		Scopes       []string `koanf:"scopes" json:"scopes"`               // 授权范围 (留空使用 openid, profile, email)
+	} `koanf:"oidc" json:"oidc"`
+	SSO AuthSSOConf `koanf:"sso" json:"sso"` // 单点登录 (宿主网站签名的用户数据)
+}
+
+type PVConf struct {
+	Enabled *bool `koanf:"enabled" json:"enabled"` // 启用页面浏览量统计 (未设置时默认启用)
+	Dedup   bool  `koanf:"dedup" json:"dedup"`     // 同一访客每天仅统计一次浏览量
+}
+
+func (c *PVConf) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+type AvatarConf struct {
+	Enabled        bool     `koanf:"enabled" json:"enabled"`                 // 启用头像代理 (由服务端获取并缓存头像，访客的邮箱哈希不会被暴露给第三方)
+	Sources        []string `koanf:"sources" json:"sources"`                 // 头像来源 (按顺序回退，支持 gravatar, cravatar, qq，均未找到时生成 Identicon)
+	GravatarMirror string   `koanf:"gravatar_mirror" json:"gravatar_mirror"` // Gravatar 镜像地址
+	CachePath      string   `koanf:"cache_path" json:"cache_path"`           // 缓存目录
+	CacheTTL       int      `koanf:"cache_ttl" json:"cache_ttl"`             // 缓存时长 (单位：小时)
+	CacheMaxSize   int      `koanf:"cache_max_size" json:"cache_max_size"`   // 缓存大小上限 (单位：MB，超出时删除最旧的缓存)
+	Timeout        int      `koanf:"timeout" json:"timeout"`                 // 获取头像的超时时间 (单位：秒)
+	RateLimit      int      `koanf:"rate_limit" json:"rate_limit"`           // 每个 IP 每分钟的最大请求数
+}
+
+type NotifyTemplatesConf struct {
+	Dir string `koanf:"dir" json:"dir"` // 模板目录 (模板文件修改后立即生效，控制台中编辑的模板优先)
+}
+
+type WebPushConf struct {
+	Enabled         bool   `koanf:"enabled" json:"enabled"`
+	Subject         string `koanf:"subject" json:"subject"`                     // 联系方式 (mailto: 或 https: 地址，推送服务商用于联系)
+	VAPIDPublicKey  string `koanf:"vapid_public_key" json:"vapid_public_key"`   // VAPID 公钥 (Base64URL 编码)
+	VAPIDPrivateKey string `koanf:"vapid_private_key" json:"vapid_private_key"` // VAPID 私钥 (Base64URL 编码)
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"strings"
 
 	"github.com/artalkjs/artalk/v2/internal/utils"
@@ -17,3 +18,69 @@ func GetHashFuncByFrontendConf(conf *Config) func(string) string {
 	}
 	return utils.GetMD5Hash
 }
+
+// Get the gravatar URL of the email hash according to the frontend gravatar configuration
+func GetGravatarURLByFrontendConf(conf *Config, emailHash string) string {
+	mirror, params := "https://www.gravatar.com/avatar/", "sha256=1&d=mp&s=240"
+	if gravatar, ok := conf.Frontend["gravatar"].(map[string]interface{}); ok {
+		if v, ok := gravatar["mirror"].(string); ok && v != "" {
+			mirror = v
+		}
+		if v, ok := gravatar["params"].(string); ok {
+			params = v
+		}
+	}
+	if !strings.HasSuffix(mirror, "/") {
+		mirror += "/"
+	}
+	url := mirror + emailHash
+	if params != "" {
+		url += "?" + params
+	}
+	return url
+}
+
+// Merge the moderator config overrides (JSON object, e.g. per-site overrides) over the base config
+//
+// Only the fields present in overrides are replaced, the base config is not modified.
+func MergeModeratorConf(base ModeratorConf, overrides string) (ModeratorConf, error) {
+	return mergeConf(base, overrides)
+}
+
+// Merge the comment limit config overrides (JSON object, e.g. per-site overrides) over the base config
+func MergeCommentLimitConf(base CommentLimitConf, overrides string) (CommentLimitConf, error) {
+	return mergeConf(base, overrides)
+}
+
+// Merge the comment close config overrides (JSON object, e.g. per-site overrides) over the base config
+func MergeCommentCloseConf(base CommentCloseConf, overrides string) (CommentCloseConf, error) {
+	return mergeConf(base, overrides)
+}
+
+// Merge the upload policy config overrides (JSON object, e.g. per-site overrides) over the base config
+func MergeUploadPolicyConf(base UploadPolicyConf, overrides string) (UploadPolicyConf, error) {
+	return mergeConf(base, overrides)
+}
+
+func mergeConf[T any](base T, overrides string) (T, error) {
+	// deep copy the base config via JSON, to avoid the maps being shared
+	raw, err := json.Marshal(base)
+	if err != nil {
+		return base, err
+	}
+
+	var merged T
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return base, err
+	}
+
+	if strings.TrimSpace(overrides) == "" {
+		return merged, nil
+	}
+
+	if err := json.Unmarshal([]byte(overrides), &merged); err != nil {
+		return base, err
+	}
+
+	return merged, nil
+}
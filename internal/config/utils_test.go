@@ -49,3 +49,48 @@ func Test_GetHashFuncByFrontendConf(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetGravatarURLByFrontendConf(t *testing.T) {
+	assert.Equal(t, "https://www.gravatar.com/avatar/abc?sha256=1&d=mp&s=240",
+		GetGravatarURLByFrontendConf(&Config{}, "abc"))
+	assert.Equal(t, "https://cravatar.cn/avatar/abc?d=mp",
+		GetGravatarURLByFrontendConf(&Config{Frontend: map[string]interface{}{
+			"gravatar": map[string]interface{}{"mirror": "https://cravatar.cn/avatar", "params": "d=mp"},
+		}}, "abc"))
+	assert.Equal(t, "https://cravatar.cn/avatar/abc",
+		GetGravatarURLByFrontendConf(&Config{Frontend: map[string]interface{}{
+			"gravatar": map[string]interface{}{"mirror": "https://cravatar.cn/avatar/", "params": ""},
+		}}, "abc"))
+}
+
+func Test_MergeModeratorConf(t *testing.T) {
+	base := ModeratorConf{
+		ApiFailBlock: false,
+		AI: AIAntispamConf{
+			Enabled: true,
+			Model:   "gpt-4o-mini",
+			ApiKey:  "global_key",
+		},
+		Scoring: ScoringAntispamConf{
+			Weights: map[string]float64{"ai": 0.8},
+		},
+	}
+
+	merged, err := MergeModeratorConf(base, `{"api_fail_block": true, "ai": {"model": "gpt-4o", "prompt_template": "{{content}}"}, "scoring": {"weights": {"akismet": 0.5}}}`)
+	assert.NoError(t, err)
+	assert.True(t, merged.ApiFailBlock)
+	assert.Equal(t, "gpt-4o", merged.AI.Model)
+	assert.Equal(t, "{{content}}", merged.AI.PromptTemplate)
+	assert.Equal(t, "global_key", merged.AI.ApiKey, "should keep the fields not overridden")
+	assert.Equal(t, map[string]float64{"ai": 0.8, "akismet": 0.5}, merged.Scoring.Weights)
+
+	assert.Equal(t, map[string]float64{"ai": 0.8}, base.Scoring.Weights, "should not modify the base config")
+	assert.Equal(t, "gpt-4o-mini", base.AI.Model)
+
+	merged, err = MergeModeratorConf(base, "")
+	assert.NoError(t, err)
+	assert.Equal(t, base, merged)
+
+	_, err = MergeModeratorConf(base, `{"ai": "invalid"}`)
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -14,14 +16,21 @@ import (
 	"github.com/artalkjs/artalk/v2/internal/hook"
 	"github.com/artalkjs/artalk/v2/internal/i18n"
 	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/metrics"
 	"github.com/artalkjs/artalk/v2/internal/pkged"
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/artalkjs/artalk/v2/internal/tracing"
+	"github.com/artalkjs/artalk/v2/internal/utils"
 )
 
 type App struct {
 	conf    *config.Config
 	dao     *dao.Dao
 	cache   *cache.Cache
+	store   store.Store
+	metrics *metrics.Metrics
 	service *map[string]Service
+	tasks   taskGroup
 
 	onTerminate   *hook.Hook[*TerminateEvent]
 	onConfUpdated *hook.Hook[*ConfUpdatedEvent]
@@ -31,10 +40,13 @@ func NewApp(conf *config.Config) *App {
 	app := &App{
 		conf:          conf,
 		service:       &map[string]Service{},
+		store:         store.NewMemoryStore(),
 		onTerminate:   &hook.Hook[*TerminateEvent]{},
 		onConfUpdated: &hook.Hook[*ConfUpdatedEvent]{},
 	}
 
+	app.metrics = newAppMetrics(app)
+
 	app.injectDefaultServices()
 	app.registerDefaultHooks()
 
@@ -46,18 +58,37 @@ func (app *App) injectDefaultServices() {
 	AppInject(app, NewEmailService(app))
 	AppInject(app, NewIPRegionService(app))
 	AppInject(app, NewNotifyService(app))
+	AppInject(app, NewWebPushService(app))
 	AppInject(app, NewAntiSpamService(app))
+	AppInject(app, NewCommentPurgeService(app))
+	AppInject(app, NewIPPurgeService(app))
+	AppInject(app, NewEmailDigestService(app))
+	AppInject(app, NewRealtimeService(app))
+	AppInject(app, NewWebhookService(app))
+	AppInject(app, NewSearchService(app))
+	AppInject(app, NewUploadService(app))
+	AppInject(app, NewUploadGCService(app))
+	AppInject(app, NewAvatarService(app))
 }
 
 func (app *App) registerDefaultHooks() {
 	app.OnTerminate().Add(func(e *TerminateEvent) error {
-		app.ResetBootstrapState()
+		// wait for the background tasks (e.g. notifications of the new comments)
+		// before the services are disposed and the connections are closed
+		app.WaitTasks(ShutdownTimeout(app.Conf()))
+
+		if err := app.ResetBootstrapState(); err != nil {
+			log.Error("[Shutdown] ", err)
+		}
 		return nil
 	})
 }
 
 var mutex = sync.Mutex{}
 
+// The timeout to export the remaining spans when the app is terminated or restarted
+const tracingShutdownTimeout = 5 * time.Second
+
 // Bootstrap implements App.
 func (app *App) Bootstrap() error {
 	mutex.Lock()
@@ -85,8 +116,27 @@ func (app *App) Bootstrap() error {
 		IsDiscard: !app.Conf().Log.Enabled,
 		IsDebug:   app.Conf().Debug,
 		LogFile:   app.Conf().Log.Filename,
+
+		Format:  app.Conf().Log.Format,
+		Level:   app.Conf().Log.Level,
+		Modules: app.Conf().Log.Modules,
+
+		MaxSize:    app.Conf().Log.MaxSize,
+		MaxBackups: app.Conf().Log.MaxBackups,
+		MaxAge:     app.Conf().Log.MaxAge,
+		NoCompress: app.Conf().Log.Compress != nil && !*app.Conf().Log.Compress,
 	})
 
+	// store of the shared states (before the services are initialized)
+	if err := app.initStore(); err != nil {
+		return err
+	}
+
+	// tracing
+	if err := tracing.Init(app.Conf().Tracing); err != nil {
+		return err
+	}
+
 	// DAO
 	if app.dao == nil {
 		if err := app.initDao(); err != nil {
@@ -124,11 +174,21 @@ func (app *App) Bootstrap() error {
 }
 
 func (app *App) ResetBootstrapState() error {
+	// call service release funcs (before the database is closed, the services may flush their states),
+	// the connections are still closed if any service fails to release
+	var errs []error
+	if app.service != nil {
+		for name, s := range *app.service {
+			if err := s.Dispose(); err != nil {
+				errs = append(errs, fmt.Errorf("service %s release error: %w", name, err))
+			}
+		}
+	}
+
 	// close database
 	if app.Dao() != nil {
-		sqlDB, _ := app.Dao().DB().DB()
-		if err := sqlDB.Close(); err != nil {
-			return err
+		if err := db.CloseDB(app.Dao().DB()); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
@@ -137,22 +197,27 @@ func (app *App) ResetBootstrapState() error {
 		app.Cache().Close()
 	}
 
+	// close store
+	if app.store != nil {
+		if err := app.closeStore(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	app.dao = nil
 	app.cache = nil
 
-	// call service release funcs
-	if app.service != nil {
-		for name, s := range *app.service {
-			if err := s.Dispose(); err != nil {
-				return fmt.Errorf("service %s release error: %w", name, err)
-			}
-		}
+	// flush the remaining spans
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := tracing.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("tracing shutdown error: %w", err))
 	}
 
 	// sync log
 	_ = log.Sync() // ignore error @see https://github.com/uber-go/zap/issues/991
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func (app *App) Inject(name string, service Service) {
@@ -210,6 +275,9 @@ func (app *App) Cache() *cache.Cache {
 }
 
 func (app *App) Restart() error {
+	// the background tasks depend on the database and the cache
+	app.WaitTasks(ShutdownTimeout(app.Conf()))
+
 	// optimistically reset the app bootstrap state
 	if err := app.ResetBootstrapState(); err != nil {
 		return err
@@ -270,11 +338,33 @@ func (app *App) initDao() error {
 	// create new dao instance
 	app.SetDao(dao.NewDao(dbInstance))
 
+	// route the reads to the read replicas (after the database is migrated)
+	if err := db.UseReplicas(dbInstance, app.conf.DB); err != nil {
+		return fmt.Errorf("db replicas init err: %w", err)
+	}
+
+	// trace the queries in the spans of requests
+	if err := db.UseTracing(dbInstance, app.conf.DB.Type); err != nil {
+		return fmt.Errorf("db tracing init err: %w", err)
+	}
+
 	// patch: switch comment email hash algorithm by config
 	app.Dao().SetCommentEmailHashFunc(func(email string) string {
 		return config.GetHashFuncByFrontendConf(app.Conf())(strings.ToLower(email))
 	})
 
+	// patch: render comment content by the markdown config
+	app.Dao().SetCommentMarkedFunc(func(content string) (string, error) {
+		conf := app.Conf().Markdown
+		return utils.MarkedWithOptions(content, utils.MarkedOptions{
+			Profile:       conf.Profile,
+			AllowedTags:   conf.AllowedTags,
+			HighlightCode: conf.HighlightCode,
+			ImagePolicy:   conf.ImagePolicy,
+			LinkRel:       conf.LinkRel,
+		})
+	})
+
 	return nil
 }
 
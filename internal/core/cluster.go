@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/cache"
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/store"
+)
+
+// Get the store of the states shared by the requests, which is in the Redis of cache for the cluster mode
+func (app *App) Store() store.Store {
+	return app.store
+}
+
+func (app *App) initStore() error {
+	if !app.conf.ClusterMode {
+		return nil
+	}
+
+	if err := checkClusterConf(app.conf); err != nil {
+		return err
+	}
+
+	client, err := cache.NewRedisClient(app.conf.Cache)
+	if err != nil {
+		return fmt.Errorf("cluster mode store init err: %w", err)
+	}
+	app.store = store.NewRedisStore(client)
+
+	return nil
+}
+
+// Release the store of cluster mode, the store in memory is kept (e.g. the rate limit counters are kept after restart)
+func (app *App) closeStore() error {
+	if _, ok := app.store.(*store.MemoryStore); ok {
+		return nil
+	}
+	err := app.store.Close()
+	app.store = store.NewMemoryStore()
+	return err
+}
+
+// Check the config of cluster mode, the instances behind the load balancer must share the states,
+// so the states kept in one instance (e.g. the builtin cache and the SQLite file) are not allowed.
+func checkClusterConf(conf *config.Config) error {
+	var errs []string
+	if !conf.Cache.Enabled || conf.Cache.Type != config.CacheTypeRedis {
+		errs = append(errs, `"cache.enabled" must be true and "cache.type" must be "redis"`)
+	}
+	if conf.DB.Type == config.TypeSQLite {
+		errs = append(errs, `"db.type" must be a database server instead of "sqlite"`)
+	}
+	if conf.Realtime.Enabled && conf.Realtime.Broker != config.RealtimeBrokerRedis {
+		errs = append(errs, `"realtime.broker" must be "redis"`)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("cluster mode is enabled, but %s", strings.Join(errs, ", "))
+	}
+
+	// the local disk may be a shared volume, which is not checked
+	if conf.ImgUpload.Enabled && !conf.ImgUpload.Upgit.Enabled && conf.ImgUpload.Storage != config.UploadStorageS3 {
+		log.Warn(`[Cluster] The uploads are stored in the local disk, please make sure "img_upload.path" is shared by the instances or use the S3 storage`)
+	}
+
+	return nil
+}
+
+// Acquire the run of periodic job in the cluster for the interval, so the job (e.g. sending the digest emails)
+// is run by only one instance at a time. It is always acquired by the single instance.
+func (app *App) acquireJob(name string, interval time.Duration) bool {
+	if !app.conf.ClusterMode {
+		return true
+	}
+
+	// the lock expires a little earlier, so the next tick of the instance is not missed
+	ok, err := app.Store().SetNX("job:"+name, "1", interval*9/10)
+	if err != nil {
+		log.Error("[Cluster] Failed to acquire the job ", name, ": ", err)
+		return false
+	}
+	return ok
+}
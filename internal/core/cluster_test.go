@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckClusterConf(t *testing.T) {
+	conf := &config.Config{
+		ClusterMode: true,
+		Cache:       config.CacheConf{Enabled: true, Type: config.CacheTypeRedis},
+		DB:          config.DBConf{Type: config.TypeMySql},
+		Realtime:    config.RealtimeConf{Enabled: true, Broker: config.RealtimeBrokerRedis},
+	}
+	assert.NoError(t, checkClusterConf(conf))
+
+	conf.Cache.Type = config.CacheTypeBuiltin
+	conf.DB.Type = config.TypeSQLite
+	conf.Realtime.Broker = config.RealtimeBrokerMemory
+	err := checkClusterConf(conf)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `"cache.type" must be "redis"`)
+		assert.Contains(t, err.Error(), `"db.type"`)
+		assert.Contains(t, err.Error(), `"realtime.broker" must be "redis"`)
+	}
+
+	conf.Realtime.Enabled = false
+	conf.Cache.Type = config.CacheTypeRedis
+	conf.DB.Type = config.TypePostgreSQL
+	assert.NoError(t, checkClusterConf(conf), "the broker is not required if realtime is disabled")
+}
+
+func TestAcquireJob(t *testing.T) {
+	app := NewApp(&config.Config{})
+	assert.True(t, app.acquireJob("test", time.Minute))
+	assert.True(t, app.acquireJob("test", time.Minute), "should always be acquired by the single instance")
+
+	// the store of cluster mode is replaced by the memory store, which behaves the same
+	app.conf.ClusterMode = true
+	assert.True(t, app.acquireJob("test", time.Minute))
+	assert.False(t, app.acquireJob("test", time.Minute), "should be acquired once in the interval")
+	assert.True(t, app.acquireJob("other", time.Minute))
+}
@@ -36,7 +36,9 @@ func (app *App) syncFromConf() {
 				IsAdmin:      true,
 				IsInConf:     true,
 				ReceiveEmail: receiveEmail,
+				AdminRole:    admin.Role,
 			}
+			user.SetAdminSites(admin.Sites)
 			app.dao.CreateUser(&user)
 		} else {
 			// update
@@ -49,6 +51,8 @@ func (app *App) syncFromConf() {
 			user.IsAdmin = true
 			user.IsInConf = true
 			user.ReceiveEmail = receiveEmail
+			user.AdminRole = admin.Role
+			user.SetAdminSites(admin.Sites)
 			app.dao.UpdateUser(&user)
 		}
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/artalkjs/artalk/v2/internal/log"
 	"github.com/artalkjs/artalk/v2/internal/pkged"
 	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/internal/webpush"
 )
 
 func Gen(genType string, specificPath string, overwrite bool) {
@@ -32,6 +33,11 @@ func Gen(genType string, specificPath string, overwrite bool) {
 		// gen random `app_key`
 		appKey := utils.RandomStringWithAlphabet(16, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ!@#$%^&*1234567890")
 		fileStr = strings.Replace(fileStr, `app_key: ""`, fmt.Sprintf(`app_key: "%s"`, appKey), 1)
+		// gen the VAPID keys of Web Push
+		if publicKey, privateKey, err := webpush.GenerateVAPIDKeys(); err == nil {
+			fileStr = strings.Replace(fileStr, `vapid_public_key: ""`, fmt.Sprintf(`vapid_public_key: "%s"`, publicKey), 1)
+			fileStr = strings.Replace(fileStr, `vapid_private_key: ""`, fmt.Sprintf(`vapid_private_key: "%s"`, privateKey), 1)
+		}
 	} else {
 		fileStr = getEmbedFile(genType)
 	}
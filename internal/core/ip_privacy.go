@@ -0,0 +1,65 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+)
+
+// The prefix of hashed IP, which is not a valid IP address
+const hashedIPPrefix = "h:"
+
+// Anonymize the IP before storing it by the `ip_privacy.anonymize` config
+//
+// The stored IPs are compared with the anonymized IP of the request (e.g. flood and duplicate checks),
+// so the real IP should be kept only in memory for the anti-spam checkers and the IP region lookup.
+func (app *App) AnonymizeIP(ip string) string {
+	return AnonymizeIP(app.conf.IPPrivacy, app.conf.AppKey, ip, time.Now())
+}
+
+// Anonymize the IP by the config at the time
+//
+// The truncated IPv4 drops the last octet (e.g. `1.2.3.0`) and the IPv6 keeps the /64 prefix (e.g. `2001:db8:1:2::`).
+// The hashed IP is the HMAC of the IP by the salt which is derived from the app key and rotated
+// every `hash_rotation` hours, so the same IP is traceable only in the rotation period.
+func AnonymizeIP(conf config.IPPrivacyConf, key string, ip string, now time.Time) string {
+	ip = strings.TrimSpace(ip)
+	if ip == "" || strings.HasPrefix(ip, hashedIPPrefix) {
+		return ip
+	}
+
+	switch config.IPAnonymizeMode(conf.Anonymize) {
+	case config.IPAnonymizeTruncate:
+		return truncateIP(ip)
+	case config.IPAnonymizeHash:
+		rotation := conf.HashRotation
+		if rotation <= 0 {
+			rotation = 24
+		}
+		period := now.Unix() / int64(rotation*3600)
+
+		mac := hmac.New(sha256.New, []byte(fmt.Sprintf("%s|%d", key, period)))
+		mac.Write([]byte(ip))
+		return hashedIPPrefix + hex.EncodeToString(mac.Sum(nil)[:16])
+	default:
+		return ip
+	}
+}
+
+// Drop the last octet of IPv4 or keep the /64 prefix of IPv6, the invalid IP is returned as is
+func truncateIP(ip string) string {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return ip
+	}
+	if ip4 := addr.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return addr.Mask(net.CIDRMask(64, 128)).String()
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymizeIP(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.Equal(t, "1.2.3.4", AnonymizeIP(config.IPPrivacyConf{}, "key", "1.2.3.4", now))
+	})
+
+	t.Run("Truncate", func(t *testing.T) {
+		conf := config.IPPrivacyConf{Anonymize: "truncate"}
+		assert.Equal(t, "1.2.3.0", AnonymizeIP(conf, "key", "1.2.3.4", now))
+		assert.Equal(t, "2001:db8:1:2::", AnonymizeIP(conf, "key", "2001:db8:1:2:3:4:5:6", now))
+		assert.Equal(t, "1.2.3.0", AnonymizeIP(conf, "key", "::ffff:1.2.3.4", now))
+		assert.Equal(t, "unknown", AnonymizeIP(conf, "key", "unknown", now), "should keep the invalid IP")
+	})
+
+	t.Run("Hash", func(t *testing.T) {
+		conf := config.IPPrivacyConf{Anonymize: "hash", HashRotation: 24}
+		hashed := AnonymizeIP(conf, "key", "1.2.3.4", now)
+		assert.Regexp(t, `^h:[0-9a-f]{32}$`, hashed)
+		assert.NotContains(t, hashed, "1.2.3.4")
+
+		assert.Equal(t, hashed, AnonymizeIP(conf, "key", "1.2.3.4", now.Add(time.Hour)), "should be the same in the rotation period")
+		assert.NotEqual(t, hashed, AnonymizeIP(conf, "key", "1.2.3.5", now), "should differ by the IP")
+		assert.NotEqual(t, hashed, AnonymizeIP(conf, "other", "1.2.3.4", now), "should differ by the key")
+		assert.NotEqual(t, hashed, AnonymizeIP(conf, "key", "1.2.3.4", now.Add(24*time.Hour)), "should rotate the salt")
+		assert.Equal(t, hashed, AnonymizeIP(conf, "key", hashed, now), "should not hash twice")
+	})
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Get the Prometheus metrics, which are kept after restart (e.g. the config is updated)
+func (app *App) Metrics() *metrics.Metrics {
+	return app.metrics
+}
+
+func newAppMetrics(app *App) *metrics.Metrics {
+	m := metrics.New()
+	m.MustRegister(&appCollector{app: app})
+	return m
+}
+
+var (
+	emailTasksDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "email", "tasks"),
+		"The number of outgoing emails in the database by the status (e.g. pending and sending).", []string{"status"}, nil)
+	emailQueueLengthDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "email", "queue_length"),
+		"The number of emails waiting in the sending queue of the instance.", nil, nil)
+	cacheHitsDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "cache", "hits_total"),
+		"The number of cache hits by the key prefix.", []string{"prefix"}, nil)
+	cacheMissesDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "cache", "misses_total"),
+		"The number of cache misses by the key prefix.", []string{"prefix"}, nil)
+	verdictCacheHitsDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "antispam", "verdict_cache_hits_total"),
+		"The number of anti-spam verdicts reused for the identical content.", nil, nil)
+	verdictCacheMissesDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "antispam", "verdict_cache_misses_total"),
+		"The number of anti-spam verdicts not found in the cache.", nil, nil)
+)
+
+// The collector of the states of app, which are read on each scrape
+// since the database, the cache and the services are replaced on restart.
+//
+// It is an unchecked collector (no descriptors are described), as the collectors of database are created on each scrape.
+type appCollector struct {
+	app *App
+}
+
+func (c *appCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+func (c *appCollector) Collect(ch chan<- prometheus.Metric) {
+	app := c.app
+
+	// Database connection pool
+	if app.dao != nil {
+		if sqlDB, err := app.dao.DB().DB(); err == nil {
+			collectors.NewDBStatsCollector(sqlDB, string(app.conf.DB.Type)).Collect(ch)
+		}
+
+		counts := app.dao.CountEmailTasksByStatus()
+		for _, status := range []string{entity.EmailTaskStatusPending, entity.EmailTaskStatusSending} {
+			if _, ok := counts[status]; !ok {
+				counts[status] = 0 // always exposed, so the alerts can be set on them
+			}
+		}
+		for status, count := range counts {
+			ch <- prometheus.MustNewConstMetric(emailTasksDesc, prometheus.GaugeValue, float64(count), status)
+		}
+	}
+
+	// Email queue
+	if emailService, err := AppService[*EmailService](app); err == nil {
+		ch <- prometheus.MustNewConstMetric(emailQueueLengthDesc, prometheus.GaugeValue, float64(emailService.QueueLen()))
+	}
+
+	// Cache (the counters are reset when the cache is recreated, which is handled by the `rate()` of Prometheus)
+	if app.cache != nil {
+		for prefix, stats := range app.cache.Stats() {
+			ch <- prometheus.MustNewConstMetric(cacheHitsDesc, prometheus.CounterValue, float64(stats.Hits), prefix)
+			ch <- prometheus.MustNewConstMetric(cacheMissesDesc, prometheus.CounterValue, float64(stats.Misses), prefix)
+		}
+	}
+	if antiSpamService, err := AppService[*AntiSpamService](app); err == nil && antiSpamService.client != nil {
+		stats := antiSpamService.CacheStats()
+		ch <- prometheus.MustNewConstMetric(verdictCacheHitsDesc, prometheus.CounterValue, float64(stats.Hits))
+		ch <- prometheus.MustNewConstMetric(verdictCacheMissesDesc, prometheus.CounterValue, float64(stats.Misses))
+	}
+}
@@ -1,11 +1,24 @@
 package core
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/anti_spam"
+	"github.com/artalkjs/artalk/v2/internal/cache"
+	"github.com/artalkjs/artalk/v2/internal/cache/simple_cache"
+	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/samber/lo"
 )
 
 var _ Service = (*AntiSpamService)(nil)
@@ -13,6 +26,16 @@ var _ Service = (*AntiSpamService)(nil)
 type AntiSpamService struct {
 	app    *App
 	client *anti_spam.AntiSpam
+
+	// Async moderation queue
+	queue       chan *antiSpamTask
+	queueMux    sync.RWMutex
+	queueClosed bool
+}
+
+type antiSpamTask struct {
+	payload *AntiSpamCheckPayload
+	onDone  func(pass bool)
 }
 
 func NewAntiSpamService(app *App) *AntiSpamService {
@@ -21,35 +44,342 @@ func NewAntiSpamService(app *App) *AntiSpamService {
 
 func (s *AntiSpamService) Init() error {
 	s.client = anti_spam.NewAntiSpam(&anti_spam.AntiSpamConf{
-		ModeratorConf: s.app.Conf().Moderator,
-		OnBlockComment: func(commentID uint) {
-			comment := s.app.dao.FindComment(commentID)
-			if comment.IsPending {
-				return // no need to block again
-			}
-
-			// update comment status
-			comment.IsPending = true
-			s.app.dao.UpdateComment(&comment)
-		},
+		ModeratorConf:    s.app.Conf().Moderator,
+		OnBlockComment:   s.setCommentPending,
+		OnPendingComment: s.setCommentPending,
 		OnUpdateComment: func(commentID uint, content string) {
 			comment := s.app.dao.FindComment(commentID)
 			comment.Content = content
 			s.app.dao.UpdateComment(&comment)
 		},
+		OnShadowVerdict:    s.setCommentModeration,
+		OnCheckerDone:      s.app.metrics.ObserveChecker,
+		VerdictCache:       s.newVerdictCache(),
+		Store:              s.app.Store(),
+		LoadSamples:        s.loadSpamSamples,
+		LoadImage:          s.loadUploadedImage,
+		FindRecentComments: s.findRecentComments,
+		LoadBans:           s.loadBans,
+		LookupCountry:      s.lookupCountry,
+		OutboundProxy:      s.app.Conf().HTTP.OutboundProxy,
 	})
 
+	// Init async moderation queue
+	if asyncConf := s.app.Conf().Moderator.Async; asyncConf.Enabled {
+		s.initQueue(max(1, asyncConf.Workers), max(0, asyncConf.BufferSize))
+	}
+
 	return nil
 }
 
+func (s *AntiSpamService) initQueue(workers int, bufferSize int) {
+	s.queueMux.Lock()
+	defer s.queueMux.Unlock()
+
+	queue := make(chan *antiSpamTask, bufferSize)
+	s.queue = queue
+	s.queueClosed = false
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for task := range queue {
+				pass := s.CheckAndBlock(task.payload)
+				if task.onDone != nil {
+					task.onDone(pass)
+				}
+				s.app.tasks.done()
+			}
+		}()
+	}
+
+	log.Debug("[AntiSpam] Async moderation queue initialize complete, workers: ", workers)
+}
+
+// Set the comment to pending and keep the moderation result for admin review
+func (s *AntiSpamService) setCommentPending(commentID uint, verdict *anti_spam.CheckerVerdict) {
+	comment := s.app.dao.FindComment(commentID)
+
+	comment.IsPending = true
+	comment.ModerationChecker = verdict.Checker
+	comment.ModerationReason = verdict.Reason
+	comment.ModerationConfidence = verdict.Confidence
+	s.app.dao.UpdateComment(&comment)
+}
+
+// Keep the moderation result of dry-run checker without changing the comment status
+func (s *AntiSpamService) setCommentModeration(commentID uint, verdict *anti_spam.CheckerVerdict) {
+	comment := s.app.dao.FindComment(commentID)
+
+	comment.ModerationChecker = verdict.Checker + ":dry_run"
+	comment.ModerationReason = fmt.Sprintf("[%s] %s", lo.If(verdict.Pass, "PASS").Else("BLOCK"), verdict.Reason)
+	comment.ModerationConfidence = verdict.Confidence
+	s.app.dao.UpdateComment(&comment)
+}
+
+// Load all the samples decided by the moderator for training
+func (s *AntiSpamService) loadSpamSamples() []anti_spam.SpamExample {
+	return lo.Map(s.app.dao.FindAllSpamSamples(), func(sample entity.SpamSample, _ int) anti_spam.SpamExample {
+		return anti_spam.SpamExample{Content: sample.Content, IsSpam: sample.IsSpam}
+	})
+}
+
+// Find the recent comments of the site for the flood checker
+func (s *AntiSpamService) findRecentComments(p *anti_spam.CheckerParams, since time.Time) []anti_spam.RecentComment {
+	storedIP := s.app.AnonymizeIP(p.UserIP) // the stored IPs may be anonymized
+	return lo.Map(s.app.dao.FindRecentComments(p.SiteName, since, 200, p.CommentID), func(comment entity.Comment, _ int) anti_spam.RecentComment {
+		return anti_spam.RecentComment{
+			Content:   comment.Content,
+			UserID:    comment.UserID,
+			IP:        lo.If(storedIP != "" && comment.IP == storedIP, p.UserIP).Else(comment.IP),
+			CreatedAt: comment.CreatedAt,
+		}
+	})
+}
+
+// Load the active ban rules of the site
+func (s *AntiSpamService) loadBans(siteName string) []anti_spam.BanRule {
+	return lo.Map(s.app.dao.FindActiveBans(siteName), func(ban entity.Ban, _ int) anti_spam.BanRule {
+		return anti_spam.BanRule{
+			ID:      ban.ID,
+			Type:    ban.Type,
+			Value:   ban.Value,
+			Pending: ban.Action == entity.BanActionPending,
+			Reason:  ban.Reason,
+		}
+	})
+}
+
+// Find the country of IP by the IP region database
+func (s *AntiSpamService) lookupCountry(ip string) (string, string) {
+	if !s.app.Conf().IPRegion.Enabled {
+		return "", ""
+	}
+	ipRegionService, err := AppService[*IPRegionService](s.app)
+	if err != nil {
+		return "", ""
+	}
+	region, _ := ipRegionService.Lookup(ip)
+	return region.CountryCode, region.Country
+}
+
+// Load the local uploaded image by the relative public path (e.g. "/static/images/xxx.png")
+func (s *AntiSpamService) loadUploadedImage(src string) ([]byte, bool) {
+	publicPath := cmp.Or(s.app.Conf().ImgUpload.PublicPath, config.IMG_UPLOAD_PUBLIC_PATH)
+	filename, ok := strings.CutPrefix(src, strings.TrimSuffix(publicPath, "/")+"/")
+	if !ok || filename == "" || filename != filepath.Base(filename) {
+		return nil, false // not an uploaded image, or a path traversal
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.app.Conf().ImgUpload.Path, filename))
+	if err != nil {
+		log.Warn("[AntiSpam] Read uploaded image err: ", err)
+		return nil, false
+	}
+
+	return data, true
+}
+
+// Get the hit rate metrics of anti-spam verdict cache
+func (s *AntiSpamService) CacheStats() anti_spam.VerdictCacheStats {
+	return s.client.CacheStats()
+}
+
+// Create the verdict cache for identical content,
+// the cache layer is used if it is enabled, otherwise the in-memory cache is used.
+func (s *AntiSpamService) newVerdictCache() anti_spam.VerdictCache {
+	ttl := time.Duration(s.app.Conf().Moderator.Cache.TTL) * time.Second
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+
+	return &antiSpamVerdictCache{
+		cache:  s.app.Cache(),
+		memory: simple_cache.New(),
+		ttl:    ttl,
+	}
+}
+
+type antiSpamVerdictCache struct {
+	cache  *cache.Cache
+	memory *simple_cache.Cache
+	ttl    time.Duration
+}
+
+type antiSpamVerdictCacheItem struct {
+	Verdict   anti_spam.CheckerVerdict
+	ExpiresAt int64 // the expiration of cache layer is global, so keep the expiration of each item
+}
+
+func (c *antiSpamVerdictCache) Get(key string) (*anti_spam.CheckerVerdict, bool) {
+	if c.cache == nil {
+		if v, ok := c.memory.Get(key); ok {
+			verdict := v.(anti_spam.CheckerVerdict)
+			return &verdict, true
+		}
+		return nil, false
+	}
+
+	var item antiSpamVerdictCacheItem
+	if err := c.cache.FindCache(key, &item); err != nil || time.Now().Unix() > item.ExpiresAt {
+		return nil, false
+	}
+	return &item.Verdict, true
+}
+
+func (c *antiSpamVerdictCache) Set(key string, verdict *anti_spam.CheckerVerdict) {
+	if c.cache == nil {
+		c.memory.Set(key, *verdict, c.ttl)
+		return
+	}
+
+	if err := c.cache.StoreCache(antiSpamVerdictCacheItem{
+		Verdict:   *verdict,
+		ExpiresAt: time.Now().Add(c.ttl).Unix(),
+	}, key); err != nil {
+		log.Error("[AntiSpam] Store verdict cache err: ", err)
+	}
+}
+
 func (s *AntiSpamService) Dispose() error {
+	s.queueMux.Lock()
+	if s.queue != nil && !s.queueClosed {
+		close(s.queue)
+		s.queueClosed = true
+	}
+	s.queueMux.Unlock()
+
+	if stats := s.client.CacheStats(); stats.Hits+stats.Misses > 0 {
+		log.Info(fmt.Sprintf("[AntiSpam] Verdict cache hits: %d, misses: %d, hit rate: %.2f%%",
+			stats.Hits, stats.Misses, stats.HitRate()*100))
+	}
+
 	s.client = nil
 
 	return nil
 }
 
-func (s *AntiSpamService) CheckAndBlock(data *AntiSpamCheckPayload) {
-	s.client.CheckAndBlock(s.payload2CheckerParams(data))
+// Check and block the comment if it is spam (sync), returns true if the comment is passed
+func (s *AntiSpamService) CheckAndBlock(data *AntiSpamCheckPayload) bool {
+	return s.getClientForSite(data.Comment.SiteName).CheckAndBlock(s.payload2CheckerParams(data))
+}
+
+// Find the matched ban rule of the commenter on the site, nil if not banned
+func (s *AntiSpamService) MatchBan(siteName string, ip string, email string) *anti_spam.BanRule {
+	return s.getClientForSite(siteName).MatchBan(siteName, ip, email)
+}
+
+// Run the sample comment through all the enabled checkers of the site without any side effects
+func (s *AntiSpamService) Test(siteName string, params *anti_spam.CheckerParams) []anti_spam.CheckerTestResult {
+	params.SiteName = siteName
+	if params.BlogURL == "" && siteName != "" {
+		site := s.app.dao.FindSite(siteName)
+		params.BlogURL = s.app.dao.CookSite(&site).FirstUrl
+	}
+
+	return s.getClientForSite(siteName).Test(params)
+}
+
+// Get the AntiSpam client with the per-site config overrides merged over the global config
+func (s *AntiSpamService) getClientForSite(siteName string) *anti_spam.AntiSpam {
+	if siteName == "" {
+		return s.client
+	}
+
+	site := s.app.dao.FindSite(siteName)
+	if site.IsEmpty() || strings.TrimSpace(site.ModeratorConf) == "" {
+		return s.client
+	}
+
+	conf, err := config.MergeModeratorConf(s.app.Conf().Moderator, site.ModeratorConf)
+	if err != nil {
+		log.Error("[AntiSpam] Invalid moderator config overrides of site ", strconv.Quote(siteName), ": ", err)
+		return s.client
+	}
+
+	return s.client.WithModeratorConf(conf)
+}
+
+// Whether the async moderation mode is enabled
+func (s *AntiSpamService) IsAsync() bool {
+	s.queueMux.RLock()
+	defer s.queueMux.RUnlock()
+
+	return s.queue != nil && !s.queueClosed
+}
+
+// Add the comment to the async moderation queue,
+// the `onDone` callback will be called after the check is finished.
+//
+// If the async mode is disabled, the check will be executed synchronously.
+func (s *AntiSpamService) AsyncCheckAndBlock(data *AntiSpamCheckPayload, onDone func(pass bool)) {
+	s.queueMux.RLock()
+	defer s.queueMux.RUnlock() // hold the lock while sending, to avoid the queue being closed
+
+	if s.queue == nil || s.queueClosed {
+		pass := s.CheckAndBlock(data)
+		if onDone != nil {
+			onDone(pass)
+		}
+		return
+	}
+
+	// the queued checks are the background tasks of app, which are waited to finish before shutdown
+	s.app.tasks.add()
+	s.queue <- &antiSpamTask{payload: data, onDone: onDone}
+}
+
+// Feed the spam or ham decision of moderator back to the anti-spam checkers (should call in async)
+//
+// The decision is submitted to the checkers which support reporting (e.g. Akismet),
+// and kept as the sample which can be injected into the AI prompt as few-shot examples
+// or used to train the bayes checker.
+func (s *AntiSpamService) Feedback(comment *entity.Comment, isSpam bool) {
+	if conf := s.app.Conf().Moderator; !(conf.Feedback.Enabled || conf.Bayes.Enabled) || comment.IsEmpty() {
+		return
+	}
+
+	if _, err := s.app.dao.SaveSpamSample(comment, isSpam); err != nil {
+		log.Error("[AntiSpam] Save spam sample err: ", err)
+	}
+
+	params := s.payload2CheckerParams(&AntiSpamCheckPayload{
+		Comment:      comment,
+		ReqIP:        comment.IP,
+		ReqUserAgent: comment.UA,
+	})
+	s.getClientForSite(comment.SiteName).Feedback(params, isSpam)
+}
+
+// Whether the user is trusted to skip the remote API checkers
+//
+// The user is trusted if it is an admin, in the allowlist (by email or domain),
+// or has enough approved comments.
+func (s *AntiSpamService) isTrustedUser(user *entity.User, comment *entity.Comment) bool {
+	conf := s.app.Conf().Moderator.Trusted
+	if !conf.Enabled || user.IsEmpty() {
+		return false
+	}
+
+	if user.IsAdmin {
+		return true
+	}
+
+	email := strings.ToLower(strings.TrimSpace(user.Email))
+	for _, item := range conf.Allowlist {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+		if email == item || strings.HasSuffix(email, "@"+strings.TrimPrefix(item, "@")) {
+			return true
+		}
+	}
+
+	if conf.MinApproved > 0 && s.app.dao.GetUserApprovedCommentNum(user.ID, comment.ID) >= conf.MinApproved {
+		return true
+	}
+
+	return false
 }
 
 // Payload for CheckAndBlock function
@@ -58,6 +388,9 @@ type AntiSpamCheckPayload struct {
 	ReqReferer   string
 	ReqIP        string
 	ReqUserAgent string
+
+	// The context of the comment request (optional, e.g. the span for tracing)
+	Context context.Context
 }
 
 // Transform `AntiSpamCheckPayload` to `CheckerParams` for `anti_spam.CheckAndBlock` func call
@@ -65,12 +398,17 @@ type AntiSpamCheckPayload struct {
 //	The `AntiSpamCheckPayload` struct is exposed and can be used by other modules
 //	The `CheckerParams` struct is used by `anti_spam.CheckAndBlock` in anti_spam module
 func (s *AntiSpamService) payload2CheckerParams(payload *AntiSpamCheckPayload) *anti_spam.CheckerParams {
-	user := s.app.dao.FetchUserForComment(payload.Comment)
+	dao := s.app.dao
+	if payload.Context != nil {
+		dao = dao.WithContext(payload.Context)
+	}
+
+	user := dao.FetchUserForComment(payload.Comment)
 	siteURL := ""
 
 	if payload.Comment.SiteName != "" {
-		site := s.app.dao.FindSite(payload.Comment.SiteName)
-		siteURL = s.app.dao.CookSite(&site).FirstUrl
+		site := dao.FindSite(payload.Comment.SiteName)
+		siteURL = dao.CookSite(&site).FirstUrl
 	}
 	if siteURL == "" {
 		// extract site url from referer
@@ -79,16 +417,61 @@ func (s *AntiSpamService) payload2CheckerParams(payload *AntiSpamCheckPayload) *
 		}
 	}
 
+	page := dao.FetchPageForComment(payload.Comment)
+	pageURL := ""
+	if !page.IsEmpty() {
+		pageURL = dao.GetPageAccessibleURL(&page)
+	}
+
+	// the parent comment for detecting reply-chain abuse
+	parentContent, parentAuthor := "", ""
+	if payload.Comment.Rid != 0 {
+		if parent := dao.FindComment(payload.Comment.Rid); !parent.IsEmpty() {
+			parentContent = parent.Content
+			parentAuthor = dao.FetchUserForComment(&parent).Name
+		}
+	}
+
+	// the recent comments of the user (only used by AI checker)
+	recentComments := []string{}
+	if aiConf := s.app.Conf().Moderator.AI; aiConf.Enabled && aiConf.HistorySize > 0 {
+		for _, c := range dao.FindRecentCommentsByUser(user.ID, aiConf.HistorySize, payload.Comment.ID) {
+			recentComments = append(recentComments, c.Content)
+		}
+	}
+
+	// the samples decided by the moderator (only used by AI checker)
+	examples := []anti_spam.SpamExample{}
+	if conf := s.app.Conf().Moderator; conf.AI.Enabled && conf.Feedback.Enabled && conf.Feedback.FewShot > 0 {
+		for _, sample := range dao.FindSpamSamples(payload.Comment.SiteName, conf.Feedback.FewShot) {
+			if sample.CommentID != payload.Comment.ID {
+				examples = append(examples, anti_spam.SpamExample{Content: sample.Content, IsSpam: sample.IsSpam})
+			}
+		}
+	}
+
 	return &anti_spam.CheckerParams{
-		BlogURL: siteURL,
+		BlogURL:   siteURL,
+		SiteName:  payload.Comment.SiteName,
+		PageURL:   pageURL,
+		PageTitle: page.Title,
 
 		Content:   payload.Comment.Content,
 		CommentID: payload.Comment.ID,
 
+		ParentContent:  parentContent,
+		ParentAuthor:   parentAuthor,
+		RecentComments: recentComments,
+		Examples:       examples,
+
 		UserName:  user.Name,
 		UserEmail: user.Email,
 		UserID:    user.ID,
 		UserIP:    payload.ReqIP,
 		UserAgent: payload.ReqUserAgent,
+
+		IsTrusted: s.isTrustedUser(&user, payload.Comment),
+
+		Context: payload.Context,
 	}
 }
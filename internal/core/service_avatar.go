@@ -0,0 +1,414 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/samber/lo"
+	"golang.org/x/sync/singleflight"
+)
+
+var _ Service = (*AvatarService)(nil)
+
+// The defaults of `avatar` config
+const (
+	defaultAvatarGravatarMirror = "https://www.gravatar.com/avatar/"
+	defaultAvatarCachePath      = "./data/avatar-cache"
+	defaultAvatarCacheTTL       = 168 // hours
+	defaultAvatarTimeout        = 5   // seconds
+	defaultAvatarCacheMaxSize   = 200 // MB
+	defaultAvatarRateLimit      = 240 // requests per minute of each IP
+	defaultAvatarSize           = 240
+)
+
+// The limits of avatar
+const avatarMaxData = 2 * 1024 * 1024 // bytes
+
+// The available sizes of avatar, the requested size is snapped to one of them,
+// so the number of the cached avatars of each hash is bounded
+var avatarSizes = []int{40, 80, 160, 240, 480}
+
+const cravatarURL = "https://cravatar.cn/avatar/"
+
+// The QQ avatar by the QQ number (`nk`), only the sizes 40, 100, 140 and 640 are available
+const qqAvatarURL = "https://q1.qlogo.cn/g?b=qq&nk=%s&s=%d"
+
+// The email hash is MD5 or SHA256 in lowercase hex
+var avatarHashPattern = regexp.MustCompile(`^(?:[a-f0-9]{32}|[a-f0-9]{64})$`)
+
+var qqEmailPattern = regexp.MustCompile(`^(\d{5,12})@qq\.com$`)
+
+// The avatar is not found in the source
+var errAvatarNotFound = errors.New("avatar not found")
+
+// Proxy and cache the avatars of email hashes by the `avatar` config,
+// so the avatars are loaded from the same origin and the email hashes are never exposed to the third parties by the browser.
+//
+// The sources are tried in order, and the identicon is generated if the avatar is not found in all the sources.
+// The avatars are cached in the disk, the expired cache is still served if all the sources are unavailable.
+type AvatarService struct {
+	app    *App
+	client *http.Client
+	group  singleflight.Group
+
+	cacheSize atomic.Int64 // The total size of the cache files
+	evictMu   sync.Mutex
+}
+
+type Avatar struct {
+	Data    []byte
+	Mime    string
+	ModTime time.Time
+}
+
+func NewAvatarService(app *App) *AvatarService {
+	return &AvatarService{app: app}
+}
+
+func (s *AvatarService) Init() error {
+	conf := s.app.Conf().Avatar
+	if !conf.Enabled {
+		return nil
+	}
+
+	timeout := time.Duration(conf.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultAvatarTimeout * time.Second
+	}
+	s.client = utils.NewHTTPClient(s.app.Conf().HTTP.OutboundProxy, timeout)
+
+	if err := os.MkdirAll(s.getCachePath(), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create the avatar cache directory: %w", err)
+	}
+
+	files, err := s.listCacheFiles()
+	if err != nil {
+		return fmt.Errorf("failed to read the avatar cache directory: %w", err)
+	}
+	s.cacheSize.Store(sumAvatarCacheSize(files))
+
+	return nil
+}
+
+func (s *AvatarService) Dispose() error {
+	s.client = nil
+	return nil
+}
+
+func (s *AvatarService) IsEnabled() bool {
+	return s.client != nil
+}
+
+// Check if the email hash is valid (MD5 or SHA256 in lowercase hex)
+func IsValidAvatarHash(hash string) bool {
+	return avatarHashPattern.MatchString(hash)
+}
+
+// Get the available size of avatar which is not smaller than the size (the largest one if exceeded),
+// the default size is used if zero
+func GetAvatarSize(size int) int {
+	if size <= 0 {
+		return defaultAvatarSize
+	}
+	for _, s := range avatarSizes {
+		if s >= size {
+			return s
+		}
+	}
+	return avatarSizes[len(avatarSizes)-1]
+}
+
+// Get the max number of requests of each IP per minute
+func GetAvatarRateLimit(conf config.AvatarConf) int {
+	return lo.If(conf.RateLimit > 0, conf.RateLimit).Else(defaultAvatarRateLimit)
+}
+
+// Get the time to live of avatar cache
+func (s *AvatarService) GetCacheTTL() time.Duration {
+	if ttl := s.app.Conf().Avatar.CacheTTL; ttl > 0 {
+		return time.Duration(ttl) * time.Hour
+	}
+	return defaultAvatarCacheTTL * time.Hour
+}
+
+// Get the avatar of email hash, which is fetched from the sources if the cache is missed or expired
+func (s *AvatarService) Get(hash string, size int) (*Avatar, error) {
+	if !s.IsEnabled() {
+		return nil, fmt.Errorf("avatar proxy is not enabled")
+	}
+	if !IsValidAvatarHash(hash) {
+		return nil, fmt.Errorf("invalid email hash")
+	}
+	size = GetAvatarSize(size)
+
+	cacheFile := filepath.Join(s.getCachePath(), fmt.Sprintf("%s_%d", hash, size))
+	cached, _ := readAvatarCache(cacheFile)
+	if cached != nil && time.Since(cached.ModTime) < s.GetCacheTTL() {
+		return cached, nil
+	}
+
+	// Fetch the avatar only once for the concurrent requests
+	v, err, _ := s.group.Do(cacheFile, func() (any, error) {
+		avatar, err := s.fetch(hash, size)
+		if err != nil {
+			if cached != nil {
+				log.Warn("[AvatarService] Failed to fetch the avatar, the expired cache is used: ", err)
+				return cached, nil
+			}
+			log.Warn("[AvatarService] Failed to fetch the avatar, the identicon is used: ", err)
+			return generateIdenticon(hash, size)
+		}
+
+		if err := writeAvatarCache(cacheFile, avatar.Data); err != nil {
+			log.Error("[AvatarService] Failed to cache the avatar: ", err)
+		} else {
+			s.addCacheSize(int64(len(avatar.Data)) - int64(len(lo.FromPtr(cached).Data)))
+		}
+		return avatar, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*Avatar), nil
+}
+
+// Fetch the avatar from the sources in order, the identicon is generated if not found in all the sources.
+//
+// An error is returned if any source is unavailable, so the identicon is not cached for the temporary failures.
+func (s *AvatarService) fetch(hash string, size int) (*Avatar, error) {
+	var errs []error
+	for _, source := range s.app.Conf().Avatar.Sources {
+		avatar, err := s.fetchSource(strings.ToLower(strings.TrimSpace(source)), hash, size)
+		if err == nil {
+			return avatar, nil
+		}
+		if !errors.Is(err, errAvatarNotFound) {
+			errs = append(errs, fmt.Errorf("%s: %w", source, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return generateIdenticon(hash, size)
+}
+
+func (s *AvatarService) fetchSource(source string, hash string, size int) (*Avatar, error) {
+	switch source {
+	case "gravatar":
+		mirror := s.app.Conf().Avatar.GravatarMirror
+		if mirror == "" {
+			mirror = defaultAvatarGravatarMirror
+		}
+		return s.fetchGravatar(mirror, hash, size)
+	case "cravatar":
+		return s.fetchGravatar(cravatarURL, hash, size)
+	case "qq":
+		// The QQ number is found by the email hash of users
+		email := strings.ToLower(s.app.Dao().FindEmailByHash(hash, "qq.com"))
+		matches := qqEmailPattern.FindStringSubmatch(email)
+		if matches == nil {
+			return nil, errAvatarNotFound
+		}
+		qqSize := 100
+		if size > 140 {
+			qqSize = 640
+		}
+		return s.fetchURL(fmt.Sprintf(qqAvatarURL, matches[1], qqSize))
+	}
+	return nil, fmt.Errorf("unknown avatar source %q", source)
+}
+
+// Fetch the avatar from Gravatar (or the compatible service), which responds 404 if not found by `d=404`
+func (s *AvatarService) fetchGravatar(mirror string, hash string, size int) (*Avatar, error) {
+	if !strings.HasSuffix(mirror, "/") {
+		mirror += "/"
+	}
+	return s.fetchURL(fmt.Sprintf("%s%s?d=404&s=%d", mirror, hash, size))
+}
+
+func (s *AvatarService) fetchURL(url string) (*Avatar, error) {
+	resp, err := s.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errAvatarNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("responded with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, avatarMaxData+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > avatarMaxData {
+		return nil, fmt.Errorf("the avatar is too large")
+	}
+
+	mime := http.DetectContentType(data)
+	if !strings.HasPrefix(mime, "image/") {
+		return nil, fmt.Errorf("the avatar is not an image (%s)", mime)
+	}
+
+	return &Avatar{Data: data, Mime: mime, ModTime: time.Now()}, nil
+}
+
+func (s *AvatarService) getCachePath() string {
+	if p := s.app.Conf().Avatar.CachePath; p != "" {
+		return p
+	}
+	return defaultAvatarCachePath
+}
+
+// Get the max total size of the cache files (bytes)
+func (s *AvatarService) getCacheMaxSize() int64 {
+	size := s.app.Conf().Avatar.CacheMaxSize
+	return int64(lo.If(size > 0, size).Else(defaultAvatarCacheMaxSize)) * 1024 * 1024
+}
+
+// Add the size of the written cache, the oldest cache files are evicted if the total size exceeds the limit
+func (s *AvatarService) addCacheSize(delta int64) {
+	if s.cacheSize.Add(delta) <= s.getCacheMaxSize() {
+		return
+	}
+	if err := s.evictCache(); err != nil {
+		log.Error("[AvatarService] Failed to evict the avatar cache: ", err)
+	}
+}
+
+// Remove the oldest cache files until the total size is below 90% of the limit,
+// so the eviction is not triggered by each new avatar
+func (s *AvatarService) evictCache() error {
+	s.evictMu.Lock()
+	defer s.evictMu.Unlock()
+
+	files, err := s.listCacheFiles()
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(files, func(a, b fs.FileInfo) int { return a.ModTime().Compare(b.ModTime()) })
+
+	total := sumAvatarCacheSize(files)
+	target := s.getCacheMaxSize() / 10 * 9
+	for _, f := range files {
+		if total <= target {
+			break
+		}
+		if err := os.Remove(filepath.Join(s.getCachePath(), f.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= f.Size()
+	}
+
+	s.cacheSize.Store(total)
+	return nil
+}
+
+func (s *AvatarService) listCacheFiles() ([]fs.FileInfo, error) {
+	entries, err := os.ReadDir(s.getCachePath())
+	if err != nil {
+		return nil, err
+	}
+	files := []fs.FileInfo{}
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && info.Mode().IsRegular() {
+			files = append(files, info)
+		}
+	}
+	return files, nil
+}
+
+func sumAvatarCacheSize(files []fs.FileInfo) int64 {
+	total := int64(0)
+	for _, f := range files {
+		total += f.Size()
+	}
+	return total
+}
+
+func readAvatarCache(file string) (*Avatar, error) {
+	stat, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return &Avatar{Data: data, Mime: http.DetectContentType(data), ModTime: stat.ModTime()}, nil
+}
+
+// Write the cache to a temporary file and rename it, so the incomplete cache is never read
+func writeAvatarCache(file string, data []byte) error {
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// Generate the identicon of email hash, which is a symmetric 5x5 grid in the color of hash
+func generateIdenticon(hash string, size int) (*Avatar, error) {
+	sum := sha256.Sum256([]byte(hash))
+
+	fg := color.RGBA{R: sum[0]/2 + 64, G: sum[1]/2 + 64, B: sum[2]/2 + 64, A: 255}
+	bg := color.RGBA{R: 240, G: 240, B: 240, A: 255}
+
+	// The left 3 columns are decided by the bits of hash, which are mirrored to the right
+	const grid = 5
+	cells := [grid][grid]bool{}
+	for y := 0; y < grid; y++ {
+		for x := 0; x < 3; x++ {
+			i := y*3 + x
+			on := sum[3+i/8]>>(i%8)&1 == 1
+			cells[y][x], cells[y][grid-1-x] = on, on
+		}
+	}
+
+	// The grid is padded by a half cell
+	cell := size / (grid + 1)
+	pad := (size - cell*grid) / 2
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for py := 0; py < size; py++ {
+		for px := 0; px < size; px++ {
+			c := bg
+			x, y := px-pad, py-pad
+			if x >= 0 && y >= 0 && x < cell*grid && y < cell*grid && cells[y/cell][x/cell] {
+				c = fg
+			}
+			img.SetRGBA(px, py, c)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, err
+	}
+
+	return &Avatar{Data: buf.Bytes(), Mime: "image/png", ModTime: time.Now()}, nil
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAvatarSize(t *testing.T) {
+	for size, want := range map[int]int{0: 240, 1: 40, 40: 40, 41: 80, 100: 160, 240: 240, 300: 480, 10000: 480} {
+		assert.Equal(t, want, GetAvatarSize(size), "size %d", size)
+	}
+}
+
+func TestAvatarCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+	app := NewApp(&config.Config{Avatar: config.AvatarConf{Enabled: true, CachePath: dir, CacheMaxSize: 1}})
+
+	// 3 files of 400 KB exceed the limit of 1 MB
+	now := time.Now()
+	for i, name := range []string{"oldest_80", "older_80", "newest_80"} {
+		file := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(file, make([]byte, 400*1024), 0644))
+		modTime := now.Add(time.Duration(i-3) * time.Hour)
+		assert.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+
+	s := NewAvatarService(app)
+	assert.NoError(t, s.Init())
+	assert.Equal(t, int64(1200*1024), s.cacheSize.Load())
+
+	s.addCacheSize(0)
+
+	assert.NoFileExists(t, filepath.Join(dir, "oldest_80"), "the oldest cache should be evicted")
+	assert.FileExists(t, filepath.Join(dir, "older_80"))
+	assert.FileExists(t, filepath.Join(dir, "newest_80"))
+	assert.Equal(t, int64(800*1024), s.cacheSize.Load())
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+var _ Service = (*CommentPurgeService)(nil)
+
+// Purge the soft deleted comments after the retention days
+type CommentPurgeService struct {
+	app  *App
+	stop chan struct{}
+}
+
+func NewCommentPurgeService(app *App) *CommentPurgeService {
+	return &CommentPurgeService{app: app}
+}
+
+func (s *CommentPurgeService) Init() error {
+	conf := s.app.Conf().SoftDelete
+	if !conf.Enabled || conf.Retention <= 0 {
+		return nil
+	}
+
+	s.stop = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if s.app.acquireJob("comment_purge", time.Hour) {
+				s.Purge()
+			}
+
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *CommentPurgeService) Dispose() error {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+	return nil
+}
+
+// Purge the comments which are deleted before the retention days
+func (s *CommentPurgeService) Purge() {
+	before := time.Now().AddDate(0, 0, -s.app.Conf().SoftDelete.Retention)
+	purged, err := s.app.Dao().PurgeDeletedComments(before)
+	if err != nil {
+		log.Error("[CommentPurgeService] ", err)
+	} else if purged > 0 {
+		log.Info("[CommentPurgeService] ", purged, " deleted comments are purged")
+	}
+}
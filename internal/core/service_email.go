@@ -1,12 +1,17 @@
 package core
 
 import (
+	"html"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/email"
 	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
 	"github.com/artalkjs/artalk/v2/internal/log"
 	"github.com/artalkjs/artalk/v2/internal/template"
+	"github.com/artalkjs/artalk/v2/internal/utils"
 )
 
 var _ Service = (*EmailService)(nil)
@@ -14,6 +19,7 @@ var _ Service = (*EmailService)(nil)
 type EmailService struct {
 	app   *App
 	queue *email.EmailQueue
+	stop  chan struct{}
 }
 
 func NewEmailService(app *App) *EmailService {
@@ -23,7 +29,18 @@ func NewEmailService(app *App) *EmailService {
 func (e *EmailService) Init() error {
 	e.queue = email.NewQueue(email.EmailConf{
 		EmailConf: e.app.Conf().Email,
+		Client:    utils.NewHTTPClient(e.app.Conf().HTTP.OutboundProxy, 10*time.Second),
 		OnSendSuccess: func(email *email.Email) {
+			if email.ID != 0 {
+				e.onTaskSent(email.ID)
+			}
+
+			for _, notify := range email.LinkedNotifies {
+				if err := e.app.Dao().NotifySetEmailed(&notify); err != nil {
+					log.Errorf("[Email] Flag email delivery status for digest notify failed: %s", err)
+				}
+			}
+
 			if email.LinkedNotify == nil {
 				log.Debug("email.LinkedNotify is null")
 				return
@@ -33,12 +50,26 @@ func (e *EmailService) Init() error {
 				log.Errorf("[Email] Flag email delivery status for associated comment failed: %s", err)
 			}
 		},
+		OnSendFailed: func(email *email.Email, err error) {
+			if email.ID != 0 {
+				e.onTaskFailed(email.ID, err)
+			}
+		},
 	})
 
+	if e.app.Conf().Email.Enabled {
+		e.startQueuePoller()
+	}
+
 	return nil
 }
 
 func (e *EmailService) Dispose() error {
+	if e.stop != nil {
+		close(e.stop)
+		e.stop = nil
+	}
+
 	if e.queue != nil {
 		e.queue.Close()
 	}
@@ -46,6 +77,14 @@ func (e *EmailService) Dispose() error {
 	return nil
 }
 
+// Get the number of emails waiting in the sending queue of the instance
+func (e *EmailService) QueueLen() int {
+	if e.queue == nil {
+		return 0
+	}
+	return e.queue.Len()
+}
+
 func (e *EmailService) GetRenderer(useAdminTplParam ...bool) *template.Renderer {
 	useAdminTpl := false
 	if len(useAdminTplParam) > 0 {
@@ -61,7 +100,8 @@ func (e *EmailService) GetRenderer(useAdminTplParam ...bool) *template.Renderer
 	}
 
 	// create new email render instance
-	return template.NewRenderer(e.app.Dao(), template.TYPE_EMAIL, template.NewFileLoader(mailTplName))
+	return template.NewRenderer(e.app.Dao(), template.TYPE_EMAIL, template.NewFileLoader(mailTplName)).
+		UseCustomTemplates(&template.CustomTemplates{Dir: e.app.Conf().NotifyTemplates.Dir, Locale: e.app.Conf().Locale})
 }
 
 func (e *EmailService) AsyncSendTo(subject string, body string, toAddr string) {
@@ -69,7 +109,7 @@ func (e *EmailService) AsyncSendTo(subject string, body string, toAddr string) {
 		return
 	}
 
-	e.queue.Push(&email.Email{
+	e.enqueue(&email.Email{
 		FromAddr: e.app.Conf().Email.SendAddr,
 		FromName: e.app.Conf().SiteDefault, // e.app.Conf().Email.SendName,
 		ToAddr:   toAddr,
@@ -84,26 +124,105 @@ func (e *EmailService) AsyncSend(notify *entity.Notify) {
 	}
 
 	receiveUser := e.app.Dao().FetchUserForNotify(notify)
+
+	// 对方已退订邮件通知
+	if !receiveUser.ReceiveEmail {
+		return
+	}
+
+	// 摘要模式 (通知合并到摘要邮件中定期发送)
+	if digest := e.app.Conf().Email.Digest; digest.Enabled && receiveUser.IsEmailDigest(digest.Default) {
+		if err := e.app.Dao().NotifySetDigestPending(notify); err != nil {
+			log.Error("[Email] Failed to add notify to digest: ", err)
+		}
+		return
+	}
+
 	renderer := e.GetRenderer(receiveUser.IsAdmin)
 
 	// render email body
-	mailBody := renderer.Render(notify)
-	mailSubject := ""
-	if !receiveUser.IsAdmin {
-		mailSubject = renderer.Render(notify, e.app.Conf().Email.MailSubject)
-	} else {
-		mailSubject = renderer.Render(notify, e.app.Conf().AdminNotify.Email.MailSubject)
+	mailBody, ok := renderer.RenderCustom(notify, template.ChannelEmail)
+	if !ok {
+		mailBody = renderer.Render(notify)
+	}
+	mailSubject, ok := renderer.RenderCustom(notify, template.ChannelEmailSubject)
+	if !ok {
+		if !receiveUser.IsAdmin {
+			mailSubject = renderer.Render(notify, e.app.Conf().Email.MailSubject)
+		} else {
+			mailSubject = renderer.Render(notify, e.app.Conf().AdminNotify.Email.MailSubject)
+		}
 	}
 
 	log.Debug(time.Now(), " "+receiveUser.Email)
 
+	unsubscribeLink := e.GetUnsubscribeLink(notify)
+
 	// add email send task to queue
-	e.queue.Push(&email.Email{
-		FromAddr:     e.app.Conf().Email.SendAddr,
-		FromName:     renderer.Render(notify, e.app.Conf().Email.SendName),
-		ToAddr:       receiveUser.Email,
-		Subject:      mailSubject,
-		Body:         mailBody,
-		LinkedNotify: notify,
+	e.enqueue(&email.Email{
+		FromAddr:        e.app.Conf().Email.SendAddr,
+		FromName:        renderer.Render(notify, e.app.Conf().Email.SendName),
+		ToAddr:          receiveUser.Email,
+		Subject:         mailSubject,
+		Body:            embedUnsubscribeLink(mailBody, unsubscribeLink),
+		LinkedNotify:    notify,
+		UnsubscribeLink: unsubscribeLink,
 	})
 }
+
+// Send the digest email of the notifies to the user
+func (e *EmailService) SendDigest(user entity.User, notifies []entity.Notify) error {
+	if !e.app.Conf().Email.Enabled {
+		return nil
+	}
+
+	custom := &template.CustomTemplates{Dir: e.app.Conf().NotifyTemplates.Dir, Locale: e.app.Conf().Locale}
+	subject, body, err := template.RenderDigest(e.app.Dao(), custom, notifies)
+	if err != nil {
+		return err
+	}
+
+	unsubscribeLink := ""
+	if len(notifies) > 0 {
+		unsubscribeLink = e.GetUnsubscribeLink(&notifies[0])
+	}
+
+	e.enqueue(&email.Email{
+		FromAddr:        e.app.Conf().Email.SendAddr,
+		FromName:        e.app.Conf().SiteDefault,
+		ToAddr:          user.Email,
+		Subject:         subject,
+		Body:            embedUnsubscribeLink(body, unsubscribeLink),
+		LinkedNotifies:  notifies,
+		UnsubscribeLink: unsubscribeLink,
+	})
+
+	return nil
+}
+
+// Get the unsubscribe link of the notify receiver, empty if `email.unsubscribe_url` is not set
+func (e *EmailService) GetUnsubscribeLink(notify *entity.Notify) string {
+	unsubscribeURL := strings.TrimSpace(e.app.Conf().Email.UnsubscribeURL)
+	if unsubscribeURL == "" || notify.Key == "" {
+		return ""
+	}
+
+	return utils.AddQueryToURL(unsubscribeURL, map[string]string{
+		"comment_id": strconv.FormatUint(uint64(notify.CommentID), 10),
+		"notify_key": notify.Key,
+	})
+}
+
+// Embed the unsubscribe link at the end of the email body
+func embedUnsubscribeLink(body string, link string) string {
+	if link == "" {
+		return body
+	}
+
+	footer := `<p style="font-size:12px;color:#999;text-align:center;"><a href="` + html.EscapeString(link) +
+		`" style="color:#999;">` + html.EscapeString(i18n.T("Unsubscribe")) + `</a></p>`
+	if i := strings.LastIndex(strings.ToLower(body), "</body>"); i != -1 {
+		return body[:i] + footer + body[i:]
+	}
+	return body + footer
+}
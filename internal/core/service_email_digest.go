@@ -0,0 +1,96 @@
+package core
+
+import (
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/samber/lo"
+)
+
+var _ Service = (*EmailDigestService)(nil)
+
+// The interval of checking the notifies waiting for the digest email
+const emailDigestCheckInterval = 10 * time.Minute
+
+// Send the digest emails which summarize the notifies of users periodically
+type EmailDigestService struct {
+	app  *App
+	stop chan struct{}
+}
+
+func NewEmailDigestService(app *App) *EmailDigestService {
+	return &EmailDigestService{app: app}
+}
+
+func (s *EmailDigestService) Init() error {
+	conf := s.app.Conf().Email
+	if !conf.Enabled || !conf.Digest.Enabled {
+		return nil
+	}
+
+	s.stop = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(emailDigestCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if s.app.acquireJob("email_digest", emailDigestCheckInterval) {
+					s.SendDue(time.Now())
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *EmailDigestService) Dispose() error {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+	return nil
+}
+
+// Send the digest emails to the users whose oldest waiting notify is older than the interval,
+// the notifies which have been read (in the sidebar) are not included
+func (s *EmailDigestService) SendDue(now time.Time) {
+	emailService, err := AppService[*EmailService](s.app)
+	if err != nil {
+		log.Error("[EmailDigestService] ", err)
+		return
+	}
+
+	interval := s.app.Conf().Email.Digest.GetInterval()
+	for _, userID := range s.app.Dao().FindDigestPendingUserIDs() {
+		notifies := s.app.Dao().FindDigestPendingNotifies(userID)
+		if len(notifies) == 0 {
+			continue
+		}
+
+		oldest := lo.MinBy(notifies, func(a, b entity.Notify) bool { return a.UpdatedAt.Before(b.UpdatedAt) })
+		if now.Sub(oldest.UpdatedAt) < interval {
+			continue
+		}
+
+		ids := lo.Map(notifies, func(n entity.Notify, _ int) uint { return n.ID })
+		unread := lo.Filter(notifies, func(n entity.Notify, _ int) bool { return !n.IsRead })
+
+		user := s.app.Dao().FindUserByID(userID)
+		if len(unread) > 0 && !user.IsEmpty() && user.Email != "" && user.ReceiveEmail {
+			if err := emailService.SendDigest(user, unread); err != nil {
+				log.Error("[EmailDigestService] Failed to send the digest to user ", userID, ": ", err)
+				continue
+			}
+		}
+
+		if err := s.app.Dao().NotifiesClearDigestPending(ids); err != nil {
+			log.Error("[EmailDigestService] ", err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailDigestService(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	app.Conf().Email.Digest.Interval = 1
+	service := core.NewEmailDigestService(app.App)
+
+	for _, id := range [][2]uint{{1000, 1000}, {1000, 1001}} {
+		notify := app.Dao().FindNotify(id[0], id[1])
+		assert.NoError(t, app.Dao().NotifySetDigestPending(&notify))
+	}
+	assert.Equal(t, []uint{1000}, app.Dao().FindDigestPendingUserIDs())
+	assert.Len(t, app.Dao().FindDigestPendingNotifies(1000), 2)
+
+	t.Run("NotDue", func(t *testing.T) {
+		service.SendDue(time.Now().Add(30 * time.Minute))
+		assert.Len(t, app.Dao().FindDigestPendingNotifies(1000), 2)
+	})
+
+	t.Run("Due", func(t *testing.T) {
+		service.SendDue(time.Now().Add(2 * time.Hour))
+		assert.Empty(t, app.Dao().FindDigestPendingNotifies(1000))
+		assert.Empty(t, app.Dao().FindDigestPendingUserIDs())
+	})
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/email"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/samber/lo"
+)
+
+const (
+	emailQueuePollInterval = 30 * time.Second
+	emailQueuePollBatch    = 100
+	emailTaskRetention     = 30 * 24 * time.Hour // The sent and dead emails are kept for 30 days
+
+	// The emails in sending longer than it are considered interrupted in cluster mode
+	emailSendingStaleTimeout = time.Hour
+)
+
+// Get the waiting time before the n-th retry of a failed email (1, 2, 4... minutes, up to 6 hours)
+func emailRetryBackoff(attempt int) time.Duration {
+	if attempt > 9 {
+		return 6 * time.Hour
+	}
+	return min(time.Minute<<max(0, attempt-1), 6*time.Hour)
+}
+
+// Send the emails left in the database periodically, which are the retries,
+// the emails dropped by the full queue and the emails interrupted by the server restart
+func (e *EmailService) startQueuePoller() {
+	// the emails in sending may be in the queue of other instances in cluster mode
+	var before time.Time
+	if e.app.Conf().ClusterMode {
+		before = time.Now().Add(-emailSendingStaleTimeout)
+	}
+	if err := e.app.Dao().ResetSendingEmailTasks(before); err != nil {
+		log.Error("[Email] Failed to reset the interrupted emails: ", err)
+	}
+	if err := e.app.Dao().DelEmailTasksBefore(time.Now().Add(-emailTaskRetention)); err != nil {
+		log.Error("[Email] Failed to clean up the sent emails: ", err)
+	}
+
+	e.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(emailQueuePollInterval)
+		defer ticker.Stop()
+
+		e.PushDue(time.Now())
+		for {
+			select {
+			case <-ticker.C:
+				e.PushDue(time.Now())
+			case <-stop:
+				return
+			}
+		}
+	}(e.stop)
+}
+
+// Persist the email and add it to the sending queue without blocking,
+// the email is sent later by the poller if the queue is full, and dropped if the address is suppressed
+func (e *EmailService) enqueue(mail *email.Email) {
+	if e.app.Dao().IsEmailSuppressed(mail.ToAddr) {
+		log.Warn("[Email] The address is suppressed (bounced or complained), dropping email to ", mail.ToAddr)
+		return
+	}
+
+	notifyIDs := lo.Map(mail.LinkedNotifies, func(n entity.Notify, _ int) uint { return n.ID })
+	if mail.LinkedNotify != nil {
+		notifyIDs = append(notifyIDs, mail.LinkedNotify.ID)
+	}
+
+	task, err := e.app.Dao().NewEmailTask(entity.EmailTask{
+		Status:          entity.EmailTaskStatusSending,
+		FromAddr:        mail.FromAddr,
+		FromName:        mail.FromName,
+		ToAddr:          mail.ToAddr,
+		Subject:         mail.Subject,
+		Body:            mail.Body,
+		UnsubscribeLink: mail.UnsubscribeLink,
+		NotifyIDs:       strings.Join(lo.Map(notifyIDs, func(id uint, _ int) string { return strconv.FormatUint(uint64(id), 10) }), ","),
+	})
+	if err != nil {
+		// fallback to the queue in memory, the email is not retried
+		if !e.queue.TryPush(mail) {
+			log.Error("[Email] The queue is full, dropping email to ", mail.ToAddr)
+		}
+		return
+	}
+
+	mail.ID = task.ID
+	if !e.queue.TryPush(mail) {
+		e.app.Dao().SetEmailTaskStatus(task.ID, entity.EmailTaskStatusPending)
+	}
+}
+
+// Add the pending emails which are due at the time to the sending queue
+func (e *EmailService) PushDue(now time.Time) {
+	for _, task := range e.app.Dao().FindDueEmailTasks(now, emailQueuePollBatch) {
+		if !e.pushTask(task) {
+			return // the queue is full, try again in next poll
+		}
+	}
+}
+
+// Claim the pending email and add it to the sending queue, false is returned if the queue is full
+func (e *EmailService) pushTask(task entity.EmailTask) bool {
+	if !e.app.Dao().SetEmailTaskStatus(task.ID, entity.EmailTaskStatusSending, entity.EmailTaskStatusPending) {
+		return true // not pending anymore
+	}
+
+	// the address is suppressed after the email is added (e.g. the earlier email bounced)
+	if e.app.Dao().IsEmailSuppressed(task.ToAddr) {
+		task.Status = entity.EmailTaskStatusDead
+		task.Error = "the address is suppressed"
+		e.app.Dao().UpdateEmailTask(&task)
+		return true
+	}
+
+	notifyIDs := []uint{}
+	for _, id := range utils.SplitAndTrimSpace(task.NotifyIDs, ",") {
+		if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+			notifyIDs = append(notifyIDs, uint(n))
+		}
+	}
+
+	if !e.queue.TryPush(&email.Email{
+		ID:              task.ID,
+		FromAddr:        task.FromAddr,
+		FromName:        task.FromName,
+		ToAddr:          task.ToAddr,
+		Subject:         task.Subject,
+		Body:            task.Body,
+		LinkedNotifies:  e.app.Dao().FindNotifiesByIDs(notifyIDs),
+		UnsubscribeLink: task.UnsubscribeLink,
+	}) {
+		e.app.Dao().SetEmailTaskStatus(task.ID, entity.EmailTaskStatusPending)
+		return false
+	}
+	return true
+}
+
+// Send the dead (or pending) email again immediately, the attempts are reset
+func (e *EmailService) Retry(task entity.EmailTask) (entity.EmailTask, error) {
+	if task.Status != entity.EmailTaskStatusDead && task.Status != entity.EmailTaskStatusPending {
+		return task, fmt.Errorf("the email is %s", task.Status)
+	}
+
+	task.Status = entity.EmailTaskStatusPending
+	task.Attempts = 0
+	task.NextAttemptAt = nil
+	if err := e.app.Dao().UpdateEmailTask(&task); err != nil {
+		return task, err
+	}
+
+	e.pushTask(task)
+
+	return e.app.Dao().FindEmailTaskByID(task.ID), nil
+}
+
+func (e *EmailService) onTaskSent(id uint) {
+	task := e.app.Dao().FindEmailTaskByID(id)
+	if task.IsEmpty() {
+		return
+	}
+
+	now := time.Now()
+	task.Status = entity.EmailTaskStatusSent
+	task.Attempts++
+	task.Error = ""
+	task.NextAttemptAt = nil
+	task.SentAt = &now
+	e.app.Dao().UpdateEmailTask(&task)
+}
+
+// Schedule the retry of failed email with backoff, it becomes the dead letter after all the retries
+func (e *EmailService) onTaskFailed(id uint, err error) {
+	task := e.app.Dao().FindEmailTaskByID(id)
+	if task.IsEmpty() {
+		return
+	}
+
+	task.Attempts++
+	task.Error = err.Error()
+	if task.Attempts > e.app.Conf().Email.Queue.GetMaxRetries() {
+		task.Status = entity.EmailTaskStatusDead
+		task.NextAttemptAt = nil
+		log.Error("[Email] Giving up sending email ID=", task.ID, " to ", task.ToAddr, " after ", task.Attempts, " attempts")
+	} else {
+		task.Status = entity.EmailTaskStatusPending
+		task.NextAttemptAt = lo.ToPtr(time.Now().Add(emailRetryBackoff(task.Attempts)))
+	}
+	e.app.Dao().UpdateEmailTask(&task)
+}
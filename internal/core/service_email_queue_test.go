@@ -0,0 +1,87 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailQueue(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	// the SMTP server is unreachable, so all the emails fail
+	conf := &app.Conf().Email
+	conf.Enabled = true
+	conf.SendType = config.TypeSMTP
+	conf.SMTP = config.SMTPConf{Host: "127.0.0.1", Port: 1}
+	conf.Queue.MaxRetries = 1
+
+	service := core.NewEmailService(app.App)
+	assert.NoError(t, service.Init())
+	defer service.Dispose()
+
+	findTask := func() entity.EmailTask {
+		tasks, _ := app.Dao().FindEmailTasks("", 0, 1)
+		if len(tasks) == 0 {
+			return entity.EmailTask{}
+		}
+		return tasks[0]
+	}
+	waitFor := func(status string, attempts int) entity.EmailTask {
+		assert.Eventually(t, func() bool {
+			task := findTask()
+			return task.Status == status && task.Attempts == attempts
+		}, 3*time.Second, 10*time.Millisecond)
+		return findTask()
+	}
+
+	service.AsyncSendTo("Subject", "Body", "reader@example.com")
+
+	t.Run("RetryLater", func(t *testing.T) {
+		task := waitFor(entity.EmailTaskStatusPending, 1)
+		assert.Equal(t, "reader@example.com", task.ToAddr)
+		assert.Contains(t, task.Error, "SMTP")
+		if assert.NotNil(t, task.NextAttemptAt) {
+			assert.True(t, task.NextAttemptAt.After(time.Now()))
+		}
+
+		service.PushDue(time.Now()) // not due yet
+		assert.Equal(t, 1, findTask().Attempts)
+	})
+
+	t.Run("DeadAfterRetries", func(t *testing.T) {
+		service.PushDue(time.Now().Add(time.Hour))
+		task := waitFor(entity.EmailTaskStatusDead, 2)
+		assert.Nil(t, task.NextAttemptAt)
+	})
+
+	t.Run("RetryDead", func(t *testing.T) {
+		task, err := service.Retry(findTask())
+		assert.NoError(t, err)
+		assert.NotEqual(t, entity.EmailTaskStatusDead, task.Status)
+
+		waitFor(entity.EmailTaskStatusPending, 1)
+
+		sent := findTask()
+		sent.Status = entity.EmailTaskStatusSent
+		assert.NoError(t, app.Dao().UpdateEmailTask(&sent))
+		_, err = service.Retry(sent)
+		assert.Error(t, err, "sent email should not be retried")
+	})
+
+	t.Run("Suppressed", func(t *testing.T) {
+		_, err := app.Dao().SaveEmailSuppression("bounced@example.com", "bounce", "mailgun", "")
+		assert.NoError(t, err)
+
+		_, countBefore := app.Dao().FindEmailTasks("", 0, 1)
+		service.AsyncSendTo("Subject", "Body", "Bounced@example.com")
+		_, count := app.Dao().FindEmailTasks("", 0, 1)
+		assert.Equal(t, countBefore, count, "the email to suppressed address should be dropped")
+	})
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+var _ Service = (*IPPurgeService)(nil)
+
+// Purge the stored IPs after the retention days
+type IPPurgeService struct {
+	app  *App
+	stop chan struct{}
+}
+
+func NewIPPurgeService(app *App) *IPPurgeService {
+	return &IPPurgeService{app: app}
+}
+
+func (s *IPPurgeService) Init() error {
+	if s.app.Conf().IPPrivacy.Retention <= 0 {
+		return nil
+	}
+
+	s.stop = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if s.app.acquireJob("ip_purge", time.Hour) {
+				s.Purge()
+			}
+
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *IPPurgeService) Dispose() error {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+	return nil
+}
+
+// Purge the IPs which are stored before the retention days
+func (s *IPPurgeService) Purge() {
+	before := time.Now().AddDate(0, 0, -s.app.Conf().IPPrivacy.Retention)
+	purged, err := s.app.Dao().PurgeIPs(before)
+	if err != nil {
+		log.Error("[IPPurgeService] ", err)
+	} else if purged > 0 {
+		log.Info("[IPPurgeService] The IPs of ", purged, " comments are purged")
+	}
+}
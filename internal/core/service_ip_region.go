@@ -20,11 +20,24 @@ func (s *IPRegionService) Dispose() error {
 	return nil
 }
 
-func (s *IPRegionService) Query(ip string) string {
-	client := ip_region.NewIPRegion(ip_region.IPRegionConf{
+func (s *IPRegionService) client() *ip_region.IPRegion {
+	return ip_region.NewIPRegion(ip_region.IPRegionConf{
 		IPRegionConf: s.app.Conf().IPRegion,
 		CacheEnabled: s.app.Conf().Cache.Enabled,
 	})
+}
+
+// Get the region of IP in the display precision
+func (s *IPRegionService) Query(ip string) string {
+	return s.client().IP2Region(ip)
+}
+
+// Find the geolocation of IP
+func (s *IPRegionService) Lookup(ip string) (ip_region.Region, bool) {
+	return s.client().Lookup(ip)
+}
 
-	return client.IP2Region(ip)
+// Format the stored region of comment (encoded by `Region.String`) in the display precision
+func (s *IPRegionService) Format(stored string) string {
+	return s.client().Format(ip_region.ParseRegion(stored))
 }
@@ -1,8 +1,12 @@
 package core
 
 import (
+	"context"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/notify_pusher"
+	"github.com/artalkjs/artalk/v2/internal/template"
 )
 
 var _ Service = (*NotifyService)(nil)
@@ -28,6 +32,15 @@ func (s *NotifyService) Init() error {
 			emailService.AsyncSend(notify)
 			return nil
 		},
+		WebPush: func(notify *entity.Notify) {
+			if webPushService, err := AppService[*WebPushService](s.app); err == nil {
+				webPushService.Push(notify)
+			}
+		},
+		Templates: &template.CustomTemplates{Dir: s.app.Conf().NotifyTemplates.Dir, Locale: s.app.Conf().Locale},
+		AvatarURL: func(emailHash string) string {
+			return config.GetGravatarURLByFrontendConf(s.app.Conf(), emailHash)
+		},
 	})
 
 	return nil
@@ -40,6 +53,11 @@ func (s *NotifyService) Dispose() error {
 }
 
 func (s *NotifyService) Push(comment *entity.Comment, pComment *entity.Comment) error {
-	s.pusher.Push(comment, pComment)
+	return s.PushWithContext(context.Background(), comment, pComment)
+}
+
+// Push the notifications with ctx (e.g. the request ID of the logs)
+func (s *NotifyService) PushWithContext(ctx context.Context, comment *entity.Comment, pComment *entity.Comment) error {
+	s.pusher.WithContext(ctx).Push(comment, pComment)
 	return nil
 }
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/realtime"
+	"github.com/redis/go-redis/v9"
+	"github.com/samber/lo"
+)
+
+var _ Service = (*RealtimeService)(nil)
+
+const (
+	RealtimeCommentCreated = "comment_created"
+	RealtimeCommentUpdated = "comment_updated"
+	RealtimeCommentDeleted = "comment_deleted"
+)
+
+// The default connection limits, see `realtime.max_conns`, `realtime.max_conns_per_ip` and `realtime.max_message_size`
+const (
+	realtimeDefaultMaxConns       = 10000
+	realtimeDefaultMaxConnsPerIP  = 10
+	realtimeDefaultMaxMessageSize = 64 // KB
+)
+
+// The event pushed to the subscribers of page
+type RealtimeEvent struct {
+	Type      string                `json:"type"`
+	CommentID uint                  `json:"comment_id"`
+	Comment   *entity.CookedComment `json:"comment,omitempty"` // The comment data (omitted for the deleted event)
+}
+
+// Push the comment events of pages to the subscribers in real-time
+type RealtimeService struct {
+	app     *App
+	broker  realtime.Broker
+	hub     *realtime.Hub
+	closing chan struct{}
+}
+
+func NewRealtimeService(app *App) *RealtimeService {
+	return &RealtimeService{app: app, closing: make(chan struct{})}
+}
+
+func (s *RealtimeService) Init() error {
+	conf := s.app.Conf().Realtime
+	if !conf.Enabled {
+		return nil
+	}
+
+	switch conf.Broker {
+	case config.RealtimeBrokerRedis:
+		network := "tcp"
+		if conf.Redis.Network != "" {
+			network = conf.Redis.Network
+		}
+		broker, err := realtime.NewRedisBroker(&redis.Options{
+			Network:  network,
+			Addr:     conf.Server,
+			Username: conf.Redis.Username,
+			Password: conf.Redis.Password,
+			DB:       conf.Redis.DB,
+		})
+		if err != nil {
+			return fmt.Errorf("realtime redis broker connect failed: %w", err)
+		}
+		s.broker = broker
+	case config.RealtimeBrokerMemory, "":
+		s.broker = realtime.NewMemoryBroker()
+	default:
+		return fmt.Errorf(`invalid realtime broker "%s", please check config option "realtime.broker"`, conf.Broker)
+	}
+
+	s.hub = realtime.NewHub(
+		lo.Ternary(conf.MaxConns > 0, conf.MaxConns, realtimeDefaultMaxConns),
+		lo.Ternary(conf.MaxConnsPerIP > 0, conf.MaxConnsPerIP, realtimeDefaultMaxConnsPerIP))
+
+	return nil
+}
+
+func (s *RealtimeService) Dispose() error {
+	if s.broker != nil {
+		err := s.broker.Close()
+		s.broker = nil
+		return err
+	}
+	return nil
+}
+
+// Closing is closed when the server is shutting down, the long-lived streams should be ended then,
+// so they do not hold the graceful shutdown and the clients reconnect to the new process.
+func (s *RealtimeService) Closing() <-chan struct{} {
+	return s.closing
+}
+
+// Notify the streams to end, see `Closing`
+func (s *RealtimeService) CloseStreams() {
+	select {
+	case <-s.closing:
+	default:
+		close(s.closing)
+	}
+}
+
+func (s *RealtimeService) Enabled() bool {
+	return s.broker != nil
+}
+
+// Whether the client IP can open a new stream within the connection limits,
+// which is checked before the handshake to respond the error
+func (s *RealtimeService) AllowStream(ip string) bool {
+	return s.hub != nil && s.hub.Allow(ip)
+}
+
+// Acquire a stream of the client IP within the connection limits, false if exceeded
+//
+// The stream is tracked as a background task of app, so the graceful shutdown waits for it to end (see `Closing`),
+// and the release func must be called after it ends.
+func (s *RealtimeService) AcquireStream(ip string) (release func(), ok bool) {
+	if s.hub == nil {
+		return nil, false
+	}
+	releaseConn, ok := s.hub.Acquire(ip)
+	if !ok {
+		return nil, false
+	}
+
+	s.app.tasks.add()
+	return sync.OnceFunc(func() {
+		releaseConn()
+		s.app.tasks.done()
+	}), true
+}
+
+// The max message size of WebSocket client in bytes
+func (s *RealtimeService) MaxMessageSize() int64 {
+	size := s.app.Conf().Realtime.MaxMessageSize
+	if size <= 0 {
+		size = realtimeDefaultMaxMessageSize
+	}
+	return int64(size) * 1024
+}
+
+// Publish the event to the subscribers of page
+func (s *RealtimeService) Publish(siteName string, pageKey string, event RealtimeEvent) error {
+	if s.broker == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.broker.Publish(realtime.PageTopic(siteName, pageKey), data)
+}
+
+// Subscribe the events of page, the subscription is nil if the service is disabled
+func (s *RealtimeService) Subscribe(siteName string, pageKey string) *realtime.Subscription {
+	if s.broker == nil {
+		return nil
+	}
+	return s.broker.Subscribe(realtime.PageTopic(siteName, pageKey))
+}
+
+// The number of subscribers of page on this node
+func (s *RealtimeService) Subscribers(siteName string, pageKey string) int {
+	if s.broker == nil {
+		return 0
+	}
+	return s.broker.Count(realtime.PageTopic(siteName, pageKey))
+}
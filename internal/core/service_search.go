@@ -0,0 +1,227 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/search"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+var _ Service = (*SearchService)(nil)
+var _ dao.CommentIndexer = (*SearchService)(nil)
+
+const (
+	searchQueueSize    = 1024
+	searchBatchSize    = 100 // The max number of changes sent to the external engine at once
+	searchReindexBatch = 500
+)
+
+// Match the comments by keywords with the configured search engine,
+// the comment changes are synced to the external engine in the background queue.
+type SearchService struct {
+	app    *App
+	engine search.Engine
+
+	queue    chan searchTask
+	queueMux sync.RWMutex
+	worker   sync.WaitGroup
+}
+
+type searchTask struct {
+	doc      *search.Document // The document to index, nil to delete
+	deleteID uint
+}
+
+func NewSearchService(app *App) *SearchService {
+	return &SearchService{app: app}
+}
+
+func (s *SearchService) Init() error {
+	conf := s.app.Conf().Search
+
+	s.queueMux.Lock()
+	defer s.queueMux.Unlock()
+
+	switch conf.Engine {
+	case config.SearchEngineMeilisearch:
+		s.engine = search.NewMeilisearchEngine(search.MeilisearchOptions{
+			Host:   conf.Meilisearch.Host,
+			APIKey: conf.Meilisearch.APIKey,
+			Index:  conf.Meilisearch.Index,
+			Client: utils.NewHTTPClient(s.app.Conf().HTTP.OutboundProxy, 10*time.Second),
+		})
+
+		// The external engine may be not ready yet, it is still used later
+		if err := s.engine.Setup(); err != nil {
+			log.Error("[SearchService] Failed to set up meilisearch: ", err)
+		}
+	default:
+		s.engine = search.NewDatabaseEngine(s.app.Dao().DB(), s.app.Dao().GetTableName(&entity.Comment{}))
+		if err := s.engine.Setup(); err != nil {
+			log.Warn("[SearchService] The full-text index of database is not available, fall back to the LIKE query: ", err)
+			s.engine = search.NewLikeEngine()
+		}
+	}
+
+	if indexer, ok := s.engine.(search.Indexer); ok {
+		s.queue = make(chan searchTask, searchQueueSize)
+		s.worker.Add(1)
+		go func(queue chan searchTask) {
+			defer s.worker.Done()
+			searchWork(indexer, queue)
+		}(s.queue)
+		s.app.Dao().SetCommentIndexer(s)
+	}
+
+	return nil
+}
+
+func (s *SearchService) Dispose() error {
+	s.queueMux.Lock()
+	if s.queue != nil {
+		if s.app.Dao() != nil {
+			s.app.Dao().SetCommentIndexer(nil)
+		}
+		close(s.queue)
+		s.queue = nil
+	}
+	s.engine = nil
+	s.queueMux.Unlock()
+
+	s.worker.Wait()
+	return nil
+}
+
+// Get the name of the search engine in use
+func (s *SearchService) Engine() string {
+	s.queueMux.RLock()
+	defer s.queueMux.RUnlock()
+	if s.engine == nil {
+		return ""
+	}
+	return s.engine.Name()
+}
+
+// Get the `WHERE` condition of the comments matched by keywords
+func (s *SearchService) Match(keywords string) (search.Condition, error) {
+	s.queueMux.RLock()
+	engine := s.engine
+	s.queueMux.RUnlock()
+
+	if engine == nil {
+		return search.Condition{}, fmt.Errorf("search service is not initialized")
+	}
+	return engine.Match(keywords)
+}
+
+// Rebuild the whole index, returns the number of comments indexed
+func (s *SearchService) Reindex() (int64, error) {
+	s.queueMux.RLock()
+	engine := s.engine
+	s.queueMux.RUnlock()
+
+	var total int64
+	s.app.Dao().DB().Model(&entity.Comment{}).Count(&total)
+
+	switch e := engine.(type) {
+	case search.Indexer:
+		if err := e.Clear(); err != nil {
+			return 0, err
+		}
+
+		var lastID uint
+		for {
+			var comments []entity.Comment
+			if err := s.app.Dao().DB().Where("id > ?", lastID).Order("id ASC").Limit(searchReindexBatch).Find(&comments).Error; err != nil {
+				return 0, err
+			}
+			if len(comments) == 0 {
+				break
+			}
+
+			docs := make([]search.Document, 0, len(comments))
+			for _, c := range comments {
+				docs = append(docs, search.NewDocument(c))
+			}
+			if err := e.Index(docs); err != nil {
+				return 0, err
+			}
+			lastID = comments[len(comments)-1].ID
+		}
+		return total, nil
+	case search.Rebuilder:
+		return total, e.Rebuild()
+	case nil:
+		return 0, fmt.Errorf("search service is not initialized")
+	}
+
+	return 0, nil // the LIKE query needs no index
+}
+
+// IndexComment implements dao.CommentIndexer
+func (s *SearchService) IndexComment(comment entity.Comment) {
+	doc := search.NewDocument(comment)
+	s.push(searchTask{doc: &doc})
+}
+
+// DeleteCommentIndex implements dao.CommentIndexer
+func (s *SearchService) DeleteCommentIndex(id uint) {
+	s.push(searchTask{deleteID: id})
+}
+
+func (s *SearchService) push(task searchTask) {
+	s.queueMux.RLock()
+	defer s.queueMux.RUnlock()
+
+	if s.queue == nil {
+		return
+	}
+
+	select {
+	case s.queue <- task:
+	default:
+		log.Error("[SearchService] The queue is full, please rebuild the search index later")
+	}
+}
+
+// Send the changes to the external engine in batches
+func searchWork(indexer search.Indexer, queue chan searchTask) {
+	for task := range queue {
+		tasks := []searchTask{task}
+	drain:
+		for len(tasks) < searchBatchSize {
+			select {
+			case t, ok := <-queue:
+				if !ok {
+					break drain
+				}
+				tasks = append(tasks, t)
+			default:
+				break drain
+			}
+		}
+
+		docs := []search.Document{}
+		deletes := []uint{}
+		for _, t := range tasks {
+			if t.doc != nil {
+				docs = append(docs, *t.doc)
+			} else {
+				deletes = append(deletes, t.deleteID)
+			}
+		}
+
+		if err := indexer.Index(docs); err != nil {
+			log.Error("[SearchService] Failed to index comments: ", err)
+		}
+		if err := indexer.Delete(deletes); err != nil {
+			log.Error("[SearchService] Failed to delete comments from index: ", err)
+		}
+	}
+}
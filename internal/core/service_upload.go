@@ -0,0 +1,183 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/s3"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+var _ Service = (*UploadService)(nil)
+
+// Store the uploaded images in the S3 compatible object storage by the `img_upload.storage` config,
+// the images are stored in the local disk by the upload handler if it is "local".
+//
+// The stored files of both storages can be listed and deleted (e.g. the garbage collection).
+type UploadService struct {
+	app    *App
+	client *s3.Client
+}
+
+// The presigned request to upload the image to the bucket directly
+type UploadPresign struct {
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"` // The headers should be sent with the upload request
+	FileName  string            `json:"file_name"`
+	PublicURL string            `json:"public_url"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// The uploaded file stored in the local disk or the object storage
+type UploadFile struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"` // In bytes
+	ModTime time.Time `json:"mod_time"`
+}
+
+func NewUploadService(app *App) *UploadService {
+	return &UploadService{app: app}
+}
+
+func (s *UploadService) Init() error {
+	conf := s.app.Conf().ImgUpload
+	if conf.Storage != config.UploadStorageS3 {
+		return nil
+	}
+
+	s.client = s3.New(s3.Options{
+		Endpoint:  conf.S3.Endpoint,
+		Region:    conf.S3.Region,
+		Bucket:    conf.S3.Bucket,
+		AccessKey: conf.S3.AccessKey,
+		SecretKey: conf.S3.SecretKey,
+		PathStyle: conf.S3.PathStyle,
+		Client:    utils.NewHTTPClient(s.app.Conf().HTTP.OutboundProxy, time.Minute),
+	})
+
+	return nil
+}
+
+func (s *UploadService) Dispose() error {
+	s.client = nil
+	return nil
+}
+
+// Check if the images are stored in the object storage
+func (s *UploadService) IsRemote() bool {
+	return s.client != nil
+}
+
+// Check if the images are allowed to upload by the presigned URL
+func (s *UploadService) IsPresignEnabled() bool {
+	return s.IsRemote() && s.app.Conf().ImgUpload.S3.Presign
+}
+
+// Upload the image to the object storage and get the public URL
+func (s *UploadService) Put(filename string, data []byte, contentType string) (string, error) {
+	if !s.IsRemote() {
+		return "", fmt.Errorf("the remote storage is not enabled")
+	}
+
+	if err := s.client.PutObject(s.getKey(filename), bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return "", err
+	}
+
+	return s.GetPublicURL(filename), nil
+}
+
+// Get the presigned request to upload the image of the size in bytes, which is expired after `presign_expires` seconds
+func (s *UploadService) Presign(filename string, contentType string, size int64) (UploadPresign, error) {
+	if !s.IsPresignEnabled() {
+		return UploadPresign{}, fmt.Errorf("the presigned upload is not enabled")
+	}
+
+	expires := time.Duration(s.app.Conf().ImgUpload.S3.PresignExpires) * time.Second
+	if expires <= 0 {
+		expires = 5 * time.Minute
+	}
+
+	return UploadPresign{
+		UploadURL: s.client.PresignPutObject(s.getKey(filename), contentType, size, expires),
+		Method:    "PUT",
+		Headers: map[string]string{
+			"Content-Type":   contentType,
+			"Content-Length": strconv.FormatInt(size, 10),
+		},
+		FileName:  filename,
+		PublicURL: s.GetPublicURL(filename),
+		ExpiresAt: time.Now().Add(expires),
+	}, nil
+}
+
+// Get the public URL of image, the bucket URL is used if `public_url` is not set
+func (s *UploadService) GetPublicURL(filename string) string {
+	if publicURL := s.app.Conf().ImgUpload.S3.PublicURL; publicURL != "" {
+		return strings.TrimSuffix(publicURL, "/") + "/" + s.getKey(filename)
+	}
+	return s.client.ObjectURL(s.getKey(filename), nil)
+}
+
+// List the uploaded files, the subdirectories (or the objects with the deeper keys) are excluded
+func (s *UploadService) ListFiles() ([]UploadFile, error) {
+	files := []UploadFile{}
+
+	if s.IsRemote() {
+		prefix := s.getKey("")
+		if prefix != "" {
+			prefix += "/"
+		}
+		objects, err := s.client.ListObjects(prefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objects {
+			if name := strings.TrimPrefix(obj.Key, prefix); name != "" && !strings.Contains(name, "/") {
+				files = append(files, UploadFile{Name: name, Size: obj.Size, ModTime: obj.LastModified})
+			}
+		}
+		return files, nil
+	}
+
+	entries, err := os.ReadDir(s.app.Conf().ImgUpload.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, UploadFile{Name: entry.Name(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return files, nil
+}
+
+// Delete the uploaded file by the name
+func (s *UploadService) DeleteFile(filename string) error {
+	if filename == "" || strings.ContainsAny(filename, "/\\") {
+		return fmt.Errorf("invalid filename %q", filename)
+	}
+
+	if s.IsRemote() {
+		return s.client.DeleteObject(s.getKey(filename))
+	}
+	return os.Remove(filepath.Join(s.app.Conf().ImgUpload.Path, filename))
+}
+
+// Get the object key of image with the prefix (e.g. "artalk-img/20240101-000000.000.png")
+func (s *UploadService) getKey(filename string) string {
+	return strings.TrimPrefix(path.Join(s.app.Conf().ImgUpload.S3.Prefix, filename), "/")
+}
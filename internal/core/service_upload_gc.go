@@ -0,0 +1,142 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+var _ Service = (*UploadGCService)(nil)
+
+// The defaults of `img_upload.gc` config
+const (
+	defaultUploadGCGracePeriod = 72 // hours
+	defaultUploadGCInterval    = 24 // hours
+)
+
+// The name of uploaded image and thumbnail (e.g. "20240101-120000.000.png" and "20240101-120000.000_thumb.png"),
+// the files in other names are not uploaded by Artalk, which are never deleted
+var uploadFilenamePattern = regexp.MustCompile(`\d{8}-\d{6}\.\d{3}(?:_thumb)?\.[a-zA-Z0-9]+`)
+
+// Delete the uploaded files which are not referenced by any comment after the grace period,
+// since the images are leaked after the comments are deleted.
+type UploadGCService struct {
+	app  *App
+	stop chan struct{}
+}
+
+// The result of garbage collection
+type UploadGCResult struct {
+	Scanned   int      `json:"scanned"`   // The number of uploaded files
+	Deleted   []string `json:"deleted"`   // The names of deleted files (or to be deleted in the dry run)
+	Reclaimed int64    `json:"reclaimed"` // The total size of deleted files in bytes
+}
+
+func NewUploadGCService(app *App) *UploadGCService {
+	return &UploadGCService{app: app}
+}
+
+func (s *UploadGCService) Init() error {
+	conf := s.app.Conf().ImgUpload.GC
+	if !conf.Enabled {
+		return nil
+	}
+
+	interval := time.Duration(conf.Interval) * time.Hour
+	if interval <= 0 {
+		interval = defaultUploadGCInterval * time.Hour
+	}
+
+	s.stop = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+			if !s.app.acquireJob("upload_gc", interval) {
+				continue
+			}
+
+			result, err := s.Run(false)
+			if err != nil {
+				log.Error("[UploadGCService] ", err)
+			} else if len(result.Deleted) > 0 {
+				log.Info("[UploadGCService] ", len(result.Deleted), " orphaned uploads are deleted, ", utils.FormatFileSize(result.Reclaimed), " reclaimed")
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *UploadGCService) Dispose() error {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+	return nil
+}
+
+// Find the uploaded files not referenced by any comment (including the soft deleted and the revisions)
+// after the grace period and delete them, nothing is deleted in the dry run.
+//
+// A thumbnail is kept if the original image is referenced.
+func (s *UploadGCService) Run(dryRun bool) (UploadGCResult, error) {
+	result := UploadGCResult{Deleted: []string{}}
+
+	uploadService, err := AppService[*UploadService](s.app)
+	if err != nil {
+		return result, err
+	}
+
+	files, err := uploadService.ListFiles()
+	if err != nil {
+		return result, fmt.Errorf("failed to list the uploaded files: %w", err)
+	}
+	result.Scanned = len(files)
+
+	// Find the referenced files in the contents, before which the files are listed,
+	// so the files uploaded during the scan are skipped by the grace period
+	referenced := map[string]bool{}
+	if err := s.app.Dao().EachCommentContent(func(content string) {
+		for _, name := range uploadFilenamePattern.FindAllString(content, -1) {
+			referenced[name] = true
+		}
+	}); err != nil {
+		return result, fmt.Errorf("failed to scan the comments: %w", err)
+	}
+
+	gracePeriod := time.Duration(s.app.Conf().ImgUpload.GC.GracePeriod) * time.Hour
+	if gracePeriod <= 0 {
+		gracePeriod = defaultUploadGCGracePeriod * time.Hour
+	}
+	before := time.Now().Add(-gracePeriod)
+
+	for _, f := range files {
+		if uploadFilenamePattern.FindString(f.Name) != f.Name {
+			continue // not uploaded by Artalk
+		}
+		if f.ModTime.After(before) || referenced[f.Name] || referenced[strings.Replace(f.Name, "_thumb.", ".", 1)] {
+			continue
+		}
+
+		if !dryRun {
+			if err := uploadService.DeleteFile(f.Name); err != nil {
+				return result, fmt.Errorf("failed to delete the file %q: %w", f.Name, err)
+			}
+		}
+		result.Deleted = append(result.Deleted, f.Name)
+		result.Reclaimed += f.Size
+	}
+
+	return result, nil
+}
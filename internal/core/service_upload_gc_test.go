@@ -0,0 +1,73 @@
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadGC(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	dir := t.TempDir()
+	app.Conf().ImgUpload.Path = dir
+	app.Conf().ImgUpload.GC.GracePeriod = 24
+
+	old := time.Now().Add(-48 * time.Hour)
+	writeFile := func(name string, size int, modTime time.Time) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), make([]byte, size), 0644))
+		assert.NoError(t, os.Chtimes(filepath.Join(dir, name), modTime, modTime))
+	}
+	writeFile("20240101-000000.000.png", 100, old)        // referenced
+	writeFile("20240101-000000.000_thumb.png", 10, old)   // the thumbnail of referenced
+	writeFile("20240101-000001.000.png", 200, old)        // referenced by the soft deleted comment
+	writeFile("20240101-000002.000.jpg", 300, old)        // referenced by the revision
+	writeFile("20240101-000003.000.png", 400, old)        // orphaned
+	writeFile("20240101-000003.000_thumb.png", 40, old)   // orphaned
+	writeFile("20240101-000004.000.png", 500, time.Now()) // in the grace period
+	writeFile("logo.png", 600, old)                       // not uploaded by Artalk
+
+	newComment := func(content string) entity.Comment {
+		comment := entity.Comment{Content: content, PageKey: "/gc.html", SiteName: "Site A"}
+		assert.NoError(t, app.Dao().DB().Create(&comment).Error)
+		return comment
+	}
+	newComment("![](/static/images/20240101-000000.000.png)")
+	deleted := newComment("![](https://cdn.example.com/artalk-img/20240101-000001.000.png)")
+	assert.NoError(t, app.Dao().DB().Delete(&deleted).Error)
+	edited := newComment("edited")
+	assert.NoError(t, app.Dao().DB().Create(&entity.CommentRevision{CommentID: edited.ID, Content: "![](/static/images/20240101-000002.000.jpg)"}).Error)
+
+	uploadService, err := core.AppService[*core.UploadService](app.App)
+	assert.NoError(t, err)
+	gc := core.NewUploadGCService(app.App)
+
+	t.Run("DryRun", func(t *testing.T) {
+		result, err := gc.Run(true)
+		assert.NoError(t, err)
+		assert.Equal(t, 8, result.Scanned)
+		sort.Strings(result.Deleted)
+		assert.Equal(t, []string{"20240101-000003.000.png", "20240101-000003.000_thumb.png"}, result.Deleted)
+		assert.Equal(t, int64(440), result.Reclaimed)
+		assert.FileExists(t, filepath.Join(dir, "20240101-000003.000.png"), "nothing should be deleted in the dry run")
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		result, err := gc.Run(false)
+		assert.NoError(t, err)
+		assert.Len(t, result.Deleted, 2)
+		assert.NoFileExists(t, filepath.Join(dir, "20240101-000003.000.png"))
+		assert.NoFileExists(t, filepath.Join(dir, "20240101-000003.000_thumb.png"))
+
+		files, _ := uploadService.ListFiles()
+		assert.Len(t, files, 6, "the other files should be kept")
+	})
+}
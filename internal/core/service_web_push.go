@@ -0,0 +1,135 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/internal/webpush"
+)
+
+var _ Service = (*WebPushService)(nil)
+
+// Push the replies and the mentions to the browsers of the users by Web Push
+type WebPushService struct {
+	app       *App
+	publicKey string // The VAPID public key, empty if Web Push is disabled
+}
+
+// The payload of the push message, which is shown by the service worker of frontend
+type WebPushPayload struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url"`
+	Tag   string `json:"tag"` // The notifications of the same comment are replaced by the browser
+}
+
+func NewWebPushService(app *App) *WebPushService {
+	return &WebPushService{app: app}
+}
+
+func (s *WebPushService) Init() error {
+	conf := s.app.Conf().WebPush
+	if !conf.Enabled {
+		return nil
+	}
+
+	publicKey, err := webpush.GetVAPIDPublicKey(conf.VAPIDPrivateKey)
+	if err != nil {
+		log.Warn("[WebPush] config `web_push.vapid_private_key` is invalid, Web Push is disabled: ", err)
+		return nil
+	}
+	if conf.VAPIDPublicKey != "" && conf.VAPIDPublicKey != publicKey {
+		log.Warn("[WebPush] config `web_push.vapid_public_key` does not match the private key, now it is: ", publicKey)
+	}
+	s.publicKey = publicKey
+
+	return nil
+}
+
+func (s *WebPushService) Dispose() error {
+	s.publicKey = ""
+	return nil
+}
+
+// Get the VAPID public key for subscribing in the browser, empty if Web Push is disabled
+func (s *WebPushService) PublicKey() string {
+	return s.publicKey
+}
+
+// Push the notify to all the browsers of the receiver asynchronously
+func (s *WebPushService) Push(notify *entity.Notify) {
+	if s.publicKey == "" {
+		return
+	}
+
+	go func() {
+		if err := s.SendNotify(notify); err != nil {
+			log.Error("[WebPush] ", err)
+		}
+	}()
+}
+
+// Push the notify to all the browsers of the receiver,
+// the expired subscriptions are removed
+func (s *WebPushService) SendNotify(notify *entity.Notify) error {
+	if s.publicKey == "" {
+		return nil
+	}
+
+	subs := s.app.Dao().FindPushSubscriptions(notify.UserID)
+	if len(subs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(s.getPayload(notify))
+	if err != nil {
+		return err
+	}
+
+	opts := webpush.Options{
+		Subject:         s.app.Conf().WebPush.Subject,
+		VAPIDPrivateKey: s.app.Conf().WebPush.VAPIDPrivateKey,
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		err := webpush.Send(context.Background(), webpush.Subscription{
+			Endpoint: sub.Endpoint,
+			P256DH:   sub.P256DH,
+			Auth:     sub.Auth,
+		}, payload, opts)
+
+		if errors.Is(err, webpush.ErrSubscriptionExpired) {
+			log.Debug("[WebPush] Remove the expired subscription ID=", sub.ID)
+			if err := s.app.Dao().DelPushSubscription(&sub); err != nil {
+				errs = append(errs, err)
+			}
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *WebPushService) getPayload(notify *entity.Notify) WebPushPayload {
+	comment := s.app.Dao().FetchCommentForNotify(notify)
+	nick := s.app.Dao().FetchUserForComment(&comment).Name
+
+	title := i18n.T("{{name}} replied to your comment", map[string]any{"name": nick})
+	if s.app.Dao().GetNotifyEvent(notify) == entity.NotifyEventMention {
+		title = i18n.T("{{name}} mentioned you", map[string]any{"name": nick})
+	}
+
+	return WebPushPayload{
+		Title: title,
+		Body:  utils.TruncateString(comment.Content, 200),
+		URL:   s.app.Dao().GetReadLinkByNotify(notify),
+		Tag:   "artalk-comment-" + utils.ToString(comment.ID),
+	}
+}
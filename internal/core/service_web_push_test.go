@@ -0,0 +1,56 @@
+package core_test
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/webpush"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebPushService(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	_, privateKey, _ := webpush.GenerateVAPIDKeys()
+	app.Conf().WebPush.Enabled = true
+	app.Conf().WebPush.VAPIDPrivateKey = privateKey
+
+	service := core.NewWebPushService(app.App)
+	assert.NoError(t, service.Init())
+	assert.NotEmpty(t, service.PublicKey())
+
+	var received atomic.Int32
+	pushService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		if r.URL.Path == "/expired" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer pushService.Close()
+
+	uaKey, _ := ecdh.P256().GenerateKey(rand.Reader)
+	p256dh := base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes())
+	for _, path := range []string{"/active", "/expired"} {
+		_, err := app.Dao().SavePushSubscription(1001, pushService.URL+path, p256dh, "c2VjcmV0c2VjcmV0c2VjcmV0", "")
+		assert.NoError(t, err)
+	}
+
+	notify := app.Dao().FindNotify(1001, 1000)
+	assert.NoError(t, service.SendNotify(&notify))
+	assert.Equal(t, int32(2), received.Load())
+
+	subs := app.Dao().FindPushSubscriptions(1001)
+	if assert.Len(t, subs, 1, "should remove the expired subscription") {
+		assert.Equal(t, pushService.URL+"/active", subs[0].Endpoint)
+	}
+}
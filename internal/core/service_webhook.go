@@ -0,0 +1,199 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
+	"github.com/samber/lo"
+)
+
+var _ Service = (*WebhookService)(nil)
+
+const (
+	webhookWorkers      = 2
+	webhookQueueSize    = 256
+	webhookLogRetention = 30 * 24 * time.Hour // The delivery logs are kept for 30 days
+)
+
+// Send the lifecycle events to the configured webhook endpoints,
+// the deliveries are sent in the background queue and logged in the database.
+type WebhookService struct {
+	app    *App
+	sender *webhook.Sender
+
+	queue    chan *webhookTask
+	queueMux sync.RWMutex
+	ctx      context.Context
+	cancel   context.CancelFunc
+	workers  sync.WaitGroup
+}
+
+type webhookTask struct {
+	endpoint webhook.Endpoint
+	delivery entity.WebhookDelivery
+}
+
+func NewWebhookService(app *App) *WebhookService {
+	return &WebhookService{app: app}
+}
+
+func (s *WebhookService) Init() error {
+	conf := s.app.Conf().Webhook
+	if !conf.Enabled {
+		return nil
+	}
+
+	timeout := 10 * time.Second
+	if conf.Timeout > 0 {
+		timeout = time.Duration(conf.Timeout) * time.Second
+	}
+	s.sender = &webhook.Sender{
+		Client:     utils.NewHTTPClient(s.app.Conf().HTTP.OutboundProxy, timeout),
+		MaxRetries: conf.MaxRetries,
+	}
+
+	if err := s.app.Dao().DelWebhookDeliveriesBefore(time.Now().Add(-webhookLogRetention)); err != nil {
+		log.Error("[WebhookService] Failed to clean up the delivery logs: ", err)
+	}
+
+	s.queueMux.Lock()
+	defer s.queueMux.Unlock()
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.queue = make(chan *webhookTask, webhookQueueSize)
+	for i := 0; i < webhookWorkers; i++ {
+		s.workers.Add(1)
+		go func(queue chan *webhookTask) {
+			defer s.workers.Done()
+			for task := range queue {
+				s.deliver(task)
+			}
+		}(s.queue)
+	}
+
+	return nil
+}
+
+// Dispose stops the queue, the deliveries in retrying are canceled
+func (s *WebhookService) Dispose() error {
+	s.queueMux.Lock()
+	if s.queue != nil {
+		close(s.queue)
+		s.queue = nil
+		s.cancel()
+	}
+	s.queueMux.Unlock()
+
+	s.workers.Wait()
+	return nil
+}
+
+func (s *WebhookService) Enabled() bool {
+	s.queueMux.RLock()
+	defer s.queueMux.RUnlock()
+	return s.queue != nil
+}
+
+// Trigger the event to all the endpoints which subscribe it
+func (s *WebhookService) Trigger(event string, data any) {
+	if !s.Enabled() {
+		return
+	}
+
+	body, err := json.Marshal(webhook.Payload{
+		Event:     event,
+		CreatedAt: time.Now(),
+		Data:      data,
+	})
+	if err != nil {
+		log.Error("[WebhookService] Failed to encode the payload: ", err)
+		return
+	}
+
+	for _, endpoint := range s.getEndpoints() {
+		if endpoint.Accepts(event) {
+			s.push(endpoint, event, string(body))
+		}
+	}
+}
+
+// Send the payload of delivery again, the endpoint should still be in config
+func (s *WebhookService) Redeliver(delivery entity.WebhookDelivery) (entity.WebhookDelivery, error) {
+	if !s.Enabled() {
+		return entity.WebhookDelivery{}, fmt.Errorf("webhook is disabled")
+	}
+
+	endpoint, ok := lo.Find(s.getEndpoints(), func(e webhook.Endpoint) bool { return e.URL == delivery.URL })
+	if !ok {
+		return entity.WebhookDelivery{}, fmt.Errorf("the endpoint is not found in config")
+	}
+
+	redelivery, ok := s.push(endpoint, delivery.Event, delivery.Payload)
+	if !ok {
+		return entity.WebhookDelivery{}, fmt.Errorf("failed to add the delivery to queue")
+	}
+	return redelivery, nil
+}
+
+func (s *WebhookService) getEndpoints() []webhook.Endpoint {
+	endpoints := []webhook.Endpoint{}
+	for _, e := range s.app.Conf().Webhook.Endpoints {
+		if e.URL == "" {
+			continue
+		}
+		endpoints = append(endpoints, webhook.Endpoint{URL: e.URL, Secret: e.Secret, Events: e.Events})
+	}
+	return endpoints
+}
+
+// Log the delivery and add it to the queue
+func (s *WebhookService) push(endpoint webhook.Endpoint, event string, payload string) (entity.WebhookDelivery, bool) {
+	delivery, err := s.app.Dao().NewWebhookDelivery(event, endpoint.URL, payload)
+	if err != nil {
+		return delivery, false
+	}
+
+	s.queueMux.RLock()
+	defer s.queueMux.RUnlock() // hold the lock while sending, to avoid the queue being closed
+
+	if s.queue == nil {
+		return delivery, false
+	}
+
+	select {
+	case s.queue <- &webhookTask{endpoint: endpoint, delivery: delivery}:
+		return delivery, true
+	default:
+		log.Error("[WebhookService] The queue is full, the delivery is dropped: ", delivery.ID)
+		delivery.Error = "the queue is full"
+		s.app.Dao().UpdateWebhookDelivery(&delivery)
+		return delivery, false
+	}
+}
+
+func (s *WebhookService) deliver(task *webhookTask) {
+	delivery := task.delivery
+	result := s.sender.Send(s.ctx, task.endpoint, delivery.Event, strconv.Itoa(int(delivery.ID)), []byte(delivery.Payload))
+
+	now := time.Now()
+	delivery.StatusCode = result.StatusCode
+	delivery.Response = result.Response
+	delivery.Error = result.Error
+	delivery.Attempts = result.Attempts
+	delivery.IsSuccess = result.IsSuccess()
+	delivery.DurationMs = result.Duration.Milliseconds()
+	delivery.DeliveredAt = &now
+	s.app.Dao().UpdateWebhookDelivery(&delivery)
+
+	if !delivery.IsSuccess {
+		log.Error("[WebhookService] Failed to deliver ", delivery.Event, " to ", delivery.URL, ": ", delivery.Error)
+	}
+}
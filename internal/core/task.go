@@ -0,0 +1,76 @@
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+// The default timeout of graceful shutdown (seconds), see `http.shutdown_timeout` config
+const DefaultShutdownTimeout = 30
+
+// Get the timeout of graceful shutdown
+func ShutdownTimeout(conf *config.Config) time.Duration {
+	if conf == nil || conf.HTTP.ShutdownTimeout <= 0 {
+		return DefaultShutdownTimeout * time.Second
+	}
+	return time.Duration(conf.HTTP.ShutdownTimeout) * time.Second
+}
+
+// The running background tasks, see `App.Go`
+//
+// It is not a `sync.WaitGroup`, since the tasks may be added while waiting (e.g. by the requests during restart).
+type taskGroup struct {
+	mu   sync.Mutex
+	n    int
+	idle chan struct{} // closed when all the tasks are finished
+}
+
+func (g *taskGroup) add() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.n == 0 {
+		g.idle = make(chan struct{})
+	}
+	g.n++
+}
+
+func (g *taskGroup) done() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.n--
+	if g.n == 0 {
+		close(g.idle)
+	}
+}
+
+// Go runs the background task in a goroutine (e.g. the jobs after a comment is created),
+// the tasks are waited to finish before the app is terminated or restarted,
+// so the database and the cache are not closed while they are running.
+func (app *App) Go(task func()) {
+	app.tasks.add()
+	go func() {
+		defer app.tasks.done()
+		task()
+	}()
+}
+
+// Wait for the background tasks to finish, false if the timeout is exceeded
+func (app *App) WaitTasks(timeout time.Duration) bool {
+	app.tasks.mu.Lock()
+	n, idle := app.tasks.n, app.tasks.idle
+	app.tasks.mu.Unlock()
+	if n == 0 {
+		return true
+	}
+
+	select {
+	case <-idle:
+		return true
+	case <-time.After(timeout):
+		log.Warn("[Shutdown] Timed out waiting for ", n, " background tasks to finish after ", timeout)
+		return false
+	}
+}
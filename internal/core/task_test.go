@@ -0,0 +1,55 @@
+package core
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppTasks(t *testing.T) {
+	app := NewApp(&config.Config{})
+
+	t.Run("Wait for the tasks", func(t *testing.T) {
+		var finished atomic.Int32
+		release := make(chan struct{})
+		for i := 0; i < 3; i++ {
+			app.Go(func() {
+				<-release
+				finished.Add(1)
+			})
+		}
+
+		assert.False(t, app.WaitTasks(50*time.Millisecond), "the tasks are still running")
+
+		close(release)
+		assert.True(t, app.WaitTasks(time.Second))
+		assert.Equal(t, int32(3), finished.Load())
+	})
+
+	t.Run("Tasks added while waiting", func(t *testing.T) {
+		var finished atomic.Int32
+		app.Go(func() {
+			time.Sleep(20 * time.Millisecond)
+			app.Go(func() {
+				time.Sleep(20 * time.Millisecond)
+				finished.Add(1)
+			})
+			finished.Add(1)
+		})
+
+		assert.True(t, app.WaitTasks(time.Second))
+		assert.Equal(t, int32(2), finished.Load())
+	})
+
+	t.Run("No tasks", func(t *testing.T) {
+		assert.True(t, app.WaitTasks(0))
+	})
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	assert.Equal(t, DefaultShutdownTimeout*time.Second, ShutdownTimeout(&config.Config{}))
+	assert.Equal(t, 5*time.Second, ShutdownTimeout(&config.Config{HTTP: config.HTTPConf{ShutdownTimeout: 5}}))
+}
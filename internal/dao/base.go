@@ -1,6 +1,9 @@
 package dao
 
 import (
+	"context"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +19,15 @@ type Dao struct {
 	// As the cache could be nil.
 	// Therefore, it is necessary to check if the cache is not nil before referencing it.
 	cache *DaoCache
+
+	// The external search index to sync the comment changes (could be nil)
+	indexer CommentIndexer
+}
+
+// CommentIndexer receives the comment changes, e.g. to sync with the external search index
+type CommentIndexer interface {
+	IndexComment(comment entity.Comment)
+	DeleteCommentIndex(id uint)
 }
 
 // Create new dao instance
@@ -44,3 +56,21 @@ func (dao *Dao) CacheAction(fn func(cache *DaoCache)) {
 		fn(dao.cache)
 	}
 }
+
+func (dao *Dao) SetCommentIndexer(indexer CommentIndexer) {
+	dao.indexer = indexer
+}
+
+func (dao *Dao) indexAction(fn func(indexer CommentIndexer)) {
+	if dao.indexer != nil {
+		fn(dao.indexer)
+	}
+}
+
+// Get the dao with the context of queries (e.g. the request context for tracing),
+// it shares the database, the cache and the indexer with the original dao.
+func (dao *Dao) WithContext(ctx context.Context) *Dao {
+	derived := *dao
+	derived.db = dao.db.WithContext(ctx)
+	return &derived
+}
@@ -55,6 +55,7 @@ func (c *DaoCache) SiteCacheSave(site *entity.Site) error {
 func (c *DaoCache) SiteCacheDel(site *entity.Site) {
 	c.DelCache(fmt.Sprintf(SiteByIDKey, site.ID))
 	c.DelCache(fmt.Sprintf(SiteByNameKey, site.Name))
+	c.SiteCommentListCacheDel(site.Name)
 }
 
 func (c *DaoCache) PageCacheSave(page *entity.Page) error {
@@ -78,12 +79,14 @@ func (c *DaoCache) CommentCacheSave(comment *entity.Comment) (err error) {
 	if storeErr := c.ChildCommentCacheSave(comment); storeErr != nil {
 		err = storeErr
 	}
+	c.CommentListCacheDel(comment.SiteName, comment.PageKey)
 	return
 }
 
 func (c *DaoCache) CommentCacheDel(comment *entity.Comment) {
 	c.DelCache(fmt.Sprintf(CommentByIDKey, comment.ID))
 	c.ChildCommentCacheDel(comment)
+	c.CommentListCacheDel(comment.SiteName, comment.PageKey)
 }
 
 // 缓存 父ID=>子ID 评论数据
@@ -0,0 +1,113 @@
+package dao
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+const (
+	CommentListKey        = "comment_list#site=%s;page=%s;ver=%s;query=%s"
+	CommentListVersionKey = "comment_list_ver#%s" // the scope is "all", "site=<name>" or "page=<site>;<key>"
+)
+
+// The comment lists are invalidated by changing the versions of scopes (all, site and page),
+// since the cache keys can not be deleted by the prefix in all the cache stores.
+// The outdated lists are never hit again and are removed after expired.
+
+// Get the cached comment list of page, false is returned if the cache is missed
+//
+// The query is the normalized query params, which is hashed to be a part of cache key.
+func (c *DaoCache) CommentListCacheFind(siteName string, pageKey string, query string, dest any) bool {
+	return c.FindCache(c.commentListKey(siteName, pageKey, query), dest) == nil
+}
+
+func (c *DaoCache) CommentListCacheSave(siteName string, pageKey string, query string, data any) error {
+	return c.StoreCache(data, c.commentListKey(siteName, pageKey, query))
+}
+
+// The query of the IPs of the shadow banned users' comments on page, which are cached as a comment list
+const commentListShadowBannedIPsQuery = "shadow_banned_ips"
+
+// Get the IPs of the comments of shadow banned users on page, which are cached with the same versions as the comment lists,
+// so they are invalidated together by the comment lifecycle events of page and the user updates
+func (dao *Dao) FindPageShadowBannedIPs(siteName string, pageKey string) []string {
+	var ips []string
+	dao.CacheAction(func(cache *DaoCache) {
+		if !cache.CommentListCacheFind(siteName, pageKey, commentListShadowBannedIPsQuery, &ips) {
+			ips = nil
+		}
+	})
+	if ips != nil {
+		return ips
+	}
+
+	ips = []string{}
+	dao.DB().Model(&entity.Comment{}).
+		Where("site_name = ? AND page_key = ?", siteName, pageKey).
+		Where("user_id IN (?)", dao.ShadowBannedUserIDs()).
+		Distinct("ip").Pluck("ip", &ips)
+
+	dao.CacheAction(func(cache *DaoCache) {
+		cache.CommentListCacheSave(siteName, pageKey, commentListShadowBannedIPsQuery, ips)
+	})
+
+	return ips
+}
+
+// Invalidate the comment lists of page
+func (c *DaoCache) CommentListCacheDel(siteName string, pageKey string) {
+	c.bumpCommentListVersion(getCommentListPageScope(siteName, pageKey))
+}
+
+// Invalidate the comment lists of all the pages of site
+func (c *DaoCache) SiteCommentListCacheDel(siteName string) {
+	c.bumpCommentListVersion("site=" + siteName)
+}
+
+// Invalidate the comment lists of all the sites
+func (c *DaoCache) AllCommentListCacheDel() {
+	c.bumpCommentListVersion("all")
+}
+
+func (c *DaoCache) commentListKey(siteName string, pageKey string, query string) string {
+	ver := c.getCommentListVersion("all") + "." +
+		c.getCommentListVersion("site="+siteName) + "." +
+		c.getCommentListVersion(getCommentListPageScope(siteName, pageKey))
+	return fmt.Sprintf(CommentListKey, siteName, pageKey, ver, utils.GetMD5Hash(query))
+}
+
+// Get the version of scope, a new version is created if not found,
+// so the lists cached before the version expired are never hit again
+func (c *DaoCache) getCommentListVersion(scope string) string {
+	var ver string
+	if err := c.FindCache(fmt.Sprintf(CommentListVersionKey, scope), &ver); err == nil && ver != "" {
+		return ver
+	}
+	return c.bumpCommentListVersion(scope)
+}
+
+func (c *DaoCache) bumpCommentListVersion(scope string) string {
+	ver := strconv.FormatInt(time.Now().UnixNano(), 36)
+	c.StoreCache(ver, fmt.Sprintf(CommentListVersionKey, scope))
+	return ver
+}
+
+func getCommentListPageScope(siteName string, pageKey string) string {
+	return "page=" + siteName + ";" + pageKey
+}
+
+// Invalidate the comment lists of the pages which the user commented on,
+// since the user info (e.g. the nickname and the badge) is in the lists
+func (dao *Dao) CommentListCacheDelByUser(userID uint) {
+	dao.CacheAction(func(cache *DaoCache) {
+		var pages []entity.Comment
+		dao.DB().Model(&entity.Comment{}).Where("user_id = ?", userID).Distinct("site_name", "page_key").Find(&pages)
+		for _, p := range pages {
+			cache.CommentListCacheDel(p.SiteName, p.PageKey)
+		}
+	})
+}
@@ -125,4 +125,53 @@ func (dao *Dao) CacheFlushAll() {
 			})
 		}
 	}
+
+	// Comment lists
+	dao.CacheAction(func(cache *DaoCache) {
+		cache.AllCommentListCacheDel()
+	})
+}
+
+// 清空站点的缓存 (站点、页面、评论和评论列表)
+func (dao *Dao) CacheFlushSite(siteName string) {
+	dao.CacheAction(func(cache *DaoCache) {
+		var sites []entity.Site
+		dao.DB().Where(&entity.Site{Name: siteName}).Find(&sites)
+		for _, item := range sites {
+			cache.SiteCacheDel(&item)
+		}
+
+		var pages []entity.Page
+		dao.DB().Where(&entity.Page{SiteName: siteName}).Find(&pages)
+		for _, item := range pages {
+			cache.PageCacheDel(&item)
+		}
+
+		var comments []entity.Comment
+		dao.DB().Unscoped().Where("site_name = ?", siteName).Find(&comments)
+		for _, item := range comments {
+			cache.CommentCacheDel(&item)
+		}
+
+		cache.SiteCommentListCacheDel(siteName)
+	})
+}
+
+// 清空页面的缓存 (页面、评论和评论列表)
+func (dao *Dao) CacheFlushPage(siteName string, pageKey string) {
+	dao.CacheAction(func(cache *DaoCache) {
+		var pages []entity.Page
+		dao.DB().Where(&entity.Page{Key: pageKey, SiteName: siteName}).Find(&pages)
+		for _, item := range pages {
+			cache.PageCacheDel(&item)
+		}
+
+		var comments []entity.Comment
+		dao.DB().Unscoped().Where("site_name = ? AND page_key = ?", siteName, pageKey).Find(&comments)
+		for _, item := range comments {
+			cache.CommentCacheDel(&item)
+		}
+
+		cache.CommentListCacheDel(siteName, pageKey)
+	})
 }
@@ -3,6 +3,7 @@
 package dao
 
 import (
+	"encoding/json"
 	"strings"
 
 	"github.com/artalkjs/artalk/v2/internal/entity"
@@ -19,6 +20,13 @@ func (dao *Dao) SetCommentEmailHashFunc(fn func(string) string) {
 	getCommentEmailHash = fn
 }
 
+// TODO: (refactor) remove this global variable
+var getCommentMarked = utils.Marked
+
+func (dao *Dao) SetCommentMarkedFunc(fn func(string) (string, error)) {
+	getCommentMarked = fn
+}
+
 // ===============
 //  Comment
 // ===============
@@ -41,34 +49,70 @@ func (dao *Dao) CookComment(c *entity.Comment) entity.CookedComment {
 		site = page.Site
 	}
 
-	markedContent, _ := utils.Marked(c.Content)
-
-	return entity.CookedComment{
-		ID:             c.ID,
-		Content:        c.Content,
-		ContentMarked:  markedContent,
-		UserID:         c.UserID,
-		Nick:           user.Name,
-		EmailEncrypted: getCommentEmailHash(user.Email),
-		Link:           user.Link,
-		UA:             c.UA,
-		Date:           c.CreatedAt.Local().Format(CommonDateTimeFormat),
-		IsCollapsed:    c.IsCollapsed,
-		IsPending:      c.IsPending,
-		IsPinned:       c.IsPinned,
-		IsAllowReply:   c.IsAllowReply(),
-		IsVerified:     lo.If(user.IsAdmin, true).Else(c.IsVerified),
-		Rid:            c.Rid,
-		BadgeName:      user.BadgeName,
-		BadgeColor:     user.BadgeColor,
-		IP:             c.IP,
-		Visible:        true,
-		VoteUp:         c.VoteUp,
-		VoteDown:       c.VoteDown,
-		PageKey:        c.PageKey,
-		PageURL:        dao.GetPageAccessibleURL(page, site),
-		SiteName:       c.SiteName,
+	markedContent, _ := getCommentMarked(c.Content)
+
+	var moderation *entity.CookedCommentModeration
+	if c.ModerationChecker != "" {
+		moderation = &entity.CookedCommentModeration{
+			Checker:    c.ModerationChecker,
+			Reason:     c.ModerationReason,
+			Confidence: c.ModerationConfidence,
+		}
+	}
+
+	editedAt := ""
+	if c.EditedAt != nil {
+		editedAt = c.EditedAt.Local().Format(CommonDateTimeFormat)
+	}
+
+	cooked := entity.CookedComment{
+		ID:              c.ID,
+		Content:         c.Content,
+		ContentMarked:   markedContent,
+		UserID:          c.UserID,
+		Nick:            user.Name,
+		EmailEncrypted:  getCommentEmailHash(user.Email),
+		Avatar:          user.Avatar,
+		Link:            user.Link,
+		UA:              c.UA,
+		Date:            c.CreatedAt.Local().Format(CommonDateTimeFormat),
+		EditedAt:        editedAt,
+		IsCollapsed:     c.IsCollapsed,
+		IsPending:       c.IsPending,
+		IsPinned:        c.IsPinned,
+		IsAllowReply:    c.IsAllowReply(),
+		IsVerified:      lo.If(user.IsAdmin, true).Else(c.IsVerified),
+		AuthProvider:    lo.If(c.IsVerified, user.AuthProvider).Else(""),
+		IsEmailVerified: user.IsEmailVerified,
+		Rid:             c.Rid,
+		BadgeName:       user.BadgeName,
+		BadgeColor:      user.BadgeColor,
+		IP:              c.IP,
+		IPRegionStored:  c.IPRegion,
+		Visible:         true,
+		VoteUp:          c.VoteUp,
+		VoteDown:        c.VoteDown,
+		PageKey:         c.PageKey,
+		PageURL:         dao.GetPageAccessibleURL(page, site),
+		SiteName:        c.SiteName,
+		Moderation:      moderation,
 	}
+
+	// The deleted comment is kept as a tombstone to preserve the replies
+	if c.DeletedAt.Valid {
+		cooked = entity.CookedComment{
+			ID:        cooked.ID,
+			Date:      cooked.Date,
+			Rid:       cooked.Rid,
+			Visible:   true,
+			IsDeleted: true,
+			PageKey:   cooked.PageKey,
+			PageURL:   cooked.PageURL,
+			SiteName:  cooked.SiteName,
+		}
+	}
+
+	return cooked
 }
 
 func (dao *Dao) CookAllComments(comments []*entity.Comment) []entity.CookedComment {
@@ -83,7 +127,7 @@ func (dao *Dao) CookCommentForEmail(c *entity.Comment) entity.CookedCommentForEm
 	user := dao.FetchUserForComment(c)
 	page := dao.FetchPageForComment(c)
 	site := dao.FetchSiteForComment(c)
-	content, _ := utils.Marked(c.Content)
+	content, _ := getCommentMarked(c.Content)
 
 	return entity.CookedCommentForEmail{
 		Content:    content,
@@ -101,7 +145,9 @@ func (dao *Dao) CookCommentForEmail(c *entity.Comment) entity.CookedCommentForEm
 		Site:       dao.CookSite(&site),
 		CookedComment: entity.CookedComment{
 			ID:             c.ID,
+			UserID:         c.UserID,
 			EmailEncrypted: getCommentEmailHash(user.Email),
+			Avatar:         user.Avatar,
 			Link:           user.Link,
 			UA:             c.UA,
 			IsCollapsed:    c.IsCollapsed,
@@ -120,6 +166,11 @@ func (dao *Dao) CookCommentForEmail(c *entity.Comment) entity.CookedCommentForEm
 // ===============
 
 func (dao *Dao) CookPage(p *entity.Page) entity.CookedPage {
+	publishedAt := ""
+	if p.PublishedAt != nil {
+		publishedAt = p.PublishedAt.Local().Format(CommonDateTimeFormat)
+	}
+
 	return entity.CookedPage{
 		ID:        p.ID,
 		AdminOnly: p.AdminOnly,
@@ -131,6 +182,9 @@ func (dao *Dao) CookPage(p *entity.Page) entity.CookedPage {
 		VoteDown:  p.VoteDown,
 		PV:        p.PV,
 		Date:      p.CreatedAt.Local().Format(CommonDateTimeFormat),
+
+		CommentStatus: p.CommentStatus,
+		PublishedAt:   publishedAt,
 	}
 }
 
@@ -159,6 +213,9 @@ func (dao *Dao) CookSite(s *entity.Site) entity.CookedSite {
 		Urls:     splitUrls,
 		UrlsRaw:  s.Urls,
 		FirstUrl: firstUrl,
+
+		AuthRequired:  s.AuthRequired,
+		AnonymousMode: s.AnonymousMode,
 	}
 }
 
@@ -187,6 +244,10 @@ func (dao *Dao) CookUser(u *entity.User) entity.CookedUser {
 		BadgeColor:   u.BadgeColor,
 		IsAdmin:      u.IsAdmin,
 		ReceiveEmail: u.ReceiveEmail,
+
+		IsEmailVerified: u.IsEmailVerified,
+		Avatar:          u.Avatar,
+		EmailDigest:     u.EmailDigest,
 	}
 }
 
@@ -201,6 +262,12 @@ func (dao *Dao) UserToCookedForAdmin(u *entity.User) entity.CookedUserForAdmin {
 		LastUA:       u.LastUA,
 		IsInConf:     u.IsInConf,
 		CommentCount: commentCount,
+
+		IsTOTPEnabled:  u.TOTPEnabled,
+		IsShadowBanned: u.IsShadowBanned,
+
+		AdminRole:  u.GetAdminRole(),
+		AdminSites: lo.If(u.GetAdminSites() == nil, []string{}).Else(u.GetAdminSites()),
 	}
 }
 
@@ -219,6 +286,16 @@ func (dao *Dao) CookNotify(n *entity.Notify) entity.CookedNotify {
 	}
 }
 
+func (dao *Dao) CookNotifyForInbox(n *entity.Notify) entity.CookedNotifyForInbox {
+	comment := dao.FetchCommentForNotify(n)
+	return entity.CookedNotifyForInbox{
+		CookedNotify: dao.CookNotify(n),
+		Event:        dao.GetNotifyEvent(n),
+		Comment:      dao.CookComment(&comment),
+		CreatedAt:    n.CreatedAt,
+	}
+}
+
 func (dao *Dao) CookAllNotifies(notifies []entity.Notify) []entity.CookedNotify {
 	cookedNotifies := []entity.CookedNotify{}
 	for _, n := range notifies {
@@ -226,3 +303,151 @@ func (dao *Dao) CookAllNotifies(notifies []entity.Notify) []entity.CookedNotify
 	}
 	return cookedNotifies
 }
+
+// ===============
+//  API Key
+// ===============
+
+func (dao *Dao) CookAPIKey(k *entity.APIKey) entity.CookedAPIKey {
+	return entity.CookedAPIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		KeyPrefix:  k.KeyPrefix,
+		Scopes:     k.GetScopes(),
+		RateLimit:  k.RateLimit,
+		UserID:     k.UserID,
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+	}
+}
+
+// ===============
+//  Notify Template
+// ===============
+
+func (dao *Dao) CookNotifyTemplate(t *entity.NotifyTemplate) entity.CookedNotifyTemplate {
+	return entity.CookedNotifyTemplate{
+		ID:        t.ID,
+		Channel:   t.Channel,
+		Event:     t.Event,
+		Locale:    t.Locale,
+		Content:   t.Content,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// ===============
+//  Comment Revision
+// ===============
+
+func (dao *Dao) CookCommentRevision(r *entity.CommentRevision) entity.CookedCommentRevision {
+	editor := dao.FindUserByID(r.EditorID)
+	return entity.CookedCommentRevision{
+		ID:         r.ID,
+		CommentID:  r.CommentID,
+		Content:    r.Content,
+		EditorID:   r.EditorID,
+		EditorName: editor.Name,
+		Date:       r.CreatedAt.Local().Format(CommonDateTimeFormat),
+	}
+}
+
+// ===============
+//  Webhook Delivery
+// ===============
+
+func (dao *Dao) CookWebhookDelivery(d *entity.WebhookDelivery) entity.CookedWebhookDelivery {
+	return entity.CookedWebhookDelivery{
+		ID:          d.ID,
+		Event:       d.Event,
+		URL:         d.URL,
+		Payload:     d.Payload,
+		StatusCode:  d.StatusCode,
+		Response:    d.Response,
+		Error:       d.Error,
+		Attempts:    d.Attempts,
+		IsSuccess:   d.IsSuccess,
+		DurationMs:  d.DurationMs,
+		CreatedAt:   d.CreatedAt,
+		DeliveredAt: d.DeliveredAt,
+	}
+}
+
+// ===============
+//  Email Task
+// ===============
+
+func (dao *Dao) CookEmailTask(t *entity.EmailTask) entity.CookedEmailTask {
+	return entity.CookedEmailTask{
+		ID:            t.ID,
+		Status:        t.Status,
+		ToAddr:        t.ToAddr,
+		Subject:       t.Subject,
+		Attempts:      t.Attempts,
+		Error:         t.Error,
+		NextAttemptAt: t.NextAttemptAt,
+		SentAt:        t.SentAt,
+		CreatedAt:     t.CreatedAt,
+	}
+}
+
+// ===============
+//  Email Suppression
+// ===============
+
+func (dao *Dao) CookEmailSuppression(s *entity.EmailSuppression) entity.CookedEmailSuppression {
+	return entity.CookedEmailSuppression{
+		ID:        s.ID,
+		Email:     s.Email,
+		Reason:    s.Reason,
+		Provider:  s.Provider,
+		Detail:    s.Detail,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+func (dao *Dao) CookAuditLog(l *entity.AuditLog) entity.CookedAuditLog {
+	parse := func(raw string) any {
+		var v any
+		if raw != "" {
+			json.Unmarshal([]byte(raw), &v)
+		}
+		return v
+	}
+
+	return entity.CookedAuditLog{
+		ID:         l.ID,
+		ActorType:  l.ActorType,
+		ActorID:    l.ActorID,
+		ActorName:  l.ActorName,
+		IP:         l.IP,
+		Action:     l.Action,
+		Method:     l.Method,
+		Path:       l.Path,
+		TargetType: l.TargetType,
+		TargetID:   l.TargetID,
+		SiteName:   l.SiteName,
+		Before:     parse(l.Before),
+		After:      parse(l.After),
+		CreatedAt:  l.CreatedAt,
+	}
+}
+
+// ===============
+//  Ban
+// ===============
+
+func (dao *Dao) CookBan(b *entity.Ban) entity.CookedBan {
+	return entity.CookedBan{
+		ID:        b.ID,
+		Type:      b.Type,
+		Value:     b.Value,
+		Action:    b.Action,
+		Reason:    b.Reason,
+		SiteName:  b.SiteName,
+		ExpiresAt: b.ExpiresAt,
+		IsExpired: b.IsExpired(),
+		UserID:    b.UserID,
+		CreatedAt: b.CreatedAt,
+	}
+}
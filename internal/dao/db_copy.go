@@ -0,0 +1,242 @@
+package dao
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// The maximum parameters of an insert statement
+const copyDBMaxParams = 2000
+
+type CopyDBOptions struct {
+	BatchSize int // The number of rows to copy at once (default: 500)
+
+	// Called after each batch is copied
+	Progress func(table string, copied int64, total int64)
+}
+
+// The validation result of the copied table
+type CopyDBTable struct {
+	Table          string `json:"table"`
+	SourceRows     int64  `json:"source_rows"`
+	TargetRows     int64  `json:"target_rows"`
+	SourceChecksum string `json:"source_checksum"`
+	TargetChecksum string `json:"target_checksum"`
+}
+
+func (t CopyDBTable) IsValid() bool {
+	return t.SourceRows == t.TargetRows && t.SourceChecksum == t.TargetChecksum
+}
+
+// Copy all the tables to the target database (e.g. migrate from SQLite to PostgreSQL),
+// which is validated by the row counts and checksums after copied.
+//
+// The schema of target is created by the migration and the tables should be empty.
+// The IDs and the soft deleted rows are kept, and the sequences of IDs are reset for PostgreSQL.
+func (dao *Dao) CopyDB(target *Dao, opts CopyDBOptions) ([]CopyDBTable, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+
+	src := dao.DB().Unscoped().Session(&gorm.Session{SkipHooks: true, Logger: dao.DB().Logger.LogMode(logger.Silent)})
+	dst := target.DB().Unscoped().Session(&gorm.Session{SkipHooks: true, Logger: target.DB().Logger.LogMode(logger.Silent)})
+
+	// The target tables should be empty, so the IDs are not conflicted
+	for _, model := range getModels() {
+		var count int64
+		if err := dst.Model(model).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("the table %s of target database is not empty", target.GetTableName(model))
+		}
+	}
+
+	results := []CopyDBTable{}
+	for _, model := range getModels() {
+		table := dao.GetTableName(model)
+
+		var total int64
+		if err := src.Model(model).Count(&total).Error; err != nil {
+			return results, err
+		}
+
+		copied := int64(0)
+		batch := reflect.New(reflect.SliceOf(reflect.TypeOf(model).Elem())).Interface()
+		if err := src.Model(model).FindInBatches(batch, opts.BatchSize, func(_ *gorm.DB, _ int) error {
+			n := int64(reflect.ValueOf(batch).Elem().Len())
+			if err := copyDBRows(dst, model, batch); err != nil {
+				return fmt.Errorf("failed to copy table %s: %w", table, err)
+			}
+
+			copied += n
+			if opts.Progress != nil {
+				opts.Progress(table, copied, total)
+			}
+			return nil
+		}).Error; err != nil {
+			return results, err
+		}
+
+		if err := resetDBSequence(dst, target.GetTableName(model)); err != nil {
+			return results, fmt.Errorf("failed to reset the id sequence of table %s: %w", table, err)
+		}
+
+		result := CopyDBTable{Table: table}
+		var err error
+		if result.SourceRows, result.SourceChecksum, err = checksumDBTable(src, model, opts.BatchSize); err != nil {
+			return results, err
+		}
+		if result.TargetRows, result.TargetChecksum, err = checksumDBTable(dst, model, opts.BatchSize); err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Insert the rows with the IDs in a transaction
+func copyDBRows(dst *gorm.DB, model any, rows any) error {
+	stmt := &gorm.Statement{DB: dst}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+
+	// Split the rows to keep the parameters of statement under the limit (e.g. 2100 of SQL Server)
+	size := max(copyDBMaxParams/len(stmt.Schema.DBNames), 1)
+
+	return dst.Transaction(func(tx *gorm.DB) error {
+		// The explicit IDs are not allowed to insert into the identity column of SQL Server by default
+		if tx.Dialector.Name() == "sqlserver" {
+			table := tx.Statement.Quote(stmt.Schema.Table)
+			if err := tx.Exec("SET IDENTITY_INSERT " + table + " ON").Error; err != nil {
+				return err
+			}
+			defer tx.Exec("SET IDENTITY_INSERT " + table + " OFF")
+		}
+
+		// Insert the maps of columns, otherwise the zero values are replaced by the default values (e.g. `default:true`)
+		return tx.Table(stmt.Schema.Table).CreateInBatches(toDBColumnMaps(stmt.Schema, rows), size).Error
+	})
+}
+
+func toDBColumnMaps(s *schema.Schema, rows any) []map[string]any {
+	list := reflect.ValueOf(rows).Elem()
+	maps := make([]map[string]any, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		m := map[string]any{}
+		for _, field := range s.Fields {
+			if field.DBName == "" {
+				continue // the associations
+			}
+			m[field.DBName], _ = field.ValueOf(context.Background(), list.Index(i))
+		}
+		maps = append(maps, m)
+	}
+	return maps
+}
+
+// Reset the sequence of IDs after inserted the explicit IDs, which is only needed for PostgreSQL
+func resetDBSequence(db *gorm.DB, table string) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %s",
+		table, db.Statement.Quote(table))).Error
+}
+
+// Get the row count and the checksum of table.
+//
+// The values are normalized before hashed to be comparable between the databases,
+// the times are compared in seconds since the precisions differ (e.g. MySQL `datetime`).
+func checksumDBTable(db *gorm.DB, model any, batchSize int) (int64, string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return 0, "", err
+	}
+
+	count := int64(0)
+	h := sha256.New()
+	batch := reflect.New(reflect.SliceOf(reflect.TypeOf(model).Elem())).Interface()
+	err := db.Model(model).FindInBatches(batch, batchSize, func(_ *gorm.DB, _ int) error {
+		rows := reflect.ValueOf(batch).Elem()
+		for i := 0; i < rows.Len(); i++ {
+			hashDBRow(h, stmt.Schema, rows.Index(i))
+			count++
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return 0, "", err
+	}
+
+	return count, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashDBRow(h hash.Hash, s *schema.Schema, row reflect.Value) {
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue // the associations
+		}
+		v, _ := field.ValueOf(context.Background(), row)
+		fmt.Fprintf(h, "%s=%s\x00", field.DBName, normalizeDBValue(v))
+	}
+	h.Write([]byte{'\n'})
+}
+
+func normalizeDBValue(v any) string {
+	switch val := v.(type) {
+	case time.Time:
+		return formatDBTime(val)
+	case *time.Time:
+		if val == nil {
+			return "<nil>"
+		}
+		return formatDBTime(*val)
+	case gorm.DeletedAt:
+		if !val.Valid {
+			return "<nil>"
+		}
+		return formatDBTime(val.Time)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'g', -1, 32)
+	case driver.Valuer:
+		dv, err := val.Value()
+		if err != nil || dv == nil {
+			return "<nil>"
+		}
+		return normalizeDBValue(dv)
+	case []byte:
+		return string(val)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "<nil>"
+		}
+		return normalizeDBValue(rv.Elem().Interface())
+	}
+	return fmt.Sprint(v)
+}
+
+func formatDBTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Truncate(time.Second).Format(time.RFC3339)
+}
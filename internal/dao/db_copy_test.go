@@ -0,0 +1,96 @@
+package dao_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/db"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func TestCopyDB(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	// The rows which are easy to lose in copying
+	user := entity.User{Name: "no_email", Email: "no_email@example.com"}
+	app.Dao().DB().Create(&user)
+	app.Dao().DB().Model(&user).Update("receive_email", false)
+	deleted := app.Dao().FindComment(1000)
+	app.Dao().DB().Delete(&deleted)
+
+	newTarget := func() *dao.Dao {
+		ddb, err := db.OpenSQLite(filepath.Join(t.TempDir(), "target.db"), &gorm.Config{
+			NamingStrategy:                           schema.NamingStrategy{TablePrefix: "atk_"},
+			DisableForeignKeyConstraintWhenMigrating: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.CloseDB(ddb) })
+		return dao.NewDao(ddb)
+	}
+
+	target := newTarget()
+	progress := map[string]int64{}
+	results, err := app.Dao().CopyDB(target, dao.CopyDBOptions{
+		BatchSize: 3,
+		Progress: func(table string, copied int64, total int64) {
+			assert.LessOrEqual(t, copied, total)
+			progress[table] = copied
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, r := range results {
+		assert.True(t, r.IsValid(), "table %s should be valid", r.Table)
+	}
+
+	var count int64
+	app.Dao().DB().Unscoped().Model(&entity.Comment{}).Count(&count)
+	assert.Greater(t, count, int64(3), "should be copied in batches")
+	assert.Equal(t, count, progress["atk_comments"])
+
+	var copiedUser entity.User
+	target.DB().First(&copiedUser, user.ID)
+	assert.False(t, copiedUser.ReceiveEmail, "should keep the zero value of field which has a default value")
+
+	var copiedDeleted entity.Comment
+	target.DB().Unscoped().First(&copiedDeleted, deleted.ID)
+	assert.True(t, copiedDeleted.DeletedAt.Valid, "should keep the soft deleted rows")
+
+	t.Run("The new rows after copied", func(t *testing.T) {
+		comment := entity.Comment{Content: "new"}
+		assert.NoError(t, target.DB().Create(&comment).Error)
+		assert.Greater(t, comment.ID, deleted.ID)
+	})
+
+	t.Run("Not empty target", func(t *testing.T) {
+		_, err := app.Dao().CopyDB(target, dao.CopyDBOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Different checksum", func(t *testing.T) {
+		target := newTarget()
+		_, err := app.Dao().CopyDB(target, dao.CopyDBOptions{})
+		assert.NoError(t, err)
+
+		a, _ := app.Dao().CopyDB(newTarget(), dao.CopyDBOptions{})
+		target.DB().Model(&entity.Comment{}).Where("id = ?", 1001).Update("content", "changed")
+		b, _ := target.CopyDB(newTarget(), dao.CopyDBOptions{})
+		for i := range a {
+			if a[i].Table == "atk_comments" {
+				assert.NotEqual(t, a[i].SourceChecksum, b[i].SourceChecksum)
+			} else {
+				assert.Equal(t, a[i].SourceChecksum, b[i].SourceChecksum, a[i].Table)
+			}
+		}
+	})
+}
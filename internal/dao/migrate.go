@@ -7,6 +7,15 @@ import (
 	"github.com/artalkjs/artalk/v2/internal/log"
 )
 
+// Get all the models of tables
+func getModels() []any {
+	return []any{&entity.Site{}, &entity.Page{}, &entity.User{},
+		&entity.AuthIdentity{}, &entity.UserEmailVerify{},
+		&entity.Comment{}, &entity.Notify{}, &entity.Vote{}, &entity.Reaction{}, &entity.CommentRevision{}, &entity.SpamSample{},
+		&entity.APIKey{}, &entity.RefreshToken{}, &entity.WebhookDelivery{}, &entity.CommentReport{}, &entity.NotifyTemplate{},
+		&entity.PushSubscription{}, &entity.EmailTask{}, &entity.EmailSuppression{}, &entity.AuditLog{}, &entity.Ban{}, &entity.Upload{}}
+}
+
 func (dao *Dao) MigrateModels() {
 	// Upgrade the database
 	if dao.DB().Migrator().HasTable(&entity.Comment{}) &&
@@ -15,10 +24,16 @@ func (dao *Dao) MigrateModels() {
 		dao.MigrateRootID()
 	}
 
+	// The hot score should be generated for existing comments
+	migrateHotScore := dao.DB().Migrator().HasTable(&entity.Comment{}) &&
+		!dao.DB().Migrator().HasColumn(&entity.Comment{}, "hot_score")
+
+	// The email hashes should be generated for existing users
+	migrateEmailHash := dao.DB().Migrator().HasTable(&entity.User{}) &&
+		!dao.DB().Migrator().HasColumn(&entity.User{}, "email_hash")
+
 	// Migrate the schema
-	dao.DB().AutoMigrate(&entity.Site{}, &entity.Page{}, &entity.User{},
-		&entity.AuthIdentity{}, &entity.UserEmailVerify{},
-		&entity.Comment{}, &entity.Notify{}, &entity.Vote{})
+	dao.DB().AutoMigrate(getModels()...)
 
 	// Delete all foreign key constraints
 	// Leave relationship maintenance to the program and reduce the difficulty of database management.
@@ -26,6 +41,14 @@ func (dao *Dao) MigrateModels() {
 	// and the DB may not support foreign keys, so don't rely on the foreign key function of the DB system.
 	dao.DropConstraintsIfExist()
 
+	if migrateHotScore {
+		dao.MigrateHotScore()
+	}
+
+	if migrateEmailHash {
+		dao.MigrateEmailHash()
+	}
+
 	// Merge pages
 	if os.Getenv("ATK_DB_MIGRATOR_FUNC_MERGE_PAGES") == "1" {
 		dao.MergePages()
@@ -110,6 +133,47 @@ func (dao *Dao) MigrateRootID() {
 	log.Info(TAG, "Root IDs generated successfully.")
 }
 
+func (dao *Dao) MigrateHotScore() {
+	const TAG = "[DB Migrator] "
+
+	log.Info(TAG, "Generating hot scores...")
+
+	comments := []entity.Comment{}
+	if err := dao.DB().Select("id", "vote_up", "vote_down", "created_at").Find(&comments).Error; err != nil {
+		log.Error(TAG, "Failed to load comments. ", err)
+		return
+	}
+
+	for _, comment := range comments {
+		if err := dao.DB().Model(&comment).UpdateColumn("hot_score", comment.GetHotScore()).Error; err != nil {
+			log.Error(TAG, "Failed to update hot score. ", err, " ID=", comment.ID)
+		}
+	}
+
+	log.Info(TAG, "Hot scores generated successfully.")
+}
+
+func (dao *Dao) MigrateEmailHash() {
+	const TAG = "[DB Migrator] "
+
+	log.Info(TAG, "Generating email hashes...")
+
+	users := []entity.User{}
+	if err := dao.DB().Select("id", "email").Where("email <> ''").Find(&users).Error; err != nil {
+		log.Error(TAG, "Failed to load users. ", err)
+		return
+	}
+
+	for _, user := range users {
+		md5Hash, sha256Hash := entity.GetEmailHashes(user.Email)
+		if err := dao.DB().Model(&user).UpdateColumns(map[string]any{"email_hash": md5Hash, "email_hash_sha256": sha256Hash}).Error; err != nil {
+			log.Error(TAG, "Failed to update email hash. ", err, " ID=", user.ID)
+		}
+	}
+
+	log.Info(TAG, "Email hashes generated successfully.")
+}
+
 func (dao *Dao) MergePages() {
 	// merge pages with same key and site_name, sum pv
 	pages := []*entity.Page{}
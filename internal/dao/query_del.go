@@ -1,6 +1,8 @@
 package dao
 
 import (
+	"time"
+
 	"github.com/artalkjs/artalk/v2/internal/entity"
 )
 
@@ -22,6 +24,21 @@ func (dao *Dao) DelComment(comment *entity.Comment) error {
 		return err
 	}
 
+	// 清除 reaction
+	if err := dao.DB().Unscoped().Where("comment_id = ?", comment.ID).Delete(&entity.Reaction{}).Error; err != nil {
+		return err
+	}
+
+	// 清除 revision
+	if err := dao.DB().Unscoped().Where("comment_id = ?", comment.ID).Delete(&entity.CommentRevision{}).Error; err != nil {
+		return err
+	}
+
+	// 清除 report
+	if err := dao.DB().Unscoped().Where("comment_id = ?", comment.ID).Delete(&entity.CommentReport{}).Error; err != nil {
+		return err
+	}
+
 	// 删除 comment
 	err := dao.DB().Unscoped().Delete(comment).Error
 	if err != nil {
@@ -33,9 +50,57 @@ func (dao *Dao) DelComment(comment *entity.Comment) error {
 		cache.CommentCacheDel(comment)
 	})
 
+	dao.indexAction(func(indexer CommentIndexer) {
+		indexer.DeleteCommentIndex(comment.ID)
+	})
+
+	return nil
+}
+
+// Soft delete the comment, it is kept as a tombstone to preserve the replies
+func (dao *Dao) SoftDelComment(comment *entity.Comment) error {
+	if err := dao.DB().Delete(comment).Error; err != nil {
+		return err
+	}
+
+	// 删除缓存
+	dao.CacheAction(func(cache *DaoCache) {
+		cache.CommentCacheDel(comment)
+	})
+
 	return nil
 }
 
+// Purge the soft deleted comments which are deleted before the time
+//
+// The tombstones which still have replies are kept with the content cleared,
+// so that the reply threads are not broken. Returns the number of purged comments.
+func (dao *Dao) PurgeDeletedComments(before time.Time) (int, error) {
+	var comments []entity.Comment
+	if err := dao.DB().Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Find(&comments).Error; err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, c := range comments {
+		var replies int64
+		dao.DB().Unscoped().Model(&entity.Comment{}).Where("rid = ? OR root_id = ?", c.ID, c.ID).Count(&replies)
+		if replies > 0 {
+			if c.Content != "" {
+				dao.DB().Unscoped().Model(&c).UpdateColumn("content", "")
+			}
+			continue
+		}
+
+		if err := dao.DelComment(&c); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
 // 删除所有子评论
 func (dao *Dao) DelCommentChildren(parentID uint) error {
 	var rErr error
@@ -55,9 +120,9 @@ func (dao *Dao) DelPage(page *entity.Page) error {
 		return err
 	}
 
-	// 删除所有相关内容
+	// 删除所有相关内容 (包括软删除的评论)
 	var comments []entity.Comment
-	dao.DB().Where("page_key = ? AND site_name = ?", page.Key, page.SiteName).Find(&comments)
+	dao.DB().Unscoped().Where("page_key = ? AND site_name = ?", page.Key, page.SiteName).Find(&comments)
 
 	for _, c := range comments {
 		dao.DelComment(&c)
@@ -121,6 +186,9 @@ func (dao *Dao) DelUser(user *entity.User) error {
 		dao.DelAuthIdentity(&a)
 	}
 
+	// Delete user push subscriptions
+	dao.DB().Unscoped().Where("user_id = ?", user.ID).Delete(&entity.PushSubscription{})
+
 	// Clear cache
 	dao.CacheAction(func(cache *DaoCache) {
 		cache.UserCacheDel(user)
@@ -142,3 +210,39 @@ func (dao *Dao) DelAuthIdentity(authIdentity *entity.AuthIdentity) error {
 
 	return nil
 }
+
+func (dao *Dao) DelAPIKey(key *entity.APIKey) error {
+	return dao.DB().Unscoped().Delete(key).Error
+}
+
+func (dao *Dao) DelNotifyTemplate(tpl *entity.NotifyTemplate) error {
+	return dao.DB().Unscoped().Delete(tpl).Error
+}
+
+func (dao *Dao) DelPushSubscription(sub *entity.PushSubscription) error {
+	return dao.DB().Unscoped().Delete(sub).Error
+}
+
+// Delete the webhook delivery logs created before the time
+func (dao *Dao) DelWebhookDeliveriesBefore(t time.Time) error {
+	return dao.DB().Unscoped().Where("created_at < ?", t).Delete(&entity.WebhookDelivery{}).Error
+}
+
+func (dao *Dao) DelEmailSuppression(suppression *entity.EmailSuppression) error {
+	return dao.DB().Unscoped().Delete(suppression).Error
+}
+
+// Delete the sent and dead emails created before the time
+func (dao *Dao) DelEmailTasksBefore(t time.Time) error {
+	return dao.DB().Unscoped().Where("status IN ? AND created_at < ?", []string{entity.EmailTaskStatusSent, entity.EmailTaskStatusDead}, t).
+		Delete(&entity.EmailTask{}).Error
+}
+
+// Dismiss the reports of comment (soft delete, so the readers can report it again)
+func (dao *Dao) DismissCommentReports(commentID uint) error {
+	return dao.DB().Where("comment_id = ?", commentID).Delete(&entity.CommentReport{}).Error
+}
+
+func (dao *Dao) DelBan(ban *entity.Ban) error {
+	return dao.DB().Unscoped().Delete(ban).Error
+}
@@ -2,6 +2,7 @@ package dao_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/test"
@@ -82,3 +83,49 @@ func TestDelUser(t *testing.T) {
 	app.Dao().DB().Where("user_id = ?", 1000).Model(&entity.AuthIdentity{}).Count(&authIdentityCount)
 	assert.Equal(t, int64(0), authIdentityCount, "User auth identities not cleaned")
 }
+
+func TestPurgeDeletedComments(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	for _, id := range []uint{1000, 1005} {
+		comment := app.Dao().FindComment(id)
+		assert.NoError(t, app.Dao().SoftDelComment(&comment))
+	}
+
+	purged, err := app.Dao().PurgeDeletedComments(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, purged, "should keep the comments deleted after the time")
+
+	purged, err = app.Dao().PurgeDeletedComments(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged, "should only purge the comment without replies")
+
+	assert.True(t, app.Dao().FindDeletedComment(1005).IsEmpty(), "should be purged")
+
+	tombstone := app.Dao().FindDeletedComment(1000)
+	assert.False(t, tombstone.IsEmpty(), "should keep the tombstone with replies")
+	assert.Empty(t, tombstone.Content, "should clear the content of tombstone")
+}
+
+func TestPurgeIPs(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	purged, err := app.Dao().PurgeIPs(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), purged, "should keep the IPs stored after the time")
+	assert.NotEmpty(t, app.Dao().FindComment(1000).IP)
+
+	purged, err = app.Dao().PurgeIPs(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Greater(t, purged, int64(0))
+
+	var count int64
+	app.Dao().DB().Unscoped().Model(&entity.Comment{}).Where("ip <> ''").Count(&count)
+	assert.Zero(t, count, "should clear the IPs of comments")
+	assert.Empty(t, app.Dao().FindComment(1000).IP, "should refresh the cache")
+
+	app.Dao().DB().Model(&entity.User{}).Where("last_ip <> ''").Count(&count)
+	assert.Zero(t, count, "should clear the last IPs of inactive users")
+}
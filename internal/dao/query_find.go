@@ -3,10 +3,12 @@ package dao
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/cache"
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
 )
 
 func QueryDBWithCache[T any](dao *Dao, name string, queryDB func() (T, error)) (T, error) {
@@ -97,6 +99,77 @@ func (dao *Dao) _findCommentChildrenOnce(source *[]entity.Comment, parentID uint
 	}
 }
 
+// Find the recent comments of the user (newest first)
+func (dao *Dao) FindRecentCommentsByUser(userID uint, limit int, excludeIDs ...uint) []entity.Comment {
+	comments := []entity.Comment{}
+	if userID == 0 || limit <= 0 {
+		return comments
+	}
+
+	query := dao.DB().Where("user_id = ?", userID)
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+	query.Order("created_at DESC, id DESC").Limit(limit).Find(&comments)
+
+	return comments
+}
+
+// Find the recent comments of the site created after the time (newest first)
+func (dao *Dao) FindRecentComments(siteName string, since time.Time, limit int, excludeIDs ...uint) []entity.Comment {
+	comments := []entity.Comment{}
+	if limit <= 0 {
+		return comments
+	}
+
+	query := dao.DB().Where("site_name = ? AND created_at >= ?", siteName, since)
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+	query.Order("created_at DESC, id DESC").Limit(limit).Find(&comments)
+
+	return comments
+}
+
+// Find the recent comments of the IP created after the time (newest first)
+func (dao *Dao) FindRecentCommentsByIP(ip string, since time.Time, limit int) []entity.Comment {
+	comments := []entity.Comment{}
+	if ip == "" || limit <= 0 {
+		return comments
+	}
+
+	dao.DB().Where("ip = ? AND created_at >= ?", ip, since).
+		Order("created_at DESC, id DESC").Limit(limit).Find(&comments)
+
+	return comments
+}
+
+// Find the latest comment of the user or the IP in the site (including the pending and deleted comments)
+func (dao *Dao) FindLatestSiteComment(siteName string, userID uint, ip string) entity.Comment {
+	var comment entity.Comment
+	dao.DB().Unscoped().Where("site_name = ? AND (user_id = ? OR ip = ?)", siteName, userID, ip).
+		Order("created_at DESC, id DESC").First(&comment)
+	return comment
+}
+
+// Get the number of approved (not pending) comments of the IP
+func (dao *Dao) GetIPApprovedCommentNum(ip string) int {
+	var num int64
+	dao.DB().Model(&entity.Comment{}).Where("ip = ? AND is_pending = ?", ip, false).Count(&num)
+	return int(num)
+}
+
+// Get the number of approved (not pending) comments of the user
+func (dao *Dao) GetUserApprovedCommentNum(userID uint, excludeIDs ...uint) int {
+	var num int64
+	query := dao.DB().Model(&entity.Comment{}).Where("user_id = ? AND is_pending = ?", userID, false)
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+	query.Count(&num)
+	return int(num)
+}
+
 // 查找用户 (精确查找 name & email)
 func (dao *Dao) FindUser(name string, email string) entity.User {
 	user, _ := QueryDBWithCache(dao, fmt.Sprintf(UserByNameEmailKey, strings.ToLower(name), strings.ToLower(email)), func() (user entity.User, err error) {
@@ -130,6 +203,25 @@ func (dao *Dao) FindUsersByEmail(email string) []entity.User {
 	return users
 }
 
+// 查找邮箱 (通过邮箱哈希 MD5 或 SHA256，仅查找指定域名的邮箱，例如 "qq.com")
+func (dao *Dao) FindEmailByHash(hash string, domain string) string {
+	column := "email_hash"
+	if len(hash) == 64 {
+		column = "email_hash_sha256"
+	}
+
+	emails := []string{}
+	dao.DB().Model(&entity.User{}).
+		Where(column+" = ?", strings.ToLower(hash)).
+		Where("LOWER(email) LIKE ?", "%@"+strings.ToLower(domain)).
+		Limit(1).Pluck("email", &emails)
+
+	if len(emails) == 0 {
+		return ""
+	}
+	return emails[0]
+}
+
 // 查找用户 (通过 ID)
 func (dao *Dao) FindUserByID(id uint) entity.User {
 	user, _ := QueryDBWithCache(dao, fmt.Sprintf("user#id=%d", id), func() (user entity.User, err error) {
@@ -204,6 +296,175 @@ func (dao *Dao) FindUnreadNotifies(userID uint) []entity.Notify {
 	return notifies
 }
 
+// The query of the notifies of user in the notification center (the notifies of deleted comments are excluded)
+func (dao *Dao) UserNotifiesQuery(userID uint, unreadOnly bool) *gorm.DB {
+	q := dao.DB().Model(&entity.Notify{}).
+		Where("user_id = ? AND comment_id IN (?)", userID, dao.DB().Model(&entity.Comment{}).Select("id"))
+	if unreadOnly {
+		q = q.Where("is_read = ?", false)
+	}
+	return q
+}
+
+// Count the comments pending review in the sites (nil for all sites)
+func (dao *Dao) CountPendingComments(siteNames []string) int64 {
+	var count int64
+	q := dao.DB().Model(&entity.Comment{}).Where("is_pending = ?", true)
+	if siteNames != nil {
+		q = q.Where("site_name IN (?)", siteNames)
+	}
+	q.Count(&count)
+	return count
+}
+
+// The filter of the moderation queue
+type PendingCommentsFilter struct {
+	SiteNames []string // The sites of comments (nil for all sites)
+	PageKey   string   // The page key of comments (empty for all pages)
+	Checker   string   // The anti-spam checker which held the comment, "none" for the comments held without checker (empty for all)
+}
+
+// The query of the comments pending review by the filter
+func (dao *Dao) PendingCommentsQuery(filter PendingCommentsFilter) *gorm.DB {
+	q := dao.DB().Model(&entity.Comment{}).Where("is_pending = ?", true)
+	if filter.SiteNames != nil {
+		q = q.Where("site_name IN (?)", filter.SiteNames)
+	}
+	if filter.PageKey != "" {
+		q = q.Where("page_key = ?", filter.PageKey)
+	}
+	switch filter.Checker {
+	case "":
+	case "none":
+		q = q.Where("moderation_checker = ? OR moderation_checker IS NULL", "")
+	default:
+		q = q.Where("moderation_checker = ?", filter.Checker)
+	}
+	return q
+}
+
+// Count the comments pending review by the anti-spam checker which held them ("none" for no checker)
+func (dao *Dao) CountPendingCommentsByChecker(filter PendingCommentsFilter) map[string]int64 {
+	filter.Checker = ""
+
+	var rows []struct {
+		ModerationChecker string
+		Count             int64
+	}
+	dao.PendingCommentsQuery(filter).
+		Select("moderation_checker, COUNT(*) AS count").
+		Group("moderation_checker").
+		Scan(&rows)
+
+	counts := map[string]int64{}
+	for _, r := range rows {
+		checker := r.ModerationChecker
+		if checker == "" {
+			checker = "none"
+		}
+		counts[checker] += r.Count
+	}
+	return counts
+}
+
+// The filter of the comment statistics
+type CommentStatsFilter struct {
+	SiteNames []string  // The sites of comments (nil for all sites)
+	From      time.Time // The start of created time (inclusive)
+	To        time.Time // The end of created time (inclusive)
+}
+
+// The query of the comments created in the time range by the filter
+func (dao *Dao) CommentStatsQuery(filter CommentStatsFilter) *gorm.DB {
+	q := dao.DB().Model(&entity.Comment{}).Where("created_at >= ? AND created_at <= ?", filter.From, filter.To)
+	if filter.SiteNames != nil {
+		q = q.Where("site_name IN (?)", filter.SiteNames)
+	}
+	return q
+}
+
+// The brief of comment for the statistics
+type CommentStatsRow struct {
+	ID                uint
+	CreatedAt         time.Time
+	IsPending         bool
+	ModerationChecker string
+	IsSpam            bool `gorm:"-"` // Decided as spam by the moderator
+}
+
+// Find the brief of comments created in the time range (the oldest first)
+func (dao *Dao) FindCommentStatsRows(filter CommentStatsFilter) []CommentStatsRow {
+	rows := []CommentStatsRow{}
+	dao.CommentStatsQuery(filter).
+		Select("id, created_at, is_pending, moderation_checker").
+		Order("id ASC").
+		Scan(&rows)
+
+	spamIDs := []uint{}
+	dao.DB().Model(&entity.SpamSample{}).
+		Where("is_spam = ? AND comment_id IN (?)", true, dao.CommentStatsQuery(filter).Select("id")).
+		Pluck("comment_id", &spamIDs)
+	spam := map[uint]bool{}
+	for _, id := range spamIDs {
+		spam[id] = true
+	}
+	for i := range rows {
+		rows[i].IsSpam = spam[rows[i].ID]
+	}
+
+	return rows
+}
+
+type PageCommentCount struct {
+	SiteName string
+	PageKey  string
+	Count    int64
+}
+
+// Find the pages with the most comments created in the time range
+func (dao *Dao) FindTopCommentedPages(filter CommentStatsFilter, limit int) []PageCommentCount {
+	counts := []PageCommentCount{}
+	dao.CommentStatsQuery(filter).
+		Select("site_name, page_key, COUNT(*) AS count").
+		Group("site_name, page_key").
+		Order("count DESC, page_key ASC").
+		Limit(limit).
+		Scan(&counts)
+	return counts
+}
+
+type UserCommentCount struct {
+	UserID uint
+	Count  int64
+}
+
+// Find the users with the most comments created in the time range
+func (dao *Dao) FindTopCommenters(filter CommentStatsFilter, limit int) []UserCommentCount {
+	counts := []UserCommentCount{}
+	dao.CommentStatsQuery(filter).
+		Where("user_id <> ?", 0).
+		Select("user_id, COUNT(*) AS count").
+		Group("user_id").
+		Order("count DESC, user_id ASC").
+		Limit(limit).
+		Scan(&counts)
+	return counts
+}
+
+// Find the IDs of users who have the notifies waiting for the digest email
+func (dao *Dao) FindDigestPendingUserIDs() []uint {
+	ids := []uint{}
+	dao.DB().Model(&entity.Notify{}).Where("is_digest_pending = ?", true).Distinct().Pluck("user_id", &ids)
+	return ids
+}
+
+// Find the notifies of user waiting for the digest email (the oldest first)
+func (dao *Dao) FindDigestPendingNotifies(userID uint) []entity.Notify {
+	notifies := []entity.Notify{}
+	dao.DB().Where("user_id = ? AND is_digest_pending = ?", userID, true).Order("id ASC").Find(&notifies)
+	return notifies
+}
+
 func (dao *Dao) FindNotifyParentComment(n *entity.Notify) entity.Comment {
 	comment := dao.FetchCommentForNotify(n)
 	if comment.Rid == 0 {
@@ -232,6 +493,155 @@ func (dao *Dao) GetVoteNumUpDown(targetName string, targetID uint) (int, int) {
 
 //#endregion
 
+// Find the soft deleted comment by ID
+func (dao *Dao) FindDeletedComment(id uint) entity.Comment {
+	var comment entity.Comment
+	dao.DB().Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&comment)
+	return comment
+}
+
+// #region Comment Revision
+
+// Find the revisions of comment in the edited order
+func (dao *Dao) FindCommentRevisions(commentID uint) []entity.CommentRevision {
+	revisions := []entity.CommentRevision{}
+	dao.DB().Where("comment_id = ?", commentID).Order("id ASC").Find(&revisions)
+	return revisions
+}
+
+//#endregion
+
+// #region Reaction
+
+// Get the reaction counts of comments, the result is grouped by comment ID and emoji
+func (dao *Dao) GetReactionCounts(commentIDs []uint) map[uint]map[string]int {
+	counts := map[uint]map[string]int{}
+	if len(commentIDs) == 0 {
+		return counts
+	}
+
+	var rows []struct {
+		CommentID uint
+		Emoji     string
+		Count     int
+	}
+	dao.DB().Model(&entity.Reaction{}).
+		Select("comment_id, emoji, COUNT(*) AS count").
+		Where("comment_id IN ?", commentIDs).
+		Group("comment_id, emoji").
+		Scan(&rows)
+
+	for _, r := range rows {
+		if counts[r.CommentID] == nil {
+			counts[r.CommentID] = map[string]int{}
+		}
+		counts[r.CommentID][r.Emoji] = r.Count
+	}
+
+	return counts
+}
+
+// Find the reactions of visitor, the visitor is identified by the user ID if logged in, otherwise by the IP
+func (dao *Dao) FindVisitorReactions(commentIDs []uint, userID uint, ip string) []entity.Reaction {
+	reactions := []entity.Reaction{}
+	if len(commentIDs) == 0 {
+		return reactions
+	}
+
+	q := dao.DB().Where("comment_id IN ?", commentIDs)
+	if userID != 0 {
+		q = q.Where("user_id = ?", userID)
+	} else {
+		q = q.Where("user_id = 0 AND ip = ?", ip)
+	}
+	q.Find(&reactions)
+
+	return reactions
+}
+
+//#endregion
+
+// #region Comment Report
+
+// Find the report of visitor, the visitor is identified by the user ID if logged in, otherwise by the IP
+func (dao *Dao) FindVisitorCommentReport(commentID uint, userID uint, ip string) entity.CommentReport {
+	var report entity.CommentReport
+	q := dao.DB().Where("comment_id = ?", commentID)
+	if userID != 0 {
+		q = q.Where("user_id = ?", userID)
+	} else {
+		q = q.Where("user_id = 0 AND ip = ?", ip)
+	}
+	q.First(&report)
+	return report
+}
+
+func (dao *Dao) CountCommentReports(commentID uint) int64 {
+	var count int64
+	dao.DB().Model(&entity.CommentReport{}).Where("comment_id = ?", commentID).Count(&count)
+	return count
+}
+
+// Count the distinct reporters of comment, each IP and each user is counted once
+//
+// The smaller one of the distinct IPs and the distinct visitors (users, or IPs of the guests) is used,
+// so the reports of multiple accounts from the same IP are counted as one.
+func (dao *Dao) CountCommentReporters(commentID uint) int64 {
+	var ips, users, guests int64
+	q := dao.DB().Model(&entity.CommentReport{}).Where("comment_id = ?", commentID)
+	q.Session(&gorm.Session{}).Distinct("ip").Count(&ips)
+	q.Session(&gorm.Session{}).Where("user_id != 0").Distinct("user_id").Count(&users)
+	q.Session(&gorm.Session{}).Where("user_id = 0").Distinct("ip").Count(&guests)
+	return min(ips, users+guests)
+}
+
+// Find the IDs of reported comments in the order of the report count and the latest report,
+// the comments are in the sites if not nil, and the deleted comments are excluded
+func (dao *Dao) FindReportedCommentIDs(siteNames []string, offset int, limit int) ([]uint, int64) {
+	q := dao.DB().Model(&entity.CommentReport{}).
+		Where("comment_id IN (?)", dao.DB().Model(&entity.Comment{}).Select("id"))
+	if siteNames != nil {
+		q = q.Where("site_name IN (?)", siteNames)
+	}
+
+	var count int64
+	q.Session(&gorm.Session{}).Distinct("comment_id").Count(&count)
+
+	ids := []uint{}
+	q.Select("comment_id").Group("comment_id").
+		Order("COUNT(*) DESC, MAX(id) DESC").
+		Offset(offset).Limit(limit).
+		Pluck("comment_id", &ids)
+
+	return ids, count
+}
+
+// Find the reports of comments in the latest order
+func (dao *Dao) FindCommentReports(commentIDs []uint) []entity.CommentReport {
+	reports := []entity.CommentReport{}
+	if len(commentIDs) == 0 {
+		return reports
+	}
+	dao.DB().Where("comment_id IN ?", commentIDs).Order("id DESC").Find(&reports)
+	return reports
+}
+
+//#endregion
+
+// #region Shadow Ban
+
+// Exclude the comments of shadow banned users
+func (dao *Dao) NoShadowBanned(db *gorm.DB) *gorm.DB {
+	return db.Where("user_id NOT IN (?)", dao.ShadowBannedUserIDs())
+}
+
+// The subquery of the shadow banned user IDs
+func (dao *Dao) ShadowBannedUserIDs() *gorm.DB {
+	return dao.DB().Model(&entity.User{}).Select("id").Where("is_shadow_banned = ?", true)
+}
+
+//#endregion
+
 // #region 管理员账号检测
 func (dao *Dao) GetAllAdmins() []entity.User {
 	// TODO add cache and flush cache when admin changed
@@ -292,3 +702,384 @@ func (dao *Dao) FindAuthIdentityByUserID(provider string, userID uint) entity.Au
 	dao.DB().Where("provider = ? AND user_id = ?", provider, userID).First(&identity)
 	return identity
 }
+
+// Find the latest spam samples decided by the moderator (newest first)
+//
+// If the siteName is empty, the samples of all sites will be found.
+func (dao *Dao) FindSpamSamples(siteName string, limit int) []entity.SpamSample {
+	samples := []entity.SpamSample{}
+	if limit <= 0 {
+		return samples
+	}
+
+	query := dao.DB()
+	if siteName != "" {
+		query = query.Where("site_name = ?", siteName)
+	}
+	query.Order("updated_at DESC, id DESC").Limit(limit).Find(&samples)
+
+	return samples
+}
+
+// Find all the spam samples decided by the moderator
+func (dao *Dao) FindAllSpamSamples() []entity.SpamSample {
+	samples := []entity.SpamSample{}
+	dao.DB().Order("id ASC").Find(&samples)
+	return samples
+}
+
+func (dao *Dao) FindAPIKeyByID(id uint) entity.APIKey {
+	var key entity.APIKey
+	dao.DB().Where("id = ?", id).First(&key)
+	return key
+}
+
+// Find the API key by the plain key
+func (dao *Dao) FindAPIKey(key string) entity.APIKey {
+	var apiKey entity.APIKey
+	if key == "" {
+		return apiKey
+	}
+	dao.DB().Where("key_hash = ?", entity.HashToken(key)).First(&apiKey)
+	return apiKey
+}
+
+func (dao *Dao) FindAllAPIKeys() []entity.APIKey {
+	keys := []entity.APIKey{}
+	dao.DB().Order("id ASC").Find(&keys)
+	return keys
+}
+
+func (dao *Dao) FindNotifyTemplateByID(id uint) entity.NotifyTemplate {
+	var tpl entity.NotifyTemplate
+	dao.DB().Where("id = ?", id).First(&tpl)
+	return tpl
+}
+
+// Find the notify templates of the channels
+func (dao *Dao) FindNotifyTemplates(channels []string) []entity.NotifyTemplate {
+	tpls := []entity.NotifyTemplate{}
+	dao.DB().Where("channel IN ?", channels).Find(&tpls)
+	return tpls
+}
+
+func (dao *Dao) FindAllNotifyTemplates() []entity.NotifyTemplate {
+	tpls := []entity.NotifyTemplate{}
+	dao.DB().Order("channel ASC, event ASC, locale ASC").Find(&tpls)
+	return tpls
+}
+
+func (dao *Dao) FindPushSubscriptionByEndpoint(endpoint string) entity.PushSubscription {
+	var sub entity.PushSubscription
+	if endpoint == "" {
+		return sub
+	}
+	dao.DB().Where("endpoint = ?", endpoint).First(&sub)
+	return sub
+}
+
+func (dao *Dao) FindPushSubscriptions(userID uint) []entity.PushSubscription {
+	subs := []entity.PushSubscription{}
+	dao.DB().Where("user_id = ?", userID).Find(&subs)
+	return subs
+}
+
+// Find the refresh token by the plain token
+func (dao *Dao) FindRefreshToken(token string) entity.RefreshToken {
+	var refreshToken entity.RefreshToken
+	if token == "" {
+		return refreshToken
+	}
+	dao.DB().Where("token_hash = ?", entity.HashToken(token)).First(&refreshToken)
+	return refreshToken
+}
+
+// Check if the login session family has an unrevoked and unexpired refresh token
+func (dao *Dao) IsRefreshTokenFamilyActive(familyID string) bool {
+	var count int64
+	dao.DB().Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL AND expires_at > ?", familyID, time.Now()).
+		Count(&count)
+	return count > 0
+}
+
+// Find the users who have commented on the page
+//
+// The users are matched by the name prefix case-insensitively if `namePrefix` is not empty.
+func (dao *Dao) FindPageParticipants(siteName string, pageKey string, namePrefix string, limit int) []entity.User {
+	users := []entity.User{}
+	q := dao.DB().Where("id IN (?)", pageParticipantIDs(dao, siteName, pageKey))
+	if namePrefix != "" {
+		q = q.Where("LOWER(name) LIKE ?", strings.ToLower(namePrefix)+"%")
+	}
+	q.Order("name ASC").Limit(limit).Find(&users)
+	return users
+}
+
+// Find the users who have commented on the page by the names (case-insensitive)
+func (dao *Dao) FindPageParticipantsByNames(siteName string, pageKey string, names []string) []entity.User {
+	users := []entity.User{}
+	if len(names) == 0 {
+		return users
+	}
+	lowerNames := []string{}
+	for _, n := range names {
+		lowerNames = append(lowerNames, strings.ToLower(n))
+	}
+	dao.DB().Where("id IN (?) AND LOWER(name) IN ?", pageParticipantIDs(dao, siteName, pageKey), lowerNames).Find(&users)
+	return users
+}
+
+func pageParticipantIDs(dao *Dao, siteName string, pageKey string) *DB {
+	return dao.DB().Model(&entity.Comment{}).Select("user_id").
+		Where("site_name = ? AND page_key = ? AND is_pending = ?", siteName, pageKey, false)
+}
+
+func (dao *Dao) FindWebhookDeliveryByID(id uint) entity.WebhookDelivery {
+	var delivery entity.WebhookDelivery
+	dao.DB().Where("id = ?", id).First(&delivery)
+	return delivery
+}
+
+// Find the webhook delivery logs in the latest order, filtered by the event and the status if not empty
+func (dao *Dao) FindWebhookDeliveries(event string, isSuccess *bool, offset int, limit int) ([]entity.WebhookDelivery, int64) {
+	query := dao.DB().Model(&entity.WebhookDelivery{})
+	if event != "" {
+		query = query.Where("event = ?", event)
+	}
+	if isSuccess != nil {
+		query = query.Where("is_success = ?", *isSuccess)
+	}
+
+	var count int64
+	query.Count(&count)
+
+	deliveries := []entity.WebhookDelivery{}
+	query.Order("id DESC").Offset(offset).Limit(limit).Find(&deliveries)
+
+	return deliveries, count
+}
+
+func (dao *Dao) FindNotifiesByIDs(ids []uint) []entity.Notify {
+	notifies := []entity.Notify{}
+	if len(ids) > 0 {
+		dao.DB().Where("id IN ?", ids).Find(&notifies)
+	}
+	return notifies
+}
+
+func (dao *Dao) FindEmailTaskByID(id uint) entity.EmailTask {
+	var task entity.EmailTask
+	dao.DB().Where("id = ?", id).First(&task)
+	return task
+}
+
+// Find the outgoing emails in the latest order, filtered by the status if not empty
+func (dao *Dao) FindEmailTasks(status string, offset int, limit int) ([]entity.EmailTask, int64) {
+	query := dao.DB().Model(&entity.EmailTask{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var count int64
+	query.Count(&count)
+
+	tasks := []entity.EmailTask{}
+	query.Order("id DESC").Offset(offset).Limit(limit).Find(&tasks)
+
+	return tasks, count
+}
+
+// Count the outgoing emails by the status
+func (dao *Dao) CountEmailTasksByStatus() map[string]int64 {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	dao.DB().Model(&entity.EmailTask{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows)
+
+	counts := map[string]int64{}
+	for _, r := range rows {
+		counts[r.Status] = r.Count
+	}
+	return counts
+}
+
+// Find the pending emails which are due to be sent at the time, in the oldest order
+func (dao *Dao) FindDueEmailTasks(now time.Time, limit int) []entity.EmailTask {
+	tasks := []entity.EmailTask{}
+	dao.DB().Where("status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", entity.EmailTaskStatusPending, now).
+		Order("id ASC").Limit(limit).Find(&tasks)
+	return tasks
+}
+
+func (dao *Dao) FindEmailSuppressionByID(id uint) entity.EmailSuppression {
+	var suppression entity.EmailSuppression
+	dao.DB().Where("id = ?", id).First(&suppression)
+	return suppression
+}
+
+func (dao *Dao) FindEmailSuppression(email string) entity.EmailSuppression {
+	var suppression entity.EmailSuppression
+	dao.DB().Where("email = ?", strings.ToLower(strings.TrimSpace(email))).First(&suppression)
+	return suppression
+}
+
+// Check if the emails should not be sent to the address (bounced or complained)
+func (dao *Dao) IsEmailSuppressed(email string) bool {
+	return !dao.FindEmailSuppression(email).IsEmpty()
+}
+
+// Find the suppressed email addresses in the latest order, filtered by the address if not empty
+func (dao *Dao) FindEmailSuppressions(search string, offset int, limit int) ([]entity.EmailSuppression, int64) {
+	query := dao.DB().Model(&entity.EmailSuppression{})
+	if search != "" {
+		query = query.Where("email LIKE ?", "%"+strings.ToLower(search)+"%")
+	}
+
+	var count int64
+	query.Count(&count)
+
+	suppressions := []entity.EmailSuppression{}
+	query.Order("id DESC").Offset(offset).Limit(limit).Find(&suppressions)
+
+	return suppressions, count
+}
+
+// Iterate the contents of all the comments in batches, including the soft deleted comments (which could be restored)
+// and the revisions (the previous versions of edited comments)
+func (dao *Dao) EachCommentContent(fn func(content string)) error {
+	comments := []entity.Comment{}
+	err := dao.DB().Unscoped().Model(&entity.Comment{}).Select("id", "content").
+		FindInBatches(&comments, 500, func(_ *gorm.DB, _ int) error {
+			for _, c := range comments {
+				fn(c.Content)
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	revisions := []entity.CommentRevision{}
+	return dao.DB().Model(&entity.CommentRevision{}).Select("id", "content").
+		FindInBatches(&revisions, 500, func(_ *gorm.DB, _ int) error {
+			for _, r := range revisions {
+				fn(r.Content)
+			}
+			return nil
+		}).Error
+}
+
+// Get the number and the total size (in bytes) of files uploaded since the time,
+// by the user if the user ID is not 0, otherwise by the guest of IP
+func (dao *Dao) GetUploadUsage(userID uint, ip string, since time.Time) (int64, int64) {
+	query := dao.DB().Model(&entity.Upload{}).Where("created_at >= ?", since)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	} else {
+		query = query.Where("user_id = ? AND ip = ?", 0, ip)
+	}
+
+	var usage struct {
+		Count int64
+		Size  int64
+	}
+	query.Select("COUNT(*) AS count, COALESCE(SUM(file_size), 0) AS size").Scan(&usage)
+
+	return usage.Count, usage.Size
+}
+
+// The filter of audit logs, the empty fields are not filtered
+type AuditLogFilter struct {
+	ActorType  string
+	ActorID    uint
+	Action     string // The prefix of action (e.g. "comment." for all the comment actions)
+	TargetType string
+	TargetID   uint
+	SiteName   string
+	From       *time.Time
+	To         *time.Time
+}
+
+// The query of the audit logs by the filter
+func (dao *Dao) AuditLogsQuery(filter AuditLogFilter) *gorm.DB {
+	q := dao.DB().Model(&entity.AuditLog{})
+	if filter.ActorType != "" {
+		q = q.Where("actor_type = ?", filter.ActorType)
+	}
+	if filter.ActorID != 0 {
+		q = q.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		q = q.Where("action LIKE ?", filter.Action+"%")
+	}
+	if filter.TargetType != "" {
+		q = q.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != 0 {
+		q = q.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.SiteName != "" {
+		q = q.Where("site_name = ?", filter.SiteName)
+	}
+	if filter.From != nil {
+		q = q.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("created_at <= ?", *filter.To)
+	}
+	return q
+}
+
+// Find the audit logs by the filter in the latest order
+func (dao *Dao) FindAuditLogs(filter AuditLogFilter, offset int, limit int) ([]entity.AuditLog, int64) {
+	var count int64
+	dao.AuditLogsQuery(filter).Count(&count)
+
+	logs := []entity.AuditLog{}
+	dao.AuditLogsQuery(filter).Order("id DESC").Offset(offset).Limit(limit).Find(&logs)
+
+	return logs, count
+}
+
+func (dao *Dao) FindBanByID(id uint) entity.Ban {
+	var ban entity.Ban
+	dao.DB().Where("id = ?", id).First(&ban)
+	return ban
+}
+
+type BansFilter struct {
+	SiteNames []string // The bans of the sites (including the global bans if empty string is contained), all if nil
+	Type      string
+	Value     string // The substring of the value
+	Active    bool   // Exclude the expired bans
+}
+
+// The query of the bans by the filter
+func (dao *Dao) BansQuery(filter BansFilter) *gorm.DB {
+	q := dao.DB().Model(&entity.Ban{})
+	if filter.SiteNames != nil {
+		q = q.Where("site_name IN ?", filter.SiteNames)
+	}
+	if filter.Type != "" {
+		q = q.Where("type = ?", filter.Type)
+	}
+	if filter.Value != "" {
+		q = q.Where("value LIKE ?", "%"+filter.Value+"%")
+	}
+	if filter.Active {
+		q = q.Where("expires_at IS NULL OR expires_at > ?", time.Now())
+	}
+	return q
+}
+
+// Find the active bans which apply to the site, including the global bans
+func (dao *Dao) FindActiveBans(siteName string) []entity.Ban {
+	bans := []entity.Ban{}
+	dao.BansQuery(BansFilter{SiteNames: []string{"", siteName}, Active: true}).Order("id ASC").Find(&bans)
+	return bans
+}
@@ -2,9 +2,11 @@ package dao_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/test"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -95,6 +97,97 @@ func TestFindCommentChildren(t *testing.T) {
 	})
 }
 
+func TestFindRecentCommentsByUser(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	comments := app.Dao().FindRecentCommentsByUser(1002, 10)
+	assert.Equal(t, []uint{1007, 1005}, lo.Map(comments, func(c entity.Comment, _ int) uint { return c.ID }), "should be newest first")
+
+	comments = app.Dao().FindRecentCommentsByUser(1002, 10, 1007)
+	assert.Equal(t, []uint{1005}, lo.Map(comments, func(c entity.Comment, _ int) uint { return c.ID }), "should exclude the given comments")
+
+	assert.Len(t, app.Dao().FindRecentCommentsByUser(1001, 3), 3, "should be limited")
+	assert.Empty(t, app.Dao().FindRecentCommentsByUser(1001, 0))
+	assert.Empty(t, app.Dao().FindRecentCommentsByUser(0, 10))
+}
+
+func TestFindRecentComments(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	since := time.Date(2022, 4, 29, 16, 50, 0, 0, time.UTC)
+	ids := func(comments []entity.Comment) []uint {
+		return lo.Map(comments, func(c entity.Comment, _ int) uint { return c.ID })
+	}
+
+	assert.Equal(t, []uint{1003, 1004, 1002}, ids(app.Dao().FindRecentComments("Site A", since, 10)), "should be newest first")
+	assert.Equal(t, []uint{1003, 1002}, ids(app.Dao().FindRecentComments("Site A", since, 10, 1004)), "should exclude the given comments")
+	assert.Equal(t, []uint{1003}, ids(app.Dao().FindRecentComments("Site A", since, 1)), "should be limited")
+	assert.Empty(t, app.Dao().FindRecentComments("Site A", since, 0))
+	assert.Equal(t, []uint{1007}, ids(app.Dao().FindRecentComments("Site B", since, 10)), "should be filtered by site")
+}
+
+func TestFindRecentCommentsByIP(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	since := time.Date(2022, 4, 29, 16, 50, 0, 0, time.UTC)
+	ids := func(comments []entity.Comment) []uint {
+		return lo.Map(comments, func(c entity.Comment, _ int) uint { return c.ID })
+	}
+
+	assert.Equal(t, []uint{1003, 1004, 1002}, ids(app.Dao().FindRecentCommentsByIP("10.90.2.101", since, 10)), "should be newest first")
+	assert.Equal(t, []uint{1003}, ids(app.Dao().FindRecentCommentsByIP("10.90.2.101", since, 1)), "should be limited")
+	assert.Equal(t, []uint{1007}, ids(app.Dao().FindRecentCommentsByIP("10.90.2.102", since, 10)), "should be filtered by ip")
+	assert.Empty(t, app.Dao().FindRecentCommentsByIP("", since, 10))
+}
+
+func TestGetIPApprovedCommentNum(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	assert.Equal(t, 5, app.Dao().GetIPApprovedCommentNum("10.90.2.101"))
+	assert.Equal(t, 1, app.Dao().GetIPApprovedCommentNum("10.90.2.102"), "should not count the pending comment")
+	assert.Equal(t, 0, app.Dao().GetIPApprovedCommentNum("10.0.0.1"))
+}
+
+func TestGetUserApprovedCommentNum(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	assert.Equal(t, 1, app.Dao().GetUserApprovedCommentNum(1002), "should not count the pending comment")
+	assert.Equal(t, 0, app.Dao().GetUserApprovedCommentNum(1002, 1005))
+	assert.Equal(t, 0, app.Dao().GetUserApprovedCommentNum(9999))
+}
+
+func TestFindSpamSamples(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	assert.Empty(t, app.Dao().FindSpamSamples("", 10))
+
+	comment1 := app.Dao().FindComment(1005)
+	comment2 := app.Dao().FindComment(1007)
+
+	_, err := app.Dao().SaveSpamSample(&comment1, true)
+	assert.NoError(t, err)
+	_, err = app.Dao().SaveSpamSample(&comment2, true)
+	assert.NoError(t, err)
+	sample, err := app.Dao().SaveSpamSample(&comment1, false)
+	assert.NoError(t, err)
+	assert.False(t, sample.IsSpam)
+
+	samples := app.Dao().FindSpamSamples("", 10)
+	assert.Len(t, samples, 2, "should replace the previous decision of the same comment")
+	assert.Equal(t, uint(1005), samples[0].CommentID, "should be newest first")
+	assert.Equal(t, comment1.Content, samples[0].Content)
+
+	assert.Len(t, app.Dao().FindSpamSamples("", 1), 1, "should be limited")
+	assert.Len(t, app.Dao().FindSpamSamples(comment1.SiteName, 10), 1, "should filter by site")
+	assert.Empty(t, app.Dao().FindSpamSamples("NoSite", 10))
+}
+
 func TestFindUser(t *testing.T) {
 	app, _ := test.NewTestApp()
 	defer app.Cleanup()
@@ -137,6 +230,26 @@ func TestFindUserByID(t *testing.T) {
 	})
 }
 
+func TestFindEmailByHash(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().NewUser("qqUser", "10001@QQ.com", "")
+	assert.NoError(t, err)
+	md5Hash, sha256Hash := entity.GetEmailHashes(user.Email)
+
+	assert.Equal(t, "10001@QQ.com", app.Dao().FindEmailByHash(md5Hash, "qq.com"))
+	assert.Equal(t, "10001@QQ.com", app.Dao().FindEmailByHash(sha256Hash, "qq.com"))
+	assert.Equal(t, "", app.Dao().FindEmailByHash(md5Hash, "example.com"), "should only find the email of the domain")
+
+	// The hashes are updated with the email
+	user.Email = "10002@qq.com"
+	assert.NoError(t, app.Dao().UpdateUser(&user))
+	assert.Equal(t, "", app.Dao().FindEmailByHash(md5Hash, "qq.com"))
+	newHash, _ := entity.GetEmailHashes("10002@qq.com")
+	assert.Equal(t, "10002@qq.com", app.Dao().FindEmailByHash(newHash, "qq.com"))
+}
+
 func TestFindPage(t *testing.T) {
 	app, _ := test.NewTestApp()
 	defer app.Cleanup()
@@ -1,8 +1,13 @@
 package dao
 
 import (
+	"strings"
+	"time"
+
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/samber/lo"
 )
 
 func (dao *Dao) NewSite(name string, urls string) entity.Site {
@@ -93,6 +98,7 @@ func (dao *Dao) CreatePage(page *entity.Page) error {
 }
 
 func (dao *Dao) CreateComment(comment *entity.Comment) error {
+	comment.HotScore = comment.GetHotScore()
 	err := dao.DB().Create(&comment).Error
 	if err != nil {
 		return err
@@ -103,6 +109,10 @@ func (dao *Dao) CreateComment(comment *entity.Comment) error {
 		cache.CommentCacheSave(comment)
 	})
 
+	dao.indexAction(func(indexer CommentIndexer) {
+		indexer.IndexComment(*comment)
+	})
+
 	return nil
 }
 
@@ -140,6 +150,38 @@ func (dao *Dao) NewVote(targetID uint, voteType entity.VoteType, userID uint, ua
 	return vote, err
 }
 
+func (dao *Dao) NewReaction(commentID uint, emoji string, userID uint, ua string, ip string) (entity.Reaction, error) {
+	reaction := entity.Reaction{
+		CommentID: commentID,
+		Emoji:     emoji,
+		UserID:    userID,
+		UA:        ua,
+		IP:        ip,
+	}
+
+	err := dao.DB().Create(&reaction).Error
+	if err != nil {
+		log.Error("Create Reaction error: ", err)
+	}
+
+	return reaction, err
+}
+
+func (dao *Dao) NewCommentRevision(commentID uint, content string, editorID uint) (entity.CommentRevision, error) {
+	revision := entity.CommentRevision{
+		CommentID: commentID,
+		Content:   content,
+		EditorID:  editorID,
+	}
+
+	err := dao.DB().Create(&revision).Error
+	if err != nil {
+		log.Error("Create CommentRevision error: ", err)
+	}
+
+	return revision, err
+}
+
 func (dao *Dao) CreateAuthIdentity(identity *entity.AuthIdentity) error {
 	err := dao.DB().Create(&identity).Error
 	if err != nil {
@@ -153,3 +195,190 @@ func (dao *Dao) CreateAuthIdentity(identity *entity.AuthIdentity) error {
 
 	return nil
 }
+
+// Save the spam sample of the comment, the previous decision of the same comment will be replaced
+func (dao *Dao) SaveSpamSample(comment *entity.Comment, isSpam bool) (entity.SpamSample, error) {
+	var sample entity.SpamSample
+	dao.DB().Where("comment_id = ?", comment.ID).First(&sample)
+
+	sample.CommentID = comment.ID
+	sample.SiteName = comment.SiteName
+	sample.Content = comment.Content
+	sample.IsSpam = isSpam
+
+	err := dao.DB().Save(&sample).Error
+	if err != nil {
+		log.Error("Save SpamSample error: ", err)
+	}
+
+	return sample, err
+}
+
+// Save the notify template of the channel, event and locale, the previous one will be replaced
+func (dao *Dao) SaveNotifyTemplate(channel string, event string, locale string, content string) (entity.NotifyTemplate, error) {
+	var tpl entity.NotifyTemplate
+	dao.DB().Where("channel = ? AND event = ? AND locale = ?", channel, event, locale).First(&tpl)
+
+	tpl.Channel = channel
+	tpl.Event = event
+	tpl.Locale = locale
+	tpl.Content = content
+
+	err := dao.DB().Save(&tpl).Error
+	if err != nil {
+		log.Error("Save NotifyTemplate error: ", err)
+	}
+
+	return tpl, err
+}
+
+// Save the push subscription of the user, the subscription of the same endpoint will be replaced
+func (dao *Dao) SavePushSubscription(userID uint, endpoint string, p256dh string, auth string, ua string) (entity.PushSubscription, error) {
+	sub := dao.FindPushSubscriptionByEndpoint(endpoint)
+
+	sub.UserID = userID
+	sub.Endpoint = endpoint
+	sub.P256DH = p256dh
+	sub.Auth = auth
+	sub.UA = ua
+
+	err := dao.DB().Save(&sub).Error
+	if err != nil {
+		log.Error("Save PushSubscription error: ", err)
+	}
+
+	return sub, err
+}
+
+func (dao *Dao) NewCommentReport(comment *entity.Comment, reason string, detail string, userID uint, ua string, ip string) (entity.CommentReport, error) {
+	report := entity.CommentReport{
+		CommentID: comment.ID,
+		SiteName:  comment.SiteName,
+		Reason:    reason,
+		Detail:    detail,
+		UserID:    userID,
+		UA:        ua,
+		IP:        ip,
+	}
+
+	err := dao.DB().Create(&report).Error
+	if err != nil {
+		log.Error("Create CommentReport error: ", err)
+	}
+
+	return report, err
+}
+
+// Create a new API key, the plain key is returned and can not be found again
+func (dao *Dao) NewAPIKey(name string, scopes []string, rateLimit int, userID uint) (entity.APIKey, string, error) {
+	plain := "atk_" + utils.RandomString(40)
+	key := entity.APIKey{
+		Name:      name,
+		KeyHash:   entity.HashToken(plain),
+		KeyPrefix: plain[:10],
+		Scopes:    strings.Join(lo.Uniq(scopes), ","),
+		RateLimit: rateLimit,
+		UserID:    userID,
+	}
+
+	err := dao.DB().Create(&key).Error
+	if err != nil {
+		log.Error("Create APIKey error: ", err)
+		return entity.APIKey{}, "", err
+	}
+
+	return key, plain, nil
+}
+
+// Create a new refresh token of the login session family, the plain token is returned
+//
+// The expired tokens of the user will be cleaned up.
+func (dao *Dao) NewRefreshToken(userID uint, familyID string, totpVerified bool, ttl time.Duration) (entity.RefreshToken, string, error) {
+	dao.DB().Unscoped().Where("user_id = ? AND expires_at < ?", userID, time.Now()).Delete(&entity.RefreshToken{})
+
+	plain := utils.RandomString(48)
+	token := entity.RefreshToken{
+		UserID:       userID,
+		FamilyID:     familyID,
+		TokenHash:    entity.HashToken(plain),
+		TOTPVerified: totpVerified,
+		ExpiresAt:    time.Now().Add(ttl),
+	}
+
+	err := dao.DB().Create(&token).Error
+	if err != nil {
+		log.Error("Create RefreshToken error: ", err)
+		return entity.RefreshToken{}, "", err
+	}
+
+	return token, plain, nil
+}
+
+// Create the delivery log of webhook event before it is sent
+func (dao *Dao) NewWebhookDelivery(event string, url string, payload string) (entity.WebhookDelivery, error) {
+	delivery := entity.WebhookDelivery{
+		Event:   event,
+		URL:     url,
+		Payload: payload,
+	}
+
+	err := dao.DB().Create(&delivery).Error
+	if err != nil {
+		log.Error("Create WebhookDelivery error: ", err)
+		return entity.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+// Persist the outgoing email before it is added to the sending queue
+func (dao *Dao) NewEmailTask(task entity.EmailTask) (entity.EmailTask, error) {
+	err := dao.DB().Create(&task).Error
+	if err != nil {
+		log.Error("Create EmailTask error: ", err)
+		return entity.EmailTask{}, err
+	}
+
+	return task, nil
+}
+
+// Suppress the email address, the reason and the detail are updated if it is already suppressed
+func (dao *Dao) SaveEmailSuppression(email string, reason string, provider string, detail string) (entity.EmailSuppression, error) {
+	suppression := dao.FindEmailSuppression(email)
+	suppression.Email = strings.ToLower(strings.TrimSpace(email))
+	suppression.Reason = reason
+	suppression.Provider = provider
+	suppression.Detail = detail
+
+	err := dao.DB().Save(&suppression).Error
+	if err != nil {
+		log.Error("Save EmailSuppression error: ", err)
+		return entity.EmailSuppression{}, err
+	}
+
+	return suppression, nil
+}
+
+func (dao *Dao) NewAuditLog(auditLog *entity.AuditLog) error {
+	err := dao.DB().Create(auditLog).Error
+	if err != nil {
+		log.Error("Create AuditLog error: ", err)
+	}
+	return err
+}
+
+func (dao *Dao) NewBan(ban *entity.Ban) error {
+	err := dao.DB().Create(ban).Error
+	if err != nil {
+		log.Error("Create Ban error: ", err)
+	}
+	return err
+}
+
+func (dao *Dao) NewUpload(upload *entity.Upload) error {
+	err := dao.DB().Create(upload).Error
+	if err != nil {
+		log.Error("Create Upload error: ", err)
+	}
+	return err
+}
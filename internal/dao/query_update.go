@@ -6,6 +6,7 @@ import (
 
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/samber/lo"
 )
 
 // 更新评论
@@ -18,6 +19,11 @@ func (dao *Dao) UpdateComment(comment *entity.Comment) error {
 	dao.CacheAction(func(cache *DaoCache) {
 		cache.CommentCacheSave(comment)
 	})
+	if err == nil {
+		dao.indexAction(func(indexer CommentIndexer) {
+			indexer.IndexComment(*comment)
+		})
+	}
 	return err
 }
 
@@ -28,6 +34,7 @@ func (dao *Dao) UpdateSite(site *entity.Site) error {
 	}
 	dao.CacheAction(func(cache *DaoCache) {
 		cache.SiteCacheSave(site)
+		cache.SiteCommentListCacheDel(site.Name)
 	})
 	return err
 }
@@ -40,6 +47,7 @@ func (dao *Dao) UpdateUser(user *entity.User) error {
 	dao.CacheAction(func(cache *DaoCache) {
 		cache.UserCacheSave(user)
 	})
+	dao.CommentListCacheDelByUser(user.ID)
 	return err
 }
 
@@ -80,3 +88,182 @@ func (dao *Dao) UpdateAuthIdentity(authIdentity *entity.AuthIdentity) error {
 	// })
 	return err
 }
+
+// Update the last used time of API key (at most once a minute to reduce the writes)
+func (dao *Dao) TouchAPIKey(key *entity.APIKey) {
+	now := time.Now()
+	if key.LastUsedAt != nil && now.Sub(*key.LastUsedAt) < time.Minute {
+		return
+	}
+	key.LastUsedAt = &now
+	if err := dao.DB().Model(key).UpdateColumn("last_used_at", now).Error; err != nil {
+		log.Error("Update APIKey error: ", err)
+	}
+}
+
+// Revoke the refresh token atomically, false is returned if it has been revoked already (e.g. by a concurrent request)
+func (dao *Dao) RevokeRefreshToken(token *entity.RefreshToken) (bool, error) {
+	now := time.Now()
+	result := dao.DB().Model(&entity.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", token.ID).
+		UpdateColumn("revoked_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected != 1 {
+		return false, nil
+	}
+
+	token.RevokedAt = &now
+	return true, nil
+}
+
+// Revoke all the refresh tokens of the login session family
+func (dao *Dao) RevokeRefreshTokenFamily(familyID string) error {
+	return dao.DB().Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		UpdateColumn("revoked_at", time.Now()).Error
+}
+
+// Invalidate all the login sessions of the user (or all users if userID is 0)
+//
+// Both the refresh tokens and the issued access tokens become invalid.
+func (dao *Dao) RevokeUserSessions(userID uint) error {
+	now := time.Now()
+
+	tokens := dao.DB().Model(&entity.RefreshToken{}).Where("revoked_at IS NULL")
+	if userID != 0 {
+		tokens = tokens.Where("user_id = ?", userID)
+	}
+	if err := tokens.UpdateColumn("revoked_at", now).Error; err != nil {
+		return err
+	}
+
+	users := dao.DB().Model(&entity.User{})
+	if userID != 0 {
+		users = users.Where("id = ?", userID)
+	} else {
+		users = users.Where("1 = 1") // allow the global update
+	}
+	if err := users.UpdateColumn("token_valid_from", now).Error; err != nil {
+		return err
+	}
+
+	// The users are updated in batch, so clear the cache of them
+	dao.CacheAction(func(cache *DaoCache) {
+		var items []entity.User
+		if userID != 0 {
+			dao.DB().Where("id = ?", userID).Find(&items)
+		} else {
+			dao.DB().Find(&items)
+		}
+		for _, item := range items {
+			cache.UserCacheDel(&item)
+		}
+	})
+
+	return nil
+}
+
+// Restore the soft deleted comment
+func (dao *Dao) RestoreComment(comment *entity.Comment) error {
+	if err := dao.DB().Unscoped().Model(comment).UpdateColumn("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	comment.DeletedAt.Valid = false
+
+	// 更新缓存
+	dao.CacheAction(func(cache *DaoCache) {
+		cache.CommentCacheSave(comment)
+	})
+
+	return nil
+}
+
+func (dao *Dao) UpdateWebhookDelivery(delivery *entity.WebhookDelivery) error {
+	err := dao.DB().Save(delivery).Error
+	if err != nil {
+		log.Error("Update WebhookDelivery error: ", err)
+	}
+	return err
+}
+
+func (dao *Dao) UpdateEmailTask(task *entity.EmailTask) error {
+	err := dao.DB().Save(task).Error
+	if err != nil {
+		log.Error("Update EmailTask error: ", err)
+	}
+	return err
+}
+
+// Set the status of the outgoing email if it is in one of the `from` status,
+// false is returned if the status has been changed by others (e.g. claimed by another sender)
+func (dao *Dao) SetEmailTaskStatus(id uint, status string, from ...string) bool {
+	query := dao.DB().Model(&entity.EmailTask{}).Where("id = ?", id)
+	if len(from) > 0 {
+		query = query.Where("status IN ?", from)
+	}
+	// the updated time is kept to find the emails which are stuck in sending, see `ResetSendingEmailTasks`
+	result := query.UpdateColumns(map[string]any{"status": status, "updated_at": time.Now()})
+	if result.Error != nil {
+		log.Error("Update EmailTask error: ", result.Error)
+		return false
+	}
+	return result.RowsAffected > 0
+}
+
+// Reset the emails left in the sending queue (interrupted by the server restart) to be sent again,
+// only the emails which are claimed before the time are reset if it is not zero
+// (e.g. in cluster mode, the emails in the queue of other instances are kept)
+func (dao *Dao) ResetSendingEmailTasks(before time.Time) error {
+	query := dao.DB().Model(&entity.EmailTask{}).Where("status = ?", entity.EmailTaskStatusSending)
+	if !before.IsZero() {
+		query = query.Where("updated_at < ?", before)
+	}
+	return query.UpdateColumn("status", entity.EmailTaskStatusPending).Error
+}
+
+// Clear the IPs which are stored before the time (the comments, votes, reactions, reports
+// and the email verifications), and the last IPs of users who are not active since the time.
+// Returns the number of comments whose IP is cleared.
+func (dao *Dao) PurgeIPs(before time.Time) (int64, error) {
+	var commentIDs, userIDs []uint
+	dao.DB().Unscoped().Model(&entity.Comment{}).Where("ip <> '' AND created_at < ?", before).Pluck("id", &commentIDs)
+	dao.DB().Unscoped().Model(&entity.User{}).Where("last_ip <> '' AND updated_at < ?", before).Pluck("id", &userIDs)
+
+	for _, ids := range lo.Chunk(commentIDs, 500) {
+		if err := dao.DB().Unscoped().Model(&entity.Comment{}).Where("id IN ?", ids).UpdateColumn("ip", "").Error; err != nil {
+			return 0, err
+		}
+	}
+	for _, ids := range lo.Chunk(userIDs, 500) {
+		if err := dao.DB().Unscoped().Model(&entity.User{}).Where("id IN ?", ids).UpdateColumn("last_ip", "").Error; err != nil {
+			return 0, err
+		}
+	}
+	for _, m := range []any{&entity.Vote{}, &entity.Reaction{}, &entity.CommentReport{}, &entity.UserEmailVerify{}} {
+		if err := dao.DB().Unscoped().Model(m).Where("ip <> '' AND created_at < ?", before).UpdateColumn("ip", "").Error; err != nil {
+			return 0, err
+		}
+	}
+
+	// Refresh the cache
+	dao.CacheAction(func(cache *DaoCache) {
+		for _, ids := range lo.Chunk(commentIDs, 500) {
+			var comments []entity.Comment
+			dao.DB().Where("id IN ?", ids).Find(&comments)
+			for i := range comments {
+				cache.CommentCacheSave(&comments[i])
+			}
+		}
+		for _, ids := range lo.Chunk(userIDs, 500) {
+			var users []entity.User
+			dao.DB().Where("id IN ?", ids).Find(&users)
+			for i := range users {
+				cache.UserCacheSave(&users[i])
+			}
+		}
+	})
+
+	return int64(len(commentIDs)), nil
+}
@@ -0,0 +1,58 @@
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshToken(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	token, plain, err := app.Dao().NewRefreshToken(1000, "family_a", true, time.Hour)
+	assert.NoError(t, err)
+	assert.NotEqual(t, plain, token.TokenHash, "should not store the plain token")
+
+	found := app.Dao().FindRefreshToken(plain)
+	assert.Equal(t, token.ID, found.ID)
+	assert.True(t, found.TOTPVerified)
+	assert.True(t, app.Dao().FindRefreshToken("invalid").IsEmpty())
+	assert.True(t, app.Dao().IsRefreshTokenFamilyActive("family_a"))
+
+	// revoke the family
+	_, _, err = app.Dao().NewRefreshToken(1000, "family_a", true, time.Hour)
+	assert.NoError(t, err)
+	stale := found
+	revoked, err := app.Dao().RevokeRefreshToken(&found)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	assert.True(t, found.IsRevoked())
+	revoked, err = app.Dao().RevokeRefreshToken(&stale)
+	assert.NoError(t, err)
+	assert.False(t, revoked, "should not revoke the token revoked already (e.g. by a concurrent request)")
+	assert.False(t, stale.IsRevoked())
+	assert.True(t, app.Dao().IsRefreshTokenFamilyActive("family_a"), "should be active with the rotated token")
+	assert.NoError(t, app.Dao().RevokeRefreshTokenFamily("family_a"))
+	assert.False(t, app.Dao().IsRefreshTokenFamilyActive("family_a"))
+
+	// expired
+	_, plain, _ = app.Dao().NewRefreshToken(1000, "family_b", false, -time.Minute)
+	assert.True(t, app.Dao().FindRefreshToken(plain).IsExpired())
+	assert.False(t, app.Dao().IsRefreshTokenFamilyActive("family_b"))
+
+	// revoke the sessions of user
+	_, _, _ = app.Dao().NewRefreshToken(1000, "family_c", false, time.Hour)
+	_, _, _ = app.Dao().NewRefreshToken(1001, "family_d", false, time.Hour)
+	assert.NoError(t, app.Dao().RevokeUserSessions(1000))
+	assert.False(t, app.Dao().IsRefreshTokenFamilyActive("family_c"))
+	assert.True(t, app.Dao().IsRefreshTokenFamilyActive("family_d"))
+	assert.True(t, app.Dao().FindUserByID(1000).TokenValidFrom.Valid)
+	assert.False(t, app.Dao().FindUserByID(1001).TokenValidFrom.Valid)
+
+	assert.NoError(t, app.Dao().RevokeUserSessions(0))
+	assert.False(t, app.Dao().IsRefreshTokenFamilyActive("family_d"))
+	assert.True(t, app.Dao().FindUserByID(1001).TokenValidFrom.Valid)
+}
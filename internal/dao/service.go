@@ -159,6 +159,7 @@ func pageExtractFromHTML(html []byte) (data pageExtractData) {
 func (dao *Dao) NotifySetInitial(n *entity.Notify) error {
 	n.IsRead = false
 	n.IsEmailed = false
+	n.IsDigestPending = false
 	return dao.DB().Save(n).Error
 }
 
@@ -176,6 +177,38 @@ func (dao *Dao) NotifySetEmailed(n *entity.Notify) error {
 	return dao.DB().Save(n).Error
 }
 
+// Wait for the notify to be sent in the digest email
+func (dao *Dao) NotifySetDigestPending(n *entity.Notify) error {
+	n.IsDigestPending = true
+	return dao.DB().Save(n).Error
+}
+
+// The notifies are sent in the digest email or discarded
+func (dao *Dao) NotifiesClearDigestPending(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return dao.DB().Model(&entity.Notify{}).Where("id IN ?", ids).UpdateColumn("is_digest_pending", false).Error
+}
+
+// Get the event type of the notify by the receiver and the comments (see `entity.NotifyEvent*`)
+func (dao *Dao) GetNotifyEvent(n *entity.Notify) string {
+	comment := dao.FetchCommentForNotify(n)
+	parent := dao.FindNotifyParentComment(n)
+	user := dao.FetchUserForNotify(n)
+
+	switch {
+	case user.IsAdmin && comment.IsPending:
+		return entity.NotifyEventPending
+	case !parent.IsEmpty() && parent.UserID == user.ID:
+		return entity.NotifyEventReply
+	case user.IsAdmin:
+		return entity.NotifyEventComment
+	default:
+		return entity.NotifyEventMention
+	}
+}
+
 func (dao *Dao) GetReadLinkByNotify(n *entity.Notify) string {
 	c := dao.FetchCommentForNotify(n)
 
@@ -195,6 +228,7 @@ func (dao *Dao) VoteSync() {
 		voteDown := dao.GetVoteNum(c.ID, string(entity.VoteTypeCommentDown))
 		c.VoteUp = int(voteUp)
 		c.VoteDown = int(voteDown)
+		c.HotScore = c.GetHotScore()
 		dao.UpdateComment(&c)
 	}
 
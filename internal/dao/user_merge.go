@@ -0,0 +1,250 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"gorm.io/gorm"
+)
+
+// The changes of merging the source user into the target user
+type UserMergeResult struct {
+	Comments       int64 // The comments reassigned
+	Reactions      int64 // The reactions reassigned
+	Votes          int64 // The votes reassigned
+	Notifies       int64 // The notifies reassigned
+	Reports        int64 // The comment reports reassigned
+	AuthIdentities int64 // The social login identities reassigned
+	Duplicates     int64 // The duplicated reactions, votes and reports of the source user which are deleted
+
+	// The target user after merged (the notification preferences and the empty profile fields are merged)
+	Target entity.User
+}
+
+// The dry run is rolled back by this error after all the changes are made in the transaction
+var errUserMergeDryRun = errors.New("user merge dry run")
+
+// Merge the source user into the target user, the source user is deleted after the data is reassigned.
+//
+// The reactions, votes and reports of the source user which duplicate the target's are deleted,
+// and the counters of the affected comments and pages are recounted. The notifications are turned off
+// if either user turned them off. If dryRun is true, the changes are counted and rolled back.
+func (dao *Dao) MergeUsers(source entity.User, target entity.User, dryRun bool) (UserMergeResult, error) {
+	if source.IsEmpty() || target.IsEmpty() || source.ID == target.ID {
+		return UserMergeResult{}, fmt.Errorf("invalid users to merge")
+	}
+
+	result := UserMergeResult{}
+	commentIDs := []uint{} // the comments which need to refresh the cache
+	pageIDs := []uint{}
+
+	err := dao.DB().Transaction(func(tx *gorm.DB) error {
+		tx.Model(&entity.Comment{}).Where("user_id = ?", source.ID).Pluck("id", &commentIDs)
+
+		// Reassign the data which is unique for each user, the duplicates are deleted
+		reactionComments, err := mergeUniqueUserData[entity.Reaction](tx, source.ID, target.ID, &result, &result.Reactions,
+			func(r entity.Reaction) string { return fmt.Sprint(r.CommentID, "|", r.Emoji) },
+			func(r entity.Reaction) uint { return r.CommentID })
+		if err != nil {
+			return err
+		}
+		voteTargets, err := mergeUniqueUserData[entity.Vote](tx, source.ID, target.ID, &result, &result.Votes,
+			func(v entity.Vote) string { return fmt.Sprint(voteTargetType(v.Type), "|", v.TargetID) },
+			func(v entity.Vote) uint { return v.TargetID })
+		if err != nil {
+			return err
+		}
+		if _, err := mergeUniqueUserData[entity.CommentReport](tx, source.ID, target.ID, &result, &result.Reports,
+			func(r entity.CommentReport) string { return fmt.Sprint(r.CommentID) },
+			func(r entity.CommentReport) uint { return r.CommentID }); err != nil {
+			return err
+		}
+
+		// Reassign the other data
+		for _, m := range []struct {
+			model any
+			count *int64
+		}{
+			{&entity.Comment{}, &result.Comments},
+			{&entity.Notify{}, &result.Notifies},
+			{&entity.AuthIdentity{}, &result.AuthIdentities},
+			{&entity.PushSubscription{}, nil},
+			{&entity.APIKey{}, nil},
+			{&entity.Ban{}, nil},
+		} {
+			res := tx.Model(m.model).Where("user_id = ?", source.ID).Update("user_id", target.ID)
+			if res.Error != nil {
+				return res.Error
+			}
+			if m.count != nil {
+				*m.count = res.RowsAffected
+			}
+		}
+
+		// Recount the reactions and votes of the comments and pages which the duplicates are deleted
+		for _, id := range reactionComments {
+			var count int64
+			tx.Model(&entity.Reaction{}).Where("comment_id = ?", id).Count(&count)
+			if err := tx.Model(&entity.Comment{}).Where("id = ?", id).Update("reaction_count", count).Error; err != nil {
+				return err
+			}
+		}
+		for key, id := range voteTargets {
+			targetType := strings.SplitN(key, "|", 2)[0]
+			if err := recountVotes(tx, targetType, id); err != nil {
+				return err
+			}
+			if targetType == "page" {
+				pageIDs = append(pageIDs, id)
+			} else {
+				commentIDs = append(commentIDs, id)
+			}
+		}
+		for _, id := range reactionComments {
+			commentIDs = append(commentIDs, id)
+		}
+
+		// Merge the notification preferences and the profile
+		target.ReceiveEmail = target.ReceiveEmail && source.ReceiveEmail
+		target.NotifyReply = target.NotifyReply && source.NotifyReply
+		target.NotifyMention = target.NotifyMention && source.NotifyMention
+		if target.EmailDigest == "" {
+			target.EmailDigest = source.EmailDigest
+		}
+		if strings.EqualFold(target.Email, source.Email) {
+			target.IsEmailVerified = target.IsEmailVerified || source.IsEmailVerified
+		}
+		for _, f := range []struct{ dst, src *string }{
+			{&target.Link, &source.Link},
+			{&target.Avatar, &source.Avatar},
+			{&target.BadgeName, &source.BadgeName},
+			{&target.BadgeColor, &source.BadgeColor},
+		} {
+			if *f.dst == "" {
+				*f.dst = *f.src
+			}
+		}
+		if err := tx.Save(&target).Error; err != nil {
+			return err
+		}
+
+		// The sessions of source user are revoked
+		if err := tx.Unscoped().Where("user_id = ?", source.ID).Delete(&entity.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&source).Error; err != nil {
+			return err
+		}
+
+		result.Target = target
+		if dryRun {
+			return errUserMergeDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errUserMergeDryRun) {
+		return result, nil
+	}
+	if err != nil {
+		return UserMergeResult{}, err
+	}
+
+	// Clear cache
+	dao.CacheAction(func(cache *DaoCache) {
+		cache.UserCacheDel(&source)
+		cache.UserCacheSave(&target)
+	})
+	for _, id := range commentIDs {
+		var comment entity.Comment
+		dao.DB().Where("id = ?", id).First(&comment)
+		if comment.IsEmpty() {
+			continue
+		}
+		dao.CacheAction(func(cache *DaoCache) {
+			cache.CommentCacheSave(&comment)
+		})
+		dao.indexAction(func(indexer CommentIndexer) {
+			indexer.IndexComment(comment)
+		})
+	}
+	for _, id := range pageIDs {
+		var page entity.Page
+		dao.DB().Where("id = ?", id).First(&page)
+		dao.CacheAction(func(cache *DaoCache) {
+			cache.PageCacheDel(&page)
+		})
+	}
+
+	return result, nil
+}
+
+// Reassign the data of source user to the target user, the data which has the same key as the target's is deleted.
+// The affected keys of the deleted data are returned (e.g. the comments to recount).
+func mergeUniqueUserData[T any](tx *gorm.DB, sourceID uint, targetID uint, result *UserMergeResult, count *int64,
+	key func(T) string, ref func(T) uint) (map[string]uint, error) {
+	targetItems := []T{}
+	tx.Where("user_id = ?", targetID).Find(&targetItems)
+	keys := map[string]bool{}
+	for _, item := range targetItems {
+		keys[key(item)] = true
+	}
+
+	sourceItems := []T{}
+	tx.Where("user_id = ?", sourceID).Find(&sourceItems)
+
+	affected := map[string]uint{}
+	for _, item := range sourceItems {
+		k := key(item)
+		if !keys[k] {
+			keys[k] = true // the source may also have the duplicates
+			if err := tx.Model(&item).Update("user_id", targetID).Error; err != nil {
+				return nil, err
+			}
+			*count++
+			continue
+		}
+
+		if err := tx.Unscoped().Delete(&item).Error; err != nil {
+			return nil, err
+		}
+		result.Duplicates++
+		affected[k] = ref(item)
+	}
+
+	return affected, nil
+}
+
+// Get the target type of vote ("comment" or "page")
+func voteTargetType(voteType entity.VoteType) string {
+	return strings.SplitN(string(voteType), "_", 2)[0]
+}
+
+// Recount the votes of the comment or page in the transaction
+func recountVotes(tx *gorm.DB, targetType string, targetID uint) error {
+	countVotes := func(voteType entity.VoteType) int {
+		var count int64
+		tx.Model(&entity.Vote{}).Where("target_id = ? AND type = ?", targetID, voteType).Count(&count)
+		return int(count)
+	}
+
+	switch targetType {
+	case "comment":
+		var comment entity.Comment
+		tx.Where("id = ?", targetID).First(&comment)
+		if comment.IsEmpty() {
+			return nil
+		}
+		comment.VoteUp = countVotes(entity.VoteTypeCommentUp)
+		comment.VoteDown = countVotes(entity.VoteTypeCommentDown)
+		comment.HotScore = comment.GetHotScore()
+		return tx.Model(&comment).Select("vote_up", "vote_down", "hot_score").Updates(&comment).Error
+	case "page":
+		return tx.Model(&entity.Page{}).Where("id = ?", targetID).Updates(map[string]any{
+			"vote_up":   countVotes(entity.VoteTypePageUp),
+			"vote_down": countVotes(entity.VoteTypePageDown),
+		}).Error
+	}
+	return nil
+}
@@ -32,7 +32,11 @@ func NewDB(conf config.DBConf) (*gorm.DB, error) {
 		dsn = getDsnByConf(conf)
 	}
 
-	switch conf.Type {
+	return openByType(conf.Type, dsn, gormConfig)
+}
+
+func openByType(dbType config.DBType, dsn string, gormConfig *gorm.Config) (*gorm.DB, error) {
+	switch dbType {
 	case config.TypeSQLite:
 		return OpenSQLite(dsn, gormConfig)
 	case config.TypeMySql:
@@ -43,7 +47,7 @@ func NewDB(conf config.DBConf) (*gorm.DB, error) {
 		return OpenSqlServer(dsn, gormConfig)
 	}
 
-	return nil, fmt.Errorf("unsupported database type %s", conf.Type)
+	return nil, fmt.Errorf("unsupported database type %s", dbType)
 }
 
 func NewTestDB() (*gorm.DB, error) {
@@ -56,6 +60,13 @@ func NewTestDB() (*gorm.DB, error) {
 }
 
 func CloseDB(db *gorm.DB) error {
+	// close the read replicas
+	if p, ok := db.Config.Plugins[replicaResolverName]; ok {
+		if err := p.(*replicaResolver).close(); err != nil {
+			return err
+		}
+	}
+
 	ddb, err := db.DB()
 	if err != nil {
 		return err
@@ -0,0 +1,364 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"gorm.io/gorm"
+)
+
+const replicaResolverName = "artalk:replica_resolver"
+
+// The defaults of `db.replicas` config
+const (
+	defaultReplicaCheckInterval = 10 // seconds
+	replicaCheckTimeout         = 5 * time.Second
+)
+
+// The reads of the table stick to the primary for a while after it is written,
+// which is the replication lag of replica plus this margin, so the written data can be read back.
+//
+// The written times are kept in the process, so the stickiness only works on a single node.
+// In cluster mode, the data written by an instance may be not read back by other instances within the replication lag.
+const replicaStickyMargin = time.Second
+
+// The setting key to route the reads to the primary
+const usePrimaryKey = "artalk:use_primary"
+
+// UsePrimary is the gorm scope to read from the primary, e.g. `db.Scopes(db.UsePrimary).Find(&comments)`
+func UsePrimary(tx *gorm.DB) *gorm.DB {
+	return tx.Set(usePrimaryKey, true)
+}
+
+// UseReplicas routes the reads to the read replicas and the writes to the primary,
+// the replicas which are unavailable or lag behind are skipped until they are recovered.
+//
+// It should be invoked after the database is migrated, since the migrator reads the schema.
+func UseReplicas(db *gorm.DB, conf config.DBConf) error {
+	if len(conf.Replicas.Dsns) == 0 {
+		return nil
+	}
+
+	resolver := &replicaResolver{
+		conf:    conf.Replicas,
+		dbType:  conf.Type,
+		written: map[string]time.Time{},
+		stop:    make(chan struct{}),
+	}
+	resolver.lagFunc = resolver.queryLag
+
+	for i, dsn := range conf.Replicas.Dsns {
+		replicaDB, err := openByType(conf.Type, dsn, &gorm.Config{
+			Logger:         db.Config.Logger,
+			NamingStrategy: db.Config.NamingStrategy,
+			PrepareStmt:    db.Config.PrepareStmt,
+		})
+		if err != nil {
+			resolver.close()
+			return fmt.Errorf("failed to open db replica #%d: %w", i+1, err)
+		}
+		resolver.replicas = append(resolver.replicas, &replica{name: fmt.Sprintf("#%d", i+1), db: replicaDB})
+	}
+
+	return db.Use(resolver)
+}
+
+type replica struct {
+	name    string
+	db      *gorm.DB
+	healthy bool
+	lag     time.Duration
+}
+
+type replicaResolver struct {
+	conf     config.DBReplicasConf
+	dbType   config.DBType
+	primary  gorm.ConnPool
+	replicas []*replica
+
+	// The func to get the replication lag of replica (replaceable in tests)
+	lagFunc func(ctx context.Context, db *gorm.DB) (time.Duration, error)
+
+	mu      sync.Mutex
+	next    int                  // The round-robin index of replicas
+	written map[string]time.Time // The last written time of tables ("" for the unknown tables), swept after the stickiness expired
+
+	stop chan struct{}
+}
+
+func (r *replicaResolver) Name() string {
+	return replicaResolverName
+}
+
+func (r *replicaResolver) Initialize(db *gorm.DB) error {
+	r.primary = db.ConnPool
+
+	// Reads
+	if err := db.Callback().Query().Before("gorm:query").Register("artalk:replica_read", r.routeRead); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("artalk:replica_read", r.routeRead); err != nil {
+		return err
+	}
+
+	// Writes (the written time is also marked after the write is done)
+	if err := db.Callback().Create().Before("gorm:create").Register("artalk:replica_write", r.routeWrite); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().After("gorm:create").Register("artalk:replica_written", r.markWritten); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("artalk:replica_write", r.routeWrite); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("artalk:replica_written", r.markWritten); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("artalk:replica_write", r.routeWrite); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("artalk:replica_written", r.markWritten); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().Before("gorm:raw").Register("artalk:replica_write", r.routeWrite); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().After("gorm:raw").Register("artalk:replica_written", r.markWritten); err != nil {
+		return err
+	}
+
+	r.check()
+	go r.runChecker()
+
+	return nil
+}
+
+func (r *replicaResolver) routeRead(db *gorm.DB) {
+	if isInTransaction(db) {
+		return
+	}
+
+	db.Statement.ConnPool = r.primary
+
+	if v, ok := db.Get(usePrimaryKey); ok && v == true {
+		return
+	}
+	if _, ok := db.Statement.Clauses["FOR"]; ok {
+		return // the locking reads (e.g. `SELECT ... FOR UPDATE`)
+	}
+
+	// The raw queries are not routed, since the tables in SQL are unknown
+	table := db.Statement.Table
+	if table == "" || db.Statement.SQL.Len() > 0 {
+		return
+	}
+
+	if rep := r.pick(table); rep != nil {
+		db.Statement.ConnPool = rep.db.ConnPool
+	}
+}
+
+func (r *replicaResolver) routeWrite(db *gorm.DB) {
+	r.markWritten(db)
+
+	if isInTransaction(db) {
+		return
+	}
+
+	db.Statement.ConnPool = r.primary
+}
+
+func (r *replicaResolver) markWritten(db *gorm.DB) {
+	r.mu.Lock()
+	r.written[db.Statement.Table] = time.Now()
+	r.mu.Unlock()
+}
+
+// Pick a healthy replica to read the table by round-robin, nil if the primary should be used
+func (r *replicaResolver) pick(table string) *replica {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lastWritten := r.written[table]
+	if t := r.written[""]; t.After(lastWritten) {
+		lastWritten = t
+	}
+
+	for i := 0; i < len(r.replicas); i++ {
+		rep := r.replicas[(r.next+i)%len(r.replicas)]
+		if !rep.healthy || time.Since(lastWritten) < rep.lag+replicaStickyMargin {
+			continue
+		}
+		r.next = (r.next + i + 1) % len(r.replicas)
+		return rep
+	}
+
+	return nil
+}
+
+func (r *replicaResolver) runChecker() {
+	interval := time.Duration(r.conf.CheckInterval) * time.Second
+	if interval <= 0 {
+		interval = defaultReplicaCheckInterval * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.check()
+			r.sweepWritten()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// Check the availability and the replication lag of replicas
+func (r *replicaResolver) check() {
+	for _, rep := range r.replicas {
+		lag, err := r.checkReplica(rep)
+		if err == nil && r.conf.MaxLag > 0 && lag > time.Duration(r.conf.MaxLag)*time.Second {
+			err = fmt.Errorf("the replication lag %s exceeds the max lag %ds", lag, r.conf.MaxLag)
+		}
+
+		r.mu.Lock()
+		wasHealthy := rep.healthy
+		rep.healthy = err == nil
+		rep.lag = lag
+		r.mu.Unlock()
+
+		if wasHealthy && err != nil {
+			log.Warn("[DB Replica] Replica ", rep.name, " is unavailable, the reads fall back to the primary: ", err)
+		} else if !wasHealthy && err == nil {
+			log.Info("[DB Replica] Replica ", rep.name, " is available")
+		}
+	}
+}
+
+// Remove the written times of tables which no longer stick the reads to the primary
+func (r *replicaResolver) sweepWritten() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The replicas lag behind more than `max_lag` are skipped, so the stickiness never lasts longer
+	ttl := time.Duration(r.conf.MaxLag) * time.Second
+	for _, rep := range r.replicas {
+		ttl = max(ttl, rep.lag)
+	}
+	ttl += replicaStickyMargin
+
+	for table, t := range r.written {
+		if time.Since(t) >= ttl {
+			delete(r.written, table)
+		}
+	}
+}
+
+func (r *replicaResolver) checkReplica(rep *replica) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), replicaCheckTimeout)
+	defer cancel()
+
+	sqlDB, err := rep.db.DB()
+	if err != nil {
+		return 0, err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return 0, err
+	}
+
+	return r.lagFunc(ctx, rep.db)
+}
+
+// Query the replication lag of replica, 0 if the database type is not supported
+func (r *replicaResolver) queryLag(ctx context.Context, db *gorm.DB) (time.Duration, error) {
+	switch r.dbType {
+	case config.TypeMySql:
+		return queryMySqlReplicaLag(ctx, db)
+	case config.TypePostgreSQL:
+		var seconds float64
+		err := db.WithContext(ctx).Raw(`SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+			ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`).Scan(&seconds).Error
+		return time.Duration(seconds * float64(time.Second)), err
+	}
+	return 0, nil
+}
+
+func queryMySqlReplicaLag(ctx context.Context, db *gorm.DB) (time.Duration, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	// `SHOW SLAVE STATUS` is deprecated since MySQL 8.0.22
+	rows, err := sqlDB.QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		rows, err = sqlDB.QueryContext(ctx, "SHOW SLAVE STATUS")
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if !rows.Next() {
+		return 0, rows.Err() // not a replica
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for i, col := range columns {
+		if col == "Seconds_Behind_Source" || col == "Seconds_Behind_Master" {
+			if !values[i].Valid {
+				return 0, fmt.Errorf("the replication is stopped")
+			}
+			var seconds int64
+			fmt.Sscan(values[i].String, &seconds)
+			return time.Duration(seconds) * time.Second, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func (r *replicaResolver) close() error {
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+
+	var errs []string
+	for _, rep := range r.replicas {
+		if err := CloseDB(rep.db); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close db replicas: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func isInTransaction(db *gorm.DB) bool {
+	_, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type replicaTestItem struct {
+	ID   uint
+	Name string
+}
+
+func TestReplicas(t *testing.T) {
+	dir := t.TempDir()
+	primaryFile := filepath.Join(dir, "primary.db")
+	replicaFile := filepath.Join(dir, "replica.db")
+
+	// Prepare the different data in the primary and the replica to tell where the reads go
+	for file, name := range map[string]string{primaryFile: "primary", replicaFile: "replica"} {
+		d, err := NewDB(config.DBConf{Type: config.TypeSQLite, Dsn: file})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, d.AutoMigrate(&replicaTestItem{}))
+		assert.NoError(t, d.Create(&replicaTestItem{Name: name}).Error)
+		CloseDB(d)
+	}
+
+	conf := config.DBConf{
+		Type: config.TypeSQLite,
+		Dsn:  primaryFile,
+		Replicas: config.DBReplicasConf{
+			Dsns:   []string{replicaFile},
+			MaxLag: 10,
+		},
+	}
+	d, err := NewDB(conf)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, UseReplicas(d, conf)) {
+		return
+	}
+	defer CloseDB(d)
+
+	resolver := d.Config.Plugins[replicaResolverName].(*replicaResolver)
+	lag := time.Duration(0)
+	resolver.lagFunc = func(ctx context.Context, db *gorm.DB) (time.Duration, error) {
+		return lag, nil
+	}
+
+	readFrom := func(tx *gorm.DB) string {
+		var item replicaTestItem
+		assert.NoError(t, tx.Order("id ASC").First(&item).Error)
+		return item.Name
+	}
+	forgetWrites := func() {
+		resolver.mu.Lock()
+		defer resolver.mu.Unlock()
+		for table := range resolver.written {
+			resolver.written[table] = time.Time{}
+		}
+	}
+
+	t.Run("Read from replica", func(t *testing.T) {
+		assert.Equal(t, "replica", readFrom(d))
+	})
+
+	t.Run("Write to primary", func(t *testing.T) {
+		assert.NoError(t, d.Create(&replicaTestItem{Name: "new"}).Error)
+		assert.Equal(t, "primary", readFrom(d), "the reads should stick to the primary after written")
+
+		forgetWrites()
+		var count int64
+		d.Model(&replicaTestItem{}).Count(&count)
+		assert.Equal(t, int64(1), count, "the written row should not be in the replica")
+		d.Scopes(UsePrimary).Model(&replicaTestItem{}).Count(&count)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("Transaction and raw query use primary", func(t *testing.T) {
+		d.Transaction(func(tx *gorm.DB) error {
+			assert.Equal(t, "primary", readFrom(tx))
+			return nil
+		})
+
+		var name string
+		d.Raw("SELECT name FROM replica_test_items ORDER BY id ASC LIMIT 1").Scan(&name)
+		assert.Equal(t, "primary", name)
+	})
+
+	t.Run("Lag exceeds max lag", func(t *testing.T) {
+		lag = 20 * time.Second
+		resolver.check()
+		assert.Equal(t, "primary", readFrom(d))
+
+		lag = 0
+		resolver.check()
+		assert.Equal(t, "replica", readFrom(d), "the replica should be used again after recovered")
+	})
+
+	t.Run("Sweep written times", func(t *testing.T) {
+		assert.NoError(t, d.Create(&replicaTestItem{Name: "swept"}).Error)
+		resolver.sweepWritten()
+		assert.Contains(t, resolver.written, "replica_test_items", "should be kept before the stickiness expired")
+
+		forgetWrites()
+		resolver.sweepWritten()
+		assert.Empty(t, resolver.written)
+	})
+
+	t.Run("Replica unavailable", func(t *testing.T) {
+		CloseDB(resolver.replicas[0].db)
+		resolver.check()
+		assert.Equal(t, "primary", readFrom(d))
+	})
+}
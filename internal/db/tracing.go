@@ -0,0 +1,126 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const tracingPluginName = "artalk:tracing"
+
+// The instance key of the span of the statement
+const tracingSpanKey = "artalk:tracing_span"
+
+// UseTracing traces the queries in the span of the statement context (e.g. `db.WithContext(c.UserContext())`),
+// the queries without the span are not traced, so the background jobs do not create the root spans.
+func UseTracing(db *gorm.DB, dbType config.DBType) error {
+	return db.Use(&tracingPlugin{system: dbSystemAttr(dbType)})
+}
+
+func dbSystemAttr(dbType config.DBType) attribute.KeyValue {
+	switch dbType {
+	case config.TypeMySql:
+		return semconv.DBSystemMySQL
+	case config.TypePostgreSQL:
+		return semconv.DBSystemPostgreSQL
+	case config.TypeMSSQL:
+		return semconv.DBSystemMSSQL
+	default:
+		return semconv.DBSystemSqlite
+	}
+}
+
+type tracingPlugin struct {
+	system attribute.KeyValue
+}
+
+func (p *tracingPlugin) Name() string {
+	return tracingPluginName
+}
+
+func (p *tracingPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("artalk:tracing_start", p.start("SELECT")); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("artalk:tracing_end", p.end); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("artalk:tracing_start", p.start("SELECT")); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().After("gorm:row").Register("artalk:tracing_end", p.end); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().Before("gorm:create").Register("artalk:tracing_start", p.start("INSERT")); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().After("gorm:create").Register("artalk:tracing_end", p.end); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("artalk:tracing_start", p.start("UPDATE")); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("artalk:tracing_end", p.end); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("artalk:tracing_start", p.start("DELETE")); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("artalk:tracing_end", p.end); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().Before("gorm:raw").Register("artalk:tracing_start", p.start("RAW")); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().After("gorm:raw").Register("artalk:tracing_end", p.end); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *tracingPlugin) start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if db.DryRun || !tracing.HasSpan(ctx) {
+			return
+		}
+
+		name := operation
+		if table := db.Statement.Table; table != "" {
+			name += " " + table
+		}
+		_, span := tracing.Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				p.system,
+				semconv.DBOperationName(operation),
+				semconv.DBCollectionName(db.Statement.Table),
+			))
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func (p *tracingPlugin) end(db *gorm.DB) {
+	v, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span := v.(trace.Span)
+
+	// the SQL with the placeholders (the values are not recorded)
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil // not an error of the database
+	}
+	tracing.End(span, err)
+}
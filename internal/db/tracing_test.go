@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type tracingTestItem struct {
+	ID   uint
+	Name string
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.UseTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer tracing.UseTracerProvider(nil)
+
+	d, err := NewDB(config.DBConf{Type: config.TypeSQLite, Dsn: filepath.Join(t.TempDir(), "tracing.db")})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer CloseDB(d)
+	assert.NoError(t, d.AutoMigrate(&tracingTestItem{}))
+	assert.NoError(t, UseTracing(d, config.TypeSQLite))
+
+	t.Run("Without span", func(t *testing.T) {
+		assert.NoError(t, d.Create(&tracingTestItem{Name: "a"}).Error)
+		assert.Empty(t, recorder.Ended(), "the queries without span should not be traced")
+	})
+
+	t.Run("In span", func(t *testing.T) {
+		ctx, span := tracing.Start(context.Background(), "request")
+		tx := d.WithContext(ctx)
+		assert.NoError(t, tx.Create(&tracingTestItem{Name: "b"}).Error)
+		var item tracingTestItem
+		tx.Where("name = ?", "not_found").First(&item)
+		span.End()
+
+		spans := recorder.Ended()
+		if !assert.Len(t, spans, 3) {
+			return
+		}
+
+		assert.Equal(t, "INSERT tracing_test_items", spans[0].Name())
+		assert.Equal(t, "SELECT tracing_test_items", spans[1].Name())
+		assert.Equal(t, span.SpanContext().SpanID(), spans[1].Parent().SpanID())
+
+		attrs := map[string]string{}
+		for _, kv := range spans[1].Attributes() {
+			attrs[string(kv.Key)] = kv.Value.Emit()
+		}
+		assert.Equal(t, "sqlite", attrs["db.system"])
+		assert.Contains(t, attrs["db.query.text"], "name = ?", "should not record the values")
+		assert.Empty(t, spans[1].Events(), "record not found should not be an error")
+	})
+}
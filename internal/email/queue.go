@@ -2,24 +2,31 @@ package email
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"sync"
 
 	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/log"
 )
 
+const defaultQueueBufferSize = 100
+
 type EmailConf struct {
 	config.EmailConf
+	Client        *http.Client // The HTTP client of the API senders
 	Sender        Sender
 	OnSendSuccess func(email *Email)
+	OnSendFailed  func(email *Email, err error) // The email is not retried by the queue, the caller decides whether to retry it
 }
 
 type EmailQueue struct {
-	conf   EmailConf
-	sender Sender
-	ch     chan *Email
-	mux    sync.Mutex
-	closed bool
+	conf    EmailConf
+	sender  Sender
+	ch      chan *Email
+	mux     sync.RWMutex
+	closed  bool
+	workers sync.WaitGroup
 }
 
 // Initialize Email Queue
@@ -29,7 +36,11 @@ func NewQueue(conf EmailConf) *EmailQueue {
 	}
 
 	// init email queue
-	queue.ch = make(chan *Email, conf.Queue.BufferSize)
+	bufferSize := conf.Queue.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultQueueBufferSize
+	}
+	queue.ch = make(chan *Email, bufferSize)
 
 	log.Debug("[Email] Email Queue initialize complete")
 
@@ -44,12 +55,17 @@ func NewQueue(conf EmailConf) *EmailQueue {
 		}
 	}
 
-	// init queue worker
-	go func() {
-		for email := range queue.ch {
-			queue.handleEmail(email)
-		}
-	}()
+	// init queue workers
+	workers := max(1, conf.Queue.Workers)
+	for i := 0; i < workers; i++ {
+		queue.workers.Add(1)
+		go func() {
+			defer queue.workers.Done()
+			for email := range queue.ch {
+				queue.handleEmail(email)
+			}
+		}()
+	}
 
 	return queue
 }
@@ -57,22 +73,30 @@ func NewQueue(conf EmailConf) *EmailQueue {
 func (q *EmailQueue) handleEmail(email *Email) {
 	log.Debug(fmt.Sprintf("[Email] Sending an email %+v: ", email))
 
+	var err error
 	if q.sender == nil {
-		log.Error("[Email] Email Sender is nil")
-		return
+		err = fmt.Errorf("email sender is not initialized")
+	} else {
+		err = q.sender.Send(email)
 	}
 
-	if isOK := q.sender.Send(email); isOK {
+	if err == nil {
 		if q.conf.OnSendSuccess != nil {
 			q.conf.OnSendSuccess(email)
 		}
 	} else {
-		log.Errorf("[Email] Failed send email to addr: %s", email.ToAddr)
+		log.Errorf("[Email] Failed send email to addr: %s: %s", email.ToAddr, err)
+		if q.conf.OnSendFailed != nil {
+			q.conf.OnSendFailed(email, err)
+		}
 	}
 }
 
-// Add an email to the sending queue
+// Add an email to the sending queue, it blocks until the queue has space
 func (q *EmailQueue) Push(email *Email) {
+	q.mux.RLock()
+	defer q.mux.RUnlock() // hold the lock while sending, to avoid the queue being closed
+
 	if q.closed {
 		log.Error("[Email] Queue closed, dropping email")
 		return
@@ -81,12 +105,45 @@ func (q *EmailQueue) Push(email *Email) {
 	q.ch <- email
 }
 
+// Add an email to the sending queue without blocking, false is returned if the queue is full or closed
+func (q *EmailQueue) TryPush(email *Email) bool {
+	q.mux.RLock()
+	defer q.mux.RUnlock()
+
+	if q.closed {
+		return false
+	}
+
+	select {
+	case q.ch <- email:
+		return true
+	default:
+		return false
+	}
+}
+
+// Get the number of emails waiting in the sending queue
+func (q *EmailQueue) Len() int {
+	return len(q.ch)
+}
+
+// Close the queue, the emails in the queue are sent before it returns
 func (q *EmailQueue) Close() {
 	q.mux.Lock()
-	defer q.mux.Unlock()
+	if q.closed {
+		q.mux.Unlock()
+		return
+	}
+	close(q.ch)
+	q.closed = true
+	q.mux.Unlock()
 
-	if !q.closed {
-		close(q.ch)
-		q.closed = true
+	q.workers.Wait()
+
+	// release the connections kept by sender
+	if closer, ok := q.sender.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Error("[Email] Failed to close the sender: ", err)
+		}
 	}
 }
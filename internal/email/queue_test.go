@@ -1,6 +1,7 @@
 package email
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -59,6 +60,40 @@ func TestQueue(t *testing.T) {
 		assert.False(t, sendSuccessHookTriggered, "Send success hook should not be triggered")
 	})
 
+	t.Run("Send failed hook", func(t *testing.T) {
+		var failedErr error
+
+		q := NewQueue(EmailConf{
+			EmailConf: config.EmailConf{},
+			Sender: &mockSender{
+				SendResult: false,
+			},
+			OnSendFailed: func(email *Email, err error) {
+				assert.Equal(t, testEmail, *email)
+				failedErr = err
+			},
+		})
+
+		q.Push(&testEmail)
+		q.Close() // wait for the emails in queue to be sent
+
+		assert.EqualError(t, failedErr, "mock send failed")
+	})
+
+	t.Run("TryPush", func(t *testing.T) {
+		q := NewQueue(EmailConf{
+			EmailConf: config.EmailConf{},
+			Sender: &mockSender{
+				SendResult: true,
+			},
+		})
+
+		assert.True(t, q.TryPush(&testEmail))
+
+		q.Close()
+		assert.False(t, q.TryPush(&testEmail), "Closed queue should not accept emails")
+	})
+
 	t.Run("Close", func(t *testing.T) {
 		q := NewQueue(EmailConf{
 			EmailConf: config.EmailConf{},
@@ -85,6 +120,9 @@ type mockSender struct {
 
 var _ Sender = (*mockSender)(nil)
 
-func (s *mockSender) Send(email *Email) bool {
-	return s.SendResult
+func (s *mockSender) Send(email *Email) error {
+	if !s.SendResult {
+		return fmt.Errorf("mock send failed")
+	}
+	return nil
 }
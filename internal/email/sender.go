@@ -2,33 +2,53 @@ package email
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/entity"
 )
 
 type Email struct {
+	ID           uint // The ID of the task persisted in the queue table (0 if not persisted)
 	FromAddr     string
 	FromName     string
 	ToAddr       string
 	Subject      string
 	Body         string
 	LinkedNotify *entity.Notify
+
+	LinkedNotifies []entity.Notify // The notifies summarized in the digest email
+
+	UnsubscribeLink string // The link for the `List-Unsubscribe` header (empty for none)
 }
 
 // Sender is an interface for sending email.
 type Sender interface {
-	Send(email *Email) bool
+	Send(email *Email) error
 }
 
 func NewSender(conf EmailConf) (Sender, error) {
+	client := conf.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
 	switch conf.SendType {
 	case config.TypeSMTP:
-		return NewSmtpSender(conf.SMTP), nil
+		return NewSmtpSender(conf.SMTP, max(1, conf.Queue.Workers)), nil
 	case config.TypeAliDM:
 		return NewAliDMSender(conf.AliDM), nil
 	case config.TypeSendmail:
 		return NewCmdSender(), nil
+	case config.TypeSES:
+		return NewSESSender(conf.SES, client), nil
+	case config.TypeMailgun:
+		return NewMailgunSender(conf.Mailgun, client), nil
+	case config.TypePostmark:
+		return NewPostmarkSender(conf.Postmark, client), nil
+	case config.TypeResend:
+		return NewResendSender(conf.Resend, client), nil
 	default:
 		return nil, fmt.Errorf("unknown email sender type")
 	}
@@ -1,6 +1,8 @@
 package email
 
 import (
+	"fmt"
+
 	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/log"
 	aliyun_email "github.com/qwqcode/go-aliyun-email"
@@ -21,7 +23,7 @@ func NewAliDMSender(conf config.AliDMConf) *AliDMSender {
 	}
 }
 
-func (s *AliDMSender) Send(email *Email) bool {
+func (s *AliDMSender) Send(email *Email) error {
 	client := aliyun_email.NewClient(
 		s.conf.AccessKeyId,
 		s.conf.AccessKeySecret,
@@ -40,11 +42,10 @@ func (s *AliDMSender) Send(email *Email) bool {
 
 	resp, err := client.SingleRequest(req)
 	if err != nil {
-		log.Error("[Email] ", "Email sending failed via Aliyun DM", err)
-		return false
+		return fmt.Errorf("email sending failed via Aliyun DM: %w", err)
 	}
 
 	log.Debug(resp)
 
-	return true
+	return nil
 }
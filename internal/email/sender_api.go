@@ -0,0 +1,56 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+// Format the sender address as `"Name" <addr>`, the non-ASCII name is encoded
+func formatAddress(addr string, name string) string {
+	return (&mail.Address{Name: name, Address: addr}).String()
+}
+
+// Get the extra headers of the email for the API senders
+func getEmailHeaders(email *Email) map[string]string {
+	headers := map[string]string{}
+	if email.UnsubscribeLink != "" {
+		headers["List-Unsubscribe"] = "<" + email.UnsubscribeLink + ">"
+		headers["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
+	}
+	return headers
+}
+
+func newJSONRequest(method string, url string, data any) (*http.Request, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// Send the request to the API of email service provider, the error contains the truncated response if not 2xx
+func doAPIRequest(client *http.Client, provider string, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("email sending failed via %s: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("email sending failed via %s: status %d: %s", provider, resp.StatusCode, utils.TruncateString(string(body), 256))
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAWSRequest(t *testing.T) {
+	// the "get-vanilla" case of AWS Signature Version 4 test suite
+	req, _ := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	now, _ := time.Parse("20060102T150405Z", "20150830T123600Z")
+
+	assert.NoError(t, signAWSRequest(req, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", now))
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+func TestAPISenders(t *testing.T) {
+	type received struct {
+		path   string
+		header http.Header
+		body   []byte
+	}
+	var last received
+	status := 200
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		last = received{r.URL.Path, r.Header, body}
+		w.WriteHeader(status)
+		w.Write([]byte(`{"message":"mock response"}`))
+	}))
+	defer server.Close()
+
+	for _, api := range []*string{&MailgunAPI, &PostmarkAPI, &ResendAPI} {
+		defer func(api *string, origin string) { *api = origin }(api, *api)
+		*api = server.URL
+	}
+	defer func(origin string) { SESEndpoint = origin }(SESEndpoint)
+	SESEndpoint = server.URL + "/%s"
+
+	testEmail := &Email{
+		FromAddr:        "noreply@example.com",
+		FromName:        "ArtalkJS",
+		ToAddr:          "reader@example.com",
+		Subject:         "TestEmail",
+		Body:            "<p>This is a test email</p>",
+		UnsubscribeLink: "https://example.com/unsubscribe",
+	}
+
+	t.Run("SES", func(t *testing.T) {
+		sender := NewSESSender(config.SESConf{Region: "eu-west-1", AccessKeyId: "AKID", AccessKeySecret: "SECRET"}, server.Client())
+		assert.NoError(t, sender.Send(testEmail))
+		assert.Equal(t, "/eu-west-1/v2/email/outbound-emails", last.path)
+		assert.Contains(t, last.header.Get("Authorization"), "Credential=AKID/")
+		assert.Contains(t, last.header.Get("Authorization"), "/eu-west-1/ses/aws4_request")
+
+		var data struct {
+			Content struct{ Raw struct{ Data []byte } }
+		}
+		assert.NoError(t, json.Unmarshal(last.body, &data))
+		assert.Contains(t, string(data.Content.Raw.Data), "Subject: TestEmail")
+		assert.Contains(t, string(data.Content.Raw.Data), "List-Unsubscribe: <https://example.com/unsubscribe>")
+	})
+
+	t.Run("Mailgun", func(t *testing.T) {
+		sender := NewMailgunSender(config.MailgunConf{Domain: "mg.example.com", ApiKey: "KEY"}, server.Client())
+		assert.NoError(t, sender.Send(testEmail))
+		assert.Equal(t, "/v3/mg.example.com/messages", last.path)
+		assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("api:KEY")), last.header.Get("Authorization"))
+
+		form, _ := url.ParseQuery(string(last.body))
+		assert.Equal(t, `"ArtalkJS" <noreply@example.com>`, form.Get("from"))
+		assert.Equal(t, "reader@example.com", form.Get("to"))
+		assert.Equal(t, testEmail.Body, form.Get("html"))
+		assert.Equal(t, "<https://example.com/unsubscribe>", form.Get("h:List-Unsubscribe"))
+	})
+
+	t.Run("Postmark", func(t *testing.T) {
+		sender := NewPostmarkSender(config.PostmarkConf{ServerToken: "TOKEN"}, server.Client())
+		assert.NoError(t, sender.Send(testEmail))
+		assert.Equal(t, "/email", last.path)
+		assert.Equal(t, "TOKEN", last.header.Get("X-Postmark-Server-Token"))
+
+		var data map[string]any
+		assert.NoError(t, json.Unmarshal(last.body, &data))
+		assert.Equal(t, "reader@example.com", data["To"])
+		assert.Equal(t, "outbound", data["MessageStream"])
+		assert.Len(t, data["Headers"], 2)
+	})
+
+	t.Run("Resend", func(t *testing.T) {
+		sender := NewResendSender(config.ResendConf{ApiKey: "re_KEY"}, server.Client())
+		assert.NoError(t, sender.Send(testEmail))
+		assert.Equal(t, "/emails", last.path)
+		assert.Equal(t, "Bearer re_KEY", last.header.Get("Authorization"))
+
+		var data map[string]any
+		assert.NoError(t, json.Unmarshal(last.body, &data))
+		assert.Equal(t, []any{"reader@example.com"}, data["to"])
+		assert.Equal(t, "TestEmail", data["subject"])
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		status = 422
+		defer func() { status = 200 }()
+
+		err := NewResendSender(config.ResendConf{}, server.Client()).Send(testEmail)
+		assert.ErrorContains(t, err, "status 422")
+		assert.ErrorContains(t, err, "mock response")
+	})
+}
+
+func TestParseMailgunWebhook(t *testing.T) {
+	now := time.Now()
+	webhook := func(key string, timestamp time.Time, eventData string) []byte {
+		ts := strconv.FormatInt(timestamp.Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(ts + "token"))
+		return []byte(fmt.Sprintf(`{"signature":{"timestamp":%q,"token":"token","signature":%q},"event-data":%s}`,
+			ts, hex.EncodeToString(mac.Sum(nil)), eventData))
+	}
+
+	t.Run("PermanentFailure", func(t *testing.T) {
+		s, err := ParseMailgunWebhook("key", webhook("key", now, `{"event":"failed","severity":"permanent","recipient":"Alice@Example.com","delivery-status":{"description":"No such user"}}`), now)
+		assert.NoError(t, err)
+		assert.Equal(t, []Suppression{{Email: "alice@example.com", Reason: SuppressionReasonBounce, Detail: "No such user"}}, s)
+	})
+
+	t.Run("TemporaryFailure", func(t *testing.T) {
+		s, err := ParseMailgunWebhook("key", webhook("key", now, `{"event":"failed","severity":"temporary","recipient":"alice@example.com"}`), now)
+		assert.NoError(t, err)
+		assert.Empty(t, s)
+	})
+
+	t.Run("Complained", func(t *testing.T) {
+		s, err := ParseMailgunWebhook("key", webhook("key", now, `{"event":"complained","recipient":"alice@example.com"}`), now)
+		assert.NoError(t, err)
+		assert.Equal(t, SuppressionReasonComplaint, s[0].Reason)
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		event := `{"event":"complained","recipient":"alice@example.com"}`
+		_, err := ParseMailgunWebhook("key", webhook("wrong", now, event), now)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+
+		_, err = ParseMailgunWebhook("", webhook("", now, event), now)
+		assert.ErrorIs(t, err, ErrInvalidSignature, "empty key should not be accepted")
+
+		_, err = ParseMailgunWebhook("key", webhook("key", now.Add(-time.Hour), event), now)
+		assert.ErrorIs(t, err, ErrInvalidSignature, "expired timestamp should not be accepted")
+	})
+}
+
+func TestParsePostmarkWebhook(t *testing.T) {
+	s, err := ParsePostmarkWebhook([]byte(`{"RecordType":"Bounce","Type":"HardBounce","Email":"alice@example.com","Description":"The server was unable to deliver your message"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Suppression{{Email: "alice@example.com", Reason: SuppressionReasonBounce, Detail: "The server was unable to deliver your message"}}, s)
+
+	s, err = ParsePostmarkWebhook([]byte(`{"RecordType":"Bounce","Type":"SoftBounce","Email":"alice@example.com"}`))
+	assert.NoError(t, err)
+	assert.Empty(t, s)
+
+	s, err = ParsePostmarkWebhook([]byte(`{"RecordType":"SpamComplaint","Type":"SpamComplaint","Email":"alice@example.com"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, SuppressionReasonComplaint, s[0].Reason)
+}
+
+func TestParseResendWebhook(t *testing.T) {
+	key := []byte("resend-webhook-secret")
+	secret := "whsec_" + base64.StdEncoding.EncodeToString(key)
+	now := time.Now()
+
+	signed := func(key []byte, timestamp time.Time, body string) http.Header {
+		ts := strconv.FormatInt(timestamp.Unix(), 10)
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte("msg_1." + ts + "." + body))
+		header := http.Header{}
+		header.Set("svix-id", "msg_1")
+		header.Set("svix-timestamp", ts)
+		header.Set("svix-signature", "v1,old v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		return header
+	}
+
+	t.Run("Bounced", func(t *testing.T) {
+		body := `{"type":"email.bounced","data":{"to":["alice@example.com"],"bounce":{"type":"Permanent","message":"Mailbox does not exist"}}}`
+		s, err := ParseResendWebhook(secret, signed(key, now, body), []byte(body), now)
+		assert.NoError(t, err)
+		assert.Equal(t, []Suppression{{Email: "alice@example.com", Reason: SuppressionReasonBounce, Detail: "Mailbox does not exist"}}, s)
+	})
+
+	t.Run("Delivered", func(t *testing.T) {
+		body := `{"type":"email.delivered","data":{"to":["alice@example.com"]}}`
+		s, err := ParseResendWebhook(secret, signed(key, now, body), []byte(body), now)
+		assert.NoError(t, err)
+		assert.Empty(t, s)
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		body := `{"type":"email.complained","data":{"to":["alice@example.com"]}}`
+		_, err := ParseResendWebhook(secret, signed([]byte("wrong"), now, body), []byte(body), now)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+
+		_, err = ParseResendWebhook(secret, signed(key, now.Add(-time.Hour), body), []byte(body), now)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+
+		_, err = ParseResendWebhook("", signed(key, now, body), []byte(body), now)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func TestParseSESNotification(t *testing.T) {
+	sns := func(typ string, message string) []byte {
+		buf, _ := json.Marshal(map[string]string{"Type": typ, "Message": message, "SubscribeURL": "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=x"})
+		return buf
+	}
+
+	t.Run("SubscriptionConfirmation", func(t *testing.T) {
+		subscribeURL, _, err := ParseSESNotification(sns("SubscriptionConfirmation", ""))
+		assert.NoError(t, err)
+		assert.Equal(t, "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=x", subscribeURL)
+
+		_, _, err = ParseSESNotification([]byte(`{"Type":"SubscriptionConfirmation","SubscribeURL":"https://evil.example.com/sns.us-east-1.amazonaws.com"}`))
+		assert.Error(t, err, "the URL not of SNS should be rejected")
+	})
+
+	t.Run("PermanentBounce", func(t *testing.T) {
+		_, s, err := ParseSESNotification(sns("Notification", `{"notificationType":"Bounce","bounce":{"bounceType":"Permanent","bouncedRecipients":[{"emailAddress":"alice@example.com","diagnosticCode":"smtp; 550 5.1.1 user unknown"}]}}`))
+		assert.NoError(t, err)
+		assert.Equal(t, []Suppression{{Email: "alice@example.com", Reason: SuppressionReasonBounce, Detail: "smtp; 550 5.1.1 user unknown"}}, s)
+	})
+
+	t.Run("TransientBounce", func(t *testing.T) {
+		_, s, err := ParseSESNotification(sns("Notification", `{"eventType":"Bounce","bounce":{"bounceType":"Transient","bouncedRecipients":[{"emailAddress":"alice@example.com"}]}}`))
+		assert.NoError(t, err)
+		assert.Empty(t, s)
+	})
+
+	t.Run("Complaint", func(t *testing.T) {
+		_, s, err := ParseSESNotification(sns("Notification", `{"eventType":"Complaint","complaint":{"complaintFeedbackType":"abuse","complainedRecipients":[{"emailAddress":"alice@example.com"},{"emailAddress":"bob@example.com"}]}}`))
+		assert.NoError(t, err)
+		assert.Len(t, s, 2)
+		assert.Equal(t, Suppression{Email: "bob@example.com", Reason: SuppressionReasonComplaint, Detail: "abuse"}, s[1])
+	})
+}
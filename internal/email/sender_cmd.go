@@ -1,6 +1,7 @@
 package email
 
 import (
+	"fmt"
 	"io"
 	"os/exec"
 
@@ -18,49 +19,39 @@ func NewCmdSender() *CmdSender {
 	return &CmdSender{}
 }
 
-func (s *CmdSender) Send(email *Email) bool {
-	LogTag := "[EMAIL] [sendmail] "
+func (s *CmdSender) Send(email *Email) error {
 	msg := getEmailMineTxt(email)
 
 	// 调用系统 sendmail
 	sendmail := exec.Command("/usr/sbin/sendmail", "-t", "-oi")
 	stdin, err := sendmail.StdinPipe()
 	if err != nil {
-		log.Error(LogTag, err)
-		return false
+		return fmt.Errorf("sendmail: %w", err)
 	}
 
 	stdout, err := sendmail.StdoutPipe()
 	if err != nil {
-		log.Error(LogTag, err)
-		return false
+		return fmt.Errorf("sendmail: %w", err)
 	}
 
 	if err := sendmail.Start(); err != nil {
-		log.Error(LogTag, err)
-		return false
+		return fmt.Errorf("sendmail: %w", err)
 	}
 
 	if _, err := stdin.Write([]byte(msg)); err != nil {
-		log.Error(LogTag, err)
-		return false
+		return fmt.Errorf("sendmail: %w", err)
 	}
 
 	if err := stdin.Close(); err != nil {
-		log.Error(LogTag, err)
-		return false
+		return fmt.Errorf("sendmail: %w", err)
 	}
 
 	sentBytes, _ := io.ReadAll(stdout)
 	if err := sendmail.Wait(); err != nil {
-		log.Error(LogTag, err)
-		if exitError, ok := err.(*exec.ExitError); ok {
-			log.Errorf("["+LogTag+"] Exit code is %d", exitError.ExitCode())
-		}
-		return false
+		return fmt.Errorf("sendmail: %w", err) // "exit status N"
 	}
 
 	log.Debug(string(sentBytes))
 
-	return true
+	return nil
 }
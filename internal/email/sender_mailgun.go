@@ -0,0 +1,116 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+)
+
+// The API base URLs of Mailgun
+var (
+	MailgunAPI   = "https://api.mailgun.net"
+	MailgunAPIEU = "https://api.eu.mailgun.net"
+)
+
+// MailgunSender implements Sender
+type MailgunSender struct {
+	conf   config.MailgunConf
+	client *http.Client
+}
+
+var _ Sender = (*MailgunSender)(nil)
+
+// NewMailgunSender Mailgun
+func NewMailgunSender(conf config.MailgunConf, client *http.Client) *MailgunSender {
+	return &MailgunSender{conf: conf, client: client}
+}
+
+func (s *MailgunSender) Send(email *Email) error {
+	api := MailgunAPI
+	if strings.EqualFold(s.conf.Region, "eu") {
+		api = MailgunAPIEU
+	}
+
+	form := url.Values{}
+	form.Set("from", formatAddress(email.FromAddr, email.FromName))
+	form.Set("to", email.ToAddr)
+	form.Set("subject", email.Subject)
+	form.Set("html", email.Body)
+	for name, value := range getEmailHeaders(email) {
+		form.Set("h:"+name, value)
+	}
+
+	req, err := http.NewRequest("POST", api+"/v3/"+url.PathEscape(s.conf.Domain)+"/messages", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("api", s.conf.ApiKey)
+
+	return doAPIRequest(s.client, "Mailgun", req)
+}
+
+// The max age of the Mailgun webhook, to prevent the replay attack
+const mailgunWebhookMaxAge = 15 * time.Minute
+
+// Parse the webhook of Mailgun, the permanent failures and the complaints are returned
+//
+// See: https://documentation.mailgun.com/docs/mailgun/user-manual/tracking-messages/#securing-webhooks
+func ParseMailgunWebhook(signingKey string, body []byte, now time.Time) ([]Suppression, error) {
+	var payload struct {
+		Signature struct {
+			Timestamp string `json:"timestamp"`
+			Token     string `json:"token"`
+			Signature string `json:"signature"`
+		} `json:"signature"`
+		EventData struct {
+			Event          string `json:"event"`
+			Severity       string `json:"severity"`
+			Recipient      string `json:"recipient"`
+			Reason         string `json:"reason"`
+			DeliveryStatus struct {
+				Description string `json:"description"`
+				Message     string `json:"message"`
+			} `json:"delivery-status"`
+		} `json:"event-data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	sign := payload.Signature
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(sign.Timestamp + sign.Token))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if signingKey == "" || !hmac.Equal([]byte(expected), []byte(sign.Signature)) {
+		return nil, ErrInvalidSignature
+	}
+	if ts, err := strconv.ParseInt(sign.Timestamp, 10, 64); err != nil || now.Sub(time.Unix(ts, 0)).Abs() > mailgunWebhookMaxAge {
+		return nil, fmt.Errorf("%w: the timestamp is expired", ErrInvalidSignature)
+	}
+
+	event := payload.EventData
+	switch {
+	case event.Event == "failed" && event.Severity == "permanent":
+		detail := event.DeliveryStatus.Description
+		if detail == "" {
+			detail = event.DeliveryStatus.Message
+		}
+		if detail == "" {
+			detail = event.Reason
+		}
+		return []Suppression{newSuppression(event.Recipient, SuppressionReasonBounce, detail)}, nil
+	case event.Event == "complained":
+		return []Suppression{newSuppression(event.Recipient, SuppressionReasonComplaint, "")}, nil
+	}
+	return nil, nil
+}
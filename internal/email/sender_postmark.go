@@ -0,0 +1,73 @@
+package email
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/samber/lo"
+)
+
+// The API base URL of Postmark
+var PostmarkAPI = "https://api.postmarkapp.com"
+
+// PostmarkSender implements Sender
+type PostmarkSender struct {
+	conf   config.PostmarkConf
+	client *http.Client
+}
+
+var _ Sender = (*PostmarkSender)(nil)
+
+// NewPostmarkSender Postmark
+func NewPostmarkSender(conf config.PostmarkConf, client *http.Client) *PostmarkSender {
+	return &PostmarkSender{conf: conf, client: client}
+}
+
+func (s *PostmarkSender) Send(email *Email) error {
+	type header struct {
+		Name  string
+		Value string
+	}
+
+	req, err := newJSONRequest("POST", PostmarkAPI+"/email", map[string]any{
+		"From":          formatAddress(email.FromAddr, email.FromName),
+		"To":            email.ToAddr,
+		"Subject":       email.Subject,
+		"HtmlBody":      email.Body,
+		"MessageStream": lo.If(s.conf.MessageStream == "", "outbound").Else(s.conf.MessageStream),
+		"Headers": lo.MapToSlice(getEmailHeaders(email), func(name string, value string) header {
+			return header{Name: name, Value: value}
+		}),
+	})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Postmark-Server-Token", s.conf.ServerToken)
+
+	return doAPIRequest(s.client, "Postmark", req)
+}
+
+// Parse the bounce or spam complaint webhook of Postmark, the hard bounces and the complaints are returned
+// (the webhook is not signed, it should be verified by the token in URL)
+//
+// See: https://postmarkapp.com/developer/webhooks/bounce-webhook
+func ParsePostmarkWebhook(body []byte) ([]Suppression, error) {
+	var payload struct {
+		RecordType  string `json:"RecordType"`
+		Type        string `json:"Type"`
+		Email       string `json:"Email"`
+		Description string `json:"Description"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case payload.RecordType == "Bounce" && (payload.Type == "HardBounce" || payload.Type == "BadEmailAddress"):
+		return []Suppression{newSuppression(payload.Email, SuppressionReasonBounce, payload.Description)}, nil
+	case payload.RecordType == "SpamComplaint":
+		return []Suppression{newSuppression(payload.Email, SuppressionReasonComplaint, payload.Description)}, nil
+	}
+	return nil, nil
+}
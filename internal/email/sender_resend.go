@@ -0,0 +1,113 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/samber/lo"
+)
+
+// The API base URL of Resend
+var ResendAPI = "https://api.resend.com"
+
+// ResendSender implements Sender
+type ResendSender struct {
+	conf   config.ResendConf
+	client *http.Client
+}
+
+var _ Sender = (*ResendSender)(nil)
+
+// NewResendSender Resend
+func NewResendSender(conf config.ResendConf, client *http.Client) *ResendSender {
+	return &ResendSender{conf: conf, client: client}
+}
+
+func (s *ResendSender) Send(email *Email) error {
+	req, err := newJSONRequest("POST", ResendAPI+"/emails", map[string]any{
+		"from":    formatAddress(email.FromAddr, email.FromName),
+		"to":      []string{email.ToAddr},
+		"subject": email.Subject,
+		"html":    email.Body,
+		"headers": getEmailHeaders(email),
+	})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.conf.ApiKey)
+
+	return doAPIRequest(s.client, "Resend", req)
+}
+
+// The tolerance of the webhook timestamp, to prevent the replay attack
+const resendWebhookTolerance = 5 * time.Minute
+
+// Parse the webhook of Resend, which is signed by Svix, the bounces and the complaints are returned
+//
+// See: https://resend.com/docs/dashboard/webhooks/verify-webhooks-requests
+func ParseResendWebhook(secret string, header http.Header, body []byte, now time.Time) ([]Suppression, error) {
+	if err := verifySvixSignature(secret, header, body, now); err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Type string `json:"type"`
+		Data struct {
+			To     []string `json:"to"`
+			Bounce struct {
+				Type    string `json:"type"`
+				Message string `json:"message"`
+			} `json:"bounce"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	var reason, detail string
+	switch payload.Type {
+	case "email.bounced":
+		if payload.Data.Bounce.Type != "" && !strings.EqualFold(payload.Data.Bounce.Type, "Permanent") {
+			return nil, nil // the transient bounce
+		}
+		reason, detail = SuppressionReasonBounce, payload.Data.Bounce.Message
+	case "email.complained":
+		reason = SuppressionReasonComplaint
+	default:
+		return nil, nil
+	}
+
+	return lo.Map(payload.Data.To, func(to string, _ int) Suppression { return newSuppression(to, reason, detail) }), nil
+}
+
+func verifySvixSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if secret == "" || err != nil {
+		return ErrInvalidSignature
+	}
+
+	id, timestamp := header.Get("svix-id"), header.Get("svix-timestamp")
+	if ts, err := strconv.ParseInt(timestamp, 10, 64); err != nil || now.Sub(time.Unix(ts, 0)).Abs() > resendWebhookTolerance {
+		return fmt.Errorf("%w: the timestamp is expired", ErrInvalidSignature)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "." + string(body)))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	// the header contains the space separated signatures like "v1,xxx v1,yyy" when the secret is rotated
+	for _, sig := range strings.Fields(header.Get("svix-signature")) {
+		if version, value, ok := strings.Cut(sig, ","); ok && version == "v1" && hmac.Equal([]byte(value), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,228 @@
+package email
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/samber/lo"
+)
+
+// The API endpoint of AWS SES (v2), `%s` is replaced by the region
+var SESEndpoint = "https://email.%s.amazonaws.com"
+
+// SESSender implements Sender
+type SESSender struct {
+	conf   config.SESConf
+	client *http.Client
+}
+
+var _ Sender = (*SESSender)(nil)
+
+// NewSESSender AWS SES
+func NewSESSender(conf config.SESConf, client *http.Client) *SESSender {
+	return &SESSender{conf: conf, client: client}
+}
+
+func (s *SESSender) Send(email *Email) error {
+	region := lo.If(s.conf.Region == "", "us-east-1").Else(s.conf.Region)
+
+	// the raw MIME message is sent, so the headers are the same as SMTP
+	data := map[string]any{
+		"Content": map[string]any{
+			"Raw": map[string]any{"Data": []byte(getEmailMineTxt(email))}, // base64 encoded by json
+		},
+	}
+	if s.conf.ConfigurationSet != "" {
+		data["ConfigurationSetName"] = s.conf.ConfigurationSet
+	}
+
+	req, err := newJSONRequest("POST", fmt.Sprintf(SESEndpoint, region)+"/v2/email/outbound-emails", data)
+	if err != nil {
+		return err
+	}
+	if err := signAWSRequest(req, s.conf.AccessKeyId, s.conf.AccessKeySecret, region, "ses", time.Now()); err != nil {
+		return err
+	}
+
+	return doAPIRequest(s.client, "AWS SES", req)
+}
+
+// Sign the request with AWS Signature Version 4
+//
+// See: https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html
+func signAWSRequest(req *http.Request, accessKeyID string, secretKey string, region string, service string, now time.Time) error {
+	var body []byte
+	if req.GetBody != nil {
+		r, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r)
+		body = buf.Bytes()
+	}
+
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	// the signed headers (host and x-amz-*, with content-type if set)
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(req.Header.Get(name))
+		}
+	}
+	names := lo.Keys(headers)
+	sort.Strings(names)
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + secretKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+	return nil
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := lo.Keys(query)
+	sort.Strings(keys)
+	pairs := []string{}
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, awsURIEncode(k)+"="+awsURIEncode(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// URI encode every byte except the unreserved characters (`url.QueryEscape` encodes the space as "+")
+func awsURIEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// The host of the SNS subscription confirmation URL
+var snsHostRegexp = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// Parse the SNS notification of the SES bounces and complaints, the permanent bounces and the complaints are returned.
+// If it is the subscription confirmation, the URL to confirm is returned
+// (the notification is not verified, it should be verified by the token in URL).
+//
+// See: https://docs.aws.amazon.com/ses/latest/dg/notification-contents.html
+func ParseSESNotification(body []byte) (subscribeURL string, suppressions []Suppression, err error) {
+	var notification struct {
+		Type         string `json:"Type"`
+		SubscribeURL string `json:"SubscribeURL"`
+		Message      string `json:"Message"`
+	}
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return "", nil, err
+	}
+
+	switch notification.Type {
+	case "SubscriptionConfirmation":
+		u, err := url.Parse(notification.SubscribeURL)
+		if err != nil || u.Scheme != "https" || !snsHostRegexp.MatchString(u.Hostname()) {
+			return "", nil, fmt.Errorf("invalid SubscribeURL")
+		}
+		return u.String(), nil, nil
+	case "Notification":
+	default:
+		return "", nil, nil
+	}
+
+	type recipient struct {
+		EmailAddress   string `json:"emailAddress"`
+		DiagnosticCode string `json:"diagnosticCode"`
+	}
+	var message struct {
+		NotificationType string `json:"notificationType"` // the notifications of identity
+		EventType        string `json:"eventType"`        // the events of configuration set
+		Bounce           struct {
+			BounceType        string      `json:"bounceType"`
+			BouncedRecipients []recipient `json:"bouncedRecipients"`
+		} `json:"bounce"`
+		Complaint struct {
+			ComplainedRecipients []recipient `json:"complainedRecipients"`
+			FeedbackType         string      `json:"complaintFeedbackType"`
+		} `json:"complaint"`
+	}
+	if err := json.Unmarshal([]byte(notification.Message), &message); err != nil {
+		return "", nil, err
+	}
+
+	switch lo.If(message.NotificationType != "", message.NotificationType).Else(message.EventType) {
+	case "Bounce":
+		if message.Bounce.BounceType != "Permanent" {
+			return "", nil, nil
+		}
+		for _, r := range message.Bounce.BouncedRecipients {
+			suppressions = append(suppressions, newSuppression(r.EmailAddress, SuppressionReasonBounce, r.DiagnosticCode))
+		}
+	case "Complaint":
+		for _, r := range message.Complaint.ComplainedRecipients {
+			suppressions = append(suppressions, newSuppression(r.EmailAddress, SuppressionReasonComplaint, message.Complaint.FeedbackType))
+		}
+	}
+	return "", suppressions, nil
+}
+
+// Confirm the SNS subscription by visiting the URL returned by `ParseSESNotification`
+func ConfirmSESSubscription(client *http.Client, subscribeURL string) error {
+	resp, err := client.Get(subscribeURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to confirm the SNS subscription: status %d", resp.StatusCode)
+	}
+	return nil
+}
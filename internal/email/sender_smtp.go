@@ -1,35 +1,133 @@
 package email
 
 import (
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/artalkjs/artalk/v2/internal/config"
-	"github.com/artalkjs/artalk/v2/internal/log"
 	"gopkg.in/gomail.v2"
 )
 
+// The idle SMTP connection is closed after the timeout (most servers drop the idle connection in minutes)
+const smtpIdleTimeout = 30 * time.Second
+
 // SmtpSender implements Sender
+//
+// The SMTP connections are reused across the emails, up to `maxIdle` idle connections are kept.
 type SmtpSender struct {
-	dialer *gomail.Dialer
+	dialer  *gomail.Dialer
+	maxIdle int
+
+	mux  sync.Mutex
+	idle []*smtpConn
+}
+
+type smtpConn struct {
+	gomail.SendCloser
+	lastUsed time.Time
 }
 
 var _ Sender = (*SmtpSender)(nil)
 
 // NewSmtpSender SMTP
-func NewSmtpSender(smtp config.SMTPConf) *SmtpSender {
+func NewSmtpSender(smtp config.SMTPConf, maxIdle int) *SmtpSender {
 	d := gomail.NewDialer(smtp.Host, smtp.Port, smtp.Username, smtp.Password)
 
 	return &SmtpSender{
-		dialer: d,
+		dialer:  d,
+		maxIdle: maxIdle,
 	}
 }
 
-func (s *SmtpSender) Send(email *Email) bool {
+func (s *SmtpSender) Send(email *Email) error {
 	m := getCookedEmail(email)
 
 	// 发送邮件
-	if err := s.dialer.DialAndSend(m); err != nil {
-		log.Error("[Email] ", "Email sending failed via SMTP ", err)
-		return false
+	conn, reused, err := s.getConn()
+	if err != nil {
+		return fmt.Errorf("email sending failed via SMTP: %w", err)
+	}
+
+	err = gomail.Send(conn, m)
+	if err != nil && reused {
+		// the idle connection may be closed by the server, retry with a new one
+		conn.Close()
+		if conn, err = s.dial(); err == nil {
+			err = gomail.Send(conn, m)
+		}
+	}
+	if err != nil {
+		if conn != nil {
+			conn.Close()
+		}
+		return fmt.Errorf("email sending failed via SMTP: %w", err)
 	}
 
-	return true
+	s.putConn(conn)
+	return nil
+}
+
+// Close the idle connections
+func (s *SmtpSender) Close() error {
+	s.mux.Lock()
+	idle := s.idle
+	s.idle = nil
+	s.mux.Unlock()
+
+	for _, conn := range idle {
+		conn.Close()
+	}
+	return nil
+}
+
+// Get an idle connection or dial a new one
+func (s *SmtpSender) getConn() (conn *smtpConn, reused bool, err error) {
+	var expired []*smtpConn
+
+	s.mux.Lock()
+	for len(s.idle) > 0 && conn == nil {
+		c := s.idle[len(s.idle)-1]
+		s.idle = s.idle[:len(s.idle)-1]
+		if time.Since(c.lastUsed) > smtpIdleTimeout {
+			expired = append(expired, c)
+		} else {
+			conn = c
+		}
+	}
+	s.mux.Unlock()
+
+	for _, c := range expired {
+		c.Close()
+	}
+	if conn != nil {
+		return conn, true, nil
+	}
+
+	conn, err = s.dial()
+	return conn, false, err
+}
+
+func (s *SmtpSender) dial() (*smtpConn, error) {
+	sc, err := s.dialer.Dial()
+	if err != nil {
+		return nil, err
+	}
+	return &smtpConn{SendCloser: sc}, nil
+}
+
+// Put the connection back to the idle list, it is closed if the list is full
+func (s *SmtpSender) putConn(conn *smtpConn) {
+	conn.lastUsed = time.Now()
+
+	s.mux.Lock()
+	if len(s.idle) < s.maxIdle {
+		s.idle = append(s.idle, conn)
+		conn = nil
+	}
+	s.mux.Unlock()
+
+	if conn != nil {
+		conn.Close()
+	}
 }
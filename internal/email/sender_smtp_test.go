@@ -0,0 +1,144 @@
+package email
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmtpSender(t *testing.T) {
+	server := newMockSmtpServer(t)
+	defer server.Close()
+
+	sender := NewSmtpSender(config.SMTPConf{Host: "127.0.0.1", Port: server.Port()}, 1)
+	defer sender.Close()
+
+	testEmail := &Email{
+		FromAddr: "artalkjs@example.com",
+		FromName: "ArtalkJS",
+		ToAddr:   "reader@example.com",
+		Subject:  "TestEmail",
+		Body:     "This is a test email",
+	}
+
+	t.Run("ReuseConnection", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, sender.Send(testEmail))
+		}
+		assert.Equal(t, int32(1), server.conns.Load(), "the connection should be reused")
+		assert.Equal(t, int32(3), server.messages.Load())
+	})
+
+	t.Run("RedialWhenClosedByServer", func(t *testing.T) {
+		server.DropConns()
+
+		assert.NoError(t, sender.Send(testEmail))
+		assert.Equal(t, int32(2), server.conns.Load())
+		assert.Equal(t, int32(4), server.messages.Load())
+	})
+
+	t.Run("DialFailed", func(t *testing.T) {
+		sender := NewSmtpSender(config.SMTPConf{Host: "127.0.0.1", Port: 1}, 1)
+		assert.Error(t, sender.Send(testEmail))
+	})
+}
+
+// -------------------------------------------------------------------
+//  Mock SMTP Server
+// -------------------------------------------------------------------
+
+type mockSmtpServer struct {
+	ln       net.Listener
+	conns    atomic.Int32
+	messages atomic.Int32
+
+	mux    sync.Mutex
+	active []net.Conn
+}
+
+func newMockSmtpServer(t *testing.T) *mockSmtpServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &mockSmtpServer{ln: ln}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.conns.Add(1)
+			s.mux.Lock()
+			s.active = append(s.active, conn)
+			s.mux.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *mockSmtpServer) Port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+// Close the connections from the server side, like the idle timeout of real servers
+func (s *mockSmtpServer) DropConns() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, conn := range s.active {
+		conn.Close()
+	}
+	s.active = nil
+}
+
+func (s *mockSmtpServer) Close() {
+	s.ln.Close()
+	s.DropConns()
+}
+
+func (s *mockSmtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(code int, msg string) { conn.Write([]byte(strconv.Itoa(code) + " " + msg + "\r\n")) }
+
+	reply(220, "mock ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		switch cmd := strings.ToUpper(strings.Fields(line + " ")[0]); cmd {
+		case "EHLO", "HELO", "MAIL", "RCPT", "RSET", "NOOP":
+			reply(250, "OK")
+		case "DATA":
+			reply(354, "Go ahead")
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+			}
+			s.messages.Add(1)
+			reply(250, "Queued")
+		case "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			reply(502, "Not implemented")
+		}
+	}
+}
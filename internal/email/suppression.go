@@ -0,0 +1,32 @@
+package email
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+)
+
+// The reasons of suppressing the address
+const (
+	SuppressionReasonBounce    = "bounce"    // The address does not exist (permanent bounce)
+	SuppressionReasonComplaint = "complaint" // The receiver marked the email as spam
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// The address reported by the bounce or complaint webhook of email service provider,
+// the emails are not sent to it anymore
+type Suppression struct {
+	Email  string
+	Reason string
+	Detail string
+}
+
+func newSuppression(email string, reason string, detail string) Suppression {
+	return Suppression{Email: strings.ToLower(strings.TrimSpace(email)), Reason: reason, Detail: detail}
+}
+
+// Check the token in the webhook URL, for the providers which don't sign the webhook (SES and Postmark)
+func CheckWebhookToken(expected string, given string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(given)) == 1
+}
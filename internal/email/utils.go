@@ -17,6 +17,11 @@ func getCookedEmail(email *Email) *gomail.Message {
 	//m.SetAddressHeader("Cc", "dan@example.com", "Dan")
 	// 主题
 	m.SetHeader("Subject", email.Subject)
+	// 退订 (RFC 8058 一键退订)
+	if email.UnsubscribeLink != "" {
+		m.SetHeader("List-Unsubscribe", "<"+email.UnsubscribeLink+">")
+		m.SetHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
 	// 内容
 	m.SetBody("text/html", email.Body)
 	// 附件
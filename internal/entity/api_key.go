@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// The API key scopes
+const (
+	APIKeyScopeCommentsRead  = "comments:read"  // List the comments (include pending) and the read-only admin data
+	APIKeyScopeCommentsWrite = "comments:write" // Create the comments without captcha
+	APIKeyScopeModerate      = "moderate"       // Approve, edit and delete the comments
+	APIKeyScopeTransfer      = "transfer"       // Import and export the data
+	APIKeyScopeFeeds         = "feeds:read"     // Read the pending comments feed by the `key` query
+)
+
+var APIKeyScopes = []string{APIKeyScopeCommentsRead, APIKeyScopeCommentsWrite, APIKeyScopeModerate, APIKeyScopeTransfer, APIKeyScopeFeeds}
+
+// The admin permissions granted by the API key scopes
+var apiKeyScopePerms = map[string]AdminPerm{
+	APIKeyScopeCommentsRead: AdminPermRead,
+	APIKeyScopeModerate:     AdminPermModerate,
+	APIKeyScopeTransfer:     AdminPermTransfer,
+}
+
+// The long-lived API key for server-to-server integrations
+//
+// Only the hash of key is stored, the plain key is shown once after created.
+type APIKey struct {
+	gorm.Model
+	Name       string `gorm:"size:255"`
+	KeyHash    string `gorm:"uniqueIndex;size:64"`
+	KeyPrefix  string `gorm:"size:16"` // The beginning of key for identification
+	Scopes     string // Comma separated
+	RateLimit  int    // Max requests per minute, 0 for unlimited
+	UserID     uint   `gorm:"index"` // The admin who created the key
+	LastUsedAt *time.Time
+}
+
+func (k APIKey) IsEmpty() bool {
+	return k.ID == 0
+}
+
+func (k APIKey) GetScopes() []string {
+	return lo.Compact(strings.Split(k.Scopes, ","))
+}
+
+func (k APIKey) HasScope(scope string) bool {
+	return lo.Contains(k.GetScopes(), scope)
+}
+
+// Check if the key is granted the admin permission by its scopes
+func (k APIKey) HasAdminPerm(perm AdminPerm) bool {
+	for _, scope := range k.GetScopes() {
+		if p, ok := apiKeyScopePerms[scope]; ok && p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// The virtual admin user of key, which is used in the admin handlers
+// (the permissions are limited by the scopes, not the role)
+func (k APIKey) AdminUser() User {
+	return User{Name: k.Name, IsAdmin: true, AdminRole: AdminRoleSuper}
+}
+
+func IsValidAPIKeyScope(scope string) bool {
+	return lo.Contains(APIKeyScopes, scope)
+}
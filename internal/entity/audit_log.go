@@ -0,0 +1,31 @@
+package entity
+
+import "gorm.io/gorm"
+
+// The actor types of audit log
+const (
+	AuditActorUser     = "user"     // The admin user
+	AuditActorAPIKey   = "api_key"  // The API key (ActorID is the key ID)
+	AuditActorTelegram = "telegram" // The Telegram user who moderates by the notification buttons (ActorName is the Telegram user)
+)
+
+// The record of an admin action, which is append-only (never updated or deleted by the API)
+type AuditLog struct {
+	gorm.Model
+
+	ActorType string `gorm:"size:32"`
+	ActorID   uint   `gorm:"index"`
+	ActorName string `gorm:"size:255"`
+	IP        string `gorm:"size:255"`
+
+	Action string `gorm:"size:255;index"` // The action name (e.g. "comment.approve"), or the route (e.g. "PUT /sites/:id") if not named
+	Method string `gorm:"size:16"`
+	Path   string // The request path
+
+	TargetType string `gorm:"size:64;index:idx_audit_logs_target"` // The type of target (e.g. "comment")
+	TargetID   uint   `gorm:"index:idx_audit_logs_target"`
+	SiteName   string `gorm:"size:255;index"`
+
+	Before string // The target before the action (JSON)
+	After  string // The target after the action (JSON)
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type CookedAuditLog struct {
+	ID         uint      `json:"id"`
+	ActorType  string    `json:"actor_type"`
+	ActorID    uint      `json:"actor_id"`
+	ActorName  string    `json:"actor_name"`
+	IP         string    `json:"ip"`
+	Action     string    `json:"action"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	TargetType string    `json:"target_type"`
+	TargetID   uint      `json:"target_id"`
+	SiteName   string    `json:"site_name"`
+	Before     any       `json:"before"` // The JSON value, null if none
+	After      any       `json:"after"`  // The JSON value, null if none
+	CreatedAt  time.Time `json:"created_at"`
+}
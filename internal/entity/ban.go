@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The ban actions
+const (
+	BanActionBlock   = "block"   // Reject the new comments
+	BanActionPending = "pending" // Hold the comments for manual review
+)
+
+// The ban rule of IP, CIDR range, email domain or ASN
+type Ban struct {
+	gorm.Model
+	Type      string `gorm:"index;size:32"` // "ip", "cidr", "email_domain" or "asn"
+	Value     string `gorm:"size:255"`      // The normalized value (e.g. "192.0.2.0/24", "example.com", "13335")
+	Action    string `gorm:"size:32"`       // "block" or "pending"
+	Reason    string
+	SiteName  string     `gorm:"index;size:255"` // The site which the ban applies to (empty for all sites)
+	ExpiresAt *time.Time `gorm:"index"`          // Never expires if nil
+	UserID    uint       // The admin who created the ban
+}
+
+func (b Ban) IsEmpty() bool {
+	return b.ID == 0
+}
+
+func (b Ban) IsExpired() bool {
+	return b.ExpiresAt != nil && b.ExpiresAt.Before(time.Now())
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type CookedBan struct {
+	ID        uint       `json:"id"`
+	Type      string     `json:"type"`
+	Value     string     `json:"value"`
+	Action    string     `json:"action"`
+	Reason    string     `json:"reason"`
+	SiteName  string     `json:"site_name"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	IsExpired bool       `json:"is_expired"`
+	UserID    uint       `json:"user_id"`
+	CreatedAt time.Time  `json:"created_at"`
+}
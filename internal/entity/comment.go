@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"math"
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -16,6 +19,7 @@ type Comment struct {
 	IsVerified bool `gorm:"default:false"`
 	UA         string
 	IP         string
+	IPRegion   string `gorm:"size:255"` // The geolocation of IP resolved when created (encoded by `ip_region.Region`)
 
 	Rid uint `gorm:"index"` // Parent Node ID
 
@@ -25,9 +29,19 @@ type Comment struct {
 
 	VoteUp   int
 	VoteDown int
+	HotScore float64 `gorm:"index"` // The score for sorting by hot (updated when voted)
+
+	ReactionCount int // The total number of reactions
 
 	RootID uint `gorm:"index"` // Root Node ID (can be derived from `Rid`)
 
+	EditedAt *time.Time // The last time of the content edited (the previous versions are stored as `CommentRevision`)
+
+	// Moderation result by the anti-spam checker which blocked the comment
+	ModerationChecker    string `gorm:"size:255"`
+	ModerationReason     string
+	ModerationConfidence float64
+
 	// Associated Page
 	//
 	// Use Composite Foreign Keys for multiple-site support.
@@ -44,3 +58,27 @@ func (c Comment) IsEmpty() bool {
 func (c Comment) IsAllowReply() bool {
 	return !c.IsCollapsed && !c.IsPending
 }
+
+// Get the hot score with time decay (the ranking algorithm of Reddit)
+//
+// The score grows logarithmically with the votes and linearly with the created time,
+// so a comment 12.5 hours older needs 10 times more votes to rank as high.
+func (c Comment) GetHotScore() float64 {
+	score := float64(c.VoteUp - c.VoteDown)
+	order := math.Log10(math.Max(math.Abs(score), 1))
+
+	sign := 0.0
+	if score > 0 {
+		sign = 1
+	} else if score < 0 {
+		sign = -1
+	}
+
+	createdAt := c.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now() // not created yet
+	}
+	seconds := float64(createdAt.Unix() - 1134028003)
+
+	return math.Round((sign*order+seconds/45000)*1e7) / 1e7
+}
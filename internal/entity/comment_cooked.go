@@ -1,29 +1,46 @@
 package entity
 
 type CookedComment struct {
-	ID             uint   `json:"id"`
-	Content        string `json:"content"`
-	ContentMarked  string `json:"content_marked"`
-	UserID         uint   `json:"user_id"`
-	Nick           string `json:"nick"`
-	EmailEncrypted string `json:"email_encrypted"`
-	Link           string `json:"link"`
-	UA             string `json:"ua"`
-	Date           string `json:"date"`
-	IsCollapsed    bool   `json:"is_collapsed"`
-	IsPending      bool   `json:"is_pending"`
-	IsPinned       bool   `json:"is_pinned"`
-	IsAllowReply   bool   `json:"is_allow_reply"`
-	IsVerified     bool   `json:"is_verified"`
-	Rid            uint   `json:"rid"`
-	BadgeName      string `json:"badge_name"`
-	BadgeColor     string `json:"badge_color"`
-	IP             string `json:"-"`
-	IPRegion       string `json:"ip_region,omitempty"`
-	Visible        bool   `json:"visible"`
-	VoteUp         int    `json:"vote_up"`
-	VoteDown       int    `json:"vote_down"`
-	PageKey        string `json:"page_key"`
-	PageURL        string `json:"page_url"`
-	SiteName       string `json:"site_name"`
+	ID              uint   `json:"id"`
+	Content         string `json:"content"`
+	ContentMarked   string `json:"content_marked"`
+	UserID          uint   `json:"user_id"`
+	Nick            string `json:"nick"`
+	EmailEncrypted  string `json:"email_encrypted"`
+	Avatar          string `json:"avatar,omitempty"` // The custom avatar URL of user (gravatar is used if empty)
+	Link            string `json:"link"`
+	UA              string `json:"ua"`
+	Date            string `json:"date"`
+	EditedAt        string `json:"edited_at,omitempty"` // The last time of the content edited (empty if not edited)
+	IsCollapsed     bool   `json:"is_collapsed"`
+	IsPending       bool   `json:"is_pending"`
+	IsPinned        bool   `json:"is_pinned"`
+	IsAllowReply    bool   `json:"is_allow_reply"`
+	IsVerified      bool   `json:"is_verified"`
+	AuthProvider    string `json:"auth_provider,omitempty"` // The social login provider of the verified user (e.g. github, google, oidc)
+	IsEmailVerified bool   `json:"is_email_verified"`       // The email of user is verified by the magic link
+	Rid             uint   `json:"rid"`
+	BadgeName       string `json:"badge_name"`
+	BadgeColor      string `json:"badge_color"`
+	IP              string `json:"-"`
+	IPRegionStored  string `json:"-"` // The stored geolocation of IP, which is formatted to `IPRegion` when displayed
+	IPRegion        string `json:"ip_region,omitempty"`
+	Visible         bool   `json:"visible"`
+	IsDeleted       bool   `json:"is_deleted,omitempty"` // The comment is deleted and kept as a tombstone (the content and user are omitted)
+	VoteUp          int    `json:"vote_up"`
+	VoteDown        int    `json:"vote_down"`
+	PageKey         string `json:"page_key"`
+	PageURL         string `json:"page_url"`
+	SiteName        string `json:"site_name"`
+
+	Reactions    []CookedReaction `json:"reactions,omitempty"`     // The reaction counts (only when the reactions are enabled)
+	RepliesCount int              `json:"replies_count,omitempty"` // The total number of replies (only for the root comments when the replies are partially loaded)
+
+	Moderation *CookedCommentModeration `json:"moderation,omitempty"` // Only visible to admin
+}
+
+type CookedCommentModeration struct {
+	Checker    string  `json:"checker"`
+	Reason     string  `json:"reason"`
+	Confidence float64 `json:"confidence"`
 }
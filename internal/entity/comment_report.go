@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// The reasons of comment report
+const (
+	CommentReportReasonSpam     = "spam"
+	CommentReportReasonAbuse    = "abuse"
+	CommentReportReasonOffTopic = "off_topic"
+	CommentReportReasonOther    = "other"
+)
+
+var CommentReportReasons = []string{CommentReportReasonSpam, CommentReportReasonAbuse, CommentReportReasonOffTopic, CommentReportReasonOther}
+
+// The report of comment by reader, each reader can report a comment once
+//
+// The reports are soft deleted after dismissed by the admin.
+type CommentReport struct {
+	gorm.Model
+
+	CommentID uint   `gorm:"index"` // 被举报的评论
+	SiteName  string `gorm:"index;size:255"`
+	Reason    string `gorm:"size:64"`
+	Detail    string // 举报说明
+
+	UserID uint `gorm:"index"` // 举报者
+	UA     string
+	IP     string
+}
+
+func (r CommentReport) IsEmpty() bool {
+	return r.ID == 0
+}
+
+func IsValidCommentReportReason(reason string) bool {
+	return slices.Contains(CommentReportReasons, reason)
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+// The previous version of an edited comment
+type CommentRevision struct {
+	gorm.Model
+
+	CommentID uint   `gorm:"index"`
+	Content   string // The content before edited
+	EditorID  uint   // The admin who edited the comment
+}
+
+type CookedCommentRevision struct {
+	ID         uint   `json:"id"`
+	CommentID  uint   `json:"comment_id"`
+	Content    string `json:"content"`     // The content before edited
+	EditorID   uint   `json:"editor_id"`   // The admin who edited the comment
+	EditorName string `json:"editor_name"` // The name of editor
+	Date       string `json:"date"`        // The time of edited
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "gorm.io/gorm"
+
+// The email address which should not be sent to, reported by the bounce or complaint webhook of email service provider
+type EmailSuppression struct {
+	gorm.Model
+	Email    string `gorm:"uniqueIndex;size:255"` // In lowercase
+	Reason   string `gorm:"size:32"`              // "bounce" or "complaint"
+	Provider string `gorm:"size:32"`              // The email service provider which reports it
+	Detail   string
+}
+
+func (s EmailSuppression) IsEmpty() bool {
+	return s.ID == 0
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type CookedEmailSuppression struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	Provider  string    `json:"provider"`
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+}
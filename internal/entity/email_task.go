@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The status of outgoing email
+const (
+	EmailTaskStatusPending = "pending" // Waiting to be sent (or retried after NextAttemptAt)
+	EmailTaskStatusSending = "sending" // In the sending queue
+	EmailTaskStatusSent    = "sent"
+	EmailTaskStatusDead    = "dead" // Still failed after all the retries (the dead letter)
+)
+
+// The outgoing email in the sending queue, it is persisted so the emails are not lost
+// when the queue is full or the server restarts
+type EmailTask struct {
+	gorm.Model
+	Status          string `gorm:"index;size:16"`
+	FromAddr        string
+	FromName        string
+	ToAddr          string `gorm:"index;size:255"`
+	Subject         string
+	Body            string
+	UnsubscribeLink string
+	NotifyIDs       string // The IDs of the linked notifies separated by comma, which are flagged as emailed after sent
+
+	Attempts      int
+	Error         string     // The error of last attempt
+	NextAttemptAt *time.Time `gorm:"index"`
+	SentAt        *time.Time
+}
+
+func (t EmailTask) IsEmpty() bool {
+	return t.ID == 0
+}
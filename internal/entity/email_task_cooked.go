@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type CookedEmailTask struct {
+	ID            uint       `json:"id"`
+	Status        string     `json:"status"`
+	ToAddr        string     `json:"to_addr"`
+	Subject       string     `json:"subject"`
+	Attempts      int        `json:"attempts"`
+	Error         string     `json:"error"`
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
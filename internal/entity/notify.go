@@ -18,9 +18,19 @@ type Notify struct {
 	IsEmailed bool
 	EmailAt   *time.Time
 
+	IsDigestPending bool `gorm:"index;default:false"` // 等待合并到摘要邮件中发送
+
 	Key string `gorm:"index;size:255"`
 }
 
+// The event types of notify
+const (
+	NotifyEventComment = "comment" // New comment (sent to the admins)
+	NotifyEventReply   = "reply"   // Reply to the comment of user
+	NotifyEventMention = "mention" // The user is mentioned in the comment
+	NotifyEventPending = "pending" // New comment pending review (sent to the admins)
+)
+
 func (n Notify) IsEmpty() bool {
 	return n.ID == 0
 }
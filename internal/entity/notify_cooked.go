@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type CookedNotify struct {
 	ID        uint   `json:"id"`
 	UserID    uint   `json:"user_id"`
@@ -8,3 +10,11 @@ type CookedNotify struct {
 	IsEmailed bool   `json:"is_emailed"`
 	ReadLink  string `json:"read_link"`
 }
+
+// The notify in the notification center of user
+type CookedNotifyForInbox struct {
+	CookedNotify
+	Event     string        `json:"event" enums:"comment,reply,mention,pending"` // The event type of notify
+	Comment   CookedComment `json:"comment"`                                     // The comment to be viewed
+	CreatedAt time.Time     `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "gorm.io/gorm"
+
+// The notification template edited in the dashboard,
+// which takes precedence over the template files in `notify_templates.dir`
+type NotifyTemplate struct {
+	gorm.Model
+	Channel string `gorm:"size:32;uniqueIndex:idx_notify_template"` // The notify channel (e.g. email, telegram)
+	Event   string `gorm:"size:32;uniqueIndex:idx_notify_template"` // The event type (e.g. reply, mention)
+	Locale  string `gorm:"size:16;uniqueIndex:idx_notify_template"` // Empty for all languages
+	Content string `gorm:"type:text"`
+}
+
+func (t NotifyTemplate) IsEmpty() bool {
+	return t.ID == 0
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type CookedNotifyTemplate struct {
+	ID        uint      `json:"id"`
+	Channel   string    `json:"channel"`
+	Event     string    `json:"event"`
+	Locale    string    `json:"locale"`
+	Content   string    `json:"content"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -1,9 +1,18 @@
 package entity
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
+// The comment status of page, which overrides the scheduled comment closing
+const (
+	PageCommentStatusAuto   = ""       // follow the `comment_close` config
+	PageCommentStatusOpen   = "open"   // always open, never closed automatically
+	PageCommentStatusClosed = "closed" // closed immediately
+)
+
 type Page struct {
 	gorm.Model
 
@@ -30,9 +39,16 @@ type Page struct {
 
 	PV int
 
+	CommentStatus string     `gorm:"size:20"` // The comment status override (see the `PageCommentStatus*` constants)
+	PublishedAt   *time.Time // The published date of page content, the age of page is counted from it (or the created date if nil)
+
 	Site *Site `gorm:"foreignKey:site_name;references:name"`
 }
 
 func (p Page) IsEmpty() bool {
 	return p.ID == 0
 }
+
+func IsValidPageCommentStatus(status string) bool {
+	return status == PageCommentStatusAuto || status == PageCommentStatusOpen || status == PageCommentStatusClosed
+}
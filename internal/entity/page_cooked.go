@@ -11,4 +11,9 @@ type CookedPage struct {
 	VoteDown  int    `json:"vote_down"`
 	PV        int    `json:"pv"`
 	Date      string `json:"date"`
+
+	CommentStatus string `json:"comment_status"`      // The comment status override ("", "open" or "closed")
+	PublishedAt   string `json:"published_at"`        // The published date of page content
+	IsClosed      bool   `json:"is_closed"`           // Whether the comments of page are closed
+	ClosesAt      string `json:"closes_at,omitempty"` // The scheduled date to close the comments
 }
@@ -0,0 +1,17 @@
+package entity
+
+import "gorm.io/gorm"
+
+// The Web Push subscription of a browser, the replies and the mentions are pushed to it
+type PushSubscription struct {
+	gorm.Model
+	UserID   uint   `gorm:"index"`
+	Endpoint string `gorm:"size:1024"` // The URL of push service (contains the token of subscription, should be kept secret)
+	P256DH   string `gorm:"size:255"`  // The ECDH public key of the browser (Base64URL encoded)
+	Auth     string `gorm:"size:255"`  // The authentication secret (Base64URL encoded)
+	UA       string
+}
+
+func (s PushSubscription) IsEmpty() bool {
+	return s.ID == 0
+}
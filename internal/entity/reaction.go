@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+type Reaction struct {
+	gorm.Model
+
+	CommentID uint   `gorm:"index"` // 回应的评论
+	Emoji     string `gorm:"index;size:64"`
+
+	UserID uint `gorm:"index"` // 回应者
+	UA     string
+	IP     string
+}
+
+func (r *Reaction) IsEmpty() bool {
+	return r.ID == 0
+}
+
+type CookedReaction struct {
+	Emoji     string `json:"emoji"`
+	Count     int    `json:"count"`
+	IsReacted bool   `json:"is_reacted"` // The current visitor has reacted with the emoji
+}
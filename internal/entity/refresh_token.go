@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The refresh token of a login session
+//
+// The refresh token is rotated on every use. All the tokens issued from the same login
+// share the family ID, which is also the `jti` of the access tokens. Once a rotated token
+// is reused, the whole family is revoked since the token may be leaked.
+type RefreshToken struct {
+	gorm.Model
+	UserID       uint   `gorm:"index"`
+	FamilyID     string `gorm:"index;size:64"`
+	TokenHash    string `gorm:"uniqueIndex;size:64"`
+	TOTPVerified bool   // The login is passed the two-factor authentication
+	ExpiresAt    time.Time
+	RevokedAt    *time.Time
+}
+
+func (t RefreshToken) IsEmpty() bool {
+	return t.ID == 0
+}
+
+func (t RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func (t RefreshToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
@@ -8,6 +8,24 @@ type Site struct {
 	gorm.Model
 	Name string `gorm:"uniqueIndex;size:255"`
 	Urls string
+
+	// The anti-spam config overrides of site (JSON object, merged over the global `moderator` config)
+	ModeratorConf string
+
+	// The comment limit config overrides of site (JSON object, merged over the global `comment_limit` config)
+	CommentLimitConf string
+
+	// The comment close config overrides of site (JSON object, merged over the global `comment_close` config)
+	CommentCloseConf string
+
+	// The upload policy config overrides of site (JSON object, merged over the global `img_upload.policy` config)
+	UploadPolicyConf string
+
+	// Only the login users are allowed to comment
+	AuthRequired bool `gorm:"default:false"`
+
+	// Allow to comment without name and email, a pseudonymous identity is generated for the visitor
+	AnonymousMode bool `gorm:"default:false"`
 }
 
 func (s Site) IsEmpty() bool {
@@ -6,4 +6,7 @@ type CookedSite struct {
 	Urls     []string `json:"urls"`
 	UrlsRaw  string   `json:"urls_raw"`
 	FirstUrl string   `json:"first_url"`
+
+	AuthRequired  bool `json:"auth_required"`
+	AnonymousMode bool `json:"anonymous_mode"`
 }
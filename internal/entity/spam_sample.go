@@ -0,0 +1,18 @@
+package entity
+
+import "gorm.io/gorm"
+
+// The sample of spam or ham comment decided by the moderator,
+// which is used to train the anti-spam checkers.
+type SpamSample struct {
+	gorm.Model
+
+	CommentID uint   `gorm:"index"`
+	SiteName  string `gorm:"index;size:255"`
+	Content   string
+	IsSpam    bool `gorm:"index"`
+}
+
+func (s SpamSample) IsEmpty() bool {
+	return s.ID == 0
+}
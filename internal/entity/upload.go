@@ -0,0 +1,19 @@
+package entity
+
+import "gorm.io/gorm"
+
+// The record of uploaded file, which is counted for the daily upload quota
+type Upload struct {
+	gorm.Model
+	UserID   uint   `gorm:"index"` // 0 for the guest, who is counted by the IP
+	IP       string `gorm:"index;size:255"`
+	SiteName string `gorm:"index;size:255"`
+	FileName string `gorm:"size:255"`
+	FileType string `gorm:"size:64"` // The MIME type (e.g. "image/png")
+	FileSize int64  // In bytes
+	URL      string
+}
+
+func (u Upload) IsEmpty() bool {
+	return u.ID == 0
+}
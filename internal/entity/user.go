@@ -2,6 +2,7 @@ package entity
 
 import (
 	"crypto/md5"
+	"crypto/sha256"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -25,14 +26,88 @@ type User struct {
 	ReceiveEmail   bool `gorm:"default:true"`
 	TokenValidFrom sql.NullTime
 
+	// The provider of the last used social login identity (e.g. github, google, oidc)
+	AuthProvider string `gorm:"size:255"`
+
+	// The custom avatar URL (provided by the single sign-on, gravatar is used if empty)
+	Avatar string
+
+	// The email ownership is verified by the magic link
+	IsEmailVerified bool `gorm:"default:false"`
+
+	// The admin role (see `AdminRole*`, empty for the super admin)
+	AdminRole string `gorm:"size:32"`
+	// The site names which the admin is restricted to (comma separated, empty for all sites)
+	AdminSites string
+
+	// Two-factor authentication (TOTP) for the admin account
+	TOTPSecret      string `gorm:"size:255"`
+	TOTPEnabled     bool   `gorm:"default:false"`
+	TOTPBackupCodes string // The sha256 hashes of the unused backup codes (comma separated)
+	TOTPLastStep    int64  // The time step of the last used code (to prevent the replay)
+
+	// The comments of shadow banned user are only visible to the user self,
+	// and never trigger the notifications
+	IsShadowBanned bool `gorm:"default:false"`
+
+	// The preference of email notifications (see `EmailDigest*`, empty to follow the `email.digest.default` config)
+	EmailDigest string `gorm:"size:16"`
+
+	// The notifications of the replies and the mentions can be turned off separately
+	NotifyReply   bool `gorm:"default:true"`
+	NotifyMention bool `gorm:"default:true"`
+
+	// The hashes of the lowercase email, which are indexed to find the user by the email hash (e.g. the QQ avatar).
+	// They are updated when the user is saved.
+	EmailHash       string `gorm:"index;size:32"` // MD5
+	EmailHashSHA256 string `gorm:"index;size:64"`
+
 	// 配置文件中添加的
 	IsInConf bool
 }
 
+// Keep the email hashes in sync with the email
+func (u *User) BeforeSave(tx *gorm.DB) error {
+	u.EmailHash, u.EmailHashSHA256 = GetEmailHashes(u.Email)
+	return nil
+}
+
+// Get the MD5 and SHA256 hashes of the lowercase email (empty if the email is empty)
+func GetEmailHashes(email string) (md5Hash string, sha256Hash string) {
+	email = strings.ToLower(email)
+	if email == "" {
+		return "", ""
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(email))), fmt.Sprintf("%x", sha256.Sum256([]byte(email)))
+}
+
 func (u User) IsEmpty() bool {
 	return u.ID == 0
 }
 
+// The preferences of email notifications
+const (
+	EmailDigestInstant = "instant" // One email per notification
+	EmailDigestOn      = "digest"  // The notifications are batched into a summary email sent periodically
+)
+
+func IsValidEmailDigest(pref string) bool {
+	return pref == "" || pref == EmailDigestInstant || pref == EmailDigestOn
+}
+
+// Check if the notifications of user are sent in the digest email,
+// defaultOn is used if the user has not set the preference
+func (u User) IsEmailDigest(defaultOn bool) bool {
+	switch u.EmailDigest {
+	case EmailDigestOn:
+		return true
+	case EmailDigestInstant:
+		return false
+	default:
+		return defaultOn
+	}
+}
+
 func (u *User) SetPasswordEncrypt(password string) (err error) {
 	var encrypted []byte
 	if encrypted, err = bcrypt.GenerateFromPassword(
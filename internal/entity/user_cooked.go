@@ -9,4 +9,8 @@ type CookedUser struct {
 	BadgeColor   string `json:"badge_color"`
 	IsAdmin      bool   `json:"is_admin"`
 	ReceiveEmail bool   `json:"receive_email"`
+
+	IsEmailVerified bool   `json:"is_email_verified"`
+	Avatar          string `json:"avatar,omitempty"` // The custom avatar URL
+	EmailDigest     string `json:"email_digest"`     // The preference of email notifications (instant, digest or empty for the default)
 }
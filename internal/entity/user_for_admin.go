@@ -6,4 +6,10 @@ type CookedUserForAdmin struct {
 	LastUA       string `json:"last_ua"`
 	IsInConf     bool   `json:"is_in_conf"`
 	CommentCount int64  `json:"comment_count"`
+
+	IsTOTPEnabled  bool `json:"is_totp_enabled"`
+	IsShadowBanned bool `json:"is_shadow_banned"`
+
+	AdminRole  string   `json:"admin_role"`  // The admin role (empty if not an admin)
+	AdminSites []string `json:"admin_sites"` // The site names which the admin is restricted to (empty for all sites)
 }
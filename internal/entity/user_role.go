@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// The admin roles
+//
+// The empty role is treated as the super admin for compatibility with the existing admins.
+const (
+	AdminRoleSuper     = "super_admin" // Full control, including the users, sites and system settings
+	AdminRoleSiteAdmin = "site_admin"  // Manage the comments, pages and settings of the assigned sites
+	AdminRoleModerator = "moderator"   // Moderate the comments of the assigned sites
+	AdminRoleReadOnly  = "read_only"   // Only view the comments and pages of the assigned sites
+)
+
+// The admin permission
+type AdminPerm string
+
+const (
+	AdminPermRead     AdminPerm = "read"     // View the comments (include pending), pages and sites
+	AdminPermModerate AdminPerm = "moderate" // Approve, edit and delete the comments
+	AdminPermManage   AdminPerm = "manage"   // Manage the pages and site settings
+	AdminPermTransfer AdminPerm = "transfer" // Import and export the data
+	AdminPermSuper    AdminPerm = "super"    // Manage the users, sites and system settings
+)
+
+var adminRolePerms = map[string][]AdminPerm{
+	AdminRoleSuper:     {AdminPermRead, AdminPermModerate, AdminPermManage, AdminPermTransfer, AdminPermSuper},
+	AdminRoleSiteAdmin: {AdminPermRead, AdminPermModerate, AdminPermManage},
+	AdminRoleModerator: {AdminPermRead, AdminPermModerate},
+	AdminRoleReadOnly:  {AdminPermRead},
+}
+
+func IsValidAdminRole(role string) bool {
+	_, ok := adminRolePerms[role]
+	return role == "" || ok
+}
+
+// Get the admin role of user, the super admin is returned if not specified
+func (u User) GetAdminRole() string {
+	if !u.IsAdmin {
+		return ""
+	}
+	if _, ok := adminRolePerms[u.AdminRole]; !ok {
+		return AdminRoleSuper
+	}
+	return u.AdminRole
+}
+
+func (u User) HasAdminPerm(perm AdminPerm) bool {
+	return lo.Contains(adminRolePerms[u.GetAdminRole()], perm)
+}
+
+// Get the site names which the admin is restricted to,
+// nil is returned if the admin is allowed to manage all sites
+func (u User) GetAdminSites() []string {
+	if u.GetAdminRole() == AdminRoleSuper {
+		return nil
+	}
+	sites := lo.Compact(lo.Map(strings.Split(u.AdminSites, ","), func(s string, _ int) string {
+		return strings.TrimSpace(s)
+	}))
+	if len(sites) == 0 {
+		return nil
+	}
+	return sites
+}
+
+func (u *User) SetAdminSites(sites []string) {
+	u.AdminSites = strings.Join(lo.Uniq(lo.Compact(lo.Map(sites, func(s string, _ int) string {
+		return strings.TrimSpace(s)
+	}))), ",")
+}
+
+// Check if the admin is allowed to manage the site
+func (u User) CanAdminSite(siteName string) bool {
+	if !u.IsAdmin {
+		return false
+	}
+	sites := u.GetAdminSites()
+	return sites == nil || lo.Contains(sites, siteName)
+}
@@ -1,5 +1,17 @@
 package entity
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Hash the secret token (e.g. API key, refresh token) for storing,
+// the tokens are random enough so the salt is not needed
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 func ContainsComment(comments []Comment, targetID uint) bool {
 	for _, c := range comments {
 		if c.ID == targetID {
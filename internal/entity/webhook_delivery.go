@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The delivery log of webhook event
+type WebhookDelivery struct {
+	gorm.Model
+	Event       string `gorm:"index;size:255"`
+	URL         string
+	Payload     string // The request body in JSON
+	StatusCode  int
+	Response    string // The truncated response body
+	Error       string
+	Attempts    int
+	IsSuccess   bool `gorm:"index"`
+	DurationMs  int64
+	DeliveredAt *time.Time // Empty if the delivery is still in the queue
+}
+
+func (d WebhookDelivery) IsEmpty() bool {
+	return d.ID == 0
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type CookedWebhookDelivery struct {
+	ID          uint       `json:"id"`
+	Event       string     `json:"event"`
+	URL         string     `json:"url"`
+	Payload     string     `json:"payload"`
+	StatusCode  int        `json:"status_code"`
+	Response    string     `json:"response"`
+	Error       string     `json:"error"`
+	Attempts    int        `json:"attempts"`
+	IsSuccess   bool       `json:"is_success"`
+	DurationMs  int64      `json:"duration_ms"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+}
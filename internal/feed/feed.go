@@ -0,0 +1,211 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// 订阅源格式
+const (
+	FormatRSS  = "rss"  // RSS 2.0
+	FormatAtom = "atom" // Atom 1.0 (RFC 4287)
+)
+
+type Feed struct {
+	Title       string
+	Link        string // 订阅源对应的网页链接
+	SelfLink    string // 订阅源自身的链接
+	Description string
+	Updated     time.Time
+	Items       []Item
+}
+
+type Item struct {
+	ID        string // 唯一标识 (为空时使用 Link)
+	Title     string
+	Link      string
+	Author    string
+	Content   string // HTML 内容
+	Published time.Time
+}
+
+// 获取订阅源格式的 Content-Type (格式无效时返回空)
+func ContentType(format string) string {
+	switch format {
+	case FormatRSS:
+		return "application/rss+xml; charset=utf-8"
+	case FormatAtom:
+		return "application/atom+xml; charset=utf-8"
+	}
+	return ""
+}
+
+// 按格式生成订阅源 XML
+func Render(f Feed, format string) ([]byte, error) {
+	var v any
+	if format == FormatAtom {
+		v = toAtom(f)
+	} else {
+		v = toRSS(f)
+	}
+
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// ===============
+//  RSS 2.0
+// ===============
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	SelfLink      *atomLink `xml:"atom:link,omitempty"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	Author      string  `xml:"dc:creator,omitempty"`
+	Description cdata   `xml:"description"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+func toRSS(f Feed) rss {
+	channel := rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+	}
+	if f.SelfLink != "" {
+		channel.SelfLink = &atomLink{Href: f.SelfLink, Rel: "self", Type: "application/rss+xml"}
+	}
+	if !f.Updated.IsZero() {
+		channel.LastBuildDate = f.Updated.Format(time.RFC1123Z)
+	}
+
+	for _, item := range f.Items {
+		guid := rssGUID{Value: item.ID}
+		if guid.Value == "" {
+			guid = rssGUID{Value: item.Link, IsPermaLink: true}
+		}
+		channel.Items = append(channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        guid,
+			Author:      item.Author,
+			Description: cdata{item.Content},
+			PubDate:     item.Published.Format(time.RFC1123Z),
+		})
+	}
+
+	return rss{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: channel,
+	}
+}
+
+// ===============
+//  Atom 1.0
+// ===============
+
+type atom struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	ID       string      `xml:"id"`
+	Links    []atomLink  `xml:"link"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Link      *atomLink   `xml:"link,omitempty"`
+	Author    *atomAuthor `xml:"author,omitempty"`
+	Content   atomContent `xml:"content"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func toAtom(f Feed) atom {
+	feed := atom{
+		Title:    f.Title,
+		ID:       f.SelfLink,
+		Subtitle: f.Description,
+		Updated:  f.Updated.Format(time.RFC3339),
+	}
+	if feed.ID == "" {
+		feed.ID = f.Link
+	}
+	if f.Link != "" {
+		feed.Links = append(feed.Links, atomLink{Href: f.Link, Rel: "alternate", Type: "text/html"})
+	}
+	if f.SelfLink != "" {
+		feed.Links = append(feed.Links, atomLink{Href: f.SelfLink, Rel: "self", Type: "application/atom+xml"})
+	}
+
+	for _, item := range f.Items {
+		entry := atomEntry{
+			Title:     item.Title,
+			ID:        item.ID,
+			Content:   atomContent{Type: "html", Value: item.Content},
+			Published: item.Published.Format(time.RFC3339),
+			Updated:   item.Published.Format(time.RFC3339),
+		}
+		if entry.ID == "" {
+			entry.ID = item.Link
+		}
+		if item.Link != "" {
+			entry.Link = &atomLink{Href: item.Link, Rel: "alternate", Type: "text/html"}
+		}
+		if item.Author != "" {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
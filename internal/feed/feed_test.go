@@ -0,0 +1,87 @@
+package feed
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	published := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	f := Feed{
+		Title:    "Comments on Test",
+		Link:     "https://example.com/test.html",
+		SelfLink: "https://artalk.example.com/api/v2/feeds/comments?page_key=/test.html",
+		Updated:  published,
+		Items: []Item{{
+			Title:     "userA on Test",
+			Link:      "https://example.com/test.html?atk_comment=1",
+			Author:    "userA",
+			Content:   "<p>Hello & <b>world</b></p>",
+			Published: published,
+		}},
+	}
+
+	t.Run("RSS", func(t *testing.T) {
+		body, err := Render(f, FormatRSS)
+		assert.NoError(t, err)
+
+		var v struct {
+			Channel struct {
+				Title string `xml:"title"`
+				Items []struct {
+					GUID        string `xml:"guid"`
+					Creator     string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+					Description string `xml:"description"`
+					PubDate     string `xml:"pubDate"`
+				} `xml:"item"`
+			} `xml:"channel"`
+		}
+		assert.NoError(t, xml.Unmarshal(body, &v), "should be valid XML")
+		assert.Equal(t, f.Title, v.Channel.Title)
+		if assert.Len(t, v.Channel.Items, 1) {
+			item := v.Channel.Items[0]
+			assert.Equal(t, f.Items[0].Link, item.GUID, "should use the link as guid if the ID is empty")
+			assert.Equal(t, "userA", item.Creator)
+			assert.Equal(t, f.Items[0].Content, item.Description)
+			assert.Equal(t, "Tue, 02 Jan 2024 03:04:05 +0000", item.PubDate)
+		}
+	})
+
+	t.Run("Atom", func(t *testing.T) {
+		body, err := Render(f, FormatAtom)
+		assert.NoError(t, err)
+
+		var v struct {
+			XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+			ID      string   `xml:"id"`
+			Entries []struct {
+				ID      string `xml:"id"`
+				Author  string `xml:"author>name"`
+				Content struct {
+					Type  string `xml:"type,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"content"`
+				Published string `xml:"published"`
+			} `xml:"entry"`
+		}
+		assert.NoError(t, xml.Unmarshal(body, &v), "should be valid Atom")
+		assert.Equal(t, f.SelfLink, v.ID)
+		if assert.Len(t, v.Entries, 1) {
+			entry := v.Entries[0]
+			assert.Equal(t, f.Items[0].Link, entry.ID)
+			assert.Equal(t, "userA", entry.Author)
+			assert.Equal(t, "html", entry.Content.Type)
+			assert.Equal(t, f.Items[0].Content, entry.Content.Value)
+			assert.Equal(t, "2024-01-02T03:04:05Z", entry.Published)
+		}
+	})
+}
+
+func TestContentType(t *testing.T) {
+	assert.Contains(t, ContentType(FormatRSS), "application/rss+xml")
+	assert.Contains(t, ContentType(FormatAtom), "application/atom+xml")
+	assert.Empty(t, ContentType("json"))
+}
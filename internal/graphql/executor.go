@@ -0,0 +1,421 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Execute the query request, the errors of resolvers are collected in the response
+// with the partial data, and the invalid query is rejected without any data.
+func (s *Schema) Execute(req Request) *Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	op, err := getOperation(doc, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	variables, err := getVariables(op, req.Variables)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	e := &executor{
+		schema:    s,
+		doc:       doc,
+		src:       req.Query,
+		variables: variables,
+		maxDepth:  s.MaxDepth,
+		maxCost:   s.MaxCost,
+	}
+	if e.maxDepth <= 0 {
+		e.maxDepth = DefaultMaxDepth
+	}
+	if e.maxCost <= 0 {
+		e.maxCost = DefaultMaxCost
+	}
+
+	// Validate the whole query before any resolver is called
+	if _, err := e.validate(s.Query, op.selection, map[string]bool{}, 1); err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	data, err := e.executeSelection(s.Query, op.selection, nil, nil)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func toError(err error) *Error {
+	var gqlErr *Error
+	if errors.As(err, &gqlErr) {
+		return gqlErr
+	}
+	return &Error{Message: err.Error()}
+}
+
+func getOperation(doc *document, name string) (*operation, error) {
+	var op *operation
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, newError("Must provide operation name if query contains multiple operations.")
+		}
+		op = doc.operations[0]
+	} else {
+		for _, o := range doc.operations {
+			if o.name == name {
+				op = o
+			}
+		}
+		if op == nil {
+			return nil, newError("Unknown operation named %q.", name)
+		}
+	}
+	if op.kind != "query" {
+		return nil, newError("The %s operation is not supported.", op.kind)
+	}
+	return op, nil
+}
+
+func getVariables(op *operation, input map[string]any) (map[string]any, error) {
+	variables := map[string]any{}
+	for _, def := range op.variables {
+		if v, ok := input[def.name]; ok && v != nil {
+			variables[def.name] = v
+		} else if def.defaultValue.kind != "" {
+			variables[def.name] = resolveValue(def.defaultValue, nil)
+		} else if def.nonNull {
+			return nil, newError("Variable \"$%s\" of required type was not provided.", def.name)
+		}
+	}
+	return variables, nil
+}
+
+func resolveValue(v value, variables map[string]any) any {
+	switch v.kind {
+	case "variable":
+		return variables[v.variable]
+	case "list":
+		list := make([]any, len(v.list))
+		for i, item := range v.list {
+			list[i] = resolveValue(item, variables)
+		}
+		return list
+	case "object":
+		obj := map[string]any{}
+		for k, item := range v.object {
+			obj[k] = resolveValue(item, variables)
+		}
+		return obj
+	}
+	return v.literal
+}
+
+type executor struct {
+	schema    *Schema
+	doc       *document
+	src       string
+	variables map[string]any
+	maxDepth  int
+	maxCost   int
+	errors    []*Error
+}
+
+// The fields with the same response key are merged
+type collectedField struct {
+	key       string
+	name      string
+	args      map[string]value
+	selection []selection
+	pos       int
+	fragments map[string]bool // The fragments spread to the field, for detecting the cycles
+}
+
+// Validate the fields, arguments and fragments of selections by the schema,
+// and get the cost of selections (the query exceeding the max cost is rejected)
+func (e *executor) validate(obj *Object, selections []selection, visited map[string]bool, depth int) (int, error) {
+	if depth > e.maxDepth {
+		return 0, newError("The query exceeds the max depth %d.", e.maxDepth)
+	}
+
+	fields, err := e.collectFields(obj, selections, visited)
+	if err != nil {
+		return 0, err
+	}
+
+	cost := 0
+	for _, f := range fields {
+		if f.name == "__typename" {
+			continue
+		}
+
+		field, ok := obj.Fields[f.name]
+		if !ok {
+			return 0, e.errorAt(f.pos, "Cannot query field %q on type %q.", f.name, obj.Name)
+		}
+		for name := range f.args {
+			if !containsString(field.Args, name) {
+				return 0, e.errorAt(f.pos, "Unknown argument %q on field \"%s.%s\".", name, obj.Name, f.name)
+			}
+		}
+
+		cost++
+		if field.Type == nil {
+			if len(f.selection) > 0 {
+				return 0, e.errorAt(f.pos, "Field %q must not have a selection since it is a scalar.", f.name)
+			}
+		} else {
+			if len(f.selection) == 0 {
+				return 0, e.errorAt(f.pos, "Field %q of type %q must have a selection of subfields.", f.name, field.Type.Name)
+			}
+			subCost, err := e.validate(field.Type, f.selection, f.fragments, depth+1)
+			if err != nil {
+				return 0, err
+			}
+			cost += subCost * e.listSize(field, f)
+		}
+
+		// checked in each step, so the cost never overflows
+		if cost > e.maxCost {
+			return 0, e.errorAt(f.pos, "The query exceeds the max cost %d.", e.maxCost)
+		}
+	}
+	return cost, nil
+}
+
+// The max number of items of the field for the query cost
+func (e *executor) listSize(field *Field, f *collectedField) int {
+	if !field.List || field.ListSize == nil {
+		return 1
+	}
+	args := Args{}
+	for name, v := range f.args {
+		args[name] = resolveValue(v, e.variables)
+	}
+	return max(field.ListSize(args), 1)
+}
+
+func (e *executor) executeSelection(obj *Object, selections []selection, source any, path []any) (*orderedMap, error) {
+	fields, err := e.collectFields(obj, selections, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &orderedMap{values: map[string]any{}}
+	for _, f := range fields {
+		v, err := e.executeField(obj, f, source, append(path[:len(path):len(path)], f.key))
+		if err != nil {
+			return nil, err
+		}
+		result.set(f.key, v)
+	}
+	return result, nil
+}
+
+func (e *executor) collectFields(obj *Object, selections []selection, visited map[string]bool) ([]*collectedField, error) {
+	var fields []*collectedField
+	index := map[string]*collectedField{}
+
+	add := func(list []*collectedField) {
+		for _, f := range list {
+			if exists, ok := index[f.key]; ok {
+				exists.selection = append(exists.selection, f.selection...)
+				continue
+			}
+			index[f.key] = f
+			fields = append(fields, f)
+		}
+	}
+
+	for _, sel := range selections {
+		if skip, err := e.shouldSkip(sel.directives); err != nil {
+			return nil, err
+		} else if skip {
+			continue
+		}
+
+		switch {
+		case sel.fragmentSpread != "":
+			frag, ok := e.doc.fragments[sel.fragmentSpread]
+			if !ok {
+				return nil, e.errorAt(sel.pos, "Unknown fragment %q.", sel.fragmentSpread)
+			}
+			if visited[frag.name] {
+				return nil, e.errorAt(sel.pos, "Cannot spread fragment %q within itself.", frag.name)
+			}
+			if frag.typeCondition != obj.Name {
+				continue
+			}
+			nested, err := e.collectFields(obj, frag.selection, withVisited(visited, frag.name))
+			if err != nil {
+				return nil, err
+			}
+			add(nested)
+		case sel.inline:
+			if sel.typeCondition != "" && sel.typeCondition != obj.Name {
+				continue
+			}
+			nested, err := e.collectFields(obj, sel.selection, visited)
+			if err != nil {
+				return nil, err
+			}
+			add(nested)
+		default:
+			key := sel.alias
+			if key == "" {
+				key = sel.name
+			}
+			add([]*collectedField{{key: key, name: sel.name, args: sel.args, selection: sel.selection, pos: sel.pos, fragments: visited}})
+		}
+	}
+	return fields, nil
+}
+
+func withVisited(visited map[string]bool, name string) map[string]bool {
+	m := map[string]bool{name: true}
+	for k := range visited {
+		m[k] = true
+	}
+	return m
+}
+
+func (e *executor) shouldSkip(directives []directive) (bool, error) {
+	for _, d := range directives {
+		cond, ok := resolveValue(d.args["if"], e.variables).(bool)
+		switch d.name {
+		case "skip":
+			if !ok {
+				return false, newError("Directive \"@skip\" argument \"if\" must be a boolean.")
+			}
+			if cond {
+				return true, nil
+			}
+		case "include":
+			if !ok {
+				return false, newError("Directive \"@include\" argument \"if\" must be a boolean.")
+			}
+			if !cond {
+				return true, nil
+			}
+		default:
+			return false, newError("Unknown directive \"@%s\".", d.name)
+		}
+	}
+	return false, nil
+}
+
+// Execute the validated field
+func (e *executor) executeField(obj *Object, f *collectedField, source any, path []any) (any, error) {
+	if f.name == "__typename" {
+		return obj.Name, nil
+	}
+
+	field := obj.Fields[f.name]
+	args := Args{}
+	for name, v := range f.args {
+		args[name] = resolveValue(v, e.variables)
+	}
+
+	resolved, err := field.Resolve(ResolveParams{Source: source, Args: args})
+	if err != nil {
+		e.errors = append(e.errors, &Error{Message: err.Error(), Locations: []Location{e.location(f.pos)}, Path: path})
+		return nil, nil
+	}
+	if field.Type == nil || isNil(resolved) {
+		return resolved, nil
+	}
+
+	if !field.List {
+		return e.executeSelection(field.Type, f.selection, resolved, path)
+	}
+
+	rv := reflect.ValueOf(resolved)
+	if rv.Kind() != reflect.Slice {
+		return nil, newError("Field %q expects a list.", f.name)
+	}
+	list := make([]any, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		item, err := e.executeSelection(field.Type, f.selection, rv.Index(i).Interface(), append(path[:len(path):len(path)], i))
+		if err != nil {
+			return nil, err
+		}
+		list[i] = item
+	}
+	return list, nil
+}
+
+func (e *executor) location(pos int) Location {
+	line := strings.Count(e.src[:pos], "\n") + 1
+	return Location{Line: line, Column: pos - strings.LastIndex(e.src[:pos], "\n")}
+}
+
+func (e *executor) errorAt(pos int, format string, a ...any) *Error {
+	err := newError(format, a...)
+	err.Locations = []Location{e.location(pos)}
+	return err
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// The map which keeps the order of fields in JSON
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func (m *orderedMap) set(key string, v any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = v
+}
+
+func (m *orderedMap) Get(key string) any {
+	return m.values[key]
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		buf.Write(key)
+		buf.WriteByte(':')
+		v, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPost struct {
+	ID       int
+	Title    string
+	ParentID int
+}
+
+var testPosts = []testPost{{1, "Hello", 0}, {2, "Re: Hello", 1}, {3, "World", 0}}
+
+func newTestSchema() *Schema {
+	post := &Object{Name: "Post"}
+	post.Fields = map[string]*Field{
+		"id":    {Resolve: func(p ResolveParams) (any, error) { return p.Source.(testPost).ID, nil }},
+		"title": {Resolve: func(p ResolveParams) (any, error) { return p.Source.(testPost).Title, nil }},
+		"parent": {Type: post, Resolve: func(p ResolveParams) (any, error) {
+			for _, item := range testPosts {
+				if item.ID == p.Source.(testPost).ParentID {
+					return item, nil
+				}
+			}
+			return nil, nil
+		}},
+		"error": {Resolve: func(p ResolveParams) (any, error) { return nil, fmt.Errorf("failed") }},
+	}
+
+	return &Schema{
+		MaxDepth: 4,
+		MaxCost:  20,
+		Query: &Object{Name: "Query", Fields: map[string]*Field{
+			"posts": {Type: post, List: true, Args: []string{"limit"}, Resolve: func(p ResolveParams) (any, error) {
+				return testPosts[:min(p.Args.Int("limit", len(testPosts)), len(testPosts))], nil
+			}, ListSize: func(args Args) int { return args.Int("limit", len(testPosts)) }},
+			"post": {Type: post, Args: []string{"id"}, Resolve: func(p ResolveParams) (any, error) {
+				for _, item := range testPosts {
+					if item.ID == p.Args.Int("id", 0) {
+						return item, nil
+					}
+				}
+				return nil, nil
+			}},
+		}},
+	}
+}
+
+func execJSON(t *testing.T, req Request) string {
+	buf, err := json.Marshal(newTestSchema().Execute(req))
+	assert.NoError(t, err)
+	return string(buf)
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name string
+		req  Request
+		want string
+	}{
+		{"Selection", Request{Query: `{ posts(limit: 2) { id title } }`},
+			`{"data":{"posts":[{"id":1,"title":"Hello"},{"id":2,"title":"Re: Hello"}]}}`},
+		{"NestedAndAlias", Request{Query: `query { reply: post(id: 2) { title parent { id, __typename } } missing: post(id: 9) { id } }`},
+			`{"data":{"reply":{"title":"Re: Hello","parent":{"id":1,"__typename":"Post"}},"missing":null}}`},
+		{"Variables", Request{Query: `query Get($id: Int!, $limit: Int = 1) { post(id: $id) { title } posts(limit: $limit) { id } }`, Variables: map[string]any{"id": float64(3)}},
+			`{"data":{"post":{"title":"World"},"posts":[{"id":1}]}}`},
+		{"Fragments", Request{Query: `{ post(id: 2) { ...PostFields ... on Post { parent { id } } } } fragment PostFields on Post { id title }`},
+			`{"data":{"post":{"id":2,"title":"Re: Hello","parent":{"id":1}}}}`},
+		{"Directives", Request{Query: `query ($full: Boolean!) { post(id: 1) { id title @include(if: $full) parent @skip(if: true) { id } } }`, Variables: map[string]any{"full": false}},
+			`{"data":{"post":{"id":1}}}`},
+		{"OperationName", Request{Query: `query A { post(id: 1) { id } } query B { post(id: 3) { id } }`, OperationName: "B"},
+			`{"data":{"post":{"id":3}}}`},
+		{"ResolverError", Request{Query: `{ post(id: 1) { id error } }`},
+			`{"data":{"post":{"id":1,"error":null}},"errors":[{"message":"failed","locations":[{"line":1,"column":20}],"path":["post","error"]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.JSONEq(t, tt.want, execJSON(t, tt.req))
+		})
+	}
+}
+
+func TestExecuteInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"Syntax", `{ posts { id }`, "Syntax Error"},
+		{"UnknownField", `{ posts { email } }`, `Cannot query field "email" on type "Post".`},
+		{"UnknownArgument", `{ posts(offset: 1) { id } }`, `Unknown argument "offset"`},
+		{"ScalarSelection", `{ posts { id { value } } }`, "must not have a selection"},
+		{"MissingSelection", `{ posts }`, "must have a selection of subfields"},
+		{"Mutation", `mutation { posts { id } }`, "mutation operation is not supported"},
+		{"MissingVariable", `query ($id: Int!) { post(id: $id) { id } }`, `Variable "$id" of required type was not provided.`},
+		{"UnknownFragment", `{ posts { ...Missing } }`, `Unknown fragment "Missing".`},
+		{"CyclicFragment", `{ posts { ...A } } fragment A on Post { parent { ...A } }`, "within itself"},
+		{"MaxDepth", `{ post(id: 2) { parent { parent { parent { parent { id } } } } } }`, "max depth"},
+		{"MaxCost", `{ posts(limit: 10) { id title parent { id } } }`, "max cost 20"},
+		{"MaxCostByVariable", `query ($limit: Int = 10) { posts(limit: $limit) { id title parent { id } } }`, "max cost 20"},
+		{"MultipleOperations", `query A { posts { id } } query B { posts { id } }`, "Must provide operation name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := newTestSchema().Execute(Request{Query: tt.query})
+			assert.Nil(t, resp.Data, "should not execute the invalid query")
+			if assert.Len(t, resp.Errors, 1) {
+				assert.Contains(t, resp.Errors[0].Message, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "<EOF>"
+	}
+	return strconv.Quote(t.value)
+}
+
+// Split the source into tokens, the commas and comments are ignored
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' && src[i] != '\r' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "..."):
+			tokens = append(tokens, token{tokenPunct, "...", i})
+			i += 3
+		case strings.ContainsRune("!$()[]{}:=@", rune(c)):
+			tokens = append(tokens, token{tokenPunct, string(c), i})
+			i++
+		case c == '_' || isLetter(c):
+			start := i
+			for i < len(src) && (src[i] == '_' || isLetter(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenName, src[start:i], start})
+		case c == '-' || isDigit(c):
+			t, n, err := lexNumber(src, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+			i = n
+		case c == '"':
+			t, n, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+			i = n
+		default:
+			r, _ := utf8.DecodeRuneInString(src[i:])
+			return nil, syntaxError(src, i, fmt.Sprintf("unexpected character %q", r))
+		}
+	}
+	tokens = append(tokens, token{tokenEOF, "", len(src)})
+	return tokens, nil
+}
+
+func lexNumber(src string, i int) (token, int, error) {
+	start := i
+	kind := tokenInt
+	if src[i] == '-' {
+		i++
+	}
+	digits := func() int {
+		n := 0
+		for i < len(src) && isDigit(src[i]) {
+			i++
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return token{}, 0, syntaxError(src, start, "invalid number")
+	}
+	if i < len(src) && src[i] == '.' {
+		kind = tokenFloat
+		i++
+		if digits() == 0 {
+			return token{}, 0, syntaxError(src, start, "invalid number")
+		}
+	}
+	if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+		kind = tokenFloat
+		i++
+		if i < len(src) && (src[i] == '+' || src[i] == '-') {
+			i++
+		}
+		if digits() == 0 {
+			return token{}, 0, syntaxError(src, start, "invalid number")
+		}
+	}
+	return token{kind, src[start:i], start}, i, nil
+}
+
+func lexString(src string, i int) (token, int, error) {
+	start := i
+
+	// Block string
+	if strings.HasPrefix(src[i:], `"""`) {
+		end := strings.Index(src[i+3:], `"""`)
+		if end == -1 {
+			return token{}, 0, syntaxError(src, start, "unterminated string")
+		}
+		value := strings.TrimSpace(src[i+3 : i+3+end])
+		return token{tokenString, value, start}, i + 3 + end + 3, nil
+	}
+
+	var sb strings.Builder
+	i++
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '"':
+			return token{tokenString, sb.String(), start}, i + 1, nil
+		case c == '\n' || c == '\r':
+			return token{}, 0, syntaxError(src, start, "unterminated string")
+		case c == '\\':
+			if i+1 >= len(src) {
+				return token{}, 0, syntaxError(src, start, "unterminated string")
+			}
+			esc := src[i+1]
+			switch esc {
+			case '"', '\\', '/':
+				sb.WriteByte(esc)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				if i+6 > len(src) {
+					return token{}, 0, syntaxError(src, i, "invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(src[i+2:i+6], 16, 32)
+				if err != nil {
+					return token{}, 0, syntaxError(src, i, "invalid unicode escape")
+				}
+				sb.WriteRune(rune(r))
+				i += 4
+			default:
+				return token{}, 0, syntaxError(src, i, fmt.Sprintf("invalid escape \\%c", esc))
+			}
+			i += 2
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return token{}, 0, syntaxError(src, start, "unterminated string")
+}
+
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+// The syntax error with the line and column of source
+func syntaxError(src string, pos int, msg string) error {
+	line := strings.Count(src[:pos], "\n") + 1
+	col := pos - strings.LastIndex(src[:pos], "\n")
+	return &Error{Message: fmt.Sprintf("Syntax Error: %s", msg), Locations: []Location{{line, col}}}
+}
@@ -0,0 +1,410 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind      string // "query", "mutation" or "subscription"
+	name      string
+	variables []variableDefinition
+	selection []selection
+}
+
+type variableDefinition struct {
+	name         string
+	defaultValue value
+	nonNull      bool
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	selection     []selection
+}
+
+// The selection is one of the field, the fragment spread and the inline fragment
+type selection struct {
+	alias      string
+	name       string
+	args       map[string]value
+	directives []directive
+	selection  []selection
+
+	fragmentSpread string // The name of spread fragment (`...Name`)
+	inline         bool   // The inline fragment (`... on Type { }`)
+	typeCondition  string
+
+	pos int
+}
+
+type directive struct {
+	name string
+	args map[string]value
+}
+
+// The value literal, the variable is resolved at execution
+type value struct {
+	variable string
+	literal  any
+	list     []value
+	object   map[string]value
+	kind     string // "variable", "literal", "list" or "object"
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	i      int
+}
+
+func parse(src string) (*document, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, tokens: tokens}
+
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.peek().kind != tokenEOF {
+		switch t := p.peek(); {
+		case t.kind == tokenPunct && t.value == "{":
+			sel, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selection: sel})
+		case t.kind == tokenName && (t.value == "query" || t.value == "mutation" || t.value == "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case t.kind == tokenName && t.value == "fragment":
+			f, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[f.name]; ok {
+				return nil, &Error{Message: fmt.Sprintf("There can be only one fragment named %q.", f.name)}
+			}
+			doc.fragments[f.name] = f
+		default:
+			return nil, p.unexpected(t)
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, &Error{Message: "Must provide an operation."}
+	}
+	return doc, nil
+}
+
+func (p *parser) peek() token { return p.tokens[p.i] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token) error {
+	return syntaxError(p.src, t.pos, fmt.Sprintf("unexpected %s", t))
+}
+
+func (p *parser) isPunct(v string) bool {
+	t := p.peek()
+	return t.kind == tokenPunct && t.value == v
+}
+
+func (p *parser) expectPunct(v string) error {
+	if t := p.next(); t.kind != tokenPunct || t.value != v {
+		return syntaxError(p.src, t.pos, fmt.Sprintf("expected %q, found %s", v, t))
+	}
+	return nil
+}
+
+func (p *parser) expectName() (string, error) {
+	t := p.next()
+	if t.kind != tokenName {
+		return "", syntaxError(p.src, t.pos, fmt.Sprintf("expected name, found %s", t))
+	}
+	return t.value, nil
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{kind: p.next().value}
+	if p.peek().kind == tokenName {
+		op.name = p.next().value
+	}
+	if p.isPunct("(") {
+		p.next()
+		for !p.isPunct(")") {
+			def, err := p.parseVariableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, def)
+		}
+		p.next()
+	}
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	sel, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selection = sel
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinition() (variableDefinition, error) {
+	var def variableDefinition
+	if err := p.expectPunct("$"); err != nil {
+		return def, err
+	}
+	name, err := p.expectName()
+	if err != nil {
+		return def, err
+	}
+	def.name = name
+	if err := p.expectPunct(":"); err != nil {
+		return def, err
+	}
+	if def.nonNull, err = p.parseType(); err != nil {
+		return def, err
+	}
+	if p.isPunct("=") {
+		p.next()
+		if def.defaultValue, err = p.parseValue(true); err != nil {
+			return def, err
+		}
+	}
+	return def, nil
+}
+
+// Parse the type reference of variable, only the nullability of outer type is returned
+func (p *parser) parseType() (nonNull bool, err error) {
+	if p.isPunct("[") {
+		p.next()
+		if _, err := p.parseType(); err != nil {
+			return false, err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return false, err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return false, err
+	}
+	if p.isPunct("!") {
+		p.next()
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *parser) parseFragment() (*fragment, error) {
+	p.next() // "fragment"
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if on, err := p.expectName(); err != nil || on != "on" {
+		return nil, syntaxError(p.src, p.tokens[p.i-1].pos, `expected "on"`)
+	}
+	typeCondition, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	sel, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &fragment{name: name, typeCondition: typeCondition, selection: sel}, nil
+}
+
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	var selections []selection
+	for !p.isPunct("}") {
+		if p.peek().kind == tokenEOF {
+			return nil, p.unexpected(p.peek())
+		}
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	p.next()
+	if len(selections) == 0 {
+		return nil, syntaxError(p.src, p.tokens[p.i-1].pos, "empty selection set")
+	}
+	return selections, nil
+}
+
+func (p *parser) parseSelection() (selection, error) {
+	sel := selection{pos: p.peek().pos}
+	var err error
+
+	if p.isPunct("...") {
+		p.next()
+		if t := p.peek(); t.kind == tokenName && t.value != "on" {
+			sel.fragmentSpread = p.next().value
+			sel.directives, err = p.parseDirectives()
+			return sel, err
+		}
+		sel.inline = true
+		if t := p.peek(); t.kind == tokenName && t.value == "on" {
+			p.next()
+			if sel.typeCondition, err = p.expectName(); err != nil {
+				return sel, err
+			}
+		}
+		if sel.directives, err = p.parseDirectives(); err != nil {
+			return sel, err
+		}
+		sel.selection, err = p.parseSelectionSet()
+		return sel, err
+	}
+
+	if sel.name, err = p.expectName(); err != nil {
+		return sel, err
+	}
+	if p.isPunct(":") {
+		p.next()
+		sel.alias = sel.name
+		if sel.name, err = p.expectName(); err != nil {
+			return sel, err
+		}
+	}
+	if sel.args, err = p.parseArguments(); err != nil {
+		return sel, err
+	}
+	if sel.directives, err = p.parseDirectives(); err != nil {
+		return sel, err
+	}
+	if p.isPunct("{") {
+		sel.selection, err = p.parseSelectionSet()
+	}
+	return sel, err
+}
+
+func (p *parser) parseArguments() (map[string]value, error) {
+	if !p.isPunct("(") {
+		return nil, nil
+	}
+	p.next()
+	args := map[string]value{}
+	for !p.isPunct(")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		if args[name], err = p.parseValue(false); err != nil {
+			return nil, err
+		}
+	}
+	p.next()
+	return args, nil
+}
+
+func (p *parser) parseDirectives() ([]directive, error) {
+	var directives []directive
+	for p.isPunct("@") {
+		p.next()
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.parseArguments()
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, directive{name: name, args: args})
+	}
+	return directives, nil
+}
+
+func (p *parser) parseValue(isConst bool) (value, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenPunct:
+		switch t.value {
+		case "$":
+			if isConst {
+				return value{}, p.unexpected(t)
+			}
+			name, err := p.expectName()
+			return value{kind: "variable", variable: name}, err
+		case "[":
+			v := value{kind: "list", list: []value{}}
+			for !p.isPunct("]") {
+				item, err := p.parseValue(isConst)
+				if err != nil {
+					return v, err
+				}
+				v.list = append(v.list, item)
+			}
+			p.next()
+			return v, nil
+		case "{":
+			v := value{kind: "object", object: map[string]value{}}
+			for !p.isPunct("}") {
+				name, err := p.expectName()
+				if err != nil {
+					return v, err
+				}
+				if err := p.expectPunct(":"); err != nil {
+					return v, err
+				}
+				if v.object[name], err = p.parseValue(isConst); err != nil {
+					return v, err
+				}
+			}
+			p.next()
+			return v, nil
+		}
+	case tokenInt:
+		n, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return value{}, syntaxError(p.src, t.pos, "invalid int")
+		}
+		return value{kind: "literal", literal: n}, nil
+	case tokenFloat:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return value{}, syntaxError(p.src, t.pos, "invalid float")
+		}
+		return value{kind: "literal", literal: f}, nil
+	case tokenString:
+		return value{kind: "literal", literal: t.value}, nil
+	case tokenName:
+		switch t.value {
+		case "true":
+			return value{kind: "literal", literal: true}, nil
+		case "false":
+			return value{kind: "literal", literal: false}, nil
+		case "null":
+			return value{kind: "literal", literal: nil}, nil
+		}
+		return value{kind: "literal", literal: t.value}, nil // enum value
+	}
+	return value{}, p.unexpected(t)
+}
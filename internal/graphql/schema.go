@@ -0,0 +1,107 @@
+// Package graphql is a minimal GraphQL executor for the read-only queries.
+//
+// It supports the field selection, aliases, arguments, variables, fragments and
+// the `@include` and `@skip` directives. The schema is defined by the resolvers
+// in Go, and the introspection is not supported except the `__typename` field.
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	DefaultMaxDepth = 5    // The default max depth of the selection sets
+	DefaultMaxCost  = 1000 // The default max cost of the query
+)
+
+// The cost of query is the number of the resolved fields in the worst case,
+// each field costs 1 and the subfields of the list field are multiplied by its `ListSize`.
+type Schema struct {
+	Query    *Object
+	MaxDepth int // The max depth of the selection sets (default: 5)
+	MaxCost  int // The max cost of the query (default: 1000)
+}
+
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+type Field struct {
+	Type    *Object  // The object type of field (nil for the scalar)
+	List    bool     // The field is a list of the type
+	Args    []string // The names of the accepted arguments
+	Resolve func(p ResolveParams) (any, error)
+
+	// The max number of items of the list field by the arguments (e.g. `limit`),
+	// which is for the query cost (1 if nil)
+	ListSize func(args Args) int
+}
+
+type ResolveParams struct {
+	Source any // The value of parent object (nil for the query root)
+	Args   Args
+}
+
+type Args map[string]any
+
+func (a Args) String(name string, def string) string {
+	if s, ok := a[name].(string); ok {
+		return s
+	}
+	return def
+}
+
+func (a Args) Int(name string, def int) int {
+	switch v := a[name].(type) {
+	case int64:
+		return int(v)
+	case int:
+		return v
+	case float64: // the number of JSON variables
+		return int(v)
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+func (a Args) Bool(name string, def bool) bool {
+	if b, ok := a[name].(bool); ok {
+		return b
+	}
+	return def
+}
+
+type Request struct {
+	Query         string         `json:"query" query:"query"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName" query:"operationName"`
+}
+
+type Response struct {
+	Data   any      `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"`
+}
+
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(format string, a ...any) *Error {
+	return &Error{Message: fmt.Sprintf(format, a...)}
+}
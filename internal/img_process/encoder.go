@@ -0,0 +1,66 @@
+package img_process
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The encoder by the external command, since there is no encoder of WebP and AVIF in the standard library
+type commandEncoder struct {
+	name string
+	args func(in string, out string, quality int) []string
+}
+
+var commandEncoders = map[string]commandEncoder{
+	// @link https://developers.google.com/speed/webp/docs/cwebp
+	"webp": {
+		name: "cwebp",
+		args: func(in, out string, quality int) []string {
+			return []string{"-quiet", "-metadata", "none", "-q", strconv.Itoa(quality), in, "-o", out}
+		},
+	},
+	// @link https://github.com/AOMediaCodec/libavif/blob/main/doc/avifenc.1.md
+	"avif": {
+		name: "avifenc",
+		args: func(in, out string, quality int) []string {
+			return []string{"-q", strconv.Itoa(quality), "--ignore-exif", "--ignore-xmp", in, out}
+		},
+	},
+}
+
+func (e commandEncoder) available() bool {
+	_, err := exec.LookPath(e.name)
+	return err == nil
+}
+
+// Encode the image by writing it to a temporary PNG as the input of command
+func (e commandEncoder) encode(img image.Image, quality int) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "artalk-img-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "in.png")
+	out := filepath.Join(dir, "out")
+	f, err := os.Create(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return nil, err
+	}
+	f.Close()
+
+	if output, err := exec.Command(e.name, e.args(in, out, quality)...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", e.name, err, strings.TrimSpace(string(output)))
+	}
+	return os.ReadFile(out)
+}
@@ -0,0 +1,206 @@
+// Package img_process processes the uploaded images, which strips the metadata (e.g. EXIF and GPS),
+// limits the dimensions, generates the thumbnails and transcodes to WebP or AVIF.
+package img_process
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"golang.org/x/image/bmp"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+type Options struct {
+	StripMetadata  bool   // Re-encode the image to strip the metadata even if it is not resized
+	MaxWidth       int    // The max width of image, 0 is unlimited
+	MaxHeight      int    // The max height of image, 0 is unlimited
+	Format         string // The output format ("jpeg", "png", "webp" or "avif"), the original format is kept if empty
+	Quality        int    // The quality of JPEG, WebP and AVIF (1-100, default: 85)
+	ThumbnailWidth int    // The width of thumbnail, 0 is disabled
+	MaxPixels      int64  // The max pixels (width * height) of image to decode, 0 is the default (40 megapixels)
+}
+
+type Image struct {
+	Data []byte
+	Mime string
+}
+
+type Result struct {
+	Image     Image
+	Thumbnail *Image // nil if the thumbnail is disabled or the image is smaller than it
+}
+
+const (
+	defaultQuality   = 85
+	defaultMaxPixels = 40_000_000
+)
+
+// The image is rejected before decoded if the declared dimensions exceed the pixel budget,
+// since the decoded image is allocated by the dimensions not the file size
+var ErrTooManyPixels = errors.New("the image dimensions exceed the pixel limit")
+
+// Process the image, the original image is returned if nothing to do.
+//
+// The EXIF orientation of JPEG is applied before re-encoded, since the metadata is removed.
+// The GIFs are kept as is to keep the animation.
+func Process(data []byte, mime string, opts Options) (Result, error) {
+	original := Result{Image: Image{Data: data, Mime: mime}}
+	if mime == "image/gif" {
+		return original, nil
+	}
+
+	if opts.Quality <= 0 || opts.Quality > 100 {
+		opts.Quality = defaultQuality
+	}
+	format := getOutputFormat(mime, opts.Format)
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return original, fmt.Errorf("failed to decode image: %w", err)
+	}
+	orientation := 1
+	if mime == "image/jpeg" {
+		orientation = getJPEGOrientation(data)
+	}
+	maxPixels := opts.MaxPixels
+	if maxPixels <= 0 {
+		maxPixels = defaultMaxPixels
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxPixels {
+		return original, fmt.Errorf("%w (%dx%d)", ErrTooManyPixels, cfg.Width, cfg.Height)
+	}
+
+	width, height := cfg.Width, cfg.Height
+	if orientation >= 5 {
+		width, height = height, width // rotated
+	}
+
+	resizeW, resizeH := fitSize(width, height, opts.MaxWidth, opts.MaxHeight)
+	needsThumbnail := opts.ThumbnailWidth > 0 && width > opts.ThumbnailWidth
+	needsEncode := opts.StripMetadata || resizeW != width || format != formatOfMime(mime)
+	if !needsEncode && !needsThumbnail {
+		return original, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return original, fmt.Errorf("failed to decode image: %w", err)
+	}
+	img = applyOrientation(img, orientation)
+
+	result := original
+	if needsEncode {
+		out, err := encode(resize(img, resizeW, resizeH), format, opts.Quality)
+		if err != nil {
+			return original, err
+		}
+		result.Image = out
+	}
+	if needsThumbnail {
+		thumbW, thumbH := fitSize(width, height, opts.ThumbnailWidth, 0)
+		thumb, err := encode(resize(img, thumbW, thumbH), format, opts.Quality)
+		if err != nil {
+			return original, err
+		}
+		result.Thumbnail = &thumb
+	}
+
+	return result, nil
+}
+
+// Get the output format, which falls back to the format can be encoded if the encoder is not found
+func getOutputFormat(mime string, format string) string {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "jpg" {
+		format = "jpeg"
+	}
+	if format == "" {
+		format = formatOfMime(mime)
+	}
+
+	if encoder, ok := commandEncoders[format]; ok && !encoder.available() {
+		fallback := formatOfMime(mime)
+		if _, isCommand := commandEncoders[fallback]; isCommand {
+			fallback = "png" // e.g. the WebP could not be encoded without `cwebp`
+		}
+		log.Warn("[ImgProcess] The command `", encoder.name, "` is not found to encode ", format, ", fallback to ", fallback)
+		return fallback
+	}
+	return format
+}
+
+func formatOfMime(mime string) string {
+	return strings.TrimPrefix(mime, "image/")
+}
+
+// Get the size to fit in the max width and height with the aspect ratio kept, the size is never enlarged
+func fitSize(width, height, maxWidth, maxHeight int) (int, int) {
+	w, h := float64(width), float64(height)
+	if maxWidth > 0 && w > float64(maxWidth) {
+		h = h * float64(maxWidth) / w
+		w = float64(maxWidth)
+	}
+	if maxHeight > 0 && h > float64(maxHeight) {
+		w = w * float64(maxHeight) / h
+		h = float64(maxHeight)
+	}
+	return max(int(w+0.5), 1), max(int(h+0.5), 1)
+}
+
+func resize(img image.Image, width, height int) image.Image {
+	if img.Bounds().Dx() == width && img.Bounds().Dy() == height {
+		return img
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+	return dst
+}
+
+func encode(img image.Image, format string, quality int) (Image, error) {
+	buf := &bytes.Buffer{}
+	var err error
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(buf, flatten(img), &jpeg.Options{Quality: quality})
+	case "png":
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(buf, img)
+	case "gif":
+		err = gif.Encode(buf, img, nil)
+	case "bmp":
+		err = bmp.Encode(buf, img)
+	default:
+		encoder, ok := commandEncoders[format]
+		if !ok {
+			return Image{}, fmt.Errorf("unsupported image format: %s", format)
+		}
+		data, err := encoder.encode(img, quality)
+		if err != nil {
+			return Image{}, fmt.Errorf("failed to encode %s: %w", format, err)
+		}
+		return Image{Data: data, Mime: "image/" + format}, nil
+	}
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to encode %s: %w", format, err)
+	}
+	return Image{Data: buf.Bytes(), Mime: "image/" + format}, nil
+}
+
+// Draw the image on the white background, since JPEG has no alpha channel
+func flatten(img image.Image) image.Image {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		return img
+	}
+	dst := image.NewRGBA(img.Bounds())
+	xdraw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, xdraw.Src)
+	xdraw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, xdraw.Over)
+	return dst
+}
@@ -0,0 +1,213 @@
+package img_process
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestImage(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{uint8(x), uint8(y), 0, 255})
+		}
+	}
+	img.Set(0, 0, color.NRGBA{255, 0, 0, 255}) // mark the top-left
+	return img
+}
+
+func encodeTestPNG(img image.Image) []byte {
+	buf := &bytes.Buffer{}
+	png.Encode(buf, img)
+	return buf.Bytes()
+}
+
+// Patch the declared dimensions in the IHDR chunk of PNG
+func withTestPNGSize(data []byte, w, h uint32) []byte {
+	data = bytes.Clone(data)
+	binary.BigEndian.PutUint32(data[16:], w)
+	binary.BigEndian.PutUint32(data[20:], h)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+// Insert the APP1 segment of EXIF with the orientation after SOI
+func withTestEXIF(jpg []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)           // count
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)      // tag
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)           // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)           // count
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation) // value
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)                   // padding and next IFD
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(payload)+2))
+	app1 = append(app1, payload...)
+
+	return append(append([]byte{0xFF, 0xD8}, app1...), jpg[2:]...)
+}
+
+func decodeConfig(t *testing.T, data []byte) (image.Config, string) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	assert.NoError(t, err)
+	return cfg, format
+}
+
+func TestProcess(t *testing.T) {
+	t.Run("NothingToDo", func(t *testing.T) {
+		data := encodeTestPNG(newTestImage(100, 50))
+		result, err := Process(data, "image/png", Options{})
+		assert.NoError(t, err)
+		assert.Equal(t, data, result.Image.Data)
+		assert.Nil(t, result.Thumbnail)
+	})
+
+	t.Run("Resize", func(t *testing.T) {
+		result, err := Process(encodeTestPNG(newTestImage(200, 100)), "image/png", Options{MaxWidth: 100, MaxHeight: 100})
+		assert.NoError(t, err)
+		cfg, format := decodeConfig(t, result.Image.Data)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, 100, cfg.Width)
+		assert.Equal(t, 50, cfg.Height)
+
+		result, err = Process(encodeTestPNG(newTestImage(100, 200)), "image/png", Options{MaxWidth: 100, MaxHeight: 100})
+		assert.NoError(t, err)
+		cfg, _ = decodeConfig(t, result.Image.Data)
+		assert.Equal(t, 50, cfg.Width, "the height should be limited too")
+		assert.Equal(t, 100, cfg.Height)
+	})
+
+	t.Run("StripMetadata", func(t *testing.T) {
+		jpg := &bytes.Buffer{}
+		jpeg.Encode(jpg, newTestImage(40, 20), nil)
+		data := withTestEXIF(jpg.Bytes(), 6)
+		assert.Equal(t, 6, getJPEGOrientation(data))
+
+		result, err := Process(data, "image/jpeg", Options{StripMetadata: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "image/jpeg", result.Image.Mime)
+		assert.Equal(t, 1, getJPEGOrientation(result.Image.Data), "the EXIF should be removed")
+		assert.NotContains(t, string(result.Image.Data), "Exif")
+
+		cfg, _ := decodeConfig(t, result.Image.Data)
+		assert.Equal(t, 20, cfg.Width, "the image should be rotated by the orientation")
+		assert.Equal(t, 40, cfg.Height)
+
+		// the metadata is kept if not stripped and nothing else to do
+		result, err = Process(data, "image/jpeg", Options{})
+		assert.NoError(t, err)
+		assert.Equal(t, data, result.Image.Data)
+	})
+
+	t.Run("ConvertFormat", func(t *testing.T) {
+		img := newTestImage(20, 20)
+		img.Set(1, 1, color.NRGBA{0, 0, 0, 0})
+
+		result, err := Process(encodeTestPNG(img), "image/png", Options{Format: "jpg", Quality: 90})
+		assert.NoError(t, err)
+		assert.Equal(t, "image/jpeg", result.Image.Mime)
+		_, format := decodeConfig(t, result.Image.Data)
+		assert.Equal(t, "jpeg", format)
+	})
+
+	t.Run("Thumbnail", func(t *testing.T) {
+		result, err := Process(encodeTestPNG(newTestImage(300, 150)), "image/png", Options{ThumbnailWidth: 100})
+		assert.NoError(t, err)
+		if assert.NotNil(t, result.Thumbnail) {
+			cfg, _ := decodeConfig(t, result.Thumbnail.Data)
+			assert.Equal(t, 100, cfg.Width)
+			assert.Equal(t, 50, cfg.Height)
+		}
+		cfg, _ := decodeConfig(t, result.Image.Data)
+		assert.Equal(t, 300, cfg.Width, "the image should not be resized")
+
+		result, err = Process(encodeTestPNG(newTestImage(80, 40)), "image/png", Options{ThumbnailWidth: 100})
+		assert.NoError(t, err)
+		assert.Nil(t, result.Thumbnail, "no thumbnail for the small image")
+	})
+
+	t.Run("GIF", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		gif.Encode(buf, newTestImage(200, 200), nil)
+		result, err := Process(buf.Bytes(), "image/gif", Options{MaxWidth: 100, StripMetadata: true})
+		assert.NoError(t, err)
+		assert.Equal(t, buf.Bytes(), result.Image.Data, "the GIF should be kept for the animation")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Process([]byte("not an image"), "image/png", Options{StripMetadata: true})
+		assert.Error(t, err)
+	})
+
+	t.Run("TooManyPixels", func(t *testing.T) {
+		// The header declares 40000x40000 but the file is tiny, which would allocate about 6 GB if decoded
+		data := withTestPNGSize(encodeTestPNG(newTestImage(20, 20)), 40000, 40000)
+		_, err := Process(data, "image/png", Options{MaxWidth: 1920})
+		assert.ErrorIs(t, err, ErrTooManyPixels)
+
+		_, err = Process(encodeTestPNG(newTestImage(20, 20)), "image/png", Options{StripMetadata: true, MaxPixels: 399})
+		assert.ErrorIs(t, err, ErrTooManyPixels, "should be limited by the option")
+		_, err = Process(encodeTestPNG(newTestImage(20, 20)), "image/png", Options{StripMetadata: true, MaxPixels: 400})
+		assert.NoError(t, err)
+	})
+
+	t.Run("FallbackWithoutEncoder", func(t *testing.T) {
+		t.Setenv("PATH", "")
+		result, err := Process(encodeTestPNG(newTestImage(20, 20)), "image/png", Options{Format: "webp"})
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", result.Image.Mime, "the original format should be kept")
+	})
+}
+
+func Test_fitSize(t *testing.T) {
+	tests := []struct {
+		w, h, maxW, maxH int
+		wantW, wantH     int
+	}{
+		{100, 100, 0, 0, 100, 100},
+		{100, 100, 200, 200, 100, 100},
+		{400, 200, 100, 0, 100, 50},
+		{400, 200, 0, 100, 200, 100},
+		{400, 200, 100, 10, 20, 10},
+		{1000, 1, 100, 0, 100, 1},
+	}
+	for _, tt := range tests {
+		w, h := fitSize(tt.w, tt.h, tt.maxW, tt.maxH)
+		assert.Equal(t, []int{tt.wantW, tt.wantH}, []int{w, h}, "%v", tt)
+	}
+}
+
+func Test_applyOrientation(t *testing.T) {
+	img := newTestImage(3, 2)
+	red := color.NRGBA{255, 0, 0, 255}
+
+	// The position of the top-left pixel after transformed
+	tests := map[int]image.Point{
+		1: {0, 0},
+		2: {2, 0},
+		3: {2, 1},
+		4: {0, 1},
+		5: {0, 0},
+		6: {1, 0},
+		7: {1, 2},
+		8: {0, 2},
+	}
+	for orientation, p := range tests {
+		out := applyOrientation(img, orientation)
+		if orientation >= 5 {
+			assert.Equal(t, image.Rect(0, 0, 2, 3), out.Bounds(), "orientation %d", orientation)
+		}
+		assert.Equal(t, red, color.NRGBAModel.Convert(out.At(p.X, p.Y)), "orientation %d", orientation)
+	}
+}
@@ -22,19 +22,44 @@ func NewIPRegion(conf IPRegionConf) *IPRegion {
 	}
 }
 
+// Get the region of IP in the display precision, empty if not found
 func (ipRegion *IPRegion) IP2Region(ip string) string {
-	if strings.TrimSpace(ip) == "" {
+	region, ok := ipRegion.Lookup(ip)
+	if !ok {
 		return ""
 	}
 
+	return ipRegion.Format(region)
+}
+
+// Find the geolocation of IP
+func (ipRegion *IPRegion) Lookup(ip string) (Region, bool) {
 	ip = ipScraper(ip)
-	region, err := search(ip, ipRegion.conf.DBPath, ipRegion.conf.CacheEnabled)
+	if ip == "" {
+		return Region{}, false
+	}
+
+	var region Region
+	var err error
+	if ipRegion.conf.GetProvider() == config.IPRegionProviderMaxMind {
+		region, err = searchMaxMind(ip, ipRegion.conf.DBPath, ipRegion.conf.Locale)
+	} else {
+		var raw string
+		if raw, err = search(ip, ipRegion.conf.DBPath, ipRegion.conf.CacheEnabled); err == nil {
+			region = parseIP2Region(raw)
+		}
+	}
 	if err != nil {
 		if !strings.HasPrefix(err.Error(), "invalid ip address") {
 			log.Warn("[IP2Region] ", err)
 		}
-		return ""
+		return Region{}, false
 	}
 
-	return regionScraper(region, config.IPRegionPrecision(ipRegion.conf.Precision))
+	return region, !region.IsEmpty()
+}
+
+// Format the region in the display precision
+func (ipRegion *IPRegion) Format(region Region) string {
+	return formatRegion(region, config.IPRegionPrecision(ipRegion.conf.Precision))
 }
@@ -0,0 +1,369 @@
+package ip_region
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// The minimal reader of MaxMind DB (e.g. GeoLite2-Country.mmdb, GeoLite2-City.mmdb)
+//
+// @link https://maxmind.github.io/MaxMind-DB/
+type mmdbReader struct {
+	buf        []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	treeSize   uint
+	ipv4Start  uint
+}
+
+var mmdbMetadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+const mmdbDataSeparatorSize = 16
+
+func newMMDBReader(buf []byte) (*mmdbReader, error) {
+	i := bytes.LastIndex(buf, mmdbMetadataMarker)
+	if i < 0 {
+		return nil, fmt.Errorf("invalid MaxMind DB: metadata not found")
+	}
+
+	metaStart := uint(i + len(mmdbMetadataMarker))
+	meta, _, err := (&mmdbDecoder{buf: buf[metaStart:]}).decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MaxMind DB metadata: %w", err)
+	}
+	metaMap, _ := meta.(map[string]any)
+
+	r := &mmdbReader{
+		buf:        buf,
+		nodeCount:  uint(toUint64(metaMap["node_count"])),
+		recordSize: uint(toUint64(metaMap["record_size"])),
+		ipVersion:  uint(toUint64(metaMap["ip_version"])),
+	}
+	if r.recordSize != 24 && r.recordSize != 28 && r.recordSize != 32 {
+		return nil, fmt.Errorf("invalid MaxMind DB: unsupported record size %d", r.recordSize)
+	}
+	r.treeSize = r.nodeCount * r.recordSize / 4
+	if r.treeSize+mmdbDataSeparatorSize > metaStart {
+		return nil, fmt.Errorf("invalid MaxMind DB: search tree out of range")
+	}
+
+	// the IPv4 addresses are in the subtree of `::/96` in the IPv6 database
+	if r.ipVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < r.nodeCount; i++ {
+			node = r.readRecord(node, 0)
+		}
+		r.ipv4Start = node
+	}
+
+	return r, nil
+}
+
+// Find the record of the IP, nil is returned if not found
+func (r *mmdbReader) lookup(ip net.IP) (any, error) {
+	bitCount := 128
+	node := uint(0)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		bitCount = 32
+		node = r.ipv4Start
+	} else if r.ipVersion == 4 {
+		return nil, nil // the IPv6 address is not in the IPv4 database
+	}
+
+	for i := 0; i < bitCount && node < r.nodeCount; i++ {
+		bit := (ip[i>>3] >> (7 - uint(i&7))) & 1
+		node = r.readRecord(node, uint(bit))
+	}
+
+	if node == r.nodeCount {
+		return nil, nil // not found
+	}
+	if node < r.nodeCount {
+		return nil, fmt.Errorf("invalid MaxMind DB: search tree is too deep")
+	}
+
+	offset := node - r.nodeCount - mmdbDataSeparatorSize
+	data := r.buf[r.treeSize+mmdbDataSeparatorSize:]
+	if offset >= uint(len(data)) {
+		return nil, fmt.Errorf("invalid MaxMind DB: data pointer out of range")
+	}
+
+	value, _, err := (&mmdbDecoder{buf: data}).decode(offset)
+	return value, err
+}
+
+// Read the left (bit 0) or right (bit 1) record of the node
+func (r *mmdbReader) readRecord(node uint, bit uint) uint {
+	switch r.recordSize {
+	case 24:
+		off := node*6 + bit*3
+		b := r.buf[off : off+3]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		b := r.buf[node*7 : node*7+7]
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default: // 32
+		off := node*8 + bit*4
+		return uint(binary.BigEndian.Uint32(r.buf[off : off+4]))
+	}
+}
+
+// The decoder of MaxMind DB data section
+type mmdbDecoder struct {
+	buf []byte
+}
+
+const (
+	mmdbTypeExtended = 0
+	mmdbTypePointer  = 1
+	mmdbTypeString   = 2
+	mmdbTypeDouble   = 3
+	mmdbTypeBytes    = 4
+	mmdbTypeUint16   = 5
+	mmdbTypeUint32   = 6
+	mmdbTypeMap      = 7
+	mmdbTypeInt32    = 8
+	mmdbTypeUint64   = 9
+	mmdbTypeUint128  = 10
+	mmdbTypeArray    = 11
+	mmdbTypeBool     = 14
+	mmdbTypeFloat    = 15
+)
+
+// Decode the value at the offset, the offset after the value is returned
+func (d *mmdbDecoder) decode(offset uint) (any, uint, error) {
+	if offset >= uint(len(d.buf)) {
+		return nil, 0, fmt.Errorf("unexpected end of data")
+	}
+
+	ctrl := d.buf[offset]
+	offset++
+	typeNum := uint(ctrl >> 5)
+
+	if typeNum == mmdbTypePointer {
+		pointer, next, err := d.decodePointer(ctrl, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		value, _, err := d.decode(pointer)
+		return value, next, err
+	}
+
+	if typeNum == mmdbTypeExtended {
+		if offset >= uint(len(d.buf)) {
+			return nil, 0, fmt.Errorf("unexpected end of data")
+		}
+		typeNum = 7 + uint(d.buf[offset])
+		offset++
+	}
+
+	size := uint(ctrl & 0x1F)
+	if size >= 29 {
+		n := size - 28 // the number of bytes of size
+		if offset+n > uint(len(d.buf)) {
+			return nil, 0, fmt.Errorf("unexpected end of data")
+		}
+		extra := uint(0)
+		for _, b := range d.buf[offset : offset+n] {
+			extra = extra<<8 | uint(b)
+		}
+		offset += n
+		switch size {
+		case 29:
+			size = 29 + extra
+		case 30:
+			size = 285 + extra
+		default:
+			size = 65821 + extra
+		}
+	}
+
+	switch typeNum {
+	case mmdbTypeMap:
+		m := make(map[string]any, size)
+		for i := uint(0); i < size; i++ {
+			key, next, err := d.decode(offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			value, next, err := d.decode(next)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[fmt.Sprint(key)] = value
+			offset = next
+		}
+		return m, offset, nil
+	case mmdbTypeArray:
+		arr := make([]any, 0, size)
+		for i := uint(0); i < size; i++ {
+			value, next, err := d.decode(offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			arr = append(arr, value)
+			offset = next
+		}
+		return arr, offset, nil
+	case mmdbTypeBool:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(d.buf)) {
+		return nil, 0, fmt.Errorf("unexpected end of data")
+	}
+	b := d.buf[offset : offset+size]
+	next := offset + size
+
+	switch typeNum {
+	case mmdbTypeString:
+		return string(b), next, nil
+	case mmdbTypeBytes:
+		return append([]byte{}, b...), next, nil
+	case mmdbTypeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("invalid double size %d", size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), next, nil
+	case mmdbTypeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("invalid float size %d", size)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), next, nil
+	case mmdbTypeUint16, mmdbTypeUint32, mmdbTypeUint64:
+		v := uint64(0)
+		for _, c := range b {
+			v = v<<8 | uint64(c)
+		}
+		return v, next, nil
+	case mmdbTypeInt32:
+		v := uint32(0)
+		for _, c := range b {
+			v = v<<8 | uint32(c)
+		}
+		return int64(int32(v)), next, nil
+	case mmdbTypeUint128:
+		return append([]byte{}, b...), next, nil // not used in the GeoIP databases
+	default:
+		return nil, 0, fmt.Errorf("unsupported data type %d", typeNum)
+	}
+}
+
+func (d *mmdbDecoder) decodePointer(ctrl byte, offset uint) (uint, uint, error) {
+	size := uint((ctrl>>3)&0x3) + 1
+	if offset+size > uint(len(d.buf)) {
+		return 0, 0, fmt.Errorf("unexpected end of data")
+	}
+	b := d.buf[offset : offset+size]
+
+	var pointer uint
+	switch size {
+	case 1:
+		pointer = uint(ctrl&0x7)<<8 | uint(b[0])
+	case 2:
+		pointer = (uint(ctrl&0x7)<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+	case 3:
+		pointer = (uint(ctrl&0x7)<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+	default:
+		pointer = uint(binary.BigEndian.Uint32(b))
+	}
+
+	return pointer, offset + size, nil
+}
+
+func toUint64(v any) uint64 {
+	switch n := v.(type) {
+	case uint64:
+		return n
+	case int64:
+		return uint64(n)
+	case float64:
+		return uint64(n)
+	case string:
+		u, _ := strconv.ParseUint(n, 10, 64)
+		return u
+	default:
+		return 0
+	}
+}
+
+var (
+	mmdbReaders   = map[string]*mmdbReader{}
+	mmdbReadersMu sync.Mutex
+)
+
+// Get the reader of MaxMind DB which is loaded in memory once
+func getMMDBReader(dbPath string) (*mmdbReader, error) {
+	mmdbReadersMu.Lock()
+	defer mmdbReadersMu.Unlock()
+
+	if r, ok := mmdbReaders[dbPath]; ok {
+		return r, nil
+	}
+
+	buf, err := os.ReadFile(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load content from %s: %w", strconv.Quote(dbPath), err)
+	}
+	r, err := newMMDBReader(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	mmdbReaders[dbPath] = r
+	return r, nil
+}
+
+// Search the region of IP in the MaxMind DB (GeoLite2 or GeoIP2 Country/City)
+func searchMaxMind(ip string, dbPath string, locale string) (Region, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return Region{}, fmt.Errorf("invalid ip address `%s`", ip)
+	}
+
+	reader, err := getMMDBReader(dbPath)
+	if err != nil {
+		return Region{}, err
+	}
+
+	record, err := reader.lookup(addr)
+	if err != nil {
+		return Region{}, err
+	}
+	data, _ := record.(map[string]any)
+
+	// the names in the locale, fallback to English
+	name := func(v any) string {
+		m, _ := v.(map[string]any)
+		names, _ := m["names"].(map[string]any)
+		if s, ok := names[locale].(string); ok && locale != "" {
+			return s
+		}
+		s, _ := names["en"].(string)
+		return s
+	}
+
+	country, _ := data["country"].(map[string]any)
+	if country == nil {
+		country, _ = data["registered_country"].(map[string]any)
+	}
+	region := Region{Country: name(country)}
+	region.CountryCode, _ = country["iso_code"].(string)
+	if subdivisions, _ := data["subdivisions"].([]any); len(subdivisions) > 0 {
+		region.Province = name(subdivisions[0])
+	}
+	region.City = name(data["city"])
+
+	return region, nil
+}
@@ -0,0 +1,128 @@
+package ip_region
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Encode the value in the MaxMind DB data format (only the types used in the tests)
+func mmdbEncode(v any) []byte {
+	ctrl := func(typeNum int, size int) []byte {
+		if typeNum > 7 {
+			return []byte{byte(size), byte(typeNum - 7)}
+		}
+		return []byte{byte(typeNum<<5 | size)}
+	}
+
+	switch v := v.(type) {
+	case string:
+		return append(ctrl(mmdbTypeString, len(v)), v...)
+	case uint16:
+		return append(ctrl(mmdbTypeUint16, 2), byte(v>>8), byte(v))
+	case []any:
+		buf := ctrl(mmdbTypeArray, len(v))
+		for _, item := range v {
+			buf = append(buf, mmdbEncode(item)...)
+		}
+		return buf
+	case [][2]any: // the ordered map
+		buf := ctrl(mmdbTypeMap, len(v))
+		for _, kv := range v {
+			buf = append(buf, mmdbEncode(kv[0])...)
+			buf = append(buf, mmdbEncode(kv[1])...)
+		}
+		return buf
+	}
+	panic("unsupported type")
+}
+
+// Build the IPv4 MaxMind DB which only contains the record of `1.0.0.0/24`
+func buildTestMMDB(record any) []byte {
+	const prefixLen = 24
+	const nodeCount = prefixLen
+	const dataPointer = nodeCount + mmdbDataSeparatorSize // the record at the offset 0 of data section
+
+	ip := []byte{1, 0, 0, 0}
+	tree := []byte{}
+	for i := 0; i < nodeCount; i++ {
+		bit := (ip[i>>3] >> (7 - uint(i&7))) & 1
+		next := uint(i + 1)
+		if i == nodeCount-1 {
+			next = dataPointer
+		}
+		records := [2]uint{nodeCount, nodeCount} // not found
+		records[bit] = next
+		for _, r := range records {
+			tree = append(tree, byte(r>>16), byte(r>>8), byte(r))
+		}
+	}
+
+	buf := append(tree, make([]byte, mmdbDataSeparatorSize)...)
+	buf = append(buf, mmdbEncode(record)...)
+	buf = append(buf, mmdbMetadataMarker...)
+	buf = append(buf, mmdbEncode([][2]any{
+		{"node_count", uint16(nodeCount)},
+		{"record_size", uint16(24)},
+		{"ip_version", uint16(4)},
+	})...)
+	return buf
+}
+
+func TestMaxMind(t *testing.T) {
+	names := func(en, zh string) [][2]any {
+		return [][2]any{{"names", [][2]any{{"en", en}, {"zh-CN", zh}}}}
+	}
+	dbPath := filepath.Join(t.TempDir(), "GeoLite2-City.mmdb")
+	os.WriteFile(dbPath, buildTestMMDB([][2]any{
+		{"city", names("Brisbane", "布里斯班")},
+		{"country", append(names("Australia", "澳大利亚"), [2]any{"iso_code", "AU"})},
+		{"subdivisions", []any{names("Queensland", "昆士兰州")}},
+	}), 0644)
+
+	region, err := searchMaxMind("1.0.0.1", dbPath, "zh-CN")
+	assert.NoError(t, err)
+	assert.Equal(t, Region{CountryCode: "AU", Country: "澳大利亚", Province: "昆士兰州", City: "布里斯班"}, region)
+
+	region, _ = searchMaxMind("1.0.0.1", dbPath, "ja")
+	assert.Equal(t, "Australia", region.Country, "should fallback to English")
+
+	region, err = searchMaxMind("1.0.1.1", dbPath, "en")
+	assert.NoError(t, err)
+	assert.True(t, region.IsEmpty())
+
+	_, err = searchMaxMind("2001:db8::1", dbPath, "en")
+	assert.NoError(t, err, "IPv6 should not be found in the IPv4 database")
+
+	ipRegion := NewIPRegion(IPRegionConf{IPRegionConf: config.IPRegionConf{DBPath: dbPath, Precision: "city", Locale: "en"}})
+	assert.Equal(t, "Queensland Brisbane", ipRegion.IP2Region("1.0.0.1"))
+	assert.Equal(t, "", ipRegion.IP2Region("1.0.1.1"))
+
+	region, ok := ipRegion.Lookup("1.0.0.1")
+	assert.True(t, ok)
+	assert.Equal(t, "AU|Australia|Queensland|Brisbane", region.String())
+	assert.Equal(t, region, ParseRegion(region.String()))
+}
+
+func TestFormatRegion(t *testing.T) {
+	tests := []struct {
+		region    Region
+		precision config.IPRegionPrecision
+		expected  string
+	}{
+		{Region{}, config.IPRegionCountry, ""},
+		{Region{CountryCode: "US"}, config.IPRegionProvince, "US"},
+		{Region{Country: "United States", Province: "California"}, config.IPRegionCountry, "United States"},
+		{Region{Country: "United States", Province: "California", City: "San Jose"}, config.IPRegionProvince, "California"},
+		{Region{Country: "United States", Province: "California", City: "San Jose"}, config.IPRegionCity, "California San Jose"},
+		{Region{Country: "Singapore", City: "Singapore"}, config.IPRegionCity, "Singapore"},
+		{Region{Country: "日本", Province: "東京都", City: "千代田区"}, config.IPRegionCity, "東京都千代田区"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, formatRegion(test.region, test.precision), test.region)
+	}
+}
@@ -0,0 +1,32 @@
+package ip_region
+
+import "strings"
+
+// The geolocation of IP
+type Region struct {
+	CountryCode string // The ISO 3166-1 alpha-2 code (only provided by the MaxMind DB)
+	Country     string
+	Province    string
+	City        string
+}
+
+func (r Region) IsEmpty() bool {
+	return r.Country == "" && r.CountryCode == ""
+}
+
+// Encode the region to store, e.g. "CN|中国|四川|成都"
+func (r Region) String() string {
+	if r.IsEmpty() {
+		return ""
+	}
+	return strings.Join([]string{r.CountryCode, r.Country, r.Province, r.City}, "|")
+}
+
+// Decode the stored region by `Region.String`
+func ParseRegion(s string) Region {
+	sep := strings.Split(s, "|")
+	if len(sep) != 4 {
+		return Region{}
+	}
+	return Region{CountryCode: sep[0], Country: sep[1], Province: sep[2], City: sep[3]}
+}
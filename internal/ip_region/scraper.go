@@ -1,7 +1,9 @@
 package ip_region
 
 import (
+	"cmp"
 	"strings"
+	"unicode"
 
 	"github.com/artalkjs/artalk/v2/internal/config"
 )
@@ -22,24 +24,43 @@ func ipScraper(ip string) string {
 }
 
 func regionScraper(raw string, precision config.IPRegionPrecision) string {
-	if precision == "" {
-		precision = config.IPRegionProvince
-	}
+	return formatRegion(parseIP2Region(raw), precision)
+}
 
+// Parse the region of ip2region, e.g. "中国|0|四川省|成都市|电信"
+func parseIP2Region(raw string) Region {
 	sep := strings.Split(raw, "|")
 	if len(sep) < 5 {
-		return ""
+		return Region{}
+	}
+
+	unknown := func(s string) string {
+		if s == "0" {
+			return ""
+		}
+		return s
+	}
+
+	return Region{
+		Country:  unknown(sep[0]),
+		Province: unknown(strings.TrimSuffix(strings.TrimSuffix(sep[2], "省"), "市")),
+		City:     unknown(strings.TrimSuffix(sep[3], "市")),
+		// Area: sep[1], ISP: sep[4]
+	}
+}
+
+func formatRegion(region Region, precision config.IPRegionPrecision) string {
+	if precision == "" {
+		precision = config.IPRegionProvince
 	}
 
 	var (
-		country  = sep[0]
-		_        = sep[1] // Area
-		province = strings.TrimSuffix(strings.TrimSuffix(sep[2], "省"), "市")
-		city     = strings.TrimSuffix(sep[3], "市")
-		// isp      = sep[4]
+		country  = cmp.Or(region.Country, region.CountryCode)
+		province = region.Province
+		city     = region.City
 	)
 
-	if country == "0" {
+	if country == "" {
 		return ""
 	}
 
@@ -48,21 +69,37 @@ func regionScraper(raw string, precision config.IPRegionPrecision) string {
 		return country
 
 	case config.IPRegionProvince:
-		if province == "0" {
+		if province == "" {
 			return country
 		}
 		return province
 
 	case config.IPRegionCity:
-		if province == "0" && city == "0" {
+		if province == "" && city == "" {
 			return country
 		}
-		if city == province { // e.g. 重庆重庆
+		if city == province || city == "" { // e.g. 重庆重庆
 			return province
 		}
-		return strings.TrimSuffix(province+""+city, "0")
+		if province == "" {
+			return city
+		}
+		if isCJK(province) {
+			return province + city
+		}
+		return province + " " + city
 
 	default:
 		return ""
 	}
 }
+
+// Check if the name is in CJK which is joined without space
+func isCJK(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package ldap_auth
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/samber/lo"
+)
+
+// The provider name of the LDAP users
+const Provider = "ldap"
+
+var (
+	ErrInvalidCredentials = fmt.Errorf("invalid ldap credentials")
+	ErrNoMatchedGroup     = fmt.Errorf("ldap user is not in any admin group")
+)
+
+// The user authenticated by LDAP
+type User struct {
+	DN     string
+	Name   string
+	Email  string
+	Groups []string
+
+	Role  string   // The mapped admin role
+	Sites []string // The mapped admin sites (nil for all sites)
+}
+
+// The connection to the LDAP server (replaceable in tests)
+var dial = func(conf *config.AdminLDAPConf) (ldap.Client, error) {
+	tlsConf := &tls.Config{InsecureSkipVerify: conf.InsecureSkipVerify}
+
+	conn, err := ldap.DialURL(conf.URL, ldap.DialWithTLSConfig(tlsConf))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(10 * time.Second)
+
+	if conf.StartTLS {
+		if err := conn.StartTLS(tlsConf); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+// Authenticate the user by LDAP and resolve the admin role by the group mapping
+//
+// The user is searched by the bind account first, then verified by binding with
+// the user DN and password.
+func Authenticate(conf *config.AdminLDAPConf, username string, password string) (User, error) {
+	// Reject the empty password to prevent the unauthenticated bind
+	if username == "" || password == "" {
+		return User{}, ErrInvalidCredentials
+	}
+
+	conn, err := dial(conf)
+	if err != nil {
+		return User{}, fmt.Errorf("ldap connect failed: %w", err)
+	}
+	defer conn.Close()
+
+	// Search the user
+	if conf.BindDN != "" {
+		if err := conn.Bind(conf.BindDN, conf.BindPassword); err != nil {
+			return User{}, fmt.Errorf("ldap bind failed: %w", err)
+		}
+	}
+
+	nameAttr := lo.If(conf.NameAttr != "", conf.NameAttr).Else("cn")
+	emailAttr := lo.If(conf.EmailAttr != "", conf.EmailAttr).Else("mail")
+	groupAttr := lo.If(conf.GroupAttr != "", conf.GroupAttr).Else("memberOf")
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		conf.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 10, false,
+		UserFilter(conf.UserFilter, username),
+		[]string{nameAttr, emailAttr, groupAttr},
+		nil,
+	))
+	if err != nil {
+		return User{}, fmt.Errorf("ldap search failed: %w", err)
+	}
+	if len(result.Entries) != 1 {
+		return User{}, ErrInvalidCredentials
+	}
+	entry := result.Entries[0]
+
+	// Verify the password
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return User{}, ErrInvalidCredentials
+		}
+		return User{}, fmt.Errorf("ldap bind failed: %w", err)
+	}
+
+	user := User{
+		DN:     entry.DN,
+		Name:   entry.GetAttributeValue(nameAttr),
+		Email:  entry.GetAttributeValue(emailAttr),
+		Groups: entry.GetAttributeValues(groupAttr),
+	}
+	if user.Name == "" || user.Email == "" {
+		return User{}, fmt.Errorf("ldap user %q has no %s or %s attribute", user.DN, nameAttr, emailAttr)
+	}
+
+	// Map the groups to admin role
+	role, sites, ok := ResolveRole(conf.Groups, user.Groups)
+	if !ok {
+		return User{}, ErrNoMatchedGroup
+	}
+	user.Role, user.Sites = role, sites
+
+	return user, nil
+}
+
+// Build the user search filter with the escaped username
+func UserFilter(filter string, username string) string {
+	if filter == "" {
+		filter = "(uid={{username}})"
+	}
+	return strings.ReplaceAll(filter, "{{username}}", ldap.EscapeFilter(username))
+}
+
+// Resolve the admin role of the groups, the first matched group in config is used
+func ResolveRole(mapping []config.AdminLDAPGroupConf, groups []string) (string, []string, bool) {
+	for _, g := range mapping {
+		if !lo.ContainsBy(groups, func(dn string) bool { return strings.EqualFold(dn, g.DN) }) {
+			continue
+		}
+
+		role := lo.If(g.Role != "", g.Role).Else(entity.AdminRoleSuper)
+		if !entity.IsValidAdminRole(role) {
+			continue
+		}
+
+		var sites []string
+		if len(g.Sites) > 0 && role != entity.AdminRoleSuper {
+			sites = g.Sites
+		}
+
+		return role, sites, true
+	}
+
+	return "", nil, false
+}
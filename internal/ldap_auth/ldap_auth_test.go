@@ -0,0 +1,106 @@
+package ldap_auth
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClient struct {
+	ldap.Client
+
+	passwords map[string]string
+	entries   []*ldap.Entry
+	filter    string
+}
+
+func (f *fakeClient) Bind(username, password string) error {
+	if p, ok := f.passwords[username]; ok && p == password {
+		return nil
+	}
+	return ldap.NewError(ldap.LDAPResultInvalidCredentials, nil)
+}
+
+func (f *fakeClient) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	f.filter = req.Filter
+	return &ldap.SearchResult{Entries: f.entries}, nil
+}
+
+func (f *fakeClient) Close() error { return nil }
+
+func TestAuthenticate(t *testing.T) {
+	client := &fakeClient{
+		passwords: map[string]string{
+			"cn=reader,dc=example,dc=com":          "reader_pass",
+			"uid=alice,ou=users,dc=example,dc=com": "alice_pass",
+		},
+		entries: []*ldap.Entry{ldap.NewEntry("uid=alice,ou=users,dc=example,dc=com", map[string][]string{
+			"cn":       {"Alice"},
+			"mail":     {"alice@example.com"},
+			"memberOf": {"cn=Moderators,ou=groups,dc=example,dc=com"},
+		})},
+	}
+	defer func(d func(*config.AdminLDAPConf) (ldap.Client, error)) { dial = d }(dial)
+	dial = func(conf *config.AdminLDAPConf) (ldap.Client, error) { return client, nil }
+
+	conf := &config.AdminLDAPConf{
+		BindDN:       "cn=reader,dc=example,dc=com",
+		BindPassword: "reader_pass",
+		UserFilter:   "(uid={{username}})",
+		Groups: []config.AdminLDAPGroupConf{
+			{DN: "cn=admins,ou=groups,dc=example,dc=com", Role: entity.AdminRoleSuper},
+			{DN: "cn=moderators,ou=groups,dc=example,dc=com", Role: entity.AdminRoleModerator, Sites: []string{"Site A"}},
+		},
+	}
+
+	user, err := Authenticate(conf, "alice", "alice_pass")
+	assert.NoError(t, err)
+	assert.Equal(t, "(uid=alice)", client.filter)
+	assert.Equal(t, "Alice", user.Name)
+	assert.Equal(t, "alice@example.com", user.Email)
+	assert.Equal(t, entity.AdminRoleModerator, user.Role)
+	assert.Equal(t, []string{"Site A"}, user.Sites)
+
+	_, err = Authenticate(conf, "alice", "wrong_pass")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = Authenticate(conf, "alice", "")
+	assert.ErrorIs(t, err, ErrInvalidCredentials, "should reject the empty password")
+
+	conf.Groups = conf.Groups[:1]
+	_, err = Authenticate(conf, "alice", "alice_pass")
+	assert.ErrorIs(t, err, ErrNoMatchedGroup)
+
+	client.entries = nil
+	_, err = Authenticate(conf, "bob", "bob_pass")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestUserFilter(t *testing.T) {
+	assert.Equal(t, "(uid=alice)", UserFilter("", "alice"))
+	assert.Equal(t, `(|(uid=a\2a\29)(mail=a\2a\29))`, UserFilter("(|(uid={{username}})(mail={{username}}))", "a*)"), "should escape the username")
+}
+
+func TestResolveRole(t *testing.T) {
+	mapping := []config.AdminLDAPGroupConf{
+		{DN: "cn=invalid", Role: "unknown"},
+		{DN: "cn=admins", Sites: []string{"Site A"}},
+		{DN: "cn=readers", Role: entity.AdminRoleReadOnly, Sites: []string{"Site B"}},
+	}
+
+	role, sites, ok := ResolveRole(mapping, []string{"cn=readers", "cn=admins"})
+	assert.True(t, ok)
+	assert.Equal(t, entity.AdminRoleSuper, role, "should use the first matched group and default to the super admin")
+	assert.Nil(t, sites, "should not restrict the sites of super admin")
+
+	role, sites, ok = ResolveRole(mapping, []string{"CN=Readers"})
+	assert.True(t, ok)
+	assert.Equal(t, entity.AdminRoleReadOnly, role)
+	assert.Equal(t, []string{"Site B"}, sites)
+
+	_, _, ok = ResolveRole(mapping, []string{"cn=invalid"})
+	assert.False(t, ok, "should skip the invalid role")
+}
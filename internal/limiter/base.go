@@ -1,7 +1,7 @@
 package limiter
 
 import (
-	"github.com/artalkjs/artalk/v2/internal/cache/simple_cache"
+	"github.com/artalkjs/artalk/v2/internal/store"
 )
 
 // 操作次数限制器（根据 IP）
@@ -22,23 +22,35 @@ import (
 //     当用户发表评论后，调用 Log 操作次数 +1，记录最后操作时间
 type Limiter struct {
 	conf  *LimiterConf
-	store *simple_cache.Cache // simple_cache is a thread-safe in-memory cache
+	store store.Store
 }
 
 type LimiterConf struct {
 	AlwaysMode          bool // 总是需要验证码模式 (允许零次操作时开启)
 	MaxActionDuringTime int  // 时间范围内允许多少次操作 (激活验证码所需调用 Log 函数次数)
 	ResetTimeout        int  // 重置超时，单位 s (当你想当评论 n 次后一直需要验证码，可以将时间设置得足够大，或是 -1)
+
+	// 自适应模式：判断请求是否可疑 (设置后仅对可疑的请求总是要求验证码，AlwaysMode 失效)
+	IsRisky func(ip string) bool
+
+	// 操作记录的存储 (集群模式下由多个实例共享，为空时使用内存存储)
+	Store store.Store
 }
 
 func NewLimiter(conf *LimiterConf) *Limiter {
-	if conf.MaxActionDuringTime <= 0 {
+	if conf.IsRisky != nil {
+		conf.AlwaysMode = false
+	} else if conf.MaxActionDuringTime <= 0 {
 		conf.AlwaysMode = true
 	}
 
+	if conf.Store == nil {
+		conf.Store = store.NewMemoryStore()
+	}
+
 	return &Limiter{
 		conf:  conf,
-		store: simple_cache.New(),
+		store: conf.Store,
 	}
 }
 
@@ -53,6 +65,18 @@ func (l *Limiter) IsPass(ip string) bool {
 		return l.isVerified(ip)
 	}
 
+	// =======================
+	//  自适应模式 (可疑的请求总是需要验证码)
+	// =======================
+	if l.conf.IsRisky != nil {
+		if l.conf.IsRisky(ip) {
+			return l.isVerified(ip)
+		}
+		if l.conf.MaxActionDuringTime <= 0 {
+			return true // 未配置操作次数限制，放行
+		}
+	}
+
 	// =======================
 	//  时间范围内允许多少次操作
 	// =======================
@@ -82,8 +106,8 @@ func (l *Limiter) Log(ip string) {
 
 // 重置操作记录
 func (l *Limiter) ResetLog(ip string) {
-	l.store.Delete(ActionTimeCachePrefix + ip)
-	l.store.Delete(ActionCountCachePrefix + ip)
+	_ = l.store.Delete(ActionTimeCachePrefix + ip)
+	_ = l.store.Delete(ActionCountCachePrefix + ip)
 }
 
 // 验证成功操作
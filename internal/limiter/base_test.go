@@ -120,4 +120,37 @@ func TestLimiter(t *testing.T) {
 			assertPrevent(t, limiter)
 		}
 	})
+
+	t.Run("AdaptiveMode", func(t *testing.T) {
+		risky := false
+		limiter := NewLimiter(&LimiterConf{
+			AlwaysMode: true, // should be ignored
+			IsRisky:    func(ip string) bool { return risky },
+		})
+
+		// not risky request is always passed
+		for i := 0; i < 100; i++ {
+			assertPassAndLog(t, limiter)
+		}
+
+		// risky request need verify
+		risky = true
+		assertPrevent(t, limiter)
+
+		limiter.MarkVerifyPassed(myIP)
+		assertPassAndLog(t, limiter)
+		assertPrevent(t, limiter)
+
+		t.Run("With Action Limit", func(t *testing.T) {
+			risky := false
+			limiter := NewLimiter(&LimiterConf{
+				MaxActionDuringTime: 2,
+				IsRisky:             func(ip string) bool { return risky },
+			})
+
+			assertPassAndLog(t, limiter)
+			assertPassAndLog(t, limiter)
+			assertPrevent(t, limiter)
+		})
+	})
 }
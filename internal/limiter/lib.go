@@ -11,6 +11,17 @@ const (
 	AlwaysModeVerifiedCachePrefix = "captcha-am-verified:"
 )
 
+// 操作记录的最短保存时间 (超过重置超时的记录已无意义，但至少保留一天的验证状态)
+const minRecordTTL = 24 * time.Hour
+
+// 操作记录的保存时间 (从不重置时永久保存)
+func (l *Limiter) recordTTL() time.Duration {
+	if l.conf.ResetTimeout <= 0 {
+		return 0
+	}
+	return max(time.Duration(l.conf.ResetTimeout)*time.Second, minRecordTTL)
+}
+
 // 现在距离上一次操作，是否超时，需要重置
 func (l *Limiter) isTimeoutSinceLastAction(ip string) bool {
 	if l.conf.ResetTimeout <= 0 {
@@ -23,14 +34,14 @@ func (l *Limiter) isTimeoutSinceLastAction(ip string) bool {
 
 // 修改最后操作时间
 func (l *Limiter) logTime(ip string) {
-	l.store.Set(ActionTimeCachePrefix+ip, strconv.FormatInt(time.Now().Unix(), 10))
+	_ = l.store.Set(ActionTimeCachePrefix+ip, strconv.FormatInt(time.Now().Unix(), 10), l.recordTTL())
 }
 
 // 获取最后操作时间
 func (l *Limiter) getLastTime(ip string) time.Time {
 	var timestamp int64
 	if val, found := l.store.Get(ActionTimeCachePrefix + ip); found {
-		timestamp, _ = strconv.ParseInt(val.(string), 10, 64)
+		timestamp, _ = strconv.ParseInt(val, 10, 64)
 	}
 	return time.Unix(timestamp, 0)
 }
@@ -38,7 +49,7 @@ func (l *Limiter) getLastTime(ip string) time.Time {
 // 获取操作次数
 func (l *Limiter) getCount(ip string) int {
 	if val, found := l.store.Get(ActionCountCachePrefix + ip); found {
-		count, _ := strconv.Atoi(val.(string))
+		count, _ := strconv.Atoi(val)
 		return count
 	}
 	return 0
@@ -46,7 +57,7 @@ func (l *Limiter) getCount(ip string) int {
 
 // 修改操作次数
 func (l *Limiter) setCount(ip string, num int) {
-	l.store.Set(ActionCountCachePrefix+ip, strconv.Itoa(num))
+	_ = l.store.Set(ActionCountCachePrefix+ip, strconv.Itoa(num), l.recordTTL())
 }
 
 // 操作次数 +1
@@ -66,5 +77,5 @@ func (l *Limiter) setVerified(ip string, pass bool) {
 	if pass {
 		val = "1"
 	}
-	l.store.Set(AlwaysModeVerifiedCachePrefix+ip, val)
+	_ = l.store.Set(AlwaysModeVerifiedCachePrefix+ip, val, l.recordTTL())
 }
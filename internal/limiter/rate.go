@@ -0,0 +1,36 @@
+package limiter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/store"
+)
+
+// 固定窗口的频率限制 (窗口为 1 分钟)
+//
+// 计数保存于存储中，集群模式下由多个实例共享
+type RateLimiter struct {
+	prefix string // 存储键的前缀
+	store  func() store.Store
+}
+
+func NewRateLimiter(prefix string, store func() store.Store) *RateLimiter {
+	return &RateLimiter{prefix: prefix, store: store}
+}
+
+// 判断 key (例如 IP) 在当前窗口内的请求数是否未超过 limit，limit 为 0 时不限制
+func (r *RateLimiter) Allow(key string, limit int, now time.Time) bool {
+	if limit <= 0 {
+		return true // unlimited
+	}
+
+	storeKey := fmt.Sprintf("%s:%s:%d", r.prefix, key, now.Unix()/60)
+	count, err := r.store().Incr(storeKey, 1, 2*time.Minute)
+	if err != nil {
+		log.Error("[Limiter] Failed to count the requests: ", err)
+		return true
+	}
+	return count <= int64(limit)
+}
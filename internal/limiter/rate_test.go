@@ -0,0 +1,23 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	s := store.NewMemoryStore()
+	r := NewRateLimiter("test_rate", func() store.Store { return s })
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, r.Allow("127.0.0.1", 3, now))
+	}
+	assert.False(t, r.Allow("127.0.0.1", 3, now), "should block the requests over limit")
+	assert.True(t, r.Allow("127.0.0.2", 3, now), "should limit per key")
+	assert.True(t, r.Allow("127.0.0.1", 3, now.Add(time.Minute)), "should reset after the window")
+	assert.True(t, NewRateLimiter("other_rate", func() store.Store { return s }).Allow("127.0.0.1", 3, now), "should count by the prefix")
+}
@@ -0,0 +1,37 @@
+package limiter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 风险信号 (用于自适应验证码模式)
+type RiskSignals struct {
+	NewIP          bool    // IP 没有通过审核的评论
+	RecentComments int     // 时间窗口内 IP 的评论数
+	SpamScore      float64 // 时间窗口内 IP 评论的最高反垃圾评分 (范围 0~1)
+}
+
+// 风险判断规则
+type RiskConf struct {
+	NewIP       bool    // 新 IP 视为可疑
+	MaxComments int     // 时间窗口内评论数达到该值视为可疑 (0 为不检测)
+	GreyScore   float64 // 反垃圾评分达到该值视为可疑 (0 为不检测)
+}
+
+// 判断请求是否可疑，并返回原因
+func (conf *RiskConf) Evaluate(signals RiskSignals) (bool, string) {
+	reasons := []string{}
+
+	if conf.NewIP && signals.NewIP {
+		reasons = append(reasons, "new ip")
+	}
+	if conf.MaxComments > 0 && signals.RecentComments >= conf.MaxComments {
+		reasons = append(reasons, fmt.Sprintf("%d recent comments", signals.RecentComments))
+	}
+	if conf.GreyScore > 0 && signals.SpamScore >= conf.GreyScore {
+		reasons = append(reasons, fmt.Sprintf("spam score %.2f", signals.SpamScore))
+	}
+
+	return len(reasons) > 0, strings.Join(reasons, ", ")
+}
@@ -0,0 +1,36 @@
+package limiter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskConf(t *testing.T) {
+	conf := &RiskConf{NewIP: true, MaxComments: 3, GreyScore: 0.5}
+
+	tests := []struct {
+		name    string
+		signals RiskSignals
+		risky   bool
+		reason  string
+	}{
+		{"Normal", RiskSignals{RecentComments: 2, SpamScore: 0.3}, false, ""},
+		{"NewIP", RiskSignals{NewIP: true}, true, "new ip"},
+		{"RecentComments", RiskSignals{RecentComments: 3}, true, "3 recent comments"},
+		{"SpamScore", RiskSignals{SpamScore: 0.5}, true, "spam score 0.50"},
+		{"Multiple", RiskSignals{NewIP: true, SpamScore: 0.8}, true, "new ip, spam score 0.80"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risky, reason := conf.Evaluate(tt.signals)
+			assert.Equal(t, tt.risky, risky)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		risky, _ := (&RiskConf{}).Evaluate(RiskSignals{NewIP: true, RecentComments: 100, SpamScore: 1})
+		assert.False(t, risky, "should not be risky if all rules are disabled")
+	})
+}
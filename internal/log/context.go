@@ -0,0 +1,48 @@
+package log
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type requestIDKey struct{}
+
+// Get the context with the request ID, which is added to the logs by `Ctx`
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// Get the request ID of the context, empty if not found
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Ctx returns the logger with the request ID and the trace ID of the context (if any),
+// to find the logs of the request (e.g. the checkers and the notifications of the comment).
+//
+//	log.Ctx(ctx).Error("[AntiSpam] ", err)
+func Ctx(ctx context.Context) *zap.SugaredLogger {
+	// the caller is skipped by the exported functions, which is not needed here
+	l := zap.L().WithOptions(zap.AddCallerSkip(-1))
+	if ctx == nil {
+		return l.Sugar()
+	}
+
+	fields := []zap.Field{}
+	if id := RequestID(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+	}
+	return l.With(fields...).Sugar()
+}
@@ -1,6 +1,8 @@
 package log
 
 import (
+	"os"
+	"strings"
 	"time"
 
 	"github.com/mattn/go-colorable"
@@ -20,8 +22,31 @@ type Options struct {
 	IsDiscard bool   // !config.Instance.Log.Enabled
 	IsDebug   bool   // config.Instance.Debug
 	LogFile   string // config.Instance.Log.Filename
+
+	Format  string            // config.Instance.Log.Format ("text" or "json" of the console logs)
+	Level   string            // config.Instance.Log.Level (the default level, "debug" in debug mode if empty)
+	Modules map[string]string // config.Instance.Log.Modules (the levels by the module tag of messages)
+
+	// The rotation of log file (the default is used if zero)
+	MaxSize    int  // config.Instance.Log.MaxSize (MB)
+	MaxBackups int  // config.Instance.Log.MaxBackups
+	MaxAge     int  // config.Instance.Log.MaxAge (days)
+	NoCompress bool // !config.Instance.Log.Compress
 }
 
+// The formats of console logs
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// The defaults of log file rotation
+const (
+	defaultMaxSize    = 500 // MB
+	defaultMaxBackups = 30  // the maximum number of old log files to retain
+	defaultMaxAge     = 15  // the maximum number of days to retain old log files
+)
+
 func Init(options ...Options) *zap.Logger {
 	var opts Options
 	if len(options) > 0 {
@@ -43,11 +68,13 @@ func Init(options ...Options) *zap.Logger {
 
 	core := newZapCore(zapInitParams{
 		logFile:    opts.LogFile,
-		logLevel:   lo.If(opts.IsDebug, zapcore.DebugLevel).Else(zapcore.InfoLevel),
-		maxSize:    500, // MB
-		maxBackups: 30,  // the maximum number of old log files to retain
-		maxAge:     15,  // the maximum number of days to retain old log files based on the
-		compress:   true,
+		logLevel:   parseLevel(opts.Level, lo.If(opts.IsDebug, zapcore.DebugLevel).Else(zapcore.InfoLevel)),
+		modules:    parseModuleLevels(opts.Modules),
+		json:       strings.EqualFold(strings.TrimSpace(opts.Format), FormatJSON),
+		maxSize:    lo.If(opts.MaxSize > 0, opts.MaxSize).Else(defaultMaxSize),
+		maxBackups: lo.If(opts.MaxBackups > 0, opts.MaxBackups).Else(defaultMaxBackups),
+		maxAge:     lo.If(opts.MaxAge > 0, opts.MaxAge).Else(defaultMaxAge),
+		compress:   !opts.NoCompress,
 	})
 
 	zapOpts := []zap.Option{
@@ -73,6 +100,8 @@ func customCallerEncoder(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayE
 type zapInitParams struct {
 	logFile    string
 	logLevel   zapcore.Level
+	modules    map[string]zapcore.Level
+	json       bool
 	maxSize    int
 	maxBackups int
 	maxAge     int
@@ -80,9 +109,9 @@ type zapInitParams struct {
 }
 
 func newZapCore(p zapInitParams) zapcore.Core {
-	// set log level
+	// set log level (the minimum of the default and the module levels, which are filtered by `moduleCore`)
 	atomicLevel := zap.NewAtomicLevel()
-	atomicLevel.SetLevel(p.logLevel)
+	atomicLevel.SetLevel(minLevel(p.logLevel, p.modules))
 
 	// set encoder
 	encoderConfig := zap.NewProductionEncoderConfig()
@@ -92,22 +121,29 @@ func newZapCore(p zapInitParams) zapcore.Core {
 	encoderConfig.EncodeName = zapcore.FullNameEncoder
 	encoderConfig.ConsoleSeparator = " "
 
-	// Log to console
+	jsonEncoderConfig := encoderConfig
+	jsonEncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	jsonEncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder // disable color
+
+	// Log to console (the JSON format is for the log collectors, e.g. Loki and ELK)
+	consoleCore := zapcore.NewCore(
+		zapcore.NewConsoleEncoder(encoderConfig),
+		zapcore.AddSync(colorable.NewColorableStdout()),
+		atomicLevel)
+	if p.json {
+		consoleCore = zapcore.NewCore(
+			zapcore.NewJSONEncoder(jsonEncoderConfig),
+			zapcore.Lock(zapcore.AddSync(os.Stdout)),
+			atomicLevel)
+	}
 	methods := []zapcore.Core{
-		zapcore.NewCore(
-			zapcore.NewConsoleEncoder(encoderConfig),
-			zapcore.AddSync(colorable.NewColorableStdout()),
-			atomicLevel),
+		newModuleCore(consoleCore, p.logLevel, p.modules, p.json),
 	}
 
 	// Log to file
 	if p.logFile != "" {
-		fileEncoderConfig := encoderConfig
-		fileEncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		fileEncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder // disable color
-
-		methods = append(methods, zapcore.NewCore(
-			zapcore.NewJSONEncoder(fileEncoderConfig), // write json format
+		methods = append(methods, newModuleCore(zapcore.NewCore(
+			zapcore.NewJSONEncoder(jsonEncoderConfig), // write json format
 			zapcore.AddSync(&lumberjack.Logger{
 				Filename:   p.logFile,
 				MaxSize:    p.maxSize,
@@ -115,7 +151,7 @@ func newZapCore(p zapInitParams) zapcore.Core {
 				MaxAge:     p.maxAge,
 				Compress:   p.compress,
 			}),
-			atomicLevel))
+			atomicLevel), p.logLevel, p.modules, true))
 	}
 
 	return zapcore.NewTee(methods...)
@@ -0,0 +1,82 @@
+package log
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// The core to filter the logs by the level of module,
+// the module is the tag at the beginning of message, e.g. "AntiSpam" of "[AntiSpam] ..."
+type moduleCore struct {
+	zapcore.Core
+
+	level     zapcore.Level            // the default level
+	modules   map[string]zapcore.Level // the levels by the lowercase module name
+	addModule bool                     // add the module as the field (for the JSON format)
+}
+
+func newModuleCore(core zapcore.Core, level zapcore.Level, modules map[string]zapcore.Level, addModule bool) zapcore.Core {
+	return &moduleCore{Core: core, level: level, modules: modules, addModule: addModule}
+}
+
+func (c *moduleCore) With(fields []zapcore.Field) zapcore.Core {
+	return &moduleCore{Core: c.Core.With(fields), level: c.level, modules: c.modules, addModule: c.addModule}
+}
+
+func (c *moduleCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	level := c.level
+	if l, ok := c.modules[strings.ToLower(Module(ent.Message))]; ok {
+		level = l
+	}
+	if ent.Level < level {
+		return ce
+	}
+	return ce.AddCore(ent, c)
+}
+
+func (c *moduleCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if module := Module(ent.Message); c.addModule && module != "" {
+		fields = append(fields, zap.String("module", module))
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// Get the module tag at the beginning of message, e.g. "AntiSpam" of "[AntiSpam] [AI] ...", empty if no tag
+func Module(msg string) string {
+	if !strings.HasPrefix(msg, "[") {
+		return ""
+	}
+	end := strings.IndexByte(msg, ']')
+	if end <= 1 || strings.ContainsAny(msg[1:end], " \n") {
+		return ""
+	}
+	return msg[1:end]
+}
+
+// Parse the level name (e.g. "debug", "warn"), the fallback is returned if it is empty or invalid
+func parseLevel(name string, fallback zapcore.Level) zapcore.Level {
+	level, err := zapcore.ParseLevel(strings.TrimSpace(name))
+	if strings.TrimSpace(name) == "" || err != nil {
+		return fallback
+	}
+	return level
+}
+
+func parseModuleLevels(modules map[string]string) map[string]zapcore.Level {
+	levels := map[string]zapcore.Level{}
+	for module, name := range modules {
+		if level, err := zapcore.ParseLevel(strings.TrimSpace(name)); err == nil && strings.TrimSpace(name) != "" {
+			levels[strings.ToLower(strings.Trim(strings.TrimSpace(module), "[]"))] = level
+		}
+	}
+	return levels
+}
+
+func minLevel(level zapcore.Level, modules map[string]zapcore.Level) zapcore.Level {
+	for _, l := range modules {
+		level = min(level, l)
+	}
+	return level
+}
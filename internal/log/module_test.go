@@ -0,0 +1,65 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestModule(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"[AntiSpam] [AI] Moderation response", "AntiSpam"},
+		{"[HTTP] GET /api/v2/comments", "HTTP"},
+		{"[] empty", ""},
+		{"[not a tag] message", ""},
+		{"no tag", ""},
+		{"[unclosed", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Module(tt.msg), tt.msg)
+	}
+}
+
+func TestModuleCore(t *testing.T) {
+	modules := parseModuleLevels(map[string]string{"[AntiSpam]": "debug", "HTTP": "error", "Notify": "invalid"})
+	assert.Equal(t, map[string]zapcore.Level{"antispam": zapcore.DebugLevel, "http": zapcore.ErrorLevel}, modules)
+
+	obs, logs := observer.New(minLevel(zapcore.InfoLevel, modules))
+	logger := zap.New(newModuleCore(obs, zapcore.InfoLevel, modules, true)).Sugar()
+
+	logger.Debug("[AntiSpam] shown by the module level")
+	logger.Debug("[Notify] hidden by the default level")
+	logger.Info("[HTTP] hidden by the module level")
+	logger.Error("[HTTP] shown")
+	logger.Info("shown without module")
+
+	assert.Equal(t, []string{"[AntiSpam] shown by the module level", "[HTTP] shown", "shown without module"},
+		lo.Map(logs.All(), func(e observer.LoggedEntry, _ int) string { return e.Message }))
+	assert.Equal(t, "AntiSpam", logs.All()[0].ContextMap()["module"])
+	assert.NotContains(t, logs.All()[2].ContextMap(), "module")
+}
+
+func TestCtx(t *testing.T) {
+	obs, logs := observer.New(zapcore.DebugLevel)
+	prev := zap.L()
+	zap.ReplaceGlobals(zap.New(obs))
+	defer zap.ReplaceGlobals(prev)
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	assert.Equal(t, "req-1", RequestID(ctx))
+	assert.Equal(t, "", RequestID(context.Background()))
+
+	Ctx(ctx).Info("[Notify] with the request ID")
+	Ctx(context.Background()).Info("[Notify] without the request ID")
+
+	assert.Equal(t, "req-1", logs.All()[0].ContextMap()["request_id"])
+	assert.NotContains(t, logs.All()[1].ContextMap(), "request_id")
+}
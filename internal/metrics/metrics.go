@@ -0,0 +1,99 @@
+// The Prometheus metrics of the server (e.g. the request latencies and the comment events),
+// which are exposed by the `/metrics` endpoint if the `metrics` config is enabled.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const Namespace = "artalk"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requestDuration *prometheus.HistogramVec
+	commentEvents   *prometheus.CounterVec
+	checkerDuration *prometheus.HistogramVec
+	checkerErrors   *prometheus.CounterVec
+}
+
+// Create the metrics with the registry of its own (instead of the global one),
+// the collectors of the states (e.g. the database and the cache) can be registered later
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "The latencies of HTTP requests by the route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+
+		commentEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "comment_events_total",
+			Help:      "The number of comment events (created, approved, spam and deleted).",
+		}, []string{"event"}),
+
+		checkerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "antispam_checker_duration_seconds",
+			Help:      "The latencies of anti-spam checkers.",
+			Buckets:   []float64{.005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"checker"}),
+
+		checkerErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "antispam_checker_errors_total",
+			Help:      "The number of anti-spam checker errors (e.g. the API is unavailable).",
+		}, []string{"checker"}),
+	}
+
+	m.registry.MustRegister(
+		m.requestDuration,
+		m.commentEvents,
+		m.checkerDuration,
+		m.checkerErrors,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Register the collectors of metrics, it panics if the metrics are registered already
+func (m *Metrics) MustRegister(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+// Get the HTTP handler of metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Record the latency of HTTP request,
+// the route is the path pattern (e.g. "/api/v2/comments/:id") to keep the number of labels bounded
+func (m *Metrics) ObserveRequest(method string, route string, status int, latency time.Duration) {
+	m.requestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(latency.Seconds())
+}
+
+// Count the comment event, the event is the name of webhook event (e.g. "comment.created")
+func (m *Metrics) CountCommentEvent(event string) {
+	m.commentEvents.WithLabelValues(strings.TrimPrefix(event, "comment.")).Inc()
+}
+
+// Record the latency and the error of anti-spam checker
+func (m *Metrics) ObserveChecker(checker string, latency time.Duration, err error) {
+	m.checkerDuration.WithLabelValues(checker).Observe(latency.Seconds())
+	if err != nil {
+		m.checkerErrors.WithLabelValues(checker).Inc()
+	}
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	m.ObserveRequest("GET", "/api/v2/comments", 200, 50*time.Millisecond)
+	m.CountCommentEvent("comment.created")
+	m.CountCommentEvent("comment.created")
+	m.CountCommentEvent("comment.spam")
+	m.ObserveChecker("akismet", time.Second, nil)
+	m.ObserveChecker("akismet", time.Second, errors.New("timeout"))
+	m.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{Namespace: Namespace, Name: "test_gauge", Help: "Test."},
+		func() float64 { return 42 }))
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	assert.Equal(t, 200, rec.Code)
+	for _, line := range []string{
+		`artalk_http_request_duration_seconds_count{method="GET",route="/api/v2/comments",status="200"} 1`,
+		`artalk_comment_events_total{event="created"} 2`,
+		`artalk_comment_events_total{event="spam"} 1`,
+		`artalk_antispam_checker_duration_seconds_count{checker="akismet"} 2`,
+		`artalk_antispam_checker_errors_total{checker="akismet"} 1`,
+		`artalk_test_gauge 42`,
+		`go_goroutines`,
+	} {
+		assert.Contains(t, string(body), line)
+	}
+}
@@ -7,10 +7,9 @@ import (
 	"github.com/artalkjs/artalk/v2/internal/dao"
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/template"
 	"github.com/nikoksr/notify"
-	"github.com/nikoksr/notify/service/dingding"
 	"github.com/nikoksr/notify/service/line"
-	"github.com/nikoksr/notify/service/slack"
 	"github.com/nikoksr/notify/service/telegram"
 )
 
@@ -20,21 +19,30 @@ type NotifyPusherConf struct {
 
 	// Provide a custom function to bridge the gap between Notify pusher and Email pusher
 	EmailPush func(notify *entity.Notify) error
+
+	// Provide a custom function to push the notify to the browsers of receiver by Web Push
+	WebPush func(notify *entity.Notify)
+
+	// The custom templates of the channels
+	Templates *template.CustomTemplates
+
+	// Provide a custom function to get the avatar URL by the email hash (for the rich messages)
+	AvatarURL func(emailHash string) string
 }
 
 type NotifyPusher struct {
-	conf   *NotifyPusherConf
-	dao    *dao.Dao
-	ctx    context.Context
-	helper *notify.Notify
+	conf    *NotifyPusherConf
+	dao     *dao.Dao
+	ctx     context.Context
+	helpers map[string]*notify.Notify // The Notify library services by the channel (rendered with the template of channel)
 }
 
 func NewNotifyPusher(conf *NotifyPusherConf) *NotifyPusher {
 	pusher := &NotifyPusher{
-		conf:   conf,
-		dao:    conf.Dao,
-		ctx:    context.Background(),
-		helper: notify.New(),
+		conf:    conf,
+		dao:     conf.Dao,
+		ctx:     context.Background(),
+		helpers: map[string]*notify.Notify{},
 	}
 
 	pusher.loadHelper()
@@ -42,44 +50,43 @@ func NewNotifyPusher(conf *NotifyPusherConf) *NotifyPusher {
 	return pusher
 }
 
+// Get the pusher bound to ctx (e.g. the detached context of the request), for the logs and the queries of the pushes
+func (pusher *NotifyPusher) WithContext(ctx context.Context) *NotifyPusher {
+	p := *pusher
+	p.ctx = ctx
+	if pusher.dao != nil {
+		p.dao = pusher.dao.WithContext(ctx)
+	}
+	return &p
+}
+
 func (pusher *NotifyPusher) loadHelper() {
 	var (
-		helper = pusher.helper
-		conf   = pusher.conf
+		conf = pusher.conf
+		use  = func(channel string, service notify.Notifier) {
+			helper := notify.New()
+			helper.UseServices(service)
+			pusher.helpers[channel] = helper
+		}
 	)
 
-	// Telegram
+	// Telegram (sent with the moderation buttons by `sendTelegramWithButtons` if enabled)
 	tgConf := conf.Telegram
-	if tgConf.Enabled {
+	if tgConf.Enabled && !tgConf.Moderation {
 		if telegramService, err := telegram.New(tgConf.ApiToken); err == nil {
 			telegramService.AddReceivers(tgConf.Receivers...)
-			helper.UseServices(telegramService)
+			use(template.ChannelTelegram, telegramService)
 		} else {
 			log.Error("[Notify] Telegram service init error: ", err)
 		}
 	}
 
-	// 钉钉
-	dingTalkConf := conf.DingTalk
-	if dingTalkConf.Enabled {
-		dingTalkService := dingding.New(&dingding.Config{Token: dingTalkConf.Token, Secret: dingTalkConf.Secret})
-		helper.UseServices(dingTalkService)
-	}
-
-	// Slack
-	slackConf := conf.Slack
-	if slackConf.Enabled {
-		slackService := slack.New(slackConf.OauthToken)
-		slackService.AddReceivers(slackConf.Receivers...)
-		helper.UseServices(slackService)
-	}
-
 	// LINE
 	LINEConf := conf.LINE
 	if LINEConf.Enabled {
 		if lineService, err := line.New(pusher.conf.LINE.ChannelSecret, pusher.conf.LINE.ChannelAccessToken); err == nil {
 			lineService.AddReceivers(LINEConf.Receivers...)
-			helper.UseServices(lineService)
+			use(template.ChannelLINE, lineService)
 		} else {
 			log.Error("[Notify] LINE service init error: ", err)
 		}
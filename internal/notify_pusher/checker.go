@@ -20,6 +20,11 @@ func (pusher *NotifyPusher) checkNeedSendEmailToUser(comment *entity.Comment, pa
 		return false
 	}
 
+	// 对方个人设定关闭回复通知
+	if !pusher.dao.FetchUserForComment(parentComment).NotifyReply {
+		return false
+	}
+
 	// 对方是管理员，但是管理员邮件接收关闭 (用于开启多元推送后禁用邮件通知管理员)
 	if pusher.dao.FetchUserForComment(parentComment).IsAdmin && !pusher.conf.Email.Enabled {
 		return false
@@ -69,6 +74,40 @@ func (pusher *NotifyPusher) checkNeedSendEmailToAdmin(comment *entity.Comment, p
 	return true
 }
 
+func (pusher *NotifyPusher) checkNeedSendEmailToMentioned(comment *entity.Comment, pComment *entity.Comment, user *entity.User) bool {
+	// 自己提及自己，不提醒
+	if comment.UserID == user.ID {
+		return false
+	}
+
+	// 待审评论不通知 (管理员审核通过后才发送)
+	if comment.IsPending {
+		return false
+	}
+
+	// 已经作为回复对象或管理员收到了该评论的通知
+	if (pComment != nil && pComment.UserID == user.ID) || !pusher.dao.FindNotify(user.ID, comment.ID).IsEmpty() {
+		return false
+	}
+
+	// 对方个人设定关闭邮件接收
+	if !user.ReceiveEmail {
+		return false
+	}
+
+	// 对方个人设定关闭提及通知
+	if !user.NotifyMention {
+		return false
+	}
+
+	// 对方是管理员，但是管理员邮件接收关闭
+	if user.IsAdmin && !pusher.conf.Email.Enabled {
+		return false
+	}
+
+	return true
+}
+
 func (pusher *NotifyPusher) checkNeedMultiPush(comment *entity.Comment, pComment *entity.Comment) bool {
 	isRootComment := pComment == nil || pComment.IsEmpty()
 
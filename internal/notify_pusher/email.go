@@ -14,7 +14,7 @@ func (pusher *NotifyPusher) sendEmail(notify *entity.Notify) {
 
 func (pusher *NotifyPusher) emailToUser(comment *entity.Comment, pComment *entity.Comment) {
 	if !pusher.checkNeedSendEmailToUser(comment, pComment) {
-		log.Debug("ignore email notify by pusher.checkNeedSendEmailToUser")
+		log.Ctx(pusher.ctx).Debug("ignore email notify by pusher.checkNeedSendEmailToUser")
 		return
 	}
 
@@ -35,7 +35,7 @@ func (pusher *NotifyPusher) emailToAdmins(comment *entity.Comment, pComment *ent
 		toAddrSent = append(toAddrSent, admin.Email)
 
 		if !pusher.checkNeedSendEmailToAdmin(comment, pComment, &admin) {
-			log.Debug("ignore email notify by pusher.checkNeedSendEmailToAdmin")
+			log.Ctx(pusher.ctx).Debug("ignore email notify by pusher.checkNeedSendEmailToAdmin")
 			continue
 		}
 
@@ -0,0 +1,33 @@
+package notify_pusher
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+// 单条评论最多通知的提及数 (避免滥用提及骚扰用户)
+const maxMentions = 10
+
+// 通知评论中提及的用户 (@username)
+//
+// Only the participants of the page can be mentioned.
+func (pusher *NotifyPusher) emailToMentioned(comment *entity.Comment, pComment *entity.Comment) {
+	names := utils.ParseMentions(comment.Content)
+	if len(names) > maxMentions {
+		names = names[:maxMentions]
+	}
+
+	for _, user := range pusher.dao.FindPageParticipantsByNames(comment.SiteName, comment.PageKey, names) {
+		if !pusher.checkNeedSendEmailToMentioned(comment, pComment, &user) {
+			log.Ctx(pusher.ctx).Debug("ignore email notify by pusher.checkNeedSendEmailToMentioned")
+			continue
+		}
+
+		notify := pusher.dao.FindCreateNotify(user.ID, comment.ID)
+		pusher.dao.NotifySetInitial(&notify)
+
+		// 邮件通知
+		pusher.sendEmail(&notify)
+	}
+}
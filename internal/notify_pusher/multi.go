@@ -19,44 +19,116 @@ func (pusher *NotifyPusher) multiPush(comment *entity.Comment, pComment *entity.
 	// 通知消息
 	allAdmins := pusher.dao.GetAllAdminIDs()
 	if len(allAdmins) == 0 {
-		log.Warn("Multi push disabled: no admin user found")
+		log.Ctx(pusher.ctx).Warn("Multi push disabled: no admin user found")
 		return
 	}
 
 	firstAdminUser := allAdmins[0]
-	subject, body := pusher.getAdminNotifySubjectBody(comment, firstAdminUser)
 
-	log.Debug(time.Now(), " 多元推送")
+	log.Ctx(pusher.ctx).Debug(time.Now(), " 多元推送")
 
 	// 使用 Notify 库发送
-	if err := pusher.helper.Send(pusher.ctx, subject, html.EscapeString(body)); err != nil {
-		log.Error("[Notify]", err)
+	for channel, helper := range pusher.helpers {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, firstAdminUser, channel)
+		if err := helper.Send(pusher.ctx, subject, html.EscapeString(body)); err != nil {
+			log.Ctx(pusher.ctx).Error("[Notify]", err)
+		}
+	}
+
+	// Telegram 审核按钮
+	if pusher.conf.Telegram.Enabled && pusher.conf.Telegram.Moderation {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, firstAdminUser, template.ChannelTelegram)
+		pusher.sendTelegramWithButtons(subject, body, comment)
+	}
+
+	// Slack
+	if pusher.conf.Slack.Enabled {
+		pusher.sendSlack(pusher.getRichMessageText(comment, firstAdminUser, template.ChannelSlack), comment)
+	}
+
+	// Discord
+	if pusher.conf.Discord.Enabled {
+		pusher.sendDiscord(pusher.getRichMessageText(comment, firstAdminUser, template.ChannelDiscord), comment)
 	}
 
 	// 飞书
 	if pusher.conf.Lark.Enabled {
-		sender.SendLark(pusher.conf.Lark.WebhookURL, subject, body, pusher.conf.Lark.MsgType == "card")
+		pusher.sendLark(comment, firstAdminUser)
+	}
+
+	// 钉钉
+	if pusher.conf.DingTalk.Enabled {
+		pusher.sendDingTalk(comment, firstAdminUser)
+	}
+
+	// 企业微信
+	if pusher.conf.WeCom.Enabled {
+		pusher.sendWeCom(comment, firstAdminUser)
 	}
 
 	// Bark
 	if pusher.conf.Bark.Enabled {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, firstAdminUser, template.ChannelBark)
 		sender.SendBark(pusher.conf.Bark.Server, subject, body)
 	}
 
+	// ntfy
+	if pusher.conf.Ntfy.Enabled {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, firstAdminUser, template.ChannelNtfy)
+		conf := pusher.conf.Ntfy
+		if err := sender.SendNtfy(conf.Server, conf.Topic, conf.Token, pusher.getPushMessage(subject, body, comment)); err != nil {
+			log.Ctx(pusher.ctx).Error("[Notify] ntfy send error: ", err)
+		}
+	}
+
+	// Gotify
+	if pusher.conf.Gotify.Enabled {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, firstAdminUser, template.ChannelGotify)
+		conf := pusher.conf.Gotify
+		if err := sender.SendGotify(conf.Server, conf.Token, pusher.getPushMessage(subject, body, comment)); err != nil {
+			log.Ctx(pusher.ctx).Error("[Notify] Gotify send error: ", err)
+		}
+	}
+
+	// Pushover
+	if pusher.conf.Pushover.Enabled {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, firstAdminUser, template.ChannelPushover)
+		conf := pusher.conf.Pushover
+		if err := sender.SendPushover(conf.Token, conf.User, conf.Device, pusher.getPushMessage(subject, body, comment)); err != nil {
+			log.Ctx(pusher.ctx).Error("[Notify] Pushover send error: ", err)
+		}
+	}
+
+	// Matrix
+	if pusher.conf.Matrix.Enabled {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, firstAdminUser, template.ChannelMatrix)
+		conf := pusher.conf.Matrix
+		if err := sender.SendMatrix(conf.Homeserver, conf.AccessToken, conf.RoomID, pusher.getPushMessage(subject, body, comment)); err != nil {
+			log.Ctx(pusher.ctx).Error("[Notify] Matrix send error: ", err)
+		}
+	}
+
 	// WebHook
 	if pusher.conf.WebHook.Enabled {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, firstAdminUser, template.ChannelWebHook)
 		pusher.sendWebhook(subject, body, comment, pComment)
 	}
 }
 
-func (pusher *NotifyPusher) getAdminNotifySubjectBody(comment *entity.Comment, toUserID uint) (string, string) {
+func (pusher *NotifyPusher) getRenderer() *template.Renderer {
+	return template.NewRenderer(pusher.dao, template.TYPE_NOTIFY, template.NewFileLoader(pusher.conf.NotifyTpl)).
+		UseCustomTemplates(pusher.conf.Templates)
+}
+
+// Get the subject and body of the notification, the body is rendered with the custom template of channel if exists
+func (pusher *NotifyPusher) getAdminNotifySubjectBody(comment *entity.Comment, toUserID uint, channel string) (string, string) {
 	// 评论内容文字截断
 	// coContent := lib.TruncateString(comment.Content, 280)
 	// if len([]rune(coContent)) > 280 {
 	// 	coContent = coContent + "..."
 	// }
 
-	render := template.NewRenderer(pusher.dao, template.TYPE_NOTIFY, template.NewFileLoader(pusher.conf.NotifyTpl))
+	render := pusher.getRenderer()
 	notify := pusher.dao.FindCreateNotify(toUserID, comment.ID)
 
 	var subject string
@@ -64,6 +136,11 @@ func (pusher *NotifyPusher) getAdminNotifySubjectBody(comment *entity.Comment, t
 		subject = render.Render(&notify, pusher.conf.NotifySubject)
 	}
 
+	// the pending comment is rendered by the template of pending event
+	if body, ok := render.RenderCustom(&notify, channel); ok {
+		return subject, body
+	}
+
 	body := render.Render(&notify)
 	if comment.IsPending {
 		body = "[" + i18n.T("Pending") + "]\n\n" + body
@@ -72,6 +149,20 @@ func (pusher *NotifyPusher) getAdminNotifySubjectBody(comment *entity.Comment, t
 	return subject, body
 }
 
+// Get the text shown with the rich message, which is the custom template of channel if exists, or the subject
+func (pusher *NotifyPusher) getRichMessageText(comment *entity.Comment, toUserID uint, channel string) string {
+	render := pusher.getRenderer()
+	notify := pusher.dao.FindCreateNotify(toUserID, comment.ID)
+
+	if text, ok := render.RenderCustom(&notify, channel); ok {
+		return text
+	}
+	if pusher.conf.NotifySubject != "" {
+		return render.Render(&notify, pusher.conf.NotifySubject)
+	}
+	return ""
+}
+
 func (pusher *NotifyPusher) sendWebhook(subject string, body string, comment *entity.Comment, pComment *entity.Comment) {
 	var pCommentCooked entity.CookedComment
 	if pComment != nil && !pComment.IsEmpty() {
@@ -22,6 +22,16 @@ func (pusher *NotifyPusher) Push(comment *entity.Comment, pComment *entity.Comme
 		pusher.emailToAdmins(comment, pComment)
 	}
 
+	// ==============
+	//  邮件通知被提及的用户
+	// ==============
+	pusher.emailToMentioned(comment, pComment)
+
+	// ==============
+	//  浏览器推送回复和提及 (无需邮箱地址)
+	// ==============
+	pusher.webPushToUsers(comment, pComment)
+
 	// 管理员多元推送
 	pusher.multiPush(comment, pComment)
 }
@@ -0,0 +1,147 @@
+package notify_pusher
+
+import (
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/notify_pusher/sender"
+	"github.com/artalkjs/artalk/v2/internal/template"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+const richMessageExcerptLen = 280
+
+// Get the rich message of the comment for the IM platforms
+func (pusher *NotifyPusher) getRichMessage(subject string, comment *entity.Comment) *sender.RichMessage {
+	cooked := pusher.dao.CookComment(comment)
+	page := pusher.dao.FetchPageForComment(comment)
+
+	avatar := cooked.Avatar
+	if avatar == "" && pusher.conf.AvatarURL != nil {
+		avatar = pusher.conf.AvatarURL(cooked.EmailEncrypted)
+	}
+
+	excerpt := strings.TrimSpace(comment.Content)
+	if len([]rune(excerpt)) > richMessageExcerptLen {
+		excerpt = utils.TruncateString(excerpt, richMessageExcerptLen) + "..."
+	}
+
+	return &sender.RichMessage{
+		Subject:    subject,
+		AuthorName: cooked.Nick,
+		AuthorURL:  cooked.Link,
+		AvatarURL:  avatar,
+		PageTitle:  page.Title,
+		PageURL:    pusher.dao.GetPageAccessibleURL(&page),
+		SiteName:   comment.SiteName,
+		Excerpt:    excerpt,
+		CommentURL: pusher.dao.GetLinkToReplyByComment(comment),
+		IsPending:  comment.IsPending,
+		Date:       comment.CreatedAt,
+	}
+}
+
+// Get the plain text message of the comment for the mobile push services
+func (pusher *NotifyPusher) getPushMessage(subject string, body string, comment *entity.Comment) *sender.PushMessage {
+	return &sender.PushMessage{
+		Title:     subject,
+		Body:      body,
+		URL:       pusher.dao.GetLinkToReplyByComment(comment),
+		IsPending: comment.IsPending,
+	}
+}
+
+// Send the notification to the Slack channels of the comment site
+func (pusher *NotifyPusher) sendSlack(subject string, comment *entity.Comment) {
+	conf := pusher.conf.Slack
+
+	channels := conf.Receivers
+	for _, site := range conf.Sites {
+		if site.Site == comment.SiteName {
+			channels = site.Receivers
+			break
+		}
+	}
+	if len(channels) == 0 {
+		return
+	}
+
+	msg := pusher.getRichMessage(subject, comment)
+	for _, channel := range channels {
+		if err := sender.SendSlack(conf.OauthToken, channel, msg); err != nil {
+			log.Ctx(pusher.ctx).Error("[Notify] Slack send error: ", err)
+		}
+	}
+}
+
+// Send the notification to the Discord channel of the comment site
+func (pusher *NotifyPusher) sendDiscord(subject string, comment *entity.Comment) {
+	conf := pusher.conf.Discord
+
+	webhookURL := conf.WebhookURL
+	for _, site := range conf.Sites {
+		if site.Site == comment.SiteName {
+			webhookURL = site.WebhookURL
+			break
+		}
+	}
+	if webhookURL == "" {
+		return
+	}
+
+	if err := sender.SendDiscord(webhookURL, pusher.getRichMessage(subject, comment)); err != nil {
+		log.Ctx(pusher.ctx).Error("[Notify] Discord send error: ", err)
+	}
+}
+
+// Send the notification to the Lark group bot, as the text or the card message
+func (pusher *NotifyPusher) sendLark(comment *entity.Comment, toUserID uint) {
+	conf := pusher.conf.Lark
+
+	var err error
+	if conf.MsgType == "card" {
+		text := pusher.getRichMessageText(comment, toUserID, template.ChannelLark)
+		err = sender.SendLarkCard(conf.WebhookURL, pusher.getRichMessage(text, comment), conf.Mentions)
+	} else {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, toUserID, template.ChannelLark)
+		err = sender.SendLark(conf.WebhookURL, subject, body, conf.Mentions)
+	}
+	if err != nil {
+		log.Ctx(pusher.ctx).Error("[Notify] Lark send error: ", err)
+	}
+}
+
+// Send the notification to the DingTalk group bot, as the text or the card message
+func (pusher *NotifyPusher) sendDingTalk(comment *entity.Comment, toUserID uint) {
+	conf := pusher.conf.DingTalk
+
+	var err error
+	if conf.MsgType == "card" {
+		text := pusher.getRichMessageText(comment, toUserID, template.ChannelDingTalk)
+		err = sender.SendDingTalkCard(conf.Token, conf.Secret, pusher.getRichMessage(text, comment), conf.Mentions)
+	} else {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, toUserID, template.ChannelDingTalk)
+		err = sender.SendDingTalk(conf.Token, conf.Secret, subject, body, conf.Mentions)
+	}
+	if err != nil {
+		log.Ctx(pusher.ctx).Error("[Notify] DingTalk send error: ", err)
+	}
+}
+
+// Send the notification to the WeCom group bot, as the text or the card message
+func (pusher *NotifyPusher) sendWeCom(comment *entity.Comment, toUserID uint) {
+	conf := pusher.conf.WeCom
+
+	var err error
+	if conf.MsgType == "card" {
+		text := pusher.getRichMessageText(comment, toUserID, template.ChannelWeCom)
+		err = sender.SendWeComCard(conf.WebhookURL, pusher.getRichMessage(text, comment), conf.Mentions)
+	} else {
+		subject, body := pusher.getAdminNotifySubjectBody(comment, toUserID, template.ChannelWeCom)
+		err = sender.SendWeCom(conf.WebhookURL, subject, body, conf.Mentions)
+	}
+	if err != nil {
+		log.Ctx(pusher.ctx).Error("[Notify] WeCom send error: ", err)
+	}
+}
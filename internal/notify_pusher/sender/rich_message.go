@@ -0,0 +1,130 @@
+package sender
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+)
+
+// The notification of new comment, rendered as the rich message (embed, blocks, etc.) by the IM platforms
+type RichMessage struct {
+	Subject    string // The notify subject (optional)
+	AuthorName string
+	AuthorURL  string // The link of author (optional)
+	AvatarURL  string
+	PageTitle  string
+	PageURL    string
+	SiteName   string
+	Excerpt    string // The truncated comment content
+	CommentURL string // The link to the comment, where the admin can reply and moderate
+	IsPending  bool
+	Date       time.Time
+}
+
+// The title of the card message, which is the subject (if set) or the author and the page
+func (m *RichMessage) getCardTitle() string {
+	title := m.Subject
+	if title == "" {
+		pageTitle := m.PageTitle
+		if pageTitle == "" {
+			pageTitle = m.PageURL
+		}
+		title = m.AuthorName + " · " + pageTitle
+	}
+	if m.IsPending {
+		title = "[" + i18n.T("Pending") + "] " + title
+	}
+	return title
+}
+
+// The label of the button linked to the comment
+func (m *RichMessage) getActionText() string {
+	if m.IsPending {
+		return i18n.T("Review")
+	}
+	return i18n.T("Reply")
+}
+
+// The plain text notification for the mobile push services (ntfy, Gotify and Pushover)
+type PushMessage struct {
+	Title     string // The default title "Artalk" is used if empty
+	Body      string
+	URL       string // The link opened when the notification is clicked
+	IsPending bool   // The pending comment is pushed with the higher priority
+}
+
+func (m *PushMessage) getTitle() string {
+	if m.Title == "" {
+		return "Artalk"
+	}
+	return m.Title
+}
+
+// Split the users to mention into everyone ("all"), the mobile numbers and the user IDs
+func parseMentions(mentions []string) (all bool, mobiles []string, userIDs []string) {
+	for _, m := range mentions {
+		m = strings.TrimSpace(m)
+		switch {
+		case m == "":
+		case m == "all" || m == "@all":
+			all = true
+		case mobileRegexp.MatchString(m):
+			mobiles = append(mobiles, m)
+		default:
+			userIDs = append(userIDs, m)
+		}
+	}
+	return
+}
+
+var mobileRegexp = regexp.MustCompile(`^\+?\d{5,}$`)
+
+// Quote the multi-line text in markdown
+func quoteMarkdown(text string) string {
+	return "> " + strings.ReplaceAll(strings.TrimSpace(text), "\n", "\n> ")
+}
+
+var richMessageClient = &http.Client{Timeout: 10 * time.Second}
+
+func postJSON(endpoint string, header http.Header, payload any) ([]byte, error) {
+	return requestJSON("POST", endpoint, header, payload)
+}
+
+func requestJSON(method string, endpoint string, header http.Header, payload any) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := richMessageClient.Do(req)
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err // the token may be in the url (e.g. discord webhook), which should not be logged
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return respBody, fmt.Errorf("failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
@@ -0,0 +1,303 @@
+package sender
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func newTestRichMessage() *RichMessage {
+	return &RichMessage{
+		AuthorName: "userA",
+		AuthorURL:  "https://a.example.com",
+		AvatarURL:  "https://www.gravatar.com/avatar/abc",
+		PageTitle:  "Hello <World>",
+		PageURL:    "https://example.com/1.html",
+		SiteName:   "Site A",
+		Excerpt:    "line 1\nline 2",
+		CommentURL: "https://example.com/1.html?atk_comment=1",
+		IsPending:  true,
+		Date:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func newTestServer(t *testing.T, resp string, body *string, header *http.Header) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw json.RawMessage
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&raw))
+		*body = string(raw)
+		*header = r.Header
+		w.Write([]byte(resp))
+	}))
+}
+
+func TestSendDiscord(t *testing.T) {
+	var body string
+	var header http.Header
+	server := newTestServer(t, "", &body, &header)
+	defer server.Close()
+
+	assert.NoError(t, SendDiscord(server.URL, newTestRichMessage()))
+
+	embed := gjson.Get(body, "embeds.0")
+	assert.Equal(t, "userA", embed.Get("author.name").String())
+	assert.Equal(t, "https://www.gravatar.com/avatar/abc", embed.Get("author.icon_url").String())
+	assert.Equal(t, "[Pending] Hello <World>", embed.Get("title").String())
+	assert.Equal(t, "https://example.com/1.html?atk_comment=1", embed.Get("url").String())
+	assert.Equal(t, "line 1\nline 2", embed.Get("description").String())
+	assert.Equal(t, "[Hello <World>](https://example.com/1.html)", embed.Get("fields.0.value").String())
+	assert.Equal(t, "Site A", embed.Get("fields.1.value").String())
+	assert.Equal(t, "Review", embed.Get("fields.2.name").String())
+	assert.Equal(t, "2024-01-01T00:00:00Z", embed.Get("timestamp").String())
+	assert.Equal(t, 0, len(gjson.Get(body, "allowed_mentions.parse").Array()))
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(404)
+		}))
+		defer server.Close()
+
+		assert.Error(t, SendDiscord(server.URL, newTestRichMessage()))
+	})
+}
+
+func TestSendSlack(t *testing.T) {
+	var body string
+	var header http.Header
+	server := newTestServer(t, `{"ok":true}`, &body, &header)
+	defer server.Close()
+
+	defaultAPI := SlackAPI
+	SlackAPI = server.URL
+	defer func() { SlackAPI = defaultAPI }()
+
+	assert.NoError(t, SendSlack("TOKEN", "C123", newTestRichMessage()))
+	assert.Equal(t, "Bearer TOKEN", header.Get("Authorization"))
+	assert.Equal(t, "C123", gjson.Get(body, "channel").String())
+	assert.Equal(t, "userA: line 1\nline 2", gjson.Get(body, "text").String())
+	assert.Equal(t, "https://www.gravatar.com/avatar/abc", gjson.Get(body, "blocks.0.elements.0.image_url").String())
+	assert.Equal(t, "*[Pending]* *userA* · <https://example.com/1.html|Hello &lt;World&gt;>", gjson.Get(body, "blocks.0.elements.1.text").String())
+	assert.Equal(t, "> line 1\n> line 2", gjson.Get(body, "blocks.1.text.text").String())
+	assert.Equal(t, "https://example.com/1.html?atk_comment=1", gjson.Get(body, "blocks.3.elements.0.url").String())
+
+	t.Run("Error", func(t *testing.T) {
+		server := newTestServer(t, `{"ok":false,"error":"channel_not_found"}`, &body, &header)
+		defer server.Close()
+		SlackAPI = server.URL
+
+		assert.ErrorContains(t, SendSlack("TOKEN", "C123", newTestRichMessage()), "channel_not_found")
+	})
+}
+
+func newTestPushMessage() *PushMessage {
+	return &PushMessage{
+		Body:      "userA: line 1",
+		URL:       "https://example.com/1.html?atk_comment=1",
+		IsPending: true,
+	}
+}
+
+func TestSendNtfy(t *testing.T) {
+	var body string
+	var header http.Header
+	server := newTestServer(t, `{}`, &body, &header)
+	defer server.Close()
+
+	assert.NoError(t, SendNtfy(server.URL, "artalk", "TOKEN", newTestPushMessage()))
+	assert.Equal(t, "Bearer TOKEN", header.Get("Authorization"))
+	assert.Equal(t, "artalk", gjson.Get(body, "topic").String())
+	assert.Equal(t, "Artalk", gjson.Get(body, "title").String())
+	assert.Equal(t, "userA: line 1", gjson.Get(body, "message").String())
+	assert.Equal(t, "https://example.com/1.html?atk_comment=1", gjson.Get(body, "click").String())
+	assert.Equal(t, int64(4), gjson.Get(body, "priority").Int())
+
+	assert.Error(t, SendNtfy(server.URL, "", "", newTestPushMessage()), "topic is required")
+}
+
+func TestSendGotify(t *testing.T) {
+	var body string
+	var header http.Header
+	server := newTestServer(t, `{}`, &body, &header)
+	defer server.Close()
+
+	msg := newTestPushMessage()
+	msg.Title = "New comment"
+	msg.IsPending = false
+
+	assert.NoError(t, SendGotify(server.URL+"/", "APP_TOKEN", msg))
+	assert.Equal(t, "APP_TOKEN", header.Get("X-Gotify-Key"))
+	assert.Equal(t, "New comment", gjson.Get(body, "title").String())
+	assert.Equal(t, int64(5), gjson.Get(body, "priority").Int())
+	assert.Equal(t, "https://example.com/1.html?atk_comment=1", gjson.Get(body, `extras.client::notification.click.url`).String())
+}
+
+func TestSendPushover(t *testing.T) {
+	var body string
+	var header http.Header
+	server := newTestServer(t, `{"status":1}`, &body, &header)
+	defer server.Close()
+
+	defaultAPI := PushoverAPI
+	PushoverAPI = server.URL
+	defer func() { PushoverAPI = defaultAPI }()
+
+	assert.NoError(t, SendPushover("APP_TOKEN", "USER_KEY", "", newTestPushMessage()))
+	assert.Equal(t, "APP_TOKEN", gjson.Get(body, "token").String())
+	assert.Equal(t, "USER_KEY", gjson.Get(body, "user").String())
+	assert.Equal(t, int64(1), gjson.Get(body, "priority").Int())
+	assert.Equal(t, "https://example.com/1.html?atk_comment=1", gjson.Get(body, "url").String())
+	assert.False(t, gjson.Get(body, "device").Exists())
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"user":"invalid","status":0}`))
+		}))
+		defer server.Close()
+		PushoverAPI = server.URL
+
+		assert.ErrorContains(t, SendPushover("APP_TOKEN", "USER_KEY", "", newTestPushMessage()), "invalid")
+	})
+}
+
+func TestSendMatrix(t *testing.T) {
+	var method, path, body string
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, header = r.Method, r.URL.EscapedPath(), r.Header
+		buf, _ := io.ReadAll(r.Body)
+		body = string(buf)
+		w.Write([]byte(`{"event_id":"$1"}`))
+	}))
+	defer server.Close()
+
+	msg := newTestPushMessage()
+	msg.Title = "New <comment>"
+
+	assert.NoError(t, SendMatrix(server.URL+"/", "ACCESS_TOKEN", "!room:example.com", msg))
+	assert.Equal(t, "PUT", method)
+	assert.Regexp(t, `^/_matrix/client/v3/rooms/%21room:example.com/send/m.room.message/artalk_\w+$`, path)
+	assert.Equal(t, "Bearer ACCESS_TOKEN", header.Get("Authorization"))
+	assert.Equal(t, "m.text", gjson.Get(body, "msgtype").String())
+	assert.Equal(t, "New <comment>\n\nuserA: line 1\n\nhttps://example.com/1.html?atk_comment=1", gjson.Get(body, "body").String())
+	assert.Equal(t, `<b>New &lt;comment&gt;</b><br><br>userA: line 1<br><br><a href="https://example.com/1.html?atk_comment=1">https://example.com/1.html?atk_comment=1</a>`,
+		gjson.Get(body, "formatted_body").String())
+
+	// the transaction ID is unique for every message
+	firstPath := path
+	assert.NoError(t, SendMatrix(server.URL, "ACCESS_TOKEN", "!room:example.com", msg))
+	assert.NotEqual(t, firstPath, path)
+
+	assert.ErrorContains(t, SendMatrix(server.URL, "ACCESS_TOKEN", "", msg), "required")
+}
+
+func TestParseMentions(t *testing.T) {
+	all, mobiles, userIDs := parseMentions([]string{"all", "13800000000", "+8613800000000", "zhangsan", " "})
+	assert.True(t, all)
+	assert.Equal(t, []string{"13800000000", "+8613800000000"}, mobiles)
+	assert.Equal(t, []string{"zhangsan"}, userIDs)
+}
+
+func TestSendLarkCard(t *testing.T) {
+	var body string
+	var header http.Header
+	server := newTestServer(t, `{"code":0,"msg":"success"}`, &body, &header)
+	defer server.Close()
+
+	assert.NoError(t, SendLarkCard(server.URL, newTestRichMessage(), []string{"all", "ou_123"}))
+	assert.Equal(t, "interactive", gjson.Get(body, "msg_type").String())
+	assert.Equal(t, "[Pending] userA · Hello <World>", gjson.Get(body, "card.header.title.content").String())
+	assert.Equal(t, "orange", gjson.Get(body, "card.header.template").String())
+	assert.Equal(t, "**userA** · [Hello <World>](https://example.com/1.html)\nSite A", gjson.Get(body, "card.elements.0.text.content").String())
+	assert.Equal(t, "line 1\nline 2", gjson.Get(body, "card.elements.1.text.content").String())
+	assert.Equal(t, "https://example.com/1.html?atk_comment=1", gjson.Get(body, "card.elements.2.actions.0.url").String())
+	assert.Equal(t, "<at id=all></at> <at id=ou_123></at>", gjson.Get(body, "card.elements.3.text.content").String())
+
+	t.Run("Error", func(t *testing.T) {
+		server := newTestServer(t, `{"code":19021,"msg":"sign match fail or timestamp is not within one hour from current time"}`, &body, &header)
+		defer server.Close()
+
+		assert.ErrorContains(t, SendLark(server.URL, "", "text", nil), "sign match fail")
+	})
+}
+
+func TestSendDingTalk(t *testing.T) {
+	var body, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		buf, _ := io.ReadAll(r.Body)
+		body = string(buf)
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	defaultAPI := DingTalkAPI
+	DingTalkAPI = server.URL
+	defer func() { DingTalkAPI = defaultAPI }()
+
+	t.Run("Text", func(t *testing.T) {
+		assert.NoError(t, SendDingTalk("TOKEN", "", "Subject", "content", []string{"13800000000"}))
+		assert.Equal(t, "access_token=TOKEN", query)
+		assert.Equal(t, "Subject\n\ncontent\n\n@13800000000", gjson.Get(body, "text.content").String())
+		assert.Equal(t, `["13800000000"]`, gjson.Get(body, "at.atMobiles").Raw)
+		assert.False(t, gjson.Get(body, "at.isAtAll").Bool())
+	})
+
+	t.Run("Card", func(t *testing.T) {
+		msg := newTestRichMessage()
+		msg.IsPending = false
+
+		assert.NoError(t, SendDingTalkCard("TOKEN", "SEC", msg, []string{"all", "user1"}))
+		assert.Regexp(t, `^access_token=TOKEN&sign=.+&timestamp=\d+$`, query)
+		assert.Equal(t, "markdown", gjson.Get(body, "msgtype").String())
+		assert.Equal(t, "userA · Hello <World>", gjson.Get(body, "markdown.title").String())
+		assert.Equal(t, "#### userA · Hello <World>\n\n**userA** · [Hello <World>](https://example.com/1.html)\n\nSite A\n\n> line 1\n> line 2\n\n[Reply](https://example.com/1.html?atk_comment=1)\n\n@user1",
+			gjson.Get(body, "markdown.text").String())
+		assert.Equal(t, `["user1"]`, gjson.Get(body, "at.atUserIds").Raw)
+		assert.True(t, gjson.Get(body, "at.isAtAll").Bool())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := newTestServer(t, `{"errcode":310000,"errmsg":"sign not match"}`, &body, new(http.Header))
+		defer server.Close()
+		DingTalkAPI = server.URL
+
+		assert.ErrorContains(t, SendDingTalk("TOKEN", "SEC", "", "content", nil), "sign not match")
+	})
+}
+
+func TestSendWeCom(t *testing.T) {
+	var body string
+	var header http.Header
+	server := newTestServer(t, `{"errcode":0,"errmsg":"ok"}`, &body, &header)
+	defer server.Close()
+
+	t.Run("Text", func(t *testing.T) {
+		assert.NoError(t, SendWeCom(server.URL, "", "content", []string{"all", "13800000000", "zhangsan"}))
+		assert.Equal(t, "content", gjson.Get(body, "text.content").String())
+		assert.Equal(t, `["zhangsan","@all"]`, gjson.Get(body, "text.mentioned_list").Raw)
+		assert.Equal(t, `["13800000000"]`, gjson.Get(body, "text.mentioned_mobile_list").Raw)
+	})
+
+	t.Run("Card", func(t *testing.T) {
+		assert.NoError(t, SendWeComCard(server.URL, newTestRichMessage(), []string{"all", "zhangsan"}))
+		assert.Equal(t, "markdown", gjson.Get(body, "msgtype").String())
+		assert.Equal(t, `<font color="warning">**[Pending] userA · Hello <World>**</font>`+"\n"+
+			"userA · [Hello <World>](https://example.com/1.html)\n"+
+			`<font color="comment">Site A</font>`+"\n"+
+			"> line 1\n> line 2\n"+
+			"[Review](https://example.com/1.html?atk_comment=1)\n"+
+			"<@zhangsan>", gjson.Get(body, "markdown.content").String())
+	})
+}
+
+func TestTruncateBytes(t *testing.T) {
+	assert.Equal(t, "abc", truncateBytes("abc", 5))
+	assert.Equal(t, "你", truncateBytes("你好", 5))
+}
@@ -0,0 +1,107 @@
+package sender
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The API endpoint of DingTalk custom robot (replaceable for testing)
+var DingTalkAPI = "https://oapi.dingtalk.com/robot/send"
+
+// 钉钉发送 (文本消息)
+func SendDingTalk(token string, secret string, title string, content string, mentions []string) error {
+	if title != "" {
+		content = title + "\n\n" + content
+	}
+	at, atText := getDingTalkMentions(mentions)
+	return postDingTalk(token, secret, map[string]any{
+		"msgtype": "text",
+		"text":    map[string]any{"content": content + atText},
+		"at":      at,
+	})
+}
+
+// 钉钉发送 (Markdown 卡片)
+//
+// The markdown message is used instead of the ActionCard, because only the former can mention the users.
+//
+// See: https://open.dingtalk.com/document/orgapp/custom-bot-send-message-type
+func SendDingTalkCard(token string, secret string, msg *RichMessage, mentions []string) error {
+	pageTitle := msg.PageTitle
+	if pageTitle == "" {
+		pageTitle = msg.PageURL
+	}
+
+	title := msg.getCardTitle()
+	lines := []string{
+		"#### " + title,
+		fmt.Sprintf("**%s** · [%s](%s)", msg.AuthorName, truncateRunes(pageTitle, 200), msg.PageURL),
+	}
+	if msg.SiteName != "" {
+		lines = append(lines, msg.SiteName)
+	}
+	lines = append(lines,
+		quoteMarkdown(truncateRunes(msg.Excerpt, 2000)),
+		fmt.Sprintf("[%s](%s)", msg.getActionText(), msg.CommentURL),
+	)
+
+	at, atText := getDingTalkMentions(mentions)
+	return postDingTalk(token, secret, map[string]any{
+		"msgtype":  "markdown",
+		"markdown": map[string]any{"title": title, "text": strings.Join(lines, "\n\n") + atText},
+		"at":       at,
+	})
+}
+
+// Get the `at` field, and the `@` text which must be contained in the content to mention the users
+func getDingTalkMentions(mentions []string) (map[string]any, string) {
+	all, mobiles, userIDs := parseMentions(mentions)
+
+	text := ""
+	for _, id := range append(mobiles, userIDs...) {
+		text += " @" + id
+	}
+	if text != "" {
+		text = "\n\n" + strings.TrimSpace(text)
+	}
+
+	return map[string]any{
+		"atMobiles": mobiles,
+		"atUserIds": userIDs,
+		"isAtAll":   all,
+	}, text
+}
+
+func postDingTalk(token string, secret string, payload any) error {
+	query := url.Values{"access_token": {token}}
+	if secret != "" {
+		// the signature of the "加签" security setting
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + "\n" + secret))
+		query.Set("timestamp", timestamp)
+		query.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	}
+
+	respBody, err := postJSON(DingTalkAPI+"?"+query.Encode(), nil, payload)
+	if err != nil {
+		return fmt.Errorf("dingtalk: %w", err)
+	}
+
+	// the error is responded with the status 200
+	var result struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(respBody, &result); err == nil && result.ErrCode != 0 {
+		return fmt.Errorf("dingtalk: %s (errcode %d)", result.ErrMsg, result.ErrCode)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package sender
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+)
+
+const (
+	discordColorNormal  = 0x5865F2
+	discordColorPending = 0xF0B232
+)
+
+// Discord 发送 (Webhook Embed)
+func SendDiscord(webhookURL string, msg *RichMessage) error {
+	pageTitle := msg.PageTitle
+	if pageTitle == "" {
+		pageTitle = msg.PageURL
+	}
+	title := pageTitle
+	color := discordColorNormal
+	if msg.IsPending {
+		title = "[" + i18n.T("Pending") + "] " + title
+		color = discordColorPending
+	}
+
+	author := map[string]any{"name": msg.AuthorName}
+	if msg.AuthorURL != "" {
+		author["url"] = msg.AuthorURL
+	}
+	if msg.AvatarURL != "" {
+		author["icon_url"] = msg.AvatarURL
+	}
+
+	action := i18n.T("Reply")
+	if msg.IsPending {
+		action = i18n.T("Review")
+	}
+
+	fields := []map[string]any{
+		{"name": i18n.T("Page"), "value": fmt.Sprintf("[%s](%s)", escapeDiscordMarkdown(truncateRunes(pageTitle, 200)), msg.PageURL), "inline": true},
+	}
+	if msg.SiteName != "" {
+		fields = append(fields, map[string]any{"name": i18n.T("Site"), "value": truncateRunes(msg.SiteName, 1024), "inline": true})
+	}
+	fields = append(fields, map[string]any{"name": action, "value": msg.CommentURL})
+
+	embed := map[string]any{
+		"author":      author,
+		"title":       truncateRunes(title, 256),
+		"url":         msg.CommentURL,
+		"description": truncateRunes(msg.Excerpt, 4096),
+		"color":       color,
+		"fields":      fields,
+		"footer":      map[string]any{"text": "Artalk"},
+	}
+	if !msg.Date.IsZero() {
+		embed["timestamp"] = msg.Date.Format(time.RFC3339)
+	}
+
+	payload := map[string]any{
+		"embeds":           []any{embed},
+		"allowed_mentions": map[string]any{"parse": []string{}}, // the comment content should not mention anyone
+	}
+	if msg.Subject != "" {
+		payload["content"] = truncateRunes(msg.Subject, 2000)
+	}
+
+	if _, err := postJSON(webhookURL, nil, payload); err != nil {
+		return fmt.Errorf("discord: %w", err)
+	}
+	return nil
+}
+
+func escapeDiscordMarkdown(text string) string {
+	var out []rune
+	for _, r := range text {
+		switch r {
+		case '[', ']', '(', ')', '*', '_', '~', '`', '|', '\\':
+			out = append(out, '\\')
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+func truncateRunes(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,40 @@
+package sender
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Gotify 发送
+//
+// See: https://gotify.net/docs/pushmsg
+func SendGotify(server string, token string, msg *PushMessage) error {
+	if server == "" {
+		return fmt.Errorf("gotify: the server is required")
+	}
+
+	priority := 5
+	if msg.IsPending {
+		priority = 8 // the priority >= 8 pops up on Android
+	}
+
+	payload := map[string]any{
+		"title":    msg.getTitle(),
+		"message":  msg.Body,
+		"priority": priority,
+	}
+	if msg.URL != "" {
+		payload["extras"] = map[string]any{
+			"client::notification": map[string]any{"click": map[string]any{"url": msg.URL}},
+		}
+	}
+
+	header := http.Header{}
+	header.Set("X-Gotify-Key", token)
+
+	if _, err := postJSON(strings.TrimSuffix(server, "/")+"/message", header, payload); err != nil {
+		return fmt.Errorf("gotify: %w", err)
+	}
+	return nil
+}
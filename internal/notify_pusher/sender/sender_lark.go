@@ -1,66 +1,94 @@
 package sender
 
 import (
+	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strconv"
 	"strings"
-
-	"github.com/artalkjs/artalk/v2/internal/i18n"
-	"github.com/artalkjs/artalk/v2/internal/log"
-	"github.com/artalkjs/artalk/v2/internal/utils"
 )
 
-// 飞书发送
-func SendLark(webhookURL string, title string, content string, isCard bool) {
+// 飞书发送 (文本消息)
+func SendLark(webhookURL string, title string, content string, mentions []string) error {
 	if title != "" {
 		content = title + "\n\n" + content
 	}
+	if at := getLarkMentions(mentions, `<at user_id="%s"></at>`); at != "" {
+		content += "\n" + at
+	}
+
+	return postLark(webhookURL, map[string]any{
+		"msg_type": "text",
+		"content":  map[string]any{"text": content},
+	})
+}
 
-	var data string
-	if isCard {
-		texts := strings.Split(strings.TrimSpace(content), "\n")
-		link := texts[len(texts)-1]
-		if utils.ValidateURL(link) {
-			content = strings.Join(texts[1:len(texts)-1], "\n")
-		} else {
-			content = strings.Join(texts[1:], "\n")
-			link = ""
-		}
-		data = getLarkCardJson(texts[0], content, link)
-	} else {
-		data = getLarkTextJson(content)
+// 飞书发送 (消息卡片)
+//
+// See: https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot
+func SendLarkCard(webhookURL string, msg *RichMessage, mentions []string) error {
+	pageTitle := msg.PageTitle
+	if pageTitle == "" {
+		pageTitle = msg.PageURL
 	}
 
-	result, err := http.Post(webhookURL, "application/json", strings.NewReader(data))
-	if err != nil {
-		log.Error("[飞书] Failed to send msg:", err)
-		return
+	info := fmt.Sprintf("**%s** · [%s](%s)", msg.AuthorName, truncateRunes(pageTitle, 200), msg.PageURL)
+	if msg.SiteName != "" {
+		info += "\n" + msg.SiteName
 	}
 
-	if result.StatusCode != 200 {
-		body, _ := io.ReadAll(result.Body)
-		log.Error("[飞书] Failed to send msg:", string(body), " ", string(data))
+	color := "blue"
+	if msg.IsPending {
+		color = "orange"
 	}
 
-	defer result.Body.Close()
-}
+	elements := []map[string]any{
+		{"tag": "div", "text": map[string]any{"tag": "lark_md", "content": info}},
+		{"tag": "div", "text": map[string]any{"tag": "plain_text", "content": truncateRunes(msg.Excerpt, 2000)}},
+		{"tag": "action", "actions": []map[string]any{
+			{"tag": "button", "text": map[string]any{"tag": "plain_text", "content": msg.getActionText()}, "url": msg.CommentURL, "type": "primary"},
+		}},
+	}
+	if at := getLarkMentions(mentions, `<at id=%s></at>`); at != "" {
+		elements = append(elements, map[string]any{"tag": "div", "text": map[string]any{"tag": "lark_md", "content": at}})
+	}
 
-func getLarkTextJson(text string) string {
-	return fmt.Sprintf(`{"msg_type":"text","content":{"text":%s}}`, strconv.Quote(text))
+	return postLark(webhookURL, map[string]any{
+		"msg_type": "interactive",
+		"card": map[string]any{
+			"config":   map[string]any{"wide_screen_mode": true},
+			"header":   map[string]any{"title": map[string]any{"tag": "plain_text", "content": truncateRunes(msg.getCardTitle(), 100)}, "template": color},
+			"elements": elements,
+		},
+	})
 }
 
-func getLarkCardJson(title string, content string, replyLink string) string {
-	title = strings.TrimSpace(title)
-	content = strings.TrimSpace(content)
+// Get the `<at>` tags of the users (open_id or "all"), the syntax of tag is different in text and card
+func getLarkMentions(mentions []string, tagFormat string) string {
+	all, mobiles, userIDs := parseMentions(mentions)
+	ids := append(mobiles, userIDs...) // the mobile number cannot be mentioned in Lark, which is treated as the ID
+	if all {
+		ids = append([]string{"all"}, ids...)
+	}
 
-	buttonCode := ""
-	if replyLink != "" {
-		buttonCode = fmt.Sprintf(`,{"tag":"action","actions":[{"tag":"button","text":{"content":%s,"tag":"lark_md"},"url":%s,"type":"default","value":{}}]}`,
-			strconv.Quote(i18n.T("Reply")), strconv.Quote(replyLink))
+	tags := []string{}
+	for _, id := range ids {
+		tags = append(tags, fmt.Sprintf(tagFormat, id))
 	}
+	return strings.Join(tags, " ")
+}
 
-	return fmt.Sprintf(`{"msg_type":"interactive","card":{"header":{"title":{"content":%s,"tag":"plain_text"}},"elements":[{"tag":"div","text":{"content":%s,"tag":"lark_md"}}%s]}}`,
-		strconv.Quote(title), strconv.Quote(content), buttonCode)
+func postLark(webhookURL string, payload any) error {
+	respBody, err := postJSON(webhookURL, nil, payload)
+	if err != nil {
+		return fmt.Errorf("lark: %w", err)
+	}
+
+	// the error is responded with the status 200
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(respBody, &result); err == nil && result.Code != 0 {
+		return fmt.Errorf("lark: %s (code %d)", result.Msg, result.Code)
+	}
+	return nil
 }
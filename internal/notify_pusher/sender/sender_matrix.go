@@ -0,0 +1,56 @@
+package sender
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var matrixTxnCounter atomic.Uint64
+
+// Matrix 发送
+//
+// The message is sent as the plain event, so the room should not enable the end-to-end encryption.
+//
+// See: https://spec.matrix.org/latest/client-server-api/#put_matrixclientv3roomsroomidsendeventtypetxnid
+func SendMatrix(homeserver string, accessToken string, roomID string, msg *PushMessage) error {
+	if homeserver == "" || roomID == "" {
+		return fmt.Errorf("matrix: the homeserver and room_id are required")
+	}
+
+	// the transaction ID is used by the homeserver to deduplicate the retried requests
+	txnID := "artalk_" + strconv.FormatInt(time.Now().UnixNano(), 36) + "_" + strconv.FormatUint(matrixTxnCounter.Add(1), 36)
+	endpoint := strings.TrimSuffix(homeserver, "/") + "/_matrix/client/v3/rooms/" +
+		url.PathEscape(roomID) + "/send/m.room.message/" + txnID
+
+	text := msg.Body
+	formatted := strings.ReplaceAll(html.EscapeString(msg.Body), "\n", "<br>")
+	if msg.Title != "" {
+		text = msg.Title + "\n\n" + text
+		formatted = "<b>" + html.EscapeString(msg.Title) + "</b><br><br>" + formatted
+	}
+	if msg.URL != "" {
+		text += "\n\n" + msg.URL
+		formatted += `<br><br><a href="` + html.EscapeString(msg.URL) + `">` + html.EscapeString(msg.URL) + "</a>"
+	}
+
+	payload := map[string]any{
+		"msgtype":        "m.text",
+		"body":           text,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": formatted,
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+accessToken)
+
+	if _, err := requestJSON("PUT", endpoint, header, payload); err != nil {
+		return fmt.Errorf("matrix: %w", err)
+	}
+	return nil
+}
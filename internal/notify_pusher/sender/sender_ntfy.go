@@ -0,0 +1,49 @@
+package sender
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const ntfyDefaultServer = "https://ntfy.sh"
+
+// ntfy 发送 (JSON publishing)
+//
+// See: https://docs.ntfy.sh/publish/#publish-as-json
+func SendNtfy(server string, topic string, token string, msg *PushMessage) error {
+	if server == "" {
+		server = ntfyDefaultServer
+	}
+	if topic == "" {
+		return fmt.Errorf("ntfy: the topic is required")
+	}
+
+	priority := 3 // default
+	tags := []string{"speech_balloon"}
+	if msg.IsPending {
+		priority = 4 // high
+		tags = []string{"hourglass"}
+	}
+
+	payload := map[string]any{
+		"topic":    topic,
+		"title":    msg.getTitle(),
+		"message":  truncateRunes(msg.Body, 4096),
+		"priority": priority,
+		"tags":     tags,
+	}
+	if msg.URL != "" {
+		payload["click"] = msg.URL
+	}
+
+	header := http.Header{}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+
+	if _, err := postJSON(strings.TrimSuffix(server, "/")+"/", header, payload); err != nil {
+		return fmt.Errorf("ntfy: %w", err)
+	}
+	return nil
+}
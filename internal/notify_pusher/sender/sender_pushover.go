@@ -0,0 +1,35 @@
+package sender
+
+import "fmt"
+
+// The API endpoint of Pushover
+var PushoverAPI = "https://api.pushover.net/1/messages.json"
+
+// Pushover 发送
+//
+// See: https://pushover.net/api
+func SendPushover(token string, user string, device string, msg *PushMessage) error {
+	priority := 0
+	if msg.IsPending {
+		priority = 1 // high priority, bypasses the quiet hours
+	}
+
+	payload := map[string]any{
+		"token":    token,
+		"user":     user,
+		"title":    truncateRunes(msg.getTitle(), 250),
+		"message":  truncateRunes(msg.Body, 1024),
+		"priority": priority,
+	}
+	if device != "" {
+		payload["device"] = device
+	}
+	if msg.URL != "" {
+		payload["url"] = msg.URL
+	}
+
+	if _, err := postJSON(PushoverAPI, nil, payload); err != nil {
+		return fmt.Errorf("pushover: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package sender
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+)
+
+// The address of Slack Web API (replaceable for testing)
+var SlackAPI = "https://slack.com/api"
+
+// Slack 发送 (Block Kit 消息)
+func SendSlack(token string, channel string, msg *RichMessage) error {
+	title := msg.PageTitle
+	if title == "" {
+		title = msg.PageURL
+	}
+
+	fallback := fmt.Sprintf("%s: %s", msg.AuthorName, strings.TrimSpace(msg.Excerpt))
+	if msg.Subject != "" {
+		fallback = msg.Subject + "\n" + fallback
+	}
+
+	header := fmt.Sprintf("*%s* · <%s|%s>", escapeSlack(msg.AuthorName), msg.PageURL, escapeSlack(title))
+	if msg.IsPending {
+		header = "*[" + escapeSlack(i18n.T("Pending")) + "]* " + header
+	}
+
+	author := []map[string]any{}
+	if msg.AvatarURL != "" {
+		author = append(author, map[string]any{"type": "image", "image_url": msg.AvatarURL, "alt_text": msg.AuthorName})
+	}
+	author = append(author, map[string]any{"type": "mrkdwn", "text": header})
+
+	action := i18n.T("Reply")
+	if msg.IsPending {
+		action = i18n.T("Review")
+	}
+
+	blocks := []map[string]any{
+		{"type": "context", "elements": author},
+		{"type": "section", "text": map[string]any{"type": "mrkdwn", "text": truncateRunes(quoteSlack(msg.Excerpt), 3000)}},
+		{"type": "context", "elements": []map[string]any{
+			{"type": "mrkdwn", "text": escapeSlack(msg.SiteName)},
+		}},
+		{"type": "actions", "elements": []map[string]any{
+			{"type": "button", "text": map[string]any{"type": "plain_text", "text": action}, "url": msg.CommentURL},
+		}},
+	}
+	if msg.Subject != "" {
+		blocks = append([]map[string]any{
+			{"type": "section", "text": map[string]any{"type": "mrkdwn", "text": "*" + escapeSlack(truncateRunes(msg.Subject, 2900)) + "*"}},
+		}, blocks...)
+	}
+
+	respBody, err := postJSON(SlackAPI+"/chat.postMessage", http.Header{"Authorization": {"Bearer " + token}}, map[string]any{
+		"channel":      channel,
+		"text":         truncateRunes(fallback, 3000), // shown in the notification and the clients without blocks support
+		"blocks":       blocks,
+		"unfurl_links": false,
+	})
+	if err != nil {
+		return fmt.Errorf("slack chat.postMessage: %w", err)
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil || !result.OK {
+		return fmt.Errorf("slack chat.postMessage failed: %s", result.Error)
+	}
+	return nil
+}
+
+// Escape the control characters of Slack mrkdwn
+func escapeSlack(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+func quoteSlack(text string) string {
+	lines := strings.Split(strings.TrimSpace(escapeSlack(text)), "\n")
+	return "> " + strings.Join(lines, "\n> ")
+}
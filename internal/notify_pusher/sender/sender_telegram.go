@@ -0,0 +1,80 @@
+package sender
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// The address of Telegram Bot API (replaceable for the self-hosted Bot API server or testing)
+var TelegramAPI = "https://api.telegram.org"
+
+var telegramClient = &http.Client{Timeout: 10 * time.Second}
+
+type TelegramButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+// Telegram 发送 (HTML 格式)，buttons 为消息下方的一行内联按钮
+func SendTelegram(token string, chatID int64, text string, buttons []TelegramButton) error {
+	payload := map[string]any{
+		"chat_id":                  chatID,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	}
+	if len(buttons) > 0 {
+		payload["reply_markup"] = map[string]any{"inline_keyboard": [][]TelegramButton{buttons}}
+	}
+	return callTelegram(token, "sendMessage", payload)
+}
+
+// Reply to the callback query of the inline button, the text is shown as a notification to the user
+func AnswerTelegramCallback(token string, callbackID string, text string) error {
+	return callTelegram(token, "answerCallbackQuery", map[string]any{
+		"callback_query_id": callbackID,
+		"text":              text,
+	})
+}
+
+// Replace the text of message and remove the inline buttons
+func EditTelegramMessage(token string, chatID int64, messageID int64, text string) error {
+	return callTelegram(token, "editMessageText", map[string]any{
+		"chat_id":                  chatID,
+		"message_id":               messageID,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	})
+}
+
+func callTelegram(token string, method string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := telegramClient.Post(fmt.Sprintf("%s/bot%s/%s", TelegramAPI, token, method), "application/json", bytes.NewReader(body))
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err // the token is in the url, which should not be logged
+		}
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err := json.Unmarshal(respBody, &result); err != nil || !result.OK {
+		return fmt.Errorf("telegram %s failed with status %d: %s", method, resp.StatusCode, result.Description)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package sender
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// 企业微信发送 (文本消息)
+//
+// See: https://developer.work.weixin.qq.com/document/path/91770
+func SendWeCom(webhookURL string, title string, content string, mentions []string) error {
+	if title != "" {
+		content = title + "\n\n" + content
+	}
+
+	all, mobiles, userIDs := parseMentions(mentions)
+	if all {
+		userIDs = append(userIDs, "@all")
+	}
+
+	return postWeCom(webhookURL, map[string]any{
+		"msgtype": "text",
+		"text": map[string]any{
+			"content":               truncateBytes(content, 2048),
+			"mentioned_list":        userIDs,
+			"mentioned_mobile_list": mobiles,
+		},
+	})
+}
+
+// 企业微信发送 (Markdown 卡片)
+//
+// Only the user IDs can be mentioned in the markdown message, the mobile numbers and "all" are ignored.
+func SendWeComCard(webhookURL string, msg *RichMessage, mentions []string) error {
+	pageTitle := msg.PageTitle
+	if pageTitle == "" {
+		pageTitle = msg.PageURL
+	}
+
+	title := "**" + msg.getCardTitle() + "**"
+	if msg.IsPending {
+		title = `<font color="warning">` + title + "</font>"
+	}
+
+	lines := []string{
+		title,
+		fmt.Sprintf("%s · [%s](%s)", msg.AuthorName, truncateRunes(pageTitle, 200), msg.PageURL),
+	}
+	if msg.SiteName != "" {
+		lines = append(lines, `<font color="comment">`+msg.SiteName+"</font>")
+	}
+	lines = append(lines,
+		quoteMarkdown(truncateRunes(msg.Excerpt, 1000)),
+		fmt.Sprintf("[%s](%s)", msg.getActionText(), msg.CommentURL),
+	)
+
+	_, _, userIDs := parseMentions(mentions)
+	if len(userIDs) > 0 {
+		at := []string{}
+		for _, id := range userIDs {
+			at = append(at, "<@"+id+">")
+		}
+		lines = append(lines, strings.Join(at, " "))
+	}
+
+	return postWeCom(webhookURL, map[string]any{
+		"msgtype":  "markdown",
+		"markdown": map[string]any{"content": truncateBytes(strings.Join(lines, "\n"), 4096)},
+	})
+}
+
+func postWeCom(webhookURL string, payload any) error {
+	respBody, err := postJSON(webhookURL, nil, payload)
+	if err != nil {
+		return fmt.Errorf("wecom: %w", err)
+	}
+
+	// the error is responded with the status 200
+	var result struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(respBody, &result); err == nil && result.ErrCode != 0 {
+		return fmt.Errorf("wecom: %s (errcode %d)", result.ErrMsg, result.ErrCode)
+	}
+	return nil
+}
+
+// Truncate the UTF-8 text to the byte length limit without breaking the characters
+func truncateBytes(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	for max > 0 && !utf8.RuneStart(text[max]) {
+		max--
+	}
+	return text[:max]
+}
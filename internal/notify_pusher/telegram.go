@@ -0,0 +1,65 @@
+package notify_pusher
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/notify_pusher/sender"
+)
+
+// The moderation actions of the Telegram inline buttons
+const (
+	TelegramActionApprove = "approve"
+	TelegramActionSpam    = "spam"
+	TelegramActionDelete  = "delete"
+)
+
+// The callback data of inline button, e.g. "approve:123"
+func TelegramCallbackData(action string, commentID uint) string {
+	return fmt.Sprintf("%s:%d", action, commentID)
+}
+
+// Parse the callback data of inline button, false is returned if it is invalid
+func ParseTelegramCallbackData(data string) (action string, commentID uint, ok bool) {
+	action, idStr, found := strings.Cut(data, ":")
+	if !found {
+		return "", 0, false
+	}
+	switch action {
+	case TelegramActionApprove, TelegramActionSpam, TelegramActionDelete:
+	default:
+		return "", 0, false
+	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		return "", 0, false
+	}
+	return action, uint(id), true
+}
+
+// Send the notification to the Telegram receivers with the moderation buttons
+func (pusher *NotifyPusher) sendTelegramWithButtons(subject string, body string, comment *entity.Comment) {
+	text := html.EscapeString(body)
+	if subject != "" {
+		text = html.EscapeString(subject) + "\n" + text
+	}
+
+	buttons := []sender.TelegramButton{}
+	if comment.IsPending {
+		buttons = append(buttons, sender.TelegramButton{Text: i18n.T("Approve"), CallbackData: TelegramCallbackData(TelegramActionApprove, comment.ID)})
+	} else {
+		buttons = append(buttons, sender.TelegramButton{Text: i18n.T("Spam"), CallbackData: TelegramCallbackData(TelegramActionSpam, comment.ID)})
+	}
+	buttons = append(buttons, sender.TelegramButton{Text: i18n.T("Delete"), CallbackData: TelegramCallbackData(TelegramActionDelete, comment.ID)})
+
+	for _, chatID := range pusher.conf.Telegram.Receivers {
+		if err := sender.SendTelegram(pusher.conf.Telegram.ApiToken, chatID, text, buttons); err != nil {
+			log.Ctx(pusher.ctx).Error("[Notify] Telegram send error: ", err)
+		}
+	}
+}
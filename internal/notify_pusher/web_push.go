@@ -0,0 +1,49 @@
+package notify_pusher
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+// 浏览器推送回复对象和被提及的用户
+//
+// The receivers are not required to have the email address, and the email preferences are not applied
+// except the reply and mention switches.
+func (pusher *NotifyPusher) webPushToUsers(comment *entity.Comment, pComment *entity.Comment) {
+	if pusher.conf.WebPush == nil {
+		return
+	}
+
+	// 待审评论不推送 (管理员审核通过后才发送)
+	if comment.IsPending {
+		return
+	}
+
+	var users []entity.User
+	if pComment != nil && !pComment.IsEmpty() {
+		if user := pusher.dao.FetchUserForComment(pComment); !user.IsEmpty() && user.NotifyReply {
+			users = append(users, user)
+		}
+	}
+
+	names := utils.ParseMentions(comment.Content)
+	if len(names) > maxMentions {
+		names = names[:maxMentions]
+	}
+	for _, user := range pusher.dao.FindPageParticipantsByNames(comment.SiteName, comment.PageKey, names) {
+		if user.NotifyMention {
+			users = append(users, user)
+		}
+	}
+
+	pushed := map[uint]bool{comment.UserID: true} // 自己回复或提及自己，不推送
+	for _, user := range users {
+		if pushed[user.ID] {
+			continue
+		}
+		pushed[user.ID] = true
+
+		notify := pusher.dao.FindCreateNotify(user.ID, comment.ID)
+		pusher.conf.WebPush(&notify)
+	}
+}
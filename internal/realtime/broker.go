@@ -0,0 +1,131 @@
+package realtime
+
+import (
+	"net/url"
+	"sync"
+)
+
+// 消息代理 (发布/订阅)
+type Broker interface {
+	// 发布消息到主题
+	Publish(topic string, data []byte) error
+
+	// 订阅主题，代理关闭或订阅者处理过慢时通道将被关闭
+	Subscribe(topic string) *Subscription
+
+	// 本节点的订阅者数量
+	Count(topic string) int
+
+	Close() error
+}
+
+// 页面的主题
+func PageTopic(siteName string, pageKey string) string {
+	return "page:" + url.QueryEscape(siteName) + ":" + url.QueryEscape(pageKey)
+}
+
+// 每个订阅者的消息缓冲数量
+const subscriptionBuffer = 32
+
+type Subscription struct {
+	C <-chan []byte
+
+	ch     chan []byte
+	once   sync.Once
+	cancel func()
+}
+
+func newSubscription(cancel func()) *Subscription {
+	ch := make(chan []byte, subscriptionBuffer)
+	return &Subscription{C: ch, ch: ch, cancel: cancel}
+}
+
+// 取消订阅
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.cancel()
+		close(s.ch)
+	})
+}
+
+// 单节点的内存消息代理
+type MemoryBroker struct {
+	mu     sync.Mutex
+	subs   map[string]map[*Subscription]struct{}
+	closed bool
+}
+
+var _ Broker = (*MemoryBroker)(nil)
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{subs: map[string]map[*Subscription]struct{}{}}
+}
+
+func (b *MemoryBroker) Publish(topic string, data []byte) error {
+	b.mu.Lock()
+	var slow []*Subscription
+	for sub := range b.subs[topic] {
+		select {
+		case sub.ch <- data:
+		default:
+			slow = append(slow, sub) // 订阅者处理过慢，断开后由客户端重新连接
+		}
+	}
+	b.mu.Unlock()
+
+	for _, sub := range slow {
+		sub.Close()
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(topic string) *Subscription {
+	var sub *Subscription
+	sub = newSubscription(func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs[topic], sub)
+		if len(b.subs[topic]) == 0 {
+			delete(b.subs, topic)
+		}
+	})
+
+	b.mu.Lock()
+	closed := b.closed
+	if !closed {
+		if b.subs[topic] == nil {
+			b.subs[topic] = map[*Subscription]struct{}{}
+		}
+		b.subs[topic][sub] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	if closed {
+		sub.Close()
+	}
+	return sub
+}
+
+// 订阅者数量
+func (b *MemoryBroker) Count(topic string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs[topic])
+}
+
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	var all []*Subscription
+	for _, subs := range b.subs {
+		for sub := range subs {
+			all = append(all, sub)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, sub := range all {
+		sub.Close()
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBroker(t *testing.T) {
+	b := NewMemoryBroker()
+	topic := PageTopic("Site A", "/test/1000.html")
+
+	t.Run("PublishSubscribe", func(t *testing.T) {
+		sub := b.Subscribe(topic)
+		other := b.Subscribe(PageTopic("Site A", "/test/1001.html"))
+		assert.Equal(t, 1, b.Count(topic))
+
+		assert.NoError(t, b.Publish(topic, []byte("hello")))
+		assert.Equal(t, "hello", string(<-sub.C))
+		assert.Len(t, other.C, 0, "should not receive the messages of other topics")
+
+		sub.Close()
+		sub.Close() // should be idempotent
+		other.Close()
+		assert.Equal(t, 0, b.Count(topic))
+		_, ok := <-sub.C
+		assert.False(t, ok, "should close the channel after unsubscribed")
+	})
+
+	t.Run("DropSlowSubscriber", func(t *testing.T) {
+		sub := b.Subscribe(topic)
+		for i := 0; i <= subscriptionBuffer; i++ {
+			assert.NoError(t, b.Publish(topic, []byte("msg")))
+		}
+		assert.Equal(t, 0, b.Count(topic), "should drop the subscriber which is full")
+
+		n := 0
+		for range sub.C {
+			n++
+		}
+		assert.Equal(t, subscriptionBuffer, n, "should keep the buffered messages")
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		sub := b.Subscribe(topic)
+		assert.NoError(t, b.Close())
+		_, ok := <-sub.C
+		assert.False(t, ok, "should close the subscribers")
+
+		_, ok = <-b.Subscribe(topic).C
+		assert.False(t, ok, "should close the new subscriber after broker closed")
+	})
+}
+
+func TestPageTopic(t *testing.T) {
+	assert.NotEqual(t, PageTopic("a:b", "c"), PageTopic("a", "b:c"), "should escape the separator")
+}
@@ -0,0 +1,56 @@
+package realtime
+
+import "sync"
+
+// 本节点的长连接 (WebSocket 和 SSE) 计数，限制全局和每个 IP 的并发连接数
+type Hub struct {
+	maxConns      int // 0 为不限制
+	maxConnsPerIP int // 0 为不限制
+
+	mu    sync.Mutex
+	total int
+	perIP map[string]int
+}
+
+func NewHub(maxConns int, maxConnsPerIP int) *Hub {
+	return &Hub{maxConns: maxConns, maxConnsPerIP: maxConnsPerIP, perIP: map[string]int{}}
+}
+
+// 是否允许该 IP 建立新的连接 (用于握手前的检查，连接建立后仍需 Acquire)
+func (h *Hub) Allow(ip string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.allow(ip)
+}
+
+// 占用该 IP 的一个连接，超出限制时返回 false，连接关闭后须调用 release 释放
+func (h *Hub) Acquire(ip string) (release func(), ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.allow(ip) {
+		return nil, false
+	}
+
+	h.total++
+	h.perIP[ip]++
+	return sync.OnceFunc(func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.total--
+		if h.perIP[ip]--; h.perIP[ip] <= 0 {
+			delete(h.perIP, ip)
+		}
+	}), true
+}
+
+// 当前的连接数
+func (h *Hub) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.total
+}
+
+func (h *Hub) allow(ip string) bool {
+	return (h.maxConns <= 0 || h.total < h.maxConns) &&
+		(h.maxConnsPerIP <= 0 || h.perIP[ip] < h.maxConnsPerIP)
+}
@@ -0,0 +1,34 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	hub := NewHub(3, 2)
+
+	releaseA1, ok := hub.Acquire("10.0.0.1")
+	assert.True(t, ok)
+	_, ok = hub.Acquire("10.0.0.1")
+	assert.True(t, ok)
+	assert.False(t, hub.Allow("10.0.0.1"), "should limit the connections of IP")
+	_, ok = hub.Acquire("10.0.0.1")
+	assert.False(t, ok)
+
+	_, ok = hub.Acquire("10.0.0.2")
+	assert.True(t, ok)
+	assert.False(t, hub.Allow("10.0.0.3"), "should limit the total connections")
+	assert.Equal(t, 3, hub.Count())
+
+	releaseA1()
+	releaseA1() // released only once
+	assert.Equal(t, 2, hub.Count())
+	assert.True(t, hub.Allow("10.0.0.1"))
+	_, ok = hub.Acquire("10.0.0.3")
+	assert.True(t, ok)
+
+	_, ok = NewHub(0, 0).Acquire("10.0.0.1")
+	assert.True(t, ok, "should be unlimited if 0")
+}
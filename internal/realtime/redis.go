@@ -0,0 +1,63 @@
+package realtime
+
+import (
+	"context"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis 频道的前缀
+const redisChannelPrefix = "artalk:realtime:"
+
+// 多节点的 Redis 消息代理
+//
+// 消息通过 Redis 的发布/订阅在节点间传递，每个节点仅订阅一次 Redis，
+// 再由内存消息代理分发给本节点的订阅者
+type RedisBroker struct {
+	client *redis.Client
+	pubsub *redis.PubSub
+	local  *MemoryBroker
+}
+
+var _ Broker = (*RedisBroker)(nil)
+
+func NewRedisBroker(opts *redis.Options) (*RedisBroker, error) {
+	client := redis.NewClient(opts)
+	pubsub := client.PSubscribe(context.Background(), redisChannelPrefix+"*")
+	if _, err := pubsub.Receive(context.Background()); err != nil { // 等待订阅确认
+		_ = pubsub.Close()
+		_ = client.Close()
+		return nil, err
+	}
+
+	b := &RedisBroker{client: client, pubsub: pubsub, local: NewMemoryBroker()}
+	go func() {
+		for msg := range pubsub.Channel() {
+			if err := b.local.Publish(strings.TrimPrefix(msg.Channel, redisChannelPrefix), []byte(msg.Payload)); err != nil {
+				log.Error("[RealtimeBroker] ", err)
+			}
+		}
+	}()
+
+	return b, nil
+}
+
+func (b *RedisBroker) Publish(topic string, data []byte) error {
+	return b.client.Publish(context.Background(), redisChannelPrefix+topic, data).Err()
+}
+
+func (b *RedisBroker) Subscribe(topic string) *Subscription {
+	return b.local.Subscribe(topic)
+}
+
+func (b *RedisBroker) Count(topic string) int {
+	return b.local.Count(topic)
+}
+
+func (b *RedisBroker) Close() error {
+	_ = b.pubsub.Close()
+	_ = b.local.Close()
+	return b.client.Close()
+}
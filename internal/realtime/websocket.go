@@ -0,0 +1,112 @@
+package realtime
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	fiberws "github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebSocket 服务端
+//
+// 仅实现推送所需的部分：服务端发送文本消息，客户端的数据消息将被忽略，
+// 客户端的 Ping 和 Close 控制帧由 websocket 库响应
+
+const (
+	WSCloseNormal        = websocket.CloseNormalClosure
+	WSCloseTryAgainLater = websocket.CloseTryAgainLater // 超出连接数限制
+
+	wsWriteTimeout = 10 * time.Second
+)
+
+var ErrWSClosed = errors.New("websocket closed")
+
+// 检查是否为 WebSocket 升级请求
+func IsWebSocketUpgrade(c *fiber.Ctx) bool {
+	return fiberws.IsWebSocketUpgrade(c)
+}
+
+type WSConn struct {
+	conn   *websocket.Conn
+	mu     sync.Mutex
+	closed chan struct{}
+	once   sync.Once
+}
+
+// 升级为 WebSocket 连接，握手完成后调用 handler，handler 返回时关闭连接
+//
+// 客户端的消息超过 maxMessageSize (字节) 时关闭连接
+func UpgradeWebSocket(c *fiber.Ctx, maxMessageSize int64, handler func(ws *WSConn)) error {
+	if !IsWebSocketUpgrade(c) || c.Method() != fiber.MethodGet {
+		return c.Status(fiber.StatusBadRequest).SendString("websocket upgrade required")
+	}
+
+	return fiberws.New(func(conn *fiberws.Conn) {
+		ws := &WSConn{conn: conn.Conn, closed: make(chan struct{})}
+		ws.conn.SetReadLimit(maxMessageSize)
+
+		// the hijacked connection is reused by fasthttp after returned, so wait for the read loop to end
+		readDone := make(chan struct{})
+		go func() {
+			defer close(readDone)
+			ws.readLoop()
+		}()
+
+		handler(ws)
+		ws.Close()
+		<-readDone
+	})(c)
+}
+
+// 连接关闭时通道将被关闭
+func (ws *WSConn) Closed() <-chan struct{} {
+	return ws.closed
+}
+
+// 发送文本消息
+func (ws *WSConn) WriteText(data []byte) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	select {
+	case <-ws.closed:
+		return ErrWSClosed
+	default:
+	}
+
+	_ = ws.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return ws.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// 发送 Ping 控制帧 (保持连接)
+func (ws *WSConn) Ping() error {
+	return ws.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+}
+
+// 正常关闭连接
+func (ws *WSConn) Close() {
+	ws.CloseWith(WSCloseNormal, "")
+}
+
+// 以指定的状态码关闭连接
+func (ws *WSConn) CloseWith(code int, reason string) {
+	ws.once.Do(func() {
+		_ = ws.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteTimeout))
+		close(ws.closed)
+		_ = ws.conn.Close()
+	})
+}
+
+// 读取并丢弃客户端的消息，出错、消息过大或收到 Close 帧时关闭连接
+func (ws *WSConn) readLoop() {
+	defer ws.Close()
+
+	for {
+		if _, _, err := ws.conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package realtime
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebSocket(t *testing.T) {
+	fb := fiber.New(fiber.Config{DisableStartupMessage: true})
+	fb.Get("/ws", func(c *fiber.Ctx) error {
+		return UpgradeWebSocket(c, 16, func(ws *WSConn) {
+			assert.NoError(t, ws.WriteText([]byte("hello")))
+			<-ws.Closed()
+		})
+	})
+	fb.Get("/ws/busy", func(c *fiber.Ctx) error {
+		return UpgradeWebSocket(c, 16, func(ws *WSConn) {
+			ws.CloseWith(WSCloseTryAgainLater, "too many connections")
+		})
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go fb.Listener(ln)
+	defer fb.Shutdown()
+
+	url := "ws://" + ln.Addr().String()
+	dial := func(t *testing.T, path string) *websocket.Conn {
+		conn, resp, err := websocket.DefaultDialer.Dial(url+path, nil)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.Equal(t, 101, resp.StatusCode)
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		return conn
+	}
+
+	t.Run("NotUpgrade", func(t *testing.T) {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/ws")
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("Upgrade", func(t *testing.T) {
+		conn := dial(t, "/ws")
+		defer conn.Close()
+
+		typ, data, err := conn.ReadMessage()
+		assert.NoError(t, err)
+		assert.Equal(t, websocket.TextMessage, typ)
+		assert.Equal(t, "hello", string(data))
+
+		// Client ping, should be answered with pong
+		pong := make(chan string, 1)
+		conn.SetPongHandler(func(data string) error {
+			pong <- data
+			return nil
+		})
+		assert.NoError(t, conn.WriteControl(websocket.PingMessage, []byte("hi"), time.Now().Add(time.Second)))
+		go conn.ReadMessage() // the control frames are handled while reading
+		select {
+		case data := <-pong:
+			assert.Equal(t, "hi", data)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "should receive the pong")
+		}
+	})
+
+	t.Run("MessageTooLarge", func(t *testing.T) {
+		conn := dial(t, "/ws")
+		defer conn.Close()
+
+		_, _, err := conn.ReadMessage()
+		assert.NoError(t, err)
+
+		assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(strings.Repeat("x", 17))))
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseMessageTooBig), "should close the connection, got: %v", err)
+	})
+
+	t.Run("CloseWith", func(t *testing.T) {
+		conn := dial(t, "/ws/busy")
+		defer conn.Close()
+
+		_, _, err := conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), "got: %v", err)
+	})
+}
@@ -0,0 +1,261 @@
+// Package s3 is a minimal client of the S3 compatible object storage (e.g. AWS S3, MinIO, R2, OSS and COS),
+// the requests are signed by AWS Signature Version 4.
+//
+// @link https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Options struct {
+	Endpoint  string // The S3 endpoint (e.g. `https://s3.us-east-1.amazonaws.com`), AWS is used if empty
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool // Use the path style URL (e.g. MinIO), otherwise the virtual-hosted style
+	Client    *http.Client
+}
+
+type Client struct {
+	opts Options
+}
+
+func New(opts Options) *Client {
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = "https://s3." + opts.Region + ".amazonaws.com"
+	}
+	opts.Endpoint = strings.TrimSuffix(opts.Endpoint, "/")
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Minute}
+	}
+	return &Client{opts: opts}
+}
+
+// Upload the object, the body is read twice to hash the payload
+func (c *Client) PutObject(key string, body io.ReadSeeker, size int64, contentType string) error {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, body); err != nil {
+		return err
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, c.ObjectURL(key, nil), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	_, err = c.do(req, hex.EncodeToString(hash.Sum(nil)))
+	return err
+}
+
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// List the objects by the prefix
+func (c *Client) ListObjects(prefix string) ([]Object, error) {
+	objects := []Object{}
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := http.NewRequest(http.MethodGet, c.ObjectURL("", query), nil)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.do(req, emptySHA256)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Contents              []Object
+			IsTruncated           bool
+			NextContinuationToken string
+		}
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+		objects = append(objects, result.Contents...)
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (c *Client) DeleteObject(key string) error {
+	req, err := http.NewRequest(http.MethodDelete, c.ObjectURL(key, nil), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, emptySHA256)
+	return err
+}
+
+// Get the presigned URL to upload the object directly (e.g. from the browser),
+// the Content-Type and Content-Length headers of the upload request should be the same as signed.
+func (c *Client) PresignPutObject(key string, contentType string, size int64, expires time.Duration) string {
+	req, _ := http.NewRequest(http.MethodPut, c.ObjectURL(key, nil), nil)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if size > 0 {
+		// The Content-Length is signed to keep the size of uploaded object the same as declared
+		req.Header.Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	presignRequest(req, c.opts.AccessKey, c.opts.SecretKey, c.opts.Region, time.Now(), expires)
+	return req.URL.String()
+}
+
+// Get the URL of object, which is accessible only if the bucket is public
+func (c *Client) ObjectURL(key string, query url.Values) string {
+	u, _ := url.Parse(c.opts.Endpoint)
+	if c.opts.PathStyle {
+		u.Path = "/" + c.opts.Bucket + "/" + key
+	} else {
+		u.Host = c.opts.Bucket + "." + u.Host
+		u.Path = "/" + key
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func (c *Client) do(req *http.Request, payloadHash string) ([]byte, error) {
+	signRequest(req, payloadHash, c.opts.AccessKey, c.opts.SecretKey, c.opts.Region, time.Now())
+
+	resp, err := c.opts.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("s3 responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// The SHA256 of empty payload
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// Sign the request by AWS Signature Version 4 with the Authorization header
+func signRequest(req *http.Request, payloadHash string, accessKey string, secretKey string, region string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	scope := amzDate[:8] + "/" + region + "/s3/aws4_request"
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders, signature := signature(req, payloadHash, secretKey, region, amzDate)
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// Sign the request by AWS Signature Version 4 with the query parameters, the payload is unsigned
+//
+// @link https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
+func presignRequest(req *http.Request, accessKey string, secretKey string, region string, now time.Time, expires time.Duration) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	scope := amzDate[:8] + "/" + region + "/s3/aws4_request"
+
+	query := req.URL.Query()
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", accessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", strings.Join(canonicalHeaderNames(req), ";"))
+	req.URL.RawQuery = query.Encode()
+
+	_, signature := signature(req, "UNSIGNED-PAYLOAD", secretKey, region, amzDate)
+	req.URL.RawQuery += "&X-Amz-Signature=" + signature
+}
+
+// Get the signed headers and the signature of request
+func signature(req *http.Request, payloadHash string, secretKey string, region string, amzDate string) (string, string) {
+	date := amzDate[:8]
+
+	// Canonical headers (the host and all the headers set)
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := canonicalHeaderNames(req)
+	canonicalHeaders := ""
+	for _, k := range names {
+		canonicalHeaders += k + ":" + headers[k] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	// The query values are encoded and sorted by the key
+	canonicalQuery := strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	for _, v := range []string{region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, v)
+	}
+
+	return signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// Get the sorted lowercase names of the host and all the headers set
+func canonicalHeaderNames(req *http.Request) []string {
+	names := []string{"host"}
+	for k := range req.Header {
+		names = append(names, strings.ToLower(k))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,212 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// Create the engine using the full-text index of database,
+// the LIKE query is used if the database is not supported
+func NewDatabaseEngine(db *gorm.DB, table string) Engine {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		return &sqliteEngine{db: db, table: table}
+	case "postgres":
+		return &postgresEngine{db: db, table: table}
+	case "mysql":
+		return &mysqlEngine{db: db, table: table}
+	}
+	return NewLikeEngine()
+}
+
+// Create the engine which matches the comments by the LIKE query without any index
+func NewLikeEngine() Engine {
+	return &likeEngine{}
+}
+
+// Match the terms by the full-text index, the terms shorter than
+// the min token length of index are matched by the LIKE query instead
+func matchTerms(keywords string, minLength int, fulltext func(terms []string) Condition) Condition {
+	long, short := lo.FilterReject(splitTerms(keywords), func(term string, _ int) bool {
+		return utf8.RuneCountInString(term) >= minLength
+	})
+
+	conds := []Condition{}
+	if len(long) > 0 {
+		conds = append(conds, fulltext(long))
+	}
+	for _, term := range short {
+		conds = append(conds, likeCondition(term))
+	}
+	return andConditions(conds)
+}
+
+func likeCondition(term string) Condition {
+	return Condition{Query: "content LIKE ?", Args: []any{"%" + term + "%"}}
+}
+
+// ===============
+//  LIKE
+// ===============
+
+type likeEngine struct{}
+
+func (e *likeEngine) Name() string { return "like" }
+
+func (e *likeEngine) Setup() error { return nil }
+
+func (e *likeEngine) Match(keywords string) (Condition, error) {
+	return andConditions(lo.Map(splitTerms(keywords), func(term string, _ int) Condition {
+		return likeCondition(term)
+	})), nil
+}
+
+// ===============
+//  SQLite FTS5
+// ===============
+
+// The min token length of the trigram tokenizer
+const sqliteTrigramLength = 3
+
+// The FTS5 extension of SQLite requires building with `-tags sqlite_fts5`
+type sqliteEngine struct {
+	db    *gorm.DB
+	table string
+}
+
+func (e *sqliteEngine) Name() string { return "sqlite_fts5" }
+
+func (e *sqliteEngine) ftsTable() string { return e.table + "_fts" }
+
+func (e *sqliteEngine) triggers() [][2]string {
+	fts := e.ftsTable()
+	insert := fmt.Sprintf("INSERT INTO %s(rowid, content) VALUES (new.id, new.content);", fts)
+	remove := fmt.Sprintf("INSERT INTO %s(%s, rowid, content) VALUES ('delete', old.id, old.content);", fts, fts)
+	return [][2]string{
+		{fts + "_ai", fmt.Sprintf("AFTER INSERT ON %s BEGIN %s END", e.table, insert)},
+		{fts + "_ad", fmt.Sprintf("AFTER DELETE ON %s BEGIN %s END", e.table, remove)},
+		{fts + "_au", fmt.Sprintf("AFTER UPDATE OF content ON %s BEGIN %s %s END", e.table, remove, insert)},
+	}
+}
+
+// Create the external content FTS5 table of comments with the trigram tokenizer (works for CJK),
+// and the triggers to sync the comment changes. The triggers are created again if missing
+// (e.g. the comments table is re-created by the migration), and the index is rebuilt.
+func (e *sqliteEngine) Setup() error {
+	triggers := e.triggers()
+	names := lo.Map(triggers, func(t [2]string, _ int) string { return t[0] })
+
+	var count int64
+	if err := e.db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name IN ?", names).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count == int64(len(triggers)) {
+		return nil
+	}
+
+	err := e.db.Transaction(func(tx *gorm.DB) error {
+		stmts := []string{fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts5(content, content='%s', content_rowid='id', tokenize='trigram')",
+			e.ftsTable(), e.table)}
+		for _, t := range triggers {
+			stmts = append(stmts, fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s %s", t[0], t[1]))
+		}
+		for _, stmt := range stmts {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return e.rebuild(tx)
+	})
+	if err != nil && strings.Contains(err.Error(), "no such module") {
+		return fmt.Errorf("%w (the sqlite should be built with `-tags sqlite_fts5`)", err)
+	}
+	return err
+}
+
+func (e *sqliteEngine) Rebuild() error {
+	return e.rebuild(e.db)
+}
+
+func (e *sqliteEngine) rebuild(db *gorm.DB) error {
+	fts := e.ftsTable()
+	return db.Exec(fmt.Sprintf("INSERT INTO %s(%s) VALUES ('rebuild')", fts, fts)).Error
+}
+
+func (e *sqliteEngine) Match(keywords string) (Condition, error) {
+	fts := e.ftsTable()
+	return matchTerms(keywords, sqliteTrigramLength, func(terms []string) Condition {
+		phrases := lo.Map(terms, func(term string, _ int) string {
+			return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		})
+		return Condition{
+			Query: fmt.Sprintf("id IN (SELECT rowid FROM %s WHERE %s MATCH ?)", fts, fts),
+			Args:  []any{strings.Join(phrases, " AND ")},
+		}
+	}), nil
+}
+
+// ===============
+//  PostgreSQL
+// ===============
+
+type postgresEngine struct {
+	db    *gorm.DB
+	table string
+}
+
+func (e *postgresEngine) Name() string { return "postgres_tsvector" }
+
+// Create the GIN index of the tsvector of comment content,
+// the expression in query must be the same as the index to use it
+func (e *postgresEngine) Setup() error {
+	return e.db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_content_tsv ON %s USING GIN (to_tsvector('simple', content))",
+		e.table, e.table)).Error
+}
+
+func (e *postgresEngine) Match(keywords string) (Condition, error) {
+	return matchTerms(keywords, 1, func(terms []string) Condition {
+		return Condition{
+			Query: "to_tsvector('simple', content) @@ plainto_tsquery('simple', ?)",
+			Args:  []any{strings.Join(terms, " ")},
+		}
+	}), nil
+}
+
+// ===============
+//  MySQL
+// ===============
+
+// The default `ngram_token_size` of the ngram parser
+const mysqlNgramLength = 2
+
+type mysqlEngine struct {
+	db    *gorm.DB
+	table string
+}
+
+func (e *mysqlEngine) Name() string { return "mysql_fulltext" }
+
+// Create the FULLTEXT index of comment content with the ngram parser (works for CJK)
+func (e *mysqlEngine) Setup() error {
+	index := fmt.Sprintf("idx_%s_content_ft", e.table)
+	if e.db.Migrator().HasIndex(e.table, index) {
+		return nil
+	}
+	return e.db.Exec(fmt.Sprintf("CREATE FULLTEXT INDEX %s ON %s (content) WITH PARSER ngram", index, e.table)).Error
+}
+
+func (e *mysqlEngine) Match(keywords string) (Condition, error) {
+	return matchTerms(keywords, mysqlNgramLength, func(terms []string) Condition {
+		phrases := lo.Map(terms, func(term string, _ int) string {
+			return `+"` + strings.ReplaceAll(term, `"`, "") + `"`
+		})
+		return Condition{
+			Query: "MATCH(content) AGAINST(? IN BOOLEAN MODE)",
+			Args:  []any{strings.Join(phrases, " ")},
+		}
+	}), nil
+}
@@ -0,0 +1,140 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type MeilisearchOptions struct {
+	Host   string
+	APIKey string
+	Index  string
+	Client *http.Client
+}
+
+// The default index name of comments
+const meilisearchDefaultIndex = "artalk_comments"
+
+// Create the engine using the external search engine Meilisearch,
+// the comments are matched by the engine and then filtered by the database
+func NewMeilisearchEngine(opts MeilisearchOptions) Engine {
+	if opts.Index == "" {
+		opts.Index = meilisearchDefaultIndex
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	opts.Host = strings.TrimSuffix(opts.Host, "/")
+	return &meilisearchEngine{opts: opts}
+}
+
+var _ Indexer = (*meilisearchEngine)(nil)
+
+type meilisearchEngine struct {
+	opts MeilisearchOptions
+}
+
+func (e *meilisearchEngine) Name() string { return "meilisearch" }
+
+// Update the settings of index, the index is created if not exists
+func (e *meilisearchEngine) Setup() error {
+	if e.opts.Host == "" {
+		return fmt.Errorf("meilisearch host is not configured")
+	}
+	return e.request(http.MethodPatch, "/settings", map[string]any{
+		"searchableAttributes": []string{"content"},
+		"filterableAttributes": []string{"site_name", "page_key"},
+	}, nil)
+}
+
+func (e *meilisearchEngine) Match(keywords string) (Condition, error) {
+	if strings.TrimSpace(keywords) == "" {
+		return noMatch, nil
+	}
+
+	var result struct {
+		Hits []struct {
+			ID uint `json:"id"`
+		} `json:"hits"`
+	}
+	if err := e.request(http.MethodPost, "/search", map[string]any{
+		"q":                    keywords,
+		"limit":                MaxHits,
+		"attributesToRetrieve": []string{"id"},
+	}, &result); err != nil {
+		return noMatch, err
+	}
+
+	if len(result.Hits) == 0 {
+		return noMatch, nil
+	}
+
+	ids := make([]uint, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ID)
+	}
+	return Condition{Query: "id IN (?)", Args: []any{ids}}, nil
+}
+
+func (e *meilisearchEngine) Index(docs []Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	return e.request(http.MethodPost, "/documents?primaryKey=id", docs, nil)
+}
+
+func (e *meilisearchEngine) Delete(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return e.request(http.MethodPost, "/documents/delete-batch", ids, nil)
+}
+
+func (e *meilisearchEngine) Clear() error {
+	return e.request(http.MethodDelete, "/documents", nil, nil)
+}
+
+// Send the request to the API of index, the tasks of writing are processed asynchronously by Meilisearch
+func (e *meilisearchEngine) request(method string, path string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequest(method, e.opts.Host+"/indexes/"+url.PathEscape(e.opts.Index)+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.opts.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.opts.APIKey)
+	}
+
+	resp, err := e.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("meilisearch responded %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if out != nil {
+		return json.Unmarshal(respBody, out)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Package search matches the comments by keywords with the full-text index,
+// which is provided by the database itself or by an external search engine.
+package search
+
+import (
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/samber/lo"
+)
+
+// The max number of search terms, the rest are ignored
+const maxTerms = 10
+
+// The max number of comments matched by the external engine
+const MaxHits = 1000
+
+// The `WHERE` condition of the comments matched by the keywords
+type Condition struct {
+	Query string
+	Args  []any
+}
+
+var noMatch = Condition{Query: "1 = 0"}
+
+type Engine interface {
+	Name() string
+
+	// Prepare the index, it is called at the app startup
+	Setup() error
+
+	// Get the condition to match the comments by keywords
+	Match(keywords string) (Condition, error)
+}
+
+// Indexer is implemented by the external engines,
+// which need to be synced with the comment changes
+type Indexer interface {
+	Index(docs []Document) error
+	Delete(ids []uint) error
+	Clear() error
+}
+
+// Rebuilder is implemented by the engines which can rebuild the index by themselves
+type Rebuilder interface {
+	Rebuild() error
+}
+
+// The comment document in the index of external engine
+type Document struct {
+	ID       uint   `json:"id"`
+	Content  string `json:"content"`
+	SiteName string `json:"site_name"`
+	PageKey  string `json:"page_key"`
+}
+
+func NewDocument(comment entity.Comment) Document {
+	return Document{
+		ID:       comment.ID,
+		Content:  comment.Content,
+		SiteName: comment.SiteName,
+		PageKey:  comment.PageKey,
+	}
+}
+
+// Split the keywords into the unique terms by spaces
+func splitTerms(keywords string) []string {
+	terms := lo.Uniq(strings.Fields(keywords))
+	if len(terms) > maxTerms {
+		terms = terms[:maxTerms]
+	}
+	return terms
+}
+
+// Join the conditions with `AND`
+func andConditions(conds []Condition) Condition {
+	if len(conds) == 0 {
+		return noMatch
+	}
+
+	queries := []string{}
+	args := []any{}
+	for _, c := range conds {
+		queries = append(queries, "("+c.Query+")")
+		args = append(args, c.Args...)
+	}
+	return Condition{Query: strings.Join(queries, " AND "), Args: args}
+}
@@ -0,0 +1,149 @@
+package search
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSplitTerms(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, splitTerms("  a b  a "))
+	assert.Empty(t, splitTerms(" "))
+	assert.Len(t, splitTerms("1 2 3 4 5 6 7 8 9 10 11 12"), maxTerms)
+}
+
+func TestLikeEngine(t *testing.T) {
+	e := NewLikeEngine()
+
+	cond, err := e.Match("foo bar")
+	assert.NoError(t, err)
+	assert.Equal(t, "(content LIKE ?) AND (content LIKE ?)", cond.Query)
+	assert.Equal(t, []any{"%foo%", "%bar%"}, cond.Args)
+
+	cond, _ = e.Match(" ")
+	assert.Equal(t, noMatch, cond)
+}
+
+func TestMatchTerms(t *testing.T) {
+	cond := matchTerms("ab abc 你好", 3, func(terms []string) Condition {
+		assert.Equal(t, []string{"abc"}, terms)
+		return Condition{Query: "fulltext", Args: []any{terms[0]}}
+	})
+	assert.Equal(t, "(fulltext) AND (content LIKE ?) AND (content LIKE ?)", cond.Query)
+	assert.Equal(t, []any{"abc", "%ab%", "%你好%"}, cond.Args)
+}
+
+func TestSQLiteEngine(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.Comment{}))
+
+	db.Create(&entity.Comment{Content: "Hello Artalk"})
+	db.Create(&entity.Comment{Content: "测试嵌套回复"})
+
+	e := NewDatabaseEngine(db, "comments")
+	if err := e.Setup(); err != nil {
+		t.Skip("FTS5 is not available: ", err)
+	}
+
+	find := func(keywords string) []uint {
+		cond, err := e.Match(keywords)
+		require.NoError(t, err)
+		var ids []uint
+		require.NoError(t, db.Model(&entity.Comment{}).Where(cond.Query, cond.Args...).Order("id").Pluck("id", &ids).Error)
+		return ids
+	}
+
+	assert.Equal(t, []uint{1}, find("artalk"))
+	assert.Equal(t, []uint{2}, find("嵌套回复"))
+	assert.Equal(t, []uint{2}, find("回复"), "the short terms should be matched by the LIKE query")
+	assert.Empty(t, find("hello 回复"))
+
+	// the index should be synced by the triggers
+	db.Model(&entity.Comment{}).Where("id = 1").Update("content", "Bye")
+	db.Create(&entity.Comment{Content: "Hello again"})
+	assert.Equal(t, []uint{3}, find("hello"))
+
+	// the existing index is kept
+	assert.NoError(t, e.Setup())
+	assert.NoError(t, e.(Rebuilder).Rebuild())
+	assert.Equal(t, []uint{3}, find("hello"))
+}
+
+func TestMeilisearchEngine(t *testing.T) {
+	type call struct {
+		Method string
+		Path   string
+		Body   string
+	}
+	var calls []call
+	var hits []map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, call{Method: r.Method, Path: r.URL.RequestURI(), Body: string(body)})
+		if r.URL.Path == "/indexes/comments/search" {
+			json.NewEncoder(w).Encode(map[string]any{"hits": hits})
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"taskUid":1}`))
+	}))
+	defer server.Close()
+
+	e := NewMeilisearchEngine(MeilisearchOptions{Host: server.URL + "/", APIKey: "secret", Index: "comments"})
+	indexer := e.(Indexer)
+
+	t.Run("Setup", func(t *testing.T) {
+		calls = nil
+		assert.NoError(t, e.Setup())
+		assert.Equal(t, http.MethodPatch, calls[0].Method)
+		assert.Equal(t, "/indexes/comments/settings", calls[0].Path)
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		hits = []map[string]any{{"id": 2}, {"id": 5}}
+		cond, err := e.Match("hello")
+		assert.NoError(t, err)
+		assert.Equal(t, "id IN (?)", cond.Query)
+		assert.Equal(t, []any{[]uint{2, 5}}, cond.Args)
+
+		hits = nil
+		cond, err = e.Match("hello")
+		assert.NoError(t, err)
+		assert.Equal(t, noMatch, cond)
+	})
+
+	t.Run("Index", func(t *testing.T) {
+		calls = nil
+		assert.NoError(t, indexer.Index([]Document{{ID: 1, Content: "hello", SiteName: "Site A", PageKey: "/1.html"}}))
+		assert.NoError(t, indexer.Delete([]uint{1, 2}))
+		assert.NoError(t, indexer.Index(nil), "nothing should be sent")
+		assert.NoError(t, indexer.Clear())
+
+		assert.Equal(t, []call{
+			{http.MethodPost, "/indexes/comments/documents?primaryKey=id", `[{"id":1,"content":"hello","site_name":"Site A","page_key":"/1.html"}]`},
+			{http.MethodPost, "/indexes/comments/documents/delete-batch", `[1,2]`},
+			{http.MethodDelete, "/indexes/comments/documents", ``},
+		}, calls)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		e := NewMeilisearchEngine(MeilisearchOptions{Host: server.URL, APIKey: "wrong"})
+		_, err := e.Match("hello")
+		assert.ErrorContains(t, err, "401")
+	})
+}
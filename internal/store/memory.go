@@ -0,0 +1,134 @@
+package store
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The number of entries to trigger the GC of expired entries
+const memoryGCThreshold = 1000
+
+// The store in memory, which is not shared by the instances
+type MemoryStore struct {
+	mu      sync.Mutex
+	data    map[string]memoryEntry
+	gcAfter int // The number of entries to trigger the next GC
+}
+
+type memoryEntry struct {
+	value      string
+	expiration time.Time
+}
+
+func (e memoryEntry) isExpired(now time.Time) bool {
+	return !e.expiration.IsZero() && !now.Before(e.expiration)
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		data:    map[string]memoryEntry{},
+		gcAfter: memoryGCThreshold,
+	}
+}
+
+func (s *MemoryStore) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.get(key, time.Now())
+	return entry.value, ok
+}
+
+func (s *MemoryStore) Set(key string, value string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set(key, value, ttl, time.Now())
+	return nil
+}
+
+func (s *MemoryStore) SetNX(key string, value string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := s.get(key, now); ok {
+		return false, nil
+	}
+	s.set(key, value, ttl, now)
+	return true, nil
+}
+
+func (s *MemoryStore) Incr(key string, delta int64, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := s.get(key, now)
+	if !ok {
+		s.set(key, strconv.FormatInt(delta, 10), ttl, now)
+		return delta, nil
+	}
+
+	n, err := strconv.ParseInt(entry.value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	n += delta
+	entry.value = strconv.FormatInt(n, 10)
+	s.data[key] = entry // the expiration is kept
+	return n, nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, key)
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = map[string]memoryEntry{}
+	return nil
+}
+
+func (s *MemoryStore) get(key string, now time.Time) (memoryEntry, bool) {
+	entry, ok := s.data[key]
+	if !ok {
+		return memoryEntry{}, false
+	}
+	if entry.isExpired(now) {
+		delete(s.data, key)
+		return memoryEntry{}, false
+	}
+	return entry, true
+}
+
+func (s *MemoryStore) set(key string, value string, ttl time.Duration, now time.Time) {
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiration = now.Add(ttl)
+	}
+	s.data[key] = entry
+
+	if len(s.data) >= s.gcAfter {
+		s.gc(now)
+	}
+}
+
+// Delete the expired entries, the next GC is triggered when the entries are doubled
+func (s *MemoryStore) gc(now time.Time) {
+	for key, entry := range s.data {
+		if entry.isExpired(now) {
+			delete(s.data, key)
+		}
+	}
+	s.gcAfter = max(memoryGCThreshold, len(s.data)*2)
+}
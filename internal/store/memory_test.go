@@ -0,0 +1,90 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore()
+	defer s.Close()
+
+	t.Run("Get and Set", func(t *testing.T) {
+		_, ok := s.Get("a")
+		assert.False(t, ok)
+
+		assert.NoError(t, s.Set("a", "1", 0))
+		val, ok := s.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, "1", val)
+
+		assert.NoError(t, s.Delete("a"))
+		_, ok = s.Get("a")
+		assert.False(t, ok)
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		assert.NoError(t, s.Set("b", "1", 20*time.Millisecond))
+		_, ok := s.Get("b")
+		assert.True(t, ok)
+
+		time.Sleep(30 * time.Millisecond)
+		_, ok = s.Get("b")
+		assert.False(t, ok)
+	})
+
+	t.Run("SetNX", func(t *testing.T) {
+		ok, err := s.SetNX("c", "1", 20*time.Millisecond)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, _ = s.SetNX("c", "2", 20*time.Millisecond)
+		assert.False(t, ok, "the key exists")
+		val, _ := s.Get("c")
+		assert.Equal(t, "1", val)
+
+		time.Sleep(30 * time.Millisecond)
+		ok, _ = s.SetNX("c", "3", 0)
+		assert.True(t, ok, "the key is expired")
+	})
+
+	t.Run("Incr", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.Incr("d", 1, time.Minute)
+			}()
+		}
+		wg.Wait()
+
+		n, err := s.Incr("d", 2, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(102), n)
+	})
+
+	t.Run("Incr keeps the expiration of window", func(t *testing.T) {
+		s.Incr("e", 1, 30*time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		s.Incr("e", 1, 30*time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+
+		n, _ := s.Incr("e", 1, 30*time.Millisecond)
+		assert.Equal(t, int64(1), n, "the window is reset after the first ttl")
+	})
+
+	t.Run("GC", func(t *testing.T) {
+		gcStore := NewMemoryStore()
+		for i := 0; i < memoryGCThreshold-1; i++ {
+			gcStore.Set(fmt.Sprint("expired_", i), "1", time.Millisecond)
+		}
+		time.Sleep(5 * time.Millisecond)
+		gcStore.Set("kept", "1", 0)
+		assert.Len(t, gcStore.data, 1)
+	})
+}
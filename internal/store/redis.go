@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// The prefix of keys in Redis
+const redisKeyPrefix = "artalk:store:"
+
+// Increase the number and set the ttl when the key is created (atomically)
+var redisIncrScript = redis.NewScript(`
+local n = redis.call("INCRBY", KEYS[1], ARGV[1])
+if n == tonumber(ARGV[1]) and tonumber(ARGV[2]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return n
+`)
+
+// The store in Redis, which is shared by the instances of cluster mode
+type RedisStore struct {
+	client redis.UniversalClient
+	ctx    context.Context
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// Create the store by the Redis client, the client is closed with the store
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client, ctx: context.Background()}
+}
+
+func (s *RedisStore) Get(key string) (string, bool) {
+	val, err := s.client.Get(s.ctx, redisKeyPrefix+key).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Error("[Store] Failed to get the key from Redis: ", err)
+		}
+		return "", false
+	}
+	return val, true
+}
+
+func (s *RedisStore) Set(key string, value string, ttl time.Duration) error {
+	return s.client.Set(s.ctx, redisKeyPrefix+key, value, ttl).Err()
+}
+
+func (s *RedisStore) SetNX(key string, value string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(s.ctx, redisKeyPrefix+key, value, ttl).Result()
+}
+
+func (s *RedisStore) Incr(key string, delta int64, ttl time.Duration) (int64, error) {
+	return redisIncrScript.Run(s.ctx, s.client, []string{redisKeyPrefix + key}, delta, ttl.Milliseconds()).Int64()
+}
+
+func (s *RedisStore) Delete(key string) error {
+	return s.client.Del(s.ctx, redisKeyPrefix+key).Err()
+}
+
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
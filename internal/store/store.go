@@ -0,0 +1,29 @@
+// The store of the states shared by the requests (e.g. the rate limit counters and the captcha challenges).
+//
+// The states are kept in memory for the single instance, and in Redis for the cluster mode,
+// so the instances behind a load balancer see the same states.
+package store
+
+import (
+	"time"
+)
+
+type Store interface {
+	// Get the value of key, false if not found or expired
+	Get(key string) (string, bool)
+
+	// Set the value of key, never expires if the ttl is 0
+	Set(key string, value string, ttl time.Duration) error
+
+	// Set the value of key only if it does not exist, false if the key exists
+	SetNX(key string, value string, ttl time.Duration) (bool, error)
+
+	// Increase the number of key by delta and return the new number,
+	// the ttl is only set when the key is created (e.g. the fixed window of rate limit)
+	Incr(key string, delta int64, ttl time.Duration) (int64, error)
+
+	// Delete the key
+	Delete(key string) error
+
+	Close() error
+}
@@ -20,6 +20,7 @@ type Renderer struct {
 	dao        *dao.Dao
 	strategy   RenderStrategy
 	defaultTpl string
+	custom     *CustomTemplates
 }
 
 func (r *Renderer) Render(notify *entity.Notify, customTpl ...string) string {
@@ -36,6 +37,21 @@ func (r *Renderer) Render(notify *entity.Notify, customTpl ...string) string {
 	return r.strategy.Render(tpl, params, notify, extra)
 }
 
+// Use the custom templates for RenderCustom
+func (r *Renderer) UseCustomTemplates(custom *CustomTemplates) *Renderer {
+	r.custom = custom
+	return r
+}
+
+// Render the notify with the custom template of the channel for its event,
+// false is returned if the custom template is not found (the default template should be used)
+func (r *Renderer) RenderCustom(notify *entity.Notify, channel string) (string, bool) {
+	if r.custom == nil {
+		return "", false
+	}
+	return r.custom.Render(r.dao, channel, notify)
+}
+
 func NewRenderer(dao *dao.Dao, renderType RenderType, defaultTemplateLoader TemplateLoader) *Renderer {
 	r := &Renderer{
 		dao: dao,
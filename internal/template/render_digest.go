@@ -0,0 +1,107 @@
+package template
+
+import (
+	htmlTemplate "html/template"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+)
+
+// The max number of the notifies listed in a digest email
+const maxDigestItems = 50
+
+// The notify in the digest email
+type DigestItem struct {
+	Event     string
+	Nick      string
+	Content   htmlTemplate.HTML // The sanitized HTML of comment content
+	PageTitle string
+	PageURL   string
+	SiteName  string
+	Link      string
+	Datetime  string
+}
+
+// The data of digest email template
+type DigestData struct {
+	Nick     string // The name of receiver
+	Count    int    // The total number of notifies
+	Items    []DigestItem
+	More     int // The number of notifies not listed
+	SiteName string
+	SiteURL  string
+
+	// The localized texts
+	Title     string
+	ReplyText string
+	MoreText  string
+}
+
+// Get the data of digest email of a user's notifies
+func GetDigestData(dao *dao.Dao, notifies []entity.Notify) DigestData {
+	data := DigestData{Count: len(notifies)}
+
+	for i, notify := range notifies {
+		extra := getNotifyExtraData(dao, &notify)
+		if i == 0 {
+			data.Nick = extra.toUser.Name
+			data.SiteName = extra.from.SiteName
+			data.SiteURL = extra.from.Site.FirstUrl
+		}
+		if i >= maxDigestItems {
+			data.More = len(notifies) - maxDigestItems
+			break
+		}
+
+		data.Items = append(data.Items, DigestItem{
+			Event:     getNotifyEvent(extra),
+			Nick:      extra.from.Nick,
+			Content:   htmlTemplate.HTML(extra.from.Content),
+			PageTitle: extra.from.Page.Title,
+			PageURL:   extra.from.Page.URL,
+			SiteName:  extra.from.SiteName,
+			Link:      dao.GetReadLinkByNotify(&notify),
+			Datetime:  extra.from.Datetime,
+		})
+	}
+
+	data.Title = i18n.T("You have {{count}} new notifications", map[string]any{"count": data.Count})
+	data.ReplyText = i18n.T("Reply")
+	data.MoreText = i18n.T("And {{count}} more", map[string]any{"count": data.More})
+
+	return data
+}
+
+// Render the subject and body of digest email,
+// the custom templates of digest event are used if exist
+func RenderDigest(dao *dao.Dao, custom *CustomTemplates, notifies []entity.Notify) (subject string, body string, err error) {
+	data := GetDigestData(dao, notifies)
+
+	subjectTpl := "[{{ .SiteName }}] {{ .Title }}"
+	bodyTpl := getInternalDigestTpl()
+	if custom != nil {
+		if tpl, ok := custom.Load(dao, ChannelEmailSubject, EventDigest); ok {
+			subjectTpl = tpl
+		}
+		if tpl, ok := custom.Load(dao, ChannelEmail, EventDigest); ok {
+			bodyTpl = tpl
+		}
+	}
+
+	if subject, err = ExecuteCustomTemplate(ChannelEmailSubject, subjectTpl, data); err != nil {
+		return "", "", err
+	}
+	if body, err = ExecuteCustomTemplate(ChannelEmail, bodyTpl, data); err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+func getInternalDigestTpl() string {
+	buf, err := internalTpl.ReadFile("email_tpl/digest.tmpl")
+	if err != nil {
+		return ""
+	}
+	return string(buf)
+}
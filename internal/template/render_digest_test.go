@@ -0,0 +1,50 @@
+package template_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/template"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDigest(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	notifies := []entity.Notify{
+		app.Dao().FindNotify(1000, 1000),
+		app.Dao().FindNotify(1000, 1001),
+	}
+
+	t.Run("Data", func(t *testing.T) {
+		data := template.GetDigestData(app.Dao(), notifies)
+		assert.Equal(t, 2, data.Count)
+		assert.Equal(t, 0, data.More)
+		assert.Len(t, data.Items, 2)
+		assert.Equal(t, []string{"comment", "reply"}, []string{data.Items[0].Event, data.Items[1].Event})
+		assert.Contains(t, data.Title, "2")
+	})
+
+	t.Run("Internal", func(t *testing.T) {
+		subject, body, err := template.RenderDigest(app.Dao(), nil, notifies)
+		assert.NoError(t, err)
+		assert.Contains(t, subject, "2")
+		assert.Contains(t, body, "回复测试 1001")
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "email"), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "email", "digest.tmpl"),
+			[]byte(`{{ .Count }}{{ range .Items }} {{ .Content }}{{ end }}`), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "email", "default.tmpl"), []byte("not used"), 0644))
+
+		_, body, err := template.RenderDigest(app.Dao(), &template.CustomTemplates{Dir: dir}, notifies[1:])
+		assert.NoError(t, err)
+		assert.Equal(t, "1 <p>回复测试 1001</p>", body, "the content should not be escaped")
+	})
+}
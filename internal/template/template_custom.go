@@ -0,0 +1,230 @@
+package template
+
+import (
+	"bytes"
+	htmlTemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	textTemplate "text/template"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/samber/lo"
+)
+
+// -------------------------------------------------------------------
+//  Custom Templates
+// -------------------------------------------------------------------
+
+// The notify channels of the custom templates
+const (
+	ChannelEmail        = "email"
+	ChannelEmailSubject = "email_subject"
+	ChannelNotify       = "notify" // The fallback of all the IM channels below
+	ChannelTelegram     = "telegram"
+	ChannelLark         = "lark"
+	ChannelDingTalk     = "ding_talk"
+	ChannelWeCom        = "wecom"
+	ChannelBark         = "bark"
+	ChannelNtfy         = "ntfy"
+	ChannelGotify       = "gotify"
+	ChannelPushover     = "pushover"
+	ChannelMatrix       = "matrix"
+	ChannelSlack        = "slack"
+	ChannelDiscord      = "discord"
+	ChannelLINE         = "line"
+	ChannelWebHook      = "webhook"
+)
+
+// The event types of the custom templates
+const (
+	EventComment = entity.NotifyEventComment
+	EventReply   = entity.NotifyEventReply
+	EventMention = entity.NotifyEventMention
+	EventPending = entity.NotifyEventPending
+	EventDigest  = "digest"  // The summary of notifies (for the email digest mode, the default is not used as the fallback)
+	EventDefault = "default" // The fallback of all the events
+)
+
+var Channels = []string{ChannelEmail, ChannelEmailSubject, ChannelNotify, ChannelTelegram, ChannelLark,
+	ChannelDingTalk, ChannelWeCom, ChannelBark, ChannelNtfy, ChannelGotify, ChannelPushover, ChannelMatrix, ChannelSlack, ChannelDiscord, ChannelLINE, ChannelWebHook}
+
+var Events = []string{EventComment, EventReply, EventMention, EventPending, EventDigest, EventDefault}
+
+func IsValidChannel(channel string) bool {
+	return lo.Contains(Channels, channel)
+}
+
+func IsValidEvent(event string) bool {
+	return lo.Contains(Events, event)
+}
+
+// The custom Go templates of notifications, which are looked up by the channel, the event and the locale.
+//
+// The templates edited in the dashboard (stored in the DB) are looked up first, then the files in Dir
+// named as `{channel}/{event}.{locale}.tmpl` or `{channel}/{event}.tmpl`.
+// The templates are loaded on every rendering, so the changes take effect without restart.
+type CustomTemplates struct {
+	Dir    string
+	Locale string
+}
+
+// The data of custom templates
+type customTplData struct {
+	tplParams
+	Event string
+
+	// The comment content is the trusted HTML in email (sanitized when cooked), and the plain text in the other channels
+	Content      any
+	ReplyContent any
+}
+
+// Get the candidates of template lookup in the order of priority
+func (ct *CustomTemplates) candidates(channel string, event string) (channels []string, events []string, locales []string) {
+	channels = []string{channel}
+	if channel != ChannelEmail && channel != ChannelEmailSubject && channel != ChannelNotify {
+		channels = append(channels, ChannelNotify)
+	}
+	events = []string{event}
+	if event != EventDigest {
+		events = append(events, EventDefault)
+	}
+	locales = []string{""}
+	if ct.Locale != "" {
+		locales = []string{ct.Locale, ""}
+	}
+	return
+}
+
+// Load the custom template, false is returned if not found
+func (ct *CustomTemplates) Load(dao *dao.Dao, channel string, event string) (string, bool) {
+	channels, events, locales := ct.candidates(channel, event)
+
+	stored := map[string]string{}
+	for _, tpl := range dao.FindNotifyTemplates(channels) {
+		stored[tpl.Channel+"/"+tpl.Event+"/"+tpl.Locale] = tpl.Content
+	}
+	loadStored := func(channel string, event string, locale string) (string, bool) {
+		content, ok := stored[channel+"/"+event+"/"+locale]
+		return content, ok
+	}
+
+	// the templates in DB take precedence over the files
+	for _, load := range []func(channel string, event string, locale string) (string, bool){loadStored, ct.loadFile} {
+		for _, ch := range channels {
+			for _, ev := range events {
+				for _, locale := range locales {
+					if content, ok := load(ch, ev, locale); ok {
+						return content, true
+					}
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+func (ct *CustomTemplates) loadFile(channel string, event string, locale string) (string, bool) {
+	if ct.Dir == "" {
+		return "", false
+	}
+
+	filename := event + ".tmpl"
+	if locale != "" {
+		if strings.ContainsAny(locale, `/\.`) {
+			return "", false
+		}
+		filename = event + "." + locale + ".tmpl"
+	}
+
+	buf, err := os.ReadFile(filepath.Join(ct.Dir, channel, filename))
+	if err != nil {
+		return "", false
+	}
+	return string(buf), true
+}
+
+// Render the custom template of the channel for the notify, false is returned if not found
+func (ct *CustomTemplates) Render(dao *dao.Dao, channel string, notify *entity.Notify) (string, bool) {
+	extra := getNotifyExtraData(dao, notify)
+	event := getNotifyEvent(extra)
+
+	tpl, ok := ct.Load(dao, channel, event)
+	if !ok {
+		return "", false
+	}
+
+	data := customTplData{
+		tplParams: getCommonParams(dao, notify, extra),
+		Event:     event,
+	}
+	if channel == ChannelEmail {
+		data.Content = htmlTemplate.HTML(data.tplParams.Content)
+		data.ReplyContent = htmlTemplate.HTML(data.tplParams.ReplyContent)
+	} else {
+		data.Content = handleEmoticonsImgTagsForNotify(extra.to.ContentRaw)
+		data.ReplyContent = handleEmoticonsImgTagsForNotify(extra.from.ContentRaw)
+	}
+
+	result, err := ExecuteCustomTemplate(channel, tpl, data)
+	if err != nil {
+		log.Error("[Template] Failed to render the ", channel, " template of ", event, ": ", err)
+		return "", false
+	}
+	return result, true
+}
+
+// Parse the custom template to check the syntax
+func ParseCustomTemplate(channel string, tpl string) error {
+	_, err := ExecuteCustomTemplate(channel, tpl, nil)
+	return err
+}
+
+// Execute the custom template, the html/template is used for the email body to escape the values,
+// and text/template is used for the others
+func ExecuteCustomTemplate(channel string, tpl string, data any) (string, error) {
+	buf := new(bytes.Buffer)
+
+	if channel == ChannelEmail {
+		t, err := htmlTemplate.New(channel).Funcs(htmlTemplate.FuncMap(templateFuncs())).Parse(tpl)
+		if err != nil {
+			return "", err
+		}
+		if data == nil {
+			return "", nil
+		}
+		if err := t.Execute(buf, data); err != nil {
+			return "", err
+		}
+	} else {
+		t, err := textTemplate.New(channel).Funcs(textTemplate.FuncMap(templateFuncs())).Parse(tpl)
+		if err != nil {
+			return "", err
+		}
+		if data == nil {
+			return "", nil
+		}
+		if err := t.Execute(buf, data); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// Get the event type of the notify by the receiver and the comments
+func getNotifyEvent(extra notifyExtraData) string {
+	switch {
+	case extra.toUser.IsAdmin && extra.from.IsPending:
+		return EventPending
+	case extra.to.ID != 0 && extra.to.UserID == extra.toUser.ID:
+		return EventReply
+	case extra.toUser.IsAdmin:
+		return EventComment
+	default:
+		return EventMention
+	}
+}
@@ -0,0 +1,92 @@
+package template_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/template"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomTemplates(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	dir := t.TempDir()
+	writeTpl := func(name string, content string) {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	custom := &template.CustomTemplates{Dir: dir, Locale: "zh-CN"}
+	render := func(renderType template.RenderType, userID uint, commentID uint, channel string) (string, bool) {
+		notify := app.Dao().FindNotify(userID, commentID)
+		return template.NewRenderer(app.Dao(), renderType, nil).UseCustomTemplates(custom).RenderCustom(&notify, channel)
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, ok := render(template.TYPE_NOTIFY, 1000, 1000, template.ChannelTelegram)
+		assert.False(t, ok)
+	})
+
+	t.Run("Events", func(t *testing.T) {
+		writeTpl("notify/default.tmpl", "{{ .Event }}")
+
+		result, ok := render(template.TYPE_NOTIFY, 1000, 1000, template.ChannelTelegram)
+		assert.True(t, ok, "should fallback to the notify channel")
+		assert.Equal(t, "comment", result)
+
+		result, _ = render(template.TYPE_NOTIFY, 1000, 1001, template.ChannelTelegram)
+		assert.Equal(t, "reply", result)
+
+		result, _ = render(template.TYPE_NOTIFY, 1001, 1001, template.ChannelTelegram)
+		assert.Equal(t, "mention", result)
+	})
+
+	t.Run("LookupOrder", func(t *testing.T) {
+		writeTpl("telegram/reply.tmpl", "[{{ .SiteName }}] {{ .ReplyNick | upper }}: {{ .ReplyContent | trunc 4 }}")
+		result, _ := render(template.TYPE_NOTIFY, 1000, 1001, template.ChannelTelegram)
+		assert.Equal(t, "[Site A] USERA: 回复测试", result)
+
+		writeTpl("telegram/reply.zh-CN.tmpl", "{{ .ReplyNick }} 回复了你")
+		result, _ = render(template.TYPE_NOTIFY, 1000, 1001, template.ChannelTelegram)
+		assert.Equal(t, "userA 回复了你", result, "the template of locale should take precedence")
+
+		_, err := app.Dao().SaveNotifyTemplate(template.ChannelTelegram, template.EventReply, "", "{{ .ReplyNick }} (db)")
+		assert.NoError(t, err)
+		result, _ = render(template.TYPE_NOTIFY, 1000, 1001, template.ChannelTelegram)
+		assert.Equal(t, "userA (db)", result, "the template in db should take precedence")
+
+		tpl, err := app.Dao().SaveNotifyTemplate(template.ChannelTelegram, template.EventReply, "", "{{ .ReplyNick }} (db changed)")
+		assert.NoError(t, err)
+		result, _ = render(template.TYPE_NOTIFY, 1000, 1001, template.ChannelTelegram)
+		assert.Equal(t, "userA (db changed)", result, "should be reloaded")
+
+		assert.NoError(t, app.Dao().DelNotifyTemplate(&tpl))
+		writeTpl("telegram/reply.zh-CN.tmpl", "changed")
+		result, _ = render(template.TYPE_NOTIFY, 1000, 1001, template.ChannelTelegram)
+		assert.Equal(t, "changed", result, "should be reloaded")
+	})
+
+	t.Run("EmailEscape", func(t *testing.T) {
+		writeTpl("email/comment.tmpl", `<p>{{ .Nick }}</p>{{ .ReplyContent }}{{ "<b>" }}`+"\n")
+		result, ok := render(template.TYPE_EMAIL, 1000, 1000, template.ChannelEmail)
+		assert.True(t, ok)
+		assert.Equal(t, "<p>admin</p><p>Hello Artalk, 你好 Artalk!</p>\n&lt;b&gt;", result)
+	})
+
+	t.Run("ExecuteError", func(t *testing.T) {
+		writeTpl("bark/default.tmpl", "{{ .NotExists }}")
+		_, ok := render(template.TYPE_NOTIFY, 1000, 1000, template.ChannelBark)
+		assert.False(t, ok, "should fallback to the default template if failed")
+	})
+}
+
+func TestParseCustomTemplate(t *testing.T) {
+	assert.NoError(t, template.ParseCustomTemplate(template.ChannelEmail, `{{ .Nick | default "Guest" | abbrev 10 }}`))
+	assert.NoError(t, template.ParseCustomTemplate(template.ChannelSlack, `{{ if empty .Content }}{{ now | date "2006-01-02" }}{{ end }}`))
+	assert.Error(t, template.ParseCustomTemplate(template.ChannelSlack, `{{ .Nick `))
+	assert.Error(t, template.ParseCustomTemplate(template.ChannelSlack, `{{ .Nick | notFunc }}`))
+}
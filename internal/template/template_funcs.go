@@ -0,0 +1,110 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+// The functions of custom templates, the names and the argument orders follow sprig
+// (https://masterminds.github.io/sprig) so that the pipelines like `{{ .Content | trunc 100 }}` work
+func templateFuncs() map[string]any {
+	return map[string]any{
+		// strings
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix string, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix string, s string) string { return strings.TrimSuffix(s, suffix) },
+		"trunc":      func(length int, s string) string { return utils.TruncateString(s, length) },
+		"abbrev":     abbrev,
+		"replace":    func(old string, new string, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":   func(substr string, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix string, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix string, s string) bool { return strings.HasSuffix(s, suffix) },
+		"repeat":     func(count int, s string) string { return strings.Repeat(s, count) },
+		"nospace":    func(s string) string { return strings.Join(strings.Fields(s), "") },
+		"quote":      func(s string) string { return fmt.Sprintf("%q", s) },
+		"indent":     indent,
+		"nindent":    func(spaces int, s string) string { return "\n" + indent(spaces, s) },
+		"join":       func(sep string, list []string) string { return strings.Join(list, sep) },
+		"splitList":  func(sep string, s string) []string { return strings.Split(s, sep) },
+		"stripTags":  stripTags,
+
+		// defaults
+		"default":  func(def any, given any) any { return defaultValue(given, def) },
+		"empty":    isEmpty,
+		"coalesce": coalesce,
+		"ternary": func(vtrue any, vfalse any, cond bool) any {
+			if cond {
+				return vtrue
+			}
+			return vfalse
+		},
+
+		// dates
+		"now":  time.Now,
+		"date": func(layout string, t time.Time) string { return t.Format(layout) },
+
+		// encoding
+		"toJson": func(v any) string {
+			buf, _ := json.Marshal(v)
+			return string(buf)
+		},
+	}
+}
+
+func abbrev(width int, s string) string {
+	if width < 4 || len([]rune(s)) <= width {
+		return s
+	}
+	return utils.TruncateString(s, width-3) + "..."
+}
+
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+var tagsRegexp = regexp.MustCompile(`<[^>]*>`)
+
+func stripTags(s string) string {
+	return tagsRegexp.ReplaceAllString(s, "")
+}
+
+// Check if the value is the zero value of its type
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+// Return the first non-empty value
+func coalesce(v ...any) any {
+	for _, val := range v {
+		if !isEmpty(val) {
+			return val
+		}
+	}
+	return nil
+}
+
+// Return the given value if it is not empty, otherwise return the default value
+func defaultValue(given any, def any) any {
+	if isEmpty(given) {
+		return def
+	}
+	return given
+}
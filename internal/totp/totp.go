@@ -0,0 +1,142 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+// 基于时间的一次性密码 (TOTP, RFC 6238)
+//
+// 使用与主流验证器应用 (Google Authenticator, Authy 等) 兼容的默认参数：
+// SHA1 算法、6 位数字、30 秒周期
+const (
+	Digits = 6
+	Period = 30 * time.Second
+	Skew   = 1 // 允许前后各 1 个周期的时间误差
+
+	BackupCodeNum    = 10
+	BackupCodeLength = 10
+)
+
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// 生成新的密钥 (base32 编码)
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return b32.EncodeToString(buf), nil
+}
+
+// 获取验证器应用的配置地址 (可用于生成二维码)
+//
+// @see https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func ProvisioningURI(issuer string, account string, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(Digits))
+	params.Set("period", fmt.Sprint(int(Period.Seconds())))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// 获取时间对应的周期序号
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// 计算周期对应的验证码
+func Code(secret string, step int64) (string, error) {
+	key, err := b32.DecodeString(strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "=")))
+	if err != nil {
+		return "", fmt.Errorf("invalid totp secret: %w", err)
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// 校验验证码，返回匹配的周期序号
+//
+// 调用方应记录返回的周期序号，拒绝不大于上次使用的序号，以防止验证码被重放
+func Validate(secret string, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+	for step := current - Skew; step <= current+Skew; step++ {
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// 生成备用码，返回明文与用于存储的哈希值 (以逗号分隔)
+func GenerateBackupCodes() (codes []string, hashed string) {
+	hashes := make([]string, 0, BackupCodeNum)
+	for i := 0; i < BackupCodeNum; i++ {
+		code := utils.RandomStringWithAlphabet(BackupCodeLength, "abcdefghijkmnpqrstuvwxyz23456789")
+		codes = append(codes, code)
+		hashes = append(hashes, hashBackupCode(code))
+	}
+	return codes, strings.Join(hashes, ",")
+}
+
+// 使用备用码，返回剩余备用码的哈希值 (每个备用码仅可使用一次)
+func UseBackupCode(hashed string, code string) (remaining string, ok bool) {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	if code == "" || hashed == "" {
+		return hashed, false
+	}
+
+	target := hashBackupCode(code)
+	hashes := strings.Split(hashed, ",")
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(target)) == 1 {
+			return strings.Join(append(hashes[:i:i], hashes[i+1:]...), ","), true
+		}
+	}
+	return hashed, false
+}
+
+func hashBackupCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
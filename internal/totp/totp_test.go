@@ -0,0 +1,82 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCode(t *testing.T) {
+	// @see https://datatracker.ietf.org/doc/html/rfc6238#appendix-B (the last 6 digits)
+	secret := b32.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		code, err := Code(secret, Step(time.Unix(tt.unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.code, code, tt.unix)
+	}
+
+	_, err := Code("invalid!", 0)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Now()
+	code, _ := Code(secret, Step(now))
+
+	step, ok := Validate(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	_, ok = Validate(secret, code, now.Add(Period))
+	assert.True(t, ok, "should allow the clock skew")
+
+	_, ok = Validate(secret, code, now.Add(3*Period))
+	assert.False(t, ok, "should reject the expired code")
+
+	_, ok = Validate(secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Artalk", "admin@example.com", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Artalk:admin@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Artalk")
+}
+
+func TestBackupCodes(t *testing.T) {
+	codes, hashed := GenerateBackupCodes()
+	assert.Len(t, codes, BackupCodeNum)
+	assert.NotContains(t, hashed, codes[0], "should not store the plain code")
+
+	remaining, ok := UseBackupCode(hashed, strings.ToUpper(codes[3]))
+	assert.True(t, ok)
+	assert.Len(t, strings.Split(remaining, ","), BackupCodeNum-1)
+
+	_, ok = UseBackupCode(remaining, codes[3])
+	assert.False(t, ok, "should not be reused")
+
+	remaining, ok = UseBackupCode(remaining, codes[0])
+	assert.True(t, ok)
+	assert.Len(t, strings.Split(remaining, ","), BackupCodeNum-2)
+
+	_, ok = UseBackupCode(remaining, "")
+	assert.False(t, ok)
+}
@@ -0,0 +1,158 @@
+// The OpenTelemetry tracing of the server (e.g. the requests, the database queries and the external API calls),
+// the spans are exported by OTLP/HTTP if the `tracing` config is enabled, otherwise they are no-op.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// The name of the tracer (the instrumentation scope)
+const TracerName = "github.com/artalkjs/artalk/v2"
+
+var (
+	current    atomic.Pointer[sdktrace.TracerProvider]
+	setGlobals sync.Once
+)
+
+// Init the tracer provider by the config, the previous one is shut down (e.g. the config is updated)
+func Init(conf config.TracingConf) error {
+	if !conf.Enabled {
+		UseTracerProvider(nil)
+		return nil
+	}
+
+	opts := []otlptracehttp.Option{}
+	if endpoint := strings.TrimSpace(conf.Endpoint); strings.Contains(endpoint, "://") {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	} else if endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+	}
+	if conf.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(conf.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(conf.Headers))
+	}
+
+	// The exporter connects to the receiver lazily, so it is not failed if the receiver is unavailable
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return fmt.Errorf("tracing exporter init err: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(conf.GetServiceName()),
+		semconv.ServiceVersion(config.Version),
+	))
+	if err != nil {
+		return fmt.Errorf("tracing resource init err: %w", err)
+	}
+
+	UseTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.GetSampleRatio()))),
+	))
+
+	log.Info("[Tracing] Exporting traces by OTLP/HTTP, sample ratio: ", conf.GetSampleRatio())
+
+	return nil
+}
+
+// Use the tracer provider (e.g. with the span recorder in tests), the previous one is shut down
+func UseTracerProvider(tp *sdktrace.TracerProvider) {
+	setGlobals.Do(func() {
+		// The global provider is only set once, since the tracers obtained from it (e.g. by the HTTP clients)
+		// are kept by the global delegate. So it is a proxy of the current provider which can be replaced.
+		otel.SetTracerProvider(&proxyProvider{})
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+			log.Warn("[Tracing] ", err)
+		}))
+	})
+
+	if prev := current.Swap(tp); prev != nil {
+		if err := prev.Shutdown(context.Background()); err != nil {
+			log.Warn("[Tracing] ", err)
+		}
+	}
+}
+
+// Flush the remaining spans and shut down the tracer provider, the spans are no-op after that
+func Shutdown(ctx context.Context) error {
+	tp := current.Swap(nil)
+	if tp == nil {
+		return nil
+	}
+	return tp.Shutdown(ctx)
+}
+
+// Get the tracer of the server
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Start a span as the child of the span in ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End the span and record the error if not nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Whether the ctx is in a (sampled or not) span
+func HasSpan(ctx context.Context) bool {
+	return ctx != nil && trace.SpanContextFromContext(ctx).IsValid()
+}
+
+// Detach ctx from the cancellation of the request, the span and the values (e.g. the request ID of logs) are kept,
+// for the background tasks continuing the trace of the request after it is responded.
+func Detach(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
+
+// The tracer provider which forwards to the current provider of `Init`
+type proxyProvider struct {
+	embedded.TracerProvider
+}
+
+func (p *proxyProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return &proxyTracer{name: name, opts: opts}
+}
+
+type proxyTracer struct {
+	embedded.Tracer
+	name string
+	opts []trace.TracerOption
+}
+
+func (t *proxyTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if tp := current.Load(); tp != nil {
+		return tp.Tracer(t.name, t.opts...).Start(ctx, spanName, opts...)
+	}
+	return noop.NewTracerProvider().Tracer(t.name).Start(ctx, spanName, opts...)
+}
@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracerProvider(t *testing.T) {
+	defer UseTracerProvider(nil)
+
+	// the tracer is obtained before the provider is set (e.g. by the HTTP clients)
+	UseTracerProvider(nil)
+	tracer := otel.Tracer("test")
+
+	recorder := tracetest.NewSpanRecorder()
+	UseTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, span := tracer.Start(context.Background(), "parent")
+	assert.True(t, HasSpan(ctx))
+
+	_, child := Start(ctx, "child")
+	End(child, errors.New("failed"))
+	span.End()
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "child", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	}
+
+	// the spans are no-op after the provider is shut down
+	assert.NoError(t, Shutdown(context.Background()))
+	_, span = tracer.Start(context.Background(), "after")
+	span.End()
+	assert.False(t, span.SpanContext().IsValid())
+	assert.Len(t, recorder.Ended(), 2)
+}
+
+func TestInitDisabled(t *testing.T) {
+	assert.NoError(t, Init(config.TracingConf{Enabled: false}))
+	assert.Nil(t, current.Load())
+
+	_, span := Start(context.Background(), "noop")
+	assert.False(t, span.IsRecording())
+}
+
+func TestDetach(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	UseTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer UseTracerProvider(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, span := Start(log.WithRequestID(ctx, "req-1"), "request")
+	detached := Detach(ctx)
+	cancel()
+	span.End()
+
+	assert.NoError(t, detached.Err(), "should not be canceled with the request")
+	assert.Equal(t, "req-1", log.RequestID(detached), "should keep the request ID of logs")
+	_, job := Start(detached, "job")
+	job.End()
+	assert.Equal(t, span.SpanContext().TraceID(), job.SpanContext().TraceID())
+}
@@ -37,3 +37,19 @@ func TestGetSha256Hash(t *testing.T) {
 		assert.Equal(t, test.expected, result)
 	}
 }
+
+func TestGetHmacSha256Hash(t *testing.T) {
+	tests := []struct {
+		key      string
+		input    string
+		expected string
+	}{
+		{"key", "The quick brown fox jumps over the lazy dog", "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"},
+		{"", "", "b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad"},
+	}
+
+	for _, test := range tests {
+		result := GetHmacSha256Hash(test.key, test.input)
+		assert.Equal(t, test.expected, result)
+	}
+}
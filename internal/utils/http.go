@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The proxy value to connect directly (ignore the global and environment proxy)
+const ProxyDirect = "direct"
+
+// Create the HTTP client with the outbound proxy
+//
+// The proxy supports the schemes of http, https and socks5 (e.g. "socks5://127.0.0.1:1080"),
+// the environment proxy (HTTP_PROXY, HTTPS_PROXY) is used if empty, and "direct" for no proxy.
+// The requests will fail with the error if the proxy is invalid.
+func NewHTTPClient(proxy string, timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	switch proxy = strings.TrimSpace(proxy); proxy {
+	case "":
+		transport.Proxy = http.ProxyFromEnvironment
+	case ProxyDirect:
+		transport.Proxy = nil
+	default:
+		proxyURL, err := ParseProxyURL(proxy)
+		if err != nil {
+			transport.Proxy = func(*http.Request) (*url.URL, error) { return nil, err }
+		} else {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// Create the HTTP client with the outbound proxy like `NewHTTPClient`, and trace the requests (e.g. the anti-spam APIs)
+//
+// The requests are only traced if the context of request is in a span (see `http.NewRequestWithContext`),
+// so the background requests do not create the root spans.
+func NewTracedHTTPClient(proxy string, timeout time.Duration) *http.Client {
+	client := NewHTTPClient(proxy, timeout)
+	client.Transport = otelhttp.NewTransport(client.Transport,
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return trace.SpanContextFromContext(r.Context()).IsValid()
+		}),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Host
+		}),
+	)
+	return client
+}
+
+// Parse the proxy URL and check the scheme
+func ParseProxyURL(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q: %w", proxy, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy %q: unsupported scheme %q", proxy, u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q: missing host", proxy)
+	}
+
+	return u, nil
+}
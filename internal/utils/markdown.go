@@ -2,6 +2,10 @@ package utils
 
 import (
 	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/yuin/goldmark"
@@ -10,29 +14,144 @@ import (
 	"github.com/yuin/goldmark/renderer/html"
 )
 
+const (
+	MarkedProfileDefault = "default" // The user generated content policy, most of the formatting tags are allowed
+	MarkedProfileStrict  = "strict"  // Only the basic text formatting, without headings, tables and styles
+
+	MarkedImageAllow = "allow" // Allow all the images
+	MarkedImageHTTPS = "https" // Only allow the images over HTTPS
+	MarkedImageNone  = "none"  // Remove all the images
+)
+
+// The default rel attribute of links
+var DefaultMarkedLinkRel = []string{"nofollow", "noreferrer"}
+
+type MarkedOptions struct {
+	Profile       string   // The sanitization profile (default: "default")
+	AllowedTags   []string // The extra HTML tags allowed (without attributes)
+	HighlightCode bool     // Keep the language class of code blocks for the code highlighters
+	ImagePolicy   string   // The policy of images (default: "allow")
+	LinkRel       []string // The rel attribute added to links (default: "nofollow noreferrer")
+}
+
+var (
+	relTokenRegexp  = regexp.MustCompile(`^[a-zA-Z-]+$`)
+	imgTagRegexp    = regexp.MustCompile(`<img\b[^>]*>`)
+	imgSrcRegexp    = regexp.MustCompile(`\ssrc="([^"]*)"`)
+	openLinkRegexp  = regexp.MustCompile(`<a\b`)
+	codeClassRegexp = regexp.MustCompile(`^language-[\w+#-]+$`)
+)
+
+// The markdown renderer is safe for concurrent use, so it is shared by all the renderings
+//
+// https://github.com/yuin/goldmark#security
+var markedRenderer = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(
+		parser.WithAutoHeadingID(),
+	),
+	goldmark.WithRendererOptions(
+		html.WithHardWraps(),
+		html.WithXHTML(),
+		html.WithUnsafe(),
+	),
+)
+
+// The sanitization policies by the options, since it is expensive to build a policy (lots of regexps are compiled)
+var markedPolicies sync.Map
+
+// Render the markdown to the sanitized HTML by the default options
 func Marked(markdownStr string) (string, error) {
-	bmPolicy := bluemonday.UGCPolicy()
-	bmPolicy.RequireNoReferrerOnLinks(true)
-	bmPolicy.AllowAttrs("width", "height", "align", "atk-emoticon").OnElements("img")
-	bmPolicy.AllowAttrs("style", "class", "align").OnElements("span", "p", "div", "a")
-
-	// https://github.com/yuin/goldmark#security
-	md := goldmark.New(
-		goldmark.WithExtensions(extension.GFM),
-		goldmark.WithParserOptions(
-			parser.WithAutoHeadingID(),
-		),
-		goldmark.WithRendererOptions(
-			html.WithHardWraps(),
-			html.WithXHTML(),
-			html.WithUnsafe(),
-		),
-	)
+	return MarkedWithOptions(markdownStr, MarkedOptions{})
+}
 
+// Render the markdown to the sanitized HTML
+func MarkedWithOptions(markdownStr string, opts MarkedOptions) (string, error) {
 	var buf bytes.Buffer
-	if err := md.Convert([]byte(markdownStr), &buf); err != nil {
+	if err := markedRenderer.Convert([]byte(markdownStr), &buf); err != nil {
 		return "", err
 	}
 
-	return bmPolicy.SanitizeReader(&buf).String(), nil
+	output := getMarkedPolicy(opts).SanitizeReader(&buf).String()
+
+	// The output is sanitized, so the tags are well-formed and the attributes are escaped
+	output = filterMarkedImages(output, opts.ImagePolicy)
+	output = addMarkedLinkRel(output, opts.LinkRel)
+
+	return output, nil
+}
+
+// Get the sanitization policy by the options, the policy is safe for concurrent use once it is built
+func getMarkedPolicy(opts MarkedOptions) *bluemonday.Policy {
+	key := opts.Profile + "|" + strings.Join(opts.AllowedTags, ",") + "|" + strconv.FormatBool(opts.HighlightCode)
+	if policy, ok := markedPolicies.Load(key); ok {
+		return policy.(*bluemonday.Policy)
+	}
+
+	policy, _ := markedPolicies.LoadOrStore(key, newMarkedPolicy(opts))
+	return policy.(*bluemonday.Policy)
+}
+
+func newMarkedPolicy(opts MarkedOptions) *bluemonday.Policy {
+	var bmPolicy *bluemonday.Policy
+	if opts.Profile == MarkedProfileStrict {
+		bmPolicy = bluemonday.NewPolicy()
+		bmPolicy.AllowStandardURLs()
+		bmPolicy.AllowElements("p", "br", "hr", "strong", "b", "em", "i", "del", "s", "code", "pre", "blockquote")
+		bmPolicy.AllowLists()
+		bmPolicy.AllowAttrs("href").OnElements("a")
+		bmPolicy.AllowImages()
+		bmPolicy.AllowAttrs("atk-emoticon").OnElements("img")
+	} else {
+		bmPolicy = bluemonday.UGCPolicy()
+		bmPolicy.AllowAttrs("width", "height", "align", "atk-emoticon").OnElements("img")
+		bmPolicy.AllowAttrs("style", "class", "align").OnElements("span", "p", "div", "a")
+	}
+
+	// The rel attribute is added by the options after sanitized
+	bmPolicy.RequireNoFollowOnLinks(false)
+
+	if len(opts.AllowedTags) > 0 {
+		bmPolicy.AllowElements(opts.AllowedTags...)
+	}
+
+	if opts.HighlightCode {
+		bmPolicy.AllowAttrs("class").Matching(codeClassRegexp).OnElements("code")
+	}
+
+	return bmPolicy
+}
+
+func filterMarkedImages(output string, policy string) string {
+	switch policy {
+	case MarkedImageNone:
+		return imgTagRegexp.ReplaceAllString(output, "")
+	case MarkedImageHTTPS:
+		return imgTagRegexp.ReplaceAllStringFunc(output, func(tag string) string {
+			src := imgSrcRegexp.FindStringSubmatch(tag)
+			if src == nil || !strings.HasPrefix(strings.ToLower(src[1]), "https://") {
+				return ""
+			}
+			return tag
+		})
+	}
+	return output
+}
+
+func addMarkedLinkRel(output string, linkRel []string) string {
+	if linkRel == nil {
+		linkRel = DefaultMarkedLinkRel
+	}
+
+	var tokens []string
+	for _, t := range linkRel {
+		if relTokenRegexp.MatchString(t) {
+			tokens = append(tokens, strings.ToLower(t))
+		}
+	}
+	if len(tokens) == 0 {
+		return output
+	}
+
+	return openLinkRegexp.ReplaceAllString(output, `<a rel="`+strings.Join(tokens, " ")+`"`)
 }
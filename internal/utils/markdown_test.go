@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,3 +12,57 @@ func TestMarked(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, html)
 }
+
+func TestMarkedWithOptions(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		html, err := MarkedWithOptions("[link](https://example.com) <script>alert(1)</script>", MarkedOptions{})
+		assert.NoError(t, err)
+		assert.Contains(t, html, `<a rel="nofollow noreferrer" href="https://example.com">link</a>`)
+		assert.NotContains(t, html, "<script>")
+	})
+
+	t.Run("StrictProfile", func(t *testing.T) {
+		html, _ := MarkedWithOptions("# Title\n\n**bold** <span style=\"color:red\">red</span>", MarkedOptions{Profile: MarkedProfileStrict})
+		assert.NotContains(t, html, "<h1")
+		assert.NotContains(t, html, "<span")
+		assert.Contains(t, html, "<strong>bold</strong>")
+	})
+
+	t.Run("AllowedTags", func(t *testing.T) {
+		html, _ := MarkedWithOptions("<kbd>Ctrl</kbd>", MarkedOptions{})
+		assert.NotContains(t, html, "<kbd>")
+
+		html, _ = MarkedWithOptions("<kbd>Ctrl</kbd>", MarkedOptions{AllowedTags: []string{"kbd"}})
+		assert.Contains(t, html, "<kbd>Ctrl</kbd>")
+	})
+
+	t.Run("HighlightCode", func(t *testing.T) {
+		html, _ := MarkedWithOptions("```go\nfunc main() {}\n```", MarkedOptions{})
+		assert.NotContains(t, html, "language-go")
+
+		html, _ = MarkedWithOptions("```go\nfunc main() {}\n```", MarkedOptions{HighlightCode: true})
+		assert.Contains(t, html, `<code class="language-go">`)
+	})
+
+	t.Run("ImagePolicy", func(t *testing.T) {
+		md := "![a](https://example.com/a.png) ![b](http://example.com/b.png)"
+
+		html, _ := MarkedWithOptions(md, MarkedOptions{})
+		assert.Equal(t, 2, strings.Count(html, "<img"))
+
+		html, _ = MarkedWithOptions(md, MarkedOptions{ImagePolicy: MarkedImageHTTPS})
+		assert.Contains(t, html, "https://example.com/a.png")
+		assert.NotContains(t, html, "http://example.com/b.png")
+
+		html, _ = MarkedWithOptions(md, MarkedOptions{ImagePolicy: MarkedImageNone})
+		assert.NotContains(t, html, "<img")
+	})
+
+	t.Run("LinkRel", func(t *testing.T) {
+		html, _ := MarkedWithOptions("[link](https://example.com)", MarkedOptions{LinkRel: []string{"nofollow", "ugc", "\" onclick=\"alert(1)"}})
+		assert.Contains(t, html, `<a rel="nofollow ugc" href="https://example.com">`)
+
+		html, _ = MarkedWithOptions("[link](https://example.com)", MarkedOptions{LinkRel: []string{}})
+		assert.NotContains(t, html, "rel=")
+	})
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 )
 
@@ -85,6 +86,24 @@ func TruncateString(str string, length int) string {
 	return truncated
 }
 
+var mentionRegexp = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@.])@([\p{L}\p{N}_-]+(?:\.[\p{L}\p{N}_-]+)*)`)
+
+// 解析内容中提及的用户名 (@username)
+//
+// The names are deduplicated case-insensitively in the order of appearance,
+// and the email addresses are not treated as mentions.
+func ParseMentions(content string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, m := range mentionRegexp.FindAllStringSubmatch(content, -1) {
+		if key := strings.ToLower(m[1]); !seen[key] {
+			seen[key] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
 // 任何类型转 String
 //
 //	(bool) true => (string) "true"
@@ -92,3 +111,20 @@ func TruncateString(str string, length int) string {
 func ToString(val interface{}) string {
 	return fmt.Sprintf("%v", val)
 }
+
+// 格式化文件大小
+//
+//	(int64) 1536 => (string) "1.5 KB"
+func FormatFileSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	for _, unit := range []string{"KB", "MB", "GB", "TB"} {
+		value /= 1024
+		if value < 1024 || unit == "TB" {
+			return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + " " + unit
+		}
+	}
+	return ""
+}
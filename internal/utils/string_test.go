@@ -90,3 +90,33 @@ func TestToString(t *testing.T) {
 		assert.Equal(t, test.expected, result, "For %v, expected %s, but got %s", test.value, test.expected, result)
 	}
 }
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected []string
+	}{
+		{"@alice hello", []string{"alice"}},
+		{"hi @alice and @Bob.", []string{"alice", "Bob"}},
+		{"@alice @ALICE @bob", []string{"alice", "bob"}},
+		{"@qwqcode，你好", []string{"qwqcode"}},
+		{"contact me at alice@example.com", []string{}},
+		{"@john.doe: thanks", []string{"john.doe"}},
+		{"no mentions", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseMentions(tt.content))
+		})
+	}
+}
+
+func TestFormatFileSize(t *testing.T) {
+	assert.Equal(t, "0 B", FormatFileSize(0))
+	assert.Equal(t, "1023 B", FormatFileSize(1023))
+	assert.Equal(t, "1 KB", FormatFileSize(1024))
+	assert.Equal(t, "1.5 KB", FormatFileSize(1536))
+	assert.Equal(t, "5 MB", FormatFileSize(5*1024*1024))
+	assert.Equal(t, "2048 TB", FormatFileSize(2048*1024*1024*1024*1024))
+}
@@ -0,0 +1,160 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// 事件类型
+const (
+	EventCommentCreated  = "comment.created"  // 新评论 (反垃圾检测后触发)
+	EventCommentApproved = "comment.approved" // 评论通过审核
+	EventCommentSpam     = "comment.spam"     // 评论被拦截或被标记为垃圾评论
+	EventCommentDeleted  = "comment.deleted"  // 评论被删除
+	EventUserRegistered  = "user.registered"  // 用户注册 (邮箱或第三方登录)
+	EventPageCreated     = "page.created"     // 新页面
+)
+
+var Events = []string{
+	EventCommentCreated, EventCommentApproved, EventCommentSpam, EventCommentDeleted,
+	EventUserRegistered, EventPageCreated,
+}
+
+// 请求头
+const (
+	HeaderEvent     = "X-Artalk-Event"
+	HeaderDelivery  = "X-Artalk-Delivery"
+	HeaderTimestamp = "X-Artalk-Timestamp"
+	HeaderSignature = "X-Artalk-Signature" // "sha256=" + HMAC-SHA256(secret, timestamp + "." + body)
+)
+
+type Endpoint struct {
+	URL    string
+	Secret string
+	Events []string // 留空为全部事件
+}
+
+// 是否订阅了该事件
+func (e Endpoint) Accepts(event string) bool {
+	return len(e.Events) == 0 || slices.Contains(e.Events, event) || slices.Contains(e.Events, "*")
+}
+
+// 请求体
+type Payload struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// 计算签名
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// 验证签名 (供接收方参考)
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// 一次投递的结果
+type Result struct {
+	StatusCode int
+	Response   string // 响应内容 (截断)
+	Error      string
+	Attempts   int
+	Duration   time.Duration
+}
+
+func (r Result) IsSuccess() bool {
+	return r.Error == "" && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// 记录的响应内容最大长度
+const maxResponseLength = 1024
+
+type Sender struct {
+	Client     *http.Client
+	MaxRetries int
+	Backoff    func(attempt int) time.Duration // 第 n 次重试前的等待时间 (默认 2^(n-1) 秒)
+}
+
+func defaultBackoff(attempt int) time.Duration {
+	return time.Duration(1<<(attempt-1)) * time.Second
+}
+
+// 投递事件，失败时 (网络错误或非 2xx 响应) 按退避时间重试
+func (s *Sender) Send(ctx context.Context, endpoint Endpoint, event string, deliveryID string, body []byte) Result {
+	backoff := s.Backoff
+	if backoff == nil {
+		backoff = defaultBackoff
+	}
+
+	start := time.Now()
+	var result Result
+	for attempt := 0; attempt <= max(0, s.MaxRetries); attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff(attempt)):
+			case <-ctx.Done():
+				result.Error = ctx.Err().Error()
+				result.Duration = time.Since(start)
+				return result
+			}
+		}
+
+		result = s.send(ctx, endpoint, event, deliveryID, body)
+		result.Attempts = attempt + 1
+		if result.IsSuccess() {
+			break
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result
+}
+
+func (s *Sender) send(ctx context.Context, endpoint Endpoint, event string, deliveryID string, body []byte) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Artalk-Webhook")
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderDelivery, deliveryID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if endpoint.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, body))
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseLength))
+	result := Result{StatusCode: resp.StatusCode, Response: string(respBody)}
+	if !result.IsSuccess() {
+		result.Error = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"comment.created"}`)
+	sig := Sign("secret", 1700000000, body)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, sig)
+	assert.True(t, Verify("secret", 1700000000, body, sig))
+	assert.False(t, Verify("secret", 1700000001, body, sig), "should bind the timestamp")
+	assert.False(t, Verify("other", 1700000000, body, sig))
+}
+
+func TestEndpointAccepts(t *testing.T) {
+	assert.True(t, Endpoint{}.Accepts(EventCommentCreated), "should accept all the events if empty")
+	assert.True(t, Endpoint{Events: []string{"*"}}.Accepts(EventPageCreated))
+
+	e := Endpoint{Events: []string{EventCommentCreated, EventCommentSpam}}
+	assert.True(t, e.Accepts(EventCommentSpam))
+	assert.False(t, e.Accepts(EventCommentDeleted))
+}
+
+func TestSender(t *testing.T) {
+	var calls atomic.Int32
+	var failTimes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		ts, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, EventCommentCreated, r.Header.Get(HeaderEvent))
+		assert.Equal(t, "42", r.Header.Get(HeaderDelivery))
+		assert.True(t, Verify("secret", ts, body, r.Header.Get(HeaderSignature)))
+
+		if n <= failTimes {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	sender := &Sender{MaxRetries: 2, Backoff: func(int) time.Duration { return 0 }}
+	endpoint := Endpoint{URL: server.URL, Secret: "secret"}
+	send := func() Result {
+		calls.Store(0)
+		return sender.Send(context.Background(), endpoint, EventCommentCreated, "42", []byte(`{}`))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		failTimes = 0
+		r := send()
+		assert.True(t, r.IsSuccess())
+		assert.Equal(t, 200, r.StatusCode)
+		assert.Equal(t, "ok", r.Response)
+		assert.Equal(t, 1, r.Attempts)
+	})
+
+	t.Run("RetryUntilSuccess", func(t *testing.T) {
+		failTimes = 2
+		r := send()
+		assert.True(t, r.IsSuccess())
+		assert.Equal(t, 3, r.Attempts)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		failTimes = 10
+		r := send()
+		assert.False(t, r.IsSuccess())
+		assert.Equal(t, 502, r.StatusCode)
+		assert.Equal(t, 3, r.Attempts, "should stop after the max retries")
+		assert.Contains(t, r.Error, "502")
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		failTimes = 10
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		r := (&Sender{MaxRetries: 2}).Send(ctx, Endpoint{URL: server.URL}, EventCommentCreated, "42", []byte(`{}`))
+		assert.False(t, r.IsSuccess())
+		assert.NotEmpty(t, r.Error)
+	})
+}
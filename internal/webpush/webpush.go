@@ -0,0 +1,286 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/crypto/hkdf"
+)
+
+// The record size of the encrypted content (RFC 8188), the payload must fit in a single record
+const recordSize = 4096
+
+// The max length of payload (record size - padding delimiter - AEAD tag - header)
+const MaxPayloadLength = recordSize - 1 - 16 - 86
+
+// The subscription is expired or unsubscribed (the push service responds 404 or 410),
+// it should be removed and never be used again
+var ErrSubscriptionExpired = errors.New("push subscription is expired")
+
+// The push subscription of a browser (the result of `PushSubscription.toJSON()`)
+type Subscription struct {
+	Endpoint string
+	P256DH   string // The ECDH public key of the user agent (Base64URL encoded)
+	Auth     string // The authentication secret (Base64URL encoded)
+}
+
+type Options struct {
+	Subject         string // The contact of sender (mailto: or https: URL)
+	VAPIDPrivateKey string // Base64URL encoded (the public key is derived from it)
+	TTL             int    // The seconds the push service retains the message (default 24 hours)
+	Timeout         time.Duration
+}
+
+// Generate a pair of VAPID keys (Base64URL encoded)
+func GenerateVAPIDKeys() (publicKey string, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// Send the payload to the subscription, encrypted by the message encryption of Web Push (RFC 8291)
+// and authorized by VAPID (RFC 8292)
+func Send(ctx context.Context, sub Subscription, payload []byte, opts Options) error {
+	if len(payload) > MaxPayloadLength {
+		return fmt.Errorf("payload is too large (%d bytes)", len(payload))
+	}
+
+	body, err := Encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+
+	auth, err := vapidAuthorization(sub.Endpoint, opts)
+	if err != nil {
+		return err
+	}
+
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = 24 * 60 * 60
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", auth)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(ttl))
+	req.Header.Set("Urgency", "normal")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err // the endpoint URL contains the token of subscription
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionExpired
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("push service responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Check the endpoint of subscription, only the HTTPS URL of public host is allowed
+// (the server sends requests to it, so the internal addresses are rejected)
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("the endpoint should be an HTTPS URL")
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") {
+		return fmt.Errorf("the endpoint should be a public host")
+	}
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified()) {
+		return fmt.Errorf("the endpoint should be a public host")
+	}
+	return nil
+}
+
+// Encrypt the payload for the subscription with the "aes128gcm" content encoding (RFC 8291)
+func Encrypt(sub Subscription, payload []byte) ([]byte, error) {
+	uaPublicBytes, err := decodeBase64(sub.P256DH)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	authSecret, err := decodeBase64(sub.Auth)
+	if err != nil || len(authSecret) == 0 {
+		return nil, fmt.Errorf("invalid auth secret")
+	}
+
+	// The ephemeral key pair of the application server
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public)
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublicBytes...), asPublicBytes...)
+	ikm, err := hkdfExpand(ecdhSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// header: salt (16) || rs (4) || idlen (1) || keyid (as_public)
+	header := make([]byte, 0, 21+len(asPublicBytes))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublicBytes)))
+	header = append(header, asPublicBytes...)
+
+	plaintext := append(append([]byte{}, payload...), 0x02) // the padding delimiter of the last record
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// Get the VAPID authorization header for the endpoint (RFC 8292)
+func vapidAuthorization(endpoint string, opts Options) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid endpoint")
+	}
+
+	key, err := parseVAPIDPrivateKey(opts.VAPIDPrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": opts.Subject,
+	}).SignedString(key)
+	if err != nil {
+		return "", err
+	}
+
+	publicKey, err := GetVAPIDPublicKey(opts.VAPIDPrivateKey)
+	if err != nil {
+		return "", err
+	}
+	return "vapid t=" + token + ", k=" + publicKey, nil
+}
+
+// Get the VAPID public key (Base64URL encoded) of the private key
+func GetVAPIDPublicKey(privateKey string) (string, error) {
+	key, err := parseECDHPrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+func parseECDHPrivateKey(privateKey string) (*ecdh.PrivateKey, error) {
+	d, err := decodeBase64(privateKey)
+	if err != nil || len(d) != 32 {
+		return nil, fmt.Errorf("invalid VAPID private key")
+	}
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	return key, nil
+}
+
+func parseVAPIDPrivateKey(privateKey string) (*ecdsa.PrivateKey, error) {
+	key, err := parseECDHPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	pub := key.PublicKey().Bytes() // 0x04 || X || Y
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(pub[1:33]),
+			Y:     new(big.Int).SetBytes(pub[33:]),
+		},
+		D: new(big.Int).SetBytes(key.Bytes()),
+	}, nil
+}
+
+func hkdfExpand(secret []byte, salt []byte, info []byte, length int) ([]byte, error) {
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Decode the Base64URL string, the padding and the standard encoding are also accepted
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(strings.TrimSpace(s), "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,148 @@
+package webpush
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+// The user agent side of the subscription, for decrypting the pushed messages
+type mockUserAgent struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newMockUserAgent(t *testing.T) *mockUserAgent {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	auth := make([]byte, 16)
+	rand.Read(auth)
+	return &mockUserAgent{key: key, auth: auth}
+}
+
+func (ua *mockUserAgent) subscription(endpoint string) Subscription {
+	return Subscription{
+		Endpoint: endpoint,
+		P256DH:   base64.RawURLEncoding.EncodeToString(ua.key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(ua.auth),
+	}
+}
+
+func (ua *mockUserAgent) decrypt(t *testing.T, body []byte) []byte {
+	salt := body[:16]
+	assert.Equal(t, uint32(recordSize), binary.BigEndian.Uint32(body[16:20]))
+	idLen := int(body[20])
+	asPublicBytes := body[21 : 21+idLen]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	assert.NoError(t, err)
+	ecdhSecret, err := ua.key.ECDH(asPublic)
+	assert.NoError(t, err)
+
+	keyInfo := append(append([]byte("WebPush: info\x00"), ua.key.PublicKey().Bytes()...), asPublicBytes...)
+	ikm, _ := hkdfExpand(ecdhSecret, ua.auth, keyInfo, 32)
+	cek, _ := hkdfExpand(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce, _ := hkdfExpand(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x02), plaintext[len(plaintext)-1], "should end with the padding delimiter")
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestGenerateVAPIDKeys(t *testing.T) {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	assert.NoError(t, err)
+
+	derived, err := GetVAPIDPublicKey(privateKey)
+	assert.NoError(t, err)
+	assert.Equal(t, publicKey, derived)
+
+	_, err = GetVAPIDPublicKey("invalid")
+	assert.Error(t, err)
+}
+
+func TestSend(t *testing.T) {
+	publicKey, privateKey, _ := GenerateVAPIDKeys()
+	ua := newMockUserAgent(t)
+
+	var received []byte
+	var header http.Header
+	status := http.StatusCreated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		header = r.Header
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	opts := Options{Subject: "mailto:admin@example.com", VAPIDPrivateKey: privateKey}
+	sub := ua.subscription(server.URL + "/push/token")
+
+	t.Run("Encrypted", func(t *testing.T) {
+		assert.NoError(t, Send(context.Background(), sub, []byte(`{"title":"Hello"}`), opts))
+		assert.Equal(t, `{"title":"Hello"}`, string(ua.decrypt(t, received)))
+		assert.Equal(t, "aes128gcm", header.Get("Content-Encoding"))
+		assert.Equal(t, "86400", header.Get("TTL"))
+	})
+
+	t.Run("VAPID", func(t *testing.T) {
+		auth := header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(auth, "vapid t="))
+		assert.True(t, strings.HasSuffix(auth, ", k="+publicKey))
+
+		tokenStr := strings.TrimSuffix(strings.TrimPrefix(auth, "vapid t="), ", k="+publicKey)
+		key, _ := parseVAPIDPrivateKey(privateKey)
+		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+			return &key.PublicKey, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, server.URL, token.Claims.(jwt.MapClaims)["aud"])
+		assert.Equal(t, "mailto:admin@example.com", token.Claims.(jwt.MapClaims)["sub"])
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		status = http.StatusGone
+		defer func() { status = http.StatusCreated }()
+		assert.ErrorIs(t, Send(context.Background(), sub, []byte("Hello"), opts), ErrSubscriptionExpired)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		assert.Error(t, Send(context.Background(), sub, make([]byte, MaxPayloadLength+1), opts))
+	})
+
+	t.Run("InvalidSubscription", func(t *testing.T) {
+		assert.Error(t, Send(context.Background(), Subscription{Endpoint: server.URL, P256DH: "invalid", Auth: "invalid"}, []byte("Hello"), opts))
+	})
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	assert.NoError(t, ValidateEndpoint("https://fcm.googleapis.com/fcm/send/token"))
+	assert.NoError(t, ValidateEndpoint("https://updates.push.services.mozilla.com/wpush/v2/token"))
+
+	for _, endpoint := range []string{
+		"http://fcm.googleapis.com/fcm/send/token",
+		"https://localhost/push",
+		"https://127.0.0.1/push",
+		"https://10.0.0.1/push",
+		"https://[::1]/push",
+		"https://169.254.169.254/latest",
+		"invalid",
+	} {
+		assert.Error(t, ValidateEndpoint(endpoint), endpoint)
+	}
+}
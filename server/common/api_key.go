@@ -0,0 +1,19 @@
+package common
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/middleware/api_key"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Get the API key authenticated by the `X-API-Key` header
+func GetAPIKeyByReq(c *fiber.Ctx) (entity.APIKey, bool) {
+	key, ok := c.Locals(api_key.LocalKey).(entity.APIKey)
+	return key, ok && !key.IsEmpty()
+}
+
+// Check if the request is authenticated by an API key with the scope
+func CheckAPIKeyScope(c *fiber.Ctx, scope string) bool {
+	key, ok := GetAPIKeyByReq(c)
+	return ok && key.HasScope(scope)
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"encoding/json"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The details of an admin action for the audit log, which are attached by the handler with AddAudit.
+//
+// All the successful write requests of admin are recorded by the admin guards, the requests without
+// the details attached are recorded with the route as the action.
+type Audit struct {
+	Action     string // The action name (e.g. "comment.approve")
+	TargetType string
+	TargetID   uint
+	SiteName   string
+	Before     any // The target before the action, which is encoded as JSON (nil for creation)
+	After      any // The target after the action, which is encoded as JSON (nil for deletion)
+}
+
+// The actor of the admin action
+type AuditActor struct {
+	Type string
+	ID   uint
+	Name string
+}
+
+const auditLocalsKey = "audit_logs"
+
+// Attach the details of action to the audit log of request, multiple actions can be attached (e.g. in bulk)
+func AddAudit(c *fiber.Ctx, audit Audit) {
+	audits, _ := c.Locals(auditLocalsKey).([]Audit)
+	c.Locals(auditLocalsKey, append(audits, audit))
+}
+
+// Record the audit logs of request if it is a successful write request
+func RecordAudit(app *core.App, c *fiber.Ctx, actor AuditActor) {
+	switch c.Method() {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return
+	}
+	if c.Response().StatusCode() >= 400 {
+		return
+	}
+
+	audits, _ := c.Locals(auditLocalsKey).([]Audit)
+	if len(audits) == 0 {
+		audits = []Audit{{Action: c.Method() + " " + c.Route().Path}}
+	}
+
+	for _, audit := range audits {
+		app.Dao().NewAuditLog(&entity.AuditLog{
+			ActorType:  actor.Type,
+			ActorID:    actor.ID,
+			ActorName:  actor.Name,
+			IP:         c.IP(),
+			Action:     audit.Action,
+			Method:     c.Method(),
+			Path:       c.Path(),
+			TargetType: audit.TargetType,
+			TargetID:   audit.TargetID,
+			SiteName:   audit.SiteName,
+			Before:     encodeAuditValue(audit.Before),
+			After:      encodeAuditValue(audit.After),
+		})
+	}
+	c.Locals(auditLocalsKey, nil)
+}
+
+func encodeAuditValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(buf)
+}
@@ -15,15 +15,26 @@ import (
 // jwtCustomClaims are custom claims extending default ones.
 // See https://github.com/golang-jwt/jwt for more examples
 type jwtCustomClaims struct {
-	UserID uint `json:"user_id"`
+	UserID       uint `json:"user_id"`
+	TOTPVerified bool `json:"totp_verified,omitempty"` // 是否已通过两步验证
 	jwt.StandardClaims
 }
 
 func LoginGetUserToken(user entity.User, key string, ttl int) (string, error) {
+	return loginGetUserToken(user, key, ttl, false, "")
+}
+
+// 签发 Token
+//
+// 启用两步验证的管理员仅能使用 totpVerified 的 Token 进行管理操作，
+// sessionID 为刷新令牌的 Family ID，会话撤销后该 Token 随之失效
+func loginGetUserToken(user entity.User, key string, ttl int, totpVerified bool, sessionID string) (string, error) {
 	// Set custom claims
 	claims := &jwtCustomClaims{
-		UserID: user.ID,
+		UserID:       user.ID,
+		TOTPVerified: totpVerified,
 		StandardClaims: jwt.StandardClaims{
+			Id:        sessionID,                                               // 会话 ID
 			IssuedAt:  time.Now().Unix(),                                       // 签发时间
 			ExpiresAt: time.Now().Add(time.Second * time.Duration(ttl)).Unix(), // 过期时间
 		},
@@ -44,6 +55,10 @@ func LoginGetUserToken(user entity.User, key string, ttl int) (string, error) {
 var ErrTokenNotProvided = fmt.Errorf("token not provided")
 var ErrTokenUserNotFound = fmt.Errorf("user not found")
 var ErrTokenInvalidFromDate = fmt.Errorf("token is invalid starting from a certain date")
+var ErrTokenRevoked = fmt.Errorf("token has been revoked")
+var ErrNotAdmin = fmt.Errorf("admin access required")
+var ErrAdminTOTPRequired = fmt.Errorf("admin two-factor authentication required")
+var ErrAdminTOTPSetupRequired = fmt.Errorf("admin two-factor authentication setup required")
 
 func GetTokenByReq(c *fiber.Ctx) string {
 	token := c.Query("token")
@@ -82,20 +97,51 @@ func GetJwtDataByReq(app *core.App, c *fiber.Ctx) (jwtCustomClaims, error) {
 }
 
 func GetUserByReq(app *core.App, c *fiber.Ctx) (entity.User, error) {
-	claims, err := GetJwtDataByReq(app, c)
+	user, _, err := getUserAndClaimsByReq(app, c)
+	return user, err
+}
+
+// 获取请求的管理员用户 (启用两步验证的管理员需使用通过两步验证的 Token)
+func GetAdminByReq(app *core.App, c *fiber.Ctx) (entity.User, error) {
+	user, claims, err := getUserAndClaimsByReq(app, c)
 	if err != nil {
 		return entity.User{}, err
 	}
+	if !user.IsAdmin {
+		return entity.User{}, ErrNotAdmin
+	}
+
+	if user.TOTPEnabled {
+		if !claims.TOTPVerified {
+			return entity.User{}, ErrAdminTOTPRequired
+		}
+	} else if app.Conf().AdminTOTP.Enforce {
+		return entity.User{}, ErrAdminTOTPSetupRequired
+	}
+
+	return user, nil
+}
+
+func getUserAndClaimsByReq(app *core.App, c *fiber.Ctx) (entity.User, jwtCustomClaims, error) {
+	claims, err := GetJwtDataByReq(app, c)
+	if err != nil {
+		return entity.User{}, claims, err
+	}
 
 	user := app.Dao().FindUserByID(claims.UserID)
 	if user.IsEmpty() {
-		return entity.User{}, ErrTokenUserNotFound
+		return entity.User{}, claims, ErrTokenUserNotFound
 	}
 
 	// check tokenValidFrom
 	if user.TokenValidFrom.Valid && claims.IssuedAt < user.TokenValidFrom.Time.Unix() {
-		return entity.User{}, ErrTokenInvalidFromDate
+		return entity.User{}, claims, ErrTokenInvalidFromDate
 	}
 
-	return user, nil
+	// check the session is not revoked
+	if claims.Id != "" && !app.Dao().IsRefreshTokenFamilyActive(claims.Id) {
+		return entity.User{}, claims, ErrTokenRevoked
+	}
+
+	return user, claims, nil
 }
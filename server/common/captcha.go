@@ -5,6 +5,7 @@ import (
 
 	"github.com/artalkjs/artalk/v2/internal/captcha"
 	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/i18n"
 	"github.com/artalkjs/artalk/v2/server/middleware/limiter"
 	"github.com/gofiber/fiber/v2"
@@ -26,6 +27,9 @@ func NewCaptchaChecker(app *core.App, c *fiber.Ctx) captcha.Checker {
 			ID: fmt.Sprint(user.ID),
 			IP: c.IP(),
 		},
+		OutboundProxy: app.Conf().HTTP.OutboundProxy,
+		Store:         app.Store(),
+		Context:       c.UserContext(),
 	})
 }
 
@@ -46,6 +50,11 @@ func LimiterGuard(app *core.App, handler fiber.Handler) fiber.Handler {
 			return handler(c)
 		}
 
+		// 具有发表评论权限的 API Key 直接忽略 (已有独立的频率限制)
+		if CheckAPIKeyScope(c, entity.APIKeyScopeCommentsWrite) {
+			return handler(c)
+		}
+
 		// 检测是否需要验证码
 		ip := c.IP()
 		if limiter.IsPass(ip) {
@@ -9,16 +9,58 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// AdminGuard only allows the super admin, use AdminPermGuard for the endpoints open to the other admin roles
 func AdminGuard(app *core.App, handler fiber.Handler) fiber.Handler {
+	return AdminPermGuard(app, entity.AdminPermSuper, func(c *fiber.Ctx, _ entity.User) error {
+		return handler(c)
+	})
+}
+
+// AdminPermGuard allows the admins whose role has the permission,
+// and the API keys whose scopes grant the permission.
+//
+// The per-site scope of admin is not checked here, call CheckAdminSite in the handler.
+func AdminPermGuard(app *core.App, perm entity.AdminPerm, handler func(*fiber.Ctx, entity.User) error) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		if !CheckIsAdminReq(app, c) {
+		if key, ok := GetAPIKeyByReq(c); ok {
+			if !key.HasAdminPerm(perm) {
+				return RespError(c, 403, i18n.T("API key scope is not allowed"), Map{"need_perm": perm})
+			}
+			err := handler(c, key.AdminUser())
+			if err == nil {
+				RecordAudit(app, c, AuditActor{Type: entity.AuditActorAPIKey, ID: key.ID, Name: key.Name})
+			}
+			return err
+		}
+
+		admin, err := GetAdminByReq(app, c)
+		if err != nil {
+			if errors.Is(err, ErrAdminTOTPSetupRequired) {
+				return RespError(c, 403, i18n.T("Two-factor authentication must be enabled for admins"), Map{"need_totp_setup": true})
+			}
 			return RespError(c, 403, i18n.T("Admin access required"), Map{"need_login": true})
 		}
 
-		return handler(c)
+		if !admin.HasAdminPerm(perm) {
+			return RespError(c, 403, i18n.T("Permission denied"), Map{"need_perm": perm})
+		}
+
+		err = handler(c, admin)
+		if err == nil {
+			RecordAudit(app, c, AuditActor{Type: entity.AuditActorUser, ID: admin.ID, Name: admin.Name})
+		}
+		return err
 	}
 }
 
+// Check if the admin is allowed to manage the site
+func CheckAdminSite(c *fiber.Ctx, admin entity.User, siteName string) (bool, error) {
+	if !admin.CanAdminSite(siteName) {
+		return false, RespError(c, 403, i18n.T("No permission for site `{{name}}`", Map{"name": siteName}))
+	}
+	return true, nil
+}
+
 func LoginGuard(app *core.App, handler func(*fiber.Ctx, entity.User) error) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		user, err := GetUserByReq(app, c)
@@ -34,9 +76,6 @@ func LoginGuard(app *core.App, handler func(*fiber.Ctx, entity.User) error) fibe
 }
 
 func CheckIsAdminReq(app *core.App, c *fiber.Ctx) bool {
-	user, err := GetUserByReq(app, c)
-	if err != nil {
-		return false
-	}
-	return !user.IsEmpty() && user.IsAdmin
+	_, err := GetAdminByReq(app, c)
+	return err == nil
 }
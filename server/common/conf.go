@@ -7,6 +7,7 @@ import (
 
 	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/utils"
 	"github.com/artalkjs/artalk/v2/server/middleware"
 	"github.com/gofiber/fiber/v2"
@@ -48,9 +49,24 @@ func GetApiPublicConfDataMap(app *core.App, c *fiber.Ctx) ConfData {
 	utils.CopyStruct(&frontendConfSrc, &frontendConf)
 
 	frontendConf["imgUpload"] = &imgUpload
+	if app.Conf().Reaction.Enabled {
+		frontendConf["reactions"] = app.Conf().Reaction.GetEmojis()
+	}
+	if app.Conf().Report.Enabled {
+		frontendConf["reportReasons"] = entity.CommentReportReasons
+	}
 	if app.Conf().Locale != "" {
 		frontendConf["locale"] = app.Conf().Locale
 	}
+	if app.Conf().Avatar.Enabled {
+		// 头像代理 (the avatars are loaded from the same origin by the gravatar mirror)
+		gravatar, _ := frontendConf["gravatar"].(map[string]interface{})
+		if gravatar == nil {
+			gravatar = map[string]interface{}{}
+		}
+		gravatar["mirror"] = c.BaseURL() + "/api/v2/avatar/"
+		frontendConf["gravatar"] = gravatar
+	}
 
 	if _, ok := frontendConf["pluginURLs"].([]any); !ok {
 		frontendConf["pluginURLs"] = []any{}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/limiter"
+	"github.com/gofiber/fiber/v2"
+)
+
+// 按 IP 限制每分钟的请求数 (管理员不受限制)，超出时响应 429
+//
+// 用于无需验证码但开销较大的公开接口 (例如 GraphQL 查询和头像代理)
+func RateLimitGuard(app *core.App, name string, limit int, handler fiber.Handler) fiber.Handler {
+	rate := limiter.NewRateLimiter(name, app.Store)
+
+	return func(c *fiber.Ctx) error {
+		if !CheckIsAdminReq(app, c) && !rate.Allow(c.IP(), limit, time.Now()) {
+			c.Set(fiber.HeaderRetryAfter, "60")
+			return RespError(c, 429, i18n.T("Too many {{name}}", Map{"name": "requests"}))
+		}
+		return handler(c)
+	}
+}
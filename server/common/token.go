@@ -0,0 +1,73 @@
+package common
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+// 默认访问令牌有效时长 (单位：秒)
+const defaultAccessTokenTTL = 900
+
+// 登录签发的令牌
+type UserTokens struct {
+	Token        string `json:"token"`                   // 访问令牌
+	RefreshToken string `json:"refresh_token,omitempty"` // 刷新令牌 (仅启用 token_refresh 时签发)
+	ExpiresIn    int    `json:"expires_in,omitempty"`    // 访问令牌有效时长 (单位：秒)
+}
+
+// 为用户签发登录令牌
+//
+// 启用 token_refresh 时签发短时效的访问令牌和刷新令牌 (有效时长为 login_timeout)，
+// 否则仅签发有效时长为 login_timeout 的访问令牌
+func IssueUserTokens(app *core.App, user entity.User, totpVerified bool) (UserTokens, error) {
+	if !app.Conf().TokenRefresh.Enabled {
+		token, err := loginGetUserToken(user, app.Conf().AppKey, app.Conf().LoginTimeout, totpVerified, "")
+		return UserTokens{Token: token}, err
+	}
+
+	return issueSessionTokens(app, user, utils.RandomString(32), totpVerified)
+}
+
+// 刷新令牌被重复使用 (已被轮换或撤销)
+var ErrRefreshTokenReused = fmt.Errorf("refresh token has been reused")
+
+// 轮换刷新令牌 (旧令牌被撤销，签发同一会话的新令牌)
+//
+// 旧令牌先被原子地撤销，撤销成功后才签发新令牌，
+// 因此同一令牌的并发请求中只有一个能轮换成功，其余返回 ErrRefreshTokenReused
+func RotateUserTokens(app *core.App, user entity.User, refreshToken *entity.RefreshToken) (UserTokens, error) {
+	revoked, err := app.Dao().RevokeRefreshToken(refreshToken)
+	if err != nil {
+		return UserTokens{}, err
+	}
+	if !revoked {
+		return UserTokens{}, ErrRefreshTokenReused
+	}
+
+	return issueSessionTokens(app, user, refreshToken.FamilyID, refreshToken.TOTPVerified)
+}
+
+func issueSessionTokens(app *core.App, user entity.User, familyID string, totpVerified bool) (UserTokens, error) {
+	_, refreshToken, err := app.Dao().NewRefreshToken(user.ID, familyID, totpVerified,
+		time.Duration(app.Conf().LoginTimeout)*time.Second)
+	if err != nil {
+		return UserTokens{}, err
+	}
+
+	ttl := cmp.Or(max(app.Conf().TokenRefresh.AccessTTL, 0), defaultAccessTokenTTL)
+	token, err := loginGetUserToken(user, app.Conf().AppKey, ttl, totpVerified, familyID)
+	if err != nil {
+		return UserTokens{}, err
+	}
+
+	return UserTokens{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    ttl,
+	}, nil
+}
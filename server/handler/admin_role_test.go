@@ -0,0 +1,68 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminRole(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.SiteList(app.App, api)
+	handler.SiteCreate(app.App, api)
+	handler.PageUpdate(app.App, api)
+	handler.CommentDelete(app.App, api)
+
+	newToken := func(role string, sites ...string) string {
+		user := entity.User{Name: role, Email: role + "@example.com", IsAdmin: true, AdminRole: role}
+		user.SetAdminSites(sites)
+		assert.NoError(t, app.Dao().CreateUser(&user))
+		token, err := common.LoginGetUserToken(user, app.Conf().AppKey, app.Conf().LoginTimeout)
+		assert.NoError(t, err)
+		return token
+	}
+	request := NewApiRequest(t, api)
+
+	moderator := newToken(entity.AdminRoleModerator, "Site B")
+	readOnly := newToken(entity.AdminRoleReadOnly)
+	super := newToken(entity.AdminRoleSuper, "Site B")
+
+	t.Run("SiteScope", func(t *testing.T) {
+		code, data := request("GET", "/sites", moderator, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(1), data.Get("count").Int())
+		assert.Equal(t, "Site B", data.Get("sites.0.name").String())
+
+		code, data = request("GET", "/sites", super, "")
+		assert.Equal(t, 200, code)
+		assert.Greater(t, data.Get("count").Int(), int64(1), "should ignore the sites of super admin")
+
+		code, _ = request("DELETE", "/comments/1000", moderator, "")
+		assert.Equal(t, 403, code, "should not delete the comment of other sites")
+		assert.False(t, app.Dao().FindComment(1000).IsEmpty())
+
+		code, _ = request("DELETE", "/comments/1006", moderator, "")
+		assert.Equal(t, 200, code)
+		assert.True(t, app.Dao().FindComment(1006).IsEmpty())
+	})
+
+	t.Run("Permission", func(t *testing.T) {
+		code, data := request("POST", "/sites", moderator, `{"name":"Site C","urls":[]}`)
+		assert.Equal(t, 403, code, "only super admin can create the site")
+		assert.Equal(t, "super", data.Get("need_perm").String())
+
+		code, _ = request("PUT", "/pages/1001", moderator, `{"key":"/site_b/1001.html","title":"New","site_name":"Site B"}`)
+		assert.Equal(t, 403, code, "moderator can not manage the pages")
+
+		code, _ = request("DELETE", "/comments/1007", readOnly, "")
+		assert.Equal(t, 403, code, "read-only admin can not delete the comments")
+
+		code, _ = request("GET", "/sites", readOnly, "")
+		assert.Equal(t, 200, code)
+	})
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"cmp"
+
+	"github.com/artalkjs/artalk/v2/internal/anti_spam"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ParamsAntiSpamTest struct {
+	Content   string `json:"content" validate:"required"`    // The sample comment content
+	SiteName  string `json:"site_name" validate:"optional"`  // The site name (the per-site config overrides are used)
+	Name      string `json:"name" validate:"optional"`       // The sample comment author name
+	Email     string `json:"email" validate:"optional"`      // The sample comment author email
+	IP        string `json:"ip" validate:"optional"`         // The sample comment IP (default is the request IP)
+	UA        string `json:"ua" validate:"optional"`         // The sample comment user agent (default is the request UA)
+	PageURL   string `json:"page_url" validate:"optional"`   // The page URL of the sample comment
+	PageTitle string `json:"page_title" validate:"optional"` // The page title of the sample comment
+}
+
+type ResponseAntiSpamTest struct {
+	Pass    bool                          `json:"pass"`    // Whether the sample comment is passed by all the enforced checkers
+	Results []anti_spam.CheckerTestResult `json:"results"` // The result of each checker
+}
+
+// @Id           TestAntiSpam
+// @Summary      Test Anti-Spam
+// @Description  Run a sample comment through the configured checker chain and get the verdict, latency and error of each checker (no comment is created or blocked)
+// @Tags         System
+// @Security     ApiKeyAuth
+// @Param        comment  body  ParamsAntiSpamTest  true  "The sample comment"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseAntiSpamTest
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /admin/anti-spam/test  [post]
+func AntiSpamTest(app *core.App, router fiber.Router) {
+	router.Post("/admin/anti-spam/test", common.AdminPermGuard(app, entity.AdminPermModerate, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsAntiSpamTest
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.SiteName != "" {
+			if _, ok, resp := common.CheckSiteExist(app, c, p.SiteName); !ok {
+				return resp
+			}
+			if ok, resp := common.CheckAdminSite(c, admin, p.SiteName); !ok {
+				return resp
+			}
+		}
+
+		antiSpamService, err := core.AppService[*core.AntiSpamService](app)
+		if err != nil {
+			log.Error("[AntiSpamService] err: ", err)
+			return common.RespError(c, 500, err.Error())
+		}
+
+		results := antiSpamService.Test(p.SiteName, &anti_spam.CheckerParams{
+			PageURL:   p.PageURL,
+			PageTitle: p.PageTitle,
+			Content:   p.Content,
+			UserName:  p.Name,
+			UserEmail: p.Email,
+			UserIP:    cmp.Or(p.IP, c.IP()),
+			UserAgent: cmp.Or(p.UA, string(c.Request().Header.UserAgent())),
+		})
+
+		return common.RespData(c, ResponseAntiSpamTest{
+			Pass: lo.EveryBy(results, func(r anti_spam.CheckerTestResult) bool {
+				return r.Pass || r.DryRun
+			}),
+			Results: results,
+		})
+	}))
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsAPIKeyCreate struct {
+	Name      string   `json:"name" validate:"required"`                                                                     // The key name for identification
+	Scopes    []string `json:"scopes" enums:"comments:read,comments:write,moderate,transfer,feeds:read" validate:"required"` // The scopes granted to the key
+	RateLimit int      `json:"rate_limit" validate:"optional"`                                                               // Max requests per minute, 0 for unlimited
+}
+
+type ResponseAPIKeyCreate struct {
+	entity.CookedAPIKey
+	Key string `json:"key"` // The plain key, only shown once
+}
+
+// @Id           CreateAPIKey
+// @Summary      Create API Key
+// @Description  Create a new long-lived API key for server-to-server integrations
+// @Tags         APIKey
+// @Security     ApiKeyAuth
+// @Param        key  body  ParamsAPIKeyCreate  true  "The API key data"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseAPIKeyCreate
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /api_keys  [post]
+func APIKeyCreate(app *core.App, router fiber.Router) {
+	router.Post("/api_keys", common.AdminPermGuard(app, entity.AdminPermSuper, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsAPIKeyCreate
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		p.Name = strings.TrimSpace(p.Name)
+		if p.Name == "" {
+			return common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": i18n.T("Name")}))
+		}
+		if len(p.Scopes) == 0 {
+			return common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": "scopes"}))
+		}
+		for _, scope := range p.Scopes {
+			if !entity.IsValidAPIKeyScope(scope) {
+				return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "scopes"}), Map{"scope": scope})
+			}
+		}
+		if p.RateLimit < 0 {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "rate_limit"}))
+		}
+
+		key, plain, err := app.Dao().NewAPIKey(p.Name, p.Scopes, p.RateLimit, admin.ID)
+		if err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} creation failed", Map{"name": "API key"}))
+		}
+
+		cooked := app.Dao().CookAPIKey(&key)
+		common.AddAudit(c, common.Audit{Action: "api_key.create", TargetType: "api_key", TargetID: key.ID, After: cooked})
+
+		return common.RespData(c, ResponseAPIKeyCreate{
+			CookedAPIKey: cooked,
+			Key:          plain,
+		})
+	}))
+}
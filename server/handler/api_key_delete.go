@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           DeleteAPIKey
+// @Summary      Revoke API Key
+// @Description  Revoke a specific API key, the key can not be used anymore
+// @Tags         APIKey
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The API key ID you want to revoke"
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /api_keys/{id}  [delete]
+func APIKeyDelete(app *core.App, router fiber.Router) {
+	router.Delete("/api_keys/:id", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		id, _ := c.ParamsInt("id")
+
+		key := app.Dao().FindAPIKeyByID(uint(id))
+		if key.IsEmpty() {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": "API key"}))
+		}
+
+		before := app.Dao().CookAPIKey(&key)
+
+		if err := app.Dao().DelAPIKey(&key); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": "API key"}))
+		}
+
+		common.AddAudit(c, common.Audit{Action: "api_key.delete", TargetType: "api_key", TargetID: key.ID, Before: before})
+
+		return common.RespSuccess(c)
+	}))
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ResponseAPIKeyList struct {
+	Keys  []entity.CookedAPIKey `json:"keys"`
+	Count int                   `json:"count"`
+}
+
+// @Id           GetAPIKeys
+// @Summary      Get API Key List
+// @Description  Get a list of API keys (the plain keys are not included)
+// @Tags         APIKey
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  ResponseAPIKeyList
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /api_keys  [get]
+func APIKeyList(app *core.App, router fiber.Router) {
+	router.Get("/api_keys", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		keys := lo.Map(app.Dao().FindAllAPIKeys(), func(k entity.APIKey, _ int) entity.CookedAPIKey {
+			return app.Dao().CookAPIKey(&k)
+		})
+
+		return common.RespData(c, ResponseAPIKeyList{
+			Keys:  keys,
+			Count: len(keys),
+		})
+	}))
+}
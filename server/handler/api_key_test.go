@@ -0,0 +1,80 @@
+package handler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/artalkjs/artalk/v2/server/middleware/api_key"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAPIKey(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	api.Use(api_key.APIKeyMiddleware(app.App))
+	handler.APIKeyList(app.App, api)
+	handler.APIKeyCreate(app.App, api)
+	handler.APIKeyDelete(app.App, api)
+	handler.CommentDelete(app.App, api)
+	handler.CommentList(app.App, api)
+	handler.SiteCreate(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	adminToken, _ := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+
+	request := func(method string, url string, headers map[string]string, body string) (int, gjson.Result) {
+		return NewApiRequest(t, api, headers)(method, url, "", body)
+	}
+	asAdmin := map[string]string{"Authorization": "Bearer " + adminToken}
+
+	code, _ := request("POST", "/api_keys", asAdmin, `{"name":"bot","scopes":["unknown"]}`)
+	assert.Equal(t, 400, code, "should reject the invalid scope")
+
+	code, data := request("POST", "/api_keys", asAdmin, `{"name":"bot","scopes":["comments:read","moderate"],"rate_limit":4}`)
+	assert.Equal(t, 200, code)
+	plain := data.Get("key").String()
+	keyID := data.Get("id").String()
+	assert.True(t, strings.HasPrefix(plain, data.Get("key_prefix").String()))
+	assert.Equal(t, admin.ID, uint(data.Get("user_id").Int()))
+
+	code, data = request("GET", "/api_keys", asAdmin, "")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, int64(1), data.Get("count").Int())
+	assert.False(t, data.Get("keys.0.key").Exists(), "should not expose the plain key")
+
+	asKey := map[string]string{"X-API-Key": plain}
+
+	t.Run("Scope", func(t *testing.T) {
+		code, data := request("GET", "/comments?page_key=_&scope=site&type=pending", asKey, "")
+		assert.Equal(t, 200, code)
+		assert.Greater(t, data.Get("count").Int(), int64(0), "should list the pending comments with the read scope")
+
+		code, _ = request("DELETE", "/comments/1000", asKey, "")
+		assert.Equal(t, 200, code)
+		assert.True(t, app.Dao().FindComment(1000).IsEmpty())
+
+		code, _ = request("GET", "/api_keys", asKey, "")
+		assert.Equal(t, 403, code, "should not manage the keys by key")
+
+		code, data = request("POST", "/sites", asKey, `{"name":"Site C","urls":[]}`)
+		assert.Equal(t, 403, code, "should reject the endpoint beyond the scopes")
+		assert.Equal(t, string(entity.AdminPermSuper), data.Get("need_perm").String())
+
+		code, _ = request("GET", "/comments?page_key=_&scope=site", map[string]string{"X-API-Key": "atk_invalid"}, "")
+		assert.Equal(t, 401, code)
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		code, _ := request("GET", "/comments?page_key=_&scope=site", asKey, "")
+		assert.Equal(t, 429, code, "should block the requests over the rate limit")
+	})
+
+	code, _ = request("DELETE", "/api_keys/"+keyID, asAdmin, "")
+	assert.Equal(t, 200, code)
+	assert.True(t, app.Dao().FindAPIKey(plain).IsEmpty())
+}
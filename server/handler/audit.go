@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The snapshot of comment for the before and after values of audit log
+func auditComment(comment entity.Comment) Map {
+	return Map{
+		"id":           comment.ID,
+		"content":      comment.Content,
+		"page_key":     comment.PageKey,
+		"site_name":    comment.SiteName,
+		"user_id":      comment.UserID,
+		"rid":          comment.Rid,
+		"ip":           comment.IP,
+		"is_pending":   comment.IsPending,
+		"is_pinned":    comment.IsPinned,
+		"is_collapsed": comment.IsCollapsed,
+	}
+}
+
+// Attach the action of comment to the audit log, the `before` or `after` can be nil
+func addCommentAudit(c *fiber.Ctx, action string, before *entity.Comment, after *entity.Comment) {
+	audit := common.Audit{Action: action, TargetType: "comment"}
+	for _, comment := range []*entity.Comment{before, after} {
+		if comment != nil {
+			audit.TargetID = comment.ID
+			audit.SiteName = comment.SiteName
+		}
+	}
+	if before != nil {
+		audit.Before = auditComment(*before)
+	}
+	if after != nil {
+		audit.After = auditComment(*after)
+	}
+	common.AddAudit(c, audit)
+}
+
+// Get the audit action of the moderator decision on the pending status
+func pendingAuditAction(isPending bool) string {
+	if isPending {
+		return "comment.spam"
+	}
+	return "comment.approve"
+}
+
+// The site config overrides (in JSON) for the audit log, nil if not overridden
+func auditSiteOverrides(raw string) any {
+	if raw == "" {
+		return nil
+	}
+	var overrides Map
+	if err := json.Unmarshal([]byte(raw), &overrides); err != nil {
+		return raw
+	}
+	return overrides
+}
+
+var auditSecretWords = []string{"token", "secret", "key", "password", "pass", "dsn"}
+
+// Get the changed keys of config for the audit log, the values of secrets are redacted
+func auditConfigChanges(before *config.Config, after *config.Config) (Map, Map) {
+	flatBefore, flatAfter := flattenConfig(before), flattenConfig(after)
+	changedBefore, changedAfter := Map{}, Map{}
+
+	for k, v := range flatBefore {
+		if nv, ok := flatAfter[k]; !ok || !reflect.DeepEqual(v, nv) {
+			changedBefore[k] = redactAuditConfig(k, v)
+		}
+	}
+	for k, v := range flatAfter {
+		if ov, ok := flatBefore[k]; !ok || !reflect.DeepEqual(ov, v) {
+			changedAfter[k] = redactAuditConfig(k, v)
+		}
+	}
+	return changedBefore, changedAfter
+}
+
+// Flatten the config to the map of keys joined by dot (e.g. "admin_notify.email.enabled")
+func flattenConfig(conf *config.Config) map[string]any {
+	flat := map[string]any{}
+	if conf == nil {
+		return flat
+	}
+
+	var nested map[string]any
+	buf, _ := json.Marshal(conf)
+	json.Unmarshal(buf, &nested)
+
+	var walk func(prefix string, m map[string]any)
+	walk = func(prefix string, m map[string]any) {
+		for k, v := range m {
+			if child, ok := v.(map[string]any); ok && len(child) > 0 {
+				walk(prefix+k+".", child)
+			} else {
+				flat[prefix+k] = v
+			}
+		}
+	}
+	walk("", nested)
+	return flat
+}
+
+func redactAuditConfig(key string, value any) any {
+	name := strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+	for _, word := range auditSecretWords {
+		if strings.Contains(name, word) && !isAuditEmpty(value) {
+			return "******"
+		}
+	}
+	return value
+}
+
+func isAuditEmpty(value any) bool {
+	return value == nil || value == "" || value == false || reflect.DeepEqual(value, []any{})
+}
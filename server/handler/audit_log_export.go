@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The max number of audit logs in an export, narrow the time range by the filter for more
+const auditLogExportMax = 10000
+
+type ParamsAuditLogExport struct {
+	ParamsAuditLogFilter
+
+	Format string `query:"format" json:"format" enums:"csv,json" validate:"optional"` // The format of exported file (default: csv)
+}
+
+// @Id           ExportAuditLogs
+// @Summary      Export Audit Logs
+// @Description  Export the audit logs by the filter as a CSV or JSON file, at most 10000 latest logs are exported
+// @Tags         AuditLog
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsAuditLogExport  true  "The options"
+// @Produce      text/csv
+// @Produce      json
+// @Success      200  {string}  string
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /audit_logs/export  [get]
+func AuditLogExport(app *core.App, router fiber.Router) {
+	router.Get("/audit_logs/export", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		var p ParamsAuditLogExport
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.Format == "" {
+			p.Format = "csv"
+		}
+		if p.Format != "csv" && p.Format != "json" {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "format"}))
+		}
+
+		filter, ok, resp := getAuditLogFilter(c, p.ParamsAuditLogFilter)
+		if !ok {
+			return resp
+		}
+
+		logs, _ := app.Dao().FindAuditLogs(filter, 0, auditLogExportMax)
+
+		cooked := []entity.CookedAuditLog{}
+		for _, l := range logs {
+			cooked = append(cooked, app.Dao().CookAuditLog(&l))
+		}
+
+		filename := "artalk-audit-logs-" + time.Now().Format("20060102-150405") + "." + p.Format
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+
+		if p.Format == "json" {
+			return c.JSON(cooked)
+		}
+
+		buf, err := encodeAuditLogsCSV(cooked)
+		if err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		return c.Send(buf)
+	}))
+}
+
+func encodeAuditLogsCSV(logs []entity.CookedAuditLog) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+
+	w.Write([]string{"id", "created_at", "actor_type", "actor_id", "actor_name", "ip", "action",
+		"method", "path", "target_type", "target_id", "site_name", "before", "after"})
+
+	encode := func(v any) string {
+		if v == nil {
+			return ""
+		}
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+	for _, l := range logs {
+		w.Write([]string{
+			strconv.FormatUint(uint64(l.ID), 10),
+			l.CreatedAt.Format(time.RFC3339),
+			l.ActorType,
+			strconv.FormatUint(uint64(l.ActorID), 10),
+			l.ActorName,
+			l.IP,
+			l.Action,
+			l.Method,
+			l.Path,
+			l.TargetType,
+			strconv.FormatUint(uint64(l.TargetID), 10),
+			l.SiteName,
+			encode(l.Before),
+			encode(l.After),
+		})
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsAuditLogFilter struct {
+	ActorType  string `query:"actor_type" json:"actor_type" enums:"user,api_key,telegram" validate:"optional"` // Filter by the actor type
+	ActorID    uint   `query:"actor_id" json:"actor_id" validate:"optional"`                                   // Filter by the user ID or the API key ID of actor
+	Action     string `query:"action" json:"action" validate:"optional"`                                       // Filter by the action prefix (e.g. "comment." or "comment.delete")
+	TargetType string `query:"target_type" json:"target_type" validate:"optional"`                             // Filter by the target type (e.g. "comment")
+	TargetID   uint   `query:"target_id" json:"target_id" validate:"optional"`                                 // Filter by the target ID
+	SiteName   string `query:"site_name" json:"site_name" validate:"optional"`                                 // Filter by the site name
+	DateFrom   string `query:"date_from" json:"date_from" validate:"optional"`                                 // Filter the logs created after the time (e.g. "2006-01-02" or RFC3339)
+	DateTo     string `query:"date_to" json:"date_to" validate:"optional"`                                     // Filter the logs created before the time, the whole day is included if the date only
+}
+
+type ParamsAuditLogList struct {
+	ParamsAuditLogFilter
+
+	Limit  int `query:"limit" json:"limit" validate:"optional"`   // The limit for pagination (default: 20)
+	Offset int `query:"offset" json:"offset" validate:"optional"` // The offset for pagination
+}
+
+type ResponseAuditLogList struct {
+	Logs  []entity.CookedAuditLog `json:"logs"`
+	Count int64                   `json:"count"`
+}
+
+// @Id           GetAuditLogs
+// @Summary      Get Audit Logs
+// @Description  Get the audit logs of the admin actions in the latest order
+// @Tags         AuditLog
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsAuditLogList  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseAuditLogList
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /audit_logs  [get]
+func AuditLogList(app *core.App, router fiber.Router) {
+	router.Get("/audit_logs", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		var p ParamsAuditLogList
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		filter, ok, resp := getAuditLogFilter(c, p.ParamsAuditLogFilter)
+		if !ok {
+			return resp
+		}
+
+		if p.Limit <= 0 || p.Limit > 100 {
+			p.Limit = 20
+		}
+		if p.Offset < 0 {
+			p.Offset = 0
+		}
+
+		logs, count := app.Dao().FindAuditLogs(filter, p.Offset, p.Limit)
+
+		cooked := []entity.CookedAuditLog{}
+		for _, l := range logs {
+			cooked = append(cooked, app.Dao().CookAuditLog(&l))
+		}
+
+		return common.RespData(c, ResponseAuditLogList{
+			Logs:  cooked,
+			Count: count,
+		})
+	}))
+}
+
+func getAuditLogFilter(c *fiber.Ctx, p ParamsAuditLogFilter) (dao.AuditLogFilter, bool, error) {
+	from, to, ok, resp := parseCommentSearchDateRange(c, p.DateFrom, p.DateTo)
+	if !ok {
+		return dao.AuditLogFilter{}, false, resp
+	}
+
+	return dao.AuditLogFilter{
+		ActorType:  p.ActorType,
+		ActorID:    p.ActorID,
+		Action:     p.Action,
+		TargetType: p.TargetType,
+		TargetID:   p.TargetID,
+		SiteName:   p.SiteName,
+		From:       from,
+		To:         to,
+	}, true, nil
+}
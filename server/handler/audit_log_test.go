@@ -0,0 +1,140 @@
+package handler_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/artalkjs/artalk/v2/server/middleware/api_key"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAuditLog(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	api.Use(api_key.APIKeyMiddleware(app.App))
+	handler.APIKeyCreate(app.App, api)
+	handler.CommentDelete(app.App, api)
+	handler.CommentUpdate(app.App, api)
+	handler.VoteSync(app.App, api)
+	handler.AuditLogList(app.App, api)
+	handler.AuditLogExport(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+	asAdmin := map[string]string{"Authorization": "Bearer " + token}
+
+	request := func(method, url string, headers map[string]string, body string) (int, []byte) {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, buf
+	}
+	list := func(query string) gjson.Result {
+		code, buf := request("GET", "/audit_logs"+query, asAdmin, "")
+		assert.Equal(t, 200, code)
+		return gjson.ParseBytes(buf)
+	}
+
+	_, buf := request("POST", "/api_keys", asAdmin, `{"name":"bot","scopes":["moderate"]}`)
+	asKey := map[string]string{"X-API-Key": gjson.GetBytes(buf, "key").String()}
+
+	t.Run("Create API key", func(t *testing.T) {
+		res := list("?action=api_key.create")
+		assert.Equal(t, int64(1), res.Get("count").Int())
+		assert.Equal(t, "bot", res.Get("logs.0.after.name").String())
+		assert.False(t, res.Get("logs.0.after.key").Exists(), "should not record the plain key")
+	})
+
+	t.Run("Update by user", func(t *testing.T) {
+		comment := app.Dao().FindComment(1007)
+		body, _ := json.Marshal(map[string]any{
+			"site_name": comment.SiteName, "page_key": comment.PageKey, "content": comment.Content, "rid": comment.Rid,
+			"is_collapsed": false, "is_pending": false, "is_pinned": false,
+		})
+		code, _ := request("PUT", "/comments/1007", asAdmin, string(body))
+		assert.Equal(t, 200, code)
+
+		res := list("?target_type=comment&target_id=1007")
+		assert.Equal(t, int64(1), res.Get("count").Int())
+		assert.Equal(t, "comment.approve", res.Get("logs.0.action").String())
+		assert.Equal(t, entity.AuditActorUser, res.Get("logs.0.actor_type").String())
+		assert.Equal(t, int64(admin.ID), res.Get("logs.0.actor_id").Int())
+		assert.True(t, res.Get("logs.0.before.is_pending").Bool())
+		assert.False(t, res.Get("logs.0.after.is_pending").Bool())
+	})
+
+	t.Run("Delete by API key", func(t *testing.T) {
+		code, _ := request("DELETE", "/comments/1001", asKey, "")
+		assert.Equal(t, 200, code)
+
+		res := list("?action=comment.delete")
+		assert.Equal(t, int64(1), res.Get("count").Int())
+		assert.Equal(t, entity.AuditActorAPIKey, res.Get("logs.0.actor_type").String())
+		assert.Equal(t, "bot", res.Get("logs.0.actor_name").String())
+		assert.Equal(t, int64(1001), res.Get("logs.0.before.id").Int())
+		assert.Equal(t, gjson.Null, res.Get("logs.0.after").Type)
+	})
+
+	t.Run("Not recorded", func(t *testing.T) {
+		count := list("").Get("count").Int()
+
+		code, _ := request("DELETE", "/comments/99999", asAdmin, "")
+		assert.Equal(t, 404, code)
+		code, _ = request("DELETE", "/comments/1002", nil, "")
+		assert.Equal(t, 403, code)
+
+		assert.Equal(t, count, list("").Get("count").Int(), "should not record the failed requests and the reads")
+	})
+
+	t.Run("Generic action", func(t *testing.T) {
+		code, _ := request("POST", "/votes/sync", asAdmin, "")
+		assert.Equal(t, 200, code)
+
+		res := list("?limit=1")
+		assert.Equal(t, "POST /votes/sync", res.Get("logs.0.action").String())
+		assert.Equal(t, "/votes/sync", res.Get("logs.0.path").String())
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		assert.Equal(t, int64(2), list("?action=comment.").Get("count").Int())
+		assert.Equal(t, int64(1), list("?actor_type=api_key").Get("count").Int())
+		assert.Equal(t, int64(0), list("?date_to=2000-01-01").Get("count").Int())
+
+		code, _ := request("GET", "/audit_logs?date_from=invalid", asAdmin, "")
+		assert.Equal(t, 400, code)
+		code, _ = request("GET", "/audit_logs", asKey, "")
+		assert.Equal(t, 403, code, "should require the super admin")
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		code, buf := request("GET", "/audit_logs/export?action=comment.", asAdmin, "")
+		assert.Equal(t, 200, code)
+		rows, err := csv.NewReader(strings.NewReader(string(buf))).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, rows, 3)
+		assert.Equal(t, "action", rows[0][6])
+		assert.Equal(t, "comment.delete", rows[1][6])
+
+		code, buf = request("GET", "/audit_logs/export?format=json&action=comment.", asAdmin, "")
+		assert.Equal(t, 200, code)
+		assert.Len(t, gjson.ParseBytes(buf).Array(), 2)
+
+		code, _ = request("GET", "/audit_logs/export?format=xml", asAdmin, "")
+		assert.Equal(t, 400, code)
+	})
+}
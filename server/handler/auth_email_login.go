@@ -71,15 +71,15 @@ func AuthEmailLogin(app *core.App, router fiber.Router) {
 		}
 
 		// Get user token
-		jwtToken, err := common.LoginGetUserToken(user, app.Conf().AppKey, app.Conf().LoginTimeout)
+		tokens, err := common.IssueUserTokens(app, user, false)
 		if err != nil {
 			log.Error("[LoginGetUserToken] ", err)
 			return common.RespError(c, 500, i18n.T("Login failed"))
 		}
 
 		return common.RespData(c, ResponseUserLogin{
-			Token: jwtToken,
-			User:  app.Dao().CookUser(&user),
+			UserTokens: tokens,
+			User:       app.Dao().CookUser(&user),
 		})
 	}))
 }
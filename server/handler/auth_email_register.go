@@ -5,6 +5,7 @@ import (
 
 	"github.com/artalkjs/artalk/v2/internal/core"
 	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
 )
@@ -82,6 +83,7 @@ func AuthEmailRegister(app *core.App, router fiber.Router) {
 		}
 
 		// Update user
+		isRegistered := user.Password == ""
 		if err := user.SetPasswordEncrypt(p.Password); err != nil {
 			return common.RespError(c, 500, "Failed to encrypt password")
 		}
@@ -91,16 +93,19 @@ func AuthEmailRegister(app *core.App, router fiber.Router) {
 		if err := app.Dao().UpdateUser(&user); err != nil {
 			return common.RespError(c, 500, "Failed to update user")
 		}
+		if isRegistered {
+			triggerUserWebhook(app, webhook.EventUserRegistered, user)
+		}
 
 		// Login
-		jwtToken, err := common.LoginGetUserToken(user, app.Conf().AppKey, app.Conf().LoginTimeout)
+		tokens, err := common.IssueUserTokens(app, user, false)
 		if err != nil {
 			return common.RespError(c, 500, err.Error())
 		}
 
 		return common.RespData(c, ResponseUserLogin{
-			Token: jwtToken,
-			User:  app.Dao().CookUser(&user),
+			UserTokens: tokens,
+			User:       app.Dao().CookUser(&user),
 		})
 	}))
 }
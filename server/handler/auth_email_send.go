@@ -57,7 +57,7 @@ func AuthEmailSend(app *core.App, router fiber.Router) {
 		defer mutex.Unlock()
 
 		// check if had send email verify code in 1 minutes
-		if err := app.Dao().DB().Model(&entity.UserEmailVerify{}).Where("email = ? OR ip = ?", p.Email, c.IP()).Where("updated_at > ?", time.Now().Add(-time.Minute*1)).First(&entity.UserEmailVerify{}).Error; err == nil {
+		if err := app.Dao().DB().Model(&entity.UserEmailVerify{}).Where("email = ? OR ip = ?", p.Email, app.AnonymizeIP(c.IP())).Where("updated_at > ?", time.Now().Add(-time.Minute*1)).First(&entity.UserEmailVerify{}).Error; err == nil {
 			return common.RespError(c, 400, "Send email verify code too frequently")
 		}
 
@@ -70,7 +70,7 @@ func AuthEmailSend(app *core.App, router fiber.Router) {
 		}).Assign(entity.UserEmailVerify{
 			Code:      code,
 			ExpiresAt: time.Now().Add(time.Minute * 5),
-			IP:        c.IP(),
+			IP:        app.AnonymizeIP(c.IP()),
 			UA:        string(c.Request().Header.UserAgent()),
 		}).FirstOrCreate(&emailVerify).Error
 		if err != nil {
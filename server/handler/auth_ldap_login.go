@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/artalkjs/artalk/v2/internal/auth"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/ldap_auth"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/markbates/goth"
+)
+
+type ParamsAuthLDAPLogin struct {
+	Username string `json:"username" validate:"required"` // The LDAP login name (matched by the user_filter)
+	Password string `json:"password" validate:"required"` // The LDAP password
+	OTPCode  string `json:"otp_code" validate:"optional"` // The two-factor authentication code or backup code (required if the admin enabled TOTP)
+}
+
+// @Id           LoginByLDAP
+// @Summary      Login by LDAP
+// @Description  Login the admin by LDAP / Active Directory, the admin role is mapped from the LDAP groups
+// @Tags         Auth
+// @Param        data  body  ParamsAuthLDAPLogin  true  "The LDAP login data"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseUserLogin
+// @Failure      401  {object}  Map{msg=string,need_otp=bool}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /auth/ldap/login  [post]
+func AuthLDAPLogin(app *core.App, router fiber.Router) {
+	router.Post("/auth/ldap/login", common.LimiterGuard(app, func(c *fiber.Ctx) error {
+		if !app.Conf().AdminLDAP.Enabled {
+			return common.RespError(c, 404, "LDAP login disabled")
+		}
+
+		var p ParamsAuthLDAPLogin
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		ldapUser, err := ldap_auth.Authenticate(&app.Conf().AdminLDAP, p.Username, p.Password)
+		if errors.Is(err, ldap_auth.ErrInvalidCredentials) {
+			return common.RespError(c, 401, i18n.T("Username or password is incorrect"))
+		} else if errors.Is(err, ldap_auth.ErrNoMatchedGroup) {
+			return common.RespError(c, 403, i18n.T("Permission denied"))
+		} else if err != nil {
+			log.Error("[LDAPLogin] ", err)
+			return common.RespError(c, 500, i18n.T("Login failed"))
+		}
+
+		user, err := findCreateLDAPUser(app, ldapUser)
+		if err != nil {
+			log.Error("[LDAPLogin] ", err)
+			return common.RespError(c, 500, i18n.T("Login failed"))
+		}
+
+		// 两步验证
+		totpVerified, isOK, resp := checkLoginTOTP(app, c, &user, p.OTPCode)
+		if !isOK {
+			return resp
+		}
+
+		tokens, err := common.IssueUserTokens(app, user, totpVerified)
+		if err != nil {
+			log.Error("[LoginGetUserToken] ", err)
+			return common.RespError(c, 500, i18n.T("Login failed"))
+		}
+
+		return common.RespData(c, ResponseUserLogin{
+			UserTokens: tokens,
+			User:       app.Dao().CookUser(&user),
+		})
+	}))
+}
+
+// Find or register the admin of LDAP user, the role is synced on every login
+func findCreateLDAPUser(app *core.App, ldapUser ldap_auth.User) (entity.User, error) {
+	authIdentity := app.Dao().FindAuthIdentityByRemoteUID(ldap_auth.Provider, ldapUser.DN)
+	if authIdentity.IsEmpty() {
+		var err error
+		authIdentity, err = auth.RegisterSocialUser(app.Dao(), auth.SocialUser{
+			User: goth.User{
+				Provider: ldap_auth.Provider,
+				UserID:   ldapUser.DN,
+				Name:     ldapUser.Name,
+				Email:    ldapUser.Email,
+			},
+			RemoteUID: ldapUser.DN,
+		})
+		if err != nil {
+			return entity.User{}, err
+		}
+		triggerUserWebhook(app, webhook.EventUserRegistered, app.Dao().FindUserByID(authIdentity.UserID))
+	}
+
+	user := app.Dao().FindUserByID(authIdentity.UserID)
+	if user.IsEmpty() {
+		return entity.User{}, errors.New("user of ldap identity not found")
+	}
+
+	user.IsAdmin = true
+	user.AdminRole = ldapUser.Role
+	user.SetAdminSites(ldapUser.Sites)
+	user.AuthProvider = ldap_auth.Provider
+	if err := app.Dao().UpdateUser(&user); err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,33 @@
+package handler_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthLDAPLogin(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.AuthLDAPLogin(app.App, api)
+
+	login := func(body string) int {
+		req := httptest.NewRequest("POST", "/auth/ldap/login", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := api.Test(req, 15000)
+		assert.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, 404, login(`{"username":"alice","password":"pass"}`), "should be disabled by default")
+
+	app.Conf().AdminLDAP.Enabled = true
+	app.Conf().AdminLDAP.URL = "ldap://127.0.0.1:1"
+
+	assert.Equal(t, 400, login(`{"username":"alice"}`), "should require the password")
+	assert.Equal(t, 500, login(`{"username":"alice","password":"pass"}`), "should fail if the server is unavailable")
+}
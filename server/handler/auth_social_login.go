@@ -5,6 +5,7 @@ import (
 	"github.com/artalkjs/artalk/v2/internal/auth/gothic_fiber"
 	"github.com/artalkjs/artalk/v2/internal/core"
 	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/markbates/goth"
@@ -89,6 +90,7 @@ func AuthSocialLogin(app *core.App, router fiber.Router) {
 				log.Error("[SocialLogin] ", err)
 				return common.RespError(c, 500, "Failed to register user")
 			}
+			triggerUserWebhook(app, webhook.EventUserRegistered, app.Dao().FindUserByID(authIdentity.UserID))
 		}
 		if authIdentity.UserID == 0 {
 			return common.RespError(c, 500, "Auth Identity user_id invalid")
@@ -100,6 +102,14 @@ func AuthSocialLogin(app *core.App, router fiber.Router) {
 			return common.RespError(c, 500, "Failed to find user")
 		}
 
+		// Link the identity provider to user for display the verified badge
+		if user.AuthProvider != provider {
+			user.AuthProvider = provider
+			if err := app.Dao().UpdateUser(&user); err != nil {
+				log.Error("[SocialLogin] ", err)
+			}
+		}
+
 		// Get user token
 		jwtToken, err := common.LoginGetUserToken(user, app.Conf().AppKey, app.Conf().LoginTimeout)
 		if err != nil {
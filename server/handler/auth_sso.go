@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/auth"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsAuthSSO struct {
+	Payload string `json:"payload" validate:"required"` // The user payload signed by the host site
+}
+
+// @Id           LoginBySSO
+// @Summary      Login by Single Sign-On
+// @Description  Login the user of host site by the HMAC-signed user payload, the user will be registered if not exists
+// @Tags         Auth
+// @Param        data  body  ParamsAuthSSO  true  "The signed user payload"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseUserLogin
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /auth/sso  [post]
+func AuthSSO(app *core.App, router fiber.Router) {
+	router.Post("/auth/sso", func(c *fiber.Ctx) error {
+		conf := app.Conf().Auth.SSO
+		if !conf.Enabled || conf.Secret == "" {
+			return common.RespError(c, 404, "SSO login disabled")
+		}
+
+		var p ParamsAuthSSO
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		maxAge := time.Duration(conf.MaxAge) * time.Second
+		if maxAge <= 0 {
+			maxAge = 5 * time.Minute
+		}
+
+		ssoUser, err := auth.ParseSSOPayload(p.Payload, conf.Secret, maxAge, time.Now())
+		if err != nil {
+			log.Debug("[SSO] ", err)
+			return common.RespError(c, 401, i18n.T("SSO payload is invalid or expired"))
+		}
+
+		// Find or register the user of host site
+		authIdentity := app.Dao().FindAuthIdentityByRemoteUID(auth.SSOProvider, ssoUser.ID)
+		if authIdentity.IsEmpty() {
+			authIdentity, err = auth.RegisterSocialUser(app.Dao(), ssoUser.SocialUser())
+			if err != nil {
+				log.Error("[SSO] ", err)
+				return common.RespError(c, 400, i18n.T("Failed to register user"))
+			}
+			triggerUserWebhook(app, webhook.EventUserRegistered, app.Dao().FindUserByID(authIdentity.UserID))
+		}
+
+		user := app.Dao().FindUserByID(authIdentity.UserID)
+		if user.IsEmpty() {
+			return common.RespError(c, 500, "Failed to find user")
+		}
+
+		// The host site is not allowed to sign in as the admin
+		if user.IsAdmin {
+			return common.RespError(c, 403, i18n.T("SSO login is not allowed for admin"))
+		}
+
+		// Sync the profile from host site
+		if ssoUser.Link != "" && utils.ValidateURL(ssoUser.Link) {
+			user.Link = ssoUser.Link
+		}
+		if ssoUser.Avatar == "" || utils.ValidateURL(ssoUser.Avatar) {
+			user.Avatar = ssoUser.Avatar
+		}
+		user.AuthProvider = auth.SSOProvider
+		if err := app.Dao().UpdateUser(&user); err != nil {
+			log.Error("[SSO] ", err)
+		}
+
+		tokens, err := common.IssueUserTokens(app, user, false)
+		if err != nil {
+			log.Error("[LoginGetUserToken] ", err)
+			return common.RespError(c, 500, i18n.T("Login failed"))
+		}
+
+		return common.RespData(c, ResponseUserLogin{
+			UserTokens: tokens,
+			User:       app.Dao().CookUser(&user),
+		})
+	})
+}
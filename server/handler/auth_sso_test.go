@@ -0,0 +1,64 @@
+package handler_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/auth"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAuthSSO(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.AuthSSO(app.App, api)
+
+	login := func(payload string) (int, gjson.Result) {
+		req := httptest.NewRequest("POST", "/auth/sso", strings.NewReader(`{"payload":"`+payload+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(buf)
+	}
+	sign := func(u auth.SSOUser) string {
+		payload, err := auth.SignSSOPayload(u, "sso_secret", time.Now())
+		assert.NoError(t, err)
+		return payload
+	}
+	user := auth.SSOUser{ID: "host_1", Name: "host_user", Email: "host_user@example.com", Avatar: "https://example.com/a.png"}
+
+	code, _ := login(sign(user))
+	assert.Equal(t, 404, code, "should be disabled by default")
+
+	app.Conf().Auth.SSO.Enabled = true
+	app.Conf().Auth.SSO.Secret = "sso_secret"
+
+	code, data := login(sign(user))
+	assert.Equal(t, 200, code)
+	assert.NotEmpty(t, data.Get("token").String())
+	assert.Equal(t, "host_user", data.Get("user.name").String())
+	assert.Equal(t, "https://example.com/a.png", data.Get("user.avatar").String())
+	userID := data.Get("user.id").Uint()
+
+	user.Avatar = "https://example.com/b.png"
+	code, data = login(sign(user))
+	assert.Equal(t, 200, code)
+	assert.Equal(t, userID, data.Get("user.id").Uint(), "should map to the same user")
+	assert.Equal(t, "https://example.com/b.png", data.Get("user.avatar").String(), "should sync the avatar")
+	assert.Equal(t, auth.SSOProvider, app.Dao().FindUserByID(uint(userID)).AuthProvider)
+
+	app.Conf().Auth.SSO.Secret = "other_secret"
+	code, _ = login(sign(user))
+	assert.Equal(t, 401, code, "should reject the invalid signature")
+	app.Conf().Auth.SSO.Secret = "sso_secret"
+
+	code, _ = login(sign(auth.SSOUser{ID: "host_admin", Name: "admin", Email: "admin@qwqaq.com"}))
+	assert.Equal(t, 403, code, "should not allow to sign in as the admin")
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsAvatar struct {
+	Size int `query:"s" json:"s" validate:"optional"` // The size of avatar in pixels (snapped to 40, 80, 160, 240 or 480)
+}
+
+// @Id           GetAvatar
+// @Summary      Get Avatar
+// @Description  Get the avatar of email hash by the avatar proxy, which is fetched and cached by the server
+// @Tags         Avatar
+// @Param        hash     path   string        true  "The email hash (MD5 or SHA256)"
+// @Param        options  query  ParamsAvatar  true  "The options"
+// @Produce      image/png
+// @Success      200  {file}    binary
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      429  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /avatar/{hash}  [get]
+func Avatar(app *core.App, router fiber.Router) {
+	router.Get("/avatar/:hash", common.RateLimitGuard(app, "avatar_rate", core.GetAvatarRateLimit(app.Conf().Avatar), func(c *fiber.Ctx) error {
+		avatarService, err := core.AppService[*core.AvatarService](app)
+		if err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+		if !avatarService.IsEnabled() {
+			return common.RespError(c, 404, i18n.T("Avatar proxy is not enabled"))
+		}
+
+		var p ParamsAvatar
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		hash := strings.ToLower(c.Params("hash"))
+		if !core.IsValidAvatarHash(hash) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", map[string]interface{}{"name": "hash"}))
+		}
+
+		avatar, err := avatarService.Get(hash, p.Size)
+		if err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+
+		c.Set(fiber.HeaderContentType, avatar.Mime)
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(avatarService.GetCacheTTL().Seconds())))
+		return c.Send(avatar.Data)
+	}))
+}
@@ -0,0 +1,153 @@
+package handler_test
+
+import (
+	"bytes"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAvatar(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	const (
+		foundHash   = "0123456789abcdef0123456789abcdef"
+		missingHash = "fedcba9876543210fedcba9876543210"
+	)
+
+	// Fake Gravatar which only has the avatar of `foundHash`
+	var requests, failures atomic.Int32
+	gravatar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failures.Load() > 0 {
+			failures.Add(-1)
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if r.URL.Query().Get("d") != "404" || !strings.HasSuffix(r.URL.Path, "/"+foundHash) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(pngHeader)
+	}))
+	defer gravatar.Close()
+
+	app.Conf().Avatar.Enabled = true
+	app.Conf().Avatar.Sources = []string{"gravatar"}
+	app.Conf().Avatar.GravatarMirror = gravatar.URL + "/avatar"
+	app.Conf().Avatar.CachePath = t.TempDir()
+	avatarService, err := core.AppService[*core.AvatarService](app.App)
+	assert.NoError(t, err)
+	assert.NoError(t, avatarService.Init())
+	defer avatarService.Dispose()
+
+	handler.Avatar(app.App, api)
+	handler.Conf(app.App, api)
+
+	get := func(t *testing.T, hash string, size string) (*http.Response, []byte) {
+		resp, err := api.Test(httptest.NewRequest("GET", "/avatar/"+hash+"?s="+size, nil))
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return resp, body
+	}
+
+	t.Run("Proxy and cache", func(t *testing.T) {
+		requests.Store(0)
+		for i := 0; i < 2; i++ {
+			resp, body := get(t, foundHash, "80")
+			assert.Equal(t, 200, resp.StatusCode)
+			assert.Equal(t, pngHeader, body)
+			assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+			assert.Contains(t, resp.Header.Get("Cache-Control"), "public")
+		}
+		assert.Equal(t, int32(1), requests.Load(), "the avatar should be fetched only once")
+	})
+
+	t.Run("Identicon fallback", func(t *testing.T) {
+		resp, body := get(t, missingHash, "64")
+		assert.Equal(t, 200, resp.StatusCode)
+
+		img, err := png.Decode(bytes.NewReader(body))
+		if assert.NoError(t, err, "the identicon should be generated") {
+			assert.Equal(t, 80, img.Bounds().Dx(), "the size should be snapped to the available size")
+		}
+
+		// The identicon is deterministic
+		_, body2 := get(t, missingHash, "64")
+		assert.Equal(t, body, body2)
+	})
+
+	t.Run("Unavailable source is not cached", func(t *testing.T) {
+		requests.Store(0)
+		failures.Store(1)
+
+		resp, body := get(t, foundHash, "100")
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.NotEqual(t, pngHeader, body, "the identicon should be used if the source is unavailable")
+
+		_, body = get(t, foundHash, "100")
+		assert.Equal(t, pngHeader, body, "the avatar should be fetched again after the source is recovered")
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("Snapped sizes share the cache", func(t *testing.T) {
+		requests.Store(0)
+		for _, size := range []string{"300", "400", "480"} {
+			resp, _ := get(t, foundHash, size)
+			assert.Equal(t, 200, resp.StatusCode)
+		}
+		assert.Equal(t, int32(1), requests.Load(), "the sizes should be snapped to 480")
+
+		files, _ := os.ReadDir(app.Conf().Avatar.CachePath)
+		for _, f := range files {
+			assert.Regexp(t, `_(40|80|160|240|480)$`, f.Name())
+		}
+	})
+
+	t.Run("Invalid hash", func(t *testing.T) {
+		resp, _ := get(t, "not-a-hash", "80")
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("Frontend conf", func(t *testing.T) {
+		resp, err := api.Test(httptest.NewRequest("GET", "/conf", nil))
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.True(t, strings.HasSuffix(gjson.GetBytes(body, "frontend_conf.gravatar.mirror").String(), "/api/v2/avatar/"),
+			"the gravatar mirror should be the avatar proxy")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		avatarService.Dispose()
+		defer avatarService.Init()
+
+		resp, _ := get(t, foundHash, "80")
+		assert.Equal(t, 404, resp.StatusCode)
+	})
+
+	t.Run("Rate limit", func(t *testing.T) {
+		app.Conf().Avatar.RateLimit = 2
+		defer func() { app.Conf().Avatar.RateLimit = 0 }()
+
+		limited := fiber.New()
+		handler.Avatar(app.App, limited)
+		for i := 0; i < 3; i++ {
+			resp, err := limited.Test(httptest.NewRequest("GET", "/avatar/"+foundHash+"?s=80", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, lo.If(i < 2, 200).Else(429), resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/backup"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           GetBackupStatus
+// @Summary      Get Backup Status
+// @Description  Get the status of scheduled backup, including the next run time, the result of last run and the local backups
+// @Tags         Transfer
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  backup.Status
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /backup/status  [get]
+func BackupStatus(app *core.App, router fiber.Router) {
+	router.Get("/backup/status", common.AdminPermGuard(app, entity.AdminPermTransfer, func(c *fiber.Ctx, _ entity.User) error {
+		backupService, err := core.AppService[*backup.Service](app)
+		if err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+
+		return common.RespData(c, backupService.Status())
+	}))
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/anti_spam"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ParamsBanCreate struct {
+	Type      string `json:"type" enums:"ip,cidr,email_domain,asn,country" validate:"required"` // The ban type
+	Value     string `json:"value" validate:"required"`                                         // The IP, CIDR range (e.g. "192.0.2.0/24"), email domain (e.g. "example.com", the subdomains are also matched) or ASN (e.g. "AS13335")
+	Action    string `json:"action" enums:"block,pending" validate:"optional"`                  // Reject the new comments or hold them for review (default: block)
+	Reason    string `json:"reason" validate:"optional"`                                        // The reason of ban for the moderators
+	SiteName  string `json:"site_name" validate:"optional"`                                     // The site which the ban applies to (empty for all sites)
+	ExpiresAt string `json:"expires_at" validate:"optional"`                                    // The expiration time (e.g. "2006-01-02" or RFC3339), never expires if empty
+}
+
+// @Id           CreateBan
+// @Summary      Create Ban
+// @Description  Ban the commenters by IP, CIDR range, email domain or ASN, the ban list is consulted before any anti-spam checker
+// @Tags         Ban
+// @Security     ApiKeyAuth
+// @Param        ban  body  ParamsBanCreate  true  "The ban data"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.CookedBan
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /bans  [post]
+func BanCreate(app *core.App, router fiber.Router) {
+	router.Post("/bans", common.AdminPermGuard(app, entity.AdminPermModerate, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsBanCreate
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if !lo.Contains(anti_spam.BanTypes, p.Type) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "type"}))
+		}
+		value, err := anti_spam.NormalizeBanValue(p.Type, p.Value)
+		if err != nil {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "value"}), Map{"err": err.Error()})
+		}
+
+		if p.Action == "" {
+			p.Action = entity.BanActionBlock
+		}
+		if p.Action != entity.BanActionBlock && p.Action != entity.BanActionPending {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "action"}))
+		}
+
+		// The global bans are only allowed for the admins of all sites
+		p.SiteName = strings.TrimSpace(p.SiteName)
+		if ok, resp := common.CheckAdminSite(c, admin, p.SiteName); !ok {
+			return resp
+		}
+		if p.SiteName != "" {
+			if site := app.Dao().FindSite(p.SiteName); site.IsEmpty() {
+				return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Site")}))
+			}
+		}
+
+		var expiresAt *time.Time
+		if p.ExpiresAt != "" {
+			t, err := parseDateTime(p.ExpiresAt)
+			if err != nil || t.Before(time.Now()) {
+				return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "expires_at"}))
+			}
+			expiresAt = &t
+		}
+
+		ban := entity.Ban{
+			Type:      p.Type,
+			Value:     value,
+			Action:    p.Action,
+			Reason:    strings.TrimSpace(p.Reason),
+			SiteName:  p.SiteName,
+			ExpiresAt: expiresAt,
+			UserID:    admin.ID,
+		}
+		if err := app.Dao().NewBan(&ban); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": "Ban"}))
+		}
+
+		cooked := app.Dao().CookBan(&ban)
+		common.AddAudit(c, common.Audit{Action: "ban.create", TargetType: "ban", TargetID: ban.ID, SiteName: ban.SiteName, After: cooked})
+
+		return common.RespData(c, cooked)
+	}))
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           DeleteBan
+// @Summary      Delete Ban
+// @Description  Lift the ban
+// @Tags         Ban
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The ban ID"
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /bans/{id}  [delete]
+func BanDelete(app *core.App, router fiber.Router) {
+	router.Delete("/bans/:id", common.AdminPermGuard(app, entity.AdminPermModerate, func(c *fiber.Ctx, admin entity.User) error {
+		id, _ := c.ParamsInt("id")
+
+		ban := app.Dao().FindBanByID(uint(id))
+		if ban.IsEmpty() {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": "Ban"}))
+		}
+		if ok, resp := common.CheckAdminSite(c, admin, ban.SiteName); !ok {
+			return resp
+		}
+
+		before := app.Dao().CookBan(&ban)
+
+		if err := app.Dao().DelBan(&ban); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": "Ban"}))
+		}
+
+		common.AddAudit(c, common.Audit{Action: "ban.delete", TargetType: "ban", TargetID: ban.ID, SiteName: ban.SiteName, Before: before})
+
+		return common.RespSuccess(c)
+	}))
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsBanList struct {
+	Type     string `query:"type" json:"type" enums:"ip,cidr,email_domain,asn,country" validate:"optional"` // Filter by the ban type
+	Value    string `query:"value" json:"value" validate:"optional"`                                        // Search by the value
+	SiteName string `query:"site_name" json:"site_name" validate:"optional"`                                // Filter by the site name, all the bans of admin if empty
+	Active   bool   `query:"active" json:"active" validate:"optional"`                                      // Exclude the expired bans
+	Limit    int    `query:"limit" json:"limit" validate:"optional"`                                        // The limit for pagination
+	Offset   int    `query:"offset" json:"offset" validate:"optional"`                                      // The offset for pagination
+}
+
+type ResponseBanList struct {
+	Bans  []entity.CookedBan `json:"bans"`
+	Count int64              `json:"count"`
+}
+
+// @Id           GetBans
+// @Summary      Get Bans
+// @Description  Get the ban list in the latest order
+// @Tags         Ban
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsBanList  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseBanList
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /bans  [get]
+func BanList(app *core.App, router fiber.Router) {
+	router.Get("/bans", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsBanList
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		filter := dao.BansFilter{
+			SiteNames: admin.GetAdminSites(),
+			Type:      p.Type,
+			Value:     p.Value,
+			Active:    p.Active,
+		}
+		if p.SiteName != "" {
+			if ok, resp := common.CheckAdminSite(c, admin, p.SiteName); !ok {
+				return resp
+			}
+			filter.SiteNames = []string{p.SiteName}
+		}
+
+		var count int64
+		app.Dao().BansQuery(filter).Count(&count)
+
+		bans := []entity.Ban{}
+		app.Dao().BansQuery(filter).Order("id DESC").Scopes(Paginate(p.Offset, p.Limit)).Find(&bans)
+
+		cooked := []entity.CookedBan{}
+		for _, ban := range bans {
+			cooked = append(cooked, app.Dao().CookBan(&ban))
+		}
+
+		return common.RespData(c, ResponseBanList{
+			Bans:  cooked,
+			Count: count,
+		})
+	}))
+}
@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/artalkjs/artalk/v2/server/middleware/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestBan(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	api.Use(limiter.ActionLimitMiddleware(app.App, limiter.ActionLimitConf{}))
+	handler.CommentCreate(app.App, api)
+	handler.BanList(app.App, api)
+	handler.BanCreate(app.App, api)
+	handler.BanDelete(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api, map[string]string{"X-Forwarded-For": "10.0.0.1"}) // mock IP
+	create := func(email string, siteName string) (int, gjson.Result) {
+		return request("POST", "/comments", "", `{"name":"userA","email":"`+email+`","content":"hello",`+
+			`"page_key":"/test/1000.html","site_name":"`+siteName+`"}`)
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		code, _ := request("POST", "/bans", token, `{"type":"unknown","value":"x"}`)
+		assert.Equal(t, 400, code)
+		code, _ = request("POST", "/bans", token, `{"type":"cidr","value":"10.0.0.1"}`)
+		assert.Equal(t, 400, code)
+		code, _ = request("POST", "/bans", token, `{"type":"ip","value":"10.0.0.1","expires_at":"2000-01-01"}`)
+		assert.Equal(t, 400, code, "should reject the expired time")
+		code, _ = request("POST", "/bans", token, `{"type":"ip","value":"10.0.0.1","site_name":"Site X"}`)
+		assert.Equal(t, 404, code)
+
+		code, data := request("POST", "/bans", token, `{"type":"email_domain","value":"@Spam.com","reason":"spammer","site_name":"Site A"}`)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "spam.com", data.Get("value").String())
+		assert.Equal(t, "block", data.Get("action").String())
+		assert.False(t, data.Get("is_expired").Bool())
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		code, data := create("a@mail.spam.com", "Site A")
+		assert.Equal(t, 403, code)
+		assert.Equal(t, "comment_banned", data.Get("err_code").String())
+
+		assert.Len(t, app.Dao().FindActiveBans("Site A"), 1)
+		assert.Empty(t, app.Dao().FindActiveBans("Site B"), "should only apply to the site")
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+		code, data := request("POST", "/bans", token, `{"type":"cidr","value":"10.0.0.0/8","expires_at":"`+expiresAt+`"}`)
+		assert.Equal(t, 200, code)
+		id := data.Get("id").String()
+
+		code, data = create("user_a@qwqaq.com", "Site B")
+		assert.Equal(t, 403, code)
+		assert.Equal(t, "comment_banned", data.Get("err_code").String())
+
+		_, data = request("GET", "/bans?active=true", token, "")
+		assert.Equal(t, int64(2), data.Get("count").Int())
+
+		// expire the ban
+		ban := app.Dao().FindBanByID(uint(data.Get("bans.0.id").Int()))
+		past := time.Now().Add(-time.Second)
+		ban.ExpiresAt = &past
+		assert.NoError(t, app.Dao().DB().Save(&ban).Error)
+
+		_, data = request("GET", "/bans?active=true", token, "")
+		assert.Equal(t, int64(1), data.Get("count").Int())
+		_, data = request("GET", "/bans?type=cidr", token, "")
+		assert.True(t, data.Get("bans.0.is_expired").Bool())
+
+		code, _ = request("DELETE", "/bans/"+id, token, "")
+		assert.Equal(t, 200, code)
+		code, _ = request("DELETE", "/bans/"+id, token, "")
+		assert.Equal(t, 404, code)
+	})
+
+	t.Run("Site scope", func(t *testing.T) {
+		admin.AdminRole = "moderator"
+		admin.AdminSites = "Site B"
+		assert.NoError(t, app.Dao().UpdateUser(&admin))
+		defer func() {
+			admin.AdminRole = ""
+			admin.AdminSites = ""
+			app.Dao().UpdateUser(&admin)
+		}()
+
+		code, _ := request("POST", "/bans", token, `{"type":"ip","value":"10.0.0.2"}`)
+		assert.Equal(t, 403, code, "the global ban should require the admin of all sites")
+
+		code, data := request("GET", "/bans", token, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(0), data.Get("count").Int(), "should not list the bans of other sites")
+	})
+}
@@ -1,10 +1,19 @@
 package handler_test
 
 import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
 	"github.com/artalkjs/artalk/v2/test"
 	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 )
 
+// Create the test app with the API routes. Note that the async jobs after a comment
+// is created would outlive the test app, so the comment create tests mostly cover rejected requests.
 func NewApiTestApp() (*test.TestApp, *fiber.App) {
 	app, _ := test.NewTestApp()
 	fiberApp := fiber.New(fiber.Config{
@@ -12,3 +21,27 @@ func NewApiTestApp() (*test.TestApp, *fiber.App) {
 	})
 	return app, fiberApp
 }
+
+// The JSON request to the test API, the status code and the JSON response are returned.
+// The token is sent as the bearer token if not empty.
+type ApiRequest func(method string, url string, token string, body string) (int, gjson.Result)
+
+// Create the JSON request to the test API, the headers are set on each request (e.g. the mock IP by `X-Forwarded-For`)
+func NewApiRequest(t *testing.T, api *fiber.App, headers ...map[string]string) ApiRequest {
+	return func(method string, url string, token string, body string) (int, gjson.Result) {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for _, h := range headers {
+			for k, v := range h {
+				req.Header.Set(k, v)
+			}
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(buf)
+	}
+}
@@ -7,11 +7,18 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type ParamsCacheFlush struct {
+	SiteName string `json:"site_name" validate:"optional"` // Only flush the cache of site (all the pages of site if page_key is empty)
+	PageKey  string `json:"page_key" validate:"optional"`  // Only flush the cache of page (site_name is required)
+}
+
 // @Id           FlushCache
 // @Summary      Flush Cache
-// @Description  Flush all cache on the server
+// @Description  Flush the cache on the server, all the cache is flushed if neither site nor page is specified
 // @Tags         Cache
 // @Security     ApiKeyAuth
+// @Param        options  body  ParamsCacheFlush  false  "The options"
+// @Accept       json
 // @Success      200  {object}  Map{msg=string}
 // @Failure      403  {object}  Map{msg=string}
 // @Failure      400  {object}  Map{msg=string}
@@ -23,9 +30,33 @@ func CacheFlush(app *core.App, router fiber.Router) {
 			return common.RespError(c, 400, "cache disabled")
 		}
 
-		go func() {
+		var p ParamsCacheFlush
+		if len(c.Body()) > 0 {
+			if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+				return resp
+			}
+		}
+
+		switch {
+		case p.PageKey != "":
+			if p.SiteName == "" {
+				return common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": "site_name"}))
+			}
+			app.Dao().CacheFlushPage(p.SiteName, p.PageKey)
+			return common.RespData(c, Map{
+				"msg": i18n.T("Cache flushed"),
+			})
+
+		case p.SiteName != "":
+			app.Dao().CacheFlushSite(p.SiteName)
+			return common.RespData(c, Map{
+				"msg": i18n.T("Cache flushed"),
+			})
+		}
+
+		app.Go(func() {
 			app.Dao().CacheFlushAll()
-		}()
+		})
 
 		return common.RespData(c, Map{
 			"msg": i18n.T("Task executing in background, please wait..."),
@@ -23,9 +23,10 @@ func CacheWarmUp(app *core.App, router fiber.Router) {
 			return common.RespError(c, 400, "cache disabled")
 		}
 
-		go func() {
+		app.Go(func() {
 			app.Dao().CacheWarmUp()
-		}()
+			warmUpCommentListCache(app)
+		})
 
 		return common.RespData(c, Map{
 			"msg": i18n.T("Task executing in background, please wait..."),
@@ -17,5 +17,6 @@ func Captcha(app *core.App, router fiber.Router) {
 		CaptchaGet(app, router)
 		CaptchaStatus(app, router)
 		CaptchaVerify(app, router)
+		CaptchaWidget(app, router)
 	}
 }
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/captcha"
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ResponseCaptchaWidget struct {
+	CaptchaType config.CaptchaType `json:"captcha_type"` // The captcha type
+	Widget      *captcha.Widget    `json:"widget"`       // The widget config of captcha provider (null if not supported, use `GET /captcha` instead)
+}
+
+// @Id           GetCaptchaWidget
+// @Summary      Get Captcha Widget
+// @Description  Get the widget config for rendering the captcha of provider (Turnstile, reCAPTCHA, hCaptcha) in frontend directly, the token is verified by `POST /captcha/verify`
+// @Tags         Captcha
+// @Produce      json
+// @Success      200  {object}  ResponseCaptchaWidget
+// @Router       /captcha/widget  [get]
+func CaptchaWidget(app *core.App, router fiber.Router) {
+	router.Get("/captcha/widget", func(c *fiber.Ctx) error {
+		resp := ResponseCaptchaWidget{
+			CaptchaType: app.Conf().Captcha.CaptchaType,
+		}
+
+		if cap, ok := common.NewCaptchaChecker(app, c).(captcha.WidgetChecker); ok {
+			widget := cap.Widget()
+			resp.Widget = &widget
+		}
+
+		return common.RespData(c, resp)
+	})
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+// The email domain of pseudonymous identities (the `.invalid` TLD is reserved and never delivered)
+const pseudonymEmailDomain = "anonymous.invalid"
+
+// Generate the pseudonymous identity of visitor in anonymous mode
+//
+// The identity is the salted hash of the visitor source (the session ID or IP),
+// so that it is stable for threading and moderation, but the source cannot be recovered from it.
+// The site name is mixed in to make the identities of different sites unlinkable.
+func getPseudonymousIdentity(app *core.App, siteName string, source string) (name string, email string) {
+	hash := utils.GetHmacSha256Hash(app.Conf().AppKey, "anonymous\x00"+siteName+"\x00"+source)
+	return "Anonymous " + strings.ToUpper(hash[:6]), "anonymous-" + hash[:16] + "@" + pseudonymEmailDomain
+}
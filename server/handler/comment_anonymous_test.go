@@ -0,0 +1,44 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentAnonymousMode(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentCreate(app.App, api)
+	handler.SiteUpdate(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+
+	t.Run("Disabled", func(t *testing.T) {
+		code, _ := request("POST", "/comments", "", `{"content":"hello","page_key":"/test/1000.html","site_name":"Site A"}`)
+		assert.Equal(t, 400, code, "should require the name and email if anonymous mode is disabled")
+	})
+
+	t.Run("ToggleBySite", func(t *testing.T) {
+		code, data := request("PUT", "/sites/1000", token, `{"name":"Site A","urls":["http://localhost:8080/"],"anonymous_mode":true}`)
+		assert.Equal(t, 200, code)
+		assert.True(t, data.Get("anonymous_mode").Bool())
+		assert.True(t, app.Dao().FindSite("Site A").AnonymousMode)
+		assert.False(t, app.Dao().FindSite("Site B").AnonymousMode, "should only be enabled for the site")
+	})
+
+	t.Run("PartialIdentity", func(t *testing.T) {
+		code, _ := request("POST", "/comments", "", `{"name":"userA","content":"hello","page_key":"/test/1000.html","site_name":"Site A"}`)
+		assert.Equal(t, 400, code, "should require the email if the name is provided")
+
+		code, _ = request("POST", "/comments", "", `{"content":"hello","page_key":"/site_b/1001.html","site_name":"Site B"}`)
+		assert.Equal(t, 400, code, "should require the name and email on the other sites")
+	})
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/anti_spam"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+// The error code of commenting by the banned commenter
+const errCodeCommentBanned = "comment_banned"
+
+// The moderation checker of the comments held by the ban list
+const banChecker = "ban"
+
+// Find the matched ban rule of the commenter on the site, nil if not banned
+func findCommentBan(app *core.App, siteName string, ip string, email string) *anti_spam.BanRule {
+	antiSpamService, err := core.AppService[*core.AntiSpamService](app)
+	if err != nil {
+		log.Error("[AntiSpamService] err: ", err)
+		return nil
+	}
+	return antiSpamService.MatchBan(siteName, ip, email)
+}
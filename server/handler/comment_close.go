@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+// The error code of commenting on the page which comments are closed
+const errCodeCommentClosed = "comment_closed"
+
+// Get the scheduled time to close the comments of page by the config,
+// false is returned if the page is never closed automatically
+func getPageCommentClosesAt(conf config.CommentCloseConf, page entity.Page) (time.Time, bool) {
+	if page.CommentStatus == entity.PageCommentStatusOpen {
+		return time.Time{}, false
+	}
+
+	var closesAt time.Time
+
+	// Close by the age of page
+	if conf.AfterDays > 0 && (page.PublishedAt != nil || !page.IsEmpty()) {
+		publishedAt := page.CreatedAt
+		if page.PublishedAt != nil {
+			publishedAt = *page.PublishedAt
+		}
+		closesAt = publishedAt.AddDate(0, 0, conf.AfterDays)
+	}
+
+	// Close at the specified time, the earlier one is taken
+	if conf.CloseAt != "" {
+		if t, err := parseDateTime(conf.CloseAt); err != nil {
+			log.Error("[CommentClose] Invalid `comment_close.close_at`: ", err)
+		} else if closesAt.IsZero() || t.Before(closesAt) {
+			closesAt = t
+		}
+	}
+
+	return closesAt, !closesAt.IsZero()
+}
+
+// Check the comments of page are closed, and get the scheduled time to close if not closed yet
+func getPageCommentClose(conf config.CommentCloseConf, page entity.Page) (isClosed bool, closesAt *time.Time) {
+	if page.CommentStatus == entity.PageCommentStatusClosed {
+		return true, nil
+	}
+
+	t, ok := getPageCommentClosesAt(conf, page)
+	if !ok {
+		return false, nil
+	}
+	if !time.Now().Before(t) {
+		return true, nil
+	}
+	return false, &t
+}
+
+// Cook the page with the comment closing status
+func cookPageWithCommentClose(app *core.App, page *entity.Page) entity.CookedPage {
+	cooked := app.Dao().CookPage(page)
+
+	isClosed, closesAt := getPageCommentClose(siteCommentCloseOverrides.resolve(app, page.SiteName), *page)
+	cooked.IsClosed = isClosed
+	if closesAt != nil {
+		cooked.ClosesAt = closesAt.Local().Format(dao.CommonDateTimeFormat)
+	}
+
+	return cooked
+}
@@ -0,0 +1,124 @@
+package handler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/artalkjs/artalk/v2/server/middleware/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentClose(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	api.Use(limiter.ActionLimitMiddleware(app.App, limiter.ActionLimitConf{}))
+	handler.CommentCreate(app.App, api)
+	handler.CommentList(app.App, api)
+	handler.PageUpdate(app.App, api)
+	handler.SiteCommentCloseGet(app.App, api)
+	handler.SiteCommentCloseUpdate(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api, map[string]string{"X-Forwarded-For": "10.0.0.1"}) // mock IP
+	create := func() (int, gjson.Result) {
+		return request("POST", "/comments", "", `{"name":"userA","email":"user_a@qwqaq.com","content":"hello",`+
+			`"page_key":"/test/1000.html","site_name":"Site A"}`)
+	}
+	getPage := func() gjson.Result {
+		code, data := request("GET", "/comments?page_key=/test/1000.html&site_name=Site%20A", "", "")
+		assert.Equal(t, 200, code)
+		return data.Get("page")
+	}
+	updatePage := func(extra string) (int, gjson.Result) {
+		return request("PUT", "/pages/1000", token, `{"site_name":"Site A","key":"/test/1000.html","title":"t","admin_only":false`+extra+`}`)
+	}
+
+	t.Run("ByPageAge", func(t *testing.T) {
+		app.Conf().CommentClose.AfterDays = 30
+		defer func() { app.Conf().CommentClose.AfterDays = 0 }()
+
+		assert.True(t, getPage().Get("is_closed").Bool(), "the page created in 2022 should be closed")
+
+		code, data := create()
+		assert.Equal(t, 403, code)
+		assert.Equal(t, "comment_closed", data.Get("err_code").String())
+
+		// the age is counted from the published date of page
+		publishedAt := time.Now().AddDate(0, 0, -10).Format("2006-01-02")
+		code, data = updatePage(`,"published_at":"` + publishedAt + `"`)
+		assert.Equal(t, 200, code)
+		assert.False(t, data.Get("is_closed").Bool())
+		assert.NotEmpty(t, data.Get("closes_at").String())
+
+		code, _ = updatePage(`,"published_at":"abc"`)
+		assert.Equal(t, 400, code)
+
+		code, _ = updatePage(`,"published_at":""`)
+		assert.Equal(t, 200, code)
+		assert.True(t, getPage().Get("is_closed").Bool())
+	})
+
+	t.Run("PageOverride", func(t *testing.T) {
+		app.Conf().CommentClose.AfterDays = 30
+		defer func() { app.Conf().CommentClose.AfterDays = 0 }()
+
+		code, _ := updatePage(`,"comment_status":"invalid"`)
+		assert.Equal(t, 400, code)
+
+		code, data := updatePage(`,"comment_status":"open"`)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "open", data.Get("comment_status").String())
+		assert.False(t, data.Get("is_closed").Bool())
+
+		// the status is kept if omitted
+		code, data = updatePage("")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "open", data.Get("comment_status").String())
+
+		app.Conf().CommentClose.AfterDays = 0
+		code, data = updatePage(`,"comment_status":"closed"`)
+		assert.Equal(t, 200, code)
+		assert.True(t, data.Get("is_closed").Bool())
+
+		code, data = create()
+		assert.Equal(t, 403, code)
+		assert.Equal(t, "comment_closed", data.Get("err_code").String())
+
+		code, _ = updatePage(`,"comment_status":""`)
+		assert.Equal(t, 200, code)
+		assert.False(t, getPage().Get("is_closed").Bool())
+	})
+
+	t.Run("SiteOverrides", func(t *testing.T) {
+		code, _ := request("PUT", "/sites/1000/comment-close", "", `{"overrides":{"close_at":"2020-01-01"}}`)
+		assert.Equal(t, 403, code)
+
+		code, _ = request("PUT", "/sites/1000/comment-close", token, `{"overrides":{"close_at":"abc"}}`)
+		assert.Equal(t, 400, code)
+
+		code, data := request("PUT", "/sites/1000/comment-close", token, `{"overrides":{"close_at":"2020-01-01"}}`)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "2020-01-01", data.Get("effective.close_at").String())
+
+		code, data = request("GET", "/sites/1000/comment-close", token, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "2020-01-01", data.Get("overrides.close_at").String())
+
+		assert.True(t, getPage().Get("is_closed").Bool())
+		code, data = create()
+		assert.Equal(t, 403, code)
+		assert.Equal(t, "comment_closed", data.Get("err_code").String())
+
+		// reset to the global config
+		code, _ = request("PUT", "/sites/1000/comment-close", token, `{"overrides":{}}`)
+		assert.Equal(t, 200, code)
+		assert.False(t, getPage().Get("is_closed").Bool())
+	})
+}
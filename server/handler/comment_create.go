@@ -2,21 +2,27 @@ package handler
 
 import (
 	"cmp"
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/artalkjs/artalk/v2/internal/anti_spam"
 	"github.com/artalkjs/artalk/v2/internal/core"
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/i18n"
 	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/tracing"
 	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type ParamsCommentCreate struct {
-	Name    string `json:"name" validate:"required"`    // The comment name
-	Email   string `json:"email" validate:"required"`   // The comment email
+	Name    string `json:"name" validate:"optional"`    // The comment name (optional in the anonymous mode of site)
+	Email   string `json:"email" validate:"optional"`   // The comment email (optional in the anonymous mode of site)
 	Link    string `json:"link" validate:"optional"`    // The comment link
 	Content string `json:"content" validate:"required"` // The comment content
 	Rid     uint   `json:"rid" validate:"optional"`     // The comment rid
@@ -26,10 +32,14 @@ type ParamsCommentCreate struct {
 	PageTitle string `json:"page_title" validate:"optional"` // The comment page_title
 
 	SiteName string `json:"site_name" validate:"required"` // The site name of your content scope
+
+	AnonymousSession string `json:"anonymous_session" validate:"optional"` // The random session ID of visitor to keep the pseudonymous identity stable in anonymous mode (default: by IP)
 }
 
 type ResponseCommentCreate struct {
 	entity.CookedComment
+	EditToken     string     `json:"edit_token,omitempty"`      // The token to edit or delete the comment by the guest (only if the guest editing is enabled)
+	EditExpiresAt *time.Time `json:"edit_expires_at,omitempty"` // The expiration time of the edit token
 }
 
 // @Id           CreateComment
@@ -51,123 +61,242 @@ func CommentCreate(app *core.App, router fiber.Router) {
 			return resp
 		}
 
-		if !utils.ValidateEmail(p.Email) {
-			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": i18n.T("Email")}))
-		}
-		if p.Link != "" && !utils.ValidateURL(p.Link) {
-			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": i18n.T("Link")}))
+		cookedComment, ok, resp := createComment(app, c, p)
+		if !ok {
+			return resp
 		}
 
-		if _, ok, resp := common.CheckSiteExist(app, c, p.SiteName); !ok {
-			return resp
+		data := ResponseCommentCreate{
+			CookedComment: cookedComment,
 		}
 
-		// Prepare the arguments for creating comment
-		var (
-			ip         = c.IP()
-			ua         = cmp.Or(p.UA, string(c.Request().Header.UserAgent())) // allows the patched UA from the post data
-			referer    = cmp.Or(c.Get("Referer"), c.Get("Origin"))
-			isAdmin    = common.CheckIsAdminReq(app, c)
-			isVerified = true // for display the verified badge
-		)
-
-		// Find or create page
-		page := app.Dao().FindCreatePage(p.PageKey, p.PageTitle, p.SiteName)
-		if page.Key == "" {
-			log.Error("[CommentCreate] FindCreatePage error")
-			return common.RespError(c, 500, i18n.T("Comment failed"))
+		// The guest can edit the comment within the time window by the token
+		if _, err := common.GetUserByReq(app, c); errors.Is(err, common.ErrTokenNotProvided) && app.Conf().GuestEdit.Enabled {
+			token, expiresAt := getCommentEditToken(app, app.Dao().FindComment(cookedComment.ID))
+			data.EditToken = token
+			data.EditExpiresAt = &expiresAt
 		}
 
-		// Check the page and the user is allowed to comment (admin only check)
-		if isAllowed, resp := isAllowComment(app, c, p.Name, p.Email, page.AdminOnly); !isAllowed {
-			return resp
+		return common.RespData(c, data)
+	}))
+}
+
+// Create the comment by the params, the error response is sent if not ok
+func createComment(app *core.App, c *fiber.Ctx, p ParamsCommentCreate) (entity.CookedComment, bool, error) {
+	site, ok, resp := common.CheckSiteExist(app, c, p.SiteName)
+	if !ok {
+		return entity.CookedComment{}, false, resp
+	}
+
+	// Generate the pseudonymous identity if the name and email are omitted in anonymous mode
+	isPseudonym := site.AnonymousMode && p.Name == "" && p.Email == ""
+	if isPseudonym {
+		p.Name, p.Email = getPseudonymousIdentity(app, site.Name, cmp.Or(p.AnonymousSession, c.IP()))
+	} else if p.Name == "" {
+		return entity.CookedComment{}, false, common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": i18n.T("Nickname")}))
+	} else if !utils.ValidateEmail(p.Email) {
+		return entity.CookedComment{}, false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": i18n.T("Email")}))
+	}
+	if p.Link != "" && !utils.ValidateURL(p.Link) {
+		return entity.CookedComment{}, false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": i18n.T("Link")}))
+	}
+
+	// The dao in the context of request, the queries are traced in the span of request
+	reqDao := app.Dao().WithContext(c.UserContext())
+
+	// Prepare the arguments for creating comment
+	var (
+		ip         = c.IP()
+		storedIP   = app.AnonymizeIP(ip)                                  // the real IP is only used for anti-spam and IP region lookup
+		ua         = cmp.Or(p.UA, string(c.Request().Header.UserAgent())) // allows the patched UA from the post data
+		referer    = cmp.Or(c.Get("Referer"), c.Get("Origin"))
+		isAdmin    = common.CheckIsAdminReq(app, c)
+		isAPIKey   = common.CheckAPIKeyScope(c, entity.APIKeyScopeCommentsWrite) // the trusted integration
+		isVerified = true                                                        // for display the verified badge
+	)
+
+	// Check the comment limits of site (the admins and the trusted integrations are not limited)
+	isLimited := !isAdmin && !isAPIKey
+	limitConf := siteCommentLimitOverrides.resolve(app, site.Name)
+	if isLimited {
+		if ok, resp := checkCommentContentLimit(c, limitConf, p.Content); !ok {
+			return entity.CookedComment{}, false, resp
 		}
+	}
 
-		// Check parent comment (reply a comment)
-		var parentComment entity.Comment
-		if p.Rid != 0 {
-			parentComment = app.Dao().FindComment(p.Rid)
-			if parentComment.IsEmpty() {
-				return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Parent comment")}))
-			}
-			if parentComment.PageKey != p.PageKey {
-				return common.RespError(c, 400, "Inconsistent with the page_key of the parent comment")
-			}
-			if !parentComment.IsAllowReply() {
-				return common.RespError(c, 400, i18n.T("Cannot reply to this comment"))
-			}
+	// Find or create page
+	page := findCreatePage(app, p.PageKey, p.PageTitle, p.SiteName)
+	if page.Key == "" {
+		log.Ctx(c.UserContext()).Error("[CommentCreate] FindCreatePage error")
+		return entity.CookedComment{}, false, common.RespError(c, 500, i18n.T("Comment failed"))
+	}
+
+	// Check the page and the user is allowed to comment (admin only check)
+	if isAllowed, resp := isAllowComment(app, c, p.Name, p.Email, page.AdminOnly); !isAllowed {
+		return entity.CookedComment{}, false, resp
+	}
+
+	// Check the comments of page are not closed (the admins and the trusted integrations are not limited)
+	if isLimited {
+		if isClosed, _ := getPageCommentClose(siteCommentCloseOverrides.resolve(app, site.Name), page); isClosed {
+			return entity.CookedComment{}, false, common.RespError(c, 403, i18n.T("Comments are closed"), Map{"err_code": errCodeCommentClosed})
 		}
+	}
 
-		// Get the user data
-		user, err := common.GetUserByReq(app, c) // if token is provided and a login user
-		if errors.Is(err, common.ErrTokenNotProvided) {
-			// Anonymous user
-			isVerified = false
-			if user, err = getUpdateAnonymousUser(app, p.Name, p.Email, p.Link, ip, ua); err != nil {
-				return common.RespError(c, 500, err.Error())
-			}
-		} else if err != nil {
-			// Login user error
-			log.Error("[CommentCreate] Get user error: ", err)
-			return common.RespError(c, 500, i18n.T("Comment failed"))
+	// Check parent comment (reply a comment)
+	var parentComment entity.Comment
+	if p.Rid != 0 {
+		parentComment = reqDao.FindComment(p.Rid)
+		if parentComment.IsEmpty() {
+			return entity.CookedComment{}, false, common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Parent comment")}))
+		}
+		if parentComment.PageKey != p.PageKey {
+			return entity.CookedComment{}, false, common.RespError(c, 400, "Inconsistent with the page_key of the parent comment")
 		}
+		if !parentComment.IsAllowReply() {
+			return entity.CookedComment{}, false, common.RespError(c, 400, i18n.T("Cannot reply to this comment"))
+		}
+	}
 
-		// Create new comment entity
-		comment := entity.Comment{
-			Content:  p.Content,
-			PageKey:  page.Key,
-			SiteName: p.SiteName,
+	// Get the user data
+	user, err := common.GetUserByReq(app, c) // if token is provided and a login user
+	if errors.Is(err, common.ErrTokenNotProvided) {
+		// Anonymous user is not allowed if the site requires login
+		if site.AuthRequired && !isAPIKey {
+			return entity.CookedComment{}, false, common.RespError(c, 401, i18n.T("Login required"), Map{"need_auth_login": true})
+		}
 
-			UserID: user.ID,
-			IP:     ip,
-			UA:     ua,
+		// Anonymous user
+		isVerified = false
+		if user, err = getUpdateAnonymousUser(app, p.Name, p.Email, p.Link, storedIP, ua); err != nil {
+			return entity.CookedComment{}, false, common.RespError(c, 500, err.Error())
+		}
 
-			Rid:    p.Rid,
-			RootID: app.Dao().FindCommentRootID(p.Rid),
+		// The pseudonymous email cannot receive any email
+		if isPseudonym && user.ReceiveEmail {
+			user.ReceiveEmail = false
+			reqDao.UpdateUser(&user)
+		}
+	} else if err != nil {
+		// Login user error
+		log.Ctx(c.UserContext()).Error("[CommentCreate] Get user error: ", err)
+		return entity.CookedComment{}, false, common.RespError(c, 500, i18n.T("Comment failed"))
+	}
 
-			IsPending:   false,
-			IsCollapsed: false,
-			IsPinned:    false,
-			IsVerified:  isVerified,
+	// Check the interval since the latest comment
+	if isLimited {
+		if ok, resp := checkCommentCooldown(app, c, limitConf, site.Name, user.ID, storedIP); !ok {
+			return entity.CookedComment{}, false, resp
 		}
+	}
 
-		// Set the default pending status
-		// (if not admin and the `PendingDefault` is enabled)
-		if !isAdmin && app.Conf().Moderator.PendingDefault {
-			comment.IsPending = true
+	// Check the ban list of admin (the admins and the trusted integrations are not banned)
+	var ban *anti_spam.BanRule
+	if isLimited {
+		if ban = findCommentBan(app, site.Name, ip, user.Email); ban != nil && !ban.Pending {
+			return entity.CookedComment{}, false, common.RespError(c, 403, i18n.T("You are banned from commenting"), Map{"err_code": errCodeCommentBanned})
 		}
+	}
+
+	// Create new comment entity
+	comment := entity.Comment{
+		Content:  p.Content,
+		PageKey:  page.Key,
+		SiteName: p.SiteName,
+
+		UserID: user.ID,
+		IP:     storedIP,
+		UA:     ua,
 
-		// Save the comment
-		if err := app.Dao().CreateComment(&comment); err != nil {
-			log.Error("Save Comment error: ", err)
-			return common.RespError(c, 500, i18n.T("Comment failed"))
+		Rid:    p.Rid,
+		RootID: reqDao.FindCommentRootID(p.Rid),
+
+		IsPending:   false,
+		IsCollapsed: false,
+		IsPinned:    false,
+		IsVerified:  isVerified,
+	}
+
+	// Set the default pending status
+	// (if not admin and the `PendingDefault` is enabled)
+	if !isAdmin && app.Conf().Moderator.PendingDefault {
+		comment.IsPending = true
+	}
+
+	// Set to pending until the async moderation is finished
+	// (if not admin and the async moderation is enabled)
+	if !isAdmin && app.Conf().Moderator.Async.Enabled {
+		comment.IsPending = true
+	}
+
+	// Set to pending until the email is verified by the magic link
+	// (if anonymous user and the email verification is enabled)
+	needEmailVerify := !isAdmin && !isAPIKey && !isVerified && !isPseudonym && isNeedEmailVerify(app, &user)
+	if needEmailVerify {
+		comment.IsPending = true
+		comment.ModerationChecker = emailVerifyChecker
+		comment.ModerationReason = "email not verified"
+	}
+
+	// Hold the comment for review if the commenter is banned by the pending rule
+	if ban != nil {
+		comment.IsPending = true
+		comment.ModerationChecker = banChecker
+		comment.ModerationReason = ban.Describe()
+		comment.ModerationConfidence = 1
+	}
+
+	// Store the geolocation of IP, which is kept when the IP database is updated
+	comment.IPRegion = lookupIPRegionForComment(app, ip)
+
+	// Save the comment
+	if err := reqDao.CreateComment(&comment); err != nil {
+		log.Ctx(c.UserContext()).Error("Save Comment error: ", err)
+		return entity.CookedComment{}, false, common.RespError(c, 500, i18n.T("Comment failed"))
+	}
+
+	// Send the email verification link
+	if needEmailVerify {
+		if err := sendEmailVerifyLink(app, &user); err != nil {
+			log.Ctx(c.UserContext()).Error("[CommentCreate] Send email verify link error: ", err)
 		}
+	}
 
-		// Async jobs after comment created
-		go commentCreatedJobs(app, comment, parentComment, commentCreatedJobsArguments{
-			ip, ua, referer, isAdmin, isVerified, page,
+	// Async jobs after comment created
+	// (the fiber ctx is released after the response, so the trace is detached from it in advance)
+	jobsCtx := tracing.Detach(c.UserContext())
+	app.Go(func() {
+		commentCreatedJobs(app, comment, parentComment, commentCreatedJobsArguments{
+			ip, ua, referer, isAdmin, isVerified, page, jobsCtx,
 		})
+	})
 
-		// Response the comment data
-		cookedComment := app.Dao().CookComment(&comment)
-		cookedComment = fetchIPRegionForComment(app, cookedComment)
+	// Response the comment data
+	cookedComment := reqDao.CookComment(&comment)
+	cookedComment = fetchIPRegionForComment(app, c, cookedComment)
 
-		return common.RespData(c, ResponseCommentCreate{
-			CookedComment: cookedComment,
-		})
-	}))
+	return cookedComment, true, nil
 }
 
 // Fetch IP Region for Comment
-func fetchIPRegionForComment(app *core.App, comment entity.CookedComment) entity.CookedComment {
-	if app.Conf().IPRegion.Enabled {
-		if ipRegionService, err := core.AppService[*core.IPRegionService](app); err == nil {
-			comment.IPRegion = ipRegionService.Query(comment.IP)
-		} else {
-			log.Error("[IPRegionService] err: ", err)
-		}
+func fetchIPRegionForComment(app *core.App, c *fiber.Ctx, comment entity.CookedComment) entity.CookedComment {
+	return findIPRegionForComments(app, c, []entity.CookedComment{comment})[0]
+}
+
+// Find the geolocation of IP to store in the comment, empty if the IP region is disabled or not found
+func lookupIPRegionForComment(app *core.App, ip string) string {
+	if !app.Conf().IPRegion.Enabled {
+		return ""
+	}
+
+	ipRegionService, err := core.AppService[*core.IPRegionService](app)
+	if err != nil {
+		log.Error("[IPRegionService] err: ", err)
+		return ""
 	}
-	return comment
+
+	region, _ := ipRegionService.Lookup(ip)
+	return region.String()
 }
 
 func isAllowComment(app *core.App, c *fiber.Ctx, name string, email string, pageAdminOnly bool) (bool, error) {
@@ -232,6 +361,7 @@ type commentCreatedJobsArguments struct {
 	IsAdmin    bool
 	IsVerified bool
 	Page       entity.Page
+	Context    context.Context // the context of request to continue the trace
 }
 
 // The jobs after comment created (should call in async)
@@ -240,33 +370,85 @@ type commentCreatedJobsArguments struct {
 //  1. Page Update
 //  2. AntiSpam Check
 //  3. Send Notify (email, webhook, telegram, etc.)
+//
+// If the async moderation is enabled, the comment will be published
+// and the notify will be sent after the check in the moderation queue is finished.
 func commentCreatedJobs(app *core.App, comment entity.Comment, parentComment entity.Comment, args commentCreatedJobsArguments) {
+	ctx, span := tracing.Start(args.Context, "comment.created_jobs", attribute.Int64("artalk.comment.id", int64(comment.ID)))
+	defer span.End()
+
 	// Page Update (if the original page title is empty and the URL is given)
 	if app.Dao().CookPage(&args.Page).URL != "" && args.Page.Title == "" {
-		go app.Dao().FetchPageFromURL(&args.Page)
+		app.Go(func() { app.Dao().FetchPageFromURL(&args.Page) })
+	}
+
+	// Publish the comment events and send the notifications,
+	// the comments of shadow banned user are still checked by anti-spam but never trigger them
+	isShadowBanned := app.Dao().FetchUserForComment(&comment).IsShadowBanned
+	notify := func(isSpam bool) {
+		if isShadowBanned {
+			return
+		}
+		if !comment.IsPending {
+			publishCommentEvent(app, core.RealtimeCommentCreated, comment)
+		}
+		triggerCommentWebhook(app, webhook.EventCommentCreated, comment)
+		if isSpam {
+			triggerCommentWebhook(app, webhook.EventCommentSpam, comment)
+		}
+		pushCommentNotify(ctx, app, &comment, &parentComment)
 	}
 
 	// AntiSpam Check
+	isSpam := false
 	if !args.IsAdmin { // if the user is an admin, skip the anti-spam check
 		if antiSpamService, err := core.AppService[*core.AntiSpamService](app); err == nil {
-			// The check and block is sync, if comment is blocked, the message will not be sent
-			antiSpamService.CheckAndBlock(&core.AntiSpamCheckPayload{
+			payload := &core.AntiSpamCheckPayload{
 				Comment:      &comment,
 				ReqReferer:   args.Referer,
 				ReqIP:        args.IP,
 				ReqUserAgent: args.UA,
-			})
+				Context:      ctx,
+			}
+
+			if antiSpamService.IsAsync() {
+				antiSpamService.AsyncCheckAndBlock(payload, func(pass bool) {
+					comment = app.Dao().FindComment(comment.ID) // reload the latest status
+
+					// Publish the comment if passed
+					// (keep pending if `PendingDefault` is enabled or the email is not verified)
+					if pass && comment.IsPending && !app.Conf().Moderator.PendingDefault && comment.ModerationChecker != emailVerifyChecker {
+						comment.IsPending = false
+						if err := app.Dao().UpdateComment(&comment); err != nil {
+							log.Ctx(ctx).Error("[AntiSpamService] publish comment err: ", err)
+						}
+					}
+
+					notify(!pass)
+				})
+				return
+			}
+
+			// The check and block is sync, if comment is blocked, the message will not be sent
+			if pass := antiSpamService.CheckAndBlock(payload); !pass {
+				comment = app.Dao().FindComment(comment.ID) // reload the blocked status
+				isSpam = true
+			}
 		} else {
-			log.Error("[AntiSpamService] err: ", err)
+			log.Ctx(ctx).Error("[AntiSpamService] err: ", err)
 		}
 	}
 
-	// Send Notify (email, webhook, telegram, etc.)
+	notify(isSpam)
+}
+
+// Send Notify (email, webhook, telegram, etc.)
+func pushCommentNotify(ctx context.Context, app *core.App, comment *entity.Comment, parentComment *entity.Comment) {
 	if notifyService, err := core.AppService[*core.NotifyService](app); err == nil {
-		if err := notifyService.Push(&comment, &parentComment); err != nil {
-			log.Error("[NotifyService] notify push err: ", err)
+		if err := notifyService.PushWithContext(ctx, comment, parentComment); err != nil {
+			log.Ctx(ctx).Error("[NotifyService] notify push err: ", err)
 		}
 	} else {
-		log.Error("[NotifyService] err: ", err)
+		log.Ctx(ctx).Error("[NotifyService] err: ", err)
 	}
 }
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/i18n"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
@@ -9,7 +10,7 @@ import (
 
 // @Id           DeleteComment
 // @Summary      Delete Comment
-// @Description  Delete a specific comment
+// @Description  Delete a specific comment, the comment is kept as a tombstone if the soft delete is enabled
 // @Tags         Comment
 // @Param        id       path  int               true  "The comment ID you want to delete"
 // @Security     ApiKeyAuth
@@ -20,7 +21,7 @@ import (
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /comments/{id}  [delete]
 func CommentDelete(app *core.App, router fiber.Router) {
-	router.Delete("/comments/:id", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Delete("/comments/:id", common.AdminPermGuard(app, entity.AdminPermModerate, func(c *fiber.Ctx, admin entity.User) error {
 		id, _ := c.ParamsInt("id")
 
 		// find comment
@@ -28,17 +29,57 @@ func CommentDelete(app *core.App, router fiber.Router) {
 		if comment.IsEmpty() {
 			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
 		}
+		if ok, resp := common.CheckAdminSite(c, admin, comment.SiteName); !ok {
+			return resp
+		}
+
+		addCommentAudit(c, "comment.delete", &comment, nil)
+		return deleteComment(app, c, comment)
+	}))
+}
+
+// Delete the comment and respond the result
+func deleteComment(app *core.App, c *fiber.Ctx, comment entity.Comment) error {
+	if err := removeComment(app, comment); err != nil {
+		return common.RespError(c, 500, err.Error())
+	}
+	return common.RespSuccess(c)
+}
+
+// @Id           RestoreComment
+// @Summary      Restore Comment
+// @Description  Restore a soft deleted comment
+// @Tags         Comment
+// @Param        id       path  int               true  "The comment ID you want to restore"
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  entity.CookedComment
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /comments/{id}/restore  [post]
+func CommentRestore(app *core.App, router fiber.Router) {
+	router.Post("/comments/:id/restore", common.AdminPermGuard(app, entity.AdminPermModerate, func(c *fiber.Ctx, admin entity.User) error {
+		id, _ := c.ParamsInt("id")
+
+		comment := app.Dao().FindDeletedComment(uint(id))
+		if comment.IsEmpty() {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
+		}
+		if ok, resp := common.CheckAdminSite(c, admin, comment.SiteName); !ok {
+			return resp
+		}
 
-		// 删除主评论
-		if err := app.Dao().DelComment(&comment); err != nil {
-			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": i18n.T("Comment")}))
+		if err := app.Dao().RestoreComment(&comment); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Comment")}))
 		}
 
-		// 删除子评论
-		if err := app.Dao().DelCommentChildren(comment.ID); err != nil {
-			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": i18n.T("Sub-comment")}))
+		if !comment.IsPending {
+			publishCommentEvent(app, core.RealtimeCommentCreated, comment)
 		}
 
-		return common.RespSuccess(c)
+		addCommentAudit(c, "comment.restore", nil, &comment)
+
+		return common.RespData(c, app.Dao().CookComment(&comment))
 	}))
 }
@@ -0,0 +1,79 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentSoftDelete(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	app.Conf().SoftDelete.Enabled = true
+
+	handler.CommentList(app.App, api)
+	handler.CommentReplies(app.App, api)
+	handler.CommentDelete(app.App, api)
+	handler.CommentRestore(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+	list := func() gjson.Result {
+		code, data := request("GET", "/comments?site_name=Site+A&page_key=/test/1000.html&limit=10", "", "")
+		assert.Equal(t, 200, code)
+		return data
+	}
+
+	t.Run("DeleteKeepsTombstone", func(t *testing.T) {
+		code, _ := request("DELETE", "/comments/1000", token, "")
+		assert.Equal(t, 200, code)
+
+		assert.True(t, app.Dao().FindComment(1000).IsEmpty(), "should be soft deleted")
+		assert.False(t, app.Dao().FindComment(1001).IsEmpty(), "should keep the replies")
+
+		data := list()
+		assert.Equal(t, int64(6), data.Get("comments.#").Int(), "should list the tombstone with replies")
+		tombstone := data.Get("comments.#(id==1000)")
+		assert.True(t, tombstone.Get("is_deleted").Bool())
+		assert.Empty(t, tombstone.Get("content").String(), "should hide the content of tombstone")
+		assert.Empty(t, tombstone.Get("nick").String(), "should hide the author of tombstone")
+
+		code, data = request("GET", "/comments/1000/replies?limit=10", "", "")
+		assert.Equal(t, 200, code, "should load the replies of tombstone")
+		assert.Equal(t, int64(4), data.Get("count").Int())
+	})
+
+	t.Run("DeleteLeafHidden", func(t *testing.T) {
+		code, _ := request("DELETE", "/comments/1005", token, "")
+		assert.Equal(t, 200, code)
+
+		data := list()
+		assert.False(t, data.Get("comments.#(id==1005)").Exists(), "should not list the deleted comment without replies")
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		code, data := request("POST", "/comments/1000/restore", token, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(1000), data.Get("id").Int())
+		assert.False(t, data.Get("is_deleted").Bool())
+		assert.NotEmpty(t, data.Get("content").String())
+
+		assert.False(t, app.Dao().FindComment(1000).IsEmpty(), "should be restored")
+		assert.False(t, list().Get("comments.#(id==1000).is_deleted").Exists())
+
+		code, _ = request("POST", "/comments/1000/restore", token, "")
+		assert.Equal(t, 404, code, "should not restore the comment not deleted")
+	})
+
+	t.Run("RestoreWithoutPerm", func(t *testing.T) {
+		code, _ := request("POST", "/comments/1005/restore", "", "")
+		assert.Equal(t, 403, code)
+	})
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	emailVerifyProvider = "email_link"   // The auth identity provider of the email verification link
+	emailVerifyChecker  = "email_verify" // The moderation checker of the comments pending for email verification
+
+	defaultEmailVerifyExpires = 24 * 60 * 60 // 24 hours
+)
+
+// Check the email of anonymous user is required to verify
+// (the email sending should be enabled, otherwise the link cannot be received)
+func isNeedEmailVerify(app *core.App, user *entity.User) bool {
+	return app.Conf().Moderator.EmailVerify.Enabled && app.Conf().Email.Enabled &&
+		!user.IsEmailVerified && !user.IsAdmin
+}
+
+var errEmailVerifyServerURLRequired = errors.New("config `moderator.email_verify.server_url` is not set, which is required to build the verification link")
+
+// Send the email verification link to user
+//
+// The link is sent only once until it is expired, to avoid sending the email for every comment.
+// The link is built from the configured server URL rather than the Host header of request,
+// otherwise the token could be sent to the forged host.
+func sendEmailVerifyLink(app *core.App, user *entity.User) error {
+	identity := app.Dao().FindAuthIdentityByUserID(emailVerifyProvider, user.ID)
+	if !identity.IsEmpty() && identity.Token != "" && identity.ExpiresAt != nil && identity.ExpiresAt.After(time.Now()) {
+		return nil // the link is sent and still valid
+	}
+
+	conf := app.Conf().Moderator.EmailVerify
+	serverURL := strings.TrimSuffix(strings.TrimSpace(conf.ServerURL), "/")
+	if serverURL == "" {
+		return errEmailVerifyServerURLRequired
+	}
+
+	expiresAt := time.Now().Add(time.Duration(cmp.Or(conf.Expires, defaultEmailVerifyExpires)) * time.Second)
+
+	identity.Provider = emailVerifyProvider
+	identity.RemoteUID = user.Email
+	identity.UserID = user.ID
+	identity.Token = utils.RandomString(32)
+	identity.ExpiresAt = &expiresAt
+	identity.ConfirmedAt = nil
+
+	var err error
+	if identity.IsEmpty() {
+		err = app.Dao().CreateAuthIdentity(&identity)
+	} else {
+		err = app.Dao().UpdateAuthIdentity(&identity)
+	}
+	if err != nil {
+		return err
+	}
+
+	emailService, err := core.AppService[*core.EmailService](app)
+	if err != nil {
+		return err
+	}
+
+	tplParams := map[string]any{
+		"name": user.Name,
+		"link": serverURL + "/api/v2/auth/email/verify_link?token=" + identity.Token,
+	}
+	emailSubject := cmp.Or(utils.RenderMustaches(conf.MailSubject, tplParams), i18n.T("Verify your email"))
+	emailBody := i18n.T("Hi {{name}}, please open the link to verify your email, and your comments will be published after verified: {{link}}", tplParams)
+
+	log.Debug("Send email verify link to: ", user.Email)
+	emailService.AsyncSendTo(emailSubject, emailBody, user.Email)
+
+	return nil
+}
+
+// @Id           VerifyEmailLink
+// @Summary      Verify email by link
+// @Description  Verify the email ownership of commenter by the link in the verification email, and publish the comments pending for verification
+// @Tags         Auth
+// @Param        token  query  string  true  "The token in the verification link"
+// @Success      302
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Produce      json
+// @Router       /auth/email/verify_link  [get]
+func AuthEmailVerifyLink(app *core.App, router fiber.Router) {
+	router.Get("/auth/email/verify_link", func(c *fiber.Ctx) error {
+		token := strings.TrimSpace(c.Query("token"))
+		if token == "" {
+			return common.RespError(c, 400, i18n.T("Invalid verify link"))
+		}
+
+		identity := app.Dao().FindAuthIdentityByToken(emailVerifyProvider, token)
+		if identity.IsEmpty() {
+			return common.RespError(c, 400, i18n.T("Invalid verify link"))
+		}
+		if identity.ExpiresAt != nil && identity.ExpiresAt.Before(time.Now()) {
+			return common.RespError(c, 400, i18n.T("Verify link expired"))
+		}
+
+		user := app.Dao().FindUserByID(identity.UserID)
+		if user.IsEmpty() || !strings.EqualFold(user.Email, identity.RemoteUID) {
+			return common.RespError(c, 400, i18n.T("Invalid verify link"))
+		}
+
+		// Mark the email as verified
+		user.IsEmailVerified = true
+		if err := app.Dao().UpdateUser(&user); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("User")}))
+		}
+
+		// Revoke the link
+		now := time.Now()
+		identity.Token = ""
+		identity.ConfirmedAt = &now
+		if err := app.Dao().UpdateAuthIdentity(&identity); err != nil {
+			log.Error("[EmailVerify] Failed to revoke the link: ", err)
+		}
+
+		// Publish the comments which are pending for email verification
+		// (the comments held by the anti-spam checkers are kept pending)
+		var comments []entity.Comment
+		app.Dao().DB().Where("user_id = ? AND is_pending = ? AND moderation_checker = ?", user.ID, true, emailVerifyChecker).
+			Order("created_at DESC").Find(&comments)
+		for i := range comments {
+			comments[i].IsPending = app.Conf().Moderator.PendingDefault
+			comments[i].ModerationChecker = ""
+			comments[i].ModerationReason = ""
+			if err := app.Dao().UpdateComment(&comments[i]); err != nil {
+				log.Error("[EmailVerify] Failed to publish comment: ", err)
+			} else if !comments[i].IsPending {
+				publishCommentEvent(app, core.RealtimeCommentCreated, comments[i])
+				triggerCommentWebhook(app, webhook.EventCommentApproved, comments[i])
+			}
+		}
+
+		// Redirect to the latest comment
+		if len(comments) > 0 {
+			if pageURL := app.Dao().CookComment(&comments[0]).PageURL; pageURL != "" {
+				return c.Redirect(fmt.Sprintf("%s#atk-comment-%d", pageURL, comments[0].ID))
+			}
+		}
+
+		return common.RespSuccess(c)
+	})
+}
@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthEmailVerifyLink(t *testing.T) {
+	app, fiber := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.AuthEmailVerifyLink(app.App, fiber)
+
+	user := app.Dao().FindUserByID(1002)
+	expiresAt := time.Now().Add(time.Hour)
+	identity := entity.AuthIdentity{Provider: "email_link", RemoteUID: user.Email, UserID: user.ID, Token: "valid_token", ExpiresAt: &expiresAt}
+	assert.NoError(t, app.Dao().CreateAuthIdentity(&identity))
+
+	expiredAt := time.Now().Add(-time.Hour)
+	expired := entity.AuthIdentity{Provider: "email_link", RemoteUID: user.Email, UserID: user.ID, Token: "expired_token", ExpiresAt: &expiredAt}
+	assert.NoError(t, app.Dao().CreateAuthIdentity(&expired))
+
+	// the comment pending for email verification
+	comment := app.Dao().FindComment(1007)
+	comment.ModerationChecker = "email_verify"
+	assert.NoError(t, app.Dao().UpdateComment(&comment))
+
+	get := func(url string) int {
+		resp, err := fiber.Test(httptest.NewRequest("GET", url, nil))
+		assert.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, 400, get("/auth/email/verify_link"))
+	assert.Equal(t, 400, get("/auth/email/verify_link?token=invalid_token"))
+	assert.Equal(t, 400, get("/auth/email/verify_link?token=expired_token"))
+	assert.False(t, app.Dao().FindUserByID(1002).IsEmailVerified)
+
+	assert.Equal(t, 302, get("/auth/email/verify_link?token=valid_token"), "should redirect to the comment")
+	assert.True(t, app.Dao().FindUserByID(1002).IsEmailVerified)
+
+	comment = app.Dao().FindComment(1007)
+	assert.False(t, comment.IsPending, "should publish the comment after verified")
+	assert.Empty(t, comment.ModerationChecker)
+
+	assert.Equal(t, 400, get("/auth/email/verify_link?token=valid_token"), "should not be reused")
+}
+
+func TestCommentEmailVerifySend(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentCreate(app.App, api)
+
+	// the SMTP server is unreachable, the emails are kept in the queue
+	app.Conf().Email.Enabled = true
+	app.Conf().Email.SendType = config.TypeSMTP
+	app.Conf().Email.SMTP = config.SMTPConf{Host: "127.0.0.1", Port: 1}
+	app.Conf().Moderator.EmailVerify.Enabled = true
+
+	create := func(email string) int {
+		req := httptest.NewRequest("POST", "/comments", strings.NewReader(`{"name":"visitor","email":"`+email+
+			`","content":"Hello","page_key":"/test/1000.html","site_name":"Site A"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Host = "evil.example.com" // forged host
+		req.Header.Set("X-Forwarded-Host", "evil.example.com")
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		app.WaitTasks(5 * time.Second)
+		return resp.StatusCode
+	}
+	findBody := func(email string) string {
+		tasks, _ := app.Dao().FindEmailTasks("", 0, 100)
+		for _, task := range tasks {
+			if task.ToAddr == email {
+				return task.Body
+			}
+		}
+		return ""
+	}
+
+	t.Run("ServerURLRequired", func(t *testing.T) {
+		assert.Equal(t, 200, create("visitor_a@example.com"))
+		assert.Empty(t, findBody("visitor_a@example.com"), "should not send the link without the server URL")
+	})
+
+	t.Run("ForgedHost", func(t *testing.T) {
+		app.Conf().Moderator.EmailVerify.ServerURL = "https://artalk.example.com/"
+		assert.Equal(t, 200, create("visitor_b@example.com"))
+
+		body := findBody("visitor_b@example.com")
+		assert.Contains(t, body, "https://artalk.example.com/api/v2/auth/email/verify_link?token=")
+		assert.NotContains(t, body, "evil.example.com", "the link should not be built from the request host")
+	})
+}
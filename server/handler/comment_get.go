@@ -30,7 +30,8 @@ func CommentGet(app *core.App, router fiber.Router) {
 
 		// Find comment by id
 		comment := app.Dao().FindComment(uint(id))
-		if comment.IsEmpty() {
+		viewer, _ := common.GetUserByReq(app, c)
+		if comment.IsEmpty() || isShadowHiddenComment(app, comment, viewer, app.AnonymizeIP(c.IP())) {
 			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
 		}
 
@@ -41,12 +42,20 @@ func CommentGet(app *core.App, router fiber.Router) {
 			if !comment.IsEmpty() {
 				rComment := app.Dao().CookComment(&rComment)
 				rComment.Visible = false
+				rComment.Moderation = nil
 				replyComment = &rComment
 			}
 		}
 
 		cookedComment := app.Dao().CookComment(&comment)
-		cookedComment = fetchIPRegionForComment(app, cookedComment)
+		cookedComment = fetchIPRegionForComment(app, c, cookedComment)
+
+		// Moderation result is only visible to admin
+		if admin, err := common.GetAdminByReq(app, c); (err != nil || !admin.CanAdminSite(comment.SiteName)) &&
+			!common.CheckAPIKeyScope(c, entity.APIKeyScopeCommentsRead) {
+			cookedComment.Moderation = nil
+			cookedComment = hideEditedForComments(app, []entity.CookedComment{cookedComment})[0]
+		}
 
 		return common.RespData(c, ResponseCommentGet{
 			Comment:      cookedComment,
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The default time window of guest editing
+const guestEditDefaultWindow = 5 * time.Minute
+
+type ParamsCommentGuestUpdate struct {
+	EditToken string `json:"edit_token" validate:"required"` // The edit token returned at the comment creation
+	Content   string `json:"content" validate:"required"`    // The new comment content
+}
+
+type ResponseCommentGuestUpdate struct {
+	entity.CookedComment
+}
+
+// @Id           GuestUpdateComment
+// @Summary      Update Comment by Guest
+// @Description  Update the content of own comment by the unauthenticated commenter, with the edit token returned at creation, within the time window of `guest_edit.window`
+// @Tags         Comment
+// @Param        id       path  int                       true  "The comment ID you want to update"
+// @Param        comment  body  ParamsCommentGuestUpdate  true  "The comment data"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseCommentGuestUpdate
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /comments/{id}/guest-edit  [put]
+func CommentGuestUpdate(app *core.App, router fiber.Router) {
+	router.Put("/comments/:id/guest-edit", common.LimiterGuard(app, func(c *fiber.Ctx) error {
+		var p ParamsCommentGuestUpdate
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		id, _ := c.ParamsInt("id")
+		comment, ok, resp := findGuestEditComment(app, c, uint(id), p.EditToken)
+		if !ok {
+			return resp
+		}
+		previous := comment
+
+		if ok, resp := checkCommentContentLimit(c, siteCommentLimitOverrides.resolve(app, comment.SiteName), p.Content); !ok {
+			return resp
+		}
+
+		isContentModified := p.Content != comment.Content
+		if isContentModified {
+			now := time.Now()
+			comment.Content = p.Content
+			comment.EditedAt = &now
+		}
+
+		// 访客编辑后始终重新审核，通过前保持待审状态 (开启编辑后待审时需人工审核)
+		editConf := app.Conf().Moderator.Edit
+		isRecheck := isContentModified
+		if isRecheck {
+			comment.IsPending = true
+		}
+
+		if err := app.Dao().UpdateComment(&comment); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Comment")}))
+		}
+
+		if isContentModified {
+			app.Dao().NewCommentRevision(comment.ID, previous.Content, comment.UserID)
+		}
+
+		if isRecheck {
+			recheckEditedComment(app, &comment, func(passed *entity.Comment) {
+				if editConf.Enabled && editConf.Pending {
+					return
+				}
+				passed.IsPending = previous.IsPending
+				app.Dao().UpdateComment(passed)
+			})
+		}
+
+		publishCommentChangedEvents(app, previous, comment)
+
+		cookedComment := app.Dao().CookComment(&comment)
+		cookedComment = fetchIPRegionForComment(app, c, cookedComment)
+		cookedComment.Moderation = nil
+
+		return common.RespData(c, ResponseCommentGuestUpdate{
+			CookedComment: cookedComment,
+		})
+	}))
+}
+
+type ParamsCommentGuestDelete struct {
+	EditToken string `query:"edit_token" json:"edit_token" validate:"required"` // The edit token returned at the comment creation
+}
+
+// @Id           GuestDeleteComment
+// @Summary      Delete Comment by Guest
+// @Description  Delete own comment by the unauthenticated commenter, with the edit token returned at creation, within the time window of `guest_edit.window`
+// @Tags         Comment
+// @Param        id       path   int                       true  "The comment ID you want to delete"
+// @Param        options  query  ParamsCommentGuestDelete  true  "The options"
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /comments/{id}/guest-edit  [delete]
+func CommentGuestDelete(app *core.App, router fiber.Router) {
+	router.Delete("/comments/:id/guest-edit", common.LimiterGuard(app, func(c *fiber.Ctx) error {
+		var p ParamsCommentGuestDelete
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		id, _ := c.ParamsInt("id")
+		comment, ok, resp := findGuestEditComment(app, c, uint(id), p.EditToken)
+		if !ok {
+			return resp
+		}
+
+		// The replies of others are not deleted by the guest
+		if !app.Conf().SoftDelete.Enabled && len(app.Dao().FindCommentChildrenShallow(comment.ID)) > 0 {
+			return common.RespError(c, 400, i18n.T("Cannot delete the comment with replies"))
+		}
+
+		return deleteComment(app, c, comment)
+	}))
+}
+
+// Find the comment to edit by guest, the edit token and the time window are checked
+func findGuestEditComment(app *core.App, c *fiber.Ctx, id uint, token string) (entity.Comment, bool, error) {
+	if !app.Conf().GuestEdit.Enabled {
+		return entity.Comment{}, false, common.RespError(c, 404, "guest edit disabled")
+	}
+
+	comment := app.Dao().FindComment(id)
+	if comment.IsEmpty() {
+		return comment, false, common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
+	}
+
+	expiresAt, ok := parseCommentEditToken(app, comment.ID, token)
+	if !ok {
+		return comment, false, common.RespError(c, 403, i18n.T("Invalid {{name}}", Map{"name": "edit_token"}))
+	}
+	if time.Now().After(expiresAt) || time.Since(comment.CreatedAt) > getGuestEditWindow(app) {
+		return comment, false, common.RespError(c, 403, i18n.T("The time to edit the comment has expired"))
+	}
+
+	return comment, true, nil
+}
+
+func getGuestEditWindow(app *core.App) time.Duration {
+	if app.Conf().GuestEdit.Window <= 0 {
+		return guestEditDefaultWindow
+	}
+	return time.Duration(app.Conf().GuestEdit.Window) * time.Second
+}
+
+// Get the edit token of comment, which is in the format of `<expires_at>.<signature>`
+func getCommentEditToken(app *core.App, comment entity.Comment) (string, time.Time) {
+	expiresAt := comment.CreatedAt.Add(getGuestEditWindow(app))
+	exp := strconv.FormatInt(expiresAt.Unix(), 10)
+	return exp + "." + signCommentEditToken(app, comment.ID, exp), expiresAt
+}
+
+func parseCommentEditToken(app *core.App, commentID uint, token string) (time.Time, bool) {
+	exp, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return time.Time{}, false
+	}
+	expected := signCommentEditToken(app, commentID, exp)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) != 1 {
+		return time.Time{}, false
+	}
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(unix, 0), true
+}
+
+func signCommentEditToken(app *core.App, commentID uint, exp string) string {
+	return utils.GetHmacSha256Hash(app.Conf().AppKey, "edit\x00"+strconv.FormatUint(uint64(commentID), 10)+"\x00"+exp)
+}
@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/artalkjs/artalk/v2/server/middleware/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentGuestEdit(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	api.Use(limiter.ActionLimitMiddleware(app.App, limiter.ActionLimitConf{}))
+	handler.CommentGuestUpdate(app.App, api)
+	handler.CommentGuestDelete(app.App, api)
+
+	request := NewApiRequest(t, api)
+	// The edit token is in the format of `<expires_at>.<signature>`
+	getToken := func(id uint, expiresAt time.Time) string {
+		exp := strconv.FormatInt(expiresAt.Unix(), 10)
+		return exp + "." + utils.GetHmacSha256Hash(app.Conf().AppKey, fmt.Sprintf("edit\x00%d\x00%s", id, exp))
+	}
+	createComment := func(rid uint) entity.Comment {
+		comment := entity.Comment{Content: "typo", PageKey: "/test/1000.html", SiteName: "Site A", UserID: 1002, Rid: rid}
+		assert.NoError(t, app.Dao().CreateComment(&comment))
+		return comment
+	}
+	update := func(id uint, token string, content string) (int, gjson.Result) {
+		return request("PUT", fmt.Sprintf("/comments/%d/guest-edit", id), "", `{"edit_token":"`+token+`","content":"`+content+`"}`)
+	}
+
+	comment := createComment(0)
+	token := getToken(comment.ID, time.Now().Add(5*time.Minute))
+
+	code, _ := update(comment.ID, token, "fixed")
+	assert.Equal(t, 404, code, "should be disabled by default")
+
+	app.Conf().GuestEdit.Enabled = true
+	app.Conf().GuestEdit.Window = 300
+
+	t.Run("Update", func(t *testing.T) {
+		code, _ := update(comment.ID, "123.invalid", "fixed")
+		assert.Equal(t, 403, code)
+
+		code, _ = update(comment.ID+1, token, "fixed")
+		assert.NotEqual(t, 200, code, "should not be valid for other comments")
+
+		code, data := update(comment.ID, token, "fixed")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "fixed", data.Get("content").String())
+		assert.NotEmpty(t, data.Get("edited_at").String())
+		assert.Equal(t, "fixed", app.Dao().FindComment(comment.ID).Content)
+		assert.Len(t, app.Dao().FindCommentRevisions(comment.ID), 1, "should keep the previous version")
+	})
+
+	t.Run("Recheck", func(t *testing.T) {
+		edit := func(content string) (entity.Comment, gjson.Result) {
+			comment := createComment(0)
+			code, data := update(comment.ID, getToken(comment.ID, time.Now().Add(5*time.Minute)), content)
+			assert.Equal(t, 200, code)
+			return app.Dao().FindComment(comment.ID), data
+		}
+
+		comment, data := edit("passed")
+		assert.False(t, data.Get("is_pending").Bool(), "should be released after the check passes")
+		assert.False(t, comment.IsPending)
+
+		ban := entity.Ban{Type: "email_domain", Value: "qwqaq.com", Action: entity.BanActionBlock, SiteName: "Site A"}
+		assert.NoError(t, app.Dao().NewBan(&ban))
+		comment, data = edit("blocked")
+		assert.True(t, data.Get("is_pending").Bool(), "should keep pending if blocked by the checker")
+		assert.True(t, comment.IsPending)
+		assert.Equal(t, "ban", comment.ModerationChecker)
+		assert.NoError(t, app.Dao().DelBan(&ban))
+
+		app.Conf().Moderator.Edit.Enabled = true
+		app.Conf().Moderator.Edit.Pending = true
+		defer func() { app.Conf().Moderator.Edit = config.ModeratorEditConf{} }()
+		comment, _ = edit("manual review")
+		assert.True(t, comment.IsPending, "should keep pending for the manual review")
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		code, _ := update(comment.ID, getToken(comment.ID, time.Now().Add(-time.Second)), "fixed again")
+		assert.Equal(t, 403, code, "should reject the expired token")
+
+		code, _ = update(1005, getToken(1005, time.Now().Add(5*time.Minute)), "fixed")
+		assert.Equal(t, 403, code, "should reject the comment out of the window")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		reply := createComment(comment.ID)
+
+		code, _ := request("DELETE", fmt.Sprintf("/comments/%d/guest-edit?edit_token=%s", comment.ID, token), "", "")
+		assert.Equal(t, 400, code, "should not delete the comment with replies")
+
+		code, _ = request("DELETE", fmt.Sprintf("/comments/%d/guest-edit?edit_token=%s", reply.ID, token), "", "")
+		assert.Equal(t, 403, code)
+
+		code, _ = request("DELETE", fmt.Sprintf("/comments/%d/guest-edit?edit_token=%s", reply.ID, getToken(reply.ID, time.Now().Add(time.Minute))), "", "")
+		assert.Equal(t, 200, code)
+		assert.True(t, app.Dao().FindComment(reply.ID).IsEmpty())
+	})
+}
@@ -0,0 +1,342 @@
+package handler
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/subtle"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/server/common"
+	cog "github.com/artalkjs/artalk/v2/server/handler/comments_get"
+	"github.com/gofiber/fiber/v2"
+)
+
+//go:embed pages/comments.html
+var commentHTMLPage embed.FS
+
+var commentHTMLTemplate = template.Must(template.ParseFS(commentHTMLPage, "pages/comments.html"))
+
+const (
+	commentHTMLPageSize   = 20         // The number of root comments per page
+	commentHTMLCSRFCookie = "atk_csrf" // The cookie of the random CSRF secret
+)
+
+type ParamsCommentHTML struct {
+	PageKey   string `query:"page_key" validate:"required"`   // The page key
+	PageTitle string `query:"page_title" validate:"optional"` // The page title (used when the page is created by the first comment)
+	SiteName  string `query:"site_name" validate:"optional"`  // The site name (default: the `site_default` of config)
+	Offset    int    `query:"offset" validate:"optional"`     // The offset of the root comments
+	Rid       uint   `query:"rid" validate:"optional"`        // The comment ID to reply
+}
+
+type commentHTMLView struct {
+	Title     string
+	PageURL   string
+	PageKey   string
+	PageTitle string
+	SiteName  string
+	Site      bool
+	Error     string
+	Notice    string
+
+	Threads        []commentHTMLThread
+	Count          int64
+	PrevURL        string
+	NextURL        string
+	ReplyTo        *commentHTMLItem
+	CancelReplyURL string
+
+	AnonymousMode bool
+	Closed        bool
+	CSRFToken     string
+	Form          ParamsCommentCreate
+}
+
+type commentHTMLThread struct {
+	Root    commentHTMLItem
+	Replies []commentHTMLItem
+}
+
+type commentHTMLItem struct {
+	entity.CookedComment
+	Content     template.HTML // The sanitized HTML of content
+	ReplyToNick string
+	ReplyURL    string
+}
+
+// @Id           GetCommentsHTML
+// @Summary      Get Comments HTML
+// @Description  Get the server-rendered HTML of page comments with a comment form, which is the fallback for the readers without JavaScript
+// @Tags         Comment
+// @Param        options  query  ParamsCommentHTML  true  "The options"
+// @Produce      html
+// @Success      200  {string}  string
+// @Failure      400  {string}  string
+// @Failure      404  {string}  string
+// @Router       /comments  [get]
+func CommentHTMLView(app *core.App, router fiber.Router) {
+	router.Get("/comments", func(c *fiber.Ctx) error {
+		var p ParamsCommentHTML
+		if err := c.QueryParser(&p); err != nil {
+			return renderCommentHTML(app, c, 400, commentHTMLView{Title: "Comments", Error: err.Error()}, p)
+		}
+		if p.PageKey == "" {
+			return renderCommentHTML(app, c, 400, commentHTMLView{
+				Title: "Comments",
+				Error: i18n.T("{{name}} cannot be empty", Map{"name": "page_key"}),
+			}, p)
+		}
+
+		return renderCommentHTML(app, c, 200, commentHTMLView{Notice: getCommentHTMLNotice(c)}, p)
+	})
+}
+
+// @Id           CreateCommentHTML
+// @Summary      Create Comment by HTML Form
+// @Description  Create a new comment by the HTML form of `GET /comments`, then redirect back to the comments page
+// @Tags         Comment
+// @Param        comment  formData  ParamsCommentCreate  true  "The comment data (with the `csrf_token` of form)"
+// @Accept       x-www-form-urlencoded
+// @Produce      html
+// @Success      303  {string}  string
+// @Failure      400  {string}  string
+// @Failure      403  {string}  string
+// @Router       /comments  [post]
+func CommentHTMLSubmit(app *core.App, router fiber.Router) {
+	router.Post("/comments", func(c *fiber.Ctx) error {
+		rid, _ := strconv.ParseUint(c.FormValue("rid"), 10, 64)
+		form := ParamsCommentCreate{
+			Name:      strings.TrimSpace(c.FormValue("name")),
+			Email:     strings.TrimSpace(c.FormValue("email")),
+			Link:      strings.TrimSpace(c.FormValue("link")),
+			Content:   c.FormValue("content"),
+			Rid:       uint(rid),
+			PageKey:   c.FormValue("page_key"),
+			PageTitle: c.FormValue("page_title"),
+			SiteName:  c.FormValue("site_name"),
+		}
+		p := ParamsCommentHTML{
+			PageKey:   form.PageKey,
+			PageTitle: form.PageTitle,
+			SiteName:  form.SiteName,
+			Rid:       form.Rid,
+		}
+		renderError := func(code int, msg string) error {
+			return renderCommentHTML(app, c, code, commentHTMLView{Error: msg, Form: form}, p)
+		}
+
+		if !checkCommentHTMLCSRFToken(app, c, c.FormValue("csrf_token")) {
+			return renderError(403, i18n.T("Invalid {{name}}", Map{"name": "CSRF token"}))
+		}
+		if form.PageKey == "" {
+			return renderError(400, i18n.T("{{name}} cannot be empty", Map{"name": "page_key"}))
+		}
+		if strings.TrimSpace(form.Content) == "" {
+			return renderError(400, i18n.T("{{name}} cannot be empty", Map{"name": i18n.T("Comment")}))
+		}
+		form.SiteName = getCommentHTMLSiteName(app, form.SiteName)
+
+		// The captcha is not able to be solved without JavaScript
+		limiter, err := common.GetLimiter(c)
+		if err != nil {
+			return err
+		}
+		ip := c.IP()
+		needCaptcha := app.Conf().Captcha.Enabled && !common.CheckIsAdminReq(app, c) && !limiter.IsPass(ip)
+		if needCaptcha {
+			return renderError(403, i18n.T("Captcha required"))
+		}
+		limiter.Log(ip)
+
+		comment, ok, _ := createComment(app, c, form)
+		if !ok {
+			// The error response of JSON is replaced by the HTML page
+			var resp struct {
+				Msg string `json:"msg"`
+			}
+			_ = json.Unmarshal(c.Response().Body(), &resp)
+			return renderError(c.Response().StatusCode(), resp.Msg)
+		}
+
+		query := getCommentHTMLQuery(form.PageKey, form.SiteName, form.PageTitle, 0, 0)
+		if comment.IsPending {
+			query.Set("pending", "1")
+		}
+		return c.Redirect(fmt.Sprintf("comments?%s#atk-comment-%d", query.Encode(), comment.ID), fiber.StatusSeeOther)
+	})
+}
+
+func renderCommentHTML(app *core.App, c *fiber.Ctx, code int, view commentHTMLView, p ParamsCommentHTML) error {
+	view.PageKey = p.PageKey
+	view.PageTitle = p.PageTitle
+	view.SiteName = getCommentHTMLSiteName(app, p.SiteName)
+	view.CSRFToken = getCommentHTMLCSRFToken(app, c)
+	if view.Title == "" {
+		view.Title = view.PageKey
+	}
+
+	site := app.Dao().FindSite(view.SiteName)
+	if view.PageKey != "" && site.IsEmpty() {
+		code = 404
+		view.Error = i18n.T("Site `{{name}}` not found. Please create it in control center.", Map{"name": view.SiteName})
+	} else if view.PageKey != "" {
+		view.Site = true
+		view.AnonymousMode = site.AnonymousMode
+
+		page := app.Dao().FindPage(view.PageKey, view.SiteName)
+		if !page.IsEmpty() {
+			cookedPage := app.Dao().CookPage(&page)
+			view.Title = cmp.Or(cookedPage.Title, view.Title)
+			view.PageURL = cookedPage.URL
+			view.Closed, _ = getPageCommentClose(siteCommentCloseOverrides.resolve(app, page.SiteName), page)
+		}
+
+		findCommentHTMLThreads(app, &view, p, app.AnonymizeIP(c.IP()))
+	}
+
+	var buf bytes.Buffer
+	if err := commentHTMLTemplate.Execute(&buf, view); err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-cache, no-store, must-revalidate")
+	c.Set(fiber.HeaderContentType, "text/html; charset=utf-8")
+	c.Set(fiber.HeaderContentSecurityPolicy, "default-src 'none'; img-src * data:; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+	c.Set(fiber.HeaderXFrameOptions, "DENY")
+	return c.Status(code).Send(buf.Bytes())
+}
+
+// Find the root comments of page by pagination, and group the replies into the threads
+func findCommentHTMLThreads(app *core.App, view *commentHTMLView, p ParamsCommentHTML, ip string) {
+	comments, count, rootsCount := cog.FindComments(app.Dao(), cog.QueryOptions{
+		IP:    ip,
+		Scope: cog.ScopePage,
+		PagePayload: cog.PageScopePayload{
+			PageKey:  view.PageKey,
+			SiteName: view.SiteName,
+		},
+		SortBy: cog.SortByDateAsc,
+	}, cog.FindOptions{
+		Nested: true,
+		Offset: max(p.Offset, 0),
+		Limit:  commentHTMLPageSize,
+	})
+
+	parents := map[uint]entity.CookedComment{}
+	for _, comment := range comments {
+		parents[comment.ID] = comment
+	}
+	rootOf := func(comment entity.CookedComment) uint {
+		for comment.Rid != 0 {
+			parent, ok := parents[comment.Rid]
+			if !ok {
+				break
+			}
+			comment = parent
+		}
+		return comment.ID
+	}
+
+	threadIndex := map[uint]int{}
+	for _, comment := range comments {
+		item := commentHTMLItem{
+			CookedComment: comment,
+			Content:       template.HTML(comment.ContentMarked), // The content is sanitized when cooked
+			ReplyToNick:   parents[comment.Rid].Nick,
+		}
+		if comment.IsAllowReply && !comment.IsDeleted {
+			item.ReplyURL = "comments?" + getCommentHTMLQuery(view.PageKey, view.SiteName, view.PageTitle, p.Offset, comment.ID).Encode() + "#atk-form"
+		}
+		if comment.ID == p.Rid && item.ReplyURL != "" {
+			replyTo := item
+			view.ReplyTo = &replyTo
+		}
+
+		if comment.Rid == 0 {
+			threadIndex[comment.ID] = len(view.Threads)
+			view.Threads = append(view.Threads, commentHTMLThread{Root: item})
+		} else if i, ok := threadIndex[rootOf(comment)]; ok {
+			view.Threads[i].Replies = append(view.Threads[i].Replies, item)
+		}
+	}
+
+	// The replies is not sorted in the nested query
+	for _, t := range view.Threads {
+		slices.SortFunc(t.Replies, func(a, b commentHTMLItem) int { return cmp.Compare(a.ID, b.ID) })
+	}
+
+	view.Count = count
+	if p.Offset > 0 {
+		view.PrevURL = "comments?" + getCommentHTMLQuery(view.PageKey, view.SiteName, view.PageTitle, max(p.Offset-commentHTMLPageSize, 0), 0).Encode()
+	}
+	if int64(p.Offset+commentHTMLPageSize) < rootsCount {
+		view.NextURL = "comments?" + getCommentHTMLQuery(view.PageKey, view.SiteName, view.PageTitle, p.Offset+commentHTMLPageSize, 0).Encode()
+	}
+	if p.Rid != 0 && view.ReplyTo != nil {
+		view.CancelReplyURL = "comments?" + getCommentHTMLQuery(view.PageKey, view.SiteName, view.PageTitle, p.Offset, 0).Encode() + "#atk-form"
+	}
+}
+
+func getCommentHTMLSiteName(app *core.App, siteName string) string {
+	return cmp.Or(strings.TrimSpace(siteName), app.Conf().SiteDefault)
+}
+
+func getCommentHTMLQuery(pageKey string, siteName string, pageTitle string, offset int, rid uint) url.Values {
+	query := url.Values{}
+	query.Set("page_key", pageKey)
+	query.Set("site_name", siteName)
+	if pageTitle != "" {
+		query.Set("page_title", pageTitle)
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+	if rid != 0 {
+		query.Set("rid", strconv.FormatUint(uint64(rid), 10))
+	}
+	return query
+}
+
+func getCommentHTMLNotice(c *fiber.Ctx) string {
+	if c.Query("pending") != "" {
+		return "Your comment has been submitted and is awaiting moderation."
+	}
+	return ""
+}
+
+// Get the CSRF token of form, which is signed from the random secret in cookie (the double submit cookie)
+func getCommentHTMLCSRFToken(app *core.App, c *fiber.Ctx) string {
+	secret := c.Cookies(commentHTMLCSRFCookie)
+	if len(secret) != 32 {
+		secret = utils.RandomString(32)
+		c.Cookie(&fiber.Cookie{
+			Name:     commentHTMLCSRFCookie,
+			Value:    secret,
+			Path:     "/",
+			HTTPOnly: true,
+			Secure:   c.Protocol() == "https",
+			SameSite: fiber.CookieSameSiteLaxMode,
+		})
+	}
+	return utils.GetHmacSha256Hash(app.Conf().AppKey, "csrf\x00"+secret)
+}
+
+func checkCommentHTMLCSRFToken(app *core.App, c *fiber.Ctx, token string) bool {
+	secret := c.Cookies(commentHTMLCSRFCookie)
+	if len(secret) != 32 || token == "" {
+		return false
+	}
+	expected := utils.GetHmacSha256Hash(app.Conf().AppKey, "csrf\x00"+secret)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1
+}
@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/artalkjs/artalk/v2/server/middleware/limiter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentHTML(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	api.Use(limiter.ActionLimitMiddleware(app.App, limiter.ActionLimitConf{}))
+	handler.CommentHTMLView(app.App, api)
+	handler.CommentHTMLSubmit(app.App, api)
+
+	request := func(req *http.Request) (int, string, *http.Response) {
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(buf), resp
+	}
+	csrfTokenRegexp := regexp.MustCompile(`name="csrf_token" value="([0-9a-f]+)"`)
+	getForm := func() (*http.Cookie, string) {
+		_, body, resp := request(httptest.NewRequest("GET", "/comments?site_name=Site+A&page_key=/test/1000.html", nil))
+		m := csrfTokenRegexp.FindStringSubmatch(body)
+		if !assert.NotNil(t, m, "should render the csrf token") || !assert.NotEmpty(t, resp.Cookies()) {
+			t.FailNow()
+		}
+		return resp.Cookies()[0], m[1]
+	}
+	submit := func(cookie *http.Cookie, form url.Values) (int, string) {
+		req := httptest.NewRequest("POST", "/comments", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		code, body, _ := request(req)
+		return code, body
+	}
+
+	t.Run("View", func(t *testing.T) {
+		code, body, resp := request(httptest.NewRequest("GET", "/comments?site_name=Site+A&page_key=/test/1000.html", nil))
+		assert.Equal(t, 200, code)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+		assert.Contains(t, resp.Header.Get("Content-Security-Policy"), "form-action 'self'")
+		for _, id := range []string{"1000", "1001", "1004", "1005"} {
+			assert.Contains(t, body, `id="atk-comment-`+id+`"`)
+		}
+		assert.NotContains(t, body, `id="atk-comment-1006"`, "should not contain the comments of other pages")
+		assert.Contains(t, body, `<form method="post" action="comments">`)
+		assert.NotContains(t, body, `name="rid"`)
+	})
+
+	t.Run("ViewReplyForm", func(t *testing.T) {
+		code, body, _ := request(httptest.NewRequest("GET", "/comments?site_name=Site+A&page_key=/test/1000.html&rid=1000", nil))
+		assert.Equal(t, 200, code)
+		assert.Contains(t, body, `<input type="hidden" name="rid" value="1000" />`)
+	})
+
+	t.Run("ViewInvalid", func(t *testing.T) {
+		code, _, _ := request(httptest.NewRequest("GET", "/comments?site_name=Site+A", nil))
+		assert.Equal(t, 400, code, "should require the page_key")
+
+		code, body, _ := request(httptest.NewRequest("GET", "/comments?site_name=Unknown&page_key=/test/1000.html", nil))
+		assert.Equal(t, 404, code)
+		assert.NotContains(t, body, "<form", "should not render the form of unknown site")
+	})
+
+	form := url.Values{
+		"site_name": {"Site A"},
+		"page_key":  {"/test/1000.html"},
+		"name":      {"userC"},
+		"email":     {"userc@example.com"},
+		"content":   {"Hello without JavaScript"},
+	}
+
+	t.Run("SubmitWithoutCSRF", func(t *testing.T) {
+		code, body := submit(nil, form)
+		assert.Equal(t, 403, code)
+		assert.Contains(t, body, "CSRF token")
+
+		cookie, _ := getForm()
+		forged := url.Values{"csrf_token": {strings.Repeat("0", 64)}}
+		for k, v := range form {
+			forged[k] = v
+		}
+		code, _ = submit(cookie, forged)
+		assert.Equal(t, 403, code, "should reject the token not signed from the cookie")
+
+		var count int64
+		app.Dao().DB().Table("comments").Where("content = ?", form.Get("content")).Count(&count)
+		assert.Zero(t, count, "should not create the comment")
+	})
+
+	t.Run("SubmitInvalid", func(t *testing.T) {
+		cookie, token := getForm()
+		invalid := url.Values{"csrf_token": {token}}
+		for k, v := range form {
+			invalid[k] = v
+		}
+		invalid.Set("email", "invalid")
+
+		code, body := submit(cookie, invalid)
+		assert.Equal(t, 400, code)
+		assert.Contains(t, body, `class="atk-error"`, "should render the error in HTML")
+		assert.Contains(t, body, "Hello without JavaScript", "should keep the form input")
+	})
+}
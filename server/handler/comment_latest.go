@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ParamsCommentLatest struct {
+	SiteName string `query:"site_name" json:"site_name" validate:"required"` // The site name of your content scope
+	Limit    int    `query:"limit" json:"limit" validate:"optional"`         // The number of comments (default: 5, max: 100)
+}
+
+type LatestComment struct {
+	entity.CookedComment
+	PageTitle string `json:"page_title"` // The title of the page commented on
+}
+
+type ResponseCommentLatest struct {
+	Comments []LatestComment `json:"comments"`
+}
+
+// @Id           GetLatestComments
+// @Summary      Get Latest Comments
+// @Description  Get the latest public comments across a site, for rendering the recent comments widget
+// @Tags         Comment
+// @Param        options  query  ParamsCommentLatest  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseCommentLatest
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /comments/latest  [get]
+func CommentLatest(app *core.App, router fiber.Router) {
+	router.Get("/comments/latest", func(c *fiber.Ctx) error {
+		var p ParamsCommentLatest
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if _, ok, resp := common.CheckSiteExist(app, c, p.SiteName); !ok {
+			return resp
+		}
+
+		if p.Limit <= 0 {
+			p.Limit = 5
+		}
+		if p.Limit > 100 {
+			p.Limit = 100
+		}
+
+		var comments []*entity.Comment
+		app.Dao().DB().Model(&entity.Comment{}).
+			Where("site_name = ?", p.SiteName).
+			Scopes(queryPublicComments(app)).
+			Order("created_at DESC").
+			Limit(p.Limit).
+			Find(&comments)
+
+		cooked := hideEditedForComments(app, hideModerationForComments(app.Dao().CookAllComments(comments)))
+		latest := lo.Map(comments, func(comment *entity.Comment, i int) LatestComment {
+			return LatestComment{
+				CookedComment: cooked[i],
+				PageTitle:     app.Dao().FetchPageForComment(comment).Title,
+			}
+		})
+
+		return common.RespData(c, ResponseCommentLatest{
+			Comments: latest,
+		})
+	})
+}
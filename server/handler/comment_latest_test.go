@@ -0,0 +1,60 @@
+package handler_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentLatest(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentLatest(app.App, api)
+
+	get := func(query string) (int, gjson.Result) {
+		resp, err := api.Test(httptest.NewRequest("GET", "/comments/latest?"+query, nil))
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(body)
+	}
+
+	t.Run("Latest comments of site", func(t *testing.T) {
+		var expected []uint
+		app.Dao().DB().Model(&entity.Comment{}).Where("site_name = ? AND is_pending = ?", "Site A", false).
+			Order("created_at DESC").Limit(3).Pluck("id", &expected)
+
+		code, data := get("site_name=Site%20A&limit=3")
+		assert.Equal(t, 200, code)
+
+		comments := data.Get("comments").Array()
+		assert.Len(t, comments, 3)
+		for i, c := range comments {
+			assert.Equal(t, int64(expected[i]), c.Get("id").Int())
+			assert.Equal(t, "Site A", c.Get("site_name").String())
+			assert.NotEmpty(t, c.Get("page_title").String())
+		}
+	})
+
+	t.Run("Pending comments are hidden", func(t *testing.T) {
+		_, data := get("site_name=Site%20B")
+		ids := []int64{}
+		for _, c := range data.Get("comments").Array() {
+			ids = append(ids, c.Get("id").Int())
+		}
+		assert.Equal(t, []int64{1006}, ids)
+	})
+
+	t.Run("Invalid params", func(t *testing.T) {
+		code, _ := get("")
+		assert.Equal(t, 400, code)
+
+		code, _ = get("site_name=Not%20Exist")
+		assert.Equal(t, 404, code)
+	})
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/artalkjs/artalk/v2/internal/anti_spam"
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The error codes of comment limits, for the frontend to display the message
+const (
+	errCodeCommentTooShort     = "comment_too_short"
+	errCodeCommentTooLong      = "comment_too_long"
+	errCodeCommentTooManyLinks = "comment_too_many_links"
+	errCodeCommentCooldown     = "comment_cooldown"
+)
+
+// Check the length and the number of links of comment content
+func checkCommentContentLimit(c *fiber.Ctx, conf config.CommentLimitConf, content string) (bool, error) {
+	length := utf8.RuneCountInString(strings.TrimSpace(content))
+	if conf.MinLength > 0 && length < conf.MinLength {
+		return false, common.RespError(c, 400, i18n.T("Comment is too short (at least {{count}} characters)", Map{"count": conf.MinLength}),
+			Map{"err_code": errCodeCommentTooShort, "min_length": conf.MinLength})
+	}
+	if conf.MaxLength > 0 && length > conf.MaxLength {
+		return false, common.RespError(c, 400, i18n.T("Comment is too long (at most {{count}} characters)", Map{"count": conf.MaxLength}),
+			Map{"err_code": errCodeCommentTooLong, "max_length": conf.MaxLength})
+	}
+	if conf.MaxLinks > 0 && anti_spam.CountLinks(content) > conf.MaxLinks {
+		return false, common.RespError(c, 400, i18n.T("Too many links in comment (at most {{count}})", Map{"count": conf.MaxLinks}),
+			Map{"err_code": errCodeCommentTooManyLinks, "max_links": conf.MaxLinks})
+	}
+	return true, nil
+}
+
+// Check the interval since the latest comment of the user (or the IP) in the site
+func checkCommentCooldown(app *core.App, c *fiber.Ctx, conf config.CommentLimitConf, siteName string, userID uint, ip string) (bool, error) {
+	if conf.Cooldown <= 0 {
+		return true, nil
+	}
+
+	latest := app.Dao().FindLatestSiteComment(siteName, userID, ip)
+	if latest.IsEmpty() {
+		return true, nil
+	}
+
+	remaining := time.Duration(conf.Cooldown)*time.Second - time.Since(latest.CreatedAt)
+	if remaining <= 0 {
+		return true, nil
+	}
+
+	retryAfter := int(math.Ceil(remaining.Seconds()))
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+	return false, common.RespError(c, 429, i18n.T("Commenting too frequently, please try again in {{count}} seconds", Map{"count": retryAfter}),
+		Map{"err_code": errCodeCommentCooldown, "retry_after": retryAfter})
+}
@@ -0,0 +1,88 @@
+package handler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/artalkjs/artalk/v2/server/middleware/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentLimit(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	api.Use(limiter.ActionLimitMiddleware(app.App, limiter.ActionLimitConf{}))
+	handler.CommentCreate(app.App, api)
+	handler.SiteCommentLimitGet(app.App, api)
+	handler.SiteCommentLimitUpdate(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api, map[string]string{"X-Forwarded-For": "10.0.0.1"}) // mock IP
+	create := func(siteName string, content string) (int, gjson.Result) {
+		return request("POST", "/comments", "", `{"name":"userA","email":"user_a@qwqaq.com","content":"`+content+
+			`","page_key":"/test/1000.html","site_name":"`+siteName+`"}`)
+	}
+
+	app.Conf().CommentLimit.MinLength = 5
+	app.Conf().CommentLimit.MaxLength = 30
+	app.Conf().CommentLimit.MaxLinks = 1
+
+	t.Run("Length", func(t *testing.T) {
+		code, data := create("Site A", "hi")
+		assert.Equal(t, 400, code)
+		assert.Equal(t, "comment_too_short", data.Get("err_code").String())
+		assert.Equal(t, int64(5), data.Get("min_length").Int())
+
+		code, data = create("Site A", strings.Repeat("长", 31))
+		assert.Equal(t, 400, code)
+		assert.Equal(t, "comment_too_long", data.Get("err_code").String())
+	})
+
+	t.Run("Links", func(t *testing.T) {
+		code, data := create("Site A", "http://a.cn http://b.cn")
+		assert.Equal(t, 400, code)
+		assert.Equal(t, "comment_too_many_links", data.Get("err_code").String())
+	})
+
+	t.Run("SiteOverrides", func(t *testing.T) {
+		code, _ := request("PUT", "/sites/1000/comment-limit", "", `{"overrides":{"min_length":0}}`)
+		assert.Equal(t, 403, code)
+
+		code, _ = request("PUT", "/sites/1000/comment-limit", token, `{"overrides":{"min_length":"abc"}}`)
+		assert.Equal(t, 400, code)
+
+		code, data := request("PUT", "/sites/1000/comment-limit", token, `{"overrides":{"min_length":0,"cooldown":30}}`)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(0), data.Get("effective.min_length").Int())
+		assert.Equal(t, int64(30), data.Get("effective.max_length").Int(), "should keep the global config")
+
+		code, data = request("GET", "/sites/1000/comment-limit", token, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(30), data.Get("overrides.cooldown").Int())
+		assert.Empty(t, app.Dao().FindSite("Site B").CommentLimitConf, "should only be overridden for the site")
+	})
+
+	t.Run("Cooldown", func(t *testing.T) {
+		assert.NoError(t, app.Dao().CreateComment(&entity.Comment{
+			Content: "latest", PageKey: "/test/1000.html", SiteName: "Site A", UserID: 1001, IP: "10.90.2.101",
+		}))
+
+		code, data := create("Site A", "hi")
+		assert.Equal(t, 429, code, "should be limited by the latest comment of the user")
+		assert.Equal(t, "comment_cooldown", data.Get("err_code").String())
+		assert.LessOrEqual(t, data.Get("retry_after").Int(), int64(30))
+		assert.Greater(t, data.Get("retry_after").Int(), int64(0))
+
+		code, data = create("Site B", "hi")
+		assert.Equal(t, 400, code, "should not have the cooldown on the other sites")
+		assert.Equal(t, "comment_too_short", data.Get("err_code").String())
+	})
+}
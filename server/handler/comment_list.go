@@ -4,8 +4,8 @@ import (
 	"errors"
 
 	"github.com/artalkjs/artalk/v2/internal/core"
-	"github.com/artalkjs/artalk/v2/internal/dao"
 	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
 	"github.com/artalkjs/artalk/v2/internal/log"
 	"github.com/artalkjs/artalk/v2/server/common"
 	cog "github.com/artalkjs/artalk/v2/server/handler/comments_get"
@@ -20,9 +20,14 @@ type ParamsCommentList struct {
 	Limit  int `query:"limit" json:"limit" validate:"optional"`   // The limit for pagination
 	Offset int `query:"offset" json:"offset" validate:"optional"` // The offset for pagination
 
-	FlatMode      bool   `query:"flat_mode" json:"flat_mode" validate:"optional"`                             // Enable flat_mode
-	SortBy        string `query:"sort_by" json:"sort_by" enums:"date_asc,date_desc,vote" validate:"optional"` // Sort by condition
-	ViewOnlyAdmin bool   `query:"view_only_admin" json:"view_only_admin" validate:"optional"`                 // Only show comments by admin
+	RepliesLimit int `query:"replies_limit" json:"replies_limit" validate:"optional"` // The max number of replies of each root comment in nested mode (0 for all, load more by the replies API)
+
+	Pagination string `query:"pagination" json:"pagination" enums:"offset,cursor" validate:"optional"` // The pagination mode (default: offset)
+	Cursor     string `query:"cursor" json:"cursor" validate:"optional"`                               // The cursor for cursor pagination (the next_cursor of previous page, empty for the first page)
+
+	FlatMode      bool   `query:"flat_mode" json:"flat_mode" validate:"optional"`                                                             // Enable flat_mode
+	SortBy        string `query:"sort_by" json:"sort_by" enums:"date_asc,date_desc,vote,reactions,top,hot,controversial" validate:"optional"` // Sort by condition
+	ViewOnlyAdmin bool   `query:"view_only_admin" json:"view_only_admin" validate:"optional"`                                                 // Only show comments by admin
 
 	Search string `query:"search" json:"search" validate:"optional"` // Search keywords
 
@@ -37,6 +42,7 @@ type ResponseCommentList struct {
 	Count      int64                  `json:"count"`
 	RootsCount int64                  `json:"roots_count"`
 	Page       *entity.CookedPage     `json:"page,omitempty"`
+	NextCursor string                 `json:"next_cursor,omitempty"` // The cursor of next page (only for cursor pagination, empty if no more)
 }
 
 // @Id           GetComments
@@ -48,9 +54,15 @@ type ResponseCommentList struct {
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  ResponseCommentList
+// @Failure      400  {object}  Map{msg=string}
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /comments  [get]
 func CommentList(app *core.App, router fiber.Router) {
+	// Warm up the comment lists of the busiest pages on startup
+	if app.Conf().Cache.Enabled && app.Conf().Cache.WarmUp {
+		app.Go(func() { warmUpCommentListCache(app) })
+	}
+
 	router.Get("/comments", func(c *fiber.Ctx) error {
 		var p ParamsCommentList
 		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
@@ -58,16 +70,7 @@ func CommentList(app *core.App, router fiber.Router) {
 		}
 
 		// Get current user
-		user, err := common.GetUserByReq(app, c)
-		if errors.Is(err, common.ErrTokenNotProvided) {
-			// If not login, find user by name and email
-			user = app.Dao().FindUser(p.Name, p.Email)
-
-			// If user is admin, but not login yet, clear user
-			if user.IsAdmin {
-				user = entity.User{}
-			}
-		}
+		user := getCommentListUser(app, c, p.Name, p.Email)
 
 		// Query scope
 		scope := cog.ScopePage
@@ -82,59 +85,111 @@ func CommentList(app *core.App, router fiber.Router) {
 			}
 		}
 
-		// Query options
-		queryOpts := cog.QueryOptions{
-			User: user,
-
-			Scope: scope,
-
-			SitePayload: cog.SitePayload{
-				Type:     cog.SiteScopeType(p.Type),
-				SiteName: p.SiteName,
-			},
+		// Query comments (the list is cached for the visitors who are not identified)
+		ip := app.AnonymizeIP(c.IP())
+		queryOpts, findOpts := getCommentListQueryOptions(p, user, ip, scope)
+		data, err := findCommentListWithCache(app, p, queryOpts, findOpts, isCommentListCacheable(app, p, scope, user, ip))
+		if err != nil {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "cursor"}))
+		}
+		comments := data.Comments
 
-			PagePayload: cog.PageScopePayload{
-				Tags:     lo.If(p.ViewOnlyAdmin, []cog.PageScopeTag{cog.AdminOnly}).Else([]cog.PageScopeTag{}),
-				PageKey:  p.PageKey,
-				SiteName: p.SiteName,
-			},
+		// Get IP region
+		comments = findIPRegionForComments(app, c, comments)
 
-			UserPayload: cog.UserScopePayload{
-				Type: cog.UserScopeType(p.Type),
-			},
+		// Get reactions
+		comments = findReactionsForComments(app, c, comments)
 
-			SortBy: cog.SortRule(p.SortBy),
-			Search: p.Search,
+		// Moderation result is only visible to admin
+		if !user.IsAdmin {
+			comments = hideModerationForComments(comments)
+			comments = hideEditedForComments(app, comments)
 		}
 
-		// Generate query by options
-		comments, count, rootsCount := cog.FindComments(app.Dao(), queryOpts, cog.FindOptions{
-			Limit:  p.Limit,
-			Offset: p.Offset,
-			Nested: !p.FlatMode,
-		})
-
-		// Get IP region
-		comments = findIPRegionForComments(app, comments)
-
 		// The response data
 		resp := ResponseCommentList{
 			Comments:   comments,
-			Count:      count,
-			RootsCount: rootsCount,
+			Count:      data.Count,
+			RootsCount: data.RootsCount,
+			NextCursor: data.NextCursor,
 		}
 
 		// If query scope is page, extra query page data
 		if scope == cog.ScopePage {
-			resp.Page = findPageData(app.Dao(), p.PageKey, p.SiteName)
+			resp.Page = findPageData(app, p.PageKey, p.SiteName)
 		}
 
 		return common.RespData(c, resp)
 	})
 }
 
-func findPageData(dao *dao.Dao, pageKey string, siteName string) *entity.CookedPage {
-	page := dao.FindPage(pageKey, siteName)
+// Get the options of comment list query by the params
+func getCommentListQueryOptions(p ParamsCommentList, user entity.User, ip string, scope cog.Scope) (cog.QueryOptions, cog.FindOptions) {
+	queryOpts := cog.QueryOptions{
+		User: user,
+		IP:   ip,
+
+		Scope: scope,
+
+		SitePayload: cog.SitePayload{
+			Type:     cog.SiteScopeType(p.Type),
+			SiteName: p.SiteName,
+		},
+
+		PagePayload: cog.PageScopePayload{
+			Tags:     lo.If(p.ViewOnlyAdmin, []cog.PageScopeTag{cog.AdminOnly}).Else([]cog.PageScopeTag{}),
+			PageKey:  p.PageKey,
+			SiteName: p.SiteName,
+		},
+
+		UserPayload: cog.UserScopePayload{
+			Type: cog.UserScopeType(p.Type),
+		},
+
+		SortBy: cog.SortRule(p.SortBy),
+		Search: p.Search,
+	}
+
+	findOpts := cog.FindOptions{
+		Limit:  p.Limit,
+		Offset: p.Offset,
+		Nested: !p.FlatMode,
+
+		RepliesLimit: p.RepliesLimit,
+	}
+
+	return queryOpts, findOpts
+}
+
+// Get the current user who views the comments
+func getCommentListUser(app *core.App, c *fiber.Ctx, name string, email string) entity.User {
+	user, err := common.GetUserByReq(app, c)
+	if key, ok := common.GetAPIKeyByReq(c); ok && key.HasAdminPerm(entity.AdminPermRead) {
+		// The API key with the read scope is treated as admin
+		user = key.AdminUser()
+	} else if errors.Is(err, common.ErrTokenNotProvided) {
+		// If not login, find user by name and email
+		user = app.Dao().FindUser(name, email)
+
+		// If user is admin, but not login yet, clear user
+		if user.IsAdmin {
+			user = entity.User{}
+		}
+	}
+	return user
+}
+
+// The comment of shadow banned user is hidden from others except for the admins
+// (the comment author is matched by the user ID or the IP)
+func isShadowHiddenComment(app *core.App, comment entity.Comment, viewer entity.User, ip string) bool {
+	if viewer.IsAdmin || comment.UserID == viewer.ID || (ip != "" && comment.IP == ip) {
+		return false
+	}
+	return app.Dao().FetchUserForComment(&comment).IsShadowBanned
+}
+
+func findPageData(app *core.App, pageKey string, siteName string) *entity.CookedPage {
+	page := app.Dao().FindPage(pageKey, siteName)
 	if page.IsEmpty() {
 		// If page not found, create a new one but not save it
 		page = entity.Page{
@@ -142,20 +197,47 @@ func findPageData(dao *dao.Dao, pageKey string, siteName string) *entity.CookedP
 			SiteName: siteName,
 		}
 	}
-	cooked := dao.CookPage(&page)
+	cooked := cookPageWithCommentClose(app, &page)
 	return &cooked
 }
 
+// Hide the moderation result of each comment
+func hideModerationForComments(comments []entity.CookedComment) []entity.CookedComment {
+	for i := range comments {
+		comments[i].Moderation = nil
+	}
+	return comments
+}
+
+// Hide the edited marker of each comment if it is disabled to show publicly
+func hideEditedForComments(app *core.App, comments []entity.CookedComment) []entity.CookedComment {
+	if app.Conf().Moderator.Edit.ShowEdited {
+		return comments
+	}
+	for i := range comments {
+		comments[i].EditedAt = ""
+	}
+	return comments
+}
+
 // Find the IP region of each comment
-func findIPRegionForComments(app *core.App, comments []entity.CookedComment) []entity.CookedComment {
-	if !app.Conf().IPRegion.Enabled {
+//
+// The stored region when created is used first, and the region is only visible to admins if `admin_only` is enabled.
+// The request context can be nil (e.g. the real-time push), which is treated as non-admin.
+func findIPRegionForComments(app *core.App, c *fiber.Ctx, comments []entity.CookedComment) []entity.CookedComment {
+	conf := app.Conf().IPRegion
+	if !conf.Enabled || (conf.AdminOnly && (c == nil || !common.CheckIsAdminReq(app, c))) {
 		return comments
 	}
 
 	ipRegionService, err := core.AppService[*core.IPRegionService](app)
 	if err == nil {
-		for i, c := range comments {
-			comments[i].IPRegion = ipRegionService.Query(c.IP)
+		for i, comment := range comments {
+			if comment.IPRegionStored != "" {
+				comments[i].IPRegion = ipRegionService.Format(comment.IPRegionStored)
+			} else {
+				comments[i].IPRegion = ipRegionService.Query(comment.IP)
+			}
 		}
 	} else {
 		log.Error("[IPRegionService] err: ", err)
@@ -163,3 +245,18 @@ func findIPRegionForComments(app *core.App, comments []entity.CookedComment) []e
 
 	return comments
 }
+
+// Find the reactions of each comment
+func findReactionsForComments(app *core.App, c *fiber.Ctx, comments []entity.CookedComment) []entity.CookedComment {
+	if !app.Conf().Reaction.Enabled {
+		return comments
+	}
+
+	userID, ip := getReactionVisitor(app, c)
+	reactions := cookReactions(app, lo.Map(comments, func(c entity.CookedComment, _ int) uint { return c.ID }), userID, ip)
+	for i, c := range comments {
+		comments[i].Reactions = reactions[c.ID]
+	}
+
+	return comments
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	cog "github.com/artalkjs/artalk/v2/server/handler/comments_get"
+	"github.com/samber/lo"
+)
+
+// The default number of the busiest pages to warm up the comment lists
+const defaultCommentListWarmUpPages = 20
+
+// The query result of comment list, which is cached before the visitor related data (e.g. the reactions) are filled
+type commentListData struct {
+	Comments   []entity.CookedComment
+	Count      int64
+	RootsCount int64
+	NextCursor string
+}
+
+// Check if the comment list can be cached, which is only for the page scope and the visitors who are not identified,
+// since the pending comments and the comments of shadow banned users are visible to the authors themselves.
+func isCommentListCacheable(app *core.App, p ParamsCommentList, scope cog.Scope, user entity.User, ip string) bool {
+	if !app.Conf().Cache.Enabled || scope != cog.ScopePage ||
+		user.ID != 0 || user.IsAdmin || p.Search != "" || p.Type != "" {
+		return false
+	}
+
+	// The comments of shadow banned users are visible to the visitors with the same IP
+	if ip != "" && lo.Contains(app.Dao().FindPageShadowBannedIPs(p.SiteName, p.PageKey), ip) {
+		return false
+	}
+
+	return true
+}
+
+// Get the normalized query params of comment list, which is a part of cache key
+func getCommentListCacheQuery(p ParamsCommentList) string {
+	return fmt.Sprintf("limit=%d;offset=%d;replies_limit=%d;pagination=%s;cursor=%s;flat_mode=%t;sort_by=%s;view_only_admin=%t",
+		p.Limit, p.Offset, p.RepliesLimit, p.Pagination, p.Cursor, p.FlatMode, p.SortBy, p.ViewOnlyAdmin)
+}
+
+// Find the comment list, the cache is used if cacheable
+//
+// The cache is invalidated by the comment lifecycle events (e.g. created, updated and deleted) of page.
+func findCommentListWithCache(app *core.App, p ParamsCommentList, queryOpts cog.QueryOptions, findOpts cog.FindOptions, cacheable bool) (commentListData, error) {
+	query := getCommentListCacheQuery(p)
+	if cacheable {
+		queryOpts.IP = "" // the cached list is shared by visitors
+		var data commentListData
+		hit := false
+		app.Dao().CacheAction(func(cache *dao.DaoCache) {
+			hit = cache.CommentListCacheFind(p.SiteName, p.PageKey, query, &data)
+		})
+		if hit {
+			return data, nil
+		}
+	}
+
+	var (
+		data commentListData
+		err  error
+	)
+	if p.Pagination == "cursor" {
+		data.Comments, data.Count, data.RootsCount, data.NextCursor, err = cog.FindCommentsByCursor(app.Dao(), queryOpts, findOpts, p.Cursor)
+		if err != nil {
+			return data, err
+		}
+	} else {
+		data.Comments, data.Count, data.RootsCount = cog.FindComments(app.Dao(), queryOpts, findOpts)
+	}
+
+	if cacheable {
+		app.Dao().CacheAction(func(cache *dao.DaoCache) {
+			if err := cache.CommentListCacheSave(p.SiteName, p.PageKey, query, data); err != nil {
+				log.Error("[CommentListCache] Failed to cache the comment list: ", err)
+			}
+		})
+	}
+
+	return data, nil
+}
+
+// Warm up the comment lists of the busiest pages (by the page views) with the default params of client,
+// so the first requests of them are served from the cache.
+func warmUpCommentListCache(app *core.App) {
+	if !app.Conf().Cache.Enabled {
+		return
+	}
+
+	start := time.Now()
+
+	limit := app.Conf().Cache.WarmUpPages
+	if limit == 0 {
+		limit = defaultCommentListWarmUpPages
+	}
+	if limit < 0 {
+		return
+	}
+
+	var pages []entity.Page
+	app.Dao().DB().Order("pv DESC").Order("id ASC").Limit(limit).Find(&pages)
+
+	pageSize, flatModes := getCommentListClientDefaults(app)
+	for _, page := range pages {
+		for _, flatMode := range flatModes {
+			p := ParamsCommentList{
+				PageKey:  page.Key,
+				SiteName: page.SiteName,
+				Limit:    pageSize,
+				FlatMode: flatMode,
+			}
+			queryOpts, findOpts := getCommentListQueryOptions(p, entity.User{}, "", cog.ScopePage)
+			findCommentListWithCache(app, p, queryOpts, findOpts, true)
+		}
+	}
+
+	log.Debug(fmt.Sprintf("[CommentLists] 缓存完毕 (共 %d 个页面，耗时：%s)", len(pages), time.Since(start)))
+}
+
+// Get the default page size and flat modes of client by the frontend config,
+// both flat modes are warmed up if the flat mode of client is "auto" (decided by the screen size)
+func getCommentListClientDefaults(app *core.App) (int, []bool) {
+	pageSize := 20
+	if pagination, ok := app.Conf().Frontend["pagination"].(map[string]interface{}); ok {
+		switch v := pagination["pageSize"].(type) {
+		case int:
+			pageSize = v
+		case int64:
+			pageSize = int(v)
+		case float64:
+			pageSize = int(v)
+		}
+	}
+
+	flatModes := []bool{false, true}
+	if v, ok := app.Conf().Frontend["flatMode"].(bool); ok {
+		flatModes = []bool{v}
+	}
+
+	return pageSize, flatModes
+}
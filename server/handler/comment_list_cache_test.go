@@ -0,0 +1,130 @@
+package handler_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/cache"
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentListCache(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	app.Conf().Cache.Enabled = true
+	app.Conf().Cache.Type = config.CacheTypeBuiltin
+	c, err := cache.New(app.Conf().Cache)
+	assert.NoError(t, err)
+	cacheAdaptor := dao.NewCacheAdaptor(c)
+	defer cacheAdaptor.Close()
+	app.Dao().SetCache(cacheAdaptor)
+
+	handler.CommentList(app.App, api)
+	handler.CacheFlush(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	const (
+		siteName = "Site A"
+		pageKey  = "/test/1000.html"
+	)
+
+	getContents := func(t *testing.T, query string) []string {
+		req := httptest.NewRequest("GET", "/comments?site_name=Site+A&page_key=/test/1000.html&flat_mode=true&limit=100"+query, nil)
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		buf, _ := io.ReadAll(resp.Body)
+		contents := []string{}
+		for _, c := range gjson.GetBytes(buf, "comments").Array() {
+			contents = append(contents, c.Get("content").String())
+		}
+		return contents
+	}
+	flush := func(t *testing.T, body string) {
+		req := httptest.NewRequest("POST", "/cache/flush", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+	}
+	// Modify the comment bypassing the dao, so the outdated list is served if cached
+	modify := func(content string) {
+		app.Dao().DB().Model(&entity.Comment{}).Where("id = ?", 1000).Update("content", content)
+	}
+
+	getContents(t, "")
+
+	t.Run("Served from cache", func(t *testing.T) {
+		modify("Modified 1")
+		assert.NotContains(t, getContents(t, ""), "Modified 1")
+		assert.Contains(t, getContents(t, "&sort_by=date_asc"), "Modified 1", "the lists of different params are cached separately")
+	})
+
+	t.Run("Not cached for identified users", func(t *testing.T) {
+		assert.Contains(t, getContents(t, "&name=userA&email=user_a@qwqaq.com"), "Modified 1")
+	})
+
+	t.Run("Invalidated by comment created", func(t *testing.T) {
+		assert.NoError(t, app.Dao().CreateComment(&entity.Comment{
+			Content:  "New comment",
+			PageKey:  pageKey,
+			SiteName: siteName,
+			UserID:   1000,
+		}))
+		contents := getContents(t, "")
+		assert.Contains(t, contents, "New comment")
+		assert.Contains(t, contents, "Modified 1")
+	})
+
+	t.Run("Not cached for the IP of shadow banned users", func(t *testing.T) {
+		user := app.Dao().FindUserByID(1002)
+		user.IsShadowBanned = true
+		assert.NoError(t, app.Dao().UpdateUser(&user))
+		assert.NoError(t, app.Dao().CreateComment(&entity.Comment{
+			Content:  "Shadow banned",
+			PageKey:  pageKey,
+			SiteName: siteName,
+			UserID:   1002,
+			IP:       "10.0.0.9",
+		}))
+
+		assert.NotContains(t, getContents(t, ""), "Shadow banned")
+		modify("Modified 4")
+		assert.NotContains(t, getContents(t, ""), "Modified 4")
+
+		req := httptest.NewRequest("GET", "/comments?site_name=Site+A&page_key=/test/1000.html&flat_mode=true&limit=100", nil)
+		req.Header.Set("X-Forwarded-For", "10.0.0.9")
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(buf), "Shadow banned")
+		assert.Contains(t, string(buf), "Modified 4", "the outdated list should not be served")
+	})
+
+	t.Run("Flush page", func(t *testing.T) {
+		modify("Modified 2")
+		assert.NotContains(t, getContents(t, ""), "Modified 2")
+		flush(t, `{"site_name":"Site A","page_key":"/test/1000.html"}`)
+		assert.Contains(t, getContents(t, ""), "Modified 2")
+	})
+
+	t.Run("Flush site", func(t *testing.T) {
+		modify("Modified 3")
+		assert.NotContains(t, getContents(t, ""), "Modified 3")
+		flush(t, `{"site_name":"Site A"}`)
+		assert.Contains(t, getContents(t, ""), "Modified 3")
+	})
+}
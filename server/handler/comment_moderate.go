@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
+)
+
+// Approve the pending comment, or set the comment to pending as spam, by the moderator decision.
+// Nothing is changed if the comment is already in the status.
+func setCommentPending(app *core.App, comment *entity.Comment, isPending bool) error {
+	if comment.IsPending == isPending {
+		return nil
+	}
+
+	previous := *comment
+	comment.IsPending = isPending
+
+	// 待审状态被修改为 false，则重新发送邮件通知
+	if !comment.IsPending {
+		if err := renotifyWhenPendingModified(app, comment); err != nil {
+			log.Error("[RenotifyWhenPendingModified] error: ", err)
+		}
+	}
+
+	if err := app.Dao().UpdateComment(comment); err != nil {
+		return errors.New(i18n.T("{{name}} save failed", Map{"name": i18n.T("Comment")}))
+	}
+
+	publishCommentChangedEvents(app, previous, *comment)
+	afterCommentPendingModified(app, *comment)
+
+	return nil
+}
+
+// Trigger the webhook and feed the decision back to the anti-spam checkers
+// after the pending status of comment is modified by the moderator
+func afterCommentPendingModified(app *core.App, comment entity.Comment) {
+	if comment.IsPending {
+		triggerCommentWebhook(app, webhook.EventCommentSpam, comment)
+	} else {
+		triggerCommentWebhook(app, webhook.EventCommentApproved, comment)
+	}
+
+	// 审核反馈 (设为待审视为垃圾评论，通过审核视为正常评论)
+	if antiSpamService, err := core.AppService[*core.AntiSpamService](app); err == nil {
+		app.Go(func() { antiSpamService.Feedback(&comment, comment.IsPending) })
+	}
+}
+
+// Delete the comment and its children, the comment is kept as a tombstone
+// (the children are kept) if the soft delete is enabled
+func removeComment(app *core.App, comment entity.Comment) error {
+	// 软删除 (保留子评论，可恢复)
+	if app.Conf().SoftDelete.Enabled {
+		if err := app.Dao().SoftDelComment(&comment); err != nil {
+			return errors.New(i18n.T("{{name}} deletion failed", Map{"name": i18n.T("Comment")}))
+		}
+	} else {
+		// 删除主评论
+		if err := app.Dao().DelComment(&comment); err != nil {
+			return errors.New(i18n.T("{{name}} deletion failed", Map{"name": i18n.T("Comment")}))
+		}
+
+		// 删除子评论
+		if err := app.Dao().DelCommentChildren(comment.ID); err != nil {
+			return errors.New(i18n.T("{{name}} deletion failed", Map{"name": i18n.T("Sub-comment")}))
+		}
+	}
+
+	publishCommentEvent(app, core.RealtimeCommentDeleted, comment)
+	triggerCommentWebhook(app, webhook.EventCommentDeleted, comment)
+
+	return nil
+}
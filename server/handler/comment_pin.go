@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ParamsCommentPin struct {
+	IDs      []uint `json:"ids" validate:"required"`       // The comment IDs to pin or unpin
+	IsPinned bool   `json:"is_pinned" validate:"required"` // Pin the comments to the top of page or unpin them
+}
+
+type ResponseCommentPin struct {
+	Comments []entity.CookedComment `json:"comments"`
+}
+
+// @Id           PinComments
+// @Summary      Pin Comments
+// @Description  Pin one or more comments to the top of page, or unpin them
+// @Tags         Comment
+// @Security     ApiKeyAuth
+// @Param        comments  body  ParamsCommentPin  true  "The comments to pin"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseCommentPin
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /comments/pin  [post]
+func CommentPin(app *core.App, router fiber.Router) {
+	router.Post("/comments/pin", common.AdminPermGuard(app, entity.AdminPermModerate, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsCommentPin
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+		if len(p.IDs) == 0 {
+			return common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": "ids"}))
+		}
+
+		// Check all the comments before updating
+		comments := []entity.Comment{}
+		for _, id := range p.IDs {
+			comment, ok, resp := findAdminComment(app, c, admin, id)
+			if !ok {
+				return resp
+			}
+			comments = append(comments, comment)
+		}
+
+		cooked := []entity.CookedComment{}
+		for _, comment := range comments {
+			previous := comment
+			comment.IsPinned = p.IsPinned
+			if err := app.Dao().UpdateComment(&comment); err != nil {
+				return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Comment")}))
+			}
+			cooked = append(cooked, app.Dao().CookComment(&comment))
+			addCommentAudit(c, lo.Ternary(p.IsPinned, "comment.pin", "comment.unpin"), &previous, &comment)
+			if !comment.IsPending {
+				publishCommentEvent(app, core.RealtimeCommentUpdated, comment)
+			}
+		}
+
+		return common.RespData(c, ResponseCommentPin{
+			Comments: cooked,
+		})
+	}))
+}
@@ -0,0 +1,64 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentPin(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentList(app.App, api)
+	handler.CommentPin(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+	getRootIDs := func(sortBy string) []int64 {
+		code, data := request("GET", "/comments?site_name=Site+A&page_key=/test/1000.html&limit=10&flat_mode=false&sort_by="+sortBy, "", "")
+		assert.Equal(t, 200, code)
+		var ids []int64
+		for _, c := range data.Get("comments").Array() {
+			if c.Get("rid").Int() == 0 {
+				ids = append(ids, c.Get("id").Int())
+			}
+		}
+		return ids
+	}
+
+	assert.Equal(t, []int64{1005, 1000}, getRootIDs(""))
+
+	t.Run("Pin", func(t *testing.T) {
+		code, data := request("POST", "/comments/pin", token, `{"ids":[1000],"is_pinned":true}`)
+		assert.Equal(t, 200, code)
+		assert.True(t, data.Get("comments.0.is_pinned").Bool())
+
+		assert.Equal(t, []int64{1000, 1005}, getRootIDs(""), "should place the pinned comment first")
+		assert.Equal(t, []int64{1000, 1005}, getRootIDs("date_desc"), "should place the pinned comment first in any sort")
+	})
+
+	t.Run("Unpin", func(t *testing.T) {
+		code, _ := request("POST", "/comments/pin", token, `{"ids":[1000,1005],"is_pinned":false}`)
+		assert.Equal(t, 200, code)
+		assert.False(t, app.Dao().FindComment(1000).IsPinned)
+		assert.Equal(t, []int64{1005, 1000}, getRootIDs(""))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		code, _ := request("POST", "/comments/pin", token, `{"ids":[1000,99999],"is_pinned":true}`)
+		assert.Equal(t, 404, code)
+		assert.False(t, app.Dao().FindComment(1000).IsPinned, "should not pin any comment if one is not found")
+
+		code, _ = request("POST", "/comments/pin", token, `{"ids":[],"is_pinned":true}`)
+		assert.Equal(t, 400, code)
+
+		code, _ = request("POST", "/comments/pin", "", `{"ids":[1000],"is_pinned":true}`)
+		assert.Equal(t, 403, code)
+	})
+}
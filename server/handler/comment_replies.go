@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	cog "github.com/artalkjs/artalk/v2/server/handler/comments_get"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsCommentReplies struct {
+	Limit  int `query:"limit" json:"limit" validate:"optional"`   // The limit for pagination (default: 20)
+	Offset int `query:"offset" json:"offset" validate:"optional"` // The offset for pagination (the number of loaded replies)
+
+	Name  string `query:"name" json:"name" validate:"optional"`   // The username
+	Email string `query:"email" json:"email" validate:"optional"` // The user email
+}
+
+type ResponseCommentReplies struct {
+	Comments []entity.CookedComment `json:"comments"` // The replies in the created order
+	Count    int64                  `json:"count"`    // The total number of replies
+}
+
+// @Id           GetCommentReplies
+// @Summary      Get the replies of a comment
+// @Description  Get the replies of a root comment by pagination, which is used to load more replies when the replies are partially loaded by `replies_limit`
+// @Tags         Comment
+// @Security     ApiKeyAuth
+// @Param        id       path   int                   true  "The root comment ID"
+// @Param        options  query  ParamsCommentReplies  true  "The options"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseCommentReplies
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /comments/{id}/replies  [get]
+func CommentReplies(app *core.App, router fiber.Router) {
+	router.Get("/comments/:id/replies", func(c *fiber.Ctx) error {
+		id, _ := c.ParamsInt("id")
+
+		var p ParamsCommentReplies
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		user := getCommentListUser(app, c, p.Name, p.Email)
+
+		// Find the root comment
+		root := app.Dao().FindComment(uint(id))
+		if root.IsEmpty() && app.Conf().SoftDelete.Enabled {
+			root = app.Dao().FindDeletedComment(uint(id)) // The tombstone of root
+		}
+		if root.IsEmpty() || (root.IsPending && !user.IsAdmin && root.UserID != user.ID) ||
+			isShadowHiddenComment(app, root, user, app.AnonymizeIP(c.IP())) {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
+		}
+		if root.Rid != 0 {
+			return common.RespError(c, 400, "not a root comment")
+		}
+
+		if p.Limit <= 0 {
+			p.Limit = 20
+		}
+
+		comments, count := cog.FindReplies(app.Dao(), cog.QueryOptions{
+			User:  user,
+			IP:    app.AnonymizeIP(c.IP()),
+			Scope: cog.ScopePage,
+			PagePayload: cog.PageScopePayload{
+				PageKey:  root.PageKey,
+				SiteName: root.SiteName,
+			},
+		}, root.ID, cog.FindOptions{
+			Limit:  p.Limit,
+			Offset: p.Offset,
+		})
+
+		comments = findIPRegionForComments(app, c, comments)
+		comments = findReactionsForComments(app, c, comments)
+		if !user.IsAdmin {
+			comments = hideModerationForComments(comments)
+			comments = hideEditedForComments(app, comments)
+		}
+
+		return common.RespData(c, ResponseCommentReplies{
+			Comments: comments,
+			Count:    count,
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package handler_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentReplies(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentList(app.App, api)
+	handler.CommentReplies(app.App, api)
+
+	get := func(url string) (int, gjson.Result) {
+		resp, err := api.Test(httptest.NewRequest("GET", url, nil))
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(buf)
+	}
+	getIDs := func(data gjson.Result) []int64 {
+		var ids []int64
+		for _, c := range data.Get("comments").Array() {
+			ids = append(ids, c.Get("id").Int())
+		}
+		return ids
+	}
+
+	t.Run("ListWithRepliesLimit", func(t *testing.T) {
+		code, data := get("/comments?site_name=Site+A&page_key=/test/1000.html&limit=10&replies_limit=2")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, []int64{1005, 1000, 1001, 1002}, getIDs(data), "should only load the first 2 replies of each root comment")
+		assert.Equal(t, int64(4), data.Get("comments.#(id==1000).replies_count").Int())
+		assert.False(t, data.Get("comments.#(id==1005).replies_count").Exists())
+		assert.Equal(t, int64(6), data.Get("count").Int())
+	})
+
+	t.Run("ListWithoutRepliesLimit", func(t *testing.T) {
+		code, data := get("/comments?site_name=Site+A&page_key=/test/1000.html&limit=10")
+		assert.Equal(t, 200, code)
+		assert.Len(t, getIDs(data), 6, "should load all the replies by default")
+		assert.False(t, data.Get("comments.#(id==1000).replies_count").Exists())
+	})
+
+	t.Run("LoadMoreReplies", func(t *testing.T) {
+		code, data := get("/comments/1000/replies?offset=2&limit=10")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, []int64{1004, 1003}, getIDs(data), "should load the rest replies in the created order")
+		assert.Equal(t, int64(4), data.Get("count").Int())
+
+		code, data = get("/comments/1000/replies?limit=1")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, []int64{1001}, getIDs(data))
+	})
+
+	t.Run("InvalidRoot", func(t *testing.T) {
+		code, _ := get("/comments/1001/replies")
+		assert.Equal(t, 400, code, "should reject the non-root comment")
+
+		code, _ = get("/comments/99999/replies")
+		assert.Equal(t, 404, code)
+	})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The moderation checker name of the comments hidden by the reports
+const reportChecker = "reports"
+
+// The max length of report detail
+const reportDetailMaxLength = 500
+
+type ParamsCommentReport struct {
+	Reason string `json:"reason" validate:"required"` // The reason of report ("spam", "abuse", "off_topic" or "other")
+	Detail string `json:"detail" validate:"optional"` // The detail of report
+}
+
+// @Id           ReportComment
+// @Summary      Report Comment
+// @Description  Report a comment by reader, each reader can report a comment once. The comment is hidden (set to pending) after reported by the number of readers of `report.threshold`
+// @Tags         Report
+// @Param        id      path  int                  true  "The comment ID"
+// @Param        report  body  ParamsCommentReport  true  "The report data"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      409  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /comments/{id}/report  [post]
+func CommentReport(app *core.App, router fiber.Router) {
+	router.Post("/comments/:id/report", common.LimiterGuard(app, func(c *fiber.Ctx) error {
+		if !app.Conf().Report.Enabled {
+			return common.RespError(c, 404, "reports disabled")
+		}
+
+		id, _ := c.ParamsInt("id")
+
+		var p ParamsCommentReport
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if !entity.IsValidCommentReportReason(p.Reason) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "reason"}))
+		}
+		p.Detail = strings.TrimSpace(p.Detail)
+		if utf8.RuneCountInString(p.Detail) > reportDetailMaxLength {
+			return common.RespError(c, 400, fmt.Sprintf("detail is too long (max %d characters)", reportDetailMaxLength))
+		}
+
+		comment := app.Dao().FindComment(uint(id))
+		if comment.IsEmpty() || comment.IsPending {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
+		}
+
+		userID, ip := getReactionVisitor(app, c)
+		if !app.Dao().FindVisitorCommentReport(comment.ID, userID, ip).IsEmpty() {
+			return common.RespError(c, 409, "already reported")
+		}
+
+		if _, err := app.Dao().NewCommentReport(&comment, p.Reason, p.Detail, userID, string(c.Request().Header.UserAgent()), ip); err != nil {
+			return common.RespError(c, 500, "Failed to create report")
+		}
+
+		hideReportedComment(app, comment)
+
+		return common.RespSuccess(c)
+	}))
+}
+
+// Hide the comment (set to pending) if the reports reach the threshold,
+// the comments of admins are not hidden
+func hideReportedComment(app *core.App, comment entity.Comment) {
+	threshold := app.Conf().Report.Threshold
+	if threshold <= 0 || comment.IsPending || app.Dao().FetchUserForComment(&comment).IsAdmin {
+		return
+	}
+
+	// The distinct reporters are counted, so one reader can not hide the comment by multiple accounts
+	count := app.Dao().CountCommentReporters(comment.ID)
+	if count < int64(threshold) {
+		return
+	}
+
+	comment.IsPending = true
+	comment.ModerationChecker = reportChecker
+	comment.ModerationReason = fmt.Sprintf("reported by %d readers", count)
+	if err := app.Dao().UpdateComment(&comment); err != nil {
+		log.Error("[CommentReport] Failed to hide comment: ", err)
+		return
+	}
+
+	publishCommentEvent(app, core.RealtimeCommentDeleted, comment)
+}
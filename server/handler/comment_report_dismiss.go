@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           DismissCommentReports
+// @Summary      Dismiss Comment Reports
+// @Description  Dismiss all the reports of a comment, the comment hidden by the reports is published again
+// @Tags         Report
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The comment ID"
+// @Produce      json
+// @Success      200  {object}  entity.CookedComment
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /reports/comment/{id}  [delete]
+func CommentReportDismiss(app *core.App, router fiber.Router) {
+	router.Delete("/reports/comment/:id", common.AdminPermGuard(app, entity.AdminPermModerate, func(c *fiber.Ctx, admin entity.User) error {
+		id, _ := c.ParamsInt("id")
+
+		comment, ok, resp := findAdminComment(app, c, admin, uint(id))
+		if !ok {
+			return resp
+		}
+
+		if err := app.Dao().DismissCommentReports(comment.ID); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": "reports"}))
+		}
+
+		previous := comment
+
+		// Publish the comment again if it is hidden by the reports
+		if comment.IsPending && comment.ModerationChecker == reportChecker {
+			comment.IsPending = false
+			comment.ModerationChecker = ""
+			comment.ModerationReason = ""
+			if err := app.Dao().UpdateComment(&comment); err != nil {
+				return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Comment")}))
+			}
+			publishCommentEvent(app, core.RealtimeCommentCreated, comment)
+		}
+
+		addCommentAudit(c, "comment.dismiss_reports", &previous, &comment)
+
+		return common.RespData(c, app.Dao().CookComment(&comment))
+	}))
+}
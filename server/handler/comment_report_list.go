@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsCommentReportList struct {
+	SiteName string `query:"site_name" json:"site_name" validate:"optional"` // Filter by the site name, all the sites of admin if empty
+	Limit    int    `query:"limit" json:"limit" validate:"optional"`         // The limit for pagination (default: 20)
+	Offset   int    `query:"offset" json:"offset" validate:"optional"`       // The offset for pagination
+}
+
+type ReportedComment struct {
+	Comment        entity.CookedComment `json:"comment"`
+	Count          int                  `json:"count"`            // The number of reports
+	Reasons        map[string]int       `json:"reasons"`          // The number of reports by reason
+	Details        []string             `json:"details"`          // The report details in the latest order
+	LastReportedAt time.Time            `json:"last_reported_at"` // The time of the latest report
+}
+
+type ResponseCommentReportList struct {
+	Reports []ReportedComment `json:"reports"` // The reported comments in the order of report count
+	Count   int64             `json:"count"`
+}
+
+// @Id           GetCommentReports
+// @Summary      Get Comment Reports
+// @Description  Get the reported comments with the summary of reports, in the order of the report count
+// @Tags         Report
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsCommentReportList  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseCommentReportList
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /reports  [get]
+func CommentReportList(app *core.App, router fiber.Router) {
+	router.Get("/reports", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsCommentReportList
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.Limit <= 0 || p.Limit > 100 {
+			p.Limit = 20
+		}
+
+		siteNames := admin.GetAdminSites()
+		if p.SiteName != "" {
+			if ok, resp := common.CheckAdminSite(c, admin, p.SiteName); !ok {
+				return resp
+			}
+			siteNames = []string{p.SiteName}
+		}
+
+		ids, count := app.Dao().FindReportedCommentIDs(siteNames, p.Offset, p.Limit)
+
+		reported := map[uint]*ReportedComment{}
+		for _, r := range app.Dao().FindCommentReports(ids) {
+			item, ok := reported[r.CommentID]
+			if !ok {
+				item = &ReportedComment{Reasons: map[string]int{}, Details: []string{}, LastReportedAt: r.CreatedAt}
+				reported[r.CommentID] = item
+			}
+			item.Count++
+			item.Reasons[r.Reason]++
+			if r.Detail != "" {
+				item.Details = append(item.Details, r.Detail)
+			}
+		}
+
+		reports := []ReportedComment{}
+		for _, id := range ids {
+			item, ok := reported[id]
+			if !ok {
+				continue
+			}
+			comment := app.Dao().FindComment(id)
+			item.Comment = app.Dao().CookComment(&comment)
+			reports = append(reports, *item)
+		}
+
+		return common.RespData(c, ResponseCommentReportList{
+			Reports: reports,
+			Count:   count,
+		})
+	}))
+}
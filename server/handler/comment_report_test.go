@@ -0,0 +1,118 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/artalkjs/artalk/v2/server/middleware/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentReport(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	api.Use(limiter.ActionLimitMiddleware(app.App, limiter.ActionLimitConf{}))
+	handler.CommentReport(app.App, api)
+	handler.CommentReportList(app.App, api)
+	handler.CommentReportDismiss(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := func(method, url, body, ip, token string) (int, gjson.Result) {
+		return NewApiRequest(t, api, map[string]string{"X-Forwarded-For": ip})(method, url, token, body) // mock IP
+	}
+	report := func(commentID, reason, ip string) int {
+		code, _ := request("POST", "/comments/"+commentID+"/report", `{"reason":"`+reason+`","detail":"bad"}`, ip, "")
+		return code
+	}
+
+	assert.Equal(t, 404, report("1001", "spam", "10.0.0.1"), "should be disabled by default")
+
+	app.Conf().Report.Enabled = true
+	app.Conf().Report.Threshold = 3
+
+	assert.Equal(t, 400, report("1001", "unknown", "10.0.0.1"), "should reject the invalid reason")
+	assert.Equal(t, 404, report("99999", "spam", "10.0.0.1"))
+
+	assert.Equal(t, 200, report("1001", "spam", "10.0.0.1"))
+	assert.Equal(t, 409, report("1001", "abuse", "10.0.0.1"), "should be deduplicated per visitor")
+	assert.Equal(t, 200, report("1001", "abuse", "10.0.0.2"))
+	assert.False(t, app.Dao().FindComment(1001).IsPending, "should not be hidden below the threshold")
+
+	assert.Equal(t, 200, report("1001", "spam", "10.0.0.3"))
+	comment := app.Dao().FindComment(1001)
+	assert.True(t, comment.IsPending, "should be hidden after reaching the threshold")
+	assert.Equal(t, "reports", comment.ModerationChecker)
+	assert.Equal(t, 404, report("1001", "spam", "10.0.0.4"), "should not report the hidden comment")
+
+	// The comments of admins are not hidden
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"} {
+		assert.Equal(t, 200, report("1000", "other", ip))
+	}
+	assert.False(t, app.Dao().FindComment(1000).IsPending)
+
+	// Admin report queue
+	code, _ := request("GET", "/reports", "", "10.0.0.1", "")
+	assert.Equal(t, 403, code)
+
+	code, data := request("GET", "/reports", "", "10.0.0.1", token)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, int64(2), data.Get("count").Int())
+	assert.Equal(t, int64(1000), data.Get("reports.0.comment.id").Int(), "should be ordered by the report count")
+	assert.Equal(t, int64(4), data.Get("reports.0.count").Int())
+	assert.Equal(t, int64(1001), data.Get("reports.1.comment.id").Int())
+	assert.Equal(t, `{"abuse":1,"spam":2}`, data.Get("reports.1.reasons").Raw)
+
+	code, data = request("GET", "/reports?site_name=Site+B", "", "10.0.0.1", token)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, int64(0), data.Get("count").Int())
+
+	// Dismiss
+	code, _ = request("DELETE", "/reports/comment/1001", "", "10.0.0.1", "")
+	assert.Equal(t, 403, code)
+
+	code, data = request("DELETE", "/reports/comment/1001", "", "10.0.0.1", token)
+	assert.Equal(t, 200, code)
+	assert.False(t, data.Get("is_pending").Bool(), "should publish the comment hidden by the reports")
+	comment = app.Dao().FindComment(1001)
+	assert.False(t, comment.IsPending)
+	assert.Empty(t, comment.ModerationChecker)
+	assert.Equal(t, int64(0), app.Dao().CountCommentReports(1001))
+
+	code, data = request("GET", "/reports", "", "10.0.0.1", token)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, int64(1), data.Get("count").Int())
+
+	t.Run("DistinctReporters", func(t *testing.T) {
+		app.Conf().Report.Threshold = 2
+		defer func() { app.Conf().Report.Threshold = 3 }()
+
+		userReport := func(userID uint, ip string) int {
+			userToken, err := common.LoginGetUserToken(app.Dao().FindUserByID(userID), app.Conf().AppKey, app.Conf().LoginTimeout)
+			assert.NoError(t, err)
+			code, _ := request("POST", "/comments/1001/report", `{"reason":"spam"}`, ip, userToken)
+			return code
+		}
+
+		// The accounts from the same IP are counted as one reporter
+		assert.Equal(t, 200, userReport(1001, "10.0.1.1"))
+		assert.Equal(t, 200, userReport(1002, "10.0.1.1"))
+		assert.Equal(t, 200, report("1001", "spam", "10.0.1.1"))
+		assert.Equal(t, int64(3), app.Dao().CountCommentReports(1001))
+		assert.Equal(t, int64(1), app.Dao().CountCommentReporters(1001))
+		assert.False(t, app.Dao().FindComment(1001).IsPending, "should not be hidden by the reports of one IP")
+
+		// The user from multiple IPs is counted as one reporter
+		assert.Equal(t, 409, userReport(1001, "10.0.1.2"), "should be deduplicated per user")
+		assert.False(t, app.Dao().FindComment(1001).IsPending)
+
+		assert.Equal(t, 200, report("1001", "spam", "10.0.1.3"))
+		assert.Equal(t, int64(2), app.Dao().CountCommentReporters(1001))
+		assert.True(t, app.Dao().FindComment(1001).IsPending, "should be hidden after reported by the distinct reporters")
+	})
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/samber/lo"
+)
+
+type ResponseCommentRevisionList struct {
+	Revisions []entity.CookedCommentRevision `json:"revisions"` // The previous versions in the edited order
+	Count     int                            `json:"count"`
+	EditedAt  string                         `json:"edited_at"` // The last time of the content edited
+}
+
+// @Id           GetCommentRevisions
+// @Summary      Get Comment Revisions
+// @Description  Get the previous versions of an edited comment
+// @Tags         Comment
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The comment ID"
+// @Produce      json
+// @Success      200  {object}  ResponseCommentRevisionList
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /comments/{id}/revisions  [get]
+func CommentRevisionList(app *core.App, router fiber.Router) {
+	router.Get("/comments/:id/revisions", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		id, _ := c.ParamsInt("id")
+
+		comment, ok, resp := findAdminComment(app, c, admin, uint(id))
+		if !ok {
+			return resp
+		}
+
+		revisions := lo.Map(app.Dao().FindCommentRevisions(comment.ID), func(r entity.CommentRevision, _ int) entity.CookedCommentRevision {
+			return app.Dao().CookCommentRevision(&r)
+		})
+
+		return common.RespData(c, ResponseCommentRevisionList{
+			Revisions: revisions,
+			Count:     len(revisions),
+			EditedAt:  app.Dao().CookComment(&comment).EditedAt,
+		})
+	}))
+}
+
+type ParamsCommentRevisionDiff struct {
+	From uint `query:"from" json:"from" validate:"required"` // The revision ID to diff from
+	To   uint `query:"to" json:"to" validate:"optional"`     // The revision ID to diff to (0 for the current content)
+}
+
+type ResponseCommentRevisionDiff struct {
+	Diff string `json:"diff"` // The unified diff of the contents
+}
+
+// @Id           GetCommentRevisionDiff
+// @Summary      Diff Comment Revisions
+// @Description  Get the unified diff between two versions of an edited comment
+// @Tags         Comment
+// @Security     ApiKeyAuth
+// @Param        id       path   int                        true  "The comment ID"
+// @Param        options  query  ParamsCommentRevisionDiff  true  "The versions to diff"
+// @Produce      json
+// @Success      200  {object}  ResponseCommentRevisionDiff
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /comments/{id}/revisions/diff  [get]
+func CommentRevisionDiff(app *core.App, router fiber.Router) {
+	router.Get("/comments/:id/revisions/diff", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		id, _ := c.ParamsInt("id")
+
+		var p ParamsCommentRevisionDiff
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		comment, ok, resp := findAdminComment(app, c, admin, uint(id))
+		if !ok {
+			return resp
+		}
+
+		revisions := app.Dao().FindCommentRevisions(comment.ID)
+		findContent := func(revisionID uint) (string, string, bool) {
+			if revisionID == 0 {
+				return comment.Content, "current", true
+			}
+			r, ok := lo.Find(revisions, func(r entity.CommentRevision) bool { return r.ID == revisionID })
+			return r.Content, fmt.Sprintf("revision %d", r.ID), ok
+		}
+
+		from, fromName, fromOK := findContent(p.From)
+		to, toName, toOK := findContent(p.To)
+		if !fromOK || !toOK {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": "revision"}))
+		}
+
+		diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(from),
+			B:        difflib.SplitLines(to),
+			FromFile: fromName,
+			ToFile:   toName,
+			Context:  3,
+		})
+
+		return common.RespData(c, ResponseCommentRevisionDiff{
+			Diff: diff,
+		})
+	}))
+}
+
+// Find the comment which the admin can manage
+func findAdminComment(app *core.App, c *fiber.Ctx, admin entity.User, id uint) (entity.Comment, bool, error) {
+	comment := app.Dao().FindComment(id)
+	if comment.IsEmpty() {
+		return comment, false, common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
+	}
+	if ok, resp := common.CheckAdminSite(c, admin, comment.SiteName); !ok {
+		return comment, false, resp
+	}
+	return comment, true, nil
+}
@@ -0,0 +1,68 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentRevisions(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentGet(app.App, api)
+	handler.CommentUpdate(app.App, api)
+	handler.CommentRevisionList(app.App, api)
+	handler.CommentRevisionDiff(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+	edit := func(content string) {
+		code, _ := request("PUT", "/comments/1005", token, `{"site_name":"Site A","page_key":"/test/1000.html","rid":0,"is_collapsed":false,"is_pending":false,"is_pinned":false,"content":"`+content+`"}`)
+		assert.Equal(t, 200, code)
+	}
+
+	original := app.Dao().FindComment(1005).Content
+	code, data := request("GET", "/comments/1005/revisions", token, "")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, int64(0), data.Get("count").Int())
+	assert.Empty(t, data.Get("edited_at").String())
+
+	edit("line 1\\nline 2")
+	edit("line 1\\nline 2")   // not modified
+	edit("line 1\\nline two") // modified again
+
+	code, data = request("GET", "/comments/1005/revisions", token, "")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, int64(2), data.Get("count").Int(), "should only store the revision when the content is modified")
+	assert.Equal(t, original, data.Get("revisions.0.content").String())
+	assert.Equal(t, "line 1\nline 2", data.Get("revisions.1.content").String())
+	assert.Equal(t, admin.Name, data.Get("revisions.1.editor_name").String())
+	assert.NotEmpty(t, data.Get("edited_at").String())
+
+	revisionID := data.Get("revisions.1.id").String()
+	code, data = request("GET", "/comments/1005/revisions/diff?from="+revisionID, token, "")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "--- revision "+revisionID+"\n+++ current\n@@ -1,2 +1,2 @@\n line 1\n-line 2\n+line two\n", data.Get("diff").String())
+
+	code, _ = request("GET", "/comments/1005/revisions/diff?from=99999", token, "")
+	assert.Equal(t, 404, code)
+
+	code, _ = request("GET", "/comments/1005/revisions", "", "")
+	assert.Equal(t, 403, code, "should be only accessible by admin")
+
+	// The edited marker
+	code, data = request("GET", "/comments/1005", "", "")
+	assert.Equal(t, 200, code)
+	assert.False(t, data.Get("comment.edited_at").Exists(), "should hide the edited marker by default")
+
+	app.Conf().Moderator.Edit.ShowEdited = true
+	code, data = request("GET", "/comments/1005", "", "")
+	assert.Equal(t, 200, code)
+	assert.NotEmpty(t, data.Get("comment.edited_at").String())
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	cog "github.com/artalkjs/artalk/v2/server/handler/comments_get"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsCommentSearch struct {
+	Keywords string `query:"q" json:"q" validate:"required"`                 // The search keywords
+	SiteName string `query:"site_name" json:"site_name" validate:"optional"` // The site name of your content scope (required for non-admin)
+	PageKey  string `query:"page_key" json:"page_key" validate:"optional"`   // Filter by the page key
+	Author   string `query:"author" json:"author" validate:"optional"`       // Filter by the author name (or the email for admin)
+	DateFrom string `query:"date_from" json:"date_from" validate:"optional"` // Filter by the created date after (e.g. "2024-01-01" or RFC3339)
+	DateTo   string `query:"date_to" json:"date_to" validate:"optional"`     // Filter by the created date before (the whole day is included if only the date is given)
+
+	SortBy string `query:"sort_by" json:"sort_by" enums:"date_desc,date_asc,vote" validate:"optional"` // Sort by condition (default: date_desc)
+	Limit  int    `query:"limit" json:"limit" validate:"optional"`                                     // The limit for pagination
+	Offset int    `query:"offset" json:"offset" validate:"optional"`                                   // The offset for pagination
+}
+
+type ResponseCommentSearch struct {
+	Comments []entity.CookedComment `json:"comments"`
+	Count    int64                  `json:"count"`
+}
+
+// @Id           SearchComments
+// @Summary      Search Comments
+// @Description  Search comments by keywords with the full-text index, and filter by the author, page and date range
+// @Tags         Comment
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsCommentSearch  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseCommentSearch
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /comments/search  [get]
+func CommentSearch(app *core.App, router fiber.Router) {
+	router.Get("/comments/search", func(c *fiber.Ctx) error {
+		var p ParamsCommentSearch
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		user := getCommentListUser(app, c, "", "")
+
+		// The readers can only search in a site
+		if p.SiteName == "" && !user.IsAdmin {
+			return common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": "site_name"}))
+		}
+		if p.SiteName != "" {
+			if _, ok, resp := common.CheckSiteExist(app, c, p.SiteName); !ok {
+				return resp
+			}
+		}
+
+		if strings.TrimSpace(p.Keywords) == "" {
+			return common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": "q"}))
+		}
+
+		from, to, ok, resp := parseCommentSearchDateRange(c, p.DateFrom, p.DateTo)
+		if !ok {
+			return resp
+		}
+
+		searchService, err := core.AppService[*core.SearchService](app)
+		if err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+		match, err := searchService.Match(p.Keywords)
+		if err != nil {
+			log.Error("[CommentSearch] Failed to search by ", searchService.Engine(), ": ", err)
+			return common.RespError(c, 500, i18n.T("Search failed"))
+		}
+
+		var userIDs []uint
+		if author := strings.TrimSpace(p.Author); author != "" {
+			userIDs = []uint{}
+			q := app.Dao().DB().Model(&entity.User{})
+			if user.IsAdmin {
+				q = q.Where("LOWER(name) = LOWER(?) OR LOWER(email) = LOWER(?)", author, author)
+			} else {
+				q = q.Where("LOWER(name) = LOWER(?)", author) // the email of others is private
+			}
+			q.Pluck("id", &userIDs)
+		}
+
+		sortBy := cog.SortByDateDesc
+		if p.SortBy != "" {
+			sortBy = cog.SortRule(p.SortBy)
+		}
+
+		limit := p.Limit
+		if limit > 100 {
+			limit = 100
+		} else if limit <= 0 {
+			limit = 15
+		}
+
+		comments, count, _ := cog.FindComments(app.Dao(), cog.QueryOptions{
+			User:  user,
+			IP:    app.AnonymizeIP(c.IP()),
+			Scope: cog.ScopeSearch,
+			SearchPayload: cog.SearchScopePayload{
+				Match:    match,
+				SiteName: p.SiteName,
+				PageKey:  p.PageKey,
+				UserIDs:  userIDs,
+				From:     from,
+				To:       to,
+			},
+			SortBy: sortBy,
+		}, cog.FindOptions{
+			Limit:  limit,
+			Offset: max(p.Offset, 0),
+		})
+
+		comments = findIPRegionForComments(app, c, comments)
+		if !user.IsAdmin {
+			comments = hideModerationForComments(comments)
+			comments = hideEditedForComments(app, comments)
+		}
+
+		return common.RespData(c, ResponseCommentSearch{
+			Comments: comments,
+			Count:    count,
+		})
+	})
+}
+
+func parseCommentSearchDateRange(c *fiber.Ctx, dateFrom string, dateTo string) (*time.Time, *time.Time, bool, error) {
+	var from, to *time.Time
+	if dateFrom != "" {
+		t, err := parseDateTime(dateFrom)
+		if err != nil {
+			return nil, nil, false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "date_from"}))
+		}
+		from = &t
+	}
+	if dateTo != "" {
+		t, err := parseDateTime(dateTo)
+		if err != nil {
+			return nil, nil, false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "date_to"}))
+		}
+		if len(strings.TrimSpace(dateTo)) == len(dateOnlyFormat) {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond) // till the end of day
+		}
+		to = &t
+	}
+	return from, to, true, nil
+}
@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentSearch(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentSearch(app.App, api)
+	handler.SearchReindex(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+	search := func(query url.Values, token string) (int, []int64, int64) {
+		code, data := request("GET", "/comments/search?"+query.Encode(), token, "")
+		ids := []int64{}
+		for _, c := range data.Get("comments").Array() {
+			if c.Get("visible").Bool() {
+				ids = append(ids, c.Get("id").Int())
+			}
+		}
+		return code, ids, data.Get("count").Int()
+	}
+
+	t.Run("Params", func(t *testing.T) {
+		code, _, _ := search(url.Values{"q": {"回复"}}, "")
+		assert.Equal(t, 400, code, "site_name is required for readers")
+
+		code, _, _ = search(url.Values{"q": {"回复"}, "site_name": {"Not Exist"}}, "")
+		assert.Equal(t, 404, code)
+
+		code, _, _ = search(url.Values{"q": {" "}, "site_name": {"Site A"}}, "")
+		assert.Equal(t, 400, code)
+
+		code, _, _ = search(url.Values{"q": {"回复"}, "site_name": {"Site A"}, "date_from": {"abc"}}, "")
+		assert.Equal(t, 400, code)
+	})
+
+	t.Run("Keywords", func(t *testing.T) {
+		code, ids, count := search(url.Values{"q": {"嵌套回复"}, "site_name": {"Site A"}}, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(3), count)
+		assert.ElementsMatch(t, []int64{1002, 1003, 1004}, ids)
+
+		// all the terms should be matched
+		_, ids, _ = search(url.Values{"q": {"嵌套回复 B"}, "site_name": {"Site A"}}, "")
+		assert.Equal(t, []int64{1003}, ids)
+
+		_, ids, _ = search(url.Values{"q": {"not-exists-keywords"}, "site_name": {"Site A"}}, "")
+		assert.Empty(t, ids)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		_, _, count := search(url.Values{"q": {"回复"}, "site_name": {"Site A"}, "author": {"userA"}}, "")
+		assert.Equal(t, int64(4), count)
+
+		_, _, count = search(url.Values{"q": {"回复"}, "site_name": {"Site A"}, "author": {"admin"}}, "")
+		assert.Equal(t, int64(0), count)
+
+		// the email is only searchable by admin
+		_, _, count = search(url.Values{"q": {"回复"}, "site_name": {"Site A"}, "author": {"user_a@qwqaq.com"}}, "")
+		assert.Equal(t, int64(0), count)
+		_, _, count = search(url.Values{"q": {"回复"}, "site_name": {"Site A"}, "author": {"user_a@qwqaq.com"}}, token)
+		assert.Equal(t, int64(4), count)
+
+		_, _, count = search(url.Values{"q": {"回复"}, "site_name": {"Site A"}, "page_key": {"/test_pagination.html"}}, "")
+		assert.Equal(t, int64(0), count)
+
+		_, _, count = search(url.Values{"q": {"嵌套回复"}, "site_name": {"Site A"}, "date_from": {"2022-04-29"}, "date_to": {"2022-04-29"}}, "")
+		assert.Equal(t, int64(3), count, "the whole day should be included")
+
+		_, _, count = search(url.Values{"q": {"嵌套回复"}, "site_name": {"Site A"}, "date_to": {"2022-04-28"}}, "")
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		_, _, count := search(url.Values{"q": {"待审"}, "site_name": {"Site B"}}, "")
+		assert.Equal(t, int64(0), count, "the pending comments should be hidden from readers")
+
+		_, _, count = search(url.Values{"q": {"待审"}}, token)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Reindex", func(t *testing.T) {
+		code, _ := request("POST", "/search/reindex", "", "")
+		assert.Equal(t, 403, code)
+
+		code, data := request("POST", "/search/reindex", token, "")
+		assert.Equal(t, 200, code)
+		assert.NotEmpty(t, data.Get("engine").String())
+	})
+}
@@ -0,0 +1,52 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentShadowBan(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentList(app.App, api)
+	handler.CommentGet(app.App, api)
+	handler.UserUpdate(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := func(method, url, body, ip, token string) (int, gjson.Result) {
+		return NewApiRequest(t, api, map[string]string{"X-Forwarded-For": ip})(method, url, token, body) // mock IP
+	}
+	getUserIDs := func(query, ip, token string) []int64 {
+		code, data := request("GET", "/comments?site_name=Site+A&page_key=/test/1000.html&flat_mode=true&limit=100"+query, "", ip, token)
+		assert.Equal(t, 200, code)
+		ids := []int64{}
+		for _, c := range data.Get("comments").Array() {
+			ids = append(ids, c.Get("user_id").Int())
+		}
+		return ids
+	}
+
+	assert.Contains(t, getUserIDs("", "10.0.0.1", ""), int64(1001))
+
+	code, data := request("PUT", "/users/1001", `{"name":"userA","email":"user_a@qwqaq.com","is_admin":false,"receive_email":true,"is_shadow_banned":true}`, "10.0.0.1", token)
+	assert.Equal(t, 200, code)
+	assert.True(t, data.Get("is_shadow_banned").Bool())
+
+	assert.NotContains(t, getUserIDs("", "10.0.0.1", ""), int64(1001), "should be hidden from others")
+	assert.Contains(t, getUserIDs("&name=userA&email=user_a@qwqaq.com", "10.0.0.1", ""), int64(1001), "should be visible to the user self")
+	assert.Contains(t, getUserIDs("", "10.90.2.101", ""), int64(1001), "should be visible to the same IP")
+	assert.Contains(t, getUserIDs("", "10.0.0.1", token), int64(1001), "should be visible to admin")
+
+	code, _ = request("GET", "/comments/1001", "", "10.0.0.1", "")
+	assert.Equal(t, 404, code)
+	code, _ = request("GET", "/comments/1001", "", "10.90.2.101", "")
+	assert.Equal(t, 200, code)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/artalkjs/artalk/v2/internal/core"
 	"github.com/artalkjs/artalk/v2/internal/entity"
@@ -48,7 +49,7 @@ type ResponseCommentUpdate struct {
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /comments/{id} [put]
 func CommentUpdate(app *core.App, router fiber.Router) {
-	router.Put("/comments/:id", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Put("/comments/:id", common.AdminPermGuard(app, entity.AdminPermModerate, func(c *fiber.Ctx, admin entity.User) error {
 		var p ParamsCommentUpdate
 		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
 			return resp
@@ -66,6 +67,14 @@ func CommentUpdate(app *core.App, router fiber.Router) {
 		if comment.IsEmpty() {
 			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
 		}
+		previous := comment
+
+		// check admin site scope
+		for _, siteName := range []string{comment.SiteName, p.SiteName} {
+			if ok, resp := common.CheckAdminSite(c, admin, siteName); !ok {
+				return resp
+			}
+		}
 
 		// check params
 		if p.Email != "" && !utils.ValidateEmail(p.Email) {
@@ -75,9 +84,13 @@ func CommentUpdate(app *core.App, router fiber.Router) {
 			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": i18n.T("Link")}))
 		}
 
-		// content
-		if p.Content != "" {
+		// content (the previous version is stored as revision)
+		isContentModified := p.Content != "" && p.Content != comment.Content
+		previousContent := comment.Content
+		if isContentModified {
+			now := time.Now()
 			comment.Content = p.Content
+			comment.EditedAt = &now
 		}
 
 		// merge user
@@ -103,21 +116,23 @@ func CommentUpdate(app *core.App, router fiber.Router) {
 
 		// pageKey
 		if p.PageKey != "" && p.PageKey != comment.PageKey {
-			app.Dao().FindCreatePage(p.PageKey, "", p.SiteName)
+			findCreatePage(app, p.PageKey, "", p.SiteName)
 			comment.PageKey = p.PageKey
 		}
 
 		if p.UA != "" {
 			comment.UA = p.UA
 		}
-		if p.IP != "" {
+		if p.IP != "" && p.IP != comment.IP {
 			comment.IP = p.IP
+			comment.IPRegion = lookupIPRegionForComment(app, p.IP)
 		}
 
 		comment.IsCollapsed = p.IsCollapsed
 		comment.IsPinned = p.IsPinned
 
-		if p.IsPending != comment.IsPending {
+		isPendingModified := p.IsPending != comment.IsPending
+		if isPendingModified {
 			// 待审状态发生改变
 			comment.IsPending = p.IsPending
 
@@ -130,12 +145,39 @@ func CommentUpdate(app *core.App, router fiber.Router) {
 			}
 		}
 
+		// 编辑后重新审核 (同时修改了待审状态时以管理员的决定为准，管理员的评论不审核)
+		editConf := app.Conf().Moderator.Edit
+		isRecheck := editConf.Enabled && isContentModified && !isPendingModified &&
+			!app.Dao().FetchUserForComment(&comment).IsAdmin
+		if isRecheck && editConf.Pending {
+			comment.IsPending = true
+		}
+
 		if err := app.Dao().UpdateComment(&comment); err != nil {
 			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Comment")}))
 		}
 
+		if isContentModified {
+			app.Dao().NewCommentRevision(comment.ID, previousContent, admin.ID)
+		}
+
+		if isRecheck {
+			recheckEditedComment(app, &comment, nil)
+		}
+
+		publishCommentChangedEvents(app, previous, comment)
+		if isPendingModified {
+			afterCommentPendingModified(app, comment)
+		}
+
+		action := "comment.update"
+		if isPendingModified {
+			action = pendingAuditAction(comment.IsPending)
+		}
+		addCommentAudit(c, action, &previous, &comment)
+
 		cookedComment := app.Dao().CookComment(&comment)
-		cookedComment = fetchIPRegionForComment(app, cookedComment)
+		cookedComment = fetchIPRegionForComment(app, c, cookedComment)
 
 		return common.RespData(c, ResponseCommentUpdate{
 			CookedComment: cookedComment,
@@ -143,6 +185,48 @@ func CommentUpdate(app *core.App, router fiber.Router) {
 	}))
 }
 
+// Re-run the anti-spam checkers on the edited comment,
+// the comment will be reloaded if it is blocked.
+//
+// The `onPass` callback is called with the comment after it passes the check,
+// which is reloaded and followed by the changed events in the async moderation mode.
+func recheckEditedComment(app *core.App, comment *entity.Comment, onPass func(comment *entity.Comment)) {
+	antiSpamService, err := core.AppService[*core.AntiSpamService](app)
+	if err != nil {
+		log.Error("[AntiSpamService] err: ", err)
+		return
+	}
+
+	edited := *comment // the checker may run in the queue after the response
+	payload := &core.AntiSpamCheckPayload{
+		Comment:      &edited,
+		ReqIP:        comment.IP,
+		ReqUserAgent: comment.UA,
+	}
+
+	if antiSpamService.IsAsync() {
+		antiSpamService.AsyncCheckAndBlock(payload, func(pass bool) {
+			if !pass || onPass == nil {
+				return
+			}
+			current := app.Dao().FindComment(edited.ID)
+			if current.IsEmpty() {
+				return
+			}
+			previous := current
+			onPass(&current)
+			publishCommentChangedEvents(app, previous, current)
+		})
+		return
+	}
+
+	if pass := antiSpamService.CheckAndBlock(payload); !pass {
+		*comment = app.Dao().FindComment(comment.ID) // reload the blocked status
+	} else if onPass != nil {
+		onPass(comment)
+	}
+}
+
 func renotifyWhenPendingModified(app *core.App, comment *entity.Comment) (err error) {
 	if comment.Rid == 0 {
 		return // Root 评论不发送通知，因为这个评论已经被管理员看到了
@@ -174,5 +258,12 @@ func renotifyWhenPendingModified(app *core.App, comment *entity.Comment) (err er
 	}
 	emailService.AsyncSend(&notify)
 
+	// 浏览器推送
+	if app.Dao().FetchUserForComment(&pComment).NotifyReply {
+		if webPushService, err := core.AppService[*core.WebPushService](app); err == nil {
+			webPushService.Push(&notify)
+		}
+	}
+
 	return
 }
@@ -0,0 +1,72 @@
+package handler_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommentUpdateRecheck(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentUpdate(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+	// Edit the content of a new comment by userA, the stored comment is returned
+	edit := func(content string) (entity.Comment, gjson.Result) {
+		comment := entity.Comment{Content: "original", PageKey: "/test/1000.html", SiteName: "Site A", UserID: 1001}
+		assert.NoError(t, app.Dao().CreateComment(&comment))
+
+		code, data := request("PUT", fmt.Sprintf("/comments/%d", comment.ID), token, `{"site_name":"Site A","page_key":"/test/1000.html",`+
+			`"rid":0,"is_collapsed":false,"is_pending":false,"is_pinned":false,"content":"`+content+`"}`)
+		assert.Equal(t, 200, code)
+		return app.Dao().FindComment(comment.ID), data
+	}
+
+	// The checker blocks the comments of userA (user_a@qwqaq.com)
+	ban := entity.Ban{Type: "email_domain", Value: "qwqaq.com", Action: entity.BanActionBlock, SiteName: "Site A"}
+	assert.NoError(t, app.Dao().NewBan(&ban))
+
+	t.Run("Disabled", func(t *testing.T) {
+		comment, _ := edit("not rechecked")
+		assert.False(t, comment.IsPending, "should not recheck if disabled")
+		assert.Empty(t, comment.ModerationChecker)
+	})
+
+	app.Conf().Moderator.Edit.Enabled = true
+
+	t.Run("Blocked", func(t *testing.T) {
+		comment, data := edit("blocked")
+		assert.True(t, data.Get("is_pending").Bool(), "should respond the verdict of checker")
+		assert.True(t, comment.IsPending)
+		assert.Equal(t, "ban", comment.ModerationChecker)
+		assert.Equal(t, "blocked", comment.Content)
+	})
+
+	t.Run("Passed", func(t *testing.T) {
+		assert.NoError(t, app.Dao().DelBan(&ban))
+
+		comment, data := edit("passed")
+		assert.False(t, data.Get("is_pending").Bool())
+		assert.False(t, comment.IsPending)
+		assert.Empty(t, comment.ModerationChecker)
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		app.Conf().Moderator.Edit.Pending = true
+
+		comment, data := edit("pending")
+		assert.True(t, data.Get("is_pending").Bool(), "should set pending for the manual review even if passed")
+		assert.True(t, comment.IsPending)
+	})
+}
@@ -4,6 +4,8 @@
 package comments_get
 
 import (
+	"slices"
+
 	"github.com/artalkjs/artalk/v2/internal/dao"
 	"github.com/artalkjs/artalk/v2/internal/entity"
 )
@@ -11,13 +13,15 @@ import (
 type Scope string
 
 const (
-	ScopePage Scope = "page"
-	ScopeUser Scope = "user"
-	ScopeSite Scope = "site"
+	ScopePage   Scope = "page"
+	ScopeUser   Scope = "user"
+	ScopeSite   Scope = "site"
+	ScopeSearch Scope = "search"
 )
 
 type QueryOptions struct {
 	User entity.User
+	IP   string // The IP of the viewer, the comments of shadow banned user are visible to the same IP
 
 	Scope Scope
 
@@ -25,6 +29,8 @@ type QueryOptions struct {
 	UserPayload UserScopePayload
 	SitePayload SitePayload
 
+	SearchPayload SearchScopePayload
+
 	SortBy SortRule
 
 	Search string
@@ -40,28 +46,54 @@ type QueryOptions struct {
 //	Updated: The `*gorm.DB` had been refactored to `liteDB`, which is a subset of `*gorm.DB`.
 //	(only contains `WHERE` conditions)
 func GetQueryScopes(dao *dao.Dao, opts QueryOptions) func(liteDB) liteDB {
+	// The scope is applied to each query of the list (e.g. find, count and replies),
+	// so the data it depends on is only fetched once here instead of in each query
+	var scope func(liteDB) liteDB
+	switch opts.Scope {
+	case ScopePage:
+		var adminUserIDs []uint
+		if slices.Contains(opts.PagePayload.Tags, AdminOnly) {
+			adminUserIDs = dao.GetAllAdminIDs()
+		}
+		scope = PageScopeQuery(opts.PagePayload, PageScopeOpts{
+			AdminUserIDs: adminUserIDs,
+		})
+	case ScopeUser:
+		scope = UserScopeQuery(opts.UserPayload, UserScopeOpts{
+			User: opts.User,
+			GetUserComments: func(userID uint) []uint {
+				return dao.GetUserAllCommentIDs(userID)
+			},
+		})
+	case ScopeSite:
+		scope = SiteScopeQuery(opts.SitePayload, opts.User)
+	case ScopeSearch:
+		scope = SearchScopeQuery(opts.SearchPayload, opts.User)
+	}
+
+	var searchScope func(liteDB) liteDB
+	if opts.Search != "" {
+		searchScope = SearchScope(dao, opts.Search)
+	}
+
 	return func(q liteDB) liteDB {
 		// Basic scope
 		q.Scopes(CommonScope(opts.User))
 
+		// Hide the comments of shadow banned users from others
+		if !opts.User.IsAdmin {
+			q.Scopes(NoShadowBanned(dao.ShadowBannedUserIDs(), opts.User.ID, opts.IP))
+		}
+
 		// Search function
-		if opts.Search != "" {
-			q.Scopes(SearchScope(dao, opts.Search))
+		if searchScope != nil {
+			q.Scopes(searchScope)
 		}
 
 		// Scopes
-		q.Scopes(map[Scope]func(liteDB) liteDB{
-			ScopePage: PageScopeQuery(opts.PagePayload, PageScopeOpts{
-				AdminUserIDs: dao.GetAllAdminIDs(),
-			}),
-			ScopeUser: UserScopeQuery(opts.UserPayload, UserScopeOpts{
-				User: opts.User,
-				GetUserComments: func(userID uint) []uint {
-					return dao.GetUserAllCommentIDs(userID)
-				},
-			}),
-			ScopeSite: SiteScopeQuery(opts.SitePayload, opts.User),
-		}[opts.Scope])
+		if scope != nil {
+			q.Scopes(scope)
+		}
 
 		return q
 	}
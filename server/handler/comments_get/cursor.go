@@ -0,0 +1,80 @@
+package comments_get
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// The cursor of the keyset pagination
+//
+// The cursor points to the last comment of previous page. The next page is the comments
+// after it in the sort order, so that the pages are stable under inserts.
+type cursor struct {
+	ID   uint   `json:"id"`   // The last comment ID of previous page
+	Sort string `json:"sort"` // The sort order which the cursor is created for
+}
+
+func encodeCursor(c cursor) string {
+	buf, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(buf, &c); err != nil || c.ID == 0 {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// Get the sort columns for the keyset pagination (the id is added as the tie-breaker)
+func getCursorSortColumns(scope Scope, sortBy SortRule) []sortColumn {
+	columns := getSortColumns(scope, sortBy)
+	return append(columns, sortColumn{"id", columns[len(columns)-1].Desc})
+}
+
+// Filter the comments after the cursor in the sort order
+//
+// The column values of the cursor comment are read by the sub-queries,
+// so that they are compared in the same format as stored in the database.
+func cursorScope(dao *dao.Dao, columns []sortColumn, id uint) func(*gorm.DB) *gorm.DB {
+	value := func(column string) *gorm.DB {
+		return dao.DB().Unscoped().Model(&entity.Comment{}).Select(column).Where("id = ?", id)
+	}
+
+	// (a > x) OR (a = x AND b > y) OR (a = x AND b = y AND c > z) ...
+	var conds []string
+	var args []any
+	for i, c := range columns {
+		var parts []string
+		for _, prev := range columns[:i] {
+			parts = append(parts, prev.Name+" = (?)")
+			args = append(args, value(prev.Name))
+		}
+		if c.Desc {
+			parts = append(parts, c.Name+" < (?)")
+		} else {
+			parts = append(parts, c.Name+" > (?)")
+		}
+		args = append(args, value(c.Name))
+		conds = append(conds, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return func(d *gorm.DB) *gorm.DB {
+		return d.Where(strings.Join(conds, " OR "), args...)
+	}
+}
@@ -0,0 +1,92 @@
+package comments_get
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestFindCommentsByCursor(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	// The comments of the page are all with the created date,
+	// and the votes are set with the ties to check the tie-breaker
+	app.Dao().DB().Model(&entity.Comment{}).Where("page_key = ?", "/test/1000.html").UpdateColumns(map[string]any{
+		"vote_up":   gorm.Expr("id % 2"),
+		"vote_down": gorm.Expr("id % 3"),
+		"hot_score": gorm.Expr("id % 2"),
+	})
+
+	pageOpts := func(sortBy SortRule) QueryOptions {
+		return QueryOptions{
+			User:        app.Dao().FindUserByID(1000),
+			Scope:       ScopePage,
+			PagePayload: PageScopePayload{SiteName: "Site A", PageKey: "/test/1000.html"},
+			SortBy:      sortBy,
+		}
+	}
+
+	getIDs := func(comments []entity.CookedComment) []uint {
+		return lo.FilterMap(comments, func(c entity.CookedComment, _ int) (uint, bool) { return c.ID, c.Visible })
+	}
+
+	for _, sortBy := range []SortRule{"", SortByDateDesc, SortByDateAsc, SortByVote, SortByTop, SortByHot, SortByControversial} {
+		t.Run("Sort_"+string(sortBy), func(t *testing.T) {
+			opts := pageOpts(sortBy)
+			all, _, _ := FindComments(app.Dao(), opts, FindOptions{Limit: 1000})
+
+			var ids []uint
+			cursor := ""
+			for i := 0; i < 100; i++ {
+				comments, count, _, next, err := FindCommentsByCursor(app.Dao(), opts, FindOptions{Limit: 2}, cursor)
+				assert.NoError(t, err)
+				assert.LessOrEqual(t, len(getIDs(comments)), 2)
+				assert.Equal(t, int64(len(all)), count)
+				ids = append(ids, getIDs(comments)...)
+				if next == "" {
+					break
+				}
+				cursor = next
+			}
+
+			assert.Equal(t, getIDs(all), ids, "should be the same as the offset pagination")
+		})
+	}
+
+	t.Run("StableUnderInserts", func(t *testing.T) {
+		opts := pageOpts(SortByDateDesc)
+
+		first, _, _, next, err := FindCommentsByCursor(app.Dao(), opts, FindOptions{Limit: 2}, "")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, next)
+
+		// A new comment is inserted on the top
+		app.Dao().DB().Create(&entity.Comment{Content: "new", PageKey: "/test/1000.html", SiteName: "Site A", UserID: 1000})
+		app.Dao().DB().Model(&entity.Comment{}).Where("content = ?", "new").Update("created_at", time.Now().Add(time.Hour))
+
+		second, _, _, _, err := FindCommentsByCursor(app.Dao(), opts, FindOptions{Limit: 2}, next)
+		assert.NoError(t, err)
+		assert.Empty(t, lo.Intersect(getIDs(first), getIDs(second)), "should not repeat the comments of previous page")
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		opts := pageOpts("")
+
+		_, _, _, _, err := FindCommentsByCursor(app.Dao(), opts, FindOptions{}, "invalid")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+
+		_, _, _, next, _ := FindCommentsByCursor(app.Dao(), opts, FindOptions{Limit: 1}, "")
+		opts.SortBy = SortByDateAsc
+		_, _, _, _, err = FindCommentsByCursor(app.Dao(), opts, FindOptions{Limit: 1}, next)
+		assert.ErrorIs(t, err, ErrInvalidCursor, "should reject the cursor of other sort order")
+
+		_, _, _, _, err = FindCommentsByCursor(app.Dao(), opts, FindOptions{}, encodeCursor(cursor{ID: 99999, Sort: getSortSQL(getCursorSortColumns(ScopePage, SortByDateAsc))}))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
@@ -11,48 +11,139 @@ type FindOptions struct {
 	Offset int
 	Limit  int
 	Nested bool
+
+	RepliesLimit int // The max number of replies of each root comment in nested mode (0 for all)
 }
 
+// The default limit of the cursor pagination
+const defaultCursorLimit = 20
+
 // Find comments by options
 func FindComments(dao *dao.Dao, opts QueryOptions, pg FindOptions) ([]entity.CookedComment, int64, int64) {
-	// Shared scopes
-	// Generated where conditions by options
-	var scopes []func(*gorm.DB) *gorm.DB
-	scopes = append(scopes, ConvertGormScopes(GetQueryScopes(dao, opts))...)
-	scopes = append(scopes, func(d *gorm.DB) *gorm.DB {
-		return d.Preload("User").Preload("Page").Preload("Page.Site")
-	})
+	scopes := getFindScopes(dao, opts)
 
 	// First query
 	var comments []*entity.Comment
-	dao.DB().Model(&entity.Comment{}).
+	findRootsQuery(dao, scopes, pg).
+		Order(GetSortSQL(opts.Scope, opts.SortBy)).
+		Offset(pg.Offset).
+		Limit(pg.Limit).
+		Find(&comments)
+
+	// Subsequent query
+	cooked := findSubsequentComments(dao, comments, scopes, pg)
+
+	// Get count
+	count, rootsCount := countComments(dao, scopes)
+
+	return cooked, count, rootsCount
+}
+
+// Find comments by the cursor (keyset pagination), the offset is ignored
+//
+// The first page is returned if the cursor is empty, and the returned next cursor
+// is empty if there is no more comments. It is faster than the offset pagination on
+// the pages with lots of comments, and the pages are stable under inserts.
+func FindCommentsByCursor(dao *dao.Dao, opts QueryOptions, pg FindOptions, cursorStr string) ([]entity.CookedComment, int64, int64, string, error) {
+	columns := getCursorSortColumns(opts.Scope, opts.SortBy)
+	sortSQL := getSortSQL(columns)
+	scopes := getFindScopes(dao, opts)
+
+	q := findRootsQuery(dao, scopes, pg)
+	if cursorStr != "" {
+		cur, err := decodeCursor(cursorStr)
+		if err != nil || cur.Sort != sortSQL {
+			return nil, 0, 0, "", ErrInvalidCursor
+		}
+
+		var exists int64
+		dao.DB().Unscoped().Model(&entity.Comment{}).Where("id = ?", cur.ID).Count(&exists)
+		if exists == 0 {
+			return nil, 0, 0, "", ErrInvalidCursor
+		}
+
+		q = q.Scopes(cursorScope(dao, columns, cur.ID))
+	}
+
+	limit := pg.Limit
+	if limit <= 0 {
+		limit = defaultCursorLimit
+	}
+
+	// Query one more to check if there is a next page
+	var comments []*entity.Comment
+	q.Order(sortSQL).Limit(limit + 1).Find(&comments)
+
+	nextCursor := ""
+	if len(comments) > limit {
+		comments = comments[:limit]
+		nextCursor = encodeCursor(cursor{ID: comments[limit-1].ID, Sort: sortSQL})
+	}
+
+	cooked := findSubsequentComments(dao, comments, scopes, pg)
+	count, rootsCount := countComments(dao, scopes)
+
+	return cooked, count, rootsCount, nextCursor, nil
+}
+
+// Find the replies of a root comment, it is used to load more replies in nested mode
+//
+// The query options should be in the page scope of the root comment.
+func FindReplies(dao *dao.Dao, opts QueryOptions, rootID uint, pg FindOptions) ([]entity.CookedComment, int64) {
+	replies, count := findReplies(dao, getFindScopes(dao, opts), rootID, pg.Offset, pg.Limit)
+	return dao.CookAllComments(replies), count
+}
+
+// Shared scopes
+// Generated where conditions by options
+func getFindScopes(dao *dao.Dao, opts QueryOptions) []func(*gorm.DB) *gorm.DB {
+	var scopes []func(*gorm.DB) *gorm.DB
+	scopes = append(scopes, ConvertGormScopes(GetQueryScopes(dao, opts))...)
+	if opts.Scope == ScopePage {
+		scopes = append(scopes, withTombstones(dao, opts.PagePayload))
+	}
+	return scopes
+}
+
+// Include the soft deleted comments which still have replies as the tombstones,
+// so that the reply threads of page are not broken
+func withTombstones(dao *dao.Dao, page PageScopePayload) func(*gorm.DB) *gorm.DB {
+	return func(d *gorm.DB) *gorm.DB {
+		pageComments := dao.DB().Model(&entity.Comment{}).Where("site_name = ? AND page_key = ?", page.SiteName, page.PageKey)
+		replied := pageComments.Session(&gorm.Session{}).Select("rid")
+		rootReplied := pageComments.Session(&gorm.Session{}).Select("root_id")
+		return d.Unscoped().Where("deleted_at IS NULL OR id IN (?) OR id IN (?)", replied, rootReplied)
+	}
+}
+
+// Preload the associations to cook the comments, which are batch loaded by one query for each association.
+// It is only for the queries to find the comments, not for the counts and the queries of ids.
+func withAssociations(d *gorm.DB) *gorm.DB {
+	return d.Preload("User").Preload("Page").Preload("Page.Site")
+}
+
+func findRootsQuery(dao *dao.Dao, scopes []func(*gorm.DB) *gorm.DB, pg FindOptions) *gorm.DB {
+	return dao.DB().Model(&entity.Comment{}).
 		Scopes(scopes...).
+		Scopes(withAssociations).
 		Scopes(func(d *gorm.DB) *gorm.DB {
 			if pg.Nested {
 				d.Scopes(OnlyRoot()) // Nested mode get only the root comments
 			}
 			return d
-		}).
-		Order(GetSortSQL(opts.Scope, opts.SortBy)).
-		Offset(pg.Offset).
-		Limit(pg.Limit).
-		Find(&comments)
+		})
+}
 
-	// Subsequent query
+func findSubsequentComments(dao *dao.Dao, comments []*entity.Comment, scopes []func(*gorm.DB) *gorm.DB, pg FindOptions) []entity.CookedComment {
 	cooked := dao.CookAllComments(comments)
 	if pg.Nested {
-		cooked = findNestedChildren(dao, cooked, scopes)
-	} else {
-		cooked = findFlatLinkedComments(dao, cooked, scopes)
-	}
-
-	// Get count
-	var count int64
-	var rootsCount int64
-	{
-		dao.DB().Model(&entity.Comment{}).Scopes(scopes...).Count(&count) // Note: Count will omit preloads
-		dao.DB().Model(&entity.Comment{}).Scopes(scopes...).Scopes(OnlyRoot()).Count(&rootsCount)
+		return findNestedChildren(dao, cooked, scopes, pg.RepliesLimit)
 	}
+	return findFlatLinkedComments(dao, cooked, scopes)
+}
 
-	return cooked, count, rootsCount
+func countComments(dao *dao.Dao, scopes []func(*gorm.DB) *gorm.DB) (count int64, rootsCount int64) {
+	dao.DB().Model(&entity.Comment{}).Scopes(scopes...).Count(&count)
+	dao.DB().Model(&entity.Comment{}).Scopes(scopes...).Scopes(OnlyRoot()).Count(&rootsCount)
+	return count, rootsCount
 }
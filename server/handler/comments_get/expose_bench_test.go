@@ -0,0 +1,149 @@
+package comments_get
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const (
+	benchPageKey  = "/bench/10k.html"
+	benchSiteName = "Site A"
+)
+
+// Seed the page with the root comments and the replies of each root by the users in turn
+func seedBenchComments(tb testing.TB, app *test.TestApp, roots int, repliesPerRoot int, users int) {
+	db := app.Dao().DB()
+	assert.NoError(tb, db.Create(&entity.Page{Key: benchPageKey, SiteName: benchSiteName}).Error)
+
+	userIDs := make([]uint, users)
+	for i := range userIDs {
+		user := entity.User{Name: fmt.Sprintf("bench_%d", i), Email: fmt.Sprintf("bench_%d@example.com", i)}
+		assert.NoError(tb, db.Create(&user).Error)
+		userIDs[i] = user.ID
+	}
+
+	created := time.Now().Add(-time.Hour)
+	newComment := func(i int, rid uint, rootID uint) *entity.Comment {
+		c := &entity.Comment{
+			Content:  fmt.Sprintf("Comment %d", i),
+			PageKey:  benchPageKey,
+			SiteName: benchSiteName,
+			UserID:   userIDs[i%len(userIDs)],
+			Rid:      rid,
+			RootID:   rootID,
+		}
+		c.CreatedAt = created.Add(time.Duration(i) * time.Millisecond)
+		return c
+	}
+
+	n := 0
+	rootComments := make([]*entity.Comment, roots)
+	for i := range rootComments {
+		rootComments[i] = newComment(n, 0, 0)
+		n++
+	}
+	assert.NoError(tb, db.CreateInBatches(rootComments, 500).Error)
+
+	replies := []*entity.Comment{}
+	for _, root := range rootComments {
+		parent := root.ID
+		for j := 0; j < repliesPerRoot; j++ {
+			replies = append(replies, newComment(n, parent, root.ID))
+			n++
+		}
+	}
+	assert.NoError(tb, db.CreateInBatches(replies, 500).Error)
+}
+
+// Count the queries executed by the func (the subqueries are built in the dry run, which are not counted)
+func countQueries(db *gorm.DB, fn func()) int64 {
+	var count atomic.Int64
+	inc := func(d *gorm.DB) {
+		if !d.DryRun {
+			count.Add(1)
+		}
+	}
+
+	const name = "bench:count_queries"
+	db.Callback().Query().After("gorm:query").Register(name, inc)
+	db.Callback().Row().After("gorm:row").Register(name, inc)
+	defer db.Callback().Query().Remove(name)
+	defer db.Callback().Row().Remove(name)
+
+	fn()
+	return count.Load()
+}
+
+func benchPageOpts() QueryOptions {
+	return QueryOptions{
+		Scope:       ScopePage,
+		PagePayload: PageScopePayload{SiteName: benchSiteName, PageKey: benchPageKey},
+	}
+}
+
+func TestFindCommentsQueryCount(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	seedBenchComments(t, app, 200, 4, 20)
+
+	cases := map[string]FindOptions{
+		"Nested":                {Limit: 20, Nested: true},
+		"Nested_Replies_Limit":  {Limit: 20, Nested: true, RepliesLimit: 2},
+		"Flat":                  {Limit: 20},
+		"Nested_Roots_x5":       {Limit: 100, Nested: true},
+		"Nested_Replies_Limit5": {Limit: 100, Nested: true, RepliesLimit: 2},
+		"Flat_x5":               {Limit: 100},
+	}
+	counts := map[string]int64{}
+	for name, pg := range cases {
+		counts[name] = countQueries(app.Dao().DB(), func() {
+			comments, _, _ := FindComments(app.Dao(), benchPageOpts(), pg)
+			assert.NotEmpty(t, comments)
+		})
+	}
+
+	// The number of queries should not grow with the number of comments
+	assert.Equal(t, counts["Nested"], counts["Nested_Roots_x5"])
+	assert.Equal(t, counts["Nested_Replies_Limit"], counts["Nested_Replies_Limit5"])
+	assert.Equal(t, counts["Flat"], counts["Flat_x5"])
+}
+
+func BenchmarkFindComments(b *testing.B) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	// 10k comments on the page
+	seedBenchComments(b, app, 2000, 4, 100)
+
+	cases := []struct {
+		name string
+		pg   FindOptions
+	}{
+		{"Nested", FindOptions{Limit: 20, Nested: true}},
+		{"Nested_Replies_Limit", FindOptions{Limit: 20, Nested: true, RepliesLimit: 3}},
+		{"Nested_Large_Page", FindOptions{Limit: 200, Nested: true, RepliesLimit: 3}},
+		{"Flat", FindOptions{Limit: 20}},
+		{"Flat_Large_Page", FindOptions{Limit: 200}},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			queries := countQueries(app.Dao().DB(), func() {
+				FindComments(app.Dao(), benchPageOpts(), c.pg)
+			})
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				FindComments(app.Dao(), benchPageOpts(), c.pg)
+			}
+			b.ReportMetric(float64(queries), "queries/op")
+		})
+	}
+}
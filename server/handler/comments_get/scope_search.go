@@ -0,0 +1,54 @@
+package comments_get
+
+import (
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/search"
+)
+
+type SearchScopePayload struct {
+	Match    search.Condition // The condition of comments matched by the keywords
+	SiteName string
+	PageKey  string
+	UserIDs  []uint // Filter by the authors (nil for all)
+	From     *time.Time
+	To       *time.Time
+}
+
+// Search Scope (for the comment search by keywords)
+func SearchScopeQuery(payload SearchScopePayload, user entity.User) func(liteDB) liteDB {
+	return func(q liteDB) liteDB {
+		if payload.Match.Query == "" {
+			// the keywords are required
+			return q.Where("1 = 0")
+		}
+		q.Where(payload.Match.Query, payload.Match.Args...)
+
+		if payload.SiteName != "" {
+			q.Where("site_name = ?", payload.SiteName)
+		}
+
+		if sites := user.GetAdminSites(); user.IsAdmin && sites != nil {
+			// only the sites which the admin is allowed to manage
+			q.Where("site_name IN (?)", sites)
+		}
+
+		if payload.PageKey != "" {
+			q.Where("page_key = ?", payload.PageKey)
+		}
+
+		if payload.UserIDs != nil {
+			q.Where("user_id IN (?)", payload.UserIDs)
+		}
+
+		if payload.From != nil {
+			q.Where("created_at >= ?", *payload.From)
+		}
+		if payload.To != nil {
+			q.Where("created_at <= ?", *payload.To)
+		}
+
+		return q
+	}
+}
@@ -30,6 +30,11 @@ func SiteScopeQuery(payload SitePayload, user entity.User) func(liteDB) liteDB {
 			q.Where("site_name = ?", payload.SiteName)
 		}
 
+		if sites := user.GetAdminSites(); sites != nil {
+			// only the sites which the admin is allowed to manage
+			q.Where("site_name IN (?)", sites)
+		}
+
 		scopes := map[SiteScopeType]func(liteDB) liteDB{
 			SiteAll: func(d liteDB) liteDB {
 				return q
@@ -109,6 +109,19 @@ func TestSiteScopeQuery(t *testing.T) {
 				assert.Equal(t, "Site B", comments[0].SiteName)
 			},
 		},
+		{
+			name: "Site-scoped admin",
+			payload: SitePayload{
+				Type: SiteAll,
+			},
+			user: entity.User{IsAdmin: true, AdminRole: entity.AdminRoleModerator, AdminSites: "Site B"},
+			want: func(comments []entity.Comment) {
+				assert.Greater(t, len(comments), 0)
+				for _, c := range comments {
+					assert.Equal(t, "Site B", c.SiteName, "should only have the assigned site")
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
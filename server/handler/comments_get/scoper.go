@@ -9,11 +9,14 @@ import (
 // Basic scope for all queries
 //
 // It will ignore pending comments for non-admin users
+// and the pending comments of other sites for the site-scoped admins
 func CommonScope(user entity.User) func(liteDB) liteDB {
 	return func(d liteDB) liteDB {
 		// Ignore pending comments
 		if !user.IsAdmin { // If not admin, ignore pending comments
 			d.Scopes(NoPending(user.ID))
+		} else if sites := user.GetAdminSites(); sites != nil {
+			d.Where("is_pending = ? OR site_name IN (?)", false, sites)
 		}
 
 		return d
@@ -38,6 +41,17 @@ func NoPending(allowUserID ...uint) func(db liteDB) liteDB {
 	}
 }
 
+// Ignore the comments of shadow banned users, except for the comments of the viewer self
+// (matched by the user ID or the IP)
+func NoShadowBanned(bannedUserIDs any, viewerID uint, viewerIP string) func(liteDB) liteDB {
+	return func(d liteDB) liteDB {
+		if viewerIP != "" {
+			return d.Where("user_id NOT IN (?) OR user_id = ? OR ip = ?", bannedUserIDs, viewerID, viewerIP)
+		}
+		return d.Where("user_id NOT IN (?) OR user_id = ?", bannedUserIDs, viewerID)
+	}
+}
+
 // Filter by search keywords
 func SearchScope(dao *dao.Dao, keywords string) func(d liteDB) liteDB {
 	var userIds []uint
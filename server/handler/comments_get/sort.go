@@ -1,27 +1,73 @@
 package comments_get
 
+import "strings"
+
 type SortRule string
 
 const (
-	SortByDateDesc SortRule = "date_desc"
-	SortByDateAsc  SortRule = "date_asc"
-	SortByVote     SortRule = "vote"
+	SortByDateDesc      SortRule = "date_desc"
+	SortByDateAsc       SortRule = "date_asc"
+	SortByVote          SortRule = "vote"
+	SortByReaction      SortRule = "reactions"
+	SortByTop           SortRule = "top"           // The most net votes
+	SortByHot           SortRule = "hot"           // The most net votes with time decay
+	SortByControversial SortRule = "controversial" // The most balanced up and down votes
 )
 
-// Get sort rule
-func GetSortSQL(scope Scope, sortBy SortRule) string {
+type sortColumn struct {
+	Name string // The column name or SQL expression
+	Desc bool
+}
+
+// Get the columns of sort rule
+//
+// The pinned comments are always placed at the top of page.
+func getSortColumns(scope Scope, sortBy SortRule) []sortColumn {
+	columns := getRuleSortColumns(sortBy)
+	if scope == ScopePage {
+		return append([]sortColumn{{"is_pinned", true}}, columns...)
+	}
+	return columns
+}
+
+func getRuleSortColumns(sortBy SortRule) []sortColumn {
 	switch sortBy {
 	case SortByDateDesc:
-		return "created_at DESC"
+		return []sortColumn{{"created_at", true}}
 	case SortByDateAsc:
-		return "created_at ASC"
+		return []sortColumn{{"created_at", false}}
 	case SortByVote:
-		return "vote_up DESC, created_at DESC"
+		return []sortColumn{{"vote_up", true}, {"created_at", true}}
+	case SortByReaction:
+		return []sortColumn{{"reaction_count", true}, {"created_at", true}}
+	case SortByTop:
+		return []sortColumn{{"(vote_up - vote_down)", true}, {"created_at", true}}
+	case SortByHot:
+		return []sortColumn{{"hot_score", true}, {"created_at", true}}
+	case SortByControversial:
+		return []sortColumn{
+			{"(CASE WHEN vote_up < vote_down THEN vote_up ELSE vote_down END)", true},
+			{"(vote_up + vote_down)", true},
+			{"created_at", true},
+		}
 	}
 
-	if scope == ScopePage {
-		return "is_pinned DESC, created_at DESC"
-	}
+	return []sortColumn{{"created_at", true}}
+}
+
+// Get sort rule
+func GetSortSQL(scope Scope, sortBy SortRule) string {
+	return getSortSQL(getSortColumns(scope, sortBy))
+}
 
-	return "created_at DESC"
+func getSortSQL(columns []sortColumn) string {
+	var orders []string
+	for _, c := range columns {
+		if c.Desc {
+			orders = append(orders, c.Name+" DESC")
+		} else {
+			orders = append(orders, c.Name+" ASC")
+		}
+	}
+	return strings.Join(orders, ", ")
 }
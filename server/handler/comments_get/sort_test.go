@@ -0,0 +1,47 @@
+package comments_get
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/test"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortByVotes(t *testing.T) {
+	app, _ := test.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now()
+	comments := []entity.Comment{
+		{VoteUp: 10, VoteDown: 0},
+		{VoteUp: 6, VoteDown: 5},
+		{VoteUp: 3, VoteDown: 0},
+		{VoteUp: 12, VoteDown: 0},
+	}
+	createdAt := []time.Time{now.Add(-48 * time.Hour), now.Add(-time.Hour), now, now.Add(-96 * time.Hour)}
+	for i := range comments {
+		comments[i].PageKey = "/test_sort.html"
+		comments[i].SiteName = "Site A"
+		comments[i].UserID = 1000
+		comments[i].CreatedAt = createdAt[i]
+		assert.NoError(t, app.Dao().CreateComment(&comments[i]))
+	}
+
+	getIDs := func(sortBy SortRule) []uint {
+		found, _, _ := FindComments(app.Dao(), QueryOptions{
+			User:        app.Dao().FindUserByID(1000),
+			Scope:       ScopePage,
+			PagePayload: PageScopePayload{SiteName: "Site A", PageKey: "/test_sort.html"},
+			SortBy:      sortBy,
+		}, FindOptions{Limit: 10})
+		return lo.Map(found, func(c entity.CookedComment, _ int) uint { return c.ID })
+	}
+	ids := lo.Map(comments, func(c entity.Comment, _ int) uint { return c.ID })
+
+	assert.Equal(t, []uint{ids[3], ids[0], ids[2], ids[1]}, getIDs(SortByTop))
+	assert.Equal(t, []uint{ids[2], ids[1], ids[0], ids[3]}, getIDs(SortByHot), "the newer comments should be ranked higher with time decay")
+	assert.Equal(t, ids[1], getIDs(SortByControversial)[0])
+}
@@ -8,20 +8,94 @@ import (
 )
 
 // Find all nested children (for nested mode)
-func findNestedChildren(dao *dao.Dao, comments []entity.CookedComment, commonScopes []func(*gorm.DB) *gorm.DB) []entity.CookedComment {
+//
+// If the repliesLimit is greater than 0, only the first N replies of each root comment are loaded,
+// and the `RepliesCount` of root comments is set for the client-side to load more by `FindReplies`.
+func findNestedChildren(dao *dao.Dao, comments []entity.CookedComment, commonScopes []func(*gorm.DB) *gorm.DB, repliesLimit int) []entity.CookedComment {
 	allRootIDs := lo.Map(comments, func(c entity.CookedComment, _ int) uint { return c.ID })
-	// TODO: Add pagination for nested mode
-	// 	All children will be loaded at once without pagination, which may cause performance issues.
-	// 	The backend will response all to the client-side, and render by the client-side itself.
-	var children []*entity.Comment
+	if len(allRootIDs) == 0 {
+		return comments
+	}
+
+	if repliesLimit <= 0 {
+		// All children will be loaded at once, and render by the client-side itself.
+		var children []*entity.Comment
+		dao.DB().Model(&entity.Comment{}).
+			Scopes(commonScopes...).
+			Scopes(withAssociations).
+			Where("root_id IN ? AND rid != 0", allRootIDs).
+			Find(&children)
+		comments = append(comments, dao.CookAllComments(children)...)
+		return comments
+	}
+
+	// Only the ids of the replies are queried to pick the first N replies of each root,
+	// then the picked ones are loaded at once, so the number of queries does not grow with the roots.
+	var refs []replyRef
 	dao.DB().Model(&entity.Comment{}).
 		Scopes(commonScopes...).
+		Select("id", "root_id").
 		Where("root_id IN ? AND rid != 0", allRootIDs).
-		Find(&children)
-	comments = append(comments, dao.CookAllComments(children)...)
+		Order("created_at ASC, id ASC").
+		Find(&refs)
+
+	counts := map[uint]int{}
+	pickedIDs := []uint{}
+	for _, ref := range refs {
+		if counts[ref.RootID] < repliesLimit {
+			pickedIDs = append(pickedIDs, ref.ID)
+		}
+		counts[ref.RootID]++
+	}
+
+	var picked []*entity.Comment
+	if len(pickedIDs) > 0 {
+		dao.DB().Model(&entity.Comment{}).
+			Scopes(commonScopes...).
+			Scopes(withAssociations).
+			Where("id IN ?", pickedIDs).
+			Order("created_at ASC, id ASC").
+			Find(&picked)
+	}
+	repliesOfRoots := lo.GroupBy(picked, func(c *entity.Comment) uint { return c.RootID })
+
+	for i, rootID := range allRootIDs {
+		comments[i].RepliesCount = counts[rootID]
+		comments = append(comments, dao.CookAllComments(repliesOfRoots[rootID])...)
+	}
 	return comments
 }
 
+// The reference of reply to pick the replies of root comments
+type replyRef struct {
+	ID     uint
+	RootID uint
+}
+
+// Find the replies of root comment in the created order
+//
+// The parent of a reply is always created before the reply, so that
+// the replies of any range are able to be linked to the loaded ones.
+func findReplies(dao *dao.Dao, commonScopes []func(*gorm.DB) *gorm.DB, rootID uint, offset int, limit int) ([]*entity.Comment, int64) {
+	var children []*entity.Comment
+	dao.DB().Model(&entity.Comment{}).
+		Scopes(commonScopes...).
+		Scopes(withAssociations).
+		Where("root_id = ? AND rid != 0", rootID).
+		Order("created_at ASC, id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&children)
+
+	var count int64
+	dao.DB().Model(&entity.Comment{}).
+		Scopes(commonScopes...).
+		Where("root_id = ? AND rid != 0", rootID).
+		Count(&count)
+
+	return children, count
+}
+
 // Find all linked comments (for flat mode)
 func findFlatLinkedComments(dao *dao.Dao, comments []entity.CookedComment, commonScopes []func(*gorm.DB) *gorm.DB) []entity.CookedComment {
 	allCommentIDs := map[uint]bool{}
@@ -40,6 +114,7 @@ func findFlatLinkedComments(dao *dao.Dao, comments []entity.CookedComment, commo
 	if len(missCommentIDs) > 0 {
 		dao.DB().Where("id IN ?", missCommentIDs).
 			Scopes(commonScopes...).
+			Scopes(withAssociations).
 			Find(&linkedComments)
 	}
 
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           DeleteEmailSuppression
+// @Summary      Delete Email Suppression
+// @Description  Remove the address from the suppression list, so the emails are sent to it again
+// @Tags         System
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The suppression ID"
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /email_suppressions/{id}  [delete]
+func EmailSuppressionDelete(app *core.App, router fiber.Router) {
+	router.Delete("/email_suppressions/:id", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		id, _ := c.ParamsInt("id")
+
+		suppression := app.Dao().FindEmailSuppressionByID(uint(id))
+		if suppression.IsEmpty() {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": "suppression"}))
+		}
+
+		if err := app.Dao().DelEmailSuppression(&suppression); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": "suppression"}))
+		}
+
+		return common.RespSuccess(c)
+	}))
+}
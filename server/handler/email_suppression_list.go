@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ParamsEmailSuppressionList struct {
+	Search string `query:"search" json:"search" validate:"optional"` // Search by the email address
+	Limit  int    `query:"limit" json:"limit" validate:"optional"`   // The limit for pagination (default: 20)
+	Offset int    `query:"offset" json:"offset" validate:"optional"` // The offset for pagination
+}
+
+type ResponseEmailSuppressionList struct {
+	Suppressions []entity.CookedEmailSuppression `json:"suppressions"` // The suppressed addresses in the latest order
+	Count        int64                           `json:"count"`
+}
+
+// @Id           GetEmailSuppressions
+// @Summary      Get Email Suppressions
+// @Description  Get the email addresses which are not sent to anymore, reported by the bounce or complaint webhook of email service provider
+// @Tags         System
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsEmailSuppressionList  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseEmailSuppressionList
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /email_suppressions  [get]
+func EmailSuppressionList(app *core.App, router fiber.Router) {
+	router.Get("/email_suppressions", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		var p ParamsEmailSuppressionList
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.Limit <= 0 || p.Limit > 100 {
+			p.Limit = 20
+		}
+
+		suppressions, count := app.Dao().FindEmailSuppressions(p.Search, p.Offset, p.Limit)
+
+		return common.RespData(c, ResponseEmailSuppressionList{
+			Suppressions: lo.Map(suppressions, func(s entity.EmailSuppression, _ int) entity.CookedEmailSuppression {
+				return app.Dao().CookEmailSuppression(&s)
+			}),
+			Count: count,
+		})
+	}))
+}
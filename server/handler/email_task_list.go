@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ParamsEmailTaskList struct {
+	Status string `query:"status" json:"status" validate:"optional"` // Filter by the status ("pending", "sending", "sent" or "dead")
+	Limit  int    `query:"limit" json:"limit" validate:"optional"`   // The limit for pagination (default: 20)
+	Offset int    `query:"offset" json:"offset" validate:"optional"` // The offset for pagination
+}
+
+type ResponseEmailTaskList struct {
+	Tasks []entity.CookedEmailTask `json:"tasks"` // The outgoing emails in the latest order
+	Count int64                    `json:"count"`
+}
+
+// @Id           GetEmailTasks
+// @Summary      Get Email Tasks
+// @Description  Get the outgoing emails in the sending queue, the emails still failed after all the retries are in the "dead" status
+// @Tags         System
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsEmailTaskList  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseEmailTaskList
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /email_tasks  [get]
+func EmailTaskList(app *core.App, router fiber.Router) {
+	router.Get("/email_tasks", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		var p ParamsEmailTaskList
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.Limit <= 0 || p.Limit > 100 {
+			p.Limit = 20
+		}
+
+		tasks, count := app.Dao().FindEmailTasks(p.Status, p.Offset, p.Limit)
+
+		return common.RespData(c, ResponseEmailTaskList{
+			Tasks: lo.Map(tasks, func(t entity.EmailTask, _ int) entity.CookedEmailTask {
+				return app.Dao().CookEmailTask(&t)
+			}),
+			Count: count,
+		})
+	}))
+}
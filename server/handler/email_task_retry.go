@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           RetryEmailTask
+// @Summary      Retry Email Task
+// @Description  Send a dead (or pending) email again immediately, the attempts are reset
+// @Tags         System
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The email task ID"
+// @Produce      json
+// @Success      200  {object}  entity.CookedEmailTask
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /email_tasks/{id}/retry  [post]
+func EmailTaskRetry(app *core.App, router fiber.Router) {
+	router.Post("/email_tasks/:id/retry", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		id, _ := c.ParamsInt("id")
+
+		task := app.Dao().FindEmailTaskByID(uint(id))
+		if task.IsEmpty() {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": "email"}))
+		}
+
+		emailService, err := core.AppService[*core.EmailService](app)
+		if err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+
+		task, err = emailService.Retry(task)
+		if err != nil {
+			return common.RespError(c, 400, err.Error())
+		}
+
+		return common.RespData(c, app.Dao().CookEmailTask(&task))
+	}))
+}
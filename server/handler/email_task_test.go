@@ -0,0 +1,62 @@
+package handler_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestEmailTask(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.EmailTaskList(app.App, api)
+	handler.EmailTaskRetry(app.App, api)
+
+	request := func(userID uint, method string, url string) (int, gjson.Result) {
+		token, err := common.LoginGetUserToken(app.Dao().FindUserByID(userID), app.Conf().AppKey, app.Conf().LoginTimeout)
+		assert.NoError(t, err)
+		return NewApiRequest(t, api)(method, url, token, "")
+	}
+
+	dead, err := app.Dao().NewEmailTask(entity.EmailTask{Status: entity.EmailTaskStatusDead, ToAddr: "a@example.com", Subject: "Dead", Attempts: 6, Error: "connection refused"})
+	assert.NoError(t, err)
+	sent, err := app.Dao().NewEmailTask(entity.EmailTask{Status: entity.EmailTaskStatusSent, ToAddr: "b@example.com", Subject: "Sent", Attempts: 1})
+	assert.NoError(t, err)
+
+	t.Run("List", func(t *testing.T) {
+		code, data := request(1000, "GET", "/email_tasks")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(2), data.Get("count").Int())
+		assert.Equal(t, "Sent", data.Get("tasks.0.subject").String())
+
+		code, data = request(1000, "GET", "/email_tasks?status=dead")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(1), data.Get("count").Int())
+		assert.Equal(t, "connection refused", data.Get("tasks.0.error").String())
+		assert.Equal(t, int64(6), data.Get("tasks.0.attempts").Int())
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		code, data := request(1000, "POST", fmt.Sprintf("/email_tasks/%d/retry", dead.ID))
+		assert.Equal(t, 200, code)
+		assert.NotEqual(t, entity.EmailTaskStatusDead, data.Get("status").String())
+		assert.LessOrEqual(t, data.Get("attempts").Int(), int64(1))
+
+		code, _ = request(1000, "POST", fmt.Sprintf("/email_tasks/%d/retry", sent.ID))
+		assert.Equal(t, 400, code)
+
+		code, _ = request(1000, "POST", "/email_tasks/99999/retry")
+		assert.Equal(t, 404, code)
+	})
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		code, _ := request(1001, "GET", "/email_tasks")
+		assert.Equal(t, 403, code)
+	})
+}
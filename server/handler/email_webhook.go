@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/email"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           EmailWebhook
+// @Summary      Email Provider Webhook
+// @Description  Receive the bounce and complaint events of the email service provider ("ses", "mailgun", "postmark" or "resend"), the addresses are suppressed to not send to anymore. The Mailgun and Resend webhooks are verified by the signature, and the SES (SNS) and Postmark webhooks by the `token` query
+// @Tags         System
+// @Param        provider  path   string  true   "The email service provider"
+// @Param        token     query  string  false  "The webhook token of SES and Postmark"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /email/{provider}/webhook  [post]
+func EmailWebhook(app *core.App, router fiber.Router) {
+	router.Post("/email/:provider/webhook", func(c *fiber.Ctx) error {
+		conf := app.Conf().Email
+		provider := c.Params("provider")
+
+		var suppressions []email.Suppression
+		var err error
+		switch provider {
+		case "ses":
+			if !email.CheckWebhookToken(conf.SES.WebhookToken, c.Query("token")) {
+				return common.RespError(c, 403, i18n.T("Permission denied"))
+			}
+
+			var subscribeURL string
+			subscribeURL, suppressions, err = email.ParseSESNotification(c.Body())
+			if err == nil && subscribeURL != "" {
+				client := utils.NewHTTPClient(app.Conf().HTTP.OutboundProxy, 10*time.Second)
+				if err := email.ConfirmSESSubscription(client, subscribeURL); err != nil {
+					log.Error("[EmailWebhook] ", err)
+					return common.RespError(c, 500, err.Error())
+				}
+				log.Info("[EmailWebhook] The SNS subscription of SES is confirmed")
+			}
+		case "mailgun":
+			suppressions, err = email.ParseMailgunWebhook(conf.Mailgun.WebhookSigningKey, c.Body(), time.Now())
+		case "postmark":
+			if !email.CheckWebhookToken(conf.Postmark.WebhookToken, c.Query("token")) {
+				return common.RespError(c, 403, i18n.T("Permission denied"))
+			}
+			suppressions, err = email.ParsePostmarkWebhook(c.Body())
+		case "resend":
+			header := http.Header{}
+			for _, name := range []string{"svix-id", "svix-timestamp", "svix-signature"} {
+				header.Set(name, c.Get(name))
+			}
+			suppressions, err = email.ParseResendWebhook(conf.Resend.WebhookSecret, header, c.Body(), time.Now())
+		default:
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": provider}))
+		}
+		if errors.Is(err, email.ErrInvalidSignature) {
+			return common.RespError(c, 403, i18n.T("Permission denied"))
+		} else if err != nil {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "payload"}))
+		}
+
+		for _, s := range suppressions {
+			if s.Email == "" {
+				continue
+			}
+			if _, err := app.Dao().SaveEmailSuppression(s.Email, s.Reason, provider, s.Detail); err != nil {
+				return common.RespError(c, 500, err.Error())
+			}
+			log.Info("[EmailWebhook] Suppressed the address ", s.Email, " (", s.Reason, " reported by ", provider, ")")
+		}
+
+		return common.RespSuccess(c)
+	})
+}
@@ -0,0 +1,97 @@
+package handler_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailWebhook(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.EmailWebhook(app.App, api)
+	handler.EmailSuppressionList(app.App, api)
+	handler.EmailSuppressionDelete(app.App, api)
+
+	app.Conf().Email.Mailgun.WebhookSigningKey = "signing-key"
+	app.Conf().Email.Postmark.WebhookToken = "postmark-token"
+
+	post := func(url string, body string) int {
+		req := httptest.NewRequest("POST", url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		io.ReadAll(resp.Body)
+		return resp.StatusCode
+	}
+	mailgunEvent := func(key string, eventData string) string {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(ts + "token"))
+		return fmt.Sprintf(`{"signature":{"timestamp":%q,"token":"token","signature":%q},"event-data":%s}`,
+			ts, hex.EncodeToString(mac.Sum(nil)), eventData)
+	}
+
+	t.Run("Mailgun", func(t *testing.T) {
+		assert.Equal(t, 200, post("/email/mailgun/webhook", mailgunEvent("signing-key",
+			`{"event":"failed","severity":"permanent","recipient":"Bounced@Example.com","delivery-status":{"description":"No such user"}}`)))
+
+		suppression := app.Dao().FindEmailSuppression("bounced@example.com")
+		assert.Equal(t, "bounce", suppression.Reason)
+		assert.Equal(t, "mailgun", suppression.Provider)
+		assert.Equal(t, "No such user", suppression.Detail)
+		assert.True(t, app.Dao().IsEmailSuppressed("BOUNCED@example.com"))
+
+		assert.Equal(t, 403, post("/email/mailgun/webhook", mailgunEvent("wrong-key",
+			`{"event":"complained","recipient":"forged@example.com"}`)))
+		assert.False(t, app.Dao().IsEmailSuppressed("forged@example.com"))
+	})
+
+	t.Run("Postmark", func(t *testing.T) {
+		body := `{"RecordType":"SpamComplaint","Email":"complained@example.com"}`
+		assert.Equal(t, 403, post("/email/postmark/webhook?token=wrong", body))
+		assert.False(t, app.Dao().IsEmailSuppressed("complained@example.com"))
+
+		assert.Equal(t, 200, post("/email/postmark/webhook?token=postmark-token", body))
+		assert.Equal(t, "complaint", app.Dao().FindEmailSuppression("complained@example.com").Reason)
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		assert.Equal(t, 403, post("/email/ses/webhook?token=", `{"Type":"Notification"}`))
+		assert.Equal(t, 403, post("/email/resend/webhook", `{"type":"email.bounced"}`))
+		assert.Equal(t, 404, post("/email/unknown/webhook", `{}`))
+	})
+
+	t.Run("Suppressions", func(t *testing.T) {
+		token, err := common.LoginGetUserToken(app.Dao().FindUserByID(1000), app.Conf().AppKey, app.Conf().LoginTimeout)
+		assert.NoError(t, err)
+		request := NewApiRequest(t, api)
+
+		code, data := request("GET", "/email_suppressions", token, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(2), data.Get("count").Int())
+
+		code, data = request("GET", "/email_suppressions?search=bounced", token, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "bounced@example.com", data.Get("suppressions.0.email").String())
+
+		code, _ = request("DELETE", fmt.Sprintf("/email_suppressions/%d", data.Get("suppressions.0.id").Int()), token, "")
+		assert.Equal(t, 200, code)
+		assert.False(t, app.Dao().IsEmailSuppressed("bounced@example.com"))
+
+		code, _ = request("DELETE", "/email_suppressions/99999", token, "")
+		assert.Equal(t, 404, code)
+	})
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/feed"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type ParamsFeed struct {
+	SiteName string `query:"site_name" validate:"required"` // The site name
+	PageKey  string `query:"page_key" validate:"optional"`  // The page key (the comments of whole site if empty)
+	Format   string `query:"format" validate:"optional"`    // The feed format ["rss", "atom"] (default: "rss")
+	Limit    int    `query:"limit" validate:"optional"`     // The number of latest comments (default: 20, max: 100)
+}
+
+// @Id           GetCommentsFeed
+// @Summary      Get Comments Feed
+// @Description  Get the RSS or Atom feed of the latest comments of a page or a whole site
+// @Tags         Feed
+// @Param        options  query  ParamsFeed  true  "The options"
+// @Produce      xml
+// @Success      200  {string}  string
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /feeds/comments  [get]
+func FeedComments(app *core.App, router fiber.Router) {
+	router.Get("/feeds/comments", func(c *fiber.Ctx) error {
+		var p ParamsFeed
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		site, ok, resp := common.CheckSiteExist(app, c, p.SiteName)
+		if !ok {
+			return resp
+		}
+
+		title := site.Name
+		link := site.FirstUrl
+		if p.PageKey != "" {
+			page := app.Dao().FindPage(p.PageKey, site.Name)
+			if page.IsEmpty() {
+				return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Page")}))
+			}
+			title = cmp.Or(page.Title, page.Key)
+			link = app.Dao().GetPageAccessibleURL(&page)
+		}
+
+		return respCommentsFeed(app, c, p, feed.Feed{
+			Title:       fmt.Sprintf("Comments on %s", title),
+			Link:        link,
+			Description: fmt.Sprintf("The latest comments on %s", title),
+		}, func(q *gorm.DB) *gorm.DB {
+			q = q.Where("site_name = ? AND is_pending = ?", site.Name, false).Scopes(app.Dao().NoShadowBanned)
+			if p.PageKey != "" {
+				q = q.Where("page_key = ?", p.PageKey)
+			}
+			return q
+		})
+	})
+}
+
+type ParamsFeedPending struct {
+	SiteName string `query:"site_name" validate:"optional"` // The site name (all the sites of admin if empty)
+	Format   string `query:"format" validate:"optional"`    // The feed format ["rss", "atom"] (default: "rss")
+	Limit    int    `query:"limit" validate:"optional"`     // The number of latest comments (default: 20, max: 100)
+}
+
+// @Id           GetPendingCommentsFeed
+// @Summary      Get Pending Comments Feed
+// @Description  Get the RSS or Atom feed of the latest comments pending moderation, the feed readers pass the API key of `feeds:read` scope by the `key` query
+// @Tags         Feed
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsFeedPending  true  "The options"
+// @Produce      xml
+// @Success      200  {string}  string
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /feeds/pending  [get]
+func FeedPending(app *core.App, router fiber.Router) {
+	handler := func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsFeedPending
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		title := "all sites"
+		if p.SiteName != "" {
+			if ok, resp := common.CheckAdminSite(c, admin, p.SiteName); !ok {
+				return resp
+			}
+			title = p.SiteName
+		}
+
+		return respCommentsFeed(app, c, ParamsFeed{
+			SiteName: p.SiteName,
+			Format:   p.Format,
+			Limit:    p.Limit,
+		}, feed.Feed{
+			Title:       fmt.Sprintf("Pending comments on %s", title),
+			Description: fmt.Sprintf("The latest comments pending moderation on %s", title),
+		}, func(q *gorm.DB) *gorm.DB {
+			q = q.Where("is_pending = ?", true)
+			if p.SiteName != "" {
+				q = q.Where("site_name = ?", p.SiteName)
+			}
+			if sites := admin.GetAdminSites(); sites != nil {
+				q = q.Where("site_name IN (?)", sites)
+			}
+			return q
+		})
+	}
+	guard := common.AdminPermGuard(app, entity.AdminPermRead, handler)
+
+	router.Get("/feeds/pending", func(c *fiber.Ctx) error {
+		// The feed readers cannot set the request header, so the dedicated API key which is revocable is passed by query
+		if raw := strings.TrimSpace(c.Query("key")); raw != "" {
+			key := app.Dao().FindAPIKey(raw)
+			if key.IsEmpty() {
+				return common.RespError(c, 401, i18n.T("Invalid API key"))
+			}
+			if !key.HasScope(entity.APIKeyScopeFeeds) {
+				return common.RespError(c, 403, i18n.T("API key scope is not allowed"), Map{"need_scope": entity.APIKeyScopeFeeds})
+			}
+			app.Dao().TouchAPIKey(&key)
+			return handler(c, key.AdminUser())
+		}
+
+		// The admin token is not accepted by query, which would be leaked by the logs of proxies and feed readers
+		if c.Query("token") != "" {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "token"}), Map{"err": "use the `key` query of an API key with the `feeds:read` scope instead"})
+		}
+
+		return guard(c)
+	})
+}
+
+// Response the feed of the latest comments found by the scope
+func respCommentsFeed(app *core.App, c *fiber.Ctx, p ParamsFeed, f feed.Feed, scope func(*gorm.DB) *gorm.DB) error {
+	format := cmp.Or(p.Format, feed.FormatRSS)
+	contentType := feed.ContentType(format)
+	if contentType == "" {
+		return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "format"}))
+	}
+
+	if p.Limit <= 0 {
+		p.Limit = 20
+	}
+	p.Limit = min(p.Limit, 100)
+
+	var comments []*entity.Comment
+	app.Dao().DB().Model(&entity.Comment{}).
+		Scopes(scope).
+		Order("created_at DESC, id DESC").
+		Limit(p.Limit).
+		Find(&comments)
+
+	f.SelfLink = getFeedSelfLink(c)
+	f.Updated = time.Now()
+	if len(comments) > 0 {
+		f.Updated = comments[0].CreatedAt
+	}
+
+	for _, comment := range comments {
+		cooked := app.Dao().CookComment(comment)
+		page := app.Dao().FetchPageForComment(comment)
+		f.Items = append(f.Items, feed.Item{
+			Title:     fmt.Sprintf("%s on %s", cooked.Nick, cmp.Or(page.Title, page.Key)),
+			Link:      app.Dao().GetLinkToReplyByComment(comment),
+			Author:    cooked.Nick,
+			Content:   cooked.ContentMarked,
+			Published: comment.CreatedAt,
+		})
+	}
+
+	body, err := feed.Render(f, format)
+	if err != nil {
+		return common.RespError(c, 500, err.Error())
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Send(body)
+}
+
+// Get the URL of feed itself without the token and key
+func getFeedSelfLink(c *fiber.Ctx) string {
+	query, _ := url.ParseQuery(string(c.Request().URI().QueryString()))
+	query.Del("token")
+	query.Del("key")
+
+	link := c.BaseURL() + c.Path()
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	return link
+}
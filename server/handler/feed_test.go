@@ -0,0 +1,115 @@
+package handler_test
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeed(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.FeedComments(app.App, api)
+	handler.FeedPending(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	type rssFeed struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title string `xml:"title"`
+				Link  string `xml:"link"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	request := func(url string) (int, string, rssFeed) {
+		resp, err := api.Test(httptest.NewRequest("GET", url, nil))
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		var f rssFeed
+		_ = xml.Unmarshal(buf, &f)
+		return resp.StatusCode, resp.Header.Get("Content-Type"), f
+	}
+
+	t.Run("PageFeed", func(t *testing.T) {
+		code, contentType, f := request("/feeds/comments?site_name=Site+A&page_key=/test/1000.html&limit=2")
+		assert.Equal(t, 200, code)
+		assert.Contains(t, contentType, "application/rss+xml")
+		if assert.Len(t, f.Channel.Items, 2, "should be limited") {
+			assert.Contains(t, f.Channel.Items[0].Link, "atk_comment=1003", "should be the latest comment first")
+			assert.Contains(t, f.Channel.Items[1].Link, "atk_comment=1004")
+		}
+	})
+
+	t.Run("SiteFeed", func(t *testing.T) {
+		code, _, f := request("/feeds/comments?site_name=Site+B")
+		assert.Equal(t, 200, code)
+		if assert.Len(t, f.Channel.Items, 1, "should not include the pending comments") {
+			assert.Contains(t, f.Channel.Items[0].Link, "atk_comment=1006")
+		}
+	})
+
+	t.Run("AtomFeed", func(t *testing.T) {
+		resp, err := api.Test(httptest.NewRequest("GET", "/feeds/comments?site_name=Site+A&format=atom", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "application/atom+xml")
+	})
+
+	t.Run("InvalidFeed", func(t *testing.T) {
+		code, _, _ := request("/feeds/comments?site_name=Site+A&format=json")
+		assert.Equal(t, 400, code)
+
+		code, _, _ = request("/feeds/comments?site_name=Site+A&page_key=/not-found.html")
+		assert.Equal(t, 404, code)
+
+		code, _, _ = request("/feeds/comments?site_name=Unknown")
+		assert.Equal(t, 404, code)
+	})
+
+	t.Run("PendingFeed", func(t *testing.T) {
+		code, _, _ := request("/feeds/pending")
+		assert.Equal(t, 403, code, "should require admin")
+
+		code, _, _ = request("/feeds/pending?token=" + token)
+		assert.Equal(t, 400, code, "should not accept the admin token by query")
+
+		req := httptest.NewRequest("GET", "/feeds/pending", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		key, plain, err := app.Dao().NewAPIKey("Feed reader", []string{entity.APIKeyScopeFeeds}, 0, admin.ID)
+		assert.NoError(t, err)
+
+		code, _, f := request("/feeds/pending?key=" + plain)
+		assert.Equal(t, 200, code)
+		if assert.Len(t, f.Channel.Items, 1) {
+			assert.Contains(t, f.Channel.Items[0].Link, "atk_comment=1007")
+		}
+
+		code, _, f = request("/feeds/pending?site_name=Site+A&key=" + plain)
+		assert.Equal(t, 200, code)
+		assert.Empty(t, f.Channel.Items)
+
+		_, readPlain, err := app.Dao().NewAPIKey("Reader", []string{entity.APIKeyScopeCommentsRead}, 0, admin.ID)
+		assert.NoError(t, err)
+		code, _, _ = request("/feeds/pending?key=" + readPlain)
+		assert.Equal(t, 403, code, "should require the feeds scope")
+
+		assert.NoError(t, app.Dao().DelAPIKey(&key))
+		code, _, _ = request("/feeds/pending?key=" + plain)
+		assert.Equal(t, 401, code, "should be revoked")
+	})
+}
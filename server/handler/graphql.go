@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/graphql"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	cog "github.com/artalkjs/artalk/v2/server/handler/comments_get"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	graphqlMaxLimit  = 100 // The max number of items of each list field
+	graphqlRateLimit = 60  // The max number of queries of each IP per minute
+)
+
+// @Id           QueryGraphQL
+// @Summary      GraphQL Query
+// @Description  Query the public comments, pages, sites and users by GraphQL with the field selection and nested queries (only the `query` operation is supported)
+// @Tags         GraphQL
+// @Param        request  body  graphql.Request  true  "The GraphQL request"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  graphql.Response
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      429  {object}  Map{msg=string}
+// @Router       /graphql  [post]
+func GraphQL(app *core.App, router fiber.Router) {
+	handler := func(c *fiber.Ctx) error {
+		var req graphql.Request
+		if c.Method() == fiber.MethodGet {
+			req.Query = c.Query("query")
+			req.OperationName = c.Query("operationName")
+			if v := c.Query("variables"); v != "" {
+				if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+					return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "variables"}))
+				}
+			}
+		} else if err := json.Unmarshal(c.Body(), &req); err != nil {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "request"}))
+		}
+		if req.Query == "" {
+			return common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": "query"}))
+		}
+
+		return c.JSON(newGraphQLSchema(app, c.IP()).Execute(req))
+	}
+
+	router.Get("/graphql", common.RateLimitGuard(app, "graphql_rate", graphqlRateLimit, handler))
+	router.Post("/graphql", common.RateLimitGuard(app, "graphql_rate", graphqlRateLimit, handler))
+}
+
+// The public user profile without the private data (such as email)
+type graphqlUser struct {
+	ID         uint
+	Name       string
+	Link       string
+	BadgeName  string
+	BadgeColor string
+	IsAdmin    bool
+}
+
+// The schema of the viewer IP, the comments are visible as in the comment list of the viewer
+func newGraphQLSchema(app *core.App, ip string) *graphql.Schema {
+	comment := &graphql.Object{Name: "Comment"}
+	page := &graphql.Object{Name: "Page"}
+	site := &graphql.Object{Name: "Site"}
+	user := &graphql.Object{Name: "User"}
+
+	// The visible comments of the anonymous viewer (the pending comments and the comments of shadow banned users are excluded),
+	// which are the same as `comments_get`, all the comment queries are scoped by it
+	visible := cog.ConvertGormScopes(cog.GetQueryScopes(app.Dao(), cog.QueryOptions{IP: ip}))
+	commentsQuery := func() *gorm.DB {
+		return app.Dao().DB().Model(&entity.Comment{}).Scopes(visible...)
+	}
+
+	findComments := func(p graphql.ResolveParams, scope func(*gorm.DB) *gorm.DB, order string) ([]entity.CookedComment, error) {
+		var comments []*entity.Comment
+		commentsQuery().
+			Scopes(scope).
+			Order(order).
+			Offset(max(p.Args.Int("offset", 0), 0)).
+			Limit(getGraphQLLimit(p.Args)).
+			Find(&comments)
+		return app.Dao().CookAllComments(comments), nil
+	}
+	findComment := func(id uint) any {
+		var c entity.Comment
+		if commentsQuery().Where("id = ?", id).Limit(1).Find(&c); c.IsEmpty() {
+			return nil
+		}
+		return app.Dao().CookComment(&c)
+	}
+	findPage := func(key string, siteName string) any {
+		p := app.Dao().FindPage(key, siteName)
+		if p.IsEmpty() {
+			return nil
+		}
+		return app.Dao().CookPage(&p)
+	}
+	findSite := func(name string) any {
+		s := app.Dao().FindSite(name)
+		if s.IsEmpty() {
+			return nil
+		}
+		return app.Dao().CookSite(&s)
+	}
+
+	comment.Fields = map[string]*graphql.Field{
+		"id":              graphqlScalar(func(c entity.CookedComment) any { return c.ID }),
+		"content":         graphqlScalar(func(c entity.CookedComment) any { return c.Content }),
+		"content_marked":  graphqlScalar(func(c entity.CookedComment) any { return c.ContentMarked }),
+		"nick":            graphqlScalar(func(c entity.CookedComment) any { return c.Nick }),
+		"email_encrypted": graphqlScalar(func(c entity.CookedComment) any { return c.EmailEncrypted }),
+		"link":            graphqlScalar(func(c entity.CookedComment) any { return c.Link }),
+		"date":            graphqlScalar(func(c entity.CookedComment) any { return c.Date }),
+		"rid":             graphqlScalar(func(c entity.CookedComment) any { return c.Rid }),
+		"is_pinned":       graphqlScalar(func(c entity.CookedComment) any { return c.IsPinned }),
+		"is_verified":     graphqlScalar(func(c entity.CookedComment) any { return c.IsVerified }),
+		"badge_name":      graphqlScalar(func(c entity.CookedComment) any { return c.BadgeName }),
+		"badge_color":     graphqlScalar(func(c entity.CookedComment) any { return c.BadgeColor }),
+		"vote_up":         graphqlScalar(func(c entity.CookedComment) any { return c.VoteUp }),
+		"vote_down":       graphqlScalar(func(c entity.CookedComment) any { return c.VoteDown }),
+		"page_key":        graphqlScalar(func(c entity.CookedComment) any { return c.PageKey }),
+		"page_url":        graphqlScalar(func(c entity.CookedComment) any { return c.PageURL }),
+		"site_name":       graphqlScalar(func(c entity.CookedComment) any { return c.SiteName }),
+		"page": {Type: page, Resolve: func(p graphql.ResolveParams) (any, error) {
+			c := p.Source.(entity.CookedComment)
+			return findPage(c.PageKey, c.SiteName), nil
+		}},
+		"user": {Type: user, Resolve: func(p graphql.ResolveParams) (any, error) {
+			u := app.Dao().FindUserByID(p.Source.(entity.CookedComment).UserID)
+			if u.IsEmpty() {
+				return nil, nil
+			}
+			return graphqlUser{ID: u.ID, Name: u.Name, Link: u.Link, BadgeName: u.BadgeName, BadgeColor: u.BadgeColor, IsAdmin: u.IsAdmin}, nil
+		}},
+		"parent": {Type: comment, Resolve: func(p graphql.ResolveParams) (any, error) {
+			if rid := p.Source.(entity.CookedComment).Rid; rid != 0 {
+				return findComment(rid), nil
+			}
+			return nil, nil
+		}},
+		"replies": {Type: comment, List: true, Args: []string{"limit", "offset"}, ListSize: getGraphQLLimit, Resolve: func(p graphql.ResolveParams) (any, error) {
+			id := p.Source.(entity.CookedComment).ID
+			return findComments(p, func(d *gorm.DB) *gorm.DB {
+				return d.Where("rid = ?", id)
+			}, "created_at ASC, id ASC")
+		}},
+	}
+
+	page.Fields = map[string]*graphql.Field{
+		"id":        graphqlScalar(func(p entity.CookedPage) any { return p.ID }),
+		"key":       graphqlScalar(func(p entity.CookedPage) any { return p.Key }),
+		"url":       graphqlScalar(func(p entity.CookedPage) any { return p.URL }),
+		"title":     graphqlScalar(func(p entity.CookedPage) any { return p.Title }),
+		"site_name": graphqlScalar(func(p entity.CookedPage) any { return p.SiteName }),
+		"pv":        graphqlScalar(func(p entity.CookedPage) any { return p.PV }),
+		"vote_up":   graphqlScalar(func(p entity.CookedPage) any { return p.VoteUp }),
+		"vote_down": graphqlScalar(func(p entity.CookedPage) any { return p.VoteDown }),
+		"comment_count": {Resolve: func(p graphql.ResolveParams) (any, error) {
+			pg := p.Source.(entity.CookedPage)
+			var count int64
+			commentsQuery().
+				Where("site_name = ? AND page_key = ?", pg.SiteName, pg.Key).
+				Count(&count)
+			return count, nil
+		}},
+		"comments": {Type: comment, List: true, Args: []string{"limit", "offset", "sort_by", "flat"}, ListSize: getGraphQLLimit, Resolve: func(p graphql.ResolveParams) (any, error) {
+			pg := p.Source.(entity.CookedPage)
+			flat := p.Args.Bool("flat", false)
+			return findComments(p, func(d *gorm.DB) *gorm.DB {
+				d = d.Where("site_name = ? AND page_key = ?", pg.SiteName, pg.Key)
+				if !flat {
+					d = d.Where("rid = 0") // The root comments, the replies are queried by the `replies` field
+				}
+				return d
+			}, cog.GetSortSQL(cog.ScopePage, cog.SortRule(p.Args.String("sort_by", ""))))
+		}},
+		"site": {Type: site, Resolve: func(p graphql.ResolveParams) (any, error) {
+			return findSite(p.Source.(entity.CookedPage).SiteName), nil
+		}},
+	}
+
+	site.Fields = map[string]*graphql.Field{
+		"id":        graphqlScalar(func(s entity.CookedSite) any { return s.ID }),
+		"name":      graphqlScalar(func(s entity.CookedSite) any { return s.Name }),
+		"urls":      graphqlScalar(func(s entity.CookedSite) any { return s.Urls }),
+		"first_url": graphqlScalar(func(s entity.CookedSite) any { return s.FirstUrl }),
+		"page": {Type: page, Args: []string{"key"}, Resolve: func(p graphql.ResolveParams) (any, error) {
+			return findPage(p.Args.String("key", ""), p.Source.(entity.CookedSite).Name), nil
+		}},
+		"pages": {Type: page, List: true, Args: []string{"limit", "offset"}, ListSize: getGraphQLLimit, Resolve: func(p graphql.ResolveParams) (any, error) {
+			var pages []entity.Page
+			app.Dao().DB().Where("site_name = ?", p.Source.(entity.CookedSite).Name).
+				Order("created_at DESC, id DESC").
+				Offset(max(p.Args.Int("offset", 0), 0)).
+				Limit(getGraphQLLimit(p.Args)).
+				Find(&pages)
+
+			cooked := make([]entity.CookedPage, len(pages))
+			for i := range pages {
+				cooked[i] = app.Dao().CookPage(&pages[i])
+			}
+			return cooked, nil
+		}},
+		"comments": {Type: comment, List: true, Args: []string{"limit", "offset"}, ListSize: getGraphQLLimit, Resolve: func(p graphql.ResolveParams) (any, error) {
+			name := p.Source.(entity.CookedSite).Name
+			return findComments(p, func(d *gorm.DB) *gorm.DB {
+				return d.Where("site_name = ?", name)
+			}, "created_at DESC, id DESC")
+		}},
+	}
+
+	user.Fields = map[string]*graphql.Field{
+		"id":          graphqlScalar(func(u graphqlUser) any { return u.ID }),
+		"name":        graphqlScalar(func(u graphqlUser) any { return u.Name }),
+		"link":        graphqlScalar(func(u graphqlUser) any { return u.Link }),
+		"badge_name":  graphqlScalar(func(u graphqlUser) any { return u.BadgeName }),
+		"badge_color": graphqlScalar(func(u graphqlUser) any { return u.BadgeColor }),
+		"is_admin":    graphqlScalar(func(u graphqlUser) any { return u.IsAdmin }),
+	}
+
+	return &graphql.Schema{
+		Query: &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+			"comment": {Type: comment, Args: []string{"id"}, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return findComment(uint(max(p.Args.Int("id", 0), 0))), nil
+			}},
+			"page": {Type: page, Args: []string{"site_name", "key"}, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return findPage(p.Args.String("key", ""), getGraphQLSiteName(app, p.Args)), nil
+			}},
+			"site": {Type: site, Args: []string{"name"}, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return findSite(p.Args.String("name", app.Conf().SiteDefault)), nil
+			}},
+		}},
+	}
+}
+
+// The scalar field resolved from the source
+func graphqlScalar[T any](get func(T) any) *graphql.Field {
+	return &graphql.Field{Resolve: func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(T)), nil
+	}}
+}
+
+func getGraphQLLimit(args graphql.Args) int {
+	limit := args.Int("limit", 20)
+	if limit <= 0 {
+		limit = 20
+	}
+	return min(limit, graphqlMaxLimit)
+}
+
+func getGraphQLSiteName(app *core.App, args graphql.Args) string {
+	return args.String("site_name", app.Conf().SiteDefault)
+}
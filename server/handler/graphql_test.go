@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGraphQL(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.GraphQL(app.App, api)
+
+	query := func(q string, variables map[string]any) (int, gjson.Result) {
+		body, _ := json.Marshal(map[string]any{"query": q, "variables": variables})
+		req := httptest.NewRequest("POST", "/graphql", strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(buf)
+	}
+
+	t.Run("NestedQuery", func(t *testing.T) {
+		code, data := query(`query ($key: String!) {
+			page(site_name: "Site A", key: $key) {
+				title
+				comment_count
+				comments(limit: 10, sort_by: "date_asc") {
+					id
+					nick
+					user { name is_admin }
+					replies { id parent { id } }
+				}
+				site { name }
+			}
+		}`, map[string]any{"key": "/test/1000.html"})
+		assert.Equal(t, 200, code)
+		assert.False(t, data.Get("errors").Exists(), data.Get("errors").Raw)
+
+		page := data.Get("data.page")
+		assert.Equal(t, "Site A", page.Get("site.name").String())
+		assert.Equal(t, int64(6), page.Get("comment_count").Int())
+		assert.Equal(t, []any{float64(1000), float64(1005)}, page.Get("comments.#.id").Value(), "should only list the root comments")
+
+		root := page.Get("comments.0")
+		assert.Equal(t, "admin", root.Get("user.name").String())
+		assert.True(t, root.Get("user.is_admin").Bool())
+		assert.False(t, root.Get("user.email").Exists(), "should not expose the email")
+		assert.Equal(t, []any{float64(1001)}, root.Get("replies.#.id").Value(), "should list the direct replies")
+		assert.Equal(t, int64(1000), root.Get("replies.0.parent.id").Int())
+	})
+
+	t.Run("FieldSelection", func(t *testing.T) {
+		code, data := query(`{ comment(id: 1001) { nick } }`, nil)
+		assert.Equal(t, 200, code)
+		assert.JSONEq(t, `{"data":{"comment":{"nick":"userA"}}}`, data.Raw)
+	})
+
+	t.Run("PendingComment", func(t *testing.T) {
+		_, data := query(`{ comment(id: 1007) { id } site(name: "Site B") { comments { id } } }`, nil)
+		assert.Equal(t, "null", data.Get("data.comment").Raw, "should not expose the pending comment")
+		assert.Equal(t, []any{float64(1006)}, data.Get("data.site.comments.#.id").Value())
+	})
+
+	t.Run("GetRequest", func(t *testing.T) {
+		resp, err := api.Test(httptest.NewRequest("GET", "/graphql?query="+url.QueryEscape(`{ site(name: "Site A") { name } }`), nil))
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "Site A", gjson.GetBytes(buf, "data.site.name").String())
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		code, data := query(`{ comment(id: 1000) { email } }`, nil)
+		assert.Equal(t, 200, code)
+		assert.False(t, data.Get("data").Exists())
+		assert.Contains(t, data.Get("errors.0.message").String(), `Cannot query field "email"`)
+
+		code, _ = query(``, nil)
+		assert.Equal(t, 400, code)
+	})
+
+	t.Run("ShadowBannedComment", func(t *testing.T) {
+		app.Dao().DB().Model(&entity.User{}).Where("id = ?", 1001).Update("is_shadow_banned", true)
+		defer app.Dao().DB().Model(&entity.User{}).Where("id = ?", 1001).Update("is_shadow_banned", false)
+
+		_, data := query(`{
+			comment(id: 1001) { id }
+			reply: comment(id: 1005) { id }
+			page(site_name: "Site A", key: "/test/1000.html") {
+				comment_count
+				comments { id replies { id } }
+			}
+		}`, nil)
+		assert.False(t, data.Get("errors").Exists(), data.Get("errors").Raw)
+		assert.Equal(t, "null", data.Get("data.comment").Raw, "should not expose the comment of shadow banned user by id")
+		assert.Equal(t, int64(1005), data.Get("data.reply.id").Int())
+		assert.Equal(t, int64(2), data.Get("data.page.comment_count").Int())
+		assert.Equal(t, []any{}, data.Get("data.page.comments.0.replies.#.id").Value(), "should not expose the replies of shadow banned user")
+	})
+
+	t.Run("MaxCost", func(t *testing.T) {
+		_, data := query(`{ site(name: "Site A") { pages(limit: 100) { comments(limit: 100) { replies(limit: 100) { id } } } } }`, nil)
+		assert.False(t, data.Get("data").Exists())
+		assert.Contains(t, data.Get("errors.0.message").String(), "max cost")
+	})
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ParamsMentionList struct {
+	PageKey  string `query:"page_key" json:"page_key" validate:"required"`   // The page key of participants
+	SiteName string `query:"site_name" json:"site_name" validate:"optional"` // The site name of your content scope
+	Keyword  string `query:"keyword" json:"keyword" validate:"optional"`     // The prefix of the username (the text typed after `@`)
+	Limit    int    `query:"limit" json:"limit" validate:"optional"`         // The limit of users (default: 10, max: 50)
+}
+
+type MentionUser struct {
+	Name string `json:"name"`
+	Link string `json:"link"`
+}
+
+type ResponseMentionList struct {
+	Users []MentionUser `json:"users"`
+}
+
+// @Id           GetMentions
+// @Summary      Get Mention Candidates
+// @Description  Autocomplete the usernames to mention (@username) among the participants of the page
+// @Tags         Comment
+// @Param        options  query  ParamsMentionList  true  "The options"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseMentionList
+// @Failure      400  {object}  Map{msg=string}
+// @Router       /mentions  [get]
+func MentionList(app *core.App, router fiber.Router) {
+	router.Get("/mentions", func(c *fiber.Ctx) error {
+		var p ParamsMentionList
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if _, ok, resp := common.CheckSiteExist(app, c, p.SiteName); !ok {
+			return resp
+		}
+
+		if p.Limit <= 0 {
+			p.Limit = 10
+		} else if p.Limit > 50 {
+			p.Limit = 50
+		}
+
+		users := app.Dao().FindPageParticipants(p.SiteName, p.PageKey, p.Keyword, p.Limit)
+
+		return common.RespData(c, ResponseMentionList{
+			Users: lo.Map(users, func(u entity.User, _ int) MentionUser {
+				return MentionUser{Name: u.Name, Link: u.Link}
+			}),
+		})
+	})
+}
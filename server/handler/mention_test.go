@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestMentionList(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.MentionList(app.App, api)
+
+	getNames := func(url string) (int, []string) {
+		resp, err := api.Test(httptest.NewRequest("GET", url, nil))
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		names := []string{}
+		for _, u := range gjson.GetBytes(buf, "users").Array() {
+			names = append(names, u.Get("name").String())
+		}
+		return resp.StatusCode, names
+	}
+
+	t.Run("Participants", func(t *testing.T) {
+		code, names := getNames("/mentions?site_name=Site+A&page_key=/test/1000.html")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, []string{"admin", "userA", "userB"}, names)
+	})
+
+	t.Run("Keyword", func(t *testing.T) {
+		_, names := getNames("/mentions?site_name=Site+A&page_key=/test/1000.html&keyword=USER")
+		assert.Equal(t, []string{"userA", "userB"}, names, "should match the prefix case-insensitively")
+
+		_, names = getNames("/mentions?site_name=Site+A&page_key=/test/1000.html&keyword=userB&limit=1")
+		assert.Equal(t, []string{"userB"}, names)
+	})
+
+	t.Run("OnlyPageParticipants", func(t *testing.T) {
+		_, names := getNames("/mentions?site_name=Site+B&page_key=/site_b/1001.html")
+		assert.Equal(t, []string{"userA"}, names, "should not include the users of pending comments")
+
+		_, names = getNames("/mentions?site_name=Site+A&page_key=/not_exists.html")
+		assert.Empty(t, names)
+	})
+}
+
+func TestMentionNotify(t *testing.T) {
+	app, _ := NewApiTestApp()
+	defer app.Cleanup()
+
+	notifyService, err := core.AppService[*core.NotifyService](app.App)
+	assert.NoError(t, err)
+
+	comment := entity.Comment{
+		Content:  "@userA @userB @nobody thanks",
+		PageKey:  "/test/1000.html",
+		SiteName: "Site A",
+		UserID:   1002,
+	}
+	assert.NoError(t, app.Dao().CreateComment(&comment))
+	assert.NoError(t, notifyService.Push(&comment, &entity.Comment{}))
+
+	assert.False(t, app.Dao().FindNotify(1001, comment.ID).IsEmpty(), "should notify the mentioned user")
+	assert.True(t, app.Dao().FindNotify(1002, comment.ID).IsEmpty(), "should not notify the user who mentions self")
+
+	t.Run("NotParticipant", func(t *testing.T) {
+		comment := entity.Comment{
+			Content:  "@userB hi",
+			PageKey:  "/site_b/1001.html",
+			SiteName: "Site B",
+			UserID:   1001,
+		}
+		assert.NoError(t, app.Dao().CreateComment(&comment))
+		assert.NoError(t, notifyService.Push(&comment, &entity.Comment{}))
+
+		assert.True(t, app.Dao().FindNotify(1002, comment.ID).IsEmpty(), "should not notify the user who is not a participant of page")
+	})
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+// The actions of bulk moderation
+const (
+	ModerationActionApprove = "approve"
+	ModerationActionSpam    = "spam"
+	ModerationActionDelete  = "delete"
+)
+
+// The max number of comments in a bulk moderation
+const moderationBulkMax = 200
+
+type ParamsModerationBulk struct {
+	IDs    []uint `json:"ids" validate:"required"`                                // The comment IDs
+	Action string `json:"action" enums:"approve,spam,delete" validate:"required"` // Approve the comments, mark them as spam (feed back to the anti-spam checkers and delete them), or delete them
+}
+
+type ModerationBulkFailure struct {
+	ID  uint   `json:"id"`
+	Msg string `json:"msg"`
+}
+
+type ResponseModerationBulk struct {
+	Succeeded []uint                  `json:"succeeded"` // The IDs of comments moderated successfully
+	Failed    []ModerationBulkFailure `json:"failed"`    // The comments failed to moderate with the reasons
+}
+
+// @Id           BulkModerateComments
+// @Summary      Bulk Moderate Comments
+// @Description  Approve, mark as spam or delete the comments in bulk, the failure of a comment does not stop the others
+// @Tags         Moderation
+// @Security     ApiKeyAuth
+// @Param        options  body  ParamsModerationBulk  true  "The comments and the action"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseModerationBulk
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /moderation/bulk  [post]
+func ModerationBulk(app *core.App, router fiber.Router) {
+	router.Post("/moderation/bulk", common.AdminPermGuard(app, entity.AdminPermModerate, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsModerationBulk
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if !lo.Contains([]string{ModerationActionApprove, ModerationActionSpam, ModerationActionDelete}, p.Action) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "action"}))
+		}
+		if len(p.IDs) == 0 {
+			return common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": "ids"}))
+		}
+		if len(p.IDs) > moderationBulkMax {
+			return common.RespError(c, 400, i18n.T("Too many {{name}}", Map{"name": "ids"}))
+		}
+
+		resp := ResponseModerationBulk{Succeeded: []uint{}, Failed: []ModerationBulkFailure{}}
+		fail := func(id uint, msg string) {
+			resp.Failed = append(resp.Failed, ModerationBulkFailure{ID: id, Msg: msg})
+		}
+
+		for _, id := range lo.Uniq(p.IDs) {
+			comment := app.Dao().FindComment(id)
+			if comment.IsEmpty() {
+				fail(id, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
+				continue
+			}
+			if !admin.CanAdminSite(comment.SiteName) {
+				fail(id, i18n.T("No permission for site `{{name}}`", Map{"name": comment.SiteName}))
+				continue
+			}
+
+			previous := comment
+
+			var err error
+			switch p.Action {
+			case ModerationActionApprove:
+				err = setCommentPending(app, &comment, false)
+			case ModerationActionSpam:
+				// the decision is fed back even if the comment is already pending
+				comment.IsPending = true
+				afterCommentPendingModified(app, comment)
+				err = removeComment(app, comment)
+			case ModerationActionDelete:
+				err = removeComment(app, comment)
+			}
+			if err != nil {
+				fail(id, err.Error())
+				continue
+			}
+
+			resp.Succeeded = append(resp.Succeeded, id)
+			if p.Action == ModerationActionApprove {
+				addCommentAudit(c, "comment.approve", &previous, &comment)
+			} else {
+				addCommentAudit(c, "comment."+p.Action, &previous, nil)
+			}
+		}
+
+		log.Info("[ModerationBulk] ", len(resp.Succeeded), " comments ", p.Action, " by admin ", admin.ID)
+
+		return common.RespData(c, resp)
+	}))
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsModerationQueue struct {
+	SiteName string `query:"site_name" json:"site_name" validate:"optional"`                        // Filter by the site name, all the sites of admin if empty
+	PageKey  string `query:"page_key" json:"page_key" validate:"optional"`                          // Filter by the page key
+	Checker  string `query:"checker" json:"checker" validate:"optional"`                            // Filter by the anti-spam checker which held the comment, "none" for the comments held without checker
+	SortBy   string `query:"sort_by" json:"sort_by" enums:"date_asc,date_desc" validate:"optional"` // The order of queue (default: date_asc, the oldest first)
+	Limit    int    `query:"limit" json:"limit" validate:"optional"`                                // The limit for pagination (default: 20)
+	Cursor   string `query:"cursor" json:"cursor" validate:"optional"`                              // The next_cursor of previous page (empty for the first page)
+}
+
+type ResponseModerationQueue struct {
+	Comments   []entity.CookedComment `json:"comments"`
+	Count      int64                  `json:"count"`       // The number of comments in the queue by the filter
+	Checkers   map[string]int64       `json:"checkers"`    // The number of comments held by each checker, regardless of the checker filter
+	NextCursor string                 `json:"next_cursor"` // The cursor of next page (empty if no more)
+}
+
+// @Id           GetModerationQueue
+// @Summary      Get Moderation Queue
+// @Description  Get the comments pending review, the pages are paginated by the cursor so that they are stable when the comments are moderated
+// @Tags         Moderation
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsModerationQueue  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseModerationQueue
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /moderation/queue  [get]
+func ModerationQueue(app *core.App, router fiber.Router) {
+	router.Get("/moderation/queue", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsModerationQueue
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.Limit <= 0 || p.Limit > 100 {
+			p.Limit = 20
+		}
+
+		filter := dao.PendingCommentsFilter{
+			SiteNames: admin.GetAdminSites(),
+			PageKey:   p.PageKey,
+			Checker:   p.Checker,
+		}
+		if p.SiteName != "" {
+			if ok, resp := common.CheckAdminSite(c, admin, p.SiteName); !ok {
+				return resp
+			}
+			filter.SiteNames = []string{p.SiteName}
+		}
+
+		desc := p.SortBy == "date_desc"
+
+		var count int64
+		app.Dao().PendingCommentsQuery(filter).Count(&count)
+
+		// The keyset pagination by the ID, which is in the same order as the date
+		q := app.Dao().PendingCommentsQuery(filter)
+		if p.Cursor != "" {
+			lastID, err := strconv.ParseUint(p.Cursor, 10, 64)
+			if err != nil {
+				return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "cursor"}))
+			}
+			if desc {
+				q = q.Where("id < ?", lastID)
+			} else {
+				q = q.Where("id > ?", lastID)
+			}
+		}
+		if desc {
+			q = q.Order("id DESC")
+		} else {
+			q = q.Order("id ASC")
+		}
+
+		comments := []entity.Comment{}
+		q.Limit(p.Limit + 1).Find(&comments) // one more to check if there is the next page
+
+		nextCursor := ""
+		if len(comments) > p.Limit {
+			comments = comments[:p.Limit]
+			nextCursor = strconv.FormatUint(uint64(comments[len(comments)-1].ID), 10)
+		}
+
+		cooked := []entity.CookedComment{}
+		for _, comment := range comments {
+			cooked = append(cooked, app.Dao().CookComment(&comment))
+		}
+		cooked = findIPRegionForComments(app, c, cooked)
+
+		return common.RespData(c, ResponseModerationQueue{
+			Comments:   cooked,
+			Count:      count,
+			Checkers:   app.Dao().CountPendingCommentsByChecker(filter),
+			NextCursor: nextCursor,
+		})
+	}))
+}
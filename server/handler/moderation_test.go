@@ -0,0 +1,115 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestModeration(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.ModerationQueue(app.App, api)
+	handler.ModerationBulk(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+
+	// Hold some comments (1007 is pending in the fixtures)
+	for id, checker := range map[uint]string{1001: "akismet", 1002: "akismet", 1003: "keywords"} {
+		comment := app.Dao().FindComment(id)
+		comment.IsPending = true
+		comment.ModerationChecker = checker
+		assert.NoError(t, app.Dao().UpdateComment(&comment))
+	}
+
+	ids := func(res gjson.Result) []int64 {
+		list := []int64{}
+		for _, c := range res.Get("comments").Array() {
+			list = append(list, c.Get("id").Int())
+		}
+		return list
+	}
+
+	t.Run("Queue", func(t *testing.T) {
+		code, res := request("GET", "/moderation/queue", token, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(4), res.Get("count").Int())
+		assert.Equal(t, []int64{1001, 1002, 1003, 1007}, ids(res), "should be the oldest first")
+		assert.Equal(t, int64(2), res.Get("checkers.akismet").Int())
+		assert.Equal(t, int64(1), res.Get("checkers.keywords").Int())
+		assert.Equal(t, int64(1), res.Get("checkers.none").Int())
+		assert.Empty(t, res.Get("next_cursor").String())
+		assert.Equal(t, "akismet", res.Get("comments.0.moderation.checker").String())
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		_, res := request("GET", "/moderation/queue?checker=akismet", token, "")
+		assert.Equal(t, []int64{1001, 1002}, ids(res))
+		assert.Equal(t, int64(1), res.Get("checkers.keywords").Int(), "the checkers should not be filtered")
+
+		_, res = request("GET", "/moderation/queue?checker=none", token, "")
+		assert.Equal(t, []int64{1007}, ids(res))
+
+		_, res = request("GET", "/moderation/queue?site_name=Site%20B", token, "")
+		assert.Equal(t, []int64{1007}, ids(res))
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		code, res := request("GET", "/moderation/queue?limit=2&sort_by=date_desc", token, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, []int64{1007, 1003}, ids(res))
+		cursor := res.Get("next_cursor").String()
+		assert.Equal(t, "1003", cursor)
+
+		// the next page is not shifted by the moderated comments of previous page
+		_, res = request("POST", "/moderation/bulk", token, `{"ids":[1007],"action":"approve"}`)
+		assert.Equal(t, []int64{1007}, intArray(res.Get("succeeded")))
+
+		_, res = request("GET", "/moderation/queue?limit=2&sort_by=date_desc&cursor="+cursor, token, "")
+		assert.Equal(t, []int64{1002, 1001}, ids(res))
+		assert.Empty(t, res.Get("next_cursor").String())
+
+		code, _ = request("GET", "/moderation/queue?cursor=abc", token, "")
+		assert.Equal(t, 400, code)
+	})
+
+	t.Run("Bulk", func(t *testing.T) {
+		code, _ := request("POST", "/moderation/bulk", token, `{"ids":[1001],"action":"ban"}`)
+		assert.Equal(t, 400, code)
+		code, _ = request("POST", "/moderation/bulk", token, `{"ids":[],"action":"approve"}`)
+		assert.Equal(t, 400, code)
+
+		code, res := request("POST", "/moderation/bulk", token, `{"ids":[1001,99999],"action":"approve"}`)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, []int64{1001}, intArray(res.Get("succeeded")))
+		assert.Equal(t, int64(99999), res.Get("failed.0.id").Int())
+		assert.False(t, app.Dao().FindComment(1001).IsPending)
+
+		_, res = request("POST", "/moderation/bulk", token, `{"ids":[1002],"action":"spam"}`)
+		assert.Equal(t, []int64{1002}, intArray(res.Get("succeeded")))
+		assert.True(t, app.Dao().FindComment(1002).IsEmpty(), "the spam should be removed")
+
+		_, res = request("POST", "/moderation/bulk", token, `{"ids":[1003,1003],"action":"delete"}`)
+		assert.Equal(t, []int64{1003}, intArray(res.Get("succeeded")))
+		assert.True(t, app.Dao().FindComment(1003).IsEmpty())
+
+		_, res = request("GET", "/moderation/queue", token, "")
+		assert.Equal(t, int64(0), res.Get("count").Int())
+	})
+}
+
+func intArray(res gjson.Result) []int64 {
+	list := []int64{}
+	for _, v := range res.Array() {
+		list = append(list, v.Int())
+	}
+	return list
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsNotifyPreferencesAuth struct {
+	CommentID uint   `query:"comment_id" json:"comment_id" validate:"optional"` // The comment ID of the notify (required with notify_key)
+	NotifyKey string `query:"notify_key" json:"notify_key" validate:"optional"` // The key of the notify in the notification email, the login token is used if empty
+}
+
+type ResponseNotifyPreferences struct {
+	ReceiveEmail  bool   `json:"receive_email"`                       // Receive the email notifications (false for the full unsubscribe)
+	NotifyReply   bool   `json:"notify_reply"`                        // Notify when the comment is replied
+	NotifyMention bool   `json:"notify_mention"`                      // Notify when the user is mentioned
+	EmailDigest   string `json:"email_digest" enums:"instant,digest"` // The preference of email notifications (empty for the default)
+	DigestEnabled bool   `json:"digest_enabled"`                      // The digest mode is enabled on the server
+}
+
+type ParamsNotifyPreferencesUpdate struct {
+	ReceiveEmail  *bool   `json:"receive_email" validate:"optional"`
+	NotifyReply   *bool   `json:"notify_reply" validate:"optional"`
+	NotifyMention *bool   `json:"notify_mention" validate:"optional"`
+	EmailDigest   *string `json:"email_digest" enums:"instant,digest" validate:"optional"`
+}
+
+// @Id           GetNotifyPreferences
+// @Summary      Get Notification Preferences
+// @Description  Get the notification preferences of the user, authorized by the notify key in the notification email or the login token
+// @Tags         Notify
+// @Param        options  query  ParamsNotifyPreferencesAuth  false  "The notify key"
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  ResponseNotifyPreferences
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /notify_preferences  [get]
+func NotifyPreferencesGet(app *core.App, router fiber.Router) {
+	router.Get("/notify_preferences", notifyPreferencesGuard(app, func(c *fiber.Ctx, user entity.User) error {
+		return common.RespData(c, getNotifyPreferences(app, user))
+	}))
+}
+
+// @Id           UpdateNotifyPreferences
+// @Summary      Update Notification Preferences
+// @Description  Update the notification preferences of the user, authorized by the notify key in the notification email or the login token
+// @Tags         Notify
+// @Param        options  query  ParamsNotifyPreferencesAuth    false  "The notify key"
+// @Param        data     body   ParamsNotifyPreferencesUpdate  true   "The preferences to update"
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseNotifyPreferences
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /notify_preferences  [put]
+func NotifyPreferencesUpdate(app *core.App, router fiber.Router) {
+	router.Put("/notify_preferences", notifyPreferencesGuard(app, func(c *fiber.Ctx, user entity.User) error {
+		var p ParamsNotifyPreferencesUpdate
+		if ok, resp := common.ParamsDecode(c, &p); !ok {
+			return resp
+		}
+
+		if p.EmailDigest != nil && !entity.IsValidEmailDigest(*p.EmailDigest) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "email_digest"}))
+		}
+
+		if p.ReceiveEmail != nil {
+			user.ReceiveEmail = *p.ReceiveEmail
+		}
+		if p.NotifyReply != nil {
+			user.NotifyReply = *p.NotifyReply
+		}
+		if p.NotifyMention != nil {
+			user.NotifyMention = *p.NotifyMention
+		}
+		if p.EmailDigest != nil {
+			user.EmailDigest = *p.EmailDigest
+		}
+
+		if err := app.Dao().UpdateUser(&user); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("User")}))
+		}
+
+		return common.RespData(c, getNotifyPreferences(app, user))
+	}))
+}
+
+// @Id           NotifyUnsubscribe
+// @Summary      Unsubscribe Email Notifications
+// @Description  Unsubscribe all the email notifications by the link in the notification email, the POST method is for the one-click unsubscribe of mail clients (RFC 8058)
+// @Tags         Notify
+// @Param        options  query  ParamsNotifyPreferencesAuth  true  "The notify key"
+// @Produce      json
+// @Success      200  {object}  Map{msg=string}
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /notify_preferences/unsubscribe  [get]
+// @Router       /notify_preferences/unsubscribe  [post]
+func NotifyUnsubscribe(app *core.App, router fiber.Router) {
+	handler := func(c *fiber.Ctx) error {
+		user, ok, resp := findUserByNotifyKey(app, c)
+		if !ok {
+			return resp
+		}
+
+		user.ReceiveEmail = false
+		if err := app.Dao().UpdateUser(&user); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("User")}))
+		}
+
+		return common.RespData(c, Map{"msg": i18n.T("You have unsubscribed from the email notifications")})
+	}
+
+	router.Get("/notify_preferences/unsubscribe", handler)
+	router.Post("/notify_preferences/unsubscribe", handler)
+}
+
+// Authorize the user by the notify key in query, or by the login token if the key is not given
+func notifyPreferencesGuard(app *core.App, handler func(*fiber.Ctx, entity.User) error) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Query("notify_key") == "" {
+			return common.LoginGuard(app, handler)(c)
+		}
+
+		user, ok, resp := findUserByNotifyKey(app, c)
+		if !ok {
+			return resp
+		}
+		return handler(c, user)
+	}
+}
+
+// Find the receiver of the notify by the comment ID and the notify key in query
+func findUserByNotifyKey(app *core.App, c *fiber.Ctx) (entity.User, bool, error) {
+	commentID := c.QueryInt("comment_id")
+	notifyKey := strings.TrimSpace(c.Query("notify_key"))
+	if commentID <= 0 || notifyKey == "" {
+		// the empty key must be rejected, otherwise any notify of the comment is matched
+		return entity.User{}, false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "notify_key"}))
+	}
+
+	notify := app.Dao().FindNotifyForComment(uint(commentID), notifyKey)
+	if notify.IsEmpty() {
+		return entity.User{}, false, common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Notify")}))
+	}
+
+	user := app.Dao().FetchUserForNotify(&notify)
+	if user.IsEmpty() {
+		return entity.User{}, false, common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("User")}))
+	}
+
+	return user, true, nil
+}
+
+func getNotifyPreferences(app *core.App, user entity.User) ResponseNotifyPreferences {
+	return ResponseNotifyPreferences{
+		ReceiveEmail:  user.ReceiveEmail,
+		NotifyReply:   user.NotifyReply,
+		NotifyMention: user.NotifyMention,
+		EmailDigest:   user.EmailDigest,
+		DigestEnabled: app.Conf().Email.Digest.Enabled,
+	}
+}
@@ -0,0 +1,68 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyPreferences(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.NotifyPreferencesGet(app.App, api)
+	handler.NotifyPreferencesUpdate(app.App, api)
+	handler.NotifyUnsubscribe(app.App, api)
+
+	request := NewApiRequest(t, api)
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		code, _ := request("GET", "/notify_preferences", "", "")
+		assert.Equal(t, 401, code)
+
+		code, _ = request("GET", "/notify_preferences?comment_id=1000&notify_key=WRONG", "", "")
+		assert.Equal(t, 404, code)
+
+		code, _ = request("GET", "/notify_preferences/unsubscribe?comment_id=1000", "", "")
+		assert.Equal(t, 400, code, "should reject the empty key")
+	})
+
+	t.Run("ByNotifyKey", func(t *testing.T) {
+		code, data := request("GET", "/notify_preferences?comment_id=1000&notify_key=ZMTM4", "", "")
+		assert.Equal(t, 200, code)
+		assert.True(t, data.Get("receive_email").Bool())
+		assert.True(t, data.Get("notify_reply").Bool())
+		assert.True(t, data.Get("notify_mention").Bool())
+
+		code, data = request("PUT", "/notify_preferences?comment_id=1000&notify_key=ZMTM4", "", `{"notify_mention":false,"email_digest":"digest"}`)
+		assert.Equal(t, 200, code)
+		assert.False(t, data.Get("notify_mention").Bool())
+		assert.Equal(t, "digest", data.Get("email_digest").String())
+
+		user := app.Dao().FindUserByID(1001)
+		assert.True(t, user.NotifyReply, "should not change the unspecified fields")
+		assert.False(t, user.NotifyMention)
+		assert.Equal(t, "digest", user.EmailDigest)
+
+		code, _ = request("PUT", "/notify_preferences?comment_id=1000&notify_key=ZMTM4", "", `{"email_digest":"weekly"}`)
+		assert.Equal(t, 400, code)
+	})
+
+	t.Run("ByLogin", func(t *testing.T) {
+		user := app.Dao().FindUserByID(1002)
+		token, _ := common.LoginGetUserToken(user, app.Conf().AppKey, app.Conf().LoginTimeout)
+
+		code, data := request("PUT", "/notify_preferences", token, `{"notify_reply":false}`)
+		assert.Equal(t, 200, code)
+		assert.False(t, data.Get("notify_reply").Bool())
+		assert.False(t, app.Dao().FindUserByID(1002).NotifyReply)
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		code, _ := request("POST", "/notify_preferences/unsubscribe?comment_id=1000&notify_key=HL6VH", "", "List-Unsubscribe=One-Click")
+		assert.Equal(t, 200, code)
+		assert.False(t, app.Dao().FindUserByID(1000).ReceiveEmail)
+	})
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"html"
+	"slices"
+	"strconv"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/notify_pusher"
+	"github.com/artalkjs/artalk/v2/internal/notify_pusher/sender"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The update pushed by Telegram, only the callback query of inline button is handled
+type TelegramUpdate struct {
+	CallbackQuery *TelegramCallbackQuery `json:"callback_query"`
+}
+
+type TelegramCallbackQuery struct {
+	ID   string `json:"id"`
+	Data string `json:"data"`
+	From struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	} `json:"from"`
+	Message *struct {
+		MessageID int64  `json:"message_id"`
+		Text      string `json:"text"`
+		Chat      struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+}
+
+// @Id           TelegramWebhook
+// @Summary      Telegram Bot Webhook
+// @Description  Receive the callback of the moderation buttons in the Telegram notifications, the request is verified by the `X-Telegram-Bot-Api-Secret-Token` header
+// @Tags         Notify
+// @Param        update  body  TelegramUpdate  true  "The update pushed by Telegram"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /notify/telegram/webhook  [post]
+func NotifyTelegramWebhook(app *core.App, router fiber.Router) {
+	router.Post("/notify/telegram/webhook", func(c *fiber.Ctx) error {
+		conf := app.Conf().AdminNotify.Telegram
+		if !conf.Enabled || !conf.Moderation {
+			return common.RespError(c, 403, i18n.T("Permission denied"))
+		}
+
+		// The secret is required, otherwise anyone can moderate the comments by faking the callback
+		secret := c.Get("X-Telegram-Bot-Api-Secret-Token")
+		if conf.WebhookSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(conf.WebhookSecret)) != 1 {
+			return common.RespError(c, 403, i18n.T("Permission denied"))
+		}
+
+		var update TelegramUpdate
+		if err := json.Unmarshal(c.Body(), &update); err != nil || update.CallbackQuery == nil {
+			return common.RespSuccess(c) // ignore the other updates
+		}
+
+		query := update.CallbackQuery
+		answer := func(text string) {
+			if err := sender.AnswerTelegramCallback(conf.ApiToken, query.ID, text); err != nil {
+				log.Error("[TelegramWebhook] ", err)
+			}
+		}
+
+		moderators := conf.Moderators
+		if len(moderators) == 0 {
+			moderators = conf.Receivers
+		}
+		if !slices.Contains(moderators, query.From.ID) {
+			answer(i18n.T("Permission denied"))
+			return common.RespSuccess(c)
+		}
+
+		action, commentID, ok := notify_pusher.ParseTelegramCallbackData(query.Data)
+		if !ok {
+			answer(i18n.T("Invalid {{name}}", Map{"name": "callback_data"}))
+			return common.RespSuccess(c)
+		}
+
+		comment := app.Dao().FindComment(commentID)
+		if comment.IsEmpty() {
+			answer(i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
+			return common.RespSuccess(c)
+		}
+
+		before := comment
+
+		var err error
+		var result string
+		switch action {
+		case notify_pusher.TelegramActionApprove:
+			err = setCommentPending(app, &comment, false)
+			result = i18n.T("Approved")
+		case notify_pusher.TelegramActionSpam:
+			err = setCommentPending(app, &comment, true)
+			result = i18n.T("Marked as spam")
+		case notify_pusher.TelegramActionDelete:
+			err = removeComment(app, comment)
+			result = i18n.T("Deleted")
+		}
+		if err != nil {
+			answer(err.Error())
+			return common.RespSuccess(c)
+		}
+
+		log.Info("[TelegramWebhook] Comment ID=", commentID, " ", action, " by Telegram user ", query.From.ID)
+		answer(result)
+
+		// The webhook is not behind the admin guard, so the audit log is recorded here
+		actorName := strconv.FormatInt(query.From.ID, 10)
+		if query.From.Username != "" {
+			actorName = "@" + query.From.Username
+		}
+		if action == notify_pusher.TelegramActionDelete {
+			addCommentAudit(c, "comment.delete", &before, nil)
+		} else {
+			addCommentAudit(c, pendingAuditAction(comment.IsPending), &before, &comment)
+		}
+		common.RecordAudit(app, c, common.AuditActor{Type: entity.AuditActorTelegram, Name: actorName})
+
+		// Mark the result in the notification message and remove the buttons
+		if query.Message != nil {
+			by := query.From.Username
+			if by != "" {
+				by = " (@" + by + ")"
+			}
+			text := html.EscapeString(query.Message.Text) + "\n\n<b>" + html.EscapeString(result+by) + "</b>"
+			if err := sender.EditTelegramMessage(conf.ApiToken, query.Message.Chat.ID, query.Message.MessageID, text); err != nil {
+				log.Error("[TelegramWebhook] ", err)
+			}
+		}
+
+		return common.RespSuccess(c)
+	})
+}
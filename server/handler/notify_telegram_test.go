@@ -0,0 +1,124 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/notify_pusher/sender"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyTelegramWebhook(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.NotifyTelegramWebhook(app.App, api)
+
+	// Mock the Telegram Bot API
+	var mu sync.Mutex
+	var calls []string
+	var answers []string
+	tg := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		calls = append(calls, r.URL.Path)
+		if text, ok := body["text"].(string); ok && strings.HasSuffix(r.URL.Path, "/answerCallbackQuery") {
+			answers = append(answers, text)
+		}
+		mu.Unlock()
+
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer tg.Close()
+
+	defaultAPI := sender.TelegramAPI
+	sender.TelegramAPI = tg.URL
+	defer func() { sender.TelegramAPI = defaultAPI }()
+
+	conf := &app.Conf().AdminNotify.Telegram
+	conf.Enabled = true
+	conf.Moderation = true
+	conf.ApiToken = "TOKEN"
+	conf.WebhookSecret = "secret"
+	conf.Receivers = []int64{42}
+
+	callback := func(secret string, fromID int64, data string) int {
+		mu.Lock()
+		calls, answers = nil, nil
+		mu.Unlock()
+
+		body := fmt.Sprintf(`{"update_id":1,"callback_query":{"id":"cb","data":%q,"from":{"id":%d,"username":"admin"},"message":{"message_id":7,"text":"New comment","chat":{"id":42}}}}`,
+			data, fromID)
+		req := httptest.NewRequest("POST", "/notify/telegram/webhook", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		io.ReadAll(resp.Body)
+		return resp.StatusCode
+	}
+
+	t.Run("Invalid secret", func(t *testing.T) {
+		assert.Equal(t, 403, callback("wrong", 42, "approve:1007"))
+		assert.Empty(t, calls)
+		assert.True(t, app.Dao().FindComment(1007).IsPending)
+	})
+
+	t.Run("Not moderator", func(t *testing.T) {
+		assert.Equal(t, 200, callback("secret", 43, "approve:1007"))
+		assert.Equal(t, []string{"Permission denied"}, answers)
+		assert.True(t, app.Dao().FindComment(1007).IsPending)
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		assert.Equal(t, 200, callback("secret", 42, "approve:1007"))
+		assert.Equal(t, []string{"Approved"}, answers)
+		assert.Equal(t, []string{"/botTOKEN/answerCallbackQuery", "/botTOKEN/editMessageText"}, calls)
+		assert.False(t, app.Dao().FindComment(1007).IsPending)
+
+		logs, _ := app.Dao().FindAuditLogs(dao.AuditLogFilter{ActorType: entity.AuditActorTelegram}, 0, 10)
+		if assert.Len(t, logs, 1) {
+			assert.Equal(t, "comment.approve", logs[0].Action)
+			assert.Equal(t, "@admin", logs[0].ActorName)
+			assert.Equal(t, uint(1007), logs[0].TargetID)
+		}
+	})
+
+	t.Run("Spam", func(t *testing.T) {
+		assert.Equal(t, 200, callback("secret", 42, "spam:1001"))
+		assert.Equal(t, []string{"Marked as spam"}, answers)
+		assert.True(t, app.Dao().FindComment(1001).IsPending)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.Equal(t, 200, callback("secret", 42, "delete:1004"))
+		assert.Equal(t, []string{"Deleted"}, answers)
+		assert.True(t, app.Dao().FindComment(1004).IsEmpty())
+	})
+
+	t.Run("Invalid callback data", func(t *testing.T) {
+		assert.Equal(t, 200, callback("secret", 42, "ban:1001"))
+		assert.Equal(t, []string{"Invalid callback_data"}, answers)
+
+		assert.Equal(t, 200, callback("secret", 42, "approve:99999"))
+		assert.Equal(t, []string{"Comment not found"}, answers)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		conf.Moderation = false
+		defer func() { conf.Moderation = true }()
+
+		assert.Equal(t, 403, callback("secret", 42, "approve:1001"))
+	})
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           DeleteNotifyTemplate
+// @Summary      Delete Notify Template
+// @Description  Delete a specific notify template, the template file or the default template is used instead
+// @Tags         NotifyTemplate
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The notify template ID you want to delete"
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /notify_templates/{id}  [delete]
+func NotifyTemplateDelete(app *core.App, router fiber.Router) {
+	router.Delete("/notify_templates/:id", common.AdminPermGuard(app, entity.AdminPermSuper, func(c *fiber.Ctx, admin entity.User) error {
+		id, _ := c.ParamsInt("id")
+
+		tpl := app.Dao().FindNotifyTemplateByID(uint(id))
+		if tpl.IsEmpty() {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Template")}))
+		}
+
+		before := app.Dao().CookNotifyTemplate(&tpl)
+
+		if err := app.Dao().DelNotifyTemplate(&tpl); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": i18n.T("Template")}))
+		}
+
+		common.AddAudit(c, common.Audit{Action: "notify_template.delete", TargetType: "notify_template", TargetID: tpl.ID, Before: before})
+
+		return common.RespSuccess(c)
+	}))
+}
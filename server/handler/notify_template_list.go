@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/template"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ResponseNotifyTemplateList struct {
+	Templates []entity.CookedNotifyTemplate `json:"templates"`
+	Channels  []string                      `json:"channels"` // The available channels
+	Events    []string                      `json:"events"`   // The available event types
+}
+
+// @Id           GetNotifyTemplates
+// @Summary      Get Notify Template List
+// @Description  Get a list of the notify templates edited in the dashboard (the template files on disk are not included)
+// @Tags         NotifyTemplate
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  ResponseNotifyTemplateList
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /notify_templates  [get]
+func NotifyTemplateList(app *core.App, router fiber.Router) {
+	router.Get("/notify_templates", common.AdminPermGuard(app, entity.AdminPermSuper, func(c *fiber.Ctx, admin entity.User) error {
+		tpls := lo.Map(app.Dao().FindAllNotifyTemplates(), func(t entity.NotifyTemplate, _ int) entity.CookedNotifyTemplate {
+			return app.Dao().CookNotifyTemplate(&t)
+		})
+
+		return common.RespData(c, ResponseNotifyTemplateList{
+			Templates: tpls,
+			Channels:  template.Channels,
+			Events:    template.Events,
+		})
+	}))
+}
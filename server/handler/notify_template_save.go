@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/template"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsNotifyTemplateSave struct {
+	Channel string `json:"channel" validate:"required"` // The notify channel (e.g. email, email_subject, notify, telegram)
+	Event   string `json:"event" validate:"required"`   // The event type (comment, reply, mention, pending or default)
+	Locale  string `json:"locale" validate:"optional"`  // The locale (e.g. en, zh-CN), empty for all languages
+	Content string `json:"content" validate:"required"` // The Go template
+}
+
+// @Id           SaveNotifyTemplate
+// @Summary      Save Notify Template
+// @Description  Create or replace the notify template of the channel, event and locale, which takes effect immediately
+// @Tags         NotifyTemplate
+// @Security     ApiKeyAuth
+// @Param        template  body  ParamsNotifyTemplateSave  true  "The notify template data"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.CookedNotifyTemplate
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /notify_templates  [put]
+func NotifyTemplateSave(app *core.App, router fiber.Router) {
+	router.Put("/notify_templates", common.AdminPermGuard(app, entity.AdminPermSuper, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsNotifyTemplateSave
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if !template.IsValidChannel(p.Channel) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "channel"}))
+		}
+		if !template.IsValidEvent(p.Event) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "event"}))
+		}
+		p.Locale = strings.TrimSpace(p.Locale)
+		if len(p.Locale) > 16 || strings.ContainsAny(p.Locale, `/\.`) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "locale"}))
+		}
+		if strings.TrimSpace(p.Content) == "" {
+			return common.RespError(c, 400, i18n.T("{{name}} cannot be empty", Map{"name": "content"}))
+		}
+		if err := template.ParseCustomTemplate(p.Channel, p.Content); err != nil {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": i18n.T("Template")}), Map{"error": err.Error()})
+		}
+
+		var before any
+		for _, tpl := range app.Dao().FindNotifyTemplates([]string{p.Channel}) {
+			if tpl.Event == p.Event && tpl.Locale == p.Locale {
+				before = app.Dao().CookNotifyTemplate(&tpl)
+			}
+		}
+
+		tpl, err := app.Dao().SaveNotifyTemplate(p.Channel, p.Event, p.Locale, p.Content)
+		if err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Template")}))
+		}
+
+		cooked := app.Dao().CookNotifyTemplate(&tpl)
+		common.AddAudit(c, common.Audit{Action: "notify_template.save", TargetType: "notify_template", TargetID: tpl.ID, Before: before, After: cooked})
+
+		return common.RespData(c, cooked)
+	}))
+}
@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyTemplate(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.NotifyTemplateList(app.App, api)
+	handler.NotifyTemplateSave(app.App, api)
+	handler.NotifyTemplateDelete(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	adminToken, _ := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+
+	request := NewApiRequest(t, api)
+
+	code, _ := request("PUT", "/notify_templates", adminToken, `{"channel":"unknown","event":"reply","content":"{{ .Nick }}"}`)
+	assert.Equal(t, 400, code, "should reject the invalid channel")
+
+	code, _ = request("PUT", "/notify_templates", adminToken, `{"channel":"email","event":"unknown","content":"{{ .Nick }}"}`)
+	assert.Equal(t, 400, code, "should reject the invalid event")
+
+	code, _ = request("PUT", "/notify_templates", adminToken, `{"channel":"email","event":"reply","locale":"../en","content":"{{ .Nick }}"}`)
+	assert.Equal(t, 400, code, "should reject the invalid locale")
+
+	code, data := request("PUT", "/notify_templates", adminToken, `{"channel":"email","event":"reply","content":"{{ .Nick "}`)
+	assert.Equal(t, 400, code, "should reject the invalid template")
+	assert.NotEmpty(t, data.Get("error").String())
+
+	code, data = request("PUT", "/notify_templates", adminToken, `{"channel":"telegram","event":"reply","locale":"en","content":"{{ .ReplyNick }}"}`)
+	assert.Equal(t, 200, code)
+	id := data.Get("id").String()
+
+	code, data = request("PUT", "/notify_templates", adminToken, `{"channel":"telegram","event":"reply","locale":"en","content":"{{ .ReplyNick | upper }}"}`)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, id, data.Get("id").String(), "should replace the template of the same channel, event and locale")
+
+	code, data = request("GET", "/notify_templates", adminToken, "")
+	assert.Equal(t, 200, code)
+	assert.Len(t, data.Get("templates").Array(), 1)
+	assert.Equal(t, "{{ .ReplyNick | upper }}", data.Get("templates.0.content").String())
+	assert.Contains(t, data.Get("channels").String(), "telegram")
+	assert.Contains(t, data.Get("events").String(), "mention")
+
+	code, _ = request("DELETE", "/notify_templates/"+id, adminToken, "")
+	assert.Equal(t, 200, code)
+
+	code, _ = request("DELETE", "/notify_templates/"+id, adminToken, "")
+	assert.Equal(t, 404, code)
+}
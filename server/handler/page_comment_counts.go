@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+// The max number of page keys in one request
+const pageCommentCountsMaxKeys = 100
+
+type ParamsPageCommentCounts struct {
+	SiteName string `query:"site_name" json:"site_name" validate:"required"` // The site name of your content scope
+	PageKeys string `query:"page_keys" json:"page_keys" validate:"required"` // Multiple page keys separated by commas (max: 100)
+}
+
+type ResponsePageCommentCounts struct {
+	Counts map[string]int64 `json:"counts"` // The number of public comments of each page key
+}
+
+// @Id           GetPageCommentCounts
+// @Summary      Get Comment Counts of Pages
+// @Description  Get the comment counts of multiple pages in one request, for rendering the comment counts on the index pages
+// @Tags         Page
+// @Param        options  query  ParamsPageCommentCounts  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponsePageCommentCounts
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /pages/comment-counts  [get]
+func PageCommentCounts(app *core.App, router fiber.Router) {
+	router.Get("/pages/comment-counts", func(c *fiber.Ctx) error {
+		var p ParamsPageCommentCounts
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if _, ok, resp := common.CheckSiteExist(app, c, p.SiteName); !ok {
+			return resp
+		}
+
+		keys := lo.Uniq(utils.SplitAndTrimSpace(p.PageKeys, ","))
+		if len(keys) > pageCommentCountsMaxKeys {
+			return common.RespError(c, 400, i18n.T("Too many {{name}}", Map{"name": "page_keys"}))
+		}
+
+		counts := countPageComments(app, []string{p.SiteName}, keys)
+		result := make(map[string]int64, len(keys))
+		for _, k := range keys {
+			result[k] = counts[p.SiteName+"\n"+k] // zero if no comments
+		}
+
+		return common.RespData(c, ResponsePageCommentCounts{
+			Counts: result,
+		})
+	})
+}
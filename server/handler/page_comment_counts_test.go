@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestPageCommentCounts(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.PageCommentCounts(app.App, api)
+
+	get := func(query string) (int, gjson.Result) {
+		resp, err := api.Test(httptest.NewRequest("GET", "/pages/comment-counts?"+query, nil))
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(body)
+	}
+
+	t.Run("Counts of pages", func(t *testing.T) {
+		var pagination int64
+		app.Dao().DB().Model(&entity.Comment{}).
+			Where("page_key = ? AND site_name = ? AND is_pending = ?", "/test_pagination.html", "Site A", false).
+			Count(&pagination)
+
+		code, data := get("site_name=Site%20A&page_keys=/test/1000.html,%20/test_pagination.html,/not_exist.html")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, map[string]int64{
+			"/test/1000.html":       6,
+			"/test_pagination.html": pagination,
+			"/not_exist.html":       0,
+		}, map[string]int64{
+			"/test/1000.html":       data.Get("counts").Get(gjsonKey("/test/1000.html")).Int(),
+			"/test_pagination.html": data.Get("counts").Get(gjsonKey("/test_pagination.html")).Int(),
+			"/not_exist.html":       data.Get("counts").Get(gjsonKey("/not_exist.html")).Int(),
+		})
+		assert.Len(t, data.Get("counts").Map(), 3)
+	})
+
+	t.Run("Counts in site only", func(t *testing.T) {
+		_, data := get("site_name=Site%20A&page_keys=/site_b/1001.html")
+		assert.Equal(t, int64(0), data.Get("counts").Get(gjsonKey("/site_b/1001.html")).Int())
+
+		_, data = get("site_name=Site%20B&page_keys=/site_b/1001.html")
+		assert.Equal(t, int64(1), data.Get("counts").Get(gjsonKey("/site_b/1001.html")).Int(), "the pending comments should not be counted")
+	})
+
+	t.Run("Invalid params", func(t *testing.T) {
+		code, _ := get("site_name=Site%20A")
+		assert.Equal(t, 400, code)
+
+		keys := []string{}
+		for i := 0; i <= 100; i++ {
+			keys = append(keys, fmt.Sprintf("/%d.html", i))
+		}
+		code, _ = get("site_name=Site%20A&page_keys=" + strings.Join(keys, ","))
+		assert.Equal(t, 400, code)
+	})
+}
+
+// Escape the key for the gjson path
+func gjsonKey(key string) string {
+	return strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`).Replace(key)
+}
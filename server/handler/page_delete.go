@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/i18n"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
@@ -20,19 +21,26 @@ import (
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /pages/{id}  [delete]
 func PageDelete(app *core.App, router fiber.Router) {
-	router.Delete("/pages/:id", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Delete("/pages/:id", common.AdminPermGuard(app, entity.AdminPermManage, func(c *fiber.Ctx, admin entity.User) error {
 		id, _ := c.ParamsInt("id")
 
 		page := app.Dao().FindPageByID(uint(id))
 		if page.IsEmpty() {
 			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Page")}))
 		}
+		if ok, resp := common.CheckAdminSite(c, admin, page.SiteName); !ok {
+			return resp
+		}
+
+		before := app.Dao().CookPage(&page)
 
 		err := app.Dao().DelPage(&page)
 		if err != nil {
 			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": i18n.T("Page")}))
 		}
 
+		common.AddAudit(c, common.Audit{Action: "page.delete", TargetType: "page", TargetID: page.ID, SiteName: page.SiteName, Before: before})
+
 		return common.RespSuccess(c)
 	}))
 }
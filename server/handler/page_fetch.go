@@ -25,13 +25,16 @@ type ResponsePageFetch struct {
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /pages/{id}/fetch  [post]
 func PageFetch(app *core.App, router fiber.Router) {
-	router.Post("/pages/:id/fetch", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Post("/pages/:id/fetch", common.AdminPermGuard(app, entity.AdminPermManage, func(c *fiber.Ctx, admin entity.User) error {
 		id, _ := c.ParamsInt("id")
 
 		page := app.Dao().FindPageByID(uint(id))
 		if page.IsEmpty() {
 			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Page")}))
 		}
+		if ok, resp := common.CheckAdminSite(c, admin, page.SiteName); !ok {
+			return resp
+		}
 
 		if err := app.Dao().FetchPageFromURL(&page); err != nil {
 			return common.RespError(c, 500, i18n.T("Page fetch failed")+": "+err.Error())
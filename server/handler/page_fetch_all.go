@@ -33,15 +33,17 @@ func PageFetchAll(app *core.App, router fiber.Router) {
 		}
 
 		// If the task is in progress
-		if allPageFetching {
+		if ok, err := app.Store().SetNX(pageFetchLockKey, "1", pageFetchLockTTL); err != nil {
+			return common.RespError(c, 500, err.Error())
+		} else if !ok {
 			return common.RespError(c, 400, i18n.T("Task in progress, please wait a moment"))
 		}
+		setPageFetchProgress(app, pageFetchProgress{})
 
 		// Start the async task
-		go func() {
-			allPageFetching = true
-			allPageFetchDone = 0
-			allPageFetchTotal = 0
+		app.Go(func() {
+			defer app.Store().Delete(pageFetchLockKey)
+
 			var pages []entity.Page
 			db := app.Dao().DB().Model(&entity.Page{})
 			if p.SiteName != "" {
@@ -49,16 +51,17 @@ func PageFetchAll(app *core.App, router fiber.Router) {
 			}
 			db.Find(&pages)
 
-			allPageFetchTotal = len(pages)
+			progress := pageFetchProgress{Total: len(pages)}
+			setPageFetchProgress(app, progress)
 			for _, p := range pages {
 				if err := app.Dao().FetchPageFromURL(&p); err != nil {
 					log.Error(c, "[api_admin_page_fetch] page fetch error: "+err.Error())
 				} else {
-					allPageFetchDone++
+					progress.Done++
+					setPageFetchProgress(app, progress)
 				}
 			}
-			allPageFetching = false
-		}()
+		})
 
 		return common.RespSuccess(c)
 	}))
@@ -1,18 +1,46 @@
 package handler
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/artalkjs/artalk/v2/internal/core"
 	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
 )
 
-var (
-	allPageFetching   = false
-	allPageFetchDone  = 0
-	allPageFetchTotal = 0
+// The progress of the task of fetching all pages is kept in the store,
+// so it is shared by the instances in cluster mode (the task may run in another instance)
+const (
+	pageFetchLockKey     = "page_fetch_all:lock"
+	pageFetchProgressKey = "page_fetch_all:progress"
+
+	// The lock is released when the task is done, or expires in case of the instance is down
+	pageFetchLockTTL = time.Hour
 )
 
+type pageFetchProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+func getPageFetchProgress(app *core.App) (progress pageFetchProgress, isProgress bool) {
+	if v, ok := app.Store().Get(pageFetchProgressKey); ok {
+		_ = json.Unmarshal([]byte(v), &progress)
+	}
+	_, isProgress = app.Store().Get(pageFetchLockKey)
+	return progress, isProgress
+}
+
+func setPageFetchProgress(app *core.App, progress pageFetchProgress) {
+	data, _ := json.Marshal(progress)
+	if err := app.Store().Set(pageFetchProgressKey, string(data), 0); err != nil {
+		log.Error("[api_admin_page_fetch] save progress error: ", err)
+	}
+}
+
 type ResponsePageFetchStatus struct {
 	Msg        string `json:"msg"`         // The message of the task status
 	IsProgress bool   `json:"is_progress"` // If the task is in progress
@@ -30,11 +58,12 @@ type ResponsePageFetchStatus struct {
 // @Router       /pages/fetch/status  [get]
 func PageFetchStatus(app *core.App, router fiber.Router) {
 	router.Get("/pages/fetch/status", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		progress, isProgress := getPageFetchProgress(app)
 		return common.RespData(c, ResponsePageFetchStatus{
-			Msg:        i18n.T("{{done}} of {{total}} done", Map{"done": allPageFetchDone, "total": allPageFetchTotal}),
-			IsProgress: allPageFetching,
-			Done:       allPageFetchDone,
-			Total:      allPageFetchTotal,
+			Msg:        i18n.T("{{done}} of {{total}} done", Map{"done": progress.Done, "total": progress.Total}),
+			IsProgress: isProgress,
+			Done:       progress.Done,
+			Total:      progress.Total,
 		})
 	}))
 }
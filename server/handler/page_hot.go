@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ParamsPageHot struct {
+	SiteName string `query:"site_name" json:"site_name" validate:"optional"`                 // The site name of your content scope
+	SortBy   string `query:"sort_by" json:"sort_by" enums:"pv,comments" validate:"optional"` // Sort by the page views or the comment count (default: pv)
+	Limit    int    `query:"limit" json:"limit" validate:"optional"`                         // The number of pages (default: 5, max: 100)
+}
+
+type HotPage struct {
+	entity.CookedPage
+	CommentCount int64 `json:"comment_count"` // The number of public comments
+}
+
+type ResponsePageHot struct {
+	Pages []HotPage `json:"pages"`
+}
+
+// @Id           GetHotPages
+// @Summary      Get Hot Pages
+// @Description  Get the most viewed or the most commented pages, for rendering the popular posts
+// @Tags         Page
+// @Param        options  query  ParamsPageHot  false  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponsePageHot
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /pages/hot  [get]
+func PageHot(app *core.App, router fiber.Router) {
+	router.Get("/pages/hot", func(c *fiber.Ctx) error {
+		var p ParamsPageHot
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.SiteName != "" {
+			if _, ok, resp := common.CheckSiteExist(app, c, p.SiteName); !ok {
+				return resp
+			}
+		}
+
+		if p.Limit <= 0 {
+			p.Limit = 5
+		}
+		if p.Limit > 100 {
+			p.Limit = 100
+		}
+
+		tbPages := app.Dao().GetTableName(&entity.Page{})
+		tbComments := app.Dao().GetTableName(&entity.Comment{})
+
+		q := app.Dao().DB().Model(&entity.Page{}).Limit(p.Limit)
+		if p.SiteName != "" {
+			q = q.Where(tbPages+".site_name = ?", p.SiteName)
+		}
+
+		// The newer page comes first when tied
+		switch p.SortBy {
+		case "", "pv":
+			q = q.Order("pv DESC").Order(tbPages + ".id DESC")
+		case "comments":
+			countComments := app.Dao().DB().Model(&entity.Comment{}).Select("COUNT(*)").
+				Where(tbComments + ".page_key = " + tbPages + ".key AND " + tbComments + ".site_name = " + tbPages + ".site_name").
+				Scopes(queryPublicComments(app))
+			q = q.Order(clause.OrderBy{Expression: clause.Expr{SQL: "(?) DESC, " + tbPages + ".id DESC", Vars: []any{countComments}}})
+		default:
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "sort_by"}))
+		}
+
+		var pages []entity.Page
+		q.Find(&pages)
+
+		counts := countPageComments(app,
+			lo.Uniq(lo.Map(pages, func(p entity.Page, _ int) string { return p.SiteName })),
+			lo.Map(pages, func(p entity.Page, _ int) string { return p.Key }))
+		hotPages := lo.Map(pages, func(page entity.Page, _ int) HotPage {
+			return HotPage{
+				CookedPage:   app.Dao().CookPage(&page),
+				CommentCount: counts[page.SiteName+"\n"+page.Key],
+			}
+		})
+
+		return common.RespData(c, ResponsePageHot{
+			Pages: hotPages,
+		})
+	})
+}
+
+// The scope of the comments which are visible to everyone
+func queryPublicComments(app *core.App) func(*gorm.DB) *gorm.DB {
+	return func(d *gorm.DB) *gorm.DB {
+		return d.Where("is_pending = ?", false).Scopes(app.Dao().NoShadowBanned)
+	}
+}
+
+// Count the public comments of pages in the sites, the key of result is the site name and the page key joined by a newline
+func countPageComments(app *core.App, siteNames []string, pageKeys []string) map[string]int64 {
+	counts := map[string]int64{}
+	if len(siteNames) == 0 || len(pageKeys) == 0 {
+		return counts
+	}
+
+	var rows []struct {
+		SiteName string
+		PageKey  string
+		Count    int64
+	}
+	app.Dao().DB().Model(&entity.Comment{}).
+		Select("site_name, page_key, COUNT(*) AS count").
+		Where("site_name IN ? AND page_key IN ?", siteNames, pageKeys).
+		Scopes(queryPublicComments(app)).
+		Group("site_name, page_key").
+		Scan(&rows)
+
+	for _, r := range rows {
+		counts[r.SiteName+"\n"+r.PageKey] = r.Count
+	}
+	return counts
+}
@@ -0,0 +1,110 @@
+package handler_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestPagePV(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.PagePV(app.App, api)
+
+	logPV := func(pageKey string, ua string) int64 {
+		req := httptest.NewRequest("POST", "/pages/pv", strings.NewReader(`{"page_key":"`+pageKey+`","site_name":"Site A"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", ua)
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		return gjson.GetBytes(body, "pv").Int()
+	}
+
+	t.Run("Count every view without dedup", func(t *testing.T) {
+		assert.Equal(t, int64(101), logPV("/test/pv_is_100.html", "A"))
+		assert.Equal(t, int64(102), logPV("/test/pv_is_100.html", "A"))
+	})
+
+	t.Run("Count once per visitor per day with dedup", func(t *testing.T) {
+		app.Conf().PV.Dedup = true
+		defer func() { app.Conf().PV.Dedup = false }()
+
+		assert.Equal(t, int64(1001), logPV("/test/pv_is_1000.html", "A"))
+		assert.Equal(t, int64(1001), logPV("/test/pv_is_1000.html", "A"), "the same visitor should not be counted again")
+		assert.Equal(t, int64(1002), logPV("/test/pv_is_1000.html", "B"))
+		assert.Equal(t, int64(1), logPV("/test/new_pv.html", "A"), "the visits should be deduplicated per page")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		disabled := false
+		app.Conf().PV.Enabled = &disabled
+		defer func() { app.Conf().PV.Enabled = nil }()
+
+		assert.Equal(t, int64(10000), logPV("/test/pv_is_10000.html", "A"))
+	})
+}
+
+func TestPageHot(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.PageHot(app.App, api)
+
+	get := func(query string) (int, gjson.Result) {
+		resp, err := api.Test(httptest.NewRequest("GET", "/pages/hot?"+query, nil))
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(body)
+	}
+	keys := func(data gjson.Result) []string {
+		keys := []string{}
+		for _, p := range data.Get("pages").Array() {
+			keys = append(keys, p.Get("key").String())
+		}
+		return keys
+	}
+
+	t.Run("Most viewed", func(t *testing.T) {
+		code, data := get("site_name=Site%20A&limit=2")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, []string{"/test/pv_is_10000.html", "/test/pv_is_1000.html"}, keys(data))
+		assert.Equal(t, int64(10000), data.Get("pages.0.pv").Int())
+	})
+
+	t.Run("Most commented", func(t *testing.T) {
+		var expected int64
+		app.Dao().DB().Model(&entity.Comment{}).
+			Where("page_key = ? AND site_name = ? AND is_pending = ?", "/test_pagination.html", "Site A", false).
+			Count(&expected)
+
+		code, data := get("site_name=Site%20A&sort_by=comments&limit=3")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, []string{"/test_pagination.html", "/test/1000.html"}, keys(data)[:2])
+		assert.Equal(t, expected, data.Get("pages.0.comment_count").Int())
+		assert.Equal(t, int64(6), data.Get("pages.1.comment_count").Int())
+		assert.Equal(t, int64(0), data.Get("pages.2.comment_count").Int())
+	})
+
+	t.Run("Pending comments are not counted", func(t *testing.T) {
+		_, data := get("site_name=Site%20B&sort_by=comments")
+		assert.Equal(t, []string{"/site_b/1001.html"}, keys(data))
+		assert.Equal(t, int64(1), data.Get("pages.0.comment_count").Int())
+	})
+
+	t.Run("Invalid params", func(t *testing.T) {
+		code, _ := get("sort_by=abc")
+		assert.Equal(t, 400, code)
+
+		code, _ = get("site_name=Not%20Exist")
+		assert.Equal(t, 404, code)
+	})
+}
@@ -32,7 +32,7 @@ type ResponsePageList struct {
 // @Failure      403  {object}  Map{msg=string}
 // @Router       /pages  [get]
 func PageList(app *core.App, router fiber.Router) {
-	router.Get("/pages", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Get("/pages", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
 		var p ParamsPageList
 		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
 			return resp
@@ -44,8 +44,13 @@ func PageList(app *core.App, router fiber.Router) {
 			if _, ok, resp := common.CheckSiteExist(app, c, p.SiteName); !ok {
 				return resp
 			}
+			if ok, resp := common.CheckAdminSite(c, admin, p.SiteName); !ok {
+				return resp
+			}
 
 			q = q.Where("site_name = ?", p.SiteName)
+		} else if sites := admin.GetAdminSites(); sites != nil {
+			q = q.Where("site_name IN ?", sites)
 		}
 
 		// Search
@@ -73,7 +78,7 @@ func PageList(app *core.App, router fiber.Router) {
 
 		var cookedPages []entity.CookedPage
 		for _, p := range pages {
-			cookedPages = append(cookedPages, app.Dao().CookPage(&p))
+			cookedPages = append(cookedPages, cookPageWithCommentClose(app, &p))
 		}
 
 		return common.RespData(c, ResponsePageList{
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"strconv"
+
 	"github.com/artalkjs/artalk/v2/internal/core"
 	"github.com/artalkjs/artalk/v2/internal/sync"
 	"github.com/artalkjs/artalk/v2/server/common"
@@ -19,7 +21,7 @@ type ResponsePagePV struct {
 
 // @Id           LogPv
 // @Summary      Increase Page Views (PV)
-// @Description  Increase and get the number of page views
+// @Description  Increase and get the number of page views, the views of a visitor on a page are counted only once per day if `pv.dedup` is enabled
 // @Tags         Page
 // @Param        page  body  ParamsPagePV  true  "The page to record pv"
 // @Accept       json
@@ -28,6 +30,7 @@ type ResponsePagePV struct {
 // @Router       /pages/pv  [post]
 func PagePV(app *core.App, router fiber.Router) {
 	mutexMap := sync.NewKeyMutex[string]()
+	visitors := newPVVisitors(app.Store)
 
 	router.Post("/pages/pv", func(c *fiber.Ctx) error {
 		var p ParamsPagePV
@@ -40,16 +43,24 @@ func PagePV(app *core.App, router fiber.Router) {
 		defer mutex.Unlock()
 
 		// find page
-		page := app.Dao().FindCreatePage(p.PageKey, p.PageTitle, p.SiteName)
-
-		// ip := c.RealIP()
-		// ua := c.Request().UserAgent()
+		page := findCreatePage(app, p.PageKey, p.PageTitle, p.SiteName)
 
-		page.PV++
-		app.Dao().UpdatePage(&page)
+		conf := app.Conf().PV
+		if conf.IsEnabled() && (!conf.Dedup || visitors.Visit(page.SiteName, page.Key, getPVVisitor(app, c))) {
+			page.PV++
+			app.Dao().UpdatePage(&page)
+		}
 
 		return common.RespData(c, ResponsePagePV{
 			PV: page.PV,
 		})
 	})
 }
+
+// Get the identity of visitor, which is the user ID if logged in, otherwise the IP and the user agent
+func getPVVisitor(app *core.App, c *fiber.Ctx) string {
+	if user, err := common.GetUserByReq(app, c); err == nil && !user.IsEmpty() {
+		return "user:" + strconv.FormatUint(uint64(user.ID), 10)
+	}
+	return "ip:" + c.IP() + "\n" + string(c.Request().Header.UserAgent())
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+)
+
+// The visits of today for deduplicating the page views,
+// which are kept in the store (shared by the instances in cluster mode) and expire at the end of the day
+type pvVisitors struct {
+	store func() store.Store
+}
+
+func newPVVisitors(store func() store.Store) *pvVisitors {
+	return &pvVisitors{store: store}
+}
+
+// Record the visit of the visitor on the page, false is returned if it is visited already today
+func (v *pvVisitors) Visit(siteName string, pageKey string, visitor string) bool {
+	now := time.Now()
+	day := now.Format(dateOnlyFormat)
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+
+	key := "pv_visit:" + day + ":" + utils.GetMD5Hash(siteName+"\n"+pageKey+"\n"+visitor)
+	ok, err := v.store().SetNX(key, "1", tomorrow.Sub(now))
+	if err != nil {
+		log.Error("[PV] Failed to record the visit: ", err)
+		return true
+	}
+	return ok
+}
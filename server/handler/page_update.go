@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"strings"
+	"time"
+
 	"github.com/artalkjs/artalk/v2/internal/core"
 	"github.com/artalkjs/artalk/v2/internal/dao"
 	"github.com/artalkjs/artalk/v2/internal/entity"
@@ -15,6 +18,9 @@ type ParamsPageUpdate struct {
 	Key       string `json:"key" validate:"required"`        // Updated page key
 	Title     string `json:"title" validate:"required"`      // Updated page title
 	AdminOnly bool   `json:"admin_only" validate:"required"` // Updated page admin_only option
+
+	CommentStatus *string `json:"comment_status" validate:"optional"` // Updated page comment status ("" to follow the `comment_close` config, "open" to never close or "closed" to close immediately)
+	PublishedAt   *string `json:"published_at" validate:"optional"`   // Updated page published date (e.g. "2024-12-31" or RFC3339, empty to use the created date)
 }
 
 type ResponsePageUpdate struct {
@@ -37,7 +43,7 @@ type ResponsePageUpdate struct {
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /pages/{id}  [put]
 func PageUpdate(app *core.App, router fiber.Router) {
-	router.Put("/pages/:id", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Put("/pages/:id", common.AdminPermGuard(app, entity.AdminPermManage, func(c *fiber.Ctx, admin entity.User) error {
 		id, _ := c.ParamsInt("id")
 
 		var p ParamsPageUpdate
@@ -56,6 +62,25 @@ func PageUpdate(app *core.App, router fiber.Router) {
 			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Page")}))
 		}
 
+		// check admin site scope
+		for _, siteName := range []string{page.SiteName, p.SiteName} {
+			if ok, resp := common.CheckAdminSite(c, admin, siteName); !ok {
+				return resp
+			}
+		}
+
+		if p.CommentStatus != nil && !entity.IsValidPageCommentStatus(*p.CommentStatus) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "comment_status"}))
+		}
+		var publishedAt *time.Time
+		if p.PublishedAt != nil && strings.TrimSpace(*p.PublishedAt) != "" {
+			t, err := parseDateTime(*p.PublishedAt)
+			if err != nil {
+				return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "published_at"}), Map{"err": err.Error()})
+			}
+			publishedAt = &t
+		}
+
 		// 重命名合法性检测
 		modifyKey := p.Key != page.Key
 		if modifyKey && !app.Dao().FindPage(p.Key, page.SiteName).IsEmpty() {
@@ -63,12 +88,20 @@ func PageUpdate(app *core.App, router fiber.Router) {
 		}
 
 		// 预先删除缓存，防止修改主键原有 page_key 占用问题
+		before := app.Dao().CookPage(&page)
+
 		app.Dao().CacheAction(func(cache *dao.DaoCache) {
 			cache.PageCacheDel(&page)
 		})
 
 		page.Title = p.Title
 		page.AdminOnly = p.AdminOnly
+		if p.CommentStatus != nil {
+			page.CommentStatus = *p.CommentStatus
+		}
+		if p.PublishedAt != nil {
+			page.PublishedAt = publishedAt
+		}
 		if modifyKey {
 			// 相关性数据修改
 			var comments []entity.Comment
@@ -86,8 +119,11 @@ func PageUpdate(app *core.App, router fiber.Router) {
 			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Page")}))
 		}
 
+		common.AddAudit(c, common.Audit{Action: "page.update", TargetType: "page", TargetID: page.ID, SiteName: page.SiteName,
+			Before: before, After: app.Dao().CookPage(&page)})
+
 		return common.RespData(c, ResponsePageUpdate{
-			CookedPage: app.Dao().CookPage(&page),
+			CookedPage: cookPageWithCommentClose(app, &page),
 		})
 	}))
 }
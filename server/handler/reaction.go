@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"slices"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ResponseReactions struct {
+	Reactions []entity.CookedReaction `json:"reactions"` // The reactions with count (the emojis without any reaction are omitted)
+	Emojis    []string                `json:"emojis"`    // The available emojis
+}
+
+// @Id           GetReactions
+// @Summary      Get Reactions
+// @Description  Get the reactions of a comment
+// @Tags         Reaction
+// @Param        comment_id  path  int  true  "The comment ID"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseReactions
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /reactions/comment/{comment_id}  [get]
+func ReactionGet(app *core.App, router fiber.Router) {
+	router.Get("/reactions/comment/:comment_id", func(c *fiber.Ctx) error {
+		if !app.Conf().Reaction.Enabled {
+			return common.RespError(c, 404, "reactions disabled")
+		}
+
+		commentID, _ := c.ParamsInt("comment_id")
+
+		userID, ip := getReactionVisitor(app, c)
+		reactions := cookReactions(app, []uint{uint(commentID)}, userID, ip)
+
+		return common.RespData(c, ResponseReactions{
+			Reactions: reactions[uint(commentID)],
+			Emojis:    app.Conf().Reaction.GetEmojis(),
+		})
+	})
+}
+
+type ParamsReactionToggle struct {
+	Emoji string `json:"emoji" validate:"required"` // The emoji of reaction (should be one of the available emojis)
+}
+
+// @Id           ToggleReaction
+// @Summary      Toggle Reaction
+// @Description  React to a comment with an emoji, or remove the reaction if already reacted
+// @Tags         Reaction
+// @Param        comment_id  path  int                   true  "The comment ID"
+// @Param        reaction    body  ParamsReactionToggle  true  "The reaction data"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseReactions
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /reactions/comment/{comment_id}  [post]
+func ReactionToggle(app *core.App, router fiber.Router) {
+	router.Post("/reactions/comment/:comment_id", common.LimiterGuard(app, func(c *fiber.Ctx) error {
+		if !app.Conf().Reaction.Enabled {
+			return common.RespError(c, 404, "reactions disabled")
+		}
+
+		commentID, _ := c.ParamsInt("comment_id")
+
+		var p ParamsReactionToggle
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if !slices.Contains(app.Conf().Reaction.GetEmojis(), p.Emoji) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "emoji"}))
+		}
+
+		comment := app.Dao().FindComment(uint(commentID))
+		if comment.IsEmpty() || comment.IsPending {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Comment")}))
+		}
+
+		// Un-react if already reacted, otherwise react
+		userID, ip := getReactionVisitor(app, c)
+		existsReactions := app.Dao().FindVisitorReactions([]uint{comment.ID}, userID, ip)
+		if i := slices.IndexFunc(existsReactions, func(r entity.Reaction) bool { return r.Emoji == p.Emoji }); i != -1 {
+			app.Dao().DB().Unscoped().Delete(&existsReactions[i])
+		} else if _, err := app.Dao().NewReaction(comment.ID, p.Emoji, userID, string(c.Request().Header.UserAgent()), ip); err != nil {
+			return common.RespError(c, 500, "Failed to create reaction")
+		}
+
+		// Sync the total count for sorting
+		var count int64
+		app.Dao().DB().Model(&entity.Reaction{}).Where("comment_id = ?", comment.ID).Count(&count)
+		comment.ReactionCount = int(count)
+		app.Dao().UpdateComment(&comment)
+
+		return common.RespData(c, ResponseReactions{
+			Reactions: cookReactions(app, []uint{comment.ID}, userID, ip)[comment.ID],
+			Emojis:    app.Conf().Reaction.GetEmojis(),
+		})
+	}))
+}
+
+// The visitor is identified by the user ID if logged in, otherwise by the (anonymized) IP
+func getReactionVisitor(app *core.App, c *fiber.Ctx) (uint, string) {
+	user, _ := common.GetUserByReq(app, c)
+	return user.ID, app.AnonymizeIP(c.IP())
+}
+
+// Get the reactions of comments in the order of available emojis
+func cookReactions(app *core.App, commentIDs []uint, userID uint, ip string) map[uint][]entity.CookedReaction {
+	counts := app.Dao().GetReactionCounts(commentIDs)
+	reacted := map[uint][]string{}
+	for _, r := range app.Dao().FindVisitorReactions(commentIDs, userID, ip) {
+		reacted[r.CommentID] = append(reacted[r.CommentID], r.Emoji)
+	}
+
+	result := map[uint][]entity.CookedReaction{}
+	for _, id := range commentIDs {
+		result[id] = []entity.CookedReaction{}
+		for _, emoji := range app.Conf().Reaction.GetEmojis() {
+			if counts[id][emoji] == 0 {
+				continue
+			}
+			result[id] = append(result[id], entity.CookedReaction{
+				Emoji:     emoji,
+				Count:     counts[id][emoji],
+				IsReacted: slices.Contains(reacted[id], emoji),
+			})
+		}
+	}
+
+	return result
+}
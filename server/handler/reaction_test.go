@@ -0,0 +1,68 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestReaction(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.ReactionGet(app.App, api)
+	handler.ReactionToggle(app.App, api)
+	handler.CommentList(app.App, api)
+
+	request := func(method, url, body, ip string) (int, gjson.Result) {
+		return NewApiRequest(t, api, map[string]string{"X-Forwarded-For": ip})(method, url, "", body) // mock IP
+	}
+	react := func(commentID, emoji, ip string) (int, gjson.Result) {
+		return request("POST", "/reactions/comment/"+commentID, `{"emoji":"`+emoji+`"}`, ip)
+	}
+
+	code, _ := react("1000", "👍", "10.0.0.1")
+	assert.Equal(t, 404, code, "should be disabled by default")
+
+	app.Conf().Reaction.Enabled = true
+
+	code, data := react("1000", "👍", "10.0.0.1")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, `[{"emoji":"👍","count":1,"is_reacted":true}]`, data.Get("reactions").Raw)
+
+	code, _ = react("1000", "🦄", "10.0.0.1")
+	assert.Equal(t, 400, code, "should reject the emoji not in the set")
+
+	code, _ = react("99999", "👍", "10.0.0.1")
+	assert.Equal(t, 404, code)
+
+	react("1000", "🎉", "10.0.0.1")
+	code, data = react("1000", "👍", "10.0.0.2")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, `[{"emoji":"👍","count":2,"is_reacted":true},{"emoji":"🎉","count":1,"is_reacted":false}]`, data.Get("reactions").Raw,
+		"should be deduplicated per visitor and ordered by the emoji set")
+
+	// Toggle off
+	code, data = react("1000", "👍", "10.0.0.2")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, `[{"emoji":"👍","count":1,"is_reacted":false},{"emoji":"🎉","count":1,"is_reacted":false}]`, data.Get("reactions").Raw)
+	assert.Equal(t, 2, app.Dao().FindComment(1000).ReactionCount)
+
+	code, data = request("GET", "/reactions/comment/1000", "", "10.0.0.1")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, int64(2), data.Get("reactions.#(is_reacted==true)#").Get("#").Int())
+	assert.Equal(t, int64(6), data.Get("emojis.#").Int())
+
+	// Comment list payload and sort
+	react("1002", "😄", "10.0.0.1")
+	react("1002", "😄", "10.0.0.2")
+	react("1002", "😄", "10.0.0.3")
+	code, data = request("GET", "/comments?site_name=Site+A&page_key=/test/1000.html&flat_mode=true&sort_by=reactions&limit=10", "", "10.0.0.3")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, int64(1002), data.Get("comments.0.id").Int(), "should sort by the reactions")
+	assert.Equal(t, `[{"emoji":"😄","count":3,"is_reacted":true}]`, data.Get("comments.0.reactions").Raw)
+	assert.Equal(t, int64(1000), data.Get("comments.1.id").Int())
+	assert.False(t, data.Get("comments.2.reactions").Exists(), "should omit the empty reactions")
+}
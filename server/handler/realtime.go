@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/realtime"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsRealtimeStream struct {
+	SiteName string `query:"site_name" json:"site_name" validate:"required"` // The site name of your content scope
+	PageKey  string `query:"page_key" json:"page_key" validate:"required"`   // The comment page_key
+}
+
+const (
+	realtimePingInterval = 30 * time.Second // The interval of keep-alive messages
+	realtimeSSERetry     = 3000             // The reconnection time of SSE in milliseconds
+)
+
+// @Id           StreamRealtimeComments
+// @Summary      Stream Comments in Real-time
+// @Description  Subscribe the comment events of a page by WebSocket (if upgrade requested) or Server-Sent Events, each message is a `core.RealtimeEvent` in JSON
+// @Tags         Comment
+// @Param        options  query  ParamsRealtimeStream  true  "The options"
+// @Produce      text/event-stream
+// @Success      200  {object}  core.RealtimeEvent
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      429  {object}  Map{msg=string}
+// @Router       /realtime  [get]
+func RealtimeStream(app *core.App, router fiber.Router) {
+	router.Get("/realtime", func(c *fiber.Ctx) error {
+		realtimeService, err := core.AppService[*core.RealtimeService](app)
+		if err != nil || !realtimeService.Enabled() {
+			return common.RespError(c, 404, "realtime disabled")
+		}
+
+		var p ParamsRealtimeStream
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if _, ok, resp := common.CheckSiteExist(app, c, p.SiteName); !ok {
+			return resp
+		}
+
+		// the limits are checked before the handshake to respond the error,
+		// and the stream is acquired after that, since the handshake may be aborted
+		ip := c.IP()
+		if !realtimeService.AllowStream(ip) {
+			return common.RespError(c, 429, i18n.T("Too many {{name}}", Map{"name": "connections"}))
+		}
+
+		if realtime.IsWebSocketUpgrade(c) {
+			return realtime.UpgradeWebSocket(c, realtimeService.MaxMessageSize(), func(ws *realtime.WSConn) {
+				release, ok := realtimeService.AcquireStream(ip)
+				if !ok {
+					ws.CloseWith(realtime.WSCloseTryAgainLater, "too many connections")
+					return
+				}
+				defer release()
+
+				sub := realtimeService.Subscribe(p.SiteName, p.PageKey)
+				if sub == nil {
+					return
+				}
+				defer sub.Close()
+
+				ticker := time.NewTicker(realtimePingInterval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case data, ok := <-sub.C:
+						if !ok || ws.WriteText(data) != nil {
+							return
+						}
+					case <-ticker.C:
+						if ws.Ping() != nil {
+							return
+						}
+					case <-ws.Closed():
+						return
+					case <-realtimeService.Closing():
+						return
+					}
+				}
+			})
+		}
+
+		c.Set(fiber.HeaderContentType, "text/event-stream; charset=utf-8")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		c.Set("X-Accel-Buffering", "no") // Disable the response buffering of nginx
+
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			fmt.Fprintf(w, "retry: %d\n\n", realtimeSSERetry)
+			if w.Flush() != nil {
+				return
+			}
+
+			release, ok := realtimeService.AcquireStream(ip)
+			if !ok {
+				return // the client reconnects after the retry time
+			}
+			defer release()
+
+			sub := realtimeService.Subscribe(p.SiteName, p.PageKey)
+			if sub == nil {
+				return
+			}
+			defer sub.Close()
+
+			ticker := time.NewTicker(realtimePingInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case data, ok := <-sub.C:
+					if !ok {
+						return
+					}
+					fmt.Fprintf(w, "data: %s\n\n", data)
+				case <-ticker.C:
+					fmt.Fprint(w, ": ping\n\n")
+				case <-realtimeService.Closing():
+					return
+				}
+				if w.Flush() != nil { // The client is disconnected
+					return
+				}
+			}
+		})
+
+		return nil
+	})
+}
+
+// Push the comment event to the real-time subscribers of page
+//
+// The comment data is the same as the public comment list, and omitted for the deleted event.
+func publishCommentEvent(app *core.App, eventType string, comment entity.Comment) {
+	realtimeService, err := core.AppService[*core.RealtimeService](app)
+	if err != nil || !realtimeService.Enabled() {
+		return
+	}
+
+	// The comments of shadow banned user are not pushed to others
+	if app.Dao().FetchUserForComment(&comment).IsShadowBanned {
+		return
+	}
+
+	event := core.RealtimeEvent{
+		Type:      eventType,
+		CommentID: comment.ID,
+	}
+	if eventType != core.RealtimeCommentDeleted {
+		cooked := []entity.CookedComment{app.Dao().CookComment(&comment)}
+		cooked = findIPRegionForComments(app, nil, cooked)
+		cooked = hideModerationForComments(cooked)
+		cooked = hideEditedForComments(app, cooked)
+		event.Comment = &cooked[0]
+	}
+
+	if err := realtimeService.Publish(comment.SiteName, comment.PageKey, event); err != nil {
+		log.Error("[RealtimeService] publish event err: ", err)
+	}
+}
+
+// Push the events of an updated comment, it is removed from the previous page
+// if moved or set to pending, and added to the current page if published
+func publishCommentChangedEvents(app *core.App, previous entity.Comment, current entity.Comment) {
+	isMoved := previous.SiteName != current.SiteName || previous.PageKey != current.PageKey
+	if !previous.IsPending && (isMoved || current.IsPending) {
+		publishCommentEvent(app, core.RealtimeCommentDeleted, previous)
+	}
+	if current.IsPending {
+		return
+	}
+	if previous.IsPending || isMoved {
+		publishCommentEvent(app, core.RealtimeCommentCreated, current)
+	} else {
+		publishCommentEvent(app, core.RealtimeCommentUpdated, current)
+	}
+}
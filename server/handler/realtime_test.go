@@ -0,0 +1,119 @@
+package handler_test
+
+import (
+	"bufio"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestRealtimeStream(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.RealtimeStream(app.App, api)
+	handler.CommentPin(app.App, api)
+	handler.CommentDelete(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := func(method string, url string, body string) int {
+		code, _ := NewApiRequest(t, api)(method, url, token, body)
+		return code
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.Equal(t, 404, request("GET", "/realtime?site_name=Site+A&page_key=/test/1000.html", ""))
+	})
+
+	app.Conf().Realtime.Enabled = true
+	realtimeService, err := core.AppService[*core.RealtimeService](app.App)
+	assert.NoError(t, err)
+	assert.NoError(t, realtimeService.Init())
+	defer realtimeService.Dispose()
+
+	t.Run("SiteNotFound", func(t *testing.T) {
+		assert.Equal(t, 404, request("GET", "/realtime?site_name=Site+X&page_key=/test/1000.html", ""))
+	})
+
+	t.Run("SSE", func(t *testing.T) {
+		type result struct {
+			contentType string
+			body        string
+		}
+		done := make(chan result)
+		go func() {
+			resp, err := api.Test(httptest.NewRequest("GET", "/realtime?site_name=Site+A&page_key=/test/1000.html", nil), -1)
+			if !assert.NoError(t, err) {
+				close(done)
+				return
+			}
+			buf, _ := io.ReadAll(resp.Body)
+			done <- result{resp.Header.Get("Content-Type"), string(buf)}
+		}()
+
+		assert.Eventually(t, func() bool {
+			return realtimeService.Subscribers("Site A", "/test/1000.html") == 1
+		}, 3*time.Second, 10*time.Millisecond, "should subscribe the page")
+
+		assert.Equal(t, 200, request("POST", "/comments/pin", `{"ids":[1000],"is_pinned":true}`))
+		assert.Equal(t, 200, request("POST", "/comments/pin", `{"ids":[1006],"is_pinned":true}`)) // the other page
+		assert.Equal(t, 200, request("DELETE", "/comments/1005", ""))
+
+		// End the stream
+		assert.NoError(t, realtimeService.Dispose())
+		r := <-done
+		assert.Contains(t, r.contentType, "text/event-stream")
+
+		var events []gjson.Result
+		scanner := bufio.NewScanner(strings.NewReader(r.body))
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				events = append(events, gjson.Parse(data))
+			}
+		}
+		assert.True(t, strings.HasPrefix(r.body, "retry: "))
+		if assert.Len(t, events, 2, "should only receive the events of page") {
+			assert.Equal(t, core.RealtimeCommentUpdated, events[0].Get("type").String())
+			assert.Equal(t, int64(1000), events[0].Get("comment.id").Int())
+			assert.True(t, events[0].Get("comment.is_pinned").Bool())
+			assert.False(t, events[0].Get("comment.moderation").Exists(), "should hide the moderation info")
+
+			assert.Equal(t, core.RealtimeCommentDeleted, events[1].Get("type").String())
+			assert.Equal(t, int64(1005), events[1].Get("comment_id").Int())
+			assert.False(t, events[1].Get("comment").Exists())
+		}
+	})
+	t.Run("ConnLimit", func(t *testing.T) {
+		app.Conf().Realtime.MaxConnsPerIP = 1
+		assert.NoError(t, realtimeService.Init())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			resp, err := api.Test(httptest.NewRequest("GET", "/realtime?site_name=Site+A&page_key=/test/1000.html", nil), -1)
+			if assert.NoError(t, err) {
+				io.ReadAll(resp.Body)
+			}
+		}()
+
+		assert.Eventually(t, func() bool {
+			return realtimeService.Subscribers("Site A", "/test/1000.html") == 1
+		}, 3*time.Second, 10*time.Millisecond)
+		assert.Equal(t, 429, request("GET", "/realtime?site_name=Site+A&page_key=/test/1000.html", ""), "should limit the connections of IP")
+		assert.Equal(t, 429, request("GET", "/realtime?site_name=Site+A&page_key=/test/1001.html", ""), "should limit the connections of IP across pages")
+
+		assert.NoError(t, realtimeService.Dispose())
+		<-done
+	})
+}
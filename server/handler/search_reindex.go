@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ResponseSearchReindex struct {
+	Engine string `json:"engine"` // The search engine in use
+	Count  int64  `json:"count"`  // The number of comments indexed
+}
+
+// @Id           ReindexSearch
+// @Summary      Rebuild Search Index
+// @Description  Rebuild the full-text index of all comments, e.g. after switching the search engine
+// @Tags         Search
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  ResponseSearchReindex
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /search/reindex  [post]
+func SearchReindex(app *core.App, router fiber.Router) {
+	// The index contains the comments of all sites
+	router.Post("/search/reindex", common.AdminPermGuard(app, entity.AdminPermSuper, func(c *fiber.Ctx, admin entity.User) error {
+		searchService, err := core.AppService[*core.SearchService](app)
+		if err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+
+		count, err := searchService.Reindex()
+		if err != nil {
+			log.Error("[SearchReindex] Failed to rebuild the index: ", err)
+			return common.RespError(c, 500, err.Error())
+		}
+
+		return common.RespData(c, ResponseSearchReindex{
+			Engine: searchService.Engine(),
+			Count:  count,
+		})
+	}))
+}
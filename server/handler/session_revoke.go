@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsSessionRevoke struct {
+	UserID uint `json:"user_id" validate:"optional"` // The user ID whose sessions to revoke (all users if empty)
+}
+
+// @Id           RevokeSessions
+// @Summary      Revoke Login Sessions
+// @Description  Invalidate all the issued access tokens and refresh tokens of a user or all users, e.g. after a credential leak
+// @Tags         Auth
+// @Security     ApiKeyAuth
+// @Param        data  body  ParamsSessionRevoke  false  "The user to revoke"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /sessions/revoke  [post]
+func SessionRevoke(app *core.App, router fiber.Router) {
+	router.Post("/sessions/revoke", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		var p ParamsSessionRevoke
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.UserID != 0 && app.Dao().FindUserByID(p.UserID).IsEmpty() {
+			return common.RespError(c, 404, i18n.T("User not found"))
+		}
+
+		if err := app.Dao().RevokeUserSessions(p.UserID); err != nil {
+			log.Error("[SessionRevoke] ", err)
+			return common.RespError(c, 500, i18n.T("Failed to revoke sessions"))
+		}
+
+		log.Info("[SessionRevoke] Login sessions revoked [user_id=", p.UserID, "]")
+
+		return common.RespSuccess(c)
+	}))
+}
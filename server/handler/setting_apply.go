@@ -53,6 +53,9 @@ func SettingApply(app *core.App, router fiber.Router) {
 			return common.RespError(c, 500, "Config instance err: "+err.Error())
 		}
 
+		before, after := auditConfigChanges(app.Conf(), conf)
+		common.AddAudit(c, common.Audit{Action: "settings.update", Before: before, After: after})
+
 		app.SetConf(conf)
 
 		// 重启服务
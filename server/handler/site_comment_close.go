@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+var siteCommentCloseOverrides = siteOverrides[config.CommentCloseConf]{
+	name:   "comment_close",
+	field:  func(site *entity.Site) *string { return &site.CommentCloseConf },
+	global: func(app *core.App) config.CommentCloseConf { return app.Conf().CommentClose },
+	merge:  config.MergeCommentCloseConf,
+	validate: func(conf config.CommentCloseConf) (string, error) {
+		if conf.CloseAt != "" {
+			if _, err := parseDateTime(conf.CloseAt); err != nil {
+				return "close_at", err
+			}
+		}
+		return "", nil
+	},
+}
+
+type ResponseSiteCommentClose struct {
+	Overrides Map                     `json:"overrides"` // The comment close config overrides of site (same structure as the `comment_close` config)
+	Effective config.CommentCloseConf `json:"effective"` // The effective config merged over the global config
+}
+
+// @Id           GetSiteCommentClose
+// @Summary      Get Site Comment Close Config
+// @Description  Get the comment close config overrides of a specific site
+// @Tags         Site
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The site ID"
+// @Produce      json
+// @Success      200  {object}  ResponseSiteCommentClose
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /sites/{id}/comment-close  [get]
+func SiteCommentCloseGet(app *core.App, router fiber.Router) {
+	router.Get("/sites/:id/comment-close", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		site, ok, resp := findAdminSiteByParam(app, c, admin)
+		if !ok {
+			return resp
+		}
+
+		return common.RespData(c, ResponseSiteCommentClose{
+			Overrides: siteCommentCloseOverrides.get(&site),
+			Effective: siteCommentCloseOverrides.resolve(app, site.Name),
+		})
+	}))
+}
+
+type ParamsSiteCommentCloseUpdate struct {
+	Overrides Map `json:"overrides" validate:"optional"` // The comment close config overrides, empty to use the global config
+}
+
+// @Id           UpdateSiteCommentClose
+// @Summary      Update Site Comment Close Config
+// @Description  Update the comment close config overrides of a specific site, which are merged over the global config
+// @Tags         Site
+// @Security     ApiKeyAuth
+// @Param        id         path  int                           true  "The site ID"
+// @Param        overrides  body  ParamsSiteCommentCloseUpdate  true  "The config overrides"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseSiteCommentClose
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /sites/{id}/comment-close  [put]
+func SiteCommentCloseUpdate(app *core.App, router fiber.Router) {
+	router.Put("/sites/:id/comment-close", common.AdminPermGuard(app, entity.AdminPermManage, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsSiteCommentCloseUpdate
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		site, ok, resp := findAdminSiteByParam(app, c, admin)
+		if !ok {
+			return resp
+		}
+		if ok, resp := siteCommentCloseOverrides.update(app, c, &site, p.Overrides); !ok {
+			return resp
+		}
+
+		return common.RespData(c, ResponseSiteCommentClose{
+			Overrides: lo.If(p.Overrides == nil, Map{}).Else(p.Overrides),
+			Effective: siteCommentCloseOverrides.resolve(app, site.Name),
+		})
+	}))
+}
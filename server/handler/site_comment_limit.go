@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+var siteCommentLimitOverrides = siteOverrides[config.CommentLimitConf]{
+	name:   "comment_limit",
+	field:  func(site *entity.Site) *string { return &site.CommentLimitConf },
+	global: func(app *core.App) config.CommentLimitConf { return app.Conf().CommentLimit },
+	merge:  config.MergeCommentLimitConf,
+}
+
+type ResponseSiteCommentLimit struct {
+	Overrides Map                     `json:"overrides"` // The comment limit config overrides of site (same structure as the `comment_limit` config)
+	Effective config.CommentLimitConf `json:"effective"` // The effective config merged over the global config
+}
+
+// @Id           GetSiteCommentLimit
+// @Summary      Get Site Comment Limit Config
+// @Description  Get the comment limit config overrides of a specific site
+// @Tags         Site
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The site ID"
+// @Produce      json
+// @Success      200  {object}  ResponseSiteCommentLimit
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /sites/{id}/comment-limit  [get]
+func SiteCommentLimitGet(app *core.App, router fiber.Router) {
+	router.Get("/sites/:id/comment-limit", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		site, ok, resp := findAdminSiteByParam(app, c, admin)
+		if !ok {
+			return resp
+		}
+
+		return common.RespData(c, ResponseSiteCommentLimit{
+			Overrides: siteCommentLimitOverrides.get(&site),
+			Effective: siteCommentLimitOverrides.resolve(app, site.Name),
+		})
+	}))
+}
+
+type ParamsSiteCommentLimitUpdate struct {
+	Overrides Map `json:"overrides" validate:"optional"` // The comment limit config overrides, empty to use the global config
+}
+
+// @Id           UpdateSiteCommentLimit
+// @Summary      Update Site Comment Limit Config
+// @Description  Update the comment limit config overrides of a specific site, which are merged over the global config
+// @Tags         Site
+// @Security     ApiKeyAuth
+// @Param        id         path  int                           true  "The site ID"
+// @Param        overrides  body  ParamsSiteCommentLimitUpdate  true  "The config overrides"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseSiteCommentLimit
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /sites/{id}/comment-limit  [put]
+func SiteCommentLimitUpdate(app *core.App, router fiber.Router) {
+	router.Put("/sites/:id/comment-limit", common.AdminPermGuard(app, entity.AdminPermManage, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsSiteCommentLimitUpdate
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		site, ok, resp := findAdminSiteByParam(app, c, admin)
+		if !ok {
+			return resp
+		}
+		if ok, resp := siteCommentLimitOverrides.update(app, c, &site, p.Overrides); !ok {
+			return resp
+		}
+
+		return common.RespData(c, ResponseSiteCommentLimit{
+			Overrides: lo.If(p.Overrides == nil, Map{}).Else(p.Overrides),
+			Effective: siteCommentLimitOverrides.resolve(app, site.Name),
+		})
+	}))
+}
@@ -60,8 +60,11 @@ func SiteCreate(app *core.App, router fiber.Router) {
 			return common.RespError(c, 500, i18n.T("{{name}} creation failed", Map{"name": i18n.T("Site")}))
 		}
 
+		cooked := app.Dao().CookSite(&site)
+		common.AddAudit(c, common.Audit{Action: "site.create", TargetType: "site", TargetID: site.ID, SiteName: site.Name, After: cooked})
+
 		return common.RespData(c, ResponseSiteCreate{
-			CookedSite: app.Dao().CookSite(&site),
+			CookedSite: cooked,
 		})
 	}))
 }
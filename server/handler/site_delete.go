@@ -28,11 +28,15 @@ func SiteDelete(app *core.App, router fiber.Router) {
 			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Site")}))
 		}
 
+		before := app.Dao().CookSite(&site)
+
 		err := app.Dao().DelSite(&site)
 		if err != nil {
 			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": i18n.T("Site")}))
 		}
 
+		common.AddAudit(c, common.Audit{Action: "site.delete", TargetType: "site", TargetID: site.ID, SiteName: site.Name, Before: before})
+
 		return common.RespSuccess(c)
 	}))
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
 )
 
 type ResponseSiteList struct {
@@ -21,9 +22,16 @@ type ResponseSiteList struct {
 // @Success      200  {object}  ResponseSiteList
 // @Router       /sites  [get]
 func SiteList(app *core.App, router fiber.Router) {
-	router.Get("/sites", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Get("/sites", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
 		sites := app.Dao().FindAllSitesCooked()
 
+		// Only the sites which the admin is allowed to manage
+		if allowed := admin.GetAdminSites(); allowed != nil {
+			sites = lo.Filter(sites, func(s entity.CookedSite, _ int) bool {
+				return lo.Contains(allowed, s.Name)
+			})
+		}
+
 		return common.RespData(c, ResponseSiteList{
 			Sites: sites,
 			Count: len(sites),
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+var siteModeratorOverrides = siteOverrides[config.ModeratorConf]{
+	name:   "moderator",
+	field:  func(site *entity.Site) *string { return &site.ModeratorConf },
+	global: func(app *core.App) config.ModeratorConf { return app.Conf().Moderator },
+	merge:  config.MergeModeratorConf,
+}
+
+type ResponseSiteModerator struct {
+	Overrides Map `json:"overrides"` // The anti-spam config overrides of site (same structure as the `moderator` config)
+}
+
+// @Id           GetSiteModerator
+// @Summary      Get Site Moderator Config
+// @Description  Get the anti-spam config overrides of a specific site
+// @Tags         Site
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The site ID"
+// @Produce      json
+// @Success      200  {object}  ResponseSiteModerator
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /sites/{id}/moderator  [get]
+func SiteModeratorGet(app *core.App, router fiber.Router) {
+	router.Get("/sites/:id/moderator", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		site, ok, resp := findAdminSiteByParam(app, c, admin)
+		if !ok {
+			return resp
+		}
+
+		return common.RespData(c, ResponseSiteModerator{
+			Overrides: siteModeratorOverrides.get(&site),
+		})
+	}))
+}
+
+type ParamsSiteModeratorUpdate struct {
+	Overrides Map `json:"overrides" validate:"optional"` // The anti-spam config overrides, empty to use the global config
+}
+
+// @Id           UpdateSiteModerator
+// @Summary      Update Site Moderator Config
+// @Description  Update the anti-spam config overrides of a specific site, which are merged over the global config at check time
+// @Tags         Site
+// @Security     ApiKeyAuth
+// @Param        id         path  int                        true  "The site ID"
+// @Param        overrides  body  ParamsSiteModeratorUpdate  true  "The config overrides"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseSiteModerator
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /sites/{id}/moderator  [put]
+func SiteModeratorUpdate(app *core.App, router fiber.Router) {
+	router.Put("/sites/:id/moderator", common.AdminPermGuard(app, entity.AdminPermManage, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsSiteModeratorUpdate
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		site, ok, resp := findAdminSiteByParam(app, c, admin)
+		if !ok {
+			return resp
+		}
+		if ok, resp := siteModeratorOverrides.update(app, c, &site, p.Overrides); !ok {
+			return resp
+		}
+
+		return common.RespData(c, ResponseSiteModerator{
+			Overrides: lo.If(p.Overrides == nil, Map{}).Else(p.Overrides),
+		})
+	}))
+}
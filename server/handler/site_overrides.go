@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The per-site config overrides, which are stored in the site as JSON and merged over the global config
+type siteOverrides[T any] struct {
+	name     string                                    // The config name (e.g. "comment_limit")
+	field    func(site *entity.Site) *string           // The overrides field of site
+	global   func(app *core.App) T                     // The global config
+	merge    func(base T, overrides string) (T, error) // Merge the overrides over the global config
+	validate func(conf T) (string, error)              // Check the merged config, the invalid field name is returned (optional)
+}
+
+// Get the effective config of site, the global config is used if the site has no valid overrides
+func (o siteOverrides[T]) resolve(app *core.App, siteName string) T {
+	if strings.TrimSpace(siteName) == "" {
+		return o.global(app)
+	}
+
+	site := app.Dao().FindSite(siteName)
+	if site.IsEmpty() || strings.TrimSpace(*o.field(&site)) == "" {
+		return o.global(app)
+	}
+
+	conf, err := o.merge(o.global(app), *o.field(&site))
+	if err != nil {
+		log.Error("[Site] Invalid ", o.name, " config overrides of site ", strconv.Quote(siteName), ": ", err)
+		return o.global(app)
+	}
+
+	return conf
+}
+
+// Get the config overrides of site
+func (o siteOverrides[T]) get(site *entity.Site) Map {
+	overrides := Map{}
+	if raw := *o.field(site); raw != "" {
+		_ = json.Unmarshal([]byte(raw), &overrides)
+	}
+	return overrides
+}
+
+// Update the config overrides of site, empty overrides to use the global config
+func (o siteOverrides[T]) update(app *core.App, c *fiber.Ctx, site *entity.Site, overrides Map) (bool, error) {
+	field := o.field(site)
+	before := *field
+
+	*field = ""
+	if len(overrides) > 0 {
+		raw, err := json.Marshal(overrides)
+		if err != nil {
+			return false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "overrides"}))
+		}
+
+		conf, err := o.merge(o.global(app), string(raw))
+		if err != nil {
+			return false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "overrides"}), Map{"err": err.Error()})
+		}
+		if o.validate != nil {
+			if name, err := o.validate(conf); err != nil {
+				return false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": name}), Map{"err": err.Error()})
+			}
+		}
+
+		*field = string(raw)
+	}
+
+	if err := app.Dao().UpdateSite(site); err != nil {
+		return false, common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Site")}))
+	}
+
+	common.AddAudit(c, common.Audit{Action: "site." + o.name + ".update", TargetType: "site", TargetID: site.ID, SiteName: site.Name,
+		Before: auditSiteOverrides(before), After: auditSiteOverrides(*field)})
+
+	return true, nil
+}
+
+// Find the site by the `id` param, which must be managed by the admin
+func findAdminSiteByParam(app *core.App, c *fiber.Ctx, admin entity.User) (entity.Site, bool, error) {
+	id, _ := c.ParamsInt("id")
+
+	site := app.Dao().FindSiteByID(uint(id))
+	if site.IsEmpty() {
+		return site, false, common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Site")}))
+	}
+	if ok, resp := common.CheckAdminSite(c, admin, site.Name); !ok {
+		return site, false, resp
+	}
+
+	return site, true, nil
+}
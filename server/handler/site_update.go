@@ -15,6 +15,9 @@ import (
 type ParamsSiteUpdate struct {
 	Name string   `json:"name" validate:"required"` // Updated site name
 	Urls []string `json:"urls" validate:"required"` // Updated site urls
+
+	AuthRequired  *bool `json:"auth_required" validate:"optional"`  // Only allow the login users to comment
+	AnonymousMode *bool `json:"anonymous_mode" validate:"optional"` // Allow to comment without name and email (a pseudonymous identity is generated)
 }
 
 type ResponseSiteUpdate struct {
@@ -33,7 +36,7 @@ type ResponseSiteUpdate struct {
 // @Success      200  {object}  ResponseSiteUpdate
 // @Router       /sites/{id}  [put]
 func SiteUpdate(app *core.App, router fiber.Router) {
-	router.Put("/sites/:id", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Put("/sites/:id", common.AdminPermGuard(app, entity.AdminPermManage, func(c *fiber.Ctx, admin entity.User) error {
 		id, _ := c.ParamsInt("id")
 
 		var p ParamsSiteUpdate
@@ -45,9 +48,17 @@ func SiteUpdate(app *core.App, router fiber.Router) {
 		if site.IsEmpty() {
 			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Site")}))
 		}
+		if ok, resp := common.CheckAdminSite(c, admin, site.Name); !ok {
+			return resp
+		}
 
 		// 重命名合法性检测
+		before := app.Dao().CookSite(&site)
+
 		modifyName := p.Name != site.Name
+		if modifyName && !admin.HasAdminPerm(entity.AdminPermSuper) {
+			return common.RespError(c, 403, i18n.T("Permission denied"), Map{"need_perm": entity.AdminPermSuper})
+		}
 		if modifyName && !app.Dao().FindSite(p.Name).IsEmpty() {
 			return common.RespError(c, 400, i18n.T("{{name}} already exists", Map{"name": i18n.T("Site")}))
 		}
@@ -87,14 +98,23 @@ func SiteUpdate(app *core.App, router fiber.Router) {
 		// 修改 site
 		site.Name = p.Name
 		site.Urls = strings.Join(p.Urls, ",")
+		if p.AuthRequired != nil {
+			site.AuthRequired = *p.AuthRequired
+		}
+		if p.AnonymousMode != nil {
+			site.AnonymousMode = *p.AnonymousMode
+		}
 
 		err := app.Dao().UpdateSite(&site)
 		if err != nil {
 			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Site")}))
 		}
 
+		after := app.Dao().CookSite(&site)
+		common.AddAudit(c, common.Audit{Action: "site.update", TargetType: "site", TargetID: site.ID, SiteName: site.Name, Before: before, After: after})
+
 		return common.RespData(c, ResponseSiteUpdate{
-			CookedSite: app.Dao().CookSite(&site),
+			CookedSite: after,
 		})
 	}))
 }
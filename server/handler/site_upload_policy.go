@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+var siteUploadPolicyOverrides = siteOverrides[config.UploadPolicyConf]{
+	name:   "upload_policy",
+	field:  func(site *entity.Site) *string { return &site.UploadPolicyConf },
+	global: func(app *core.App) config.UploadPolicyConf { return app.Conf().ImgUpload.Policy },
+	merge:  config.MergeUploadPolicyConf,
+	validate: func(conf config.UploadPolicyConf) (string, error) {
+		for _, t := range conf.AllowedTypes {
+			if _, ok := uploadMineToExts[t]; !ok {
+				return "allowed_types", errors.New("unsupported type: " + t)
+			}
+		}
+		return "", nil
+	},
+}
+
+type ResponseSiteUploadPolicy struct {
+	Overrides Map                     `json:"overrides"` // The upload policy config overrides of site (same structure as the `img_upload.policy` config)
+	Effective config.UploadPolicyConf `json:"effective"` // The effective config merged over the global config
+}
+
+// @Id           GetSiteUploadPolicy
+// @Summary      Get Site Upload Policy Config
+// @Description  Get the upload policy config overrides of a specific site
+// @Tags         Site
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The site ID"
+// @Produce      json
+// @Success      200  {object}  ResponseSiteUploadPolicy
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /sites/{id}/upload-policy  [get]
+func SiteUploadPolicyGet(app *core.App, router fiber.Router) {
+	router.Get("/sites/:id/upload-policy", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		site, ok, resp := findAdminSiteByParam(app, c, admin)
+		if !ok {
+			return resp
+		}
+
+		return common.RespData(c, ResponseSiteUploadPolicy{
+			Overrides: siteUploadPolicyOverrides.get(&site),
+			Effective: siteUploadPolicyOverrides.resolve(app, site.Name),
+		})
+	}))
+}
+
+type ParamsSiteUploadPolicyUpdate struct {
+	Overrides Map `json:"overrides" validate:"optional"` // The upload policy config overrides, empty to use the global config
+}
+
+// @Id           UpdateSiteUploadPolicy
+// @Summary      Update Site Upload Policy Config
+// @Description  Update the upload policy config overrides of a specific site, which are merged over the global config
+// @Tags         Site
+// @Security     ApiKeyAuth
+// @Param        id         path  int                           true  "The site ID"
+// @Param        overrides  body  ParamsSiteUploadPolicyUpdate  true  "The config overrides"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseSiteUploadPolicy
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /sites/{id}/upload-policy  [put]
+func SiteUploadPolicyUpdate(app *core.App, router fiber.Router) {
+	router.Put("/sites/:id/upload-policy", common.AdminPermGuard(app, entity.AdminPermManage, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsSiteUploadPolicyUpdate
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		site, ok, resp := findAdminSiteByParam(app, c, admin)
+		if !ok {
+			return resp
+		}
+		if ok, resp := siteUploadPolicyOverrides.update(app, c, &site, p.Overrides); !ok {
+			return resp
+		}
+
+		return common.RespData(c, ResponseSiteUploadPolicy{
+			Overrides: lo.If(p.Overrides == nil, Map{}).Else(p.Overrides),
+			Effective: siteUploadPolicyOverrides.resolve(app, site.Name),
+		})
+	}))
+}
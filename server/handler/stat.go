@@ -60,7 +60,7 @@ func Stat(app *core.App, router fiber.Router) {
 		}
 		// Query Comments by `site_name` and `is_pending=false`
 		QueryComments := func(d *gorm.DB) *gorm.DB {
-			return d.Model(&entity.Comment{}).Where(&entity.Comment{SiteName: p.SiteName, IsPending: false})
+			return d.Model(&entity.Comment{}).Where(&entity.Comment{SiteName: p.SiteName, IsPending: false}).Scopes(app.Dao().NoShadowBanned)
 		}
 		// Query Order by RAND()
 		QueryOrderRand := func(d *gorm.DB) *gorm.DB {
@@ -83,7 +83,7 @@ func Stat(app *core.App, router fiber.Router) {
 				Find(&comments)
 
 			return common.RespData(c, ResponseStat{
-				Data: app.Dao().CookAllComments(comments),
+				Data: hideModerationForComments(app.Dao().CookAllComments(comments)),
 			})
 
 		case "latest_pages":
@@ -200,7 +200,7 @@ func Stat(app *core.App, router fiber.Router) {
 				Find(&comments)
 
 			return common.RespData(c, ResponseStat{
-				Data: app.Dao().CookAllComments(comments),
+				Data: hideModerationForComments(app.Dao().CookAllComments(comments)),
 			})
 
 		case "rand_pages":
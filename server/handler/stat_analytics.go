@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsAnalytics struct {
+	SiteName string `query:"site_name" json:"site_name" validate:"optional"`                 // Filter by the site name, all the sites of admin if empty
+	Range    string `query:"range" json:"range" enums:"7d,30d,90d,365d" validate:"optional"` // The days till today (default: 30d), ignored if date_from is set
+	DateFrom string `query:"date_from" json:"date_from" validate:"optional"`                 // The start date of range (e.g. 2024-01-01)
+	DateTo   string `query:"date_to" json:"date_to" validate:"optional"`                     // The end date of range (inclusive, default: today)
+	Limit    int    `query:"limit" json:"limit" validate:"optional"`                         // The limit of top pages and commenters (default: 10, max: 50)
+}
+
+type ResponseAnalytics struct {
+	DateFrom      string                  `json:"date_from"`
+	DateTo        string                  `json:"date_to"`
+	Summary       AnalyticsSummary        `json:"summary"`
+	Daily         []AnalyticsDaily        `json:"daily"` // The counts of each day in the range (zero-filled)
+	TopPages      []AnalyticsTopPage      `json:"top_pages"`
+	TopCommenters []AnalyticsTopCommenter `json:"top_commenters"`
+	Checkers      []AnalyticsChecker      `json:"checkers"` // The anti-spam checkers which held the comments
+}
+
+type AnalyticsSummary struct {
+	Total         int64   `json:"total"`
+	Approved      int64   `json:"approved"`
+	Pending       int64   `json:"pending"`
+	Spam          int64   `json:"spam"`           // Decided as spam by the moderator
+	ApprovalRatio float64 `json:"approval_ratio"` // Approved / Total
+	SpamRatio     float64 `json:"spam_ratio"`     // Spam / Total
+}
+
+type AnalyticsDaily struct {
+	Date     string `json:"date"`
+	Total    int64  `json:"total"`
+	Approved int64  `json:"approved"`
+	Pending  int64  `json:"pending"`
+	Spam     int64  `json:"spam"`
+}
+
+type AnalyticsTopPage struct {
+	SiteName string `json:"site_name"`
+	PageKey  string `json:"page_key"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Count    int64  `json:"count"`
+}
+
+type AnalyticsTopCommenter struct {
+	UserID uint   `json:"user_id"`
+	Name   string `json:"name"`
+	Count  int64  `json:"count"`
+}
+
+type AnalyticsChecker struct {
+	Checker   string  `json:"checker"`
+	Held      int64   `json:"held"`       // The number of comments held or blocked by the checker
+	Approved  int64   `json:"approved"`   // The held comments approved by the moderator later (false positives)
+	BlockRate float64 `json:"block_rate"` // Held / Total
+}
+
+// The maximum days of the analytics range
+const analyticsMaxDays = 366
+
+// @Id           GetAnalytics
+// @Summary      Get Analytics
+// @Description  Get the time-series statistics of comments for the dashboard, including the daily counts, the approval and spam ratio, the top pages and commenters, and the block rates of anti-spam checkers
+// @Tags         Statistic
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsAnalytics  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseAnalytics
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /analytics  [get]
+func Analytics(app *core.App, router fiber.Router) {
+	router.Get("/analytics", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, admin entity.User) error {
+		var p ParamsAnalytics
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.Limit <= 0 {
+			p.Limit = 10
+		}
+		if p.Limit > 50 {
+			p.Limit = 50
+		}
+
+		from, to, ok, resp := parseAnalyticsRange(c, p)
+		if !ok {
+			return resp
+		}
+
+		filter := dao.CommentStatsFilter{
+			SiteNames: admin.GetAdminSites(),
+			From:      from,
+			To:        to,
+		}
+		if p.SiteName != "" {
+			if ok, resp := common.CheckAdminSite(c, admin, p.SiteName); !ok {
+				return resp
+			}
+			filter.SiteNames = []string{p.SiteName}
+		}
+
+		result := ResponseAnalytics{
+			DateFrom:      from.Format(dateOnlyFormat),
+			DateTo:        to.Format(dateOnlyFormat),
+			Daily:         []AnalyticsDaily{},
+			TopPages:      []AnalyticsTopPage{},
+			TopCommenters: []AnalyticsTopCommenter{},
+			Checkers:      []AnalyticsChecker{},
+		}
+
+		// Daily counts (zero-filled)
+		dayIndex := map[string]int{}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			date := day.Format(dateOnlyFormat)
+			dayIndex[date] = len(result.Daily)
+			result.Daily = append(result.Daily, AnalyticsDaily{Date: date})
+		}
+
+		checkers := map[string]*AnalyticsChecker{}
+		for _, row := range app.Dao().FindCommentStatsRows(filter) {
+			i, ok := dayIndex[row.CreatedAt.In(time.Local).Format(dateOnlyFormat)]
+			if !ok {
+				continue
+			}
+			day := &result.Daily[i]
+			day.Total++
+			result.Summary.Total++
+			if row.IsPending {
+				day.Pending++
+				result.Summary.Pending++
+			} else {
+				day.Approved++
+				result.Summary.Approved++
+			}
+			if row.IsSpam {
+				day.Spam++
+				result.Summary.Spam++
+			}
+
+			if row.ModerationChecker != "" {
+				checker, ok := checkers[row.ModerationChecker]
+				if !ok {
+					checker = &AnalyticsChecker{Checker: row.ModerationChecker}
+					checkers[row.ModerationChecker] = checker
+				}
+				checker.Held++
+				if !row.IsPending {
+					checker.Approved++
+				}
+			}
+		}
+
+		if total := result.Summary.Total; total > 0 {
+			result.Summary.ApprovalRatio = ratio(result.Summary.Approved, total)
+			result.Summary.SpamRatio = ratio(result.Summary.Spam, total)
+		}
+
+		// Block rates of checkers (the most held first)
+		for _, checker := range checkers {
+			checker.BlockRate = ratio(checker.Held, result.Summary.Total)
+			result.Checkers = append(result.Checkers, *checker)
+		}
+		sort.Slice(result.Checkers, func(i, j int) bool {
+			a, b := result.Checkers[i], result.Checkers[j]
+			if a.Held != b.Held {
+				return a.Held > b.Held
+			}
+			return a.Checker < b.Checker
+		})
+
+		// Top pages
+		for _, count := range app.Dao().FindTopCommentedPages(filter, p.Limit) {
+			page := app.Dao().FindPage(count.PageKey, count.SiteName)
+			result.TopPages = append(result.TopPages, AnalyticsTopPage{
+				SiteName: count.SiteName,
+				PageKey:  count.PageKey,
+				Title:    page.Title,
+				URL:      app.Dao().GetPageAccessibleURL(&page),
+				Count:    count.Count,
+			})
+		}
+
+		// Top commenters
+		for _, count := range app.Dao().FindTopCommenters(filter, p.Limit) {
+			result.TopCommenters = append(result.TopCommenters, AnalyticsTopCommenter{
+				UserID: count.UserID,
+				Name:   app.Dao().FindUserByID(count.UserID).Name,
+				Count:  count.Count,
+			})
+		}
+
+		return common.RespData(c, result)
+	}))
+}
+
+// Parse the date range of analytics, the from is the start of day and the to is the end of day
+func parseAnalyticsRange(c *fiber.Ctx, p ParamsAnalytics) (time.Time, time.Time, bool, error) {
+	from, to, ok, resp := parseCommentSearchDateRange(c, p.DateFrom, p.DateTo)
+	if !ok {
+		return time.Time{}, time.Time{}, false, resp
+	}
+
+	startOfDay := func(t time.Time) time.Time {
+		y, m, d := t.In(time.Local).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+
+	end := time.Now()
+	if to != nil {
+		end = *to
+	}
+	end = startOfDay(end).AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	var start time.Time
+	if from != nil {
+		start = startOfDay(*from)
+	} else {
+		days := 30
+		if p.Range != "" {
+			n, err := strconv.Atoi(strings.TrimSuffix(p.Range, "d"))
+			if err != nil || n <= 0 || n > analyticsMaxDays {
+				return time.Time{}, time.Time{}, false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "range"}))
+			}
+			days = n
+		}
+		start = startOfDay(end).AddDate(0, 0, 1-days)
+	}
+
+	if start.After(end) || start.AddDate(0, 0, analyticsMaxDays).Before(end) {
+		return time.Time{}, time.Time{}, false, common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "date_from"}))
+	}
+
+	return start, end, true, nil
+}
+
+func ratio(n int64, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
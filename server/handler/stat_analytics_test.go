@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalytics(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.Analytics(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+
+	// Hold the comment by the checker and decide it as spam
+	comment := app.Dao().FindComment(1001)
+	comment.IsPending = true
+	comment.ModerationChecker = "akismet"
+	assert.NoError(t, app.Dao().UpdateComment(&comment))
+	_, err = app.Dao().SaveSpamSample(&comment, true)
+	assert.NoError(t, err)
+
+	// Held by the checker but approved later
+	comment = app.Dao().FindComment(1002)
+	comment.ModerationChecker = "keywords"
+	assert.NoError(t, app.Dao().UpdateComment(&comment))
+
+	t.Run("Range", func(t *testing.T) {
+		code, res := request("GET", "/analytics?date_from=2022-04-28&date_to=2022-04-30", token, "")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "2022-04-28", res.Get("date_from").String())
+		assert.Equal(t, "2022-04-30", res.Get("date_to").String())
+
+		assert.Equal(t, int64(7), res.Get("summary.total").Int())
+		assert.Equal(t, int64(6), res.Get("summary.approved").Int())
+		assert.Equal(t, int64(1), res.Get("summary.pending").Int())
+		assert.Equal(t, int64(1), res.Get("summary.spam").Int())
+		assert.InDelta(t, 6.0/7, res.Get("summary.approval_ratio").Float(), 1e-9)
+
+		daily := res.Get("daily").Array()
+		assert.Len(t, daily, 3, "should be zero-filled")
+		assert.Equal(t, "2022-04-29", daily[1].Get("date").String())
+		sum := int64(0)
+		for _, day := range daily {
+			sum += day.Get("total").Int()
+		}
+		assert.Equal(t, int64(7), sum)
+
+		assert.Equal(t, "/test/1000.html", res.Get("top_pages.0.page_key").String())
+		assert.Equal(t, int64(6), res.Get("top_pages.0.count").Int())
+		assert.Equal(t, int64(1001), res.Get("top_commenters.0.user_id").Int())
+		assert.Equal(t, int64(5), res.Get("top_commenters.0.count").Int())
+
+		assert.Equal(t, []string{"akismet", "keywords"}, []string{res.Get("checkers.0.checker").String(), res.Get("checkers.1.checker").String()})
+		assert.Equal(t, int64(0), res.Get("checkers.0.approved").Int())
+		assert.Equal(t, int64(1), res.Get("checkers.1.approved").Int())
+		assert.InDelta(t, 1.0/7, res.Get("checkers.0.block_rate").Float(), 1e-9)
+	})
+
+	t.Run("Site", func(t *testing.T) {
+		_, res := request("GET", "/analytics?date_from=2022-04-28&date_to=2022-04-30&site_name=Site%20B&limit=1", token, "")
+		assert.Equal(t, int64(1), res.Get("summary.total").Int())
+		assert.Len(t, res.Get("top_pages").Array(), 1)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		code, res := request("GET", "/analytics?range=7d", token, "")
+		assert.Equal(t, 200, code)
+		assert.Len(t, res.Get("daily").Array(), 7)
+
+		_, res = request("GET", "/analytics", token, "")
+		assert.Len(t, res.Get("daily").Array(), 30)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, url := range []string{
+			"/analytics?range=abc",
+			"/analytics?range=1000d",
+			"/analytics?date_from=2022-05-01&date_to=2022-04-01",
+			"/analytics?date_from=2020-01-01&date_to=2022-01-01",
+		} {
+			code, _ := request("GET", url, token, "")
+			assert.Equal(t, 400, code, url)
+		}
+	})
+}
@@ -9,4 +9,5 @@ func Transfer(app *core.App, router fiber.Router) {
 	TransferImport(app, router)
 	TransferUpload(app, router)
 	TransferExport(app, router)
+	BackupStatus(app, router)
 }
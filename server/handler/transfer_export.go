@@ -3,11 +3,17 @@ package handler
 import (
 	"github.com/artalkjs/artalk/v2/internal/artransfer"
 	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/i18n"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
 )
 
+type ParamsTransferExport struct {
+	Since   string `query:"since" json:"since" validate:"optional"`       // Only export the comments created or updated since the time (e.g. `2024-01-01 08:00:00 +0800`), the ancestors are included
+	SinceID uint   `query:"since_id" json:"since_id" validate:"optional"` // Only export the comments whose ID is greater than it
+}
+
 type ResponseTransferExport struct {
 	// The exported data which is a JSON string
 	Artrans string `json:"artrans"`
@@ -15,22 +21,31 @@ type ResponseTransferExport struct {
 
 // @Id           ExportArtrans
 // @Summary      Export Artrans
-// @Description  Export data from Artalk
+// @Description  Export data from Artalk, only the changes are exported if `since` or `since_id` is set (incremental export)
 // @Tags         Transfer
 // @Security     ApiKeyAuth
+// @Param        options  query  ParamsTransferExport  false  "The options"
 // @Produce      json
 // @Success      200  {object}  ResponseTransferExport
+// @Failure      400  {object}  Map{msg=string}
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /transfer/export  [get]
 func TransferExport(app *core.App, router fiber.Router) {
-	router.Get("/transfer/export", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Get("/transfer/export", common.AdminPermGuard(app, entity.AdminPermTransfer, func(c *fiber.Ctx, _ entity.User) error {
+		var p ParamsTransferExport
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
 		var siteNameScope []string
 
 		jsonStr, err := artransfer.RunExportArtrans(app.Dao(), &artransfer.ExportParams{
 			SiteNameScope: siteNameScope,
+			Since:         p.Since,
+			SinceID:       p.SinceID,
 		})
 		if err != nil {
-			common.RespError(c, 500, i18n.T("Export error"), common.Map{
+			return common.RespError(c, 500, i18n.T("Export error"), common.Map{
 				"err": err,
 			})
 		}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/artalkjs/artalk/v2/internal/artransfer"
 	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
 )
@@ -17,7 +18,7 @@ type ParamsTransferImport struct {
 
 // @Id           ImportArtrans
 // @Summary      Import Artrans
-// @Description  Import data to Artalk
+// @Description  Import data to Artalk, the Artrans JSON or the export of other comment systems (e.g. Disqus XML) by the `format`. The changes are reported and not saved if `dry_run` is true
 // @Tags         Transfer
 // @Security     ApiKeyAuth
 // @Param        data  body  ParamsTransferImport  true  "The data to import"
@@ -28,7 +29,7 @@ type ParamsTransferImport struct {
 func TransferImport(app *core.App, router fiber.Router) {
 	var mu sync.Mutex
 
-	router.Post("/transfer/import", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Post("/transfer/import", common.AdminPermGuard(app, entity.AdminPermTransfer, func(c *fiber.Ctx, _ entity.User) error {
 		if !mu.TryLock() {
 			return common.RespError(c, fiber.StatusTooManyRequests, "Another import is in progress")
 		}
@@ -55,6 +56,19 @@ func TransferImport(app *core.App, router fiber.Router) {
 		<script>function scroll() { if (!!document.body) { document.body.scrollTo(0, 999999999999); } }</script>`))
 
 		p.Assumeyes = true
+
+		// the imported data is not recorded, which may be large
+		common.AddAudit(c, common.Audit{Action: "transfer.import", SiteName: p.TargetSiteName, After: Map{
+			"target_site_name": p.TargetSiteName,
+			"target_site_url":  p.TargetSiteURL,
+			"url_resolver":     p.URLResolver,
+			"url_keep_domain":  p.URLKeepDomain,
+			"json_file":        p.JsonFile,
+			"json_data_size":   len(p.JsonData),
+			"format":           p.Format,
+			"dry_run":          p.DryRun,
+			"on_conflict":      p.OnConflict,
+		}})
 		artransfer.RunImportArtrans(app.Dao(), &p.ImportParams, func(s string) {
 			buf.Write([]byte(html.EscapeString(s)))
 			buf.Write([]byte("<script>scroll();</script>"))
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/log"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
@@ -27,7 +28,7 @@ type ResponseTransferUpload struct {
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /transfer/upload  [post]
 func TransferUpload(app *core.App, router fiber.Router) {
-	router.Post("/transfer/upload", common.AdminGuard(app, func(c *fiber.Ctx) error {
+	router.Post("/transfer/upload", common.AdminPermGuard(app, entity.AdminPermTransfer, func(c *fiber.Ctx, _ entity.User) error {
 		// Get file from FormData
 		file, err := c.FormFile("file")
 		if err != nil {
@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"fmt"
+	"errors"
 	"io"
 	"net/http"
 	"os"
@@ -14,6 +14,7 @@ import (
 	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/core"
 	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/img_process"
 	"github.com/artalkjs/artalk/v2/internal/log"
 	"github.com/artalkjs/artalk/v2/internal/utils"
 	"github.com/artalkjs/artalk/v2/server/common"
@@ -21,12 +22,15 @@ import (
 )
 
 type ParamsUpload struct {
+	SiteName string `form:"site_name" json:"site_name" validate:"optional"` // The site name of the upload policy overrides
 }
 
 type ResponseUpload struct {
 	FileType  string `json:"file_type" enum:"image"`
 	FileName  string `json:"file_name"`
 	PublicURL string `json:"public_url"`
+
+	ThumbnailURL string `json:"thumbnail_url,omitempty"` // The URL of thumbnail if `img_upload.process.thumbnail_width` is set
 }
 
 // @Id           Upload
@@ -34,12 +38,14 @@ type ResponseUpload struct {
 // @Description  Upload file from this endpoint
 // @Tags         Upload
 // @Param        file           formData  file    true   "Upload file"
+// @Param        site_name      formData  string  false  "The site name"
 // @Security     ApiKeyAuth
 // @Accept       mpfd
 // @Produce      json
 // @Success      200  {object}  ResponseUpload
 // @Failure      400  {object}  Map{msg=string}
 // @Failure      403  {object}  Map{msg=string}
+// @Failure      429  {object}  Map{msg=string}
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /upload  [post]
 func Upload(app *core.App, router fiber.Router) {
@@ -62,13 +68,13 @@ func Upload(app *core.App, router fiber.Router) {
 		// ip := c.RealIP()
 		// ua := c.Request().UserAgent()
 
+		// 上传策略 (站点可覆盖全局配置)
+		policy := siteUploadPolicyOverrides.resolve(app, p.SiteName)
+		maxSize := getUploadMaxSize(app, policy)
+
 		// 图片大小限制 (Based on content length)
-		if app.Conf().ImgUpload.MaxSize != 0 {
-			if int64(c.Request().Header.ContentLength()) > app.Conf().ImgUpload.MaxSize*1024*1024 {
-				return common.RespError(c, 400, i18n.T("Image exceeds {{file_size}} limit", Map{
-					"file_size": fmt.Sprintf("%dMB", app.Conf().ImgUpload.MaxSize),
-				}))
-			}
+		if isOK, resp := checkUploadSize(c, maxSize, int64(c.Request().Header.ContentLength())); !isOK {
+			return resp
 		}
 
 		// 获取 Form
@@ -94,12 +100,8 @@ func Upload(app *core.App, router fiber.Router) {
 		}
 
 		// 大小限制 (Based on content read)
-		if app.Conf().ImgUpload.MaxSize != 0 {
-			if int64(len(buf)) > app.Conf().ImgUpload.MaxSize*1024*1024 {
-				return common.RespError(c, 400, i18n.T("Image exceeds {{file_size}} limit", Map{
-					"file_size": fmt.Sprintf("%dMB", app.Conf().ImgUpload.MaxSize),
-				}))
-			}
+		if isOK, resp := checkUploadSize(c, maxSize, int64(len(buf))); !isOK {
+			return resp
 		}
 
 		// 文件格式判断
@@ -108,95 +110,159 @@ func Upload(app *core.App, router fiber.Router) {
 		// @link https://mimesniff.spec.whatwg.org/
 		// @link https://developer.mozilla.org/zh-CN/docs/Web/HTTP/Basics_of_HTTP/MIME_types
 		fileMine := http.DetectContentType(buf)
-		allowMines := []string{
-			"image/jpeg", "image/png", "image/gif", "image/webp", "image/bmp",
-			// "image/svg+xml",
+		if isOK, resp := checkUploadType(c, policy, fileMine); !isOK {
+			return resp
 		}
-		if !utils.ContainsStr(allowMines, fileMine) {
-			return common.RespError(c, 400, i18n.T("Unsupported formats"))
+
+		// 每日上传配额
+		if isOK, resp := checkUploadQuota(app, c, policy, int64(len(buf))); !isOK {
+			return resp
 		}
 
-		// 图片文件名
-		mineToExts := map[string]string{
-			"image/jpeg": ".jpg",
-			"image/png":  ".png",
-			"image/gif":  ".gif",
-			"image/webp": ".webp",
-			"image/bmp":  ".bmp",
-			// "image/svg+xml": ".svg",
+		// 图片处理 (移除元数据、限制尺寸、转换格式和生成缩略图)
+		img := img_process.Image{Data: buf, Mime: fileMine}
+		var thumb *img_process.Image
+		if conf := app.Conf().ImgUpload.Process; conf.Enabled {
+			result, err := img_process.Process(buf, fileMine, img_process.Options{
+				StripMetadata:  conf.StripMetadata,
+				MaxWidth:       conf.MaxWidth,
+				MaxHeight:      conf.MaxHeight,
+				Format:         conf.Format,
+				Quality:        conf.Quality,
+				ThumbnailWidth: conf.ThumbnailWidth,
+				MaxPixels:      int64(conf.MaxPixels) * 1_000_000,
+			})
+			if errors.Is(err, img_process.ErrTooManyPixels) {
+				return common.RespError(c, 400, i18n.T("Image dimensions exceed the limit"))
+			}
+			if err != nil {
+				log.Error("[IMG_UPLOAD] [process] ", err)
+				return common.RespError(c, 500, i18n.T("Image processing failed"))
+			}
+			img, thumb = result.Image, result.Thumbnail
 		}
 
-		t := time.Now()
-		filename := t.Format("20060102-150405.000") + mineToExts[fileMine]
+		// 图片文件名
+		filename := getUploadFilename(img.Mime)
 
-		// 创建图片目标文件
-		if err := utils.EnsureDir(app.Conf().ImgUpload.Path); err != nil {
-			log.Error(err)
-			return common.RespError(c, 500, "Folder creation failed")
+		imgURL, isOK, resp := saveUploadImage(app, c, filename, img.Data, img.Mime)
+		if !isOK {
+			return resp
 		}
 
-		fileFullPath := strings.TrimSuffix(app.Conf().ImgUpload.Path, "/") + "/" + filename
-		dst, err := os.Create(fileFullPath)
-		if err != nil {
-			log.Error(err)
-			return common.RespError(c, 500, "File creation failed")
+		// 保存缩略图
+		thumbURL := ""
+		if thumb != nil {
+			ext := path.Ext(filename)
+			if thumbURL, isOK, resp = saveUploadImage(app, c, strings.TrimSuffix(filename, ext)+"_thumb"+ext, thumb.Data, thumb.Mime); !isOK {
+				return resp
+			}
 		}
-		defer dst.Close()
 
-		// 写入图片文件
-		if _, err = dst.Write(buf); err != nil {
-			log.Error(err)
-			return common.RespError(c, 500, "File write failed")
-		}
+		// 记录上传 (用于统计配额)
+		recordUpload(app, c, p.SiteName, filename, img.Mime, int64(len(img.Data)), imgURL)
+
+		// 响应数据
+		return common.RespData(c, ResponseUpload{
+			FileType:     "image",
+			FileName:     filename,
+			PublicURL:    imgURL,
+			ThumbnailURL: thumbURL,
+		})
+	}))
+}
 
-		// 生成外部可访问链接
-		baseURL := app.Conf().ImgUpload.PublicPath
-		if baseURL == "" {
-			baseURL = config.IMG_UPLOAD_PUBLIC_PATH
+// Save the image to the object storage or the local disk (and upload by UpGit if enabled),
+// the public URL of image is returned.
+func saveUploadImage(app *core.App, c *fiber.Ctx, filename string, buf []byte, fileMine string) (string, bool, error) {
+	// 上传到对象存储
+	if uploadService, err := core.AppService[*core.UploadService](app); err == nil && uploadService.IsRemote() {
+		imgURL, err := uploadService.Put(filename, buf, fileMine)
+		if err != nil {
+			log.Error("[IMG_UPLOAD] [s3] ", err)
+			return "", false, common.RespError(c, 500, i18n.T("Upload image via {{method}} failed", Map{"method": "s3"}))
 		}
+		return imgURL, true, nil
+	}
 
-		var imgURL string
-		if utils.ValidateURL(baseURL) {
-			// full url
-			imgURL = strings.TrimSuffix(baseURL, "/") + "/" + filename
-		} else {
-			// relative path
-			imgURL = path.Join(baseURL, filename)
-		}
-
-		// 使用 upgit
-		if app.Conf().ImgUpload.Upgit.Enabled {
-			upgitURL := execUpgitUpload(app.Conf().ImgUpload.Upgit.Exec, fileFullPath)
-			if upgitURL == "" || !utils.ValidateURL(upgitURL) {
-				// 上传失败，删除源图片文件
-				var err = os.Remove(fileFullPath)
-				if err != nil {
-					log.Error(err)
-				}
-
-				log.Error("[IMG_UPLOAD] [upgit] upgit output: ", upgitURL)
-				return common.RespError(c, 500, i18n.T("Upload image via {{method}} failed", Map{"method": "upgit"}))
-			}
+	// 创建图片目标文件
+	if err := utils.EnsureDir(app.Conf().ImgUpload.Path); err != nil {
+		log.Error(err)
+		return "", false, common.RespError(c, 500, "Folder creation failed")
+	}
+
+	fileFullPath := strings.TrimSuffix(app.Conf().ImgUpload.Path, "/") + "/" + filename
+	dst, err := os.Create(fileFullPath)
+	if err != nil {
+		log.Error(err)
+		return "", false, common.RespError(c, 500, "File creation failed")
+	}
+	defer dst.Close()
+
+	// 写入图片文件
+	if _, err = dst.Write(buf); err != nil {
+		log.Error(err)
+		return "", false, common.RespError(c, 500, "File write failed")
+	}
+
+	// 生成外部可访问链接
+	baseURL := app.Conf().ImgUpload.PublicPath
+	if baseURL == "" {
+		baseURL = config.IMG_UPLOAD_PUBLIC_PATH
+	}
+
+	var imgURL string
+	if utils.ValidateURL(baseURL) {
+		// full url
+		imgURL = strings.TrimSuffix(baseURL, "/") + "/" + filename
+	} else {
+		// relative path
+		imgURL = path.Join(baseURL, filename)
+	}
 
-			// 上传成功，删除本地文件
-			if app.Conf().ImgUpload.Upgit.DelLocal {
-				var err = os.Remove(fileFullPath)
-				if err != nil {
-					log.Error(err)
-				}
+	// 使用 upgit
+	if app.Conf().ImgUpload.Upgit.Enabled {
+		upgitURL := execUpgitUpload(app.Conf().ImgUpload.Upgit.Exec, fileFullPath)
+		if upgitURL == "" || !utils.ValidateURL(upgitURL) {
+			// 上传失败，删除源图片文件
+			var err = os.Remove(fileFullPath)
+			if err != nil {
+				log.Error(err)
 			}
 
-			// 使用从 upgit 获取的图片 URL
-			imgURL = upgitURL
+			log.Error("[IMG_UPLOAD] [upgit] upgit output: ", upgitURL)
+			return "", false, common.RespError(c, 500, i18n.T("Upload image via {{method}} failed", Map{"method": "upgit"}))
 		}
 
-		// 响应数据
-		return common.RespData(c, ResponseUpload{
-			FileType:  "image",
-			FileName:  filename,
-			PublicURL: imgURL,
-		})
-	}))
+		// 上传成功，删除本地文件
+		if app.Conf().ImgUpload.Upgit.DelLocal {
+			var err = os.Remove(fileFullPath)
+			if err != nil {
+				log.Error(err)
+			}
+		}
+
+		// 使用从 upgit 获取的图片 URL
+		imgURL = upgitURL
+	}
+
+	return imgURL, true, nil
+}
+
+// The allowed image types and their file extensions
+var uploadMineToExts = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"image/bmp":  ".bmp",
+	"image/avif": ".avif", // not detected by http.DetectContentType, only the output of image processing or the presigned upload
+	// "image/svg+xml": ".svg",
+}
+
+// Get the filename of uploaded image by the time
+func getUploadFilename(fileMine string) string {
+	return time.Now().Format("20060102-150405.000") + uploadMineToExts[fileMine]
 }
 
 // Call UpGit to upload images
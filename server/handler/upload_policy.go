@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/utils"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The error codes of upload policy, for the frontend to display the message
+const (
+	errCodeUploadTooLarge       = "upload_too_large"
+	errCodeUploadTypeNotAllowed = "upload_type_not_allowed"
+	errCodeUploadQuotaExceeded  = "upload_quota_exceeded"
+)
+
+// Get the size limit of a file in MB, 0 is unlimited
+func getUploadMaxSize(app *core.App, policy config.UploadPolicyConf) int64 {
+	if policy.MaxSize > 0 {
+		return policy.MaxSize
+	}
+	return app.Conf().ImgUpload.MaxSize
+}
+
+// Check the file size is under the limit
+func checkUploadSize(c *fiber.Ctx, maxSize int64, size int64) (bool, error) {
+	if maxSize != 0 && size > maxSize*1024*1024 {
+		return false, common.RespError(c, 400, i18n.T("Image exceeds {{file_size}} limit", Map{
+			"file_size": fmt.Sprintf("%dMB", maxSize),
+		}), Map{"err_code": errCodeUploadTooLarge, "max_size": maxSize})
+	}
+	return true, nil
+}
+
+// Check the MIME type (sniffed by the content) is supported and allowed by the policy
+func checkUploadType(c *fiber.Ctx, policy config.UploadPolicyConf, fileMine string) (bool, error) {
+	if _, ok := uploadMineToExts[fileMine]; !ok {
+		return false, common.RespError(c, 400, i18n.T("Unsupported formats"), Map{"err_code": errCodeUploadTypeNotAllowed})
+	}
+	if len(policy.AllowedTypes) > 0 && !utils.ContainsStr(policy.AllowedTypes, fileMine) {
+		return false, common.RespError(c, 400, i18n.T("Unsupported formats"),
+			Map{"err_code": errCodeUploadTypeNotAllowed, "allowed_types": policy.AllowedTypes})
+	}
+	return true, nil
+}
+
+// Check the uploads of today by the user (or the IP of guest) would not exceed the daily quota, the admins are not limited
+func checkUploadQuota(app *core.App, c *fiber.Ctx, policy config.UploadPolicyConf, size int64) (bool, error) {
+	if (policy.DailyCount <= 0 && policy.DailySize <= 0) || common.CheckIsAdminReq(app, c) {
+		return true, nil
+	}
+
+	user, _ := common.GetUserByReq(app, c)
+	count, total := app.Dao().GetUploadUsage(user.ID, c.IP(), getTodayStart())
+
+	if (policy.DailyCount > 0 && count >= int64(policy.DailyCount)) ||
+		(policy.DailySize > 0 && total+size > policy.DailySize*1024*1024) {
+		return false, common.RespError(c, 429, i18n.T("Daily upload quota exceeded, please try again tomorrow"), Map{
+			"err_code":    errCodeUploadQuotaExceeded,
+			"daily_count": policy.DailyCount,
+			"daily_size":  policy.DailySize,
+		})
+	}
+	return true, nil
+}
+
+// Record the uploaded file for counting the quota
+func recordUpload(app *core.App, c *fiber.Ctx, siteName string, filename string, fileMine string, size int64, url string) {
+	user, _ := common.GetUserByReq(app, c)
+	_ = app.Dao().NewUpload(&entity.Upload{
+		UserID:   user.ID,
+		IP:       c.IP(),
+		SiteName: strings.TrimSpace(siteName),
+		FileName: filename,
+		FileType: fileMine,
+		FileSize: size,
+		URL:      url,
+	})
+}
+
+// The start of today in the local time, when the daily quota is reset
+func getTodayStart() time.Time {
+	y, m, d := time.Now().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
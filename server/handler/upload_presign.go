@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsUploadPresign struct {
+	FileType string `json:"file_type" validate:"required"` // The MIME type of image (e.g. "image/png")
+	FileSize int64  `json:"file_size" validate:"required"` // The size of image in bytes, which is signed into the upload request
+	SiteName string `json:"site_name" validate:"optional"` // The site name of the upload policy overrides
+}
+
+// @Id           UploadPresign
+// @Summary      Presign Upload
+// @Description  Get the presigned URL to upload the image to the object storage directly
+// @Tags         Upload
+// @Param        data  body  ParamsUploadPresign  true  "The image to upload"
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  core.UploadPresign
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      429  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /upload/presign  [post]
+func UploadPresign(app *core.App, router fiber.Router) {
+	router.Post("/upload/presign", common.LimiterGuard(app, func(c *fiber.Ctx) error {
+		// 功能开关 (管理员始终开启)
+		if !app.Conf().ImgUpload.Enabled && !common.CheckIsAdminReq(app, c) {
+			return common.RespError(c, 403, i18n.T("Image upload forbidden"), common.Map{
+				"img_upload_enabled": false,
+			})
+		}
+
+		var p ParamsUploadPresign
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		uploadService, err := core.AppService[*core.UploadService](app)
+		if err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+		if !uploadService.IsPresignEnabled() {
+			return common.RespError(c, 400, i18n.T("Presigned upload is not enabled"))
+		}
+
+		// 上传策略 (the size and type of presigned upload are declared by the client, and signed into the upload request)
+		if p.FileSize <= 0 {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "file_size"}))
+		}
+		policy := siteUploadPolicyOverrides.resolve(app, p.SiteName)
+		if isOK, resp := checkUploadSize(c, getUploadMaxSize(app, policy), p.FileSize); !isOK {
+			return resp
+		}
+		if isOK, resp := checkUploadType(c, policy, p.FileType); !isOK {
+			return resp
+		}
+		if isOK, resp := checkUploadQuota(app, c, policy, p.FileSize); !isOK {
+			return resp
+		}
+
+		presign, err := uploadService.Presign(getUploadFilename(p.FileType), p.FileType, p.FileSize)
+		if err != nil {
+			log.Error("[IMG_UPLOAD] [s3] ", err)
+			return common.RespError(c, 500, err.Error())
+		}
+
+		// 记录上传 (用于统计配额)
+		recordUpload(app, c, p.SiteName, presign.FileName, p.FileType, p.FileSize, presign.PublicURL)
+
+		return common.RespData(c, presign)
+	}))
+}
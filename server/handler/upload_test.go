@@ -0,0 +1,278 @@
+package handler_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestUploadS3(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	// Fake S3 which records the uploaded objects
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	s3Server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		objects[r.URL.Path] = body
+		mu.Unlock()
+	}))
+	defer s3Server.Close()
+
+	app.Conf().ImgUpload.Enabled = true
+	app.Conf().ImgUpload.Storage = config.UploadStorageS3
+	app.Conf().ImgUpload.S3 = config.UploadS3Conf{
+		Endpoint:  s3Server.URL,
+		Bucket:    "bucket",
+		AccessKey: "ak",
+		SecretKey: "sk",
+		Prefix:    "artalk-img",
+		PathStyle: true,
+		PublicURL: "https://cdn.example.com/",
+	}
+	uploadService, err := core.AppService[*core.UploadService](app.App)
+	assert.NoError(t, err)
+	assert.NoError(t, uploadService.Init())
+	defer func() {
+		app.Conf().ImgUpload.Storage = config.UploadStorageLocal
+		uploadService.Init()
+	}()
+
+	handler.Upload(app.App, api)
+	handler.UploadPresign(app.App, api)
+
+	t.Run("Upload", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		part, _ := form.CreateFormFile("file", "a.png")
+		part.Write(pngHeader)
+		form.Close()
+
+		req := httptest.NewRequest("POST", "/upload", body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, 200, resp.StatusCode, string(buf))
+
+		data := gjson.ParseBytes(buf)
+		filename := data.Get("file_name").String()
+		assert.True(t, strings.HasSuffix(filename, ".png"))
+		assert.Equal(t, "https://cdn.example.com/artalk-img/"+filename, data.Get("public_url").String())
+		assert.Equal(t, pngHeader, objects["/bucket/artalk-img/"+filename], "the image should be stored in the bucket")
+	})
+
+	presign := func(body string) (int, gjson.Result) {
+		req := httptest.NewRequest("POST", "/upload/presign", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(buf)
+	}
+
+	t.Run("PresignDisabled", func(t *testing.T) {
+		code, _ := presign(`{"file_type":"image/png","file_size":100}`)
+		assert.Equal(t, 400, code)
+	})
+
+	t.Run("Presign", func(t *testing.T) {
+		app.Conf().ImgUpload.S3.Presign = true
+		defer func() { app.Conf().ImgUpload.S3.Presign = false }()
+
+		code, data := presign(`{"file_type":"image/png","file_size":100}`)
+		assert.Equal(t, 200, code, data.Raw)
+		filename := data.Get("file_name").String()
+		assert.True(t, strings.HasPrefix(data.Get("upload_url").String(), s3Server.URL+"/bucket/artalk-img/"+filename+"?"))
+		assert.Contains(t, data.Get("upload_url").String(), "X-Amz-Signature=")
+		assert.Equal(t, "PUT", data.Get("method").String())
+		assert.Equal(t, "image/png", data.Get("headers.Content-Type").String())
+		assert.Equal(t, "100", data.Get("headers.Content-Length").String())
+		assert.Contains(t, data.Get("upload_url").String(), "X-Amz-SignedHeaders=content-length%3Bcontent-type%3Bhost", "the size should be signed")
+		assert.Equal(t, "https://cdn.example.com/artalk-img/"+filename, data.Get("public_url").String())
+
+		code, _ = presign(`{"file_type":"image/png"}`)
+		assert.Equal(t, 400, code, "the size should be required")
+		code, _ = presign(`{"file_type":"image/png","file_size":-1}`)
+		assert.Equal(t, 400, code, "the invalid size should be rejected")
+
+		code, _ = presign(`{"file_type":"image/svg+xml","file_size":100}`)
+		assert.Equal(t, 400, code, "the unsupported type should be rejected")
+
+		maxSize := app.Conf().ImgUpload.MaxSize
+		app.Conf().ImgUpload.MaxSize = 1
+		defer func() { app.Conf().ImgUpload.MaxSize = maxSize }()
+		code, _ = presign(`{"file_type":"image/png","file_size":2097152}`)
+		assert.Equal(t, 400, code, "the oversized image should be rejected")
+	})
+}
+
+func TestUploadProcess(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	dir := t.TempDir()
+	imgUpload := app.Conf().ImgUpload
+	defer func() { app.Conf().ImgUpload = imgUpload }()
+	app.Conf().ImgUpload.Enabled = true
+	app.Conf().ImgUpload.Path = dir
+	app.Conf().ImgUpload.PublicPath = "/static/images/"
+	app.Conf().ImgUpload.Process = config.ImgProcessConf{
+		Enabled:        true,
+		StripMetadata:  true,
+		MaxWidth:       100,
+		Format:         "jpeg",
+		ThumbnailWidth: 20,
+	}
+
+	handler.Upload(app.App, api)
+
+	src := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	pngBuf := &bytes.Buffer{}
+	png.Encode(pngBuf, src)
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, _ := form.CreateFormFile("file", "a.png")
+	part.Write(pngBuf.Bytes())
+	form.Close()
+
+	req := httptest.NewRequest("POST", "/upload", body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp, err := api.Test(req)
+	assert.NoError(t, err)
+	buf, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, 200, resp.StatusCode, string(buf))
+
+	data := gjson.ParseBytes(buf)
+	filename := data.Get("file_name").String()
+	assert.True(t, strings.HasSuffix(filename, ".jpg"), "the image should be converted to JPEG")
+	thumbname := strings.TrimSuffix(filename, ".jpg") + "_thumb.jpg"
+	assert.Equal(t, "/static/images/"+thumbname, data.Get("thumbnail_url").String())
+
+	for name, width := range map[string]int{filename: 100, thumbname: 20} {
+		f, err := os.Open(filepath.Join(dir, name))
+		if !assert.NoError(t, err) {
+			continue
+		}
+		cfg, format, err := image.DecodeConfig(f)
+		f.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, width, cfg.Width, name)
+	}
+}
+
+func TestUploadPolicy(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	imgUpload := app.Conf().ImgUpload
+	defer func() { app.Conf().ImgUpload = imgUpload }()
+	app.Conf().ImgUpload.Enabled = true
+	app.Conf().ImgUpload.Path = t.TempDir()
+	app.Conf().ImgUpload.MaxSize = 5
+	app.Conf().ImgUpload.Policy = config.UploadPolicyConf{DailyCount: 2}
+
+	handler.Upload(app.App, api)
+	handler.SiteUploadPolicyGet(app.App, api)
+	handler.SiteUploadPolicyUpdate(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	upload := func(ip string, siteName string, token string, data []byte) (int, gjson.Result) {
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		form.WriteField("site_name", siteName)
+		part, _ := form.CreateFormFile("file", "a.png")
+		part.Write(data)
+		form.Close()
+
+		req := httptest.NewRequest("POST", "/upload", body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set("X-Forwarded-For", ip)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(buf)
+	}
+	updatePolicy := func(overrides string) (int, gjson.Result) {
+		req := httptest.NewRequest("PUT", "/sites/1000/upload-policy", strings.NewReader(`{"overrides":`+overrides+`}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(buf)
+	}
+
+	t.Run("DailyCount", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			code, data := upload("10.0.0.1", "", "", pngHeader)
+			assert.Equal(t, 200, code, data.Raw)
+		}
+
+		code, data := upload("10.0.0.1", "", "", pngHeader)
+		assert.Equal(t, 429, code, "should be limited by the daily quota")
+		assert.Equal(t, "upload_quota_exceeded", data.Get("err_code").String())
+
+		code, _ = upload("10.0.0.2", "", "", pngHeader)
+		assert.Equal(t, 200, code, "the guests are counted by the IP")
+
+		code, _ = upload("10.0.0.1", "", token, pngHeader)
+		assert.Equal(t, 200, code, "the admin is not limited")
+	})
+
+	t.Run("SiteOverrides", func(t *testing.T) {
+		code, _ := updatePolicy(`{"allowed_types":["image/svg+xml"]}`)
+		assert.Equal(t, 400, code, "the unsupported type should be rejected")
+
+		code, data := updatePolicy(`{"allowed_types":["image/jpeg"],"max_size":1,"daily_count":0}`)
+		assert.Equal(t, 200, code, data.Raw)
+		assert.Equal(t, int64(1), data.Get("effective.max_size").Int())
+		defer updatePolicy(`null`)
+
+		code, data = upload("10.0.0.3", "Site A", "", pngHeader)
+		assert.Equal(t, 400, code, "the type should be sniffed and not allowed by the site")
+		assert.Equal(t, "upload_type_not_allowed", data.Get("err_code").String())
+		assert.Equal(t, []any{"image/jpeg"}, data.Get("allowed_types").Value())
+
+		code, data = upload("10.0.0.1", "Site B", "", pngHeader)
+		assert.Equal(t, 429, code, "the other sites should use the global policy")
+
+		large := append(append([]byte{}, pngHeader...), make([]byte, 2*1024*1024)...)
+		jpg := append([]byte("\xFF\xD8\xFF\xE0"), large...)
+		code, data = upload("10.0.0.3", "Site A", "", jpg)
+		assert.Equal(t, 400, code, "should be limited by the max size of site")
+		assert.Equal(t, "upload_too_large", data.Get("err_code").String())
+	})
+}
@@ -19,6 +19,9 @@ type ParamsUserCreate struct {
 	ReceiveEmail bool   `json:"receive_email" validate:"required"` // The user receive email
 	BadgeName    string `json:"badge_name" validate:"optional"`    // The user badge name
 	BadgeColor   string `json:"badge_color" validate:"optional"`   // The user badge color (hex format)
+
+	AdminRole  string   `json:"admin_role" enums:"super_admin,site_admin,moderator,read_only" validate:"optional"` // The admin role (empty for the super admin)
+	AdminSites []string `json:"admin_sites" validate:"optional"`                                                   // The site names which the admin is restricted to (empty for all sites)
 }
 
 type ResponseUserCreate struct {
@@ -55,6 +58,9 @@ func UserCreate(app *core.App, router fiber.Router) {
 		if p.Link != "" && !utils.ValidateURL(p.Link) {
 			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": i18n.T("Link")}))
 		}
+		if !entity.IsValidAdminRole(p.AdminRole) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "admin_role"}))
+		}
 
 		user := entity.User{}
 		user.Name = p.Name
@@ -64,6 +70,8 @@ func UserCreate(app *core.App, router fiber.Router) {
 		user.ReceiveEmail = p.ReceiveEmail
 		user.BadgeName = p.BadgeName
 		user.BadgeColor = p.BadgeColor
+		user.AdminRole = p.AdminRole
+		user.SetAdminSites(p.AdminSites)
 
 		if p.Password != "" {
 			err := user.SetPasswordEncrypt(p.Password)
@@ -78,8 +86,11 @@ func UserCreate(app *core.App, router fiber.Router) {
 			return common.RespError(c, 500, i18n.T("{{name}} creation failed", Map{"name": i18n.T("User")}))
 		}
 
+		cooked := app.Dao().UserToCookedForAdmin(&user)
+		common.AddAudit(c, common.Audit{Action: "user.create", TargetType: "user", TargetID: user.ID, After: cooked})
+
 		return common.RespData(c, ResponseUserCreate{
-			CookedUserForAdmin: app.Dao().UserToCookedForAdmin(&user),
+			CookedUserForAdmin: cooked,
 		})
 	}))
 }
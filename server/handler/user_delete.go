@@ -28,10 +28,15 @@ func UserDelete(app *core.App, router fiber.Router) {
 			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("User")}))
 		}
 
+		before := app.Dao().UserToCookedForAdmin(&user)
+
 		err := app.Dao().DelUser(&user)
 		if err != nil {
 			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": i18n.T("User")}))
 		}
+
+		common.AddAudit(c, common.Audit{Action: "user.delete", TargetType: "user", TargetID: user.ID, Before: before})
+
 		return common.RespSuccess(c)
 	}))
 }
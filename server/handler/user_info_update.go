@@ -16,6 +16,8 @@ type RequestUserInfoUpdate struct {
 	Name  string `json:"name" validate:"required"`
 	Link  string `json:"link" validate:"optional"`
 	Code  string `json:"code" validate:"optional"`
+
+	EmailDigest *string `json:"email_digest" enums:"instant,digest" validate:"optional"` // The preference of email notifications (empty for the default)
 }
 
 type ResponseUserInfoUpdate struct {
@@ -93,6 +95,15 @@ func UserInfoUpdate(app *core.App, router fiber.Router) {
 			user.Link = p.Link
 		}
 
+		// Modify the preference of email notifications
+		if p.EmailDigest != nil {
+			if !entity.IsValidEmailDigest(*p.EmailDigest) {
+				return common.RespError(c, 400, i18n.T("Invalid {{name}}", map[string]interface{}{"name": "email_digest"}))
+			}
+
+			user.EmailDigest = *p.EmailDigest
+		}
+
 		if err := app.Dao().UpdateUser(&user); err != nil {
 			return common.RespError(c, 500, "Failed to update user")
 		}
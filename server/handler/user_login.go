@@ -14,11 +14,12 @@ type ParamsUserLogin struct {
 	Name     string `json:"name" validate:"optional"`     // The username
 	Email    string `json:"email" validate:"required"`    // The user email
 	Password string `json:"password" validate:"required"` // The user password
+	OTPCode  string `json:"otp_code" validate:"optional"` // The two-factor authentication code or backup code (required if the admin enabled TOTP)
 }
 
 type ResponseUserLogin struct {
-	Token string            `json:"token"`
-	User  entity.CookedUser `json:"user"`
+	common.UserTokens
+	User entity.CookedUser `json:"user"`
 }
 
 // @Id           Login
@@ -30,7 +31,7 @@ type ResponseUserLogin struct {
 // @Produce      json
 // @Success      200  {object}  ResponseUserLogin
 // @Failure      400  {object}  Map{msg=string,data=object{need_name_select=[]string}}  "Multiple users with the same email address are matched"
-// @Failure      401  {object}  Map{msg=string}
+// @Failure      401  {object}  Map{msg=string,need_otp=bool}  "The two-factor authentication code is required or incorrect"
 // @Failure      500  {object}  Map{msg=string}
 // @Router       /user/access_token  [post]
 func UserLogin(app *core.App, router fiber.Router) {
@@ -77,15 +78,34 @@ func UserLogin(app *core.App, router fiber.Router) {
 			return common.RespError(c, 401, i18n.T("Password is incorrect"))
 		}
 
-		jwtToken, err := common.LoginGetUserToken(user, app.Conf().AppKey, app.Conf().LoginTimeout)
+		// 两步验证
+		totpVerified, isOK, resp := checkLoginTOTP(app, c, &user, p.OTPCode)
+		if !isOK {
+			return resp
+		}
+		tokens, err := common.IssueUserTokens(app, user, totpVerified)
 		if err != nil {
 			log.Error("[LoginGetUserToken] ", err)
 			return common.RespError(c, 500, i18n.T("Login failed"))
 		}
 
 		return common.RespData(c, ResponseUserLogin{
-			Token: jwtToken,
-			User:  app.Dao().CookUser(&user),
+			UserTokens: tokens,
+			User:       app.Dao().CookUser(&user),
 		})
 	}))
 }
+
+// 登录时校验管理员的两步验证 (未启用则跳过)
+func checkLoginTOTP(app *core.App, c *fiber.Ctx, user *entity.User, otpCode string) (totpVerified bool, isContinue bool, resp error) {
+	if !user.IsAdmin || !user.TOTPEnabled {
+		return false, true, nil
+	}
+	if otpCode == "" {
+		return false, false, common.RespError(c, 401, i18n.T("Two-factor authentication code required"), common.Map{"need_otp": true})
+	}
+	if !verifyUserTOTP(app, user, otpCode) {
+		return false, false, common.RespError(c, 401, i18n.T("Two-factor authentication code is incorrect"), common.Map{"need_otp": true})
+	}
+	return true, true, nil
+}
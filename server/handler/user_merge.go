@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsUserMerge struct {
+	SourceID uint `json:"source_id" validate:"required"` // The user to be merged and deleted
+	TargetID uint `json:"target_id" validate:"required"` // The user to keep
+	DryRun   bool `json:"dry_run" validate:"optional"`   // Preview the changes without applying
+}
+
+type ResponseUserMerge struct {
+	DryRun         bool                      `json:"dry_run"`
+	Comments       int64                     `json:"comments"`        // The number of comments reassigned
+	Reactions      int64                     `json:"reactions"`       // The number of reactions reassigned
+	Votes          int64                     `json:"votes"`           // The number of votes reassigned
+	Notifies       int64                     `json:"notifies"`        // The number of notifies reassigned
+	Reports        int64                     `json:"reports"`         // The number of comment reports reassigned
+	AuthIdentities int64                     `json:"auth_identities"` // The number of social login identities reassigned
+	Duplicates     int64                     `json:"duplicates"`      // The number of duplicated reactions, votes and reports deleted
+	User           entity.CookedUserForAdmin `json:"user"`            // The target user after merged
+}
+
+// @Id           MergeUsers
+// @Summary      Merge Users
+// @Description  Merge the duplicate user into another, the comments, reactions, votes, notifies and notification preferences are reassigned to the target user and the source user is deleted. Set dry_run to preview the changes
+// @Tags         User
+// @Security     ApiKeyAuth
+// @Param        data  body  ParamsUserMerge  true  "The data"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseUserMerge
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /users/merge  [post]
+func UserMerge(app *core.App, router fiber.Router) {
+	router.Post("/users/merge", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		var p ParamsUserMerge
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.SourceID == p.TargetID {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "target_id"}))
+		}
+
+		source := app.Dao().FindUserByID(p.SourceID)
+		target := app.Dao().FindUserByID(p.TargetID)
+		if source.IsEmpty() || target.IsEmpty() {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("User")}))
+		}
+
+		// The admin users are managed by the config or the admin roles, which should not be lost by merging
+		if source.IsAdmin || source.IsInConf {
+			return common.RespError(c, 400, i18n.T("Cannot merge the admin user"))
+		}
+
+		before := []entity.CookedUserForAdmin{app.Dao().UserToCookedForAdmin(&source), app.Dao().UserToCookedForAdmin(&target)}
+
+		result, err := app.Dao().MergeUsers(source, target, p.DryRun)
+		if err != nil {
+			log.Error("[UserMerge] Failed to merge user ", source.ID, " into ", target.ID, ": ", err)
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("User")}))
+		}
+
+		user := app.Dao().UserToCookedForAdmin(&result.Target)
+		if p.DryRun {
+			user.CommentCount = before[0].CommentCount + before[1].CommentCount
+			common.AddAudit(c, common.Audit{Action: "user.merge_preview", TargetType: "user", TargetID: target.ID})
+		} else {
+			common.AddAudit(c, common.Audit{Action: "user.merge", TargetType: "user", TargetID: target.ID, Before: before, After: user})
+		}
+
+		return common.RespData(c, ResponseUserMerge{
+			DryRun:         p.DryRun,
+			Comments:       result.Comments,
+			Reactions:      result.Reactions,
+			Votes:          result.Votes,
+			Notifies:       result.Notifies,
+			Reports:        result.Reports,
+			AuthIdentities: result.AuthIdentities,
+			Duplicates:     result.Duplicates,
+			User:           user,
+		})
+	}))
+}
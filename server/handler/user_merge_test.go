@@ -0,0 +1,88 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUserMerge(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.UserMerge(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+
+	// userB (1002) is the duplicate of userA (1001), who has up-voted the comment 1000 in the fixtures
+	app.Dao().NewVote(1000, entity.VoteTypeCommentDown, 1002, "", "10.0.0.2") // duplicate
+	app.Dao().NewVote(1001, entity.VoteTypePageUp, 1002, "", "10.0.0.2")
+	app.Dao().NewReaction(1000, "👍", 1001, "", "10.0.0.1")
+	app.Dao().NewReaction(1000, "👍", 1002, "", "10.0.0.2") // duplicate
+	app.Dao().NewReaction(1000, "🎉", 1002, "", "10.0.0.2")
+	userB := app.Dao().FindUserByID(1002)
+	userB.NotifyReply = false
+	userB.BadgeName = "VIP"
+	assert.NoError(t, app.Dao().UpdateUser(&userB))
+
+	t.Run("Invalid", func(t *testing.T) {
+		code, _ := request("POST", "/users/merge", token, `{"source_id": 1001, "target_id": 1001}`)
+		assert.Equal(t, 400, code)
+		code, _ = request("POST", "/users/merge", token, `{"source_id": 1000, "target_id": 1001}`)
+		assert.Equal(t, 400, code, "should not merge the admin user")
+		code, _ = request("POST", "/users/merge", token, `{"source_id": 9999, "target_id": 1001}`)
+		assert.Equal(t, 404, code)
+	})
+
+	expect := func(t *testing.T, res gjson.Result) {
+		assert.Equal(t, int64(2), res.Get("comments").Int())
+		assert.Equal(t, int64(1), res.Get("reactions").Int())
+		assert.Equal(t, int64(1), res.Get("votes").Int())
+		assert.Equal(t, int64(2), res.Get("duplicates").Int())
+		assert.Equal(t, int64(1001), res.Get("user.id").Int())
+		assert.Equal(t, "VIP", res.Get("user.badge_name").String(), "the empty profile should be filled")
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		code, res := request("POST", "/users/merge", token, `{"source_id": 1002, "target_id": 1001, "dry_run": true}`)
+		assert.Equal(t, 200, code)
+		assert.True(t, res.Get("dry_run").Bool())
+		expect(t, res)
+
+		assert.False(t, app.Dao().FindUserByID(1002).IsEmpty(), "should not be changed")
+		assert.Equal(t, uint(1002), app.Dao().FindComment(1005).UserID)
+		assert.True(t, app.Dao().FindUserByID(1001).NotifyReply)
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		code, res := request("POST", "/users/merge", token, `{"source_id": 1002, "target_id": 1001}`)
+		assert.Equal(t, 200, code)
+		assert.False(t, res.Get("dry_run").Bool())
+		expect(t, res)
+
+		assert.True(t, app.Dao().FindUserByID(1002).IsEmpty())
+		assert.Equal(t, uint(1001), app.Dao().FindComment(1005).UserID)
+		assert.Equal(t, uint(1001), app.Dao().FindComment(1007).UserID)
+
+		userA := app.Dao().FindUserByID(1001)
+		assert.False(t, userA.NotifyReply, "the notification turned off should be kept")
+		assert.True(t, userA.NotifyMention)
+
+		comment := app.Dao().FindComment(1000)
+		assert.Equal(t, app.Dao().GetVoteNum(1000, string(entity.VoteTypeCommentDown)), comment.VoteDown, "should be recounted")
+		assert.Equal(t, 2, comment.ReactionCount)
+		assert.Equal(t, map[string]int{"👍": 1, "🎉": 1}, app.Dao().GetReactionCounts([]uint{1000})[1000])
+
+		var count int64
+		app.Dao().DB().Model(&entity.AuditLog{}).Where("action = ?", "user.merge").Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+}
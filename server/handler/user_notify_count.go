@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ResponseUserNotifyCount struct {
+	Unread  int   `json:"unread"`  // The total number of unread notifies
+	Reply   int   `json:"reply"`   // The number of unread replies
+	Mention int   `json:"mention"` // The number of unread mentions
+	Comment int   `json:"comment"` // The number of unread new comments (for admins)
+	Pending int64 `json:"pending"` // The number of comments pending review (for moderators)
+}
+
+// @Id           GetUserNotifyCount
+// @Summary      Get User Unread Notify Count
+// @Description  Get the unread counts of the logged in user for the badge display, the comments pending review are counted for the moderators
+// @Tags         Notify
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  ResponseUserNotifyCount
+// @Failure      401  {object}  Map{msg=string}
+// @Router       /user/notifies/count  [get]
+func UserNotifyCount(app *core.App, router fiber.Router) {
+	router.Get("/user/notifies/count", common.LoginGuard(app, func(c *fiber.Ctx, user entity.User) error {
+		var notifies []entity.Notify
+		app.Dao().UserNotifiesQuery(user.ID, true).Find(&notifies)
+
+		resp := ResponseUserNotifyCount{Unread: len(notifies)}
+		for _, n := range notifies {
+			switch app.Dao().GetNotifyEvent(&n) {
+			case entity.NotifyEventReply:
+				resp.Reply++
+			case entity.NotifyEventMention:
+				resp.Mention++
+			case entity.NotifyEventComment, entity.NotifyEventPending:
+				resp.Comment++
+			}
+		}
+
+		if user.IsAdmin && user.HasAdminPerm(entity.AdminPermModerate) {
+			resp.Pending = app.Dao().CountPendingComments(user.GetAdminSites())
+		}
+
+		return common.RespData(c, resp)
+	}))
+}
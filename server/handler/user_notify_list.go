@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsUserNotifyList struct {
+	Unread bool `query:"unread" json:"unread" validate:"optional"` // Only list the unread notifies
+	Limit  int  `query:"limit" json:"limit" validate:"optional"`   // The limit for pagination
+	Offset int  `query:"offset" json:"offset" validate:"optional"` // The offset for pagination
+}
+
+type ResponseUserNotifyList struct {
+	Notifies []entity.CookedNotifyForInbox `json:"notifies"`
+	Count    int64                         `json:"count"`
+}
+
+// @Id           GetUserNotifies
+// @Summary      Get User Notifies
+// @Description  Get the notifies of the logged in user for the notification center, sorted by the latest
+// @Tags         Notify
+// @Param        options  query  ParamsUserNotifyList  true  "The options"
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  ResponseUserNotifyList
+// @Failure      401  {object}  Map{msg=string}
+// @Router       /user/notifies  [get]
+func UserNotifyList(app *core.App, router fiber.Router) {
+	router.Get("/user/notifies", common.LoginGuard(app, func(c *fiber.Ctx, user entity.User) error {
+		var p ParamsUserNotifyList
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		q := app.Dao().UserNotifiesQuery(user.ID, p.Unread)
+
+		var total int64
+		q.Count(&total)
+
+		var notifies []entity.Notify
+		q.Order("created_at DESC").Scopes(Paginate(p.Offset, p.Limit)).Find(&notifies)
+
+		cooked := []entity.CookedNotifyForInbox{}
+		for _, n := range notifies {
+			cooked = append(cooked, app.Dao().CookNotifyForInbox(&n))
+		}
+
+		return common.RespData(c, ResponseUserNotifyList{
+			Notifies: cooked,
+			Count:    total,
+		})
+	}))
+}
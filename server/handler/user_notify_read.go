@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           MarkUserNotifyRead
+// @Summary      Mark User Notify as Read
+// @Description  Mark a specific notify of the logged in user as read
+// @Tags         Notify
+// @Param        id  path  int  true  "The notify ID"
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /user/notifies/{id}/read  [post]
+func UserNotifyRead(app *core.App, router fiber.Router) {
+	router.Post("/user/notifies/:id/read", common.LoginGuard(app, func(c *fiber.Ctx, user entity.User) error {
+		id, _ := c.ParamsInt("id")
+
+		var notify entity.Notify
+		app.Dao().DB().Where("id = ? AND user_id = ?", id, user.ID).First(&notify)
+		if notify.IsEmpty() {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": i18n.T("Notify")}))
+		}
+
+		if notify.IsRead {
+			return common.RespSuccess(c)
+		}
+
+		if err := app.Dao().NotifySetRead(&notify); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("Notify")}))
+		}
+
+		return common.RespSuccess(c)
+	}))
+}
+
+// @Id           MarkAllUserNotifyRead
+// @Summary      Mark All User Notifies as Read
+// @Description  Mark all the notifies of the logged in user as read
+// @Tags         Notify
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /user/notifies/read  [post]
+func UserNotifyReadAll(app *core.App, router fiber.Router) {
+	router.Post("/user/notifies/read", common.LoginGuard(app, func(c *fiber.Ctx, user entity.User) error {
+		if err := app.Dao().UserNotifyMarkAllAsRead(user.ID); err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+
+		return common.RespSuccess(c)
+	}))
+}
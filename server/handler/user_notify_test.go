@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUserNotify(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.UserNotifyList(app.App, api)
+	handler.UserNotifyCount(app.App, api)
+	handler.UserNotifyRead(app.App, api)
+	handler.UserNotifyReadAll(app.App, api)
+
+	request := func(method string, url string, userID uint) (int, gjson.Result) {
+		token := ""
+		if userID != 0 {
+			token, _ = common.LoginGetUserToken(app.Dao().FindUserByID(userID), app.Conf().AppKey, app.Conf().LoginTimeout)
+		}
+		return NewApiRequest(t, api)(method, url, token, "")
+	}
+
+	t.Run("LoginRequired", func(t *testing.T) {
+		code, _ := request("GET", "/user/notifies", 0)
+		assert.Equal(t, 401, code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		code, data := request("GET", "/user/notifies", 1001)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(2), data.Get("count").Int())
+
+		code, data = request("GET", "/user/notifies?unread=true", 1001)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(1), data.Get("count").Int())
+		assert.Equal(t, int64(1000), data.Get("notifies.0.comment_id").Int())
+		assert.Equal(t, "mention", data.Get("notifies.0.event").String())
+		assert.Equal(t, int64(1000), data.Get("notifies.0.comment.id").Int())
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		code, data := request("GET", "/user/notifies/count", 1001)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(1), data.Get("unread").Int())
+		assert.Equal(t, int64(1), data.Get("mention").Int())
+		assert.Equal(t, int64(0), data.Get("pending").Int(), "should not count the pending comments for non-moderators")
+
+		code, data = request("GET", "/user/notifies/count", 1000)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(1), data.Get("comment").Int())
+		assert.Greater(t, data.Get("pending").Int(), int64(0))
+	})
+
+	t.Run("Read", func(t *testing.T) {
+		code, _ := request("POST", "/user/notifies/1000/read", 1001)
+		assert.Equal(t, 404, code, "should not mark the notify of others")
+
+		code, _ = request("POST", "/user/notifies/1002/read", 1001)
+		assert.Equal(t, 200, code)
+		assert.True(t, app.Dao().FindNotify(1001, 1000).IsRead)
+	})
+
+	t.Run("ReadAll", func(t *testing.T) {
+		code, _ := request("POST", "/user/notifies/read", 1000)
+		assert.Equal(t, 200, code)
+
+		_, data := request("GET", "/user/notifies/count", 1000)
+		assert.Equal(t, int64(0), data.Get("unread").Int())
+	})
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ParamsUserTokenRefresh struct {
+	RefreshToken string `json:"refresh_token" validate:"required"` // The refresh token issued by login
+}
+
+// @Id           RefreshUserToken
+// @Summary      Refresh Access Token
+// @Description  Get a new access token by the refresh token, the refresh token is rotated and the old one can not be used anymore (reusing it will revoke the whole login session)
+// @Tags         Auth
+// @Param        data  body  ParamsUserTokenRefresh  true  "The refresh token"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  common.UserTokens
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /user/refresh_token  [post]
+func UserTokenRefresh(app *core.App, router fiber.Router) {
+	router.Post("/user/refresh_token", func(c *fiber.Ctx) error {
+		var p ParamsUserTokenRefresh
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		refreshToken := app.Dao().FindRefreshToken(p.RefreshToken)
+		if refreshToken.IsEmpty() || refreshToken.IsExpired() {
+			return common.RespError(c, 401, i18n.T("Refresh token is invalid or expired"))
+		}
+
+		user := app.Dao().FindUserByID(refreshToken.UserID)
+		if user.IsEmpty() {
+			return common.RespError(c, 401, i18n.T("User not found"))
+		}
+
+		// The token is revoked atomically before the new tokens are issued,
+		// so the revoked token (including the one revoked by a concurrent request) can not be rotated
+		tokens, err := common.RotateUserTokens(app, user, &refreshToken)
+		if errors.Is(err, common.ErrRefreshTokenReused) {
+			// The rotated token is reused, which means it may be leaked,
+			// so revoke the whole login session for safety
+			if err := app.Dao().RevokeRefreshTokenFamily(refreshToken.FamilyID); err != nil {
+				log.Error("[RefreshToken] ", err)
+			}
+			log.Warn("[RefreshToken] Reused refresh token detected, session revoked [user_id=", refreshToken.UserID, "]")
+			return common.RespError(c, 401, i18n.T("Refresh token is invalid or expired"))
+		}
+		if err != nil {
+			log.Error("[RefreshToken] ", err)
+			return common.RespError(c, 500, i18n.T("Login failed"))
+		}
+
+		return common.RespData(c, tokens)
+	})
+}
+
+type ParamsUserLogout struct {
+	RefreshToken string `json:"refresh_token" validate:"optional"` // The refresh token of the session to revoke
+}
+
+// @Id           Logout
+// @Summary      Logout
+// @Description  Revoke the login session of the refresh token or the current access token
+// @Tags         Auth
+// @Security     ApiKeyAuth
+// @Param        data  body  ParamsUserLogout  false  "The refresh token"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /user/logout  [post]
+func UserLogout(app *core.App, router fiber.Router) {
+	router.Post("/user/logout", func(c *fiber.Ctx) error {
+		var p ParamsUserLogout
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		familyID := ""
+		if refreshToken := app.Dao().FindRefreshToken(p.RefreshToken); !refreshToken.IsEmpty() {
+			familyID = refreshToken.FamilyID
+		} else if claims, err := common.GetJwtDataByReq(app, c); err == nil {
+			familyID = claims.Id
+		}
+
+		if familyID != "" {
+			if err := app.Dao().RevokeRefreshTokenFamily(familyID); err != nil {
+				log.Error("[Logout] ", err)
+				return common.RespError(c, 500, i18n.T("Logout failed"))
+			}
+		}
+
+		return common.RespSuccess(c)
+	})
+}
@@ -0,0 +1,143 @@
+package handler_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUserTokenRefresh(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	app.Conf().LoginTimeout = 3600
+	app.Conf().TokenRefresh.Enabled = true
+	app.Conf().TokenRefresh.AccessTTL = 60
+
+	handler.UserLogin(app.App, api)
+	handler.UserTokenRefresh(app.App, api)
+	handler.UserLogout(app.App, api)
+	handler.SessionRevoke(app.App, api)
+	api.Get("/me", common.LoginGuard(app.App, func(c *fiber.Ctx, user entity.User) error { return common.RespSuccess(c) }))
+
+	post := func(url string, token string, body string) (int, gjson.Result) {
+		req := httptest.NewRequest("POST", url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(buf)
+	}
+	isLogin := func(token string) bool {
+		req := httptest.NewRequest("GET", "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		return resp.StatusCode == 200
+	}
+	login := func() (string, string) {
+		code, data := post("/user/access_token", "", `{"email":"admin@qwqaq.com","password":"123456"}`)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, int64(60), data.Get("expires_in").Int())
+		return data.Get("token").String(), data.Get("refresh_token").String()
+	}
+	refresh := func(refreshToken string) (int, gjson.Result) {
+		return post("/user/refresh_token", "", `{"refresh_token":"`+refreshToken+`"}`)
+	}
+
+	t.Run("Rotation", func(t *testing.T) {
+		token, refreshToken := login()
+		assert.NotEmpty(t, refreshToken)
+		assert.True(t, isLogin(token))
+
+		code, data := refresh(refreshToken)
+		assert.Equal(t, 200, code)
+		newRefreshToken := data.Get("refresh_token").String()
+		assert.NotEqual(t, refreshToken, newRefreshToken, "should rotate the refresh token")
+		assert.True(t, isLogin(data.Get("token").String()))
+
+		code, _ = refresh(refreshToken)
+		assert.Equal(t, 401, code, "should reject the reused refresh token")
+		code, _ = refresh(newRefreshToken)
+		assert.Equal(t, 401, code, "should revoke the whole session if the reuse detected")
+		assert.False(t, isLogin(token), "should invalidate the access token of the revoked session")
+
+		code, _ = refresh("invalid")
+		assert.Equal(t, 401, code)
+	})
+
+	t.Run("ConcurrentRotation", func(t *testing.T) {
+		_, refreshToken := login()
+
+		// Both the requests found the token before any of them revoked it
+		found := app.Dao().FindRefreshToken(refreshToken)
+		stale := found
+		user := app.Dao().FindUserByID(found.UserID)
+
+		tokens, err := common.RotateUserTokens(app.App, user, &found)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.RefreshToken)
+
+		reused, err := common.RotateUserTokens(app.App, user, &stale)
+		assert.ErrorIs(t, err, common.ErrRefreshTokenReused, "only one of the concurrent requests should rotate the token")
+		assert.Empty(t, reused.RefreshToken, "should not issue the new tokens for the reused token")
+
+		code, _ := refresh(refreshToken)
+		assert.Equal(t, 401, code)
+		code, _ = refresh(tokens.RefreshToken)
+		assert.Equal(t, 401, code, "should revoke the whole session if the reuse detected")
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		token, refreshToken := login()
+		code, _ := post("/user/logout", token, "{}")
+		assert.Equal(t, 200, code)
+		assert.False(t, isLogin(token))
+		code, _ = refresh(refreshToken)
+		assert.Equal(t, 401, code)
+
+		token, refreshToken = login()
+		code, _ = post("/user/logout", "", `{"refresh_token":"`+refreshToken+`"}`)
+		assert.Equal(t, 200, code)
+		assert.False(t, isLogin(token))
+	})
+
+	t.Run("RevokeAll", func(t *testing.T) {
+		token, refreshToken := login()
+		other, _ := login()
+
+		code, _ := post("/sessions/revoke", token, `{"user_id":99999}`)
+		assert.Equal(t, 404, code)
+
+		code, _ = post("/sessions/revoke", token, "{}")
+		assert.Equal(t, 200, code)
+		assert.False(t, isLogin(token))
+		assert.False(t, isLogin(other))
+		code, _ = refresh(refreshToken)
+		assert.Equal(t, 401, code)
+
+		token, _ = login()
+		assert.True(t, isLogin(token), "should be able to login again")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		app.Conf().TokenRefresh.Enabled = false
+		defer func() { app.Conf().TokenRefresh.Enabled = true }()
+
+		code, data := post("/user/access_token", "", `{"email":"admin@qwqaq.com","password":"123456"}`)
+		assert.Equal(t, 200, code)
+		assert.False(t, data.Get("refresh_token").Exists())
+		assert.True(t, isLogin(data.Get("token").String()))
+	})
+}
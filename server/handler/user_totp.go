@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"cmp"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/totp"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ResponseUserTOTPSetup struct {
+	Secret string `json:"secret"` // The secret to be entered in the authenticator app manually
+	URI    string `json:"uri"`    // The otpauth:// provisioning URI (for the QR code)
+}
+
+type ParamsUserTOTPCode struct {
+	Code string `json:"code" validate:"required"` // The code from the authenticator app (or the backup code when disabling)
+}
+
+type ResponseUserTOTPEnable struct {
+	BackupCodes []string `json:"backup_codes"` // The one-time backup codes (only shown once)
+
+	// The new tokens passed the two-factor authentication
+	common.UserTokens
+}
+
+// @Id           SetupUserTOTP
+// @Summary      Setup Two-Factor Authentication
+// @Description  Generate a new TOTP secret for the admin, the TOTP will take effect after enabled
+// @Tags         Auth
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object}  ResponseUserTOTPSetup
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /user/totp/setup  [post]
+func UserTOTPSetup(app *core.App, router fiber.Router) {
+	router.Post("/user/totp/setup", common.LoginGuard(app, func(c *fiber.Ctx, user entity.User) error {
+		if !user.IsAdmin {
+			return common.RespError(c, 403, i18n.T("Admin access required"))
+		}
+		if user.TOTPEnabled {
+			return common.RespError(c, 400, i18n.T("Two-factor authentication is already enabled"))
+		}
+
+		secret, err := totp.GenerateSecret()
+		if err != nil {
+			log.Error("[TOTPSetup] ", err)
+			return common.RespError(c, 500, "Failed to generate secret")
+		}
+
+		user.TOTPSecret = secret
+		if err := app.Dao().UpdateUser(&user); err != nil {
+			return common.RespError(c, 500, "Failed to update user")
+		}
+
+		issuer := cmp.Or(app.Conf().AdminTOTP.Issuer, app.Conf().SiteDefault, "Artalk")
+
+		return common.RespData(c, ResponseUserTOTPSetup{
+			Secret: secret,
+			URI:    totp.ProvisioningURI(issuer, user.Email, secret),
+		})
+	}))
+}
+
+// @Id           EnableUserTOTP
+// @Summary      Enable Two-Factor Authentication
+// @Description  Confirm the code from the authenticator app to enable the TOTP, and get the backup codes
+// @Tags         Auth
+// @Security     ApiKeyAuth
+// @Param        data  body  ParamsUserTOTPCode  true  "The code from the authenticator app"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ResponseUserTOTPEnable
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /user/totp/enable  [post]
+func UserTOTPEnable(app *core.App, router fiber.Router) {
+	router.Post("/user/totp/enable", common.LoginGuard(app, func(c *fiber.Ctx, user entity.User) error {
+		var p ParamsUserTOTPCode
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if !user.IsAdmin {
+			return common.RespError(c, 403, i18n.T("Admin access required"))
+		}
+		if user.TOTPEnabled {
+			return common.RespError(c, 400, i18n.T("Two-factor authentication is already enabled"))
+		}
+		if user.TOTPSecret == "" {
+			return common.RespError(c, 400, i18n.T("Two-factor authentication is not set up"))
+		}
+
+		step, ok := totp.Validate(user.TOTPSecret, p.Code, time.Now())
+		if !ok {
+			return common.RespError(c, 400, i18n.T("Two-factor authentication code is incorrect"))
+		}
+
+		codes, hashed := totp.GenerateBackupCodes()
+		user.TOTPEnabled = true
+		user.TOTPLastStep = step
+		user.TOTPBackupCodes = hashed
+		if err := app.Dao().UpdateUser(&user); err != nil {
+			return common.RespError(c, 500, "Failed to update user")
+		}
+
+		// The previous token is no longer valid for the admin access,
+		// so issue a new one passed the two-factor authentication
+		tokens, err := common.IssueUserTokens(app, user, true)
+		if err != nil {
+			log.Error("[LoginGetUserToken] ", err)
+			return common.RespError(c, 500, i18n.T("Login failed"))
+		}
+
+		return common.RespData(c, ResponseUserTOTPEnable{
+			BackupCodes: codes,
+			UserTokens:  tokens,
+		})
+	}))
+}
+
+// @Id           DisableUserTOTP
+// @Summary      Disable Two-Factor Authentication
+// @Description  Disable the TOTP of the admin with a code from the authenticator app or a backup code
+// @Tags         Auth
+// @Security     ApiKeyAuth
+// @Param        data  body  ParamsUserTOTPCode  true  "The code from the authenticator app or a backup code"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /user/totp/disable  [post]
+func UserTOTPDisable(app *core.App, router fiber.Router) {
+	router.Post("/user/totp/disable", common.AdminPermGuard(app, entity.AdminPermRead, func(c *fiber.Ctx, user entity.User) error {
+		var p ParamsUserTOTPCode
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if !user.TOTPEnabled {
+			return common.RespError(c, 400, i18n.T("Two-factor authentication is not enabled"))
+		}
+		if !verifyUserTOTP(app, &user, p.Code) {
+			return common.RespError(c, 400, i18n.T("Two-factor authentication code is incorrect"))
+		}
+
+		user.TOTPEnabled = false
+		user.TOTPSecret = ""
+		user.TOTPBackupCodes = ""
+		user.TOTPLastStep = 0
+		if err := app.Dao().UpdateUser(&user); err != nil {
+			return common.RespError(c, 500, "Failed to update user")
+		}
+
+		return common.RespSuccess(c)
+	}))
+}
+
+// Verify the TOTP code or the backup code of the user,
+// the used code will be consumed and can not be reused
+func verifyUserTOTP(app *core.App, user *entity.User, code string) bool {
+	if step, ok := totp.Validate(user.TOTPSecret, code, time.Now()); ok {
+		if step <= user.TOTPLastStep {
+			return false // replay
+		}
+		user.TOTPLastStep = step
+	} else if remaining, ok := totp.UseBackupCode(user.TOTPBackupCodes, code); ok {
+		user.TOTPBackupCodes = remaining
+	} else {
+		return false
+	}
+
+	if err := app.Dao().UpdateUser(user); err != nil {
+		return false
+	}
+	return true
+}
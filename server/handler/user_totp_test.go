@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/totp"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUserTOTP(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.UserLogin(app.App, api)
+	handler.UserTOTPSetup(app.App, api)
+	handler.UserTOTPEnable(app.App, api)
+	handler.UserTOTPDisable(app.App, api)
+	api.Get("/admin", common.AdminGuard(app.App, func(c *fiber.Ctx) error { return common.RespSuccess(c) }))
+
+	post := func(url string, token string, body string) (int, gjson.Result) {
+		req := httptest.NewRequest("POST", url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		buf, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, gjson.ParseBytes(buf)
+	}
+	isAdmin := func(token string) int {
+		req := httptest.NewRequest("GET", "/admin", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := api.Test(req)
+		assert.NoError(t, err)
+		return resp.StatusCode
+	}
+	login := func(otp string) (int, gjson.Result) {
+		return post("/user/access_token", "", `{"email":"admin@qwqaq.com","password":"123456","otp_code":"`+otp+`"}`)
+	}
+
+	code, data := login("")
+	assert.Equal(t, 200, code)
+	token := data.Get("token").String()
+	assert.Equal(t, 200, isAdmin(token))
+
+	t.Run("Enforce", func(t *testing.T) {
+		app.Conf().AdminTOTP.Enforce = true
+		defer func() { app.Conf().AdminTOTP.Enforce = false }()
+
+		assert.Equal(t, 403, isAdmin(token), "should require the admin to setup TOTP")
+	})
+
+	code, data = post("/user/totp/setup", token, "")
+	assert.Equal(t, 200, code)
+	secret := data.Get("secret").String()
+	assert.NotEmpty(t, secret)
+	assert.True(t, strings.HasPrefix(data.Get("uri").String(), "otpauth://totp/"))
+
+	code, _ = post("/user/totp/enable", token, `{"code":"000000"}`)
+	assert.Equal(t, 400, code, "should reject the incorrect code")
+
+	otp, _ := totp.Code(secret, totp.Step(time.Now()))
+	code, data = post("/user/totp/enable", token, `{"code":"`+otp+`"}`)
+	assert.Equal(t, 200, code)
+	backupCodes := data.Get("backup_codes").Array()
+	assert.Len(t, backupCodes, totp.BackupCodeNum)
+	assert.Equal(t, 200, isAdmin(data.Get("token").String()))
+	assert.Equal(t, 403, isAdmin(token), "should require the token passed the two-factor authentication")
+
+	code, data = login("")
+	assert.Equal(t, 401, code)
+	assert.True(t, data.Get("need_otp").Bool())
+
+	code, _ = login(otp)
+	assert.Equal(t, 401, code, "should not reuse the code")
+
+	code, data = login(backupCodes[0].String())
+	assert.Equal(t, 200, code, "should login with the backup code")
+	token = data.Get("token").String()
+	assert.Equal(t, 200, isAdmin(token))
+
+	code, _ = login(backupCodes[0].String())
+	assert.Equal(t, 401, code, "should not reuse the backup code")
+
+	code, _ = post("/user/totp/disable", token, `{"code":"`+backupCodes[1].String()+`"}`)
+	assert.Equal(t, 200, code)
+	assert.False(t, app.Dao().FindUserByID(1000).TOTPEnabled)
+
+	code, _ = login("")
+	assert.Equal(t, 200, code)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/artalkjs/artalk/v2/internal/utils"
 	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
 )
 
 type ParamsUserUpdate struct {
@@ -19,6 +20,11 @@ type ParamsUserUpdate struct {
 	ReceiveEmail bool   `json:"receive_email" validate:"required"` // The user receive email
 	BadgeName    string `json:"badge_name" validate:"optional"`    // The user badge name
 	BadgeColor   string `json:"badge_color" validate:"optional"`   // The user badge color (hex format)
+
+	AdminRole  string   `json:"admin_role" enums:"super_admin,site_admin,moderator,read_only" validate:"optional"` // The admin role (empty for the super admin)
+	AdminSites []string `json:"admin_sites" validate:"optional"`                                                   // The site names which the admin is restricted to (empty for all sites)
+
+	IsShadowBanned bool `json:"is_shadow_banned" validate:"optional"` // The comments of user are only visible to the user self
 }
 
 type ResponseUserUpdate struct {
@@ -68,6 +74,12 @@ func UserUpdate(app *core.App, router fiber.Router) {
 		if p.Link != "" && !utils.ValidateURL(p.Link) {
 			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": i18n.T("Link")}))
 		}
+		if !entity.IsValidAdminRole(p.AdminRole) {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "admin_role"}))
+		}
+
+		before := app.Dao().UserToCookedForAdmin(&user)
+		wasShadowBanned := user.IsShadowBanned
 
 		// 删除原有缓存
 		app.Dao().CacheAction(func(cache *dao.DaoCache) {
@@ -85,14 +97,24 @@ func UserUpdate(app *core.App, router fiber.Router) {
 		user.ReceiveEmail = p.ReceiveEmail
 		user.BadgeName = p.BadgeName
 		user.BadgeColor = p.BadgeColor
+		user.AdminRole = p.AdminRole
+		user.SetAdminSites(p.AdminSites)
+		user.IsShadowBanned = p.IsShadowBanned
 
 		err := app.Dao().UpdateUser(&user)
 		if err != nil {
 			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": i18n.T("User")}))
 		}
 
+		after := app.Dao().UserToCookedForAdmin(&user)
+		action := "user.update"
+		if user.IsShadowBanned != wasShadowBanned {
+			action = lo.Ternary(user.IsShadowBanned, "user.ban", "user.unban")
+		}
+		common.AddAudit(c, common.Audit{Action: action, TargetType: "user", TargetID: user.ID, Before: before, After: after})
+
 		return common.RespData(c, ResponseUserUpdate{
-			CookedUserForAdmin: app.Dao().UserToCookedForAdmin(&user),
+			CookedUserForAdmin: after,
 		})
 	}))
 }
@@ -1,9 +1,33 @@
 package handler
 
-import "gorm.io/gorm"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/dao"
+	"gorm.io/gorm"
+)
 
 type Map = map[string]interface{}
 
+// The date format without time
+const dateOnlyFormat = "2006-01-02"
+
+// 日期时间参数的格式 (未指定时区则为本地时区)
+var dateTimeFormats = []string{time.RFC3339, dao.CommonDateTimeFormat, dateOnlyFormat}
+
+// 解析日期时间参数
+func parseDateTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateTimeFormats {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected format like \"2006-01-02\" or RFC3339", s)
+}
+
 // 分页
 func Paginate(offset int, limit int) func(db *gorm.DB) *gorm.DB {
 	if offset < 0 {
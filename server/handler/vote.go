@@ -38,7 +38,8 @@ func VoteGet(app *core.App, router fiber.Router) {
 
 		var result ResponseVote
 		result.Up, result.Down = app.Dao().GetVoteNumUpDown(targetName, uint(targetID))
-		exitsVotes := getExistsVotesByIP(app.Dao(), c.IP(), targetName, uint(targetID))
+		loginUser, _ := common.GetUserByReq(app, c)
+		exitsVotes := getExistsVotes(app.Dao(), loginUser.ID, app.AnonymizeIP(c.IP()), targetName, uint(targetID))
 		if len(exitsVotes) > 0 {
 			choice := getVoteChoice(string(exitsVotes[0].Type))
 			result.IsUp = choice == "up"
@@ -74,7 +75,7 @@ func VoteCreate(app *core.App, router fiber.Router) {
 		targetName := c.Params("target_name")
 		targetID, _ := c.ParamsInt("target_id")
 		choice := c.Params("choice")
-		ip := c.IP()
+		ip := app.AnonymizeIP(c.IP())
 
 		var p ParamsVoteCreate
 		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
@@ -107,8 +108,11 @@ func VoteCreate(app *core.App, router fiber.Router) {
 			return common.RespError(c, 404, "unknown vote target name")
 		}
 
-		// Find user
-		if p.Name != "" && p.Email != "" {
+		// Find user (the votes of logged in user are stored per user, otherwise per IP)
+		loginUser, _ := common.GetUserByReq(app, c)
+		if !loginUser.IsEmpty() {
+			user = loginUser
+		} else if p.Name != "" && p.Email != "" {
 			var err error
 			user, err = app.Dao().FindCreateUser(p.Name, p.Email, "")
 			if err != nil {
@@ -124,6 +128,7 @@ func VoteCreate(app *core.App, router fiber.Router) {
 			case "comment":
 				comment.VoteUp = up
 				comment.VoteDown = down
+				comment.HotScore = comment.GetHotScore()
 				app.Dao().UpdateComment(&comment)
 			case "page":
 				page.VoteUp = up
@@ -146,7 +151,7 @@ func VoteCreate(app *core.App, router fiber.Router) {
 			})
 		}
 
-		exitsVotes := getExistsVotesByIP(app.Dao(), ip, targetName, uint(targetID))
+		exitsVotes := getExistsVotes(app.Dao(), loginUser.ID, ip, targetName, uint(targetID))
 		if len(exitsVotes) == 0 {
 			// vote
 			create(choice)
@@ -194,9 +199,16 @@ func getVoteTargetName(voteType string) string {
 	return strings.TrimSuffix(strings.TrimSuffix(voteType, "_up"), "_down")
 }
 
-func getExistsVotesByIP(dao *dao.Dao, ip string, targetName string, targetID uint) []entity.Vote {
+// Find the votes of logged in user, or the votes by IP if not logged in
+func getExistsVotes(dao *dao.Dao, userID uint, ip string, targetName string, targetID uint) []entity.Vote {
 	var existsVotes []entity.Vote
-	dao.DB().Where("type LIKE ? AND target_id = ? AND ip = ?", targetName+"%", uint(targetID), ip).Find(&existsVotes)
+	q := dao.DB().Where("type LIKE ? AND target_id = ?", targetName+"%", uint(targetID))
+	if userID != 0 {
+		q = q.Where("user_id = ?", userID)
+	} else {
+		q = q.Where("ip = ?", ip)
+	}
+	q.Find(&existsVotes)
 	return existsVotes
 }
 
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/artalkjs/artalk/v2/server/common"
 	"github.com/artalkjs/artalk/v2/server/handler"
 	"github.com/stretchr/testify/assert"
 )
@@ -183,3 +184,38 @@ func TestVote(t *testing.T) {
 		})
 	}
 }
+
+func TestVoteByUser(t *testing.T) {
+	app, fiber := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.VoteGet(app.App, fiber)
+	handler.VoteCreate(app.App, fiber)
+
+	token, err := common.LoginGetUserToken(app.Dao().FindUserByID(1001), app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	vote := func(method, url, ip, token string) string {
+		req := httptest.NewRequest(method, url, bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", ip) // mock IP
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := fiber.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	assert.Equal(t, `{"up":1,"down":0,"is_up":true,"is_down":false}`, vote("POST", "/votes/comment/1001/up", "10.0.0.1", token))
+	assert.Equal(t, `{"up":1,"down":0,"is_up":true,"is_down":false}`, vote("GET", "/votes/comment/1001", "10.0.0.2", token),
+		"should find the vote of user from another IP")
+	assert.Equal(t, `{"up":1,"down":0,"is_up":false,"is_down":false}`, vote("GET", "/votes/comment/1001", "10.0.0.2", ""))
+	assert.Equal(t, `{"up":0,"down":0,"is_up":false,"is_down":false}`, vote("POST", "/votes/comment/1001/up", "10.0.0.2", token),
+		"should un-vote the vote of user from another IP")
+	assert.Equal(t, `{"up":1,"down":0,"is_up":true,"is_down":false}`, vote("POST", "/votes/comment/1001/up", "10.0.0.1", ""))
+	assert.Equal(t, `{"up":2,"down":0,"is_up":true,"is_down":false}`, vote("POST", "/votes/comment/1001/up", "10.0.0.1", token),
+		"should not share the vote with the anonymous visitor of same IP")
+}
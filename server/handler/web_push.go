@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/webpush"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ResponseWebPushPublicKey struct {
+	Enabled   bool   `json:"enabled"`
+	PublicKey string `json:"public_key"` // The VAPID public key as the `applicationServerKey` of `PushManager.subscribe()`
+}
+
+// The push subscription (the result of `PushSubscription.toJSON()`)
+type ParamsWebPushSubscribe struct {
+	Endpoint string `json:"endpoint" validate:"required"`
+	Keys     struct {
+		P256DH string `json:"p256dh" validate:"required"`
+		Auth   string `json:"auth" validate:"required"`
+	} `json:"keys" validate:"required"`
+}
+
+type ParamsWebPushUnsubscribe struct {
+	Endpoint string `json:"endpoint" validate:"required"`
+}
+
+// @Id           GetWebPushPublicKey
+// @Summary      Get Web Push Public Key
+// @Description  Get the VAPID public key for subscribing the Web Push in the browser
+// @Tags         Notify
+// @Produce      json
+// @Success      200  {object}  ResponseWebPushPublicKey
+// @Router       /web_push/public_key  [get]
+func WebPushPublicKey(app *core.App, router fiber.Router) {
+	router.Get("/web_push/public_key", func(c *fiber.Ctx) error {
+		publicKey := ""
+		if webPushService, err := core.AppService[*core.WebPushService](app); err == nil {
+			publicKey = webPushService.PublicKey()
+		}
+
+		return common.RespData(c, ResponseWebPushPublicKey{
+			Enabled:   publicKey != "",
+			PublicKey: publicKey,
+		})
+	})
+}
+
+// @Id           CreateWebPushSubscription
+// @Summary      Subscribe Web Push
+// @Description  Register the push subscription of the browser to receive the replies and the mentions, authorized by the notify key or the login token
+// @Tags         Notify
+// @Param        options  query  ParamsNotifyPreferencesAuth  false  "The notify key"
+// @Param        data     body   ParamsWebPushSubscribe       true   "The push subscription"
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      401  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /web_push/subscriptions  [post]
+func WebPushSubscribe(app *core.App, router fiber.Router) {
+	router.Post("/web_push/subscriptions", notifyPreferencesGuard(app, func(c *fiber.Ctx, user entity.User) error {
+		if webPushService, err := core.AppService[*core.WebPushService](app); err != nil || webPushService.PublicKey() == "" {
+			return common.RespError(c, 400, i18n.T("{{name}} is not enabled", Map{"name": "Web Push"}))
+		}
+
+		var p ParamsWebPushSubscribe
+		if ok, resp := common.ParamsDecode(c, &p); !ok {
+			return resp
+		}
+
+		p.Endpoint = strings.TrimSpace(p.Endpoint)
+		if err := webpush.ValidateEndpoint(p.Endpoint); err != nil {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "endpoint"}))
+		}
+		if _, err := webpush.Encrypt(webpush.Subscription{Endpoint: p.Endpoint, P256DH: p.Keys.P256DH, Auth: p.Keys.Auth}, nil); err != nil {
+			return common.RespError(c, 400, i18n.T("Invalid {{name}}", Map{"name": "keys"}))
+		}
+
+		if _, err := app.Dao().SavePushSubscription(user.ID, p.Endpoint, p.Keys.P256DH, p.Keys.Auth, string(c.Request().Header.UserAgent())); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} save failed", Map{"name": "Push Subscription"}))
+		}
+
+		return common.RespSuccess(c)
+	}))
+}
+
+// @Id           DeleteWebPushSubscription
+// @Summary      Unsubscribe Web Push
+// @Description  Remove the push subscription of the browser by the endpoint
+// @Tags         Notify
+// @Param        data  body  ParamsWebPushUnsubscribe  true  "The endpoint of push subscription"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  Map{}
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      500  {object}  Map{msg=string}
+// @Router       /web_push/unsubscribe  [post]
+func WebPushUnsubscribe(app *core.App, router fiber.Router) {
+	router.Post("/web_push/unsubscribe", func(c *fiber.Ctx) error {
+		var p ParamsWebPushUnsubscribe
+		if ok, resp := common.ParamsDecode(c, &p); !ok {
+			return resp
+		}
+
+		// the endpoint is only known by the browser and the server, so it is enough to authorize
+		sub := app.Dao().FindPushSubscriptionByEndpoint(strings.TrimSpace(p.Endpoint))
+		if sub.IsEmpty() {
+			return common.RespSuccess(c)
+		}
+
+		if err := app.Dao().DelPushSubscription(&sub); err != nil {
+			return common.RespError(c, 500, i18n.T("{{name}} deletion failed", Map{"name": "Push Subscription"}))
+		}
+
+		return common.RespSuccess(c)
+	})
+}
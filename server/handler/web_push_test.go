@@ -0,0 +1,81 @@
+package handler_test
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/webpush"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebPush(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.WebPushPublicKey(app.App, api)
+	handler.WebPushSubscribe(app.App, api)
+	handler.WebPushUnsubscribe(app.App, api)
+
+	user := app.Dao().FindUserByID(1001)
+	token, _ := common.LoginGetUserToken(user, app.Conf().AppKey, app.Conf().LoginTimeout)
+
+	request := NewApiRequest(t, api)
+
+	uaKey, _ := ecdh.P256().GenerateKey(rand.Reader)
+	p256dh := base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes())
+	subscription := func(endpoint string) string {
+		return fmt.Sprintf(`{"endpoint":%q,"keys":{"p256dh":%q,"auth":"c2VjcmV0c2VjcmV0c2VjcmV0"}}`, endpoint, p256dh)
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		code, data := request("GET", "/web_push/public_key", token, "")
+		assert.Equal(t, 200, code)
+		assert.False(t, data.Get("enabled").Bool())
+
+		code, _ = request("POST", "/web_push/subscriptions", token, subscription("https://push.example.com/token"))
+		assert.Equal(t, 400, code)
+	})
+
+	// enable Web Push
+	publicKey, privateKey, _ := webpush.GenerateVAPIDKeys()
+	app.Conf().WebPush.Enabled = true
+	app.Conf().WebPush.VAPIDPrivateKey = privateKey
+	webPushService, _ := core.AppService[*core.WebPushService](app.App)
+	assert.NoError(t, webPushService.Init())
+
+	t.Run("PublicKey", func(t *testing.T) {
+		code, data := request("GET", "/web_push/public_key", token, "")
+		assert.Equal(t, 200, code)
+		assert.True(t, data.Get("enabled").Bool())
+		assert.Equal(t, publicKey, data.Get("public_key").String())
+	})
+
+	t.Run("Subscribe", func(t *testing.T) {
+		code, _ := request("POST", "/web_push/subscriptions", token, subscription("http://127.0.0.1:8080/internal"))
+		assert.Equal(t, 400, code, "should reject the internal endpoint")
+
+		code, _ = request("POST", "/web_push/subscriptions", token, `{"endpoint":"https://push.example.com/token","keys":{"p256dh":"invalid","auth":"invalid"}}`)
+		assert.Equal(t, 400, code, "should reject the invalid keys")
+
+		code, _ = request("POST", "/web_push/subscriptions", token, subscription("https://push.example.com/token"))
+		assert.Equal(t, 200, code)
+		code, _ = request("POST", "/web_push/subscriptions", token, subscription("https://push.example.com/token"))
+		assert.Equal(t, 200, code)
+
+		subs := app.Dao().FindPushSubscriptions(1001)
+		assert.Len(t, subs, 1, "should not duplicate the same endpoint")
+		assert.Equal(t, p256dh, subs[0].P256DH)
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		code, _ := request("POST", "/web_push/unsubscribe", token, `{"endpoint":"https://push.example.com/token"}`)
+		assert.Equal(t, 200, code)
+		assert.Empty(t, app.Dao().FindPushSubscriptions(1001))
+	})
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
+)
+
+// The data of webhook payload for the comment events
+type WebhookCommentData struct {
+	Comment entity.CookedComment `json:"comment"`
+	User    entity.CookedUser    `json:"user"`
+	Page    entity.CookedPage    `json:"page"`
+}
+
+// The data of webhook payload for the user events
+type WebhookUserData struct {
+	User entity.CookedUser `json:"user"`
+}
+
+// The data of webhook payload for the page events
+type WebhookPageData struct {
+	Page entity.CookedPage `json:"page"`
+}
+
+func triggerWebhook(app *core.App, event string, data any) {
+	webhookService, err := core.AppService[*core.WebhookService](app)
+	if err != nil {
+		log.Error("[WebhookService] err: ", err)
+		return
+	}
+	webhookService.Trigger(event, data)
+}
+
+func triggerCommentWebhook(app *core.App, event string, comment entity.Comment) {
+	// the comment events are counted regardless of whether the webhook is enabled
+	app.Metrics().CountCommentEvent(event)
+
+	if !isWebhookEnabled(app) {
+		return
+	}
+
+	user := app.Dao().FetchUserForComment(&comment)
+	page := app.Dao().FetchPageForComment(&comment)
+	triggerWebhook(app, event, WebhookCommentData{
+		Comment: app.Dao().CookComment(&comment),
+		User:    app.Dao().CookUser(&user),
+		Page:    app.Dao().CookPage(&page),
+	})
+}
+
+func triggerUserWebhook(app *core.App, event string, user entity.User) {
+	if !isWebhookEnabled(app) {
+		return
+	}
+	triggerWebhook(app, event, WebhookUserData{User: app.Dao().CookUser(&user)})
+}
+
+func isWebhookEnabled(app *core.App) bool {
+	webhookService, err := core.AppService[*core.WebhookService](app)
+	return err == nil && webhookService.Enabled()
+}
+
+// Find or create the page, the page created event is triggered if the page is new
+func findCreatePage(app *core.App, pageKey string, pageTitle string, siteName string) entity.Page {
+	isNew := app.Dao().FindPage(pageKey, siteName).IsEmpty()
+	page := app.Dao().FindCreatePage(pageKey, pageTitle, siteName)
+	if isNew && !page.IsEmpty() && isWebhookEnabled(app) {
+		triggerWebhook(app, webhook.EventPageCreated, WebhookPageData{Page: app.Dao().CookPage(&page)})
+	}
+	return page
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+)
+
+type ParamsWebhookDeliveryList struct {
+	Event  string `query:"event" json:"event" validate:"optional"`   // Filter by the event (e.g. "comment.created")
+	Status string `query:"status" json:"status" validate:"optional"` // Filter by the status ("success" or "failed")
+	Limit  int    `query:"limit" json:"limit" validate:"optional"`   // The limit for pagination (default: 20)
+	Offset int    `query:"offset" json:"offset" validate:"optional"` // The offset for pagination
+}
+
+type ResponseWebhookDeliveryList struct {
+	Deliveries []entity.CookedWebhookDelivery `json:"deliveries"` // The deliveries in the latest order
+	Count      int64                          `json:"count"`
+}
+
+// @Id           GetWebhookDeliveries
+// @Summary      Get Webhook Deliveries
+// @Description  Get the delivery logs of webhook events, the logs are kept for 30 days
+// @Tags         Webhook
+// @Security     ApiKeyAuth
+// @Param        options  query  ParamsWebhookDeliveryList  true  "The options"
+// @Produce      json
+// @Success      200  {object}  ResponseWebhookDeliveryList
+// @Failure      403  {object}  Map{msg=string}
+// @Router       /webhooks/deliveries  [get]
+func WebhookDeliveryList(app *core.App, router fiber.Router) {
+	router.Get("/webhooks/deliveries", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		var p ParamsWebhookDeliveryList
+		if isOK, resp := common.ParamsDecode(c, &p); !isOK {
+			return resp
+		}
+
+		if p.Limit <= 0 || p.Limit > 100 {
+			p.Limit = 20
+		}
+
+		var isSuccess *bool
+		switch p.Status {
+		case "success":
+			isSuccess = lo.ToPtr(true)
+		case "failed":
+			isSuccess = lo.ToPtr(false)
+		}
+
+		deliveries, count := app.Dao().FindWebhookDeliveries(p.Event, isSuccess, p.Offset, p.Limit)
+
+		return common.RespData(c, ResponseWebhookDeliveryList{
+			Deliveries: lo.Map(deliveries, func(d entity.WebhookDelivery, _ int) entity.CookedWebhookDelivery {
+				return app.Dao().CookWebhookDelivery(&d)
+			}),
+			Count: count,
+		})
+	}))
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Id           RedeliverWebhook
+// @Summary      Redeliver Webhook
+// @Description  Send the payload of a webhook delivery again, a new delivery is logged
+// @Tags         Webhook
+// @Security     ApiKeyAuth
+// @Param        id  path  int  true  "The delivery ID"
+// @Produce      json
+// @Success      200  {object}  entity.CookedWebhookDelivery
+// @Failure      400  {object}  Map{msg=string}
+// @Failure      403  {object}  Map{msg=string}
+// @Failure      404  {object}  Map{msg=string}
+// @Router       /webhooks/deliveries/{id}/redeliver  [post]
+func WebhookRedeliver(app *core.App, router fiber.Router) {
+	router.Post("/webhooks/deliveries/:id/redeliver", common.AdminGuard(app, func(c *fiber.Ctx) error {
+		id, _ := c.ParamsInt("id")
+
+		delivery := app.Dao().FindWebhookDeliveryByID(uint(id))
+		if delivery.IsEmpty() {
+			return common.RespError(c, 404, i18n.T("{{name}} not found", Map{"name": "delivery"}))
+		}
+
+		webhookService, err := core.AppService[*core.WebhookService](app)
+		if err != nil {
+			return common.RespError(c, 500, err.Error())
+		}
+
+		redelivery, err := webhookService.Redeliver(delivery)
+		if err != nil {
+			return common.RespError(c, 400, err.Error())
+		}
+
+		return common.RespData(c, app.Dao().CookWebhookDelivery(&redelivery))
+	}))
+}
@@ -0,0 +1,140 @@
+package handler_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/webhook"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/artalkjs/artalk/v2/server/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestWebhook(t *testing.T) {
+	app, api := NewApiTestApp()
+	defer app.Cleanup()
+
+	handler.CommentDelete(app.App, api)
+	handler.PagePV(app.App, api)
+	handler.WebhookDeliveryList(app.App, api)
+	handler.WebhookRedeliver(app.App, api)
+
+	admin := app.Dao().FindUserByID(1000)
+	token, err := common.LoginGetUserToken(admin, app.Conf().AppKey, app.Conf().LoginTimeout)
+	assert.NoError(t, err)
+
+	request := NewApiRequest(t, api)
+
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	receiver := make(chan received, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver <- received{r.Header, body}
+	}))
+	defer server.Close()
+
+	receive := func() received {
+		select {
+		case r := <-receiver:
+			return r
+		case <-time.After(3 * time.Second):
+			t.Fatal("the webhook is not received")
+			return received{}
+		}
+	}
+
+	app.Conf().Webhook = config.WebhookConf{
+		Enabled: true,
+		Endpoints: []config.WebhookEndpointConf{
+			{URL: server.URL, Secret: "secret", Events: []string{webhook.EventCommentDeleted, webhook.EventPageCreated}},
+		},
+	}
+	webhookService, err := core.AppService[*core.WebhookService](app.App)
+	assert.NoError(t, err)
+	assert.NoError(t, webhookService.Init())
+	defer webhookService.Dispose()
+
+	t.Run("CommentDeleted", func(t *testing.T) {
+		code, _ := request("DELETE", "/comments/1005", token, "")
+		assert.Equal(t, 200, code)
+
+		r := receive()
+		ts, _ := strconv.ParseInt(r.header.Get(webhook.HeaderTimestamp), 10, 64)
+		assert.Equal(t, webhook.EventCommentDeleted, r.header.Get(webhook.HeaderEvent))
+		assert.True(t, webhook.Verify("secret", ts, r.body, r.header.Get(webhook.HeaderSignature)), "should be signed")
+
+		payload := gjson.ParseBytes(r.body)
+		assert.Equal(t, webhook.EventCommentDeleted, payload.Get("event").String())
+		assert.Equal(t, int64(1005), payload.Get("data.comment.id").Int())
+		assert.Equal(t, "/test/1000.html", payload.Get("data.page.key").String())
+		assert.NotEmpty(t, payload.Get("data.user.name").String())
+	})
+
+	t.Run("PageCreated", func(t *testing.T) {
+		code, _ := request("POST", "/pages/pv", token, `{"page_key":"/webhook/new.html","site_name":"Site A"}`)
+		assert.Equal(t, 200, code)
+		r := receive()
+		assert.Equal(t, webhook.EventPageCreated, r.header.Get(webhook.HeaderEvent))
+		assert.Equal(t, "/webhook/new.html", gjson.GetBytes(r.body, "data.page.key").String())
+
+		// Not triggered for the existing page
+		code, _ = request("POST", "/pages/pv", token, `{"page_key":"/webhook/new.html","site_name":"Site A"}`)
+		assert.Equal(t, 200, code)
+		select {
+		case r := <-receiver:
+			t.Errorf("should not trigger for the existing page: %s", r.header.Get(webhook.HeaderEvent))
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	t.Run("DeliveryList", func(t *testing.T) {
+		var data gjson.Result
+		assert.Eventually(t, func() bool {
+			_, data = request("GET", "/webhooks/deliveries?status=success", token, "")
+			return data.Get("count").Int() == 2
+		}, 3*time.Second, 20*time.Millisecond, "should log the deliveries")
+
+		assert.Equal(t, webhook.EventPageCreated, data.Get("deliveries.0.event").String(), "should be the latest first")
+		assert.Equal(t, int64(200), data.Get("deliveries.0.status_code").Int())
+		assert.Equal(t, int64(1), data.Get("deliveries.0.attempts").Int())
+
+		_, data = request("GET", "/webhooks/deliveries?event="+webhook.EventCommentDeleted, token, "")
+		assert.Equal(t, int64(1), data.Get("count").Int())
+
+		_, data = request("GET", "/webhooks/deliveries?status=failed", token, "")
+		assert.Equal(t, int64(0), data.Get("count").Int())
+	})
+
+	t.Run("Redeliver", func(t *testing.T) {
+		_, data := request("GET", "/webhooks/deliveries?event="+webhook.EventCommentDeleted, token, "")
+		id := data.Get("deliveries.0.id").Int()
+
+		code, data := request("POST", fmt.Sprintf("/webhooks/deliveries/%d/redeliver", id), token, "")
+		assert.Equal(t, 200, code)
+		assert.NotEqual(t, id, data.Get("id").Int(), "should log a new delivery")
+
+		r := receive()
+		assert.Equal(t, webhook.EventCommentDeleted, r.header.Get(webhook.HeaderEvent))
+		assert.Equal(t, int64(1005), gjson.GetBytes(r.body, "data.comment.id").Int())
+
+		code, _ = request("POST", "/webhooks/deliveries/99999/redeliver", token, "")
+		assert.Equal(t, 404, code)
+	})
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		resp, err := api.Test(httptest.NewRequest("GET", "/webhooks/deliveries", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 403, resp.StatusCode)
+	})
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/log"
+)
+
+// The first file descriptor passed by systemd socket activation
+// @see https://www.freedesktop.org/software/systemd/man/latest/sd_listen_fds.html
+const listenFdsStart = 3
+
+// Create the listener of server
+//
+// The socket passed by systemd socket activation is used if available,
+// otherwise the address is bound (with SO_REUSEPORT if `http.reuse_port` is enabled).
+// Both of them allow the new process to accept the connections before the old one exits.
+func listen(app *core.App) (net.Listener, error) {
+	ln, err := systemdListener()
+	if err != nil {
+		return nil, err
+	}
+
+	if ln != nil {
+		log.Info("[HTTP] Using the socket passed by systemd: ", ln.Addr())
+	} else {
+		addr := fmt.Sprintf("%s:%d", app.Conf().Host, app.Conf().Port)
+
+		lc := net.ListenConfig{}
+		if app.Conf().HTTP.ReusePort {
+			lc.Control = reusePortControl
+		}
+		if ln, err = lc.Listen(context.Background(), "tcp", addr); err != nil {
+			return nil, err
+		}
+	}
+
+	if app.Conf().SSL.Enabled {
+		cert, err := tls.LoadX509KeyPair(app.Conf().SSL.CertPath, app.Conf().SSL.KeyPath)
+		if err != nil {
+			ln.Close()
+			return nil, fmt.Errorf("tls: cannot load TLS key pair: %w", err)
+		}
+		ln = tls.NewListener(ln, &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		})
+	}
+
+	return ln, nil
+}
+
+// Get the listener passed by systemd socket activation, nil if not activated by systemd
+func systemdListener() (net.Listener, error) {
+	if os.Getenv("LISTEN_PID") != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, nil
+	}
+
+	// The env vars should not be inherited by the child processes
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	if fds > 1 {
+		log.Warn("[HTTP] Only the first of the ", fds, " sockets passed by systemd is used")
+	}
+
+	file := os.NewFile(uintptr(listenFdsStart), "LISTEN_FD_3")
+	defer file.Close() // the fd is duplicated by `net.FileListener`
+
+	ln, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use the socket passed by systemd: %w", err)
+	}
+	return ln, nil
+}
@@ -0,0 +1,13 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package server
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+)
+
+func reusePortControl(network, address string, c syscall.RawConn) error {
+	return fmt.Errorf("`http.reuse_port` is not supported on %s", runtime.GOOS)
+}
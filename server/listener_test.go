@@ -0,0 +1,39 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReusePort(t *testing.T) {
+	lc := net.ListenConfig{Control: reusePortControl}
+
+	ln1, err := lc.Listen(context.Background(), "tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer ln1.Close()
+
+	// the new process can bind the same port before the old one exits
+	ln2, err := lc.Listen(context.Background(), "tcp", ln1.Addr().String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer ln2.Close()
+
+	assert.Equal(t, ln1.Addr().String(), ln2.Addr().String())
+}
+
+func TestSystemdListenerNotActivated(t *testing.T) {
+	t.Setenv("LISTEN_PID", "")
+	t.Setenv("LISTEN_FDS", "1")
+
+	ln, err := systemdListener()
+	assert.NoError(t, err)
+	assert.Nil(t, ln)
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/log"
+	"github.com/artalkjs/artalk/v2/server/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// Record the latencies of requests and expose the Prometheus metrics endpoint
+func metrics(app *core.App, fb *fiber.App) {
+	conf := app.Conf().Metrics
+	if !conf.Enabled {
+		return
+	}
+
+	path := conf.GetPath()
+	log.Info("[Metrics] Prometheus metrics enabled, you can access it via `", path, "`")
+
+	fb.Use(func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		// the status of error is set by the error handler after the middlewares, so get it from the error
+		status := c.Response().StatusCode()
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		if c.Path() != path {
+			app.Metrics().ObserveRequest(c.Method(), c.Route().Path, status, time.Since(start))
+		}
+
+		return err
+	})
+
+	handler := adaptor.HTTPHandler(app.Metrics().Handler())
+	fb.Get(path, func(c *fiber.Ctx) error {
+		if token := app.Conf().Metrics.Token; token != "" {
+			auth, _ := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(auth), []byte(token)) != 1 {
+				return common.RespError(c, fiber.StatusUnauthorized, i18n.T("Permission denied"))
+			}
+		}
+		return handler(c)
+	})
+}
@@ -0,0 +1,95 @@
+package api_key
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/i18n"
+	"github.com/artalkjs/artalk/v2/internal/limiter"
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	Header   = "X-API-Key"
+	LocalKey = "api_key"
+)
+
+// API Key 认证 中间件
+//
+// 识别请求头 `X-API-Key` 中的 API Key，校验通过后保存到 Locals 中，
+// 并按照每个 API Key 的频率限制 (每分钟请求数) 拦截超出的请求
+func APIKeyMiddleware(app *core.App) fiber.Handler {
+	rate := newRateLimiter(app.Store)
+	touch := newTouchThrottle(time.Minute)
+
+	return func(c *fiber.Ctx) error {
+		raw := strings.TrimSpace(c.Get(Header))
+		if raw == "" {
+			return c.Next()
+		}
+
+		key := app.Dao().FindAPIKey(raw)
+		if key.IsEmpty() {
+			return c.Status(401).JSON(fiber.Map{"msg": i18n.T("Invalid API key")})
+		}
+
+		if !rate.Allow(key.ID, key.RateLimit, time.Now()) {
+			c.Set(fiber.HeaderRetryAfter, "60")
+			return c.Status(429).JSON(fiber.Map{"msg": i18n.T("API key rate limit exceeded")})
+		}
+
+		if touch.Allow(key.ID, time.Now()) {
+			app.Dao().TouchAPIKey(&key)
+		}
+		c.Locals(LocalKey, key)
+
+		return c.Next()
+	}
+}
+
+// 按 API Key 的频率限制 (每分钟请求数)
+type rateLimiter struct {
+	rate *limiter.RateLimiter
+}
+
+func newRateLimiter(store func() store.Store) *rateLimiter {
+	return &rateLimiter{rate: limiter.NewRateLimiter("api_key_rate", store)}
+}
+
+func (r *rateLimiter) Allow(id uint, limit int, now time.Time) bool {
+	return r.rate.Allow(strconv.FormatUint(uint64(id), 10), limit, now)
+}
+
+// 限制 API Key 最后使用时间的写入频率 (每个 Key 每个间隔最多写入一次)，仅保存在内存中
+type touchThrottle struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	touched map[uint]time.Time
+}
+
+func newTouchThrottle(interval time.Duration) *touchThrottle {
+	return &touchThrottle{interval: interval, touched: map[uint]time.Time{}}
+}
+
+func (t *touchThrottle) Allow(id uint, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.touched[id]; ok && now.Sub(last) < t.interval {
+		return false
+	}
+
+	// 清理过期的记录 (例如已删除的 Key)
+	for k, last := range t.touched {
+		if now.Sub(last) >= t.interval {
+			delete(t.touched, k)
+		}
+	}
+	t.touched[id] = now
+	return true
+}
@@ -0,0 +1,37 @@
+package api_key
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	s := store.NewMemoryStore()
+	r := newRateLimiter(func() store.Store { return s })
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, r.Allow(1, 3, now))
+	}
+	assert.False(t, r.Allow(1, 3, now), "should block the requests over limit")
+	assert.True(t, r.Allow(2, 3, now), "should limit per key")
+	assert.True(t, r.Allow(1, 3, now.Add(time.Minute)), "should reset after the window")
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, r.Allow(3, 0, now), "should not limit if 0")
+	}
+}
+
+func TestTouchThrottle(t *testing.T) {
+	th := newTouchThrottle(time.Minute)
+	now := time.Now()
+
+	assert.True(t, th.Allow(1, now))
+	assert.False(t, th.Allow(1, now.Add(30*time.Second)), "should touch at most once a minute")
+	assert.True(t, th.Allow(2, now), "should throttle per key")
+	assert.True(t, th.Allow(1, now.Add(time.Minute)), "should touch again after the interval")
+	assert.Len(t, th.touched, 1, "the expired records should be removed")
+}
@@ -1,8 +1,15 @@
 package limiter
 
 import (
+	"cmp"
+	"strings"
+	"time"
+
+	"github.com/artalkjs/artalk/v2/internal/config"
 	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/entity"
 	"github.com/artalkjs/artalk/v2/internal/limiter"
+	"github.com/artalkjs/artalk/v2/internal/log"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -15,16 +22,69 @@ type Limiter = limiter.Limiter
 
 // 操作限制 中间件
 func ActionLimitMiddleware(app *core.App, conf ActionLimitConf) fiber.Handler {
-	limiter := limiter.NewLimiter(&limiter.LimiterConf{
+	limiterConf := &limiter.LimiterConf{
 		AlwaysMode:          app.Conf().Captcha.Always,
 		MaxActionDuringTime: app.Conf().Captcha.ActionLimit,
 		ResetTimeout:        app.Conf().Captcha.ActionReset,
-	})
-
+	}
+	if app.Conf().Captcha.Adaptive.Enabled {
+		limiterConf.IsRisky = newRiskChecker(app, app.Conf().Captcha.Adaptive)
+	}
 	return func(c *fiber.Ctx) error {
+		// 每次请求获取存储 (集群模式下操作记录由多个实例共享，应用重启时存储会被替换)
+		conf := *limiterConf
+		conf.Store = app.Store()
+
 		// 任何页面都保存 limiter 实例
-		c.Locals(LimiterLocalKey, limiter)
+		c.Locals(LimiterLocalKey, limiter.NewLimiter(&conf))
 
 		return c.Next()
 	}
 }
+
+const (
+	defaultRiskWindow   = 600 // 默认时间窗口 (单位：s)
+	maxRiskRecentNumber = 100 // 最多统计的近期评论数
+)
+
+// 自适应验证码的风险判断 (根据 IP 的历史评论)
+func newRiskChecker(app *core.App, conf config.CaptchaAdaptiveConf) func(ip string) bool {
+	risk := &limiter.RiskConf{
+		NewIP:       conf.NewIP,
+		MaxComments: conf.MaxComments,
+		GreyScore:   conf.GreyScore,
+	}
+	window := time.Duration(cmp.Or(conf.Window, defaultRiskWindow)) * time.Second
+
+	return func(ip string) bool {
+		storedIP := app.AnonymizeIP(ip) // 与已存储的 (可能已匿名化的) IP 比对
+
+		signals := limiter.RiskSignals{}
+		if risk.NewIP {
+			signals.NewIP = app.Dao().GetIPApprovedCommentNum(storedIP) == 0
+		}
+
+		recent := app.Dao().FindRecentCommentsByIP(storedIP, time.Now().Add(-window), maxRiskRecentNumber)
+		signals.RecentComments = len(recent)
+		for _, comment := range recent {
+			signals.SpamScore = max(signals.SpamScore, getModerationSpamScore(comment))
+		}
+
+		isRisky, reason := risk.Evaluate(signals)
+		if isRisky {
+			log.Debug("[Captcha] Risky request from ", ip, ": ", reason)
+		}
+		return isRisky
+	}
+}
+
+// 获取评论被反垃圾检测标记的评分 (范围 0~1)
+func getModerationSpamScore(comment entity.Comment) float64 {
+	if comment.ModerationChecker == "" || strings.HasSuffix(comment.ModerationChecker, ":dry_run") {
+		return 0 // not marked by the checker
+	}
+	if comment.ModerationConfidence <= 0 {
+		return 1 // the confidence is unknown
+	}
+	return comment.ModerationConfidence
+}
@@ -1,9 +1,10 @@
 package server
 
 import (
-	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	_ "github.com/artalkjs/artalk/v2/docs/swagger"
 	"github.com/artalkjs/artalk/v2/internal/config"
@@ -13,6 +14,7 @@ import (
 	"github.com/artalkjs/artalk/v2/server/common"
 	h "github.com/artalkjs/artalk/v2/server/handler"
 	"github.com/artalkjs/artalk/v2/server/middleware"
+	"github.com/artalkjs/artalk/v2/server/middleware/api_key"
 	"github.com/artalkjs/artalk/v2/server/middleware/limiter"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/filesystem"
@@ -56,10 +58,13 @@ func Serve(app *core.App) (*fiber.App, error) {
 	})
 
 	reqID(fb)
-	logger(fb, app.Conf().Debug)
+	logger(app, fb)
+	metrics(app, fb)
+	tracingRequests(app, fb)
 	swaggerDocs(fb)
 	cors(app, fb)
 	actionLimit(app, fb)
+	apiKey(app, fb)
 
 	if app.Conf().Debug {
 		log.Debug("[PPROF] pprof enabled, you can access it via `/debug/pprof`.")
@@ -70,15 +75,43 @@ func Serve(app *core.App) (*fiber.App, error) {
 	{
 		h.CommentCreate(app, api)
 		h.CommentList(app, api)
+		h.CommentSearch(app, api) // before the `/comments/:id` route
+		h.CommentLatest(app, api) // before the `/comments/:id` route
 		h.CommentGet(app, api)
+		h.CommentReplies(app, api)
+		h.MentionList(app, api)
+		h.FeedComments(app, api)
+		h.GraphQL(app, api)
+		h.RealtimeStream(app, api)
 		h.VoteGet(app, api)
 		h.VoteCreate(app, api)
+		h.ReactionGet(app, api)
+		h.ReactionToggle(app, api)
+		h.CommentReport(app, api)
+		h.CommentGuestUpdate(app, api)
+		h.CommentGuestDelete(app, api)
 		h.PagePV(app, api)
+		h.PageHot(app, api)
+		h.PageCommentCounts(app, api)
 		h.Stat(app, api)
 		h.NotifyList(app, api)
 		h.NotifyReadAll(app, api)
 		h.NotifyRead(app, api)
+		h.NotifyPreferencesGet(app, api)
+		h.NotifyPreferencesUpdate(app, api)
+		h.NotifyUnsubscribe(app, api)
+		h.UserNotifyList(app, api)
+		h.UserNotifyCount(app, api)
+		h.UserNotifyRead(app, api)
+		h.UserNotifyReadAll(app, api)
+		h.WebPushPublicKey(app, api)
+		h.WebPushSubscribe(app, api)
+		h.WebPushUnsubscribe(app, api)
+		h.NotifyTelegramWebhook(app, api)
+		h.EmailWebhook(app, api)
 		h.Upload(app, api)
+		h.UploadPresign(app, api)
+		h.Avatar(app, api)
 
 		h.Conf(app, api)
 		h.Version(app, api)
@@ -91,6 +124,9 @@ func Serve(app *core.App) (*fiber.App, error) {
 		h.AuthEmailLogin(app, api)
 		h.AuthEmailRegister(app, api)
 		h.AuthEmailSend(app, api)
+		h.AuthEmailVerifyLink(app, api)
+		h.AuthSSO(app, api)
+		h.AuthLDAPLogin(app, api)
 		h.AuthMergeApply(app, api)
 		h.AuthMergeCheck(app, api)
 
@@ -100,34 +136,62 @@ func Serve(app *core.App) (*fiber.App, error) {
 		h.UserInfo(app, api)
 		h.UserInfoUpdate(app, api)
 		h.UserLogin(app, api)
+		h.UserTokenRefresh(app, api)
+		h.UserLogout(app, api)
 		h.UserStatus(app, api)
+		h.UserTOTPSetup(app, api)
+		h.UserTOTPEnable(app, api)
+		h.UserTOTPDisable(app, api)
 
 		// admin
 		admin(app, api)
 	}
 
+	// the no-js fallback of comments
+	h.CommentHTMLView(app, fb)
+	h.CommentHTMLSubmit(app, fb)
+
 	index(fb)
 
 	static(fb)
 	uploadedStatic(app, fb)
 
-	app.OnTerminate().Add(func(e *core.TerminateEvent) error {
-		_ = fb.Shutdown()
+	// the server is shut down before the default hook, which closes the database and the cache
+	app.OnTerminate().PreAdd(func(e *core.TerminateEvent) error {
+		shutdown(app, fb)
 		return nil
 	})
 
 	// listen serves HTTP requests
-	listenAddr := fmt.Sprintf("%s:%d", app.Conf().Host, app.Conf().Port)
-	if app.Conf().SSL.Enabled {
-		return fb, fb.ListenTLS(listenAddr, app.Conf().SSL.CertPath, app.Conf().SSL.KeyPath)
-	} else {
-		return fb, fb.Listen(listenAddr)
+	ln, err := listen(app)
+	if err != nil {
+		return fb, err
+	}
+	return fb, fb.Listener(ln)
+}
+
+// Gracefully shut down the server, the listener is closed first and the in-flight requests are drained
+func shutdown(app *core.App, fb *fiber.App) {
+	// end the real-time streams, otherwise they hold the connections until the timeout
+	if realtimeService, err := core.AppService[*core.RealtimeService](app); err == nil {
+		realtimeService.CloseStreams()
+	}
+
+	timeout := core.ShutdownTimeout(app.Conf())
+	log.Info("[Shutdown] Draining the in-flight requests (timeout ", timeout, ")")
+	if err := fb.ShutdownWithTimeout(timeout); err != nil {
+		log.Warn("[Shutdown] ", err)
 	}
 }
 
 func admin(app *core.App, api fiber.Router) {
 	h.CommentUpdate(app, api)
 	h.CommentDelete(app, api)
+	h.CommentPin(app, api)
+	h.CommentRestore(app, api)
+	h.CommentRevisionList(app, api)
+	h.CommentRevisionDiff(app, api)
+	h.FeedPending(app, api)
 	h.PageList(app, api)
 	h.PageUpdate(app, api)
 	h.PageDelete(app, api)
@@ -138,27 +202,75 @@ func admin(app *core.App, api fiber.Router) {
 	h.SiteCreate(app, api)
 	h.SiteUpdate(app, api)
 	h.SiteDelete(app, api)
+	h.SiteModeratorGet(app, api)
+	h.SiteModeratorUpdate(app, api)
+	h.SiteCommentLimitGet(app, api)
+	h.SiteCommentLimitUpdate(app, api)
+	h.SiteCommentCloseGet(app, api)
+	h.SiteCommentCloseUpdate(app, api)
+	h.SiteUploadPolicyGet(app, api)
+	h.SiteUploadPolicyUpdate(app, api)
+	h.AntiSpamTest(app, api)
 	h.UserList(app, api)
 	h.UserCreate(app, api)
 	h.UserUpdate(app, api)
 	h.UserDelete(app, api)
+	h.UserMerge(app, api)
+	h.SessionRevoke(app, api)
 	h.CacheWarmUp(app, api)
 	h.CacheFlush(app, api)
+	h.SearchReindex(app, api)
 	h.EmailSend(app, api)
 	h.VoteSync(app, api)
 	h.SettingGet(app, api)
 	h.SettingApply(app, api)
 	h.SettingTemplate(app, api)
 	h.Transfer(app, api)
+	h.APIKeyList(app, api)
+	h.APIKeyCreate(app, api)
+	h.APIKeyDelete(app, api)
+	h.NotifyTemplateList(app, api)
+	h.NotifyTemplateSave(app, api)
+	h.NotifyTemplateDelete(app, api)
+	h.WebhookDeliveryList(app, api)
+	h.WebhookRedeliver(app, api)
+	h.EmailTaskList(app, api)
+	h.EmailTaskRetry(app, api)
+	h.EmailSuppressionList(app, api)
+	h.EmailSuppressionDelete(app, api)
+	h.CommentReportList(app, api)
+	h.CommentReportDismiss(app, api)
+	h.ModerationQueue(app, api)
+	h.ModerationBulk(app, api)
+	h.AuditLogList(app, api)
+	h.AuditLogExport(app, api)
+	h.BanList(app, api)
+	h.BanCreate(app, api)
+	h.BanDelete(app, api)
+	h.Analytics(app, api)
 }
 
 func reqID(fb *fiber.App) {
 	fb.Use(requestid.New())
+
+	// pass the request ID to the logs of the handlers and the background jobs (by `log.Ctx(c.UserContext())`)
+	fb.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(log.WithRequestID(c.UserContext(), c.GetRespHeader(fiber.HeaderXRequestID)))
+		return c.Next()
+	})
 }
 
-func logger(fb *fiber.App, debugMode bool) {
+func logger(app *core.App, fb *fiber.App) {
+	debugMode := app.Conf().Debug
+	jsonFormat := strings.EqualFold(strings.TrimSpace(app.Conf().Log.Format), log.FormatJSON)
+
+	format := "[HTTP] ${time} | ${status} | ${latency} | ${ip} | ${method} | ${path} | ${error} | ${respHeader:X-Request-ID}"
+	if jsonFormat {
+		format = "${error}" // the other values are the fields of the entry
+	}
+
 	fb.Use(fiber_logger.New(fiber_logger.Config{
-		Format:        "[HTTP] ${time} | ${status} | ${latency} | ${ip} | ${method} | ${path} | ${error} | ${respHeader:X-Request-ID}",
+		Format:        format,
 		Output:        io.Discard,
 		DisableColors: true,
 		Done: func(c *fiber.Ctx, msg []byte) {
@@ -167,6 +279,18 @@ func logger(fb *fiber.App, debugMode bool) {
 				zap.WithCaller(false),
 			)
 			code := c.Response().StatusCode()
+			if jsonFormat {
+				l = l.With(
+					zap.Int("status", code),
+					zap.Duration("latency", time.Since(c.Context().Time())),
+					zap.String("ip", c.IP()),
+					zap.String("method", c.Method()),
+					zap.String("path", c.Path()),
+					zap.String("error", string(msg)),
+					zap.String("request_id", c.GetRespHeader(fiber.HeaderXRequestID)),
+				)
+				msg = []byte("[HTTP] " + c.Method() + " " + c.Path())
+			}
 			if (code >= 200 && code <= 299) || (code >= 300 && code <= 308) {
 				if debugMode {
 					l.Info(string(msg))
@@ -186,6 +310,10 @@ func actionLimit(app *core.App, f fiber.Router) {
 	f.Use(limiter.ActionLimitMiddleware(app, limiter.ActionLimitConf{}))
 }
 
+func apiKey(app *core.App, f fiber.Router) {
+	f.Use(api_key.APIKeyMiddleware(app))
+}
+
 func static(f fiber.Router) {
 	f.Use("/", filesystem.New(filesystem.Config{
 		Root:       http.FS(pkged.FS()),
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/artalkjs/artalk/v2/internal/core"
+	"github.com/artalkjs/artalk/v2/internal/tracing"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Trace the API requests, the span is passed to the handlers by `c.UserContext()`
+func tracingRequests(app *core.App, fb *fiber.App) {
+	if !app.Conf().Tracing.Enabled {
+		return
+	}
+
+	fb.Use(func(c *fiber.Ctx) error {
+		// only the API requests are traced (not the static files of the frontend)
+		if !strings.HasPrefix(c.Path(), "/api/") {
+			return c.Next()
+		}
+
+		// continue the trace of the caller (e.g. the reverse proxy)
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), propagation.HeaderCarrier(http.Header(c.GetReqHeaders())))
+		ctx, span := tracing.Tracer().Start(ctx, c.Method()+" "+c.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+				semconv.ClientAddress(c.IP()),
+				semconv.UserAgentOriginal(c.Get(fiber.HeaderUserAgent)),
+				attribute.String("artalk.request_id", c.GetRespHeader(fiber.HeaderXRequestID)),
+			))
+		defer span.End()
+
+		c.SetUserContext(ctx)
+		err := c.Next()
+
+		// the route is matched after the handlers are executed
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route))
+
+		// the status of error is set by the error handler after the middlewares, so get it from the error
+		status := c.Response().StatusCode()
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		return err
+	})
+}
@@ -2165,6 +2165,8 @@ export class Api<SecurityDataType extends unknown> extends HttpClient<SecurityDa
          * @format binary
          */
         file: File
+        /** The site name */
+        site_name?: string
       },
       params: RequestParams = {},
     ) =>
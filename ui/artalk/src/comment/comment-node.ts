@@ -200,8 +200,9 @@ export default class CommentNode {
     this.$el?.remove()
   }
 
-  /** 获取 Gravatar 头像 URL */
+  /** 获取 Gravatar 头像 URL (优先使用用户自定义头像) */
   public getGravatarURL() {
+    if (this.data.avatar) return this.data.avatar
     return Utils.getGravatarURL({
       mirror: this.opts.gravatar.mirror,
       params: this.opts.gravatar.params,
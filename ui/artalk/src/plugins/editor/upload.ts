@@ -119,7 +119,9 @@ export default class Upload extends EditorPlugin {
       const customUploaderFn = this.kit.useConf().imgUploader
       if (!customUploaderFn) {
         // 使用 Artalk 进行图片上传
-        resp = (await this.kit.useApi().upload.upload({ file })).data
+        resp = (
+          await this.kit.useApi().upload.upload({ file, site_name: this.kit.useConf().site })
+        ).data
       } else {
         // 使用自定义的图片上传器
         resp = { public_url: await customUploaderFn(file) }
@@ -14,6 +14,9 @@ export interface CommentData {
   /** 用户邮箱（已加密） */
   email_encrypted: string
 
+  /** 用户自定义头像 URL (由单点登录提供) */
+  avatar?: string
+
   /** 用户链接 */
   link: string
 
@@ -67,6 +70,20 @@ export interface CommentData {
 
   /** 反对数 */
   vote_down: number
+
+  /** 表情回应 (仅在启用时返回) */
+  reactions?: ReactionData[]
+}
+
+export interface ReactionData {
+  /** 表情 */
+  emoji: string
+
+  /** 回应数 */
+  count: number
+
+  /** 当前访客是否已回应 */
+  is_reacted: boolean
 }
 
 export interface ListData {
@@ -107,6 +124,18 @@ export interface PageData {
 
   /** 反对数 */
   vote_down: number
+
+  /** 评论状态覆盖（"" 跟随定时关闭配置，"open" 始终开放，"closed" 已关闭） */
+  comment_status: '' | 'open' | 'closed'
+
+  /** 页面发布时间 */
+  published_at: string
+
+  /** 评论已关闭 */
+  is_closed: boolean
+
+  /** 计划关闭评论的时间 */
+  closes_at?: string
 }
 
 export interface SiteData {